@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/http/handler"
+	"linkko-api/internal/observability/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminConfigReload(t *testing.T) {
+	log, _ := logger.New("test", "error")
+	var reloadErr error
+	reloads := 0
+	reload := func(ctx context.Context, source string) ([]config.Change, error) {
+		reloads++
+		assert.Equal(t, "admin_endpoint", source)
+		if reloadErr != nil {
+			return nil, reloadErr
+		}
+		return []config.Change{{Key: "LOG_LEVEL", Old: "info", New: "debug", Applied: true}}, nil
+	}
+	newRouter := func(adminToken string) http.Handler {
+		return buildRouter(RouterDeps{
+			Cfg:           &config.Config{AdminToken: adminToken},
+			Log:           log,
+			ConfigHandler: handler.NewConfigHandler(reload),
+		})
+	}
+	post := func(r http.Handler, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/config:reload", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("NotMountedWithoutAdminToken", func(t *testing.T) {
+		w := post(newRouter(""), "Bearer ")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("RejectsMissingOrWrongToken", func(t *testing.T) {
+		r := newRouter("admin-secret")
+		for _, authorization := range []string{"", "Bearer wrong", "admin-secret"} {
+			w := post(r, authorization)
+			assert.Equal(t, http.StatusUnauthorized, w.Code, authorization)
+			assert.Contains(t, w.Body.String(), "INVALID_TOKEN")
+		}
+		assert.Zero(t, reloads)
+	})
+
+	t.Run("ReturnsChanges", func(t *testing.T) {
+		w := post(newRouter("admin-secret"), "Bearer admin-secret")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":{"changes":[{"key":"LOG_LEVEL","old":"info","new":"debug","applied":true}]}}`, w.Body.String())
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		reloadErr = errors.New("LOG_LEVEL must be one of debug, info, warn, error")
+		defer func() { reloadErr = nil }()
+		w := post(newRouter("admin-secret"), "Bearer admin-secret")
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_CONFIG")
+	})
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/config"
+	"linkko-api/internal/http/client"
+	"linkko-api/internal/loadtest"
+
+	"github.com/spf13/cobra"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Replay synthetic traffic and report latency percentiles",
+	Long: `Replay a synthetic traffic profile (` + strings.Join(loadtest.ProfileNames(), ", ") + `) against the API and print latency percentiles per step.
+
+Without --target, Postgres and Redis are started with testcontainers (requires Docker), the base
+schema and migrations are applied, a workspace and an admin actor are seeded and a "linkko-api serve"
+process is started on a free port against them; --workspace and --actor are only needed with --target.
+With --baseline, the run fails when a step's p95 or error rate regresses beyond the tolerance.`,
+	RunE: runBench,
+}
+
+var benchOpts struct {
+	profile     string
+	target      string
+	workspaceID string
+	actorID     string
+	duration    time.Duration
+	warmup      time.Duration
+	concurrency int
+	out         string
+	baseline    string
+	tolerance   float64
+	schema      string
+}
+
+func init() {
+	f := benchCmd.Flags()
+	f.StringVar(&benchOpts.profile, "profile", "board-polling", "traffic profile: "+strings.Join(loadtest.ProfileNames(), ", "))
+	f.StringVar(&benchOpts.target, "target", "", "base URL of a running API (default: start a local server)")
+	f.StringVar(&benchOpts.workspaceID, "workspace", "", "workspace used by the profile (required with --target)")
+	f.StringVar(&benchOpts.actorID, "actor", "", "user id the token is minted for; must be a workspace member (required with --target)")
+	f.DurationVar(&benchOpts.duration, "duration", 30*time.Second, "measured duration")
+	f.DurationVar(&benchOpts.warmup, "warmup", 5*time.Second, "warmup before measuring")
+	f.IntVar(&benchOpts.concurrency, "concurrency", 10, "concurrent workers")
+	f.StringVar(&benchOpts.out, "out", "", "write the result as JSON (use as a future baseline)")
+	f.StringVar(&benchOpts.baseline, "baseline", "", "compare against a previous JSON result and fail on regressions")
+	f.Float64Var(&benchOpts.tolerance, "tolerance", 0.2, "accepted relative p95 increase over the baseline")
+	f.StringVar(&benchOpts.schema, "schema", "NEXTCRM_DATABASE_SCHEMAS_SQL.sql", "base schema loaded into the Postgres container before the migrations")
+	rootCmd.AddCommand(benchCmd)
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	profile, ok := loadtest.Profiles[benchOpts.profile]
+	if !ok {
+		return fmt.Errorf("unknown profile %q (available: %s)", benchOpts.profile, strings.Join(loadtest.ProfileNames(), ", "))
+	}
+
+	target := benchOpts.target
+	workspaceID, actorID := benchOpts.workspaceID, benchOpts.actorID
+	if target != "" && (workspaceID == "" || actorID == "") {
+		return fmt.Errorf("--workspace and --actor are required with --target")
+	}
+	if target == "" {
+		stopDeps, err := startBenchDependencies(ctx, benchOpts.schema)
+		if err != nil {
+			return err
+		}
+		defer stopDeps()
+		workspaceID, actorID = benchWorkspaceID, benchActorID
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	token, err := mintBenchToken(cfg, workspaceID, actorID, benchOpts.warmup+benchOpts.duration)
+	if err != nil {
+		return err
+	}
+
+	if target == "" {
+		url, shutdown, err := startBenchServer(ctx)
+		if err != nil {
+			return err
+		}
+		defer shutdown()
+		target = url
+	}
+
+	c := &loadtest.Client{BaseURL: target, Token: token, HTTPClient: client.NewCustomHTTPClient(30 * time.Second)}
+	result, err := loadtest.Run(ctx, c, profile, loadtest.Vars{"workspaceId": workspaceID}, loadtest.Options{
+		Concurrency: benchOpts.concurrency,
+		Duration:    benchOpts.duration,
+		Warmup:      benchOpts.warmup,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if err := result.WriteText(out); err != nil {
+		return err
+	}
+	if benchOpts.out != "" {
+		if err := loadtest.SaveResult(benchOpts.out, result); err != nil {
+			return fmt.Errorf("failed to write result: %w", err)
+		}
+	}
+
+	if benchOpts.baseline != "" {
+		baseline, err := loadtest.LoadResult(benchOpts.baseline)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline: %w", err)
+		}
+		regressions := loadtest.Compare(baseline, result, loadtest.GateOptions{
+			Tolerance:            benchOpts.tolerance,
+			MinDelta:             5 * time.Millisecond,
+			MaxErrorRateIncrease: 0.01,
+		})
+		if len(regressions) > 0 {
+			fmt.Fprintln(out, "\nregressions:")
+			for _, r := range regressions {
+				fmt.Fprintln(out, "  "+r.String())
+			}
+			return fmt.Errorf("%d performance regression(s) against %s", len(regressions), benchOpts.baseline)
+		}
+		fmt.Fprintf(out, "\nno regressions against %s\n", benchOpts.baseline)
+	}
+	return nil
+}
+
+// mintBenchToken assina um JWT HS256 com o segredo e o issuer de JWT_SIGNER_ISSUER.
+func mintBenchToken(cfg *config.Config, workspaceID, actorID string, ttl time.Duration) (string, error) {
+	secret, err := base64.StdEncoding.DecodeString(cfg.JWTHS256Secret)
+	if err != nil {
+		return "", fmt.Errorf("JWT_HS256_SECRET must be base64: %w", err)
+	}
+	signerCfg, err := cfg.GetJWTSigner()
+	if err != nil {
+		return "", err
+	}
+
+	signer := auth.NewHS256Signer(secret, signerCfg.Issuer, signerCfg.Audience, signerCfg.KeyID)
+	token, _, err := signer.Sign(&auth.CustomClaims{
+		WorkspaceID: workspaceID,
+		ActorID:     actorID,
+	}, ttl+5*time.Minute)
+	return token, err
+}
+
+// startBenchServer sobe "linkko-api serve" (o mesmo binário) em uma porta livre e espera o /health.
+func startBenchServer(ctx context.Context) (string, func(), error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", nil, err
+	}
+	server := exec.Command(exe, "serve")
+	server.Env = append(os.Environ(), fmt.Sprintf("PORT=%d", port))
+	server.Stderr = os.Stderr
+	if err := server.Start(); err != nil {
+		return "", nil, fmt.Errorf("failed to start server: %w", err)
+	}
+	shutdown := func() {
+		_ = server.Process.Signal(syscall.SIGTERM)
+		_ = server.Wait()
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	deadline := time.Now().Add(60 * time.Second) // inclui as migrations do serve
+	for {
+		resp, err := http.Get(url + "/health")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return url, shutdown, nil
+			}
+		}
+		if time.Now().After(deadline) || ctx.Err() != nil {
+			shutdown()
+			return "", nil, fmt.Errorf("server did not become healthy at %s", url)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"linkko-api/internal/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Workspace e ator semeados no Postgres descartável do bench.
+const (
+	benchWorkspaceID = "wks_bench"
+	benchActorID     = "usr_bench"
+)
+
+// benchSeedSQL cria organização, usuário admin e workspace (mesmo formato do testutil/factory).
+var benchSeedSQL = []string{
+	`INSERT INTO public."Organization" (id, name, "updatedAt") VALUES ('org_bench', 'Bench Org', NOW())`,
+	`INSERT INTO public."User" (id, name, email, "updatedAt")
+	 VALUES ('` + benchActorID + `', 'Bench Admin', 'bench@test.linkko.local', NOW())`,
+	`INSERT INTO public."Workspace" (id, name, slug, "ownerId", "organizationId", "updatedAt")
+	 VALUES ('` + benchWorkspaceID + `', 'Bench Workspace', '` + benchWorkspaceID + `', '` + benchActorID + `', 'org_bench', NOW())`,
+	`INSERT INTO public."WorkspaceMember" ("userId", "workspaceId", "workspaceRoleId", accepted_at)
+	 SELECT '` + benchActorID + `', '` + benchWorkspaceID + `', wr.id, NOW()
+	 FROM public."WorkspaceRole" wr WHERE wr.name = 'work_admin'`,
+}
+
+// startBenchDependencies sobe Postgres (pgvector) e Redis com testcontainers, aplica o schema base
+// e as migrations, semeia o workspace do bench e aponta DATABASE_URL/REDIS_URL para os containers
+// (o serve iniciado depois herda o ambiente). Sem JWT_* no ambiente, usa valores descartáveis.
+func startBenchDependencies(ctx context.Context, schemaPath string) (func(), error) {
+	schema, err := filepath.Abs(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(schema); err != nil {
+		return nil, fmt.Errorf("base schema: %w (run from the repository root or pass --schema)", err)
+	}
+
+	var containers []testcontainers.Container
+	stop := func() {
+		for i := len(containers) - 1; i >= 0; i-- {
+			_ = containers[i].Terminate(context.Background())
+		}
+	}
+
+	pg, err := tcpostgres.RunContainer(ctx,
+		testcontainers.WithImage("pgvector/pgvector:pg16"),
+		tcpostgres.WithDatabase("linkko"),
+		tcpostgres.WithUsername("linkko"),
+		tcpostgres.WithPassword("linkko"),
+		tcpostgres.WithInitScripts(schema),
+		testcontainers.WithWaitStrategy(wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).WithStartupTimeout(2*time.Minute)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start postgres container: %w", err)
+	}
+	containers = append(containers, pg)
+
+	rd, err := tcredis.RunContainer(ctx, testcontainers.WithImage("redis:7-alpine"))
+	if err != nil {
+		stop()
+		return nil, fmt.Errorf("failed to start redis container: %w", err)
+	}
+	containers = append(containers, rd)
+
+	databaseURL, err := pg.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	redisURL, err := rd.ConnectionString(ctx)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	if err := database.RunMigrations(databaseURL); err != nil {
+		stop()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	if err := seedBenchWorkspace(ctx, databaseURL); err != nil {
+		stop()
+		return nil, fmt.Errorf("failed to seed bench workspace: %w", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		stop()
+		return nil, err
+	}
+	// O serve do bench usa só os containers (sem shards de residência)
+	env := map[string]string{"DATABASE_URL": databaseURL, "REDIS_URL": redisURL, "DATABASE_SHARDS": ""}
+	defaults := map[string]string{
+		"JWT_HS256_SECRET":    base64.StdEncoding.EncodeToString(secret),
+		"JWT_ALLOWED_ISSUERS": "linkko-bench",
+		"JWT_AUDIENCE":        "linkko-api-gateway",
+	}
+	for key, value := range defaults {
+		if os.Getenv(key) == "" {
+			env[key] = value
+		}
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			stop()
+			return nil, err
+		}
+	}
+	return stop, nil
+}
+
+func seedBenchWorkspace(ctx context.Context, databaseURL string) error {
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	for _, stmt := range benchSeedSQL {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
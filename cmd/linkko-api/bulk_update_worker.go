@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var bulkUpdateWorkerCmd = &cobra.Command{
+	Use:   "bulk-update-worker",
+	Short: "Run contact bulk update jobs",
+	Long:  `Poll pending contact bulk update jobs and apply their patch (tags, owner, lifecycle stage) to the selected contacts in batches`,
+	RunE:  runBulkUpdateWorker,
+}
+
+var bulkUpdateWorkerOnce bool
+
+func init() {
+	bulkUpdateWorkerCmd.Flags().BoolVar(&bulkUpdateWorkerOnce, "once", false, "process pending jobs and exit")
+	rootCmd.AddCommand(bulkUpdateWorkerCmd)
+}
+
+func runBulkUpdateWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	// Initialize service
+	bulkService := service.NewContactBulkService(
+		repo.NewContactBulkRepository(pool),
+		repo.NewWorkspaceRepository(pool),
+		repo.NewUserRepository(pool),
+		repo.NewAuditRepo(pool),
+		log,
+	)
+
+	interval := cfg.BulkUpdateWorkerInterval
+	log.Info(ctx, "starting bulk update worker", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := bulkService.ProcessNext(ctx)
+		if err != nil {
+			log.Error(ctx, "bulk update worker cycle failed", zap.Error(err))
+		}
+
+		// Havia job: provavelmente há mais na fila, processa de novo sem esperar
+		if err == nil && processed && ctx.Err() == nil {
+			continue
+		}
+
+		if bulkUpdateWorkerOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "bulk update worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"linkko-api/internal/config"
 	"linkko-api/internal/database"
@@ -15,8 +16,8 @@ import (
 
 var cleanupCmd = &cobra.Command{
 	Use:   "cleanup",
-	Short: "Cleanup expired idempotency keys",
-	Long:  `Remove idempotency keys older than 24 hours from the database`,
+	Short: "Cleanup expired idempotency keys, undo tokens and trash, create upcoming partitions and refresh shard anchors",
+	Long:  `Remove idempotency keys older than 24 hours and expired undo tokens from the database, permanently delete records soft-deleted more than TRASH_RETENTION_DAYS ago along with their identity mappings, create the monthly partitions of Activity and audit_log for the next PARTITION_PREMAKE_MONTHS months, and refresh the workspace, members and users copied to the data residency shards`,
 	RunE:  runCleanup,
 }
 
@@ -50,7 +51,7 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 	defer pool.Close()
 
 	// Initialize repository
-	idempotencyRepo := repo.NewIdempotencyRepo(pool)
+	idempotencyRepo := repo.NewIdempotencyRepo(pool, nil)
 
 	// Cleanup expired keys
 	rowsDeleted, err := idempotencyRepo.CleanupExpired(ctx)
@@ -59,8 +60,101 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to cleanup expired keys: %w", err)
 	}
 
-	log.Info("cleanup completed", zap.Int64("rows_deleted", rowsDeleted))
-	fmt.Printf("✓ Cleanup completed: %d expired keys removed\n", rowsDeleted)
+	// Tokens de undo expirados não servem mais para nada
+	undoRepo := repo.NewUndoRepository(pool)
+	undoDeleted, err := undoRepo.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		log.Error("undo tokens cleanup failed", zap.Error(err))
+		return fmt.Errorf("failed to cleanup expired undo tokens: %w", err)
+	}
+
+	// Lixeira: registros excluídos há mais de TRASH_RETENTION_DAYS são removidos definitivamente
+	trashRepo := repo.NewTrashRepository(pool)
+	purgeBefore := time.Now().UTC().Add(-cfg.TrashRetention)
+	purged, err := trashRepo.Purge(ctx, purgeBefore)
+	if err != nil {
+		log.Error("trash purge failed", zap.Error(err))
+		return fmt.Errorf("failed to purge trash: %w", err)
+	}
+	var trashPurged int64
+	for _, n := range purged {
+		trashPurged += n
+	}
+
+	// Identity mappings dos registros purgados (sem FK: entityId é polimórfico)
+	mappingsDeleted, err := repo.NewIdentityMappingRepository(pool).DeleteOrphaned(ctx)
+	if err != nil {
+		log.Error("identity mappings cleanup failed", zap.Error(err))
+		return fmt.Errorf("failed to cleanup orphaned identity mappings: %w", err)
+	}
+
+	// Partições mensais: mês corrente e os próximos PARTITION_PREMAKE_MONTHS, para que nada
+	// caia na partição default
+	partitionRepo := repo.NewPartitionRepository(pool)
+	now := time.Now().UTC()
+	var partitionsCreated int
+	for _, table := range repo.PartitionedTables {
+		for i := 0; i <= cfg.PartitionPremakeMonths; i++ {
+			month := time.Date(now.Year(), now.Month()+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+			created, moved, err := partitionRepo.EnsureMonthly(ctx, table, month)
+			if err != nil {
+				log.Error("partition maintenance failed", zap.String("table", table.Name), zap.Error(err))
+				return fmt.Errorf("failed to create partition %s: %w", table.PartitionName(month), err)
+			}
+			if created {
+				partitionsCreated++
+				log.Info("partition created", zap.String("partition", table.PartitionName(month)), zap.Int64("rows_moved_from_default", moved))
+			}
+		}
+	}
+
+	// Shards de residência: workspace, membros e usuários copiados para o shard acompanham o
+	// banco padrão (membros novos só podem ser referenciados nos dados depois da cópia)
+	anchorsSynced, err := syncAllShardAnchors(ctx, cfg, pool)
+	if err != nil {
+		log.Error("shard anchor sync failed", zap.Error(err))
+		return err
+	}
+
+	log.Info("cleanup completed", zap.Int64("rows_deleted", rowsDeleted), zap.Int64("undo_tokens_deleted", undoDeleted), zap.Any("trash_purged", purged), zap.Int64("identity_mappings_deleted", mappingsDeleted), zap.Int("partitions_created", partitionsCreated), zap.Int("shard_anchors_synced", anchorsSynced))
+	fmt.Printf("✓ Cleanup completed: %d expired keys removed, %d expired undo tokens removed, %d trashed records purged, %d orphaned identity mappings removed, %d partitions created, %d sharded workspaces synced\n", rowsDeleted, undoDeleted, trashPurged, mappingsDeleted, partitionsCreated, anchorsSynced)
 
 	return nil
 }
+
+// syncAllShardAnchors atualiza as âncoras de todos os workspaces fora do banco padrão. Sem
+// DATABASE_SHARDS (ou rodando com DATABASE_URL de um shard, onde o mapa é vazio) não faz nada.
+func syncAllShardAnchors(ctx context.Context, cfg *config.Config, pool database.DB) (int, error) {
+	urls := cfg.GetDatabaseShards()
+	if len(urls) == 0 {
+		return 0, nil
+	}
+	list, err := repo.NewShardRepository(pool).List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list workspace shards: %w", err)
+	}
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	shardDBs := map[string]database.DB{}
+	for name, url := range urls {
+		shardPool, err := database.NewPool(ctx, url)
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to database shard %s: %w", name, err)
+		}
+		defer shardPool.Close()
+		shardDBs[name] = shardPool
+	}
+
+	for _, ws := range list {
+		db, ok := shardDBs[ws.Shard]
+		if !ok {
+			return 0, fmt.Errorf("workspace %s is mapped to unconfigured shard %q", ws.WorkspaceID, ws.Shard)
+		}
+		if _, err := repo.NewShardCopier(pool, db, db, ws.Shard).SyncAnchors(ctx, ws.WorkspaceID); err != nil {
+			return 0, fmt.Errorf("failed to sync shard anchors of workspace %s: %w", ws.WorkspaceID, err)
+		}
+	}
+	return len(list), nil
+}
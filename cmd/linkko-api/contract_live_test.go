@@ -0,0 +1,461 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/http/client"
+	"linkko-api/internal/loadtest"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// contractQueries query strings por rota (templates expandidos com as variáveis do seed), para que
+// as listagens filtradas retornem os registros semeados.
+var contractQueries = map[string]string{
+	"/v1/workspaces/{workspaceId}/timeline":         "contactId={contactId}",
+	"/v1/workspaces/{workspaceId}/timeline/:digest": "contactId={contactId}",
+	"/v1/workspaces/{workspaceId}/deals":            "pipelineId={pipelineId}",
+}
+
+// TestContractLive exercita todas as rotas GET de /v1 e as escritas de contractMutations contra uma
+// API real, com dados semeados pelo próprio teste, e compara o formato das respostas (chaves e tipos
+// JSON) com os golden files em testdata/contract/live. Requer um workspace descartável (no CI, o
+// job contract-live sobe o banco, aplica as migrations e semeia um workspace com
+// scripts/contract-seed.sql):
+//
+//	CONTRACT_API_URL=http://localhost:8080 CONTRACT_WORKSPACE_ID=... CONTRACT_ACTOR_ID=... \
+//	  go test ./cmd/linkko-api -run ContractLive [-update]
+//
+// O token é assinado com JWT_HS256_SECRET/JWT_ALLOWED_ISSUERS/JWT_AUDIENCE do ambiente, como no bench.
+func TestContractLive(t *testing.T) {
+	baseURL := os.Getenv("CONTRACT_API_URL")
+	workspaceID := os.Getenv("CONTRACT_WORKSPACE_ID")
+	actorID := os.Getenv("CONTRACT_ACTOR_ID")
+	if baseURL == "" || workspaceID == "" || actorID == "" {
+		t.Skip("CONTRACT_API_URL, CONTRACT_WORKSPACE_ID and CONTRACT_ACTOR_ID not set, skipping live contract tests")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	token, err := mintBenchToken(cfg, workspaceID, actorID, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+
+	ctx := context.Background()
+	c := &loadtest.Client{BaseURL: baseURL, Token: token, HTTPClient: client.NewCustomHTTPClient(30 * time.Second)}
+	seeded := map[string][]byte{}
+	vars, err := seedContractData(ctx, c, workspaceID, seeded)
+	if err != nil {
+		t.Fatalf("failed to seed data: %v", err)
+	}
+
+	var routes, writes []string
+	walk := func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = normalizeChiPath(route)
+		if !strings.HasPrefix(route, "/v1/") {
+			return nil
+		}
+		if method == http.MethodGet {
+			routes = append(routes, route)
+		} else {
+			writes = append(writes, method+" "+route)
+		}
+		return nil
+	}
+	if err := chi.Walk(buildRouter(noopRouterDeps()), walk); err != nil {
+		t.Fatalf("failed to walk chi router: %v", err)
+	}
+	sort.Strings(routes)
+	sort.Strings(writes)
+
+	for _, route := range routes {
+		t.Run(route, func(t *testing.T) {
+			path, missing := expandContractPath(route, vars)
+			if missing != "" {
+				t.Skipf("no seeded value for {%s}", missing)
+			}
+
+			status, body, err := c.Do(ctx, http.MethodGet, path, nil)
+			if err != nil {
+				t.Fatalf("GET %s: %v", path, err)
+			}
+			if status != http.StatusOK {
+				t.Fatalf("GET %s: status %d: %s", path, status, body)
+			}
+			var payload any
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Skipf("non-JSON response (%v)", err)
+			}
+			checkLiveGolden(t, contractGoldenName(route), payload)
+		})
+	}
+
+	// Escritas: as criações do seed e, depois das leituras, contractMutations em ordem
+	// (as exclusões por último, para não esvaziar os registros usados pelas demais)
+	covered := map[string]bool{}
+	for key, body := range seeded {
+		covered[key] = true
+		t.Run(key, func(t *testing.T) {
+			var payload any
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("non-JSON response: %v", err)
+			}
+			method, route, _ := strings.Cut(key, " ")
+			checkLiveGolden(t, contractWriteGoldenName(method, route), payload)
+		})
+	}
+	for _, m := range contractMutations {
+		key := m.method + " " + m.route
+		covered[key] = true
+		t.Run(key, func(t *testing.T) {
+			path, missing := expandContractPath(m.route, vars)
+			if missing != "" {
+				t.Fatalf("no seeded value for {%s}", missing)
+			}
+			var body any
+			if m.body != nil {
+				body = m.body(vars)
+			}
+
+			status, resp, err := c.Do(ctx, m.method, path, body)
+			if err != nil {
+				t.Fatalf("%s %s: %v", m.method, path, err)
+			}
+			if status >= 300 {
+				t.Fatalf("%s %s: status %d: %s", m.method, path, status, resp)
+			}
+			if len(resp) == 0 {
+				return
+			}
+			var payload any
+			if err := json.Unmarshal(resp, &payload); err != nil {
+				t.Skipf("non-JSON response (%v)", err)
+			}
+			checkLiveGolden(t, contractWriteGoldenName(m.method, m.route), payload)
+		})
+	}
+	for _, key := range writes {
+		if !covered[key] {
+			t.Run(key, func(t *testing.T) {
+				t.Skip("no request in contractMutations")
+			})
+		}
+	}
+}
+
+// checkLiveGolden compara o formato da resposta com o golden (ou o regrava com -update).
+func checkLiveGolden(t *testing.T, name string, payload any) {
+	t.Helper()
+
+	goldenDir := filepath.Join("testdata", "contract", "live")
+	got := jsonShape(payload)
+	golden := filepath.Join(goldenDir, name)
+	if *updateGolden {
+		if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("missing golden %s; run with -update against a seeded workspace", golden)
+	}
+	if diff := diffLines(string(want), got); diff != "" {
+		t.Errorf("response shape changed (- golden, + current). If intentional, run with -update:\n%s", diff)
+	}
+}
+
+// contractMutation escrita exercitada pelo teste ao vivo; body recebe os ids semeados.
+type contractMutation struct {
+	method string
+	route  string
+	body   func(vars map[string]string) any
+}
+
+// contractMutations escritas cobertas além das criações do seed, na ordem de execução.
+var contractMutations = []contractMutation{
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/contacts/{contactId}", func(map[string]string) any {
+		return map[string]any{"phone": "+55 11 99999-0000"}
+	}},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/contacts/{contactId}/:follow", nil},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/contacts/{contactId}/:unfollow", nil},
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/companies/{companyId}", func(map[string]string) any {
+		return map[string]any{"name": "Contract Company (renamed)"}
+	}},
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/deals/{dealId}", func(map[string]string) any {
+		return map[string]any{"value": 2500}
+	}},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/deals/{dealId}/:move", func(vars map[string]string) any {
+		return map[string]any{"stageId": vars["stageId"]}
+	}},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/deals/{dealId}/:follow", nil},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/deals/{dealId}/:unfollow", nil},
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/tasks/{taskId}", func(map[string]string) any {
+		return map[string]any{"title": "Contract Task (renamed)"}
+	}},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/tasks/{taskId}/:move", func(map[string]string) any {
+		return map[string]any{"toStatus": "IN_PROGRESS"}
+	}},
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/timeline/notes/{noteId}", func(map[string]string) any {
+		return map[string]any{"content": "Contract note (edited)"}
+	}},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/timeline/notes/{noteId}/:pin", nil},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/timeline/notes/{noteId}/:unpin", nil},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/timeline/calls", func(vars map[string]string) any {
+		return map[string]any{
+			"contactId": vars["contactId"],
+			"direction": "OUTBOUND",
+			"calledAt":  time.Now().UTC().Add(-time.Hour).Format(time.RFC3339),
+		}
+	}},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/timeline/meetings", func(vars map[string]string) any {
+		start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+		return map[string]any{
+			"title":       "Contract Meeting",
+			"meetingType": "VIDEO",
+			"startTime":   start.Format(time.RFC3339),
+			"endTime":     start.Add(30 * time.Minute).Format(time.RFC3339),
+			"contactId":   vars["contactId"],
+		}
+	}},
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/email-templates/{templateId}", func(map[string]string) any {
+		return map[string]any{"subject": "Contract subject (edited)"}
+	}},
+	{http.MethodPost, "/v1/workspaces/{workspaceId}/email-templates/{templateId}/:render", func(vars map[string]string) any {
+		return map[string]any{"contactId": vars["contactId"]}
+	}},
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/holidays/{holidayId}", func(map[string]string) any {
+		return map[string]any{"name": "Contract Holiday (renamed)"}
+	}},
+	{http.MethodPatch, "/v1/workspaces/{workspaceId}/time-entries/{timeEntryId}", func(map[string]string) any {
+		return map[string]any{"description": "Contract entry (edited)"}
+	}},
+	{http.MethodDelete, "/v1/workspaces/{workspaceId}/time-entries/{timeEntryId}", nil},
+	{http.MethodDelete, "/v1/workspaces/{workspaceId}/holidays/{holidayId}", nil},
+	{http.MethodDelete, "/v1/workspaces/{workspaceId}/email-templates/{templateId}", nil},
+	{http.MethodDelete, "/v1/workspaces/{workspaceId}/tasks/{taskId}", nil},
+}
+
+// seedContractData cria um registro de cada entidade principal e devolve os ids para os path params.
+// As respostas das criações ficam em seeded ("POST <rota>") para o contrato das escritas.
+func seedContractData(ctx context.Context, c *loadtest.Client, workspaceID string, seeded map[string][]byte) (map[string]string, error) {
+	vars := map[string]string{"workspaceId": workspaceID}
+	ws := "/v1/workspaces/" + workspaceID
+
+	create := func(param, path string, body any) error {
+		status, resp, err := c.Do(ctx, http.MethodPost, ws+expandVars(path, vars), body)
+		if err != nil {
+			return err
+		}
+		if status >= 300 {
+			return fmt.Errorf("POST %s: status %d: %s", path, status, resp)
+		}
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(resp, &created); err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
+		if param != "" {
+			vars[param] = created.ID
+			seeded[http.MethodPost+" /v1/workspaces/{workspaceId}"+path] = resp
+		}
+		return nil
+	}
+	first := func(param, path string) error {
+		status, resp, err := c.Do(ctx, http.MethodGet, ws+expandVars(path, vars), nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("GET %s: status %d: %s", path, status, resp)
+		}
+		var list struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(resp, &list); err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
+		if len(list.Data) > 0 {
+			vars[param] = list.Data[0].ID
+		}
+		return nil
+	}
+
+	suffix := time.Now().UTC().Format("20060102150405")
+	// Datas de feriado não se repetem entre execuções (unique por workspace)
+	holiday := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(time.Now().Unix()%36500))
+
+	steps := []func() error{
+		func() error { return first("pipelineId", "/pipelines") },
+		func() error {
+			if vars["pipelineId"] != "" {
+				return nil
+			}
+			if err := create("", "/pipelines/:seed-default", map[string]any{}); err != nil {
+				return err
+			}
+			return first("pipelineId", "/pipelines")
+		},
+		func() error { return first("stageId", "/pipelines/{pipelineId}/stages") },
+		func() error {
+			return create("contactId", "/contacts", map[string]any{
+				"fullName": "Contract Contact " + suffix,
+				"email":    "contract+" + suffix + "@example.com",
+			})
+		},
+		func() error {
+			return create("companyId", "/companies", map[string]any{"name": "Contract Company " + suffix})
+		},
+		func() error {
+			return create("dealId", "/deals", map[string]any{
+				"name":       "Contract Deal " + suffix,
+				"pipelineId": vars["pipelineId"],
+				"stageId":    vars["stageId"],
+				"contactId":  vars["contactId"],
+				"companyId":  vars["companyId"],
+				"value":      1000,
+				"currency":   "BRL",
+			})
+		},
+		func() error { return create("taskId", "/tasks", map[string]any{"title": "Contract Task " + suffix}) },
+		func() error {
+			return create("noteId", "/timeline/notes", map[string]any{
+				"content":   "Contract note",
+				"contactId": vars["contactId"],
+			})
+		},
+		func() error {
+			return create("templateId", "/email-templates", map[string]any{
+				"name":    "Contract Template " + suffix,
+				"subject": "Hello {{contact.fullName}}",
+				"body":    "Hi {{contact.fullName}}",
+			})
+		},
+		func() error {
+			return create("timeEntryId", "/time-entries", map[string]any{
+				"taskId":          vars["taskId"],
+				"description":     "Contract entry",
+				"durationSeconds": 600,
+			})
+		},
+		func() error {
+			return create("holidayId", "/holidays", map[string]any{
+				"date": holiday.Format("2006-01-02"),
+				"name": "Contract Holiday",
+			})
+		},
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return vars, nil
+}
+
+var contractParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// expandContractPath preenche os path params e a query da rota; missing é o primeiro param sem valor.
+func expandContractPath(route string, vars map[string]string) (path, missing string) {
+	path = route
+	if q := contractQueries[route]; q != "" {
+		path += "?" + q
+	}
+	for _, m := range contractParamPattern.FindAllStringSubmatch(path, -1) {
+		if vars[m[1]] == "" {
+			return "", m[1]
+		}
+	}
+	return expandVars(path, vars), ""
+}
+
+func expandVars(path string, vars map[string]string) string {
+	for k, v := range vars {
+		path = strings.ReplaceAll(path, "{"+k+"}", v)
+	}
+	return path
+}
+
+// contractGoldenName nome do golden da rota: /v1/workspaces/{workspaceId}/deals/{dealId} -> deals.dealId.golden
+func contractGoldenName(route string) string {
+	name := strings.TrimPrefix(route, "/v1/workspaces/{workspaceId}")
+	name = strings.NewReplacer("{", "", "}", "", ":", "").Replace(strings.Trim(name, "/"))
+	if name == "" {
+		name = "workspace"
+	}
+	return strings.ReplaceAll(name, "/", ".") + ".golden"
+}
+
+// contractWriteGoldenName nome do golden de uma escrita: PATCH .../deals/{dealId} -> deals.dealId.PATCH.golden
+func contractWriteGoldenName(method, route string) string {
+	return strings.TrimSuffix(contractGoldenName(route), ".golden") + "." + method + ".golden"
+}
+
+// generatedKeyPattern chaves de mapas que são ids gerados (ex.: contadores por estágio), trocadas
+// por "{id}" para o golden não depender dos dados.
+var generatedKeyPattern = regexp.MustCompile(`^([a-z]+_[a-z2-7]{24}|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// jsonShape descreve a resposta como linhas "caminho tipo" ordenadas. Elementos de arrays são
+// mesclados em "[]" e um campo com tipos diferentes entre elementos lista todos ("string|null").
+func jsonShape(v any) string {
+	types := map[string]map[string]bool{}
+	var walk func(path string, v any)
+	walk = func(path string, v any) {
+		add := func(kind string) {
+			if types[path] == nil {
+				types[path] = map[string]bool{}
+			}
+			types[path][kind] = true
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			add("object")
+			for k, child := range val {
+				if generatedKeyPattern.MatchString(k) {
+					k = "{id}"
+				}
+				walk(path+"."+k, child)
+			}
+		case []any:
+			add("array")
+			for _, child := range val {
+				walk(path+"[]", child)
+			}
+		case string:
+			add("string")
+		case float64:
+			add("number")
+		case bool:
+			add("boolean")
+		case nil:
+			add("null")
+		}
+	}
+	walk("$", v)
+
+	var b strings.Builder
+	for _, path := range sortedKeys(types) {
+		fmt.Fprintf(&b, "%s %s\n", path, strings.Join(sortedKeys(types[path]), "|"))
+	}
+	return b.String()
+}
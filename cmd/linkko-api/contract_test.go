@@ -0,0 +1,239 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// updateGolden regrava os golden files de contrato: go test ./cmd/linkko-api -run Contract -update
+var updateGolden = flag.Bool("update", false, "rewrite API contract golden files")
+
+// contractPackages pacotes cujos structs com tags json formam o contrato público (requests e respostas).
+var contractPackages = []string{
+	"internal/domain",
+	"internal/http/handler",
+}
+
+// TestContractShapes compara os campos JSON de todos os structs expostos pela API com os golden
+// files em testdata/contract. Renomear ou remover um campo (ex.: a tag json de Pipeline) quebra o
+// app web sem erro de compilação; aqui a mudança precisa ser explícita, regravando o golden.
+func TestContractShapes(t *testing.T) {
+	for _, pkg := range contractPackages {
+		shapes, err := structShapes(filepath.Join("..", "..", pkg))
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", pkg, err)
+		}
+
+		goldenDir := filepath.Join("testdata", "contract", filepath.Base(pkg))
+		if *updateGolden {
+			if err := writeShapeGoldens(goldenDir, shapes); err != nil {
+				t.Fatalf("failed to update goldens: %v", err)
+			}
+			continue
+		}
+
+		for file, got := range shapes {
+			want, err := os.ReadFile(filepath.Join(goldenDir, file+".golden"))
+			if os.IsNotExist(err) {
+				t.Errorf("%s/%s: missing golden file; run `go test ./cmd/linkko-api -run Contract -update`", pkg, file)
+				continue
+			}
+			if err != nil {
+				t.Fatalf("failed to read golden: %v", err)
+			}
+			if diff := diffLines(string(want), got); diff != "" {
+				t.Errorf("%s/%s: API contract changed (- golden, + current). If intentional, run with -update:\n%s", pkg, file, diff)
+			}
+		}
+
+		// Golden sem arquivo correspondente: struct movido ou removido
+		goldens, _ := filepath.Glob(filepath.Join(goldenDir, "*.golden"))
+		for _, g := range goldens {
+			if _, ok := shapes[strings.TrimSuffix(filepath.Base(g), ".golden")]; !ok {
+				t.Errorf("%s: golden %s has no source file anymore; run with -update if intentional", pkg, filepath.Base(g))
+			}
+		}
+	}
+}
+
+// structShapes descreve, por arquivo-fonte, os campos JSON dos structs do pacote que têm tags json.
+// Cada struct vira um bloco "Nome" seguido de "  campo tipo [omitempty]" na ordem de declaração.
+func structShapes(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]string{}
+	for _, pkg := range pkgs {
+		for path, file := range pkg.Files {
+			var b strings.Builder
+			ast.Inspect(file, func(n ast.Node) bool {
+				spec, ok := n.(*ast.TypeSpec)
+				if !ok {
+					return true
+				}
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok || !hasJSONTags(st) {
+					return true
+				}
+				fmt.Fprintf(&b, "%s\n", spec.Name.Name)
+				writeStructFields(&b, st, "  ")
+				return true
+			})
+			if b.Len() > 0 {
+				out[strings.TrimSuffix(filepath.Base(path), ".go")] = b.String()
+			}
+		}
+	}
+	return out, nil
+}
+
+func writeStructFields(b *strings.Builder, st *ast.StructType, indent string) {
+	for _, field := range st.Fields.List {
+		typ := types.ExprString(field.Type)
+		name, opts, tagged := jsonTag(field)
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		// Embutido sem tag: encoding/json promove os campos
+		if len(field.Names) == 0 && !tagged {
+			fmt.Fprintf(b, "%s(embedded %s)\n", indent, typ)
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(typ)}
+		}
+		for _, ident := range names {
+			if !ident.IsExported() {
+				continue
+			}
+			jsonName := name
+			if jsonName == "" {
+				jsonName = ident.Name
+			}
+			line := indent + jsonName + " " + typ
+			if opts != "" {
+				line += " " + opts
+			}
+			fmt.Fprintln(b, line)
+
+			// Structs anônimos (ex.: meta inline) fazem parte do contrato
+			if inner, ok := field.Type.(*ast.StructType); ok {
+				writeStructFields(b, inner, indent+"  ")
+			}
+		}
+	}
+}
+
+func hasJSONTags(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if _, _, tagged := jsonTag(field); tagged {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTag retorna o nome e as opções (ex.: "omitempty") da tag json do campo.
+func jsonTag(field *ast.Field) (name, opts string, ok bool) {
+	if field.Tag == nil {
+		return "", "", false
+	}
+	tag, ok := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Lookup("json")
+	if !ok {
+		return "", "", false
+	}
+	name, opts, _ = strings.Cut(tag, ",")
+	return name, opts, true
+}
+
+func writeShapeGoldens(dir string, shapes map[string]string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for file, shape := range shapes {
+		if err := os.WriteFile(filepath.Join(dir, file+".golden"), []byte(shape), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffLines diff por linha (LCS) entre golden e atual; vazio quando iguais.
+func diffLines(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Só as linhas alteradas, precedidas do struct a que pertencem
+	var out []string
+	header, printed := "", ""
+	emit := func(line string) {
+		if header != printed {
+			out = append(out, "@ "+header)
+			printed = header
+		}
+		out = append(out, line)
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			if !strings.HasPrefix(a[i], " ") {
+				header = a[i]
+			}
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			emit("+ " + b[j])
+			j++
+		default:
+			emit("- " + a[i])
+			i++
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// sortedKeys chaves de um mapa em ordem alfabética.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
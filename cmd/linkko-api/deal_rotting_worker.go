@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var dealRottingWorkerCmd = &cobra.Command{
+	Use:   "deal-rotting-worker",
+	Short: "Flag stale deals",
+	Long:  `Flag open deals with no activity or stage change for longer than their stage's rottingDays (working days of the workspace business calendar), clear the flag when they move again, and record a DEAL_ROTTING timeline event for each newly flagged deal`,
+	RunE:  runDealRottingWorker,
+}
+
+var dealRottingWorkerOnce bool
+
+func init() {
+	dealRottingWorkerCmd.Flags().BoolVar(&dealRottingWorkerOnce, "once", false, "process a single batch and exit")
+	rootCmd.AddCommand(dealRottingWorkerCmd)
+}
+
+func runDealRottingWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	// Initialize service
+	rottingService := service.NewDealRottingService(
+		repo.NewDealRepository(pool),
+		repo.NewActivityRepository(pool),
+		repo.NewBusinessHoursRepository(pool),
+		log,
+	)
+
+	interval := cfg.DealRottingWorkerInterval
+	log.Info(ctx, "starting deal rotting worker",
+		zap.Duration("interval", interval),
+		zap.Int("batch_size", cfg.DealRottingWorkerBatchSize),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		flagged, err := rottingService.ProcessRotting(ctx, time.Now().UTC(), cfg.DealRottingWorkerBatchSize)
+		if err != nil {
+			log.Error(ctx, "deal rotting worker batch failed", zap.Error(err))
+		} else if flagged > 0 {
+			log.Info(ctx, "deal rotting worker batch completed", zap.Int("flagged", flagged))
+		}
+
+		if dealRottingWorkerOnce {
+			return nil
+		}
+
+		// Lote cheio: provavelmente há mais deals parados, processa de novo sem esperar
+		if err == nil && flagged == cfg.DealRottingWorkerBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "deal rotting worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
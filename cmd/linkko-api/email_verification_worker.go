@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/integrations/emailverify"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var emailVerificationWorkerCmd = &cobra.Command{
+	Use:   "email-verification-worker",
+	Short: "Verify contact email addresses",
+	Long:  `Check the email of new or changed contacts (syntax, MX records and the optional verification provider) and set their emailStatus to VALID, RISKY or INVALID`,
+	RunE:  runEmailVerificationWorker,
+}
+
+var emailVerificationWorkerOnce bool
+
+func init() {
+	emailVerificationWorkerCmd.Flags().BoolVar(&emailVerificationWorkerOnce, "once", false, "verify pending emails and exit")
+	rootCmd.AddCommand(emailVerificationWorkerCmd)
+}
+
+func runEmailVerificationWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	provider, err := emailverify.Open(cfg.EmailVerificationProvider)
+	if err != nil {
+		return fmt.Errorf("failed to open email verification provider: %w", err)
+	}
+
+	// Initialize service
+	verificationService := service.NewEmailVerificationService(
+		repo.NewEmailVerificationRepository(pool),
+		emailverify.NewVerifier(net.DefaultResolver, provider),
+		log,
+	)
+
+	interval := cfg.EmailVerificationWorkerInterval
+	log.Info(ctx, "starting email verification worker",
+		zap.Duration("interval", interval),
+		zap.Int("batch_size", cfg.EmailVerificationWorkerBatchSize),
+		zap.String("provider", cfg.EmailVerificationProvider),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := verificationService.ProcessPending(ctx, cfg.EmailVerificationWorkerBatchSize)
+		if err != nil {
+			log.Error(ctx, "email verification worker batch failed", zap.Error(err))
+		} else if processed > 0 {
+			log.Info(ctx, "email verification worker batch completed", zap.Int("processed", processed))
+		}
+
+		// Lote cheio: provavelmente há mais emails pendentes, processa de novo sem esperar
+		if err == nil && processed == cfg.EmailVerificationWorkerBatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		if emailVerificationWorkerOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "email verification worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
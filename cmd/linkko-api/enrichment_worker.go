@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/integrations/enrichment"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var enrichmentWorkerCmd = &cobra.Command{
+	Use:   "enrichment-worker",
+	Short: "Run company enrichment jobs",
+	Long:  `Poll pending company enrichment jobs, query the configured enrichment provider and fill industry, size, revenue and logo on the company`,
+	RunE:  runEnrichmentWorker,
+}
+
+var enrichmentWorkerOnce bool
+
+func init() {
+	enrichmentWorkerCmd.Flags().BoolVar(&enrichmentWorkerOnce, "once", false, "process pending jobs and exit")
+	rootCmd.AddCommand(enrichmentWorkerCmd)
+}
+
+func runEnrichmentWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	provider, err := enrichment.Open(cfg.EnrichmentProvider)
+	if err != nil {
+		return fmt.Errorf("failed to open enrichment provider: %w", err)
+	}
+
+	// Initialize service
+	workspaceRepo := repo.NewWorkspaceRepository(pool)
+	companyRepo := repo.NewCompanyRepository(pool)
+	fieldHistoryService := service.NewFieldHistoryService(
+		repo.NewFieldHistoryRepository(pool),
+		repo.NewContactRepository(pool),
+		companyRepo,
+		repo.NewDealRepository(pool),
+		workspaceRepo,
+		log,
+	)
+	enrichmentService := service.NewCompanyEnrichmentService(
+		repo.NewCompanyEnrichmentRepository(pool),
+		companyRepo,
+		workspaceRepo,
+		repo.NewAuditRepo(pool),
+		fieldHistoryService,
+		provider,
+		log,
+	)
+
+	interval := cfg.EnrichmentWorkerInterval
+	log.Info(ctx, "starting enrichment worker", zap.Duration("interval", interval), zap.String("provider", provider.Name()))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := enrichmentService.ProcessNext(ctx)
+		if err != nil {
+			log.Error(ctx, "enrichment worker cycle failed", zap.Error(err))
+		}
+
+		// Havia job: provavelmente há mais na fila, processa de novo sem esperar
+		if err == nil && processed {
+			continue
+		}
+
+		if enrichmentWorkerOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "enrichment worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"linkko-api/internal/http/docs"
+	"linkko-api/internal/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+var genCmd = &cobra.Command{
+	Use:   "gen",
+	Short: "Generate artifacts from the API definition",
+}
+
+var genSDKCmd = &cobra.Command{
+	Use:   "sdk",
+	Short: "Generate the Go client and the OpenAPI spec used for the TypeScript client",
+	Long: `Write <out>/go/client.go, a dependency-free Go client with the route constants, request/response
+structs and one method per endpoint listed in internal/sdk, and <out>/openapi.yaml, the spec served
+at /docs, for TypeScript generation (e.g. npx openapi-typescript <out>/openapi.yaml -o linkko.d.ts).`,
+	Args: cobra.NoArgs,
+	RunE: runGenSDK,
+}
+
+var (
+	genSDKOut     string
+	genSDKPackage string
+)
+
+func init() {
+	genSDKCmd.Flags().StringVar(&genSDKOut, "out", "sdk", "output directory")
+	genSDKCmd.Flags().StringVar(&genSDKPackage, "package", "linkko", "package name of the Go client")
+
+	genCmd.AddCommand(genSDKCmd)
+	rootCmd.AddCommand(genCmd)
+}
+
+func runGenSDK(cmd *cobra.Command, args []string) error {
+	src, err := sdk.GenerateGo(genSDKPackage)
+	if err != nil {
+		return fmt.Errorf("generate go client: %w", err)
+	}
+
+	goDir := filepath.Join(genSDKOut, "go")
+	if err := os.MkdirAll(goDir, 0o755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+	clientPath := filepath.Join(goDir, "client.go")
+	if err := os.WriteFile(clientPath, src, 0o644); err != nil {
+		return fmt.Errorf("write go client: %w", err)
+	}
+
+	specPath := filepath.Join(genSDKOut, "openapi.yaml")
+	if err := os.WriteFile(specPath, docs.GetSpecBytes(), 0o644); err != nil {
+		return fmt.Errorf("write openapi spec: %w", err)
+	}
+
+	fmt.Printf("Go client: %s (%d endpoints)\n", clientPath, len(sdk.Endpoints))
+	fmt.Printf("OpenAPI:   %s\n", specPath)
+	return nil
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"sort"
 
+	"linkko-api/internal/config"
 	"linkko-api/internal/database"
 
 	"github.com/spf13/cobra"
@@ -20,10 +22,31 @@ func init() {
 }
 
 func runMigrate(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadMigrationConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
 	// Run migrations
-	if err := database.RunMigrations(); err != nil {
+	if err := database.RunMigrations(cfg.DatabaseURL); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Shards de residência têm o schema completo
+	shards, err := cfg.GetDatabaseShards()
+	if err != nil {
+		return fmt.Errorf("invalid DATABASE_SHARDS: %w", err)
+	}
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := database.RunMigrations(shards[name]); err != nil {
+			return fmt.Errorf("failed to run migrations on shard %s: %w", name, err)
+		}
+	}
+
 	return nil
 }
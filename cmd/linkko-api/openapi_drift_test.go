@@ -12,6 +12,7 @@ import (
 	"linkko-api/internal/http/docs"
 	"linkko-api/internal/http/handler"
 	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
@@ -19,25 +20,7 @@ import (
 
 func TestOpenAPIDriftCheck(t *testing.T) {
 	// 1. Build router with minimal/noop deps
-	cfg := &config.Config{
-		OTELServiceName: "test",
-		AppEnv:          "test",
-	}
-	log, _ := logger.New("test", "error")
-
-	deps := RouterDeps{
-		Cfg:              cfg,
-		Log:              log,
-		ContactHandler:   &handler.ContactHandler{},
-		TaskHandler:      &handler.TaskHandler{},
-		CompanyHandler:   &handler.CompanyHandler{},
-		PipelineHandler:  &handler.PipelineHandler{},
-		DealHandler:      &handler.DealHandler{},
-		ActivityHandler:  &handler.ActivityHandler{},
-		PortfolioHandler: &handler.PortfolioHandler{},
-		DebugHandler:     &handler.DebugHandler{},
-	}
-	r := buildRouter(deps)
+	r := buildRouter(noopRouterDeps())
 
 	// 2. Load OpenAPI spec
 	specBytes := docs.GetSpecBytes()
@@ -93,6 +76,70 @@ func TestOpenAPIDriftCheck(t *testing.T) {
 	}
 }
 
+// noopRouterDeps dependências vazias: suficientes para montar o router e percorrer as rotas.
+func noopRouterDeps() RouterDeps {
+	cfg := &config.Config{
+		OTELServiceName:     "test",
+		AppEnv:              "test",
+		StripeWebhookSecret: "whsec_test", // monta o webhook do Stripe
+		AdminToken:          "admin_test", // monta /admin/config:reload
+	}
+	log, _ := logger.New("test", "error")
+
+	return RouterDeps{
+		Cfg:                      cfg,
+		Log:                      log,
+		ScimResolver:             &service.ScimService{}, // monta /scim/v2
+		ContactHandler:           &handler.ContactHandler{},
+		TaskHandler:              &handler.TaskHandler{},
+		CompanyHandler:           &handler.CompanyHandler{},
+		PipelineHandler:          &handler.PipelineHandler{},
+		DealHandler:              &handler.DealHandler{},
+		ActivityHandler:          &handler.ActivityHandler{},
+		PortfolioHandler:         &handler.PortfolioHandler{},
+		SandboxHandler:           &handler.SandboxHandler{},
+		ReportHandler:            &handler.ReportHandler{},
+		EmailTemplateHandler:     &handler.EmailTemplateHandler{},
+		SequenceHandler:          &handler.SequenceHandler{},
+		TimeEntryHandler:         &handler.TimeEntryHandler{},
+		MyWorkHandler:            &handler.MyWorkHandler{},
+		CounterHandler:           &handler.CounterHandler{},
+		SnapshotHandler:          &handler.SnapshotHandler{},
+		FieldHistoryHandler:      &handler.FieldHistoryHandler{},
+		UndoHandler:              &handler.UndoHandler{},
+		BusinessHoursHandler:     &handler.BusinessHoursHandler{},
+		CompanyEnrichmentHandler: &handler.CompanyEnrichmentHandler{},
+		DealParticipantHandler:   &handler.DealParticipantHandler{},
+		DealSplitHandler:         &handler.DealSplitHandler{},
+		IdentityMappingHandler:   &handler.IdentityMappingHandler{},
+		SyncPolicyHandler:        &handler.SyncPolicyHandler{},
+		FollowerHandler:          &handler.FollowerHandler{},
+		ContactBulkHandler:       &handler.ContactBulkHandler{},
+		TrashHandler:             &handler.TrashHandler{},
+		CustomObjectHandler:      &handler.CustomObjectHandler{},
+		ComputedFieldHandler:     &handler.ComputedFieldHandler{},
+		ReportScheduleHandler:    &handler.ReportScheduleHandler{},
+		OrganizationHandler:      &handler.OrganizationHandler{},
+		ImpersonationHandler:     &handler.ImpersonationHandler{},
+		FormHandler:              &handler.FormHandler{},
+		TrackedLinkHandler:       &handler.TrackedLinkHandler{},
+		EmailEventHandler:        &handler.EmailEventHandler{},
+		DocumentTemplateHandler:  &handler.DocumentTemplateHandler{},
+		QuoteHandler:             &handler.QuoteHandler{},
+		InvoiceHandler:           &handler.InvoiceHandler{},
+		ScimHandler:              &handler.ScimHandler{},
+		SsoHandler:               &handler.SsoHandler{},
+		ServiceAccountHandler:    &handler.ServiceAccountHandler{},
+		BillingHandler:           &handler.BillingHandler{},
+		PublicFormHandler:        &handler.PublicFormHandler{},
+		SessionHandler:           &handler.SessionHandler{},
+		DebugHandler:             &handler.DebugHandler{},
+		ConfigHandler:            &handler.ConfigHandler{},
+		MaintenanceHandler:       &handler.MaintenanceHandler{},
+		StatusHandler:            &handler.StatusHandler{},
+	}
+}
+
 // normalizeChiPath removes regex from chi parameters and trailing slashes
 func normalizeChiPath(path string) string {
 	// Remove regex: {id:[0-9]+} -> {id}
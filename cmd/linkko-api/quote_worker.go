@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/objectstore"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var quoteWorkerCmd = &cobra.Command{
+	Use:   "quote-worker",
+	Short: "Render pending quote PDFs",
+	Long:  `Poll quotes with pending PDFs, rendering each one and storing it in the note attachment object storage`,
+	RunE:  runQuoteWorker,
+}
+
+var quoteWorkerOnce bool
+
+func init() {
+	quoteWorkerCmd.Flags().BoolVar(&quoteWorkerOnce, "once", false, "render pending PDFs and exit")
+	rootCmd.AddCommand(quoteWorkerCmd)
+}
+
+func runQuoteWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	// Os PDFs ficam no mesmo object storage dos anexos de notas
+	store, err := objectstore.Open(cfg.NoteAttachmentStorageURL)
+	if err != nil {
+		return fmt.Errorf("failed to open note attachment storage: %w", err)
+	}
+
+	// Initialize service
+	quoteService := service.NewQuoteService(
+		repo.NewQuoteRepository(pool),
+		repo.NewDocumentTemplateRepository(pool),
+		repo.NewContactRepository(pool),
+		repo.NewCompanyRepository(pool),
+		repo.NewDealRepository(pool),
+		repo.NewWorkspaceRepository(pool),
+		repo.NewAuditRepo(pool),
+		store,
+		cfg.QuoteShareBaseURL,
+		log,
+	)
+
+	interval := cfg.QuoteWorkerInterval
+	log.Info(ctx, "starting quote worker", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := quoteService.ProcessNext(ctx)
+		if err != nil {
+			log.Error(ctx, "quote worker cycle failed", zap.Error(err))
+		}
+
+		// Havia PDF pendente: provavelmente há mais na fila, processa de novo sem esperar
+		if err == nil && processed {
+			continue
+		}
+
+		if quoteWorkerOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "quote worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
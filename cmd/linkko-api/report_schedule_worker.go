@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/http/client"
+	"linkko-api/internal/integrations/mailer"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var reportScheduleWorkerCmd = &cobra.Command{
+	Use:   "report-schedule-worker",
+	Short: "Deliver scheduled reports",
+	Long:  `Run due report schedules: build the report as the schedule creator, render it to CSV or PDF and deliver it by email and/or signed webhook`,
+	RunE:  runReportScheduleWorker,
+}
+
+var reportScheduleWorkerOnce bool
+
+func init() {
+	reportScheduleWorkerCmd.Flags().BoolVar(&reportScheduleWorkerOnce, "once", false, "run due schedules and exit")
+	rootCmd.AddCommand(reportScheduleWorkerCmd)
+}
+
+func runReportScheduleWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	m, err := mailer.Open(mailer.Config{
+		Provider: cfg.MailProvider,
+		From:     cfg.MailFrom,
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open mailer: %w", err)
+	}
+
+	// Initialize services
+	workspaceRepo := repo.NewWorkspaceRepository(pool)
+	snapshots := database.NewTxManager(pool)
+	reportService := service.NewReportService(
+		repo.NewReportRepository(snapshots),
+		repo.NewDealRepository(snapshots),
+		repo.NewBusinessHoursRepository(snapshots),
+		workspaceRepo,
+		log,
+	).WithSnapshots(snapshots)
+	scheduleService := service.NewReportScheduleService(
+		repo.NewReportScheduleRepository(pool),
+		reportService,
+		workspaceRepo,
+		repo.NewAuditRepo(pool),
+		m,
+		client.NewExternalHTTPClient(),
+		log,
+	)
+
+	interval := cfg.ReportScheduleWorkerInterval
+	log.Info(ctx, "starting report schedule worker",
+		zap.Duration("interval", interval),
+		zap.Int("batch_size", cfg.ReportScheduleWorkerBatchSize),
+		zap.String("mail_provider", cfg.MailProvider),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := scheduleService.ProcessDue(ctx, cfg.ReportScheduleWorkerBatchSize)
+		if err != nil {
+			log.Error(ctx, "report schedule worker batch failed", zap.Error(err))
+		} else if processed > 0 {
+			log.Info(ctx, "report schedule worker batch completed", zap.Int("processed", processed))
+		}
+
+		// Lote cheio: provavelmente há mais agendamentos vencidos, processa de novo sem esperar
+		if err == nil && processed == cfg.ReportScheduleWorkerBatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		if reportScheduleWorkerOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "report schedule worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
 	"strings"
 	"time"
 
+	"linkko-api/internal/apperr"
 	"linkko-api/internal/auth"
 	"linkko-api/internal/config"
 	"linkko-api/internal/http/docs"
 	"linkko-api/internal/http/handler"
+	"linkko-api/internal/http/httperr"
 	"linkko-api/internal/http/middleware"
+	"linkko-api/internal/i18n"
+	"linkko-api/internal/integrations/errortracker"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/ratelimit"
 	"linkko-api/internal/repo"
@@ -20,39 +25,115 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 )
 
 // RouterDeps contém as dependências necessárias para construir o router.
 type RouterDeps struct {
 	Cfg             *config.Config
+	LiveConfig      *config.Live // Settings recarregáveis (nil usa Cfg fixo)
 	Log             *logger.Logger
 	Resolver        *auth.KeyResolver
 	S2SStore        *auth.S2STokenStore
 	IdempotencyRepo *repo.IdempotencyRepo
 	RateLimiter     *ratelimit.RedisRateLimiter
 	Metrics         *telemetry.Metrics
-	Pool            *pgxpool.Pool // Necessário para readiness check e debug handler
+	Pool            *pgxpool.Pool                    // Necessário para readiness check e debug handler
+	QuotaEnforcer   middleware.QuotaEnforcer         // Quotas do plano (nil desabilita)
+	WorkspaceStatus middleware.WorkspaceStatusLookup // Existência/suspensão do workspace (nil desabilita)
+	ScimResolver    middleware.ScimTokenResolver     // Token SCIM do workspace (nil desabilita /scim/v2)
+	Maintenance     middleware.MaintenanceLookup     // Modo de manutenção: 503 nas escritas (nil desabilita)
+	Shards          middleware.ShardResolver         // Shard de dados do workspace (nil = só o banco padrão)
+	ErrorTracker    errortracker.Tracker             // Relatórios de panics recuperados (nil desabilita)
 
 	// Handlers
-	ContactHandler   *handler.ContactHandler
-	TaskHandler      *handler.TaskHandler
-	CompanyHandler   *handler.CompanyHandler
-	PipelineHandler  *handler.PipelineHandler
-	DealHandler      *handler.DealHandler
-	ActivityHandler  *handler.ActivityHandler
-	PortfolioHandler *handler.PortfolioHandler
-	DebugHandler     *handler.DebugHandler
+	ContactHandler           *handler.ContactHandler
+	TaskHandler              *handler.TaskHandler
+	CompanyHandler           *handler.CompanyHandler
+	PipelineHandler          *handler.PipelineHandler
+	DealHandler              *handler.DealHandler
+	ActivityHandler          *handler.ActivityHandler
+	PortfolioHandler         *handler.PortfolioHandler
+	SandboxHandler           *handler.SandboxHandler
+	ReportHandler            *handler.ReportHandler
+	EmailTemplateHandler     *handler.EmailTemplateHandler
+	SequenceHandler          *handler.SequenceHandler
+	TimeEntryHandler         *handler.TimeEntryHandler
+	MyWorkHandler            *handler.MyWorkHandler
+	CounterHandler           *handler.CounterHandler
+	SnapshotHandler          *handler.SnapshotHandler
+	FieldHistoryHandler      *handler.FieldHistoryHandler
+	UndoHandler              *handler.UndoHandler
+	BusinessHoursHandler     *handler.BusinessHoursHandler
+	CompanyEnrichmentHandler *handler.CompanyEnrichmentHandler
+	DealParticipantHandler   *handler.DealParticipantHandler
+	DealSplitHandler         *handler.DealSplitHandler
+	IdentityMappingHandler   *handler.IdentityMappingHandler
+	SyncPolicyHandler        *handler.SyncPolicyHandler
+	FollowerHandler          *handler.FollowerHandler
+	ContactBulkHandler       *handler.ContactBulkHandler
+	TrashHandler             *handler.TrashHandler
+	CustomObjectHandler      *handler.CustomObjectHandler
+	ComputedFieldHandler     *handler.ComputedFieldHandler
+	ReportScheduleHandler    *handler.ReportScheduleHandler
+	OrganizationHandler      *handler.OrganizationHandler
+	ImpersonationHandler     *handler.ImpersonationHandler
+	FormHandler              *handler.FormHandler
+	TrackedLinkHandler       *handler.TrackedLinkHandler
+	EmailEventHandler        *handler.EmailEventHandler
+	DocumentTemplateHandler  *handler.DocumentTemplateHandler
+	QuoteHandler             *handler.QuoteHandler
+	InvoiceHandler           *handler.InvoiceHandler
+	ScimHandler              *handler.ScimHandler
+	SsoHandler               *handler.SsoHandler
+	ServiceAccountHandler    *handler.ServiceAccountHandler
+	BillingHandler           *handler.BillingHandler
+	PublicFormHandler        *handler.PublicFormHandler
+	SessionHandler           *handler.SessionHandler
+	DebugHandler             *handler.DebugHandler
+	ConfigHandler            *handler.ConfigHandler
+	MaintenanceHandler       *handler.MaintenanceHandler
+	StatusHandler            *handler.StatusHandler
+
+	// V2Handlers sobrescreve os handlers montados em /v2 (opcional).
+	V2Handlers *HandlerSet
+}
+
+// defaultLocale resolve DEFAULT_LOCALE; valores vazios/desconhecidos usam i18n.DefaultLocale.
+func defaultLocale(cfg *config.Config) i18n.Locale {
+	if locale, ok := i18n.Parse(cfg.DefaultLocale); ok {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+// current configuração em uso: a do LiveConfig (após reloads) ou Cfg
+func (deps RouterDeps) current() *config.Config {
+	if deps.LiveConfig != nil {
+		return deps.LiveConfig.Current()
+	}
+	return deps.Cfg
 }
 
 // buildRouter constrói o chi.Router com todos os middlewares e rotas.
 func buildRouter(deps RouterDeps) chi.Router {
 	r := chi.NewRouter()
 
+	// Settings recarregáveis são lidos a cada request
+	rateLimitPerMin := func() int { return deps.current().RateLimitPerWorkspacePerMin }
+	blockMutations := func() bool { return deps.current().ImpersonationBlockMutations }
+
 	// Global middlewares
 	r.Use(middleware.RequestIDMiddleware)
-	r.Use(middleware.RequestLoggingMiddleware(deps.Log))
-	r.Use(middleware.RecoveryMiddleware(deps.Log))
+	r.Use(middleware.RegionMiddleware(deps.Cfg.Region))
+	r.Use(middleware.SampledRequestLoggingMiddleware(deps.Log, middleware.LogSampling{
+		Rate:          deps.Cfg.LogSampleRate,
+		SlowThreshold: deps.Cfg.LogSlowRequestThreshold,
+		RouteRates:    deps.Cfg.GetLogSampleRouteRates(),
+	}))
+	r.Use(middleware.RecoveryMiddlewareWithTracker(deps.Log, deps.ErrorTracker))
+	r.Use(middleware.LocaleMiddleware(defaultLocale(deps.Cfg)))
 	r.Use(telemetry.OTelMiddleware(deps.Cfg.OTELServiceName))
 	if deps.Metrics != nil {
 		r.Use(telemetry.MetricsMiddleware(deps.Metrics))
@@ -67,8 +148,29 @@ func buildRouter(deps RouterDeps) chi.Router {
 
 	r.Get("/openapi.yaml", docs.OpenAPIHandler().ServeHTTP)
 	r.Get("/docs", docs.ScalarDocsHandler("/openapi.yaml").ServeHTTP)
+	r.Get(apperr.DocsPath, docs.ErrorCatalogHandler().ServeHTTP)
 	r.Get("/metrics", metricsMiddleware(deps.Cfg.MetricsToken)(promhttp.Handler()).ServeHTTP)
 
+	// Rotas administrativas da instância (ADMIN_TOKEN); sem token configurado elas não existem
+	if deps.Cfg.AdminToken != "" {
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(adminTokenMiddleware(deps.Cfg.AdminToken))
+			// Reload de configuração (mesmo efeito do SIGHUP)
+			if deps.ConfigHandler != nil {
+				r.Post("/config:reload", deps.ConfigHandler.Reload)
+			}
+			// Modo de manutenção global ou por workspace
+			if deps.MaintenanceHandler != nil {
+				mh := deps.MaintenanceHandler
+				r.Get("/maintenance", mh.ListMaintenance)
+				r.Put("/maintenance", mh.EnableMaintenance)
+				r.Delete("/maintenance", mh.DisableMaintenance)
+				r.Put("/workspaces/{workspaceId}/maintenance", mh.EnableMaintenance)
+				r.Delete("/workspaces/{workspaceId}/maintenance", mh.DisableMaintenance)
+			}
+		})
+	}
+
 	r.Get("/ready", func(w http.ResponseWriter, r *http.Request) {
 		if deps.Pool == nil {
 			w.Header().Set("Content-Type", "application/json")
@@ -107,116 +209,844 @@ func buildRouter(deps RouterDeps) chi.Router {
 		})
 	}
 
-	// Protected routes with workspace isolation
-	r.Route("/v1/workspaces/{workspaceId}", func(r chi.Router) {
-		r.Use(auth.AuthMiddleware(deps.Resolver, deps.S2SStore))
-		r.Use(middleware.WorkspaceMiddleware)
-		r.Use(middleware.RateLimitMiddleware(deps.RateLimiter, deps.Cfg.RateLimitPerWorkspacePerMin))
+	// Limiter compartilhado entre as versões: o limite é por workspace, não por prefixo
+	concurrencyLimiter := middleware.NewConcurrencyLimiter(
+		deps.Cfg.ConcurrencyLimitPerWorkspace,
+		deps.Cfg.ConcurrencyQueueTimeout,
+	)
 
-		// Contacts
-		if deps.ContactHandler != nil {
-			r.Route("/contacts", func(r chi.Router) {
-				r.Get("/", deps.ContactHandler.ListContacts)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.ContactHandler.CreateContact)
-				r.Route("/{contactId}", func(r chi.Router) {
-					r.Get("/", deps.ContactHandler.GetContact)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Patch("/", deps.ContactHandler.UpdateContact)
-					r.Delete("/", deps.ContactHandler.DeleteContact)
-				})
+	// Fila global da lane batch (por instância)
+	batchQueue := middleware.NewConcurrencyLimiter(
+		deps.Cfg.BatchLaneConcurrency,
+		deps.Cfg.BatchLaneQueueTimeout,
+	)
+
+	var workspaceRejections metric.Int64Counter
+	if deps.Metrics != nil {
+		workspaceRejections = deps.Metrics.WorkspaceRejections
+	}
+
+	// Protected routes with workspace isolation.
+	// Cada versão monta o mesmo conjunto de rotas; os handlers compartilham os services.
+	mountVersion := func(version string, hs HandlerSet, deprecation *middleware.DeprecationPolicy) {
+		r.Route("/"+version+"/workspaces/{workspaceId}", func(r chi.Router) {
+			r.Use(middleware.APIVersionMiddleware(version))
+			if deprecation != nil {
+				r.Use(middleware.DeprecationMiddleware(*deprecation))
+			}
+			r.Use(middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)))
+			r.Use(auth.AuthMiddleware(deps.Resolver, deps.S2SStore))
+			r.Use(middleware.WorkspaceMiddleware)
+			r.Use(middleware.PathIDMiddleware)
+			r.Use(middleware.WorkspaceStatusMiddleware(deps.WorkspaceStatus, workspaceRejections))
+			r.Use(middleware.ShardMiddleware(deps.Shards))
+			r.Use(middleware.MaintenanceMiddleware(deps.Maintenance))
+			r.Use(middleware.ScopeMiddleware)
+			r.Use(middleware.ImpersonationMiddleware(blockMutations))
+			r.Use(middleware.QuotaMiddleware(deps.QuotaEnforcer))
+
+			// Uso da cota só em headers, sem consumi-la nem entrar na fila de concorrência
+			if deps.RateLimiter != nil {
+				r.Head("/rate-limit", middleware.RateLimitStatusHandler(deps.RateLimiter, rateLimitPerMin))
+			}
+			// Status da API com a manutenção e o rate limit do workspace, também sem consumir a cota
+			if deps.StatusHandler != nil {
+				r.Get("/status", deps.StatusHandler.GetWorkspaceStatus)
+			}
+
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RateLimitMiddleware(deps.RateLimiter, rateLimitPerMin))
+				r.Use(middleware.ConcurrencyLimitMiddleware(concurrencyLimiter))
+				r.Use(middleware.PriorityMiddleware(batchQueue))
+				mountWorkspaceRoutes(r, hs, deps.IdempotencyRepo)
 			})
+		})
+	}
+
+	// Com a v2 montada e a data de remoção configurada, a v1 anuncia o sunset e a rota sucessora
+	var v1Deprecation *middleware.DeprecationPolicy
+	if sunset := deps.Cfg.GetAPIV1Sunset(); deps.Cfg.APIV2Enabled && !sunset.IsZero() {
+		v1Deprecation = &middleware.DeprecationPolicy{
+			Sunset:           sunset,
+			SuccessorVersion: middleware.APIVersionV2,
 		}
+	}
 
-		// Tasks
-		if deps.TaskHandler != nil {
-			r.Route("/tasks", func(r chi.Router) {
-				r.Get("/", deps.TaskHandler.ListTasks)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.TaskHandler.CreateTask)
-				r.Route("/{taskId}", func(r chi.Router) {
-					r.Get("/", deps.TaskHandler.GetTask)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Patch("/", deps.TaskHandler.UpdateTask)
-					r.Delete("/", deps.TaskHandler.DeleteTask)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/:move", deps.TaskHandler.MoveTask)
+	v1 := deps.v1Handlers()
+	mountVersion(middleware.APIVersionV1, v1, v1Deprecation)
+
+	// Status público da API (sem credencial): componentes e manutenção global, para os clientes
+	// mostrarem aviso de instabilidade
+	if deps.StatusHandler != nil {
+		r.With(
+			middleware.PublicCORSMiddleware(),
+			middleware.APIVersionMiddleware(middleware.APIVersionV1),
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+		).Get("/"+middleware.APIVersionV1+"/status", deps.StatusHandler.GetStatus)
+	}
+
+	// Sessão atual (fora de workspace): perfil resolvido da credencial
+	if deps.SessionHandler != nil {
+		r.With(
+			middleware.APIVersionMiddleware(middleware.APIVersionV1),
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+			auth.AuthMiddleware(deps.Resolver, deps.S2SStore),
+			middleware.ScopeMiddleware,
+			middleware.ImpersonationMiddleware(blockMutations),
+		).Get("/"+middleware.APIVersionV1+"/me", deps.SessionHandler.GetMe)
+	}
+
+	// Formulários públicos (anônimos): o Form cadastrado ou o form token substitui o JWT; sem WorkspaceMiddleware nem
+	// rate limit por workspace (o limite é por formulário, aplicado no service)
+	if deps.PublicFormHandler != nil {
+		r.With(
+			middleware.PublicCORSMiddleware("X-Form-Token"),
+			middleware.APIVersionMiddleware(middleware.APIVersionV1),
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+			middleware.MaintenanceMiddleware(deps.Maintenance),
+			middleware.ShardProbeMiddleware(deps.Shards != nil),
+		).Route("/"+middleware.APIVersionV1+"/public/forms/{formId}/submissions", func(r chi.Router) {
+			r.Post("/", deps.PublicFormHandler.Submit)
+		})
+	}
+
+	// Links curtos (públicos, fora do versionamento para a URL não mudar): registra o clique e redireciona
+	if deps.TrackedLinkHandler != nil {
+		r.With(
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+			middleware.ShardProbeMiddleware(deps.Shards != nil),
+		).Route("/l/{code}", func(r chi.Router) {
+			r.Get("/", deps.TrackedLinkHandler.Redirect)
+			r.Head("/", deps.TrackedLinkHandler.Redirect)
+		})
+	}
+
+	// Orçamentos compartilhados (anônimos): o token do link substitui o JWT
+	if deps.QuoteHandler != nil {
+		r.With(
+			middleware.PublicCORSMiddleware(),
+			middleware.APIVersionMiddleware(middleware.APIVersionV1),
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+			middleware.MaintenanceMiddleware(deps.Maintenance),
+			middleware.ShardProbeMiddleware(deps.Shards != nil),
+		).Route("/"+middleware.APIVersionV1+"/public/quotes/{token}", func(r chi.Router) {
+			r.Get("/", deps.QuoteHandler.ViewSharedQuote)
+			r.Get("/:download", deps.QuoteHandler.DownloadSharedQuote)
+			r.Post("/:accept", deps.QuoteHandler.AcceptSharedQuote)
+		})
+	}
+
+	// Webhook do Stripe: a assinatura (STRIPE_WEBHOOK_SECRET) substitui o JWT; sem segredo a rota não existe
+	if deps.BillingHandler != nil && deps.Cfg.StripeWebhookSecret != "" {
+		r.With(
+			middleware.APIVersionMiddleware(middleware.APIVersionV1),
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+			middleware.MaintenanceMiddleware(deps.Maintenance), // 503: o Stripe reenvia depois
+		).Post("/"+middleware.APIVersionV1+"/webhooks/stripe", deps.BillingHandler.StripeWebhook)
+	}
+
+	// SSO OIDC (anônimo): o id_token do IdP configurado no workspace substitui o JWT e vira uma sessão do Linkko
+	if deps.SsoHandler != nil {
+		r.With(
+			middleware.PublicCORSMiddleware(),
+			middleware.APIVersionMiddleware(middleware.APIVersionV1),
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+		).Post("/"+middleware.APIVersionV1+"/auth/sso/oidc/exchange", deps.SsoHandler.Exchange)
+	}
+
+	// Token de service account (grant client_credentials): client_id + secret substituem o JWT
+	if deps.ServiceAccountHandler != nil {
+		r.With(
+			middleware.APIVersionMiddleware(middleware.APIVersionV1),
+			middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)),
+		).Post("/"+middleware.APIVersionV1+"/auth/token", deps.ServiceAccountHandler.Token)
+	}
+
+	// SCIM 2.0 (Okta, Azure AD): o token SCIM do workspace substitui o JWT e define o workspace
+	if deps.ScimHandler != nil && deps.ScimResolver != nil {
+		sh := deps.ScimHandler
+		r.Route("/scim/v2", func(r chi.Router) {
+			r.Use(middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)))
+			r.Use(middleware.ScimAuthMiddleware(deps.ScimResolver))
+			r.Use(middleware.RateLimitMiddleware(deps.RateLimiter, rateLimitPerMin))
+			r.Get("/ServiceProviderConfig", sh.ServiceProviderConfig)
+			r.Route("/Users", func(r chi.Router) {
+				r.Get("/", sh.ListUsers)
+				r.Post("/", sh.CreateUser)
+				r.Route("/{userId}", func(r chi.Router) {
+					r.Get("/", sh.GetUser)
+					r.Put("/", sh.ReplaceUser)
+					r.Patch("/", sh.PatchUser)
+					r.Delete("/", sh.DeleteUser)
 				})
 			})
-		}
-
-		// Companies
-		if deps.CompanyHandler != nil {
-			r.Route("/companies", func(r chi.Router) {
-				r.Get("/", deps.CompanyHandler.ListCompanies)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.CompanyHandler.CreateCompany)
-				r.Route("/{companyId}", func(r chi.Router) {
-					r.Get("/", deps.CompanyHandler.GetCompany)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Patch("/", deps.CompanyHandler.UpdateCompany)
-					r.Delete("/", deps.CompanyHandler.DeleteCompany)
+			r.Route("/Groups", func(r chi.Router) {
+				r.Get("/", sh.ListGroups)
+				r.Post("/", sh.CreateGroup)
+				r.Route("/{groupId}", func(r chi.Router) {
+					r.Get("/", sh.GetGroup)
+					r.Put("/", sh.ReplaceGroup)
+					r.Patch("/", sh.PatchGroup)
+					r.Delete("/", sh.DeleteGroup)
 				})
 			})
+		})
+	}
+
+	// Organizations (acima dos workspaces): o papel vem de OrganizationMember, checado no service
+	if deps.OrganizationHandler != nil {
+		oh := deps.OrganizationHandler
+		r.Route("/"+middleware.APIVersionV1+"/orgs", func(r chi.Router) {
+			r.Use(middleware.APIVersionMiddleware(middleware.APIVersionV1))
+			r.Use(middleware.TimeoutMiddleware(requestTimeouts(deps.Cfg)))
+			r.Use(auth.AuthMiddleware(deps.Resolver, deps.S2SStore))
+			r.Use(middleware.ScopeMiddleware)
+			r.Use(middleware.ImpersonationMiddleware(blockMutations))
+			r.Use(middleware.MaintenanceMiddleware(deps.Maintenance))
+			r.Get("/", oh.ListOrganizations)
+			r.Route("/{orgId}", func(r chi.Router) {
+				r.Use(middleware.OrgMiddleware)
+				r.Use(middleware.RateLimitMiddleware(deps.RateLimiter, rateLimitPerMin))
+				r.Get("/", oh.GetOrganization)
+				r.Patch("/", oh.UpdateOrganization)
+				r.Get("/billing", oh.GetBilling)
+				r.Get("/workspaces", oh.ListWorkspaces)
+				r.Get("/members", oh.ListMembers)
+				r.Put("/members/{userId}", oh.SetMemberRole)
+				r.Delete("/members/{userId}", oh.RemoveMember)
+				r.Get("/reports/overview", oh.OverviewReport)
+			})
+		})
+	}
+
+	// v2 é opt-in até o contrato ser publicado; sem handlers próprios reaproveita os de v1
+	if deps.Cfg.APIV2Enabled {
+		v2 := v1
+		if deps.V2Handlers != nil {
+			v2 = *deps.V2Handlers
 		}
+		mountVersion(middleware.APIVersionV2, v2, nil)
+	}
+
+	return r
+}
+
+// requestTimeouts converte os deadlines configurados (segundos) em TimeoutConfig.
+func requestTimeouts(cfg *config.Config) middleware.TimeoutConfig {
+	return middleware.TimeoutConfig{
+		Read:   cfg.RequestTimeoutRead,
+		Write:  cfg.RequestTimeoutWrite,
+		Import: cfg.RequestTimeoutImport,
+	}
+}
+
+// HandlerSet agrupa os handlers de negócio montados sob uma versão da API.
+type HandlerSet struct {
+	Contact         *handler.ContactHandler
+	Task            *handler.TaskHandler
+	Company         *handler.CompanyHandler
+	Pipeline        *handler.PipelineHandler
+	Deal            *handler.DealHandler
+	Activity        *handler.ActivityHandler
+	Portfolio       *handler.PortfolioHandler
+	Sandbox         *handler.SandboxHandler
+	Report          *handler.ReportHandler
+	EmailTemplate   *handler.EmailTemplateHandler
+	Sequence        *handler.SequenceHandler
+	TimeEntry       *handler.TimeEntryHandler
+	MyWork          *handler.MyWorkHandler
+	Counter         *handler.CounterHandler
+	Snapshot        *handler.SnapshotHandler
+	FieldHistory    *handler.FieldHistoryHandler
+	Undo            *handler.UndoHandler
+	BusinessHours   *handler.BusinessHoursHandler
+	Enrichment      *handler.CompanyEnrichmentHandler
+	Participant     *handler.DealParticipantHandler
+	Split           *handler.DealSplitHandler
+	IdentityMapping *handler.IdentityMappingHandler
+	SyncPolicy      *handler.SyncPolicyHandler
+	Follower        *handler.FollowerHandler
+	ContactBulk     *handler.ContactBulkHandler
+	Trash           *handler.TrashHandler
+	CustomObject    *handler.CustomObjectHandler
+	ComputedField   *handler.ComputedFieldHandler
+	ReportSchedule  *handler.ReportScheduleHandler
+	Impersonation   *handler.ImpersonationHandler
+	Form            *handler.FormHandler
+	TrackedLink     *handler.TrackedLinkHandler
+	EmailEvent      *handler.EmailEventHandler
+	DocTemplate     *handler.DocumentTemplateHandler
+	Quote           *handler.QuoteHandler
+	Invoice         *handler.InvoiceHandler
+	Scim            *handler.ScimHandler
+	Sso             *handler.SsoHandler
+	ServiceAccount  *handler.ServiceAccountHandler
+	Billing         *handler.BillingHandler
+	PublicForm      *handler.PublicFormHandler
+}
+
+// v1Handlers monta o HandlerSet de v1 a partir dos campos de RouterDeps.
+func (d RouterDeps) v1Handlers() HandlerSet {
+	return HandlerSet{
+		Contact:         d.ContactHandler,
+		Task:            d.TaskHandler,
+		Company:         d.CompanyHandler,
+		Pipeline:        d.PipelineHandler,
+		Deal:            d.DealHandler,
+		Activity:        d.ActivityHandler,
+		Portfolio:       d.PortfolioHandler,
+		Sandbox:         d.SandboxHandler,
+		Report:          d.ReportHandler,
+		EmailTemplate:   d.EmailTemplateHandler,
+		Sequence:        d.SequenceHandler,
+		TimeEntry:       d.TimeEntryHandler,
+		MyWork:          d.MyWorkHandler,
+		Counter:         d.CounterHandler,
+		Snapshot:        d.SnapshotHandler,
+		FieldHistory:    d.FieldHistoryHandler,
+		Undo:            d.UndoHandler,
+		BusinessHours:   d.BusinessHoursHandler,
+		Enrichment:      d.CompanyEnrichmentHandler,
+		Participant:     d.DealParticipantHandler,
+		Split:           d.DealSplitHandler,
+		IdentityMapping: d.IdentityMappingHandler,
+		SyncPolicy:      d.SyncPolicyHandler,
+		Follower:        d.FollowerHandler,
+		ContactBulk:     d.ContactBulkHandler,
+		Trash:           d.TrashHandler,
+		CustomObject:    d.CustomObjectHandler,
+		ComputedField:   d.ComputedFieldHandler,
+		ReportSchedule:  d.ReportScheduleHandler,
+		Impersonation:   d.ImpersonationHandler,
+		Form:            d.FormHandler,
+		TrackedLink:     d.TrackedLinkHandler,
+		EmailEvent:      d.EmailEventHandler,
+		DocTemplate:     d.DocumentTemplateHandler,
+		Quote:           d.QuoteHandler,
+		Invoice:         d.InvoiceHandler,
+		Scim:            d.ScimHandler,
+		Sso:             d.SsoHandler,
+		ServiceAccount:  d.ServiceAccountHandler,
+		Billing:         d.BillingHandler,
+		PublicForm:      d.PublicFormHandler,
+	}
+}
+
+// mountWorkspaceRoutes registra as rotas tenant-scoped de um HandlerSet.
+// Handlers nil não são montados (útil para testes e para versões parciais).
+func mountWorkspaceRoutes(r chi.Router, hs HandlerSet, idempotencyRepo *repo.IdempotencyRepo) {
+	// Contacts
+	if hs.Contact != nil {
+		r.Route("/contacts", func(r chi.Router) {
+			r.Get("/", hs.Contact.ListContacts)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Contact.CreateContact)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:associate-companies", hs.Contact.AssociateCompanies)
+			// Upsert pela chave do integrador (cria ou atualiza)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Put("/by-external-id/{externalId}", hs.Contact.UpsertContactByExternalID)
+			// Atualização em massa assíncrona (job executado pelo bulk-update-worker)
+			if hs.ContactBulk != nil {
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:bulk-update", hs.ContactBulk.BulkUpdateContacts)
+				r.Get("/bulk-updates/{jobId}", hs.ContactBulk.GetBulkUpdateJob)
+			}
+			r.Route("/{contactId}", func(r chi.Router) {
+				r.Get("/", hs.Contact.GetContact)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Contact.UpdateContact)
+				r.Delete("/", hs.Contact.DeleteContact)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:transition-stage", hs.Contact.TransitionLifecycleStage)
+				if hs.FieldHistory != nil {
+					r.Get("/history", hs.FieldHistory.ContactHistory)
+				}
+				if hs.Follower != nil {
+					r.Get("/followers", hs.Follower.ContactFollowers)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:follow", hs.Follower.FollowContact)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:unfollow", hs.Follower.UnfollowContact)
+				}
+			})
+		})
+	}
+
+	// Tasks
+	if hs.Task != nil {
+		r.Route("/tasks", func(r chi.Router) {
+			r.Get("/", hs.Task.ListTasks)
+			r.Get("/board", hs.Task.GetTaskBoard)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Task.CreateTask)
+			r.Route("/{taskId}", func(r chi.Router) {
+				r.Get("/", hs.Task.GetTask)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Task.UpdateTask)
+				r.Delete("/", hs.Task.DeleteTask)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:move", hs.Task.MoveTask)
+				if hs.Follower != nil {
+					r.Get("/followers", hs.Follower.TaskFollowers)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:follow", hs.Follower.FollowTask)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:unfollow", hs.Follower.UnfollowTask)
+				}
+			})
+		})
+	}
+
+	// Companies
+	if hs.Company != nil {
+		r.Route("/companies", func(r chi.Router) {
+			r.Get("/", hs.Company.ListCompanies)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Company.CreateCompany)
+			r.Get("/:duplicates", hs.Company.FindDuplicates)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Put("/by-external-id/{externalId}", hs.Company.UpsertCompanyByExternalID)
+			r.Route("/{companyId}", func(r chi.Router) {
+				r.Get("/", hs.Company.GetCompany)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Company.UpdateCompany)
+				r.Delete("/", hs.Company.DeleteCompany)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:merge", hs.Company.MergeCompanies)
+				if hs.FieldHistory != nil {
+					r.Get("/history", hs.FieldHistory.CompanyHistory)
+				}
+				// Enriquecimento assíncrono (job executado pelo enrichment-worker)
+				if hs.Enrichment != nil {
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:enrich", hs.Enrichment.EnrichCompany)
+					r.Get("/enrichments/{jobId}", hs.Enrichment.GetEnrichmentJob)
+				}
+			})
+		})
+	}
 
-		// Pipelines
-		if deps.PipelineHandler != nil {
-			r.Route("/pipelines", func(r chi.Router) {
-				r.Get("/", deps.PipelineHandler.ListPipelines)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.PipelineHandler.CreatePipeline)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/:create-with-stages", deps.PipelineHandler.CreatePipelineWithStages)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/:seed-default", deps.PipelineHandler.SeedDefaultPipeline)
-				r.Route("/{pipelineId}", func(r chi.Router) {
-					r.Get("/", deps.PipelineHandler.GetPipeline)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Patch("/", deps.PipelineHandler.UpdatePipeline)
-					r.Delete("/", deps.PipelineHandler.DeletePipeline)
-					r.Route("/stages", func(r chi.Router) {
-						r.Get("/", deps.PipelineHandler.ListStages)
-						r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.PipelineHandler.CreateStage)
-						r.Route("/{stageId}", func(r chi.Router) {
-							r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Patch("/", deps.PipelineHandler.UpdateStage)
-							r.Delete("/", deps.PipelineHandler.DeleteStage)
-						})
+	// Pipelines
+	if hs.Pipeline != nil {
+		r.Route("/pipelines", func(r chi.Router) {
+			r.Get("/", hs.Pipeline.ListPipelines)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Pipeline.CreatePipeline)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:create-with-stages", hs.Pipeline.CreatePipelineWithStages)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:seed-default", hs.Pipeline.SeedDefaultPipeline)
+			r.Route("/{pipelineId}", func(r chi.Router) {
+				r.Get("/", hs.Pipeline.GetPipeline)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Pipeline.UpdatePipeline)
+				r.Delete("/", hs.Pipeline.DeletePipeline)
+				r.Route("/stages", func(r chi.Router) {
+					r.Get("/", hs.Pipeline.ListStages)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Pipeline.CreateStage)
+					r.Route("/{stageId}", func(r chi.Router) {
+						r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Pipeline.UpdateStage)
+						r.Delete("/", hs.Pipeline.DeleteStage)
 					})
 				})
 			})
-		}
+		})
+	}
 
-		// Deals
-		if deps.DealHandler != nil {
-			r.Route("/deals", func(r chi.Router) {
-				r.Get("/", deps.DealHandler.ListDeals)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.DealHandler.CreateDeal)
-				r.Route("/{dealId}", func(r chi.Router) {
-					r.Get("/", deps.DealHandler.GetDeal)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Patch("/", deps.DealHandler.UpdateDeal)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/:move", deps.DealHandler.UpdateDealStage)
-				})
+	// Deals
+	if hs.Deal != nil {
+		r.Route("/deals", func(r chi.Router) {
+			r.Get("/", hs.Deal.ListDeals)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Deal.CreateDeal)
+			r.Get("/board", hs.Deal.GetDealBoard)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Put("/board/preferences", hs.Deal.UpdateBoardPreferences)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Put("/by-external-id/{externalId}", hs.Deal.UpsertDealByExternalID)
+			r.Route("/{dealId}", func(r chi.Router) {
+				r.Get("/", hs.Deal.GetDeal)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Deal.UpdateDeal)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:move", hs.Deal.UpdateDealStage)
+				if hs.FieldHistory != nil {
+					r.Get("/history", hs.FieldHistory.DealHistory)
+				}
+				if hs.Follower != nil {
+					r.Get("/followers", hs.Follower.DealFollowers)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:follow", hs.Follower.FollowDeal)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:unfollow", hs.Follower.UnfollowDeal)
+				}
+				if hs.Participant != nil {
+					r.Route("/participants", func(r chi.Router) {
+						r.Get("/", hs.Participant.ListParticipants)
+						r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Participant.AddParticipant)
+						r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/{contactId}", hs.Participant.UpdateParticipant)
+						r.Delete("/{contactId}", hs.Participant.RemoveParticipant)
+					})
+				}
+				if hs.Split != nil {
+					r.Get("/splits", hs.Split.ListSplits)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Put("/splits", hs.Split.ReplaceSplits)
+				}
 			})
-		}
+		})
+	}
 
-		// Timeline
-		if deps.ActivityHandler != nil {
-			r.Route("/timeline", func(r chi.Router) {
-				r.Get("/", deps.ActivityHandler.ListTimeline)
-				r.Route("/notes", func(r chi.Router) {
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.ActivityHandler.CreateNote)
-				})
-				r.Route("/calls", func(r chi.Router) {
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.ActivityHandler.CreateCall)
+	// Timeline
+	if hs.Activity != nil {
+		r.Route("/timeline", func(r chi.Router) {
+			r.Get("/", hs.Activity.ListTimeline)
+			r.Get("/:digest", hs.Activity.TimelineDigest)
+			r.Route("/notes", func(r chi.Router) {
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Activity.CreateNote)
+				r.Route("/{noteId}", func(r chi.Router) {
+					r.Get("/", hs.Activity.GetNote)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Activity.UpdateNote)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:pin", hs.Activity.PinNote)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:unpin", hs.Activity.UnpinNote)
+					r.Post("/attachments", hs.Activity.UploadNoteAttachment)
+					r.Get("/attachments/{attachmentId}", hs.Activity.DownloadNoteAttachment)
 				})
 			})
-		}
+			r.Route("/calls", func(r chi.Router) {
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Activity.CreateCall)
+			})
+			r.Route("/meetings", func(r chi.Router) {
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Activity.CreateMeeting)
+			})
+		})
+	}
+
+	// Portfolio
+	if hs.Portfolio != nil {
+		r.Route("/portfolio", func(r chi.Router) {
+			r.Get("/", hs.Portfolio.ListPortfolioItems)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Portfolio.CreatePortfolioItem)
+			r.Route("/{itemID}", func(r chi.Router) {
+				r.Get("/", hs.Portfolio.GetPortfolioItem)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Portfolio.UpdatePortfolioItem)
+				r.Delete("/", hs.Portfolio.DeletePortfolioItem)
+			})
+		})
+	}
 
-		// Portfolio
-		if deps.PortfolioHandler != nil {
-			r.Route("/portfolio", func(r chi.Router) {
-				r.Get("/", deps.PortfolioHandler.ListPortfolioItems)
-				r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Post("/", deps.PortfolioHandler.CreatePortfolioItem)
-				r.Route("/{itemID}", func(r chi.Router) {
-					r.Get("/", deps.PortfolioHandler.GetPortfolioItem)
-					r.With(middleware.IdempotencyMiddleware(deps.IdempotencyRepo)).Patch("/", deps.PortfolioHandler.UpdatePortfolioItem)
-					r.Delete("/", deps.PortfolioHandler.DeletePortfolioItem)
+	// Reports
+	if hs.Contact != nil || hs.Report != nil {
+		r.Route("/reports", func(r chi.Router) {
+			if hs.Contact != nil {
+				r.Get("/lifecycle", hs.Contact.LifecycleReport)
+			}
+			if hs.Report != nil {
+				r.Get("/attribution", hs.Report.AttributionReport)
+				r.Get("/overdue", hs.Report.OverdueReport)
+				r.Get("/time", hs.Report.TimeReport)
+				r.Get("/sla-breaches", hs.Report.SLABreachReport)
+				r.Get("/pipeline-summary", hs.Report.PipelineSummaryReport)
+				r.Get("/revenue", hs.Report.RevenueReport)
+				r.Get("/forecast", hs.Report.ForecastReport)
+			}
+		})
+	}
+
+	// Email Templates
+	if hs.EmailTemplate != nil {
+		r.Route("/email-templates", func(r chi.Router) {
+			r.Get("/", hs.EmailTemplate.ListEmailTemplates)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.EmailTemplate.CreateEmailTemplate)
+			r.Route("/{templateId}", func(r chi.Router) {
+				r.Get("/", hs.EmailTemplate.GetEmailTemplate)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.EmailTemplate.UpdateEmailTemplate)
+				r.Delete("/", hs.EmailTemplate.DeleteEmailTemplate)
+				r.Post("/:render", hs.EmailTemplate.RenderEmailTemplate)
+			})
+		})
+	}
+
+	// Sequences
+	if hs.Sequence != nil {
+		r.Route("/sequences", func(r chi.Router) {
+			r.Get("/", hs.Sequence.ListSequences)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Sequence.CreateSequence)
+			r.Route("/{sequenceId}", func(r chi.Router) {
+				r.Get("/", hs.Sequence.GetSequence)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Sequence.UpdateSequence)
+				r.Delete("/", hs.Sequence.DeleteSequence)
+				r.Route("/enrollments", func(r chi.Router) {
+					r.Get("/", hs.Sequence.ListEnrollments)
+					r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Sequence.EnrollContact)
+					r.Route("/{enrollmentId}", func(r chi.Router) {
+						r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:pause", hs.Sequence.PauseEnrollment)
+						r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:resume", hs.Sequence.ResumeEnrollment)
+						r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:unenroll", hs.Sequence.UnenrollContact)
+					})
 				})
 			})
-		}
-	})
+		})
+	}
 
-	return r
+	// Time Entries
+	if hs.TimeEntry != nil {
+		r.Route("/time-entries", func(r chi.Router) {
+			r.Get("/", hs.TimeEntry.ListTimeEntries)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.TimeEntry.CreateTimeEntry)
+			r.Route("/{timeEntryId}", func(r chi.Router) {
+				r.Get("/", hs.TimeEntry.GetTimeEntry)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.TimeEntry.UpdateTimeEntry)
+				r.Delete("/", hs.TimeEntry.DeleteTimeEntry)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:stop", hs.TimeEntry.StopTimeEntry)
+			})
+		})
+	}
+
+	// My Work (escopo do ator)
+	if hs.MyWork != nil {
+		r.Get("/me/work", hs.MyWork.GetMyWork)
+	}
+
+	// Notifications (caixa de entrada do ator: eventos das entidades que ele segue)
+	if hs.Follower != nil {
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", hs.Follower.ListNotifications)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:mark-read", hs.Follower.MarkNotificationsRead)
+		})
+		r.Get("/me/notification-preferences", hs.Follower.GetNotificationPreferences)
+		r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/me/notification-preferences", hs.Follower.UpdateNotificationPreferences)
+	}
+
+	// Counters (cabeçalho do dashboard, cache Redis)
+	if hs.Counter != nil {
+		r.Get("/counters", hs.Counter.GetCounters)
+	}
+
+	// Snapshots (admin-only, jobs assíncronos executados pelo snapshot-worker)
+	if hs.Snapshot != nil {
+		r.Route("/snapshots", func(r chi.Router) {
+			r.Get("/", hs.Snapshot.ListSnapshots)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Snapshot.CreateSnapshot)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:restore", hs.Snapshot.RestoreSnapshot)
+			r.Route("/{snapshotId}", func(r chi.Router) {
+				r.Get("/", hs.Snapshot.GetSnapshot)
+				r.Get("/:download", hs.Snapshot.DownloadSnapshot)
+			})
+		})
+	}
+
+	// Business calendar (expediente e feriados consumidos por SLA, sequências e deal rotting)
+	if hs.BusinessHours != nil {
+		r.Route("/business-hours", func(r chi.Router) {
+			r.Get("/", hs.BusinessHours.GetBusinessHours)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Put("/", hs.BusinessHours.SetBusinessHours)
+			r.Delete("/", hs.BusinessHours.DeleteBusinessHours)
+		})
+		r.Route("/holidays", func(r chi.Router) {
+			r.Get("/", hs.BusinessHours.ListHolidays)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.BusinessHours.CreateHoliday)
+			r.Route("/{holidayId}", func(r chi.Router) {
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.BusinessHours.UpdateHoliday)
+				r.Delete("/", hs.BusinessHours.DeleteHoliday)
+			})
+		})
+	}
+
+	// Identity mappings (ids dos registros em vários sistemas externos, registrados e resolvidos em lote)
+	if hs.IdentityMapping != nil {
+		r.Route("/identity-mappings", func(r chi.Router) {
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:register", hs.IdentityMapping.RegisterMappings)
+			r.Post("/:resolve", hs.IdentityMapping.ResolveMappings)
+			r.Delete("/{mappingId}", hs.IdentityMapping.DeleteMapping)
+		})
+	}
+
+	// Sync policies (resolução de conflitos por integração nos upserts por externalId) e log de conflitos
+	if hs.SyncPolicy != nil {
+		r.Route("/sync-policies", func(r chi.Router) {
+			r.Get("/", hs.SyncPolicy.ListPolicies)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Put("/{system}", hs.SyncPolicy.PutPolicy)
+			r.Delete("/{system}", hs.SyncPolicy.DeletePolicy)
+		})
+		r.Get("/sync-conflicts", hs.SyncPolicy.ListConflicts)
+	}
+
+	// Undo (restaura o registro excluído a partir do undoToken do DELETE)
+	if hs.Undo != nil {
+		r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:undo", hs.Undo.Undo)
+	}
+
+	// Lixeira (registros excluídos de todas as entidades, até a purga)
+	if hs.Trash != nil {
+		r.Get("/trash", hs.Trash.ListTrash)
+		r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/trash/{entityType}/{entityId}/:restore", hs.Trash.RestoreTrashItem)
+	}
+
+	// Objetos customizados (definições em /object-types, registros em /objects/{objectKey})
+	if hs.CustomObject != nil {
+		r.Route("/object-types", func(r chi.Router) {
+			r.Get("/", hs.CustomObject.ListObjectTypes)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.CustomObject.CreateObjectType)
+			r.Route("/{objectKey}", func(r chi.Router) {
+				r.Get("/", hs.CustomObject.GetObjectType)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.CustomObject.UpdateObjectType)
+				r.Delete("/", hs.CustomObject.DeleteObjectType)
+			})
+		})
+		r.Route("/objects/{objectKey}", func(r chi.Router) {
+			r.Get("/", hs.CustomObject.ListRecords)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.CustomObject.CreateRecord)
+			r.Route("/{recordId}", func(r chi.Router) {
+				r.Get("/", hs.CustomObject.GetRecord)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.CustomObject.UpdateRecord)
+				r.Delete("/", hs.CustomObject.DeleteRecord)
+			})
+		})
+	}
+
+	// Campos calculados de empresas e negócios (avaliados na leitura, em computed.{key})
+	if hs.ComputedField != nil {
+		r.Route("/computed-fields", func(r chi.Router) {
+			r.Get("/", hs.ComputedField.ListComputedFields)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.ComputedField.CreateComputedField)
+			r.Route("/{fieldId}", func(r chi.Router) {
+				r.Get("/", hs.ComputedField.GetComputedField)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.ComputedField.UpdateComputedField)
+				r.Delete("/", hs.ComputedField.DeleteComputedField)
+			})
+		})
+	}
+
+	// Relatórios agendados (executados pelo report-schedule-worker)
+	if hs.ReportSchedule != nil {
+		r.Route("/report-schedules", func(r chi.Router) {
+			r.Get("/", hs.ReportSchedule.ListReportSchedules)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.ReportSchedule.CreateReportSchedule)
+			r.Route("/{scheduleId}", func(r chi.Router) {
+				r.Get("/", hs.ReportSchedule.GetReportSchedule)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.ReportSchedule.UpdateReportSchedule)
+				r.Delete("/", hs.ReportSchedule.DeleteReportSchedule)
+			})
+		})
+	}
+
+	// Impersonation (admin-only): token para o suporte agir como um usuário do workspace
+	if hs.Impersonation != nil {
+		r.Post("/impersonation", hs.Impersonation.StartImpersonation)
+	}
+
+	// Formulários de captura de leads (submissões em /v1/public/forms/{formId}/submissions)
+	if hs.Form != nil {
+		r.Route("/forms", func(r chi.Router) {
+			r.Get("/", hs.Form.ListForms)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Form.CreateForm)
+			r.Route("/{formId}", func(r chi.Router) {
+				r.Get("/", hs.Form.GetForm)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.Form.UpdateForm)
+				r.Delete("/", hs.Form.DeleteForm)
+			})
+		})
+	}
+
+	// Links rastreados (URL curta pública em /l/{code})
+	if hs.TrackedLink != nil {
+		r.Route("/links", func(r chi.Router) {
+			r.Get("/", hs.TrackedLink.ListLinks)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.TrackedLink.CreateLink)
+			r.Route("/{linkId}", func(r chi.Router) {
+				r.Get("/", hs.TrackedLink.GetLink)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.TrackedLink.UpdateLink)
+				r.Delete("/", hs.TrackedLink.DeleteLink)
+				r.Get("/clicks", hs.TrackedLink.ListClicks)
+				r.Get("/contact-url", hs.TrackedLink.ContactURL)
+			})
+		})
+	}
+
+	// Templates de documentos e orçamentos (PDF gerado pelo quote-worker)
+	if hs.DocTemplate != nil {
+		r.Route("/document-templates", func(r chi.Router) {
+			r.Get("/", hs.DocTemplate.ListTemplates)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.DocTemplate.CreateTemplate)
+			r.Route("/{templateId}", func(r chi.Router) {
+				r.Get("/", hs.DocTemplate.GetTemplate)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Patch("/", hs.DocTemplate.UpdateTemplate)
+				r.Delete("/", hs.DocTemplate.DeleteTemplate)
+			})
+		})
+	}
+	if hs.Quote != nil {
+		r.Route("/quotes", func(r chi.Router) {
+			r.Get("/", hs.Quote.ListQuotes)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Quote.CreateQuote)
+			r.Route("/{quoteId}", func(r chi.Router) {
+				r.Get("/", hs.Quote.GetQuote)
+				r.Delete("/", hs.Quote.DeleteQuote)
+				r.Get("/:download", hs.Quote.DownloadQuote)
+				r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:send", hs.Quote.SendQuote)
+			})
+		})
+	}
+
+	// Faturas dos negócios ganhos (:reconcile é S2S do provedor de pagamento)
+	if hs.Invoice != nil {
+		r.Route("/invoices", func(r chi.Router) {
+			r.Get("/", hs.Invoice.ListInvoices)
+			r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/", hs.Invoice.CreateInvoice)
+			r.Post("/:reconcile", hs.Invoice.ReconcileInvoices)
+			r.Route("/{invoiceId}", func(r chi.Router) {
+				r.Get("/", hs.Invoice.GetInvoice)
+				r.Patch("/", hs.Invoice.UpdateInvoice)
+				r.Delete("/", hs.Invoice.DeleteInvoice)
+			})
+		})
+	}
+
+	// Provisionamento SCIM (admin): token do diretório e mapeamento grupo -> papel
+	if hs.Scim != nil {
+		r.Route("/scim", func(r chi.Router) {
+			r.Get("/", hs.Scim.GetConfig)
+			r.Post("/token", hs.Scim.RotateToken)
+			r.Delete("/token", hs.Scim.RevokeToken)
+			r.Get("/groups", hs.Scim.ListGroupRoles)
+			r.Put("/groups/{groupId}/role", hs.Scim.SetGroupRole)
+		})
+	}
+
+	// SSO (admin): IdPs OIDC cujos id_tokens são trocados por sessões em /v1/auth/sso/oidc/exchange
+	if hs.Sso != nil {
+		r.Route("/sso/providers", func(r chi.Router) {
+			r.Get("/", hs.Sso.ListProviders)
+			r.Post("/", hs.Sso.CreateProvider)
+			r.Route("/{providerId}", func(r chi.Router) {
+				r.Get("/", hs.Sso.GetProvider)
+				r.Patch("/", hs.Sso.UpdateProvider)
+				r.Delete("/", hs.Sso.DeleteProvider)
+			})
+		})
+	}
+
+	// Service accounts (admin): credenciais de integrações, trocadas por tokens com escopo em /v1/auth/token
+	if hs.ServiceAccount != nil {
+		r.Route("/service-accounts", func(r chi.Router) {
+			r.Get("/", hs.ServiceAccount.List)
+			r.Post("/", hs.ServiceAccount.Create)
+			r.Route("/{serviceAccountId}", func(r chi.Router) {
+				r.Get("/", hs.ServiceAccount.Get)
+				r.Patch("/", hs.ServiceAccount.Update)
+				r.Delete("/", hs.ServiceAccount.Delete)
+				r.Post("/secret", hs.ServiceAccount.RotateSecret)
+			})
+		})
+	}
+
+	// Engajamento de email (S2S do provedor de envio; reenvios deduplicados por providerEventId)
+	if hs.EmailEvent != nil {
+		r.Post("/email-events", hs.EmailEvent.IngestEvents)
+	}
+
+	// Billing: plano, assinatura, quotas e consumo do workspace
+	if hs.Billing != nil {
+		r.Get("/billing", hs.Billing.GetBilling)
+	}
+
+	// Public form tokens (admin/manager): credencial de formulários embutidos em sites
+	if hs.PublicForm != nil {
+		r.Post("/public-form-tokens", hs.PublicForm.IssueToken)
+	}
+
+	// Sandbox (admin-only)
+	if hs.Sandbox != nil {
+		r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:clone-to-sandbox", hs.Sandbox.CloneWorkspace)
+		r.Get("/promotion-preview", hs.Sandbox.PreviewPromotion)
+		r.With(middleware.IdempotencyMiddleware(idempotencyRepo)).Post("/:promote", hs.Sandbox.Promote)
+	}
+}
+
+// adminTokenMiddleware exige Authorization: Bearer <ADMIN_TOKEN> (comparação em tempo constante).
+func adminTokenMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+				httperr.Unauthorized401(w, r.Context(), httperr.ErrCodeInvalidToken, "invalid or missing admin token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 // metricsMiddleware protege o endpoint de métricas com um token opcional.
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var sequenceWorkerCmd = &cobra.Command{
+	Use:   "sequence-worker",
+	Short: "Run due sequence steps",
+	Long:  `Poll active sequence enrollments and materialize due steps as tasks, pausing or unenrolling contacts when a deal is won or the contact replies`,
+	RunE:  runSequenceWorker,
+}
+
+var sequenceWorkerOnce bool
+
+func init() {
+	sequenceWorkerCmd.Flags().BoolVar(&sequenceWorkerOnce, "once", false, "process a single batch and exit")
+	rootCmd.AddCommand(sequenceWorkerCmd)
+}
+
+func runSequenceWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	// Initialize service
+	sequenceService := service.NewSequenceService(
+		repo.NewSequenceRepository(pool),
+		repo.NewEmailTemplateRepository(pool),
+		repo.NewContactRepository(pool),
+		repo.NewDealRepository(pool),
+		repo.NewTaskRepository(pool),
+		repo.NewBusinessHoursRepository(pool),
+		repo.NewWorkspaceRepository(pool),
+		repo.NewAuditRepo(pool),
+		log,
+	)
+
+	interval := cfg.SequenceWorkerInterval
+	log.Info(ctx, "starting sequence worker",
+		zap.Duration("interval", interval),
+		zap.Int("batch_size", cfg.SequenceWorkerBatchSize),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := sequenceService.ProcessDueEnrollments(ctx, time.Now().UTC(), cfg.SequenceWorkerBatchSize)
+		if err != nil {
+			log.Error(ctx, "sequence worker batch failed", zap.Error(err))
+		} else if processed > 0 {
+			log.Info(ctx, "sequence worker batch completed", zap.Int("processed", processed))
+		}
+
+		if sequenceWorkerOnce {
+			return nil
+		}
+
+		// Lote cheio: provavelmente há mais itens vencidos, processa de novo sem esperar
+		if err == nil && processed == cfg.SequenceWorkerBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "sequence worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -7,19 +7,33 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
 	"linkko-api/internal/auth"
 	"linkko-api/internal/config"
+	"linkko-api/internal/counters"
 	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/client"
 	"linkko-api/internal/http/handler"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/http/middleware"
+	"linkko-api/internal/integrations/antivirus"
+	"linkko-api/internal/integrations/captcha"
+	"linkko-api/internal/integrations/enrichment"
+	"linkko-api/internal/integrations/errortracker"
+	"linkko-api/internal/integrations/mailer"
+	"linkko-api/internal/objectstore"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/ratelimit"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/resilience"
 	"linkko-api/internal/service"
 	"linkko-api/internal/telemetry"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel/metric"
@@ -49,7 +63,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize logger
-	log, err := logger.New(cfg.OTELServiceName, "info")
+	log, err := logger.New(cfg.OTELServiceName, cfg.LogLevel)
 	if err != nil {
 		return fmt.Errorf("failed to create logger: %w", err)
 	}
@@ -57,11 +71,18 @@ func runServe(cmd *cobra.Command, args []string) error {
 	log.Info(context.Background(), "starting linkko api",
 		zap.String("version", "1.0.0"),
 		zap.String("service", cfg.OTELServiceName),
+		zap.String("region", cfg.Region),
 	)
+	log.Info(ctx, "effective configuration",
+		zap.String("config_file", cfg.ConfigFile),
+		zap.Any("config", cfg.Effective()),
+	)
+
+	httperr.ExposeErrorID = cfg.AppEnv == "dev"
 
 	// Run database migrations
 	log.Info(ctx, "running database migrations")
-	if err := database.RunMigrations(); err != nil {
+	if err := database.RunMigrations(cfg.DatabaseURL); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 	log.Info(ctx, "migrations completed successfully")
@@ -75,7 +96,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 		log.Info(ctx, "initializing telemetry", zap.String("endpoint", cfg.OTELExporterEndpoint))
 
 		// Initialize tracer
-		tp, err := telemetry.InitTracer(ctx, cfg.OTELServiceName, cfg.OTELExporterEndpoint, cfg.OTELSamplingRatio)
+		tp, err := telemetry.InitTracer(ctx, cfg.OTELServiceName, cfg.Region, cfg.OTELExporterEndpoint, cfg.OTELSamplingRatio)
 		if err != nil {
 			log.Warn(ctx, "failed to initialize tracer, continuing without tracing", zap.Error(err))
 		} else {
@@ -90,7 +111,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 		}
 
 		// Initialize metrics
-		mp, m, err := telemetry.InitMetrics(ctx, cfg.OTELServiceName, cfg.OTELExporterEndpoint)
+		mp, m, err := telemetry.InitMetrics(ctx, cfg.OTELServiceName, cfg.Region, cfg.OTELExporterEndpoint, cfg.OTELMetricsExemplars)
 		if err != nil {
 			log.Warn(ctx, "failed to initialize metrics, continuing without metrics", zap.Error(err))
 		} else {
@@ -119,6 +140,43 @@ func runServe(cmd *cobra.Command, args []string) error {
 	defer pool.Close()
 	log.Info(ctx, "database connected")
 
+	// Pool separado para a lane batch: imports/syncs não disputam conexões com o tráfego interativo
+	var batchPool *pgxpool.Pool
+	if cfg.DBBatchPoolMaxConns > 0 {
+		batchPool, err = database.NewPoolWithMaxConns(ctx, cfg.DatabaseURL, int32(cfg.DBBatchPoolMaxConns))
+		if err != nil {
+			return fmt.Errorf("failed to connect batch database pool: %w", err)
+		}
+		defer batchPool.Close()
+		log.Info(ctx, "batch database pool connected", zap.Int("max_conns", cfg.DBBatchPoolMaxConns))
+	}
+	db := database.NewLanePool(pool, batchPool)
+
+	// Shards de residência de dados (DATABASE_SHARDS): os repositórios de dados do workspace usam
+	// dataDB, que escolhe o banco pelo shard resolvido no ShardMiddleware; o plano de controle
+	// (workspaces, membros, billing, SSO/SCIM) fica sempre no banco padrão
+	shardDBs := map[string]database.DB{}
+	for name, url := range cfg.GetDatabaseShards() {
+		shardPool, err := database.NewPool(ctx, url)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database shard %s: %w", name, err)
+		}
+		defer shardPool.Close()
+		var shardBatchPool *pgxpool.Pool
+		if cfg.DBBatchPoolMaxConns > 0 {
+			shardBatchPool, err = database.NewPoolWithMaxConns(ctx, url, int32(cfg.DBBatchPoolMaxConns))
+			if err != nil {
+				return fmt.Errorf("failed to connect batch pool of database shard %s: %w", name, err)
+			}
+			defer shardBatchPool.Close()
+		}
+		shardDBs[name] = database.NewLanePool(shardPool, shardBatchPool)
+		log.Info(ctx, "database shard connected", zap.String("shard", name))
+	}
+	shardRouter := database.NewShardRouter(db, shardDBs)
+	// TxManager: exports e relatórios com várias consultas leem um único snapshot (ReadSnapshot)
+	dataDB := database.NewTxManager(shardRouter)
+
 	// Connect to Redis
 	log.Info(ctx, "connecting to redis")
 	redisOpts, err := redis.ParseURL(cfg.RedisURL)
@@ -151,17 +209,19 @@ func runServe(cmd *cobra.Command, args []string) error {
 		zap.Int("decoded_bytes", len(secretBytes)),
 	)
 
-	// Parse allowed issuers from CSV
-	allowedIssuers := cfg.GetAllowedIssuers()
+	// Issuers aceitos (JWT_ALLOWED_ISSUERS + JWT_ISSUERS), cada um com audiência e clock skew próprios
+	jwtIssuers, err := cfg.GetJWTIssuers()
+	if err != nil {
+		return err
+	}
+	allowedIssuers := make([]string, 0, len(jwtIssuers)+1)
+	for _, issuer := range jwtIssuers {
+		allowedIssuers = append(allowedIssuers, issuer.Issuer)
+	}
 	if len(allowedIssuers) == 0 {
 		return fmt.Errorf("JWT_ALLOWED_ISSUERS must contain at least one valid issuer")
 	}
 
-	// Load HS256 key for all allowed issuers (same secret for all)
-	for _, issuer := range allowedIssuers {
-		keyStore.LoadHS256Key(issuer, "v1", secretBytes)
-	}
-
 	// Load RS256 key for MCP server (if configured)
 	if cfg.JWTPublicKeyMCPV1 != "" {
 		if err := keyStore.LoadRS256Key("linkko-mcp-server", "v1", cfg.JWTPublicKeyMCPV1); err != nil {
@@ -169,39 +229,104 @@ func runServe(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Create validators with clock skew
-	clockSkew := time.Duration(cfg.JWTClockSkewSeconds) * time.Second
-
-	// Create resolver with allowed issuers
-	resolver := auth.NewKeyResolver(allowedIssuers, []string{cfg.JWTAudience})
+	// Create resolver; JWT_AUDIENCE is the default audience
+	resolver := auth.NewKeyResolver(nil, []string{cfg.JWTAudience})
+
+	// Register one validator per issuer: HS256 (same secret for all) unless JWT_ISSUERS sets a public key
+	for _, issuer := range jwtIssuers {
+		clockSkew := time.Duration(issuer.ClockSkewSeconds) * time.Second
+		// Opaque: sem chave local, tokens validados no endpoint de introspecção do parceiro
+		if issuer.Algorithm == config.JWTAlgorithmOpaque {
+			introspector := auth.NewIntrospectionValidator(
+				issuer.Issuer,
+				issuer.IntrospectionURL,
+				issuer.ClientID,
+				issuer.ClientSecret,
+				time.Duration(issuer.CacheSeconds)*time.Second,
+				clockSkew,
+				client.NewCustomHTTPClient(5*time.Second),
+			)
+			resolver.RegisterOpaqueIssuer(issuer.Issuer, introspector, issuer.Audiences, issuer.TokenPrefix)
+			log.Info(ctx, "JWT issuer registered",
+				zap.String("issuer", issuer.Issuer),
+				zap.String("algorithm", issuer.Algorithm),
+				zap.Strings("audiences", issuer.Audiences),
+				zap.String("token_prefix", issuer.TokenPrefix),
+				zap.Int("cache_seconds", issuer.CacheSeconds),
+			)
+			continue
+		}
 
-	// Register HS256 validator for all allowed issuers
-	for _, issuer := range allowedIssuers {
-		hs256Validator := auth.NewHS256Validator(keyStore, issuer, clockSkew)
-		resolver.RegisterValidator(issuer, hs256Validator)
+		var validator auth.TokenValidator
+		switch issuer.Algorithm {
+		case config.JWTAlgorithmRS256:
+			err = keyStore.LoadRS256Key(issuer.Issuer, issuer.KeyID, issuer.PublicKey)
+			validator = auth.NewRS256Validator(keyStore, issuer.Issuer, clockSkew)
+		case config.JWTAlgorithmES256:
+			err = keyStore.LoadES256Key(issuer.Issuer, issuer.KeyID, issuer.PublicKey)
+			validator = auth.NewES256Validator(keyStore, issuer.Issuer, clockSkew)
+		case config.JWTAlgorithmEdDSA:
+			err = keyStore.LoadEdDSAKey(issuer.Issuer, issuer.KeyID, issuer.PublicKey)
+			validator = auth.NewEdDSAValidator(keyStore, issuer.Issuer, clockSkew)
+		default:
+			keyStore.LoadHS256Key(issuer.Issuer, issuer.KeyID, secretBytes)
+			validator = auth.NewHS256Validator(keyStore, issuer.Issuer, clockSkew)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load %s public key for issuer %s: %w", issuer.Algorithm, issuer.Issuer, err)
+		}
+		resolver.RegisterIssuer(issuer.Issuer, validator, issuer.Audiences)
+		log.Info(ctx, "JWT issuer registered",
+			zap.String("issuer", issuer.Issuer),
+			zap.String("algorithm", issuer.Algorithm),
+			zap.Strings("audiences", issuer.Audiences),
+			zap.Int("clock_skew_seconds", issuer.ClockSkewSeconds),
+		)
 	}
 
-	// Register RS256 validator if configured
+	// Register RS256 validator if configured (legacy; JWT_ISSUERS com algorithm tem precedência)
 	if cfg.JWTPublicKeyMCPV1 != "" {
-		rs256Validator := auth.NewRS256Validator(keyStore, "linkko-mcp-server", clockSkew)
-		resolver.RegisterValidator("linkko-mcp-server", rs256Validator)
+		mcpIssuer, err := cfg.GetJWTIssuer("linkko-mcp-server")
+		if err != nil {
+			return err
+		}
+		if mcpIssuer.Algorithm != config.JWTAlgorithmHS256 {
+			return fmt.Errorf("JWT_PUBLIC_KEY_MCP_V1 conflicts with JWT_ISSUERS[%s].algorithm", mcpIssuer.Issuer)
+		}
+		clockSkew := time.Duration(mcpIssuer.ClockSkewSeconds) * time.Second
+		resolver.RegisterIssuer(mcpIssuer.Issuer, auth.NewRS256Validator(keyStore, mcpIssuer.Issuer, clockSkew), mcpIssuer.Audiences)
 		// Add MCP issuer to allowed list if not already present
-		mcpIssuer := "linkko-mcp-server"
 		hasRs256Issuer := false
 		for _, issuer := range allowedIssuers {
-			if issuer == mcpIssuer {
+			if issuer == mcpIssuer.Issuer {
 				hasRs256Issuer = true
 				break
 			}
 		}
 		if !hasRs256Issuer {
-			allowedIssuers = append(allowedIssuers, mcpIssuer)
+			allowedIssuers = append(allowedIssuers, mcpIssuer.Issuer)
+		}
+	}
+
+	// Cache de decisões: clientes S2S de alto RPS reutilizam o mesmo JWT
+	if cfg.AuthCacheTTL > 0 || cfg.AuthNegativeCacheTTL > 0 {
+		var authCacheLookups metric.Int64Counter
+		if metrics != nil {
+			authCacheLookups = metrics.AuthCacheLookups
 		}
+		resolver.SetDecisionCache(auth.NewDecisionCache(auth.DecisionCacheConfig{
+			TTL:                cfg.AuthCacheTTL,
+			NegativeTTL:        cfg.AuthNegativeCacheTTL,
+			MaxEntries:         cfg.AuthCacheMaxEntries,
+			MaxNegativeEntries: cfg.AuthNegativeCacheMaxEntries,
+			Lookups:            authCacheLookups,
+		}))
 	}
 
 	log.Info(ctx, "JWT authentication initialized",
 		zap.Strings("allowed_issuers", allowedIssuers),
-		zap.Int("clock_skew_seconds", cfg.JWTClockSkewSeconds),
+		zap.Duration("clock_skew", cfg.JWTClockSkew),
+		zap.Duration("auth_cache_ttl", cfg.AuthCacheTTL),
 	)
 
 	// Initialize S2S token store
@@ -215,27 +340,182 @@ func runServe(cmd *cobra.Command, args []string) error {
 		log.Info(ctx, "S2S token registered", zap.String("client", "mcp"))
 	}
 
-	// Initialize repositories
-	idempotencyRepo := repo.NewIdempotencyRepo(pool)
-	workspaceRepo := repo.NewWorkspaceRepository(pool)
-	auditRepo := repo.NewAuditRepo(pool)
-	contactRepo := repo.NewContactRepository(pool)
-	taskRepo := repo.NewTaskRepository(pool)
-	companyRepo := repo.NewCompanyRepository(pool)
-	pipelineRepo := repo.NewPipelineRepository(pool)
-	dealRepo := repo.NewDealRepository(pool)
-	activityRepo := repo.NewActivityRepository(pool)
-	portfolioRepo := repo.NewPortfolioRepository(pool)
+	// Circuit breakers: um por dependência, compartilhados entre os clientes dela
+	var breakerTransitions metric.Int64Counter
+	var breakerOpen metric.Int64UpDownCounter
+	if metrics != nil {
+		breakerTransitions = metrics.CircuitBreakerTransitions
+		breakerOpen = metrics.CircuitBreakerOpen
+	}
+	newBreaker := func(name string) *resilience.Breaker {
+		return resilience.NewBreaker(resilience.BreakerConfig{
+			Name:             name,
+			FailureThreshold: cfg.CircuitBreakerFailureThreshold,
+			OpenTimeout:      cfg.CircuitBreakerOpen,
+			Transitions:      breakerTransitions,
+			Open:             breakerOpen,
+		})
+	}
+	redisBreaker := newBreaker("redis")
+	postgresBreaker := newBreaker("postgres")
+
+	// Object storage dos snapshots (exportações são geradas pelo snapshot-worker)
+	snapshotStore, err := objectstore.Open(cfg.SnapshotStorageURL)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot storage: %w", err)
+	}
+
+	// Object storage dos anexos de notas (e dos PDFs de orçamentos, gerados pelo quote-worker)
+	attachmentStore, err := objectstore.Open(cfg.NoteAttachmentStorageURL)
+	if err != nil {
+		return fmt.Errorf("failed to open note attachment storage: %w", err)
+	}
+	// Antivírus dos anexos enviados (nil com ATTACHMENT_SCAN_PROVIDER=none)
+	attachmentScanner, err := antivirus.Open(cfg.AttachmentScanProvider, cfg.AttachmentScanAddress, cfg.AttachmentScanTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to open attachment scanner: %w", err)
+	}
+
+	// Emails de notificação (preferência "email" dos seguidores)
+	notificationMailer, err := mailer.Open(mailer.Config{
+		Provider: cfg.MailProvider,
+		From:     cfg.MailFrom,
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open mailer: %w", err)
+	}
+
+	// Rastreador de erros: recebe os panics recuperados pelo RecoveryMiddleware
+	serverName := cfg.Region
+	if serverName == "" {
+		serverName, _ = os.Hostname()
+	}
+	errorTracker, err := errortracker.Open(errortracker.Config{
+		DSN:         cfg.ErrorTrackerDSN,
+		Environment: cfg.AppEnv,
+		ServerName:  serverName,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to open error tracker: %w", err)
+	}
+
+	// Provedor de enriquecimento de empresas (consultado pelo enrichment-worker)
+	enrichmentProvider, err := enrichment.Open(cfg.EnrichmentProvider)
+	if err != nil {
+		return fmt.Errorf("failed to open enrichment provider: %w", err)
+	}
+
+	// Initialize repositories (dados do workspace em dataDB, plano de controle em db)
+	// Idempotência por região: o banco pode ser compartilhado entre deploys regionais
+	idempotencyRepo := repo.NewIdempotencyRepo(dataDB, postgresBreaker).WithRegion(cfg.Region)
+	workspaceRepo := repo.NewWorkspaceRepository(db)
+	userRepo := repo.NewUserRepository(db)
+	auditRepo := repo.NewAuditRepo(dataDB)
+	contactRepo := repo.NewContactRepository(dataDB)
+	taskRepo := repo.NewTaskRepository(dataDB)
+	companyRepo := repo.NewCompanyRepository(dataDB)
+	pipelineRepo := repo.NewPipelineRepository(dataDB)
+	dealRepo := repo.NewDealRepository(dataDB)
+	activityRepo := repo.NewActivityRepository(dataDB)
+	portfolioRepo := repo.NewPortfolioRepository(dataDB)
+	reportRepo := repo.NewReportRepository(dataDB)
+	emailTemplateRepo := repo.NewEmailTemplateRepository(dataDB)
+	sequenceRepo := repo.NewSequenceRepository(dataDB)
+	timeEntryRepo := repo.NewTimeEntryRepository(dataDB)
+	myWorkRepo := repo.NewMyWorkRepository(dataDB)
+	counterRepo := repo.NewCounterRepository(dataDB)
+	snapshotRepo := repo.NewSnapshotRepository(dataDB)
+	fieldHistoryRepo := repo.NewFieldHistoryRepository(dataDB)
+	undoRepo := repo.NewUndoRepository(dataDB)
+	businessHoursRepo := repo.NewBusinessHoursRepository(dataDB)
+	companyEnrichmentRepo := repo.NewCompanyEnrichmentRepository(dataDB)
+	dealParticipantRepo := repo.NewDealParticipantRepository(dataDB)
+	dealSplitRepo := repo.NewDealSplitRepository(dataDB)
+	identityMappingRepo := repo.NewIdentityMappingRepository(dataDB)
+	syncPolicyRepo := repo.NewSyncPolicyRepository(dataDB)
+	followerRepo := repo.NewFollowerRepository(dataDB)
+	contactBulkRepo := repo.NewContactBulkRepository(dataDB)
+	trashRepo := repo.NewTrashRepository(dataDB)
+	customObjectRepo := repo.NewCustomObjectRepository(dataDB)
+	computedFieldRepo := repo.NewComputedFieldRepository(dataDB)
+	reportScheduleRepo := repo.NewReportScheduleRepository(dataDB)
+	formRepo := repo.NewFormRepository(dataDB)
+	trackedLinkRepo := repo.NewTrackedLinkRepository(dataDB)
+	emailEventRepo := repo.NewEmailEventRepository(dataDB)
+	emailVerificationRepo := repo.NewEmailVerificationRepository(dataDB)
+	documentTemplateRepo := repo.NewDocumentTemplateRepository(dataDB)
+	promotionRepo := repo.NewPromotionRepository(dataDB)
+	boardPreferenceRepo := repo.NewBoardPreferenceRepository(dataDB)
+	quoteRepo := repo.NewQuoteRepository(dataDB)
+	invoiceRepo := repo.NewInvoiceRepository(dataDB)
+	scimRepo := repo.NewScimRepository(db)
+	ssoRepo := repo.NewSsoRepository(db)
+	serviceAccountRepo := repo.NewServiceAccountRepository(db)
+	billingRepo := repo.NewBillingRepository(db).WithDataDB(dataDB)
+	organizationRepo := repo.NewOrganizationRepository(db)
+	sessionRepo := repo.NewSessionRepository(db)
+
+	// Residência de dados: shard de cada workspace, em cache por SHARD_MAP_CACHE_TTL_SECONDS
+	var shardService *service.ShardService
+	var shardResolver middleware.ShardResolver
+	if len(shardDBs) > 0 {
+		shardService = service.NewShardService(repo.NewShardRepository(db), shardRouter, cfg.ShardMapCacheTTL, log)
+		shardResolver = shardService
+	}
 
 	// Initialize services
-	contactService := service.NewContactService(contactRepo, auditRepo, workspaceRepo, companyRepo, log)
-	taskService := service.NewTaskService(taskRepo, auditRepo, workspaceRepo, log)
-	companyService := service.NewCompanyService(companyRepo, auditRepo, workspaceRepo, log)
-	pipelineService := service.NewPipelineService(pipelineRepo, auditRepo, workspaceRepo, log)
-	dealService := service.NewDealService(dealRepo, pipelineRepo, workspaceRepo, auditRepo, log)
-	activityService := service.NewActivityService(activityRepo, workspaceRepo, auditRepo, log)
+	counterStore := counters.NewRedisStore(redisClient, redisBreaker, cfg.CountersCacheTTL)
+	counterService := service.NewCounterService(counterRepo, counterStore, workspaceRepo, log)
+	fieldHistoryService := service.NewFieldHistoryService(fieldHistoryRepo, contactRepo, companyRepo, dealRepo, workspaceRepo, log)
+	followerService := service.NewFollowerService(followerRepo, contactRepo, dealRepo, taskRepo, workspaceRepo, auditRepo, notificationMailer, log)
+	undoService := service.NewUndoService(undoRepo, contactRepo, companyRepo, taskRepo, workspaceRepo, auditRepo, counterService, cfg.UndoWindow, log)
+	// Políticas de conflito aplicadas pelos upserts por externalId com ?system=
+	syncPolicyService := service.NewSyncPolicyService(syncPolicyRepo, workspaceRepo, auditRepo, log)
+	contactService := service.NewContactService(contactRepo, auditRepo, workspaceRepo, userRepo, companyRepo, activityRepo, counterService, fieldHistoryService, undoService, followerService, log,
+		domain.NewCompanyAssociationPolicy(cfg.ContactCompanyAutoAssociate, cfg.GetContactCompanyDenyDomains())).
+		WithSyncPolicies(syncPolicyService)
+	taskService := service.NewTaskService(taskRepo, auditRepo, workspaceRepo, userRepo, counterService, undoService, followerService, log)
+	computedFieldService := service.NewComputedFieldService(computedFieldRepo, workspaceRepo, auditRepo, log)
+	companyService := service.NewCompanyService(companyRepo, auditRepo, workspaceRepo, userRepo, fieldHistoryService, undoService, computedFieldService, log).
+		WithSyncPolicies(syncPolicyService)
+	pipelineService := service.NewPipelineService(pipelineRepo, auditRepo, workspaceRepo, log).WithCounters(counterService)
+	dealService := service.NewDealService(dealRepo, pipelineRepo, workspaceRepo, userRepo, auditRepo, counterService, fieldHistoryService, businessHoursRepo, dealParticipantRepo, boardPreferenceRepo, followerService, computedFieldService, log, cfg.DealRequireNextStep).
+		WithSyncPolicies(syncPolicyService)
+	activityService := service.NewActivityService(activityRepo, workspaceRepo, auditRepo, attachmentStore, followerService, log, cfg.NoteAttachmentMaxBytes).
+		WithScanner(attachmentScanner, cfg.AttachmentScanFailOpen)
 	portfolioService := service.NewPortfolioService(portfolioRepo, workspaceRepo, auditRepo, log)
-
+	sandboxService := service.NewSandboxService(pipelineRepo, contactRepo, workspaceRepo, promotionRepo, auditRepo, log)
+	reportService := service.NewReportService(reportRepo, dealRepo, businessHoursRepo, workspaceRepo, log).WithSnapshots(dataDB)
+	// A API só gerencia os agendamentos: geração e entrega ficam no report-schedule-worker
+	reportScheduleService := service.NewReportScheduleService(reportScheduleRepo, reportService, workspaceRepo, auditRepo, nil, nil, log)
+	organizationService := service.NewOrganizationService(organizationRepo, log)
+	// Tokens de impersonation, troca SSO e service accounts: segredo HS256 com o issuer de
+	// JWT_SIGNER_ISSUER (validado contra os issuers registrados acima)
+	signerCfg, err := cfg.GetJWTSigner()
+	if err != nil {
+		return err
+	}
+	impersonationSigner := auth.NewHS256Signer(secretBytes, signerCfg.Issuer, signerCfg.Audience, signerCfg.KeyID)
+	impersonationService := service.NewImpersonationService(workspaceRepo, auditRepo, impersonationSigner,
+		cfg.ImpersonationMaxTTL, cfg.ImpersonationBlockMutations, log)
+	emailTemplateService := service.NewEmailTemplateService(emailTemplateRepo, contactRepo, dealRepo, workspaceRepo, auditRepo, log)
+	sequenceService := service.NewSequenceService(sequenceRepo, emailTemplateRepo, contactRepo, dealRepo, taskRepo, businessHoursRepo, workspaceRepo, auditRepo, log)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepo, taskRepo, dealRepo, workspaceRepo, auditRepo, log).WithBusinessHours(businessHoursRepo)
+	myWorkService := service.NewMyWorkService(myWorkRepo, workspaceRepo, log)
+	businessHoursService := service.NewBusinessHoursService(businessHoursRepo, workspaceRepo, auditRepo, log)
+	snapshotService := service.NewSnapshotService(snapshotRepo, workspaceRepo, auditRepo, snapshotStore, counterService, log)
+	companyEnrichmentService := service.NewCompanyEnrichmentService(companyEnrichmentRepo, companyRepo, workspaceRepo, auditRepo, fieldHistoryService, enrichmentProvider, log)
+	dealParticipantService := service.NewDealParticipantService(dealParticipantRepo, dealRepo, contactRepo, workspaceRepo, auditRepo, log)
+	dealSplitService := service.NewDealSplitService(dealSplitRepo, dealRepo, workspaceRepo, auditRepo, log)
+	identityMappingService := service.NewIdentityMappingService(identityMappingRepo, workspaceRepo, auditRepo, log)
+	contactBulkService := service.NewContactBulkService(contactBulkRepo, workspaceRepo, userRepo, auditRepo, log)
+	trashService := service.NewTrashService(trashRepo, contactRepo, companyRepo, dealRepo, taskRepo, pipelineRepo, workspaceRepo, auditRepo, counterService, cfg.TrashRetention, log)
+
+	customObjectService := service.NewCustomObjectService(customObjectRepo, contactRepo, companyRepo, dealRepo, workspaceRepo, auditRepo, log)
 	// Initialize handlers
 	contactHandler := handler.NewContactHandler(contactService)
 	taskHandler := handler.NewTaskHandler(taskService)
@@ -244,33 +524,215 @@ func runServe(cmd *cobra.Command, args []string) error {
 	dealHandler := handler.NewDealHandler(dealService)
 	activityHandler := handler.NewActivityHandler(activityService)
 	portfolioHandler := handler.NewPortfolioHandler(portfolioService)
-	debugHandler := handler.NewDebugHandler(pool)
+	sandboxHandler := handler.NewSandboxHandler(sandboxService)
+	reportHandler := handler.NewReportHandler(reportService)
+	emailTemplateHandler := handler.NewEmailTemplateHandler(emailTemplateService)
+	sequenceHandler := handler.NewSequenceHandler(sequenceService)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeEntryService)
+	myWorkHandler := handler.NewMyWorkHandler(myWorkService)
+	counterHandler := handler.NewCounterHandler(counterService)
+	snapshotHandler := handler.NewSnapshotHandler(snapshotService)
+	fieldHistoryHandler := handler.NewFieldHistoryHandler(fieldHistoryService)
+	undoHandler := handler.NewUndoHandler(undoService)
+	businessHoursHandler := handler.NewBusinessHoursHandler(businessHoursService)
+	companyEnrichmentHandler := handler.NewCompanyEnrichmentHandler(companyEnrichmentService)
+	dealParticipantHandler := handler.NewDealParticipantHandler(dealParticipantService)
+	dealSplitHandler := handler.NewDealSplitHandler(dealSplitService)
+	identityMappingHandler := handler.NewIdentityMappingHandler(identityMappingService)
+	syncPolicyHandler := handler.NewSyncPolicyHandler(syncPolicyService)
+	followerHandler := handler.NewFollowerHandler(followerService)
+	contactBulkHandler := handler.NewContactBulkHandler(contactBulkService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	customObjectHandler := handler.NewCustomObjectHandler(customObjectService)
+	computedFieldHandler := handler.NewComputedFieldHandler(computedFieldService)
+	reportScheduleHandler := handler.NewReportScheduleHandler(reportScheduleService)
+	organizationHandler := handler.NewOrganizationHandler(organizationService)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationService)
+	debugHandler := handler.NewDebugHandler(pool, cfg.AppEnv)
 
 	// Initialize rate limiter
-	var rateLimitCounter metric.Int64Counter
+	var rateLimitCounter, rateLimitFallbackCounter metric.Int64Counter
 	if metrics != nil {
 		rateLimitCounter = metrics.RateLimitRejections
+		rateLimitFallbackCounter = metrics.RateLimitFallbacks
+	}
+	rateLimitAlgorithm, err := ratelimit.ParseAlgorithm(cfg.RateLimitAlgorithm)
+	if err != nil {
+		return fmt.Errorf("invalid rate limit config: %w", err)
+	}
+	// Redis fora: limite aproximado em memória (RATE_LIMIT_FALLBACK_INSTANCES) em vez de fail-open
+	rateLimiter := ratelimit.NewRedisRateLimiter(redisClient, rateLimitAlgorithm, rateLimitCounter, redisBreaker).
+		WithNamespace(cfg.Region).
+		WithLocalFallback(ratelimit.NewLocalRateLimiter(cfg.RateLimitFallbackInstances), rateLimitFallbackCounter, log)
+
+	// GET /v1/me lê o consumo do rate limit sem consumir a cota
+	sessionService := service.NewSessionService(sessionRepo, rateLimiter, cfg.RateLimitPerWorkspacePerMin, log)
+	sessionHandler := handler.NewSessionHandler(sessionService)
+
+	// Formulários: os cadastrados (Form) sempre aceitam submissões; form tokens dependem de
+	// PUBLIC_FORM_TOKEN_SECRET
+	var formSecret []byte
+	if cfg.PublicFormTokenSecret != "" {
+		formSecret, err = base64.StdEncoding.DecodeString(cfg.PublicFormTokenSecret)
+		if err != nil {
+			return fmt.Errorf("failed to decode PUBLIC_FORM_TOKEN_SECRET: %w", err)
+		}
+	}
+	captchaProvider, err := captcha.Open(cfg.FormCaptchaProvider, cfg.FormCaptchaSecret, client.NewCustomHTTPClient(5*time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to open captcha provider: %w", err)
+	}
+	formService := service.NewFormService(formRepo, pipelineRepo, workspaceRepo, auditRepo, log)
+	formHandler := handler.NewFormHandler(formService)
+	publicFormService := service.NewPublicFormService(contactService, dealService, formRepo, workspaceRepo, auditRepo, rateLimiter, captchaProvider, formSecret, log).
+		WithShards(shardService)
+	publicFormHandler := handler.NewPublicFormHandler(publicFormService)
+
+	trackedLinkService := service.NewTrackedLinkService(trackedLinkRepo, contactRepo, dealRepo, activityRepo, workspaceRepo, auditRepo, cfg.TrackedLinkBaseURL, log)
+	trackedLinkHandler := handler.NewTrackedLinkHandler(trackedLinkService)
+
+	emailEventService := service.NewEmailEventService(emailEventRepo, contactRepo, activityRepo, emailVerificationRepo, workspaceRepo, auditRepo, log)
+	emailEventHandler := handler.NewEmailEventHandler(emailEventService)
+
+	documentTemplateService := service.NewDocumentTemplateService(documentTemplateRepo, workspaceRepo, auditRepo, log)
+	documentTemplateHandler := handler.NewDocumentTemplateHandler(documentTemplateService)
+
+	quoteService := service.NewQuoteService(quoteRepo, documentTemplateRepo, contactRepo, companyRepo, dealRepo, workspaceRepo, auditRepo, attachmentStore, cfg.QuoteShareBaseURL, log)
+	quoteHandler := handler.NewQuoteHandler(quoteService)
+
+	invoiceService := service.NewInvoiceService(invoiceRepo, dealRepo, workspaceRepo, auditRepo, log)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceService)
+
+	billingService := service.NewBillingService(billingRepo, workspaceRepo, cfg.StripeWebhookSecret, cfg.GetStripePricePlans(), cfg.RateLimitPerWorkspacePerMin, log)
+	billingHandler := handler.NewBillingHandler(billingService)
+
+	scimService := service.NewScimService(scimRepo, workspaceRepo, billingService, auditRepo, log)
+	scimHandler := handler.NewScimHandler(scimService)
+
+	// Sessões SSO usam o mesmo signer dos tokens de impersonation (aceitas pelo resolver como qualquer JWT HS256)
+	oidcVerifier := auth.NewOIDCVerifier(client.NewCustomHTTPClient(5*time.Second), cfg.JWTClockSkew)
+	ssoService := service.NewSsoService(ssoRepo, workspaceRepo, oidcVerifier, impersonationSigner, billingService, auditRepo,
+		cfg.SSOSessionTTL, log)
+	ssoHandler := handler.NewSsoHandler(ssoService)
+
+	// Tokens de service account também saem do signer de impersonation
+	serviceAccountService := service.NewServiceAccountService(serviceAccountRepo, workspaceRepo, impersonationSigner, auditRepo,
+		cfg.ServiceAccountTokenTTL, log)
+	serviceAccountHandler := handler.NewServiceAccountHandler(serviceAccountService)
+
+	// Workspaces inexistentes (404) e suspensos (423) são recusados antes dos services
+	workspaceStatusService := service.NewWorkspaceStatusService(workspaceRepo,
+		cfg.WorkspaceStatusCacheTTL,
+		cfg.WorkspaceStatusNegativeCacheTTL)
+
+	// Modo de manutenção: escritas recebem 503 enquanto um admin mantém a manutenção ligada
+	maintenanceService := service.NewMaintenanceService(repo.NewMaintenanceRepository(db), workspaceRepo, cfg.MaintenanceCacheTTL, log)
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceService)
+
+	// GET /v1/status: saúde dos componentes, manutenção e rate limit (sem consumir a cota)
+	statusService := service.NewStatusService(pool, maintenanceService, rateLimiter, cfg.RateLimitPerWorkspacePerMin, log)
+	statusHandler := handler.NewStatusHandler(statusService)
+
+	// Hot reload (SIGHUP ou POST /admin/config:reload): só as variáveis com reload:"true" mudam em
+	// runtime; as demais são reportadas e exigem restart
+	live := config.NewLive(cfg, config.LoadConfig)
+	live.OnReload(func(c *config.Config) {
+		log.SetLevel(c.LogLevel)
+		sessionService.SetLimitPerMin(c.RateLimitPerWorkspacePerMin)
+		statusService.SetLimitPerMin(c.RateLimitPerWorkspacePerMin)
+		billingService.SetDefaultRPM(c.RateLimitPerWorkspacePerMin)
+		dealService.SetRequireNextStep(c.DealRequireNextStep)
+		impersonationService.SetBlockMutations(c.ImpersonationBlockMutations)
+		contactService.SetAssociationPolicy(domain.NewCompanyAssociationPolicy(c.ContactCompanyAutoAssociate, c.GetContactCompanyDenyDomains()))
+		reloadAllowedIssuers(ctx, log, c, resolver, keyStore, secretBytes)
+	})
+	reloadConfig := func(ctx context.Context, source string) ([]config.Change, error) {
+		changes, err := live.Reload()
+		if err != nil {
+			log.Error(ctx, "config reload failed", zap.String("source", source), zap.Error(err))
+			return nil, err
+		}
+		for _, change := range changes {
+			fields := []zap.Field{
+				zap.String("key", change.Key),
+				zap.String("old", change.Old),
+				zap.String("new", change.New),
+				zap.String("source", source),
+			}
+			if change.Applied {
+				log.Info(ctx, "config change applied", fields...)
+			} else {
+				log.Warn(ctx, "config change requires restart", fields...)
+			}
+		}
+		log.Info(ctx, "config reloaded", zap.String("source", source), zap.Int("changes", len(changes)))
+		return changes, nil
 	}
-	rateLimiter := ratelimit.NewRedisRateLimiter(redisClient, rateLimitCounter)
 
 	// Build router
 	r := buildRouter(RouterDeps{
-		Cfg:              cfg,
-		Log:              log,
-		Resolver:         resolver,
-		S2SStore:         s2sStore,
-		IdempotencyRepo:  idempotencyRepo,
-		RateLimiter:      rateLimiter,
-		Metrics:          metrics,
-		Pool:             pool,
-		ContactHandler:   contactHandler,
-		TaskHandler:      taskHandler,
-		CompanyHandler:   companyHandler,
-		PipelineHandler:  pipelineHandler,
-		DealHandler:      dealHandler,
-		ActivityHandler:  activityHandler,
-		PortfolioHandler: portfolioHandler,
-		DebugHandler:     debugHandler,
+		Cfg:                      cfg,
+		LiveConfig:               live,
+		Log:                      log,
+		Resolver:                 resolver,
+		S2SStore:                 s2sStore,
+		IdempotencyRepo:          idempotencyRepo,
+		RateLimiter:              rateLimiter,
+		Metrics:                  metrics,
+		Pool:                     pool,
+		QuotaEnforcer:            billingService,
+		WorkspaceStatus:          workspaceStatusService,
+		Maintenance:              maintenanceService,
+		Shards:                   shardResolver,
+		ErrorTracker:             errorTracker,
+		ScimResolver:             scimService,
+		ContactHandler:           contactHandler,
+		TaskHandler:              taskHandler,
+		CompanyHandler:           companyHandler,
+		PipelineHandler:          pipelineHandler,
+		DealHandler:              dealHandler,
+		ActivityHandler:          activityHandler,
+		PortfolioHandler:         portfolioHandler,
+		SandboxHandler:           sandboxHandler,
+		ReportHandler:            reportHandler,
+		EmailTemplateHandler:     emailTemplateHandler,
+		SequenceHandler:          sequenceHandler,
+		TimeEntryHandler:         timeEntryHandler,
+		MyWorkHandler:            myWorkHandler,
+		CounterHandler:           counterHandler,
+		SnapshotHandler:          snapshotHandler,
+		FieldHistoryHandler:      fieldHistoryHandler,
+		UndoHandler:              undoHandler,
+		BusinessHoursHandler:     businessHoursHandler,
+		CompanyEnrichmentHandler: companyEnrichmentHandler,
+		DealParticipantHandler:   dealParticipantHandler,
+		DealSplitHandler:         dealSplitHandler,
+		IdentityMappingHandler:   identityMappingHandler,
+		SyncPolicyHandler:        syncPolicyHandler,
+		FollowerHandler:          followerHandler,
+		ContactBulkHandler:       contactBulkHandler,
+		TrashHandler:             trashHandler,
+		CustomObjectHandler:      customObjectHandler,
+		ComputedFieldHandler:     computedFieldHandler,
+		ReportScheduleHandler:    reportScheduleHandler,
+		OrganizationHandler:      organizationHandler,
+		ImpersonationHandler:     impersonationHandler,
+		FormHandler:              formHandler,
+		TrackedLinkHandler:       trackedLinkHandler,
+		EmailEventHandler:        emailEventHandler,
+		DocumentTemplateHandler:  documentTemplateHandler,
+		QuoteHandler:             quoteHandler,
+		InvoiceHandler:           invoiceHandler,
+		ScimHandler:              scimHandler,
+		SsoHandler:               ssoHandler,
+		ServiceAccountHandler:    serviceAccountHandler,
+		BillingHandler:           billingHandler,
+		PublicFormHandler:        publicFormHandler,
+		SessionHandler:           sessionHandler,
+		DebugHandler:             debugHandler,
+		ConfigHandler:            handler.NewConfigHandler(reloadConfig),
+		MaintenanceHandler:       maintenanceHandler,
+		StatusHandler:            statusHandler,
 	})
 
 	// Create HTTP server
@@ -291,6 +753,16 @@ func runServe(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
+	// SIGHUP recarrega a configuração sem derrubar o servidor
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	defer signal.Stop(hupChan)
+	go func() {
+		for range hupChan {
+			_, _ = reloadConfig(ctx, "sighup")
+		}
+	}()
+
 	// Wait for interrupt signal for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -310,3 +782,33 @@ func runServe(cmd *cobra.Command, args []string) error {
 	log.Info(shutdownCtx, "shutdown complete")
 	return nil
 }
+
+// reloadAllowedIssuers aplica um novo JWT_ALLOWED_ISSUERS. Issuers novos ganham validador HS256 com o
+// segredo atual; os configurados com outro algoritmo em JWT_ISSUERS já foram registrados no startup.
+func reloadAllowedIssuers(ctx context.Context, log *logger.Logger, cfg *config.Config, resolver *auth.KeyResolver, keyStore *auth.KeyStore, secret []byte) {
+	jwtIssuers, err := cfg.GetJWTIssuers()
+	if err != nil {
+		log.Error(ctx, "issuer allowlist not reloaded", zap.Error(err))
+		return
+	}
+	allowed := make([]string, 0, len(jwtIssuers)+1)
+	for _, issuer := range jwtIssuers {
+		if !resolver.HasIssuer(issuer.Issuer) {
+			if issuer.Algorithm != config.JWTAlgorithmHS256 {
+				log.Warn(ctx, "JWT issuer requires restart", zap.String("issuer", issuer.Issuer), zap.String("algorithm", issuer.Algorithm))
+				continue
+			}
+			keyStore.LoadHS256Key(issuer.Issuer, issuer.KeyID, secret)
+			clockSkew := time.Duration(issuer.ClockSkewSeconds) * time.Second
+			resolver.RegisterIssuer(issuer.Issuer, auth.NewHS256Validator(keyStore, issuer.Issuer, clockSkew), issuer.Audiences)
+			log.Info(ctx, "JWT issuer registered", zap.String("issuer", issuer.Issuer), zap.String("algorithm", issuer.Algorithm))
+		}
+		allowed = append(allowed, issuer.Issuer)
+	}
+	// Issuer legado do JWT_PUBLIC_KEY_MCP_V1 continua aceito
+	if cfg.JWTPublicKeyMCPV1 != "" && !slices.Contains(allowed, "linkko-mcp-server") {
+		allowed = append(allowed, "linkko-mcp-server")
+	}
+	resolver.SetAllowedIssuers(allowed)
+	log.Info(ctx, "JWT issuer allowlist reloaded", zap.Strings("allowed_issuers", allowed))
+}
@@ -3,13 +3,18 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/handler"
 	"linkko-api/internal/http/middleware"
 	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/ratelimit"
+	"linkko-api/internal/service"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -305,3 +310,113 @@ func TestMiddlewareOrder(t *testing.T) {
 	expected := []string{"requestid", "recovery", "logging", "handler"}
 	assert.Equal(t, expected, executionOrder, "Middleware should execute in correct order: RequestID → Recovery → Logging → Handler")
 }
+
+type fakeStatusPinger struct{ err error }
+
+func (p fakeStatusPinger) Ping(ctx context.Context) error { return p.err }
+
+type fakeStatusRateLimiter struct {
+	decision ratelimit.Decision
+	degraded bool
+}
+
+func (l fakeStatusRateLimiter) Peek(ctx context.Context, workspaceID string, limit int, windowSeconds int) (ratelimit.Decision, error) {
+	return l.decision, nil
+}
+
+func (l fakeStatusRateLimiter) Degraded() bool { return l.degraded }
+
+// TestStatusEndpoint verifies /v1/status is public and reports degraded components with 200
+func TestStatusEndpoint(t *testing.T) {
+	log, _ := logger.New("test", "error")
+
+	tests := []struct {
+		name     string
+		db       error
+		degraded bool
+		expected domain.ServiceStatus
+	}{
+		{name: "AllHealthy", expected: domain.ServiceOperational},
+		{name: "DatabaseDown", db: errors.New("connection refused"), expected: domain.ServiceDegraded},
+		{name: "RateLimitFallback", degraded: true, expected: domain.ServiceDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := noopRouterDeps()
+			deps.StatusHandler = handler.NewStatusHandler(service.NewStatusService(
+				fakeStatusPinger{err: tt.db}, nil, fakeStatusRateLimiter{degraded: tt.degraded}, 100, log))
+			r := buildRouter(deps)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+			var status domain.APIStatus
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+			assert.Equal(t, tt.expected, status.Status)
+			assert.Len(t, status.Components, 2)
+			assert.Nil(t, status.Workspace)
+		})
+	}
+}
+
+// TestStatusService_WorkspaceThrottle verifies the workspace status reports an exhausted rate limit
+func TestStatusService_WorkspaceThrottle(t *testing.T) {
+	log, _ := logger.New("test", "error")
+	limiter := fakeStatusRateLimiter{decision: ratelimit.Decision{Allowed: false, Limit: 50, ResetAfter: 30 * time.Second}}
+	svc := service.NewStatusService(nil, nil, limiter, 100, log)
+
+	status := svc.WorkspaceStatus(context.Background(), "ws-123", 50)
+
+	assert.Equal(t, domain.ServiceOperational, status.Status)
+	require.NotNil(t, status.Workspace)
+	require.NotNil(t, status.Workspace.RateLimit)
+	assert.True(t, status.Workspace.RateLimit.Throttled)
+	assert.Equal(t, 50, status.Workspace.RateLimit.Limit)
+	assert.Equal(t, 0, status.Workspace.RateLimit.Remaining)
+}
+
+// TestV1DeprecationHeaders verifies /v1 announces its sunset and the /v2 successor once both are configured
+func TestV1DeprecationHeaders(t *testing.T) {
+	tests := []struct {
+		name       string
+		v2Enabled  bool
+		sunset     string
+		path       string
+		deprecated bool
+	}{
+		{name: "V1WithSunset", v2Enabled: true, sunset: "2027-06-30", path: "/v1/workspaces/ws-1/contacts", deprecated: true},
+		{name: "V1WithoutSunset", v2Enabled: true, path: "/v1/workspaces/ws-1/contacts"},
+		{name: "V1WithoutV2", sunset: "2027-06-30", path: "/v1/workspaces/ws-1/contacts"},
+		{name: "V2", v2Enabled: true, sunset: "2027-06-30", path: "/v2/workspaces/ws-1/contacts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := noopRouterDeps()
+			deps.Cfg.APIV2Enabled = tt.v2Enabled
+			deps.Cfg.APIV1Sunset = tt.sunset
+			r := buildRouter(deps)
+
+			// Sem credencial: os headers saem mesmo na resposta de erro da autenticação
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+			if !tt.deprecated {
+				assert.Empty(t, w.Header().Get("Deprecation"))
+				assert.Empty(t, w.Header().Get("Sunset"))
+				assert.Empty(t, w.Header().Get("Link"))
+				return
+			}
+			assert.Equal(t, "true", w.Header().Get("Deprecation"))
+			assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+			assert.Equal(t, `</v2/workspaces/ws-1/contacts>; rel="successor-version"`, w.Header().Get("Link"))
+		})
+	}
+}
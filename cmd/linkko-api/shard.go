@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/database"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var shardCmd = &cobra.Command{
+	Use:   "shard",
+	Short: "Manage workspace data residency shards",
+	Long:  `List the workspace → database shard map (DATABASE_SHARDS), move a workspace's data to another shard and refresh the control-plane rows copied to a shard`,
+}
+
+var shardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workspaces stored outside the default database",
+	Args:  cobra.NoArgs,
+	RunE:  runShardList,
+}
+
+var shardMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move a workspace's data to another shard",
+	Long: `Block the workspace's writes (maintenance mode), copy its data to the target shard, verify the row counts,
+switch the shard map and remove the data from the source shard. Use --to default to bring a workspace back to DATABASE_URL.
+Workers running against the source shard must not process the workspace during the move.`,
+	Args: cobra.NoArgs,
+	RunE: runShardMigrate,
+}
+
+var shardSyncAnchorsCmd = &cobra.Command{
+	Use:   "sync-anchors",
+	Short: "Refresh the workspace, members and users copied to the workspace's shard",
+	Long:  `Copy the control-plane rows referenced by the workspace data (workspace, organization, roles, members and users) to the workspace's shard. Without --workspace, every workspace outside the default database is refreshed (also done by cleanup).`,
+	Args:  cobra.NoArgs,
+	RunE:  runShardSyncAnchors,
+}
+
+var (
+	shardWorkspaceID string
+	shardTarget      string
+	shardKeepSource  bool
+)
+
+func init() {
+	shardMigrateCmd.Flags().StringVar(&shardWorkspaceID, "workspace", "", "workspace ID")
+	shardMigrateCmd.Flags().StringVar(&shardTarget, "to", "", "target shard (a DATABASE_SHARDS name or default)")
+	shardMigrateCmd.Flags().BoolVar(&shardKeepSource, "keep-source", false, "keep the workspace data in the source shard")
+	_ = shardMigrateCmd.MarkFlagRequired("workspace")
+	_ = shardMigrateCmd.MarkFlagRequired("to")
+
+	shardSyncAnchorsCmd.Flags().StringVar(&shardWorkspaceID, "workspace", "", "workspace ID (default: all workspaces outside the default database)")
+
+	shardCmd.AddCommand(shardListCmd, shardMigrateCmd, shardSyncAnchorsCmd)
+	rootCmd.AddCommand(shardCmd)
+}
+
+// shardEnv conexões e services usados pelos subcomandos
+type shardEnv struct {
+	cfg    *config.Config
+	log    *logger.Logger
+	router *database.ShardRouter
+	shards *service.ShardService
+	close  func()
+}
+
+func openShardEnv(ctx context.Context) (*shardEnv, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	closers := []func(){pool.Close}
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+		_ = log.Sync()
+	}
+
+	shardDBs := map[string]database.DB{}
+	for name, url := range cfg.GetDatabaseShards() {
+		shardPool, err := database.NewPool(ctx, url)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to connect to database shard %s: %w", name, err)
+		}
+		closers = append(closers, shardPool.Close)
+		shardDBs[name] = shardPool
+	}
+
+	router := database.NewShardRouter(pool, shardDBs)
+	// Sem cache: o comando sempre lê o mapa atual
+	shards := service.NewShardService(repo.NewShardRepository(pool), router, 0, log)
+	return &shardEnv{cfg: cfg, log: log, router: router, shards: shards, close: closeAll}, nil
+}
+
+func runShardList(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	env, err := openShardEnv(ctx)
+	if err != nil {
+		return err
+	}
+	defer env.close()
+
+	list, err := env.shards.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list workspace shards: %w", err)
+	}
+
+	fmt.Printf("Configured shards: %v\n", env.router.Names())
+	if len(list) == 0 {
+		fmt.Println("All workspaces are in the default database")
+		return nil
+	}
+	for _, ws := range list {
+		fmt.Printf("%s\t%s\t%s\n", ws.WorkspaceID, ws.Shard, ws.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	}
+	return nil
+}
+
+func runShardMigrate(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	env, err := openShardEnv(ctx)
+	if err != nil {
+		return err
+	}
+	defer env.close()
+
+	control, _ := env.router.Shard(database.DefaultShard)
+	workspaceRepo := repo.NewWorkspaceRepository(control)
+	maintenance := service.NewMaintenanceService(repo.NewMaintenanceRepository(control), workspaceRepo, 0, env.log)
+
+	// As esperas cobrem os caches das instâncias da API: a manutenção precisa valer em todas antes
+	// da cópia e o mapa novo antes de remover a origem
+	result, err := env.shards.Migrate(ctx, shardWorkspaceID, shardTarget, service.ShardMigrationOptions{
+		Maintenance:       maintenance,
+		MaintenanceSettle: env.cfg.MaintenanceCacheTTL,
+		ShardMapSettle:    env.cfg.ShardMapCacheTTL,
+		KeepSource:        shardKeepSource,
+	})
+	if err != nil {
+		env.log.Error(ctx, "shard migration failed", zap.String("workspace_id", shardWorkspaceID), zap.String("to", shardTarget), zap.Error(err))
+		return fmt.Errorf("failed to migrate workspace %s to shard %s: %w", shardWorkspaceID, shardTarget, err)
+	}
+
+	env.log.Info(ctx, "shard migration completed",
+		zap.String("workspace_id", result.WorkspaceID),
+		zap.String("from", result.From),
+		zap.String("to", result.To),
+		zap.Any("copied", result.Copied),
+		zap.Any("purged", result.Purged),
+	)
+	fmt.Printf("✓ Workspace %s moved from %s to %s: %d rows copied, %d rows removed from %s\n",
+		result.WorkspaceID, result.From, result.To, sumCounts(result.Copied), sumCounts(result.Purged), result.From)
+	return nil
+}
+
+func runShardSyncAnchors(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	env, err := openShardEnv(ctx)
+	if err != nil {
+		return err
+	}
+	defer env.close()
+
+	synced, err := syncShardAnchors(ctx, env.shards, env.log, shardWorkspaceID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Shard anchors refreshed for %d workspaces\n", synced)
+	return nil
+}
+
+// syncShardAnchors atualiza as âncoras de um workspace ou, sem workspaceID, de todos os
+// workspaces fora do banco padrão. Retorna quantos workspaces foram atualizados.
+func syncShardAnchors(ctx context.Context, shards *service.ShardService, log *logger.Logger, workspaceID string) (int, error) {
+	var workspaceIDs []string
+	if workspaceID != "" {
+		workspaceIDs = []string{workspaceID}
+	} else {
+		list, err := shards.List(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list workspace shards: %w", err)
+		}
+		for _, ws := range list {
+			workspaceIDs = append(workspaceIDs, ws.WorkspaceID)
+		}
+	}
+
+	for _, id := range workspaceIDs {
+		synced, err := shards.SyncAnchors(ctx, id)
+		if err != nil {
+			log.Error(ctx, "shard anchor sync failed", zap.String("workspace_id", id), zap.Error(err))
+			return 0, fmt.Errorf("failed to sync shard anchors of workspace %s: %w", id, err)
+		}
+		log.Info(ctx, "shard anchors synced", zap.String("workspace_id", id), zap.Any("rows", synced))
+	}
+	return len(workspaceIDs), nil
+}
+
+func sumCounts(counts map[string]int64) int64 {
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/counters"
+	"linkko-api/internal/database"
+	"linkko-api/internal/objectstore"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/resilience"
+	"linkko-api/internal/service"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var snapshotWorkerCmd = &cobra.Command{
+	Use:   "snapshot-worker",
+	Short: "Run workspace snapshot export and restore jobs",
+	Long:  `Poll pending workspace snapshot jobs, writing full workspace exports to object storage and importing archives for restore requests`,
+	RunE:  runSnapshotWorker,
+}
+
+var snapshotWorkerOnce bool
+
+func init() {
+	snapshotWorkerCmd.Flags().BoolVar(&snapshotWorkerOnce, "once", false, "process pending jobs and exit")
+	rootCmd.AddCommand(snapshotWorkerCmd)
+}
+
+func runSnapshotWorker(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.OTELServiceName, "info")
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer log.Sync()
+
+	// Connect to database
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	store, err := objectstore.Open(cfg.SnapshotStorageURL)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot storage: %w", err)
+	}
+
+	// Redis só é usado para invalidar os contadores do dashboard após uma restauração;
+	// indisponibilidade não bloqueia o worker (o cache expira pelo TTL).
+	redisOpts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+	redisClient := redis.NewClient(redisOpts)
+	defer redisClient.Close()
+	redisBreaker := resilience.NewBreaker(resilience.BreakerConfig{
+		Name:             "redis",
+		FailureThreshold: cfg.CircuitBreakerFailureThreshold,
+		OpenTimeout:      cfg.CircuitBreakerOpen,
+	})
+
+	// Initialize service
+	workspaceRepo := repo.NewWorkspaceRepository(pool)
+	counterService := service.NewCounterService(
+		repo.NewCounterRepository(pool),
+		counters.NewRedisStore(redisClient, redisBreaker, cfg.CountersCacheTTL),
+		workspaceRepo,
+		log,
+	)
+	snapshotService := service.NewSnapshotService(
+		repo.NewSnapshotRepository(pool),
+		workspaceRepo,
+		repo.NewAuditRepo(pool),
+		store,
+		counterService,
+		log,
+	)
+
+	interval := cfg.SnapshotWorkerInterval
+	log.Info(ctx, "starting snapshot worker", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := snapshotService.ProcessNext(ctx)
+		if err != nil {
+			log.Error(ctx, "snapshot worker cycle failed", zap.Error(err))
+		}
+
+		// Havia job: provavelmente há mais na fila, processa de novo sem esperar
+		if err == nil && processed {
+			continue
+		}
+
+		if snapshotWorkerOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(context.Background(), "snapshot worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package apperr define o catálogo de erros de domínio da API.
+//
+// Cada erro sentinela é criado com Define e carrega o código legível por máquina,
+// o status HTTP e a mensagem pública. A tradução para HTTP é feita em um único
+// lugar (httperr.WriteAppError), então um erro novo definido aqui nunca cai
+// silenciosamente em 500.
+package apperr
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// DocsPath é servido pela API e lista o catálogo; DocsURL aponta para a âncora do código.
+const DocsPath = "/docs/errors"
+
+// Códigos do catálogo. Os genéricos (NOT_FOUND, CONFLICT...) mantêm o contrato
+// já publicado; os específicos descrevem regras de negócio.
+const (
+	CodeForbidden           = "FORBIDDEN"
+	CodeNotFound            = "NOT_FOUND"
+	CodeConflict            = "CONFLICT"
+	CodeValidationError     = "VALIDATION_ERROR"
+	CodeInvalidParameter    = "INVALID_PARAMETER"
+	CodeInvalidStatus       = "INVALID_STATUS"
+	CodeInvalidPosition     = "INVALID_POSITION"
+	CodePositionCollision   = "POSITION_COLLISION"
+	CodeInvalidStage        = "INVALID_STAGE"
+	CodeCannotDeleteDefault = "CANNOT_DELETE_DEFAULT"
+	CodeServiceUnavailable  = "SERVICE_UNAVAILABLE"
+
+	CodeInvalidLifecycleTransition = "INVALID_LIFECYCLE_TRANSITION"
+	CodeNextStepRequired           = "NEXT_STEP_REQUIRED"
+	CodeInvalidOwner               = "INVALID_OWNER"
+	CodeAttachmentInfected         = "ATTACHMENT_INFECTED"
+)
+
+// Error é um erro de domínio catalogado.
+// Compare com errors.Is contra o sentinela; use errors.As para ler os metadados.
+type Error struct {
+	// Code é o código legível por máquina enviado em error.code
+	Code string
+	// Status é o status HTTP da resposta
+	Status int
+	// Message é a mensagem interna (logs, Error())
+	Message string
+	// Public é a mensagem enviada ao cliente
+	Public string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// DocsURL returns the documentation link for the error code
+func (e *Error) DocsURL() string {
+	return DocsPath + "#" + e.Code
+}
+
+var (
+	mu      sync.RWMutex
+	catalog []*Error
+)
+
+// Define cria e registra um erro sentinela no catálogo.
+// public vazio reutiliza message.
+func Define(code string, status int, message, public string) *Error {
+	if public == "" {
+		public = message
+	}
+	e := &Error{Code: code, Status: status, Message: message, Public: public}
+
+	mu.Lock()
+	catalog = append(catalog, e)
+	mu.Unlock()
+	return e
+}
+
+// From extrai o erro catalogado de uma cadeia de erros
+func From(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// CatalogEntry é a representação pública de um erro do catálogo
+type CatalogEntry struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	DocsURL string `json:"docsUrl"`
+}
+
+// Catalog returns all defined errors sorted by status and code
+func Catalog() []CatalogEntry {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	entries := make([]CatalogEntry, 0, len(catalog))
+	for _, e := range catalog {
+		entries = append(entries, CatalogEntry{Code: e.Code, Status: e.Status, Message: e.Public, DocsURL: e.DocsURL()})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Status != entries[j].Status {
+			return entries[i].Status < entries[j].Status
+		}
+		return entries[i].Code < entries[j].Code
+	})
+	return entries
+}
+
+// Helpers para as classes mais comuns
+
+// NotFound defines a 404 NOT_FOUND error
+func NotFound(message, public string) *Error {
+	return Define(CodeNotFound, http.StatusNotFound, message, public)
+}
+
+// Conflict defines a 409 CONFLICT error
+func Conflict(message, public string) *Error {
+	return Define(CodeConflict, http.StatusConflict, message, public)
+}
+
+// Forbidden defines a 403 FORBIDDEN error
+func Forbidden(message, public string) *Error {
+	return Define(CodeForbidden, http.StatusForbidden, message, public)
+}
+
+// Unprocessable defines a 422 error with a specific code
+func Unprocessable(code, message, public string) *Error {
+	return Define(code, http.StatusUnprocessableEntity, message, public)
+}
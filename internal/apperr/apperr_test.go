@@ -0,0 +1,54 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDefineAndFrom(t *testing.T) {
+	errThing := Define("THING_BROKEN", http.StatusConflict, "thing is broken internally", "thing is broken")
+
+	wrapped := fmt.Errorf("update thing: %w", errThing)
+	if !errors.Is(wrapped, errThing) {
+		t.Fatal("expected errors.Is to match the sentinel")
+	}
+
+	appErr, ok := From(wrapped)
+	if !ok {
+		t.Fatal("expected From to find the cataloged error")
+	}
+	if appErr.Status != http.StatusConflict || appErr.Code != "THING_BROKEN" {
+		t.Errorf("unexpected metadata: %+v", appErr)
+	}
+	if appErr.Error() != "thing is broken internally" {
+		t.Errorf("expected internal message from Error(), got %q", appErr.Error())
+	}
+	if appErr.Public != "thing is broken" {
+		t.Errorf("expected public message, got %q", appErr.Public)
+	}
+
+	if _, ok := From(errors.New("plain")); ok {
+		t.Error("expected plain errors to be uncataloged")
+	}
+}
+
+func TestDefine_PublicDefaultsToMessage(t *testing.T) {
+	e := Conflict("already exists", "")
+	if e.Public != "already exists" {
+		t.Errorf("expected public to default to message, got %q", e.Public)
+	}
+}
+
+func TestCatalog_Sorted(t *testing.T) {
+	Define("Z_FIRST", http.StatusBadRequest, "z", "")
+	Define("A_LAST", http.StatusServiceUnavailable, "a", "")
+
+	entries := Catalog()
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].Status > entries[i].Status {
+			t.Fatalf("catalog not sorted by status at %d: %v", i, entries)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Resultados do lookup no cache de decisões (atributo "result" de auth_cache_lookups_total)
+const (
+	authCacheHit         = "hit"
+	authCacheNegativeHit = "negative_hit"
+	authCacheMiss        = "miss"
+)
+
+// DecisionCacheConfig configura o cache de decisões do KeyResolver
+type DecisionCacheConfig struct {
+	// TTL máximo de uma validação bem-sucedida; nunca passa do exp do token
+	TTL time.Duration
+	// NegativeTTL por quanto tempo um token malformado é rejeitado sem ser decodificado de novo (0 = desligado)
+	NegativeTTL time.Duration
+	// MaxEntries limita as validações cacheadas; cheio, descarta a usada há mais tempo (LRU)
+	MaxEntries int
+	// MaxNegativeEntries limite separado para as rejeições (0 = MaxEntries/10, mínimo 1)
+	MaxNegativeEntries int
+	// Lookups auth_cache_lookups_total{result=hit|negative_hit|miss} (pode ser nil)
+	Lookups metric.Int64Counter
+}
+
+// DecisionCache caches token validation outcomes keyed by the token's SHA-256, so high-RPS
+// clients reusing the same token skip signature verification. Safe for concurrent use.
+type DecisionCache struct {
+	cfg   DecisionCacheConfig
+	cache *tokenCache
+}
+
+// NewDecisionCache creates a decision cache
+func NewDecisionCache(cfg DecisionCacheConfig) *DecisionCache {
+	if cfg.MaxNegativeEntries <= 0 {
+		cfg.MaxNegativeEntries = max(cfg.MaxEntries/10, 1)
+	}
+	return &DecisionCache{
+		cfg:   cfg,
+		cache: newTokenCache(cfg.MaxEntries, cfg.MaxNegativeEntries),
+	}
+}
+
+// Get returns the cached decision for the token: claims on a hit, the original error on a
+// negative hit. ok is false on a miss.
+func (c *DecisionCache) Get(ctx context.Context, key string) (*CustomClaims, bool, error) {
+	claims, ok, err := c.cache.get(key)
+	switch {
+	case !ok:
+		c.record(ctx, authCacheMiss)
+	case err != nil:
+		c.record(ctx, authCacheNegativeHit)
+	default:
+		c.record(ctx, authCacheHit)
+	}
+	return claims, ok, err
+}
+
+// StoreSuccess caches validated claims for TTL, bounded by the token's remaining lifetime
+func (c *DecisionCache) StoreSuccess(key string, claims *CustomClaims) {
+	if c.cfg.TTL <= 0 {
+		return
+	}
+	now := c.cache.now()
+	expiresAt := now.Add(c.cfg.TTL)
+	if claims.ExpiresAt != nil && claims.ExpiresAt.Before(expiresAt) {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	c.cache.set(key, tokenCacheEntry{claims: *claims, expiresAt: expiresAt}) // set copia as claims
+}
+
+// StoreFailure caches a malformed-token rejection for NegativeTTL
+func (c *DecisionCache) StoreFailure(key string, err error) {
+	if c.cfg.NegativeTTL <= 0 {
+		return
+	}
+	c.cache.set(key, tokenCacheEntry{err: err, expiresAt: c.cache.now().Add(c.cfg.NegativeTTL)})
+}
+
+// Purge descarta todas as decisões (ex.: allowlist de issuers alterada)
+func (c *DecisionCache) Purge() {
+	c.cache.clear()
+}
+
+func (c *DecisionCache) record(ctx context.Context, result string) {
+	if c.cfg.Lookups != nil {
+		c.cfg.Lookups.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+	}
+}
+
+type tokenCacheEntry struct {
+	claims    CustomClaims
+	err       error
+	expiresAt time.Time
+}
+
+// tokenCacheItem elemento das listas LRU
+type tokenCacheItem struct {
+	key   string
+	entry tokenCacheEntry
+}
+
+// tokenCache mapa token hash -> decisão com expiração, compartilhado pelo cache de decisões
+// e pela introspecção. Decisões positivas e negativas ficam em listas LRU separadas, cada uma
+// com seu limite: cheia, a lista descarta a decisão usada há mais tempo (O(1)), e uma rajada de
+// tokens inválidos não tira do cache os tokens válidos em uso.
+type tokenCache struct {
+	maxEntries         int
+	maxNegativeEntries int
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	positive *list.List // mais recente na frente
+	negative *list.List
+	now      func() time.Time
+}
+
+func newTokenCache(maxEntries, maxNegativeEntries int) *tokenCache {
+	return &tokenCache{
+		maxEntries:         maxEntries,
+		maxNegativeEntries: maxNegativeEntries,
+		entries:            make(map[string]*list.Element),
+		positive:           list.New(),
+		negative:           list.New(),
+		now:                time.Now,
+	}
+}
+
+// get devolve uma cópia profunda das claims para o chamador não alterar a entrada cacheada
+func (c *tokenCache) get(key string) (*CustomClaims, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	item := elem.Value.(*tokenCacheItem)
+	if !c.now().Before(item.entry.expiresAt) {
+		c.remove(elem)
+		return nil, false, nil
+	}
+	c.list(item.entry).MoveToFront(elem)
+	if item.entry.err != nil {
+		return nil, true, item.entry.err
+	}
+	return cloneClaims(&item.entry.claims), true, nil
+}
+
+func (c *tokenCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.positive.Init()
+	c.negative.Init()
+}
+
+func (c *tokenCache) set(key string, entry tokenCacheEntry) {
+	if !c.now().Before(entry.expiresAt) {
+		return
+	}
+	if entry.err == nil {
+		entry.claims = *cloneClaims(&entry.claims)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	limit := c.maxEntries
+	if entry.err != nil {
+		limit = c.maxNegativeEntries
+	}
+	if limit <= 0 {
+		return
+	}
+	l := c.list(entry)
+	for l.Len() >= limit {
+		c.remove(l.Back())
+	}
+	c.entries[key] = l.PushFront(&tokenCacheItem{key: key, entry: entry})
+}
+
+func (c *tokenCache) list(entry tokenCacheEntry) *list.List {
+	if entry.err != nil {
+		return c.negative
+	}
+	return c.positive
+}
+
+func (c *tokenCache) remove(elem *list.Element) {
+	item := elem.Value.(*tokenCacheItem)
+	c.list(item.entry).Remove(elem)
+	delete(c.entries, item.key)
+}
+
+// cloneClaims copia as claims sem compartilhar slices nem ponteiros com a origem
+func cloneClaims(src *CustomClaims) *CustomClaims {
+	claims := *src
+	claims.Workspaces = slices.Clone(src.Workspaces)
+	claims.Audience = slices.Clone(src.Audience)
+	claims.ExpiresAt = cloneNumericDate(src.ExpiresAt)
+	claims.NotBefore = cloneNumericDate(src.NotBefore)
+	claims.IssuedAt = cloneNumericDate(src.IssuedAt)
+	return &claims
+}
+
+func cloneNumericDate(d *jwt.NumericDate) *jwt.NumericDate {
+	if d == nil {
+		return nil
+	}
+	clone := *d
+	return &clone
+}
+
+// tokenCacheKey evita manter o token em claro na memória do cache
+func tokenCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingValidator counts signature verifications reaching the validator
+type countingValidator struct {
+	TokenValidator
+	calls int32
+}
+
+func (v *countingValidator) Validate(tokenString string, kid string) (*CustomClaims, error) {
+	atomic.AddInt32(&v.calls, 1)
+	return v.TokenValidator.Validate(tokenString, kid)
+}
+
+func newCachedResolver(cfg DecisionCacheConfig) (*KeyResolver, *countingValidator, *DecisionCache) {
+	keyStore := NewKeyStore()
+	keyStore.LoadHS256Key(testIssuer, "v1", []byte(testSecret))
+	validator := &countingValidator{TokenValidator: NewHS256Validator(keyStore, testIssuer, 60*time.Second)}
+
+	resolver := NewKeyResolver([]string{testIssuer}, []string{testAudience})
+	resolver.RegisterValidator(testIssuer, validator)
+	cache := NewDecisionCache(cfg)
+	resolver.SetDecisionCache(cache)
+	return resolver, validator, cache
+}
+
+func TestDecisionCache_CachesSuccessfulValidation(t *testing.T) {
+	resolver, validator, _ := newCachedResolver(DecisionCacheConfig{TTL: time.Minute, MaxEntries: 10})
+	token := createTestToken(testSecret, &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}, time.Now().Add(time.Hour))
+
+	for i := 0; i < 3; i++ {
+		claims, err := resolver.Resolve(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, "ws-12345", claims.WorkspaceID)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&validator.calls))
+}
+
+func TestDecisionCache_TTLBoundedByExpiry(t *testing.T) {
+	resolver, validator, cache := newCachedResolver(DecisionCacheConfig{TTL: time.Hour, MaxEntries: 10})
+	now := time.Now()
+	cache.cache.now = func() time.Time { return now }
+
+	token := createTestToken(testSecret, &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}, now.Add(30*time.Second))
+	_, err := resolver.Resolve(context.Background(), token)
+	require.NoError(t, err)
+
+	// Past the token's exp the cached decision is gone even though the TTL is an hour
+	now = now.Add(31 * time.Second)
+	_, _ = resolver.Resolve(context.Background(), token)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&validator.calls))
+}
+
+func TestDecisionCache_NegativeCachingOfMalformedTokens(t *testing.T) {
+	resolver, _, cache := newCachedResolver(DecisionCacheConfig{TTL: time.Minute, NegativeTTL: 5 * time.Second, MaxEntries: 10})
+
+	_, err := resolver.Resolve(context.Background(), "eyJnot.a.jwt")
+	require.Error(t, err)
+
+	_, ok, cachedErr := cache.cache.get(tokenCacheKey("eyJnot.a.jwt"))
+	require.True(t, ok)
+	assert.Equal(t, err, cachedErr)
+
+	_, err = resolver.Resolve(context.Background(), "eyJnot.a.jwt")
+	require.Error(t, err)
+}
+
+func TestDecisionCache_InvalidSignatureIsNotCached(t *testing.T) {
+	resolver, validator, _ := newCachedResolver(DecisionCacheConfig{TTL: time.Minute, NegativeTTL: 5 * time.Second, MaxEntries: 10})
+	token := createTestToken("wrong-secret-key-must-be-at-least-32-chars-long", &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}, time.Now().Add(time.Hour))
+
+	for i := 0; i < 2; i++ {
+		_, err := resolver.Resolve(context.Background(), token)
+		require.Error(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&validator.calls))
+}
+
+func TestDecisionCache_MaxEntriesEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewDecisionCache(DecisionCacheConfig{TTL: time.Minute, MaxEntries: 2})
+	claims := &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}
+	ctx := context.Background()
+
+	cache.StoreSuccess("a", claims)
+	cache.StoreSuccess("b", claims)
+	_, _, _ = cache.Get(ctx, "a") // a passa a ser a mais recente
+	cache.StoreSuccess("c", claims)
+
+	_, okA, _ := cache.Get(ctx, "a")
+	_, okB, _ := cache.Get(ctx, "b")
+	_, okC, _ := cache.Get(ctx, "c")
+	assert.True(t, okA)
+	assert.False(t, okB, "least recently used decision is evicted")
+	assert.True(t, okC, "full cache still stores new decisions")
+}
+
+func TestDecisionCache_NegativeEntriesCappedSeparately(t *testing.T) {
+	cache := NewDecisionCache(DecisionCacheConfig{TTL: time.Minute, NegativeTTL: time.Minute, MaxEntries: 2, MaxNegativeEntries: 1})
+	claims := &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}
+	ctx := context.Background()
+
+	cache.StoreSuccess("valid-1", claims)
+	cache.StoreSuccess("valid-2", claims)
+	for _, key := range []string{"bad-1", "bad-2", "bad-3"} {
+		cache.StoreFailure(key, errors.New("malformed"))
+	}
+
+	_, ok1, _ := cache.Get(ctx, "valid-1")
+	_, ok2, _ := cache.Get(ctx, "valid-2")
+	assert.True(t, ok1 && ok2, "rejections never evict valid tokens")
+
+	_, okOld, _ := cache.Get(ctx, "bad-2")
+	_, okNew, err := cache.Get(ctx, "bad-3")
+	assert.False(t, okOld)
+	assert.True(t, okNew)
+	assert.EqualError(t, err, "malformed")
+}
+
+func TestDecisionCache_DefaultNegativeLimit(t *testing.T) {
+	assert.Equal(t, 100, NewDecisionCache(DecisionCacheConfig{MaxEntries: 1000}).cache.maxNegativeEntries)
+	assert.Equal(t, 1, NewDecisionCache(DecisionCacheConfig{MaxEntries: 5}).cache.maxNegativeEntries)
+}
+
+func TestDecisionCache_ReturnsDeepCopies(t *testing.T) {
+	cache := NewDecisionCache(DecisionCacheConfig{TTL: time.Minute, MaxEntries: 10})
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	stored := &CustomClaims{
+		WorkspaceID: "ws-12345",
+		Workspaces:  []string{"ws-12345", "ws-67890"},
+		ActorID:     "user-67890",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{testAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	cache.StoreSuccess("token", stored)
+
+	// Alterações do chamador, antes ou depois do Get, não chegam à entrada cacheada
+	stored.Audience[0] = "tampered"
+	stored.ExpiresAt.Time = expiresAt.Add(24 * time.Hour)
+
+	first, ok, err := cache.Get(context.Background(), "token")
+	require.NoError(t, err)
+	require.True(t, ok)
+	first.Audience[0] = "tampered"
+	first.Workspaces[1] = "ws-evil"
+	first.ExpiresAt.Time = expiresAt.Add(24 * time.Hour)
+
+	second, ok, err := cache.Get(context.Background(), "token")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, jwt.ClaimStrings{testAudience}, second.Audience)
+	assert.Equal(t, []string{"ws-12345", "ws-67890"}, second.Workspaces)
+	assert.True(t, second.ExpiresAt.Time.Equal(expiresAt))
+}
@@ -1,33 +1,203 @@
 package auth
 
 import (
+	"context"
+	"strings"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Actor types of the auth context (also recorded in audit_log.actor_type)
+const (
+	ActorTypeUser    = "user"
+	ActorTypeService = "service"
+	// ActorTypeServiceAccount workspace service account authenticated with client credentials
+	ActorTypeServiceAccount = "service_account"
+)
+
 // CustomClaims represents the custom JWT claims for the API
 type CustomClaims struct {
 	WorkspaceID string `json:"workspaceId"`
-	ActorID     string `json:"actorId"`
+	// Workspaces additional workspaces the actor may access with the same token (agency users).
+	// The role is still resolved per workspace from workspace_members.
+	Workspaces []string `json:"workspaces,omitempty"`
+	ActorID    string   `json:"actorId"`
+	// OrgID organization the token is bound to; /v1/orgs/{orgId} must match it when present
+	OrgID string `json:"orgId,omitempty"`
+	// ImpersonatorID admin acting as ActorID (impersonation token); every audit entry keeps it
+	ImpersonatorID string `json:"impersonatorId,omitempty"`
+	// Role papel do ator em WorkspaceID quando o token foi emitido (sessões SSO). Informativo:
+	// a autorização continua consultando workspace_members a cada request.
+	Role string `json:"role,omitempty"`
+	// Scope escopos do token, separados por espaço (RFC 8693 §4.2). Vazio = sem restrição;
+	// tokens de service account sempre trazem escopo.
+	Scope string `json:"scope,omitempty"`
+	// ActorType "service_account" quando ActorID é uma service account (vazio = usuário)
+	ActorType string `json:"actorType,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // Validate performs additional validation on custom claims
 func (c *CustomClaims) Validate() error {
-	if c.WorkspaceID == "" {
+	if c.WorkspaceID == "" && len(c.Workspaces) == 0 {
 		return jwt.ErrTokenInvalidClaims
 	}
+	for _, ws := range c.Workspaces {
+		if ws == "" {
+			return jwt.ErrTokenInvalidClaims
+		}
+	}
 	if c.ActorID == "" {
 		return jwt.ErrTokenInvalidClaims
 	}
+	if c.ImpersonatorID != "" && c.ImpersonatorID == c.ActorID {
+		return jwt.ErrTokenInvalidClaims
+	}
+	if c.ActorType != "" && c.ActorType != ActorTypeServiceAccount {
+		return jwt.ErrTokenInvalidClaims
+	}
+	// Service accounts não podem ser impersonadas e nunca recebem token sem escopo
+	if c.ActorType == ActorTypeServiceAccount && (c.ImpersonatorID != "" || strings.TrimSpace(c.Scope) == "") {
+		return jwt.ErrTokenInvalidClaims
+	}
 	return nil
 }
 
+// AllowedWorkspaces returns workspaceId followed by the workspaces array, without duplicates
+func (c *CustomClaims) AllowedWorkspaces() []string {
+	allowed := make([]string, 0, len(c.Workspaces)+1)
+	seen := make(map[string]bool, len(c.Workspaces)+1)
+	for _, ws := range append([]string{c.WorkspaceID}, c.Workspaces...) {
+		if ws != "" && !seen[ws] {
+			seen[ws] = true
+			allowed = append(allowed, ws)
+		}
+	}
+	return allowed
+}
+
 // AuthContext represents authentication context injected into request context
 type AuthContext struct {
 	WorkspaceID string
+	Workspaces  []string // JWT: every workspace the token grants (workspaceId + workspaces claim)
+	OrgID       string   // JWT: orgId claim (empty = not bound to an organization)
 	ActorID     string
-	ActorType   string // "user", "service", etc.
-	AuthMethod  string // "jwt", "s2s", etc.
-	Issuer      string // For JWT: issuer claim
-	Client      string // For S2S: "crm-web", "mcp", etc.
+	// ImpersonatorID JWT: admin acting as ActorID (empty = not impersonated)
+	ImpersonatorID string
+	ActorType      string // "user", "service", "service_account"
+	AuthMethod     string // "jwt", "s2s", etc.
+	Issuer         string // For JWT: issuer claim
+	Client         string // For S2S: "crm-web", "mcp", etc.
+	// Scopes JWT: scope claim (nil = unrestricted); enforced by middleware.ScopeMiddleware
+	Scopes []string
+}
+
+// authRecorder receives the auth context of the request even when it is set on a derived context,
+// so middlewares that run before authentication (RecoveryMiddleware) can read it afterwards.
+type authRecorder struct {
+	authCtx *AuthContext
+}
+
+type authRecorderKey struct{}
+
+// WithAuthRecorder prepares ctx so the auth context set later in the chain can be read with
+// RecordedAuthContext from ctx itself.
+func WithAuthRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authRecorderKey{}, &authRecorder{})
+}
+
+// RecordedAuthContext returns the auth context recorded for the request, if it was authenticated.
+func RecordedAuthContext(ctx context.Context) (*AuthContext, bool) {
+	rec, ok := ctx.Value(authRecorderKey{}).(*authRecorder)
+	if !ok || rec.authCtx == nil {
+		return nil, false
+	}
+	return rec.authCtx, true
+}
+
+// withAuthContext stores authCtx in ctx and in the request recorder, when there is one.
+func withAuthContext(ctx context.Context, authCtx *AuthContext) context.Context {
+	if rec, ok := ctx.Value(authRecorderKey{}).(*authRecorder); ok {
+		rec.authCtx = authCtx
+	}
+	return context.WithValue(ctx, authContextKey, authCtx)
+}
+
+// newJWTAuthContext builds the auth context of a validated JWT (shared by every JWT middleware)
+func newJWTAuthContext(claims *CustomClaims) *AuthContext {
+	actorType := ActorTypeUser // Default actor type
+	if claims.ActorType == ActorTypeServiceAccount {
+		actorType = ActorTypeServiceAccount
+	}
+	var scopes []string
+	if fields := strings.Fields(claims.Scope); len(fields) > 0 {
+		scopes = fields
+	}
+	return &AuthContext{
+		WorkspaceID:    claims.WorkspaceID,
+		Workspaces:     claims.AllowedWorkspaces(),
+		OrgID:          claims.OrgID,
+		ActorID:        claims.ActorID,
+		ImpersonatorID: claims.ImpersonatorID,
+		ActorType:      actorType,
+		AuthMethod:     "jwt", // Authentication method
+		Issuer:         claims.Issuer,
+		Scopes:         scopes,
+	}
+}
+
+// CanAccessWorkspace reports whether the authenticated principal may act on workspaceID.
+// A context without any workspace binding (S2S without X-Workspace-Id) is not restricted here.
+func (a *AuthContext) CanAccessWorkspace(workspaceID string) bool {
+	if len(a.Workspaces) == 0 {
+		return a.WorkspaceID == "" || a.WorkspaceID == workspaceID
+	}
+	for _, ws := range a.Workspaces {
+		if ws == workspaceID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsScoped reports whether the token is limited to Scopes (service account tokens always are)
+func (a *AuthContext) IsScoped() bool {
+	return a.Scopes != nil || a.ActorType == ActorTypeServiceAccount
+}
+
+// IsImpersonated reports whether the request runs under an impersonation token
+func (a *AuthContext) IsImpersonated() bool {
+	return a.ImpersonatorID != ""
+}
+
+// ImpersonatorIDFromContext returns the admin behind an impersonated request, if any.
+// Used by the audit log so every entry written under impersonation is flagged.
+func ImpersonatorIDFromContext(ctx context.Context) (string, bool) {
+	authCtx, ok := GetAuthContext(ctx)
+	if !ok || !authCtx.IsImpersonated() {
+		return "", false
+	}
+	return authCtx.ImpersonatorID, true
+}
+
+// ActorTypeFromContext returns the actor type of the authenticated request when actorID is the
+// authenticated actor. Used by the audit log to tell service accounts from users.
+func ActorTypeFromContext(ctx context.Context, actorID string) (string, bool) {
+	authCtx, ok := GetAuthContext(ctx)
+	if !ok || authCtx.ActorType == "" || authCtx.ActorID != actorID {
+		return "", false
+	}
+	return authCtx.ActorType, true
+}
+
+// WithServiceAccount returns a context authenticated as the service account. Used by the token
+// endpoint, which runs without a JWT, so its audit entries carry the right actor type.
+func WithServiceAccount(ctx context.Context, workspaceID, accountID string) context.Context {
+	return context.WithValue(ctx, authContextKey, &AuthContext{
+		WorkspaceID: workspaceID,
+		Workspaces:  []string{workspaceID},
+		ActorID:     accountID,
+		ActorType:   ActorTypeServiceAccount,
+		AuthMethod:  "client_credentials",
+	})
 }
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// maxIntrospectionCacheEntries limita a memória do cache; cheio, descarta o resultado usado há mais tempo
+	maxIntrospectionCacheEntries = 10000
+	// maxIntrospectionResponseBytes limita o corpo lido do endpoint de introspecção
+	maxIntrospectionResponseBytes = 1 << 20
+)
+
+// TokenIntrospector validates opaque (non-JWT) tokens of an issuer
+type TokenIntrospector interface {
+	Introspect(ctx context.Context, token string) (*CustomClaims, error)
+}
+
+// introspectionResponse resposta RFC 7662; claims do workspace/ator vêm como extensões
+type introspectionResponse struct {
+	Active bool `json:"active"`
+	CustomClaims
+}
+
+// IntrospectionValidator validates opaque tokens by calling an RFC 7662 introspection endpoint.
+// Only active results are cached (keyed by the token's SHA-256), never beyond the token's exp.
+type IntrospectionValidator struct {
+	issuer       string
+	endpoint     string
+	clientID     string
+	clientSecret string
+	cacheTTL     time.Duration
+	clockSkew    time.Duration
+	httpClient   *http.Client
+	cache        *tokenCache
+}
+
+// NewIntrospectionValidator creates a new introspection validator. cacheTTL 0 disables the cache.
+func NewIntrospectionValidator(issuer, endpoint, clientID, clientSecret string, cacheTTL, clockSkew time.Duration, httpClient *http.Client) *IntrospectionValidator {
+	return &IntrospectionValidator{
+		issuer:       issuer,
+		endpoint:     endpoint,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		cacheTTL:     cacheTTL,
+		clockSkew:    clockSkew,
+		httpClient:   httpClient,
+		cache:        newTokenCache(maxIntrospectionCacheEntries, 0), // só resultados active=true são cacheados,
+	}
+}
+
+// Introspect validates an opaque token, using the cache when the token was recently active
+func (v *IntrospectionValidator) Introspect(ctx context.Context, token string) (*CustomClaims, error) {
+	key := tokenCacheKey(token)
+	if v.cacheTTL > 0 {
+		if claims, ok, _ := v.cache.get(key); ok {
+			return claims, nil
+		}
+	}
+
+	resp, err := v.introspect(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Active {
+		return nil, NewAuthError(AuthFailureUnknown, "token is not active", nil)
+	}
+
+	claims := resp.CustomClaims
+	// RFC 7662 usa sub para o dono do token; actorId tem precedência quando o parceiro o envia
+	if claims.ActorID == "" {
+		claims.ActorID = claims.Subject
+	}
+	if claims.Issuer == "" {
+		claims.Issuer = v.issuer
+	}
+	if claims.ExpiresAt != nil && v.cache.now().After(claims.ExpiresAt.Add(v.clockSkew)) {
+		return nil, NewAuthError(AuthFailureTokenExpired, "token expired", nil)
+	}
+	if err := claims.Validate(); err != nil {
+		return nil, NewAuthError(AuthFailureUnknown, "invalid claims", err)
+	}
+
+	v.store(key, claims)
+	result := claims
+	return &result, nil
+}
+
+// introspect calls the endpoint (POST form, client credentials via Basic auth)
+func (v *IntrospectionValidator) introspect(ctx context.Context, token string) (*introspectionResponse, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, NewAuthError(AuthFailureUnknown, "failed to build introspection request", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if v.clientID != "" {
+		req.SetBasicAuth(v.clientID, v.clientSecret)
+	}
+
+	httpResp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, NewAuthError(AuthFailureUnknown, fmt.Sprintf("introspection request to %s failed", v.issuer), err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, NewAuthError(AuthFailureUnknown, fmt.Sprintf("introspection endpoint of %s returned %d", v.issuer, httpResp.StatusCode), nil)
+	}
+
+	var resp introspectionResponse
+	if err := json.NewDecoder(io.LimitReader(httpResp.Body, maxIntrospectionResponseBytes)).Decode(&resp); err != nil {
+		return nil, NewAuthError(AuthFailureUnknown, "failed to decode introspection response", err)
+	}
+	return &resp, nil
+}
+
+// store cacheia um resultado ativo por cacheTTL, sem passar do exp do token
+func (v *IntrospectionValidator) store(key string, claims CustomClaims) {
+	if v.cacheTTL <= 0 {
+		return
+	}
+	expiresAt := v.cache.now().Add(v.cacheTTL)
+	if claims.ExpiresAt != nil && claims.ExpiresAt.Before(expiresAt) {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	v.cache.set(key, tokenCacheEntry{claims: claims, expiresAt: expiresAt})
+}
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"linkko-api/internal/observability/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOpaqueIssuer = "partner-gateway"
+
+// newIntrospectionServer responds with body for every call and counts the calls
+func newIntrospectionServer(t *testing.T, body map[string]interface{}) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "linkko", user)
+		assert.Equal(t, "s3cret", pass)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "opaque-token-123", r.PostForm.Get("token"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestIntrospectionValidator_ActiveToken(t *testing.T) {
+	srv, calls := newIntrospectionServer(t, map[string]interface{}{
+		"active":      true,
+		"sub":         "partner-user-1",
+		"workspaceId": "ws-12345",
+		"aud":         "linkko-api-gateway",
+		"exp":         time.Now().Add(time.Hour).Unix(),
+	})
+	validator := NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, 0, srv.Client())
+
+	claims, err := validator.Introspect(context.Background(), "opaque-token-123")
+	require.NoError(t, err)
+	assert.Equal(t, "ws-12345", claims.WorkspaceID)
+	assert.Equal(t, "partner-user-1", claims.ActorID, "sub is used when actorId is absent")
+	assert.Equal(t, testOpaqueIssuer, claims.Issuer)
+
+	// Second call is served from the cache
+	_, err = validator.Introspect(context.Background(), "opaque-token-123")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestIntrospectionValidator_CacheExpires(t *testing.T) {
+	srv, calls := newIntrospectionServer(t, map[string]interface{}{
+		"active":      true,
+		"actorId":     "user-67890",
+		"workspaceId": "ws-12345",
+	})
+	validator := NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, 0, srv.Client())
+	now := time.Now()
+	validator.cache.now = func() time.Time { return now }
+
+	_, err := validator.Introspect(context.Background(), "opaque-token-123")
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	_, err = validator.Introspect(context.Background(), "opaque-token-123")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestIntrospectionValidator_InactiveTokenIsNotCached(t *testing.T) {
+	srv, calls := newIntrospectionServer(t, map[string]interface{}{"active": false})
+	validator := NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, 0, srv.Client())
+
+	for i := 0; i < 2; i++ {
+		claims, err := validator.Introspect(context.Background(), "opaque-token-123")
+		require.Error(t, err)
+		assert.Nil(t, claims)
+		authErr, ok := IsAuthError(err)
+		require.True(t, ok)
+		assert.Equal(t, AuthFailureUnknown, authErr.Reason)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestIntrospectionValidator_ExpiredToken(t *testing.T) {
+	srv, _ := newIntrospectionServer(t, map[string]interface{}{
+		"active":      true,
+		"actorId":     "user-67890",
+		"workspaceId": "ws-12345",
+		"exp":         time.Now().Add(-time.Hour).Unix(),
+	})
+	validator := NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, time.Minute, srv.Client())
+
+	_, err := validator.Introspect(context.Background(), "opaque-token-123")
+	authErr, ok := IsAuthError(err)
+	require.True(t, ok)
+	assert.Equal(t, AuthFailureTokenExpired, authErr.Reason)
+}
+
+func TestIntrospectionValidator_MissingWorkspace(t *testing.T) {
+	srv, _ := newIntrospectionServer(t, map[string]interface{}{"active": true, "sub": "partner-user-1"})
+	validator := NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, 0, srv.Client())
+
+	_, err := validator.Introspect(context.Background(), "opaque-token-123")
+	require.Error(t, err)
+}
+
+func TestIntrospectionValidator_EndpointError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	validator := NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "", "", time.Minute, 0, srv.Client())
+
+	_, err := validator.Introspect(context.Background(), "opaque-token-123")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
+
+func TestKeyResolver_OpaqueIssuer(t *testing.T) {
+	srv, _ := newIntrospectionServer(t, map[string]interface{}{
+		"active":      true,
+		"sub":         "partner-user-1",
+		"workspaceId": "ws-12345",
+		"aud":         "partner-api",
+	})
+
+	keyStore := NewKeyStore()
+	keyStore.LoadHS256Key(testIssuer, "v1", []byte(testSecret))
+	resolver := NewKeyResolver([]string{testIssuer}, []string{testAudience})
+	resolver.RegisterValidator(testIssuer, NewHS256Validator(keyStore, testIssuer, 60*time.Second))
+	resolver.RegisterOpaqueIssuer(testOpaqueIssuer,
+		NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, 0, srv.Client()),
+		[]string{"partner-api"}, "")
+
+	t.Run("opaque token is introspected", func(t *testing.T) {
+		assert.True(t, resolver.IsOpaqueToken("opaque-token-123"))
+
+		claims, err := resolver.Resolve(context.Background(), "opaque-token-123")
+		require.NoError(t, err)
+		assert.Equal(t, testOpaqueIssuer, claims.Issuer)
+		assert.Equal(t, "partner-user-1", claims.ActorID)
+	})
+
+	t.Run("JWT keeps the signature path", func(t *testing.T) {
+		token := createTestToken(testSecret, &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}, time.Now().Add(time.Hour))
+		assert.False(t, resolver.IsOpaqueToken(token))
+
+		claims, err := resolver.Resolve(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, testIssuer, claims.Issuer)
+	})
+}
+
+func TestKeyResolver_OpaqueIssuerAudience(t *testing.T) {
+	srv, _ := newIntrospectionServer(t, map[string]interface{}{
+		"active":      true,
+		"sub":         "partner-user-1",
+		"workspaceId": "ws-12345",
+		"aud":         "someone-else",
+	})
+	resolver := NewKeyResolver(nil, []string{testAudience})
+	resolver.RegisterOpaqueIssuer(testOpaqueIssuer,
+		NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, 0, srv.Client()),
+		[]string{"partner-api"}, "")
+
+	_, err := resolver.Resolve(context.Background(), "opaque-token-123")
+	authErr, ok := IsAuthError(err)
+	require.True(t, ok)
+	assert.Equal(t, AuthFailureInvalidAudience, authErr.Reason)
+}
+
+func TestKeyResolver_OpaqueTokenPrefix(t *testing.T) {
+	resolver := NewKeyResolver(nil, []string{testAudience})
+	resolver.RegisterOpaqueIssuer("partner-a", nil, nil, "pa_")
+	resolver.RegisterOpaqueIssuer("partner-b", nil, nil, "pb_")
+
+	oi, ok := resolver.opaqueIssuerFor("pb_abc")
+	require.True(t, ok)
+	assert.Equal(t, "partner-b", oi.issuer)
+
+	assert.False(t, resolver.IsOpaqueToken("other-token"), "no issuer without prefix")
+}
+
+func TestAuthMiddleware_OpaqueTokenAndS2S(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+
+	srv, _ := newIntrospectionServer(t, map[string]interface{}{
+		"active":      true,
+		"sub":         "partner-user-1",
+		"workspaceId": "ws-12345",
+		"aud":         testAudience,
+	})
+
+	store := NewS2STokenStore()
+	store.RegisterToken("test-s2s-token-crm", "crm-web")
+	resolver := NewKeyResolver(nil, []string{testAudience})
+	resolver.RegisterOpaqueIssuer(testOpaqueIssuer,
+		NewIntrospectionValidator(testOpaqueIssuer, srv.URL, "linkko", "s3cret", time.Minute, 0, srv.Client()),
+		nil, "")
+
+	tests := []struct {
+		name       string
+		token      string
+		authMethod string
+		actorID    string
+	}{
+		{"opaque token", "opaque-token-123", "jwt", "partner-user-1"},
+		{"S2S token is not introspected", "test-s2s-token-crm", "s2s", "service-456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", nil).WithContext(ctx)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			req.Header.Set("X-Workspace-Id", "ws-12345")
+			req.Header.Set("X-Actor-Id", "service-456")
+			rr := httptest.NewRecorder()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authCtx, ok := GetAuthContext(r.Context())
+				require.True(t, ok)
+				assert.Equal(t, tt.authMethod, authCtx.AuthMethod)
+				assert.Equal(t, tt.actorID, authCtx.ActorID)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			AuthMiddleware(resolver, store)(handler).ServeHTTP(rr, req)
+			assert.Equal(t, http.StatusOK, rr.Code)
+		})
+	}
+}
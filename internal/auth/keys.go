@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"fmt"
 	"strings"
@@ -10,8 +13,10 @@ import (
 
 // KeyStore manages JWT signing keys by issuer and kid
 type KeyStore struct {
-	hs256Keys map[string]map[string][]byte         // issuer -> kid -> secret
-	rs256Keys map[string]map[string]*rsa.PublicKey // issuer -> kid -> public key
+	hs256Keys map[string]map[string][]byte            // issuer -> kid -> secret
+	rs256Keys map[string]map[string]*rsa.PublicKey    // issuer -> kid -> public key
+	es256Keys map[string]map[string]*ecdsa.PublicKey  // issuer -> kid -> P-256 public key
+	eddsaKeys map[string]map[string]ed25519.PublicKey // issuer -> kid -> Ed25519 public key
 }
 
 // NewKeyStore creates a new KeyStore
@@ -19,6 +24,8 @@ func NewKeyStore() *KeyStore {
 	return &KeyStore{
 		hs256Keys: make(map[string]map[string][]byte),
 		rs256Keys: make(map[string]map[string]*rsa.PublicKey),
+		es256Keys: make(map[string]map[string]*ecdsa.PublicKey),
+		eddsaKeys: make(map[string]map[string]ed25519.PublicKey),
 	}
 }
 
@@ -32,14 +39,7 @@ func (ks *KeyStore) LoadHS256Key(issuer, kid string, secret []byte) {
 
 // LoadRS256Key adds an RS256 public key for an issuer and kid
 func (ks *KeyStore) LoadRS256Key(issuer, kid string, publicKeyPEM string) error {
-	// CORREÇÃO: Normalizar \n literais para quebras de linha reais
-	// Isso resolve o problema quando a chave vem de variáveis de ambiente
-	normalizedPEM := strings.ReplaceAll(publicKeyPEM, `\n`, "\n")
-
-	// Também limpar espaços extras no início e fim
-	normalizedPEM = strings.TrimSpace(normalizedPEM)
-
-	publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(normalizedPEM))
+	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(normalizePEM(publicKeyPEM))
 	if err != nil {
 		return fmt.Errorf("failed to parse RSA public key: %w", err)
 	}
@@ -51,6 +51,42 @@ func (ks *KeyStore) LoadRS256Key(issuer, kid string, publicKeyPEM string) error
 	return nil
 }
 
+// LoadES256Key adds an ECDSA P-256 public key for an issuer and kid
+func (ks *KeyStore) LoadES256Key(issuer, kid string, publicKeyPEM string) error {
+	publicKey, err := jwt.ParseECPublicKeyFromPEM(normalizePEM(publicKeyPEM))
+	if err != nil {
+		return fmt.Errorf("failed to parse ECDSA public key: %w", err)
+	}
+	// ES256 exige P-256; outras curvas pertencem a ES384/ES512
+	if publicKey.Curve != elliptic.P256() {
+		return fmt.Errorf("ECDSA public key must use curve P-256, got %s", publicKey.Curve.Params().Name)
+	}
+
+	if _, ok := ks.es256Keys[issuer]; !ok {
+		ks.es256Keys[issuer] = make(map[string]*ecdsa.PublicKey)
+	}
+	ks.es256Keys[issuer][kid] = publicKey
+	return nil
+}
+
+// LoadEdDSAKey adds an Ed25519 public key for an issuer and kid
+func (ks *KeyStore) LoadEdDSAKey(issuer, kid string, publicKeyPEM string) error {
+	key, err := jwt.ParseEdPublicKeyFromPEM(normalizePEM(publicKeyPEM))
+	if err != nil {
+		return fmt.Errorf("failed to parse Ed25519 public key: %w", err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("public key is not an Ed25519 key")
+	}
+
+	if _, ok := ks.eddsaKeys[issuer]; !ok {
+		ks.eddsaKeys[issuer] = make(map[string]ed25519.PublicKey)
+	}
+	ks.eddsaKeys[issuer][kid] = publicKey
+	return nil
+}
+
 // GetHS256Key retrieves an HS256 secret for an issuer and kid
 func (ks *KeyStore) GetHS256Key(issuer, kid string) ([]byte, bool) {
 	if keys, ok := ks.hs256Keys[issuer]; ok {
@@ -70,3 +106,29 @@ func (ks *KeyStore) GetRS256Key(issuer, kid string) (*rsa.PublicKey, bool) {
 	}
 	return nil, false
 }
+
+// GetES256Key retrieves an ES256 public key for an issuer and kid
+func (ks *KeyStore) GetES256Key(issuer, kid string) (*ecdsa.PublicKey, bool) {
+	if keys, ok := ks.es256Keys[issuer]; ok {
+		if publicKey, ok := keys[kid]; ok {
+			return publicKey, true
+		}
+	}
+	return nil, false
+}
+
+// GetEdDSAKey retrieves an Ed25519 public key for an issuer and kid
+func (ks *KeyStore) GetEdDSAKey(issuer, kid string) (ed25519.PublicKey, bool) {
+	if keys, ok := ks.eddsaKeys[issuer]; ok {
+		if publicKey, ok := keys[kid]; ok {
+			return publicKey, true
+		}
+	}
+	return nil, false
+}
+
+// normalizePEM converte \n literais em quebras de linha reais e remove espaços nas pontas.
+// Isso resolve o problema quando a chave vem de variáveis de ambiente.
+func normalizePEM(publicKeyPEM string) []byte {
+	return []byte(strings.TrimSpace(strings.ReplaceAll(publicKeyPEM, `\n`, "\n")))
+}
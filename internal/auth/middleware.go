@@ -77,22 +77,18 @@ func JWTAuthMiddleware(resolver *KeyResolver) func(http.Handler) http.Handler {
 			}
 
 			// Create auth context with metadata
-			authCtx := &AuthContext{
-				WorkspaceID: claims.WorkspaceID,
-				ActorID:     claims.ActorID,
-				ActorType:   "user", // Default actor type
-				AuthMethod:  "jwt",  // Authentication method
-				Issuer:      claims.Issuer,
-			}
+			authCtx := newJWTAuthContext(claims)
 
 			// Add claims and auth context to request context
 			ctx := context.WithValue(r.Context(), claimsContextKey, claims)
-			ctx = context.WithValue(ctx, authContextKey, authCtx)
+			ctx = withAuthContext(ctx, authCtx)
 
 			// Log successful authentication
 			log.Info(r.Context(), "authenticated request",
 				zap.String("workspace_id", claims.WorkspaceID),
+				zap.Int("workspace_count", len(authCtx.Workspaces)),
 				zap.String("actor_id", claims.ActorID),
+				zap.String("impersonator_id", claims.ImpersonatorID),
 				zap.String("actor_type", authCtx.ActorType),
 				zap.String("auth_method", authCtx.AuthMethod),
 				zap.String("issuer", claims.Issuer),
@@ -0,0 +1,323 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// oidcKeysTTL por quanto tempo o JWKS de um issuer é reaproveitado
+	oidcKeysTTL = time.Hour
+	// oidcMinRefreshInterval intervalo mínimo entre downloads do JWKS quando chega um kid desconhecido
+	// (rotação de chave no IdP); evita que tokens forjados forcem uma requisição por chamada
+	oidcMinRefreshInterval = time.Minute
+	// maxOIDCResponseBytes limita o corpo lido do discovery e do JWKS
+	maxOIDCResponseBytes = 1 << 20
+)
+
+// OIDCIdentity identity asserted by a validated id_token
+type OIDCIdentity struct {
+	Issuer  string
+	Subject string
+	Email   string
+	// EmailVerified nil when the IdP does not send email_verified (Azure AD)
+	EmailVerified *bool
+	Name          string
+}
+
+// oidcClaims claims do id_token (OpenID Connect Core §2)
+type oidcClaims struct {
+	Email             string      `json:"email"`
+	EmailVerified     interface{} `json:"email_verified"` // bool; alguns IdPs enviam "true"/"false"
+	Name              string      `json:"name"`
+	PreferredUsername string      `json:"preferred_username"`
+	Nonce             string      `json:"nonce"`
+	AuthorizedParty   string      `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// oidcKeySet chaves públicas de um issuer (kid -> chave)
+type oidcKeySet struct {
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+// OIDCVerifier validates id_tokens of external OpenID Connect providers.
+// Keys come from the issuer discovery document (jwks_uri) and are cached per issuer.
+type OIDCVerifier struct {
+	httpClient *http.Client
+	clockSkew  time.Duration
+
+	mu      sync.Mutex
+	keySets map[string]*oidcKeySet
+}
+
+// NewOIDCVerifier creates a new OIDC verifier
+func NewOIDCVerifier(httpClient *http.Client, clockSkew time.Duration) *OIDCVerifier {
+	return &OIDCVerifier{
+		httpClient: httpClient,
+		clockSkew:  clockSkew,
+		keySets:    make(map[string]*oidcKeySet),
+	}
+}
+
+// UnverifiedIssuer returns the iss claim of a JWT without checking its signature.
+// Only used to pick the provider configuration; Verify checks the issuer again.
+func UnverifiedIssuer(rawToken string) (string, error) {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawToken, &claims); err != nil {
+		return "", NewAuthError(AuthFailureUnknown, "malformed id_token", err)
+	}
+	if claims.Issuer == "" {
+		return "", NewAuthError(AuthFailureInvalidIssuer, "id_token has no issuer", nil)
+	}
+	return claims.Issuer, nil
+}
+
+// Verify validates signature, iss, aud (clientID), exp and, when informed, the nonce of an id_token
+func (v *OIDCVerifier) Verify(ctx context.Context, issuer, clientID, rawToken, nonce string) (*OIDCIdentity, error) {
+	claims := &oidcClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.key(ctx, issuer, kid)
+	},
+		jwt.WithLeeway(v.clockSkew),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, jwt.ErrTokenExpired):
+			return nil, NewAuthError(AuthFailureTokenExpired, "id_token expired", err)
+		case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+			return nil, NewAuthError(AuthFailureInvalidSignature, "invalid id_token signature", err)
+		case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+			return nil, NewAuthError(AuthFailureInvalidIssuer, "invalid id_token issuer", err)
+		case errors.Is(err, jwt.ErrTokenInvalidAudience):
+			return nil, NewAuthError(AuthFailureInvalidAudience, "invalid id_token audience", err)
+		}
+		return nil, NewAuthError(AuthFailureUnknown, "failed to parse id_token", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, NewAuthError(AuthFailureUnknown, "id_token has no subject", nil)
+	}
+	// Com mais de uma audiência, o token precisa ter sido emitido para este client (Core §3.1.3.7)
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != clientID {
+		return nil, NewAuthError(AuthFailureInvalidAudience, "id_token azp does not match client", nil)
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, NewAuthError(AuthFailureUnknown, "id_token nonce mismatch", nil)
+	}
+
+	identity := &OIDCIdentity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified: parseEmailVerified(claims.EmailVerified),
+		Name:          strings.TrimSpace(claims.Name),
+	}
+	// Azure AD nem sempre envia email; o UPN (preferred_username) tem o mesmo formato
+	if identity.Email == "" && strings.Contains(claims.PreferredUsername, "@") {
+		identity.Email = strings.ToLower(strings.TrimSpace(claims.PreferredUsername))
+	}
+	return identity, nil
+}
+
+// key returns the public key of issuer/kid, downloading the JWKS when it is missing or stale.
+// An empty kid is accepted when the issuer publishes a single key.
+func (v *OIDCVerifier) key(ctx context.Context, issuer, kid string) (interface{}, error) {
+	v.mu.Lock()
+	set := v.keySets[issuer]
+	v.mu.Unlock()
+
+	now := time.Now()
+	if set != nil && now.Sub(set.fetchedAt) < oidcKeysTTL {
+		if key, ok := set.lookup(kid); ok {
+			return key, nil
+		}
+		if now.Sub(set.fetchedAt) < oidcMinRefreshInterval {
+			return nil, fmt.Errorf("unknown key id %q for issuer %s", kid, issuer)
+		}
+	}
+
+	keys, err := v.fetchKeys(ctx, issuer)
+	if err != nil {
+		return nil, err
+	}
+	set = &oidcKeySet{keys: keys, fetchedAt: now}
+	v.mu.Lock()
+	v.keySets[issuer] = set
+	v.mu.Unlock()
+
+	if key, ok := set.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %q for issuer %s", kid, issuer)
+}
+
+func (s *oidcKeySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" {
+		if len(s.keys) == 1 {
+			for _, key := range s.keys {
+				return key, true
+			}
+		}
+		return nil, false
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// fetchKeys lê o discovery do issuer e as chaves de assinatura do jwks_uri
+func (v *OIDCVerifier) fetchKeys(ctx context.Context, issuer string) (map[string]interface{}, error) {
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := v.getJSON(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	// O documento precisa ser do próprio issuer (Discovery §4.3)
+	if discovery.Issuer != issuer {
+		return nil, fmt.Errorf("oidc discovery issuer %q does not match %q", discovery.Issuer, issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery of %s has no jwks_uri", issuer)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(ctx, discovery.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("oidc jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Chaves de tipos não suportados não impedem o uso das demais
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("oidc jwks of %s has no usable signing keys", issuer)
+	}
+	return keys, nil
+}
+
+func (v *OIDCVerifier) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCResponseBytes)).Decode(out); err != nil {
+		return fmt.Errorf("decode %s: %w", url, err)
+	}
+	return nil
+}
+
+// jsonWebKey chave pública de um JWKS (RFC 7517); somente RSA e EC P-256
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode n: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode e: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode y: %w", err)
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid P-256 coordinates")
+		}
+		// ecdh valida que o ponto está na curva
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, fmt.Errorf("invalid P-256 point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+}
+
+func parseEmailVerified(v interface{}) *bool {
+	var verified bool
+	switch value := v.(type) {
+	case bool:
+		verified = value
+	case string:
+		switch strings.ToLower(value) {
+		case "true":
+			verified = true
+		case "false":
+			verified = false
+		default:
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &verified
+}
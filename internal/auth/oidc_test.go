@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOIDCClientID = "linkko-web"
+
+// newOIDCProvider serves discovery and JWKS for key (kid "k1") and counts the JWKS downloads
+func newOIDCProvider(t *testing.T, key *rsa.PrivateKey) (*httptest.Server, *int32) {
+	t.Helper()
+	var jwksCalls int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{"issuer": srv.URL, "jwks_uri": srv.URL + "/keys"})
+		case "/keys":
+			atomic.AddInt32(&jwksCalls, 1)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "k1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &jwksCalls
+}
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func idTokenClaims(issuer string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            issuer,
+		"aud":            testOIDCClientID,
+		"sub":            "00u1abc",
+		"email":          "Ana@Acme.com",
+		"email_verified": true,
+		"name":           "Ana Souza",
+		"nonce":          "n-123",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+	}
+}
+
+func TestOIDCVerifier_ValidToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	srv, jwksCalls := newOIDCProvider(t, key)
+	verifier := NewOIDCVerifier(srv.Client(), 0)
+
+	raw := signIDToken(t, key, "k1", idTokenClaims(srv.URL))
+
+	issuer, err := UnverifiedIssuer(raw)
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL, issuer)
+
+	identity, err := verifier.Verify(context.Background(), srv.URL, testOIDCClientID, raw, "n-123")
+	require.NoError(t, err)
+	assert.Equal(t, "00u1abc", identity.Subject)
+	assert.Equal(t, "ana@acme.com", identity.Email)
+	require.NotNil(t, identity.EmailVerified)
+	assert.True(t, *identity.EmailVerified)
+	assert.Equal(t, "Ana Souza", identity.Name)
+
+	// Keys are cached per issuer
+	_, err = verifier.Verify(context.Background(), srv.URL, testOIDCClientID, raw, "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(jwksCalls))
+}
+
+func TestOIDCVerifier_Rejections(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	srv, _ := newOIDCProvider(t, key)
+
+	tests := []struct {
+		name   string
+		token  func() string
+		nonce  string
+		reason AuthFailureReason
+	}{
+		{
+			name: "wrong audience",
+			token: func() string {
+				c := idTokenClaims(srv.URL)
+				c["aud"] = "other-app"
+				return signIDToken(t, key, "k1", c)
+			},
+			reason: AuthFailureInvalidAudience,
+		},
+		{
+			name:   "wrong issuer",
+			token:  func() string { c := idTokenClaims("https://evil.example"); return signIDToken(t, key, "k1", c) },
+			reason: AuthFailureInvalidIssuer,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				c := idTokenClaims(srv.URL)
+				c["exp"] = time.Now().Add(-time.Hour).Unix()
+				return signIDToken(t, key, "k1", c)
+			},
+			reason: AuthFailureTokenExpired,
+		},
+		{
+			name:   "signed with another key",
+			token:  func() string { return signIDToken(t, otherKey, "k1", idTokenClaims(srv.URL)) },
+			reason: AuthFailureInvalidSignature,
+		},
+		{
+			name:   "nonce mismatch",
+			token:  func() string { return signIDToken(t, key, "k1", idTokenClaims(srv.URL)) },
+			nonce:  "other-nonce",
+			reason: AuthFailureUnknown,
+		},
+		{
+			name: "azp required with multiple audiences",
+			token: func() string {
+				c := idTokenClaims(srv.URL)
+				c["aud"] = []string{testOIDCClientID, "other-app"}
+				return signIDToken(t, key, "k1", c)
+			},
+			reason: AuthFailureInvalidAudience,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewOIDCVerifier(srv.Client(), 0)
+			_, err := verifier.Verify(context.Background(), srv.URL, testOIDCClientID, tt.token(), tt.nonce)
+			require.Error(t, err)
+			var authErr *AuthError
+			require.ErrorAs(t, err, &authErr)
+			assert.Equal(t, tt.reason, authErr.Reason)
+		})
+	}
+}
+
+func TestOIDCVerifier_UnknownKidRefreshIsThrottled(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	srv, jwksCalls := newOIDCProvider(t, key)
+	verifier := NewOIDCVerifier(srv.Client(), 0)
+
+	_, err = verifier.Verify(context.Background(), srv.URL, testOIDCClientID, signIDToken(t, key, "k1", idTokenClaims(srv.URL)), "")
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = verifier.Verify(context.Background(), srv.URL, testOIDCClientID, signIDToken(t, key, "rotated", idTokenClaims(srv.URL)), "")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(jwksCalls), "unknown kids do not refetch the JWKS within a minute")
+}
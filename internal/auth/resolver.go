@@ -4,19 +4,37 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"linkko-api/internal/logger"
 
+	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
 )
 
 // KeyResolver resolves JWT validators based on issuer and kid
 type KeyResolver struct {
+	mu               sync.RWMutex // allowedIssuers/validators/issuerAudiences mudam em SetAllowedIssuers
 	validators       map[string]TokenValidator
 	allowedIssuers   map[string]bool
-	allowedAudiences []string
+	allowedAudiences []string            // default for issuers without their own list
+	issuerAudiences  map[string][]string // issuer -> audiences (JWT_ISSUERS)
+	opaqueIssuers    []opaqueIssuer      // issuers de tokens opacos, na ordem de registro
+	decisionCache    *DecisionCache      // nil = toda requisição valida a assinatura
+}
+
+// errMalformedToken token que não pode ser decodificado como JWT (candidato ao cache negativo)
+var errMalformedToken = errors.New("malformed token")
+
+// opaqueIssuer issuer cujos tokens não são JWT e são validados por introspecção (RFC 7662)
+type opaqueIssuer struct {
+	issuer       string
+	tokenPrefix  string // vazio = qualquer token que não seja JWT
+	introspector TokenIntrospector
 }
 
 // NewKeyResolver creates a new KeyResolver
@@ -30,22 +48,166 @@ func NewKeyResolver(allowedIssuers []string, allowedAudiences []string) *KeyReso
 		validators:       make(map[string]TokenValidator),
 		allowedIssuers:   issuersMap,
 		allowedAudiences: allowedAudiences,
+		issuerAudiences:  make(map[string][]string),
 	}
 }
 
 // RegisterValidator registers a validator for an issuer
 func (kr *KeyResolver) RegisterValidator(issuer string, validator TokenValidator) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.validators[issuer] = validator
+}
+
+// RegisterIssuer allows an issuer with its own validator (and clock skew) and audiences.
+// Empty audiences fall back to the resolver-wide list.
+func (kr *KeyResolver) RegisterIssuer(issuer string, validator TokenValidator, audiences []string) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.allowedIssuers[issuer] = true
 	kr.validators[issuer] = validator
+	if len(audiences) > 0 {
+		kr.issuerAudiences[issuer] = audiences
+	}
+}
+
+// RegisterOpaqueIssuer allows an issuer whose opaque tokens are validated by introspection.
+// Tokens starting with tokenPrefix are routed to it; an empty prefix takes every non-JWT token.
+func (kr *KeyResolver) RegisterOpaqueIssuer(issuer string, introspector TokenIntrospector, audiences []string, tokenPrefix string) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.allowedIssuers[issuer] = true
+	kr.opaqueIssuers = append(kr.opaqueIssuers, opaqueIssuer{
+		issuer:       issuer,
+		tokenPrefix:  tokenPrefix,
+		introspector: introspector,
+	})
+	if len(audiences) > 0 {
+		kr.issuerAudiences[issuer] = audiences
+	}
+}
+
+// SetAllowedIssuers replaces the issuer allowlist (hot reload of JWT_ALLOWED_ISSUERS). Issuers
+// must already be registered to validate tokens; decisões cacheadas são descartadas para que um
+// issuer removido pare de ser aceito imediatamente.
+func (kr *KeyResolver) SetAllowedIssuers(issuers []string) {
+	allowed := make(map[string]bool, len(issuers))
+	for _, issuer := range issuers {
+		allowed[issuer] = true
+	}
+
+	kr.mu.Lock()
+	kr.allowedIssuers = allowed
+	kr.mu.Unlock()
+
+	if kr.decisionCache != nil {
+		kr.decisionCache.Purge()
+	}
+}
+
+// AllowedIssuers returns the current allowlist, sorted
+func (kr *KeyResolver) AllowedIssuers() []string {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	issuers := make([]string, 0, len(kr.allowedIssuers))
+	for issuer := range kr.allowedIssuers {
+		issuers = append(issuers, issuer)
+	}
+	sort.Strings(issuers)
+	return issuers
+}
+
+// HasIssuer reports whether a validator (JWT or opaque) is registered for the issuer
+func (kr *KeyResolver) HasIssuer(issuer string) bool {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	if _, ok := kr.validators[issuer]; ok {
+		return true
+	}
+	for _, oi := range kr.opaqueIssuers {
+		if oi.issuer == issuer {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDecisionCache enables caching of JWT validation outcomes (opaque tokens use the
+// introspection cache instead)
+func (kr *KeyResolver) SetDecisionCache(cache *DecisionCache) {
+	kr.decisionCache = cache
+}
+
+// IsOpaqueToken reports whether the token is routed to an opaque issuer
+func (kr *KeyResolver) IsOpaqueToken(tokenString string) bool {
+	_, ok := kr.opaqueIssuerFor(tokenString)
+	return ok
 }
 
-// Resolve validates a JWT token by resolving the appropriate validator
+// opaqueIssuerFor prefixos explícitos vencem; o issuer sem prefixo só recebe tokens que não parecem JWT
+func (kr *KeyResolver) opaqueIssuerFor(tokenString string) (opaqueIssuer, bool) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	for _, oi := range kr.opaqueIssuers {
+		if oi.tokenPrefix != "" && strings.HasPrefix(tokenString, oi.tokenPrefix) {
+			return oi, true
+		}
+	}
+	if isJWTToken(tokenString) {
+		return opaqueIssuer{}, false
+	}
+	for _, oi := range kr.opaqueIssuers {
+		if oi.tokenPrefix == "" {
+			return oi, true
+		}
+	}
+	return opaqueIssuer{}, false
+}
+
+// Resolve validates a token by resolving the appropriate validator. With a decision cache,
+// repeated JWTs skip signature verification until the cached decision expires.
 func (kr *KeyResolver) Resolve(ctx context.Context, tokenString string) (*CustomClaims, error) {
 	log := logger.GetLogger(ctx)
 
+	// Opaque tokens: introspection instead of signature validation
+	if oi, ok := kr.opaqueIssuerFor(tokenString); ok {
+		log.Debug("opaque token routed to introspection", zap.String("issuer", oi.issuer))
+		claims, err := oi.introspector.Introspect(ctx, tokenString)
+		if err != nil {
+			if _, ok := IsAuthError(err); ok {
+				return nil, err
+			}
+			return nil, NewAuthError(AuthFailureUnknown, "token introspection failed", err)
+		}
+		return kr.verifyIssuerAndAudience(oi.issuer, claims)
+	}
+
+	if kr.decisionCache == nil {
+		return kr.resolveJWT(ctx, tokenString)
+	}
+
+	key := tokenCacheKey(tokenString)
+	if claims, ok, err := kr.decisionCache.Get(ctx, key); ok {
+		return claims, err
+	}
+	claims, err := kr.resolveJWT(ctx, tokenString)
+	switch {
+	case err == nil:
+		kr.decisionCache.StoreSuccess(key, claims)
+	case errors.Is(err, errMalformedToken) || errors.Is(err, jwt.ErrTokenMalformed):
+		kr.decisionCache.StoreFailure(key, err)
+	}
+	return claims, err
+}
+
+// resolveJWT validates a JWT signature and claims with the validator of its issuer
+func (kr *KeyResolver) resolveJWT(ctx context.Context, tokenString string) (*CustomClaims, error) {
+	log := logger.GetLogger(ctx)
+
 	// Extract issuer and kid from JWT header without validating signature
 	issuer, kid, originalKid, err := kr.extractHeaderInfo(tokenString)
 	if err != nil {
-		return nil, NewAuthError(AuthFailureUnknown, "failed to extract header info", err)
+		return nil, NewAuthError(AuthFailureUnknown, "failed to extract header info", fmt.Errorf("%w: %w", errMalformedToken, err))
 	}
 
 	// Log kid selection for debugging
@@ -63,12 +225,15 @@ func (kr *KeyResolver) Resolve(ctx context.Context, tokenString string) (*Custom
 	}
 
 	// Check if issuer is allowed
-	if !kr.allowedIssuers[issuer] {
+	kr.mu.RLock()
+	allowed := kr.allowedIssuers[issuer]
+	validator, ok := kr.validators[issuer]
+	kr.mu.RUnlock()
+	if !allowed {
 		return nil, NewAuthError(AuthFailureInvalidIssuer, fmt.Sprintf("issuer not allowed: %s", issuer), nil)
 	}
 
 	// Get validator for issuer
-	validator, ok := kr.validators[issuer]
 	if !ok {
 		return nil, NewAuthError(AuthFailureInvalidIssuer, fmt.Sprintf("no validator found for issuer: %s", issuer), nil)
 	}
@@ -83,13 +248,25 @@ func (kr *KeyResolver) Resolve(ctx context.Context, tokenString string) (*Custom
 		return nil, NewAuthError(AuthFailureUnknown, "token validation failed", err)
 	}
 
+	return kr.verifyIssuerAndAudience(issuer, claims)
+}
+
+// verifyIssuerAndAudience checks the validated claims against the issuer they were routed to
+func (kr *KeyResolver) verifyIssuerAndAudience(issuer string, claims *CustomClaims) (*CustomClaims, error) {
+	kr.mu.RLock()
+	allowed := kr.allowedIssuers[issuer]
+	kr.mu.RUnlock()
+	if !allowed {
+		return nil, NewAuthError(AuthFailureInvalidIssuer, fmt.Sprintf("issuer not allowed: %s", issuer), nil)
+	}
+
 	// Verify issuer claim
 	if claims.Issuer != issuer {
 		return nil, NewAuthError(AuthFailureInvalidIssuer, fmt.Sprintf("issuer mismatch: expected %s, got %s", issuer, claims.Issuer), nil)
 	}
 
 	// Verify audience
-	if !kr.validAudience(claims.Audience) {
+	if !kr.validAudience(issuer, claims.Audience) {
 		return nil, NewAuthError(AuthFailureInvalidAudience, fmt.Sprintf("invalid audience: %v", claims.Audience), nil)
 	}
 
@@ -146,10 +323,16 @@ func (kr *KeyResolver) extractHeaderInfo(tokenString string) (string, string, st
 	return payload.Issuer, selectedKid, originalKid, nil
 }
 
-// validAudience checks if any audience claim matches allowed audiences
-func (kr *KeyResolver) validAudience(audiences []string) bool {
+// validAudience checks if any audience claim matches the audiences allowed for the issuer
+func (kr *KeyResolver) validAudience(issuer string, audiences []string) bool {
+	kr.mu.RLock()
+	allowedAudiences, ok := kr.issuerAudiences[issuer]
+	kr.mu.RUnlock()
+	if !ok {
+		allowedAudiences = kr.allowedAudiences
+	}
 	for _, aud := range audiences {
-		for _, allowed := range kr.allowedAudiences {
+		for _, allowed := range allowedAudiences {
 			if aud == allowed {
 				return true
 			}
@@ -445,3 +445,80 @@ func TestKeyResolver_MultipleAllowedAudiences(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyResolver_PerIssuerAudience(t *testing.T) {
+	const mcpIssuer = "linkko-mcp-server"
+
+	keyStore := NewKeyStore()
+	keyStore.LoadHS256Key(testIssuer, "v1", []byte(testSecret))
+	keyStore.LoadHS256Key(mcpIssuer, "v1", []byte(testSecret))
+
+	// RegisterIssuer adiciona o issuer à allowlist com audiência própria
+	resolver := NewKeyResolver([]string{testIssuer}, []string{testAudience})
+	resolver.RegisterValidator(testIssuer, NewHS256Validator(keyStore, testIssuer, 60*time.Second))
+	resolver.RegisterIssuer(mcpIssuer, NewHS256Validator(keyStore, mcpIssuer, 5*time.Second), []string{"linkko-mcp"})
+
+	sign := func(issuer, audience string) string {
+		claims := &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}
+		claims.RegisteredClaims = jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, _ := token.SignedString([]byte(testSecret))
+		return tokenString
+	}
+
+	ctx := context.Background()
+
+	t.Run("MCP issuer accepts its own audience", func(t *testing.T) {
+		result, err := resolver.Resolve(ctx, sign(mcpIssuer, "linkko-mcp"))
+		require.NoError(t, err)
+		assert.Equal(t, mcpIssuer, result.Issuer)
+	})
+
+	t.Run("MCP issuer rejects the default audience", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, sign(mcpIssuer, testAudience))
+		require.Error(t, err)
+		authErr, ok := IsAuthError(err)
+		require.True(t, ok)
+		assert.Equal(t, AuthFailureInvalidAudience, authErr.Reason)
+	})
+
+	t.Run("CRM issuer keeps the default audience", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, sign(testIssuer, testAudience))
+		require.NoError(t, err)
+
+		_, err = resolver.Resolve(ctx, sign(testIssuer, "linkko-mcp"))
+		require.Error(t, err)
+	})
+}
+
+func TestKeyResolver_SetAllowedIssuers(t *testing.T) {
+	keyStore := NewKeyStore()
+	keyStore.LoadHS256Key(testIssuer, "v1", []byte(testSecret))
+
+	resolver := NewKeyResolver(nil, []string{testAudience})
+	resolver.RegisterIssuer(testIssuer, NewHS256Validator(keyStore, testIssuer, 60*time.Second), nil)
+	resolver.SetDecisionCache(NewDecisionCache(DecisionCacheConfig{TTL: time.Minute, MaxEntries: 10}))
+
+	token := createTestToken(testSecret, &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}, time.Now().Add(1*time.Hour))
+	ctx := context.Background()
+
+	_, err := resolver.Resolve(ctx, token)
+	require.NoError(t, err)
+	assert.True(t, resolver.HasIssuer(testIssuer))
+
+	// Removido da allowlist: rejeitado mesmo com decisão em cache
+	resolver.SetAllowedIssuers([]string{"linkko-admin-portal"})
+	assert.Equal(t, []string{"linkko-admin-portal"}, resolver.AllowedIssuers())
+	_, err = resolver.Resolve(ctx, token)
+	authErr, ok := IsAuthError(err)
+	require.True(t, ok)
+	assert.Equal(t, AuthFailureInvalidIssuer, authErr.Reason)
+
+	resolver.SetAllowedIssuers([]string{testIssuer})
+	_, err = resolver.Resolve(ctx, token)
+	assert.NoError(t, err)
+}
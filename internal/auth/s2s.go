@@ -124,8 +124,9 @@ func AuthMiddleware(resolver *KeyResolver, s2sStore *S2STokenStore) func(http.Ha
 			tokenString := parts[1]
 			var ctx context.Context
 
-			// Determine if token is JWT or S2S
-			if isJWTToken(tokenString) {
+			// Determine if token is JWT (or an opaque token of a configured issuer) or S2S
+			_, isS2S := s2sStore.ValidateToken(tokenString)
+			if isJWTToken(tokenString) || (!isS2S && resolver.IsOpaqueToken(tokenString)) {
 				// Handle JWT authentication
 				ctx = handleJWTAuth(r.Context(), resolver, tokenString, log, w, r)
 				if ctx == nil {
@@ -175,23 +176,19 @@ func handleJWTAuth(ctx context.Context, resolver *KeyResolver, tokenString strin
 	}
 
 	// Create auth context with metadata
-	authCtx := &AuthContext{
-		WorkspaceID: claims.WorkspaceID,
-		ActorID:     claims.ActorID,
-		ActorType:   "user", // Default actor type
-		AuthMethod:  "jwt",  // Authentication method
-		Issuer:      claims.Issuer,
-	}
+	authCtx := newJWTAuthContext(claims)
 
 	// Add claims and auth context to request context
 	ctx = context.WithValue(ctx, claimsContextKey, claims)
-	ctx = context.WithValue(ctx, authContextKey, authCtx)
+	ctx = withAuthContext(ctx, authCtx)
 
 	// Log successful authentication
 	log.Info(ctx, "authenticated request",
 		zap.String("auth_type", "jwt"),
 		zap.String("workspace_id", claims.WorkspaceID),
+		zap.Int("workspace_count", len(authCtx.Workspaces)),
 		zap.String("actor_id", claims.ActorID),
+		zap.String("impersonator_id", claims.ImpersonatorID),
 		zap.String("actor_type", authCtx.ActorType),
 		zap.String("auth_method", authCtx.AuthMethod),
 		zap.String("issuer", claims.Issuer),
@@ -237,13 +234,13 @@ func handleS2SAuth(ctx context.Context, s2sStore *S2STokenStore, tokenString str
 	authCtx := &AuthContext{
 		WorkspaceID: workspaceID,
 		ActorID:     actorID,
-		ActorType:   "service",
+		ActorType:   ActorTypeService,
 		AuthMethod:  "s2s",
 		Client:      client,
 	}
 
 	// Add auth context to request context
-	ctx = context.WithValue(ctx, authContextKey, authCtx)
+	ctx = withAuthContext(ctx, authCtx)
 
 	// Log successful authentication
 	logFields := []logger.Field{
@@ -330,6 +330,36 @@ func TestAuthMiddleware_JWT_vs_S2S_Precedence(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 	})
 
+	t.Run("JWT token carries workspaces, orgId and impersonator", func(t *testing.T) {
+		claims := &CustomClaims{
+			WorkspaceID:    "ws-jwt",
+			Workspaces:     []string{"ws-agency"},
+			OrgID:          "org-1",
+			ActorID:        "user-jwt",
+			ImpersonatorID: "admin-jwt",
+		}
+		jwtToken := createJWTTestToken(testSecret, claims, testIssuer, testAudience, time.Now().Add(1*time.Hour))
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		req = req.WithContext(ctx)
+		req.Header.Set("Authorization", "Bearer "+jwtToken)
+
+		rr := httptest.NewRecorder()
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authCtx, ok := GetAuthContext(r.Context())
+			require.True(t, ok)
+			assert.Equal(t, []string{"ws-jwt", "ws-agency"}, authCtx.Workspaces)
+			assert.True(t, authCtx.CanAccessWorkspace("ws-agency"))
+			assert.Equal(t, "org-1", authCtx.OrgID)
+			assert.Equal(t, "admin-jwt", authCtx.ImpersonatorID)
+			w.WriteHeader(http.StatusOK)
+		})
+
+		middleware(handler).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
 	t.Run("S2S token is validated as S2S", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/test", nil)
 		req = req.WithContext(ctx)
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// HS256Signer issues HS256 tokens accepted by HS256Validator for the same issuer and kid.
+// Used for tokens minted by the API itself (impersonation, bench).
+type HS256Signer struct {
+	secret   []byte
+	issuer   string
+	audience string
+	kid      string
+}
+
+// NewHS256Signer creates a new HS256 signer
+func NewHS256Signer(secret []byte, issuer, audience, kid string) *HS256Signer {
+	return &HS256Signer{
+		secret:   secret,
+		issuer:   issuer,
+		audience: audience,
+		kid:      kid,
+	}
+}
+
+// Sign fills the registered claims (iss, aud, iat, exp) and signs the token
+func (s *HS256Signer) Sign(claims *CustomClaims, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims.Issuer = s.issuer
+	claims.Audience = jwt.ClaimStrings{s.audience}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+
+	if err := claims.Validate(); err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid claims: %w", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = s.kid
+	signed, err := token.SignedString(s.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHS256Signer_ImpersonationRoundTrip(t *testing.T) {
+	keyStore := NewKeyStore()
+	keyStore.LoadHS256Key(testIssuer, "v1", []byte(testSecret))
+	validator := NewHS256Validator(keyStore, testIssuer, 60*time.Second)
+	signer := NewHS256Signer([]byte(testSecret), testIssuer, testAudience, "v1")
+
+	tokenString, expiresAt, err := signer.Sign(&CustomClaims{
+		WorkspaceID:    "workspace-123",
+		ActorID:        "user-456",
+		ImpersonatorID: "admin-789",
+	}, 15*time.Minute)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, 5*time.Second)
+
+	claims, err := validator.Validate(tokenString, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-456", claims.ActorID)
+	assert.Equal(t, "admin-789", claims.ImpersonatorID)
+	assert.Equal(t, testIssuer, claims.Issuer)
+}
+
+func TestHS256Signer_RejectsSelfImpersonation(t *testing.T) {
+	signer := NewHS256Signer([]byte(testSecret), testIssuer, testAudience, "v1")
+
+	_, _, err := signer.Sign(&CustomClaims{
+		WorkspaceID:    "workspace-123",
+		ActorID:        "admin-789",
+		ImpersonatorID: "admin-789",
+	}, 15*time.Minute)
+	assert.Error(t, err)
+}
+
+func TestHS256Signer_ServiceAccountRoundTrip(t *testing.T) {
+	keyStore := NewKeyStore()
+	keyStore.LoadHS256Key(testIssuer, "v1", []byte(testSecret))
+	validator := NewHS256Validator(keyStore, testIssuer, 60*time.Second)
+	signer := NewHS256Signer([]byte(testSecret), testIssuer, testAudience, "v1")
+
+	tokenString, _, err := signer.Sign(&CustomClaims{
+		WorkspaceID: "workspace-123",
+		ActorID:     "sa_123",
+		Scope:       "contacts:write deals:read",
+		ActorType:   ActorTypeServiceAccount,
+	}, 15*time.Minute)
+	require.NoError(t, err)
+
+	claims, err := validator.Validate(tokenString, "v1")
+	require.NoError(t, err)
+	authCtx := newJWTAuthContext(claims)
+	assert.Equal(t, ActorTypeServiceAccount, authCtx.ActorType)
+	assert.Equal(t, []string{"contacts:write", "deals:read"}, authCtx.Scopes)
+	assert.True(t, authCtx.IsScoped())
+}
+
+func TestHS256Signer_RejectsUnscopedServiceAccount(t *testing.T) {
+	signer := NewHS256Signer([]byte(testSecret), testIssuer, testAudience, "v1")
+
+	_, _, err := signer.Sign(&CustomClaims{
+		WorkspaceID: "workspace-123",
+		ActorID:     "sa_123",
+		ActorType:   ActorTypeServiceAccount,
+	}, 15*time.Minute)
+	assert.Error(t, err)
+}
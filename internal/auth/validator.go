@@ -124,3 +124,85 @@ func (v *RS256Validator) Validate(tokenString string, kid string) (*CustomClaims
 
 	return claims, nil
 }
+
+// ES256Validator validates ES256 (ECDSA P-256) JWT tokens
+type ES256Validator struct {
+	keyStore  *KeyStore
+	issuer    string
+	clockSkew time.Duration
+}
+
+// NewES256Validator creates a new ES256 validator
+func NewES256Validator(keyStore *KeyStore, issuer string, clockSkew time.Duration) *ES256Validator {
+	return &ES256Validator{
+		keyStore:  keyStore,
+		issuer:    issuer,
+		clockSkew: clockSkew,
+	}
+}
+
+// Validate validates an ES256 JWT token
+func (v *ES256Validator) Validate(tokenString string, kid string) (*CustomClaims, error) {
+	publicKey, ok := v.keyStore.GetES256Key(v.issuer, kid)
+	if !ok {
+		return nil, NewAuthError(AuthFailureUnknown, fmt.Sprintf("key not found for issuer %s and kid %s", v.issuer, kid), nil)
+	}
+
+	return parseAsymmetricToken(tokenString, v.clockSkew, jwt.SigningMethodES256.Alg(), publicKey)
+}
+
+// EdDSAValidator validates EdDSA (Ed25519) JWT tokens
+type EdDSAValidator struct {
+	keyStore  *KeyStore
+	issuer    string
+	clockSkew time.Duration
+}
+
+// NewEdDSAValidator creates a new EdDSA validator
+func NewEdDSAValidator(keyStore *KeyStore, issuer string, clockSkew time.Duration) *EdDSAValidator {
+	return &EdDSAValidator{
+		keyStore:  keyStore,
+		issuer:    issuer,
+		clockSkew: clockSkew,
+	}
+}
+
+// Validate validates an EdDSA JWT token
+func (v *EdDSAValidator) Validate(tokenString string, kid string) (*CustomClaims, error) {
+	publicKey, ok := v.keyStore.GetEdDSAKey(v.issuer, kid)
+	if !ok {
+		return nil, NewAuthError(AuthFailureUnknown, fmt.Sprintf("key not found for issuer %s and kid %s", v.issuer, kid), nil)
+	}
+
+	return parseAsymmetricToken(tokenString, v.clockSkew, jwt.SigningMethodEdDSA.Alg(), publicKey)
+}
+
+// parseAsymmetricToken valida assinatura, expiração e claims de um token assinado com alg.
+// O alg é conferido exatamente (ES384 não passa como ES256).
+func parseAsymmetricToken(tokenString string, clockSkew time.Duration, alg string, publicKey interface{}) (*CustomClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return publicKey, nil
+	}, jwt.WithLeeway(clockSkew), jwt.WithValidMethods([]string{alg}))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, NewAuthError(AuthFailureTokenExpired, "token expired", err)
+		}
+		if errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			return nil, NewAuthError(AuthFailureInvalidSignature, "invalid signature", err)
+		}
+		return nil, NewAuthError(AuthFailureUnknown, "failed to parse token", err)
+	}
+
+	claims, ok := token.Claims.(*CustomClaims)
+	if !ok || !token.Valid {
+		return nil, NewAuthError(AuthFailureUnknown, fmt.Sprintf("invalid token: valid=%v", token.Valid), nil)
+	}
+
+	// Validate custom claims
+	if err := claims.Validate(); err != nil {
+		return nil, NewAuthError(AuthFailureUnknown, "invalid claims", err)
+	}
+
+	return claims, nil
+}
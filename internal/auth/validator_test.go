@@ -1,7 +1,15 @@
 package auth
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
+	"strings"
 	"testing"
 	"time"
 
@@ -151,6 +159,35 @@ func TestHS256Validator_MissingWorkspaceID(t *testing.T) {
 	assert.Equal(t, AuthFailureUnknown, authErr.Reason)
 }
 
+func TestHS256Validator_WorkspacesClaim(t *testing.T) {
+	keyStore := NewKeyStore()
+	keyStore.LoadHS256Key(testIssuer, "v1", []byte(testSecret))
+	validator := NewHS256Validator(keyStore, testIssuer, 60*time.Second)
+
+	// Agency token: only the workspaces array, no primary workspaceId
+	claims := &CustomClaims{
+		Workspaces: []string{"ws-a", "ws-b", "ws-a"},
+		ActorID:    "user-67890",
+	}
+	token := createTestToken(testSecret, claims, time.Now().Add(1*time.Hour))
+
+	result, err := validator.Validate(token, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ws-a", "ws-b"}, result.AllowedWorkspaces())
+
+	// Empty entries are rejected
+	claims = &CustomClaims{
+		WorkspaceID: "ws-a",
+		Workspaces:  []string{""},
+		ActorID:     "user-67890",
+	}
+	token = createTestToken(testSecret, claims, time.Now().Add(1*time.Hour))
+
+	result, err = validator.Validate(token, "v1")
+	require.Error(t, err)
+	assert.Nil(t, result)
+}
+
 func TestHS256Validator_MissingActorID(t *testing.T) {
 	// Setup
 	keyStore := NewKeyStore()
@@ -344,3 +381,127 @@ func TestHS256Validator_Base64EncodedSecret_InvalidSignature(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, AuthFailureInvalidSignature, authErr.Reason)
 }
+
+// publicKeyPEM encodes a public key as PKIX PEM, escaping newlines like JWT_ISSUERS values do
+func publicKeyPEM(t *testing.T, publicKey crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	block := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	return strings.ReplaceAll(string(block), "\n", `\n`)
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key crypto.PrivateKey, exp time.Time) string {
+	t.Helper()
+	claims := &CustomClaims{
+		WorkspaceID: "ws-12345",
+		ActorID:     "user-67890",
+	}
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Issuer:    testIssuer,
+		Audience:  jwt.ClaimStrings{testAudience},
+		ExpiresAt: jwt.NewNumericDate(exp),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+	tokenString, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return tokenString
+}
+
+func TestES256Validator(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keyStore := NewKeyStore()
+	require.NoError(t, keyStore.LoadES256Key(testIssuer, "v1", publicKeyPEM(t, &privateKey.PublicKey)))
+	validator := NewES256Validator(keyStore, testIssuer, 60*time.Second)
+
+	t.Run("valid token", func(t *testing.T) {
+		result, err := validator.Validate(signTestToken(t, jwt.SigningMethodES256, privateKey, time.Now().Add(time.Hour)), "v1")
+		require.NoError(t, err)
+		assert.Equal(t, "ws-12345", result.WorkspaceID)
+		assert.Equal(t, "user-67890", result.ActorID)
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		result, err := validator.Validate(signTestToken(t, jwt.SigningMethodES256, otherKey, time.Now().Add(time.Hour)), "v1")
+		require.Error(t, err)
+		assert.Nil(t, result)
+		authErr, ok := IsAuthError(err)
+		require.True(t, ok)
+		assert.Equal(t, AuthFailureInvalidSignature, authErr.Reason)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		_, err := validator.Validate(signTestToken(t, jwt.SigningMethodES256, privateKey, time.Now().Add(-2*time.Minute)), "v1")
+		authErr, ok := IsAuthError(err)
+		require.True(t, ok)
+		assert.Equal(t, AuthFailureTokenExpired, authErr.Reason)
+	})
+
+	t.Run("HS256 token is rejected", func(t *testing.T) {
+		token := createTestToken(testSecret, &CustomClaims{WorkspaceID: "ws-12345", ActorID: "user-67890"}, time.Now().Add(time.Hour))
+		result, err := validator.Validate(token, "v1")
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		_, err := validator.Validate(signTestToken(t, jwt.SigningMethodES256, privateKey, time.Now().Add(time.Hour)), "v2")
+		require.Error(t, err)
+	})
+}
+
+func TestKeyStore_LoadES256Key_RejectsOtherCurves(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	err = NewKeyStore().LoadES256Key(testIssuer, "v1", publicKeyPEM(t, &privateKey.PublicKey))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "P-256")
+}
+
+func TestEdDSAValidator(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyStore := NewKeyStore()
+	require.NoError(t, keyStore.LoadEdDSAKey(testIssuer, "v1", publicKeyPEM(t, publicKey)))
+	validator := NewEdDSAValidator(keyStore, testIssuer, 60*time.Second)
+
+	t.Run("valid token", func(t *testing.T) {
+		result, err := validator.Validate(signTestToken(t, jwt.SigningMethodEdDSA, privateKey, time.Now().Add(time.Hour)), "v1")
+		require.NoError(t, err)
+		assert.Equal(t, "ws-12345", result.WorkspaceID)
+		assert.Equal(t, testIssuer, result.Issuer)
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = validator.Validate(signTestToken(t, jwt.SigningMethodEdDSA, otherKey, time.Now().Add(time.Hour)), "v1")
+		authErr, ok := IsAuthError(err)
+		require.True(t, ok)
+		assert.Equal(t, AuthFailureInvalidSignature, authErr.Reason)
+	})
+
+	t.Run("ES256 token is rejected", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		result, err := validator.Validate(signTestToken(t, jwt.SigningMethodES256, ecKey, time.Now().Add(time.Hour)), "v1")
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("ECDSA key is not an Ed25519 key", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		require.Error(t, NewKeyStore().LoadEdDSAKey(testIssuer, "v1", publicKeyPEM(t, &ecKey.PublicKey)))
+	})
+}
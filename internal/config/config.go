@@ -1,59 +1,257 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/caarlos0/env/v11"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/i18n"
 )
 
-// Config holds all application configuration
+// Config holds all application configuration.
+//
+// Durações (time.Duration) aceitam o formato Go ("90s", "15m") ou um inteiro na unidade
+// declarada na tag unit (s, ms, m ou d), que é a unidade do nome da variável: JWT_CLOCK_SKEW_SECONDS=60.
+// Campos com secret:"true" aparecem mascarados em Effective; campos com reload:"true" são
+// reaplicados sem restart por Live.Reload (SIGHUP ou POST /admin/config:reload).
 type Config struct {
+	// Arquivo YAML ou TOML opcional com os mesmos nomes das variáveis; o ambiente tem precedência
+	ConfigFile string `env:"CONFIG_FILE"`
+
 	// Database
-	DatabaseURL string `env:"DATABASE_URL,required"`
+	DatabaseURL string `env:"DATABASE_URL,required" secret:"true"`
+	// Shards de residência de dados (CSV "nome=postgres://..."): workspaces mapeados para um
+	// shard (tabela "WorkspaceShard", comando shard) têm os dados nesse banco; o mapa fica em
+	// cache por instância por SHARD_MAP_CACHE_TTL_SECONDS (0 desabilita o cache)
+	DatabaseShards   string        `env:"DATABASE_SHARDS" secret:"true"`
+	ShardMapCacheTTL time.Duration `env:"SHARD_MAP_CACHE_TTL_SECONDS" envDefault:"60s" unit:"s"`
 
 	// Redis
-	RedisURL string `env:"REDIS_URL,required"`
+	RedisURL string `env:"REDIS_URL,required" secret:"true"`
 
 	// JWT Configuration
-	JWTHS256Secret      string `env:"JWT_HS256_SECRET,required"`    // Base64-encoded HMAC secret
-	JWTAllowedIssuers   string `env:"JWT_ALLOWED_ISSUERS,required"` // CSV list of allowed issuers (e.g., "linkko-crm-web,linkko-mcp-server")
-	JWTAudience         string `env:"JWT_AUDIENCE,required"`        // Expected JWT audience
-	JWTClockSkewSeconds int    `env:"JWT_CLOCK_SKEW_SECONDS" envDefault:"60"`
+	JWTHS256Secret    string        `env:"JWT_HS256_SECRET,required" secret:"true"`    // Base64-encoded HMAC secret
+	JWTAllowedIssuers string        `env:"JWT_ALLOWED_ISSUERS,required" reload:"true"` // CSV list of allowed issuers (e.g., "linkko-crm-web,linkko-mcp-server")
+	JWTAudience       string        `env:"JWT_AUDIENCE,required"`                      // Expected JWT audience
+	JWTClockSkew      time.Duration `env:"JWT_CLOCK_SKEW_SECONDS" envDefault:"60s" unit:"s"`
+	// JWTIssuers audiência, clock skew e chave por issuer (JSON ou YAML), ex.:
+	// {"linkko-mcp-server": {"audiences": ["linkko-mcp"], "clockSkewSeconds": 10}}
+	// Issuers assimétricos informam algorithm (RS256, ES256, EdDSA), publicKey (PEM) e keyId (default v1);
+	// issuers opaque informam introspectionUrl, clientId, clientSecret, tokenPrefix e cacheSeconds
+	JWTIssuers string `env:"JWT_ISSUERS" secret:"true"`
+	// Tokens emitidos pela própria API (impersonation, troca SSO, service accounts): issuer, kid e
+	// audiência; o issuer precisa estar em JWT_ISSUERS/JWT_ALLOWED_ISSUERS como HS256 com o mesmo
+	// kid e aceitar a audiência. Vazio = primeiro issuer permitido e JWT_AUDIENCE
+	JWTSignerIssuer   string `env:"JWT_SIGNER_ISSUER"`
+	JWTSignerKeyID    string `env:"JWT_SIGNER_KEY_ID" envDefault:"v1"`
+	JWTSignerAudience string `env:"JWT_SIGNER_AUDIENCE"`
+
+	// Cache de decisões de autenticação: JWTs válidos pulam a verificação de assinatura por até
+	// AUTH_CACHE_TTL_SECONDS (nunca além do exp; 0 desabilita); tokens malformados são rejeitados
+	// do cache por AUTH_NEGATIVE_CACHE_TTL_SECONDS. Cada tipo tem seu limite de entradas (LRU)
+	AuthCacheTTL                time.Duration `env:"AUTH_CACHE_TTL_SECONDS" envDefault:"30s" unit:"s"`
+	AuthNegativeCacheTTL        time.Duration `env:"AUTH_NEGATIVE_CACHE_TTL_SECONDS" envDefault:"5s" unit:"s"`
+	AuthCacheMaxEntries         int           `env:"AUTH_CACHE_MAX_ENTRIES" envDefault:"10000"`
+	AuthNegativeCacheMaxEntries int           `env:"AUTH_NEGATIVE_CACHE_MAX_ENTRIES" envDefault:"1000"`
+
+	// Status do workspace (WorkspaceStatusMiddleware): existência e suspensão ficam em cache por
+	// instância; inexistentes por menos tempo para workspaces recém-criados (0 desabilita)
+	WorkspaceStatusCacheTTL         time.Duration `env:"WORKSPACE_STATUS_CACHE_TTL_SECONDS" envDefault:"30s" unit:"s"`
+	WorkspaceStatusNegativeCacheTTL time.Duration `env:"WORKSPACE_STATUS_NEGATIVE_CACHE_TTL_SECONDS" envDefault:"5s" unit:"s"`
+
+	// Modo de manutenção (MaintenanceMiddleware): cada instância relê as manutenções ligadas a cada
+	// TTL; é o atraso máximo para uma mudança feita em outra instância valer (0 desabilita o cache)
+	MaintenanceCacheTTL time.Duration `env:"MAINTENANCE_CACHE_TTL_SECONDS" envDefault:"5s" unit:"s"`
 
 	// Legacy JWT Configuration (deprecated)
-	JWTSecretCRMV1    string `env:"JWT_SECRET_CRM_V1"`     // Deprecated: use JWT_HS256_SECRET
-	JWTPublicKeyMCPV1 string `env:"JWT_PUBLIC_KEY_MCP_V1"` // Deprecated: use S2S tokens
-	JWTIssuer         string `env:"JWT_ISSUER"`            // Deprecated: use JWT_ALLOWED_ISSUERS (CSV)
+	JWTSecretCRMV1    string `env:"JWT_SECRET_CRM_V1" secret:"true"` // Deprecated: use JWT_HS256_SECRET
+	JWTPublicKeyMCPV1 string `env:"JWT_PUBLIC_KEY_MCP_V1"`           // Deprecated: use S2S tokens
+	JWTIssuer         string `env:"JWT_ISSUER"`                      // Deprecated: use JWT_ALLOWED_ISSUERS (CSV)
 
 	// S2S (Service-to-Service) Tokens
-	S2STokenCRM string `env:"S2S_TOKEN_CRM"`
-	S2STokenMCP string `env:"S2S_TOKEN_MCP"`
+	S2STokenCRM string `env:"S2S_TOKEN_CRM" secret:"true"`
+	S2STokenMCP string `env:"S2S_TOKEN_MCP" secret:"true"`
 
 	// OpenTelemetry
 	OTELEnabled          bool    `env:"OTEL_ENABLED" envDefault:"false"`
 	OTELExporterEndpoint string  `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
 	OTELServiceName      string  `env:"OTEL_SERVICE_NAME" envDefault:"linkko-api-go"`
 	OTELSamplingRatio    float64 `env:"OTEL_SAMPLING_RATIO" envDefault:"0.1"`
+	// Exemplars: pontos do histograma de latência levam o trace_id da request amostrada
+	OTELMetricsExemplars bool `env:"OTEL_METRICS_EXEMPLARS" envDefault:"true"`
 
 	// Server
 	Port string `env:"PORT" envDefault:"3002"`
+	// Multi-região: deploys de regiões diferentes podem compartilhar o Postgres; o rate limit (Redis)
+	// e a idempotência ficam por região. Vazio = deploy de região única (chaves sem namespace).
+	Region string `env:"REGION"`
+
+	// Request deadlines - devem ficar abaixo do WriteTimeout do servidor (30s)
+	RequestTimeoutRead   time.Duration `env:"REQUEST_TIMEOUT_READ_SECONDS" envDefault:"5s" unit:"s"`
+	RequestTimeoutWrite  time.Duration `env:"REQUEST_TIMEOUT_WRITE_SECONDS" envDefault:"10s" unit:"s"`
+	RequestTimeoutImport time.Duration `env:"REQUEST_TIMEOUT_IMPORT_SECONDS" envDefault:"25s" unit:"s"`
+
+	// Rate Limiting: sliding_window_log (nunca excede o limite em nenhuma janela de 60s) ou
+	// token_bucket (rajadas de até o limite, depois vazão constante de limite/min)
+	RateLimitPerWorkspacePerMin int    `env:"RATE_LIMIT_PER_WORKSPACE_PER_MIN" envDefault:"100" reload:"true"`
+	RateLimitAlgorithm          string `env:"RATE_LIMIT_ALGORITHM" envDefault:"sliding_window_log"`
+	// Redis indisponível: cada réplica limita em memória a limite/RATE_LIMIT_FALLBACK_INSTANCES
+	RateLimitFallbackInstances int `env:"RATE_LIMIT_FALLBACK_INSTANCES" envDefault:"1"`
+
+	// Concurrency: requests simultâneas por workspace (0 = desabilitado), por instância
+	ConcurrencyLimitPerWorkspace int           `env:"CONCURRENCY_LIMIT_PER_WORKSPACE" envDefault:"10"`
+	ConcurrencyQueueTimeout      time.Duration `env:"CONCURRENCY_QUEUE_TIMEOUT_MS" envDefault:"250ms" unit:"ms"`
+
+	// Priority lanes: tráfego batch (imports, lote, X-Request-Priority: batch)
+	// usa um pool separado (0 = compartilha o pool principal) e uma fila própria
+	DBBatchPoolMaxConns   int           `env:"DB_BATCH_POOL_MAX_CONNS" envDefault:"5"`
+	BatchLaneConcurrency  int           `env:"BATCH_LANE_CONCURRENCY" envDefault:"4"`
+	BatchLaneQueueTimeout time.Duration `env:"BATCH_LANE_QUEUE_TIMEOUT_MS" envDefault:"2000ms" unit:"ms"`
+
+	// Circuit breaker para Redis/Postgres
+	CircuitBreakerFailureThreshold int           `env:"CIRCUIT_BREAKER_FAILURE_THRESHOLD" envDefault:"5"`
+	CircuitBreakerOpen             time.Duration `env:"CIRCUIT_BREAKER_OPEN_SECONDS" envDefault:"30s" unit:"s"`
+
+	// API versioning: monta /v2 em paralelo a /v1
+	APIV2Enabled bool `env:"API_V2_ENABLED" envDefault:"false"`
+	// Data (YYYY-MM-DD) de remoção da /v1; com a /v2 montada, a /v1 passa a emitir
+	// os headers Deprecation/Sunset/Link apontando para a rota equivalente na /v2
+	APIV1Sunset string `env:"API_V1_SUNSET"`
+
+	// Deals: exige nextStepAt futuro ao atualizar negócios OPEN
+	DealRequireNextStep bool `env:"DEAL_REQUIRE_NEXT_STEP" envDefault:"false" reload:"true"`
+
+	// Contacts: associa o contato à empresa pelo domínio do e-mail; a deny-list (CSV) soma-se
+	// aos provedores de e-mail gratuito padrão
+	ContactCompanyAutoAssociate bool   `env:"CONTACT_COMPANY_AUTO_ASSOCIATE" envDefault:"true" reload:"true"`
+	ContactCompanyDenyDomains   string `env:"CONTACT_COMPANY_DENY_DOMAINS" reload:"true"`
+
+	// Sequences: intervalo de polling e lote de inscrições por ciclo do sequence-worker
+	SequenceWorkerInterval  time.Duration `env:"SEQUENCE_WORKER_INTERVAL_SECONDS" envDefault:"60s" unit:"s"`
+	SequenceWorkerBatchSize int           `env:"SEQUENCE_WORKER_BATCH_SIZE" envDefault:"100"`
+
+	// Counters: TTL do cache Redis dos contadores do dashboard (intervalo máximo entre reconciliações)
+	CountersCacheTTL time.Duration `env:"COUNTERS_CACHE_TTL_SECONDS" envDefault:"300s" unit:"s"`
+
+	// Snapshots: object storage dos arquivos (file:///caminho) e polling do snapshot-worker
+	SnapshotStorageURL     string        `env:"SNAPSHOT_STORAGE_URL" envDefault:"file:///var/lib/linkko/snapshots"`
+	SnapshotWorkerInterval time.Duration `env:"SNAPSHOT_WORKER_INTERVAL_SECONDS" envDefault:"30s" unit:"s"`
+
+	// Notas: object storage dos anexos (file:///caminho) e tamanho máximo por arquivo
+	NoteAttachmentStorageURL string `env:"NOTE_ATTACHMENT_STORAGE_URL" envDefault:"file:///var/lib/linkko/attachments"`
+	NoteAttachmentMaxBytes   int64  `env:"NOTE_ATTACHMENT_MAX_BYTES" envDefault:"10485760"`
+
+	// Antivírus dos anexos enviados: none, stub, clamav (endereço host:porta do clamd) ou icap
+	// (icap://host:porta/servico). Fail-open aceita o anexo sem scan com o scanner indisponível.
+	AttachmentScanProvider string        `env:"ATTACHMENT_SCAN_PROVIDER" envDefault:"none"`
+	AttachmentScanAddress  string        `env:"ATTACHMENT_SCAN_ADDRESS"`
+	AttachmentScanTimeout  time.Duration `env:"ATTACHMENT_SCAN_TIMEOUT_SECONDS" envDefault:"30s" unit:"s"`
+	AttachmentScanFailOpen bool          `env:"ATTACHMENT_SCAN_FAIL_OPEN" envDefault:"false"`
+
+	// Deal rotting: polling e lote de deals marcados por ciclo do deal-rotting-worker
+	DealRottingWorkerInterval  time.Duration `env:"DEAL_ROTTING_WORKER_INTERVAL_SECONDS" envDefault:"3600s" unit:"s"`
+	DealRottingWorkerBatchSize int           `env:"DEAL_ROTTING_WORKER_BATCH_SIZE" envDefault:"500"`
+
+	// Enriquecimento de empresas: provedor (stub) e polling do enrichment-worker
+	EnrichmentProvider       string        `env:"ENRICHMENT_PROVIDER" envDefault:"stub"`
+	EnrichmentWorkerInterval time.Duration `env:"ENRICHMENT_WORKER_INTERVAL_SECONDS" envDefault:"15s" unit:"s"`
+
+	// Atualização em massa de contatos: polling do bulk-update-worker
+	BulkUpdateWorkerInterval time.Duration `env:"BULK_UPDATE_WORKER_INTERVAL_SECONDS" envDefault:"5s" unit:"s"`
+
+	// Verificação de email dos contatos: provedor opcional (none | stub), polling e lote do email-verification-worker
+	EmailVerificationProvider        string        `env:"EMAIL_VERIFICATION_PROVIDER" envDefault:"none"`
+	EmailVerificationWorkerInterval  time.Duration `env:"EMAIL_VERIFICATION_WORKER_INTERVAL_SECONDS" envDefault:"60s" unit:"s"`
+	EmailVerificationWorkerBatchSize int           `env:"EMAIL_VERIFICATION_WORKER_BATCH_SIZE" envDefault:"100"`
+
+	// Envio de emails (relatórios agendados e notificações): provedor (none | stub | smtp), remetente e servidor SMTP
+	MailProvider string `env:"MAIL_PROVIDER" envDefault:"none"`
+	MailFrom     string `env:"MAIL_FROM"`
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     int    `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD" secret:"true"`
+
+	// Relatórios agendados: polling e lote do report-schedule-worker
+	ReportScheduleWorkerInterval  time.Duration `env:"REPORT_SCHEDULE_WORKER_INTERVAL_SECONDS" envDefault:"60s" unit:"s"`
+	ReportScheduleWorkerBatchSize int           `env:"REPORT_SCHEDULE_WORKER_BATCH_SIZE" envDefault:"20"`
 
-	// Rate Limiting
-	RateLimitPerWorkspacePerMin int `env:"RATE_LIMIT_PER_WORKSPACE_PER_MIN" envDefault:"100"`
+	// Impersonation: validade máxima do token emitido por admins e bloqueio opcional de escrita
+	ImpersonationMaxTTL         time.Duration `env:"IMPERSONATION_MAX_TTL_MINUTES" envDefault:"60m" unit:"m"`
+	ImpersonationBlockMutations bool          `env:"IMPERSONATION_BLOCK_MUTATIONS" envDefault:"true" reload:"true"`
+
+	// SSO: validade do JWT de sessão emitido na troca do id_token OIDC
+	SSOSessionTTL time.Duration `env:"SSO_SESSION_TTL_MINUTES" envDefault:"60m" unit:"m"`
+
+	// Service accounts: validade (1m a 60m) dos tokens emitidos em POST /v1/auth/token
+	ServiceAccountTokenTTL time.Duration `env:"SERVICE_ACCOUNT_TOKEN_TTL_MINUTES" envDefault:"15m" unit:"m"`
+
+	// Formulários públicos: segredo (base64, >= 32 bytes) dos form tokens; vazio desliga só os tokens
+	// (Forms cadastrados continuam recebendo submissões).
+	// Captcha: none, stub, turnstile, hcaptcha ou recaptcha (os três últimos exigem FORM_CAPTCHA_SECRET)
+	PublicFormTokenSecret string `env:"PUBLIC_FORM_TOKEN_SECRET" secret:"true"`
+	FormCaptchaProvider   string `env:"FORM_CAPTCHA_PROVIDER" envDefault:"none"`
+	FormCaptchaSecret     string `env:"FORM_CAPTCHA_SECRET" secret:"true"`
+
+	// Links rastreados: origem pública das URLs curtas (ex.: https://go.linkko.io); vazio gera /l/{code}
+	TrackedLinkBaseURL string `env:"TRACKED_LINK_BASE_URL"`
+
+	// Orçamentos: origem da página pública do link compartilhado ({base}/q/{token}; vazio gera URL
+	// relativa) e polling do quote-worker (geração dos PDFs)
+	QuoteShareBaseURL   string        `env:"QUOTE_SHARE_BASE_URL"`
+	QuoteWorkerInterval time.Duration `env:"QUOTE_WORKER_INTERVAL_SECONDS" envDefault:"10s" unit:"s"`
+
+	// Billing: segredo de assinatura do webhook do Stripe (whsec_...; vazio desliga a rota) e mapeamento
+	// price → plano (CSV "price_abc=STARTER,price_def=PRO"; planos FREE, STARTER, PRO, ENTERPRISE)
+	StripeWebhookSecret string `env:"STRIPE_WEBHOOK_SECRET" secret:"true"`
+	StripePricePlans    string `env:"STRIPE_PRICE_PLANS"`
+
+	// Undo: validade do undoToken devolvido pelos DELETEs
+	UndoWindow time.Duration `env:"UNDO_WINDOW_MINUTES" envDefault:"10m" unit:"m"`
+
+	// Lixeira: dias que um registro excluído fica recuperável antes da purga (cleanup)
+	TrashRetention time.Duration `env:"TRASH_RETENTION_DAYS" envDefault:"720h" unit:"d"`
+
+	// Partições mensais de "Activity" e audit_log: meses à frente criados pelo cleanup
+	PartitionPremakeMonths int `env:"PARTITION_PREMAKE_MONTHS" envDefault:"3"`
+
+	// Logs de request: fração dos 2xx/3xx registrados (erros e requests lentas são sempre
+	// registrados), limiar de request lenta e overrides por rota (CSV "padrão=fração", padrão chi
+	// com método opcional: "GET /health=0,/v1/workspaces/{workspaceId}/deals=0.5")
+	LogSampleRate           float64       `env:"LOG_SAMPLE_RATE" envDefault:"1"`
+	LogSlowRequestThreshold time.Duration `env:"LOG_SLOW_REQUEST_MS" envDefault:"1000ms" unit:"ms"`
+	LogSampleRouteRates     string        `env:"LOG_SAMPLE_ROUTE_RATES"`
+
+	// Rastreador de erros: panics recuperados são enviados com pilha e contexto da request
+	// (DSN no formato do Sentry https://<key>@<host>/<project> | stub | none)
+	ErrorTrackerDSN string `env:"ERROR_TRACKER_DSN" envDefault:"none" secret:"true"`
+
+	// Idioma padrão das mensagens de erro quando não há Accept-Language (en | pt-BR)
+	DefaultLocale string `env:"DEFAULT_LOCALE" envDefault:"en"`
 
 	// Environment
 	AppEnv string `env:"APP_ENV" envDefault:"prod"`
 
+	// Nível dos logs (debug, info, warn, error)
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info" reload:"true"`
+
 	// Metrics
-	MetricsToken string `env:"METRICS_TOKEN"`
+	MetricsToken string `env:"METRICS_TOKEN" secret:"true"`
+
+	// Admin: token de POST /admin/config:reload (Authorization: Bearer); vazio desliga a rota
+	AdminToken string `env:"ADMIN_TOKEN" secret:"true"`
 }
 
-// LoadConfig loads configuration from environment variables
+// LoadConfig loads configuration from environment variables, CONFIG_FILE and defaults (nessa ordem de precedência)
 func LoadConfig() (*Config, error) {
 	cfg := &Config{}
 
-	if err := env.Parse(cfg); err != nil {
+	if err := load(cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
@@ -64,6 +262,30 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// MigrationConfig subconjunto usado pelo comando migrate, que não precisa de Redis nem JWT
+type MigrationConfig struct {
+	ConfigFile     string `env:"CONFIG_FILE"`
+	DatabaseURL    string `env:"DATABASE_URL,required"`
+	DatabaseShards string `env:"DATABASE_SHARDS"`
+}
+
+// GetDatabaseShards returns the shard name → database URL map (shards também recebem as migrações)
+func (c *MigrationConfig) GetDatabaseShards() (map[string]string, error) {
+	return parseDatabaseShards(c.DatabaseShards)
+}
+
+// LoadMigrationConfig loads the migrate command configuration (same sources as LoadConfig)
+func LoadMigrationConfig() (*MigrationConfig, error) {
+	cfg := &MigrationConfig{}
+	if err := load(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return cfg, nil
+}
+
+// regionPattern REGION vai em chaves do Redis, headers e métricas: ex. sa-east-1, us-east-1
+var regionPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
 // Validate performs custom validation on the configuration
 func (c *Config) Validate() error {
 	if c.DatabaseURL == "" {
@@ -104,25 +326,260 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT_AUDIENCE is required")
 	}
 
+	if c.Region != "" && !regionPattern.MatchString(c.Region) {
+		return fmt.Errorf("REGION must contain only lowercase letters, digits and hyphens (e.g. sa-east-1)")
+	}
+
 	if c.OTELSamplingRatio < 0 || c.OTELSamplingRatio > 1 {
 		return fmt.Errorf("OTEL_SAMPLING_RATIO must be between 0 and 1")
 	}
 
-	if c.JWTClockSkewSeconds < 0 {
+	if c.JWTClockSkew < 0 {
 		return fmt.Errorf("JWT_CLOCK_SKEW_SECONDS must be non-negative")
 	}
 
+	if _, err := parseJWTIssuers(c.JWTIssuers); err != nil {
+		return err
+	}
+	if _, err := c.GetJWTSigner(); err != nil {
+		return err
+	}
+
+	if c.AuthCacheTTL < 0 {
+		return fmt.Errorf("AUTH_CACHE_TTL_SECONDS must be non-negative")
+	}
+	if c.AuthNegativeCacheTTL < 0 {
+		return fmt.Errorf("AUTH_NEGATIVE_CACHE_TTL_SECONDS must be non-negative")
+	}
+	if c.AuthCacheMaxEntries <= 0 {
+		return fmt.Errorf("AUTH_CACHE_MAX_ENTRIES must be positive")
+	}
+	if c.AuthNegativeCacheMaxEntries <= 0 {
+		return fmt.Errorf("AUTH_NEGATIVE_CACHE_MAX_ENTRIES must be positive")
+	}
+
+	if c.WorkspaceStatusCacheTTL < 0 {
+		return fmt.Errorf("WORKSPACE_STATUS_CACHE_TTL_SECONDS must be non-negative")
+	}
+	if c.WorkspaceStatusNegativeCacheTTL < 0 {
+		return fmt.Errorf("WORKSPACE_STATUS_NEGATIVE_CACHE_TTL_SECONDS must be non-negative")
+	}
+	if c.MaintenanceCacheTTL < 0 {
+		return fmt.Errorf("MAINTENANCE_CACHE_TTL_SECONDS must be non-negative")
+	}
+
+	if _, err := parseDatabaseShards(c.DatabaseShards); err != nil {
+		return fmt.Errorf("DATABASE_SHARDS: %w", err)
+	}
+	if c.ShardMapCacheTTL < 0 {
+		return fmt.Errorf("SHARD_MAP_CACHE_TTL_SECONDS must be non-negative")
+	}
+
 	if c.RateLimitPerWorkspacePerMin <= 0 {
 		return fmt.Errorf("RATE_LIMIT_PER_WORKSPACE_PER_MIN must be positive")
 	}
 
+	switch c.RateLimitAlgorithm {
+	case "sliding_window_log", "token_bucket":
+	default:
+		return fmt.Errorf("RATE_LIMIT_ALGORITHM must be one of: sliding_window_log, token_bucket")
+	}
+
+	if c.RateLimitFallbackInstances < 1 {
+		return fmt.Errorf("RATE_LIMIT_FALLBACK_INSTANCES must be at least 1")
+	}
+
+	if c.RequestTimeoutRead < 0 || c.RequestTimeoutWrite < 0 || c.RequestTimeoutImport < 0 {
+		return fmt.Errorf("REQUEST_TIMEOUT_*_SECONDS must be non-negative")
+	}
+
+	if c.ConcurrencyLimitPerWorkspace < 0 || c.ConcurrencyQueueTimeout < 0 {
+		return fmt.Errorf("CONCURRENCY_LIMIT_PER_WORKSPACE and CONCURRENCY_QUEUE_TIMEOUT_MS must be non-negative")
+	}
+
+	if c.DBBatchPoolMaxConns < 0 || c.BatchLaneConcurrency < 0 || c.BatchLaneQueueTimeout < 0 {
+		return fmt.Errorf("DB_BATCH_POOL_MAX_CONNS, BATCH_LANE_CONCURRENCY and BATCH_LANE_QUEUE_TIMEOUT_MS must be non-negative")
+	}
+
+	if c.CircuitBreakerFailureThreshold <= 0 {
+		return fmt.Errorf("CIRCUIT_BREAKER_FAILURE_THRESHOLD must be positive")
+	}
+
+	if c.CircuitBreakerOpen <= 0 {
+		return fmt.Errorf("CIRCUIT_BREAKER_OPEN_SECONDS must be positive")
+	}
+
+	if c.SequenceWorkerInterval <= 0 || c.SequenceWorkerBatchSize <= 0 {
+		return fmt.Errorf("SEQUENCE_WORKER_INTERVAL_SECONDS and SEQUENCE_WORKER_BATCH_SIZE must be positive")
+	}
+
+	if c.CountersCacheTTL <= 0 {
+		return fmt.Errorf("COUNTERS_CACHE_TTL_SECONDS must be positive")
+	}
+
+	if !strings.HasPrefix(c.SnapshotStorageURL, "file:///") {
+		return fmt.Errorf("SNAPSHOT_STORAGE_URL must be a file:/// URL with an absolute path")
+	}
+
+	if c.SnapshotWorkerInterval <= 0 {
+		return fmt.Errorf("SNAPSHOT_WORKER_INTERVAL_SECONDS must be positive")
+	}
+
+	if !strings.HasPrefix(c.NoteAttachmentStorageURL, "file:///") {
+		return fmt.Errorf("NOTE_ATTACHMENT_STORAGE_URL must be a file:/// URL with an absolute path")
+	}
+
+	if c.NoteAttachmentMaxBytes <= 0 {
+		return fmt.Errorf("NOTE_ATTACHMENT_MAX_BYTES must be positive")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(c.AttachmentScanProvider)) {
+	case "", "none", "stub":
+	case "clamav", "icap":
+		if c.AttachmentScanAddress == "" {
+			return fmt.Errorf("ATTACHMENT_SCAN_ADDRESS is required when ATTACHMENT_SCAN_PROVIDER=%s", c.AttachmentScanProvider)
+		}
+	default:
+		return fmt.Errorf("ATTACHMENT_SCAN_PROVIDER must be one of none, stub, clamav, icap (got %q)", c.AttachmentScanProvider)
+	}
+
+	if c.AttachmentScanTimeout <= 0 {
+		return fmt.Errorf("ATTACHMENT_SCAN_TIMEOUT_SECONDS must be positive")
+	}
+
+	if c.DealRottingWorkerInterval <= 0 || c.DealRottingWorkerBatchSize <= 0 {
+		return fmt.Errorf("DEAL_ROTTING_WORKER_INTERVAL_SECONDS and DEAL_ROTTING_WORKER_BATCH_SIZE must be positive")
+	}
+
+	if c.EnrichmentWorkerInterval <= 0 {
+		return fmt.Errorf("ENRICHMENT_WORKER_INTERVAL_SECONDS must be positive")
+	}
+
+	if c.BulkUpdateWorkerInterval <= 0 {
+		return fmt.Errorf("BULK_UPDATE_WORKER_INTERVAL_SECONDS must be positive")
+	}
+
+	if c.EmailVerificationWorkerInterval <= 0 || c.EmailVerificationWorkerBatchSize <= 0 {
+		return fmt.Errorf("EMAIL_VERIFICATION_WORKER_INTERVAL_SECONDS and EMAIL_VERIFICATION_WORKER_BATCH_SIZE must be positive")
+	}
+
+	if c.SMTPPort <= 0 {
+		return fmt.Errorf("SMTP_PORT must be positive")
+	}
+
+	if c.ReportScheduleWorkerInterval <= 0 || c.ReportScheduleWorkerBatchSize <= 0 {
+		return fmt.Errorf("REPORT_SCHEDULE_WORKER_INTERVAL_SECONDS and REPORT_SCHEDULE_WORKER_BATCH_SIZE must be positive")
+	}
+
+	if c.ImpersonationMaxTTL <= 0 {
+		return fmt.Errorf("IMPERSONATION_MAX_TTL_MINUTES must be positive")
+	}
+
+	if c.SSOSessionTTL <= 0 {
+		return fmt.Errorf("SSO_SESSION_TTL_MINUTES must be positive")
+	}
+
+	if c.ServiceAccountTokenTTL < time.Minute || c.ServiceAccountTokenTTL > time.Hour {
+		return fmt.Errorf("SERVICE_ACCOUNT_TOKEN_TTL_MINUTES must be between 1 and 60")
+	}
+
+	if c.PublicFormTokenSecret != "" {
+		secret, err := base64.StdEncoding.DecodeString(c.PublicFormTokenSecret)
+		if err != nil {
+			return fmt.Errorf("PUBLIC_FORM_TOKEN_SECRET must be valid base64: %w", err)
+		}
+		if len(secret) < 32 {
+			return fmt.Errorf("PUBLIC_FORM_TOKEN_SECRET decoded bytes must be at least 32 bytes (256 bits), got %d bytes", len(secret))
+		}
+		if c.PublicFormTokenSecret == c.JWTHS256Secret {
+			return fmt.Errorf("PUBLIC_FORM_TOKEN_SECRET must differ from JWT_HS256_SECRET")
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(c.FormCaptchaProvider)) {
+	case "", "none", "stub":
+	case "turnstile", "hcaptcha", "recaptcha":
+		if c.FormCaptchaSecret == "" {
+			return fmt.Errorf("FORM_CAPTCHA_SECRET is required when FORM_CAPTCHA_PROVIDER=%s", c.FormCaptchaProvider)
+		}
+	default:
+		return fmt.Errorf("FORM_CAPTCHA_PROVIDER must be one of none, stub, turnstile, hcaptcha, recaptcha (got %q)", c.FormCaptchaProvider)
+	}
+
+	if c.TrackedLinkBaseURL != "" {
+		u, err := url.Parse(c.TrackedLinkBaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("TRACKED_LINK_BASE_URL must be an absolute http(s) URL")
+		}
+	}
+
+	if c.QuoteShareBaseURL != "" {
+		u, err := url.Parse(c.QuoteShareBaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("QUOTE_SHARE_BASE_URL must be an absolute http(s) URL")
+		}
+	}
+
+	if c.QuoteWorkerInterval <= 0 {
+		return fmt.Errorf("QUOTE_WORKER_INTERVAL_SECONDS must be positive")
+	}
+
+	if _, err := domain.ParseStripePricePlans(c.StripePricePlans); err != nil {
+		return fmt.Errorf("STRIPE_PRICE_PLANS: %w", err)
+	}
+
+	if c.UndoWindow <= 0 {
+		return fmt.Errorf("UNDO_WINDOW_MINUTES must be positive")
+	}
+
+	if c.LogSampleRate < 0 || c.LogSampleRate > 1 {
+		return fmt.Errorf("LOG_SAMPLE_RATE must be between 0 and 1")
+	}
+
+	if c.LogSlowRequestThreshold < 0 {
+		return fmt.Errorf("LOG_SLOW_REQUEST_MS must not be negative")
+	}
+
+	if _, err := parseLogSampleRouteRates(c.LogSampleRouteRates); err != nil {
+		return fmt.Errorf("LOG_SAMPLE_ROUTE_RATES: %w", err)
+	}
+
+	if c.TrashRetention <= 0 {
+		return fmt.Errorf("TRASH_RETENTION_DAYS must be positive")
+	}
+
+	if c.PartitionPremakeMonths < 1 {
+		return fmt.Errorf("PARTITION_PREMAKE_MONTHS must be at least 1")
+	}
+
+	if c.DefaultLocale != "" && !isSupportedLocale(c.DefaultLocale) {
+		return fmt.Errorf("DEFAULT_LOCALE must be one of: en, pt-BR")
+	}
+
 	if c.AppEnv == "" {
 		c.AppEnv = "prod"
 	}
 
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("LOG_LEVEL must be one of: debug, info, warn, error")
+	}
+
+	if c.APIV1Sunset != "" {
+		if _, err := time.Parse(time.DateOnly, c.APIV1Sunset); err != nil {
+			return fmt.Errorf("API_V1_SUNSET must be a date in YYYY-MM-DD format")
+		}
+	}
+
 	return nil
 }
 
+func isSupportedLocale(locale string) bool {
+	_, ok := i18n.Parse(locale)
+	return ok
+}
+
 // GetAllowedIssuers returns the list of allowed JWT issuers
 func (c *Config) GetAllowedIssuers() []string {
 	issuers := strings.Split(c.JWTAllowedIssuers, ",")
@@ -136,6 +593,103 @@ func (c *Config) GetAllowedIssuers() []string {
 	return result
 }
 
+// GetContactCompanyDenyDomains returns the extra email domains never used for company association
+func (c *Config) GetContactCompanyDenyDomains() []string {
+	domains := strings.Split(c.ContactCompanyDenyDomains, ",")
+	result := make([]string, 0, len(domains))
+	for _, d := range domains {
+		trimmed := strings.TrimSpace(d)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// GetAPIV1Sunset returns the /v1 sunset date (zero when unset; validated in Validate)
+func (c *Config) GetAPIV1Sunset() time.Time {
+	sunset, err := time.Parse(time.DateOnly, c.APIV1Sunset)
+	if err != nil {
+		return time.Time{}
+	}
+	return sunset
+}
+
+// GetStripePricePlans returns the Stripe price → plan mapping (validated in Validate)
+func (c *Config) GetStripePricePlans() map[string]domain.BillingPlan {
+	plans, err := domain.ParseStripePricePlans(c.StripePricePlans)
+	if err != nil {
+		return map[string]domain.BillingPlan{}
+	}
+	return plans
+}
+
+// GetLogSampleRouteRates returns the per-route request log sample rates (validated in Validate)
+func (c *Config) GetLogSampleRouteRates() map[string]float64 {
+	rates, err := parseLogSampleRouteRates(c.LogSampleRouteRates)
+	if err != nil {
+		return map[string]float64{}
+	}
+	return rates
+}
+
+// parseLogSampleRouteRates interpreta "GET /health=0,/v1/workspaces/{workspaceId}/deals=0.5".
+func parseLogSampleRouteRates(s string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid route rate %q (expected [METHOD ]/route/pattern=0..1)", entry)
+		}
+		route := strings.Join(strings.Fields(entry[:i]), " ")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(entry[i+1:]), 64)
+		if err != nil || rate < 0 || rate > 1 || !strings.Contains(route, "/") {
+			return nil, fmt.Errorf("invalid route rate %q (expected [METHOD ]/route/pattern=0..1)", entry)
+		}
+		rates[route] = rate
+	}
+	return rates, nil
+}
+
+// GetDatabaseShards returns the shard name → database URL map (validated in Validate)
+func (c *Config) GetDatabaseShards() map[string]string {
+	shards, err := parseDatabaseShards(c.DatabaseShards)
+	if err != nil {
+		return map[string]string{}
+	}
+	return shards
+}
+
+// parseDatabaseShards interpreta "eu=postgres://...,us=postgres://...". Os nomes seguem o
+// formato de REGION e "default" é reservado para o DATABASE_URL.
+func parseDatabaseShards(s string) (map[string]string, error) {
+	shards := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, url, ok := strings.Cut(entry, "=")
+		name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+		if !ok || !regionPattern.MatchString(name) ||
+			!(strings.HasPrefix(url, "postgres://") || strings.HasPrefix(url, "postgresql://")) {
+			return nil, fmt.Errorf("invalid shard %q (expected name=postgres://...)", name)
+		}
+		if name == "default" {
+			return nil, fmt.Errorf("shard name %q is reserved for DATABASE_URL", name)
+		}
+		if _, dup := shards[name]; dup {
+			return nil, fmt.Errorf("duplicate shard %q", name)
+		}
+		shards[name] = url
+	}
+	return shards, nil
+}
+
 // TelemetryEnabled returns true only if OTel is explicitly enabled and an endpoint is provided.
 // This prevents accidental outbound traffic and ensures telemetry is strictly opt-in.
 func (c *Config) TelemetryEnabled() bool {
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Algoritmos de assinatura aceitos em JWT_ISSUERS
+const (
+	JWTAlgorithmHS256 = "HS256" // segredo compartilhado JWT_HS256_SECRET (default)
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmES256 = "ES256" // ECDSA P-256
+	JWTAlgorithmEdDSA = "EdDSA" // Ed25519
+	// JWTAlgorithmOpaque tokens não-JWT validados no introspectionUrl (RFC 7662)
+	JWTAlgorithmOpaque = "opaque"
+)
+
+// defaultJWTKeyID kid assumido quando o token não traz kid (ver KeyResolver)
+const defaultJWTKeyID = "v1"
+
+// defaultIntrospectionCacheSeconds por quanto tempo um resultado active=true é reaproveitado
+const defaultIntrospectionCacheSeconds = 60
+
+// JWTIssuer audiência, clock skew e chave de verificação aceitos para um issuer
+type JWTIssuer struct {
+	Issuer           string
+	Audiences        []string
+	ClockSkewSeconds int
+	Algorithm        string
+	PublicKey        string // PEM; vazio em HS256
+	KeyID            string
+
+	// Somente opaque
+	IntrospectionURL string
+	ClientID         string
+	ClientSecret     string
+	TokenPrefix      string // vazio = qualquer token que não seja JWT nem S2S
+	CacheSeconds     int
+}
+
+// jwtIssuerOverride entrada de JWT_ISSUERS; campos ausentes herdam JWT_AUDIENCE e JWT_CLOCK_SKEW_SECONDS
+type jwtIssuerOverride struct {
+	Audiences        []string `yaml:"audiences"`
+	ClockSkewSeconds *int     `yaml:"clockSkewSeconds"`
+	Algorithm        string   `yaml:"algorithm"`
+	PublicKey        string   `yaml:"publicKey"`
+	KeyID            string   `yaml:"keyId"`
+	IntrospectionURL string   `yaml:"introspectionUrl"`
+	ClientID         string   `yaml:"clientId"`
+	ClientSecret     string   `yaml:"clientSecret"`
+	TokenPrefix      string   `yaml:"tokenPrefix"`
+	CacheSeconds     *int     `yaml:"cacheSeconds"`
+}
+
+// parseJWTIssuers lê JWT_ISSUERS: um mapa issuer -> {audiences, clockSkewSeconds, algorithm,
+// publicKey, keyId} em JSON ou YAML. Issuers opaque usam introspectionUrl, clientId, clientSecret,
+// tokenPrefix e cacheSeconds no lugar da chave.
+// Campos desconhecidos são rejeitados para um erro de digitação não passar despercebido.
+func parseJWTIssuers(raw string) (map[string]jwtIssuerOverride, error) {
+	overrides := map[string]jwtIssuerOverride{}
+	if strings.TrimSpace(raw) == "" {
+		return overrides, nil
+	}
+
+	dec := yaml.NewDecoder(strings.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&overrides); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("JWT_ISSUERS must be a JSON/YAML map of issuer to {audiences, clockSkewSeconds, algorithm, publicKey, keyId}: %w", err)
+	}
+
+	unprefixedOpaque := ""
+	for issuer, o := range overrides {
+		if strings.TrimSpace(issuer) == "" {
+			return nil, fmt.Errorf("JWT_ISSUERS contains an empty issuer")
+		}
+		for _, aud := range o.Audiences {
+			if strings.TrimSpace(aud) == "" {
+				return nil, fmt.Errorf("JWT_ISSUERS[%s].audiences contains an empty audience", issuer)
+			}
+		}
+		if o.ClockSkewSeconds != nil && *o.ClockSkewSeconds < 0 {
+			return nil, fmt.Errorf("JWT_ISSUERS[%s].clockSkewSeconds must be non-negative", issuer)
+		}
+		switch o.Algorithm {
+		case "", JWTAlgorithmHS256:
+			if o.PublicKey != "" {
+				return nil, fmt.Errorf("JWT_ISSUERS[%s].publicKey is only valid for RS256, ES256 and EdDSA", issuer)
+			}
+		case JWTAlgorithmRS256, JWTAlgorithmES256, JWTAlgorithmEdDSA:
+			if strings.TrimSpace(o.PublicKey) == "" {
+				return nil, fmt.Errorf("JWT_ISSUERS[%s].publicKey is required for %s", issuer, o.Algorithm)
+			}
+		case JWTAlgorithmOpaque:
+			if err := validateIntrospectionURL(o.IntrospectionURL); err != nil {
+				return nil, fmt.Errorf("JWT_ISSUERS[%s].introspectionUrl %w", issuer, err)
+			}
+			if o.PublicKey != "" {
+				return nil, fmt.Errorf("JWT_ISSUERS[%s].publicKey is not valid for opaque issuers", issuer)
+			}
+			if o.CacheSeconds != nil && *o.CacheSeconds < 0 {
+				return nil, fmt.Errorf("JWT_ISSUERS[%s].cacheSeconds must be non-negative", issuer)
+			}
+			// Sem prefixo o issuer recebe qualquer token opaco: só um pode ficar assim
+			if o.TokenPrefix == "" {
+				if unprefixedOpaque != "" {
+					return nil, fmt.Errorf("JWT_ISSUERS: opaque issuers %s and %s both lack tokenPrefix", unprefixedOpaque, issuer)
+				}
+				unprefixedOpaque = issuer
+			}
+		default:
+			return nil, fmt.Errorf("JWT_ISSUERS[%s].algorithm must be one of HS256, RS256, ES256, EdDSA, opaque", issuer)
+		}
+		if o.Algorithm != JWTAlgorithmOpaque &&
+			(o.IntrospectionURL != "" || o.ClientID != "" || o.ClientSecret != "" || o.TokenPrefix != "" || o.CacheSeconds != nil) {
+			return nil, fmt.Errorf("JWT_ISSUERS[%s]: introspection settings require algorithm opaque", issuer)
+		}
+	}
+	return overrides, nil
+}
+
+// GetJWTIssuers returns every accepted issuer with its audiences and clock skew:
+// JWT_ALLOWED_ISSUERS first (in order), then issuers only present in JWT_ISSUERS (sorted).
+func (c *Config) GetJWTIssuers() ([]JWTIssuer, error) {
+	overrides, err := parseJWTIssuers(c.JWTIssuers)
+	if err != nil {
+		return nil, err
+	}
+
+	names := c.GetAllowedIssuers()
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+	extra := make([]string, 0, len(overrides))
+	for name := range overrides {
+		if !seen[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	names = append(names, extra...)
+
+	issuers := make([]JWTIssuer, 0, len(names))
+	for _, name := range names {
+		issuers = append(issuers, c.jwtIssuer(name, overrides[name]))
+	}
+	return issuers, nil
+}
+
+// GetJWTIssuer returns the settings of a single issuer (global defaults when it has no entry)
+func (c *Config) GetJWTIssuer(name string) (JWTIssuer, error) {
+	overrides, err := parseJWTIssuers(c.JWTIssuers)
+	if err != nil {
+		return JWTIssuer{}, err
+	}
+	return c.jwtIssuer(name, overrides[name]), nil
+}
+
+// JWTSigner issuer, kid e audiência dos tokens HS256 emitidos pela própria API
+type JWTSigner struct {
+	Issuer   string
+	KeyID    string
+	Audience string
+}
+
+// GetJWTSigner resolve JWT_SIGNER_ISSUER, JWT_SIGNER_KEY_ID e JWT_SIGNER_AUDIENCE e confere que os
+// tokens assinados com eles passam na validação da própria API: o issuer está configurado como
+// HS256 com o mesmo kid e aceita a audiência.
+func (c *Config) GetJWTSigner() (JWTSigner, error) {
+	signer := JWTSigner{
+		Issuer:   strings.TrimSpace(c.JWTSignerIssuer),
+		KeyID:    strings.TrimSpace(c.JWTSignerKeyID),
+		Audience: strings.TrimSpace(c.JWTSignerAudience),
+	}
+	if signer.Issuer == "" {
+		allowed := c.GetAllowedIssuers()
+		if len(allowed) == 0 {
+			return JWTSigner{}, fmt.Errorf("JWT_SIGNER_ISSUER is required when JWT_ALLOWED_ISSUERS is empty")
+		}
+		signer.Issuer = allowed[0]
+	}
+	if signer.KeyID == "" {
+		signer.KeyID = defaultJWTKeyID
+	}
+	if signer.Audience == "" {
+		signer.Audience = c.JWTAudience
+	}
+
+	issuers, err := c.GetJWTIssuers()
+	if err != nil {
+		return JWTSigner{}, err
+	}
+	for _, issuer := range issuers {
+		if issuer.Issuer != signer.Issuer {
+			continue
+		}
+		if issuer.Algorithm != JWTAlgorithmHS256 {
+			return JWTSigner{}, fmt.Errorf("JWT_SIGNER_ISSUER %s must be an HS256 issuer, got %s", signer.Issuer, issuer.Algorithm)
+		}
+		if issuer.KeyID != signer.KeyID {
+			return JWTSigner{}, fmt.Errorf("JWT_SIGNER_KEY_ID %s does not match JWT_ISSUERS[%s].keyId %s", signer.KeyID, signer.Issuer, issuer.KeyID)
+		}
+		if !slices.Contains(issuer.Audiences, signer.Audience) {
+			return JWTSigner{}, fmt.Errorf("JWT_SIGNER_AUDIENCE %s is not accepted by issuer %s", signer.Audience, signer.Issuer)
+		}
+		return signer, nil
+	}
+	return JWTSigner{}, fmt.Errorf("JWT_SIGNER_ISSUER %s is not an allowed issuer", signer.Issuer)
+}
+
+func (c *Config) jwtIssuer(name string, o jwtIssuerOverride) JWTIssuer {
+	issuer := JWTIssuer{
+		Issuer:           name,
+		Audiences:        []string{c.JWTAudience},
+		ClockSkewSeconds: int(c.JWTClockSkew / time.Second),
+		Algorithm:        JWTAlgorithmHS256,
+		PublicKey:        o.PublicKey,
+		KeyID:            defaultJWTKeyID,
+		IntrospectionURL: o.IntrospectionURL,
+		ClientID:         o.ClientID,
+		ClientSecret:     o.ClientSecret,
+		TokenPrefix:      o.TokenPrefix,
+	}
+	if o.Algorithm != "" {
+		issuer.Algorithm = o.Algorithm
+	}
+	if o.KeyID != "" {
+		issuer.KeyID = o.KeyID
+	}
+	if issuer.Algorithm == JWTAlgorithmOpaque {
+		issuer.CacheSeconds = defaultIntrospectionCacheSeconds
+		if o.CacheSeconds != nil {
+			issuer.CacheSeconds = *o.CacheSeconds
+		}
+	}
+	if len(o.Audiences) > 0 {
+		issuer.Audiences = o.Audiences
+	}
+	if o.ClockSkewSeconds != nil {
+		issuer.ClockSkewSeconds = *o.ClockSkewSeconds
+	}
+	return issuer
+}
+
+// validateIntrospectionURL exige URL absoluta http(s); o complemento da mensagem segue o nome do campo
+func validateIntrospectionURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("is required for opaque issuers")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an absolute http(s) URL")
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted valor exibido em Effective para segredos configurados
+const redacted = "[REDACTED]"
+
+// load monta o ambiente efetivo (defaults < CONFIG_FILE < variáveis de ambiente), converte as
+// durações informadas como inteiros para a unidade da tag unit e preenche cfg (ponteiro para struct).
+func load(cfg any) error {
+	environ := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			environ[k] = v
+		}
+	}
+
+	merged := make(map[string]string, len(environ))
+	if path := environ["CONFIG_FILE"]; path != "" {
+		fileVars, err := readConfigFile(path)
+		if err != nil {
+			return err
+		}
+		for k, v := range fileVars {
+			merged[k] = v
+		}
+	}
+	for k, v := range environ {
+		merged[k] = v
+	}
+
+	if err := normalizeDurations(reflect.TypeOf(cfg).Elem(), merged); err != nil {
+		return err
+	}
+	return env.ParseWithOptions(cfg, env.Options{Environment: merged})
+}
+
+// readConfigFile lê CONFIG_FILE: .toml usa o subconjunto plano (CHAVE = valor); qualquer outra
+// extensão é lida como YAML (JSON incluso). As chaves são os nomes das variáveis de ambiente.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("CONFIG_FILE: %w", err)
+	}
+	var vars map[string]string
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		vars, err = parseFlatTOML(data)
+	} else {
+		vars, err = parseYAMLVars(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CONFIG_FILE %s: %w", path, err)
+	}
+	return vars, nil
+}
+
+func parseYAMLVars(data []byte) (map[string]string, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	vars := make(map[string]string, len(raw))
+	for k, v := range raw {
+		switch val := v.(type) {
+		case nil:
+			vars[strings.ToUpper(k)] = ""
+		case string:
+			vars[strings.ToUpper(k)] = val
+		case map[string]any, []any:
+			// Valores estruturados (ex.: JWT_ISSUERS) viram JSON, formato aceito pela variável
+			encoded, err := json.Marshal(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			vars[strings.ToUpper(k)] = string(encoded)
+		default:
+			vars[strings.ToUpper(k)] = fmt.Sprint(val)
+		}
+	}
+	return vars, nil
+}
+
+// parseFlatTOML aceita apenas pares CHAVE = valor no nível raiz (strings, números e booleanos);
+// tabelas e arrays não têm equivalente em variáveis de ambiente.
+func parseFlatTOML(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(line, "[") {
+			return nil, fmt.Errorf("line %d: expected KEY = value", n)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, `"`):
+			end := closingQuote(value)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", n)
+			}
+			unquoted, err := strconv.Unquote(value[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'"):
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", n)
+			}
+			value = value[1 : end+1]
+		default:
+			if i := strings.Index(value, "#"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+			if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+				return nil, fmt.Errorf("line %d: arrays and inline tables are not supported", n)
+			}
+		}
+		vars[strings.ToUpper(key)] = value
+	}
+	return vars, scanner.Err()
+}
+
+// closingQuote índice da aspa que fecha a string básica iniciada em s[0] (-1 se não houver)
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeDurations converte inteiros puros dos campos com tag unit ("30" em *_SECONDS) para o
+// formato de time.ParseDuration; valores como "90s" passam direto.
+func normalizeDurations(t reflect.Type, vars map[string]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		unit := field.Tag.Get("unit")
+		if unit == "" {
+			continue
+		}
+		key := envKey(field)
+		value := strings.TrimSpace(vars[key])
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		switch unit {
+		case "ms", "s", "m":
+			vars[key] = strconv.FormatInt(n, 10) + unit
+		case "d":
+			vars[key] = strconv.FormatInt(n*24, 10) + "h"
+		default:
+			return fmt.Errorf("config: unknown unit %q on %s", unit, field.Name)
+		}
+	}
+	return nil
+}
+
+// envKey nome da variável declarada na tag env ("" para campos sem tag)
+func envKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+	return key
+}
+
+// Effective retorna a configuração em uso por nome de variável, com durações no formato Go e
+// segredos configurados mascarados. Logada na subida do servidor.
+func (c *Config) Effective() map[string]string {
+	values := c.values()
+	for key := range secretKeys {
+		values[key] = redactedIfSet(values[key])
+	}
+	return values
+}
+
+// values valores formatados por nome de variável, sem mascarar segredos
+func (c *Config) values() map[string]string {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	out := make(map[string]string, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key := envKey(t.Field(i))
+		if key == "" {
+			continue
+		}
+		if d, ok := v.Field(i).Interface().(time.Duration); ok {
+			out[key] = d.String()
+		} else {
+			out[key] = fmt.Sprint(v.Field(i).Interface())
+		}
+	}
+	return out
+}
+
+// secretKeys e reloadableKeys variáveis marcadas com secret:"true" e reload:"true" em Config
+var secretKeys, reloadableKeys = taggedKeys("secret"), taggedKeys("reload")
+
+func taggedKeys(tag string) map[string]bool {
+	t := reflect.TypeOf(Config{})
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get(tag) == "true" {
+			keys[envKey(t.Field(i))] = true
+		}
+	}
+	return keys
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Change variável cuja nova leitura difere da configuração em uso. Applied é false para variáveis
+// sem reload:"true", que só valem após restart; segredos aparecem mascarados.
+type Change struct {
+	Key     string `json:"key"`
+	Old     string `json:"old"`
+	New     string `json:"new"`
+	Applied bool   `json:"applied"`
+}
+
+// Live guarda a configuração em uso e a troca em Reload. Componentes com settings recarregáveis
+// se inscrevem com OnReload e recebem a nova configuração já validada.
+type Live struct {
+	current atomic.Pointer[Config]
+	load    func() (*Config, error)
+
+	mu        sync.Mutex // serializa Reload e OnReload
+	listeners []func(cfg *Config)
+}
+
+// NewLive parte de cfg; load relê a configuração (LoadConfig em produção).
+func NewLive(cfg *Config, load func() (*Config, error)) *Live {
+	l := &Live{load: load}
+	l.current.Store(cfg)
+	return l
+}
+
+// Current returns the configuration in use (never mutate it)
+func (l *Live) Current() *Config {
+	return l.current.Load()
+}
+
+// OnReload registra fn, chamada após cada Reload que aplicou alguma mudança
+func (l *Live) OnReload(fn func(cfg *Config)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listeners = append(l.listeners, fn)
+}
+
+// Reload relê ambiente e CONFIG_FILE e aplica só as variáveis recarregáveis; as demais mudanças
+// voltam com Applied=false. Se a nova leitura não validar, nada muda.
+func (l *Live) Reload() ([]Change, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	loaded, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	current := l.current.Load()
+	changes := diff(current, loaded)
+
+	applied := false
+	for _, change := range changes {
+		applied = applied || change.Applied
+	}
+	if !applied {
+		return changes, nil
+	}
+
+	next := *current
+	nv, lv := reflect.ValueOf(&next).Elem(), reflect.ValueOf(loaded).Elem()
+	for i := 0; i < nv.NumField(); i++ {
+		if reloadableKeys[envKey(nv.Type().Field(i))] {
+			nv.Field(i).Set(lv.Field(i))
+		}
+	}
+	l.current.Store(&next)
+
+	for _, fn := range l.listeners {
+		fn(&next)
+	}
+	return changes, nil
+}
+
+// diff mudanças entre duas leituras, ordenadas por variável
+func diff(old, next *Config) []Change {
+	oldValues, nextValues := old.values(), next.values()
+	changes := []Change{}
+	for key, value := range nextValues {
+		if oldValues[key] == value {
+			continue
+		}
+		change := Change{Key: key, Old: oldValues[key], New: value, Applied: reloadableKeys[key]}
+		if secretKeys[key] {
+			change.Old, change.New = redactedIfSet(change.Old), redactedIfSet(change.New)
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+func redactedIfSet(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
@@ -0,0 +1,135 @@
+package counters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/resilience"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	fieldOpenDeals        = "openDeals"
+	fieldTasksDueToday    = "tasksDueToday"
+	fieldNewLeadsThisWeek = "newLeadsThisWeek"
+	fieldReconciledAt     = "reconciledAt"
+)
+
+// incrScript aplica os deltas somente se o hash existir: sem cache não há base
+// para incrementar, e a próxima leitura reconcilia com o Postgres.
+var incrScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[2])
+redis.call('HINCRBY', KEYS[1], ARGV[3], ARGV[4])
+redis.call('HINCRBY', KEYS[1], ARGV[5], ARGV[6])
+return 1
+`)
+
+// RedisStore guarda os contadores do dashboard em um hash por workspace e dia.
+// A chave expira após ttl, forçando a reconciliação periódica com o Postgres e
+// corrigindo desvios de escritas que não passam pelos incrementos (workers, imports).
+type RedisStore struct {
+	client  *redis.Client
+	breaker *resilience.Breaker
+	ttl     time.Duration
+}
+
+// NewRedisStore creates a new Redis-backed counter store.
+// breaker pode ser nil; quando aberto, as operações retornam resilience.ErrCircuitOpen
+// e o service recorre ao Postgres.
+func NewRedisStore(client *redis.Client, breaker *resilience.Breaker, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, breaker: breaker, ttl: ttl}
+}
+
+func counterKey(workspaceID string, period domain.CounterPeriod) string {
+	return fmt.Sprintf("counters:workspace:%s:%s", workspaceID, period.Key())
+}
+
+// Get returns the cached counters; ok is false on cache miss.
+func (s *RedisStore) Get(ctx context.Context, workspaceID string, period domain.CounterPeriod) (*domain.WorkspaceCounters, bool, error) {
+	var values map[string]string
+	err := s.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		values, err = s.client.HGetAll(ctx, counterKey(workspaceID, period)).Result()
+		return err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("get counters: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, false, nil
+	}
+
+	c := &domain.WorkspaceCounters{Cached: true}
+	var reconciledAt int64
+	for field, dst := range map[string]*int64{
+		fieldOpenDeals:        &c.OpenDeals,
+		fieldTasksDueToday:    &c.TasksDueToday,
+		fieldNewLeadsThisWeek: &c.NewLeadsThisWeek,
+		fieldReconciledAt:     &reconciledAt,
+	} {
+		v, err := strconv.ParseInt(values[field], 10, 64)
+		if err != nil {
+			// Hash incompleto/corrompido: trata como miss
+			return nil, false, nil
+		}
+		*dst = v
+	}
+	c.ReconciledAt = time.UnixMilli(reconciledAt).UTC()
+
+	return c, true, nil
+}
+
+// Set replaces the cached counters and restarts the reconciliation TTL.
+func (s *RedisStore) Set(ctx context.Context, workspaceID string, period domain.CounterPeriod, c *domain.WorkspaceCounters) error {
+	key := counterKey(workspaceID, period)
+	err := s.breaker.Execute(ctx, func(ctx context.Context) error {
+		pipe := s.client.TxPipeline()
+		pipe.HSet(ctx, key,
+			fieldOpenDeals, c.OpenDeals,
+			fieldTasksDueToday, c.TasksDueToday,
+			fieldNewLeadsThisWeek, c.NewLeadsThisWeek,
+			fieldReconciledAt, c.ReconciledAt.UnixMilli(),
+		)
+		pipe.Expire(ctx, key, s.ttl)
+		_, err := pipe.Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("set counters: %w", err)
+	}
+	return nil
+}
+
+// Incr applies deltas to the cached counters, if present (sem retry: HINCRBY não é idempotente).
+func (s *RedisStore) Incr(ctx context.Context, workspaceID string, period domain.CounterPeriod, d domain.CounterDeltas) error {
+	key := counterKey(workspaceID, period)
+	err := s.breaker.Execute(ctx, func(ctx context.Context) error {
+		return incrScript.Run(ctx, s.client, []string{key},
+			fieldOpenDeals, d.OpenDeals,
+			fieldTasksDueToday, d.TasksDueToday,
+			fieldNewLeadsThisWeek, d.NewLeadsThisWeek,
+		).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("incr counters: %w", err)
+	}
+	return nil
+}
+
+// Invalidate drops the cached counters; the next read reconciles with Postgres.
+func (s *RedisStore) Invalidate(ctx context.Context, workspaceID string, period domain.CounterPeriod) error {
+	err := s.breaker.Execute(ctx, func(ctx context.Context) error {
+		return s.client.Del(ctx, counterKey(workspaceID, period)).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("invalidate counters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Package cron interpreta expressões cron de 5 campos (minuto hora dia-do-mês mês dia-da-semana)
+// usadas pelos agendamentos de relatórios.
+//
+// Cada campo aceita *, valores, listas (1,15), intervalos (1-5) e passos (*/15, 8-18/2).
+// Dia da semana vai de 0 (domingo) a 7 (também domingo). Como no cron clássico, quando dia do
+// mês e dia da semana são ambos restritos, basta um deles coincidir.
+package cron
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule expressão já interpretada.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64 // bitsets
+	domStar, dowStar              bool
+}
+
+type fieldSpec struct {
+	name     string
+	min, max int
+}
+
+var fields = []fieldSpec{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Parse interpreta a expressão. Aceita também os atalhos @hourly, @daily, @weekly e @monthly.
+func Parse(expr string) (*Schedule, error) {
+	switch strings.TrimSpace(expr) {
+	case "@hourly":
+		expr = "0 * * * *"
+	case "@daily":
+		expr = "0 0 * * *"
+	case "@weekly":
+		expr = "0 0 * * 0"
+	case "@monthly":
+		expr = "0 0 1 * *"
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields (minute hour day-of-month month day-of-week)")
+	}
+
+	var set [5]uint64
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		set[i] = b
+	}
+
+	// 7 também é domingo
+	if set[4]&(1<<7) != 0 {
+		set[4] |= 1
+	}
+
+	return &Schedule{
+		minute:  set[0],
+		hour:    set[1],
+		dom:     set[2],
+		month:   set[3],
+		dow:     set[4],
+		domStar: parts[2] == "*",
+		dowStar: parts[4] == "*",
+	}, nil
+}
+
+// RunsPerHour quantas execuções a expressão pode disparar dentro de uma mesma hora.
+func (s *Schedule) RunsPerHour() int {
+	return bits.OnesCount64(s.minute)
+}
+
+func parseField(field string, spec fieldSpec) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", spec.name, field)
+			}
+			rangePart, step = item[:i], n
+		}
+
+		lo, hi := spec.min, spec.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %s field %q", spec.name, field)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %s field %q", spec.name, field)
+				}
+			} else if step > 1 {
+				// "5/15" equivale a "5-max/15"
+				hi = spec.max
+			}
+		}
+		if lo < spec.min || hi > spec.max || lo > hi {
+			return 0, fmt.Errorf("%s field %q out of range %d-%d", spec.name, field, spec.min, spec.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// maxSearch limita a busca da próxima execução (ex.: "0 0 30 2 *" nunca acontece).
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Next retorna a primeira execução estritamente depois de after, no fuso de after.
+// Retorna o instante zero se não houver execução nos próximos 5 anos. Horários que não existem
+// no fuso (pulados pelo horário de verão) não disparam; horários que se repetem na volta do
+// horário de verão disparam uma vez só.
+func (s *Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(maxSearch)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		// Na volta do horário de verão a mesma hora local se repete
+		if !wallClock(t).After(wallClock(after)) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// forward devolve next quando ele avança. Um horário local que não existe (pulado pelo horário
+// de verão) pode ser normalizado pelo time.Date para antes de t; a busca segue da próxima hora cheia.
+func forward(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+// wallClock data e hora locais de t, sem o offset do fuso.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
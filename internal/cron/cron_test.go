@@ -0,0 +1,167 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func utc(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"", "must have 5 fields"},
+		{"* * * *", "must have 5 fields"},
+		{"* * * * * *", "must have 5 fields"},
+		{"60 * * * *", "minute field"},
+		{"* 24 * * *", "hour field"},
+		{"* * 0 * *", "day of month field"},
+		{"* * 32 * *", "day of month field"},
+		{"* * * 13 *", "month field"},
+		{"* * * * 8", "day of week field"},
+		{"*/0 * * * *", "invalid step"},
+		{"*/x * * * *", "invalid step"},
+		{"5-1 * * * *", "out of range"},
+		{"a * * * *", "invalid value"},
+		{"1-x * * * *", "invalid value"},
+		{"@yearly", "must have 5 fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSchedule_RunsPerHour(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"0 * * * *", 1},
+		{"@daily", 1},
+		{"0,30 * * * *", 2},
+		{"*/15 * * * *", 4},
+		{"10-20/5 * * * *", 3},
+		{"* * * * *", 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.RunsPerHour())
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// 2026-01-09 é uma sexta-feira
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"strictly after", "30 10 * * *", utc(2026, 1, 9, 10, 30), utc(2026, 1, 10, 10, 30)},
+		{"seconds are truncated", "30 10 * * *", utc(2026, 1, 9, 10, 29).Add(59 * time.Second), utc(2026, 1, 9, 10, 30)},
+		{"step", "*/15 * * * *", utc(2026, 1, 9, 10, 7), utc(2026, 1, 9, 10, 15)},
+		{"step wraps the hour", "*/15 * * * *", utc(2026, 1, 9, 10, 45), utc(2026, 1, 9, 11, 0)},
+		{"step from a value", "5/20 * * * *", utc(2026, 1, 9, 10, 26), utc(2026, 1, 9, 10, 45)},
+		{"step over a range", "0 8-18/2 * * *", utc(2026, 1, 9, 10, 0), utc(2026, 1, 9, 12, 0)},
+		{"step over a range wraps the day", "0 8-18/2 * * *", utc(2026, 1, 9, 18, 0), utc(2026, 1, 10, 8, 0)},
+		{"list", "0 9,17 * * *", utc(2026, 1, 9, 9, 0), utc(2026, 1, 9, 17, 0)},
+		{"month list", "0 12 1 2,6 *", utc(2026, 2, 1, 12, 0), utc(2026, 6, 1, 12, 0)},
+		{"weekdays skip the weekend", "0 9 * * 1-5", utc(2026, 1, 9, 10, 0), utc(2026, 1, 12, 9, 0)},
+		{"0 is Sunday", "0 9 * * 0", utc(2026, 1, 9, 10, 0), utc(2026, 1, 11, 9, 0)},
+		{"7 is Sunday", "0 9 * * 7", utc(2026, 1, 9, 10, 0), utc(2026, 1, 11, 9, 0)},
+		{"range ending in 7 includes Sunday", "0 9 * * 6-7", utc(2026, 1, 10, 10, 0), utc(2026, 1, 11, 9, 0)},
+		{"day of month only", "0 0 13 * *", utc(2026, 1, 1, 0, 0), utc(2026, 1, 13, 0, 0)},
+		{"day of week only", "0 0 * * 5", utc(2026, 1, 1, 0, 0), utc(2026, 1, 2, 0, 0)},
+		{"day of month or day of week: first friday", "0 0 13 * 5", utc(2026, 1, 1, 0, 0), utc(2026, 1, 2, 0, 0)},
+		{"day of month or day of week: next friday", "0 0 13 * 5", utc(2026, 1, 2, 0, 0), utc(2026, 1, 9, 0, 0)},
+		{"day of month or day of week: the 13th", "0 0 13 * 5", utc(2026, 1, 9, 0, 0), utc(2026, 1, 13, 0, 0)},
+		{"31st skips short months", "0 0 31 * *", utc(2026, 4, 1, 0, 0), utc(2026, 5, 31, 0, 0)},
+		{"29 February waits for a leap year", "0 0 29 2 *", utc(2026, 3, 1, 0, 0), utc(2028, 2, 29, 0, 0)},
+		{"@hourly", "@hourly", utc(2026, 1, 9, 10, 0), utc(2026, 1, 9, 11, 0)},
+		{"@daily", "@daily", utc(2026, 1, 9, 10, 0), utc(2026, 1, 10, 0, 0)},
+		{"@weekly", "@weekly", utc(2026, 1, 9, 10, 0), utc(2026, 1, 11, 0, 0)},
+		{"@monthly", "@monthly", utc(2026, 1, 9, 10, 0), utc(2026, 2, 1, 0, 0)},
+		{"year boundary", "0 0 1 1 *", utc(2026, 1, 1, 0, 0), utc(2027, 1, 1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(tt.after))
+		})
+	}
+}
+
+func TestSchedule_NextImpossibleDates(t *testing.T) {
+	for _, expr := range []string{"0 0 31 2 *", "0 0 30 2 *", "0 0 31 4,6,9,11 *"} {
+		t.Run(expr, func(t *testing.T) {
+			s, err := Parse(expr)
+			require.NoError(t, err)
+			assert.True(t, s.Next(utc(2026, 1, 1, 0, 0)).IsZero())
+		})
+	}
+}
+
+func TestSchedule_NextDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	ny := func(month time.Month, day, hour, min int, offset int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, time.FixedZone("", offset*3600))
+	}
+
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		// 2026-03-08: 02:00 EST vira 03:00 EDT
+		{"time in the spring gap is skipped", "30 2 * * *",
+			time.Date(2026, 3, 7, 3, 0, 0, 0, newYork), ny(3, 9, 2, 30, -4)},
+		{"hourly jumps over the missing hour", "0 * * * *",
+			time.Date(2026, 3, 8, 1, 30, 0, 0, newYork), ny(3, 8, 3, 0, -4)},
+		{"daily keeps the local time after spring forward", "0 9 * * *",
+			time.Date(2026, 3, 7, 9, 0, 0, 0, newYork), ny(3, 8, 9, 0, -4)},
+		// 2026-11-01: 02:00 EDT volta para 01:00 EST
+		{"repeated hour runs on its first occurrence", "30 1 * * *",
+			time.Date(2026, 11, 1, 0, 0, 0, 0, newYork), ny(11, 1, 1, 30, -4)},
+		{"repeated hour does not run twice", "30 1 * * *",
+			ny(11, 1, 1, 30, -4).In(newYork), ny(11, 2, 1, 30, -5)},
+		{"hourly does not repeat the local hour", "0 * * * *",
+			ny(11, 1, 1, 0, -4).In(newYork), ny(11, 1, 2, 0, -5)},
+		// 2018-11-04: meia-noite não existia em São Paulo (00:00 -03 virava 01:00 -02)
+		{"missing midnight", "0 0 * * *",
+			time.Date(2018, 11, 3, 12, 0, 0, 0, saoPaulo), time.Date(2018, 11, 5, 0, 0, 0, 0, time.FixedZone("", -2*3600))},
+		{"day after a missing midnight", "0 12 4 11 *",
+			time.Date(2018, 11, 3, 12, 0, 0, 0, saoPaulo), time.Date(2018, 11, 4, 12, 0, 0, 0, time.FixedZone("", -2*3600))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			got := s.Next(tt.after)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+			assert.Equal(t, tt.after.Location(), got.Location())
+		})
+	}
+}
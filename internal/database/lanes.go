@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Lane classifica a request para fins de prioridade de acesso ao banco.
+type Lane string
+
+const (
+	// LaneInteractive é o default: listas, boards e CRUD disparados por usuários
+	LaneInteractive Lane = "interactive"
+	// LaneBatch cobre imports, ações em lote e syncs de integrações
+	LaneBatch Lane = "batch"
+)
+
+type laneKey struct{}
+
+// WithLane stores the request lane in context
+func WithLane(ctx context.Context, lane Lane) context.Context {
+	return context.WithValue(ctx, laneKey{}, lane)
+}
+
+// LaneFromContext returns the request lane (LaneInteractive when unset)
+func LaneFromContext(ctx context.Context) Lane {
+	if lane, ok := ctx.Value(laneKey{}).(Lane); ok {
+		return lane
+	}
+	return LaneInteractive
+}
+
+// DB é o subconjunto de *pgxpool.Pool usado pelos repositórios.
+// Compatível com sqlc.DBTX.
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// LanePool escolhe o pool de conexões conforme a lane da request:
+// tráfego batch usa um pool menor e separado, de modo que um sync grande
+// não consome as conexões das requests interativas.
+type LanePool struct {
+	interactive *pgxpool.Pool
+	batch       *pgxpool.Pool
+}
+
+// NewLanePool creates a LanePool. batch pode ser nil (batch compartilha o pool interativo).
+func NewLanePool(interactive, batch *pgxpool.Pool) *LanePool {
+	return &LanePool{interactive: interactive, batch: batch}
+}
+
+func (p *LanePool) pick(ctx context.Context) *pgxpool.Pool {
+	if p.batch != nil && LaneFromContext(ctx) == LaneBatch {
+		return p.batch
+	}
+	return p.interactive
+}
+
+func (p *LanePool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return p.pick(ctx).Exec(ctx, sql, args...)
+}
+
+func (p *LanePool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return p.pick(ctx).Query(ctx, sql, args...)
+}
+
+func (p *LanePool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return p.pick(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (p *LanePool) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.pick(ctx).Begin(ctx)
+}
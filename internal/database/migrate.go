@@ -4,7 +4,6 @@ import (
 	"embed"
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -15,10 +14,9 @@ import (
 var migrationsFS embed.FS
 
 // RunMigrations executes all pending database migrations
-func RunMigrations() error {
-	databaseURL := os.Getenv("DATABASE_URL")
+func RunMigrations(databaseURL string) error {
 	if databaseURL == "" {
-		return fmt.Errorf("DATABASE_URL environment variable is not set")
+		return fmt.Errorf("DATABASE_URL is not set")
 	}
 
 	fmt.Println("Starting Database Migrations...")
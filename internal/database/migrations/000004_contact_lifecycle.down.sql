@@ -0,0 +1,7 @@
+-- Migration: 000004_contact_lifecycle.down.sql
+-- Description: Rollback contact lifecycle stages
+-- Date: 2026-10-17
+
+-- PostgreSQL não remove valores de ENUM (ALTER TYPE ... DROP VALUE não existe).
+-- 'CHURNED' permanece em "ContactLifecycleStage"; esta migration é no-op no rollback.
+SELECT 1;
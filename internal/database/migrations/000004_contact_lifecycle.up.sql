@@ -0,0 +1,13 @@
+-- Migration: 000004_contact_lifecycle.up.sql
+-- Description: Add CHURNED to ContactLifecycleStage (alinha com CompanyLifecycleStage)
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Enum: ContactLifecycleStage
+-- Purpose: Funil de contatos = LEAD, MQL, SQL, CUSTOMER, CHURNED (igual a Company).
+-- OPPORTUNITY e EVANGELIST continuam no enum do Prisma (valores legados),
+-- mas a API só aceita transições para os estágios do funil.
+-- O índice ("workspaceId", "lifecycleStage") já existe no schema do Prisma.
+-- =====================================================
+ALTER TYPE "ContactLifecycleStage" ADD VALUE IF NOT EXISTS 'CHURNED';
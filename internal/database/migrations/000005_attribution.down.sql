@@ -0,0 +1,23 @@
+-- Migration: 000005_attribution.down.sql
+-- Description: Rollback source/UTM attribution
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Deal_workspaceId_utmCampaign_idx";
+DROP INDEX IF EXISTS "Deal_workspaceId_source_idx";
+DROP INDEX IF EXISTS "Contact_workspaceId_utmCampaign_idx";
+DROP INDEX IF EXISTS "Contact_workspaceId_source_idx";
+
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "utmContent";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "utmTerm";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "utmCampaign";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "utmMedium";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "utmSource";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "sourceDetail";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "source";
+
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "utmContent";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "utmTerm";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "utmCampaign";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "utmMedium";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "utmSource";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "sourceDetail";
@@ -0,0 +1,35 @@
+-- Migration: 000005_attribution.up.sql
+-- Description: Source/UTM attribution on Contact and Deal
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Contact
+-- Purpose: "source" já existe (default 'manual'); adiciona detalhe e UTMs
+-- =====================================================
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "sourceDetail" TEXT;
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "utmSource" TEXT;
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "utmMedium" TEXT;
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "utmCampaign" TEXT;
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "utmTerm" TEXT;
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "utmContent" TEXT;
+
+-- =====================================================
+-- Table: Deal
+-- Purpose: mesma atribuição do contato, capturada na criação do negócio
+-- =====================================================
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "source" TEXT DEFAULT 'manual';
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "sourceDetail" TEXT;
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "utmSource" TEXT;
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "utmMedium" TEXT;
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "utmCampaign" TEXT;
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "utmTerm" TEXT;
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "utmContent" TEXT;
+
+-- =====================================================
+-- Indexes: filtros de listagem e /reports/attribution
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Contact_workspaceId_source_idx" ON "Contact" ("workspaceId", "source");
+CREATE INDEX IF NOT EXISTS "Contact_workspaceId_utmCampaign_idx" ON "Contact" ("workspaceId", "utmCampaign");
+CREATE INDEX IF NOT EXISTS "Deal_workspaceId_source_idx" ON "Deal" ("workspaceId", "source");
+CREATE INDEX IF NOT EXISTS "Deal_workspaceId_utmCampaign_idx" ON "Deal" ("workspaceId", "utmCampaign");
@@ -0,0 +1,12 @@
+-- Migration: 000006_next_step.down.sql
+-- Description: Rollback follow-up "next step"
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Deal_workspaceId_nextStepAt_idx";
+DROP INDEX IF EXISTS "Contact_workspaceId_nextStepAt_idx";
+
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "nextStepNote";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "nextStepAt";
+
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "nextStepNote";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "nextStepAt";
@@ -0,0 +1,24 @@
+-- Migration: 000006_next_step.up.sql
+-- Description: Follow-up "next step" on Contact and Deal
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Contact
+-- =====================================================
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "nextStepAt" TIMESTAMP(3);
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "nextStepNote" TEXT;
+
+-- =====================================================
+-- Table: Deal
+-- =====================================================
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "nextStepAt" TIMESTAMP(3);
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "nextStepNote" TEXT;
+
+-- =====================================================
+-- Indexes: /reports/overdue (somente registros ativos com próximo passo)
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Contact_workspaceId_nextStepAt_idx" ON "Contact" ("workspaceId", "nextStepAt")
+    WHERE "deletedAt" IS NULL AND "nextStepAt" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "Deal_workspaceId_nextStepAt_idx" ON "Deal" ("workspaceId", "nextStepAt")
+    WHERE "deletedAt" IS NULL AND "nextStepAt" IS NOT NULL;
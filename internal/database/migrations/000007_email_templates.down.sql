@@ -0,0 +1,5 @@
+-- Migration: 000007_email_templates.down.sql
+-- Description: Rollback email templates
+-- Date: 2026-10-17
+
+DROP TABLE IF EXISTS "EmailTemplate";
@@ -0,0 +1,34 @@
+-- Migration: 000007_email_templates.up.sql
+-- Description: Email templates with merge fields per workspace
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: EmailTemplate
+-- Purpose: assunto/corpo com merge fields ({contact.firstName}, {deal.value})
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "EmailTemplate" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "subject" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "description" TEXT,
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "EmailTemplate_pkey" PRIMARY KEY ("id")
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "EmailTemplate_workspaceId_idx" ON "EmailTemplate" ("workspaceId");
+
+-- Nome único por workspace entre templates ativos
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_email_template_name_per_workspace"
+    ON "EmailTemplate" ("workspaceId", "name")
+    WHERE "deletedAt" IS NULL;
@@ -0,0 +1,6 @@
+-- Migration: 000008_sequences.down.sql
+-- Description: Rollback sequences
+-- Date: 2026-10-17
+
+DROP TABLE IF EXISTS "SequenceEnrollment";
+DROP TABLE IF EXISTS "Sequence";
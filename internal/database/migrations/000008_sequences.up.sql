@@ -0,0 +1,70 @@
+-- Migration: 000008_sequences.up.sql
+-- Description: Sequences (cadences) with ordered steps and contact enrollments
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Sequence
+-- Purpose: cadência com passos ordenados (task, email, wait) em JSONB
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "Sequence" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "steps" JSONB NOT NULL DEFAULT '[]',
+    "onDealWon" TEXT NOT NULL DEFAULT 'UNENROLL',
+    "onReply" TEXT NOT NULL DEFAULT 'PAUSE',
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "Sequence_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "Sequence_onDealWon_check" CHECK ("onDealWon" IN ('NONE', 'PAUSE', 'UNENROLL')),
+    CONSTRAINT "Sequence_onReply_check" CHECK ("onReply" IN ('NONE', 'PAUSE', 'UNENROLL'))
+);
+
+-- =====================================================
+-- Table: SequenceEnrollment
+-- Purpose: inscrição de um contato (e opcionalmente um negócio) em uma sequência.
+-- O worker executa o passo "currentStep" quando "nextRunAt" vence.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "SequenceEnrollment" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "sequenceId" TEXT NOT NULL,
+    "contactId" TEXT NOT NULL,
+    "dealId" TEXT,
+    "status" TEXT NOT NULL DEFAULT 'ACTIVE',
+    "statusReason" TEXT,
+    "statusChangedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "currentStep" INTEGER NOT NULL DEFAULT 0,
+    "nextRunAt" TIMESTAMP(3),
+    "enrolledById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SequenceEnrollment_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "SequenceEnrollment_sequenceId_fkey" FOREIGN KEY ("sequenceId") REFERENCES "Sequence"("id") ON DELETE CASCADE,
+    CONSTRAINT "SequenceEnrollment_status_check" CHECK ("status" IN ('ACTIVE', 'PAUSED', 'COMPLETED', 'EXITED'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Sequence_workspaceId_idx" ON "Sequence" ("workspaceId");
+
+CREATE INDEX IF NOT EXISTS "SequenceEnrollment_workspaceId_sequenceId_idx"
+    ON "SequenceEnrollment" ("workspaceId", "sequenceId");
+
+-- Fila do worker: apenas inscrições ativas com passo agendado
+CREATE INDEX IF NOT EXISTS "SequenceEnrollment_nextRunAt_idx"
+    ON "SequenceEnrollment" ("nextRunAt")
+    WHERE "status" = 'ACTIVE';
+
+-- Um contato só pode ter uma inscrição em andamento por sequência
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_active_enrollment_per_sequence"
+    ON "SequenceEnrollment" ("sequenceId", "contactId")
+    WHERE "status" IN ('ACTIVE', 'PAUSED');
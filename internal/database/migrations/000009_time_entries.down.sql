@@ -0,0 +1,5 @@
+-- Migration: 000009_time_entries.down.sql
+-- Description: Rollback time entries
+-- Date: 2026-10-17
+
+DROP TABLE IF EXISTS "TimeEntry";
@@ -0,0 +1,47 @@
+-- Migration: 000009_time_entries.up.sql
+-- Description: Time tracking entries attached to tasks and deals
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: TimeEntry
+-- Purpose: horas trabalhadas por usuário em uma tarefa e/ou negócio.
+-- "endedAt"/"durationSeconds" NULL = timer em andamento.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "TimeEntry" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "taskId" TEXT,
+    "dealId" TEXT,
+    "description" TEXT,
+    "startedAt" TIMESTAMP(3) NOT NULL,
+    "endedAt" TIMESTAMP(3),
+    "durationSeconds" INTEGER,
+    "billable" BOOLEAN NOT NULL DEFAULT TRUE,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "TimeEntry_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "TimeEntry_target_check" CHECK ("taskId" IS NOT NULL OR "dealId" IS NOT NULL),
+    CONSTRAINT "TimeEntry_duration_check" CHECK (
+        ("endedAt" IS NULL AND "durationSeconds" IS NULL)
+        OR ("endedAt" IS NOT NULL AND "durationSeconds" >= 0)
+    )
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "TimeEntry_workspaceId_startedAt_idx"
+    ON "TimeEntry" ("workspaceId", "startedAt")
+    WHERE "deletedAt" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "TimeEntry_taskId_idx" ON "TimeEntry" ("taskId") WHERE "deletedAt" IS NULL;
+CREATE INDEX IF NOT EXISTS "TimeEntry_dealId_idx" ON "TimeEntry" ("dealId") WHERE "deletedAt" IS NULL;
+
+-- Um usuário só pode ter um timer em andamento por workspace
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_running_time_entry_per_user"
+    ON "TimeEntry" ("workspaceId", "userId")
+    WHERE "endedAt" IS NULL AND "deletedAt" IS NULL;
@@ -0,0 +1,7 @@
+-- Migration: 000010_my_work_indexes.down.sql
+-- Description: Rollback my work indexes
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Deal_workspaceId_ownerId_open_idx";
+DROP INDEX IF EXISTS "Meeting_workspaceId_userId_startTime_idx";
+DROP INDEX IF EXISTS "Task_workspace_assignee_open_due_idx";
@@ -0,0 +1,26 @@
+-- Migration: 000010_my_work_indexes.up.sql
+-- Description: Targeted indexes for GET /me/work (per-user work aggregation)
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Task: tarefas abertas do responsável por vencimento
+-- NOTE: colunas snake_case, como lidas pelo TaskRepository
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Task_workspace_assignee_open_due_idx"
+    ON "Task" (workspace_id, assigned_to, due_date)
+    WHERE deleted_at IS NULL AND status IN ('TODO', 'IN_PROGRESS') AND due_date IS NOT NULL;
+
+-- =====================================================
+-- Meeting: próximas reuniões do usuário
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Meeting_workspaceId_userId_startTime_idx"
+    ON "Meeting" ("workspaceId", "userId", "startTime")
+    WHERE "deletedAt" IS NULL;
+
+-- =====================================================
+-- Deal: negócios OPEN do responsável
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Deal_workspaceId_ownerId_open_idx"
+    ON "Deal" ("workspaceId", "ownerId")
+    WHERE "deletedAt" IS NULL AND stage = 'OPEN';
@@ -0,0 +1,8 @@
+-- Migration: 000011_workspace_snapshots.down.sql
+-- Description: Rollback workspace snapshot jobs
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "unique_active_snapshot_per_workspace";
+DROP INDEX IF EXISTS "WorkspaceSnapshot_pending_idx";
+DROP INDEX IF EXISTS "WorkspaceSnapshot_workspaceId_createdAt_idx";
+DROP TABLE IF EXISTS "WorkspaceSnapshot";
@@ -0,0 +1,47 @@
+-- Migration: 000011_workspace_snapshots.up.sql
+-- Description: Async workspace snapshot (export) and restore jobs
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: WorkspaceSnapshot
+-- Purpose: jobs de exportação/restauração de um workspace inteiro.
+-- EXPORT grava um tar.gz (NDJSON por entidade) no object storage em "objectKey";
+-- RESTORE lê um arquivo existente em "objectKey" e importa no workspace.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "WorkspaceSnapshot" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "kind" TEXT NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'PENDING',
+    "objectKey" TEXT NOT NULL,
+    "sizeBytes" BIGINT,
+    "entityCounts" JSONB NOT NULL DEFAULT '{}',
+    "error" TEXT,
+    "requestedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "startedAt" TIMESTAMP(3),
+    "completedAt" TIMESTAMP(3),
+
+    CONSTRAINT "WorkspaceSnapshot_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "WorkspaceSnapshot_kind_check" CHECK ("kind" IN ('EXPORT', 'RESTORE')),
+    CONSTRAINT "WorkspaceSnapshot_status_check" CHECK ("status" IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "WorkspaceSnapshot_workspaceId_createdAt_idx"
+    ON "WorkspaceSnapshot" ("workspaceId", "createdAt" DESC);
+
+-- Fila do snapshot-worker
+CREATE INDEX IF NOT EXISTS "WorkspaceSnapshot_pending_idx"
+    ON "WorkspaceSnapshot" ("createdAt")
+    WHERE "status" = 'PENDING';
+
+-- Um job ativo (PENDING/RUNNING) por workspace: exportar durante uma restauração
+-- (ou restaurar duas vezes em paralelo) geraria um arquivo inconsistente.
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_active_snapshot_per_workspace"
+    ON "WorkspaceSnapshot" ("workspaceId")
+    WHERE "status" IN ('PENDING', 'RUNNING');
@@ -0,0 +1,6 @@
+-- Migration: 000012_field_history.down.sql
+-- Description: Rollback field-level change history
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "FieldRevision_entity_createdAt_idx";
+DROP TABLE IF EXISTS "FieldRevision";
@@ -0,0 +1,30 @@
+-- Migration: 000012_field_history.up.sql
+-- Description: Field-level change history for contacts, companies and deals
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: FieldRevision
+-- Purpose: uma linha por alteração de um registro, com o diff por campo em "changes":
+-- {"value": {"from": 1000, "to": 1500}, "stage": {"from": "OPEN", "to": "WON"}}.
+-- Complementa o audit_log (quem fez o quê) com o que mudou.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "FieldRevision" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "action" TEXT NOT NULL,
+    "changes" JSONB NOT NULL,
+    "actorId" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "FieldRevision_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "FieldRevision_entityType_check" CHECK ("entityType" IN ('contact', 'company', 'deal'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "FieldRevision_entity_createdAt_idx"
+    ON "FieldRevision" ("workspaceId", "entityType", "entityId", "createdAt" DESC);
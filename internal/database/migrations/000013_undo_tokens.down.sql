@@ -0,0 +1,6 @@
+-- Migration: 000013_undo_tokens.down.sql
+-- Description: Rollback undo tokens
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "UndoToken_expiresAt_idx";
+DROP TABLE IF EXISTS "UndoToken";
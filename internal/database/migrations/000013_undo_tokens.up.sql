@@ -0,0 +1,30 @@
+-- Migration: 000013_undo_tokens.up.sql
+-- Description: Short-lived undo tokens for soft deletes
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: UndoToken
+-- Purpose: token de uso único emitido no DELETE que permite restaurar o registro
+-- (limpa deletedAt) dentro da janela UNDO_WINDOW_MINUTES.
+-- Só o hash SHA-256 do token é persistido.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "UndoToken" (
+    "tokenHash" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "actorId" TEXT NOT NULL,
+    "expiresAt" TIMESTAMP(3) NOT NULL,
+    "usedAt" TIMESTAMP(3),
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "UndoToken_pkey" PRIMARY KEY ("tokenHash"),
+    CONSTRAINT "UndoToken_entityType_check" CHECK ("entityType" IN ('contact', 'company', 'task'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Limpeza de tokens expirados (linkko-api cleanup)
+CREATE INDEX IF NOT EXISTS "UndoToken_expiresAt_idx" ON "UndoToken" ("expiresAt");
@@ -0,0 +1,13 @@
+-- Migration: 000014_deal_rotting.down.sql
+-- Description: Rollback stale deal detection
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Deal_workspaceId_rottingSince_idx";
+
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "rottingSince";
+
+ALTER TABLE "PipelineStage" DROP CONSTRAINT IF EXISTS "PipelineStage_rottingDays_check";
+ALTER TABLE "PipelineStage" DROP COLUMN IF EXISTS "rottingDays";
+
+-- PostgreSQL não remove valores de ENUM (ALTER TYPE ... DROP VALUE não existe).
+-- 'DEAL_ROTTING' permanece em "ActivityType".
@@ -0,0 +1,33 @@
+-- Migration: 000014_deal_rotting.up.sql
+-- Description: Stale ("rotting") deal detection per pipeline stage
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: PipelineStage
+-- Purpose: dias sem atividade/mudança de estágio até o deal ser marcado como parado.
+-- NULL desativa a detecção no estágio.
+-- =====================================================
+ALTER TABLE "PipelineStage" ADD COLUMN IF NOT EXISTS "rottingDays" INTEGER;
+ALTER TABLE "PipelineStage" DROP CONSTRAINT IF EXISTS "PipelineStage_rottingDays_check";
+ALTER TABLE "PipelineStage" ADD CONSTRAINT "PipelineStage_rottingDays_check" CHECK ("rottingDays" IS NULL OR "rottingDays" > 0);
+
+-- =====================================================
+-- Table: Deal
+-- Purpose: momento em que o deal passou a ficar parado (mantido pelo deal-rotting-worker,
+-- limpo ao mudar de estágio)
+-- =====================================================
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "rottingSince" TIMESTAMP(3);
+
+-- =====================================================
+-- Enum: ActivityType
+-- Purpose: evento DEAL_ROTTING na timeline (gatilho para automações)
+-- =====================================================
+ALTER TYPE "ActivityType" ADD VALUE IF NOT EXISTS 'DEAL_ROTTING';
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- GET /deals?rotting=true e varredura de deals marcados pelo worker
+CREATE INDEX IF NOT EXISTS "Deal_workspaceId_rottingSince_idx" ON "Deal" ("workspaceId", "rottingSince")
+    WHERE "deletedAt" IS NULL AND "rottingSince" IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration: 000015_ticket_sla.down.sql
+-- Description: Rollback ticket SLA targets
+-- Date: 2026-10-17
+
+ALTER TABLE "PipelineStage" DROP CONSTRAINT IF EXISTS "PipelineStage_sla_check";
+ALTER TABLE "PipelineStage" DROP COLUMN IF EXISTS "resolutionSlaMinutes";
+ALTER TABLE "PipelineStage" DROP COLUMN IF EXISTS "firstResponseSlaMinutes";
@@ -0,0 +1,18 @@
+-- Migration: 000015_ticket_sla.up.sql
+-- Description: First-response and resolution SLA targets on TICKET stages
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: PipelineStage
+-- Purpose: metas de SLA (minutos a partir da abertura do ticket) dos deals em estágios
+-- do tipo TICKET. NULL desativa o timer.
+-- =====================================================
+ALTER TABLE "PipelineStage" ADD COLUMN IF NOT EXISTS "firstResponseSlaMinutes" INTEGER;
+ALTER TABLE "PipelineStage" ADD COLUMN IF NOT EXISTS "resolutionSlaMinutes" INTEGER;
+
+ALTER TABLE "PipelineStage" DROP CONSTRAINT IF EXISTS "PipelineStage_sla_check";
+ALTER TABLE "PipelineStage" ADD CONSTRAINT "PipelineStage_sla_check" CHECK (
+    ("firstResponseSlaMinutes" IS NULL OR "firstResponseSlaMinutes" > 0)
+    AND ("resolutionSlaMinutes" IS NULL OR "resolutionSlaMinutes" > 0)
+);
@@ -0,0 +1,7 @@
+-- Migration: 000016_business_hours.down.sql
+-- Description: Rollback business hours and holiday calendar
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Holiday_workspaceId_date_key";
+DROP TABLE IF EXISTS "Holiday";
+DROP TABLE IF EXISTS "BusinessHours";
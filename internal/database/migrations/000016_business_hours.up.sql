@@ -0,0 +1,43 @@
+-- Migration: 000016_business_hours.up.sql
+-- Description: Workspace business hours and holiday calendar
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: BusinessHours
+-- Purpose: expediente semanal do workspace (uma linha por workspace). Timers de SLA,
+-- esperas de sequências e a detecção de deals parados pausam fora destas faixas.
+-- intervals: [{"weekday":1,"start":"09:00","end":"18:00"}, ...] no fuso "timezone".
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "BusinessHours" (
+    "workspaceId" TEXT NOT NULL,
+    "timezone" TEXT NOT NULL DEFAULT 'UTC',
+    "intervals" JSONB NOT NULL DEFAULT '[]',
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "BusinessHours_pkey" PRIMARY KEY ("workspaceId")
+);
+
+-- =====================================================
+-- Table: Holiday
+-- Purpose: dias sem expediente do workspace (data no fuso do expediente).
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "Holiday" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "date" DATE NOT NULL,
+    "name" TEXT NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Holiday_pkey" PRIMARY KEY ("id")
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Um feriado por data no workspace (também atende a listagem por período)
+CREATE UNIQUE INDEX IF NOT EXISTS "Holiday_workspaceId_date_key" ON "Holiday" ("workspaceId", "date");
@@ -0,0 +1,11 @@
+-- Migration: 000017_company_enrichment.down.sql
+-- Description: Rollback company enrichment
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "unique_active_enrichment_per_company";
+DROP INDEX IF EXISTS "CompanyEnrichmentJob_pending_idx";
+DROP TABLE IF EXISTS "CompanyEnrichmentJob";
+
+ALTER TABLE "Company" DROP COLUMN IF EXISTS "customFields";
+ALTER TABLE "Company" DROP COLUMN IF EXISTS "logoUrl";
+ALTER TABLE "Company" DROP COLUMN IF EXISTS "industry";
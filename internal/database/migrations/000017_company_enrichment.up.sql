@@ -0,0 +1,50 @@
+-- Migration: 000017_company_enrichment.up.sql
+-- Description: Company enrichment fields and async enrichment jobs
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Company
+-- Purpose: campos preenchidos pelo enriquecimento (industry, logoUrl) e customFields,
+-- onde fica a proveniência dos dados enriquecidos ("enrichment": provider, job, campos).
+-- =====================================================
+ALTER TABLE "Company" ADD COLUMN IF NOT EXISTS "industry" TEXT;
+ALTER TABLE "Company" ADD COLUMN IF NOT EXISTS "logoUrl" TEXT;
+ALTER TABLE "Company" ADD COLUMN IF NOT EXISTS "customFields" JSONB NOT NULL DEFAULT '{}';
+
+-- =====================================================
+-- Table: CompanyEnrichmentJob
+-- Purpose: jobs de enriquecimento de empresa executados pelo enrichment-worker.
+-- "appliedFields" lista os campos gravados na empresa quando o job conclui.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "CompanyEnrichmentJob" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "companyId" TEXT NOT NULL,
+    "provider" TEXT NOT NULL,
+    "overwrite" BOOLEAN NOT NULL DEFAULT false,
+    "status" TEXT NOT NULL DEFAULT 'PENDING',
+    "appliedFields" JSONB NOT NULL DEFAULT '[]',
+    "error" TEXT,
+    "requestedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "startedAt" TIMESTAMP(3),
+    "completedAt" TIMESTAMP(3),
+
+    CONSTRAINT "CompanyEnrichmentJob_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "CompanyEnrichmentJob_status_check" CHECK ("status" IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Fila do enrichment-worker
+CREATE INDEX IF NOT EXISTS "CompanyEnrichmentJob_pending_idx"
+    ON "CompanyEnrichmentJob" ("createdAt")
+    WHERE "status" = 'PENDING';
+
+-- Um job ativo (PENDING/RUNNING) por empresa: pedidos repetidos devolvem 409
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_active_enrichment_per_company"
+    ON "CompanyEnrichmentJob" ("companyId")
+    WHERE "status" IN ('PENDING', 'RUNNING');
@@ -0,0 +1,7 @@
+-- Migration: 000018_deal_participants.down.sql
+-- Description: Rollback deal participants
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "DealParticipant_contactId_idx";
+DROP INDEX IF EXISTS "unique_deal_participant";
+DROP TABLE IF EXISTS "DealParticipant";
@@ -0,0 +1,37 @@
+-- Migration: 000018_deal_participants.up.sql
+-- Description: Deal participants (deal ↔ contact many-to-many with roles)
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: DealParticipant
+-- Purpose: contatos envolvidos em um negócio B2B e o papel de cada um
+-- (decisor, champion, financeiro...). "Deal"."contactId" continua sendo o contato
+-- principal; os participantes complementam.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "DealParticipant" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "dealId" TEXT NOT NULL,
+    "contactId" TEXT NOT NULL,
+    "role" TEXT NOT NULL DEFAULT 'OTHER',
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "DealParticipant_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "DealParticipant_dealId_fkey" FOREIGN KEY ("dealId") REFERENCES "Deal"("id") ON DELETE CASCADE,
+    CONSTRAINT "DealParticipant_contactId_fkey" FOREIGN KEY ("contactId") REFERENCES "Contact"("id") ON DELETE CASCADE,
+    CONSTRAINT "DealParticipant_role_check" CHECK ("role" IN ('DECISION_MAKER', 'CHAMPION', 'BILLING', 'INFLUENCER', 'OTHER'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Um contato participa uma única vez de cada negócio
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_deal_participant"
+    ON "DealParticipant" ("dealId", "contactId");
+
+-- Negócios de que um contato participa
+CREATE INDEX IF NOT EXISTS "DealParticipant_contactId_idx"
+    ON "DealParticipant" ("workspaceId", "contactId");
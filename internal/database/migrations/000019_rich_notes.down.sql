@@ -0,0 +1,12 @@
+-- Migration: 000019_rich_notes.down.sql
+-- Description: Rollback rich-text notes
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Note_pinned_idx";
+DROP INDEX IF EXISTS "NoteAttachment_noteId_idx";
+DROP TABLE IF EXISTS "NoteAttachment";
+
+-- "content" continua com o texto puro de cada nota: o body pode ser descartado sem perda de texto
+ALTER TABLE "Note" DROP COLUMN IF EXISTS "pinnedById";
+ALTER TABLE "Note" DROP COLUMN IF EXISTS "pinnedAt";
+ALTER TABLE "Note" DROP COLUMN IF EXISTS "body";
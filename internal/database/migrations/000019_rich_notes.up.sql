@@ -0,0 +1,63 @@
+-- Migration: 000019_rich_notes.up.sql
+-- Description: Rich-text note bodies, note pinning and note attachments
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Note
+-- Purpose: "body" guarda o texto rico em JSON portátil (doc/paragraph/text + marks);
+-- "content" passa a ser a versão texto puro derivada do body (busca, previews e clientes
+-- antigos). "pinnedAt"/"pinnedById" fixam a nota no topo da timeline da entidade.
+-- =====================================================
+ALTER TABLE "Note" ADD COLUMN IF NOT EXISTS "body" JSONB;
+ALTER TABLE "Note" ADD COLUMN IF NOT EXISTS "pinnedAt" TIMESTAMP(3);
+ALTER TABLE "Note" ADD COLUMN IF NOT EXISTS "pinnedById" TEXT;
+
+-- Notas em texto puro viram um doc com um parágrafo por linha
+UPDATE "Note" n
+SET "body" = jsonb_build_object(
+    'type', 'doc',
+    'content', (
+        SELECT jsonb_agg(
+            CASE WHEN line = '' THEN jsonb_build_object('type', 'paragraph')
+                 ELSE jsonb_build_object('type', 'paragraph', 'content',
+                      jsonb_build_array(jsonb_build_object('type', 'text', 'text', line)))
+            END ORDER BY pos)
+        FROM regexp_split_to_table(n."content", E'\r?\n') WITH ORDINALITY AS l(line, pos)
+    )
+)
+WHERE "body" IS NULL;
+
+-- Notas já fixadas mantêm a posição a partir da última edição
+UPDATE "Note" SET "pinnedAt" = "updatedAt" WHERE "isPinned" AND "pinnedAt" IS NULL;
+
+-- =====================================================
+-- Table: NoteAttachment
+-- Purpose: arquivos anexados a notas; o conteúdo fica no object storage (storageKey) e o
+-- body da nota referencia o anexo inline por id (nó "attachment").
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "NoteAttachment" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "noteId" TEXT NOT NULL,
+    "fileName" TEXT NOT NULL,
+    "contentType" TEXT NOT NULL,
+    "size" BIGINT NOT NULL,
+    "storageKey" TEXT NOT NULL,
+    "uploadedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "NoteAttachment_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "NoteAttachment_noteId_fkey" FOREIGN KEY ("noteId") REFERENCES "Note"("id") ON DELETE CASCADE
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "NoteAttachment_noteId_idx"
+    ON "NoteAttachment" ("noteId");
+
+-- Notas fixadas por entidade (topo da timeline)
+CREATE INDEX IF NOT EXISTS "Note_pinned_idx"
+    ON "Note" ("workspaceId", "pinnedAt")
+    WHERE "pinnedAt" IS NOT NULL AND "deletedAt" IS NULL;
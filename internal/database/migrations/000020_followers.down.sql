@@ -0,0 +1,9 @@
+-- Migration: 000020_followers.down.sql
+-- Description: Rollback followers and notifications
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Notification_userId_idx";
+DROP INDEX IF EXISTS "Follower_userId_idx";
+DROP INDEX IF EXISTS "unique_follower";
+DROP TABLE IF EXISTS "Notification";
+DROP TABLE IF EXISTS "Follower";
@@ -0,0 +1,57 @@
+-- Migration: 000020_followers.up.sql
+-- Description: Followers of contacts/deals/tasks and in-app notifications
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Follower
+-- Purpose: usuários que acompanham um contato, negócio ou tarefa. O vínculo nasce
+-- explicitamente (MANUAL) ou automaticamente ao ser responsável (ASSIGNED) ou
+-- mencionado em uma nota (MENTIONED).
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "Follower" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "reason" TEXT NOT NULL DEFAULT 'MANUAL',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Follower_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "Follower_entityType_check" CHECK ("entityType" IN ('CONTACT', 'DEAL', 'TASK')),
+    CONSTRAINT "Follower_reason_check" CHECK ("reason" IN ('MANUAL', 'ASSIGNED', 'MENTIONED'))
+);
+
+-- =====================================================
+-- Table: Notification
+-- Purpose: eventos entregues aos seguidores de uma entidade (caixa de entrada do usuário).
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "Notification" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "event" TEXT NOT NULL,
+    "actorId" TEXT NOT NULL,
+    "readAt" TIMESTAMP(3),
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Notification_pkey" PRIMARY KEY ("id")
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Um usuário segue cada entidade uma única vez
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_follower"
+    ON "Follower" ("entityType", "entityId", "userId");
+
+-- Filtro "following" das listagens
+CREATE INDEX IF NOT EXISTS "Follower_userId_idx"
+    ON "Follower" ("workspaceId", "userId", "entityType");
+
+-- Caixa de entrada do usuário, mais recentes primeiro
+CREATE INDEX IF NOT EXISTS "Notification_userId_idx"
+    ON "Notification" ("workspaceId", "userId", "createdAt" DESC);
@@ -0,0 +1,9 @@
+-- Migration: 000021_visibility.down.sql
+-- Description: Rollback note/task visibility
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Note_restricted_idx";
+ALTER TABLE "Task" DROP CONSTRAINT IF EXISTS "Task_visibility_check";
+ALTER TABLE "Task" DROP COLUMN IF EXISTS visibility;
+ALTER TABLE "Note" DROP CONSTRAINT IF EXISTS "Note_visibility_check";
+ALTER TABLE "Note" DROP COLUMN IF EXISTS "visibility";
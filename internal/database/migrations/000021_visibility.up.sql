@@ -0,0 +1,28 @@
+-- Migration: 000021_visibility.up.sql
+-- Description: Private/team/workspace visibility on notes and tasks
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Visibility
+-- Purpose: informação sensível (ex.: exceções de preço) deixa de ser visível a todo o
+-- workspace. PRIVATE - só o autor/dono (e o responsável, em tarefas); TEAM - também quem
+-- trabalha no registro (responsáveis e seguidores); WORKSPACE - todos os membros.
+-- Admins enxergam tudo. Registros existentes continuam visíveis ao workspace.
+-- =====================================================
+ALTER TABLE "Note" ADD COLUMN IF NOT EXISTS "visibility" TEXT NOT NULL DEFAULT 'WORKSPACE';
+ALTER TABLE "Note" DROP CONSTRAINT IF EXISTS "Note_visibility_check";
+ALTER TABLE "Note" ADD CONSTRAINT "Note_visibility_check" CHECK ("visibility" IN ('PRIVATE', 'TEAM', 'WORKSPACE'));
+
+-- NOTE: colunas snake_case, como lidas pelo TaskRepository
+ALTER TABLE "Task" ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'WORKSPACE';
+ALTER TABLE "Task" DROP CONSTRAINT IF EXISTS "Task_visibility_check";
+ALTER TABLE "Task" ADD CONSTRAINT "Task_visibility_check" CHECK (visibility IN ('PRIVATE', 'TEAM', 'WORKSPACE'));
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Notas restritas são minoria: o filtro de visibilidade só precisa delas
+CREATE INDEX IF NOT EXISTS "Note_restricted_idx"
+    ON "Note" ("workspaceId", "userId")
+    WHERE "visibility" <> 'WORKSPACE';
@@ -0,0 +1,6 @@
+-- Migration: 000022_contact_bulk_update.down.sql
+-- Description: Rollback contact bulk update jobs
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "ContactBulkUpdateJob_pending_idx";
+DROP TABLE IF EXISTS "ContactBulkUpdateJob";
@@ -0,0 +1,40 @@
+-- Migration: 000022_contact_bulk_update.up.sql
+-- Description: Async bulk update jobs for contacts (tags, owner, lifecycle stage)
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: ContactBulkUpdateJob
+-- Purpose: "selecionar todos" da UI - um patch aplicado aos contatos de uma lista de ids
+-- ou de um filtro, executado pelo bulk-update-worker em lotes. "cursor" é o último id
+-- processado: um job retomado após queda do worker continua de onde parou.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ContactBulkUpdateJob" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "contactIds" TEXT[],
+    "filter" JSONB,
+    "patch" JSONB NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'PENDING',
+    "total" INTEGER,
+    "processed" INTEGER NOT NULL DEFAULT 0,
+    "updated" INTEGER NOT NULL DEFAULT 0,
+    "cursor" TEXT,
+    "error" TEXT,
+    "requestedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "startedAt" TIMESTAMP(3),
+    "completedAt" TIMESTAMP(3),
+
+    CONSTRAINT "ContactBulkUpdateJob_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "ContactBulkUpdateJob_status_check" CHECK ("status" IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Fila do bulk-update-worker
+CREATE INDEX IF NOT EXISTS "ContactBulkUpdateJob_pending_idx"
+    ON "ContactBulkUpdateJob" ("createdAt")
+    WHERE "status" = 'PENDING';
@@ -0,0 +1,12 @@
+-- Migration: 000023_trash.down.sql
+-- Description: Rollback workspace trash
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Pipeline_trash_idx";
+DROP INDEX IF EXISTS "Task_trash_idx";
+DROP INDEX IF EXISTS "Deal_trash_idx";
+DROP INDEX IF EXISTS "Company_trash_idx";
+DROP INDEX IF EXISTS "Contact_trash_idx";
+
+ALTER TABLE "Pipeline" DROP COLUMN IF EXISTS "deletedById";
+ALTER TABLE "Task" DROP COLUMN IF EXISTS deleted_by_id;
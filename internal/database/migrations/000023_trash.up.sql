@@ -0,0 +1,23 @@
+-- Migration: 000023_trash.up.sql
+-- Description: Workspace trash - who deleted each record and lookup of recently deleted records
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Deleted by
+-- Purpose: a lixeira mostra quem excluiu o registro. Contact, Company e Deal já têm
+-- "deletedById"; Task e Pipeline passam a registrar o ator do DELETE.
+-- =====================================================
+-- NOTE: colunas snake_case, como lidas pelo TaskRepository
+ALTER TABLE "Task" ADD COLUMN IF NOT EXISTS deleted_by_id TEXT;
+ALTER TABLE "Pipeline" ADD COLUMN IF NOT EXISTS "deletedById" TEXT;
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- GET /trash e a purga do cleanup só olham registros excluídos
+CREATE INDEX IF NOT EXISTS "Contact_trash_idx" ON "Contact" ("workspaceId", "deletedAt") WHERE "deletedAt" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "Company_trash_idx" ON "Company" ("workspaceId", "deletedAt") WHERE "deletedAt" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "Deal_trash_idx" ON "Deal" ("workspaceId", "deletedAt") WHERE "deletedAt" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "Task_trash_idx" ON "Task" (workspace_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "Pipeline_trash_idx" ON "Pipeline" ("workspaceId", "deletedAt") WHERE "deletedAt" IS NOT NULL;
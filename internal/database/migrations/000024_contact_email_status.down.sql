@@ -0,0 +1,11 @@
+-- Migration: 000024_contact_email_status.down.sql
+-- Description: Rollback email verification status on Contact
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Contact_workspaceId_emailStatus_idx";
+DROP INDEX IF EXISTS "Contact_emailCheckedAt_pending_idx";
+
+ALTER TABLE "Contact" DROP CONSTRAINT IF EXISTS "Contact_emailStatus_check";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "emailCheckedAt";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "emailStatusReason";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "emailStatus";
@@ -0,0 +1,27 @@
+-- Migration: 000024_contact_email_status.up.sql
+-- Description: Email verification/deliverability status on Contact
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Contact
+-- Purpose: resultado da verificação do email (sintaxe, MX, provedor opcional) feita pelo
+-- email-verification-worker. NULL = ainda não verificado (ou email alterado desde a última verificação).
+-- =====================================================
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "emailStatus" TEXT;
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "emailStatusReason" TEXT;
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "emailCheckedAt" TIMESTAMP(3);
+
+ALTER TABLE "Contact" DROP CONSTRAINT IF EXISTS "Contact_emailStatus_check";
+ALTER TABLE "Contact" ADD CONSTRAINT "Contact_emailStatus_check"
+    CHECK ("emailStatus" IS NULL OR "emailStatus" IN ('VALID', 'RISKY', 'INVALID'));
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Fila do worker: contatos ativos com email ainda não verificado
+CREATE INDEX IF NOT EXISTS "Contact_emailCheckedAt_pending_idx" ON "Contact" ("createdAt")
+    WHERE "deletedAt" IS NULL AND "email" IS NOT NULL AND "emailCheckedAt" IS NULL;
+-- Filtro ?emailStatus= na listagem
+CREATE INDEX IF NOT EXISTS "Contact_workspaceId_emailStatus_idx" ON "Contact" ("workspaceId", "emailStatus")
+    WHERE "deletedAt" IS NULL;
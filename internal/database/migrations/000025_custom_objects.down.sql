@@ -0,0 +1,10 @@
+-- Migration: 000025_custom_objects.down.sql
+-- Description: Rollback custom object framework
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "CustomObjectRecord_data_idx";
+DROP INDEX IF EXISTS "CustomObjectRecord_objectTypeId_createdAt_idx";
+DROP INDEX IF EXISTS "CustomObjectType_workspaceId_key_key";
+
+DROP TABLE IF EXISTS "CustomObjectRecord";
+DROP TABLE IF EXISTS "CustomObjectType";
@@ -0,0 +1,62 @@
+-- Migration: 000025_custom_objects.up.sql
+-- Description: Custom object framework - workspace-defined object types and their JSONB records
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: CustomObjectType
+-- Purpose: definição de um objeto customizado do workspace (ex.: imóveis, apólices).
+-- "key" identifica o objeto nas rotas /objects/{objectKey}; "fields" é a lista de campos em JSONB.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "CustomObjectType" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "key" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "fields" JSONB NOT NULL DEFAULT '[]',
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "CustomObjectType_pkey" PRIMARY KEY ("id")
+);
+
+-- =====================================================
+-- Table: CustomObjectRecord
+-- Purpose: registro genérico de um objeto customizado; os valores dos campos ficam em "data" (JSONB).
+-- Campos de relacionamento guardam o ID do contato/empresa/negócio relacionado.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "CustomObjectRecord" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "objectTypeId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "data" JSONB NOT NULL DEFAULT '{}',
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+    "deletedById" TEXT,
+
+    CONSTRAINT "CustomObjectRecord_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "CustomObjectRecord_objectTypeId_fkey" FOREIGN KEY ("objectTypeId") REFERENCES "CustomObjectType"("id") ON DELETE CASCADE
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- A chave é única entre os objetos ativos do workspace (pode ser reutilizada após excluir)
+CREATE UNIQUE INDEX IF NOT EXISTS "CustomObjectType_workspaceId_key_key"
+    ON "CustomObjectType" ("workspaceId", "key") WHERE "deletedAt" IS NULL;
+
+-- Listagem paginada por objeto (mais recentes primeiro)
+CREATE INDEX IF NOT EXISTS "CustomObjectRecord_objectTypeId_createdAt_idx"
+    ON "CustomObjectRecord" ("objectTypeId", "createdAt" DESC) WHERE "deletedAt" IS NULL;
+
+-- Filtros por valor de campo (data @> '{"field": value}'), inclusive registros relacionados a um contato
+CREATE INDEX IF NOT EXISTS "CustomObjectRecord_data_idx"
+    ON "CustomObjectRecord" USING GIN ("data" jsonb_path_ops);
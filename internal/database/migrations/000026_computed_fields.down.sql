@@ -0,0 +1,8 @@
+-- Migration: 000026_computed_fields.down.sql
+-- Description: Rollback computed field definitions
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "Activity_companyId_createdAt_idx";
+DROP INDEX IF EXISTS "ComputedField_workspaceId_entityType_key_key";
+
+DROP TABLE IF EXISTS "ComputedField";
@@ -0,0 +1,39 @@
+-- Migration: 000026_computed_fields.up.sql
+-- Description: Computed/rollup field definitions for companies and deals
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: ComputedField
+-- Purpose: campo calculado do workspace (ex.: soma dos negócios abertos da empresa, dias desde a
+-- última atividade). "formula" usa a mini-linguagem de internal/formula e é avaliada na leitura;
+-- "resultType" (number/date) é derivado da fórmula ao salvar.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ComputedField" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "key" TEXT NOT NULL,
+    "label" TEXT NOT NULL,
+    "formula" TEXT NOT NULL,
+    "resultType" TEXT NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ComputedField_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "ComputedField_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "ComputedField_entityType_check" CHECK ("entityType" IN ('company', 'deal')),
+    CONSTRAINT "ComputedField_resultType_check" CHECK ("resultType" IN ('number', 'date'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE UNIQUE INDEX IF NOT EXISTS "ComputedField_workspaceId_entityType_key_key"
+    ON "ComputedField" ("workspaceId", "entityType", "key");
+
+-- Agregações de atividades por empresa (ex.: days_since(max(activities.createdAt)))
+CREATE INDEX IF NOT EXISTS "Activity_companyId_createdAt_idx"
+    ON "Activity" ("companyId", "createdAt" DESC);
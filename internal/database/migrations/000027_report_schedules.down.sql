@@ -0,0 +1,8 @@
+-- Migration: 000027_report_schedules.down.sql
+-- Description: Rollback scheduled report delivery
+-- Date: 2026-10-17
+
+DROP INDEX IF EXISTS "ReportSchedule_nextRunAt_idx";
+DROP INDEX IF EXISTS "ReportSchedule_workspaceId_createdAt_idx";
+
+DROP TABLE IF EXISTS "ReportSchedule";
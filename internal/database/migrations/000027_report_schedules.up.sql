@@ -0,0 +1,51 @@
+-- Migration: 000027_report_schedules.up.sql
+-- Description: Scheduled report delivery (CSV/PDF by email or webhook)
+-- Date: 2026-10-17
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: ReportSchedule
+-- Purpose: relatório recorrente do workspace (ex.: resumo do pipeline toda segunda às 8h).
+-- O report-schedule-worker executa os agendamentos com "nextRunAt" vencido, gera o arquivo
+-- ("format") e entrega aos "recipients" por email e/ou ao "webhookUrl" (POST assinado com
+-- "webhookSecret"). "cron" é interpretado no fuso "timezone".
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ReportSchedule" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "reportType" TEXT NOT NULL,
+    "filters" JSONB NOT NULL DEFAULT '{}',
+    "format" TEXT NOT NULL DEFAULT 'csv',
+    "recipients" TEXT[] NOT NULL DEFAULT '{}',
+    "webhookUrl" TEXT,
+    "webhookSecret" TEXT,
+    "cron" TEXT NOT NULL,
+    "timezone" TEXT NOT NULL DEFAULT 'UTC',
+    "enabled" BOOLEAN NOT NULL DEFAULT true,
+    "nextRunAt" TIMESTAMP(3),
+    "lastRunAt" TIMESTAMP(3),
+    "lastStatus" TEXT,
+    "lastError" TEXT,
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ReportSchedule_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "ReportSchedule_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "ReportSchedule_reportType_check" CHECK ("reportType" IN ('pipeline_summary', 'attribution', 'overdue', 'time')),
+    CONSTRAINT "ReportSchedule_format_check" CHECK ("format" IN ('csv', 'pdf')),
+    CONSTRAINT "ReportSchedule_lastStatus_check" CHECK ("lastStatus" IS NULL OR "lastStatus" IN ('SUCCEEDED', 'FAILED'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "ReportSchedule_workspaceId_createdAt_idx"
+    ON "ReportSchedule" ("workspaceId", "createdAt" DESC);
+
+-- Fila do worker: só agendamentos ativos
+CREATE INDEX IF NOT EXISTS "ReportSchedule_nextRunAt_idx"
+    ON "ReportSchedule" ("nextRunAt")
+    WHERE "enabled" = true;
@@ -0,0 +1,12 @@
+-- Migration: 000028_organizations.down.sql
+-- Description: Rollback organization layer
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "OrganizationMember_userId_idx";
+DROP INDEX IF EXISTS "OrganizationMember_organizationId_userId_key";
+
+DROP TABLE IF EXISTS "OrganizationMember";
+
+ALTER TABLE "Organization" DROP COLUMN IF EXISTS "stripe_subscription_id";
+ALTER TABLE "Organization" DROP COLUMN IF EXISTS "stripe_customer_id";
+ALTER TABLE "Organization" DROP COLUMN IF EXISTS "billingEmail";
@@ -0,0 +1,52 @@
+-- Migration: 000028_organizations.up.sql
+-- Description: Organization layer above workspaces (org roles, shared billing)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Organization: cobrança compartilhada
+-- Purpose: os workspaces de uma organização são cobrados juntos. "billingEmail" recebe as
+-- faturas; os ids do Stripe ficam na organização (os de "Workspace" seguem para o legado).
+-- =====================================================
+ALTER TABLE "Organization" ADD COLUMN IF NOT EXISTS "billingEmail" TEXT;
+ALTER TABLE "Organization" ADD COLUMN IF NOT EXISTS "stripe_customer_id" TEXT;
+ALTER TABLE "Organization" ADD COLUMN IF NOT EXISTS "stripe_subscription_id" TEXT;
+
+-- =====================================================
+-- Table: OrganizationMember
+-- Purpose: papel do usuário na organização (org_admin administra a organização, a cobrança
+-- e os relatórios entre workspaces; org_member vê o diretório). Independe do papel em cada
+-- workspace, que continua em "WorkspaceMember".
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "OrganizationMember" (
+    "id" TEXT NOT NULL,
+    "organizationId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "role" TEXT NOT NULL DEFAULT 'org_member',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "OrganizationMember_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "OrganizationMember_organizationId_fkey" FOREIGN KEY ("organizationId") REFERENCES "Organization"("id") ON DELETE CASCADE,
+    CONSTRAINT "OrganizationMember_userId_fkey" FOREIGN KEY ("userId") REFERENCES "User"("id") ON DELETE CASCADE,
+    CONSTRAINT "OrganizationMember_role_check" CHECK ("role" IN ('org_admin', 'org_member'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE UNIQUE INDEX IF NOT EXISTS "OrganizationMember_organizationId_userId_key"
+    ON "OrganizationMember" ("organizationId", "userId");
+
+CREATE INDEX IF NOT EXISTS "OrganizationMember_userId_idx"
+    ON "OrganizationMember" ("userId");
+
+-- =====================================================
+-- Backfill: donos dos workspaces viram org_admin da organização
+-- =====================================================
+INSERT INTO "OrganizationMember" ("id", "organizationId", "userId", "role")
+SELECT 'orm_' || md5(w."organizationId" || ':' || w."ownerId"), w."organizationId", w."ownerId", 'org_admin'
+FROM "Workspace" w
+JOIN "User" u ON u.id = w."ownerId"
+GROUP BY w."organizationId", w."ownerId"
+ON CONFLICT DO NOTHING;
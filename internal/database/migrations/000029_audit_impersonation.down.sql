@@ -0,0 +1,7 @@
+-- Migration: 000029_audit_impersonation.down.sql
+-- Description: Rollback impersonation flag on audit_log
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS idx_audit_impersonator;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS impersonator_id;
@@ -0,0 +1,14 @@
+-- Migration: 000029_audit_impersonation.up.sql
+-- Description: Flag audit entries written under impersonation
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- audit_log.impersonator_id
+-- Purpose: admin que agia como actor_id (token de impersonation). NULL = ação do próprio ator.
+-- =====================================================
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS impersonator_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_audit_impersonator
+    ON audit_log(impersonator_id, created_at DESC)
+    WHERE impersonator_id IS NOT NULL;
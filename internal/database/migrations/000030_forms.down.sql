@@ -0,0 +1,7 @@
+-- Migration: 000030_forms.down.sql
+-- Description: Rollback lead-capture web forms
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "Form_workspaceId_createdAt_idx";
+
+DROP TABLE IF EXISTS "Form";
@@ -0,0 +1,41 @@
+-- Migration: 000030_forms.up.sql
+-- Description: Lead-capture web forms
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Form
+-- Purpose: formulário de captura de leads embutido em sites. "fields" lista os campos e o
+-- atributo de contato/negócio que cada um preenche; as submissões anônimas
+-- (POST /v1/public/forms/{id}/submissions) criam o contato (e o negócio, com "pipelineId")
+-- em nome de "ownerId". "spamProtection" guarda honeypot, tempo mínimo, captcha e rate limit.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "Form" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "fields" JSONB NOT NULL DEFAULT '[]',
+    "pipelineId" TEXT,
+    "stageId" TEXT,
+    "ownerId" TEXT NOT NULL,
+    "successRedirectUrl" TEXT,
+    "successMessage" TEXT,
+    "spamProtection" JSONB NOT NULL DEFAULT '{}',
+    "enabled" BOOLEAN NOT NULL DEFAULT true,
+    "submissionCount" BIGINT NOT NULL DEFAULT 0,
+    "lastSubmissionAt" TIMESTAMP(3),
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Form_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "Form_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Form_workspaceId_createdAt_idx"
+    ON "Form" ("workspaceId", "createdAt" DESC);
@@ -0,0 +1,9 @@
+-- Migration: 000031_tracked_links.down.sql
+-- Description: Rollback short links with click tracking
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "TrackedLinkClick";
+DROP TABLE IF EXISTS "TrackedLink";
+
+-- PostgreSQL não remove valores de ENUM (ALTER TYPE ... DROP VALUE não existe).
+-- 'LINK_CLICK' permanece em "ActivityType".
@@ -0,0 +1,70 @@
+-- Migration: 000031_tracked_links.up.sql
+-- Description: Short links with click tracking (UTM + contact token)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: TrackedLink
+-- Purpose: link curto (GET /l/{code}) que redireciona para "destinationUrl" com os parâmetros
+-- UTM. "tokenSecret" assina os contact tokens (?c=) que identificam o contato no clique.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "TrackedLink" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "code" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "destinationUrl" TEXT NOT NULL,
+    "utmSource" TEXT,
+    "utmMedium" TEXT,
+    "utmCampaign" TEXT,
+    "utmTerm" TEXT,
+    "utmContent" TEXT,
+    "contactId" TEXT,
+    "dealId" TEXT,
+    "tokenSecret" BYTEA NOT NULL,
+    "clickCount" BIGINT NOT NULL DEFAULT 0,
+    "lastClickedAt" TIMESTAMP(3),
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "TrackedLink_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "TrackedLink_code_key" UNIQUE ("code"),
+    CONSTRAINT "TrackedLink_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "TrackedLink_contactId_fkey" FOREIGN KEY ("contactId") REFERENCES "Contact"("id") ON DELETE SET NULL,
+    CONSTRAINT "TrackedLink_dealId_fkey" FOREIGN KEY ("dealId") REFERENCES "Deal"("id") ON DELETE SET NULL
+);
+
+-- =====================================================
+-- Table: TrackedLinkClick
+-- Purpose: um registro por clique (GET /links/{id}/clicks); "contactId" vem do link ou do
+-- contact token.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "TrackedLinkClick" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "linkId" TEXT NOT NULL,
+    "contactId" TEXT,
+    "referrer" TEXT,
+    "userAgent" TEXT,
+    "clickedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "TrackedLinkClick_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "TrackedLinkClick_linkId_fkey" FOREIGN KEY ("linkId") REFERENCES "TrackedLink"("id") ON DELETE CASCADE,
+    CONSTRAINT "TrackedLinkClick_contactId_fkey" FOREIGN KEY ("contactId") REFERENCES "Contact"("id") ON DELETE SET NULL
+);
+
+-- =====================================================
+-- Enum: ActivityType
+-- Purpose: evento LINK_CLICK na timeline do contato
+-- =====================================================
+ALTER TYPE "ActivityType" ADD VALUE IF NOT EXISTS 'LINK_CLICK';
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "TrackedLink_workspaceId_createdAt_idx"
+    ON "TrackedLink" ("workspaceId", "createdAt" DESC);
+
+CREATE INDEX IF NOT EXISTS "TrackedLinkClick_linkId_clickedAt_idx"
+    ON "TrackedLinkClick" ("linkId", "clickedAt" DESC);
@@ -0,0 +1,8 @@
+-- Migration: 000032_email_events.down.sql
+-- Description: Rollback email engagement events
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "EmailEvent";
+
+-- PostgreSQL não remove valores de ENUM (ALTER TYPE ... DROP VALUE não existe).
+-- 'EMAIL_OPENED', 'EMAIL_CLICKED', 'EMAIL_BOUNCED' e 'EMAIL_UNSUBSCRIBED' permanecem em "ActivityType".
@@ -0,0 +1,57 @@
+-- Migration: 000032_email_events.up.sql
+-- Description: Email engagement events (opens, clicks, bounces, unsubscribes)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: EmailEvent
+-- Purpose: eventos do provedor de envio ingeridos via S2S (POST /email-events). "contactId"
+-- NULL = nenhum contato com o email no momento da ingestão. UNSUBSCRIBED e bounces HARD são a
+-- lista de supressão consultada pela futura integração de envio.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "EmailEvent" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "contactId" TEXT,
+    "type" TEXT NOT NULL,
+    "email" TEXT NOT NULL,
+    "provider" TEXT NOT NULL,
+    "providerEventId" TEXT,
+    "messageId" TEXT,
+    "url" TEXT,
+    "bounceType" TEXT,
+    "reason" TEXT,
+    "occurredAt" TIMESTAMP(3) NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "EmailEvent_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "EmailEvent_type_check" CHECK ("type" IN ('OPENED', 'CLICKED', 'BOUNCED', 'UNSUBSCRIBED')),
+    CONSTRAINT "EmailEvent_bounceType_check" CHECK ("bounceType" IS NULL OR "bounceType" IN ('HARD', 'SOFT')),
+    CONSTRAINT "EmailEvent_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "EmailEvent_contactId_fkey" FOREIGN KEY ("contactId") REFERENCES "Contact"("id") ON DELETE SET NULL
+);
+
+-- =====================================================
+-- Enum: ActivityType
+-- Purpose: eventos de engajamento na timeline do contato (gatilho para automações)
+-- =====================================================
+ALTER TYPE "ActivityType" ADD VALUE IF NOT EXISTS 'EMAIL_OPENED';
+ALTER TYPE "ActivityType" ADD VALUE IF NOT EXISTS 'EMAIL_CLICKED';
+ALTER TYPE "ActivityType" ADD VALUE IF NOT EXISTS 'EMAIL_BOUNCED';
+ALTER TYPE "ActivityType" ADD VALUE IF NOT EXISTS 'EMAIL_UNSUBSCRIBED';
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Reenvios do mesmo evento pelo provedor são ignorados
+CREATE UNIQUE INDEX IF NOT EXISTS "EmailEvent_workspaceId_provider_providerEventId_key"
+    ON "EmailEvent" ("workspaceId", "provider", "providerEventId")
+    WHERE "providerEventId" IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "EmailEvent_workspaceId_contactId_occurredAt_idx"
+    ON "EmailEvent" ("workspaceId", "contactId", "occurredAt" DESC);
+
+-- Supressão por email (descadastros e bounces HARD)
+CREATE INDEX IF NOT EXISTS "EmailEvent_workspaceId_email_idx"
+    ON "EmailEvent" ("workspaceId", "email")
+    WHERE "type" = 'UNSUBSCRIBED' OR "bounceType" = 'HARD';
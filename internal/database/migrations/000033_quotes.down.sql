@@ -0,0 +1,6 @@
+-- Migration: 000033_quotes.down.sql
+-- Description: Rollback document templates and quotes
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "Quote";
+DROP TABLE IF EXISTS "DocumentTemplate";
@@ -0,0 +1,84 @@
+-- Migration: 000033_quotes.up.sql
+-- Description: Document templates and quotes with async PDF rendering and share links
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: DocumentTemplate
+-- Purpose: título/corpo de propostas e orçamentos com merge fields ({deal.name}, {quote.total})
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "DocumentTemplate" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "title" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "description" TEXT,
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "DocumentTemplate_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "DocumentTemplate_workspaceId_name_key" UNIQUE ("workspaceId", "name"),
+    CONSTRAINT "DocumentTemplate_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE
+);
+
+-- =====================================================
+-- Table: Quote
+-- Purpose: orçamento gerado de um template e de um negócio. "title"/"body" guardam o texto já
+-- renderizado; o PDF é gerado pelo quote-worker ("pdfStatus") e gravado em "pdfObjectKey".
+-- "shareTokenHash" é o SHA-256 do token do link público (/q/{token}).
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "Quote" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "number" BIGINT NOT NULL,
+    "dealId" TEXT NOT NULL,
+    "contactId" TEXT,
+    "companyId" TEXT,
+    "templateId" TEXT,
+    "title" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "currency" TEXT NOT NULL,
+    "lineItems" JSONB NOT NULL DEFAULT '[]',
+    "subtotal" DOUBLE PRECISION NOT NULL,
+    "discount" DOUBLE PRECISION NOT NULL,
+    "total" DOUBLE PRECISION NOT NULL,
+    "validUntil" TIMESTAMP(3),
+    "status" TEXT NOT NULL DEFAULT 'DRAFT',
+    "shareTokenHash" BYTEA,
+    "sentAt" TIMESTAMP(3),
+    "viewedAt" TIMESTAMP(3),
+    "acceptedAt" TIMESTAMP(3),
+    "acceptedByName" TEXT,
+    "pdfStatus" TEXT NOT NULL DEFAULT 'PENDING',
+    "pdfObjectKey" TEXT NOT NULL,
+    "pdfSizeBytes" BIGINT,
+    "pdfError" TEXT,
+    "pdfStartedAt" TIMESTAMP(3),
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Quote_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "Quote_workspaceId_number_key" UNIQUE ("workspaceId", "number"),
+    CONSTRAINT "Quote_shareTokenHash_key" UNIQUE ("shareTokenHash"),
+    CONSTRAINT "Quote_status_check" CHECK ("status" IN ('DRAFT', 'SENT', 'VIEWED', 'ACCEPTED')),
+    CONSTRAINT "Quote_pdfStatus_check" CHECK ("pdfStatus" IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED')),
+    CONSTRAINT "Quote_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "Quote_dealId_fkey" FOREIGN KEY ("dealId") REFERENCES "Deal"("id") ON DELETE CASCADE,
+    CONSTRAINT "Quote_contactId_fkey" FOREIGN KEY ("contactId") REFERENCES "Contact"("id") ON DELETE SET NULL,
+    CONSTRAINT "Quote_companyId_fkey" FOREIGN KEY ("companyId") REFERENCES "Company"("id") ON DELETE SET NULL,
+    CONSTRAINT "Quote_templateId_fkey" FOREIGN KEY ("templateId") REFERENCES "DocumentTemplate"("id") ON DELETE SET NULL
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+CREATE INDEX IF NOT EXISTS "Quote_workspaceId_dealId_idx" ON "Quote" ("workspaceId", "dealId", "createdAt" DESC);
+
+-- Fila do quote-worker
+CREATE INDEX IF NOT EXISTS "Quote_pdf_pending_idx"
+    ON "Quote" ("createdAt")
+    WHERE "pdfStatus" IN ('PENDING', 'RUNNING');
@@ -0,0 +1,15 @@
+-- Migration: 000034_workspace_billing.down.sql
+-- Description: Rollback workspace subscription state
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "StripeEvent_receivedAt_idx";
+DROP INDEX IF EXISTS "Workspace_stripe_customer_id_idx";
+
+DROP TABLE IF EXISTS "StripeEvent";
+
+ALTER TABLE "Workspace" DROP CONSTRAINT IF EXISTS "Workspace_billingStatus_check";
+ALTER TABLE "Workspace" DROP CONSTRAINT IF EXISTS "Workspace_billingPlan_check";
+ALTER TABLE "Workspace" DROP COLUMN IF EXISTS "billingUpdatedAt";
+ALTER TABLE "Workspace" DROP COLUMN IF EXISTS "stripe_cancel_at_period_end";
+ALTER TABLE "Workspace" DROP COLUMN IF EXISTS "billingStatus";
+ALTER TABLE "Workspace" DROP COLUMN IF EXISTS "billingPlan";
@@ -0,0 +1,51 @@
+-- Migration: 000034_workspace_billing.up.sql
+-- Description: Workspace subscription state synced from Stripe webhooks (plan, status, period)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Workspace
+-- Purpose: estado da assinatura mantido pelos webhooks do Stripe. "billingPlan" é o plano
+-- contratado (mapeado do price pelo STRIPE_PRICE_PLANS) e define as quotas do workspace;
+-- "billingStatus" segue o status da assinatura (NONE = nunca assinou).
+-- stripe_customer_id / stripe_subscription_id / stripe_price_id / stripe_current_period_end
+-- já existiam e passam a ser preenchidos pelo webhook.
+-- =====================================================
+ALTER TABLE "Workspace" ADD COLUMN IF NOT EXISTS "billingPlan" TEXT NOT NULL DEFAULT 'FREE';
+ALTER TABLE "Workspace" ADD COLUMN IF NOT EXISTS "billingStatus" TEXT NOT NULL DEFAULT 'NONE';
+ALTER TABLE "Workspace" ADD COLUMN IF NOT EXISTS "stripe_cancel_at_period_end" BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE "Workspace" ADD COLUMN IF NOT EXISTS "billingUpdatedAt" TIMESTAMP(3);
+
+ALTER TABLE "Workspace" DROP CONSTRAINT IF EXISTS "Workspace_billingPlan_check";
+ALTER TABLE "Workspace" ADD CONSTRAINT "Workspace_billingPlan_check" CHECK ("billingPlan" IN ('FREE', 'STARTER', 'PRO', 'ENTERPRISE'));
+ALTER TABLE "Workspace" DROP CONSTRAINT IF EXISTS "Workspace_billingStatus_check";
+ALTER TABLE "Workspace" ADD CONSTRAINT "Workspace_billingStatus_check" CHECK (
+    "billingStatus" IN ('NONE', 'TRIALING', 'ACTIVE', 'PAST_DUE', 'UNPAID', 'INCOMPLETE', 'PAUSED', 'CANCELED')
+);
+
+-- =====================================================
+-- Table: StripeEvent
+-- Purpose: eventos de webhook já aplicados. O Stripe reenvia eventos (entrega at-least-once);
+-- o id do evento deduplica e eventos fora de ordem são descartados por "createdAt".
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "StripeEvent" (
+    "id" TEXT NOT NULL,
+    "type" TEXT NOT NULL,
+    "workspaceId" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL,
+    "receivedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "StripeEvent_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "StripeEvent_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE SET NULL
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Eventos de assinatura sem metadata.workspaceId são resolvidos pelo customer
+CREATE INDEX IF NOT EXISTS "Workspace_stripe_customer_id_idx"
+    ON "Workspace" ("stripe_customer_id")
+    WHERE "stripe_customer_id" IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "StripeEvent_receivedAt_idx"
+    ON "StripeEvent" ("receivedAt");
@@ -0,0 +1,5 @@
+-- Migration: 000035_invoices.down.sql
+-- Description: Rollback invoices
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "Invoice";
@@ -0,0 +1,53 @@
+-- Migration: 000035_invoices.up.sql
+-- Description: Invoices linked to won deals, reconciled with the payment provider
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Invoice
+-- Purpose: cobranças de negócios ganhos. "provider" + "externalId" identificam a fatura no
+-- provedor de pagamento (reconciliação via S2S em POST /invoices/:reconcile);
+-- "providerUpdatedAt" é o instante do último evento aplicado (eventos fora de ordem são ignorados).
+-- Receita "booked" vem do valor dos negócios ganhos; "collected" de "amountPaid" das faturas PAID.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "Invoice" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "dealId" TEXT NOT NULL,
+    "number" TEXT,
+    "status" TEXT NOT NULL DEFAULT 'OPEN',
+    "amount" DOUBLE PRECISION NOT NULL,
+    "amountPaid" DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "currency" TEXT NOT NULL,
+    "dueDate" TIMESTAMP(3),
+    "paidAt" TIMESTAMP(3),
+    "provider" TEXT,
+    "externalId" TEXT,
+    "providerUpdatedAt" TIMESTAMP(3),
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Invoice_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "Invoice_status_check" CHECK ("status" IN ('OPEN', 'PAID', 'VOID', 'UNCOLLECTIBLE')),
+    CONSTRAINT "Invoice_amount_check" CHECK ("amount" >= 0 AND "amountPaid" >= 0),
+    CONSTRAINT "Invoice_externalId_provider_check" CHECK ("externalId" IS NULL OR "provider" IS NOT NULL),
+    CONSTRAINT "Invoice_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "Invoice_dealId_fkey" FOREIGN KEY ("dealId") REFERENCES "Deal"("id") ON DELETE CASCADE
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Uma fatura por id externo do provedor (chave da reconciliação)
+CREATE UNIQUE INDEX IF NOT EXISTS "Invoice_workspaceId_provider_externalId_key"
+    ON "Invoice" ("workspaceId", "provider", "externalId")
+    WHERE "externalId" IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "Invoice_workspaceId_dealId_idx"
+    ON "Invoice" ("workspaceId", "dealId", "createdAt" DESC);
+
+-- Relatório de receita (collected por "paidAt")
+CREATE INDEX IF NOT EXISTS "Invoice_workspaceId_paidAt_idx"
+    ON "Invoice" ("workspaceId", "paidAt")
+    WHERE "status" = 'PAID';
@@ -0,0 +1,8 @@
+-- Migration: 000036_scim.down.sql
+-- Description: Rollback SCIM provisioning
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "ScimGroupMember";
+DROP TABLE IF EXISTS "ScimGroup";
+DROP TABLE IF EXISTS "ScimUser";
+DROP TABLE IF EXISTS "ScimConfig";
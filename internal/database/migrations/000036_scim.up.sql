@@ -0,0 +1,100 @@
+-- Migration: 000036_scim.up.sql
+-- Description: SCIM 2.0 provisioning (per-workspace token, provisioned users, directory groups)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: ScimConfig
+-- Purpose: configuração SCIM do workspace. "tokenHash" é o sha256 do bearer token usado pelo
+-- diretório (Okta, Azure AD) em /scim/v2; NULL = provisionamento desativado. "defaultRole" é o
+-- papel dos usuários provisionados que não estão em nenhum grupo mapeado.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ScimConfig" (
+    "workspaceId" TEXT NOT NULL,
+    "tokenHash" BYTEA,
+    "defaultRole" TEXT NOT NULL DEFAULT 'work_user',
+    "tokenCreatedAt" TIMESTAMP(3),
+    "tokenLastUsedAt" TIMESTAMP(3),
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ScimConfig_pkey" PRIMARY KEY ("workspaceId"),
+    CONSTRAINT "ScimConfig_defaultRole_check" CHECK ("defaultRole" IN ('work_admin', 'work_manager', 'work_user', 'work_viewer')),
+    CONSTRAINT "ScimConfig_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "ScimConfig_tokenHash_key"
+    ON "ScimConfig" ("tokenHash")
+    WHERE "tokenHash" IS NOT NULL;
+
+-- =====================================================
+-- Table: ScimUser
+-- Purpose: usuários provisionados pelo diretório no workspace. O id SCIM é o id do "User";
+-- "active" = false (ou DELETE) remove o "WorkspaceMember" mas mantém o vínculo para reativação.
+-- Membros adicionados fora do SCIM não são alterados pelo diretório.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ScimUser" (
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "userName" TEXT NOT NULL,
+    "externalId" TEXT,
+    "givenName" TEXT,
+    "familyName" TEXT,
+    "displayName" TEXT,
+    "email" TEXT NOT NULL,
+    "active" BOOLEAN NOT NULL DEFAULT true,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ScimUser_pkey" PRIMARY KEY ("workspaceId", "userId"),
+    CONSTRAINT "ScimUser_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "ScimUser_userId_fkey" FOREIGN KEY ("userId") REFERENCES "User"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "ScimUser_workspaceId_userName_key"
+    ON "ScimUser" ("workspaceId", lower("userName"));
+
+CREATE INDEX IF NOT EXISTS "ScimUser_workspaceId_externalId_idx"
+    ON "ScimUser" ("workspaceId", "externalId")
+    WHERE "externalId" IS NOT NULL;
+
+-- =====================================================
+-- Table: ScimGroup
+-- Purpose: grupos do diretório. "role" (definido por um admin do workspace) mapeia o grupo para
+-- um papel; o membro recebe o papel mais alto entre seus grupos mapeados.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ScimGroup" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "displayName" TEXT NOT NULL,
+    "externalId" TEXT,
+    "role" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ScimGroup_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "ScimGroup_role_check" CHECK ("role" IS NULL OR "role" IN ('work_admin', 'work_manager', 'work_user', 'work_viewer')),
+    CONSTRAINT "ScimGroup_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "ScimGroup_workspaceId_displayName_key"
+    ON "ScimGroup" ("workspaceId", lower("displayName"));
+
+-- =====================================================
+-- Table: ScimGroupMember
+-- Purpose: membros dos grupos do diretório (somente usuários provisionados via SCIM)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ScimGroupMember" (
+    "groupId" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ScimGroupMember_pkey" PRIMARY KEY ("groupId", "userId"),
+    CONSTRAINT "ScimGroupMember_groupId_fkey" FOREIGN KEY ("groupId") REFERENCES "ScimGroup"("id") ON DELETE CASCADE,
+    CONSTRAINT "ScimGroupMember_user_fkey" FOREIGN KEY ("workspaceId", "userId") REFERENCES "ScimUser"("workspaceId", "userId") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "ScimGroupMember_workspaceId_userId_idx"
+    ON "ScimGroupMember" ("workspaceId", "userId");
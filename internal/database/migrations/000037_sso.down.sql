@@ -0,0 +1,6 @@
+-- Migration: 000037_sso.down.sql
+-- Description: Rollback OIDC SSO
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "SsoIdentity";
+DROP TABLE IF EXISTS "SsoProvider";
@@ -0,0 +1,55 @@
+-- Migration: 000037_sso.up.sql
+-- Description: OIDC SSO (workspace identity providers, linked identities)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: SsoProvider
+-- Purpose: IdPs OIDC configurados pelo admin do workspace. POST /v1/auth/sso/oidc/exchange aceita
+-- id_tokens emitidos por "issuer" para o "clientId" (aud) e devolve um JWT de sessão do Linkko.
+-- "allowedDomains" vazio = qualquer domínio de email; "jitProvisioning" adiciona ao workspace,
+-- com "defaultRole", usuários que ainda não são membros.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "SsoProvider" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "issuer" TEXT NOT NULL,
+    "clientId" TEXT NOT NULL,
+    "allowedDomains" TEXT[] NOT NULL DEFAULT '{}',
+    "defaultRole" TEXT NOT NULL DEFAULT 'work_user',
+    "jitProvisioning" BOOLEAN NOT NULL DEFAULT true,
+    "enabled" BOOLEAN NOT NULL DEFAULT true,
+    "lastLoginAt" TIMESTAMP(3),
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SsoProvider_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "SsoProvider_defaultRole_check" CHECK ("defaultRole" IN ('work_admin', 'work_manager', 'work_user', 'work_viewer')),
+    CONSTRAINT "SsoProvider_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "SsoProvider_workspaceId_issuer_key"
+    ON "SsoProvider" ("workspaceId", "issuer");
+
+-- =====================================================
+-- Table: SsoIdentity
+-- Purpose: vínculo do "sub" do IdP com o "User" do Linkko. Criado no primeiro login (pelo email);
+-- os seguintes usam o sub, então trocar o email no IdP não cria outra conta.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "SsoIdentity" (
+    "providerId" TEXT NOT NULL,
+    "subject" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "email" TEXT NOT NULL,
+    "lastLoginAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SsoIdentity_pkey" PRIMARY KEY ("providerId", "subject"),
+    CONSTRAINT "SsoIdentity_providerId_fkey" FOREIGN KEY ("providerId") REFERENCES "SsoProvider"("id") ON DELETE CASCADE,
+    CONSTRAINT "SsoIdentity_userId_fkey" FOREIGN KEY ("userId") REFERENCES "User"("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "SsoIdentity_userId_idx"
+    ON "SsoIdentity" ("userId");
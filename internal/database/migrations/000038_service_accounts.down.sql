@@ -0,0 +1,12 @@
+-- Migration: 000038_service_accounts.down.sql
+-- Description: Rollback workspace service accounts
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS idx_audit_service_account;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS actor_type;
+
+DELETE FROM "WorkspaceMember" m
+USING "ServiceAccount" sa
+WHERE m."userId" = sa."id" AND m."workspaceId" = sa."workspaceId";
+
+DROP TABLE IF EXISTS "ServiceAccount";
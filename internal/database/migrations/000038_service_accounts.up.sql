@@ -0,0 +1,45 @@
+-- Migration: 000038_service_accounts.up.sql
+-- Description: Workspace service accounts (client credentials) and actor type in the audit log
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: ServiceAccount
+-- Purpose: contas de integração do workspace. POST /v1/auth/token (grant client_credentials) troca
+-- id + secret por um JWT curto limitado a "scopes" ("contacts:read", "deals:write", "*:read"...).
+-- Enquanto ativa, a conta é membro do workspace (WorkspaceMember."userId" = id) com "role";
+-- nunca work_admin. Só o hash sha256 do secret é guardado.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ServiceAccount" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "clientSecretHash" BYTEA NOT NULL,
+    "secretCreatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "scopes" TEXT[] NOT NULL DEFAULT '{}',
+    "role" TEXT NOT NULL DEFAULT 'work_user',
+    "enabled" BOOLEAN NOT NULL DEFAULT true,
+    "lastTokenIssuedAt" TIMESTAMP(3),
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ServiceAccount_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "ServiceAccount_role_check" CHECK ("role" IN ('work_manager', 'work_user', 'work_viewer')),
+    CONSTRAINT "ServiceAccount_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "ServiceAccount_workspaceId_name_key"
+    ON "ServiceAccount" ("workspaceId", "name");
+
+-- =====================================================
+-- audit_log.actor_type
+-- Purpose: tipo do actor_id ("user", "service_account", "service" para S2S). NULL = entradas
+-- anteriores ou escritas fora de uma request autenticada.
+-- =====================================================
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS actor_type TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_audit_service_account
+    ON audit_log(workspace_id, actor_id, created_at DESC)
+    WHERE actor_type = 'service_account';
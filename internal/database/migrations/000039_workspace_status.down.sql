@@ -0,0 +1,8 @@
+-- Migration: 000039_workspace_status.down.sql
+-- Description: Rollback workspace status
+-- Date: 2026-10-18
+
+ALTER TABLE "Workspace" DROP CONSTRAINT IF EXISTS "Workspace_status_check";
+ALTER TABLE "Workspace" DROP COLUMN IF EXISTS "suspendedReason";
+ALTER TABLE "Workspace" DROP COLUMN IF EXISTS "suspendedAt";
+ALTER TABLE "Workspace" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,18 @@
+-- Migration: 000039_workspace_status.up.sql
+-- Description: Workspace status (ACTIVE/SUSPENDED) checked by the API before reaching services
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Workspace
+-- Purpose: workspaces suspensos (inadimplência, abuso, pedido do cliente) recebem 423
+-- WORKSPACE_SUSPENDED em todas as rotas /v1/workspaces/{workspaceId}. A suspensão é feita
+-- pelo time de operações; "suspendedReason" é interno e não vai na resposta.
+-- =====================================================
+
+ALTER TABLE "Workspace" ADD COLUMN IF NOT EXISTS "status" TEXT NOT NULL DEFAULT 'ACTIVE';
+ALTER TABLE "Workspace" ADD COLUMN IF NOT EXISTS "suspendedAt" TIMESTAMP(3);
+ALTER TABLE "Workspace" ADD COLUMN IF NOT EXISTS "suspendedReason" TEXT;
+
+ALTER TABLE "Workspace" DROP CONSTRAINT IF EXISTS "Workspace_status_check";
+ALTER TABLE "Workspace" ADD CONSTRAINT "Workspace_status_check" CHECK ("status" IN ('ACTIVE', 'SUSPENDED'));
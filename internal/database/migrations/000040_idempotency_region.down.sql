@@ -0,0 +1,14 @@
+-- Migration: 000040_idempotency_region.down.sql
+-- Description: Rollback idempotency keys scoped by region
+-- Date: 2026-10-18
+
+-- Chaves repetidas entre regiões não cabem na constraint antiga: fica a mais antiga
+DELETE FROM idempotency_keys a
+USING idempotency_keys b
+WHERE a.workspace_id = b.workspace_id
+  AND a.key_hash = b.key_hash
+  AND (a.created_at, a.id) > (b.created_at, b.id);
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS unique_workspace_region_key;
+ALTER TABLE idempotency_keys ADD CONSTRAINT unique_workspace_key UNIQUE (workspace_id, key_hash);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS region;
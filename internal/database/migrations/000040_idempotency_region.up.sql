@@ -0,0 +1,17 @@
+-- Migration: 000040_idempotency_region.up.sql
+-- Description: Idempotency keys scoped by region (multi-region deploys sharing the database)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: idempotency_keys
+-- Purpose: cada deploy regional (REGION) só reaproveita as respostas que ele mesmo gravou; a mesma
+-- Idempotency-Key enviada a outra região é uma request nova. Deploys de região única usam '' e
+-- continuam enxergando as chaves existentes.
+-- =====================================================
+
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS region TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS unique_workspace_key;
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS unique_workspace_region_key;
+ALTER TABLE idempotency_keys ADD CONSTRAINT unique_workspace_region_key UNIQUE (workspace_id, region, key_hash);
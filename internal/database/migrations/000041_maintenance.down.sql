@@ -0,0 +1,5 @@
+-- Migration: 000041_maintenance.down.sql
+-- Description: Rollback maintenance mode
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "Maintenance";
@@ -0,0 +1,19 @@
+-- Migration: 000041_maintenance.up.sql
+-- Description: Maintenance mode (global or per workspace) that blocks writes with 503
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Maintenance
+-- Purpose: ligada por um admin (PUT /admin/maintenance ou /admin/workspaces/{id}/maintenance)
+-- durante migrações longas. Enquanto a linha existe, escritas do escopo recebem 503
+-- MAINTENANCE_MODE com Retry-After e leituras seguem normais. "scope" é 'global' ou o id do
+-- workspace; "endsAt" é só a previsão usada no Retry-After.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "Maintenance" (
+    "scope" TEXT PRIMARY KEY,
+    "message" TEXT,
+    "endsAt" TIMESTAMP(3),
+    "startedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
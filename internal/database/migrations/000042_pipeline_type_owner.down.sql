@@ -0,0 +1,9 @@
+-- Migration: 000042_pipeline_type_owner.down.sql
+-- Description: Rollback pipeline type, active flag and owner
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "Pipeline_workspaceId_ownerId_idx";
+DROP INDEX IF EXISTS "Pipeline_workspaceId_pipelineType_idx";
+ALTER TABLE "Pipeline" DROP COLUMN IF EXISTS "ownerId";
+ALTER TABLE "Pipeline" DROP COLUMN IF EXISTS "isActive";
+ALTER TABLE "Pipeline" DROP COLUMN IF EXISTS "pipelineType";
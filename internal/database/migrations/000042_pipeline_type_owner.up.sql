@@ -0,0 +1,19 @@
+-- Migration: 000042_pipeline_type_owner.up.sql
+-- Description: Pipeline type, active flag and owner (already exposed by the API, never persisted)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: Pipeline
+-- Purpose: "pipelineType", "isActive" e "ownerId" já eram aceitos na criação e devolvidos na
+-- resposta, mas o repo não gravava nem lia as colunas: a leitura seguinte devolvia DEAL,
+-- false e ''. Pipelines existentes ficam DEAL/ativos e sem dono.
+-- =====================================================
+
+ALTER TABLE "Pipeline" ADD COLUMN IF NOT EXISTS "pipelineType" "PipelineType" NOT NULL DEFAULT 'DEAL';
+ALTER TABLE "Pipeline" ADD COLUMN IF NOT EXISTS "isActive" BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE "Pipeline" ADD COLUMN IF NOT EXISTS "ownerId" TEXT;
+
+-- Filtros da listagem (?pipelineType=, ?isActive=, ?ownerId=)
+CREATE INDEX IF NOT EXISTS "Pipeline_workspaceId_pipelineType_idx" ON "Pipeline" ("workspaceId", "pipelineType") WHERE "deletedAt" IS NULL;
+CREATE INDEX IF NOT EXISTS "Pipeline_workspaceId_ownerId_idx" ON "Pipeline" ("workspaceId", "ownerId") WHERE "deletedAt" IS NULL;
@@ -0,0 +1,7 @@
+-- Migration: 000043_deal_search.down.sql
+-- Description: Rollback deal search indexes
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "Company_search_idx";
+DROP INDEX IF EXISTS "Contact_search_idx";
+DROP INDEX IF EXISTS "Deal_search_idx";
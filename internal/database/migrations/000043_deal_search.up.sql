@@ -0,0 +1,20 @@
+-- Migration: 000043_deal_search.up.sql
+-- Description: Full-text indexes for deal search (?q=) by deal, contact and company name
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- GET /deals?q= busca no nome do deal e nos nomes do contato e da empresa vinculados
+-- (vendedores procuram o negócio pelo nome do cliente). Cada tabela tem um índice GIN com
+-- a mesma expressão usada na query; os de Contact e Company são as expressões da busca
+-- ?q= das listagens de contatos e empresas, que passam a usá-los também.
+-- =====================================================
+
+CREATE INDEX IF NOT EXISTS "Deal_search_idx"
+    ON "Deal" USING GIN (to_tsvector('simple', "name"));
+
+CREATE INDEX IF NOT EXISTS "Contact_search_idx"
+    ON "Contact" USING GIN (to_tsvector('simple', "fullName" || ' ' || COALESCE("email", '')));
+
+CREATE INDEX IF NOT EXISTS "Company_search_idx"
+    ON "Company" USING GIN (to_tsvector('simple', "name" || ' ' || COALESCE("website", '')));
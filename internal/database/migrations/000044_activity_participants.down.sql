@@ -0,0 +1,9 @@
+-- Migration: 000044_activity_participants.down.sql
+-- Description: Rollback activity participants
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "ActivityParticipant_contactId_idx";
+DROP INDEX IF EXISTS "ActivityParticipant_userId_idx";
+DROP INDEX IF EXISTS "unique_activity_participant_contact";
+DROP INDEX IF EXISTS "unique_activity_participant_user";
+DROP TABLE IF EXISTS "ActivityParticipant";
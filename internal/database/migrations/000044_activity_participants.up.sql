@@ -0,0 +1,44 @@
+-- Migration: 000044_activity_participants.up.sql
+-- Description: Activity participants (users and contacts on calls and meetings, with role)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: ActivityParticipant
+-- Purpose: chamadas e reuniões envolvem várias pessoas. Cada linha liga um usuário OU um
+-- contato ao registro ("activityType" + "activityId" = "Call".id / "Meeting".id, o mesmo par
+-- de "Activity"), com o papel na interação. "Call"."userId"/"contactId" e "Meeting"."userId"
+-- seguem sendo o autor e o contato principal; participantes complementam.
+-- "MeetingAttendee" (convites sincronizados do calendário) não é usado pela API.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "ActivityParticipant" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "activityType" TEXT NOT NULL,
+    "activityId" TEXT NOT NULL,
+    "userId" TEXT,
+    "contactId" TEXT,
+    "role" TEXT NOT NULL DEFAULT 'ATTENDEE',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ActivityParticipant_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "ActivityParticipant_contactId_fkey" FOREIGN KEY ("contactId") REFERENCES "Contact"("id") ON DELETE CASCADE,
+    CONSTRAINT "ActivityParticipant_activityType_check" CHECK ("activityType" IN ('CALL', 'MEETING')),
+    CONSTRAINT "ActivityParticipant_role_check" CHECK ("role" IN ('ORGANIZER', 'ATTENDEE', 'OPTIONAL')),
+    CONSTRAINT "ActivityParticipant_subject_check" CHECK (("userId" IS NULL) <> ("contactId" IS NULL))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Cada pessoa aparece uma única vez por chamada/reunião
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_activity_participant_user"
+    ON "ActivityParticipant" ("activityId", "userId") WHERE "userId" IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_activity_participant_contact"
+    ON "ActivityParticipant" ("activityId", "contactId") WHERE "contactId" IS NOT NULL;
+
+-- Timeline ?participantId= ("reuniões de que participei")
+CREATE INDEX IF NOT EXISTS "ActivityParticipant_userId_idx"
+    ON "ActivityParticipant" ("workspaceId", "userId") WHERE "userId" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "ActivityParticipant_contactId_idx"
+    ON "ActivityParticipant" ("workspaceId", "contactId") WHERE "contactId" IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Migration: 000045_notification_preferences.down.sql
+-- Description: Rollback notification preferences
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "NotificationPreference";
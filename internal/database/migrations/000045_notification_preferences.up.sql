@@ -0,0 +1,26 @@
+-- Migration: 000045_notification_preferences.up.sql
+-- Description: Per-user notification preferences (channel per event and quiet hours)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: NotificationPreference
+-- Purpose: canal de cada evento de notificação (in_app, email, none) e horário silencioso
+-- do usuário no workspace. Sem linha, vale o padrão (tudo in_app, sem horário silencioso).
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "NotificationPreference" (
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "events" JSONB NOT NULL DEFAULT '{}',
+    "quietHoursStart" TEXT,
+    "quietHoursEnd" TEXT,
+    "quietHoursTimezone" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "NotificationPreference_pkey" PRIMARY KEY ("workspaceId", "userId"),
+    CONSTRAINT "NotificationPreference_quietHours_check" CHECK (
+        ("quietHoursStart" IS NULL) = ("quietHoursEnd" IS NULL)
+        AND ("quietHoursStart" IS NULL) = ("quietHoursTimezone" IS NULL)
+    )
+);
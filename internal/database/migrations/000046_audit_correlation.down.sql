@@ -0,0 +1,9 @@
+-- Migration: 000046_audit_correlation.down.sql
+-- Description: Rollback audit request/trace correlation
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS idx_audit_trace_id;
+DROP INDEX IF EXISTS idx_audit_request_id;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS trace_id;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS request_id;
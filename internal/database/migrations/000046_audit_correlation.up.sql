@@ -0,0 +1,21 @@
+-- Migration: 000046_audit_correlation.up.sql
+-- Description: Correlate audit entries with the request and the OTel trace
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- audit_log.request_id / audit_log.trace_id
+-- Purpose: X-Request-Id e trace_id OTel da request que gravou a entrada, para ir de um trace
+-- (ou de uma linha de log) ao audit trail. NULL = entradas anteriores, escritas fora de uma
+-- request ou sem trace amostrado.
+-- =====================================================
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS request_id TEXT;
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS trace_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_audit_request_id
+    ON audit_log(request_id)
+    WHERE request_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_audit_trace_id
+    ON audit_log(trace_id)
+    WHERE trace_id IS NOT NULL;
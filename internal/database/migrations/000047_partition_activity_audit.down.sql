@@ -0,0 +1,51 @@
+-- Migration: 000047_partition_activity_audit.down.sql
+-- Description: Rollback monthly partitioning of "Activity" and audit_log
+-- Date: 2026-10-18
+
+-- Volta cada tabela a uma tabela comum com PK (id); índices e FKs são recriados com os
+-- mesmos nomes. As linhas de todas as partições (inclusive a default) são copiadas.
+CREATE FUNCTION pg_temp.unpartition(tbl TEXT) RETURNS VOID
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    partitioned TEXT := tbl || '_partitioned';
+    index_defs  TEXT[];
+    fk_defs     TEXT[];
+    def         TEXT;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = format('public.%I', tbl)::regclass) THEN
+        RETURN;
+    END IF;
+
+    SELECT COALESCE(array_agg(pg_get_indexdef(indexrelid)), '{}') INTO index_defs
+    FROM pg_index
+    WHERE indrelid = format('public.%I', tbl)::regclass AND NOT indisprimary;
+
+    SELECT COALESCE(array_agg(format('ALTER TABLE public.%I ADD CONSTRAINT %I %s', tbl, conname, pg_get_constraintdef(oid))), '{}') INTO fk_defs
+    FROM pg_constraint
+    WHERE conrelid = format('public.%I', tbl)::regclass AND contype = 'f' AND conparentid = 0;
+
+    EXECUTE format('ALTER TABLE public.%I RENAME TO %I', tbl, partitioned);
+    EXECUTE format('ALTER TABLE public.%I RENAME CONSTRAINT %I TO %I', partitioned, tbl || '_pkey', partitioned || '_pkey');
+
+    EXECUTE format(
+        'CREATE TABLE public.%I (LIKE public.%I INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING STORAGE INCLUDING COMMENTS)',
+        tbl, partitioned);
+    EXECUTE format('ALTER TABLE public.%I ADD CONSTRAINT %I PRIMARY KEY (id)', tbl, tbl || '_pkey');
+
+    EXECUTE format('INSERT INTO public.%I SELECT * FROM public.%I', tbl, partitioned);
+    EXECUTE format('DROP TABLE public.%I', partitioned);
+
+    FOREACH def IN ARRAY index_defs LOOP
+        EXECUTE def;
+    END LOOP;
+    FOREACH def IN ARRAY fk_defs LOOP
+        EXECUTE def;
+    END LOOP;
+END;
+$$;
+
+SELECT pg_temp.unpartition('audit_log');
+SELECT pg_temp.unpartition('Activity');
+
+DROP FUNCTION pg_temp.unpartition(TEXT);
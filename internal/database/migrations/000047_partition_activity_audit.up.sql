@@ -0,0 +1,92 @@
+-- Migration: 000047_partition_activity_audit.up.sql
+-- Description: Monthly range partitioning for "Activity" and audit_log
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Particionamento mensal de "Activity" ("createdAt") e audit_log (created_at)
+-- Purpose: as duas tabelas crescem sem limite e dominam o storage. Com uma partição por mês
+-- (<tabela>_AAAA_MM, limites em UTC) as consultas com faixa de datas só leem os meses
+-- envolvidos (partition pruning) e meses antigos podem ser destacados/arquivados inteiros.
+--
+-- - Partições criadas aqui: do mês do registro mais antigo até 3 meses à frente. Os meses
+--   seguintes são criados pelo job de manutenção (cleanup, PARTITION_PREMAKE_MONTHS).
+-- - <tabela>_default recebe o que cair fora das partições mensais; o job de manutenção move
+--   essas linhas para a partição do mês ao criá-la.
+-- - A chave primária passa a incluir a coluna de partição (exigência do Postgres):
+--   "Activity" (id, "createdAt") e audit_log (id, created_at). Os ids continuam únicos na
+--   prática (gerados pela aplicação / gen_random_uuid).
+-- - Índices e FKs existentes são recriados com os mesmos nomes na tabela particionada.
+--
+-- A conversão copia os dados dentro da transação da migração: em bases grandes, rodar em
+-- janela de manutenção (as duas tabelas ficam bloqueadas durante a cópia).
+-- =====================================================
+
+CREATE FUNCTION pg_temp.partition_monthly(tbl TEXT, col TEXT) RETURNS VOID
+LANGUAGE plpgsql
+SET timezone = 'UTC'
+AS $$
+DECLARE
+    legacy      TEXT := tbl || '_unpartitioned';
+    index_defs  TEXT[];
+    fk_defs     TEXT[];
+    def         TEXT;
+    pkey        TEXT;
+    first_month DATE;
+    last_month  DATE := (date_trunc('month', now()) + INTERVAL '3 months')::DATE;
+    m           DATE;
+BEGIN
+    -- Idempotente: tabela já particionada
+    IF EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = format('public.%I', tbl)::regclass) THEN
+        RETURN;
+    END IF;
+
+    SELECT COALESCE(array_agg(pg_get_indexdef(indexrelid)), '{}') INTO index_defs
+    FROM pg_index
+    WHERE indrelid = format('public.%I', tbl)::regclass AND NOT indisprimary;
+
+    SELECT COALESCE(array_agg(format('ALTER TABLE public.%I ADD CONSTRAINT %I %s', tbl, conname, pg_get_constraintdef(oid))), '{}') INTO fk_defs
+    FROM pg_constraint
+    WHERE conrelid = format('public.%I', tbl)::regclass AND contype = 'f';
+
+    SELECT conname INTO pkey
+    FROM pg_constraint
+    WHERE conrelid = format('public.%I', tbl)::regclass AND contype = 'p';
+
+    EXECUTE format('ALTER TABLE public.%I RENAME TO %I', tbl, legacy);
+    IF pkey IS NOT NULL THEN
+        EXECUTE format('ALTER TABLE public.%I RENAME CONSTRAINT %I TO %I', legacy, pkey, legacy || '_pkey');
+    END IF;
+
+    EXECUTE format(
+        'CREATE TABLE public.%I (LIKE public.%I INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING STORAGE INCLUDING COMMENTS) PARTITION BY RANGE (%I)',
+        tbl, legacy, col);
+    EXECUTE format('ALTER TABLE public.%I ADD CONSTRAINT %I PRIMARY KEY (id, %I)', tbl, tbl || '_pkey', col);
+
+    EXECUTE format('SELECT date_trunc(''month'', min(%I))::DATE FROM public.%I', col, legacy) INTO first_month;
+    first_month := LEAST(COALESCE(first_month, date_trunc('month', now())::DATE), date_trunc('month', now())::DATE);
+
+    m := first_month;
+    WHILE m <= last_month LOOP
+        EXECUTE format('CREATE TABLE public.%I PARTITION OF public.%I FOR VALUES FROM (%L) TO (%L)',
+            tbl || '_' || to_char(m, 'YYYY_MM'), tbl, m, (m + INTERVAL '1 month')::DATE);
+        m := (m + INTERVAL '1 month')::DATE;
+    END LOOP;
+    EXECUTE format('CREATE TABLE public.%I PARTITION OF public.%I DEFAULT', tbl || '_default', tbl);
+
+    EXECUTE format('INSERT INTO public.%I SELECT * FROM public.%I', tbl, legacy);
+    EXECUTE format('DROP TABLE public.%I', legacy);
+
+    FOREACH def IN ARRAY index_defs LOOP
+        EXECUTE def;
+    END LOOP;
+    FOREACH def IN ARRAY fk_defs LOOP
+        EXECUTE def;
+    END LOOP;
+END;
+$$;
+
+SELECT pg_temp.partition_monthly('Activity', 'createdAt');
+SELECT pg_temp.partition_monthly('audit_log', 'created_at');
+
+DROP FUNCTION pg_temp.partition_monthly(TEXT, TEXT);
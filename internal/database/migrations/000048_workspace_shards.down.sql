@@ -0,0 +1,5 @@
+-- Migration: 000048_workspace_shards.down.sql
+-- Description: Rollback workspace shard map
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "WorkspaceShard";
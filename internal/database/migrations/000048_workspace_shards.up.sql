@@ -0,0 +1,20 @@
+-- Migration: 000048_workspace_shards.up.sql
+-- Description: Workspace → database shard map for data residency
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: WorkspaceShard
+-- Purpose: workspaces cujos dados ficam em outro banco (DATABASE_SHARDS, ex. "eu" para
+-- clientes que exigem armazenamento só na UE). Fica no banco padrão, junto do plano de
+-- controle; workspaces sem linha usam o banco padrão. Escrita só pelo comando
+-- "linkko-api shard migrate", que copia os dados antes de trocar o shard.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "WorkspaceShard" (
+    "workspaceId" TEXT PRIMARY KEY REFERENCES "Workspace"(id) ON DELETE CASCADE,
+    "shard" TEXT NOT NULL,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_shard_shard ON "WorkspaceShard"("shard");
@@ -0,0 +1,11 @@
+-- Migration: 000049_deal_forecast_category.down.sql
+-- Description: Rollback deal forecast categories
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "Deal_workspaceId_forecastCategory_idx";
+ALTER TABLE "Deal" DROP CONSTRAINT IF EXISTS "Deal_forecastCategory_check";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "forecastCategoryOverridden";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "forecastCategory";
+DROP FUNCTION IF EXISTS deal_forecast_category("DealStage", INTEGER);
+ALTER TABLE "PipelineStage" DROP CONSTRAINT IF EXISTS "PipelineStage_probability_check";
+ALTER TABLE "PipelineStage" DROP COLUMN IF EXISTS "probability";
@@ -0,0 +1,56 @@
+-- Migration: 000049_deal_forecast_category.up.sql
+-- Description: Forecast categories on deals, derived from the stage probability
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: PipelineStage
+-- Purpose: probabilidade de fechamento da etapa (0-100). NULL = não configurada; os deals
+-- usam a própria probability para derivar a categoria de forecast.
+-- =====================================================
+ALTER TABLE "PipelineStage" ADD COLUMN IF NOT EXISTS "probability" INTEGER;
+ALTER TABLE "PipelineStage" DROP CONSTRAINT IF EXISTS "PipelineStage_probability_check";
+ALTER TABLE "PipelineStage" ADD CONSTRAINT "PipelineStage_probability_check" CHECK ("probability" IS NULL OR "probability" BETWEEN 0 AND 100);
+
+-- =====================================================
+-- Function: deal_forecast_category
+-- Purpose: categoria padrão do deal. Ganhos = COMMIT, perdidos = OMITTED; em aberto, pela
+-- probabilidade (a da etapa; sem ela, a do deal): >= 90 COMMIT, >= 60 BEST_CASE, > 0 PIPELINE,
+-- 0 OMITTED. Usada por CreateDeal/UpdateDeal e pelo recálculo ao mudar a probabilidade da etapa.
+-- =====================================================
+CREATE OR REPLACE FUNCTION deal_forecast_category(stage "DealStage", probability INTEGER) RETURNS TEXT
+LANGUAGE sql IMMUTABLE
+AS $$
+    SELECT CASE
+        WHEN stage = 'WON' THEN 'COMMIT'
+        WHEN stage = 'LOST' THEN 'OMITTED'
+        WHEN COALESCE(probability, 0) >= 90 THEN 'COMMIT'
+        WHEN COALESCE(probability, 0) >= 60 THEN 'BEST_CASE'
+        WHEN COALESCE(probability, 0) > 0 THEN 'PIPELINE'
+        ELSE 'OMITTED'
+    END
+$$;
+
+-- =====================================================
+-- Table: Deal
+-- Purpose: categoria de forecast (COMMIT, BEST_CASE, PIPELINE, OMITTED). Derivada por
+-- deal_forecast_category e recalculada ao mover o deal ou mudar a probabilidade da etapa,
+-- exceto quando definida manualmente ("forecastCategoryOverridden").
+-- =====================================================
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "forecastCategory" TEXT NOT NULL DEFAULT 'PIPELINE';
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "forecastCategoryOverridden" BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE "Deal" DROP CONSTRAINT IF EXISTS "Deal_forecastCategory_check";
+ALTER TABLE "Deal" ADD CONSTRAINT "Deal_forecastCategory_check" CHECK ("forecastCategory" IN ('COMMIT', 'BEST_CASE', 'PIPELINE', 'OMITTED'));
+
+UPDATE "Deal" d
+SET "forecastCategory" = deal_forecast_category(d.stage, COALESCE(s.probability, d.probability))
+FROM "Deal" cur
+LEFT JOIN "PipelineStage" s ON s.id = cur."stageId"
+WHERE cur.id = d.id;
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- GET /reports/forecast
+CREATE INDEX IF NOT EXISTS "Deal_workspaceId_forecastCategory_idx" ON "Deal" ("workspaceId", "forecastCategory")
+    WHERE "deletedAt" IS NULL;
@@ -0,0 +1,7 @@
+-- Migration: 000050_deal_splits.down.sql
+-- Description: Rollback deal splits
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "DealSplit_userId_idx";
+DROP INDEX IF EXISTS "unique_deal_split";
+DROP TABLE IF EXISTS "DealSplit";
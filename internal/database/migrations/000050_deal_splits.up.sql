@@ -0,0 +1,35 @@
+-- Migration: 000050_deal_splits.up.sql
+-- Description: Deal splits (crédito de um negócio dividido entre vários donos)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: DealSplit
+-- Purpose: divisão do crédito de um negócio entre usuários (ex.: SDR 30% e AE 70%).
+-- Os percentuais de um negócio somam 100 (validado na API, que substitui a lista inteira);
+-- sem splits, o crédito é 100% do "Deal"."ownerId". Os relatórios por dono usam value × percentage.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "DealSplit" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "dealId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "percentage" DOUBLE PRECISION NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "DealSplit_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "DealSplit_dealId_fkey" FOREIGN KEY ("dealId") REFERENCES "Deal"("id") ON DELETE CASCADE,
+    CONSTRAINT "DealSplit_percentage_check" CHECK ("percentage" > 0 AND "percentage" <= 100)
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Um usuário aparece uma única vez nos splits de cada negócio
+CREATE UNIQUE INDEX IF NOT EXISTS "unique_deal_split"
+    ON "DealSplit" ("dealId", "userId");
+
+-- Relatórios por dono
+CREATE INDEX IF NOT EXISTS "DealSplit_userId_idx"
+    ON "DealSplit" ("workspaceId", "userId");
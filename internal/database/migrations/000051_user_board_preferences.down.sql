@@ -0,0 +1,5 @@
+-- Migration: 000051_user_board_preferences.down.sql
+-- Description: Rollback user board preferences
+-- Date: 2026-10-18
+
+DROP TABLE IF EXISTS "UserBoardPreference";
@@ -0,0 +1,25 @@
+-- Migration: 000051_user_board_preferences.up.sql
+-- Description: Per-user deal board personalization (column order, collapsed and hidden stages)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: UserBoardPreference
+-- Purpose: ordem das colunas e etapas recolhidas/ocultas do board de negócios de cada
+-- usuário, por pipeline. Fica no servidor para acompanhar o usuário entre dispositivos.
+-- Sem linha, vale o padrão (orderIndex das etapas, nada recolhido nem oculto). IDs de
+-- etapas excluídas depois são ignorados na leitura.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "UserBoardPreference" (
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "pipelineId" TEXT NOT NULL,
+    "stageOrder" TEXT[] NOT NULL DEFAULT '{}',
+    "collapsedStageIds" TEXT[] NOT NULL DEFAULT '{}',
+    "hiddenStageIds" TEXT[] NOT NULL DEFAULT '{}',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "UserBoardPreference_pkey" PRIMARY KEY ("workspaceId", "userId", "pipelineId"),
+    CONSTRAINT "UserBoardPreference_pipelineId_fkey" FOREIGN KEY ("pipelineId") REFERENCES "Pipeline"("id") ON DELETE CASCADE
+);
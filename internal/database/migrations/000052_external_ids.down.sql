@@ -0,0 +1,10 @@
+-- Migration: 000052_external_ids.down.sql
+-- Description: Rollback integrator-owned external IDs
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "Deal_workspaceId_externalId_key";
+DROP INDEX IF EXISTS "Company_workspaceId_externalId_key";
+DROP INDEX IF EXISTS "Contact_workspaceId_externalId_key";
+ALTER TABLE "Deal" DROP COLUMN IF EXISTS "externalId";
+ALTER TABLE "Company" DROP COLUMN IF EXISTS "externalId";
+ALTER TABLE "Contact" DROP COLUMN IF EXISTS "externalId";
@@ -0,0 +1,26 @@
+-- Migration: 000052_external_ids.up.sql
+-- Description: Integrator-owned external IDs on contacts, companies and deals
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Column: externalId (Contact, Company, Deal)
+-- Purpose: chave do sistema de origem do integrador. PUT .../by-external-id/{externalId}
+-- cria ou atualiza pelo externalId; o índice único garante um registro ativo por chave
+-- mesmo com duas sincronizações concorrentes. Registros na lixeira liberam a chave.
+-- =====================================================
+ALTER TABLE "Contact" ADD COLUMN IF NOT EXISTS "externalId" TEXT;
+ALTER TABLE "Company" ADD COLUMN IF NOT EXISTS "externalId" TEXT;
+ALTER TABLE "Deal" ADD COLUMN IF NOT EXISTS "externalId" TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "Contact_workspaceId_externalId_key"
+    ON "Contact" ("workspaceId", "externalId")
+    WHERE "externalId" IS NOT NULL AND "deletedAt" IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "Company_workspaceId_externalId_key"
+    ON "Company" ("workspaceId", "externalId")
+    WHERE "externalId" IS NOT NULL AND "deletedAt" IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "Deal_workspaceId_externalId_key"
+    ON "Deal" ("workspaceId", "externalId")
+    WHERE "externalId" IS NOT NULL AND "deletedAt" IS NULL;
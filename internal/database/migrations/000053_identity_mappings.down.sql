@@ -0,0 +1,7 @@
+-- Migration: 000053_identity_mappings.down.sql
+-- Description: Rollback identity mappings
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "IdentityMapping_workspaceId_entityType_entityId_idx";
+DROP INDEX IF EXISTS "IdentityMapping_workspaceId_system_entityType_externalId_key";
+DROP TABLE IF EXISTS "IdentityMapping";
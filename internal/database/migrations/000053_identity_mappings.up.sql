@@ -0,0 +1,38 @@
+-- Migration: 000053_identity_mappings.up.sql
+-- Description: Identity mappings (ids de vários sistemas externos por registro)
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: IdentityMapping
+-- Purpose: tradução sistema externo -> id externo -> id Linkko, para workspaces que sincronizam
+-- com vários sistemas ao mesmo tempo (ERP, e-commerce, suporte...). Complementa o "externalId"
+-- das entidades, que guarda uma única chave. Um id externo aponta para um único registro por
+-- sistema e tipo; o mesmo registro pode ter ids em vários sistemas.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "IdentityMapping" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "externalId" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "IdentityMapping_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "IdentityMapping_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "IdentityMapping_entityType_check" CHECK ("entityType" IN ('contact', 'company', 'deal'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Um id externo por sistema e tipo (alvo do upsert de :register)
+CREATE UNIQUE INDEX IF NOT EXISTS "IdentityMapping_workspaceId_system_entityType_externalId_key"
+    ON "IdentityMapping" ("workspaceId", "system", "entityType", "externalId");
+
+-- Resolução reversa (id Linkko -> ids externos)
+CREATE INDEX IF NOT EXISTS "IdentityMapping_workspaceId_entityType_entityId_idx"
+    ON "IdentityMapping" ("workspaceId", "entityType", "entityId");
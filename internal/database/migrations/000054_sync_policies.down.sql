@@ -0,0 +1,10 @@
+-- Migration: 000054_sync_policies.down.sql
+-- Description: Rollback sync policies
+-- Date: 2026-10-18
+
+DROP INDEX IF EXISTS "SyncConflict_workspaceId_entityType_entityId_idx";
+DROP INDEX IF EXISTS "SyncConflict_workspaceId_createdAt_idx";
+DROP INDEX IF EXISTS "SyncPolicy_workspaceId_system_key";
+DROP TABLE IF EXISTS "SyncConflict";
+DROP TABLE IF EXISTS "SyncFieldSource";
+DROP TABLE IF EXISTS "SyncPolicy";
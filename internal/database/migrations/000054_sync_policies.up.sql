@@ -0,0 +1,81 @@
+-- Migration: 000054_sync_policies.up.sql
+-- Description: Políticas de conflito de sincronização, origem dos campos e log de conflitos
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: SyncPolicy
+-- Purpose: política de conflito de cada integração (system, o mesmo nome de "IdentityMapping").
+-- LAST_WRITER_WINS: os upserts do sistema sempre sobrescrevem; SOURCE_PRIORITY: um campo gravado
+-- por outro sistema só é sobrescrito com prioridade maior ou igual à dele ("fieldPriorities" por
+-- campo, "priority" no resto). Sistemas sem política usam LAST_WRITER_WINS com prioridade 0.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "SyncPolicy" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "strategy" TEXT NOT NULL DEFAULT 'LAST_WRITER_WINS',
+    "priority" INTEGER NOT NULL DEFAULT 0,
+    "fieldPriorities" JSONB NOT NULL DEFAULT '{}',
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SyncPolicy_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "SyncPolicy_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "SyncPolicy_strategy_check" CHECK ("strategy" IN ('LAST_WRITER_WINS', 'SOURCE_PRIORITY'))
+);
+
+-- =====================================================
+-- Table: SyncFieldSource
+-- Purpose: último sistema que gravou cada campo de um registro via upsert (?system=). É a base
+-- para detectar conflitos: só escritas de sistemas diferentes sobre o mesmo campo conflitam.
+-- Edições pela API sem system não alteram a origem.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "SyncFieldSource" (
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "field" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SyncFieldSource_pkey" PRIMARY KEY ("workspaceId", "entityType", "entityId", "field"),
+    CONSTRAINT "SyncFieldSource_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE
+);
+
+-- =====================================================
+-- Table: SyncConflict
+-- Purpose: log dos conflitos resolvidos nos upserts: valor recebido, valor atual, sistemas
+-- envolvidos e a resolução (OVERWRITTEN ou KEPT_CURRENT). Mostra ping-pong entre sistemas.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "SyncConflict" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "field" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "currentSystem" TEXT NOT NULL,
+    "incomingValue" JSONB,
+    "currentValue" JSONB,
+    "resolution" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SyncConflict_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "SyncConflict_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "SyncConflict_resolution_check" CHECK ("resolution" IN ('OVERWRITTEN', 'KEPT_CURRENT'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Uma política por sistema
+CREATE UNIQUE INDEX IF NOT EXISTS "SyncPolicy_workspaceId_system_key"
+    ON "SyncPolicy" ("workspaceId", "system");
+
+-- Listagem do log (mais recentes primeiro) e filtro por registro
+CREATE INDEX IF NOT EXISTS "SyncConflict_workspaceId_createdAt_idx"
+    ON "SyncConflict" ("workspaceId", "createdAt" DESC);
+CREATE INDEX IF NOT EXISTS "SyncConflict_workspaceId_entityType_entityId_idx"
+    ON "SyncConflict" ("workspaceId", "entityType", "entityId");
@@ -0,0 +1,22 @@
+-- Migration: 000055_soft_delete_cascade.down.sql
+-- Description: Rollback soft delete propagation (reassocia as referências desassociadas)
+-- Date: 2026-10-18
+
+UPDATE "Contact" c SET "companyId" = d."parentId"
+FROM "SoftDeleteDetachment" d
+WHERE d."childType" = 'contact' AND d."field" = 'companyId' AND d."childId" = c.id AND c."companyId" IS NULL;
+
+UPDATE "Deal" c SET "companyId" = d."parentId"
+FROM "SoftDeleteDetachment" d
+WHERE d."childType" = 'deal' AND d."field" = 'companyId' AND d."childId" = c.id AND c."companyId" IS NULL;
+
+UPDATE "Deal" c SET "contactId" = d."parentId"
+FROM "SoftDeleteDetachment" d
+WHERE d."childType" = 'deal' AND d."field" = 'contactId' AND d."childId" = c.id AND c."contactId" IS NULL;
+
+UPDATE "Task" c SET contact_id = d."parentId"
+FROM "SoftDeleteDetachment" d
+WHERE d."childType" = 'task' AND d."field" = 'contactId' AND d."childId" = c.id AND c.contact_id IS NULL;
+
+DROP INDEX IF EXISTS "SoftDeleteDetachment_detachedAt_idx";
+DROP TABLE IF EXISTS "SoftDeleteDetachment";
@@ -0,0 +1,71 @@
+-- Migration: 000055_soft_delete_cascade.up.sql
+-- Description: Soft delete propagation - detached references of deleted companies/contacts
+-- Date: 2026-10-18
+-- Author: Linkko Platform Team
+
+-- =====================================================
+-- Table: SoftDeleteDetachment
+-- Purpose: o soft delete de uma empresa (ou contato) desassocia os registros que apontam para
+-- ela, como o ON DELETE SET NULL das FKs faria num DELETE, e guarda aqui cada referência
+-- removida para que o restore (lixeira ou undo) reassocie os registros. As linhas saem no
+-- restore ou na purga do registro pai.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS "SoftDeleteDetachment" (
+    "workspaceId" TEXT NOT NULL,
+    "parentType" TEXT NOT NULL,
+    "parentId" TEXT NOT NULL,
+    "childType" TEXT NOT NULL,
+    "childId" TEXT NOT NULL,
+    "field" TEXT NOT NULL,
+    "detachedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SoftDeleteDetachment_pkey" PRIMARY KEY ("parentId", "childType", "childId", "field"),
+    CONSTRAINT "SoftDeleteDetachment_workspaceId_fkey" FOREIGN KEY ("workspaceId") REFERENCES "Workspace"("id") ON DELETE CASCADE,
+    CONSTRAINT "SoftDeleteDetachment_parentType_check" CHECK ("parentType" IN ('company', 'contact'))
+);
+
+-- =====================================================
+-- Indexes
+-- =====================================================
+-- Purga das desassociações de registros purgados
+CREATE INDEX IF NOT EXISTS "SoftDeleteDetachment_detachedAt_idx"
+    ON "SoftDeleteDetachment" ("detachedAt");
+
+-- =====================================================
+-- Backfill
+-- Purpose: registros que já apontam para empresas/contatos excluídos são desassociados com a
+-- data do soft delete do pai. Stages ativos de pipelines excluídos passam a excluídos junto.
+-- Negócios de pipelines excluídos não mudam: a data antiga os levaria direto para a purga.
+-- =====================================================
+INSERT INTO "SoftDeleteDetachment" ("workspaceId", "parentType", "parentId", "childType", "childId", "field", "detachedAt")
+SELECT c."workspaceId", 'company', c."companyId", 'contact', c.id, 'companyId', p."deletedAt"
+FROM "Contact" c JOIN "Company" p ON p.id = c."companyId" AND p."deletedAt" IS NOT NULL
+ON CONFLICT DO NOTHING;
+UPDATE "Contact" c SET "companyId" = NULL
+FROM "Company" p WHERE p.id = c."companyId" AND p."deletedAt" IS NOT NULL;
+
+INSERT INTO "SoftDeleteDetachment" ("workspaceId", "parentType", "parentId", "childType", "childId", "field", "detachedAt")
+SELECT d."workspaceId", 'company', d."companyId", 'deal', d.id, 'companyId', p."deletedAt"
+FROM "Deal" d JOIN "Company" p ON p.id = d."companyId" AND p."deletedAt" IS NOT NULL
+ON CONFLICT DO NOTHING;
+UPDATE "Deal" d SET "companyId" = NULL
+FROM "Company" p WHERE p.id = d."companyId" AND p."deletedAt" IS NOT NULL;
+
+INSERT INTO "SoftDeleteDetachment" ("workspaceId", "parentType", "parentId", "childType", "childId", "field", "detachedAt")
+SELECT d."workspaceId", 'contact', d."contactId", 'deal', d.id, 'contactId', p."deletedAt"
+FROM "Deal" d JOIN "Contact" p ON p.id = d."contactId" AND p."deletedAt" IS NOT NULL
+ON CONFLICT DO NOTHING;
+UPDATE "Deal" d SET "contactId" = NULL
+FROM "Contact" p WHERE p.id = d."contactId" AND p."deletedAt" IS NOT NULL;
+
+-- NOTE: colunas snake_case, como lidas pelo TaskRepository
+INSERT INTO "SoftDeleteDetachment" ("workspaceId", "parentType", "parentId", "childType", "childId", "field", "detachedAt")
+SELECT t.workspace_id, 'contact', t.contact_id, 'task', t.id, 'contactId', p."deletedAt"
+FROM "Task" t JOIN "Contact" p ON p.id = t.contact_id AND p."deletedAt" IS NOT NULL
+ON CONFLICT DO NOTHING;
+UPDATE "Task" t SET contact_id = NULL
+FROM "Contact" p WHERE p.id = t.contact_id AND p."deletedAt" IS NOT NULL;
+
+UPDATE "PipelineStage" s SET "deletedAt" = p."deletedAt"
+FROM "Pipeline" p
+WHERE p.id = s."pipelineId" AND p."deletedAt" IS NOT NULL AND s."deletedAt" IS NULL;
@@ -5,20 +5,30 @@ import (
 	"fmt"
 	"time"
 
+	"linkko-api/internal/telemetry"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DefaultMaxConns é o tamanho do pool principal (interativo)
+const DefaultMaxConns = 25
+
 // NewPool creates a new PostgreSQL connection pool with retry logic
 func NewPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+	return NewPoolWithMaxConns(ctx, databaseURL, DefaultMaxConns)
+}
+
+// NewPoolWithMaxConns creates a pool with a custom size (ex.: pool da lane batch)
+func NewPoolWithMaxConns(ctx context.Context, databaseURL string, maxConns int32) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database URL: %w", err)
 	}
 
 	// Configure pool settings
-	config.MaxConns = 25
-	config.MinConns = 5
+	config.MaxConns = maxConns
+	config.MinConns = min(5, maxConns)
 	config.HealthCheckPeriod = 1 * time.Minute
 	config.MaxConnLifetime = 1 * time.Hour
 	config.MaxConnIdleTime = 30 * time.Minute
@@ -27,6 +37,9 @@ func NewPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
 	// Desabilita o cache de prepared statements que causa o erro SQLSTATE 42P05
 	config.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
 
+	// Span por query (db.operation/db.sql.table) dentro dos traces das requests
+	config.ConnConfig.Tracer = telemetry.NewQueryTracer()
+
 	// Create pool
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DefaultShard é o banco do DATABASE_URL: workspaces sem entrada no mapa de shards ficam nele,
+// assim como todo o plano de controle (usuários, membros, billing...).
+const DefaultShard = "default"
+
+// ErrUnknownShard shard da request sem banco configurado (DATABASE_SHARDS). As queries falham
+// em vez de cair no banco padrão: gravar fora do shard violaria a residência dos dados.
+var ErrUnknownShard = errors.New("database shard is not configured")
+
+type shardKey struct{}
+
+// WithShard stores the workspace shard in context
+func WithShard(ctx context.Context, shard string) context.Context {
+	return context.WithValue(ctx, shardKey{}, shard)
+}
+
+type probeKey struct{}
+
+// shardProbe shard encontrado por uma consulta em modo de sondagem (vazio = nenhum ainda)
+type shardProbe struct {
+	mu    sync.Mutex
+	shard string
+}
+
+// WithShardProbe marca a request como sem workspace conhecido (rotas públicas: formulários, links
+// curtos, orçamentos compartilhados). Até uma consulta encontrar o registro, cada QueryRow tenta
+// os shards em ordem (padrão primeiro); o shard onde a linha foi encontrada passa a valer para o
+// resto da request, inclusive escritas. Antes disso as demais operações vão para o padrão.
+func WithShardProbe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, probeKey{}, &shardProbe{})
+}
+
+// ShardFromContext returns the request shard (DefaultShard when unset)
+func ShardFromContext(ctx context.Context) string {
+	if shard, ok := ctx.Value(shardKey{}).(string); ok && shard != "" {
+		return shard
+	}
+	if probe, ok := ctx.Value(probeKey{}).(*shardProbe); ok {
+		probe.mu.Lock()
+		defer probe.mu.Unlock()
+		if probe.shard != "" {
+			return probe.shard
+		}
+	}
+	return DefaultShard
+}
+
+// probing retorna a sondagem pendente da request (nil sem sondagem ou com shard já encontrado)
+func probing(ctx context.Context) *shardProbe {
+	if shard, ok := ctx.Value(shardKey{}).(string); ok && shard != "" {
+		return nil
+	}
+	probe, ok := ctx.Value(probeKey{}).(*shardProbe)
+	if !ok {
+		return nil
+	}
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+	if probe.shard != "" {
+		return nil
+	}
+	return probe
+}
+
+// ShardRouter escolhe o banco conforme o shard do workspace da request (WithShard), para a
+// residência de dados por workspace (ex.: clientes que exigem armazenamento só na UE). Cada
+// shard tem o schema completo; os repositórios de dados do workspace recebem o router e os do
+// plano de controle continuam no banco padrão.
+type ShardRouter struct {
+	shards map[string]DB
+	names  []string // padrão primeiro, os demais em ordem alfabética
+}
+
+// NewShardRouter creates a ShardRouter. shards não inclui o padrão (DefaultShard = defaultDB).
+func NewShardRouter(defaultDB DB, shards map[string]DB) *ShardRouter {
+	all := make(map[string]DB, len(shards)+1)
+	names := make([]string, 0, len(shards))
+	for name, db := range shards {
+		all[name] = db
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	all[DefaultShard] = defaultDB
+	return &ShardRouter{shards: all, names: append([]string{DefaultShard}, names...)}
+}
+
+// Shard retorna o banco do shard.
+func (r *ShardRouter) Shard(name string) (DB, bool) {
+	db, ok := r.shards[name]
+	return db, ok
+}
+
+// Names lista os shards configurados: o padrão primeiro, os demais em ordem alfabética.
+func (r *ShardRouter) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+func (r *ShardRouter) pick(ctx context.Context) DB {
+	shard := ShardFromContext(ctx)
+	if db, ok := r.shards[shard]; ok {
+		return db
+	}
+	return unavailableDB{err: fmt.Errorf("%w: %q", ErrUnknownShard, shard)}
+}
+
+func (r *ShardRouter) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return r.pick(ctx).Exec(ctx, sql, args...)
+}
+
+func (r *ShardRouter) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return r.pick(ctx).Query(ctx, sql, args...)
+}
+
+func (r *ShardRouter) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if probe := probing(ctx); probe != nil && len(r.shards) > 1 {
+		return &probeRow{router: r, probe: probe, ctx: ctx, sql: sql, args: args}
+	}
+	return r.pick(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (r *ShardRouter) Begin(ctx context.Context) (pgx.Tx, error) {
+	return r.pick(ctx).Begin(ctx)
+}
+
+// probeRow executa a consulta em cada shard até um deles retornar a linha.
+type probeRow struct {
+	router *ShardRouter
+	probe  *shardProbe
+	ctx    context.Context
+	sql    string
+	args   []any
+}
+
+func (p *probeRow) Scan(dest ...any) error {
+	for _, name := range p.router.names {
+		err := p.router.shards[name].QueryRow(p.ctx, p.sql, p.args...).Scan(dest...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err == nil {
+			p.probe.mu.Lock()
+			p.probe.shard = name
+			p.probe.mu.Unlock()
+		}
+		return err
+	}
+	return pgx.ErrNoRows
+}
+
+// unavailableDB falha todas as operações com err.
+type unavailableDB struct {
+	err error
+}
+
+func (u unavailableDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, u.err
+}
+
+func (u unavailableDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, u.err
+}
+
+func (u unavailableDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	return errRow{err: u.err}
+}
+
+func (u unavailableDB) Begin(context.Context) (pgx.Tx, error) {
+	return nil, u.err
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
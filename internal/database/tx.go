@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type snapshotKey struct{}
+
+// readSnapshot transação aberta por ReadSnapshot e o TxManager dono dela
+type readSnapshot struct {
+	owner *TxManager
+	tx    pgx.Tx
+}
+
+// TxManager compartilha uma transação entre repositórios pelo ctx. Os repositórios recebem o
+// TxManager como DB: fora de ReadSnapshot as operações vão direto para o banco embrulhado; dentro,
+// para a transação do snapshot.
+type TxManager struct {
+	db DB
+}
+
+// NewTxManager creates a TxManager over db. Embrulhar um TxManager devolve o próprio.
+func NewTxManager(db DB) *TxManager {
+	if m, ok := db.(*TxManager); ok {
+		return m
+	}
+	return &TxManager{db: db}
+}
+
+// ReadSnapshot executa fn em uma transação REPEATABLE READ somente leitura: as consultas feitas
+// com o ctx recebido por fn, em qualquer repositório sobre este TxManager, enxergam o mesmo estado
+// do banco, então exports e relatórios que leem várias tabelas produzem totais coerentes entre si.
+// Chamadas aninhadas reaproveitam o snapshot aberto. A transação usa uma única conexão: fn deve
+// consultar em sequência (fechando cada Rows antes da próxima) e escritas falham. Receiver nil
+// executa fn sem snapshot.
+func (m *TxManager) ReadSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m == nil {
+		return fn(ctx)
+	}
+	if s, ok := ctx.Value(snapshotKey{}).(*readSnapshot); ok && s.owner == m {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin read snapshot: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`); err != nil {
+		return fmt.Errorf("set read snapshot isolation: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, snapshotKey{}, &readSnapshot{owner: m, tx: tx})); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (m *TxManager) conn(ctx context.Context) DB {
+	if s, ok := ctx.Value(snapshotKey{}).(*readSnapshot); ok && s.owner == m {
+		return s.tx
+	}
+	return m.db
+}
+
+func (m *TxManager) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return m.conn(ctx).Exec(ctx, sql, args...)
+}
+
+func (m *TxManager) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return m.conn(ctx).Query(ctx, sql, args...)
+}
+
+func (m *TxManager) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return m.conn(ctx).QueryRow(ctx, sql, args...)
+}
+
+// Begin dentro de um snapshot abre um savepoint na transação dele.
+func (m *TxManager) Begin(ctx context.Context) (pgx.Tx, error) {
+	return m.conn(ctx).Begin(ctx)
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDB registra, em ordem, por onde passou cada chamada ("db" ou "tx").
+type recordingDB struct {
+	calls *[]string
+	name  string
+	txs   int
+}
+
+func (d *recordingDB) record(call string) { *d.calls = append(*d.calls, d.name+":"+call) }
+
+func (d *recordingDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	d.record(sql)
+	return pgconn.NewCommandTag("SET"), nil
+}
+
+func (d *recordingDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	d.record(sql)
+	return nil, nil
+}
+
+func (d *recordingDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	d.record(sql)
+	return nil
+}
+
+func (d *recordingDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	d.record("BEGIN")
+	d.txs++
+	return &recordingTx{recordingDB: recordingDB{calls: d.calls, name: "tx"}}, nil
+}
+
+type recordingTx struct {
+	pgx.Tx
+	recordingDB
+}
+
+func (t *recordingTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return t.recordingDB.Exec(ctx, sql, args...)
+}
+
+func (t *recordingTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return t.recordingDB.Query(ctx, sql, args...)
+}
+
+func (t *recordingTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.recordingDB.QueryRow(ctx, sql, args...)
+}
+
+func (t *recordingTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	return t.recordingDB.Begin(ctx)
+}
+
+func (t *recordingTx) Commit(ctx context.Context) error {
+	t.record("COMMIT")
+	return nil
+}
+
+func (t *recordingTx) Rollback(ctx context.Context) error {
+	t.record("ROLLBACK")
+	return nil
+}
+
+func TestTxManager_ReadSnapshot(t *testing.T) {
+	var calls []string
+	db := &recordingDB{calls: &calls, name: "db"}
+	m := NewTxManager(db)
+	ctx := context.Background()
+
+	_, _ = m.Query(ctx, "before")
+	err := m.ReadSnapshot(ctx, func(ctx context.Context) error {
+		_, _ = m.Query(ctx, "first")
+		m.QueryRow(ctx, "second")
+		// Aninhado reaproveita o snapshot aberto
+		return m.ReadSnapshot(ctx, func(ctx context.Context) error {
+			_, err := m.Exec(ctx, "nested")
+			return err
+		})
+	})
+	require.NoError(t, err)
+	_, _ = m.Query(ctx, "after")
+
+	assert.Equal(t, []string{
+		"db:before",
+		"db:BEGIN",
+		"tx:SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY",
+		"tx:first",
+		"tx:second",
+		"tx:nested",
+		"tx:COMMIT",
+		"tx:ROLLBACK",
+		"db:after",
+	}, calls)
+	assert.Equal(t, 1, db.txs)
+}
+
+func TestTxManager_ReadSnapshot_Error(t *testing.T) {
+	var calls []string
+	m := NewTxManager(&recordingDB{calls: &calls, name: "db"})
+
+	boom := errors.New("boom")
+	err := m.ReadSnapshot(context.Background(), func(ctx context.Context) error { return boom })
+	assert.ErrorIs(t, err, boom)
+	assert.NotContains(t, calls, "tx:COMMIT")
+	assert.Contains(t, calls, "tx:ROLLBACK")
+}
+
+func TestTxManager_SnapshotIsPerManager(t *testing.T) {
+	var calls []string
+	first := NewTxManager(&recordingDB{calls: &calls, name: "db"})
+	second := NewTxManager(&recordingDB{calls: &calls, name: "other"})
+
+	err := first.ReadSnapshot(context.Background(), func(ctx context.Context) error {
+		// Outro TxManager não enxerga a transação do snapshot
+		_, err := second.Query(ctx, "elsewhere")
+		return err
+	})
+	require.NoError(t, err)
+	assert.Contains(t, calls, "other:elsewhere")
+}
+
+func TestNewTxManager(t *testing.T) {
+	m := NewTxManager(&recordingDB{calls: new([]string)})
+	assert.Same(t, m, NewTxManager(m))
+
+	// Receiver nil executa fn sem snapshot
+	var nilManager *TxManager
+	ran := false
+	require.NoError(t, nilManager.ReadSnapshot(context.Background(), func(ctx context.Context) error {
+		ran = true
+		return nil
+	}))
+	assert.True(t, ran)
+}
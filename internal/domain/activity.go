@@ -15,6 +15,14 @@ const (
 	ActivityTypeMeeting         ActivityType = "MEETING"
 	ActivityTypeMessage         ActivityType = "MESSAGE"
 	ActivityTypeLifecycleChange ActivityType = "LIFECYCLE_CHANGE"
+	ActivityTypeDealRotting     ActivityType = "DEAL_ROTTING"
+	ActivityTypeLinkClick       ActivityType = "LINK_CLICK"
+
+	// Engajamento de emails (ingerido do provedor de envio)
+	ActivityTypeEmailOpened       ActivityType = "EMAIL_OPENED"
+	ActivityTypeEmailClicked      ActivityType = "EMAIL_CLICKED"
+	ActivityTypeEmailBounced      ActivityType = "EMAIL_BOUNCED"
+	ActivityTypeEmailUnsubscribed ActivityType = "EMAIL_UNSUBSCRIBED"
 )
 
 // MessageDirection representa se a comunicação foi receptiva ou ativa.
@@ -37,6 +45,12 @@ type Activity struct {
 	UserID       string       `json:"userId"`
 	Metadata     []byte       `json:"metadata"`
 	CreatedAt    time.Time    `json:"createdAt"`
+
+	// Notas fixadas: momento em que a nota foi fixada no topo da timeline
+	PinnedAt *time.Time `json:"pinnedAt,omitempty"`
+
+	// Participantes de chamadas e reuniões
+	Participants []ActivityParticipant `json:"participants,omitempty"`
 }
 
 // Note representa uma anotação na timeline.
@@ -46,12 +60,23 @@ type Note struct {
 	CompanyID   *string    `json:"companyId"`
 	ContactID   *string    `json:"contactId"`
 	DealID      *string    `json:"dealId"`
-	Content     string     `json:"content"`
+	Content     string     `json:"content"` // texto puro derivado do body
 	IsPinned    bool       `json:"isPinned"`
 	UserID      string     `json:"userId"`
 	CreatedAt   time.Time  `json:"createdAt"`
 	UpdatedAt   time.Time  `json:"updatedAt"`
 	DeletedAt   *time.Time `json:"deletedAt"`
+
+	// Texto rico (JSON portátil) e fixação no topo da timeline
+	Body       *RichTextNode `json:"body"`
+	PinnedAt   *time.Time    `json:"pinnedAt"`
+	PinnedByID *string       `json:"pinnedById"`
+
+	// Quem enxerga a nota (PRIVATE, TEAM, WORKSPACE)
+	Visibility Visibility `json:"visibility"`
+
+	// Anexos (somente no GET da nota)
+	Attachments []NoteAttachment `json:"attachments,omitempty"`
 }
 
 // Call representa o registro de uma chamada telefônica.
@@ -67,26 +92,92 @@ type Call struct {
 	UserID       string           `json:"userId"`
 	CalledAt     time.Time        `json:"calledAt"`
 	CreatedAt    time.Time        `json:"createdAt"`
+
+	// Pessoas envolvidas (o autor entra como ORGANIZER)
+	Participants []ActivityParticipant `json:"participants"`
+}
+
+// MeetingType representa o formato da reunião.
+// Schema: public."MeetingType" ('CALL', 'VIDEO', 'IN_PERSON')
+type MeetingType string
+
+const (
+	MeetingTypeCall     MeetingType = "CALL"
+	MeetingTypeVideo    MeetingType = "VIDEO"
+	MeetingTypeInPerson MeetingType = "IN_PERSON"
+)
+
+// Meeting representa uma reunião registrada na timeline.
+type Meeting struct {
+	ID          string      `json:"id"`
+	WorkspaceID string      `json:"workspaceId"`
+	Title       string      `json:"title"`
+	Description *string     `json:"description"`
+	MeetingType MeetingType `json:"meetingType"`
+	StartTime   time.Time   `json:"startTime"`
+	EndTime     time.Time   `json:"endTime"`
+	Location    *string     `json:"location"`
+	MeetingURL  *string     `json:"meetingUrl"`
+	UserID      string      `json:"userId"`
+	CreatedAt   time.Time   `json:"createdAt"`
+
+	// Pessoas envolvidas (o autor entra como ORGANIZER)
+	Participants []ActivityParticipant `json:"participants"`
 }
 
 // CreateNoteRequest DTO para criação de Notas.
+// Body (texto rico) tem precedência; Content (texto puro) segue aceito por clientes antigos.
 type CreateNoteRequest struct {
-	Content   string  `json:"content" validate:"required"`
-	CompanyID *string `json:"companyId"`
+	Content   string        `json:"content" validate:"required_without=Body"`
+	Body      *RichTextNode `json:"body"`
+	CompanyID *string       `json:"companyId"`
 	ContactID *string `json:"contactId"`
 	DealID    *string `json:"dealId"`
+
+	// Visibilidade (padrão: WORKSPACE)
+	Visibility *Visibility `json:"visibility" validate:"omitempty,oneof=PRIVATE TEAM WORKSPACE"`
 }
 
 // CreateCallRequest DTO para registro de Chamadas.
 type CreateCallRequest struct {
-	ContactID    string           `json:"contactId" validate:"required"`
+	ContactID    string           `json:"contactId" validate:"required,id"`
 	CompanyID    *string          `json:"companyId"`
 	Direction    MessageDirection `json:"direction" validate:"required"`
 	Duration     *int32           `json:"duration"`
 	RecordingURL *string          `json:"recordingUrl"`
 	Summary      *string          `json:"summary"`
 	CalledAt     time.Time        `json:"calledAt"`
+
+	// Participantes além do autor (usuários do workspace e/ou contatos)
+	Participants []ActivityParticipantInput `json:"participants" validate:"omitempty,max=50,dive"`
+}
+
+// Validate valida o request.
+func (r *CreateCallRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// CreateMeetingRequest DTO para registro de Reuniões.
+// ContactID, CompanyID e DealID vinculam a entrada da timeline.
+type CreateMeetingRequest struct {
+	Title       string      `json:"title" validate:"required,max=255"`
+	Description *string     `json:"description" validate:"omitempty,max=5000"`
+	MeetingType MeetingType `json:"meetingType" validate:"required,oneof=CALL VIDEO IN_PERSON"`
+	StartTime   time.Time   `json:"startTime" validate:"required"`
+	EndTime     time.Time   `json:"endTime" validate:"required,gtefield=StartTime"`
+	Location    *string     `json:"location" validate:"omitempty,max=500"`
+	MeetingURL  *string     `json:"meetingUrl" validate:"omitempty,url"`
+	ContactID   *string     `json:"contactId" validate:"omitempty,id"`
+	CompanyID   *string     `json:"companyId" validate:"omitempty,id"`
+	DealID      *string     `json:"dealId" validate:"omitempty,id"`
+
+	// Participantes além do autor (usuários do workspace e/ou contatos)
+	Participants []ActivityParticipantInput `json:"participants" validate:"omitempty,max=50,dive"`
+}
+
+// Validate valida o request.
+func (r *CreateMeetingRequest) Validate() error {
+	return validate.Struct(r)
 }
 
-// Outros tipos como Meeting e Message podem ser expandidos conforme necessário.
-// Por agora, focamos nos principais solicitados.
+// Message pode ser expandido conforme necessário.
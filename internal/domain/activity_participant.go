@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// ActivityParticipantRole papel de uma pessoa em uma chamada ou reunião.
+// Schema: "ActivityParticipant"."role" ('ORGANIZER', 'ATTENDEE', 'OPTIONAL')
+type ActivityParticipantRole string
+
+const (
+	ActivityParticipantOrganizer ActivityParticipantRole = "ORGANIZER" // Conduziu a chamada/reunião
+	ActivityParticipantAttendee  ActivityParticipantRole = "ATTENDEE"
+	ActivityParticipantOptional  ActivityParticipantRole = "OPTIONAL" // Convidado opcional
+)
+
+// ActivityParticipant usuário ou contato envolvido em uma chamada ou reunião.
+// Exatamente um de UserID e ContactID é preenchido. ActivityID é o ID da chamada/reunião
+// (o mesmo "activityId" da entrada da timeline).
+type ActivityParticipant struct {
+	ID           string                  `json:"id"`
+	ActivityType ActivityType            `json:"activityType"`
+	ActivityID   string                  `json:"activityId"`
+	UserID       *string                 `json:"userId,omitempty"`
+	ContactID    *string                 `json:"contactId,omitempty"`
+	Role         ActivityParticipantRole `json:"role"`
+	CreatedAt    time.Time               `json:"createdAt"`
+}
+
+// ActivityParticipantInput participante informado na criação de chamadas e reuniões.
+type ActivityParticipantInput struct {
+	UserID    *string                 `json:"userId,omitempty" validate:"required_without=ContactID,excluded_with=ContactID,omitempty,id"`
+	ContactID *string                 `json:"contactId,omitempty" validate:"required_without=UserID,omitempty,id"`
+	Role      ActivityParticipantRole `json:"role,omitempty" validate:"omitempty,oneof=ORGANIZER ATTENDEE OPTIONAL"`
+}
+
+// NormalizeActivityParticipants aplica o papel padrão (ATTENDEE), remove pessoas repetidas
+// (vale a primeira ocorrência) e inclui o autor como ORGANIZER quando ele não foi informado.
+// Deve ser chamado depois da validação.
+func NormalizeActivityParticipants(in []ActivityParticipantInput, actorID string) []ActivityParticipantInput {
+	out := make([]ActivityParticipantInput, 0, len(in)+1)
+	seen := make(map[string]bool, len(in)+1)
+	for _, p := range in {
+		key := participantKey(p)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if p.Role == "" {
+			p.Role = ActivityParticipantAttendee
+		}
+		out = append(out, p)
+	}
+
+	if actorID != "" && !seen["user:"+actorID] {
+		author := actorID
+		out = append([]ActivityParticipantInput{{UserID: &author, Role: ActivityParticipantOrganizer}}, out...)
+	}
+	return out
+}
+
+func participantKey(p ActivityParticipantInput) string {
+	if p.UserID != nil {
+		return "user:" + *p.UserID
+	}
+	return "contact:" + *p.ContactID
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeActivityParticipants(t *testing.T) {
+	user := func(id string, role ActivityParticipantRole) ActivityParticipantInput {
+		return ActivityParticipantInput{UserID: &id, Role: role}
+	}
+	contact := func(id string, role ActivityParticipantRole) ActivityParticipantInput {
+		return ActivityParticipantInput{ContactID: &id, Role: role}
+	}
+
+	tests := []struct {
+		name string
+		in   []ActivityParticipantInput
+		want []ActivityParticipantInput
+	}{
+		{
+			name: "author only",
+			in:   nil,
+			want: []ActivityParticipantInput{user("usr_author", ActivityParticipantOrganizer)},
+		},
+		{
+			name: "author first and default role",
+			in:   []ActivityParticipantInput{contact("cnt_1", ""), user("usr_2", ActivityParticipantOptional)},
+			want: []ActivityParticipantInput{
+				user("usr_author", ActivityParticipantOrganizer),
+				contact("cnt_1", ActivityParticipantAttendee),
+				user("usr_2", ActivityParticipantOptional),
+			},
+		},
+		{
+			name: "author already listed keeps the given role",
+			in:   []ActivityParticipantInput{contact("cnt_1", ""), user("usr_author", ActivityParticipantAttendee)},
+			want: []ActivityParticipantInput{
+				contact("cnt_1", ActivityParticipantAttendee),
+				user("usr_author", ActivityParticipantAttendee),
+			},
+		},
+		{
+			// Vale a primeira ocorrência; usuário e contato com o mesmo ID são pessoas distintas
+			name: "duplicates are dropped",
+			in: []ActivityParticipantInput{
+				contact("cnt_1", ActivityParticipantOptional),
+				contact("cnt_1", ActivityParticipantAttendee),
+				user("cnt_1", ""),
+			},
+			want: []ActivityParticipantInput{
+				user("usr_author", ActivityParticipantOrganizer),
+				contact("cnt_1", ActivityParticipantOptional),
+				user("cnt_1", ActivityParticipantAttendee),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeActivityParticipants(tt.in, "usr_author"))
+		})
+	}
+}
+
+func TestNormalizeActivityParticipants_NoActor(t *testing.T) {
+	contactID := "cnt_1"
+	got := NormalizeActivityParticipants([]ActivityParticipantInput{{ContactID: &contactID}}, "")
+	assert.Equal(t, []ActivityParticipantInput{{ContactID: &contactID, Role: ActivityParticipantAttendee}}, got)
+}
+
+func TestCreateMeetingRequest_Validate(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	valid := func() *CreateMeetingRequest {
+		return &CreateMeetingRequest{
+			Title:       "Kickoff",
+			MeetingType: MeetingTypeVideo,
+			StartTime:   start,
+			EndTime:     start.Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*CreateMeetingRequest)
+		wantErr bool
+	}{
+		{"valid", func(r *CreateMeetingRequest) {}, false},
+		{"ends before it starts", func(r *CreateMeetingRequest) { r.EndTime = start.Add(-time.Minute) }, true},
+		{"unknown meeting type", func(r *CreateMeetingRequest) { r.MeetingType = "PHONE" }, true},
+		{"user participant", func(r *CreateMeetingRequest) {
+			r.Participants = []ActivityParticipantInput{{UserID: strPtr("usr_1")}}
+		}, false},
+		{"participant without user or contact", func(r *CreateMeetingRequest) {
+			r.Participants = []ActivityParticipantInput{{Role: ActivityParticipantAttendee}}
+		}, true},
+		{"participant with user and contact", func(r *CreateMeetingRequest) {
+			r.Participants = []ActivityParticipantInput{{UserID: strPtr("usr_1"), ContactID: strPtr("cnt_1")}}
+		}, true},
+		{"unknown participant role", func(r *CreateMeetingRequest) {
+			r.Participants = []ActivityParticipantInput{{ContactID: strPtr("cnt_1"), Role: "HOST"}}
+		}, true},
+		{"malformed participant id", func(r *CreateMeetingRequest) {
+			r.Participants = []ActivityParticipantInput{{ContactID: strPtr("cnt 1")}}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(req)
+			if tt.wantErr {
+				assert.Error(t, req.Validate())
+			} else {
+				assert.NoError(t, req.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// SourceManual é a origem padrão de registros criados por usuários (JWT).
+// Requests S2S sem source explícito usam o nome do cliente (ex.: "crm-web", "mcp").
+const SourceManual = "manual"
+
+// Attribution registra de onde veio um contato ou negócio (canal + parâmetros UTM).
+// Capturada na criação; embutida em Contact, Deal e nos DTOs de criação.
+type Attribution struct {
+	Source       *string `json:"source,omitempty" validate:"omitempty,max=100"`
+	SourceDetail *string `json:"sourceDetail,omitempty" validate:"omitempty,max=255"`
+	UTMSource    *string `json:"utmSource,omitempty" validate:"omitempty,max=255"`
+	UTMMedium    *string `json:"utmMedium,omitempty" validate:"omitempty,max=255"`
+	UTMCampaign  *string `json:"utmCampaign,omitempty" validate:"omitempty,max=255"`
+	UTMTerm      *string `json:"utmTerm,omitempty" validate:"omitempty,max=255"`
+	UTMContent   *string `json:"utmContent,omitempty" validate:"omitempty,max=255"`
+}
+
+// DefaultSource preenche Source quando o cliente não informou (ou enviou vazio).
+func (a *Attribution) DefaultSource(source string) {
+	if a.Source == nil || *a.Source == "" {
+		a.Source = &source
+	}
+}
+
+// AttributionFilter filtra listagens por atribuição (nil = sem filtro).
+type AttributionFilter struct {
+	Source      *string
+	UTMSource   *string
+	UTMMedium   *string
+	UTMCampaign *string
+}
+
+// AttributionGroupBy é a dimensão de agrupamento do relatório de atribuição.
+type AttributionGroupBy string
+
+const (
+	AttributionBySource      AttributionGroupBy = "source"
+	AttributionByUTMSource   AttributionGroupBy = "utmSource"
+	AttributionByUTMMedium   AttributionGroupBy = "utmMedium"
+	AttributionByUTMCampaign AttributionGroupBy = "utmCampaign"
+)
+
+// IsValid valida se a dimensão de agrupamento é suportada.
+func (g AttributionGroupBy) IsValid() bool {
+	switch g {
+	case AttributionBySource, AttributionByUTMSource, AttributionByUTMMedium, AttributionByUTMCampaign:
+		return true
+	}
+	return false
+}
+
+// AttributionReportParams parâmetros do relatório de atribuição.
+// From/To filtram pela data de criação dos contatos e negócios ([From, To)).
+type AttributionReportParams struct {
+	WorkspaceID string
+	GroupBy     AttributionGroupBy
+	From        *time.Time
+	To          *time.Time
+}
+
+// AttributionRow agrega contatos e negócios de um canal.
+// Key nil agrupa registros sem atribuição na dimensão escolhida.
+// Valores somam deal.value sem conversão de moeda.
+type AttributionRow struct {
+	Key           *string `json:"key"`
+	Contacts      int64   `json:"contacts"`
+	Deals         int64   `json:"deals"`
+	WonDeals      int64   `json:"wonDeals"`
+	PipelineValue float64 `json:"pipelineValue"` // soma de negócios OPEN
+	WonValue      float64 `json:"wonValue"`      // soma de negócios WON
+}
+
+// AttributionReport resposta de /reports/attribution.
+type AttributionReport struct {
+	GroupBy AttributionGroupBy `json:"groupBy"`
+	Rows    []AttributionRow   `json:"rows"`
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BillingPlan plano contratado pelo workspace (define as quotas).
+type BillingPlan string
+
+const (
+	PlanFree       BillingPlan = "FREE"
+	PlanStarter    BillingPlan = "STARTER"
+	PlanPro        BillingPlan = "PRO"
+	PlanEnterprise BillingPlan = "ENTERPRISE"
+)
+
+// IsValid reporta se o plano é conhecido
+func (p BillingPlan) IsValid() bool {
+	_, ok := PlanQuotas[p]
+	return ok
+}
+
+// SubscriptionStatus status da assinatura do workspace, espelhando o do Stripe.
+// NONE = workspace nunca assinou (plano FREE).
+type SubscriptionStatus string
+
+const (
+	SubscriptionNone       SubscriptionStatus = "NONE"
+	SubscriptionTrialing   SubscriptionStatus = "TRIALING"
+	SubscriptionActive     SubscriptionStatus = "ACTIVE"
+	SubscriptionPastDue    SubscriptionStatus = "PAST_DUE"
+	SubscriptionUnpaid     SubscriptionStatus = "UNPAID"
+	SubscriptionIncomplete SubscriptionStatus = "INCOMPLETE"
+	SubscriptionPaused     SubscriptionStatus = "PAUSED"
+	SubscriptionCanceled   SubscriptionStatus = "CANCELED"
+)
+
+// SubscriptionStatusFromStripe converte o status da subscription do Stripe.
+// incomplete_expired é terminal e conta como cancelada.
+func SubscriptionStatusFromStripe(status string) SubscriptionStatus {
+	switch status {
+	case "trialing":
+		return SubscriptionTrialing
+	case "active":
+		return SubscriptionActive
+	case "past_due":
+		return SubscriptionPastDue
+	case "unpaid":
+		return SubscriptionUnpaid
+	case "incomplete":
+		return SubscriptionIncomplete
+	case "paused":
+		return SubscriptionPaused
+	default:
+		return SubscriptionCanceled
+	}
+}
+
+// Entitled reporta se a assinatura dá direito ao plano pago. PAST_DUE mantém o plano
+// durante as retentativas de cobrança do Stripe.
+func (s SubscriptionStatus) Entitled() bool {
+	switch s {
+	case SubscriptionTrialing, SubscriptionActive, SubscriptionPastDue:
+		return true
+	default:
+		return false
+	}
+}
+
+// QuotaResource recurso com limite de registros por plano.
+type QuotaResource string
+
+const (
+	QuotaContacts QuotaResource = "contacts"
+	QuotaDeals    QuotaResource = "deals"
+	QuotaMembers  QuotaResource = "members"
+)
+
+// PlanQuota limites do plano. Limites 0 = ilimitado; RequestsPerMinute 0 = limite global
+// (RATE_LIMIT_PER_WORKSPACE_PER_MIN).
+type PlanQuota struct {
+	RequestsPerMinute int   `json:"requestsPerMinute"`
+	MaxContacts       int64 `json:"maxContacts"`
+	MaxDeals          int64 `json:"maxDeals"`
+	MaxMembers        int64 `json:"maxMembers"`
+}
+
+// Limit retorna o limite do recurso (0 = ilimitado)
+func (q PlanQuota) Limit(resource QuotaResource) int64 {
+	switch resource {
+	case QuotaContacts:
+		return q.MaxContacts
+	case QuotaDeals:
+		return q.MaxDeals
+	case QuotaMembers:
+		return q.MaxMembers
+	default:
+		return 0
+	}
+}
+
+// PlanQuotas mapeamento plano → quotas consumido pelo QuotaMiddleware e pelo GET /billing.
+var PlanQuotas = map[BillingPlan]PlanQuota{
+	PlanFree:       {RequestsPerMinute: 0, MaxContacts: 1000, MaxDeals: 250, MaxMembers: 3},
+	PlanStarter:    {RequestsPerMinute: 300, MaxContacts: 10000, MaxDeals: 5000, MaxMembers: 10},
+	PlanPro:        {RequestsPerMinute: 600, MaxContacts: 100000, MaxDeals: 50000, MaxMembers: 50},
+	PlanEnterprise: {RequestsPerMinute: 1200},
+}
+
+// WorkspaceSubscription estado da assinatura do workspace, mantido pelos webhooks do Stripe.
+type WorkspaceSubscription struct {
+	WorkspaceID          string
+	Plan                 BillingPlan
+	Status               SubscriptionStatus
+	StripeCustomerID     *string
+	StripeSubscriptionID *string
+	StripePriceID        *string
+	CurrentPeriodEnd     *time.Time
+	CancelAtPeriodEnd    bool
+	UpdatedAt            *time.Time // createdAt do último evento aplicado
+}
+
+// EffectivePlan plano que vale para as quotas: sem assinatura em dia, FREE.
+func (s *WorkspaceSubscription) EffectivePlan() BillingPlan {
+	if s.Plan == PlanFree || !s.Status.Entitled() || !s.Plan.IsValid() {
+		return PlanFree
+	}
+	return s.Plan
+}
+
+// Quota retorna as quotas do plano efetivo
+func (s *WorkspaceSubscription) Quota() PlanQuota {
+	return PlanQuotas[s.EffectivePlan()]
+}
+
+// BillingUsage consumo atual dos recursos com quota.
+type BillingUsage struct {
+	Contacts int64 `json:"contacts"`
+	Deals    int64 `json:"deals"`
+	Members  int64 `json:"members"`
+}
+
+// WorkspaceBilling resposta de GET /billing: plano, assinatura, quotas e consumo.
+// Plan é o contratado; EffectivePlan é o que vale para as quotas (FREE se a assinatura
+// não está em dia).
+type WorkspaceBilling struct {
+	WorkspaceID       string             `json:"workspaceId"`
+	Plan              BillingPlan        `json:"plan"`
+	EffectivePlan     BillingPlan        `json:"effectivePlan"`
+	Status            SubscriptionStatus `json:"status"`
+	CurrentPeriodEnd  *time.Time         `json:"currentPeriodEnd,omitempty"`
+	CancelAtPeriodEnd bool               `json:"cancelAtPeriodEnd"`
+	Quota             PlanQuota          `json:"quota"`
+	Usage             BillingUsage       `json:"usage"`
+}
+
+// ParseStripePricePlans lê o mapeamento price → plano ("price_abc=STARTER,price_def=PRO").
+func ParseStripePricePlans(s string) (map[string]BillingPlan, error) {
+	plans := make(map[string]BillingPlan)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		priceID, plan, ok := strings.Cut(entry, "=")
+		priceID = strings.TrimSpace(priceID)
+		p := BillingPlan(strings.ToUpper(strings.TrimSpace(plan)))
+		if !ok || priceID == "" || !p.IsValid() {
+			return nil, fmt.Errorf("invalid price mapping %q (expected price_id=FREE|STARTER|PRO|ENTERPRISE)", entry)
+		}
+		plans[priceID] = p
+	}
+	return plans, nil
+}
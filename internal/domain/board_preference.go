@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// BoardPreference personalização do board de negócios de um usuário em um pipeline
+// (UserBoardPreference). StageOrder traz sempre todas as etapas do pipeline: as que o
+// usuário não ordenou entram no fim, em orderIndex.
+type BoardPreference struct {
+	PipelineID        string     `json:"pipelineId"`
+	StageOrder        []string   `json:"stageOrder"`
+	CollapsedStageIDs []string   `json:"collapsedStageIds"`
+	HiddenStageIDs    []string   `json:"hiddenStageIds"`
+	UpdatedAt         *time.Time `json:"updatedAt"` // nil enquanto o usuário usa o padrão
+}
+
+// UpdateBoardPreferenceRequest DTO de PUT /deals/board/preferences (substitui as preferências
+// do pipeline). StageOrder pode ser parcial; etapas de outro pipeline são rejeitadas.
+type UpdateBoardPreferenceRequest struct {
+	PipelineID        string   `json:"pipelineId" validate:"required,id"`
+	StageOrder        []string `json:"stageOrder" validate:"max=100,dive,required,id"`
+	CollapsedStageIDs []string `json:"collapsedStageIds" validate:"max=100,dive,required,id"`
+	HiddenStageIDs    []string `json:"hiddenStageIds" validate:"max=100,dive,required,id"`
+}
+
+// Validate valida o UpdateBoardPreferenceRequest.
+func (r *UpdateBoardPreferenceRequest) Validate() error {
+	r.PipelineID = strings.TrimSpace(r.PipelineID)
+
+	return validate.Struct(r)
+}
+
+// Normalize alinha as preferências às etapas atuais do pipeline (em orderIndex): descarta
+// IDs de etapas que não existem mais e duplicados, e completa StageOrder com as etapas
+// que faltam.
+func (p *BoardPreference) Normalize(stages []PipelineStage) {
+	known := make(map[string]bool, len(stages))
+	for _, s := range stages {
+		known[s.ID] = true
+	}
+	keep := func(ids []string) []string {
+		seen := make(map[string]bool, len(ids))
+		out := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if known[id] && !seen[id] {
+				seen[id] = true
+				out = append(out, id)
+			}
+		}
+		return out
+	}
+
+	p.StageOrder = keep(p.StageOrder)
+	p.CollapsedStageIDs = keep(p.CollapsedStageIDs)
+	p.HiddenStageIDs = keep(p.HiddenStageIDs)
+
+	ordered := make(map[string]bool, len(p.StageOrder))
+	for _, id := range p.StageOrder {
+		ordered[id] = true
+	}
+	for _, s := range stages {
+		if !ordered[s.ID] {
+			p.StageOrder = append(p.StageOrder, s.ID)
+		}
+	}
+}
+
+// DealBoardParams parâmetros de GET /deals/board.
+type DealBoardParams struct {
+	PipelineID string
+	Limit      int // máximo de negócios por coluna
+}
+
+// Normalize aplica defaults (Limit por coluna: 20, máximo 100).
+func (p *DealBoardParams) Normalize() {
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 20
+	}
+}
+
+// DealBoardColumn coluna (etapa) do board. Count e Value cobrem todos os negócios da etapa;
+// colunas recolhidas trazem só os totais (Deals vazio).
+type DealBoardColumn struct {
+	Stage     PipelineStage `json:"stage"`
+	Collapsed bool          `json:"collapsed"`
+	Count     int           `json:"count"`
+	Value     float64       `json:"value"`
+	HasMore   bool          `json:"hasMore"`
+	Deals     []Deal        `json:"deals"`
+}
+
+// DealBoard resposta de GET /deals/board: colunas na ordem do usuário, sem as ocultas.
+type DealBoard struct {
+	PipelineID  string            `json:"pipelineId"`
+	Preferences BoardPreference   `json:"preferences"`
+	Columns     []DealBoardColumn `json:"columns"`
+}
+
+// NewDealBoard monta o board a partir das etapas do pipeline, das preferências já normalizadas
+// e dos negócios do pipeline (na ordem da listagem).
+func NewDealBoard(pipelineID string, stages []PipelineStage, prefs BoardPreference, deals []Deal, limit int) *DealBoard {
+	board := &DealBoard{PipelineID: pipelineID, Preferences: prefs, Columns: []DealBoardColumn{}}
+
+	hidden := make(map[string]bool, len(prefs.HiddenStageIDs))
+	for _, id := range prefs.HiddenStageIDs {
+		hidden[id] = true
+	}
+	collapsed := make(map[string]bool, len(prefs.CollapsedStageIDs))
+	for _, id := range prefs.CollapsedStageIDs {
+		collapsed[id] = true
+	}
+	byID := make(map[string]PipelineStage, len(stages))
+	for _, s := range stages {
+		byID[s.ID] = s
+	}
+
+	index := make(map[string]int, len(prefs.StageOrder))
+	for _, id := range prefs.StageOrder {
+		stage, ok := byID[id]
+		if !ok || hidden[id] {
+			continue
+		}
+		index[id] = len(board.Columns)
+		board.Columns = append(board.Columns, DealBoardColumn{Stage: stage, Collapsed: collapsed[id], Deals: []Deal{}})
+	}
+
+	for _, d := range deals {
+		if d.StageID == nil {
+			continue
+		}
+		i, ok := index[*d.StageID]
+		if !ok {
+			continue
+		}
+		column := &board.Columns[i]
+		column.Count++
+		if d.Value != nil {
+			column.Value += *d.Value
+		}
+		switch {
+		case column.Collapsed:
+		case len(column.Deals) < limit:
+			column.Deals = append(column.Deals, d)
+		default:
+			column.HasMore = true
+		}
+	}
+	return board
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func boardStages(ids ...string) []PipelineStage {
+	stages := make([]PipelineStage, len(ids))
+	for i, id := range ids {
+		stages[i] = PipelineStage{ID: id, Name: "Etapa " + id, OrderIndex: i + 1}
+	}
+	return stages
+}
+
+func TestBoardPreference_Normalize(t *testing.T) {
+	stages := boardStages("stg_a", "stg_b", "stg_c", "stg_d")
+
+	tests := []struct {
+		name string
+		in   BoardPreference
+		want BoardPreference
+	}{
+		{
+			name: "defaults to pipeline order",
+			in:   BoardPreference{},
+			want: BoardPreference{
+				StageOrder:        []string{"stg_a", "stg_b", "stg_c", "stg_d"},
+				CollapsedStageIDs: []string{},
+				HiddenStageIDs:    []string{},
+			},
+		},
+		{
+			name: "partial order is completed in orderIndex",
+			in:   BoardPreference{StageOrder: []string{"stg_c", "stg_a"}},
+			want: BoardPreference{
+				StageOrder:        []string{"stg_c", "stg_a", "stg_b", "stg_d"},
+				CollapsedStageIDs: []string{},
+				HiddenStageIDs:    []string{},
+			},
+		},
+		{
+			name: "removed stages and duplicates are dropped",
+			in: BoardPreference{
+				StageOrder:        []string{"stg_d", "stg_gone", "stg_d", "stg_b"},
+				CollapsedStageIDs: []string{"stg_gone", "stg_b", "stg_b"},
+				HiddenStageIDs:    []string{"stg_c", "stg_gone"},
+			},
+			want: BoardPreference{
+				StageOrder:        []string{"stg_d", "stg_b", "stg_a", "stg_c"},
+				CollapsedStageIDs: []string{"stg_b"},
+				HiddenStageIDs:    []string{"stg_c"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.in
+			p.Normalize(stages)
+			assert.Equal(t, tt.want, p)
+		})
+	}
+}
+
+func TestDealBoardParams_Normalize(t *testing.T) {
+	for _, tt := range []struct{ in, want int }{{0, 20}, {-1, 20}, {101, 20}, {1, 1}, {100, 100}} {
+		p := DealBoardParams{Limit: tt.in}
+		p.Normalize()
+		assert.Equal(t, tt.want, p.Limit, tt.in)
+	}
+}
+
+func TestNewDealBoard(t *testing.T) {
+	stages := boardStages("stg_a", "stg_b", "stg_c", "stg_d")
+	prefs := BoardPreference{
+		StageOrder:        []string{"stg_c", "stg_a"},
+		CollapsedStageIDs: []string{"stg_b"},
+		HiddenStageIDs:    []string{"stg_d"},
+	}
+	prefs.Normalize(stages)
+
+	deal := func(id, stageID string, value float64) Deal {
+		return Deal{ID: id, StageID: &stageID, Value: &value}
+	}
+	deals := []Deal{
+		deal("deal_1", "stg_a", 100),
+		deal("deal_2", "stg_a", 200),
+		deal("deal_3", "stg_a", 300),
+		deal("deal_4", "stg_b", 50),
+		deal("deal_5", "stg_d", 999),
+		{ID: "deal_6"}, // sem etapa
+		deal("deal_7", "stg_gone", 1),
+	}
+
+	board := NewDealBoard("pip_1", stages, prefs, deals, 2)
+	assert.Equal(t, "pip_1", board.PipelineID)
+	assert.Equal(t, prefs, board.Preferences)
+
+	type column struct {
+		stage     string
+		collapsed bool
+		count     int
+		value     float64
+		hasMore   bool
+		deals     []string
+	}
+	got := make([]column, len(board.Columns))
+	for i, c := range board.Columns {
+		ids := []string{}
+		for _, d := range c.Deals {
+			ids = append(ids, d.ID)
+		}
+		got[i] = column{c.Stage.ID, c.Collapsed, c.Count, c.Value, c.HasMore, ids}
+	}
+
+	// Ordem do usuário, sem a etapa oculta; recolhida só com os totais
+	assert.Equal(t, []column{
+		{"stg_c", false, 0, 0, false, []string{}},
+		{"stg_a", false, 3, 600, true, []string{"deal_1", "deal_2"}},
+		{"stg_b", true, 1, 50, false, []string{}},
+	}, got)
+}
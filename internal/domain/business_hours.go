@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BusinessInterval é uma faixa de expediente em um dia da semana, no fuso do workspace.
+// Start/End usam HH:MM; End "24:00" representa o fim do dia.
+type BusinessInterval struct {
+	Weekday int    `json:"weekday" validate:"gte=0,lte=6"` // 0 = domingo
+	Start   string `json:"start" validate:"required,len=5"`
+	End     string `json:"end" validate:"required,len=5"`
+}
+
+// BusinessHours é o expediente do workspace consumido por timers de SLA, esperas de
+// sequências e detecção de deals parados. Sem configuração os timers correm 24x7.
+type BusinessHours struct {
+	WorkspaceID string             `json:"workspaceId"`
+	Timezone    string             `json:"timezone"`
+	Intervals   []BusinessInterval `json:"intervals"`
+	UpdatedByID *string            `json:"updatedById"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+// SetBusinessHoursRequest DTO para definir (substituir) o expediente do workspace.
+type SetBusinessHoursRequest struct {
+	Timezone  string             `json:"timezone" validate:"required,max=64"`
+	Intervals []BusinessInterval `json:"intervals" validate:"required,min=1,max=50,dive"`
+}
+
+// Validate sanitiza e valida o request: fuso IANA, horários HH:MM com início antes do fim e
+// faixas sem sobreposição no mesmo dia.
+func (r *SetBusinessHoursRequest) Validate() error {
+	r.Timezone = strings.TrimSpace(r.Timezone)
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return fmt.Errorf("timezone %q is not a valid IANA time zone", r.Timezone)
+	}
+
+	ranges, err := buildWeek(r.Intervals)
+	if err != nil {
+		return err
+	}
+	for wd, day := range ranges {
+		for i := 1; i < len(day); i++ {
+			if day[i].start < day[i-1].end {
+				return fmt.Errorf("intervals overlap on weekday %d", wd)
+			}
+		}
+	}
+	return nil
+}
+
+// Holiday é um dia sem expediente no workspace (data no fuso do expediente).
+type Holiday struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspaceId"`
+	Date        string    `json:"date"` // YYYY-MM-DD
+	Name        string    `json:"name"`
+	CreatedByID string    `json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// HolidayListResponse resposta da listagem (sem paginação: filtrada por ano).
+type HolidayListResponse struct {
+	Data []Holiday `json:"data"`
+}
+
+// CreateHolidayRequest DTO para criação de feriado.
+type CreateHolidayRequest struct {
+	Date string `json:"date" validate:"required,datetime=2006-01-02"`
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateHolidayRequest) Validate() error {
+	r.Date = strings.TrimSpace(r.Date)
+	r.Name = strings.TrimSpace(r.Name)
+	return validate.Struct(r)
+}
+
+// UpdateHolidayRequest DTO para atualização parcial (nil = não modificar).
+type UpdateHolidayRequest struct {
+	Date *string `json:"date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Name *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateHolidayRequest) Validate() error {
+	if r.Date != nil {
+		trimmed := strings.TrimSpace(*r.Date)
+		r.Date = &trimmed
+	}
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+	return validate.Struct(r)
+}
+
+// ListHolidaysParams filtros da listagem de feriados.
+type ListHolidaysParams struct {
+	WorkspaceID string
+	Year        *int
+}
+
+// maxCalendarScanDays limita a varredura de dias úteis (calendários patológicos, ex.: todos
+// os dias do ano cadastrados como feriado, não podem travar o cálculo).
+const maxCalendarScanDays = 3 * 366
+
+// clockRange é uma faixa de expediente em minutos desde a meia-noite.
+type clockRange struct {
+	start, end int
+}
+
+// BusinessCalendar calcula prazos contando apenas o expediente do workspace.
+// Um calendário nil corre 24x7 sem feriados.
+type BusinessCalendar struct {
+	loc      *time.Location
+	week     [7][]clockRange
+	holidays map[string]bool
+}
+
+// NewBusinessCalendar monta o calendário a partir do expediente e dos feriados.
+// Sem expediente configurado os dias são integrais (apenas feriados pausam os timers);
+// sem nenhum dos dois retorna nil.
+func NewBusinessCalendar(hours *BusinessHours, holidays []Holiday) (*BusinessCalendar, error) {
+	if hours == nil && len(holidays) == 0 {
+		return nil, nil
+	}
+
+	c := &BusinessCalendar{loc: time.UTC, holidays: make(map[string]bool, len(holidays))}
+	if hours != nil {
+		loc, err := time.LoadLocation(hours.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("load business hours timezone: %w", err)
+		}
+		week, err := buildWeek(hours.Intervals)
+		if err != nil {
+			return nil, err
+		}
+		c.loc = loc
+		c.week = week
+	} else {
+		for wd := range c.week {
+			c.week[wd] = []clockRange{{start: 0, end: 24 * 60}}
+		}
+	}
+	for _, h := range holidays {
+		c.holidays[h.Date] = true
+	}
+	return c, nil
+}
+
+// IsWorkingDay indica se o dia de t tem expediente e não é feriado.
+func (c *BusinessCalendar) IsWorkingDay(t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	local := t.In(c.loc)
+	return len(c.week[local.Weekday()]) > 0 && !c.holidays[local.Format("2006-01-02")]
+}
+
+// NextWorkingTime retorna t se estiver dentro do expediente ou o início da próxima faixa.
+func (c *BusinessCalendar) NextWorkingTime(t time.Time) time.Time {
+	if c == nil {
+		return t
+	}
+
+	next := t
+	c.scan(t, func(from, to time.Time) bool {
+		next = from
+		return true
+	})
+	return next
+}
+
+// AddWorkingMinutes soma minutes de expediente a start (timers pausam fora do expediente).
+func (c *BusinessCalendar) AddWorkingMinutes(start time.Time, minutes int) time.Time {
+	if c == nil || minutes <= 0 {
+		return start.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	remaining := time.Duration(minutes) * time.Minute
+	due := start.Add(remaining)
+	c.scan(start, func(from, to time.Time) bool {
+		if avail := to.Sub(from); remaining > avail {
+			remaining -= avail
+			return false
+		}
+		due = from.Add(remaining)
+		return true
+	})
+	return due
+}
+
+// AddWorkingDays avança days dias úteis a partir de start mantendo o horário local.
+func (c *BusinessCalendar) AddWorkingDays(start time.Time, days int) time.Time {
+	if c == nil {
+		return start.AddDate(0, 0, days)
+	}
+
+	t := start.In(c.loc)
+	for n, i := 0, 0; n < days; i++ {
+		if i >= maxCalendarScanDays {
+			return start.AddDate(0, 0, days)
+		}
+		t = t.AddDate(0, 0, 1)
+		if c.IsWorkingDay(t) {
+			n++
+		}
+	}
+	return t
+}
+
+// scan percorre as faixas de expediente a partir de t (recortadas em t) até fn retornar true.
+func (c *BusinessCalendar) scan(t time.Time, fn func(from, to time.Time) bool) {
+	local := t.In(c.loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.loc)
+	for i := 0; i < maxCalendarScanDays; i++ {
+		if !c.holidays[day.Format("2006-01-02")] {
+			for _, r := range c.week[day.Weekday()] {
+				from := c.clock(day, r.start)
+				to := c.clock(day, r.end)
+				if !to.After(t) {
+					continue
+				}
+				if from.Before(t) {
+					from = t
+				}
+				if fn(from, to) {
+					return
+				}
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, c.loc)
+	}
+}
+
+// clock retorna o instante minute (desde a meia-noite) do dia no fuso do calendário.
+// 24:00 normaliza para a meia-noite do dia seguinte.
+func (c *BusinessCalendar) clock(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, c.loc)
+}
+
+// buildWeek converte as faixas em minutos por dia da semana, ordenadas pelo início.
+func buildWeek(intervals []BusinessInterval) ([7][]clockRange, error) {
+	var week [7][]clockRange
+	for _, in := range intervals {
+		if in.Weekday < 0 || in.Weekday > 6 {
+			return week, fmt.Errorf("weekday must be between 0 and 6")
+		}
+		start, err := parseClock(in.Start)
+		if err != nil {
+			return week, err
+		}
+		end, err := parseClock(in.End)
+		if err != nil {
+			return week, err
+		}
+		if end <= start {
+			return week, fmt.Errorf("interval end %s must be after start %s", in.End, in.Start)
+		}
+		week[in.Weekday] = append(week[in.Weekday], clockRange{start: start, end: end})
+	}
+	for wd := range week {
+		sort.Slice(week[wd], func(i, j int) bool { return week[wd][i].start < week[wd][j].start })
+	}
+	return week, nil
+}
+
+// parseClock converte HH:MM (00:00 a 24:00) em minutos desde a meia-noite.
+func parseClock(s string) (int, error) {
+	var h, m int
+	if len(s) != 5 || s[2] != ':' {
+		return 0, fmt.Errorf("time %q must use HH:MM", s)
+	}
+	if _, err := fmt.Sscanf(s, "%02d:%02d", &h, &m); err != nil || h > 24 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("time %q must use HH:MM", s)
+	}
+	return h*60 + m, nil
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// weekdays expediente de segunda a sexta nas faixas informadas.
+func weekdays(ranges ...[2]string) []BusinessInterval {
+	var intervals []BusinessInterval
+	for wd := 1; wd <= 5; wd++ {
+		for _, r := range ranges {
+			intervals = append(intervals, BusinessInterval{Weekday: wd, Start: r[0], End: r[1]})
+		}
+	}
+	return intervals
+}
+
+func newCalendar(t *testing.T, timezone string, intervals []BusinessInterval, holidays ...string) *BusinessCalendar {
+	t.Helper()
+	var hs []Holiday
+	for _, date := range holidays {
+		hs = append(hs, Holiday{Date: date})
+	}
+	var hours *BusinessHours
+	if intervals != nil {
+		hours = &BusinessHours{Timezone: timezone, Intervals: intervals}
+	}
+	c, err := NewBusinessCalendar(hours, hs)
+	require.NoError(t, err)
+	return c
+}
+
+func TestBusinessCalendar_NextWorkingTime(t *testing.T) {
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 1, day, hour, min, 0, 0, sp) }
+
+	// 2026-01-05 é segunda-feira; 2026-01-07 (quarta) é feriado
+	c := newCalendar(t, "America/Sao_Paulo", weekdays([2]string{"09:00", "12:00"}, [2]string{"13:00", "18:00"}), "2026-01-07")
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"inside a range", at(5, 10, 30), at(5, 10, 30)},
+		{"range start", at(5, 9, 0), at(5, 9, 0)},
+		{"before opening", at(5, 7, 0), at(5, 9, 0)},
+		{"lunch break", at(5, 12, 0), at(5, 13, 0)},
+		{"range end is closed", at(5, 18, 0), at(6, 9, 0)},
+		{"after closing", at(5, 20, 0), at(6, 9, 0)},
+		{"holiday on a working day", at(7, 10, 0), at(8, 9, 0)},
+		{"evening before a holiday", at(6, 19, 0), at(8, 9, 0)},
+		{"saturday", at(10, 10, 0), at(12, 9, 0)},
+		{"other time zone", time.Date(2026, 1, 5, 11, 0, 0, 0, time.UTC), at(5, 9, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.NextWorkingTime(tt.t)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestBusinessCalendar_AddWorkingMinutes(t *testing.T) {
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 1, day, hour, min, 0, 0, sp) }
+
+	c := newCalendar(t, "America/Sao_Paulo", weekdays([2]string{"09:00", "12:00"}, [2]string{"13:00", "18:00"}), "2026-01-07")
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		minutes int
+		want    time.Time
+	}{
+		{"within the same range", at(5, 9, 0), 60, at(5, 10, 0)},
+		{"ends exactly at the range end", at(5, 11, 0), 60, at(5, 12, 0)},
+		{"pauses at lunch", at(5, 11, 30), 60, at(5, 13, 30)},
+		{"pauses overnight", at(5, 17, 0), 120, at(6, 10, 0)},
+		{"starts before opening", at(5, 6, 0), 30, at(5, 9, 30)},
+		{"starts during lunch", at(5, 12, 15), 30, at(5, 13, 30)},
+		{"a full working day ends at closing", at(5, 9, 0), 8 * 60, at(5, 18, 0)},
+		{"one minute past a full day", at(5, 9, 0), 8*60 + 1, at(6, 9, 1)},
+		{"skips a holiday on a working day", at(6, 17, 0), 120, at(8, 10, 0)},
+		{"starts on a holiday", at(7, 10, 0), 30, at(8, 9, 30)},
+		{"skips the weekend", at(9, 17, 0), 120, at(12, 10, 0)},
+		{"zero minutes keeps start", at(10, 10, 0), 0, at(10, 10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.AddWorkingMinutes(tt.start, tt.minutes)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestBusinessCalendar_AddWorkingDays(t *testing.T) {
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 1, day, hour, min, 0, 0, sp) }
+
+	c := newCalendar(t, "America/Sao_Paulo", weekdays([2]string{"09:00", "18:00"}), "2026-01-07")
+
+	tests := []struct {
+		name  string
+		start time.Time
+		days  int
+		want  time.Time
+	}{
+		{"next day", at(5, 15, 0), 1, at(6, 15, 0)},
+		{"skips a holiday on a working day", at(6, 15, 0), 1, at(8, 15, 0)},
+		{"skips the weekend", at(9, 15, 0), 1, at(12, 15, 0)},
+		{"several days across holiday and weekend", at(5, 10, 0), 5, at(13, 10, 0)},
+		{"from a saturday", at(10, 8, 0), 1, at(12, 8, 0)},
+		{"zero days keeps start", at(5, 10, 0), 0, at(5, 10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.AddWorkingDays(tt.start, tt.days)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestBusinessCalendar_DST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// 2026-03-08 (domingo): 02:00 EST vira 03:00 EDT; 2026-11-01 (domingo): 02:00 EDT volta para 01:00 EST
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, ny)
+	}
+
+	office := newCalendar(t, "America/New_York", weekdays([2]string{"09:00", "17:00"}))
+
+	t.Run("working minutes across the spring weekend keep local opening hours", func(t *testing.T) {
+		got := office.AddWorkingMinutes(at(3, 6, 16, 0), 120)
+		assert.True(t, at(3, 9, 10, 0).Equal(got), "got %s", got)
+		_, offset := got.Zone()
+		assert.Equal(t, -4*3600, offset)
+	})
+	t.Run("working minutes across the fall weekend keep local opening hours", func(t *testing.T) {
+		got := office.AddWorkingMinutes(at(10, 30, 16, 0), 120)
+		assert.True(t, at(11, 2, 10, 0).Equal(got), "got %s", got)
+		_, offset := got.Zone()
+		assert.Equal(t, -5*3600, offset)
+	})
+	t.Run("working days keep the local time across DST", func(t *testing.T) {
+		got := office.AddWorkingDays(at(3, 6, 10, 0), 1)
+		assert.True(t, at(3, 9, 10, 0).Equal(got), "got %s", got)
+		assert.Equal(t, 71*time.Hour, got.Sub(at(3, 6, 10, 0)))
+	})
+	t.Run("next working time after the spring change", func(t *testing.T) {
+		got := office.NextWorkingTime(at(3, 8, 1, 30))
+		assert.True(t, at(3, 9, 9, 0).Equal(got), "got %s", got)
+	})
+
+	// Dia inteiro: o domingo da mudança tem 23 horas reais (e 25 na volta)
+	allDay := make([]BusinessInterval, 7)
+	for wd := range allDay {
+		allDay[wd] = BusinessInterval{Weekday: wd, Start: "00:00", End: "24:00"}
+	}
+	fullWeek := newCalendar(t, "America/New_York", allDay)
+
+	t.Run("working minutes count real time on the short day", func(t *testing.T) {
+		got := fullWeek.AddWorkingMinutes(at(3, 8, 0, 0), 24*60)
+		assert.True(t, at(3, 9, 1, 0).Equal(got), "got %s", got)
+	})
+	t.Run("working minutes count real time on the long day", func(t *testing.T) {
+		got := fullWeek.AddWorkingMinutes(at(11, 1, 0, 0), 24*60)
+		assert.True(t, at(11, 1, 23, 0).Equal(got), "got %s", got)
+	})
+}
+
+func TestBusinessCalendar_HolidaysOnly(t *testing.T) {
+	// Sem expediente: dias integrais em UTC, só os feriados pausam
+	c := newCalendar(t, "", nil, "2026-01-07")
+	start := time.Date(2026, 1, 6, 23, 0, 0, 0, time.UTC)
+
+	assert.True(t, time.Date(2026, 1, 8, 1, 0, 0, 0, time.UTC).Equal(c.AddWorkingMinutes(start, 120)))
+	assert.True(t, time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC).Equal(c.NextWorkingTime(time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC))))
+	assert.False(t, c.IsWorkingDay(time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, c.IsWorkingDay(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestBusinessCalendar_Nil(t *testing.T) {
+	c, err := NewBusinessCalendar(nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	start := time.Date(2026, 1, 10, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, start.Add(90*time.Minute), c.AddWorkingMinutes(start, 90))
+	assert.Equal(t, start.AddDate(0, 0, 2), c.AddWorkingDays(start, 2))
+	assert.Equal(t, start, c.NextWorkingTime(start))
+	assert.True(t, c.IsWorkingDay(start))
+}
+
+func TestSetBusinessHoursRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		timezone  string
+		intervals []BusinessInterval
+		wantErr   string
+	}{
+		{"valid", "America/Sao_Paulo", weekdays([2]string{"09:00", "12:00"}, [2]string{"13:00", "18:00"}), ""},
+		{"until midnight", "UTC", []BusinessInterval{{Weekday: 6, Start: "18:00", End: "24:00"}}, ""},
+		{"unknown time zone", "America/Atlantis", weekdays([2]string{"09:00", "18:00"}), "IANA"},
+		{"end before start", "UTC", []BusinessInterval{{Weekday: 1, Start: "18:00", End: "09:00"}}, "must be after start"},
+		{"invalid clock", "UTC", []BusinessInterval{{Weekday: 1, Start: "09:60", End: "18:00"}}, "HH:MM"},
+		{"past midnight", "UTC", []BusinessInterval{{Weekday: 1, Start: "09:00", End: "24:30"}}, "HH:MM"},
+		{"overlap", "UTC", []BusinessInterval{{Weekday: 1, Start: "09:00", End: "12:00"}, {Weekday: 1, Start: "11:00", End: "13:00"}}, "overlap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&SetBusinessHoursRequest{Timezone: tt.timezone, Intervals: tt.intervals}).Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -134,6 +134,7 @@ type Company struct {
 	Phone   *string `json:"phone,omitempty" db:"phone"`
 	Email   *string `json:"email,omitempty" db:"email"`
 	Website *string `json:"website,omitempty" db:"website"`
+	LogoURL *string `json:"logoUrl,omitempty" db:"logoUrl"`
 
 	// Address (JSONB)
 	Address map[string]interface{} `json:"address,omitempty" db:"address"`
@@ -145,6 +146,9 @@ type Company struct {
 	// Ownership - assignedToId no schema real
 	OwnerID string `json:"ownerId" db:"assignedToId"`
 
+	// Chave do sistema de origem do integrador - única entre as empresas ativas do workspace
+	ExternalID *string `json:"externalId" db:"externalId"`
+
 	// Metadata
 	Tags         []string               `json:"tags" db:"tags"`
 	CustomFields map[string]interface{} `json:"customFields" db:"customFields"`
@@ -154,6 +158,9 @@ type Company struct {
 	CreatedAt time.Time  `json:"createdAt" db:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt" db:"updatedAt"`
 	DeletedAt *time.Time `json:"deletedAt,omitempty" db:"deletedAt"`
+
+	// Campos calculados do workspace (ComputedField), avaliados na leitura
+	Computed map[string]any `json:"computed,omitempty" db:"-"`
 }
 
 // CreateCompanyRequest DTO para criação de empresa.
@@ -185,6 +192,9 @@ type CreateCompanyRequest struct {
 	// Ownership (opcional - default JWT claims) - ID é TEXT
 	OwnerID *string `json:"ownerId,omitempty"`
 
+	// Chave do integrador - Opcional: PUT .../companies/by-external-id/{externalId} preenche pelo path
+	ExternalID *string `json:"externalId,omitempty" validate:"omitempty,min=1,max=255"`
+
 	// Metadata
 	Tags         []string               `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1"`
 	CustomFields map[string]interface{} `json:"customFields,omitempty"`
@@ -218,6 +228,9 @@ type UpdateCompanyRequest struct {
 	// Ownership - ID é TEXT
 	OwnerID *string `json:"ownerId,omitempty"`
 
+	// Chave do integrador - nil mantém a atual (não é possível limpá-la)
+	ExternalID *string `json:"externalId,omitempty" validate:"omitempty,min=1,max=255"`
+
 	// Metadata
 	Tags         *[]string              `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1"`
 	CustomFields map[string]interface{} `json:"customFields,omitempty"`
@@ -240,7 +253,7 @@ type ListCompaniesParams struct {
 
 	// Paginação
 	Limit  int
-	Cursor *string // RFC3339 timestamp
+	Cursor *string // nextCursor da página anterior (opaco)
 	Sort   string  // "name:asc", "createdAt:desc", etc.
 }
 
@@ -270,3 +283,29 @@ type CompanyListResponse struct {
 		NextCursor  *string `json:"nextCursor,omitempty"`
 	} `json:"meta"`
 }
+
+// UpsertUpdate converte o corpo de PUT .../companies/by-external-id/{externalId} na atualização
+// aplicada quando a empresa já existe.
+func (r *CreateCompanyRequest) UpsertUpdate() *UpdateCompanyRequest {
+	update := &UpdateCompanyRequest{
+		Name:           &r.Name,
+		Domain:         r.Domain,
+		Industry:       r.Industry,
+		LifecycleStage: r.LifecycleStage,
+		CompanySize:    r.CompanySize,
+		Phone:          r.Phone,
+		Email:          r.Email,
+		Website:        r.Website,
+		Address:        r.Address,
+		AnnualRevenue:  r.AnnualRevenue,
+		EmployeeCount:  r.EmployeeCount,
+		OwnerID:        r.OwnerID,
+		ExternalID:     r.ExternalID,
+		CustomFields:   r.CustomFields,
+		Notes:          r.Notes,
+	}
+	if r.Tags != nil {
+		update.Tags = &r.Tags
+	}
+	return update
+}
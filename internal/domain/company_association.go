@@ -0,0 +1,77 @@
+package domain
+
+import "strings"
+
+// DefaultFreeMailDomains provedores de e-mail gratuito: o domínio do e-mail não identifica a
+// empresa do contato, então nunca é usado para associação automática.
+var DefaultFreeMailDomains = []string{
+	"gmail.com", "googlemail.com", "outlook.com", "hotmail.com", "live.com", "msn.com",
+	"yahoo.com", "yahoo.com.br", "icloud.com", "me.com", "aol.com", "proton.me",
+	"protonmail.com", "gmx.com", "mail.com", "zoho.com", "yandex.com",
+	"uol.com.br", "bol.com.br", "terra.com.br", "ig.com.br",
+}
+
+// CompanyAssociationPolicy decide se e por qual domínio um contato pode ser associado
+// automaticamente à empresa cujo website tem o mesmo domínio do e-mail.
+type CompanyAssociationPolicy struct {
+	Enabled bool
+	deny    map[string]bool
+}
+
+// NewCompanyAssociationPolicy monta a política com a deny-list padrão mais extraDeny.
+func NewCompanyAssociationPolicy(enabled bool, extraDeny []string) CompanyAssociationPolicy {
+	p := CompanyAssociationPolicy{Enabled: enabled, deny: map[string]bool{}}
+	for _, d := range DefaultFreeMailDomains {
+		p.deny[d] = true
+	}
+	for _, d := range extraDeny {
+		if d = NormalizeDomain(d); d != "" {
+			p.deny[d] = true
+		}
+	}
+	return p
+}
+
+// MatchDomain retorna o domínio do e-mail usado na associação; false quando a política está
+// desativada, o e-mail é inválido ou o domínio está na deny-list.
+func (p CompanyAssociationPolicy) MatchDomain(email string) (string, bool) {
+	if !p.Enabled {
+		return "", false
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return "", false
+	}
+	d := NormalizeDomain(email[at+1:])
+	if d == "" || !strings.Contains(d, ".") || p.deny[d] {
+		return "", false
+	}
+	return d, true
+}
+
+// DeniedDomains lista a deny-list efetiva (usada no backfill em SQL).
+func (p CompanyAssociationPolicy) DeniedDomains() []string {
+	out := make([]string, 0, len(p.deny))
+	for d := range p.deny {
+		out = append(out, d)
+	}
+	return out
+}
+
+// NormalizeDomain reduz website/URL/domínio ao host em minúsculas, sem "www." nem porta
+// ("https://www.Acme.com:443/x" → "acme.com").
+func NormalizeDomain(raw string) string {
+	d := strings.ToLower(strings.TrimSpace(raw))
+	if i := strings.Index(d, "://"); i >= 0 {
+		d = d[i+3:]
+	}
+	if i := strings.IndexAny(d, "/?#:"); i >= 0 {
+		d = d[:i]
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(d, "www."), ".")
+}
+
+// AssociateCompaniesResult resultado do backfill de associação contato → empresa.
+type AssociateCompaniesResult struct {
+	Associated int64 `json:"associated"`
+}
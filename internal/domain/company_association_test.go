@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"acme.com", "acme.com"},
+		{"  ACME.com  ", "acme.com"},
+		{"https://www.Acme.com:443/contato?x=1", "acme.com"},
+		{"http://acme.com.br/", "acme.com.br"},
+		{"www.acme.com.", "acme.com"},
+		{"acme.com#sobre", "acme.com"},
+		{"sub.acme.com", "sub.acme.com"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeDomain(tt.raw))
+		})
+	}
+}
+
+func TestCompanyAssociationPolicy_MatchDomain(t *testing.T) {
+	policy := NewCompanyAssociationPolicy(true, []string{" Parceiro.com.br ", "https://www.revenda.com/"})
+
+	tests := []struct {
+		email  string
+		want   string
+		wantOK bool
+	}{
+		{"ana@acme.com", "acme.com", true},
+		{"Ana@ACME.com", "acme.com", true},
+		{"ana@sub.acme.com", "sub.acme.com", true},
+		{"ana@gmail.com", "", false},
+		{"ana@Yahoo.com.br", "", false},
+		{"ana@parceiro.com.br", "", false},
+		{"ana@revenda.com", "", false},
+		{"ana@localhost", "", false},
+		{"ana@", "", false},
+		{"sem-arroba", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got, ok := policy.MatchDomain(tt.email)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := NewCompanyAssociationPolicy(false, nil).MatchDomain("ana@acme.com")
+	assert.False(t, ok, "disabled policy never matches")
+}
+
+func TestCompanyAssociationPolicy_DeniedDomains(t *testing.T) {
+	denied := NewCompanyAssociationPolicy(true, []string{"WWW.Parceiro.com", "", "gmail.com"}).DeniedDomains()
+	assert.Len(t, denied, len(DefaultFreeMailDomains)+1)
+	assert.Contains(t, denied, "parceiro.com")
+	assert.Contains(t, denied, "gmail.com")
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultDuplicateThreshold similaridade mínima de nome (0–1) para sugerir duplicata.
+const DefaultDuplicateThreshold = 0.8
+
+// companyLegalSuffixes sufixos societários ignorados na comparação de nomes.
+var companyLegalSuffixes = map[string]bool{
+	"ltda": true, "me": true, "epp": true, "eireli": true, "sa": true, "s": true, "a": true,
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true, "corp": true,
+	"corporation": true, "co": true, "company": true, "gmbh": true, "ag": true, "plc": true,
+}
+
+// DuplicateCandidate dados mínimos de uma empresa para detecção de duplicatas.
+type DuplicateCandidate struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Domain    *string   `json:"domain"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// DuplicateCompanyPair duas empresas possivelmente duplicadas. Companies vem em ordem de
+// criação: a primeira é a sugestão de sobrevivente no merge.
+type DuplicateCompanyPair struct {
+	Companies [2]DuplicateCandidate `json:"companies"`
+	Score     float64               `json:"score"`
+	Reasons   []string              `json:"reasons"` // "domain" e/ou "name"
+}
+
+// CompanyDuplicatesResponse resposta do relatório de duplicatas.
+type CompanyDuplicatesResponse struct {
+	Data []DuplicateCompanyPair `json:"data"`
+}
+
+// CompanyDuplicatesParams parâmetros do relatório.
+type CompanyDuplicatesParams struct {
+	WorkspaceID string
+	Threshold   float64
+	Limit       int
+}
+
+// Normalize aplica defaults.
+func (p *CompanyDuplicatesParams) Normalize() {
+	if p.Threshold <= 0 || p.Threshold > 1 {
+		p.Threshold = DefaultDuplicateThreshold
+	}
+	if p.Limit <= 0 || p.Limit > 200 {
+		p.Limit = 50
+	}
+}
+
+// NormalizeCompanyName reduz o nome a tokens alfanuméricos em minúsculas, sem sufixos
+// societários ("ACME Ltda." → "acme").
+func NormalizeCompanyName(name string) string {
+	tokens := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := tokens[:0]
+	for _, t := range tokens {
+		if !companyLegalSuffixes[t] {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == 0 {
+		return strings.Join(tokens, " ")
+	}
+	return strings.Join(kept, " ")
+}
+
+// NameSimilarity coeficiente de Dice sobre trigramas dos nomes normalizados (0–1).
+func NameSimilarity(a, b string) float64 {
+	a, b = NormalizeCompanyName(a), NormalizeCompanyName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	ta, tb := trigrams(a), trigrams(b)
+	shared := 0
+	for t, n := range ta {
+		if m, ok := tb[t]; ok {
+			shared += min(n, m)
+		}
+	}
+	total := 0
+	for _, n := range ta {
+		total += n
+	}
+	for _, n := range tb {
+		total += n
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+func trigrams(s string) map[string]int {
+	padded := []rune("  " + s + " ")
+	out := make(map[string]int, len(padded))
+	for i := 0; i+3 <= len(padded); i++ {
+		out[string(padded[i:i+3])]++
+	}
+	return out
+}
+
+// FindCompanyDuplicates compara as empresas por domínio normalizado (igualdade) e nome
+// (similaridade >= threshold). Para evitar comparar todos os pares, nomes só são comparados
+// dentro do mesmo bloco (primeiras duas letras do nome normalizado).
+// Retorna os pares de maior score primeiro, até limit.
+func FindCompanyDuplicates(companies []DuplicateCandidate, threshold float64, limit int) []DuplicateCompanyPair {
+	sorted := append([]DuplicateCandidate(nil), companies...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	byDomain := map[string][]int{}
+	byBlock := map[string][]int{}
+	for i, c := range sorted {
+		if c.Domain != nil {
+			if d := NormalizeDomain(*c.Domain); strings.Contains(d, ".") {
+				byDomain[d] = append(byDomain[d], i)
+			}
+		}
+		if n := NormalizeCompanyName(c.Name); n != "" {
+			key := string([]rune(n)[:min(2, len([]rune(n)))])
+			byBlock[key] = append(byBlock[key], i)
+		}
+	}
+
+	type pairKey struct{ a, b int }
+	found := map[pairKey]*DuplicateCompanyPair{}
+	add := func(i, j int, reason string, score float64) {
+		k := pairKey{i, j}
+		p, ok := found[k]
+		if !ok {
+			p = &DuplicateCompanyPair{Companies: [2]DuplicateCandidate{sorted[i], sorted[j]}}
+			found[k] = p
+		}
+		p.Reasons = append(p.Reasons, reason)
+		if score > p.Score {
+			p.Score = score
+		}
+	}
+
+	for _, idx := range byDomain {
+		for x := 0; x < len(idx); x++ {
+			for y := x + 1; y < len(idx); y++ {
+				add(idx[x], idx[y], "domain", 1)
+			}
+		}
+	}
+	for _, idx := range byBlock {
+		for x := 0; x < len(idx); x++ {
+			for y := x + 1; y < len(idx); y++ {
+				if s := NameSimilarity(sorted[idx[x]].Name, sorted[idx[y]].Name); s >= threshold {
+					add(idx[x], idx[y], "name", s)
+				}
+			}
+		}
+	}
+
+	pairs := make([]DuplicateCompanyPair, 0, len(found))
+	for _, p := range found {
+		pairs = append(pairs, *p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		if pairs[i].Companies[0].ID != pairs[j].Companies[0].ID {
+			return pairs[i].Companies[0].ID < pairs[j].Companies[0].ID
+		}
+		return pairs[i].Companies[1].ID < pairs[j].Companies[1].ID
+	})
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs
+}
+
+// MergeCompaniesRequest DTO do merge: as empresas de SourceCompanyIDs são absorvidas pela
+// empresa do path (sobrevivente) e removidas (soft delete).
+type MergeCompaniesRequest struct {
+	SourceCompanyIDs []string `json:"sourceCompanyIds" validate:"required,min=1,max=20,dive,required"`
+}
+
+// Validate sanitiza e valida o request (ids duplicados são descartados).
+func (r *MergeCompaniesRequest) Validate() error {
+	seen := map[string]bool{}
+	ids := r.SourceCompanyIDs[:0]
+	for _, id := range r.SourceCompanyIDs {
+		id = strings.TrimSpace(id)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	r.SourceCompanyIDs = ids
+	return validate.Struct(r)
+}
+
+// MergeCompaniesResult resultado do merge: a empresa sobrevivente e quantos registros
+// foram reassociados por entidade.
+type MergeCompaniesResult struct {
+	Company          *Company         `json:"company"`
+	MergedCompanyIDs []string         `json:"mergedCompanyIds"`
+	Reassigned       map[string]int64 `json:"reassigned"`
+}
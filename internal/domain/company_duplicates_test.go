@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCompanyName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"ACME Ltda.", "acme"},
+		{"Acme S.A.", "acme"},
+		{"Acme Comércio e Serviços EIRELI", "acme comércio e serviços"},
+		{"Globex Corporation, Inc.", "globex"},
+		{"  Padaria   do-Centro ", "padaria do centro"},
+		// Só sufixos: mantém os tokens para não virar nome vazio
+		{"Ltda", "ltda"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeCompanyName(tt.name))
+		})
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, NameSimilarity("ACME Ltda", "Acme S.A."))
+	assert.InDelta(t, 0.91, NameSimilarity("Linkko Tecnologia", "Linko Tecnologia"), 0.01)
+	assert.Less(t, NameSimilarity("Acme", "Apex"), 0.5)
+	assert.Zero(t, NameSimilarity("", "Acme"))
+	assert.Zero(t, NameSimilarity("...", "..."))
+	assert.Equal(t, NameSimilarity("Padaria Central", "Padaria do Centro"), NameSimilarity("Padaria do Centro", "Padaria Central"))
+}
+
+func TestFindCompanyDuplicates(t *testing.T) {
+	base := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	company := func(id, name string, domain *string, minutes int) DuplicateCandidate {
+		return DuplicateCandidate{ID: id, Name: name, Domain: domain, CreatedAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	site := func(s string) *string { return &s }
+
+	// Fora de ordem de criação: o mais antigo do par vem primeiro (sugestão de sobrevivente)
+	companies := []DuplicateCandidate{
+		company("cmp_2", "ACME S.A.", site("www.acme.com"), 1),
+		company("cmp_1", "Acme Ltda", site("https://acme.com/contato"), 0),
+		company("cmp_3", "Linkko Tecnologia", nil, 2),
+		company("cmp_4", "Linko Tecnologia", site("localhost"), 3),
+		company("cmp_5", "Beta", site("beta.com"), 4),
+		company("cmp_6", "Gama", site("http://beta.com"), 5),
+		company("cmp_7", "Zeta", nil, 6),
+	}
+
+	pairs := FindCompanyDuplicates(companies, DefaultDuplicateThreshold, 50)
+	require.Len(t, pairs, 3)
+
+	assert.Equal(t, "cmp_1", pairs[0].Companies[0].ID)
+	assert.Equal(t, "cmp_2", pairs[0].Companies[1].ID)
+	assert.Equal(t, 1.0, pairs[0].Score)
+	assert.Equal(t, []string{"domain", "name"}, pairs[0].Reasons)
+
+	assert.Equal(t, [2]string{"cmp_5", "cmp_6"}, [2]string{pairs[1].Companies[0].ID, pairs[1].Companies[1].ID})
+	assert.Equal(t, []string{"domain"}, pairs[1].Reasons)
+
+	assert.Equal(t, [2]string{"cmp_3", "cmp_4"}, [2]string{pairs[2].Companies[0].ID, pairs[2].Companies[1].ID})
+	assert.Equal(t, []string{"name"}, pairs[2].Reasons)
+	assert.Less(t, pairs[2].Score, 1.0)
+
+	assert.Len(t, FindCompanyDuplicates(companies, 0.95, 50), 2, "threshold")
+	assert.Len(t, FindCompanyDuplicates(companies, DefaultDuplicateThreshold, 1), 1, "limit")
+	assert.Empty(t, FindCompanyDuplicates(nil, DefaultDuplicateThreshold, 50))
+}
+
+func TestCompanyDuplicatesParams_Normalize(t *testing.T) {
+	p := CompanyDuplicatesParams{Threshold: 1.5, Limit: 500}
+	p.Normalize()
+	assert.Equal(t, DefaultDuplicateThreshold, p.Threshold)
+	assert.Equal(t, 50, p.Limit)
+
+	p = CompanyDuplicatesParams{Threshold: 0.6, Limit: 10}
+	p.Normalize()
+	assert.Equal(t, 0.6, p.Threshold)
+	assert.Equal(t, 10, p.Limit)
+}
+
+func TestMergeCompaniesRequest_Validate(t *testing.T) {
+	req := &MergeCompaniesRequest{SourceCompanyIDs: []string{" cmp_1 ", "cmp_2", "cmp_1"}}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, []string{"cmp_1", "cmp_2"}, req.SourceCompanyIDs)
+
+	assert.Error(t, (&MergeCompaniesRequest{}).Validate())
+	assert.Error(t, (&MergeCompaniesRequest{SourceCompanyIDs: []string{"  "}}).Validate())
+}
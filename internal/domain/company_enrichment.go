@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+// EnrichmentStatus ciclo de vida do job: PENDING → RUNNING → COMPLETED | FAILED.
+type EnrichmentStatus string
+
+const (
+	EnrichmentStatusPending   EnrichmentStatus = "PENDING"
+	EnrichmentStatusRunning   EnrichmentStatus = "RUNNING"
+	EnrichmentStatusCompleted EnrichmentStatus = "COMPLETED"
+	EnrichmentStatusFailed    EnrichmentStatus = "FAILED"
+)
+
+// EnrichmentCustomFieldKey chave de customFields onde fica a proveniência do último enriquecimento.
+const EnrichmentCustomFieldKey = "enrichment"
+
+// CompanyEnrichmentJob é um job assíncrono que consulta o provedor de enriquecimento e
+// preenche os dados firmográficos da empresa. AppliedFields lista o que foi de fato alterado.
+type CompanyEnrichmentJob struct {
+	ID            string           `json:"id"`
+	WorkspaceID   string           `json:"workspaceId"`
+	CompanyID     string           `json:"companyId"`
+	Provider      string           `json:"provider"`
+	Overwrite     bool             `json:"overwrite"`
+	Status        EnrichmentStatus `json:"status"`
+	AppliedFields []string         `json:"appliedFields"`
+	Error         *string          `json:"error"`
+	RequestedByID string           `json:"requestedById"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+	StartedAt     *time.Time       `json:"startedAt"`
+	CompletedAt   *time.Time       `json:"completedAt"`
+}
+
+// EnrichCompanyRequest DTO (corpo opcional) para solicitar o enriquecimento.
+// Overwrite=false preenche apenas campos vazios, preservando dados informados pelo usuário.
+type EnrichCompanyRequest struct {
+	Overwrite bool `json:"overwrite"`
+}
+
+// CompanyEnrichmentPatch são os valores a gravar na empresa (nil = manter).
+type CompanyEnrichmentPatch struct {
+	Industry      *string
+	Size          *CompanySize
+	AnnualRevenue *float64
+	LogoURL       *string
+}
+
+// Fields lista os campos presentes no patch, na nomenclatura da API.
+func (p *CompanyEnrichmentPatch) Fields() []string {
+	fields := []string{}
+	if p.Industry != nil {
+		fields = append(fields, "industry")
+	}
+	if p.Size != nil {
+		fields = append(fields, "companySize")
+	}
+	if p.AnnualRevenue != nil {
+		fields = append(fields, "annualRevenue")
+	}
+	if p.LogoURL != nil {
+		fields = append(fields, "logoUrl")
+	}
+	return fields
+}
+
+// PlanCompanyEnrichment decide o que aplicar dos valores encontrados pelo provedor:
+// sem overwrite só entram campos vazios na empresa; valores iguais aos atuais são ignorados.
+func PlanCompanyEnrichment(current *Company, industry *string, size *CompanySize, revenue *float64, logoURL *string, overwrite bool) *CompanyEnrichmentPatch {
+	patch := &CompanyEnrichmentPatch{}
+
+	if industry != nil && *industry != "" && (current.Industry == nil || (overwrite && *current.Industry != *industry)) {
+		patch.Industry = industry
+	}
+	if size != nil && size.IsValid() && (!current.Size.IsValid() || (overwrite && current.Size != *size)) {
+		patch.Size = size
+	}
+	if revenue != nil && (current.AnnualRevenue == nil || (overwrite && *current.AnnualRevenue != *revenue)) {
+		patch.AnnualRevenue = revenue
+	}
+	if logoURL != nil && *logoURL != "" && (current.LogoURL == nil || (overwrite && *current.LogoURL != *logoURL)) {
+		patch.LogoURL = logoURL
+	}
+
+	return patch
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"linkko-api/internal/formula"
+)
+
+// MaxComputedFieldsPerEntity limita as fórmulas avaliadas em cada leitura de empresas/negócios.
+const MaxComputedFieldsPerEntity = 20
+
+var computedFieldKeyPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+
+// ComputedFieldEntity entidade que recebe o campo calculado.
+type ComputedFieldEntity string
+
+const (
+	ComputedFieldCompany ComputedFieldEntity = "company"
+	ComputedFieldDeal    ComputedFieldEntity = "deal"
+)
+
+func (e ComputedFieldEntity) IsValid() bool {
+	_, ok := ComputedFieldSchemas[e]
+	return ok
+}
+
+// ComputedFieldSchemas campos e coleções que as fórmulas de cada entidade podem referenciar.
+// Os nomes são mapeados para colunas no repo (ComputedFieldRepository).
+var ComputedFieldSchemas = map[ComputedFieldEntity]formula.Schema{
+	ComputedFieldCompany: {
+		Fields: map[string]formula.Type{
+			"revenue":   formula.Number,
+			"score":     formula.Number,
+			"createdAt": formula.Date,
+		},
+		Collections: map[string]formula.Collection{
+			"deals":      computedDealsCollection,
+			"activities": computedActivitiesCollection,
+		},
+	},
+	ComputedFieldDeal: {
+		Fields: map[string]formula.Type{
+			"value":             formula.Number,
+			"probability":       formula.Number,
+			"createdAt":         formula.Date,
+			"expectedCloseDate": formula.Date,
+			"closedAt":          formula.Date,
+		},
+		Collections: map[string]formula.Collection{
+			"activities": computedActivitiesCollection,
+		},
+	},
+}
+
+var (
+	computedDealsCollection = formula.Collection{Fields: map[string]formula.Type{
+		"value":             formula.Number,
+		"probability":       formula.Number,
+		"stage":             formula.String,
+		"currency":          formula.String,
+		"ownerId":           formula.String,
+		"createdAt":         formula.Date,
+		"expectedCloseDate": formula.Date,
+		"closedAt":          formula.Date,
+	}}
+	computedActivitiesCollection = formula.Collection{Fields: map[string]formula.Type{
+		"activityType": formula.String,
+		"createdAt":    formula.Date,
+	}}
+)
+
+// ComputedField campo calculado de empresas ou negócios, avaliado na leitura (GET e listagens)
+// e devolvido em computed.{key}. ResultType é derivado da fórmula (number ou date).
+type ComputedField struct {
+	ID          string              `json:"id"`
+	WorkspaceID string              `json:"workspaceId"`
+	EntityType  ComputedFieldEntity `json:"entityType"`
+	Key         string              `json:"key"`
+	Label       string              `json:"label"`
+	Formula     string              `json:"formula"`
+	ResultType  formula.Type        `json:"resultType"`
+	CreatedByID string              `json:"createdById"`
+	UpdatedByID *string             `json:"updatedById"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+// ComputedFieldListResponse resposta da listagem (sem paginação: limitado por entidade).
+type ComputedFieldListResponse struct {
+	Data []ComputedField `json:"data"`
+}
+
+// CreateComputedFieldRequest DTO para criação de campo calculado.
+type CreateComputedFieldRequest struct {
+	EntityType ComputedFieldEntity `json:"entityType" validate:"required,oneof=company deal"`
+	Key        string              `json:"key" validate:"required"`
+	Label      string              `json:"label" validate:"required,min=1,max=255"`
+	Formula    string              `json:"formula" validate:"required"`
+
+	// ResultType preenchido por Validate a partir da fórmula
+	ResultType formula.Type `json:"-"`
+}
+
+// Validate sanitiza o request e valida a fórmula contra o schema da entidade.
+func (r *CreateComputedFieldRequest) Validate() error {
+	r.Key = strings.TrimSpace(r.Key)
+	r.Label = strings.TrimSpace(r.Label)
+	r.Formula = strings.TrimSpace(r.Formula)
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if !computedFieldKeyPattern.MatchString(r.Key) {
+		return fmt.Errorf("key must start with a letter and contain only letters, digits and underscores (max 64 chars)")
+	}
+
+	expr, err := formula.Parse(r.Formula, ComputedFieldSchemas[r.EntityType])
+	if err != nil {
+		return err
+	}
+	r.ResultType = expr.Type()
+	return nil
+}
+
+// UpdateComputedFieldRequest DTO para atualização parcial (nil = não modificar).
+// entityType e key são imutáveis.
+type UpdateComputedFieldRequest struct {
+	Label   *string `json:"label,omitempty" validate:"omitempty,min=1,max=255"`
+	Formula *string `json:"formula,omitempty"`
+
+	// ResultType preenchido por ValidateFormula quando formula é enviada
+	ResultType formula.Type `json:"-"`
+}
+
+// Validate sanitiza o request. A fórmula depende da entidade do campo: ver ValidateFormula.
+func (r *UpdateComputedFieldRequest) Validate() error {
+	if r.Label != nil {
+		trimmed := strings.TrimSpace(*r.Label)
+		r.Label = &trimmed
+	}
+	if r.Formula != nil {
+		trimmed := strings.TrimSpace(*r.Formula)
+		r.Formula = &trimmed
+	}
+	return validate.Struct(r)
+}
+
+// ValidateFormula valida a nova fórmula (se enviada) contra o schema da entidade do campo.
+func (r *UpdateComputedFieldRequest) ValidateFormula(entity ComputedFieldEntity) error {
+	if r.Formula == nil {
+		return nil
+	}
+	expr, err := formula.Parse(*r.Formula, ComputedFieldSchemas[entity])
+	if err != nil {
+		return err
+	}
+	r.ResultType = expr.Type()
+	return nil
+}
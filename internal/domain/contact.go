@@ -3,10 +3,97 @@ package domain
 import (
 	"strings"
 	"time"
+)
+
+// ContactLifecycleStage representa o estágio do contato no funil (native PostgreSQL ENUM).
+// Espelha CompanyLifecycleStage: LEAD → MQL → SQL → CUSTOMER → CHURNED.
+// Schema: public."ContactLifecycleStage" - OPPORTUNITY e EVANGELIST são valores legados do Prisma.
+type ContactLifecycleStage string
+
+const (
+	ContactStageLead     ContactLifecycleStage = "LEAD"     // Initial contact, not qualified
+	ContactStageMQL      ContactLifecycleStage = "MQL"      // Marketing Qualified Lead
+	ContactStageSQL      ContactLifecycleStage = "SQL"      // Sales Qualified Lead
+	ContactStageCustomer ContactLifecycleStage = "CUSTOMER" // Active customer
+	ContactStageChurned  ContactLifecycleStage = "CHURNED"  // Former customer/churned
+
+	// Valores legados (podem existir em linhas antigas, mas não são destino de transição)
+	ContactStageOpportunity ContactLifecycleStage = "OPPORTUNITY"
+	ContactStageEvangelist  ContactLifecycleStage = "EVANGELIST"
+)
+
+// ContactLifecycleStages lista os estágios do funil na ordem de progressão
+var ContactLifecycleStages = []ContactLifecycleStage{
+	ContactStageLead,
+	ContactStageMQL,
+	ContactStageSQL,
+	ContactStageCustomer,
+	ContactStageChurned,
+}
+
+// contactStageTransitions define para onde cada estágio pode ir.
+// Pré-venda (LEAD/MQL/SQL) avança ou recua livremente; CUSTOMER só sai por churn;
+// CHURNED pode ser reativado em qualquer estágio.
+var contactStageTransitions = map[ContactLifecycleStage][]ContactLifecycleStage{
+	ContactStageLead:     {ContactStageMQL, ContactStageSQL, ContactStageCustomer},
+	ContactStageMQL:      {ContactStageLead, ContactStageSQL, ContactStageCustomer},
+	ContactStageSQL:      {ContactStageLead, ContactStageMQL, ContactStageCustomer},
+	ContactStageCustomer: {ContactStageChurned},
+	ContactStageChurned:  {ContactStageLead, ContactStageMQL, ContactStageSQL, ContactStageCustomer},
+}
+
+// IsValid valida se o valor é um estágio do funil (legados não são aceitos na entrada).
+func (s ContactLifecycleStage) IsValid() bool {
+	switch s {
+	case ContactStageLead, ContactStageMQL, ContactStageSQL, ContactStageCustomer, ContactStageChurned:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo informa se o contato pode sair do estágio atual para "to".
+// Estágios legados podem migrar para qualquer estágio do funil.
+func (s ContactLifecycleStage) CanTransitionTo(to ContactLifecycleStage) bool {
+	if !to.IsValid() || s == to {
+		return false
+	}
+	allowed, ok := contactStageTransitions[s]
+	if !ok {
+		return true
+	}
+	for _, stage := range allowed {
+		if stage == to {
+			return true
+		}
+	}
+	return false
+}
 
-	"github.com/go-playground/validator/v10"
+// EmailStatus resultado da verificação de entregabilidade do email (email-verification-worker).
+type EmailStatus string
+
+const (
+	EmailStatusValid   EmailStatus = "VALID"   // sintaxe e MX ok (e aceito pelo provedor, se configurado)
+	EmailStatusRisky   EmailStatus = "RISKY"   // descartável, catch-all ou DNS indisponível na verificação
+	EmailStatusInvalid EmailStatus = "INVALID" // sintaxe inválida, domínio sem MX ou caixa recusada
 )
 
+// IsValid valida se o status é suportado.
+func (s EmailStatus) IsValid() bool {
+	switch s {
+	case EmailStatusValid, EmailStatusRisky, EmailStatusInvalid:
+		return true
+	}
+	return false
+}
+
+// PendingEmailVerification contato na fila do email-verification-worker.
+type PendingEmailVerification struct {
+	ContactID   string
+	WorkspaceID string
+	Email       string
+}
+
 // Contact representa um contato no CRM com isolamento multi-tenant.
 // Campos mapeados para o schema real do Prisma (Contact table).
 //
@@ -22,9 +109,26 @@ type Contact struct {
 	Email    string  `json:"email" db:"email"`
 	Phone    *string `json:"phone,omitempty" db:"phone"`
 
+	// Verificação do email - preenchida pelo email-verification-worker (nil = não verificado)
+	EmailStatus       *EmailStatus `json:"emailStatus"`
+	EmailStatusReason *string      `json:"emailStatusReason,omitempty"`
+	EmailCheckedAt    *time.Time   `json:"emailCheckedAt,omitempty"`
+
 	// Relacionamentos
 	CompanyID *string `json:"companyId,omitempty" db:"companyId"`
 
+	// Chave do sistema de origem do integrador - única entre os contatos ativos do workspace
+	ExternalID *string `json:"externalId" db:"externalId"`
+
+	// Funil - alterado apenas via :transition-stage após a criação
+	LifecycleStage ContactLifecycleStage `json:"lifecycleStage" db:"lifecycleStage"`
+
+	// Origem (source/utm*) - capturada na criação
+	Attribution
+
+	// Follow-up - próximo passo agendado
+	NextStep
+
 	// Actor (owner) - Conceito unificado para User ou AI Agent
 	// DB: ownerId | Conceito: ActorID
 	ActorID string `json:"actorId" db:"ownerId"`
@@ -54,6 +158,18 @@ type CreateContactRequest struct {
 	// Relacionamentos opcionais - IDs são TEXT
 	CompanyID *string `json:"companyId,omitempty"`
 
+	// Chave do integrador - Opcional: PUT .../contacts/by-external-id/{externalId} preenche pelo path
+	ExternalID *string `json:"externalId,omitempty" validate:"omitempty,min=1,max=255"`
+
+	// Estágio inicial - Opcional: se nil, LEAD
+	LifecycleStage *ContactLifecycleStage `json:"lifecycleStage,omitempty" validate:"omitempty,oneof=LEAD MQL SQL CUSTOMER CHURNED"`
+
+	// Origem - Opcional: source default "manual" (JWT) ou nome do cliente (S2S)
+	Attribution
+
+	// Follow-up - Opcional
+	NextStep
+
 	// Actor (owner) - Opcional: se nil, usa claims.ActorID do JWT
 	ActorID *string `json:"actorId,omitempty"`
 
@@ -80,6 +196,12 @@ type UpdateContactRequest struct {
 	CompanyID *string `json:"companyId,omitempty"`
 	ActorID   *string `json:"actorId,omitempty"`
 
+	// Chave do integrador - nil mantém a atual (não é possível limpá-la)
+	ExternalID *string `json:"externalId,omitempty" validate:"omitempty,min=1,max=255"`
+
+	// Follow-up - nil mantém o próximo passo atual
+	NextStep
+
 	// Metadata
 	Tags         *[]string              `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1"`
 	CustomFields map[string]interface{} `json:"customFields,omitempty"`
@@ -95,13 +217,47 @@ type ListContactsParams struct {
 
 	// Paginação
 	Limit  int
-	Cursor *string // RFC3339 timestamp ou ULID
+	Cursor *string // nextCursor da página anterior (opaco)
 	Sort   string  // "created_at:desc", "name:asc", etc.
 
 	// Filtros - IDs são TEXT
 	Query     *string // Full-text search (name + email)
 	ActorID   *string // Filter by actor (owner)
 	CompanyID *string // Filter by company
+
+	FollowerID *string // Filter by follower (?following=true → usuário autenticado)
+
+	LifecycleStage *ContactLifecycleStage // Filter by funnel stage
+	Attribution    AttributionFilter      // Filter by source/utm*
+	EmailStatus    *EmailStatus           // Filter by email verification result
+}
+
+// TransitionLifecycleStageRequest DTO para mover o contato no funil.
+type TransitionLifecycleStageRequest struct {
+	ToStage ContactLifecycleStage `json:"toStage" validate:"required,oneof=LEAD MQL SQL CUSTOMER CHURNED"`
+	Reason  *string               `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+// Validate valida o TransitionLifecycleStageRequest.
+func (r *TransitionLifecycleStageRequest) Validate() error {
+	if r.Reason != nil {
+		trimmed := strings.TrimSpace(*r.Reason)
+		r.Reason = &trimmed
+	}
+	return validate.Struct(r)
+}
+
+// LifecycleStageCount é a quantidade de contatos em um estágio.
+type LifecycleStageCount struct {
+	Stage ContactLifecycleStage `json:"stage"`
+	Count int64                 `json:"count"`
+}
+
+// ContactLifecycleReport resume o funil de contatos do workspace.
+// Stages sempre traz os cinco estágios do funil (com zero quando vazio), na ordem de progressão.
+type ContactLifecycleReport struct {
+	Stages []LifecycleStageCount `json:"stages"`
+	Total  int64                 `json:"total"`
 }
 
 // ContactListResponse resposta paginada de contatos.
@@ -125,9 +281,9 @@ func (r *CreateContactRequest) Validate() error {
 		trimmed := strings.TrimSpace(*r.Phone)
 		r.Phone = &trimmed
 	}
+	r.ExternalID = trimExternalID(r.ExternalID)
 
 	// Validação com go-playground/validator
-	validate := validator.New()
 	return validate.Struct(r)
 }
 
@@ -143,8 +299,28 @@ func (r *UpdateContactRequest) Validate() error {
 		trimmed := strings.TrimSpace(*r.Phone)
 		r.Phone = &trimmed
 	}
+	r.ExternalID = trimExternalID(r.ExternalID)
 
 	// Validação com go-playground/validator
-	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// UpsertUpdate converte o corpo de PUT .../contacts/by-external-id/{externalId} na atualização
+// aplicada quando o contato já existe. lifecycleStage (só via :transition-stage) e a origem
+// (capturada na criação) não mudam.
+func (r *CreateContactRequest) UpsertUpdate() *UpdateContactRequest {
+	update := &UpdateContactRequest{
+		FullName:     &r.FullName,
+		Email:        &r.Email,
+		Phone:        r.Phone,
+		CompanyID:    r.CompanyID,
+		ActorID:      r.ActorID,
+		ExternalID:   r.ExternalID,
+		NextStep:     r.NextStep,
+		CustomFields: r.CustomFields,
+	}
+	if r.Tags != nil {
+		update.Tags = &r.Tags
+	}
+	return update
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// BulkUpdateStatus ciclo de vida do job: PENDING → RUNNING → COMPLETED | FAILED.
+type BulkUpdateStatus string
+
+const (
+	BulkUpdateStatusPending   BulkUpdateStatus = "PENDING"
+	BulkUpdateStatusRunning   BulkUpdateStatus = "RUNNING"
+	BulkUpdateStatusCompleted BulkUpdateStatus = "COMPLETED"
+	BulkUpdateStatusFailed    BulkUpdateStatus = "FAILED"
+)
+
+// MaxBulkUpdateIDs limite de ids explícitos por requisição; acima disso, use um filtro.
+const MaxBulkUpdateIDs = 10000
+
+// ContactBulkFilter seleciona os contatos do "selecionar todos" da UI com os mesmos
+// filtros da listagem (GET /contacts). Filtro vazio = todos os contatos do workspace.
+type ContactBulkFilter struct {
+	Query          *string                `json:"q,omitempty" validate:"omitempty,max=255"`
+	ActorID        *string                `json:"actorId,omitempty"`
+	CompanyID      *string                `json:"companyId,omitempty"`
+	LifecycleStage *ContactLifecycleStage `json:"lifecycleStage,omitempty" validate:"omitempty,oneof=LEAD MQL SQL CUSTOMER CHURNED OPPORTUNITY EVANGELIST"`
+	Tag            *string                `json:"tag,omitempty" validate:"omitempty,min=1"`
+}
+
+// ContactBulkPatch alterações aplicadas a cada contato selecionado.
+// Tags são adicionadas/removidas sem tocar nas demais; actorId troca o dono;
+// lifecycleStage só é aplicado aos contatos cuja transição é permitida (os demais são ignorados).
+type ContactBulkPatch struct {
+	AddTags        []string               `json:"addTags,omitempty" validate:"omitempty,max=20,dive,min=1"`
+	RemoveTags     []string               `json:"removeTags,omitempty" validate:"omitempty,max=20,dive,min=1"`
+	ActorID        *string                `json:"actorId,omitempty" validate:"omitempty,id"`
+	LifecycleStage *ContactLifecycleStage `json:"lifecycleStage,omitempty" validate:"omitempty,oneof=LEAD MQL SQL CUSTOMER CHURNED"`
+}
+
+// IsEmpty informa se o patch não altera nada.
+func (p *ContactBulkPatch) IsEmpty() bool {
+	return len(p.AddTags) == 0 && len(p.RemoveTags) == 0 && p.ActorID == nil && p.LifecycleStage == nil
+}
+
+// BulkUpdateContactsRequest DTO de POST /contacts/:bulk-update.
+// Exatamente um entre ids e filter deve ser informado.
+type BulkUpdateContactsRequest struct {
+	IDs    []string           `json:"ids,omitempty" validate:"omitempty,dive,min=1"`
+	Filter *ContactBulkFilter `json:"filter,omitempty"`
+	Patch  ContactBulkPatch   `json:"patch"`
+}
+
+// Validate valida o BulkUpdateContactsRequest.
+// Sanitiza as tags (trim) antes da validação.
+func (r *BulkUpdateContactsRequest) Validate() error {
+	if (len(r.IDs) == 0) == (r.Filter == nil) {
+		return errors.New("exactly one of ids or filter is required")
+	}
+	if len(r.IDs) > MaxBulkUpdateIDs {
+		return errors.New("ids must have at most 10000 items; use filter instead")
+	}
+	if r.Patch.IsEmpty() {
+		return errors.New("patch must change at least one field")
+	}
+
+	for i, tag := range r.Patch.AddTags {
+		r.Patch.AddTags[i] = strings.TrimSpace(tag)
+	}
+	for i, tag := range r.Patch.RemoveTags {
+		r.Patch.RemoveTags[i] = strings.TrimSpace(tag)
+	}
+
+	return validate.Struct(r)
+}
+
+// ContactBulkUpdateJob é um job assíncrono que aplica um patch aos contatos selecionados,
+// em lotes. Total é conhecido quando o worker começa; Processed/Updated avançam a cada lote
+// (Updated conta só os contatos de fato alterados).
+type ContactBulkUpdateJob struct {
+	ID            string             `json:"id"`
+	WorkspaceID   string             `json:"workspaceId"`
+	ContactIDs    []string           `json:"contactIds,omitempty"`
+	Filter        *ContactBulkFilter `json:"filter,omitempty"`
+	Patch         ContactBulkPatch   `json:"patch"`
+	Status        BulkUpdateStatus   `json:"status"`
+	Total         *int               `json:"total"`
+	Processed     int                `json:"processed"`
+	Updated       int                `json:"updated"`
+	Progress      int                `json:"progress"` // 0-100
+	Error         *string            `json:"error"`
+	RequestedByID string             `json:"requestedById"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+	StartedAt     *time.Time         `json:"startedAt"`
+	CompletedAt   *time.Time         `json:"completedAt"`
+
+	// Cursor último id processado (interno; o worker retoma a partir dele)
+	Cursor *string `json:"-"`
+}
+
+// ComputeProgress atualiza Progress a partir de Processed/Total.
+func (j *ContactBulkUpdateJob) ComputeProgress() {
+	switch {
+	case j.Status == BulkUpdateStatusCompleted:
+		j.Progress = 100
+	case j.Total == nil || *j.Total == 0:
+		j.Progress = 0
+	default:
+		j.Progress = min(j.Processed*100 / *j.Total, 100)
+	}
+}
+
+// ContactStagesAllowedInto lista os estágios (inclusive legados) de onde um contato
+// pode ir para "to" - usado para aplicar a transição em massa direto no SQL.
+func ContactStagesAllowedInto(to ContactLifecycleStage) []string {
+	stages := []string{}
+	candidates := append([]ContactLifecycleStage{ContactStageOpportunity, ContactStageEvangelist}, ContactLifecycleStages...)
+	for _, from := range candidates {
+		if from.CanTransitionTo(to) {
+			stages = append(stages, string(from))
+		}
+	}
+	return stages
+}
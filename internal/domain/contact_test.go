@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactLifecycleStage_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from ContactLifecycleStage
+		to   ContactLifecycleStage
+		want bool
+	}{
+		{ContactStageLead, ContactStageMQL, true},
+		{ContactStageLead, ContactStageSQL, true},
+		{ContactStageLead, ContactStageCustomer, true},
+		{ContactStageLead, ContactStageChurned, false},
+		{ContactStageMQL, ContactStageLead, true},
+		{ContactStageSQL, ContactStageMQL, true},
+		{ContactStageSQL, ContactStageChurned, false},
+		{ContactStageCustomer, ContactStageChurned, true},
+		{ContactStageCustomer, ContactStageLead, false},
+		{ContactStageCustomer, ContactStageSQL, false},
+		{ContactStageChurned, ContactStageLead, true},
+		{ContactStageChurned, ContactStageCustomer, true},
+		{ContactStageLead, ContactStageLead, false},
+		{ContactStageCustomer, ContactStageCustomer, false},
+		// Legados migram para qualquer estágio do funil, mas nunca são destino
+		{ContactStageOpportunity, ContactStageSQL, true},
+		{ContactStageEvangelist, ContactStageChurned, true},
+		{ContactStageLead, ContactStageOpportunity, false},
+		{ContactStageLead, ContactStageEvangelist, false},
+		{ContactStageLead, "PROSPECT", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestContactLifecycleStage_IsValid(t *testing.T) {
+	for _, stage := range ContactLifecycleStages {
+		assert.True(t, stage.IsValid(), stage)
+	}
+	assert.False(t, ContactStageOpportunity.IsValid())
+	assert.False(t, ContactStageEvangelist.IsValid())
+	assert.False(t, ContactLifecycleStage("lead").IsValid())
+}
+
+func TestTransitionLifecycleStageRequest_Validate(t *testing.T) {
+	reason := "  assinou o contrato  "
+	req := &TransitionLifecycleStageRequest{ToStage: ContactStageCustomer, Reason: &reason}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "assinou o contrato", *req.Reason)
+
+	assert.Error(t, (&TransitionLifecycleStageRequest{}).Validate(), "toStage is required")
+	assert.Error(t, (&TransitionLifecycleStageRequest{ToStage: ContactStageOpportunity}).Validate(), "legacy stages are not targets")
+
+	long := strings.Repeat("x", 501)
+	assert.Error(t, (&TransitionLifecycleStageRequest{ToStage: ContactStageMQL, Reason: &long}).Validate())
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// WorkspaceCounters resposta de GET /counters: contadores do cabeçalho do dashboard.
+// Cached indica se os valores vieram do Redis (true) ou de uma reconciliação com o Postgres.
+type WorkspaceCounters struct {
+	OpenDeals        int64     `json:"openDeals"`
+	TasksDueToday    int64     `json:"tasksDueToday"`
+	NewLeadsThisWeek int64     `json:"newLeadsThisWeek"`
+	ReconciledAt     time.Time `json:"reconciledAt"`
+	Cached           bool      `json:"cached"`
+}
+
+// CounterPeriod delimita os contadores dependentes de data (UTC):
+// tarefas com vencimento em [DayStart, DayEnd) e contatos criados desde WeekStart (segunda-feira).
+type CounterPeriod struct {
+	DayStart  time.Time
+	DayEnd    time.Time
+	WeekStart time.Time
+}
+
+// NewCounterPeriod retorna o período do dia corrente de now.
+func NewCounterPeriod(now time.Time) CounterPeriod {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // segunda = 0
+	return CounterPeriod{
+		DayStart:  day,
+		DayEnd:    day.AddDate(0, 0, 1),
+		WeekStart: day.AddDate(0, 0, -offset),
+	}
+}
+
+// Key identifica o período no cache; a virada do dia troca a chave.
+func (p CounterPeriod) Key() string {
+	return p.DayStart.Format("2006-01-02")
+}
+
+// CounterDeltas são as variações incrementais aplicadas aos contadores em cache.
+type CounterDeltas struct {
+	OpenDeals        int64
+	TasksDueToday    int64
+	NewLeadsThisWeek int64
+}
+
+// IsZero reporta se não há variação a aplicar.
+func (d CounterDeltas) IsZero() bool {
+	return d == CounterDeltas{}
+}
+
+// DealCounterDelta calcula a variação de openDeals entre dois estados do negócio (nil = inexistente).
+func DealCounterDelta(before, after *Deal) CounterDeltas {
+	return CounterDeltas{OpenDeals: countsAsOpenDeal(after) - countsAsOpenDeal(before)}
+}
+
+// TaskCounterDelta calcula a variação de tasksDueToday entre dois estados da tarefa (nil = inexistente).
+func (p CounterPeriod) TaskCounterDelta(before, after *Task) CounterDeltas {
+	return CounterDeltas{TasksDueToday: p.countsAsTaskDueToday(after) - p.countsAsTaskDueToday(before)}
+}
+
+func countsAsOpenDeal(d *Deal) int64 {
+	if d != nil && d.Stage == DealStageOpen {
+		return 1
+	}
+	return 0
+}
+
+func (p CounterPeriod) countsAsTaskDueToday(t *Task) int64 {
+	if t == nil || t.DeletedAt != nil || t.DueDate == nil {
+		return 0
+	}
+	if t.Status != TaskStatusTodo && t.Status != TaskStatusInProgress {
+		return 0
+	}
+	if t.DueDate.Before(p.DayStart) || !t.DueDate.Before(p.DayEnd) {
+		return 0
+	}
+	return 1
+}
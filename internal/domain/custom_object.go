@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxCustomObjectTextValue tamanho máximo de um valor de campo text.
+const MaxCustomObjectTextValue = 10000
+
+var (
+	customObjectKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,62}$`)
+	customFieldKeyPattern  = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+)
+
+// CustomFieldType tipo de um campo de objeto customizado.
+type CustomFieldType string
+
+const (
+	CustomFieldText     CustomFieldType = "text"
+	CustomFieldNumber   CustomFieldType = "number"
+	CustomFieldBoolean  CustomFieldType = "boolean"
+	CustomFieldDate     CustomFieldType = "date"     // YYYY-MM-DD
+	CustomFieldSelect   CustomFieldType = "select"   // um dos valores de options
+	CustomFieldRelation CustomFieldType = "relation" // ID de um registro da entidade target
+)
+
+// CustomRelationTarget entidades núcleo que um campo de relacionamento pode referenciar.
+type CustomRelationTarget string
+
+const (
+	CustomRelationContact CustomRelationTarget = "contact"
+	CustomRelationCompany CustomRelationTarget = "company"
+	CustomRelationDeal    CustomRelationTarget = "deal"
+)
+
+// CustomObjectField definição de um campo. Options só vale para select; Target só para relation.
+type CustomObjectField struct {
+	Key      string                `json:"key" validate:"required"`
+	Label    string                `json:"label" validate:"required,min=1,max=255"`
+	Type     CustomFieldType       `json:"type" validate:"required,oneof=text number boolean date select relation"`
+	Required bool                  `json:"required"`
+	Options  []string              `json:"options,omitempty" validate:"omitempty,max=200,dive,min=1,max=255"`
+	Target   *CustomRelationTarget `json:"target,omitempty" validate:"omitempty,oneof=contact company deal"`
+}
+
+// validateShape verifica a chave e os atributos exigidos por tipo de campo.
+func (f CustomObjectField) validateShape(index int) error {
+	if !customFieldKeyPattern.MatchString(f.Key) {
+		return fmt.Errorf("fields[%d]: key must start with a letter and contain only letters, digits and underscores (max 64 chars)", index)
+	}
+	switch f.Type {
+	case CustomFieldSelect:
+		if len(f.Options) == 0 {
+			return fmt.Errorf("fields[%d]: select fields require options", index)
+		}
+	case CustomFieldRelation:
+		if f.Target == nil {
+			return fmt.Errorf("fields[%d]: relation fields require target", index)
+		}
+	}
+	return nil
+}
+
+func validateCustomObjectFields(fields []CustomObjectField) error {
+	seen := make(map[string]bool, len(fields))
+	for i, f := range fields {
+		if err := f.validateShape(i); err != nil {
+			return err
+		}
+		if seen[f.Key] {
+			return fmt.Errorf("fields[%d]: duplicate key %q", i, f.Key)
+		}
+		seen[f.Key] = true
+	}
+	return nil
+}
+
+// CustomObjectType definição de um objeto customizado do workspace.
+// Key é imutável e identifica o objeto em /objects/{objectKey}.
+type CustomObjectType struct {
+	ID          string              `json:"id"`
+	WorkspaceID string              `json:"workspaceId"`
+	Key         string              `json:"key"`
+	Name        string              `json:"name"`
+	Description *string             `json:"description"`
+	Fields      []CustomObjectField `json:"fields"`
+	CreatedByID string              `json:"createdById"`
+	UpdatedByID *string             `json:"updatedById"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+	DeletedAt   *time.Time          `json:"deletedAt,omitempty"`
+}
+
+// Field retorna a definição do campo pela chave.
+func (t *CustomObjectType) Field(key string) (CustomObjectField, bool) {
+	for _, f := range t.Fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return CustomObjectField{}, false
+}
+
+// ValidateData valida os valores de um registro contra os campos do objeto.
+// Na criação (partial=false) os campos obrigatórios devem estar presentes; no PATCH
+// (partial=true) apenas os campos enviados são verificados e null remove o valor.
+func (t *CustomObjectType) ValidateData(data map[string]any, partial bool) error {
+	for key, value := range data {
+		field, ok := t.Field(key)
+		if !ok {
+			return fmt.Errorf("data.%s: unknown field", key)
+		}
+		if value == nil {
+			if field.Required {
+				return fmt.Errorf("data.%s: field is required", key)
+			}
+			continue
+		}
+		if err := field.validateValue(value); err != nil {
+			return fmt.Errorf("data.%s: %w", key, err)
+		}
+	}
+	if partial {
+		return nil
+	}
+	for _, f := range t.Fields {
+		if f.Required && data[f.Key] == nil {
+			return fmt.Errorf("data.%s: field is required", f.Key)
+		}
+	}
+	return nil
+}
+
+// validateValue verifica o tipo JSON do valor (números chegam como float64).
+func (f CustomObjectField) validateValue(value any) error {
+	switch f.Type {
+	case CustomFieldText:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		if len(s) > MaxCustomObjectTextValue {
+			return fmt.Errorf("must be at most %d characters", MaxCustomObjectTextValue)
+		}
+	case CustomFieldNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("must be a number")
+		}
+	case CustomFieldBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+	case CustomFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a date (YYYY-MM-DD)")
+		}
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return fmt.Errorf("must be a date (YYYY-MM-DD)")
+		}
+	case CustomFieldSelect:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be one of: %s", strings.Join(f.Options, ", "))
+		}
+		for _, option := range f.Options {
+			if option == s {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of: %s", strings.Join(f.Options, ", "))
+	case CustomFieldRelation:
+		if s, ok := value.(string); !ok || strings.TrimSpace(s) == "" {
+			return fmt.Errorf("must be the id of a %s", *f.Target)
+		}
+	}
+	return nil
+}
+
+// CustomObjectTypeListResponse resposta da listagem de objetos (sem paginação: poucos por workspace).
+type CustomObjectTypeListResponse struct {
+	Data []CustomObjectType `json:"data"`
+}
+
+// CreateCustomObjectTypeRequest DTO para criação de objeto customizado.
+type CreateCustomObjectTypeRequest struct {
+	Key         string              `json:"key" validate:"required"`
+	Name        string              `json:"name" validate:"required,min=1,max=255"`
+	Description *string             `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Fields      []CustomObjectField `json:"fields" validate:"max=100,dive"`
+}
+
+// Validate sanitiza e valida o request, incluindo a chave do objeto e os campos.
+func (r *CreateCustomObjectTypeRequest) Validate() error {
+	r.Key = strings.TrimSpace(r.Key)
+	r.Name = strings.TrimSpace(r.Name)
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if !customObjectKeyPattern.MatchString(r.Key) {
+		return fmt.Errorf("key must start with a lowercase letter and contain only lowercase letters, digits and underscores (2-63 chars)")
+	}
+	return validateCustomObjectFields(r.Fields)
+}
+
+// UpdateCustomObjectTypeRequest DTO para atualização parcial (nil = não modificar).
+// Fields substitui a lista inteira; valores de campos removidos permanecem nos registros, mas deixam
+// de ser aceitos na escrita.
+type UpdateCustomObjectTypeRequest struct {
+	Name        *string             `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string             `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Fields      []CustomObjectField `json:"fields,omitempty" validate:"omitempty,max=100,dive"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateCustomObjectTypeRequest) Validate() error {
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateCustomObjectFields(r.Fields)
+}
+
+// CustomObjectRecord registro de um objeto customizado.
+type CustomObjectRecord struct {
+	ID           string         `json:"id"`
+	WorkspaceID  string         `json:"workspaceId"`
+	ObjectTypeID string         `json:"objectTypeId"`
+	ObjectKey    string         `json:"objectKey"`
+	Name         string         `json:"name"`
+	Data         map[string]any `json:"data"`
+	CreatedByID  string         `json:"createdById"`
+	UpdatedByID  *string        `json:"updatedById"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+}
+
+// ListCustomObjectRecordsParams parâmetros de GET /objects/{objectKey}.
+// Filters compara valores de campos por igualdade (?filter[campo]=valor), já convertidos pelo service.
+type ListCustomObjectRecordsParams struct {
+	WorkspaceID  string
+	ObjectTypeID string
+	Query        *string
+	Filters      map[string]any
+	Cursor       *time.Time
+	Limit        int
+}
+
+// CustomObjectRecordListResponse resposta paginada de registros (mais recentes primeiro).
+type CustomObjectRecordListResponse struct {
+	Data []CustomObjectRecord `json:"data"`
+	Meta struct {
+		HasNextPage bool    `json:"hasNextPage"`
+		NextCursor  *string `json:"nextCursor,omitempty"`
+	} `json:"meta"`
+}
+
+// CreateCustomObjectRecordRequest DTO para criação de registro.
+type CreateCustomObjectRecordRequest struct {
+	Name string         `json:"name" validate:"required,min=1,max=255"`
+	Data map[string]any `json:"data"`
+}
+
+// Validate sanitiza e valida o request (os campos de data são validados contra o objeto no service).
+func (r *CreateCustomObjectRecordRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Data == nil {
+		r.Data = map[string]any{}
+	}
+	return validate.Struct(r)
+}
+
+// UpdateCustomObjectRecordRequest DTO para atualização parcial. Data é mesclado ao registro:
+// campos ausentes não mudam e null remove o valor.
+type UpdateCustomObjectRecordRequest struct {
+	Name *string        `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Data map[string]any `json:"data,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateCustomObjectRecordRequest) Validate() error {
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+	return validate.Struct(r)
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func customObjectFixture() *CustomObjectType {
+	target := CustomRelationCompany
+	return &CustomObjectType{
+		Key:  "project",
+		Name: "Projeto",
+		Fields: []CustomObjectField{
+			{Key: "code", Label: "Código", Type: CustomFieldText, Required: true},
+			{Key: "budget", Label: "Orçamento", Type: CustomFieldNumber},
+			{Key: "active", Label: "Ativo", Type: CustomFieldBoolean},
+			{Key: "kickoff", Label: "Início", Type: CustomFieldDate},
+			{Key: "phase", Label: "Fase", Type: CustomFieldSelect, Options: []string{"discovery", "delivery"}},
+			{Key: "client", Label: "Cliente", Type: CustomFieldRelation, Target: &target},
+		},
+	}
+}
+
+func TestCustomObjectType_ValidateData(t *testing.T) {
+	objectType := customObjectFixture()
+
+	tests := []struct {
+		name    string
+		data    map[string]any
+		partial bool
+		wantErr string
+	}{
+		{"all fields", map[string]any{
+			"code": "P-1", "budget": 1500.5, "active": true, "kickoff": "2026-03-02", "phase": "delivery", "client": "cmp_1",
+		}, false, ""},
+		{"only the required field", map[string]any{"code": "P-1"}, false, ""},
+		{"missing required field", map[string]any{"budget": 10.0}, false, "data.code: field is required"},
+		{"partial update skips required check", map[string]any{"budget": 10.0}, true, ""},
+		{"null clears an optional field", map[string]any{"budget": nil}, true, ""},
+		{"null on a required field", map[string]any{"code": nil}, true, "data.code: field is required"},
+		{"unknown field", map[string]any{"code": "P-1", "owner": "x"}, false, "data.owner: unknown field"},
+		{"number as string", map[string]any{"budget": "10"}, true, "data.budget: must be a number"},
+		{"boolean as string", map[string]any{"active": "true"}, true, "data.active: must be a boolean"},
+		{"invalid date", map[string]any{"kickoff": "02/03/2026"}, true, "data.kickoff: must be a date"},
+		{"option not listed", map[string]any{"phase": "closing"}, true, "data.phase: must be one of: discovery, delivery"},
+		{"blank relation", map[string]any{"client": " "}, true, "data.client: must be the id of a company"},
+		{"text too long", map[string]any{"code": strings.Repeat("a", MaxCustomObjectTextValue+1)}, true, "data.code: must be at most"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := objectType.ValidateData(tt.data, tt.partial)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateCustomObjectTypeRequest_Validate(t *testing.T) {
+	target := CustomRelationDeal
+
+	tests := []struct {
+		name    string
+		req     CreateCustomObjectTypeRequest
+		wantErr string
+	}{
+		{"valid", CreateCustomObjectTypeRequest{Key: " project_v2 ", Name: " Projeto ", Fields: customObjectFixture().Fields}, ""},
+		{"no fields", CreateCustomObjectTypeRequest{Key: "project", Name: "Projeto"}, ""},
+		{"uppercase key", CreateCustomObjectTypeRequest{Key: "Project", Name: "Projeto"}, "key must start with a lowercase letter"},
+		{"single character key", CreateCustomObjectTypeRequest{Key: "p", Name: "Projeto"}, "key must start with a lowercase letter"},
+		{"invalid field key", CreateCustomObjectTypeRequest{Key: "project", Name: "Projeto", Fields: []CustomObjectField{
+			{Key: "1st", Label: "Primeiro", Type: CustomFieldText},
+		}}, "fields[0]: key must start with a letter"},
+		{"duplicate field key", CreateCustomObjectTypeRequest{Key: "project", Name: "Projeto", Fields: []CustomObjectField{
+			{Key: "code", Label: "Código", Type: CustomFieldText},
+			{Key: "code", Label: "Código 2", Type: CustomFieldNumber},
+		}}, `fields[1]: duplicate key "code"`},
+		{"select without options", CreateCustomObjectTypeRequest{Key: "project", Name: "Projeto", Fields: []CustomObjectField{
+			{Key: "phase", Label: "Fase", Type: CustomFieldSelect},
+		}}, "fields[0]: select fields require options"},
+		{"relation without target", CreateCustomObjectTypeRequest{Key: "project", Name: "Projeto", Fields: []CustomObjectField{
+			{Key: "deal", Label: "Negócio", Type: CustomFieldRelation},
+		}}, "fields[0]: relation fields require target"},
+		{"relation with target", CreateCustomObjectTypeRequest{Key: "project", Name: "Projeto", Fields: []CustomObjectField{
+			{Key: "deal", Label: "Negócio", Type: CustomFieldRelation, Target: &target},
+		}}, ""},
+		{"unknown field type", CreateCustomObjectTypeRequest{Key: "project", Name: "Projeto", Fields: []CustomObjectField{
+			{Key: "file", Label: "Arquivo", Type: "file"},
+		}}, "fields[0].type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, strings.TrimSpace(tt.req.Key), tt.req.Key)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateCustomObjectRecordRequest_Validate(t *testing.T) {
+	req := CreateCustomObjectRecordRequest{Name: "  Implantação  "}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "Implantação", req.Name)
+	assert.NotNil(t, req.Data, "data defaults to an empty object")
+
+	assert.Error(t, (&CreateCustomObjectRecordRequest{Name: "   "}).Validate())
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// dateOnlyLayout formato de data local dos filtros (?from=2026-10-18)
+const dateOnlyLayout = "2006-01-02"
+
+var errInvalidDateBound = errors.New("must be an RFC3339 timestamp or a YYYY-MM-DD date")
+
+// DateBound limite de um filtro de data (from/to/since). Um instante RFC3339 vale como está; uma data
+// YYYY-MM-DD é resolvida no fuso da request (?tz= ou o fuso do workspace), então "hoje" em BRT
+// começa às 03:00 UTC.
+type DateBound struct {
+	instant  time.Time
+	date     time.Time // meia-noite UTC da data; só ano/mês/dia importam
+	dateOnly bool
+}
+
+// ParseDateBound lê um instante RFC3339 ou uma data YYYY-MM-DD.
+func ParseDateBound(value string) (DateBound, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return DateBound{instant: t}, nil
+	}
+	if d, err := time.Parse(dateOnlyLayout, value); err == nil {
+		return DateBound{date: d, dateOnly: true}, nil
+	}
+	return DateBound{}, errInvalidDateBound
+}
+
+// IsDate reporta se o limite é uma data local (depende do fuso).
+func (b DateBound) IsDate() bool {
+	return b.dateOnly
+}
+
+// Start limite inicial (inclusivo) em UTC: o instante, ou o início do dia em loc.
+func (b DateBound) Start(loc *time.Location) time.Time {
+	if !b.dateOnly {
+		return b.instant.UTC()
+	}
+	return time.Date(b.date.Year(), b.date.Month(), b.date.Day(), 0, 0, 0, 0, locationOrUTC(loc)).UTC()
+}
+
+// End limite final (exclusivo) em UTC: o instante, ou o início do dia seguinte em loc, de modo que
+// to=2026-10-18 inclui o dia inteiro.
+func (b DateBound) End(loc *time.Location) time.Time {
+	if !b.dateOnly {
+		return b.instant.UTC()
+	}
+	return time.Date(b.date.Year(), b.date.Month(), b.date.Day()+1, 0, 0, 0, 0, locationOrUTC(loc)).UTC()
+}
+
+// StartOfDay início (em UTC) do dia de t no fuso loc.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(locationOrUTC(loc))
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location()).UTC()
+}
+
+// LocationName nome IANA usado nas agregações por mês no SQL (nil = UTC).
+func LocationName(loc *time.Location) string {
+	return locationOrUTC(loc).String()
+}
+
+func locationOrUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
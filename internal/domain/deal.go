@@ -69,6 +69,31 @@ type Deal struct {
 	CreatedAt         time.Time  `json:"createdAt"`
 	UpdatedAt         time.Time  `json:"updatedAt"`
 
+	// Origem (source/utm*) - capturada na criação
+	Attribution
+
+	// Follow-up - próximo passo agendado
+	NextStep
+
+	// Parado desde (deal-rotting-worker); nil enquanto o deal tem atividade recente
+	RottingSince *time.Time `json:"rottingSince"`
+
+	// Forecast - categoria efetiva; Overridden = definida manualmente (não acompanha a etapa)
+	ForecastCategory           ForecastCategory `json:"forecastCategory"`
+	ForecastCategoryOverridden bool             `json:"forecastCategoryOverridden"`
+
+	// Chave do sistema de origem do integrador - única entre os deals ativos do workspace
+	ExternalID *string `json:"externalId"`
+
+	// SLA do ticket (somente deals em estágios TICKET com metas; calculado na leitura)
+	SLA *DealSLA `json:"sla,omitempty"`
+
+	// Participantes (somente com ?expand=participants)
+	Participants []DealParticipant `json:"participants,omitempty"`
+
+	// Campos calculados do workspace (ComputedField), avaliados na leitura
+	Computed map[string]any `json:"computed,omitempty"`
+
 	// Relational fields (Joins)
 	ContactName *string `json:"contactName,omitempty"`
 	CompanyName *string `json:"companyName,omitempty"`
@@ -89,7 +114,7 @@ type DealStageHistory struct {
 // CreateDealRequest é o DTO para criação de Negócios.
 type CreateDealRequest struct {
 	Name              string     `json:"name" validate:"required"`
-	PipelineID        string     `json:"pipelineId" validate:"required"`
+	PipelineID        string     `json:"pipelineId" validate:"required,id"`
 	StageID           *string    `json:"stageId"`
 	ContactID         *string    `json:"contactId"`
 	CompanyID         *string    `json:"companyId"`
@@ -99,6 +124,18 @@ type CreateDealRequest struct {
 	ExpectedCloseDate *time.Time `json:"expectedCloseDate"`
 	Description       *string    `json:"description"`
 	OwnerID           *string    `json:"ownerId"`
+
+	// Origem - Opcional: source default "manual" (JWT) ou nome do cliente (S2S)
+	Attribution
+
+	// Follow-up - Opcional
+	NextStep
+
+	// Forecast - Opcional: categoria manual; sem ela é derivada da etapa
+	ForecastCategory *ForecastCategory `json:"forecastCategory"`
+
+	// Chave do integrador - Opcional: PUT .../deals/by-external-id/{externalId} preenche pelo path
+	ExternalID *string `json:"externalId" validate:"omitempty,min=1,max=255"`
 }
 
 // UpdateDealRequest é o DTO para atualização de Negócios.
@@ -110,12 +147,59 @@ type UpdateDealRequest struct {
 	ExpectedCloseDate *time.Time `json:"expectedCloseDate"`
 	Description       *string    `json:"description"`
 	OwnerID           *string    `json:"ownerId"`
+
+	// Follow-up - nil mantém o próximo passo atual
+	NextStep
+
+	// Forecast - nil mantém; AUTO volta a derivar da etapa
+	ForecastCategory *ForecastCategory `json:"forecastCategory"`
+
+	// Chave do integrador - nil mantém a atual (não é possível limpá-la)
+	ExternalID *string `json:"externalId" validate:"omitempty,min=1,max=255"`
 }
 
 // UpdateDealStageRequest é o DTO para movimentação de estágio (Pipeline).
 type UpdateDealStageRequest struct {
-	StageID   string     `json:"stageId" validate:"required"`
+	StageID   string     `json:"stageId" validate:"required,id"`
 	Stage     *DealStage `json:"stage"` // OPEN, WON, LOST
 	Reason    *string    `json:"reason"`
 	ClosedAt  *time.Time `json:"closedAt"`
 }
+
+// RottingDeal é um deal recém-marcado como parado pelo deal-rotting-worker.
+// LastActivityAt é a última criação, mudança de estágio ou atividade na timeline;
+// RottingSince = LastActivityAt + RottingDays (dias úteis) do estágio.
+type RottingDeal struct {
+	ID             string
+	WorkspaceID    string
+	PipelineID     string
+	StageID        *string
+	ContactID      *string
+	CompanyID      *string
+	OwnerID        *string
+	CreatedByID    string
+	RottingDays    int
+	LastActivityAt time.Time
+	RottingSince   time.Time
+}
+
+// UpsertUpdate converte o corpo de PUT .../deals/by-external-id/{externalId} na atualização
+// aplicada quando o deal já existe. Pipeline, etapa e vínculos (contato/empresa) não mudam:
+// a etapa é movida por :move, que registra o histórico.
+func (r *CreateDealRequest) UpsertUpdate() *UpdateDealRequest {
+	update := &UpdateDealRequest{
+		Name:              &r.Name,
+		Value:             r.Value,
+		Probability:       r.Probability,
+		ExpectedCloseDate: r.ExpectedCloseDate,
+		Description:       r.Description,
+		OwnerID:           r.OwnerID,
+		NextStep:          r.NextStep,
+		ForecastCategory:  r.ForecastCategory,
+		ExternalID:        r.ExternalID,
+	}
+	if r.Currency != "" {
+		update.Currency = &r.Currency
+	}
+	return update
+}
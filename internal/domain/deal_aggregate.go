@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DealGroupBy define a dimensão de GET /deals?groupBy=, que retorna buckets agregados em vez de deals.
+type DealGroupBy string
+
+const (
+	DealGroupByOwner DealGroupBy = "owner" // ownerId (null = sem dono)
+	DealGroupByStage DealGroupBy = "stage" // stageId, na ordem do pipeline
+	DealGroupByMonth DealGroupBy = "month" // YYYY-MM de createdAt no fuso da request
+)
+
+// IsValid valida se a dimensão é suportada.
+func (g DealGroupBy) IsValid() bool {
+	switch g {
+	case DealGroupByOwner, DealGroupByStage, DealGroupByMonth:
+		return true
+	}
+	return false
+}
+
+// DealAggregate função de agregação de GET /deals?aggregate=.
+type DealAggregate string
+
+const (
+	DealAggregateCount    DealAggregate = "count"
+	DealAggregateSumValue DealAggregate = "sum(value)"
+)
+
+// ParseDealAggregates lê a lista separada por vírgula de aggregate (vazia = count).
+// Repetições são ignoradas.
+func ParseDealAggregates(raw string) ([]DealAggregate, error) {
+	if strings.TrimSpace(raw) == "" {
+		return []DealAggregate{DealAggregateCount}, nil
+	}
+	aggregates := []DealAggregate{}
+	seen := map[DealAggregate]bool{}
+	for _, part := range strings.Split(raw, ",") {
+		agg := DealAggregate(strings.ReplaceAll(strings.ToLower(part), " ", ""))
+		switch agg {
+		case DealAggregateCount, DealAggregateSumValue:
+		default:
+			return nil, errors.New("aggregate must be a comma-separated list of: count, sum(value)")
+		}
+		if !seen[agg] {
+			seen[agg] = true
+			aggregates = append(aggregates, agg)
+		}
+	}
+	return aggregates, nil
+}
+
+// DealAggregateParams parâmetros de GET /deals?groupBy=. Os filtros são os mesmos da listagem;
+// Location define os meses de groupBy=month (nil = UTC).
+type DealAggregateParams struct {
+	WorkspaceID string
+	GroupBy     DealGroupBy
+	Aggregates  []DealAggregate
+	Location    *time.Location
+	PipelineID  *string
+	StageID     *string
+	OwnerID     *string
+	FollowerID  *string
+	Query       *string
+	RottingOnly bool
+	Attribution AttributionFilter
+}
+
+// DealBucket totais de uma chave da dimensão. Somente os agregados pedidos são preenchidos;
+// SumValue soma deal.value sem conversão de moeda. Label é o nome da etapa em groupBy=stage.
+type DealBucket struct {
+	Key      *string  `json:"key"`
+	Label    *string  `json:"label,omitempty"`
+	Count    *int64   `json:"count,omitempty"`
+	SumValue *float64 `json:"sumValue,omitempty"`
+}
+
+// DealAggregation resposta de GET /deals?groupBy=.
+type DealAggregation struct {
+	GroupBy    DealGroupBy     `json:"groupBy"`
+	Aggregates []DealAggregate `json:"aggregates"`
+	Buckets    []DealBucket    `json:"buckets"`
+}
+
+// NewDealAggregation mantém em cada bucket apenas os agregados pedidos.
+func NewDealAggregation(params DealAggregateParams, buckets []DealBucket) *DealAggregation {
+	var count, sum bool
+	for _, agg := range params.Aggregates {
+		switch agg {
+		case DealAggregateCount:
+			count = true
+		case DealAggregateSumValue:
+			sum = true
+		}
+	}
+	for i := range buckets {
+		if !count {
+			buckets[i].Count = nil
+		}
+		if sum {
+			if buckets[i].SumValue != nil {
+				v := roundMoney(*buckets[i].SumValue)
+				buckets[i].SumValue = &v
+			}
+		} else {
+			buckets[i].SumValue = nil
+		}
+	}
+	return &DealAggregation{GroupBy: params.GroupBy, Aggregates: params.Aggregates, Buckets: buckets}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDealGroupBy_IsValid(t *testing.T) {
+	for _, g := range []DealGroupBy{DealGroupByOwner, DealGroupByStage, DealGroupByMonth} {
+		assert.True(t, g.IsValid(), g)
+	}
+	assert.False(t, DealGroupBy("pipeline").IsValid())
+	assert.False(t, DealGroupBy("Owner").IsValid())
+}
+
+func TestParseDealAggregates(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    []DealAggregate
+		wantErr bool
+	}{
+		{"", []DealAggregate{DealAggregateCount}, false},
+		{"  ", []DealAggregate{DealAggregateCount}, false},
+		{"sum(value)", []DealAggregate{DealAggregateSumValue}, false},
+		{"sum(value),count", []DealAggregate{DealAggregateSumValue, DealAggregateCount}, false},
+		{"COUNT, Sum( value )", []DealAggregate{DealAggregateCount, DealAggregateSumValue}, false},
+		{"count,count", []DealAggregate{DealAggregateCount}, false},
+		{"avg(value)", nil, true},
+		{"count,", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseDealAggregates(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewDealAggregation(t *testing.T) {
+	buckets := func() []DealBucket {
+		count, sum := int64(3), 1234.5678
+		return []DealBucket{{Key: strPtr("usr_1"), Count: &count, SumValue: &sum}}
+	}
+
+	got := NewDealAggregation(DealAggregateParams{
+		GroupBy:    DealGroupByOwner,
+		Aggregates: []DealAggregate{DealAggregateCount},
+	}, buckets())
+	assert.Equal(t, DealGroupByOwner, got.GroupBy)
+	require.Len(t, got.Buckets, 1)
+	assert.Equal(t, int64(3), *got.Buckets[0].Count)
+	assert.Nil(t, got.Buckets[0].SumValue)
+
+	got = NewDealAggregation(DealAggregateParams{
+		GroupBy:    DealGroupByOwner,
+		Aggregates: []DealAggregate{DealAggregateSumValue},
+	}, buckets())
+	assert.Nil(t, got.Buckets[0].Count)
+	assert.Equal(t, 1234.57, *got.Buckets[0].SumValue)
+}
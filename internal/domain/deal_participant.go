@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// DealParticipantRole papel de um contato em um negócio.
+// Schema: "DealParticipant"."role" ('DECISION_MAKER', 'CHAMPION', 'BILLING', 'INFLUENCER', 'OTHER')
+type DealParticipantRole string
+
+const (
+	ParticipantDecisionMaker DealParticipantRole = "DECISION_MAKER" // Aprova a compra
+	ParticipantChampion      DealParticipantRole = "CHAMPION"       // Defende a compra internamente
+	ParticipantBilling       DealParticipantRole = "BILLING"        // Responsável financeiro/faturamento
+	ParticipantInfluencer    DealParticipantRole = "INFLUENCER"     // Influencia sem decidir
+	ParticipantOther         DealParticipantRole = "OTHER"
+)
+
+// IsValid valida se o valor de DealParticipantRole é válido.
+func (r DealParticipantRole) IsValid() bool {
+	switch r {
+	case ParticipantDecisionMaker, ParticipantChampion, ParticipantBilling, ParticipantInfluencer, ParticipantOther:
+		return true
+	}
+	return false
+}
+
+// DealExpandParticipants valor de ?expand= que inclui os participantes no deal.
+const DealExpandParticipants = "participants"
+
+// DealParticipant contato envolvido em um negócio, com o seu papel.
+// Deal.ContactID segue sendo o contato principal; participantes complementam.
+type DealParticipant struct {
+	ID          string              `json:"id"`
+	WorkspaceID string              `json:"workspaceId"`
+	DealID      string              `json:"dealId"`
+	ContactID   string              `json:"contactId"`
+	Role        DealParticipantRole `json:"role"`
+	CreatedByID string              `json:"createdById"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+
+	// Relational fields (Joins)
+	ContactName  *string `json:"contactName,omitempty"`
+	ContactEmail *string `json:"contactEmail,omitempty"`
+}
+
+// AddDealParticipantRequest DTO para adicionar um contato ao negócio.
+type AddDealParticipantRequest struct {
+	ContactID string              `json:"contactId" validate:"required,id"`
+	Role      DealParticipantRole `json:"role" validate:"omitempty,oneof=DECISION_MAKER CHAMPION BILLING INFLUENCER OTHER"`
+}
+
+// Validate sanitiza e valida o request (papel padrão OTHER).
+func (r *AddDealParticipantRequest) Validate() error {
+	r.ContactID = strings.TrimSpace(r.ContactID)
+	if r.Role == "" {
+		r.Role = ParticipantOther
+	}
+	return validate.Struct(r)
+}
+
+// UpdateDealParticipantRequest DTO para trocar o papel de um participante.
+type UpdateDealParticipantRequest struct {
+	Role DealParticipantRole `json:"role" validate:"required,oneof=DECISION_MAKER CHAMPION BILLING INFLUENCER OTHER"`
+}
+
+// Validate valida o request.
+func (r *UpdateDealParticipantRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// DealParticipantListResponse resposta da listagem de participantes de um negócio.
+type DealParticipantListResponse struct {
+	Data []DealParticipant `json:"data"`
+}
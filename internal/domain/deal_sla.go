@@ -0,0 +1,134 @@
+package domain
+
+import "time"
+
+// SLAStatus estado de um timer de SLA de ticket.
+type SLAStatus string
+
+const (
+	SLAStatusPending  SLAStatus = "PENDING"  // Em aberto, dentro do prazo
+	SLAStatusMet      SLAStatus = "MET"      // Concluído dentro do prazo
+	SLAStatusBreached SLAStatus = "BREACHED" // Prazo vencido (em aberto ou concluído com atraso)
+)
+
+// SLATimerKind identifica o timer de SLA.
+type SLATimerKind string
+
+const (
+	SLATimerFirstResponse SLATimerKind = "firstResponse"
+	SLATimerResolution    SLATimerKind = "resolution"
+)
+
+// IsValid valida se o timer é suportado.
+func (k SLATimerKind) IsValid() bool {
+	return k == SLATimerFirstResponse || k == SLATimerResolution
+}
+
+// SLAResponseActivityTypes atividades da timeline que contam como primeira resposta.
+// Notas são internas e eventos do sistema não são resposta ao cliente.
+var SLAResponseActivityTypes = []ActivityType{
+	ActivityTypeEmail,
+	ActivityTypeCall,
+	ActivityTypeMessage,
+	ActivityTypeMeeting,
+}
+
+// SLATimer é um timer de SLA calculado. DueAt = abertura do ticket + TargetMinutes de
+// expediente (o timer pausa fora do horário comercial e em feriados do workspace).
+type SLATimer struct {
+	TargetMinutes int        `json:"targetMinutes"`
+	DueAt         time.Time  `json:"dueAt"`
+	CompletedAt   *time.Time `json:"completedAt"`
+	Status        SLAStatus  `json:"status"`
+}
+
+// DealSLA timers de SLA de um deal em estágio TICKET com metas configuradas.
+// Timers sem meta no estágio são omitidos.
+type DealSLA struct {
+	FirstResponse *SLATimer `json:"firstResponse,omitempty"`
+	Resolution    *SLATimer `json:"resolution,omitempty"`
+}
+
+// Timer retorna o timer pelo tipo (nil se não configurado).
+func (s *DealSLA) Timer(kind SLATimerKind) *SLATimer {
+	if kind == SLATimerFirstResponse {
+		return s.FirstResponse
+	}
+	return s.Resolution
+}
+
+// DealSLAInput dados de um ticket para calcular os timers.
+// As metas vêm do estágio atual; a contagem começa na abertura do ticket (createdAt).
+// FirstResponseAt é a primeira atividade de SLAResponseActivityTypes; ResolvedAt é o
+// closedAt quando o ticket não está mais OPEN.
+type DealSLAInput struct {
+	DealID                  string
+	Name                    string
+	PipelineID              string
+	StageID                 *string
+	OwnerID                 *string
+	OpenedAt                time.Time
+	FirstResponseSLAMinutes *int
+	ResolutionSLAMinutes    *int
+	FirstResponseAt         *time.Time
+	ResolvedAt              *time.Time
+}
+
+// Compute calcula os timers em now usando o calendário do workspace (nil = 24x7).
+func (in DealSLAInput) Compute(cal *BusinessCalendar, now time.Time) *DealSLA {
+	return &DealSLA{
+		FirstResponse: newSLATimer(cal, in.OpenedAt, in.FirstResponseSLAMinutes, in.FirstResponseAt, now),
+		Resolution:    newSLATimer(cal, in.OpenedAt, in.ResolutionSLAMinutes, in.ResolvedAt, now),
+	}
+}
+
+func newSLATimer(cal *BusinessCalendar, start time.Time, targetMinutes *int, completedAt *time.Time, now time.Time) *SLATimer {
+	if targetMinutes == nil {
+		return nil
+	}
+
+	t := &SLATimer{
+		TargetMinutes: *targetMinutes,
+		DueAt:         cal.AddWorkingMinutes(start, *targetMinutes),
+		CompletedAt:   completedAt,
+		Status:        SLAStatusPending,
+	}
+	switch {
+	case completedAt != nil && !completedAt.After(t.DueAt):
+		t.Status = SLAStatusMet
+	case completedAt != nil || now.After(t.DueAt):
+		t.Status = SLAStatusBreached
+	}
+	return t
+}
+
+// SLABreachReportParams parâmetros de /reports/sla-breaches.
+// AsOf é o instante de referência para timers em aberto.
+type SLABreachReportParams struct {
+	WorkspaceID string
+	PipelineID  *string
+	OwnerID     *string
+	Timer       *SLATimerKind
+	AsOf        time.Time
+}
+
+// SLABreachItem é um timer violado de um ticket OPEN.
+type SLABreachItem struct {
+	DealID         string       `json:"dealId"`
+	Name           string       `json:"name"`
+	PipelineID     string       `json:"pipelineId"`
+	StageID        *string      `json:"stageId"`
+	OwnerID        *string      `json:"ownerId"`
+	Timer          SLATimerKind `json:"timer"`
+	TargetMinutes  int          `json:"targetMinutes"`
+	DueAt          time.Time    `json:"dueAt"`
+	CompletedAt    *time.Time   `json:"completedAt"`
+	MinutesOverdue int          `json:"minutesOverdue"`
+}
+
+// SLABreachReport resposta de /reports/sla-breaches.
+type SLABreachReport struct {
+	AsOf  time.Time       `json:"asOf"`
+	Items []SLABreachItem `json:"items"`
+	Total int             `json:"total"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDealSLAInput_Compute(t *testing.T) {
+	opened := time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := opened.Add(d)
+		return &v
+	}
+	minutes := func(n int) *int { return &n }
+
+	tests := []struct {
+		name        string
+		completedAt *time.Time
+		now         time.Time
+		want        SLAStatus
+	}{
+		{"pending within target", nil, opened.Add(59 * time.Minute), SLAStatusPending},
+		{"pending at the due instant", nil, opened.Add(time.Hour), SLAStatusPending},
+		{"open past due", nil, opened.Add(61 * time.Minute), SLAStatusBreached},
+		{"met before due", at(30 * time.Minute), opened.Add(48 * time.Hour), SLAStatusMet},
+		{"met exactly at due", at(time.Hour), opened.Add(48 * time.Hour), SLAStatusMet},
+		{"completed late", at(90 * time.Minute), opened.Add(48 * time.Hour), SLAStatusBreached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := DealSLAInput{OpenedAt: opened, FirstResponseSLAMinutes: minutes(60), FirstResponseAt: tt.completedAt}
+			sla := in.Compute(nil, tt.now)
+			require.NotNil(t, sla.FirstResponse)
+			assert.Nil(t, sla.Resolution, "timer without target is omitted")
+
+			timer := sla.Timer(SLATimerFirstResponse)
+			assert.Equal(t, 60, timer.TargetMinutes)
+			assert.Equal(t, opened.Add(time.Hour), timer.DueAt)
+			assert.Equal(t, tt.completedAt, timer.CompletedAt)
+			assert.Equal(t, tt.want, timer.Status)
+		})
+	}
+}
+
+func TestDealSLAInput_ComputeBusinessHours(t *testing.T) {
+	cal := newCalendar(t, "America/Sao_Paulo", weekdays([2]string{"09:00", "18:00"}))
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	// Aberto sexta 17:30: 30 min na sexta, o restante pausa até segunda 09:00
+	opened := time.Date(2026, 1, 9, 17, 30, 0, 0, loc)
+	firstResponse, resolution := 60, 9*60
+	in := DealSLAInput{OpenedAt: opened, FirstResponseSLAMinutes: &firstResponse, ResolutionSLAMinutes: &resolution}
+
+	saturday := time.Date(2026, 1, 10, 12, 0, 0, 0, loc)
+	sla := in.Compute(cal, saturday)
+	assert.True(t, sla.FirstResponse.DueAt.Equal(time.Date(2026, 1, 12, 9, 30, 0, 0, loc)), sla.FirstResponse.DueAt)
+	assert.True(t, sla.Resolution.DueAt.Equal(time.Date(2026, 1, 12, 17, 30, 0, 0, loc)), sla.Resolution.DueAt)
+	assert.Equal(t, SLAStatusPending, sla.FirstResponse.Status, "the weekend does not count")
+
+	monday := time.Date(2026, 1, 12, 10, 0, 0, 0, loc)
+	sla = in.Compute(cal, monday)
+	assert.Equal(t, SLAStatusBreached, sla.FirstResponse.Status)
+	assert.Equal(t, SLAStatusPending, sla.Resolution.Status)
+}
+
+func TestSLATimerKind_IsValid(t *testing.T) {
+	assert.True(t, SLATimerFirstResponse.IsValid())
+	assert.True(t, SLATimerResolution.IsValid())
+	assert.False(t, SLATimerKind("firstresponse").IsValid())
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// MaxDealSplits limite de donos que dividem o crédito de um negócio.
+const MaxDealSplits = 10
+
+// DealSplit parte do crédito de um negócio atribuída a um usuário (ex.: SDR 30% e AE 70%).
+// Sem splits, o crédito é 100% do ownerId. Os relatórios por dono (/reports/revenue?groupBy=owner,
+// /reports/forecast e /reports/pipeline-summary com ownerId) somam value × percentage.
+type DealSplit struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspaceId"`
+	DealID      string    `json:"dealId"`
+	UserID      string    `json:"userId"`
+	Percentage  float64   `json:"percentage"`
+	CreatedByID string    `json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// DealSplitInput item de PUT /deals/{dealId}/splits.
+type DealSplitInput struct {
+	UserID     string  `json:"userId" validate:"required"`
+	Percentage float64 `json:"percentage" validate:"gt=0,lte=100"`
+}
+
+// ReplaceDealSplitsRequest DTO de PUT /deals/{dealId}/splits: substitui todos os splits do negócio.
+// Lista vazia remove os splits (crédito volta a ser 100% do dono).
+type ReplaceDealSplitsRequest struct {
+	Splits []DealSplitInput `json:"splits" validate:"max=10,dive"`
+}
+
+// Validate valida o request: um split por usuário, percentuais com até duas casas somando 100.
+func (r *ReplaceDealSplitsRequest) Validate() error {
+	if r.Splits == nil {
+		return errors.New("splits is required")
+	}
+	for i := range r.Splits {
+		r.Splits[i].UserID = strings.TrimSpace(r.Splits[i].UserID)
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if len(r.Splits) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(r.Splits))
+	var total int64
+	for _, split := range r.Splits {
+		if seen[split.UserID] {
+			return errors.New("each user can appear only once in splits")
+		}
+		seen[split.UserID] = true
+
+		// Centésimos de ponto percentual, para a soma não depender de ponto flutuante
+		hundredths := math.Round(split.Percentage * 100)
+		if math.Abs(split.Percentage*100-hundredths) > 1e-6 {
+			return errors.New("percentage must have at most two decimal places")
+		}
+		total += int64(hundredths)
+	}
+	if total != 100*100 {
+		return errors.New("split percentages must total 100")
+	}
+	return nil
+}
+
+// DealSplitListResponse resposta de GET/PUT /deals/{dealId}/splits.
+type DealSplitListResponse struct {
+	Data []DealSplit `json:"data"`
+}
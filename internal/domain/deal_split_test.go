@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceDealSplitsRequest_Validate(t *testing.T) {
+	split := func(userID string, percentage float64) DealSplitInput {
+		return DealSplitInput{UserID: userID, Percentage: percentage}
+	}
+
+	tests := []struct {
+		name    string
+		splits  []DealSplitInput
+		wantErr string
+	}{
+		{name: "single owner", splits: []DealSplitInput{split("usr_1", 100)}},
+		{name: "two owners", splits: []DealSplitInput{split("usr_1", 30), split("usr_2", 70)}},
+		{name: "thirds rounded to two decimals", splits: []DealSplitInput{split("usr_1", 33.33), split("usr_2", 33.33), split("usr_3", 33.34)}},
+		{name: "sum exact despite float error", splits: []DealSplitInput{split("usr_1", 0.1), split("usr_2", 0.2), split("usr_3", 99.7)}},
+		{name: "empty list clears the splits", splits: []DealSplitInput{}},
+		{name: "missing list", splits: nil, wantErr: "splits is required"},
+		{name: "thirds short of 100", splits: []DealSplitInput{split("usr_1", 33.33), split("usr_2", 33.33), split("usr_3", 33.33)}, wantErr: "total 100"},
+		{name: "over 100", splits: []DealSplitInput{split("usr_1", 60), split("usr_2", 40.01)}, wantErr: "total 100"},
+		{name: "more than two decimals", splits: []DealSplitInput{split("usr_1", 33.333), split("usr_2", 66.667)}, wantErr: "two decimal places"},
+		{name: "duplicate user", splits: []DealSplitInput{split("usr_1", 50), split(" usr_1 ", 50)}, wantErr: "only once"},
+		{name: "zero percentage", splits: []DealSplitInput{split("usr_1", 100), split("usr_2", 0)}, wantErr: "percentage"},
+		{name: "missing user", splits: []DealSplitInput{split("", 100)}, wantErr: "userId"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ReplaceDealSplitsRequest{Splits: tt.splits}
+			err := req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestReplaceDealSplitsRequest_Validate_MaxSplits(t *testing.T) {
+	// 11 splits somando 100: 10 × 9% + 10%
+	splits := make([]DealSplitInput, MaxDealSplits+1)
+	for i := range splits {
+		splits[i] = DealSplitInput{UserID: fmt.Sprintf("usr_%d", i), Percentage: 9}
+	}
+	splits[0].Percentage = 10
+
+	req := ReplaceDealSplitsRequest{Splits: splits}
+	err := req.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max")
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// DocumentTemplate modelo de documento do workspace (propostas, orçamentos) com merge fields.
+// Além dos campos de contato e negócio, aceita {company.name} e os campos {quote.*}.
+type DocumentTemplate struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspaceId"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	Description *string   `json:"description"`
+	MergeFields []string  `json:"mergeFields"` // derivado de title+body
+	CreatedByID string    `json:"createdById"`
+	UpdatedByID *string   `json:"updatedById"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// DocumentTemplateListResponse resposta da listagem (sem paginação).
+type DocumentTemplateListResponse struct {
+	Data []DocumentTemplate `json:"data"`
+}
+
+// DocumentMergeFieldSet merge fields suportados nos documentos.
+// {quote.lineItems} vira a tabela de itens; se o body não a usar, a tabela e os totais
+// são anexados ao fim do documento.
+var DocumentMergeFieldSet = append(append([]string{}, MergeFieldSet...),
+	"company.name",
+	"quote.number",
+	"quote.date",
+	"quote.validUntil",
+	"quote.currency",
+	"quote.subtotal",
+	"quote.discount",
+	"quote.total",
+	"quote.lineItems",
+)
+
+var knownDocumentMergeFields = func() map[string]bool {
+	m := make(map[string]bool, len(DocumentMergeFieldSet))
+	for _, f := range DocumentMergeFieldSet {
+		m[f] = true
+	}
+	return m
+}()
+
+// CreateDocumentTemplateRequest DTO para criação de template de documento.
+type CreateDocumentTemplateRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=255"`
+	Title       string  `json:"title" validate:"required,min=1,max=255"`
+	Body        string  `json:"body" validate:"required,min=1,max=100000"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// Validate sanitiza e valida o request, incluindo os merge fields.
+func (r *CreateDocumentTemplateRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Title = strings.TrimSpace(r.Title)
+	r.Description = emptyToNil(trimOptional(r.Description))
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateMergeFieldsIn(knownDocumentMergeFields, r.Title, r.Body)
+}
+
+// UpdateDocumentTemplateRequest DTO para atualização parcial (nil = não modificar; description "" limpa).
+// Orçamentos já gerados guardam o texto renderizado e não mudam.
+type UpdateDocumentTemplateRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Title       *string `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Body        *string `json:"body,omitempty" validate:"omitempty,min=1,max=100000"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// Validate sanitiza e valida o request, incluindo os merge fields enviados.
+func (r *UpdateDocumentTemplateRequest) Validate() error {
+	r.Name = trimOptional(r.Name)
+	r.Title = trimOptional(r.Title)
+	if r.Description != nil {
+		trimmed := strings.TrimSpace(*r.Description)
+		r.Description = &trimmed
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	var texts []string
+	if r.Title != nil {
+		texts = append(texts, *r.Title)
+	}
+	if r.Body != nil {
+		texts = append(texts, *r.Body)
+	}
+	return validateMergeFieldsIn(knownDocumentMergeFields, texts...)
+}
+
+// Apply mescla o PATCH no template.
+func (r *UpdateDocumentTemplateRequest) Apply(t *DocumentTemplate) {
+	if r.Name != nil {
+		t.Name = *r.Name
+	}
+	if r.Title != nil {
+		t.Title = *r.Title
+	}
+	if r.Body != nil {
+		t.Body = *r.Body
+	}
+	if r.Description != nil {
+		t.Description = emptyToNil(r.Description)
+	}
+}
+
+// Render substitui os merge fields do título e do corpo. Retorna também os merge fields sem valor.
+func (t *DocumentTemplate) Render(values map[string]string) (title, body string, missing []string) {
+	missingSet := make(map[string]bool)
+	title = replaceMergeFields(t.Title, values, missingSet)
+	body = replaceMergeFields(t.Body, values, missingSet)
+	return title, body, sortedKeys(missingSet)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDocumentTemplateRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateDocumentTemplateRequest
+		wantErr string
+	}{
+		{"contact and quote fields", CreateDocumentTemplateRequest{
+			Name: "Proposta", Title: "Proposta {quote.number} - {company.name}",
+			Body: "Olá {contact.firstName},\n\n{quote.lineItems}\n\nTotal: {quote.total}",
+		}, ""},
+		{"unknown field", CreateDocumentTemplateRequest{Name: "Proposta", Title: "Proposta", Body: "{quote.tax}"}, "unknown merge fields: {quote.tax}"},
+		{"missing body", CreateDocumentTemplateRequest{Name: "Proposta", Title: "Proposta"}, "body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	// Os campos de orçamento não valem nos templates de email
+	email := CreateEmailTemplateRequest{Name: "Proposta", Subject: "Proposta {quote.number}", Body: "Segue"}
+	assert.ErrorContains(t, email.Validate(), "{quote.number}")
+}
+
+func TestUpdateDocumentTemplateRequest_Apply(t *testing.T) {
+	template := &DocumentTemplate{Name: "Proposta", Title: "Proposta", Body: "Olá", Description: strPtr("Padrão")}
+
+	req := UpdateDocumentTemplateRequest{Name: strPtr("  Proposta v2 "), Description: strPtr("  ")}
+	require.NoError(t, req.Validate())
+	req.Apply(template)
+	assert.Equal(t, "Proposta v2", template.Name)
+	assert.Nil(t, template.Description, `"" clears the description`)
+	assert.Equal(t, "Olá", template.Body)
+
+	assert.ErrorContains(t, (&UpdateDocumentTemplateRequest{Body: strPtr("{deal.owner}")}).Validate(), "{deal.owner}")
+}
+
+func TestDocumentTemplate_Render(t *testing.T) {
+	template := &DocumentTemplate{Title: "Proposta {quote.number}", Body: "Para {company.name}: {quote.total}"}
+	title, body, missing := template.Render(map[string]string{"quote.number": "7", "quote.total": "900.00"})
+	assert.Equal(t, "Proposta 7", title)
+	assert.Equal(t, "Para : 900.00", body)
+	assert.Equal(t, []string{"company.name"}, missing)
+}
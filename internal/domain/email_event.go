@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EmailEventType evento de engajamento reportado pelo provedor de envio de emails.
+type EmailEventType string
+
+const (
+	EmailEventOpened       EmailEventType = "OPENED"
+	EmailEventClicked      EmailEventType = "CLICKED"
+	EmailEventBounced      EmailEventType = "BOUNCED"
+	EmailEventUnsubscribed EmailEventType = "UNSUBSCRIBED"
+)
+
+// ActivityType evento de timeline emitido para automações
+func (t EmailEventType) ActivityType() ActivityType {
+	switch t {
+	case EmailEventOpened:
+		return ActivityTypeEmailOpened
+	case EmailEventClicked:
+		return ActivityTypeEmailClicked
+	case EmailEventBounced:
+		return ActivityTypeEmailBounced
+	default:
+		return ActivityTypeEmailUnsubscribed
+	}
+}
+
+// EmailBounceType HARD (caixa inexistente: marca o email do contato como INVALID) ou SOFT (temporário)
+type EmailBounceType string
+
+const (
+	EmailBounceHard EmailBounceType = "HARD"
+	EmailBounceSoft EmailBounceType = "SOFT"
+)
+
+// MaxEmailEventsPerRequest limite de eventos por chamada de ingestão
+const MaxEmailEventsPerRequest = 500
+
+// EmailEventInput um evento no lote de ingestão. O contato é contactId (se informado) ou o
+// contato ativo mais recente com o email.
+type EmailEventInput struct {
+	Type      EmailEventType `json:"type" validate:"required,oneof=OPENED CLICKED BOUNCED UNSUBSCRIBED"`
+	Email     string         `json:"email" validate:"required,email,max=255"`
+	ContactID *string        `json:"contactId,omitempty" validate:"omitempty,id"`
+	// ProviderEventID id do evento no provedor: reenvios do mesmo evento são ignorados
+	ProviderEventID *string          `json:"providerEventId,omitempty" validate:"omitempty,min=1,max=255"`
+	MessageID       *string          `json:"messageId,omitempty" validate:"omitempty,max=255"`
+	URL             *string          `json:"url,omitempty" validate:"omitempty,max=2048"`
+	BounceType      *EmailBounceType `json:"bounceType,omitempty" validate:"omitempty,oneof=HARD SOFT"`
+	Reason          *string          `json:"reason,omitempty" validate:"omitempty,max=1000"`
+	OccurredAt      time.Time        `json:"occurredAt" validate:"required"`
+}
+
+// IngestEmailEventsRequest POST /v1/workspaces/{workspaceId}/email-events (somente S2S).
+type IngestEmailEventsRequest struct {
+	// Provider nome do provedor (padrão: o cliente S2S)
+	Provider string            `json:"provider,omitempty" validate:"max=100"`
+	Events   []EmailEventInput `json:"events" validate:"required,min=1,max=500,dive"`
+}
+
+// Validate sanitiza e valida o lote.
+func (r *IngestEmailEventsRequest) Validate() error {
+	r.Provider = strings.ToLower(strings.TrimSpace(r.Provider))
+	for i := range r.Events {
+		e := &r.Events[i]
+		e.Email = strings.ToLower(strings.TrimSpace(e.Email))
+		e.ContactID = emptyToNil(trimOptional(e.ContactID))
+		e.ProviderEventID = emptyToNil(trimOptional(e.ProviderEventID))
+		e.MessageID = emptyToNil(trimOptional(e.MessageID))
+		e.URL = emptyToNil(trimOptional(e.URL))
+		e.Reason = emptyToNil(trimOptional(e.Reason))
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	for i, e := range r.Events {
+		if e.Type == EmailEventClicked && e.URL == nil {
+			return fmt.Errorf("events[%d].url is required for CLICKED", i)
+		}
+		if e.Type == EmailEventBounced && e.BounceType == nil {
+			return fmt.Errorf("events[%d].bounceType is required for BOUNCED", i)
+		}
+	}
+	return nil
+}
+
+// EmailEvent evento gravado (ContactID nil = nenhum contato com o email no workspace).
+type EmailEvent struct {
+	ID              string           `json:"id"`
+	WorkspaceID     string           `json:"workspaceId"`
+	ContactID       *string          `json:"contactId"`
+	Type            EmailEventType   `json:"type"`
+	Email           string           `json:"email"`
+	Provider        string           `json:"provider"`
+	ProviderEventID *string          `json:"providerEventId"`
+	MessageID       *string          `json:"messageId"`
+	URL             *string          `json:"url"`
+	BounceType      *EmailBounceType `json:"bounceType"`
+	Reason          *string          `json:"reason"`
+	OccurredAt      time.Time        `json:"occurredAt"`
+	CreatedAt       time.Time        `json:"createdAt"`
+}
+
+// Resultado de cada evento da ingestão
+const (
+	EmailEventResultCreated   = "created"   // gravado e ligado ao contato (evento na timeline)
+	EmailEventResultUnmatched = "unmatched" // gravado sem contato
+	EmailEventResultDuplicate = "duplicate" // providerEventId já recebido
+)
+
+// EmailEventResult resultado de um evento, na ordem do lote.
+type EmailEventResult struct {
+	Index     int     `json:"index"`
+	Status    string  `json:"status"`
+	EventID   *string `json:"eventId,omitempty"`
+	ContactID *string `json:"contactId,omitempty"`
+}
+
+// IngestEmailEventsResponse resposta da ingestão.
+type IngestEmailEventsResponse struct {
+	Created   int                `json:"created"`
+	Unmatched int                `json:"unmatched"`
+	Duplicate int                `json:"duplicate"`
+	Results   []EmailEventResult `json:"results"`
+}
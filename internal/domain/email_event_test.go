@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestEmailEventsRequest_Validate(t *testing.T) {
+	occurredAt := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	hard := EmailBounceHard
+	event := func(typ EmailEventType, opts ...func(*EmailEventInput)) EmailEventInput {
+		e := EmailEventInput{Type: typ, Email: "ana@example.com", OccurredAt: occurredAt}
+		for _, opt := range opts {
+			opt(&e)
+		}
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		events  []EmailEventInput
+		wantErr string
+	}{
+		{"opened", []EmailEventInput{event(EmailEventOpened)}, ""},
+		{"clicked with url", []EmailEventInput{event(EmailEventClicked, func(e *EmailEventInput) { e.URL = strPtr("https://example.com") })}, ""},
+		{"bounced with type", []EmailEventInput{event(EmailEventBounced, func(e *EmailEventInput) { e.BounceType = &hard })}, ""},
+		{"clicked without url", []EmailEventInput{event(EmailEventOpened), event(EmailEventClicked)}, "events[1].url is required for CLICKED"},
+		{"bounced without type", []EmailEventInput{event(EmailEventBounced)}, "events[0].bounceType is required for BOUNCED"},
+		{"blank url", []EmailEventInput{event(EmailEventClicked, func(e *EmailEventInput) { e.URL = strPtr("  ") })}, "events[0].url is required for CLICKED"},
+		{"unknown type", []EmailEventInput{event("DELIVERED")}, "events[0].type"},
+		{"invalid email", []EmailEventInput{event(EmailEventOpened, func(e *EmailEventInput) { e.Email = "ana" })}, "events[0].email"},
+		{"missing occurredAt", []EmailEventInput{event(EmailEventOpened, func(e *EmailEventInput) { e.OccurredAt = time.Time{} })}, "events[0].occurredAt"},
+		{"empty batch", nil, "events"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := IngestEmailEventsRequest{Events: tt.events}
+			err := req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	req := IngestEmailEventsRequest{Provider: " SendGrid ", Events: []EmailEventInput{
+		event(EmailEventOpened, func(e *EmailEventInput) { e.Email = " Ana@Example.COM "; e.ProviderEventID = strPtr(" ") }),
+	}}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "sendgrid", req.Provider)
+	assert.Equal(t, "ana@example.com", req.Events[0].Email)
+	assert.Nil(t, req.Events[0].ProviderEventID)
+}
+
+func TestEmailEventType_ActivityType(t *testing.T) {
+	assert.Equal(t, ActivityTypeEmailOpened, EmailEventOpened.ActivityType())
+	assert.Equal(t, ActivityTypeEmailClicked, EmailEventClicked.ActivityType())
+	assert.Equal(t, ActivityTypeEmailBounced, EmailEventBounced.ActivityType())
+	assert.Equal(t, ActivityTypeEmailUnsubscribed, EmailEventUnsubscribed.ActivityType())
+}
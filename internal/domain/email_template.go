@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmailTemplate é um modelo de email do workspace com merge fields.
+// Merge fields usam a sintaxe {entidade.campo}, ex.: {contact.firstName}, {deal.value}.
+type EmailTemplate struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspaceId"`
+	Name        string     `json:"name"`
+	Subject     string     `json:"subject"`
+	Body        string     `json:"body"`
+	Description *string    `json:"description"`
+	MergeFields []string   `json:"mergeFields"` // derivado de subject+body
+	CreatedByID string     `json:"createdById"`
+	UpdatedByID *string    `json:"updatedById"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+}
+
+// EmailTemplateListResponse resposta da listagem (sem paginação: poucos templates por workspace).
+type EmailTemplateListResponse struct {
+	Data []EmailTemplate `json:"data"`
+}
+
+// MergeFieldSet lista os merge fields suportados na renderização.
+var MergeFieldSet = []string{
+	"contact.fullName",
+	"contact.firstName",
+	"contact.lastName",
+	"contact.email",
+	"contact.phone",
+	"deal.name",
+	"deal.value",
+	"deal.currency",
+	"deal.stage",
+	"deal.expectedCloseDate",
+}
+
+var (
+	mergeFieldPattern = regexp.MustCompile(`\{([a-zA-Z]+\.[a-zA-Z]+)\}`)
+	knownMergeFields  = func() map[string]bool {
+		m := make(map[string]bool, len(MergeFieldSet))
+		for _, f := range MergeFieldSet {
+			m[f] = true
+		}
+		return m
+	}()
+)
+
+// ExtractMergeFields retorna os merge fields distintos dos textos, em ordem alfabética.
+func ExtractMergeFields(texts ...string) []string {
+	seen := make(map[string]bool)
+	fields := []string{}
+	for _, text := range texts {
+		for _, m := range mergeFieldPattern.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				fields = append(fields, m[1])
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// validateMergeFields rejeita merge fields fora de MergeFieldSet.
+func validateMergeFields(texts ...string) error {
+	return validateMergeFieldsIn(knownMergeFields, texts...)
+}
+
+// validateMergeFieldsIn rejeita merge fields fora do conjunto known.
+func validateMergeFieldsIn(known map[string]bool, texts ...string) error {
+	var unknown []string
+	for _, f := range ExtractMergeFields(texts...) {
+		if !known[f] {
+			unknown = append(unknown, "{"+f+"}")
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown merge fields: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// CreateEmailTemplateRequest DTO para criação de template.
+type CreateEmailTemplateRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=255"`
+	Subject     string  `json:"subject" validate:"required,min=1,max=998"`
+	Body        string  `json:"body" validate:"required,min=1,max=100000"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// Validate sanitiza e valida o request, incluindo os merge fields.
+func (r *CreateEmailTemplateRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Subject = strings.TrimSpace(r.Subject)
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateMergeFields(r.Subject, r.Body)
+}
+
+// UpdateEmailTemplateRequest DTO para atualização parcial (nil = não modificar).
+type UpdateEmailTemplateRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Subject     *string `json:"subject,omitempty" validate:"omitempty,min=1,max=998"`
+	Body        *string `json:"body,omitempty" validate:"omitempty,min=1,max=100000"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// Validate sanitiza e valida o request, incluindo os merge fields enviados.
+func (r *UpdateEmailTemplateRequest) Validate() error {
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+	if r.Subject != nil {
+		trimmed := strings.TrimSpace(*r.Subject)
+		r.Subject = &trimmed
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	var texts []string
+	if r.Subject != nil {
+		texts = append(texts, *r.Subject)
+	}
+	if r.Body != nil {
+		texts = append(texts, *r.Body)
+	}
+	return validateMergeFields(texts...)
+}
+
+// RenderEmailTemplateRequest escolhe os registros usados no preview.
+// Se apenas dealId for enviado, o contato do negócio é usado.
+type RenderEmailTemplateRequest struct {
+	ContactID *string `json:"contactId,omitempty"`
+	DealID    *string `json:"dealId,omitempty"`
+}
+
+// RenderedEmail é o resultado do preview.
+// MissingFields lista merge fields sem valor (registro não informado ou campo vazio),
+// substituídos por string vazia.
+type RenderedEmail struct {
+	Subject       string   `json:"subject"`
+	Body          string   `json:"body"`
+	MissingFields []string `json:"missingFields"`
+}
+
+// MergeValues monta os valores dos merge fields a partir do contato e do negócio (ambos opcionais).
+// Campos sem valor ficam fora do mapa.
+func MergeValues(contact *Contact, deal *Deal) map[string]string {
+	values := make(map[string]string)
+	set := func(key string, v *string) {
+		if v != nil && *v != "" {
+			values[key] = *v
+		}
+	}
+
+	if contact != nil {
+		set("contact.fullName", &contact.FullName)
+		set("contact.email", &contact.Email)
+		set("contact.phone", contact.Phone)
+
+		// Contact só expõe fullName: primeiro nome = primeira palavra
+		if parts := strings.Fields(contact.FullName); len(parts) > 0 {
+			values["contact.firstName"] = parts[0]
+			if len(parts) > 1 {
+				values["contact.lastName"] = strings.Join(parts[1:], " ")
+			}
+		}
+	}
+
+	if deal != nil {
+		set("deal.name", &deal.Name)
+		set("deal.currency", &deal.Currency)
+		values["deal.stage"] = string(deal.Stage)
+		if deal.Value != nil {
+			values["deal.value"] = strconv.FormatFloat(*deal.Value, 'f', 2, 64)
+		}
+		if deal.ExpectedCloseDate != nil {
+			values["deal.expectedCloseDate"] = deal.ExpectedCloseDate.Format("2006-01-02")
+		}
+	}
+
+	return values
+}
+
+// Render substitui os merge fields do template pelos valores informados.
+func (t *EmailTemplate) Render(values map[string]string) *RenderedEmail {
+	missing := make(map[string]bool)
+	rendered := &RenderedEmail{
+		Subject: replaceMergeFields(t.Subject, values, missing),
+		Body:    replaceMergeFields(t.Body, values, missing),
+	}
+	rendered.MissingFields = sortedKeys(missing)
+	return rendered
+}
+
+// replaceMergeFields substitui os merge fields de text; os sem valor viram "" e vão para missing.
+func replaceMergeFields(text string, values map[string]string, missing map[string]bool) string {
+	return mergeFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		field := match[1 : len(match)-1]
+		v, ok := values[field]
+		if !ok {
+			missing[field] = true
+		}
+		return v
+	})
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
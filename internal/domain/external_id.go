@@ -0,0 +1,22 @@
+package domain
+
+import "strings"
+
+// ExternalIDMaxLength tamanho máximo do externalId (chave do sistema de origem do integrador)
+const ExternalIDMaxLength = 255
+
+// NormalizeExternalID remove os espaços das bordas. ok é false quando o resultado é vazio ou
+// passa de ExternalIDMaxLength (externalId do path em PUT .../by-external-id/{externalId}).
+func NormalizeExternalID(raw string) (externalID string, ok bool) {
+	externalID = strings.TrimSpace(raw)
+	return externalID, externalID != "" && len(externalID) <= ExternalIDMaxLength
+}
+
+// trimExternalID sanitização do externalId opcional dos requests de criação e atualização
+func trimExternalID(externalID *string) *string {
+	if externalID == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*externalID)
+	return &trimmed
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// HistoryEntityType entidades com histórico por campo.
+type HistoryEntityType string
+
+const (
+	HistoryEntityContact HistoryEntityType = "contact"
+	HistoryEntityCompany HistoryEntityType = "company"
+	HistoryEntityDeal    HistoryEntityType = "deal"
+)
+
+// historyIgnoredFields campos que não entram no diff: identidade, timestamps de controle
+// e campos derivados de joins (mudam sem que o registro mude).
+var historyIgnoredFields = map[string]bool{
+	"id":           true,
+	"workspaceId":  true,
+	"createdAt":    true,
+	"updatedAt":    true,
+	"deletedAt":    true,
+	"updatedById":  true,
+	"contactName":  true,
+	"companyName":  true,
+	"rottingSince": true,
+	"sla":          true,
+	"companySize":  true, // espelho de "size" em Company
+}
+
+// FieldChange valores antes/depois de um campo, no formato JSON da API (null = ausente).
+type FieldChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// FieldRevision é uma alteração de um registro: quem, quando, por qual operação e o diff por campo.
+type FieldRevision struct {
+	ID          string                 `json:"id"`
+	WorkspaceID string                 `json:"workspaceId"`
+	EntityType  HistoryEntityType      `json:"entityType"`
+	EntityID    string                 `json:"entityId"`
+	Action      string                 `json:"action"`
+	Changes     map[string]FieldChange `json:"changes"`
+	ActorID     string                 `json:"actorId"`
+	CreatedAt   time.Time              `json:"createdAt"`
+}
+
+// DiffFields compara as representações JSON de before e after e retorna os campos
+// alterados. Campos ausentes (omitempty) valem null. Retorna mapa vazio sem alterações.
+func DiffFields(before, after any) (map[string]FieldChange, error) {
+	from, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	null := json.RawMessage("null")
+	changes := map[string]FieldChange{}
+	for field := range merge(from, to) {
+		if historyIgnoredFields[field] {
+			continue
+		}
+		f, ok := from[field]
+		if !ok {
+			f = null
+		}
+		t, ok := to[field]
+		if !ok {
+			t = null
+		}
+		if !bytes.Equal(f, t) {
+			changes[field] = FieldChange{From: f, To: t}
+		}
+	}
+	return changes, nil
+}
+
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal history snapshot: %w", err)
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("decode history snapshot: %w", err)
+	}
+	return fields, nil
+}
+
+func merge(a, b map[string]json.RawMessage) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+// ListFieldHistoryParams parâmetros de GET /{entidade}/{id}/history.
+// Cursor é o createdAt da última revisão da página anterior (mais recentes primeiro).
+type ListFieldHistoryParams struct {
+	WorkspaceID string
+	EntityType  HistoryEntityType
+	EntityID    string
+	Field       *string
+	Cursor      *time.Time
+	Limit       int
+}
+
+// Normalize aplica o limite padrão (50, máx. 200).
+func (p *ListFieldHistoryParams) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = 50
+	}
+	if p.Limit > 200 {
+		p.Limit = 200
+	}
+}
+
+// FieldHistoryResponse resposta do histórico por campo.
+type FieldHistoryResponse struct {
+	Data []FieldRevision `json:"data"`
+	Meta struct {
+		HasNextPage bool    `json:"hasNextPage"`
+		NextCursor  *string `json:"nextCursor,omitempty"`
+	} `json:"meta"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFields(t *testing.T) {
+	phone := "+55 11 99999-0000"
+	before := Contact{
+		ID:          "ctc_1",
+		WorkspaceID: "ws_1",
+		FullName:    "Ana Souza",
+		Email:       "ana@acme.com",
+		UpdatedAt:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	after := before
+	after.FullName = "Ana Lima"
+	after.Phone = &phone
+	after.UpdatedAt = before.UpdatedAt.Add(time.Hour)
+
+	changes, err := DiffFields(before, after)
+	require.NoError(t, err)
+
+	assert.Len(t, changes, 2, "only changed fields; updatedAt is ignored")
+	assert.Equal(t, FieldChange{From: json.RawMessage(`"Ana Souza"`), To: json.RawMessage(`"Ana Lima"`)}, changes["fullName"])
+	assert.Equal(t, FieldChange{From: json.RawMessage(`null`), To: json.RawMessage(`"+55 11 99999-0000"`)}, changes["phone"],
+		"omitted field counts as null")
+
+	reverted, err := DiffFields(after, before)
+	require.NoError(t, err)
+	assert.Equal(t, FieldChange{From: json.RawMessage(`"+55 11 99999-0000"`), To: json.RawMessage(`null`)}, reverted["phone"])
+}
+
+func TestDiffFields_NoChanges(t *testing.T) {
+	contact := Contact{ID: "ctc_1", FullName: "Ana Souza"}
+	touched := contact
+	touched.UpdatedAt = time.Now()
+
+	changes, err := DiffFields(contact, touched)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
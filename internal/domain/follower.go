@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// FollowEntityType entidades que podem ser seguidas.
+// Schema: "Follower"."entityType" ('CONTACT', 'DEAL', 'TASK')
+type FollowEntityType string
+
+const (
+	FollowEntityContact FollowEntityType = "CONTACT"
+	FollowEntityDeal    FollowEntityType = "DEAL"
+	FollowEntityTask    FollowEntityType = "TASK"
+)
+
+// FollowReason origem do vínculo de seguidor.
+// Schema: "Follower"."reason" ('MANUAL', 'ASSIGNED', 'MENTIONED')
+type FollowReason string
+
+const (
+	FollowReasonManual    FollowReason = "MANUAL"    // Seguiu explicitamente
+	FollowReasonAssigned  FollowReason = "ASSIGNED"  // Responsável (owner/assignee) do registro
+	FollowReasonMentioned FollowReason = "MENTIONED" // Mencionado em uma nota
+)
+
+// NotificationEvent evento entregue aos seguidores de uma entidade.
+type NotificationEvent string
+
+const (
+	NotificationUpdated      NotificationEvent = "updated"
+	NotificationAssigned     NotificationEvent = "assigned"
+	NotificationMentioned    NotificationEvent = "mentioned"
+	NotificationStageChanged NotificationEvent = "stage_changed"
+	NotificationNoteAdded    NotificationEvent = "note_added"
+)
+
+// Follower usuário que acompanha um contato, negócio ou tarefa.
+type Follower struct {
+	ID          string           `json:"id"`
+	WorkspaceID string           `json:"workspaceId"`
+	EntityType  FollowEntityType `json:"entityType"`
+	EntityID    string           `json:"entityId"`
+	UserID      string           `json:"userId"`
+	Reason      FollowReason     `json:"reason"`
+	CreatedAt   time.Time        `json:"createdAt"`
+}
+
+// FollowerListResponse resposta da listagem de seguidores de uma entidade.
+type FollowerListResponse struct {
+	Data []Follower `json:"data"`
+}
+
+// Notification evento na caixa de entrada de um usuário. ActorID é quem causou a mudança;
+// o próprio autor nunca é notificado.
+type Notification struct {
+	ID          string            `json:"id"`
+	WorkspaceID string            `json:"workspaceId"`
+	UserID      string            `json:"userId"`
+	EntityType  FollowEntityType  `json:"entityType"`
+	EntityID    string            `json:"entityId"`
+	Event       NotificationEvent `json:"event"`
+	ActorID     string            `json:"actorId"`
+	ReadAt      *time.Time        `json:"readAt,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// ListNotificationsParams parâmetros de GET /notifications.
+// Cursor é o createdAt da última notificação da página anterior (mais recentes primeiro).
+type ListNotificationsParams struct {
+	WorkspaceID string
+	UserID      string
+	UnreadOnly  bool
+	Cursor      *time.Time
+	Limit       int
+}
+
+// Normalize aplica o limite padrão (50, máx. 200).
+func (p *ListNotificationsParams) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = 50
+	}
+	if p.Limit > 200 {
+		p.Limit = 200
+	}
+}
+
+// NotificationListResponse resposta paginada da caixa de entrada.
+type NotificationListResponse struct {
+	Data []Notification `json:"data"`
+	Meta struct {
+		HasNextPage bool    `json:"hasNextPage"`
+		NextCursor  *string `json:"nextCursor,omitempty"`
+	} `json:"meta"`
+}
+
+// MarkNotificationsReadRequest DTO para marcar notificações como lidas.
+// Sem ids, marca todas as notificações do usuário no workspace.
+type MarkNotificationsReadRequest struct {
+	IDs []string `json:"ids" validate:"omitempty,max=200,dive,required,max=64"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *MarkNotificationsReadRequest) Validate() error {
+	for i := range r.IDs {
+		r.IDs[i] = strings.TrimSpace(r.IDs[i])
+	}
+	return validate.Struct(r)
+}
+
+// MarkNotificationsReadResponse quantidade de notificações marcadas como lidas.
+type MarkNotificationsReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// MentionedUserIDs lista os usuários mencionados no documento (attrs.id dos nós
+// "mention"), sem repetição.
+func (n *RichTextNode) MentionedUserIDs() []string {
+	var ids []string
+	seen := map[string]bool{}
+	var walk func(*RichTextNode)
+	walk = func(node *RichTextNode) {
+		if node.Type == "mention" {
+			if id, ok := node.Attrs["id"].(string); ok && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		for i := range node.Content {
+			walk(&node.Content[i])
+		}
+	}
+	walk(n)
+	return ids
+}
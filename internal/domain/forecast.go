@@ -0,0 +1,99 @@
+package domain
+
+import "time"
+
+// ForecastCategory categoria de forecast do deal, como os gestores de vendas fecham a previsão:
+// COMMIT (vai fechar), BEST_CASE (pode fechar), PIPELINE (em andamento) e OMITTED (fora da previsão).
+// Sem categoria manual, é derivada no banco (deal_forecast_category, migration 000049): ganhos = COMMIT,
+// perdidos = OMITTED; em aberto, pela probabilidade da etapa (ou a do deal): >= 90 COMMIT,
+// >= 60 BEST_CASE, > 0 PIPELINE, 0 OMITTED.
+type ForecastCategory string
+
+const (
+	ForecastCommit   ForecastCategory = "COMMIT"
+	ForecastBestCase ForecastCategory = "BEST_CASE"
+	ForecastPipeline ForecastCategory = "PIPELINE"
+	ForecastOmitted  ForecastCategory = "OMITTED"
+
+	// ForecastAuto remove a categoria definida manualmente (PATCH); o deal volta a seguir a etapa.
+	ForecastAuto ForecastCategory = "AUTO"
+)
+
+// ForecastCategories ordem das categorias em /reports/forecast.
+var ForecastCategories = []ForecastCategory{ForecastCommit, ForecastBestCase, ForecastPipeline, ForecastOmitted}
+
+// IsValid valida se a categoria é uma das quatro do forecast (AUTO não é persistida).
+func (c ForecastCategory) IsValid() bool {
+	switch c {
+	case ForecastCommit, ForecastBestCase, ForecastPipeline, ForecastOmitted:
+		return true
+	}
+	return false
+}
+
+// ForecastReportParams parâmetros de /reports/forecast. O período filtra pela data de fechamento:
+// closedAt dos deals ganhos/perdidos e expectedCloseDate dos em aberto (sem data ficam de fora
+// quando há período).
+type ForecastReportParams struct {
+	WorkspaceID string
+	PipelineID  *string
+	OwnerID     *string
+	From        *time.Time
+	To          *time.Time // exclusivo
+}
+
+// ForecastReportRow totais de uma categoria. WeightedValue soma value × probabilidade, para comparar
+// com a previsão ponderada; valores somam deal.value sem conversão de moeda.
+type ForecastReportRow struct {
+	Category      ForecastCategory `json:"category"`
+	Deals         int64            `json:"deals"`
+	Value         float64          `json:"value"`
+	WeightedValue float64          `json:"weightedValue"`
+}
+
+// ForecastReport resposta de /reports/forecast. Os totais são cumulativos, como a previsão é lida:
+// BestCaseValue = COMMIT + BEST_CASE e PipelineValue = COMMIT + BEST_CASE + PIPELINE.
+type ForecastReport struct {
+	From          *time.Time          `json:"from"`
+	To            *time.Time          `json:"to"`
+	Categories    []ForecastReportRow `json:"categories"`
+	CommitValue   float64             `json:"commitValue"`
+	BestCaseValue float64             `json:"bestCaseValue"`
+	PipelineValue float64             `json:"pipelineValue"`
+	WeightedValue float64             `json:"weightedValue"`
+}
+
+// NewForecastReport devolve as quatro categorias na ordem do forecast (zeradas quando sem deals).
+func NewForecastReport(params ForecastReportParams, rows []ForecastReportRow) *ForecastReport {
+	byCategory := make(map[ForecastCategory]ForecastReportRow, len(rows))
+	for _, row := range rows {
+		byCategory[row.Category] = row
+	}
+
+	report := &ForecastReport{From: params.From, To: params.To, Categories: make([]ForecastReportRow, 0, len(ForecastCategories))}
+	for _, category := range ForecastCategories {
+		row := byCategory[category]
+		row.Category = category
+		row.Value = roundMoney(row.Value)
+		row.WeightedValue = roundMoney(row.WeightedValue)
+		report.Categories = append(report.Categories, row)
+
+		switch category {
+		case ForecastCommit:
+			report.CommitValue += row.Value
+			report.BestCaseValue += row.Value
+			report.PipelineValue += row.Value
+		case ForecastBestCase:
+			report.BestCaseValue += row.Value
+			report.PipelineValue += row.Value
+		case ForecastPipeline:
+			report.PipelineValue += row.Value
+		}
+		report.WeightedValue += row.WeightedValue
+	}
+	report.CommitValue = roundMoney(report.CommitValue)
+	report.BestCaseValue = roundMoney(report.BestCaseValue)
+	report.PipelineValue = roundMoney(report.PipelineValue)
+	report.WeightedValue = roundMoney(report.WeightedValue)
+	return report
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastCategory_IsValid(t *testing.T) {
+	for _, c := range ForecastCategories {
+		assert.True(t, c.IsValid(), c)
+	}
+	// AUTO só existe no PATCH
+	assert.False(t, ForecastAuto.IsValid())
+	assert.False(t, ForecastCategory("commit").IsValid())
+}
+
+func TestNewForecastReport(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+	params := ForecastReportParams{WorkspaceID: "ws_1", From: &from, To: &to}
+
+	report := NewForecastReport(params, []ForecastReportRow{
+		{Category: ForecastPipeline, Deals: 4, Value: 4000, WeightedValue: 800},
+		{Category: ForecastCommit, Deals: 2, Value: 1500.006, WeightedValue: 1400.004},
+		{Category: ForecastOmitted, Deals: 1, Value: 900, WeightedValue: 0},
+	})
+
+	assert.Equal(t, &from, report.From)
+	assert.Equal(t, &to, report.To)
+	// Sempre as quatro categorias, na ordem do forecast
+	assert.Equal(t, []ForecastReportRow{
+		{Category: ForecastCommit, Deals: 2, Value: 1500.01, WeightedValue: 1400},
+		{Category: ForecastBestCase},
+		{Category: ForecastPipeline, Deals: 4, Value: 4000, WeightedValue: 800},
+		{Category: ForecastOmitted, Deals: 1, Value: 900},
+	}, report.Categories)
+	// Totais cumulativos; OMITTED fica fora
+	assert.Equal(t, 1500.01, report.CommitValue)
+	assert.Equal(t, 1500.01, report.BestCaseValue)
+	assert.Equal(t, 5500.01, report.PipelineValue)
+	assert.Equal(t, 2200.0, report.WeightedValue)
+
+	empty := NewForecastReport(ForecastReportParams{}, nil)
+	assert.Len(t, empty.Categories, len(ForecastCategories))
+	assert.Zero(t, empty.PipelineValue)
+}
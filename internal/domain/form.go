@@ -0,0 +1,319 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FormFieldType controle renderizado pelo formulário embutido.
+type FormFieldType string
+
+const (
+	FormFieldText     FormFieldType = "text"
+	FormFieldEmail    FormFieldType = "email"
+	FormFieldPhone    FormFieldType = "phone"
+	FormFieldTextarea FormFieldType = "textarea"
+	FormFieldNumber   FormFieldType = "number"
+	FormFieldSelect   FormFieldType = "select"
+	FormFieldCheckbox FormFieldType = "checkbox"
+	FormFieldHidden   FormFieldType = "hidden"
+)
+
+// FormField campo do formulário e o atributo que ele preenche (ver IsValidFormFieldTarget):
+// contact.*, deal.*, attribution.* ou contact.customFields.<chave>.
+type FormField struct {
+	Name     string        `json:"name" validate:"required,max=100"`
+	Label    string        `json:"label" validate:"max=255"`
+	Type     FormFieldType `json:"type" validate:"required,oneof=text email phone textarea number select checkbox hidden"`
+	Target   string        `json:"target" validate:"required"`
+	Required bool          `json:"required"`
+	// Options valores aceitos por campos select
+	Options []string `json:"options,omitempty" validate:"omitempty,max=100,dive,min=1,max=255"`
+}
+
+// FormSpamProtection proteções aplicadas às submissões anônimas.
+// Honeypot preenchido e envio rápido demais são descartados em silêncio (o bot recebe 202).
+type FormSpamProtection struct {
+	// HoneypotField campo escondido do formulário que humanos deixam vazio
+	HoneypotField *string `json:"honeypotField" validate:"omitempty,min=1,max=100"`
+	// MinSubmitSeconds tempo mínimo entre exibir (_renderedAt) e enviar o formulário (0 = desligado)
+	MinSubmitSeconds   int  `json:"minSubmitSeconds" validate:"min=0,max=3600"`
+	CaptchaRequired    bool `json:"captchaRequired"`
+	RateLimitPerMinute int  `json:"rateLimitPerMinute" validate:"min=1,max=600"`
+}
+
+// Form formulário de captura de leads cadastrado no workspace. As submissões criam o contato
+// (e o negócio, com pipelineId) em nome de OwnerID, com source=webform e sourceDetail=id.
+type Form struct {
+	ID                 string             `json:"id"`
+	WorkspaceID        string             `json:"workspaceId"`
+	Name               string             `json:"name"`
+	Description        *string            `json:"description"`
+	Fields             []FormField        `json:"fields"`
+	PipelineID         *string            `json:"pipelineId"`
+	StageID            *string            `json:"stageId"`
+	OwnerID            string             `json:"ownerId"`
+	SuccessRedirectURL *string            `json:"successRedirectUrl"`
+	SuccessMessage     *string            `json:"successMessage"`
+	SpamProtection     FormSpamProtection `json:"spamProtection"`
+	Enabled            bool               `json:"enabled"`
+	SubmissionCount    int64              `json:"submissionCount"`
+	LastSubmissionAt   *time.Time         `json:"lastSubmissionAt"`
+	SubmissionURL      string             `json:"submissionUrl"`
+	CreatedByID        string             `json:"createdById"`
+	UpdatedByID        *string            `json:"updatedById"`
+	CreatedAt          time.Time          `json:"createdAt"`
+	UpdatedAt          time.Time          `json:"updatedAt"`
+}
+
+// FormListResponse resposta da listagem (sem paginação).
+type FormListResponse struct {
+	Data []Form `json:"data"`
+}
+
+// CreateFormRequest DTO para criação de formulário.
+type CreateFormRequest struct {
+	Name               string              `json:"name" validate:"required,min=1,max=255"`
+	Description        *string             `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Fields             []FormField         `json:"fields" validate:"required,min=2,max=50,dive"`
+	PipelineID         *string             `json:"pipelineId,omitempty" validate:"omitempty,id"`
+	StageID            *string             `json:"stageId,omitempty" validate:"omitempty,id"`
+	OwnerID            *string             `json:"ownerId,omitempty" validate:"omitempty,id"`
+	SuccessRedirectURL *string             `json:"successRedirectUrl,omitempty" validate:"omitempty,url,max=2048"`
+	SuccessMessage     *string             `json:"successMessage,omitempty" validate:"omitempty,max=1000"`
+	SpamProtection     *FormSpamProtection `json:"spamProtection,omitempty"`
+	Enabled            *bool               `json:"enabled,omitempty"`
+}
+
+// Validate sanitiza o request, aplica os defaults de spamProtection e valida os campos.
+func (r *CreateFormRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.SuccessRedirectURL = trimOptional(r.SuccessRedirectURL)
+	normalizeFormFields(r.Fields)
+	if r.SpamProtection == nil {
+		r.SpamProtection = &FormSpamProtection{}
+	}
+	if r.SpamProtection.RateLimitPerMinute == 0 {
+		r.SpamProtection.RateLimitPerMinute = DefaultFormRateLimitPerMinute
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateFormDefinition(r.Fields, r.PipelineID, r.StageID, r.SuccessRedirectURL, r.SpamProtection)
+}
+
+// UpdateFormRequest DTO para atualização parcial (nil = não modificar).
+// fields substitui a lista inteira; description, pipelineId, stageId, successRedirectUrl e successMessage "" removem o valor.
+type UpdateFormRequest struct {
+	Name               *string             `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description        *string             `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Fields             *[]FormField        `json:"fields,omitempty" validate:"omitempty,min=2,max=50,dive"`
+	PipelineID         *string             `json:"pipelineId,omitempty"`
+	StageID            *string             `json:"stageId,omitempty"`
+	OwnerID            *string             `json:"ownerId,omitempty" validate:"omitempty,id"`
+	SuccessRedirectURL *string             `json:"successRedirectUrl,omitempty" validate:"omitempty,max=2048"`
+	SuccessMessage     *string             `json:"successMessage,omitempty" validate:"omitempty,max=1000"`
+	SpamProtection     *FormSpamProtection `json:"spamProtection,omitempty"`
+	Enabled            *bool               `json:"enabled,omitempty"`
+}
+
+// Validate sanitiza o request. A coerência entre campos e pipeline depende do formulário atual:
+// o resultado da mesclagem é validado por Form.Validate.
+func (r *UpdateFormRequest) Validate() error {
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+	if r.SuccessRedirectURL != nil {
+		trimmed := strings.TrimSpace(*r.SuccessRedirectURL)
+		r.SuccessRedirectURL = &trimmed
+	}
+	if r.Fields != nil {
+		normalizeFormFields(*r.Fields)
+	}
+	if r.SpamProtection != nil && r.SpamProtection.RateLimitPerMinute == 0 {
+		r.SpamProtection.RateLimitPerMinute = DefaultFormRateLimitPerMinute
+	}
+	return validate.Struct(r)
+}
+
+// Apply mescla o update no formulário.
+func (r *UpdateFormRequest) Apply(f *Form) {
+	if r.Name != nil {
+		f.Name = *r.Name
+	}
+	if r.Description != nil {
+		f.Description = emptyToNil(r.Description)
+	}
+	if r.Fields != nil {
+		f.Fields = *r.Fields
+	}
+	if r.PipelineID != nil {
+		if f.PipelineID == nil || *f.PipelineID != *r.PipelineID {
+			// Trocar (ou remover) o pipeline descarta o estágio anterior
+			f.StageID = nil
+		}
+		f.PipelineID = emptyToNil(r.PipelineID)
+	}
+	if r.StageID != nil {
+		f.StageID = emptyToNil(r.StageID)
+	}
+	if r.OwnerID != nil {
+		f.OwnerID = *r.OwnerID
+	}
+	if r.SuccessRedirectURL != nil {
+		f.SuccessRedirectURL = emptyToNil(r.SuccessRedirectURL)
+	}
+	if r.SuccessMessage != nil {
+		f.SuccessMessage = emptyToNil(r.SuccessMessage)
+	}
+	if r.SpamProtection != nil {
+		f.SpamProtection = *r.SpamProtection
+	}
+	if r.Enabled != nil {
+		f.Enabled = *r.Enabled
+	}
+}
+
+// Validate revalida campos, pipeline, redirect e proteções de um formulário mesclado.
+func (f *Form) Validate() error {
+	if err := validate.Var(f.Fields, "min=2,max=50,dive"); err != nil {
+		return err
+	}
+	if err := validate.Struct(f.SpamProtection); err != nil {
+		return err
+	}
+	return validateFormDefinition(f.Fields, f.PipelineID, f.StageID, f.SuccessRedirectURL, &f.SpamProtection)
+}
+
+// PublicConfig converte o formulário na configuração usada pelo endpoint público.
+func (f *Form) PublicConfig() PublicFormConfig {
+	mapping := make(map[string]string, len(f.Fields))
+	for _, field := range f.Fields {
+		mapping[field.Name] = field.Target
+	}
+	return PublicFormConfig{
+		FormID:             f.ID,
+		FieldMapping:       mapping,
+		PipelineID:         f.PipelineID,
+		StageID:            f.StageID,
+		RateLimitPerMinute: f.SpamProtection.RateLimitPerMinute,
+		CaptchaRequired:    f.SpamProtection.CaptchaRequired,
+	}
+}
+
+// CheckSubmission confere campos obrigatórios e opções de select antes do mapeamento.
+func (f *Form) CheckSubmission(fields map[string]string) error {
+	for _, field := range f.Fields {
+		value := strings.TrimSpace(fields[field.Name])
+		if value == "" {
+			if field.Required {
+				return fmt.Errorf("%s is required", field.Name)
+			}
+			continue
+		}
+		if field.Type == FormFieldSelect && len(field.Options) > 0 && !containsString(field.Options, value) {
+			return fmt.Errorf("%s must be one of: %s", field.Name, strings.Join(field.Options, ", "))
+		}
+	}
+	return nil
+}
+
+// IsSpam aplica honeypot e tempo mínimo de preenchimento.
+func (f *Form) IsSpam(sub *PublicFormSubmission, now time.Time) bool {
+	p := f.SpamProtection
+	if p.HoneypotField != nil && strings.TrimSpace(sub.Fields[*p.HoneypotField]) != "" {
+		return true
+	}
+	if p.MinSubmitSeconds > 0 {
+		if sub.RenderedAt == nil || now.Sub(*sub.RenderedAt) < time.Duration(p.MinSubmitSeconds)*time.Second {
+			return true
+		}
+	}
+	return false
+}
+
+// validateFormDefinition regras compartilhadas por criação e atualização.
+func validateFormDefinition(fields []FormField, pipelineID, stageID, redirectURL *string, spam *FormSpamProtection) error {
+	names := make(map[string]bool, len(fields))
+	targets := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if names[field.Name] {
+			return fmt.Errorf("fields: name %q is used more than once", field.Name)
+		}
+		names[field.Name] = true
+
+		if !IsValidFormFieldTarget(field.Target) {
+			return fmt.Errorf("fields.%s: unsupported target %q", field.Name, field.Target)
+		}
+		if targets[field.Target] {
+			return fmt.Errorf("fields: target %q is mapped more than once", field.Target)
+		}
+		targets[field.Target] = true
+
+		if field.Type == FormFieldSelect && len(field.Options) == 0 {
+			return fmt.Errorf("fields.%s: select fields require options", field.Name)
+		}
+		if strings.HasPrefix(field.Target, "deal.") && pipelineID == nil {
+			return fmt.Errorf("fields.%s: %s requires pipelineId", field.Name, field.Target)
+		}
+	}
+	if !targets[FormTargetContactFullName] || !targets[FormTargetContactEmail] {
+		return fmt.Errorf("fields must map %s and %s", FormTargetContactFullName, FormTargetContactEmail)
+	}
+	if stageID != nil && pipelineID == nil {
+		return fmt.Errorf("stageId requires pipelineId")
+	}
+	if spam.HoneypotField != nil && names[*spam.HoneypotField] {
+		return fmt.Errorf("spamProtection.honeypotField must not be one of the form fields")
+	}
+	if redirectURL != nil {
+		u, err := url.Parse(*redirectURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("successRedirectUrl must be an absolute http(s) URL")
+		}
+	}
+	return nil
+}
+
+func normalizeFormFields(fields []FormField) {
+	for i := range fields {
+		fields[i].Name = strings.TrimSpace(fields[i].Name)
+		fields[i].Label = strings.TrimSpace(fields[i].Label)
+		fields[i].Target = strings.TrimSpace(fields[i].Target)
+		if fields[i].Type == "" {
+			fields[i].Type = FormFieldText
+		}
+	}
+}
+
+func trimOptional(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func emptyToNil(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func containsString(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func formFields() []FormField {
+	return []FormField{
+		{Name: "nome", Label: "Nome", Type: FormFieldText, Target: FormTargetContactFullName, Required: true},
+		{Name: "email", Label: "E-mail", Type: FormFieldEmail, Target: FormTargetContactEmail, Required: true},
+		{Name: "plano", Label: "Plano", Type: FormFieldSelect, Target: "contact.customFields.plano", Options: []string{"basico", "pro"}},
+	}
+}
+
+func TestCreateFormRequest_Validate(t *testing.T) {
+	withField := func(field FormField) []FormField { return append(formFields(), field) }
+
+	tests := []struct {
+		name    string
+		req     CreateFormRequest
+		wantErr string
+	}{
+		{"valid", CreateFormRequest{Name: "Contato", Fields: formFields()}, ""},
+		{"deal target with pipeline", CreateFormRequest{Name: "Contato", PipelineID: strPtr("pip_1"),
+			Fields: withField(FormField{Name: "empresa", Type: FormFieldText, Target: FormTargetDealName})}, ""},
+		{"deal target without pipeline", CreateFormRequest{Name: "Contato",
+			Fields: withField(FormField{Name: "valor", Type: FormFieldNumber, Target: FormTargetDealValue})}, "fields.valor: deal.value requires pipelineId"},
+		{"missing email target", CreateFormRequest{Name: "Contato", Fields: []FormField{
+			{Name: "nome", Type: FormFieldText, Target: FormTargetContactFullName},
+			{Name: "fone", Type: FormFieldPhone, Target: FormTargetContactPhone},
+		}}, "fields must map contact.fullName and contact.email"},
+		{"duplicate name", CreateFormRequest{Name: "Contato",
+			Fields: withField(FormField{Name: "email", Type: FormFieldText, Target: FormTargetContactPhone})}, `fields: name "email" is used more than once`},
+		{"duplicate target", CreateFormRequest{Name: "Contato",
+			Fields: withField(FormField{Name: "email2", Type: FormFieldEmail, Target: FormTargetContactEmail})}, `fields: target "contact.email" is mapped more than once`},
+		{"unsupported target", CreateFormRequest{Name: "Contato",
+			Fields: withField(FormField{Name: "cargo", Type: FormFieldText, Target: "contact.jobTitle"})}, `fields.cargo: unsupported target "contact.jobTitle"`},
+		{"select without options", CreateFormRequest{Name: "Contato",
+			Fields: withField(FormField{Name: "origem", Type: FormFieldSelect, Target: FormTargetUTMSource})}, "fields.origem: select fields require options"},
+		{"stage without pipeline", CreateFormRequest{Name: "Contato", Fields: formFields(), StageID: strPtr("stg_1")}, "stageId requires pipelineId"},
+		{"honeypot is a form field", CreateFormRequest{Name: "Contato", Fields: formFields(),
+			SpamProtection: &FormSpamProtection{HoneypotField: strPtr("email")}}, "spamProtection.honeypotField must not be one of the form fields"},
+		{"relative redirect", CreateFormRequest{Name: "Contato", Fields: formFields(), SuccessRedirectURL: strPtr("/obrigado")}, "successRedirectUrl"},
+		{"single field", CreateFormRequest{Name: "Contato", Fields: formFields()[:1]}, "fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, DefaultFormRateLimitPerMinute, tt.req.SpamProtection.RateLimitPerMinute)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateFormRequest_ValidateNormalizesFields(t *testing.T) {
+	req := CreateFormRequest{
+		Name:               "  Contato  ",
+		SuccessRedirectURL: strPtr("   "),
+		Fields: []FormField{
+			{Name: " nome ", Target: " contact.fullName "},
+			{Name: "email", Type: FormFieldEmail, Target: FormTargetContactEmail},
+		},
+	}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "Contato", req.Name)
+	assert.Nil(t, req.SuccessRedirectURL)
+	assert.Equal(t, FormField{Name: "nome", Type: FormFieldText, Target: FormTargetContactFullName}, req.Fields[0])
+}
+
+func TestUpdateFormRequest_Apply(t *testing.T) {
+	form := func() *Form {
+		return &Form{
+			Name:               "Contato",
+			Fields:             formFields(),
+			PipelineID:         strPtr("pip_1"),
+			StageID:            strPtr("stg_1"),
+			SuccessRedirectURL: strPtr("https://example.com/obrigado"),
+			SpamProtection:     FormSpamProtection{RateLimitPerMinute: DefaultFormRateLimitPerMinute},
+			Enabled:            true,
+		}
+	}
+
+	t.Run("changing the pipeline drops the stage", func(t *testing.T) {
+		f := form()
+		(&UpdateFormRequest{PipelineID: strPtr("pip_2")}).Apply(f)
+		assert.Equal(t, "pip_2", *f.PipelineID)
+		assert.Nil(t, f.StageID)
+	})
+
+	t.Run("same pipeline keeps the stage", func(t *testing.T) {
+		f := form()
+		(&UpdateFormRequest{PipelineID: strPtr("pip_1")}).Apply(f)
+		assert.Equal(t, "stg_1", *f.StageID)
+	})
+
+	t.Run("empty strings clear optional values", func(t *testing.T) {
+		f := form()
+		disabled := false
+		(&UpdateFormRequest{PipelineID: strPtr(""), SuccessRedirectURL: strPtr(""), Enabled: &disabled}).Apply(f)
+		assert.Nil(t, f.PipelineID)
+		assert.Nil(t, f.StageID)
+		assert.Nil(t, f.SuccessRedirectURL)
+		assert.False(t, f.Enabled)
+		assert.NoError(t, f.Validate())
+	})
+
+	t.Run("merged result is revalidated", func(t *testing.T) {
+		f := form()
+		f.Fields = append(f.Fields, FormField{Name: "empresa", Type: FormFieldText, Target: FormTargetDealName})
+		(&UpdateFormRequest{PipelineID: strPtr("")}).Apply(f)
+		assert.ErrorContains(t, f.Validate(), "deal.name requires pipelineId")
+	})
+}
+
+func TestForm_CheckSubmission(t *testing.T) {
+	f := &Form{Fields: formFields()}
+
+	assert.NoError(t, f.CheckSubmission(map[string]string{"nome": "Ana", "email": "ana@example.com"}))
+	assert.NoError(t, f.CheckSubmission(map[string]string{"nome": "Ana", "email": "ana@example.com", "plano": "pro"}))
+	assert.EqualError(t, f.CheckSubmission(map[string]string{"nome": "  ", "email": "ana@example.com"}), "nome is required")
+	assert.EqualError(t, f.CheckSubmission(map[string]string{"nome": "Ana", "email": "ana@example.com", "plano": "enterprise"}),
+		"plano must be one of: basico, pro")
+}
+
+func TestForm_IsSpam(t *testing.T) {
+	now := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	rendered := func(ago time.Duration) *time.Time {
+		v := now.Add(-ago)
+		return &v
+	}
+	f := &Form{SpamProtection: FormSpamProtection{HoneypotField: strPtr("website"), MinSubmitSeconds: 3}}
+
+	tests := []struct {
+		name string
+		sub  PublicFormSubmission
+		want bool
+	}{
+		{"human", PublicFormSubmission{Fields: map[string]string{"nome": "Ana"}, RenderedAt: rendered(10 * time.Second)}, false},
+		{"honeypot filled", PublicFormSubmission{Fields: map[string]string{"website": "http://spam"}, RenderedAt: rendered(time.Minute)}, true},
+		{"too fast", PublicFormSubmission{Fields: map[string]string{}, RenderedAt: rendered(time.Second)}, true},
+		{"no render time", PublicFormSubmission{Fields: map[string]string{}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, f.IsSpam(&tt.sub, now))
+		})
+	}
+
+	assert.False(t, (&Form{}).IsSpam(&PublicFormSubmission{Fields: map[string]string{}}, now), "protections off")
+}
+
+func TestForm_PublicConfigMapsSubmission(t *testing.T) {
+	f := &Form{
+		ID:             "frm_1",
+		Fields:         append(formFields(), FormField{Name: "valor", Type: FormFieldNumber, Target: FormTargetDealValue}),
+		PipelineID:     strPtr("pip_1"),
+		SpamProtection: FormSpamProtection{RateLimitPerMinute: 20, CaptchaRequired: true},
+	}
+	config := f.PublicConfig()
+	assert.Equal(t, "frm_1", config.FormID)
+	assert.Equal(t, 20, config.RateLimitPerMinute)
+	assert.True(t, config.CaptchaRequired)
+	assert.Equal(t, FormTargetDealValue, config.FieldMapping["valor"])
+
+	contact, deal, err := config.MapSubmission(map[string]string{
+		"nome": "Ana", "email": "ana@example.com", "plano": "pro", "valor": "1500.50", "ignorado": "x",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Ana", contact.FullName)
+	assert.Equal(t, "pro", contact.CustomFields["plano"])
+	assert.Equal(t, SourceWebForm, *contact.Attribution.Source)
+	assert.Equal(t, "frm_1", *contact.Attribution.SourceDetail)
+	require.NotNil(t, deal)
+	assert.Equal(t, "Ana", deal.Name, "deal name defaults to the contact name")
+	assert.Equal(t, 1500.50, *deal.Value)
+
+	_, _, err = config.MapSubmission(map[string]string{"nome": "Ana", "email": "ana@example.com", "valor": "mil"})
+	assert.EqualError(t, err, "valor must be a number")
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+// IDMaxLength tamanho máximo de um ID aceito pela API (colunas são TEXT; o limite protege índices e logs)
+const IDMaxLength = 64
+
+// idPattern conjunto de caracteres comum a todos os formatos de ID em uso: cuid (registros
+// antigos, "c" + 24 chars), ULIDs com prefixo gerados por internal/id (cnt_..., sa_...) e UUIDs
+// de integrações. Nenhum formato é assumido além disso: as colunas de ID são TEXT.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ErrInvalidID is returned by ParseID for empty, oversized or malformed IDs.
+var ErrInvalidID = errors.New("invalid id format")
+
+// ID identificador de recurso. É opaco: handlers, services e repos não devem interpretar o formato.
+type ID string
+
+// ParseID valida s e retorna o ID correspondente.
+func ParseID(s string) (ID, error) {
+	if !IsValidID(s) {
+		return "", ErrInvalidID
+	}
+	return ID(s), nil
+}
+
+// IsValidID reports whether s is an acceptable resource ID (cuid, prefixed or UUID).
+func IsValidID(s string) bool {
+	return s != "" && len(s) <= IDMaxLength && idPattern.MatchString(s)
+}
+
+// String implements fmt.Stringer.
+func (id ID) String() string {
+	return string(id)
+}
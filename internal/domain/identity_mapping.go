@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IdentityEntityType tipo de registro Linkko que um identity mapping referencia
+type IdentityEntityType string
+
+const (
+	IdentityEntityContact IdentityEntityType = "contact"
+	IdentityEntityCompany IdentityEntityType = "company"
+	IdentityEntityDeal    IdentityEntityType = "deal"
+)
+
+// MaxIdentityMappingsPerRequest limite de itens em :register e de ids em :resolve
+const MaxIdentityMappingsPerRequest = 500
+
+// IdentityMapping id de um registro Linkko num sistema externo (ERP, e-commerce, suporte...).
+// Complementa o externalId da entidade, que guarda uma única chave: com mappings o mesmo registro
+// tem um id por sistema. Por sistema e tipo, um externalId aponta para um único registro.
+type IdentityMapping struct {
+	ID          string             `json:"id"`
+	WorkspaceID string             `json:"workspaceId"`
+	System      string             `json:"system"`
+	EntityType  IdentityEntityType `json:"entityType"`
+	ExternalID  string             `json:"externalId"`
+	EntityID    string             `json:"entityId"`
+	CreatedByID string             `json:"createdById"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+// IdentityMappingInput item de POST /identity-mappings/:register. System é normalizado para
+// minúsculas ("ERP" e "erp" são o mesmo sistema).
+type IdentityMappingInput struct {
+	System     string             `json:"system" validate:"required,max=100"`
+	EntityType IdentityEntityType `json:"entityType" validate:"required,oneof=contact company deal"`
+	ExternalID string             `json:"externalId" validate:"required,max=255"`
+	EntityID   string             `json:"entityId" validate:"required,id"`
+}
+
+// RegisterIdentityMappingsRequest DTO de POST /identity-mappings/:register: cria ou atualiza (pela
+// chave system + entityType + externalId) os mappings do lote, todos ou nenhum.
+type RegisterIdentityMappingsRequest struct {
+	Mappings []IdentityMappingInput `json:"mappings" validate:"required,min=1,max=500,dive"`
+}
+
+// Validate sanitiza e valida o lote: a mesma chave não pode aparecer duas vezes.
+func (r *RegisterIdentityMappingsRequest) Validate() error {
+	for i := range r.Mappings {
+		m := &r.Mappings[i]
+		m.System = strings.ToLower(strings.TrimSpace(m.System))
+		m.ExternalID = strings.TrimSpace(m.ExternalID)
+		m.EntityID = strings.TrimSpace(m.EntityID)
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	seen := make(map[string]int, len(r.Mappings))
+	for i, m := range r.Mappings {
+		key := m.System + "\x00" + string(m.EntityType) + "\x00" + m.ExternalID
+		if first, ok := seen[key]; ok {
+			return fmt.Errorf("mappings[%d] repeats the system, entityType and externalId of mappings[%d]", i, first)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// ResolveIdentityMappingsRequest DTO de POST /identity-mappings/:resolve. Informe externalIds
+// (id externo -> id Linkko, system obrigatório) ou entityIds (id Linkko -> ids externos, em todos
+// os sistemas quando system é omitido).
+type ResolveIdentityMappingsRequest struct {
+	System      string             `json:"system" validate:"max=100"`
+	EntityType  IdentityEntityType `json:"entityType" validate:"required,oneof=contact company deal"`
+	ExternalIDs []string           `json:"externalIds" validate:"max=500,dive,required,max=255"`
+	EntityIDs   []string           `json:"entityIds" validate:"max=500,dive,required,id"`
+}
+
+// Validate sanitiza e valida o request: exatamente uma das listas.
+func (r *ResolveIdentityMappingsRequest) Validate() error {
+	r.System = strings.ToLower(strings.TrimSpace(r.System))
+	for i := range r.ExternalIDs {
+		r.ExternalIDs[i] = strings.TrimSpace(r.ExternalIDs[i])
+	}
+	for i := range r.EntityIDs {
+		r.EntityIDs[i] = strings.TrimSpace(r.EntityIDs[i])
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	switch {
+	case len(r.ExternalIDs) == 0 && len(r.EntityIDs) == 0:
+		return errors.New("externalIds or entityIds is required")
+	case len(r.ExternalIDs) > 0 && len(r.EntityIDs) > 0:
+		return errors.New("externalIds and entityIds are mutually exclusive")
+	case len(r.ExternalIDs) > 0 && r.System == "":
+		return errors.New("system is required to resolve externalIds")
+	}
+	return nil
+}
+
+// IdentityMappingListResponse resposta de POST /identity-mappings/:register.
+type IdentityMappingListResponse struct {
+	Data []IdentityMapping `json:"data"`
+}
+
+// ResolveIdentityMappingsResponse resposta de POST /identity-mappings/:resolve. Unresolved lista os
+// ids pedidos (externalIds ou entityIds) sem nenhum mapping, na ordem do request.
+type ResolveIdentityMappingsResponse struct {
+	Data       []IdentityMapping `json:"data"`
+	Unresolved []string          `json:"unresolved"`
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterIdentityMappingsRequest_Validate(t *testing.T) {
+	input := func(system, externalID string) IdentityMappingInput {
+		return IdentityMappingInput{System: system, EntityType: IdentityEntityContact, ExternalID: externalID, EntityID: "ctc_1"}
+	}
+
+	t.Run("normalizes the key", func(t *testing.T) {
+		req := RegisterIdentityMappingsRequest{Mappings: []IdentityMappingInput{input(" ERP ", " C-1 ")}}
+		require.NoError(t, req.Validate())
+		assert.Equal(t, "erp", req.Mappings[0].System)
+		assert.Equal(t, "C-1", req.Mappings[0].ExternalID)
+	})
+
+	t.Run("the same key cannot repeat in a batch", func(t *testing.T) {
+		req := RegisterIdentityMappingsRequest{Mappings: []IdentityMappingInput{input("erp", "C-1"), input("ERP", "C-1 ")}}
+		err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mappings[1] repeats")
+	})
+
+	t.Run("the same externalId in another system or type is a different key", func(t *testing.T) {
+		deal := input("erp", "C-1")
+		deal.EntityType = IdentityEntityDeal
+		req := RegisterIdentityMappingsRequest{Mappings: []IdentityMappingInput{input("erp", "C-1"), input("shop", "C-1"), deal}}
+		assert.NoError(t, req.Validate())
+	})
+}
+
+func TestResolveIdentityMappingsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ResolveIdentityMappingsRequest
+		wantErr string
+	}{
+		{name: "externalIds", req: ResolveIdentityMappingsRequest{System: "erp", EntityType: IdentityEntityContact, ExternalIDs: []string{"C-1"}}},
+		{name: "entityIds in all systems", req: ResolveIdentityMappingsRequest{EntityType: IdentityEntityContact, EntityIDs: []string{"ctc_1"}}},
+		{name: "no ids", req: ResolveIdentityMappingsRequest{EntityType: IdentityEntityContact}, wantErr: "is required"},
+		{name: "both lists", req: ResolveIdentityMappingsRequest{System: "erp", EntityType: IdentityEntityContact, ExternalIDs: []string{"C-1"}, EntityIDs: []string{"ctc_1"}}, wantErr: "mutually exclusive"},
+		{name: "externalIds without system", req: ResolveIdentityMappingsRequest{System: "  ", EntityType: IdentityEntityContact, ExternalIDs: []string{"C-1"}}, wantErr: "system is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
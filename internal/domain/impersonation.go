@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// CreateImpersonationRequest POST /v1/workspaces/{workspaceId}/impersonation: o admin passa a
+// agir como ActorID para reproduzir um problema. Reason vai para o audit log.
+type CreateImpersonationRequest struct {
+	ActorID    string `json:"actorId" validate:"required,max=255"`
+	Reason     string `json:"reason" validate:"required,min=3,max=500"`
+	TTLMinutes *int   `json:"ttlMinutes,omitempty" validate:"omitempty,min=1"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateImpersonationRequest) Validate() error {
+	r.ActorID = strings.TrimSpace(r.ActorID)
+	r.Reason = strings.TrimSpace(r.Reason)
+	return validate.Struct(r)
+}
+
+// ImpersonationToken token de impersonation: actorId é o usuário impersonado e impersonatorId o
+// admin que o emitiu. ReadOnly indica que a API bloqueia escrita sob impersonation.
+type ImpersonationToken struct {
+	Token          string    `json:"token"`
+	TokenType      string    `json:"tokenType"`
+	WorkspaceID    string    `json:"workspaceId"`
+	ActorID        string    `json:"actorId"`
+	ImpersonatorID string    `json:"impersonatorId"`
+	ReadOnly       bool      `json:"readOnly"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InvoiceStatus situação da cobrança. O provedor de pagamento é a fonte da verdade para
+// faturas com externalId (reconciliação via S2S); as demais são atualizadas manualmente.
+type InvoiceStatus string
+
+const (
+	InvoiceStatusOpen          InvoiceStatus = "OPEN"          // emitida, aguardando pagamento
+	InvoiceStatusPaid          InvoiceStatus = "PAID"          // paga (entra na receita collected)
+	InvoiceStatusVoid          InvoiceStatus = "VOID"          // cancelada
+	InvoiceStatusUncollectible InvoiceStatus = "UNCOLLECTIBLE" // dada como perdida
+)
+
+// IsValid valida se o status é suportado.
+func (s InvoiceStatus) IsValid() bool {
+	switch s {
+	case InvoiceStatusOpen, InvoiceStatusPaid, InvoiceStatusVoid, InvoiceStatusUncollectible:
+		return true
+	}
+	return false
+}
+
+// MaxInvoiceEventsPerRequest limite de eventos por chamada de reconciliação
+const MaxInvoiceEventsPerRequest = 500
+
+var errInvoiceExternalIDWithoutProvider = errors.New("provider is required when externalId is set")
+
+// Invoice cobrança de um negócio ganho.
+type Invoice struct {
+	ID          string        `json:"id"`
+	WorkspaceID string        `json:"workspaceId"`
+	DealID      string        `json:"dealId"`
+	Number      *string       `json:"number"`
+	Status      InvoiceStatus `json:"status"`
+	Amount      float64       `json:"amount"`
+	AmountPaid  float64       `json:"amountPaid"`
+	Currency    string        `json:"currency"`
+	DueDate     *time.Time    `json:"dueDate"`
+	PaidAt      *time.Time    `json:"paidAt"`
+	// Provider + ExternalID identificam a fatura no provedor de pagamento
+	Provider          *string    `json:"provider"`
+	ExternalID        *string    `json:"externalId"`
+	ProviderUpdatedAt *time.Time `json:"providerUpdatedAt"`
+	CreatedByID       string     `json:"createdById"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+// ApplyStatus muda o status. Uma fatura que passa a PAID sem amountPaid quita o valor total
+// e, sem paidAt, é paga em now; os demais status limpam paidAt (só faturas PAID entram na
+// receita collected).
+func (i *Invoice) ApplyStatus(status InvoiceStatus, amountPaid *float64, paidAt *time.Time, now time.Time) {
+	wasPaid := i.Status == InvoiceStatusPaid
+	i.Status = status
+	if amountPaid != nil {
+		i.AmountPaid = *amountPaid
+	}
+	if status != InvoiceStatusPaid {
+		i.PaidAt = nil
+		return
+	}
+	if amountPaid == nil && !wasPaid {
+		i.AmountPaid = i.Amount
+	}
+	switch {
+	case paidAt != nil:
+		i.PaidAt = paidAt
+	case i.PaidAt == nil:
+		i.PaidAt = &now
+	}
+}
+
+// InvoiceListParams filtros de GET /invoices.
+type InvoiceListParams struct {
+	DealID *string
+	Status *InvoiceStatus
+}
+
+// InvoiceListResponse resposta da listagem (sem paginação: poucas faturas por negócio).
+type InvoiceListResponse struct {
+	Data []Invoice `json:"data"`
+}
+
+// CreateInvoiceRequest POST /v1/workspaces/{workspaceId}/invoices.
+// Sem amount/currency, a fatura usa o valor e a moeda do negócio.
+type CreateInvoiceRequest struct {
+	DealID     string     `json:"dealId" validate:"required,id"`
+	Number     *string    `json:"number,omitempty" validate:"omitempty,max=100"`
+	Amount     *float64   `json:"amount,omitempty" validate:"omitempty,gte=0"`
+	Currency   *string    `json:"currency,omitempty" validate:"omitempty,len=3"`
+	DueDate    *time.Time `json:"dueDate,omitempty"`
+	Provider   *string    `json:"provider,omitempty" validate:"omitempty,max=100"`
+	ExternalID *string    `json:"externalId,omitempty" validate:"omitempty,max=255"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateInvoiceRequest) Validate() error {
+	r.DealID = strings.TrimSpace(r.DealID)
+	r.Number = emptyToNil(trimOptional(r.Number))
+	r.Currency = emptyToNil(trimOptional(r.Currency))
+	if r.Currency != nil {
+		upper := strings.ToUpper(*r.Currency)
+		r.Currency = &upper
+	}
+	r.Provider = normalizeInvoiceProvider(r.Provider)
+	r.ExternalID = emptyToNil(trimOptional(r.ExternalID))
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if r.ExternalID != nil && r.Provider == nil {
+		return errInvoiceExternalIDWithoutProvider
+	}
+	return nil
+}
+
+// UpdateInvoiceRequest PATCH /v1/workspaces/{workspaceId}/invoices/{invoiceId}.
+// Campos nil mantêm o valor atual; status PAID sem amountPaid/paidAt quita o valor total agora.
+type UpdateInvoiceRequest struct {
+	Number     *string        `json:"number,omitempty" validate:"omitempty,max=100"`
+	Amount     *float64       `json:"amount,omitempty" validate:"omitempty,gte=0"`
+	DueDate    *time.Time     `json:"dueDate,omitempty"`
+	Status     *InvoiceStatus `json:"status,omitempty" validate:"omitempty,oneof=OPEN PAID VOID UNCOLLECTIBLE"`
+	AmountPaid *float64       `json:"amountPaid,omitempty" validate:"omitempty,gte=0"`
+	PaidAt     *time.Time     `json:"paidAt,omitempty"`
+	Provider   *string        `json:"provider,omitempty" validate:"omitempty,max=100"`
+	ExternalID *string        `json:"externalId,omitempty" validate:"omitempty,max=255"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateInvoiceRequest) Validate() error {
+	r.Number = trimOptional(r.Number)
+	r.Provider = normalizeInvoiceProvider(r.Provider)
+	r.ExternalID = emptyToNil(trimOptional(r.ExternalID))
+	return validate.Struct(r)
+}
+
+// InvoiceEventInput um evento no lote de reconciliação: o estado atual da fatura no provedor.
+type InvoiceEventInput struct {
+	ExternalID string        `json:"externalId" validate:"required,min=1,max=255"`
+	Status     InvoiceStatus `json:"status" validate:"required,oneof=OPEN PAID VOID UNCOLLECTIBLE"`
+	Amount     *float64      `json:"amount,omitempty" validate:"omitempty,gte=0"`
+	AmountPaid *float64      `json:"amountPaid,omitempty" validate:"omitempty,gte=0"`
+	DueDate    *time.Time    `json:"dueDate,omitempty"`
+	PaidAt     *time.Time    `json:"paidAt,omitempty"`
+	// OccurredAt instante do evento no provedor: eventos mais antigos que o último aplicado são ignorados
+	OccurredAt time.Time `json:"occurredAt" validate:"required"`
+}
+
+// ReconcileInvoicesRequest POST /v1/workspaces/{workspaceId}/invoices/:reconcile (somente S2S).
+type ReconcileInvoicesRequest struct {
+	// Provider nome do provedor (padrão: o cliente S2S)
+	Provider string              `json:"provider,omitempty" validate:"max=100"`
+	Events   []InvoiceEventInput `json:"events" validate:"required,min=1,max=500,dive"`
+}
+
+// Validate sanitiza e valida o lote.
+func (r *ReconcileInvoicesRequest) Validate() error {
+	r.Provider = strings.ToLower(strings.TrimSpace(r.Provider))
+	for i := range r.Events {
+		r.Events[i].ExternalID = strings.TrimSpace(r.Events[i].ExternalID)
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	for i, e := range r.Events {
+		if e.Status != InvoiceStatusPaid && e.PaidAt != nil {
+			return fmt.Errorf("events[%d].paidAt is only allowed for PAID", i)
+		}
+	}
+	return nil
+}
+
+// Resultado de cada evento da reconciliação
+const (
+	InvoiceEventResultUpdated   = "updated"   // estado aplicado à fatura
+	InvoiceEventResultStale     = "stale"     // evento anterior ao último aplicado
+	InvoiceEventResultUnmatched = "unmatched" // nenhuma fatura com o externalId
+)
+
+// InvoiceEventResult resultado de um evento, na ordem do lote.
+type InvoiceEventResult struct {
+	Index         int            `json:"index"`
+	Status        string         `json:"status"`
+	InvoiceID     *string        `json:"invoiceId,omitempty"`
+	InvoiceStatus *InvoiceStatus `json:"invoiceStatus,omitempty"`
+}
+
+// ReconcileInvoicesResponse resposta da reconciliação.
+type ReconcileInvoicesResponse struct {
+	Updated   int                  `json:"updated"`
+	Stale     int                  `json:"stale"`
+	Unmatched int                  `json:"unmatched"`
+	Results   []InvoiceEventResult `json:"results"`
+}
+
+// normalizeInvoiceProvider provedores são comparados em minúsculas, como nos eventos de email.
+func normalizeInvoiceProvider(p *string) *string {
+	p = emptyToNil(trimOptional(p))
+	if p != nil {
+		lower := strings.ToLower(*p)
+		p = &lower
+	}
+	return p
+}
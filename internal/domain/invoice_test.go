@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoice_ApplyStatus(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-48 * time.Hour)
+
+	t.Run("paid without paidAt is settled in full now", func(t *testing.T) {
+		inv := Invoice{Status: InvoiceStatusOpen, Amount: 1500}
+		inv.ApplyStatus(InvoiceStatusPaid, nil, nil, now)
+
+		assert.Equal(t, InvoiceStatusPaid, inv.Status)
+		assert.Equal(t, 1500.0, inv.AmountPaid)
+		require.NotNil(t, inv.PaidAt)
+		assert.Equal(t, now, *inv.PaidAt)
+	})
+
+	t.Run("explicit paidAt and partial amount win", func(t *testing.T) {
+		partial := 500.0
+		inv := Invoice{Status: InvoiceStatusOpen, Amount: 1500}
+		inv.ApplyStatus(InvoiceStatusPaid, &partial, &earlier, now)
+
+		assert.Equal(t, 500.0, inv.AmountPaid)
+		assert.Equal(t, earlier, *inv.PaidAt)
+	})
+
+	t.Run("an already paid invoice keeps its payment", func(t *testing.T) {
+		inv := Invoice{Status: InvoiceStatusPaid, Amount: 1500, AmountPaid: 1200, PaidAt: &earlier}
+		inv.ApplyStatus(InvoiceStatusPaid, nil, nil, now)
+
+		assert.Equal(t, 1200.0, inv.AmountPaid)
+		assert.Equal(t, earlier, *inv.PaidAt)
+	})
+
+	t.Run("leaving PAID clears paidAt", func(t *testing.T) {
+		inv := Invoice{Status: InvoiceStatusPaid, Amount: 1500, AmountPaid: 1500, PaidAt: &earlier}
+		inv.ApplyStatus(InvoiceStatusVoid, nil, nil, now)
+
+		assert.Equal(t, InvoiceStatusVoid, inv.Status)
+		assert.Nil(t, inv.PaidAt)
+	})
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaintenanceScopeGlobal escopo da manutenção que vale para todos os workspaces
+const MaintenanceScopeGlobal = "global"
+
+// MaintenanceDefaultRetryAfter Retry-After quando a manutenção não tem previsão de término
+const MaintenanceDefaultRetryAfter = 60 * time.Second
+
+// Maintenance modo de manutenção ligado por um admin (ADMIN_TOKEN). Enquanto existe, requests de
+// escrita do escopo recebem 503 MAINTENANCE_MODE com Retry-After; leituras continuam funcionando.
+type Maintenance struct {
+	Scope     string     `json:"scope"` // MaintenanceScopeGlobal ou o ID do workspace
+	Message   *string    `json:"message"`
+	EndsAt    *time.Time `json:"endsAt"` // previsão de término; não desliga a manutenção sozinha
+	StartedAt time.Time  `json:"startedAt"`
+}
+
+// RetryAfter espera sugerida aos clientes: até a previsão de término ou, sem previsão (ou já
+// vencida), MaintenanceDefaultRetryAfter.
+func (m *Maintenance) RetryAfter(now time.Time) time.Duration {
+	if m.EndsAt != nil && m.EndsAt.After(now) {
+		return m.EndsAt.Sub(now)
+	}
+	return MaintenanceDefaultRetryAfter
+}
+
+// SetMaintenanceRequest DTO para ligar (ou atualizar) a manutenção de um escopo.
+type SetMaintenanceRequest struct {
+	Message *string    `json:"message" validate:"omitempty,max=500"`
+	EndsAt  *time.Time `json:"endsAt"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *SetMaintenanceRequest) Validate() error {
+	if r.Message != nil {
+		trimmed := strings.TrimSpace(*r.Message)
+		r.Message = &trimmed
+		if trimmed == "" {
+			r.Message = nil
+		}
+	}
+	return validate.Struct(r)
+}
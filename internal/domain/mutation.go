@@ -0,0 +1,37 @@
+package domain
+
+// ReturnExtended valor de ?return= que pede os agregados afetados junto com a resposta de
+// uma mutação (UI otimista: o board se atualiza sem recarregar tudo após o drag-and-drop).
+const ReturnExtended = "extended"
+
+// StageAggregate totais de um estágio do pipeline (negócios não excluídos).
+type StageAggregate struct {
+	StageID string  `json:"stageId"`
+	Count   int64   `json:"count"`
+	Value   float64 `json:"value"`
+}
+
+// TaskStatusAggregate total de tarefas visíveis ao usuário em uma coluna do board.
+type TaskStatusAggregate struct {
+	Status TaskStatus `json:"status"`
+	Count  int64      `json:"count"`
+}
+
+// MutationAggregates agregados afetados por uma mutação: estágios (ou colunas) de origem e destino.
+type MutationAggregates struct {
+	Stages   []StageAggregate      `json:"stages,omitempty"`
+	Statuses []TaskStatusAggregate `json:"statuses,omitempty"`
+}
+
+// DealMutationResult negócio alterado com os totais dos estágios afetados (return=extended).
+// Os campos do negócio continuam na raiz; aggregates é adicional.
+type DealMutationResult struct {
+	*Deal
+	Aggregates MutationAggregates `json:"aggregates"`
+}
+
+// TaskMutationResult tarefa alterada com os totais das colunas afetadas (return=extended).
+type TaskMutationResult struct {
+	*Task
+	Aggregates MutationAggregates `json:"aggregates"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDealMutationResult_JSON(t *testing.T) {
+	result := DealMutationResult{
+		Deal: &Deal{ID: "deal_1", Name: "Renovação"},
+		Aggregates: MutationAggregates{Stages: []StageAggregate{
+			{StageID: "stg_from", Count: 2, Value: 1500},
+			{StageID: "stg_to", Count: 1, Value: 500},
+		}},
+	}
+
+	body, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(body, &got))
+	// Campos do negócio continuam na raiz, como na resposta sem return=extended
+	assert.Equal(t, "deal_1", got["id"])
+	assert.Equal(t, "Renovação", got["name"])
+	assert.Equal(t, map[string]any{"stages": []any{
+		map[string]any{"stageId": "stg_from", "count": 2.0, "value": 1500.0},
+		map[string]any{"stageId": "stg_to", "count": 1.0, "value": 500.0},
+	}}, got["aggregates"])
+}
+
+func TestTaskMutationResult_JSON(t *testing.T) {
+	result := TaskMutationResult{
+		Task: &Task{ID: "tsk_1", Status: TaskStatusDone},
+		Aggregates: MutationAggregates{Statuses: []TaskStatusAggregate{
+			{Status: TaskStatusTodo, Count: 0},
+			{Status: TaskStatusDone, Count: 3},
+		}},
+	}
+
+	body, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "tsk_1", got["id"])
+	assert.Equal(t, map[string]any{"statuses": []any{
+		map[string]any{"status": "TODO", "count": 0.0},
+		map[string]any{"status": "DONE", "count": 3.0},
+	}}, got["aggregates"])
+}
@@ -0,0 +1,99 @@
+package domain
+
+import "time"
+
+// MyWorkParams parâmetros de GET /me/work.
+// "Hoje" é o dia corrente de Now no fuso Location; Limit vale por seção.
+type MyWorkParams struct {
+	Now      time.Time
+	Location *time.Location
+	Limit    int
+}
+
+// MyWorkMeetingsWindow é o horizonte das próximas reuniões.
+const MyWorkMeetingsWindow = 7 * 24 * time.Hour
+
+// Normalize aplica defaults: agora, UTC e 20 itens por seção (máx. 100).
+func (p *MyWorkParams) Normalize() {
+	if p.Now.IsZero() {
+		p.Now = time.Now().UTC()
+	}
+	if p.Location == nil {
+		p.Location = time.UTC
+	}
+	if p.Limit <= 0 {
+		p.Limit = 20
+	}
+	if p.Limit > 100 {
+		p.Limit = 100
+	}
+}
+
+// Today retorna o intervalo [início, fim) do dia corrente no fuso dos parâmetros.
+func (p MyWorkParams) Today() (time.Time, time.Time) {
+	local := p.Now.In(p.Location)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, p.Location)
+	return start.UTC(), start.AddDate(0, 0, 1).UTC()
+}
+
+// MyWorkTasks é uma seção de tarefas; Count é o total, Items respeita o limite.
+type MyWorkTasks struct {
+	Count int    `json:"count"`
+	Items []Task `json:"items"`
+}
+
+// MyWorkMeeting é uma reunião futura do usuário.
+type MyWorkMeeting struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	MeetingType string    `json:"meetingType"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	Location    *string   `json:"location"`
+	MeetingURL  *string   `json:"meetingUrl"`
+}
+
+// MyWorkMeetings é a seção de reuniões.
+type MyWorkMeetings struct {
+	Count int             `json:"count"`
+	Items []MyWorkMeeting `json:"items"`
+}
+
+// DealAttentionReason indica por que um negócio OPEN precisa de atenção.
+type DealAttentionReason string
+
+const (
+	DealAttentionNextStepOverdue DealAttentionReason = "next_step_overdue"
+	DealAttentionNoNextStep      DealAttentionReason = "no_next_step"
+	DealAttentionCloseDatePassed DealAttentionReason = "close_date_passed"
+)
+
+// MyWorkDeal é um negócio OPEN do usuário que precisa de atenção.
+// Reason traz o primeiro motivo aplicável, na ordem das constantes.
+type MyWorkDeal struct {
+	ID                string              `json:"id"`
+	Name              string              `json:"name"`
+	PipelineID        string              `json:"pipelineId"`
+	StageID           *string             `json:"stageId"`
+	Value             *float64            `json:"value"`
+	Currency          string              `json:"currency"`
+	NextStepAt        *time.Time          `json:"nextStepAt"`
+	ExpectedCloseDate *time.Time          `json:"expectedCloseDate"`
+	Reason            DealAttentionReason `json:"reason"`
+}
+
+// MyWorkDeals é a seção de negócios.
+type MyWorkDeals struct {
+	Count int          `json:"count"`
+	Items []MyWorkDeal `json:"items"`
+}
+
+// MyWork resposta de GET /me/work: o trabalho do ator em uma chamada.
+type MyWork struct {
+	AsOf                    time.Time      `json:"asOf"`
+	TimeZone                string         `json:"timeZone"`
+	OverdueTasks            MyWorkTasks    `json:"overdueTasks"`
+	TodayTasks              MyWorkTasks    `json:"todayTasks"`
+	UpcomingMeetings        MyWorkMeetings `json:"upcomingMeetings"`
+	DealsRequiringAttention MyWorkDeals    `json:"dealsRequiringAttention"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// NextStep é o próximo passo de follow-up de um contato ou negócio.
+// Embutido em Contact, Deal e nos DTOs de criação/atualização.
+type NextStep struct {
+	NextStepAt   *time.Time `json:"nextStepAt,omitempty"`
+	NextStepNote *string    `json:"nextStepNote,omitempty" validate:"omitempty,max=1000"`
+}
+
+// IsScheduledAfter indica se há um próximo passo agendado depois de now.
+func (n NextStep) IsScheduledAfter(now time.Time) bool {
+	return n.NextStepAt != nil && n.NextStepAt.After(now)
+}
+
+// OverdueRecordType identifica o tipo de registro no relatório de atrasados.
+type OverdueRecordType string
+
+const (
+	OverdueContact OverdueRecordType = "contact"
+	OverdueDeal    OverdueRecordType = "deal"
+)
+
+// IsValid valida se o tipo de registro é suportado.
+func (t OverdueRecordType) IsValid() bool {
+	return t == OverdueContact || t == OverdueDeal
+}
+
+// OverdueReportParams parâmetros de /reports/overdue.
+// AsOf é o instante de corte: próximos passos anteriores a ele estão atrasados.
+type OverdueReportParams struct {
+	WorkspaceID string
+	OwnerID     *string
+	Type        *OverdueRecordType
+	AsOf        time.Time
+}
+
+// OverdueItem é um contato ou negócio (OPEN) com próximo passo vencido.
+type OverdueItem struct {
+	Type         OverdueRecordType `json:"type"`
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	OwnerID      *string           `json:"ownerId"`
+	NextStepAt   time.Time         `json:"nextStepAt"`
+	NextStepNote *string           `json:"nextStepNote"`
+	DaysOverdue  int               `json:"daysOverdue"`
+}
+
+// OverdueOwnerGroup agrupa os itens atrasados de um responsável.
+// OwnerID nil agrupa registros sem responsável.
+type OverdueOwnerGroup struct {
+	OwnerID *string       `json:"ownerId"`
+	Count   int           `json:"count"`
+	Items   []OverdueItem `json:"items"`
+}
+
+// OverdueReport resposta de /reports/overdue.
+type OverdueReport struct {
+	AsOf   time.Time           `json:"asOf"`
+	Owners []OverdueOwnerGroup `json:"owners"`
+	Total  int                 `json:"total"`
+}
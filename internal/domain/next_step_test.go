@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextStep_IsScheduledAfter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+
+	tests := []struct {
+		name string
+		step NextStep
+		want bool
+	}{
+		{"not scheduled", NextStep{}, false},
+		{"future", NextStep{NextStepAt: at(time.Hour)}, true},
+		{"exactly now", NextStep{NextStepAt: at(0)}, false},
+		{"past due", NextStep{NextStepAt: at(-time.Minute)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.step.IsScheduledAfter(now))
+		})
+	}
+}
+
+func TestOverdueRecordType_IsValid(t *testing.T) {
+	assert.True(t, OverdueContact.IsValid())
+	assert.True(t, OverdueDeal.IsValid())
+	assert.False(t, OverdueRecordType("company").IsValid())
+	assert.False(t, OverdueRecordType("").IsValid())
+}
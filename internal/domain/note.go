@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultNoteAttachmentMaxBytes tamanho máximo padrão de um anexo de nota (10 MiB).
+const DefaultNoteAttachmentMaxBytes = 10 << 20
+
+// NoteAttachment arquivo anexado a uma nota; o conteúdo fica no object storage e o body da
+// nota pode referenciá-lo inline por id (nó "attachment").
+type NoteAttachment struct {
+	ID           string    `json:"id"`
+	WorkspaceID  string    `json:"workspaceId"`
+	NoteID       string    `json:"noteId"`
+	FileName     string    `json:"fileName"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	StorageKey   string    `json:"-"`
+	UploadedByID string    `json:"uploadedById"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Validate sanitiza e valida o request: o body é sanitizado e o content passa a ser a
+// versão texto puro dele; só com content, o body é montado a partir do texto.
+func (r *CreateNoteRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	body, content, err := normalizeNoteBody(r.Body, r.Content)
+	if err != nil {
+		return err
+	}
+	r.Body, r.Content = body, content
+	return nil
+}
+
+// UpdateNoteRequest DTO para editar o texto de uma nota (mesma regra de body/content da criação).
+type UpdateNoteRequest struct {
+	Content    string        `json:"content" validate:"required_without=Body"`
+	Body       *RichTextNode `json:"body"`
+	Visibility *Visibility   `json:"visibility" validate:"omitempty,oneof=PRIVATE TEAM WORKSPACE"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateNoteRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	body, content, err := normalizeNoteBody(r.Body, r.Content)
+	if err != nil {
+		return err
+	}
+	r.Body, r.Content = body, content
+	return nil
+}
+
+func normalizeNoteBody(body *RichTextNode, content string) (*RichTextNode, string, error) {
+	if body == nil {
+		content = strings.TrimSpace(content)
+		if content == "" {
+			return nil, "", fmt.Errorf("note content cannot be empty")
+		}
+		return NewPlainTextDoc(content), content, nil
+	}
+	if err := body.Sanitize(); err != nil {
+		return nil, "", err
+	}
+	content = body.PlainText()
+	if strings.TrimSpace(content) == "" && len(body.AttachmentIDs()) == 0 {
+		return nil, "", fmt.Errorf("note body cannot be empty")
+	}
+	return body, content, nil
+}
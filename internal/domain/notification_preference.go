@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NotificationChannel por onde um evento chega ao usuário.
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app" // Somente caixa de entrada (padrão)
+	NotificationChannelEmail NotificationChannel = "email"  // Caixa de entrada + email
+	NotificationChannelNone  NotificationChannel = "none"   // Não entrega
+)
+
+// NotificationEvents eventos configuráveis nas preferências.
+var NotificationEvents = []NotificationEvent{
+	NotificationUpdated,
+	NotificationAssigned,
+	NotificationMentioned,
+	NotificationStageChanged,
+	NotificationNoteAdded,
+}
+
+// QuietHours faixa diária (HH:MM, no fuso Timezone) em que emails de notificação não são
+// enviados; a notificação continua indo para a caixa de entrada. Start depois de End
+// atravessa a meia-noite (ex.: 22:00-07:00).
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start,omitempty" validate:"required_if=Enabled true,omitempty,len=5"`
+	End      string `json:"end,omitempty" validate:"required_if=Enabled true,omitempty,len=5"`
+	Timezone string `json:"timezone,omitempty" validate:"required_if=Enabled true,omitempty,max=64"`
+}
+
+// Contains informa se t cai dentro da faixa silenciosa. Faixas inválidas nunca contêm t.
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil || !q.Enabled {
+		return false
+	}
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return false
+	}
+	start, err1 := parseClock(q.Start)
+	end, err2 := parseClock(q.End)
+	if err1 != nil || err2 != nil || start == end {
+		return false
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// NotificationPreferences preferências de notificação do usuário no workspace. Events
+// sempre traz todos os eventos de NotificationEvents (in_app quando não configurado).
+type NotificationPreferences struct {
+	WorkspaceID string                                    `json:"workspaceId"`
+	UserID      string                                    `json:"userId"`
+	Events      map[NotificationEvent]NotificationChannel `json:"events"`
+	QuietHours  QuietHours                                `json:"quietHours"`
+	UpdatedAt   *time.Time                                `json:"updatedAt"` // nil enquanto o usuário usa o padrão
+}
+
+// DefaultNotificationPreferences preferências de quem nunca configurou: tudo in_app.
+func DefaultNotificationPreferences(workspaceID, userID string) *NotificationPreferences {
+	p := &NotificationPreferences{WorkspaceID: workspaceID, UserID: userID}
+	p.Normalize()
+	return p
+}
+
+// Normalize preenche com in_app os eventos ausentes e descarta eventos desconhecidos.
+func (p *NotificationPreferences) Normalize() {
+	events := make(map[NotificationEvent]NotificationChannel, len(NotificationEvents))
+	for _, event := range NotificationEvents {
+		channel, ok := p.Events[event]
+		if !ok || channel == "" {
+			channel = NotificationChannelInApp
+		}
+		events[event] = channel
+	}
+	p.Events = events
+}
+
+// Channel canal configurado para o evento (in_app quando não configurado).
+func (p *NotificationPreferences) Channel(event NotificationEvent) NotificationChannel {
+	if channel, ok := p.Events[event]; ok && channel != "" {
+		return channel
+	}
+	return NotificationChannelInApp
+}
+
+// UpdateNotificationPreferencesRequest DTO do PATCH: eventos informados substituem o canal
+// atual (os demais são mantidos); quietHours, quando informado, substitui a faixa inteira
+// ({"enabled": false} desliga).
+type UpdateNotificationPreferencesRequest struct {
+	Events     map[NotificationEvent]NotificationChannel `json:"events" validate:"omitempty,dive,keys,oneof=updated assigned mentioned stage_changed note_added,endkeys,oneof=in_app email none"`
+	QuietHours *QuietHours                               `json:"quietHours"`
+}
+
+// Validate sanitiza e valida o request: horários HH:MM distintos e fuso IANA.
+func (r *UpdateNotificationPreferencesRequest) Validate() error {
+	if q := r.QuietHours; q != nil {
+		q.Start = strings.TrimSpace(q.Start)
+		q.End = strings.TrimSpace(q.End)
+		q.Timezone = strings.TrimSpace(q.Timezone)
+		if !q.Enabled {
+			*q = QuietHours{}
+		}
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	if q := r.QuietHours; q != nil && q.Enabled {
+		start, err := parseClock(q.Start)
+		if err != nil {
+			return err
+		}
+		end, err := parseClock(q.End)
+		if err != nil {
+			return err
+		}
+		if start == end {
+			return fmt.Errorf("quietHours start and end must differ")
+		}
+		if _, err := time.LoadLocation(q.Timezone); err != nil {
+			return fmt.Errorf("timezone %q is not a valid IANA time zone", q.Timezone)
+		}
+	}
+	return nil
+}
+
+// Apply aplica o PATCH sobre as preferências atuais.
+func (r *UpdateNotificationPreferencesRequest) Apply(p *NotificationPreferences) {
+	for event, channel := range r.Events {
+		p.Events[event] = channel
+	}
+	if r.QuietHours != nil {
+		p.QuietHours = *r.QuietHours
+	}
+	p.Normalize()
+}
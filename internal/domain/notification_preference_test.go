@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHours_Contains(t *testing.T) {
+	overnight := &QuietHours{Enabled: true, Start: "22:00", End: "07:00", Timezone: "America/Sao_Paulo"}
+	lunch := &QuietHours{Enabled: true, Start: "12:00", End: "13:30", Timezone: "America/Sao_Paulo"}
+
+	// São Paulo = UTC-3
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour+3, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		quiet *QuietHours
+		t     time.Time
+		want  bool
+	}{
+		{"overnight late evening", overnight, at(23, 15), true},
+		{"overnight at start", overnight, at(22, 0), true},
+		{"overnight early morning", overnight, at(6, 59), true},
+		{"overnight at end", overnight, at(7, 0), false},
+		{"overnight daytime", overnight, at(15, 0), false},
+		{"same-day inside", lunch, at(12, 45), true},
+		{"same-day before", lunch, at(11, 59), false},
+		{"same-day at end", lunch, at(13, 30), false},
+		{"disabled", &QuietHours{Start: "00:00", End: "23:59", Timezone: "UTC"}, at(12, 0), false},
+		{"nil", nil, at(12, 0), false},
+		{"unknown timezone", &QuietHours{Enabled: true, Start: "00:00", End: "23:59", Timezone: "Mars/Base"}, at(12, 0), false},
+		{"empty range", &QuietHours{Enabled: true, Start: "10:00", End: "10:00", Timezone: "UTC"}, at(12, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.quiet.Contains(tt.t))
+		})
+	}
+}
+
+func TestNotificationPreferences_Normalize(t *testing.T) {
+	p := &NotificationPreferences{Events: map[NotificationEvent]NotificationChannel{
+		NotificationMentioned: NotificationChannelEmail,
+		NotificationAssigned:  "",
+		"archived":            NotificationChannelNone,
+	}}
+	p.Normalize()
+
+	assert.Len(t, p.Events, len(NotificationEvents))
+	assert.NotContains(t, p.Events, NotificationEvent("archived"))
+	assert.Equal(t, NotificationChannelEmail, p.Channel(NotificationMentioned))
+	assert.Equal(t, NotificationChannelInApp, p.Channel(NotificationAssigned))
+	assert.Equal(t, NotificationChannelInApp, p.Channel(NotificationUpdated))
+
+	defaults := DefaultNotificationPreferences("ws_1", "usr_1")
+	for _, event := range NotificationEvents {
+		assert.Equal(t, NotificationChannelInApp, defaults.Events[event], event)
+	}
+	assert.False(t, defaults.QuietHours.Enabled)
+	assert.Nil(t, defaults.UpdatedAt)
+}
+
+func TestUpdateNotificationPreferencesRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     UpdateNotificationPreferencesRequest
+		wantErr bool
+	}{
+		{"empty", UpdateNotificationPreferencesRequest{}, false},
+		{"events", UpdateNotificationPreferencesRequest{Events: map[NotificationEvent]NotificationChannel{
+			NotificationMentioned: NotificationChannelEmail, NotificationUpdated: NotificationChannelNone,
+		}}, false},
+		{"unknown event", UpdateNotificationPreferencesRequest{Events: map[NotificationEvent]NotificationChannel{
+			"archived": NotificationChannelEmail,
+		}}, true},
+		{"unknown channel", UpdateNotificationPreferencesRequest{Events: map[NotificationEvent]NotificationChannel{
+			NotificationMentioned: "sms",
+		}}, true},
+		{"quiet hours", UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{
+			Enabled: true, Start: " 22:00 ", End: "07:00", Timezone: "America/Sao_Paulo",
+		}}, false},
+		{"quiet hours without timezone", UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{
+			Enabled: true, Start: "22:00", End: "07:00",
+		}}, true},
+		{"quiet hours with unknown timezone", UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{
+			Enabled: true, Start: "22:00", End: "07:00", Timezone: "Mars/Base",
+		}}, true},
+		{"quiet hours malformed", UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{
+			Enabled: true, Start: "22h00", End: "07:00", Timezone: "UTC",
+		}}, true},
+		{"quiet hours out of range", UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{
+			Enabled: true, Start: "25:00", End: "07:00", Timezone: "UTC",
+		}}, true},
+		{"quiet hours empty range", UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{
+			Enabled: true, Start: "07:00", End: "07:00", Timezone: "UTC",
+		}}, true},
+		{"disabled quiet hours ignore the rest", UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{
+			Start: "garbage", Timezone: "Mars/Base",
+		}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateNotificationPreferencesRequest_Apply(t *testing.T) {
+	p := DefaultNotificationPreferences("ws_1", "usr_1")
+	p.Events[NotificationAssigned] = NotificationChannelEmail
+	p.QuietHours = QuietHours{Enabled: true, Start: "22:00", End: "07:00", Timezone: "UTC"}
+
+	// Eventos omitidos e quietHours ausente são mantidos
+	req := &UpdateNotificationPreferencesRequest{Events: map[NotificationEvent]NotificationChannel{
+		NotificationMentioned: NotificationChannelNone,
+	}}
+	require.NoError(t, req.Validate())
+	req.Apply(p)
+	assert.Equal(t, NotificationChannelEmail, p.Channel(NotificationAssigned))
+	assert.Equal(t, NotificationChannelNone, p.Channel(NotificationMentioned))
+	assert.True(t, p.QuietHours.Enabled)
+
+	// {"enabled": false} desliga a faixa inteira
+	req = &UpdateNotificationPreferencesRequest{QuietHours: &QuietHours{Start: "01:00"}}
+	require.NoError(t, req.Validate())
+	req.Apply(p)
+	assert.Equal(t, QuietHours{}, p.QuietHours)
+	assert.Equal(t, NotificationChannelEmail, p.Channel(NotificationAssigned))
+}
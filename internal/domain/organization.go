@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// OrgRole papel do usuário na organização (OrganizationMember.role). Independe do papel em
+// cada workspace, que continua sendo resolvido por WorkspaceMember.
+type OrgRole string
+
+const (
+	// OrgRoleAdmin administra a organização: dados, cobrança, papéis e relatórios entre workspaces
+	OrgRoleAdmin OrgRole = "org_admin"
+	// OrgRoleMember vê a organização, seus workspaces e o diretório de membros
+	OrgRoleMember OrgRole = "org_member"
+)
+
+// IsValid checks if the org role is one of the defined constants
+func (r OrgRole) IsValid() bool {
+	return r == OrgRoleAdmin || r == OrgRoleMember
+}
+
+// IsOrgMember checks if the role grants read access to the organization
+func IsOrgMember(role OrgRole) bool {
+	return role.IsValid()
+}
+
+// CanManageOrganization checks if the role can change the organization, its billing and members
+func CanManageOrganization(role OrgRole) bool {
+	return role == OrgRoleAdmin
+}
+
+// Organization agrupa workspaces com cobrança e diretório de membros compartilhados.
+type Organization struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Document       *string   `json:"document,omitempty"`
+	BillingEmail   *string   `json:"billingEmail,omitempty"`
+	WorkspaceCount int       `json:"workspaceCount"`
+	Role           OrgRole   `json:"role"` // papel do ator na organização
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// OrganizationListResponse organizações do ator.
+type OrganizationListResponse struct {
+	Data []Organization `json:"data"`
+}
+
+// UpdateOrganizationRequest PATCH /v1/orgs/{orgId}. billingEmail vazio remove o email de cobrança.
+type UpdateOrganizationRequest struct {
+	Name         *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	BillingEmail *string `json:"billingEmail,omitempty" validate:"omitempty,email,max=254"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateOrganizationRequest) Validate() error {
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+	if r.BillingEmail != nil {
+		trimmed := strings.ToLower(strings.TrimSpace(*r.BillingEmail))
+		r.BillingEmail = &trimmed
+	}
+	return validate.Struct(r)
+}
+
+// OrganizationBilling cobrança compartilhada da organização. Seats conta usuários distintos
+// com acesso a pelo menos um workspace da organização.
+type OrganizationBilling struct {
+	OrganizationID       string  `json:"organizationId"`
+	BillingEmail         *string `json:"billingEmail,omitempty"`
+	StripeCustomerID     *string `json:"stripeCustomerId,omitempty"`
+	StripeSubscriptionID *string `json:"stripeSubscriptionId,omitempty"`
+	WorkspaceCount       int     `json:"workspaceCount"`
+	Seats                int     `json:"seats"`
+}
+
+// OrganizationWorkspace workspace da organização.
+type OrganizationWorkspace struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	OwnerID     string    `json:"ownerId"`
+	MemberCount int       `json:"memberCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// OrganizationWorkspaceListResponse workspaces da organização.
+type OrganizationWorkspaceListResponse struct {
+	Data []OrganizationWorkspace `json:"data"`
+}
+
+// OrganizationMemberWorkspace papel do membro em um workspace da organização.
+type OrganizationMemberWorkspace struct {
+	WorkspaceID   string `json:"workspaceId"`
+	WorkspaceName string `json:"workspaceName"`
+	Role          Role   `json:"role"`
+}
+
+// OrganizationMember entrada do diretório compartilhado: todo usuário com papel na organização
+// ou em algum dos seus workspaces. OrgRole nil = só membro de workspace.
+type OrganizationMember struct {
+	UserID     string                        `json:"userId"`
+	Name       *string                       `json:"name,omitempty"`
+	Email      *string                       `json:"email,omitempty"`
+	Image      *string                       `json:"image,omitempty"`
+	OrgRole    *OrgRole                      `json:"orgRole,omitempty"`
+	Workspaces []OrganizationMemberWorkspace `json:"workspaces"`
+}
+
+// OrganizationMemberListResponse diretório de membros da organização.
+type OrganizationMemberListResponse struct {
+	Data []OrganizationMember `json:"data"`
+}
+
+// SetOrganizationMemberRequest PUT /v1/orgs/{orgId}/members/{userId}.
+type SetOrganizationMemberRequest struct {
+	Role OrgRole `json:"role" validate:"required,oneof=org_admin org_member"`
+}
+
+// Validate valida o request.
+func (r *SetOrganizationMemberRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// OrganizationWorkspaceStats números de um workspace no relatório da organização.
+type OrganizationWorkspaceStats struct {
+	WorkspaceID   string  `json:"workspaceId"`
+	WorkspaceName string  `json:"workspaceName"`
+	Contacts      int     `json:"contacts"`
+	Companies     int     `json:"companies"`
+	OpenDeals     int     `json:"openDeals"`
+	OpenValue     float64 `json:"openValue"`
+	WonDeals      int     `json:"wonDeals"`
+	WonValue      float64 `json:"wonValue"`
+	OpenTasks     int     `json:"openTasks"`
+}
+
+// OrganizationOverviewReport relatório entre workspaces: estoque atual (contatos, empresas,
+// negócios e tarefas abertos) e negócios ganhos desde Since, por workspace e no total.
+type OrganizationOverviewReport struct {
+	OrganizationID string                       `json:"organizationId"`
+	Since          time.Time                    `json:"since"`
+	Workspaces     []OrganizationWorkspaceStats `json:"workspaces"`
+	Totals         OrganizationWorkspaceStats   `json:"totals"`
+}
+
+// DefaultOrganizationOverviewDays janela padrão dos negócios ganhos no relatório da organização.
+const DefaultOrganizationOverviewDays = 30
+
+// NewOrganizationOverviewReport monta o relatório e soma os totais.
+func NewOrganizationOverviewReport(orgID string, since time.Time, rows []OrganizationWorkspaceStats) *OrganizationOverviewReport {
+	report := &OrganizationOverviewReport{OrganizationID: orgID, Since: since, Workspaces: rows}
+	for _, row := range rows {
+		report.Totals.Contacts += row.Contacts
+		report.Totals.Companies += row.Companies
+		report.Totals.OpenDeals += row.OpenDeals
+		report.Totals.OpenValue += row.OpenValue
+		report.Totals.WonDeals += row.WonDeals
+		report.Totals.WonValue += row.WonValue
+		report.Totals.OpenTasks += row.OpenTasks
+	}
+	return report
+}
@@ -115,7 +115,6 @@ func (t PipelineType) Value() (driver.Value, error) {
 
 // Pipeline representa um funil de vendas/processo no CRM.
 // Schema: public."Pipeline" (schema real do Prisma)
-// IMPORTANTE: Schema real é mais simples - apenas name, description, isDefault
 type Pipeline struct {
 	// Identificadores - IDs são TEXT no Prisma
 	ID          string `json:"id" db:"id"`
@@ -125,11 +124,11 @@ type Pipeline struct {
 	Name        string  `json:"name" db:"name"`
 	Description *string `json:"description,omitempty" db:"description"`
 
-	// Configuração - schema real só tem isDefault
-	PipelineType PipelineType `json:"pipelineType" db:"pipeline_type"` // Added for service compatibility
-	IsActive     bool         `json:"isActive" db:"is_active"`         // Added for service compatibility
+	// Configuração
+	PipelineType PipelineType `json:"pipelineType" db:"pipelineType"`
+	IsActive     bool         `json:"isActive" db:"isActive"`
 	IsDefault    bool         `json:"isDefault" db:"isDefault"`
-	OwnerID      string       `json:"ownerId" db:"owner_id"` // Added for service compatibility
+	OwnerID      string       `json:"ownerId" db:"ownerId"` // '' = sem dono (pipelines anteriores à coluna)
 
 	// Timestamps
 	CreatedAt time.Time  `json:"createdAt" db:"createdAt"`
@@ -158,13 +157,28 @@ type PipelineStage struct {
 	OrderIndex      int          `json:"orderIndex" db:"orderIndex"`
 	Color           *string      `json:"color,omitempty" db:"color"`
 	IsLocked        bool         `json:"isLocked" db:"isLocked"`
-	Probability     int          `json:"probability" db:"probability"`
+	Probability     *int         `json:"probability,omitempty" db:"probability"` // 0-100; deriva a categoria de forecast dos deals
 	AutoArchiveDays *int         `json:"autoArchiveDays,omitempty" db:"auto_archive_after_days"`
+	RottingDays     *int         `json:"rottingDays,omitempty" db:"rottingDays"` // Dias sem atividade até o deal ficar parado (nil = desativado)
+
+	// SLA (somente estágios TICKET): metas em minutos desde a abertura do ticket
+	FirstResponseSLAMinutes *int `json:"firstResponseSlaMinutes,omitempty" db:"firstResponseSlaMinutes"`
+	ResolutionSLAMinutes    *int `json:"resolutionSlaMinutes,omitempty" db:"resolutionSlaMinutes"`
 
 	// Timestamps
 	CreatedAt time.Time  `json:"createdAt" db:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt" db:"updatedAt"`
 	DeletedAt *time.Time `json:"deletedAt,omitempty" db:"deletedAt"`
+
+	// Totais do cabeçalho do quadro (somente com ?includeMetrics=true)
+	Metrics *StageMetrics `json:"metrics,omitempty" db:"-"`
+}
+
+// StageMetrics totaliza os negócios em aberto (stage OPEN, não excluídos) de uma etapa.
+// OpenValue soma deal.value sem conversão de moeda, como em /reports/pipeline-summary.
+type StageMetrics struct {
+	OpenDeals int64   `json:"openDeals"`
+	OpenValue float64 `json:"openValue"`
 }
 
 // CreatePipelineRequest DTO para criação de pipeline.
@@ -176,9 +190,14 @@ type CreatePipelineRequest struct {
 	// Dados opcionais
 	Description  *string       `json:"description,omitempty" validate:"omitempty,max=5000"`
 	IsDefault    *bool         `json:"isDefault,omitempty"`
-	PipelineType *PipelineType `json:"pipelineType,omitempty"`
+	PipelineType *PipelineType `json:"pipelineType,omitempty" validate:"omitempty,oneof=TASK DEAL TICKET CONTACT"`
 	IsActive     *bool         `json:"isActive,omitempty"`
-	OwnerID      *string       `json:"ownerId,omitempty"`
+	OwnerID      *string       `json:"ownerId,omitempty" validate:"omitempty,id"`
+}
+
+// Validate valida o request; pipelineType fora do ENUM viraria erro do banco (500).
+func (r *CreatePipelineRequest) Validate() error {
+	return validate.Struct(r)
 }
 
 // CreatePipelineWithStagesRequest DTO para criar pipeline + stages em uma operação.
@@ -201,14 +220,23 @@ type CreateStageRequest struct {
 	OrderIndex           *int        `json:"orderIndex,omitempty" validate:"omitempty,gte=0"`
 	Probability          *int        `json:"probability,omitempty" validate:"omitempty,gte=0,lte=100"`
 	AutoArchiveDays      *int        `json:"autoArchiveDays,omitempty" validate:"omitempty,gte=1"`
+	RottingDays          *int        `json:"rottingDays,omitempty" validate:"omitempty,gte=1,lte=365"`
 	Color                *string     `json:"color,omitempty"`
 }
 
 // UpdatePipelineRequest DTO para atualização parcial de pipeline (PATCH semântico).
 type UpdatePipelineRequest struct {
-	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
-	Description *string `json:"description,omitempty" validate:"omitempty,max=5000"`
-	IsDefault   *bool   `json:"isDefault,omitempty"`
+	Name         *string       `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description  *string       `json:"description,omitempty" validate:"omitempty,max=5000"`
+	IsDefault    *bool         `json:"isDefault,omitempty"`
+	PipelineType *PipelineType `json:"pipelineType,omitempty" validate:"omitempty,oneof=TASK DEAL TICKET CONTACT"`
+	IsActive     *bool         `json:"isActive,omitempty"`
+	OwnerID      *string       `json:"ownerId,omitempty" validate:"omitempty,id"`
+}
+
+// Validate valida o request de atualização.
+func (r *UpdatePipelineRequest) Validate() error {
+	return validate.Struct(r)
 }
 
 // UpdateStageRequest DTO para atualização parcial de estágio.
@@ -220,12 +248,20 @@ type UpdateStageRequest struct {
 	OrderIndex  *int          `json:"orderIndex,omitempty" validate:"omitempty,gte=0"`
 	Color       *string       `json:"color,omitempty"`
 	IsLocked    *bool         `json:"isLocked,omitempty"`
+	RottingDays *int          `json:"rottingDays,omitempty" validate:"omitempty,gte=0,lte=365"` // 0 desativa a detecção
+
+	// Probabilidade de fechamento (0-100); recalcula a categoria de forecast dos deals da etapa
+	Probability *int `json:"probability,omitempty" validate:"omitempty,gte=0,lte=100"`
+
+	// SLA (somente estágios TICKET); 0 desativa o timer
+	FirstResponseSLAMinutes *int `json:"firstResponseSlaMinutes,omitempty" validate:"omitempty,gte=0,lte=525600"`
+	ResolutionSLAMinutes    *int `json:"resolutionSlaMinutes,omitempty" validate:"omitempty,gte=0,lte=525600"`
 }
 
 // ReorderStagesRequest DTO para reordenar stages (batch update).
 type ReorderStagesRequest struct {
 	StageOrders []struct {
-		StageID    string `json:"stageId" validate:"required"`
+		StageID    string `json:"stageId" validate:"required,id"`
 		OrderIndex int    `json:"orderIndex" validate:"required,gte=0"`
 	} `json:"stageOrders" validate:"required,min=1,dive"`
 }
@@ -236,7 +272,10 @@ type ListPipelinesParams struct {
 	WorkspaceID string
 
 	// Filtros opcionais
-	IsDefault *bool
+	IsDefault    *bool
+	PipelineType *PipelineType
+	IsActive     *bool
+	OwnerID      *string
 
 	// Busca textual (name + description)
 	Query *string
@@ -246,7 +285,7 @@ type ListPipelinesParams struct {
 
 	// Paginação
 	Limit  int
-	Cursor *string // RFC3339 timestamp
+	Cursor *string // nextCursor da página anterior (opaco)
 	Sort   string  // "name:asc", "createdAt:desc", etc.
 }
 
@@ -276,3 +315,8 @@ type PipelineListResponse struct {
 		NextCursor  *string `json:"nextCursor,omitempty"`
 	} `json:"meta"`
 }
+
+// PipelineStageListResponse resposta de GET /pipelines/{pipelineId}/stages.
+type PipelineStageListResponse struct {
+	Data []PipelineStage `json:"data"`
+}
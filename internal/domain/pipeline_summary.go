@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// PipelineSummaryParams parâmetros de /reports/pipeline-summary.
+type PipelineSummaryParams struct {
+	WorkspaceID string
+	PipelineID  *string
+	OwnerID     *string
+	Since       time.Time // início da janela de ganhos/perdas (closedAt)
+}
+
+// PipelineSummaryRow totais de uma etapa: negócios em aberto (posição atual) e
+// ganhos/perdidos fechados desde params.Since. Valores somam deal.value sem conversão de moeda.
+type PipelineSummaryRow struct {
+	PipelineID   string  `json:"pipelineId"`
+	PipelineName string  `json:"pipelineName"`
+	StageID      string  `json:"stageId"`
+	StageName    string  `json:"stageName"`
+	OpenDeals    int64   `json:"openDeals"`
+	OpenValue    float64 `json:"openValue"`
+	WonDeals     int64   `json:"wonDeals"`
+	WonValue     float64 `json:"wonValue"`
+	LostDeals    int64   `json:"lostDeals"`
+}
+
+// PipelineSummaryReport resposta de /reports/pipeline-summary.
+type PipelineSummaryReport struct {
+	Since     time.Time            `json:"since"`
+	Rows      []PipelineSummaryRow `json:"rows"`
+	OpenDeals int64                `json:"openDeals"`
+	OpenValue float64              `json:"openValue"`
+	WonDeals  int64                `json:"wonDeals"`
+	WonValue  float64              `json:"wonValue"`
+	LostDeals int64                `json:"lostDeals"`
+}
+
+// DefaultPipelineSummaryDays janela padrão de ganhos/perdas (última semana).
+const DefaultPipelineSummaryDays = 7
+
+// NewPipelineSummaryReport soma os totais gerais a partir das linhas.
+func NewPipelineSummaryReport(since time.Time, rows []PipelineSummaryRow) *PipelineSummaryReport {
+	report := &PipelineSummaryReport{Since: since, Rows: rows}
+	for _, row := range rows {
+		report.OpenDeals += row.OpenDeals
+		report.OpenValue += row.OpenValue
+		report.WonDeals += row.WonDeals
+		report.WonValue += row.WonValue
+		report.LostDeals += row.LostDeals
+	}
+	return report
+}
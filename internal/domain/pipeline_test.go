@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePipelineRequest_Validate(t *testing.T) {
+	ticket, unknown := PipelineTypeTicket, PipelineType("SALES")
+	owner, badOwner := "usr_01J0OWNER", "usr 1; DROP"
+
+	tests := []struct {
+		name    string
+		req     CreatePipelineRequest
+		wantErr bool
+	}{
+		{"name only", CreatePipelineRequest{Name: "Vendas"}, false},
+		{"type and owner", CreatePipelineRequest{Name: "Suporte", PipelineType: &ticket, OwnerID: &owner}, false},
+		{"missing name", CreatePipelineRequest{PipelineType: &ticket}, true},
+		{"type outside the enum", CreatePipelineRequest{Name: "Vendas", PipelineType: &unknown}, true},
+		{"malformed owner", CreatePipelineRequest{Name: "Vendas", OwnerID: &badOwner}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdatePipelineRequest_Validate(t *testing.T) {
+	contact, unknown := PipelineTypeContact, PipelineType("deal")
+	inactive := false
+	empty, owner := "", "usr_01J0OWNER"
+
+	assert.NoError(t, (&UpdatePipelineRequest{}).Validate())
+	assert.NoError(t, (&UpdatePipelineRequest{PipelineType: &contact, IsActive: &inactive, OwnerID: &owner}).Validate())
+	assert.Error(t, (&UpdatePipelineRequest{PipelineType: &unknown}).Validate())
+	assert.Error(t, (&UpdatePipelineRequest{Name: &empty}).Validate())
+}
@@ -0,0 +1,92 @@
+package domain
+
+import "strings"
+
+// PromotionResource recurso de configuração copiado na promoção sandbox → produção.
+type PromotionResource string
+
+const (
+	PromotionPipeline         PromotionResource = "pipeline"
+	PromotionStage            PromotionResource = "stage"
+	PromotionComputedField    PromotionResource = "computedField"
+	PromotionCustomObjectType PromotionResource = "customObjectType"
+	PromotionEmailTemplate    PromotionResource = "emailTemplate"
+	PromotionDocumentTemplate PromotionResource = "documentTemplate"
+	PromotionSequence         PromotionResource = "sequence"
+)
+
+// PromotionAction o que a promoção faz com o recurso no workspace de destino.
+type PromotionAction string
+
+const (
+	PromotionCreate    PromotionAction = "CREATE"
+	PromotionUpdate    PromotionAction = "UPDATE"
+	PromotionUnchanged PromotionAction = "UNCHANGED"
+)
+
+// PromoteWorkspaceRequest DTO para promover a configuração de um sandbox para produção.
+//
+// O sandbox (origem) vem do path parameter; no preview o destino vem da query string.
+type PromoteWorkspaceRequest struct {
+	// Workspace de produção que receberá a configuração
+	TargetWorkspaceID string `json:"targetWorkspaceId" validate:"required,id"`
+}
+
+// Validate valida o PromoteWorkspaceRequest.
+func (r *PromoteWorkspaceRequest) Validate() error {
+	r.TargetWorkspaceID = strings.TrimSpace(r.TargetWorkspaceID)
+
+	return validate.Struct(r)
+}
+
+// PromotionFieldChange valor de um campo antes (destino) e depois (origem) da promoção.
+type PromotionFieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// PromotionChange um recurso da origem casado (pela chave natural) com o destino.
+// Key é a chave natural legível: nome do pipeline/template/sequência, "pipeline / etapa",
+// "entityType.key" nos campos calculados e key nos objetos customizados.
+// TargetID é null para CREATE no preview (o id só é gerado ao aplicar). Fields só é
+// preenchido em UPDATE.
+type PromotionChange struct {
+	Resource PromotionResource      `json:"resource"`
+	Key      string                 `json:"key"`
+	Action   PromotionAction        `json:"action"`
+	SourceID string                 `json:"sourceId"`
+	TargetID *string                `json:"targetId"`
+	Fields   []PromotionFieldChange `json:"fields,omitempty"`
+}
+
+// PromotionSummary contagem de recursos por ação.
+type PromotionSummary struct {
+	Create    int `json:"create"`
+	Update    int `json:"update"`
+	Unchanged int `json:"unchanged"`
+}
+
+// PromotionPlan diff entre a configuração do sandbox e a de produção.
+// Applied=false no preview; true quando as mudanças foram gravadas (na mesma transação).
+// Recursos que só existem no destino nunca são removidos e não aparecem no plano.
+type PromotionPlan struct {
+	SourceWorkspaceID string            `json:"sourceWorkspaceId"`
+	TargetWorkspaceID string            `json:"targetWorkspaceId"`
+	Applied           bool              `json:"applied"`
+	Summary           PromotionSummary  `json:"summary"`
+	Changes           []PromotionChange `json:"changes"`
+}
+
+// Add inclui a mudança no plano e atualiza o resumo.
+func (p *PromotionPlan) Add(change PromotionChange) {
+	switch change.Action {
+	case PromotionCreate:
+		p.Summary.Create++
+	case PromotionUpdate:
+		p.Summary.Update++
+	default:
+		p.Summary.Unchanged++
+	}
+	p.Changes = append(p.Changes, change)
+}
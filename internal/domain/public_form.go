@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SourceWebForm origem dos contatos/negócios criados por formulários públicos
+const SourceWebForm = "webform"
+
+// Destinos aceitos no mapeamento de campos de um formulário público: "<entidade>.<atributo>".
+// Contato é sempre criado (fullName e email obrigatórios); deal.* só vale com pipelineId.
+const (
+	FormTargetContactFullName = "contact.fullName"
+	FormTargetContactEmail    = "contact.email"
+	FormTargetContactPhone    = "contact.phone"
+	FormTargetDealName        = "deal.name"
+	FormTargetDealValue       = "deal.value"
+	FormTargetDealCurrency    = "deal.currency"
+	FormTargetDealDescription = "deal.description"
+	FormTargetSourceDetail    = "attribution.sourceDetail"
+	FormTargetUTMSource       = "attribution.utmSource"
+	FormTargetUTMMedium       = "attribution.utmMedium"
+	FormTargetUTMCampaign     = "attribution.utmCampaign"
+	FormTargetUTMTerm         = "attribution.utmTerm"
+	FormTargetUTMContent      = "attribution.utmContent"
+
+	// FormTargetContactCustomFieldPrefix destino em customFields do contato: "contact.customFields.<chave>"
+	FormTargetContactCustomFieldPrefix = "contact.customFields."
+)
+
+var formFieldTargets = map[string]bool{
+	FormTargetContactFullName: true,
+	FormTargetContactEmail:    true,
+	FormTargetContactPhone:    true,
+	FormTargetDealName:        true,
+	FormTargetDealValue:       true,
+	FormTargetDealCurrency:    true,
+	FormTargetDealDescription: true,
+	FormTargetSourceDetail:    true,
+	FormTargetUTMSource:       true,
+	FormTargetUTMMedium:       true,
+	FormTargetUTMCampaign:     true,
+	FormTargetUTMTerm:         true,
+	FormTargetUTMContent:      true,
+}
+
+// IsValidFormFieldTarget checks whether a field mapping target is supported
+func IsValidFormFieldTarget(target string) bool {
+	if key, ok := strings.CutPrefix(target, FormTargetContactCustomFieldPrefix); ok {
+		return customFieldKeyPattern.MatchString(key)
+	}
+	return formFieldTargets[target]
+}
+
+// Limites de rate limit por formulário (submissões por minuto, somando todos os visitantes)
+const (
+	DefaultFormRateLimitPerMinute = 10
+	MaxFormRateLimitPerMinute     = 600
+)
+
+// PublicFormConfig configuração de um formulário embutível, assinada dentro do form token:
+// o endpoint público não precisa de JWT e confia apenas no que o token carrega.
+type PublicFormConfig struct {
+	FormID string `json:"formId"`
+	// FieldMapping nome do campo enviado pelo formulário -> destino (ex.: "nome" -> "contact.fullName")
+	FieldMapping       map[string]string `json:"fieldMapping"`
+	PipelineID         *string           `json:"pipelineId,omitempty"`
+	StageID            *string           `json:"stageId,omitempty"`
+	RateLimitPerMinute int               `json:"rateLimitPerMinute"`
+	CaptchaRequired    bool              `json:"captchaRequired"`
+}
+
+// CreatePublicFormTokenRequest POST /v1/workspaces/{workspaceId}/public-form-tokens.
+// Os registros criados pelo formulário ficam em nome de quem emitiu o token.
+type CreatePublicFormTokenRequest struct {
+	FormID             string            `json:"formId" validate:"required,max=100,excludesall=/?#"`
+	FieldMapping       map[string]string `json:"fieldMapping" validate:"required,min=2,max=50,dive,keys,min=1,max=100,endkeys,required"`
+	PipelineID         *string           `json:"pipelineId,omitempty" validate:"omitempty,id"`
+	StageID            *string           `json:"stageId,omitempty" validate:"omitempty,id"`
+	RateLimitPerMinute *int              `json:"rateLimitPerMinute,omitempty" validate:"omitempty,min=1,max=600"`
+	CaptchaRequired    bool              `json:"captchaRequired"`
+	// ExpiresInDays validade do token (nil = não expira; revogue removendo o emissor do workspace)
+	ExpiresInDays *int `json:"expiresInDays,omitempty" validate:"omitempty,min=1,max=3650"`
+}
+
+// Validate sanitiza e valida o request, incluindo os destinos do mapeamento.
+func (r *CreatePublicFormTokenRequest) Validate() error {
+	r.FormID = strings.TrimSpace(r.FormID)
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	mapped := make(map[string]bool, len(r.FieldMapping))
+	for field, target := range r.FieldMapping {
+		if !IsValidFormFieldTarget(target) {
+			return fmt.Errorf("fieldMapping.%s: unsupported target %q", field, target)
+		}
+		if mapped[target] {
+			return fmt.Errorf("fieldMapping: target %q is mapped more than once", target)
+		}
+		mapped[target] = true
+	}
+	if !mapped[FormTargetContactFullName] || !mapped[FormTargetContactEmail] {
+		return fmt.Errorf("fieldMapping must map %s and %s", FormTargetContactFullName, FormTargetContactEmail)
+	}
+	if r.PipelineID == nil {
+		for target := range mapped {
+			if strings.HasPrefix(target, "deal.") {
+				return fmt.Errorf("fieldMapping: %s requires pipelineId", target)
+			}
+		}
+		if r.StageID != nil {
+			return fmt.Errorf("stageId requires pipelineId")
+		}
+	}
+	return nil
+}
+
+// Config returns the configuration signed into the form token
+func (r *CreatePublicFormTokenRequest) Config() PublicFormConfig {
+	limit := DefaultFormRateLimitPerMinute
+	if r.RateLimitPerMinute != nil {
+		limit = *r.RateLimitPerMinute
+	}
+	return PublicFormConfig{
+		FormID:             r.FormID,
+		FieldMapping:       r.FieldMapping,
+		PipelineID:         r.PipelineID,
+		StageID:            r.StageID,
+		RateLimitPerMinute: limit,
+		CaptchaRequired:    r.CaptchaRequired,
+	}
+}
+
+// PublicFormToken token para embutir no formulário (header X-Form-Token ou campo _formToken)
+type PublicFormToken struct {
+	Token         string            `json:"token"`
+	FormID        string            `json:"formId"`
+	WorkspaceID   string            `json:"workspaceId"`
+	SubmissionURL string            `json:"submissionUrl"`
+	Config        *PublicFormConfig `json:"config"`
+	ExpiresAt     *time.Time        `json:"expiresAt,omitempty"`
+}
+
+// PublicFormSubmission envio de um visitante, já separado dos campos de controle.
+// Token vazio = formulário cadastrado (Form), identificado só pelo formId.
+type PublicFormSubmission struct {
+	Token        string
+	CaptchaToken string
+	RemoteIP     string
+	// RenderedAt quando o formulário foi exibido (campo _renderedAt), usado por minSubmitSeconds
+	RenderedAt *time.Time
+	Fields     map[string]string
+}
+
+// PublicFormSubmissionResult resposta do endpoint público (sem IDs internos)
+type PublicFormSubmissionResult struct {
+	Status      string  `json:"status"` // received
+	Message     *string `json:"message,omitempty"`
+	RedirectURL *string `json:"redirectUrl,omitempty"`
+}
+
+// MapSubmission converte os campos enviados nos requests de criação, segundo o mapeamento.
+// Campos sem mapeamento são ignorados. deal é nil quando o formulário não cria negócio.
+func (c *PublicFormConfig) MapSubmission(fields map[string]string) (*CreateContactRequest, *CreateDealRequest, error) {
+	contact := &CreateContactRequest{}
+	var deal *CreateDealRequest
+	if c.PipelineID != nil {
+		deal = &CreateDealRequest{PipelineID: *c.PipelineID, StageID: c.StageID}
+	}
+
+	var attribution Attribution
+	for field, target := range c.FieldMapping {
+		raw, ok := fields[field]
+		value := strings.TrimSpace(raw)
+		if !ok || value == "" {
+			continue
+		}
+		v := value
+		switch target {
+		case FormTargetContactFullName:
+			contact.FullName = v
+		case FormTargetContactEmail:
+			contact.Email = v
+		case FormTargetContactPhone:
+			contact.Phone = &v
+		case FormTargetSourceDetail:
+			attribution.SourceDetail = &v
+		case FormTargetUTMSource:
+			attribution.UTMSource = &v
+		case FormTargetUTMMedium:
+			attribution.UTMMedium = &v
+		case FormTargetUTMCampaign:
+			attribution.UTMCampaign = &v
+		case FormTargetUTMTerm:
+			attribution.UTMTerm = &v
+		case FormTargetUTMContent:
+			attribution.UTMContent = &v
+		default:
+			if key, ok := strings.CutPrefix(target, FormTargetContactCustomFieldPrefix); ok {
+				if contact.CustomFields == nil {
+					contact.CustomFields = make(map[string]interface{})
+				}
+				contact.CustomFields[key] = v
+			}
+		}
+		if deal == nil {
+			continue
+		}
+		switch target {
+		case FormTargetDealName:
+			deal.Name = v
+		case FormTargetDealValue:
+			amount, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s must be a number", field)
+			}
+			deal.Value = &amount
+		case FormTargetDealCurrency:
+			deal.Currency = strings.ToUpper(v)
+		case FormTargetDealDescription:
+			deal.Description = &v
+		}
+	}
+
+	source := SourceWebForm
+	attribution.Source = &source
+	if attribution.SourceDetail == nil {
+		formID := c.FormID
+		attribution.SourceDetail = &formID
+	}
+	contact.Attribution = attribution
+	if deal != nil {
+		deal.Attribution = attribution
+		if deal.Name == "" {
+			deal.Name = contact.FullName
+		}
+	}
+	return contact, deal, nil
+}
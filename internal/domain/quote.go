@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// QuoteStatus situação do orçamento no link de compartilhamento.
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft    QuoteStatus = "DRAFT"    // gerado, ainda sem link
+	QuoteStatusSent     QuoteStatus = "SENT"     // link gerado (POST /quotes/{quoteId}/send)
+	QuoteStatusViewed   QuoteStatus = "VIEWED"   // cliente abriu o link
+	QuoteStatusAccepted QuoteStatus = "ACCEPTED" // cliente aceitou pelo link
+)
+
+// QuotePDFStatus estado da geração assíncrona do PDF (quote-worker).
+type QuotePDFStatus string
+
+const (
+	QuotePDFPending   QuotePDFStatus = "PENDING"
+	QuotePDFRunning   QuotePDFStatus = "RUNNING"
+	QuotePDFCompleted QuotePDFStatus = "COMPLETED"
+	QuotePDFFailed    QuotePDFStatus = "FAILED"
+)
+
+// MaxQuoteLineItems limite de itens por orçamento
+const MaxQuoteLineItems = 200
+
+// QuoteLineItem item do orçamento. Total = quantidade x preço unitário, menos o desconto (%).
+type QuoteLineItem struct {
+	Description     string   `json:"description" validate:"required,min=1,max=500"`
+	Quantity        float64  `json:"quantity" validate:"gt=0"`
+	UnitPrice       float64  `json:"unitPrice" validate:"gte=0"`
+	DiscountPercent *float64 `json:"discountPercent,omitempty" validate:"omitempty,gte=0,lte=100"`
+	Total           float64  `json:"total"`
+}
+
+// Quote orçamento gerado a partir de um DocumentTemplate e de um negócio. Título e corpo são
+// renderizados na criação (o template pode mudar depois); o PDF é gerado pelo quote-worker e
+// guardado no object storage dos anexos.
+type Quote struct {
+	ID          string          `json:"id"`
+	WorkspaceID string          `json:"workspaceId"`
+	Number      int64           `json:"number"`
+	DealID      string          `json:"dealId"`
+	ContactID   *string         `json:"contactId"`
+	CompanyID   *string         `json:"companyId"`
+	TemplateID  *string         `json:"templateId"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	Currency    string          `json:"currency"`
+	LineItems   []QuoteLineItem `json:"lineItems"`
+	Subtotal    float64         `json:"subtotal"`
+	Discount    float64         `json:"discount"`
+	Total       float64         `json:"total"`
+	ValidUntil  *time.Time      `json:"validUntil"`
+
+	Status         QuoteStatus `json:"status"`
+	SentAt         *time.Time  `json:"sentAt"`
+	ViewedAt       *time.Time  `json:"viewedAt"`
+	AcceptedAt     *time.Time  `json:"acceptedAt"`
+	AcceptedByName *string     `json:"acceptedByName"`
+
+	PDFStatus    QuotePDFStatus `json:"pdfStatus"`
+	PDFSizeBytes *int64         `json:"pdfSizeBytes"`
+	PDFError     *string        `json:"pdfError"`
+
+	// ShareURL link público; só retornado por POST /send (o token não é guardado em claro)
+	ShareURL *string `json:"shareUrl,omitempty"`
+
+	CreatedByID string    `json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// PDFObjectKey chave do PDF no object storage
+	PDFObjectKey string `json:"-"`
+}
+
+// QuotePDFObjectKey chave do PDF do orçamento no object storage dos anexos.
+func QuotePDFObjectKey(workspaceID, quoteID string) string {
+	return "quotes/" + workspaceID + "/" + quoteID + ".pdf"
+}
+
+// Expired reporta se a validade do orçamento já passou.
+func (q *Quote) Expired(now time.Time) bool {
+	return q.ValidUntil != nil && now.After(*q.ValidUntil)
+}
+
+// Acceptable reporta se o orçamento pode ser aceito pelo link (enviado, não aceito, dentro da validade).
+func (q *Quote) Acceptable(now time.Time) bool {
+	return (q.Status == QuoteStatusSent || q.Status == QuoteStatusViewed) && !q.Expired(now)
+}
+
+// QuoteListResponse resposta da listagem (sem paginação: poucos orçamentos por negócio).
+type QuoteListResponse struct {
+	Data []Quote `json:"data"`
+}
+
+// CreateQuoteRequest POST /v1/workspaces/{workspaceId}/quotes.
+// Sem lineItems, o orçamento tem um item com o nome e o valor do negócio.
+type CreateQuoteRequest struct {
+	TemplateID string          `json:"templateId" validate:"required,id"`
+	DealID     string          `json:"dealId" validate:"required,id"`
+	LineItems  []QuoteLineItem `json:"lineItems,omitempty" validate:"max=200,dive"`
+	ValidUntil *time.Time      `json:"validUntil,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateQuoteRequest) Validate() error {
+	r.TemplateID = strings.TrimSpace(r.TemplateID)
+	r.DealID = strings.TrimSpace(r.DealID)
+	for i := range r.LineItems {
+		r.LineItems[i].Description = strings.TrimSpace(r.LineItems[i].Description)
+	}
+	return validate.Struct(r)
+}
+
+// AcceptQuoteRequest POST /q/{token}/accept: nome de quem aceitou.
+type AcceptQuoteRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *AcceptQuoteRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	return validate.Struct(r)
+}
+
+// PublicQuote visão do orçamento no link de compartilhamento (sem ids internos).
+type PublicQuote struct {
+	Number         int64           `json:"number"`
+	Title          string          `json:"title"`
+	Body           string          `json:"body"`
+	Currency       string          `json:"currency"`
+	LineItems      []QuoteLineItem `json:"lineItems"`
+	Subtotal       float64         `json:"subtotal"`
+	Discount       float64         `json:"discount"`
+	Total          float64         `json:"total"`
+	ValidUntil     *time.Time      `json:"validUntil"`
+	Status         QuoteStatus     `json:"status"`
+	AcceptedAt     *time.Time      `json:"acceptedAt"`
+	AcceptedByName *string         `json:"acceptedByName"`
+	PDFReady       bool            `json:"pdfReady"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// Public converte para a visão do link de compartilhamento.
+func (q *Quote) Public() *PublicQuote {
+	return &PublicQuote{
+		Number:         q.Number,
+		Title:          q.Title,
+		Body:           q.Body,
+		Currency:       q.Currency,
+		LineItems:      q.LineItems,
+		Subtotal:       q.Subtotal,
+		Discount:       q.Discount,
+		Total:          q.Total,
+		ValidUntil:     q.ValidUntil,
+		Status:         q.Status,
+		AcceptedAt:     q.AcceptedAt,
+		AcceptedByName: q.AcceptedByName,
+		PDFReady:       q.PDFStatus == QuotePDFCompleted,
+		CreatedAt:      q.CreatedAt,
+	}
+}
+
+// ComputeTotals calcula o total de cada item e os totais do orçamento (2 casas decimais).
+func (q *Quote) ComputeTotals() {
+	var subtotal, total float64
+	for i := range q.LineItems {
+		item := &q.LineItems[i]
+		gross := item.Quantity * item.UnitPrice
+		net := gross
+		if item.DiscountPercent != nil {
+			net = gross * (1 - *item.DiscountPercent/100)
+		}
+		item.Total = roundMoney(net)
+		subtotal += roundMoney(gross)
+		total += item.Total
+	}
+	q.Subtotal = roundMoney(subtotal)
+	q.Total = roundMoney(total)
+	q.Discount = roundMoney(q.Subtotal - q.Total)
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func formatMoney(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// MergeValues valores dos merge fields {quote.*} e {company.name}, somados aos de contato e negócio.
+func (q *Quote) MergeValues(contact *Contact, deal *Deal, company *Company) map[string]string {
+	values := MergeValues(contact, deal)
+	if company != nil && company.Name != "" {
+		values["company.name"] = company.Name
+	}
+	values["quote.number"] = strconv.FormatInt(q.Number, 10)
+	values["quote.date"] = q.CreatedAt.Format("2006-01-02")
+	if q.ValidUntil != nil {
+		values["quote.validUntil"] = q.ValidUntil.Format("2006-01-02")
+	}
+	values["quote.currency"] = q.Currency
+	values["quote.subtotal"] = formatMoney(q.Subtotal)
+	values["quote.discount"] = formatMoney(q.Discount)
+	values["quote.total"] = formatMoney(q.Total)
+	values["quote.lineItems"] = strings.Join(q.LineItemLines(), "\n")
+	return values
+}
+
+// QuoteLineItemsField merge field da tabela de itens; sem ele no template, a tabela é
+// anexada ao fim do corpo renderizado.
+const QuoteLineItemsField = "{quote.lineItems}"
+
+// Largura da coluna de descrição na tabela de itens
+const quoteDescriptionWidth = 36
+
+// LineItemLines tabela de itens e totais em texto monoespaçado.
+func (q *Quote) LineItemLines() []string {
+	row := func(desc, qty, price, discount, total string) string {
+		if utf8.RuneCountInString(desc) > quoteDescriptionWidth {
+			desc = string([]rune(desc)[:quoteDescriptionWidth-1]) + "~"
+		}
+		return fmt.Sprintf("%-*s %8s %12s %6s %12s", quoteDescriptionWidth, desc, qty, price, discount, total)
+	}
+
+	lines := []string{
+		row("Item", "Qty", "Unit price", "Disc.", "Total"),
+		strings.Repeat("-", quoteDescriptionWidth+42),
+	}
+	for _, item := range q.LineItems {
+		discount := ""
+		if item.DiscountPercent != nil && *item.DiscountPercent > 0 {
+			discount = strconv.FormatFloat(*item.DiscountPercent, 'f', -1, 64) + "%"
+		}
+		lines = append(lines, row(item.Description, strconv.FormatFloat(item.Quantity, 'f', -1, 64),
+			formatMoney(item.UnitPrice), discount, formatMoney(item.Total)))
+	}
+	lines = append(lines,
+		strings.Repeat("-", quoteDescriptionWidth+42),
+		row("Subtotal", "", "", "", formatMoney(q.Subtotal)),
+	)
+	if q.Discount > 0 {
+		lines = append(lines, row("Discount", "", "", "", "-"+formatMoney(q.Discount)))
+	}
+	lines = append(lines, row("Total ("+q.Currency+")", "", "", "", formatMoney(q.Total)))
+	return lines
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote_ComputeTotals(t *testing.T) {
+	ten, half := 10.0, 50.0
+	tests := []struct {
+		name                      string
+		items                     []QuoteLineItem
+		subtotal, discount, total float64
+		itemTotals                []float64
+	}{
+		{"no items", nil, 0, 0, 0, nil},
+		{"single item", []QuoteLineItem{{Quantity: 2, UnitPrice: 150}}, 300, 0, 300, []float64{300}},
+		{"discounts per item", []QuoteLineItem{
+			{Quantity: 3, UnitPrice: 100, DiscountPercent: &ten},
+			{Quantity: 1, UnitPrice: 99.99, DiscountPercent: &half},
+		}, 399.99, 79.99, 320, []float64{270, 50}},
+		{"fractional quantity rounds to cents", []QuoteLineItem{{Quantity: 1.5, UnitPrice: 33.333}}, 50, 0, 50, []float64{50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Quote{LineItems: tt.items}
+			q.ComputeTotals()
+			assert.Equal(t, tt.subtotal, q.Subtotal)
+			assert.Equal(t, tt.discount, q.Discount)
+			assert.Equal(t, tt.total, q.Total)
+			for i, want := range tt.itemTotals {
+				assert.Equal(t, want, q.LineItems[i].Total)
+			}
+		})
+	}
+}
+
+func TestQuote_Acceptable(t *testing.T) {
+	now := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	tomorrow, yesterday := now.Add(24*time.Hour), now.Add(-24*time.Hour)
+
+	tests := []struct {
+		name       string
+		status     QuoteStatus
+		validUntil *time.Time
+		want       bool
+	}{
+		{"sent", QuoteStatusSent, nil, true},
+		{"viewed within validity", QuoteStatusViewed, &tomorrow, true},
+		{"draft", QuoteStatusDraft, nil, false},
+		{"already accepted", QuoteStatusAccepted, nil, false},
+		{"expired", QuoteStatusViewed, &yesterday, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Quote{Status: tt.status, ValidUntil: tt.validUntil}
+			assert.Equal(t, tt.want, q.Acceptable(now))
+		})
+	}
+}
+
+func TestQuote_LineItemLines(t *testing.T) {
+	ten := 10.0
+	q := &Quote{Currency: "BRL", LineItems: []QuoteLineItem{
+		{Description: "Implantação", Quantity: 1, UnitPrice: 1000, DiscountPercent: &ten},
+		{Description: strings.Repeat("Licença anual ", 4), Quantity: 12, UnitPrice: 50},
+	}}
+	q.ComputeTotals()
+	lines := q.LineItemLines()
+
+	require.Len(t, lines, 8)
+	width := len([]rune(lines[0]))
+	for _, line := range lines {
+		assert.Equal(t, width, len([]rune(line)), "columns stay aligned: %q", line)
+	}
+	assert.Contains(t, lines[2], "10%")
+	assert.Contains(t, lines[3], "Licença anual Licença anual Licença~")
+	assert.True(t, strings.HasSuffix(lines[6], "-100.00"))
+	assert.True(t, strings.HasPrefix(lines[7], "Total (BRL)"))
+	assert.True(t, strings.HasSuffix(lines[7], "1500.00"))
+
+	noDiscount := &Quote{Currency: "BRL", LineItems: []QuoteLineItem{{Description: "Item", Quantity: 1, UnitPrice: 10}}}
+	noDiscount.ComputeTotals()
+	for _, line := range noDiscount.LineItemLines() {
+		assert.False(t, strings.HasPrefix(line, "Discount"))
+	}
+}
+
+func TestQuote_MergeValues(t *testing.T) {
+	validUntil := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	q := &Quote{
+		Number:     42,
+		Currency:   "BRL",
+		LineItems:  []QuoteLineItem{{Description: "Item", Quantity: 2, UnitPrice: 10.5}},
+		ValidUntil: &validUntil,
+		CreatedAt:  time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC),
+	}
+	q.ComputeTotals()
+
+	values := q.MergeValues(nil, nil, &Company{Name: "Acme"})
+	assert.Equal(t, "Acme", values["company.name"])
+	assert.Equal(t, "42", values["quote.number"])
+	assert.Equal(t, "2026-03-02", values["quote.date"])
+	assert.Equal(t, "2026-04-01", values["quote.validUntil"])
+	assert.Equal(t, "21.00", values["quote.total"])
+	assert.Equal(t, "0.00", values["quote.discount"])
+	assert.Equal(t, strings.Join(q.LineItemLines(), "\n"), values["quote.lineItems"])
+
+	_, ok := q.MergeValues(nil, nil, nil)["company.name"]
+	assert.False(t, ok)
+}
+
+func TestQuote_Public(t *testing.T) {
+	q := &Quote{ID: "quo_1", WorkspaceID: "ws_1", Number: 3, Status: QuoteStatusSent, PDFStatus: QuotePDFRunning}
+	assert.False(t, q.Public().PDFReady)
+	q.PDFStatus = QuotePDFCompleted
+	assert.True(t, q.Public().PDFReady)
+	assert.Equal(t, int64(3), q.Public().Number)
+}
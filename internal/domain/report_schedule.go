@@ -0,0 +1,284 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"linkko-api/internal/cron"
+)
+
+// ReportType relatório gerado pelo agendamento.
+type ReportType string
+
+const (
+	ReportTypePipelineSummary ReportType = "pipeline_summary"
+	ReportTypeAttribution     ReportType = "attribution"
+	ReportTypeOverdue         ReportType = "overdue"
+	ReportTypeTime            ReportType = "time"
+)
+
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportTypePipelineSummary, ReportTypeAttribution, ReportTypeOverdue, ReportTypeTime:
+		return true
+	}
+	return false
+}
+
+// ReportFormat formato do arquivo entregue.
+type ReportFormat string
+
+const (
+	ReportFormatCSV ReportFormat = "csv"
+	ReportFormatPDF ReportFormat = "pdf"
+)
+
+// ReportRunStatus resultado da última execução.
+type ReportRunStatus string
+
+const (
+	ReportRunSucceeded ReportRunStatus = "SUCCEEDED"
+	ReportRunFailed    ReportRunStatus = "FAILED"
+)
+
+// ReportScheduleFilters filtros aplicados ao relatório. Cada tipo aceita um subconjunto:
+//   - pipeline_summary: pipelineId, ownerId, periodDays (janela de ganhos/perdas)
+//   - attribution: groupBy (source, utmSource, utmMedium, utmCampaign), periodDays
+//   - overdue: ownerId
+//   - time: groupBy (user, deal, task), ownerId (usuário do apontamento), periodDays
+type ReportScheduleFilters struct {
+	PipelineID *string `json:"pipelineId,omitempty"`
+	OwnerID    *string `json:"ownerId,omitempty"`
+	GroupBy    *string `json:"groupBy,omitempty"`
+	PeriodDays *int    `json:"periodDays,omitempty" validate:"omitempty,min=1,max=366"`
+}
+
+// Validate confere que os filtros enviados fazem sentido para o tipo de relatório.
+func (f *ReportScheduleFilters) Validate(reportType ReportType) error {
+	if err := validate.Struct(f); err != nil {
+		return err
+	}
+
+	if f.PipelineID != nil && reportType != ReportTypePipelineSummary {
+		return fmt.Errorf("filters.pipelineId is only supported by pipeline_summary reports")
+	}
+	if f.OwnerID != nil && reportType == ReportTypeAttribution {
+		return fmt.Errorf("filters.ownerId is not supported by attribution reports")
+	}
+	if f.PeriodDays != nil && reportType == ReportTypeOverdue {
+		return fmt.Errorf("filters.periodDays is not supported by overdue reports")
+	}
+	if f.GroupBy != nil {
+		switch reportType {
+		case ReportTypeAttribution:
+			if !AttributionGroupBy(*f.GroupBy).IsValid() {
+				return fmt.Errorf("filters.groupBy must be one of: source, utmSource, utmMedium, utmCampaign")
+			}
+		case ReportTypeTime:
+			if !TimeReportGroupBy(*f.GroupBy).IsValid() {
+				return fmt.Errorf("filters.groupBy must be one of: user, deal, task")
+			}
+		default:
+			return fmt.Errorf("filters.groupBy is only supported by attribution and time reports")
+		}
+	}
+	return nil
+}
+
+// ReportSchedule relatório recorrente entregue por email (anexo) e/ou webhook.
+// WebhookSecret só é devolvido na resposta que o gera (criação ou primeiro webhookUrl).
+type ReportSchedule struct {
+	ID            string                `json:"id"`
+	WorkspaceID   string                `json:"workspaceId"`
+	Name          string                `json:"name"`
+	ReportType    ReportType            `json:"reportType"`
+	Filters       ReportScheduleFilters `json:"filters"`
+	Format        ReportFormat          `json:"format"`
+	Recipients    []string              `json:"recipients"`
+	WebhookURL    *string               `json:"webhookUrl"`
+	WebhookSecret *string               `json:"webhookSecret,omitempty"`
+	Cron          string                `json:"cron"`
+	Timezone      string                `json:"timezone"`
+	Enabled       bool                  `json:"enabled"`
+	NextRunAt     *time.Time            `json:"nextRunAt"`
+	LastRunAt     *time.Time            `json:"lastRunAt"`
+	LastStatus    *ReportRunStatus      `json:"lastStatus"`
+	LastError     *string               `json:"lastError"`
+	CreatedByID   string                `json:"createdById"`
+	UpdatedByID   *string               `json:"updatedById"`
+	CreatedAt     time.Time             `json:"createdAt"`
+	UpdatedAt     time.Time             `json:"updatedAt"`
+}
+
+// ReportScheduleListResponse resposta da listagem (sem paginação).
+type ReportScheduleListResponse struct {
+	Data []ReportSchedule `json:"data"`
+}
+
+// CreateReportScheduleRequest DTO para criação de agendamento.
+type CreateReportScheduleRequest struct {
+	Name       string                `json:"name" validate:"required,min=1,max=255"`
+	ReportType ReportType            `json:"reportType" validate:"required,oneof=pipeline_summary attribution overdue time"`
+	Filters    ReportScheduleFilters `json:"filters"`
+	Format     ReportFormat          `json:"format" validate:"omitempty,oneof=csv pdf"`
+	Recipients []string              `json:"recipients" validate:"max=20,dive,email"`
+	WebhookURL *string               `json:"webhookUrl,omitempty" validate:"omitempty,url,startswith=https://"`
+	Cron       string                `json:"cron" validate:"required"`
+	Timezone   string                `json:"timezone" validate:"omitempty,max=64"`
+	Enabled    *bool                 `json:"enabled,omitempty"`
+}
+
+// Validate sanitiza o request, aplica os defaults (csv, UTC, ativo) e valida cron, fuso e destinos.
+func (r *CreateReportScheduleRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Cron = strings.TrimSpace(r.Cron)
+	r.Timezone = strings.TrimSpace(r.Timezone)
+	r.Recipients = normalizeRecipients(r.Recipients)
+	if r.WebhookURL != nil {
+		trimmed := strings.TrimSpace(*r.WebhookURL)
+		r.WebhookURL = &trimmed
+		if trimmed == "" {
+			r.WebhookURL = nil
+		}
+	}
+	if r.Format == "" {
+		r.Format = ReportFormatCSV
+	}
+	if r.Timezone == "" {
+		r.Timezone = "UTC"
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if err := r.Filters.Validate(r.ReportType); err != nil {
+		return err
+	}
+	if len(r.Recipients) == 0 && r.WebhookURL == nil {
+		return fmt.Errorf("at least one of recipients or webhookUrl is required")
+	}
+	_, err := NextReportRun(r.Cron, r.Timezone, time.Now())
+	return err
+}
+
+// UpdateReportScheduleRequest DTO para atualização parcial (nil = não modificar).
+// reportType é imutável; webhookUrl "" remove o webhook.
+type UpdateReportScheduleRequest struct {
+	Name       *string                `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Filters    *ReportScheduleFilters `json:"filters,omitempty"`
+	Format     *ReportFormat          `json:"format,omitempty" validate:"omitempty,oneof=csv pdf"`
+	Recipients *[]string              `json:"recipients,omitempty" validate:"omitempty,max=20,dive,email"`
+	WebhookURL *string                `json:"webhookUrl,omitempty" validate:"omitempty,url,startswith=https://"`
+	Cron       *string                `json:"cron,omitempty" validate:"omitempty,min=1"`
+	Timezone   *string                `json:"timezone,omitempty" validate:"omitempty,min=1,max=64"`
+	Enabled    *bool                  `json:"enabled,omitempty"`
+}
+
+// Validate sanitiza o request. Filtros e destinos dependem do agendamento atual: o service
+// valida o resultado da mesclagem (ver ReportSchedule.Validate).
+func (r *UpdateReportScheduleRequest) Validate() error {
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+	if r.Cron != nil {
+		trimmed := strings.TrimSpace(*r.Cron)
+		r.Cron = &trimmed
+	}
+	if r.Timezone != nil {
+		trimmed := strings.TrimSpace(*r.Timezone)
+		r.Timezone = &trimmed
+	}
+	if r.WebhookURL != nil {
+		trimmed := strings.TrimSpace(*r.WebhookURL)
+		r.WebhookURL = &trimmed
+	}
+	if r.Recipients != nil {
+		normalized := normalizeRecipients(*r.Recipients)
+		r.Recipients = &normalized
+	}
+	return validate.Struct(r)
+}
+
+// Apply mescla o update no agendamento. Retorna true se cron, fuso ou enabled mudaram
+// (próxima execução precisa ser recalculada).
+func (r *UpdateReportScheduleRequest) Apply(s *ReportSchedule) (reschedule bool) {
+	if r.Name != nil {
+		s.Name = *r.Name
+	}
+	if r.Filters != nil {
+		s.Filters = *r.Filters
+	}
+	if r.Format != nil {
+		s.Format = *r.Format
+	}
+	if r.Recipients != nil {
+		s.Recipients = *r.Recipients
+	}
+	if r.WebhookURL != nil {
+		if *r.WebhookURL == "" {
+			s.WebhookURL = nil
+		} else {
+			s.WebhookURL = r.WebhookURL
+		}
+	}
+	if r.Cron != nil && *r.Cron != s.Cron {
+		s.Cron, reschedule = *r.Cron, true
+	}
+	if r.Timezone != nil && *r.Timezone != s.Timezone {
+		s.Timezone, reschedule = *r.Timezone, true
+	}
+	if r.Enabled != nil && *r.Enabled != s.Enabled {
+		s.Enabled, reschedule = *r.Enabled, true
+	}
+	return reschedule
+}
+
+// Validate revalida filtros, destinos e cron de um agendamento mesclado.
+func (s *ReportSchedule) Validate() error {
+	if err := s.Filters.Validate(s.ReportType); err != nil {
+		return err
+	}
+	if len(s.Recipients) == 0 && s.WebhookURL == nil {
+		return fmt.Errorf("at least one of recipients or webhookUrl is required")
+	}
+	_, err := NextReportRun(s.Cron, s.Timezone, time.Now())
+	return err
+}
+
+// NextReportRun calcula a próxima execução (UTC) da expressão cron no fuso informado.
+// Agendamentos podem rodar no máximo uma vez por hora.
+func NextReportRun(expr, timezone string, after time.Time) (time.Time, error) {
+	schedule, err := cron.Parse(expr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron: %w", err)
+	}
+	if schedule.RunsPerHour() > 1 {
+		return time.Time{}, fmt.Errorf("cron must not run more than once per hour")
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timezone must be a valid IANA time zone (e.g. America/Sao_Paulo)")
+	}
+	next := schedule.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron never matches a date")
+	}
+	return next.UTC(), nil
+}
+
+// normalizeRecipients remove espaços, normaliza para minúsculas e descarta duplicados.
+func normalizeRecipients(recipients []string) []string {
+	out := make([]string, 0, len(recipients))
+	seen := make(map[string]bool, len(recipients))
+	for _, email := range recipients {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		out = append(out, email)
+	}
+	return out
+}
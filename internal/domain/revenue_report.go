@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// RevenueReportGroupBy define a dimensão de agregação de /reports/revenue.
+type RevenueReportGroupBy string
+
+const (
+	RevenueByMonth RevenueReportGroupBy = "month" // chave YYYY-MM no fuso da request
+	RevenueByOwner RevenueReportGroupBy = "owner" // dono do negócio
+)
+
+// IsValid valida se a dimensão é suportada.
+func (g RevenueReportGroupBy) IsValid() bool {
+	switch g {
+	case RevenueByMonth, RevenueByOwner:
+		return true
+	}
+	return false
+}
+
+// RevenueReportParams parâmetros de /reports/revenue. From/To filtram closedAt dos negócios
+// ganhos (booked) e paidAt das faturas pagas (collected). Location define os meses de
+// groupBy=month (nil = UTC).
+type RevenueReportParams struct {
+	WorkspaceID string
+	GroupBy     RevenueReportGroupBy
+	From        *time.Time
+	To          *time.Time
+	Location    *time.Location
+}
+
+// RevenueReportRow totais de uma chave da dimensão. Booked soma deal.value dos negócios ganhos;
+// Collected soma amountPaid das faturas PAID. Valores sem conversão de moeda.
+// Key nil agrupa negócios sem dono em groupBy=owner.
+type RevenueReportRow struct {
+	Key       *string `json:"key"`
+	WonDeals  int64   `json:"wonDeals"`
+	Booked    float64 `json:"booked"`
+	Collected float64 `json:"collected"`
+}
+
+// RevenueReport resposta de /reports/revenue. Outstanding é o saldo em aberto das faturas OPEN
+// na data da consulta (não depende do período).
+type RevenueReport struct {
+	GroupBy     RevenueReportGroupBy `json:"groupBy"`
+	Rows        []RevenueReportRow   `json:"rows"`
+	WonDeals    int64                `json:"wonDeals"`
+	Booked      float64              `json:"booked"`
+	Collected   float64              `json:"collected"`
+	Outstanding float64              `json:"outstanding"`
+}
+
+// NewRevenueReport soma os totais gerais a partir das linhas.
+func NewRevenueReport(groupBy RevenueReportGroupBy, rows []RevenueReportRow, outstanding float64) *RevenueReport {
+	report := &RevenueReport{GroupBy: groupBy, Rows: rows, Outstanding: roundMoney(outstanding)}
+	for _, row := range rows {
+		report.WonDeals += row.WonDeals
+		report.Booked += row.Booked
+		report.Collected += row.Collected
+	}
+	report.Booked = roundMoney(report.Booked)
+	report.Collected = roundMoney(report.Collected)
+	return report
+}
@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limites do texto rico de notas.
+const (
+	RichTextMaxDepth = 16
+	RichTextMaxNodes = 5000
+	RichTextMaxChars = 100000
+)
+
+// RichTextNode é um nó do texto rico em JSON portátil (mesmo formato de documento do
+// ProseMirror/TipTap): a raiz é "doc", blocos têm content e folhas "text" têm text e marks.
+// Nada é interpretado como HTML; clientes renderizam a partir dos tipos permitidos.
+type RichTextNode struct {
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []RichTextMark         `json:"marks,omitempty"`
+	Content []RichTextNode         `json:"content,omitempty"`
+}
+
+// RichTextMark formatação aplicada a um nó text.
+type RichTextMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// richTextBlocks nós que encerram uma linha na versão texto puro.
+var richTextBlocks = map[string]bool{
+	"paragraph": true, "heading": true, "blockquote": true, "codeBlock": true,
+	"bulletList": true, "orderedList": true, "listItem": true,
+}
+
+// richTextNodeTypes tipos de nó aceitos (os demais são rejeitados).
+var richTextNodeTypes = map[string]bool{
+	"doc": true, "text": true, "hardBreak": true, "horizontalRule": true,
+	"mention": true, "attachment": true,
+}
+
+// richTextMarkTypes tipos de mark aceitos.
+var richTextMarkTypes = map[string]bool{
+	"bold": true, "italic": true, "underline": true, "strike": true, "code": true, "link": true,
+}
+
+// NewPlainTextDoc converte texto puro em doc com um parágrafo por linha (notas antigas).
+func NewPlainTextDoc(text string) *RichTextNode {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	doc := &RichTextNode{Type: "doc", Content: make([]RichTextNode, len(lines))}
+	for i, line := range lines {
+		doc.Content[i] = RichTextNode{Type: "paragraph"}
+		if line != "" {
+			doc.Content[i].Content = []RichTextNode{{Type: "text", Text: line}}
+		}
+	}
+	return doc
+}
+
+// Sanitize valida o documento e descarta attrs não suportados. Rejeita tipos de nó ou
+// mark desconhecidos, links que não sejam http(s)/mailto e documentos acima dos limites.
+func (n *RichTextNode) Sanitize() error {
+	if n.Type != "doc" {
+		return fmt.Errorf("rich text body must be a doc node")
+	}
+	var nodes, chars int
+	return n.sanitize(0, &nodes, &chars)
+}
+
+func (n *RichTextNode) sanitize(depth int, nodes, chars *int) error {
+	if depth > RichTextMaxDepth {
+		return fmt.Errorf("rich text body exceeds %d nesting levels", RichTextMaxDepth)
+	}
+	if *nodes++; *nodes > RichTextMaxNodes {
+		return fmt.Errorf("rich text body exceeds %d nodes", RichTextMaxNodes)
+	}
+	if !richTextBlocks[n.Type] && !richTextNodeTypes[n.Type] {
+		return fmt.Errorf("rich text node type %q is not supported", n.Type)
+	}
+	if n.Type == "doc" && depth > 0 {
+		return fmt.Errorf("rich text doc node is only allowed at the root")
+	}
+
+	if n.Type == "text" {
+		if n.Text == "" || len(n.Content) > 0 {
+			return fmt.Errorf("rich text text nodes need text and cannot have content")
+		}
+		if *chars += utf8.RuneCountInString(n.Text); *chars > RichTextMaxChars {
+			return fmt.Errorf("rich text body exceeds %d characters", RichTextMaxChars)
+		}
+		for i := range n.Marks {
+			if err := n.Marks[i].sanitize(); err != nil {
+				return err
+			}
+		}
+	} else if n.Text != "" || len(n.Marks) > 0 {
+		return fmt.Errorf("rich text node %q cannot have text or marks", n.Type)
+	}
+
+	attrs, err := sanitizeNodeAttrs(n.Type, n.Attrs)
+	if err != nil {
+		return err
+	}
+	n.Attrs = attrs
+
+	for i := range n.Content {
+		if err := n.Content[i].sanitize(depth+1, nodes, chars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sanitizeNodeAttrs(nodeType string, in map[string]interface{}) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	switch nodeType {
+	case "heading":
+		level, ok := in["level"].(float64)
+		if !ok || level < 1 || level > 3 || level != float64(int(level)) {
+			return nil, fmt.Errorf("rich text heading level must be 1, 2 or 3")
+		}
+		out["level"] = level
+	case "orderedList":
+		if start, ok := in["start"].(float64); ok && start >= 0 {
+			out["start"] = float64(int(start))
+		}
+	case "codeBlock":
+		if lang, ok := in["language"].(string); ok && lang != "" && len(lang) <= 32 {
+			out["language"] = lang
+		}
+	case "mention":
+		id, _ := in["id"].(string)
+		if id == "" {
+			return nil, fmt.Errorf("rich text mention requires attrs.id")
+		}
+		out["id"] = id
+		if label, ok := in["label"].(string); ok && label != "" {
+			out["label"] = label
+		}
+	case "attachment":
+		id, _ := in["attachmentId"].(string)
+		if id == "" {
+			return nil, fmt.Errorf("rich text attachment requires attrs.attachmentId")
+		}
+		out["attachmentId"] = id
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+func (m *RichTextMark) sanitize() error {
+	if !richTextMarkTypes[m.Type] {
+		return fmt.Errorf("rich text mark type %q is not supported", m.Type)
+	}
+	if m.Type != "link" {
+		m.Attrs = nil
+		return nil
+	}
+	href, _ := m.Attrs["href"].(string)
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "mailto") {
+		return fmt.Errorf("rich text links must use http, https or mailto")
+	}
+	m.Attrs = map[string]interface{}{"href": u.String()}
+	return nil
+}
+
+// PlainText renderiza o documento como texto puro: uma linha por bloco, menções como
+// @label e anexos omitidos.
+func (n *RichTextNode) PlainText() string {
+	var b strings.Builder
+	n.writePlainText(&b)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (n *RichTextNode) writePlainText(b *strings.Builder) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+	case "hardBreak":
+		b.WriteString("\n")
+	case "mention":
+		if label, ok := n.Attrs["label"].(string); ok {
+			b.WriteString("@" + label)
+		}
+	}
+	for i := range n.Content {
+		n.Content[i].writePlainText(b)
+	}
+	if richTextBlocks[n.Type] && n.Type != "listItem" && n.Type != "bulletList" && n.Type != "orderedList" {
+		b.WriteString("\n")
+	}
+}
+
+// AttachmentIDs lista os anexos referenciados inline no documento.
+func (n *RichTextNode) AttachmentIDs() []string {
+	var ids []string
+	var walk func(*RichTextNode)
+	walk = func(node *RichTextNode) {
+		if node.Type == "attachment" {
+			if id, ok := node.Attrs["attachmentId"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+		for i := range node.Content {
+			walk(&node.Content[i])
+		}
+	}
+	walk(n)
+	return ids
+}
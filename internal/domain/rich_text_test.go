@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseRichText(t *testing.T, body string) *RichTextNode {
+	t.Helper()
+	var doc RichTextNode
+	require.NoError(t, json.Unmarshal([]byte(body), &doc))
+	return &doc
+}
+
+func linkDoc(href string) string {
+	return `{"type":"doc","content":[{"type":"paragraph","content":[
+		{"type":"text","text":"click","marks":[{"type":"link","attrs":{"href":` + mustJSON(href) + `}}]}]}]}`
+}
+
+func mustJSON(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func TestRichTextNode_SanitizeLinks(t *testing.T) {
+	tests := []struct {
+		href    string
+		want    string
+		wantErr bool
+	}{
+		{href: "https://linkko.com/docs?a=1", want: "https://linkko.com/docs?a=1"},
+		{href: "http://linkko.com", want: "http://linkko.com"},
+		{href: "mailto:vendas@linkko.com", want: "mailto:vendas@linkko.com"},
+		{href: "  https://linkko.com  ", want: "https://linkko.com"},
+		{href: "HTTPS://linkko.com", want: "https://linkko.com"},
+		{href: "javascript:alert(1)", wantErr: true},
+		{href: "JavaScript:alert(1)", wantErr: true},
+		{href: " javascript:alert(document.cookie)", wantErr: true},
+		{href: "java\tscript:alert(1)", wantErr: true},
+		{href: "data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==", wantErr: true},
+		{href: "DATA:text/html,<script>alert(1)</script>", wantErr: true},
+		{href: "vbscript:msgbox(1)", wantErr: true},
+		{href: "file:///etc/passwd", wantErr: true},
+		{href: "//evil.example.com", wantErr: true},
+		{href: "/relative", wantErr: true},
+		{href: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.href, func(t *testing.T) {
+			doc := parseRichText(t, linkDoc(tt.href))
+			err := doc.Sanitize()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "http, https or mailto")
+				return
+			}
+			require.NoError(t, err)
+			mark := doc.Content[0].Content[0].Marks[0]
+			assert.Equal(t, map[string]interface{}{"href": tt.want}, mark.Attrs)
+		})
+	}
+}
+
+func TestRichTextNode_SanitizeRejectsUnknownTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"root is not a doc", `{"type":"paragraph"}`, "must be a doc node"},
+		{"unknown node", `{"type":"doc","content":[{"type":"iframe","attrs":{"src":"https://evil.example.com"}}]}`, `node type "iframe" is not supported`},
+		{"html node", `{"type":"doc","content":[{"type":"html","text":"<script>alert(1)</script>"}]}`, `node type "html" is not supported`},
+		{"unknown nested node", `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"image","attrs":{"src":"x"}}]}]}`, `node type "image" is not supported`},
+		{"unknown mark", `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"x","marks":[{"type":"textStyle","attrs":{"color":"red"}}]}]}]}`, `mark type "textStyle" is not supported`},
+		{"nested doc", `{"type":"doc","content":[{"type":"doc"}]}`, "only allowed at the root"},
+		{"empty text", `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":""}]}]}`, "need text"},
+		{"text with content", `{"type":"doc","content":[{"type":"text","text":"x","content":[{"type":"text","text":"y"}]}]}`, "cannot have content"},
+		{"block with text", `{"type":"doc","content":[{"type":"paragraph","text":"x"}]}`, `node "paragraph" cannot have text or marks`},
+		{"block with marks", `{"type":"doc","content":[{"type":"paragraph","marks":[{"type":"bold"}]}]}`, `node "paragraph" cannot have text or marks`},
+		{"heading without level", `{"type":"doc","content":[{"type":"heading"}]}`, "heading level"},
+		{"heading level out of range", `{"type":"doc","content":[{"type":"heading","attrs":{"level":4}}]}`, "heading level"},
+		{"heading fractional level", `{"type":"doc","content":[{"type":"heading","attrs":{"level":1.5}}]}`, "heading level"},
+		{"mention without id", `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"label":"Ana"}}]}]}`, "mention requires attrs.id"},
+		{"attachment without id", `{"type":"doc","content":[{"type":"attachment"}]}`, "attachment requires attrs.attachmentId"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseRichText(t, tt.body).Sanitize()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRichTextNode_SanitizeDropsUnsupportedAttrs(t *testing.T) {
+	doc := parseRichText(t, `{"type":"doc","attrs":{"onload":"alert(1)"},"content":[
+		{"type":"paragraph","attrs":{"style":"color:red","class":"x"},"content":[
+			{"type":"text","text":"bold","marks":[{"type":"bold","attrs":{"onclick":"alert(1)"}}]},
+			{"type":"text","text":"link","marks":[{"type":"link","attrs":{"href":"https://linkko.com","target":"_blank","onclick":"alert(1)"}}]},
+			{"type":"mention","attrs":{"id":"usr_1","label":"Ana","style":"x"}}
+		]},
+		{"type":"heading","attrs":{"level":2,"id":"anchor"}},
+		{"type":"orderedList","attrs":{"start":3.7}},
+		{"type":"codeBlock","attrs":{"language":"`+strings.Repeat("x", 33)+`"}},
+		{"type":"attachment","attrs":{"attachmentId":"att_1","url":"https://evil.example.com"}}
+	]}`)
+	require.NoError(t, doc.Sanitize())
+
+	assert.Nil(t, doc.Attrs)
+	paragraph := doc.Content[0]
+	assert.Nil(t, paragraph.Attrs)
+	assert.Nil(t, paragraph.Content[0].Marks[0].Attrs)
+	assert.Equal(t, map[string]interface{}{"href": "https://linkko.com"}, paragraph.Content[1].Marks[0].Attrs)
+	assert.Equal(t, map[string]interface{}{"id": "usr_1", "label": "Ana"}, paragraph.Content[2].Attrs)
+	assert.Equal(t, map[string]interface{}{"level": float64(2)}, doc.Content[1].Attrs)
+	assert.Equal(t, map[string]interface{}{"start": float64(3)}, doc.Content[2].Attrs)
+	assert.Nil(t, doc.Content[3].Attrs, "language longer than 32 chars is dropped")
+	assert.Equal(t, map[string]interface{}{"attachmentId": "att_1"}, doc.Content[4].Attrs)
+	assert.Equal(t, []string{"att_1"}, doc.AttachmentIDs())
+}
+
+func nestedBlockquotes(levels int) *RichTextNode {
+	doc := &RichTextNode{Type: "doc"}
+	parent := doc
+	for range levels {
+		parent.Content = []RichTextNode{{Type: "blockquote"}}
+		parent = &parent.Content[0]
+	}
+	return doc
+}
+
+func TestRichTextNode_SanitizeLimits(t *testing.T) {
+	t.Run("depth at the limit", func(t *testing.T) {
+		assert.NoError(t, nestedBlockquotes(RichTextMaxDepth).Sanitize())
+	})
+	t.Run("depth over the limit", func(t *testing.T) {
+		err := nestedBlockquotes(RichTextMaxDepth + 1).Sanitize()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nesting levels")
+	})
+
+	paragraphs := func(n int) *RichTextNode {
+		return &RichTextNode{Type: "doc", Content: make([]RichTextNode, n)}
+	}
+	t.Run("node count at the limit", func(t *testing.T) {
+		doc := paragraphs(RichTextMaxNodes - 1) // + doc
+		for i := range doc.Content {
+			doc.Content[i].Type = "paragraph"
+		}
+		assert.NoError(t, doc.Sanitize())
+	})
+	t.Run("node count over the limit", func(t *testing.T) {
+		doc := paragraphs(RichTextMaxNodes)
+		for i := range doc.Content {
+			doc.Content[i].Type = "paragraph"
+		}
+		err := doc.Sanitize()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nodes")
+	})
+
+	text := func(chars int) *RichTextNode {
+		return &RichTextNode{Type: "doc", Content: []RichTextNode{
+			{Type: "paragraph", Content: []RichTextNode{{Type: "text", Text: strings.Repeat("é", chars/2)}}},
+			{Type: "paragraph", Content: []RichTextNode{{Type: "text", Text: strings.Repeat("a", chars-chars/2)}}},
+		}}
+	}
+	t.Run("characters at the limit", func(t *testing.T) {
+		assert.NoError(t, text(RichTextMaxChars).Sanitize())
+	})
+	t.Run("characters over the limit", func(t *testing.T) {
+		err := text(RichTextMaxChars + 1).Sanitize()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "characters")
+	})
+}
+
+func TestRichTextNode_PlainText(t *testing.T) {
+	doc := parseRichText(t, `{"type":"doc","content":[
+		{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Reunião"}]},
+		{"type":"paragraph","content":[
+			{"type":"text","text":"Falar com "},
+			{"type":"mention","attrs":{"id":"usr_1","label":"Ana"}},
+			{"type":"hardBreak"},
+			{"type":"text","text":"amanhã"}
+		]},
+		{"type":"bulletList","content":[
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"proposta"}]}]},
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"contrato"}]}]}
+		]},
+		{"type":"attachment","attrs":{"attachmentId":"att_1"}}
+	]}`)
+	require.NoError(t, doc.Sanitize())
+	assert.Equal(t, "Reunião\nFalar com @Ana\namanhã\nproposta\ncontrato", doc.PlainText())
+
+	assert.Equal(t, "linha 1\n\nlinha 3", NewPlainTextDoc("linha 1\r\n\nlinha 3").PlainText())
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "strings"
+
+// Limites para dados de exemplo anonimizados copiados para o sandbox.
+const (
+	DefaultSandboxSampleSize = 25
+	MaxSandboxSampleSize     = 100
+)
+
+// CloneWorkspaceRequest DTO para clonar a estrutura de um workspace em um sandbox.
+//
+// O workspace de origem vem do path parameter; o destino precisa existir e o
+// ator precisa ser admin nos dois workspaces.
+type CloneWorkspaceRequest struct {
+	// Workspace sandbox que receberá a estrutura clonada
+	TargetWorkspaceID string `json:"targetWorkspaceId" validate:"required,id"`
+
+	// Copia contatos de exemplo com dados pessoais substituídos
+	IncludeSampleData bool `json:"includeSampleData"`
+
+	// Quantidade de contatos de exemplo (default 25, máximo 100)
+	SampleSize *int `json:"sampleSize,omitempty" validate:"omitempty,gte=1,lte=100"`
+}
+
+// Validate valida o CloneWorkspaceRequest.
+func (r *CloneWorkspaceRequest) Validate() error {
+	r.TargetWorkspaceID = strings.TrimSpace(r.TargetWorkspaceID)
+
+	return validate.Struct(r)
+}
+
+// EffectiveSampleSize retorna a quantidade de contatos de exemplo a copiar.
+func (r *CloneWorkspaceRequest) EffectiveSampleSize() int {
+	if !r.IncludeSampleData {
+		return 0
+	}
+	if r.SampleSize == nil {
+		return DefaultSandboxSampleSize
+	}
+	return *r.SampleSize
+}
+
+// CloneWorkspaceResult resumo da clonagem executada.
+// Resources conta, por tipo, os recursos de configuração da origem presentes no sandbox após a
+// clonagem (criados, atualizados ou já iguais).
+// Skipped lista recursos de estrutura que ainda não existem nesta API
+// (ex.: custom fields, automations) para o cliente saber o que não foi copiado.
+type CloneWorkspaceResult struct {
+	SourceWorkspaceID string                    `json:"sourceWorkspaceId"`
+	TargetWorkspaceID string                    `json:"targetWorkspaceId"`
+	PipelinesCloned   int                       `json:"pipelinesCloned"`
+	StagesCloned      int                       `json:"stagesCloned"`
+	ContactsCloned    int                       `json:"contactsCloned"`
+	Resources         map[PromotionResource]int `json:"resources"`
+	Skipped           []string                  `json:"skipped"`
+}
@@ -0,0 +1,788 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Schemas SCIM 2.0 (RFC 7643/7644) usados em /scim/v2
+const (
+	ScimSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+const (
+	// ScimDefaultCount itens por página quando o diretório não informa count
+	ScimDefaultCount = 100
+	// ScimMaxCount limite de itens por página
+	ScimMaxCount = 200
+	// ScimMaxPatchOperations limite de operações por PATCH
+	ScimMaxPatchOperations = 100
+)
+
+// scimType dos erros SCIM (RFC 7644 §3.12)
+const (
+	ScimTypeInvalidFilter = "invalidFilter"
+	ScimTypeInvalidPath   = "invalidPath"
+	ScimTypeInvalidValue  = "invalidValue"
+	ScimTypeInvalidSyntax = "invalidSyntax"
+	ScimTypeUniqueness    = "uniqueness"
+	ScimTypeMutability    = "mutability"
+)
+
+var (
+	errScimUserNameRequired = errors.New("userName is required")
+	errScimEmailRequired    = errors.New("a valid email (emails[primary] or userName) is required")
+	errScimDisplayName      = errors.New("displayName is required")
+)
+
+// ScimInvalidValueError atributos ou operações recusados do diretório (400 invalidValue).
+type ScimInvalidValueError struct {
+	Err error
+}
+
+func (e *ScimInvalidValueError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ScimInvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// =====================================================
+// Configuração (rotas /v1 do admin do workspace)
+// =====================================================
+
+// ScimConfig estado do provisionamento SCIM do workspace. O token só é exibido na criação.
+type ScimConfig struct {
+	WorkspaceID     string     `json:"workspaceId"`
+	Enabled         bool       `json:"enabled"`
+	DefaultRole     Role       `json:"defaultRole"`
+	TokenCreatedAt  *time.Time `json:"tokenCreatedAt"`
+	TokenLastUsedAt *time.Time `json:"tokenLastUsedAt"`
+	UpdatedAt       *time.Time `json:"updatedAt"`
+}
+
+// RotateScimTokenRequest POST /v1/workspaces/{workspaceId}/scim/token.
+// Gera um novo token (o anterior deixa de valer); defaultRole nil mantém o atual (padrão work_user).
+type RotateScimTokenRequest struct {
+	DefaultRole *Role `json:"defaultRole,omitempty" validate:"omitempty,oneof=work_admin work_manager work_user work_viewer"`
+}
+
+// Validate valida o request.
+func (r *RotateScimTokenRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// ScimTokenResponse resposta da rotação: o token em claro aparece somente aqui.
+type ScimTokenResponse struct {
+	Token string `json:"token"`
+	ScimConfig
+}
+
+// ScimGroupSummary grupo do diretório e o papel mapeado, para o admin do workspace.
+type ScimGroupSummary struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"displayName"`
+	ExternalID  *string   `json:"externalId"`
+	Role        *Role     `json:"role"`
+	MemberCount int       `json:"memberCount"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ScimGroupListResponse resposta de GET /v1/workspaces/{workspaceId}/scim/groups.
+type ScimGroupListResponse struct {
+	Data []ScimGroupSummary `json:"data"`
+}
+
+// UpdateScimGroupRoleRequest PUT /v1/workspaces/{workspaceId}/scim/groups/{groupId}/role.
+// role null remove o mapeamento (os membros voltam ao defaultRole ou ao papel de outro grupo).
+type UpdateScimGroupRoleRequest struct {
+	Role *Role `json:"role" validate:"omitempty,oneof=work_admin work_manager work_user work_viewer"`
+}
+
+// Validate valida o request.
+func (r *UpdateScimGroupRoleRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// =====================================================
+// Entidades provisionadas
+// =====================================================
+
+// ScimUser usuário provisionado pelo diretório. ID é o id do "User" do Linkko.
+type ScimUser struct {
+	WorkspaceID string
+	UserID      string
+	UserName    string
+	ExternalID  *string
+	GivenName   *string
+	FamilyName  *string
+	DisplayName *string
+	Email       string
+	Active      bool
+	Groups      []ScimMemberRef
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ScimGroup grupo do diretório; Role mapeia o grupo para um papel do workspace.
+type ScimGroup struct {
+	ID          string
+	WorkspaceID string
+	DisplayName string
+	ExternalID  *string
+	Role        *Role
+	Members     []ScimMemberRef
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ScimUserInput atributos do usuário aceitos do diretório (POST/PUT/PATCH).
+type ScimUserInput struct {
+	UserName    string  `validate:"required,max=255"`
+	ExternalID  *string `validate:"omitempty,max=255"`
+	GivenName   *string `validate:"omitempty,max=255"`
+	FamilyName  *string `validate:"omitempty,max=255"`
+	DisplayName *string `validate:"omitempty,max=255"`
+	Email       string  `validate:"required,email,max=320"`
+	Active      bool
+}
+
+// Validate sanitiza e valida os atributos. Sem email, usa o userName quando ele é um email
+// (padrão do Okta e do Azure AD).
+func (in *ScimUserInput) Validate() error {
+	in.UserName = strings.TrimSpace(in.UserName)
+	in.ExternalID = trimOptional(in.ExternalID)
+	in.GivenName = trimOptional(in.GivenName)
+	in.FamilyName = trimOptional(in.FamilyName)
+	in.DisplayName = trimOptional(in.DisplayName)
+	in.Email = strings.ToLower(strings.TrimSpace(in.Email))
+	if in.UserName == "" {
+		return errScimUserNameRequired
+	}
+	if in.Email == "" && strings.Contains(in.UserName, "@") {
+		in.Email = strings.ToLower(in.UserName)
+	}
+	if in.Email == "" {
+		return errScimEmailRequired
+	}
+	return validate.Struct(in)
+}
+
+// FullName nome exibido do usuário: displayName ou givenName + familyName.
+func (in *ScimUserInput) FullName() *string {
+	if in.DisplayName != nil {
+		return in.DisplayName
+	}
+	parts := make([]string, 0, 2)
+	if in.GivenName != nil {
+		parts = append(parts, *in.GivenName)
+	}
+	if in.FamilyName != nil {
+		parts = append(parts, *in.FamilyName)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	name := strings.Join(parts, " ")
+	return &name
+}
+
+// Input atributos atuais do usuário, base para PATCH.
+func (u *ScimUser) Input() ScimUserInput {
+	return ScimUserInput{
+		UserName:    u.UserName,
+		ExternalID:  u.ExternalID,
+		GivenName:   u.GivenName,
+		FamilyName:  u.FamilyName,
+		DisplayName: u.DisplayName,
+		Email:       u.Email,
+		Active:      u.Active,
+	}
+}
+
+// ScimGroupInput atributos do grupo aceitos do diretório (POST/PUT).
+type ScimGroupInput struct {
+	DisplayName string   `validate:"required,max=255"`
+	ExternalID  *string  `validate:"omitempty,max=255"`
+	MemberIDs   []string `validate:"max=10000"`
+}
+
+// Validate sanitiza e valida os atributos.
+func (in *ScimGroupInput) Validate() error {
+	in.DisplayName = strings.TrimSpace(in.DisplayName)
+	in.ExternalID = trimOptional(in.ExternalID)
+	if in.DisplayName == "" {
+		return errScimDisplayName
+	}
+	in.MemberIDs = uniqueTrimmed(in.MemberIDs)
+	return validate.Struct(in)
+}
+
+// =====================================================
+// Recursos SCIM (JSON de /scim/v2)
+// =====================================================
+
+// ScimMeta metadados do recurso.
+type ScimMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// ScimName nome estruturado do usuário.
+type ScimName struct {
+	Formatted  *string `json:"formatted,omitempty"`
+	GivenName  *string `json:"givenName,omitempty"`
+	FamilyName *string `json:"familyName,omitempty"`
+}
+
+// ScimEmail email do usuário.
+type ScimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// ScimMemberRef referência a um usuário (membros do grupo) ou a um grupo (grupos do usuário).
+type ScimMemberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// ScimUserResource recurso User, usado nas requests e nas respostas.
+type ScimUserResource struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	ExternalID  *string         `json:"externalId,omitempty"`
+	UserName    string          `json:"userName"`
+	Name        *ScimName       `json:"name,omitempty"`
+	DisplayName *string         `json:"displayName,omitempty"`
+	Emails      []ScimEmail     `json:"emails,omitempty"`
+	Active      *bool           `json:"active,omitempty"`
+	Groups      []ScimMemberRef `json:"groups,omitempty"`
+	Meta        *ScimMeta       `json:"meta,omitempty"`
+}
+
+// Input converte o recurso recebido do diretório. active ausente = true.
+func (r *ScimUserResource) Input() ScimUserInput {
+	in := ScimUserInput{
+		UserName:    r.UserName,
+		ExternalID:  r.ExternalID,
+		DisplayName: r.DisplayName,
+		Email:       primaryScimEmail(r.Emails),
+		Active:      r.Active == nil || *r.Active,
+	}
+	if r.Name != nil {
+		in.GivenName = r.Name.GivenName
+		in.FamilyName = r.Name.FamilyName
+		if in.DisplayName == nil {
+			in.DisplayName = r.Name.Formatted
+		}
+	}
+	return in
+}
+
+// Resource representação SCIM do usuário.
+func (u *ScimUser) Resource() *ScimUserResource {
+	active := u.Active
+	created, modified := u.CreatedAt, u.UpdatedAt
+	res := &ScimUserResource{
+		Schemas:     []string{ScimSchemaUser},
+		ID:          u.UserID,
+		ExternalID:  u.ExternalID,
+		UserName:    u.UserName,
+		DisplayName: u.DisplayName,
+		Emails:      []ScimEmail{{Value: u.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Groups:      u.Groups,
+		Meta: &ScimMeta{
+			ResourceType: "User",
+			Created:      &created,
+			LastModified: &modified,
+			Location:     "/scim/v2/Users/" + u.UserID,
+		},
+	}
+	if u.GivenName != nil || u.FamilyName != nil {
+		res.Name = &ScimName{GivenName: u.GivenName, FamilyName: u.FamilyName}
+	}
+	if res.Groups == nil {
+		res.Groups = []ScimMemberRef{}
+	}
+	return res
+}
+
+// ScimGroupResource recurso Group, usado nas requests e nas respostas.
+type ScimGroupResource struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	ExternalID  *string         `json:"externalId,omitempty"`
+	DisplayName string          `json:"displayName"`
+	Members     []ScimMemberRef `json:"members"`
+	Meta        *ScimMeta       `json:"meta,omitempty"`
+}
+
+// Input converte o recurso recebido do diretório.
+func (r *ScimGroupResource) Input() ScimGroupInput {
+	in := ScimGroupInput{DisplayName: r.DisplayName, ExternalID: r.ExternalID}
+	for _, m := range r.Members {
+		in.MemberIDs = append(in.MemberIDs, m.Value)
+	}
+	return in
+}
+
+// Resource representação SCIM do grupo.
+func (g *ScimGroup) Resource() *ScimGroupResource {
+	created, modified := g.CreatedAt, g.UpdatedAt
+	members := g.Members
+	if members == nil {
+		members = []ScimMemberRef{}
+	}
+	return &ScimGroupResource{
+		Schemas:     []string{ScimSchemaGroup},
+		ID:          g.ID,
+		ExternalID:  g.ExternalID,
+		DisplayName: g.DisplayName,
+		Members:     members,
+		Meta: &ScimMeta{
+			ResourceType: "Group",
+			Created:      &created,
+			LastModified: &modified,
+			Location:     "/scim/v2/Groups/" + g.ID,
+		},
+	}
+}
+
+// ScimListResponse página de recursos (startIndex começa em 1).
+type ScimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// ScimServiceProviderConfig recursos suportados pelo endpoint.
+type ScimServiceProviderConfig struct {
+	Schemas               []string              `json:"schemas"`
+	Patch                 ScimSupported         `json:"patch"`
+	Bulk                  ScimBulkConfig        `json:"bulk"`
+	Filter                ScimFilterConfig      `json:"filter"`
+	ChangePassword        ScimSupported         `json:"changePassword"`
+	Sort                  ScimSupported         `json:"sort"`
+	ETag                  ScimSupported         `json:"etag"`
+	AuthenticationSchemes []ScimAuthScheme      `json:"authenticationSchemes"`
+	Meta                  ScimServiceProviderMT `json:"meta"`
+}
+
+// ScimSupported flag de recurso suportado.
+type ScimSupported struct {
+	Supported bool `json:"supported"`
+}
+
+// ScimBulkConfig bulk não é suportado.
+type ScimBulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// ScimFilterConfig filtros suportados (somente "eq").
+type ScimFilterConfig struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// ScimAuthScheme esquema de autenticação (bearer token por workspace).
+type ScimAuthScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+// ScimServiceProviderMT meta do ServiceProviderConfig.
+type ScimServiceProviderMT struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+// NewScimServiceProviderConfig configuração anunciada em /scim/v2/ServiceProviderConfig.
+func NewScimServiceProviderConfig() *ScimServiceProviderConfig {
+	return &ScimServiceProviderConfig{
+		Schemas: []string{ScimSchemaSPConfig},
+		Patch:   ScimSupported{Supported: true},
+		Filter:  ScimFilterConfig{Supported: true, MaxResults: ScimMaxCount},
+		AuthenticationSchemes: []ScimAuthScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer token",
+			Description: "Workspace SCIM token generated in POST /v1/workspaces/{workspaceId}/scim/token",
+			Primary:     true,
+		}},
+		Meta: ScimServiceProviderMT{ResourceType: "ServiceProviderConfig", Location: "/scim/v2/ServiceProviderConfig"},
+	}
+}
+
+// =====================================================
+// Listagem e filtros
+// =====================================================
+
+// ScimFilter filtro "<atributo> eq \"<valor>\"", o único operador usado por Okta e Azure AD.
+type ScimFilter struct {
+	Attribute string
+	Value     string
+}
+
+// ScimListParams parâmetros de listagem (startIndex 1-based).
+type ScimListParams struct {
+	Filter     *ScimFilter
+	StartIndex int
+	Count      int
+}
+
+var scimFilterPattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// ParseScimFilter interpreta o filtro; allowed lista os atributos aceitos (comparação sem caixa,
+// devolvidos na grafia de allowed).
+func ParseScimFilter(filter string, allowed ...string) (*ScimFilter, error) {
+	m := scimFilterPattern.FindStringSubmatch(filter)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported filter %q: only <attribute> eq \"<value>\" is supported", filter)
+	}
+	for _, attr := range allowed {
+		if strings.EqualFold(attr, m[1]) {
+			var value string
+			if err := json.Unmarshal([]byte(`"`+m[2]+`"`), &value); err != nil {
+				return nil, fmt.Errorf("invalid filter value: %w", err)
+			}
+			return &ScimFilter{Attribute: attr, Value: value}, nil
+		}
+	}
+	return nil, fmt.Errorf("filtering by %q is not supported (use one of: %s)", m[1], strings.Join(allowed, ", "))
+}
+
+// NormalizeScimPaging aplica os padrões de startIndex e count.
+func NormalizeScimPaging(startIndex, count int) (int, int) {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	switch {
+	case count <= 0:
+		count = ScimDefaultCount
+	case count > ScimMaxCount:
+		count = ScimMaxCount
+	}
+	return startIndex, count
+}
+
+// =====================================================
+// PATCH
+// =====================================================
+
+// ScimPatchRequest corpo de PATCH (urn:ietf:params:scim:api:messages:2.0:PatchOp).
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+// ScimPatchOperation uma operação. op é comparado sem caixa (o Azure AD envia "Replace").
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func (r *ScimPatchRequest) validate() error {
+	if len(r.Operations) == 0 {
+		return errors.New("Operations is required")
+	}
+	if len(r.Operations) > ScimMaxPatchOperations {
+		return fmt.Errorf("at most %d operations are allowed", ScimMaxPatchOperations)
+	}
+	for i, op := range r.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace", "remove":
+		default:
+			return fmt.Errorf("Operations[%d].op must be one of: add, replace, remove", i)
+		}
+	}
+	return nil
+}
+
+// ApplyUserPatch aplica as operações aos atributos atuais do usuário.
+// Paths aceitos: active, userName, externalId, displayName, name.givenName, name.familyName,
+// name.formatted e emails (inclusive emails[type eq "work"].value); sem path, value é um objeto
+// com esses atributos.
+func (r *ScimPatchRequest) ApplyUserPatch(in *ScimUserInput) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	for i, op := range r.Operations {
+		remove := strings.EqualFold(op.Op, "remove")
+		if op.Path == "" {
+			if remove {
+				return fmt.Errorf("Operations[%d].path is required for remove", i)
+			}
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return fmt.Errorf("Operations[%d].value must be an object", i)
+			}
+			for path, value := range attrs {
+				if err := applyScimUserAttribute(in, path, value, false); err != nil {
+					return fmt.Errorf("Operations[%d]: %w", i, err)
+				}
+			}
+			continue
+		}
+		if err := applyScimUserAttribute(in, op.Path, op.Value, remove); err != nil {
+			return fmt.Errorf("Operations[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func applyScimUserAttribute(in *ScimUserInput, path string, value json.RawMessage, remove bool) error {
+	lower := strings.ToLower(path)
+	switch {
+	case lower == "active":
+		if remove {
+			return errors.New("active cannot be removed")
+		}
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		in.Active = active
+	case lower == "username":
+		if remove {
+			return errors.New("userName cannot be removed")
+		}
+		return json.Unmarshal(value, &in.UserName)
+	case lower == "externalid":
+		return scimOptionalString(&in.ExternalID, value, remove)
+	case lower == "displayname", lower == "name.formatted":
+		return scimOptionalString(&in.DisplayName, value, remove)
+	case lower == "name.givenname":
+		return scimOptionalString(&in.GivenName, value, remove)
+	case lower == "name.familyname":
+		return scimOptionalString(&in.FamilyName, value, remove)
+	case lower == "name":
+		var name ScimName
+		if !remove {
+			if err := json.Unmarshal(value, &name); err != nil {
+				return errors.New("name must be an object")
+			}
+		}
+		in.GivenName, in.FamilyName = name.GivenName, name.FamilyName
+		if name.Formatted != nil {
+			in.DisplayName = name.Formatted
+		}
+	case lower == "emails":
+		if remove {
+			return errors.New("emails cannot be removed")
+		}
+		var emails []ScimEmail
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return errors.New("emails must be an array")
+		}
+		if email := primaryScimEmail(emails); email != "" {
+			in.Email = email
+		}
+	case strings.HasPrefix(lower, "emails[") && strings.HasSuffix(lower, "].value"):
+		if remove {
+			return errors.New("emails cannot be removed")
+		}
+		return json.Unmarshal(value, &in.Email)
+	default:
+		// Atributos não suportados (ex.: extensões enterprise) são ignorados, como recomenda a RFC
+	}
+	return nil
+}
+
+// ScimGroupPatch alterações de um PATCH de grupo.
+type ScimGroupPatch struct {
+	DisplayName    *string
+	ExternalID     *string
+	AddMembers     []string
+	RemoveMembers  []string
+	ReplaceMembers []string
+	// MembersReplaced indica que ReplaceMembers substitui todos os membros (pode ser vazio)
+	MembersReplaced bool
+}
+
+var scimMemberFilterPath = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]+)"\s*\]$`)
+
+// GroupPatch interpreta as operações de PATCH de grupo: add/remove/replace de members
+// (inclusive members[value eq "id"]), replace de displayName e externalId.
+func (r *ScimPatchRequest) GroupPatch() (*ScimGroupPatch, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	patch := &ScimGroupPatch{}
+	for i, op := range r.Operations {
+		kind := strings.ToLower(op.Op)
+		path := strings.ToLower(op.Path)
+
+		if m := scimMemberFilterPath.FindStringSubmatch(op.Path); m != nil {
+			if kind != "remove" {
+				return nil, fmt.Errorf("Operations[%d]: only remove is supported with a members filter", i)
+			}
+			patch.RemoveMembers = append(patch.RemoveMembers, m[1])
+			continue
+		}
+
+		switch path {
+		case "members":
+			var refs []ScimMemberRef
+			if len(op.Value) > 0 {
+				if err := json.Unmarshal(op.Value, &refs); err != nil {
+					return nil, fmt.Errorf("Operations[%d].value must be an array of members", i)
+				}
+			}
+			ids := make([]string, 0, len(refs))
+			for _, ref := range refs {
+				ids = append(ids, ref.Value)
+			}
+			switch kind {
+			case "add":
+				patch.AddMembers = append(patch.AddMembers, ids...)
+			case "remove":
+				if len(op.Value) == 0 {
+					patch.ReplaceMembers, patch.MembersReplaced = []string{}, true
+					patch.AddMembers = nil
+				} else {
+					patch.RemoveMembers = append(patch.RemoveMembers, ids...)
+				}
+			default:
+				patch.ReplaceMembers, patch.MembersReplaced = ids, true
+				patch.AddMembers, patch.RemoveMembers = nil, nil
+			}
+		case "displayname":
+			var name string
+			if kind == "remove" || json.Unmarshal(op.Value, &name) != nil {
+				return nil, fmt.Errorf("Operations[%d]: displayName must be a string", i)
+			}
+			patch.DisplayName = &name
+		case "externalid":
+			if err := scimOptionalString(&patch.ExternalID, op.Value, kind == "remove"); err != nil {
+				return nil, fmt.Errorf("Operations[%d]: %w", i, err)
+			}
+		case "":
+			// Okta: {"op":"replace","value":{"id":"...","displayName":"..."}}
+			var attrs struct {
+				DisplayName *string         `json:"displayName"`
+				ExternalID  *string         `json:"externalId"`
+				Members     []ScimMemberRef `json:"members"`
+			}
+			if kind == "remove" || json.Unmarshal(op.Value, &attrs) != nil {
+				return nil, fmt.Errorf("Operations[%d].value must be an object", i)
+			}
+			if attrs.DisplayName != nil {
+				patch.DisplayName = attrs.DisplayName
+			}
+			if attrs.ExternalID != nil {
+				patch.ExternalID = attrs.ExternalID
+			}
+			if attrs.Members != nil {
+				ids := make([]string, 0, len(attrs.Members))
+				for _, ref := range attrs.Members {
+					ids = append(ids, ref.Value)
+				}
+				if kind == "add" {
+					patch.AddMembers = append(patch.AddMembers, ids...)
+				} else {
+					patch.ReplaceMembers, patch.MembersReplaced = ids, true
+					patch.AddMembers, patch.RemoveMembers = nil, nil
+				}
+			}
+		default:
+			return nil, fmt.Errorf("Operations[%d]: unsupported path %q", i, op.Path)
+		}
+	}
+	patch.AddMembers = uniqueTrimmed(patch.AddMembers)
+	patch.RemoveMembers = uniqueTrimmed(patch.RemoveMembers)
+	if patch.MembersReplaced {
+		patch.ReplaceMembers = uniqueTrimmed(patch.ReplaceMembers)
+		if patch.ReplaceMembers == nil {
+			patch.ReplaceMembers = []string{}
+		}
+	}
+	if patch.DisplayName != nil {
+		name := strings.TrimSpace(*patch.DisplayName)
+		if name == "" {
+			return nil, errScimDisplayName
+		}
+		patch.DisplayName = &name
+	}
+	return patch, nil
+}
+
+// primaryScimEmail email primário, ou o primeiro da lista.
+func primaryScimEmail(emails []ScimEmail) string {
+	for _, e := range emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
+
+// scimBool aceita true/false e as strings "True"/"False" enviadas pelo Azure AD.
+func scimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, errors.New("active must be a boolean")
+}
+
+func scimOptionalString(dst **string, value json.RawMessage, remove bool) error {
+	if remove || len(value) == 0 || string(value) == "null" {
+		*dst = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return errors.New("value must be a string")
+	}
+	*dst = &s
+	return nil
+}
+
+func uniqueTrimmed(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SequenceStepType identifica o tipo de passo de uma sequência (cadência).
+type SequenceStepType string
+
+const (
+	SequenceStepTask  SequenceStepType = "task"  // cria uma tarefa para o responsável
+	SequenceStepEmail SequenceStepType = "email" // cria uma tarefa EMAIL com o template renderizado
+	SequenceStepWait  SequenceStepType = "wait"  // aguarda N dias antes do próximo passo
+)
+
+// SequenceStep é um passo da sequência. Os campos usados dependem de Type:
+// task → title (description/taskType opcionais), email → emailTemplateId, wait → waitDays.
+type SequenceStep struct {
+	Type            SequenceStepType `json:"type" validate:"required,oneof=task email wait"`
+	Title           *string          `json:"title,omitempty" validate:"omitempty,min=1,max=500"`
+	Description     *string          `json:"description,omitempty" validate:"omitempty,max=5000"`
+	TaskType        *TaskType        `json:"taskType,omitempty" validate:"omitempty,oneof=CALL EMAIL MEETING FOLLOWUP OTHER"`
+	EmailTemplateID *string          `json:"emailTemplateId,omitempty"`
+	WaitDays        *int             `json:"waitDays,omitempty" validate:"omitempty,min=1,max=365"`
+}
+
+// validateShape verifica os campos obrigatórios de cada tipo de passo.
+func (s SequenceStep) validateShape(index int) error {
+	switch s.Type {
+	case SequenceStepTask:
+		if s.Title == nil || strings.TrimSpace(*s.Title) == "" {
+			return fmt.Errorf("steps[%d]: task steps require title", index)
+		}
+	case SequenceStepEmail:
+		if s.EmailTemplateID == nil || *s.EmailTemplateID == "" {
+			return fmt.Errorf("steps[%d]: email steps require emailTemplateId", index)
+		}
+	case SequenceStepWait:
+		if s.WaitDays == nil {
+			return fmt.Errorf("steps[%d]: wait steps require waitDays", index)
+		}
+	}
+	return nil
+}
+
+func validateSequenceSteps(steps []SequenceStep) error {
+	for i, step := range steps {
+		if err := step.validateShape(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SequenceExitAction define o que acontece com inscrições ativas quando um evento ocorre.
+type SequenceExitAction string
+
+const (
+	SequenceExitNone     SequenceExitAction = "NONE"
+	SequenceExitPause    SequenceExitAction = "PAUSE"
+	SequenceExitUnenroll SequenceExitAction = "UNENROLL"
+)
+
+// Sequence é uma cadência de follow-up com passos ordenados.
+// OnDealWon/OnReply são avaliados pelo worker antes de executar cada passo.
+type Sequence struct {
+	ID          string             `json:"id"`
+	WorkspaceID string             `json:"workspaceId"`
+	Name        string             `json:"name"`
+	Description *string            `json:"description"`
+	Steps       []SequenceStep     `json:"steps"`
+	OnDealWon   SequenceExitAction `json:"onDealWon"`
+	OnReply     SequenceExitAction `json:"onReply"`
+	CreatedByID string             `json:"createdById"`
+	UpdatedByID *string            `json:"updatedById"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	DeletedAt   *time.Time         `json:"deletedAt,omitempty"`
+}
+
+// HasEmailSteps informa se a sequência envia emails (contatos com email INVALID não entram).
+func (s *Sequence) HasEmailSteps() bool {
+	return len(emailTemplateIDs(s.Steps)) > 0
+}
+
+func emailTemplateIDs(steps []SequenceStep) []string {
+	var ids []string
+	for _, step := range steps {
+		if step.Type == SequenceStepEmail && step.EmailTemplateID != nil {
+			ids = append(ids, *step.EmailTemplateID)
+		}
+	}
+	return ids
+}
+
+// SequenceListResponse resposta da listagem (sem paginação: poucas sequências por workspace).
+type SequenceListResponse struct {
+	Data []Sequence `json:"data"`
+}
+
+// CreateSequenceRequest DTO para criação de sequência.
+type CreateSequenceRequest struct {
+	Name        string              `json:"name" validate:"required,min=1,max=255"`
+	Description *string             `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Steps       []SequenceStep      `json:"steps" validate:"required,min=1,max=50,dive"`
+	OnDealWon   *SequenceExitAction `json:"onDealWon,omitempty" validate:"omitempty,oneof=NONE PAUSE UNENROLL"`
+	OnReply     *SequenceExitAction `json:"onReply,omitempty" validate:"omitempty,oneof=NONE PAUSE UNENROLL"`
+}
+
+// Validate sanitiza e valida o request, incluindo os campos exigidos por tipo de passo.
+func (r *CreateSequenceRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateSequenceSteps(r.Steps)
+}
+
+// EmailTemplateIDs retorna os templates referenciados pelos passos de email.
+func (r *CreateSequenceRequest) EmailTemplateIDs() []string {
+	return emailTemplateIDs(r.Steps)
+}
+
+// UpdateSequenceRequest DTO para atualização parcial (nil = não modificar).
+// Steps substitui a lista inteira; inscrições em andamento continuam do índice atual.
+type UpdateSequenceRequest struct {
+	Name        *string             `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string             `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Steps       []SequenceStep      `json:"steps,omitempty" validate:"omitempty,min=1,max=50,dive"`
+	OnDealWon   *SequenceExitAction `json:"onDealWon,omitempty" validate:"omitempty,oneof=NONE PAUSE UNENROLL"`
+	OnReply     *SequenceExitAction `json:"onReply,omitempty" validate:"omitempty,oneof=NONE PAUSE UNENROLL"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateSequenceRequest) Validate() error {
+	if r.Name != nil {
+		trimmed := strings.TrimSpace(*r.Name)
+		r.Name = &trimmed
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateSequenceSteps(r.Steps)
+}
+
+// EmailTemplateIDs retorna os templates referenciados pelos passos de email.
+func (r *UpdateSequenceRequest) EmailTemplateIDs() []string {
+	return emailTemplateIDs(r.Steps)
+}
+
+// EnrollmentStatus representa o estado de uma inscrição.
+type EnrollmentStatus string
+
+const (
+	EnrollmentActive    EnrollmentStatus = "ACTIVE"
+	EnrollmentPaused    EnrollmentStatus = "PAUSED"
+	EnrollmentCompleted EnrollmentStatus = "COMPLETED"
+	EnrollmentExited    EnrollmentStatus = "EXITED"
+)
+
+// IsValid valida se o status é suportado.
+func (s EnrollmentStatus) IsValid() bool {
+	switch s {
+	case EnrollmentActive, EnrollmentPaused, EnrollmentCompleted, EnrollmentExited:
+		return true
+	}
+	return false
+}
+
+// Motivos registrados em statusReason.
+const (
+	EnrollmentReasonManual          = "manual"
+	EnrollmentReasonDealWon         = "deal_won"
+	EnrollmentReasonContactReplied  = "contact_replied"
+	EnrollmentReasonSequenceDeleted = "sequence_deleted"
+	EnrollmentReasonContactDeleted  = "contact_deleted"
+	EnrollmentReasonEmailInvalid    = "email_invalid"
+)
+
+// SequenceEnrollment é a inscrição de um contato em uma sequência.
+// CurrentStep é o índice do próximo passo a executar; NextRunAt é quando o worker o executa.
+type SequenceEnrollment struct {
+	ID              string           `json:"id"`
+	WorkspaceID     string           `json:"workspaceId"`
+	SequenceID      string           `json:"sequenceId"`
+	ContactID       string           `json:"contactId"`
+	DealID          *string          `json:"dealId"`
+	Status          EnrollmentStatus `json:"status"`
+	StatusReason    *string          `json:"statusReason"`
+	StatusChangedAt time.Time        `json:"statusChangedAt"`
+	CurrentStep     int              `json:"currentStep"`
+	NextRunAt       *time.Time       `json:"nextRunAt"`
+	EnrolledByID    string           `json:"enrolledById"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+}
+
+// SequenceEnrollmentListResponse resposta da listagem de inscrições.
+type SequenceEnrollmentListResponse struct {
+	Data []SequenceEnrollment `json:"data"`
+}
+
+// EnrollContactRequest DTO para inscrever um contato.
+// DealID vincula a inscrição a um negócio (usado em merge fields e em onDealWon).
+type EnrollContactRequest struct {
+	ContactID string  `json:"contactId" validate:"required,id"`
+	DealID    *string `json:"dealId,omitempty"`
+}
+
+// Validate valida o request.
+func (r *EnrollContactRequest) Validate() error {
+	r.ContactID = strings.TrimSpace(r.ContactID)
+	return validate.Struct(r)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSequenceRequest_Validate(t *testing.T) {
+	days := func(n int) *int { return &n }
+	task := SequenceStep{Type: SequenceStepTask, Title: strPtr("Ligar para o contato")}
+
+	tests := []struct {
+		name    string
+		steps   []SequenceStep
+		wantErr string
+	}{
+		{"task, wait and email", []SequenceStep{
+			task,
+			{Type: SequenceStepWait, WaitDays: days(2)},
+			{Type: SequenceStepEmail, EmailTemplateID: strPtr("etpl_1")},
+		}, ""},
+		{"no steps", []SequenceStep{}, "steps"},
+		{"unknown step type", []SequenceStep{{Type: "sms"}}, "steps[0].type"},
+		{"task without title", []SequenceStep{task, {Type: SequenceStepTask, Title: strPtr("  ")}}, "steps[1]: task steps require title"},
+		{"email without template", []SequenceStep{{Type: SequenceStepEmail}}, "steps[0]: email steps require emailTemplateId"},
+		{"wait without days", []SequenceStep{{Type: SequenceStepWait}}, "steps[0]: wait steps require waitDays"},
+		{"wait out of range", []SequenceStep{{Type: SequenceStepWait, WaitDays: days(366)}}, "steps[0].waitDays"},
+		{"unknown task type", []SequenceStep{{Type: SequenceStepTask, Title: strPtr("x"), TaskType: new(TaskType)}}, "steps[0].taskType"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &CreateSequenceRequest{Name: "  Onboarding  ", Steps: tt.steps}
+			err := req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "Onboarding", req.Name)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUpdateSequenceRequest_Validate(t *testing.T) {
+	blank := "   "
+	assert.Error(t, (&UpdateSequenceRequest{Name: &blank}).Validate())
+
+	// Steps omitido mantém a lista atual
+	assert.NoError(t, (&UpdateSequenceRequest{}).Validate())
+	assert.ErrorContains(t, (&UpdateSequenceRequest{Steps: []SequenceStep{{Type: SequenceStepWait}}}).Validate(), "waitDays")
+
+	onReply := SequenceExitAction("STOP")
+	assert.Error(t, (&UpdateSequenceRequest{OnReply: &onReply}).Validate())
+}
+
+func TestSequence_EmailSteps(t *testing.T) {
+	req := &CreateSequenceRequest{Steps: []SequenceStep{
+		{Type: SequenceStepEmail, EmailTemplateID: strPtr("etpl_1")},
+		{Type: SequenceStepTask, Title: strPtr("Ligar")},
+		{Type: SequenceStepEmail, EmailTemplateID: strPtr("etpl_2")},
+	}}
+	assert.Equal(t, []string{"etpl_1", "etpl_2"}, req.EmailTemplateIDs())
+	assert.True(t, (&Sequence{Steps: req.Steps}).HasEmailSteps())
+	assert.False(t, (&Sequence{Steps: req.Steps[1:2]}).HasEmailSteps())
+}
+
+func TestEnrollmentStatus_IsValid(t *testing.T) {
+	for _, s := range []EnrollmentStatus{EnrollmentActive, EnrollmentPaused, EnrollmentCompleted, EnrollmentExited} {
+		assert.True(t, s.IsValid(), s)
+	}
+	assert.False(t, EnrollmentStatus("active").IsValid())
+}
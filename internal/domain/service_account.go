@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ServiceAccountTokenType tipo do access_token emitido em POST /v1/auth/token
+	ServiceAccountTokenType = "Bearer"
+	// GrantTypeClientCredentials único grant aceito pelo endpoint de token (RFC 6749 §4.4)
+	GrantTypeClientCredentials = "client_credentials"
+
+	ScopeActionRead  = "read"
+	ScopeActionWrite = "write"
+	// ScopeAllResources recurso curinga: "*:read" vale para todos os ServiceAccountScopeResources
+	ScopeAllResources = "*"
+)
+
+// ServiceAccountScopeResources recursos (primeiro segmento depois de /workspaces/{workspaceId})
+// que um token com escopo pode acessar. Rotas administrativas (billing, sso, scim, service-accounts,
+// impersonation, trash...) ficam de fora: só usuários as acessam.
+var ServiceAccountScopeResources = []string{
+	"business-hours", "companies", "computed-fields", "contacts", "counters", "deals",
+	"document-templates", "email-events", "email-templates", "forms", "holidays",
+	"identity-mappings", "invoices", "links", "notifications", "object-types", "objects",
+	"pipelines", "portfolio", "quotes", "report-schedules", "reports", "sequences",
+	"sync-conflicts", "tasks", "time-entries", "timeline",
+}
+
+// =====================================================
+// Service accounts do workspace (rotas /v1 do admin)
+// =====================================================
+
+// ServiceAccount conta de integração do workspace. O ID é o client_id do grant client_credentials;
+// o secret só aparece na criação e na rotação (ServiceAccountCredentials).
+type ServiceAccount struct {
+	ID          string  `json:"id"`
+	WorkspaceID string  `json:"workspaceId"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	// Scopes escopos máximos dos tokens da conta ("contacts:read", "deals:write", "*:read")
+	Scopes []string `json:"scopes"`
+	// Role papel da conta no workspace; os escopos restringem, nunca ampliam, o que o papel permite
+	Role              Role       `json:"role"`
+	Enabled           bool       `json:"enabled"`
+	SecretCreatedAt   time.Time  `json:"secretCreatedAt"`
+	LastTokenIssuedAt *time.Time `json:"lastTokenIssuedAt"`
+	CreatedByID       string     `json:"createdById"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+// ServiceAccountCredentials resposta da criação e da rotação do secret (mostrado uma única vez)
+type ServiceAccountCredentials struct {
+	ServiceAccount
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+// ServiceAccountListResponse resposta de GET /v1/workspaces/{workspaceId}/service-accounts.
+type ServiceAccountListResponse struct {
+	Data []ServiceAccount `json:"data"`
+}
+
+// CreateServiceAccountRequest POST /v1/workspaces/{workspaceId}/service-accounts.
+type CreateServiceAccountRequest struct {
+	Name        string   `json:"name" validate:"required,min=1,max=255"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Scopes      []string `json:"scopes" validate:"required,min=1,max=50"`
+	Role        *Role    `json:"role,omitempty" validate:"omitempty,oneof=work_manager work_user work_viewer"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateServiceAccountRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = trimOptional(r.Description)
+	r.Scopes = normalizeScopes(r.Scopes)
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return ValidateScopes(r.Scopes)
+}
+
+// UpdateServiceAccountRequest PATCH /v1/workspaces/{workspaceId}/service-accounts/{serviceAccountId}
+// (nil = não modificar). Tokens já emitidos mantêm o escopo até expirar; o papel e enabled valem
+// na próxima request.
+type UpdateServiceAccountRequest struct {
+	Name        *string   `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string   `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Scopes      *[]string `json:"scopes,omitempty" validate:"omitempty,min=1,max=50"`
+	Role        *Role     `json:"role,omitempty" validate:"omitempty,oneof=work_manager work_user work_viewer"`
+	Enabled     *bool     `json:"enabled,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateServiceAccountRequest) Validate() error {
+	r.Name = trimOptional(r.Name)
+	if r.Description != nil {
+		description := strings.TrimSpace(*r.Description)
+		r.Description = &description
+	}
+	if r.Scopes != nil {
+		scopes := normalizeScopes(*r.Scopes)
+		r.Scopes = &scopes
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if r.Scopes != nil {
+		return ValidateScopes(*r.Scopes)
+	}
+	return nil
+}
+
+// Apply mescla o update na conta. description vazia remove a descrição.
+func (r *UpdateServiceAccountRequest) Apply(sa *ServiceAccount) {
+	if r.Name != nil {
+		sa.Name = *r.Name
+	}
+	if r.Description != nil {
+		sa.Description = emptyToNil(r.Description)
+	}
+	if r.Scopes != nil {
+		sa.Scopes = *r.Scopes
+	}
+	if r.Role != nil {
+		sa.Role = *r.Role
+	}
+	if r.Enabled != nil {
+		sa.Enabled = *r.Enabled
+	}
+}
+
+// =====================================================
+// Token (POST /v1/auth/token, sem JWT)
+// =====================================================
+
+// ServiceAccountTokenRequest corpo application/x-www-form-urlencoded do grant client_credentials.
+// client_id/client_secret também podem vir em HTTP Basic (RFC 6749 §2.3.1).
+type ServiceAccountTokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	// Scope escopos pedidos, separados por espaço (vazio = todos os da conta)
+	Scope string
+}
+
+// ServiceAccountToken resposta do endpoint de token (RFC 6749 §5.1)
+type ServiceAccountToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// =====================================================
+// Escopos
+// =====================================================
+
+// ValidateScopes exige o formato recurso:ação, com recurso de ServiceAccountScopeResources ou "*".
+func ValidateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		resource, action, ok := strings.Cut(scope, ":")
+		if !ok || (action != ScopeActionRead && action != ScopeActionWrite) {
+			return fmt.Errorf("invalid scope %q: must be <resource>:read or <resource>:write", scope)
+		}
+		if resource != ScopeAllResources && !containsString(ServiceAccountScopeResources, resource) {
+			return fmt.Errorf("invalid scope %q: unknown resource %q", scope, resource)
+		}
+	}
+	return nil
+}
+
+// ScopesAllow reports whether scopes grant action on resource. write implies read and "*" covers
+// every resource in ServiceAccountScopeResources; any other resource is always denied.
+func ScopesAllow(scopes []string, resource, action string) bool {
+	if !containsString(ServiceAccountScopeResources, resource) {
+		return false
+	}
+	for _, scope := range scopes {
+		r, a, ok := strings.Cut(scope, ":")
+		if !ok || (r != resource && r != ScopeAllResources) {
+			continue
+		}
+		if a == action || a == ScopeActionWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopesCover reports whether every requested scope is within granted (a token never gets more
+// than the account allows).
+func ScopesCover(granted, requested []string) bool {
+	for _, scope := range requested {
+		resource, action, _ := strings.Cut(scope, ":")
+		if resource != ScopeAllResources {
+			if !ScopesAllow(granted, resource, action) {
+				return false
+			}
+			continue
+		}
+		if !containsString(granted, ScopeAllResources+":"+action) && !containsString(granted, ScopeAllResources+":"+ScopeActionWrite) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseScope separa o parâmetro scope (RFC 6749 §3.3) em escopos normalizados
+func ParseScope(scope string) []string {
+	return normalizeScopes(strings.Fields(scope))
+}
+
+// normalizeScopes minúsculas, sem vazios e duplicados
+func normalizeScopes(scopes []string) []string {
+	if scopes == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s != "" && !containsString(normalized, s) {
+			normalized = append(normalized, s)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+// Permissões efetivas devolvidas por GET /v1/me. Derivadas do papel no workspace pelos
+// helpers de RBAC (ver Permission Matrix em workspace.go); não substituem a checagem nos services.
+const (
+	PermissionWorkspaceRead   = "workspace:read"
+	PermissionRecordsWrite    = "records:write"
+	PermissionRecordsDelete   = "records:delete"
+	PermissionMembersManage   = "members:manage"
+	PermissionWorkspaceManage = "workspace:manage"
+)
+
+// PermissionsForRole lista as permissões efetivas de um papel, da mais básica para a mais ampla.
+func PermissionsForRole(role Role) []string {
+	permissions := []string{}
+	if IsWorkspaceMember(role) {
+		permissions = append(permissions, PermissionWorkspaceRead)
+	}
+	if CanModifyContacts(role) {
+		permissions = append(permissions, PermissionRecordsWrite)
+	}
+	if CanDeleteContacts(role) {
+		permissions = append(permissions, PermissionRecordsDelete)
+	}
+	if CanManageMembers(role) {
+		permissions = append(permissions, PermissionMembersManage)
+	}
+	if CanManageWorkspace(role) {
+		permissions = append(permissions, PermissionWorkspaceManage)
+	}
+	return permissions
+}
+
+// SessionActor quem está autenticado. Nome, email e imagem vêm de "User" quando o ator é um usuário.
+type SessionActor struct {
+	ID    string  `json:"id"`
+	Type  string  `json:"type"` // user | service
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+	Image *string `json:"image,omitempty"`
+}
+
+// SessionToken metadados da credencial. JWT: issuer e validade; S2S: client.
+type SessionToken struct {
+	Issuer           *string    `json:"issuer,omitempty"`
+	Client           *string    `json:"client,omitempty"`
+	IssuedAt         *time.Time `json:"issuedAt,omitempty"`
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty"`
+	ExpiresInSeconds *int64     `json:"expiresInSeconds,omitempty"`
+}
+
+// SessionRateLimit consumo atual da janela de rate limit do workspace (sem consumir a cota).
+type SessionRateLimit struct {
+	Limit         int       `json:"limit"`
+	Remaining     int       `json:"remaining"`
+	WindowSeconds int       `json:"windowSeconds"`
+	ResetAt       time.Time `json:"resetAt"`
+}
+
+// SessionWorkspace workspace do ator: papel, permissões efetivas e se a credencial atual dá
+// acesso a ele (claims workspaceId/workspaces no JWT, X-Workspace-Id no S2S).
+type SessionWorkspace struct {
+	WorkspaceID    string            `json:"workspaceId"`
+	WorkspaceName  *string           `json:"workspaceName,omitempty"`
+	Role           *Role             `json:"role,omitempty"` // nil = a credencial aponta para um workspace sem associação
+	Permissions    []string          `json:"permissions"`
+	GrantedByToken bool              `json:"grantedByToken"`
+	RateLimit      *SessionRateLimit `json:"rateLimit,omitempty"`
+}
+
+// Session resposta de GET /v1/me: o contexto de autenticação resolvido, para o cliente não
+// precisar decodificar o JWT e para chamadas S2S conferirem a configuração.
+type Session struct {
+	Actor          SessionActor       `json:"actor"`
+	AuthMethod     string             `json:"authMethod"` // jwt | s2s
+	ImpersonatorID *string            `json:"impersonatorId,omitempty"`
+	OrgID          *string            `json:"orgId,omitempty"`
+	Token          SessionToken       `json:"token"`
+	Workspaces     []SessionWorkspace `json:"workspaces"`
+}
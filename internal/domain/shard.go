@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// WorkspaceShard shard de banco que guarda os dados do workspace (residência de dados).
+// Workspaces sem entrada ficam no banco padrão.
+type WorkspaceShard struct {
+	WorkspaceID string    `json:"workspaceId"`
+	Shard       string    `json:"shard"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ShardMigration resultado de "linkko-api shard migrate": linhas copiadas para o shard de
+// destino e removidas do shard de origem, por tabela.
+type ShardMigration struct {
+	WorkspaceID string           `json:"workspaceId"`
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Copied      map[string]int64 `json:"copied"`
+	Purged      map[string]int64 `json:"purged"`
+}
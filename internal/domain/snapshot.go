@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// SnapshotKind distingue exportação e restauração.
+type SnapshotKind string
+
+const (
+	SnapshotKindExport  SnapshotKind = "EXPORT"
+	SnapshotKindRestore SnapshotKind = "RESTORE"
+)
+
+// SnapshotStatus ciclo de vida do job: PENDING → RUNNING → COMPLETED | FAILED.
+type SnapshotStatus string
+
+const (
+	SnapshotStatusPending   SnapshotStatus = "PENDING"
+	SnapshotStatusRunning   SnapshotStatus = "RUNNING"
+	SnapshotStatusCompleted SnapshotStatus = "COMPLETED"
+	SnapshotStatusFailed    SnapshotStatus = "FAILED"
+)
+
+// SnapshotFormatVersion versão do layout do arquivo (manifest.json + <entidade>.ndjson).
+const SnapshotFormatVersion = 1
+
+// SnapshotImportPrefix prefixo das chaves aceitas para restauração de arquivos
+// trazidos de outro ambiente: imports/{workspaceId}/...
+const SnapshotImportPrefix = "imports/"
+
+var errRestoreSource = errors.New("provide either snapshotId or objectKey")
+
+// WorkspaceSnapshot é um job assíncrono de exportação ou restauração do workspace.
+// ObjectKey aponta para o arquivo no object storage (gerado pelo EXPORT, lido pelo RESTORE).
+type WorkspaceSnapshot struct {
+	ID            string           `json:"id"`
+	WorkspaceID   string           `json:"workspaceId"`
+	Kind          SnapshotKind     `json:"kind"`
+	Status        SnapshotStatus   `json:"status"`
+	ObjectKey     string           `json:"objectKey"`
+	SizeBytes     *int64           `json:"sizeBytes"`
+	EntityCounts  map[string]int64 `json:"entityCounts"`
+	Error         *string          `json:"error"`
+	RequestedByID string           `json:"requestedById"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+	StartedAt     *time.Time       `json:"startedAt"`
+	CompletedAt   *time.Time       `json:"completedAt"`
+}
+
+// Downloadable reporta se o arquivo do snapshot pode ser baixado.
+func (s *WorkspaceSnapshot) Downloadable() bool {
+	return s.Kind == SnapshotKindExport && s.Status == SnapshotStatusCompleted
+}
+
+// SnapshotObjectKey chave do arquivo gerado por uma exportação.
+func SnapshotObjectKey(workspaceID, snapshotID string) string {
+	return "snapshots/" + workspaceID + "/" + snapshotID + ".tar.gz"
+}
+
+// RestoreSnapshotRequest DTO de POST /snapshots/:restore.
+// SnapshotID restaura uma exportação concluída do próprio workspace; ObjectKey restaura
+// um arquivo copiado de outro ambiente para imports/{workspaceId}/.
+type RestoreSnapshotRequest struct {
+	SnapshotID *string `json:"snapshotId,omitempty" validate:"omitempty,id"`
+	ObjectKey  *string `json:"objectKey,omitempty" validate:"omitempty,min=1,max=512"`
+}
+
+// Validate exige exatamente uma origem.
+func (r *RestoreSnapshotRequest) Validate() error {
+	if r.ObjectKey != nil {
+		trimmed := strings.TrimSpace(*r.ObjectKey)
+		r.ObjectKey = &trimmed
+	}
+	if (r.SnapshotID == nil) == (r.ObjectKey == nil) {
+		return errRestoreSource
+	}
+	return validate.Struct(r)
+}
+
+// SnapshotListResponse resposta da listagem de snapshots.
+type SnapshotListResponse struct {
+	Data []WorkspaceSnapshot `json:"data"`
+}
+
+// ListSnapshotsParams filtros de GET /snapshots.
+type ListSnapshotsParams struct {
+	WorkspaceID string
+	Limit       int
+}
+
+// Normalize aplica o limite padrão (20, máx. 100).
+func (p *ListSnapshotsParams) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = 20
+	}
+	if p.Limit > 100 {
+		p.Limit = 100
+	}
+}
+
+// SnapshotManifest é o manifest.json, primeiro membro do arquivo.
+// Entities traz a contagem de linhas de cada <entidade>.ndjson.
+type SnapshotManifest struct {
+	Version     int              `json:"version"`
+	WorkspaceID string           `json:"workspaceId"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	Entities    map[string]int64 `json:"entities"`
+}
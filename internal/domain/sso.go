@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SsoSessionTokenType tipo do token devolvido pela troca do id_token
+const SsoSessionTokenType = "Bearer"
+
+// =====================================================
+// IdPs do workspace (rotas /v1 do admin)
+// =====================================================
+
+// SsoProvider IdP OpenID Connect configurado no workspace. id_tokens emitidos por Issuer para
+// ClientID são trocados por um JWT de sessão do Linkko em POST /v1/auth/sso/oidc/exchange.
+type SsoProvider struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspaceId"`
+	Name        string `json:"name"`
+	Issuer      string `json:"issuer"`
+	ClientID    string `json:"clientId"`
+	// AllowedDomains domínios de email aceitos (vazio = qualquer domínio)
+	AllowedDomains []string `json:"allowedDomains"`
+	// DefaultRole papel dos membros criados no primeiro login (JIT)
+	DefaultRole Role `json:"defaultRole"`
+	// JITProvisioning adiciona ao workspace quem ainda não é membro; desligado, só membros entram
+	JITProvisioning bool       `json:"jitProvisioning"`
+	Enabled         bool       `json:"enabled"`
+	LastLoginAt     *time.Time `json:"lastLoginAt"`
+	CreatedByID     string     `json:"createdById"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// AllowsEmail reports whether the email domain is accepted by the provider.
+func (p *SsoProvider) AllowsEmail(email string) bool {
+	if len(p.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	return containsString(p.AllowedDomains, strings.ToLower(email[at+1:]))
+}
+
+// OwnsEmailDomain reports whether the email domain is explicitly listed in AllowedDomains.
+// Only then may the first login link an existing user from outside the workspace by email.
+func (p *SsoProvider) OwnsEmailDomain(email string) bool {
+	return len(p.AllowedDomains) > 0 && p.AllowsEmail(email)
+}
+
+// SsoProviderListResponse resposta de GET /v1/workspaces/{workspaceId}/sso/providers.
+type SsoProviderListResponse struct {
+	Data []SsoProvider `json:"data"`
+}
+
+// CreateSsoProviderRequest POST /v1/workspaces/{workspaceId}/sso/providers.
+// issuer é a URL https do IdP (o discovery fica em {issuer}/.well-known/openid-configuration).
+type CreateSsoProviderRequest struct {
+	Name            string   `json:"name" validate:"required,min=1,max=255"`
+	Issuer          string   `json:"issuer" validate:"required,max=2048"`
+	ClientID        string   `json:"clientId" validate:"required,min=1,max=255"`
+	AllowedDomains  []string `json:"allowedDomains,omitempty" validate:"omitempty,max=50,dive,fqdn"`
+	DefaultRole     *Role    `json:"defaultRole,omitempty" validate:"omitempty,oneof=work_admin work_manager work_user work_viewer"`
+	JITProvisioning *bool    `json:"jitProvisioning,omitempty"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateSsoProviderRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Issuer = strings.TrimSpace(r.Issuer)
+	r.ClientID = strings.TrimSpace(r.ClientID)
+	r.AllowedDomains = normalizeEmailDomains(r.AllowedDomains)
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateSsoIssuer(r.Issuer)
+}
+
+// UpdateSsoProviderRequest PATCH /v1/workspaces/{workspaceId}/sso/providers/{providerId}
+// (nil = não modificar). O issuer não muda: as identidades vinculadas pertencem a ele.
+type UpdateSsoProviderRequest struct {
+	Name            *string   `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	ClientID        *string   `json:"clientId,omitempty" validate:"omitempty,min=1,max=255"`
+	AllowedDomains  *[]string `json:"allowedDomains,omitempty" validate:"omitempty,max=50,dive,fqdn"`
+	DefaultRole     *Role     `json:"defaultRole,omitempty" validate:"omitempty,oneof=work_admin work_manager work_user work_viewer"`
+	JITProvisioning *bool     `json:"jitProvisioning,omitempty"`
+	Enabled         *bool     `json:"enabled,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateSsoProviderRequest) Validate() error {
+	r.Name = trimOptional(r.Name)
+	r.ClientID = trimOptional(r.ClientID)
+	if r.AllowedDomains != nil {
+		domains := normalizeEmailDomains(*r.AllowedDomains)
+		r.AllowedDomains = &domains
+	}
+	return validate.Struct(r)
+}
+
+// Apply mescla o update no provider.
+func (r *UpdateSsoProviderRequest) Apply(p *SsoProvider) {
+	if r.Name != nil {
+		p.Name = *r.Name
+	}
+	if r.ClientID != nil {
+		p.ClientID = *r.ClientID
+	}
+	if r.AllowedDomains != nil {
+		p.AllowedDomains = *r.AllowedDomains
+	}
+	if r.DefaultRole != nil {
+		p.DefaultRole = *r.DefaultRole
+	}
+	if r.JITProvisioning != nil {
+		p.JITProvisioning = *r.JITProvisioning
+	}
+	if r.Enabled != nil {
+		p.Enabled = *r.Enabled
+	}
+}
+
+// =====================================================
+// Troca do id_token (POST /v1/auth/sso/oidc/exchange, sem JWT)
+// =====================================================
+
+// SsoExchangeRequest id_token obtido pelo front direto no IdP do workspace. nonce, quando o
+// front o enviou na autorização, precisa ser igual ao claim do token.
+type SsoExchangeRequest struct {
+	WorkspaceID string  `json:"workspaceId" validate:"required,max=255"`
+	IDToken     string  `json:"idToken" validate:"required,max=16384"`
+	Nonce       *string `json:"nonce,omitempty" validate:"omitempty,max=255"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *SsoExchangeRequest) Validate() error {
+	r.WorkspaceID = strings.TrimSpace(r.WorkspaceID)
+	r.IDToken = strings.TrimSpace(r.IDToken)
+	r.Nonce = trimOptional(r.Nonce)
+	return validate.Struct(r)
+}
+
+// SsoSession JWT de sessão emitido pelo Linkko para o workspace. Provisioned indica que o
+// usuário entrou no workspace neste login (JIT).
+type SsoSession struct {
+	Token       string    `json:"token"`
+	TokenType   string    `json:"tokenType"`
+	WorkspaceID string    `json:"workspaceId"`
+	ActorID     string    `json:"actorId"`
+	Role        Role      `json:"role"`
+	Provisioned bool      `json:"provisioned"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// SsoLogin identidade validada do IdP, usada para vincular ou criar o usuário.
+type SsoLogin struct {
+	Subject string
+	Email   string
+	Name    *string
+}
+
+// validateSsoIssuer exige URL https absoluta; http só para localhost (desenvolvimento)
+func validateSsoIssuer(issuer string) error {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("issuer must be an absolute URL without query or fragment")
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if host := u.Hostname(); host == "localhost" || host == "127.0.0.1" {
+			return nil
+		}
+	}
+	return fmt.Errorf("issuer must use https")
+}
+
+// normalizeEmailDomains minúsculas, sem @ inicial, vazios e duplicados
+func normalizeEmailDomains(domains []string) []string {
+	if domains == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(domains))
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d != "" && !containsString(normalized, d) {
+			normalized = append(normalized, d)
+		}
+	}
+	return normalized
+}
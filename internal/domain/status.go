@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// ServiceStatus situação resumida da API (ou de um componente) em GET /v1/status
+type ServiceStatus string
+
+const (
+	ServiceOperational ServiceStatus = "operational"
+	// ServiceDegraded a API responde, mas um componente está fora ou em fallback
+	ServiceDegraded ServiceStatus = "degraded"
+	// ServiceMaintenance há manutenção ligada: escritas recebem 503 MAINTENANCE_MODE
+	ServiceMaintenance ServiceStatus = "maintenance"
+	// ServiceDown só em componentes: a dependência não respondeu ao health check
+	ServiceDown ServiceStatus = "down"
+)
+
+// Componentes reportados em GET /v1/status
+const (
+	StatusComponentDatabase  = "database"
+	StatusComponentRateLimit = "rateLimit"
+)
+
+// StatusComponent saúde de uma dependência da API.
+type StatusComponent struct {
+	Name    string        `json:"name"`
+	Status  ServiceStatus `json:"status"`
+	Message *string       `json:"message"`
+}
+
+// StatusThrottle consumo atual do rate limit do workspace (sem consumir a cota). Throttled
+// indica que as próximas requests recebem 429 até ResetAt.
+type StatusThrottle struct {
+	Limit         int       `json:"limit"`
+	Remaining     int       `json:"remaining"`
+	WindowSeconds int       `json:"windowSeconds"`
+	ResetAt       time.Time `json:"resetAt"`
+	Throttled     bool      `json:"throttled"`
+}
+
+// WorkspaceAPIStatus parte do status que depende do workspace: a manutenção do próprio workspace
+// (a global fica em APIStatus.Maintenance) e o rate limit.
+type WorkspaceAPIStatus struct {
+	WorkspaceID string          `json:"workspaceId"`
+	Maintenance *Maintenance    `json:"maintenance"`
+	RateLimit   *StatusThrottle `json:"rateLimit"` // nil quando o rate limit não pôde ser lido
+}
+
+// APIStatus resposta de GET /v1/status e GET /v1/workspaces/{workspaceId}/status: legível por
+// máquina, para os clientes mostrarem um aviso de instabilidade em vez de um erro genérico.
+type APIStatus struct {
+	Status      ServiceStatus       `json:"status"`
+	CheckedAt   time.Time           `json:"checkedAt"`
+	Components  []StatusComponent   `json:"components"`
+	Maintenance *Maintenance        `json:"maintenance"` // manutenção global
+	Workspace   *WorkspaceAPIStatus `json:"workspace,omitempty"`
+}
+
+// Resolve calcula Status: maintenance quando há manutenção (global ou do workspace), degraded
+// quando algum componente não está operational e operational caso contrário.
+func (s *APIStatus) Resolve() {
+	switch {
+	case s.Maintenance != nil || (s.Workspace != nil && s.Workspace.Maintenance != nil):
+		s.Status = ServiceMaintenance
+	default:
+		s.Status = ServiceOperational
+		for _, c := range s.Components {
+			if c.Status != ServiceOperational {
+				s.Status = ServiceDegraded
+				break
+			}
+		}
+	}
+}
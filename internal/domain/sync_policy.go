@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyncStrategy como os upserts de uma integração resolvem conflitos com outros sistemas
+type SyncStrategy string
+
+const (
+	// SyncLastWriterWins o upsert sempre sobrescreve (padrão de sistemas sem política)
+	SyncLastWriterWins SyncStrategy = "LAST_WRITER_WINS"
+	// SyncSourcePriority um campo gravado por outro sistema só é sobrescrito com prioridade maior ou igual
+	SyncSourcePriority SyncStrategy = "SOURCE_PRIORITY"
+)
+
+// SyncResolution resultado de um conflito registrado no log
+type SyncResolution string
+
+const (
+	SyncOverwritten SyncResolution = "OVERWRITTEN"  // o valor recebido foi gravado
+	SyncKeptCurrent SyncResolution = "KEPT_CURRENT" // o valor recebido foi descartado
+)
+
+// SyncSystemMaxLength tamanho máximo do nome do sistema (o mesmo system dos identity mappings)
+const SyncSystemMaxLength = 100
+
+// MaxSyncFieldPriorities limite de campos com prioridade própria numa política
+const MaxSyncFieldPriorities = 100
+
+// NormalizeSyncSystem normaliza o system (minúsculas, sem espaços nas bordas), como nos identity
+// mappings. ok é false quando o resultado é vazio ou passa de SyncSystemMaxLength.
+func NormalizeSyncSystem(raw string) (system string, ok bool) {
+	system = strings.ToLower(strings.TrimSpace(raw))
+	return system, system != "" && len(system) <= SyncSystemMaxLength
+}
+
+// SyncPolicy política de conflito de uma integração (system). FieldPriorities usa chaves
+// "<entityType>.<campo>" (ex.: "contact.email") com o nome do campo no JSON da API.
+type SyncPolicy struct {
+	ID              string         `json:"id"`
+	WorkspaceID     string         `json:"workspaceId"`
+	System          string         `json:"system"`
+	Strategy        SyncStrategy   `json:"strategy"`
+	Priority        int            `json:"priority"`
+	FieldPriorities map[string]int `json:"fieldPriorities"`
+	CreatedByID     string         `json:"createdById"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
+// PriorityFor prioridade do sistema para um campo. Política nil (sistema sem política) vale 0.
+func (p *SyncPolicy) PriorityFor(entityType IdentityEntityType, field string) int {
+	if p == nil {
+		return 0
+	}
+	if priority, ok := p.FieldPriorities[string(entityType)+"."+field]; ok {
+		return priority
+	}
+	return p.Priority
+}
+
+// StrategyOrDefault estratégia da política; LAST_WRITER_WINS para sistemas sem política.
+func (p *SyncPolicy) StrategyOrDefault() SyncStrategy {
+	if p == nil {
+		return SyncLastWriterWins
+	}
+	return p.Strategy
+}
+
+// PutSyncPolicyRequest DTO de PUT /sync-policies/{system}: cria ou substitui a política.
+type PutSyncPolicyRequest struct {
+	Strategy        SyncStrategy   `json:"strategy" validate:"required,oneof=LAST_WRITER_WINS SOURCE_PRIORITY"`
+	Priority        int            `json:"priority" validate:"gte=0,lte=1000"`
+	FieldPriorities map[string]int `json:"fieldPriorities" validate:"max=100,dive,gte=0,lte=1000"`
+}
+
+// Validate valida o request: chaves de fieldPriorities no formato "<contact|company|deal>.<campo>".
+func (r *PutSyncPolicyRequest) Validate() error {
+	if r.FieldPriorities == nil {
+		r.FieldPriorities = map[string]int{}
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	for key := range r.FieldPriorities {
+		entityType, field, found := strings.Cut(key, ".")
+		switch IdentityEntityType(entityType) {
+		case IdentityEntityContact, IdentityEntityCompany, IdentityEntityDeal:
+		default:
+			found = false
+		}
+		if !found || field == "" || strings.ContainsAny(field, ". ") {
+			return fmt.Errorf("fieldPriorities key %q must be <contact|company|deal>.<field>", key)
+		}
+	}
+	return nil
+}
+
+// SyncPolicyListResponse resposta de GET /sync-policies.
+type SyncPolicyListResponse struct {
+	Data []SyncPolicy `json:"data"`
+}
+
+// SyncConflict um conflito resolvido num upsert: System tentou gravar IncomingValue num campo
+// gravado por CurrentSystem (valor CurrentValue). Valores no formato JSON da API.
+type SyncConflict struct {
+	ID            string             `json:"id"`
+	WorkspaceID   string             `json:"workspaceId"`
+	EntityType    IdentityEntityType `json:"entityType"`
+	EntityID      string             `json:"entityId"`
+	Field         string             `json:"field"`
+	System        string             `json:"system"`
+	CurrentSystem string             `json:"currentSystem"`
+	IncomingValue json.RawMessage    `json:"incomingValue"`
+	CurrentValue  json.RawMessage    `json:"currentValue"`
+	Resolution    SyncResolution     `json:"resolution"`
+	CreatedAt     time.Time          `json:"createdAt"`
+}
+
+// ListSyncConflictsParams parâmetros de GET /sync-conflicts. Cursor é o createdAt do último
+// conflito da página anterior (mais recentes primeiro).
+type ListSyncConflictsParams struct {
+	WorkspaceID string
+	System      *string
+	EntityType  *IdentityEntityType
+	EntityID    *string
+	Cursor      *time.Time
+	Limit       int
+}
+
+// Normalize aplica o limite padrão (50, máx. 200).
+func (p *ListSyncConflictsParams) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = 50
+	}
+	if p.Limit > 200 {
+		p.Limit = 200
+	}
+}
+
+// SyncConflictListResponse resposta de GET /sync-conflicts.
+type SyncConflictListResponse struct {
+	Data []SyncConflict `json:"data"`
+	Meta struct {
+		HasNextPage bool    `json:"hasNextPage"`
+		NextCursor  *string `json:"nextCursor,omitempty"`
+	} `json:"meta"`
+}
+
+// syncIgnoredFields além dos campos fora do histórico: o externalId é a chave do próprio upsert.
+var syncIgnoredFields = map[string]bool{
+	"externalId": true,
+}
+
+// SyncChangedFields campos que o request de upsert altera no registro: presentes e não nulos em
+// incoming, com valor diferente do atual (current nil = registro novo). Ordenados pelo nome.
+func SyncChangedFields(current, incoming any) ([]string, map[string]FieldChange, error) {
+	from := map[string]json.RawMessage{}
+	if current != nil {
+		var err error
+		if from, err = jsonFields(current); err != nil {
+			return nil, nil, err
+		}
+	}
+	to, err := jsonFields(incoming)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	null := json.RawMessage("null")
+	changes := map[string]FieldChange{}
+	fields := []string{}
+	for field, value := range to {
+		if historyIgnoredFields[field] || syncIgnoredFields[field] || bytes.Equal(value, null) {
+			continue
+		}
+		f, ok := from[field]
+		if !ok {
+			f = null
+		}
+		if !bytes.Equal(f, value) {
+			changes[field] = FieldChange{From: f, To: value}
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields, changes, nil
+}
+
+// OmitJSONFields remove de req os campos (nomes do JSON) descartados pela política de conflito:
+// no request de update, campo ausente mantém o valor atual.
+func OmitJSONFields[T any](req *T, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	values, err := jsonFields(req)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		delete(values, field)
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("marshal sync request: %w", err)
+	}
+	var out T
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return fmt.Errorf("decode sync request: %w", err)
+	}
+	*req = out
+	return nil
+}
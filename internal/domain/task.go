@@ -3,6 +3,7 @@ package domain
 import (
 	"database/sql/driver"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -188,6 +189,9 @@ type Task struct {
 	// Kanban positioning (DECIMAL(20,10) para precisão)
 	Position float64 `json:"position" db:"position"`
 
+	// Quem enxerga a tarefa (PRIVATE, TEAM, WORKSPACE)
+	Visibility Visibility `json:"visibility" db:"visibility"`
+
 	// Relacionamentos - IDs são TEXT
 	ActorID    string  `json:"actorId" db:"ownerId"`                 // Owner/creator
 	AssignedTo *string `json:"assignedTo,omitempty" db:"assignedTo"` // Assignee
@@ -220,6 +224,9 @@ type CreateTaskRequest struct {
 	Priority *Priority   `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH URGENT"`
 	Type     *TaskType   `json:"type,omitempty" validate:"omitempty,oneof=CALL EMAIL MEETING FOLLOWUP OTHER"`
 
+	// Visibilidade (padrão: WORKSPACE)
+	Visibility *Visibility `json:"visibility,omitempty" validate:"omitempty,oneof=PRIVATE TEAM WORKSPACE"`
+
 	// Relacionamentos opcionais - IDs são TEXT
 	ActorID    *string `json:"actorId,omitempty"`
 	AssignedTo *string `json:"assignedTo,omitempty"`
@@ -242,6 +249,9 @@ type UpdateTaskRequest struct {
 	Priority *Priority `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH URGENT"`
 	Type     *TaskType `json:"type,omitempty" validate:"omitempty,oneof=CALL EMAIL MEETING FOLLOWUP OTHER"`
 
+	// Visibilidade
+	Visibility *Visibility `json:"visibility,omitempty" validate:"omitempty,oneof=PRIVATE TEAM WORKSPACE"`
+
 	// Relacionamentos - IDs são TEXT
 	AssignedTo *string `json:"assignedTo,omitempty"`
 	ContactID  *string `json:"contactId,omitempty"`
@@ -285,13 +295,17 @@ type ListTasksParams struct {
 	AssignedTo *string
 	ActorID    *string // Owner
 	ContactID  *string
+	FollowerID *string // Tarefas seguidas pelo usuário (?following=true)
+
+	// Visibilidade: nil = sem filtro (admin); senão só tarefas visíveis ao usuário
+	Viewer *string
 
 	// Busca textual (título + descrição)
 	Query *string
 
 	// Paginação
 	Limit  int
-	Cursor *string // RFC3339 timestamp ou ULID
+	Cursor *string // nextCursor da página anterior (opaco)
 	Sort   string  // Padrão: "position:asc" dentro de cada status
 }
 
@@ -324,3 +338,179 @@ type TaskListResponse struct {
 		NextCursor  *string `json:"nextCursor,omitempty"`
 	} `json:"meta"`
 }
+
+// TaskSwimlane define o agrupamento secundário (linhas) do board de tarefas.
+type TaskSwimlane string
+
+const (
+	TaskSwimlaneNone     TaskSwimlane = ""
+	TaskSwimlaneAssignee TaskSwimlane = "assignee"
+	TaskSwimlanePriority TaskSwimlane = "priority"
+)
+
+// IsValid valida se o agrupamento é suportado.
+func (s TaskSwimlane) IsValid() bool {
+	switch s {
+	case TaskSwimlaneNone, TaskSwimlaneAssignee, TaskSwimlanePriority:
+		return true
+	}
+	return false
+}
+
+// BoardStatuses são as colunas do board, sempre presentes e nesta ordem.
+var BoardStatuses = []TaskStatus{TaskStatusTodo, TaskStatusInProgress, TaskStatusDone, TaskStatusCancelled}
+
+// TaskBoardParams parâmetros da projeção Kanban (GET /tasks/board).
+//
+// Reaproveita os filtros de ListTasksParams; Status é ignorado (o board traz todas as colunas)
+// e Limit é o máximo de tarefas por coluna de cada lane.
+type TaskBoardParams struct {
+	ListTasksParams
+	Swimlane TaskSwimlane
+}
+
+// Normalize aplica defaults (Limit por coluna: 20, máximo 100).
+func (p *TaskBoardParams) Normalize() {
+	p.Status = nil
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 20
+	}
+	if p.Query != nil {
+		q := strings.TrimSpace(*p.Query)
+		if q == "" {
+			p.Query = nil
+		} else {
+			p.Query = &q
+		}
+	}
+}
+
+// TaskBoardCount é o total de tarefas de uma célula (lane × status) do board.
+type TaskBoardCount struct {
+	LaneKey *string
+	Status  TaskStatus
+	Count   int
+}
+
+// TaskBoardColumn é uma coluna (status) dentro de uma lane.
+// Count é o total da coluna; Tasks traz no máximo Limit itens em ordem de position.
+type TaskBoardColumn struct {
+	Status  TaskStatus `json:"status"`
+	Count   int        `json:"count"`
+	HasMore bool       `json:"hasMore"`
+	Tasks   []Task     `json:"tasks"`
+}
+
+// TaskBoardLane é uma linha do board. Key é o assignee ou a prioridade da lane;
+// nil agrupa tarefas sem responsável (ou o board inteiro quando não há swimlane).
+type TaskBoardLane struct {
+	Key     *string           `json:"key"`
+	Count   int               `json:"count"`
+	Columns []TaskBoardColumn `json:"columns"`
+}
+
+// TaskBoard resposta de GET /tasks/board.
+type TaskBoard struct {
+	Swimlane *TaskSwimlane   `json:"swimlane"`
+	Total    int             `json:"total"`
+	Lanes    []TaskBoardLane `json:"lanes"`
+}
+
+// laneKey retorna a chave de lane de uma tarefa para o agrupamento informado.
+func (s TaskSwimlane) laneKey(t *Task) *string {
+	switch s {
+	case TaskSwimlaneAssignee:
+		return t.AssignedTo
+	case TaskSwimlanePriority:
+		p := string(t.Priority)
+		return &p
+	}
+	return nil
+}
+
+// priorityLaneOrder ordena as lanes de prioridade da mais urgente para a menos.
+var priorityLaneOrder = map[string]int{
+	string(PriorityUrgent): 0,
+	string(PriorityHigh):   1,
+	string(PriorityMedium): 2,
+	string(PriorityLow):    3,
+}
+
+// NewTaskBoard monta o board a partir das contagens por célula e das tarefas já limitadas por célula.
+// Toda lane traz as quatro colunas de BoardStatuses. Lanes: sem swimlane há uma única lane (key nil);
+// por prioridade, de URGENT a LOW; por assignee, em ordem de ID com "sem responsável" por último.
+func NewTaskBoard(swimlane TaskSwimlane, counts []TaskBoardCount, tasks []Task) *TaskBoard {
+	board := &TaskBoard{Lanes: []TaskBoardLane{}}
+	if swimlane != TaskSwimlaneNone {
+		board.Swimlane = &swimlane
+	}
+
+	laneIndex := make(map[string]int)
+	lane := func(key *string) *TaskBoardLane {
+		k := ""
+		if key != nil {
+			k = "=" + *key // diferencia lane "" de lane nil
+		}
+		idx, ok := laneIndex[k]
+		if !ok {
+			idx = len(board.Lanes)
+			laneIndex[k] = idx
+			columns := make([]TaskBoardColumn, len(BoardStatuses))
+			for i, status := range BoardStatuses {
+				columns[i] = TaskBoardColumn{Status: status, Tasks: []Task{}}
+			}
+			board.Lanes = append(board.Lanes, TaskBoardLane{Key: key, Columns: columns})
+		}
+		return &board.Lanes[idx]
+	}
+	column := func(l *TaskBoardLane, status TaskStatus) *TaskBoardColumn {
+		for i := range l.Columns {
+			if l.Columns[i].Status == status {
+				return &l.Columns[i]
+			}
+		}
+		return nil
+	}
+
+	if swimlane == TaskSwimlaneNone {
+		lane(nil)
+	}
+
+	for _, c := range counts {
+		l := lane(c.LaneKey)
+		col := column(l, c.Status)
+		if col == nil {
+			continue // status fora do board (ex.: legado)
+		}
+		col.Count += c.Count
+		l.Count += c.Count
+		board.Total += c.Count
+	}
+
+	for i := range tasks {
+		l := lane(swimlane.laneKey(&tasks[i]))
+		if col := column(l, tasks[i].Status); col != nil {
+			col.Tasks = append(col.Tasks, tasks[i])
+		}
+	}
+
+	for i := range board.Lanes {
+		for j := range board.Lanes[i].Columns {
+			col := &board.Lanes[i].Columns[j]
+			col.HasMore = col.Count > len(col.Tasks)
+		}
+	}
+
+	sort.SliceStable(board.Lanes, func(i, j int) bool {
+		a, b := board.Lanes[i].Key, board.Lanes[j].Key
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		if swimlane == TaskSwimlanePriority {
+			return priorityLaneOrder[*a] < priorityLaneOrder[*b]
+		}
+		return *a < *b
+	})
+
+	return board
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func laneKeys(board *TaskBoard) []*string {
+	keys := make([]*string, len(board.Lanes))
+	for i, l := range board.Lanes {
+		keys[i] = l.Key
+	}
+	return keys
+}
+
+func TestTaskBoardParams_Normalize(t *testing.T) {
+	status := TaskStatusDone
+	blank := "   "
+	p := TaskBoardParams{ListTasksParams: ListTasksParams{Status: &status, Query: &blank}}
+	p.Normalize()
+	assert.Nil(t, p.Status, "the board always has every column")
+	assert.Nil(t, p.Query)
+	assert.Equal(t, 20, p.Limit)
+
+	q := "  proposta "
+	p = TaskBoardParams{ListTasksParams: ListTasksParams{Limit: 101, Query: &q}}
+	p.Normalize()
+	assert.Equal(t, 20, p.Limit)
+	assert.Equal(t, "proposta", *p.Query)
+
+	p = TaskBoardParams{ListTasksParams: ListTasksParams{Limit: 5}}
+	p.Normalize()
+	assert.Equal(t, 5, p.Limit)
+}
+
+func TestTaskSwimlane_IsValid(t *testing.T) {
+	assert.True(t, TaskSwimlaneNone.IsValid())
+	assert.True(t, TaskSwimlaneAssignee.IsValid())
+	assert.True(t, TaskSwimlanePriority.IsValid())
+	assert.False(t, TaskSwimlane("status").IsValid())
+}
+
+func TestNewTaskBoard_NoSwimlane(t *testing.T) {
+	counts := []TaskBoardCount{
+		{Status: TaskStatusTodo, Count: 3},
+		{Status: TaskStatusDone, Count: 1},
+		{Status: TaskStatusBacklog, Count: 7}, // fora das colunas do board
+	}
+	tasks := []Task{
+		{ID: "tsk_1", Status: TaskStatusTodo},
+		{ID: "tsk_2", Status: TaskStatusTodo},
+		{ID: "tsk_3", Status: TaskStatusDone},
+	}
+
+	board := NewTaskBoard(TaskSwimlaneNone, counts, tasks)
+	assert.Nil(t, board.Swimlane)
+	assert.Equal(t, 4, board.Total)
+	require.Len(t, board.Lanes, 1)
+
+	lane := board.Lanes[0]
+	assert.Nil(t, lane.Key)
+	assert.Equal(t, 4, lane.Count)
+	require.Len(t, lane.Columns, len(BoardStatuses))
+	for i, status := range BoardStatuses {
+		assert.Equal(t, status, lane.Columns[i].Status)
+		assert.NotNil(t, lane.Columns[i].Tasks)
+	}
+
+	todo := lane.Columns[0]
+	assert.Equal(t, 3, todo.Count)
+	assert.Len(t, todo.Tasks, 2)
+	assert.True(t, todo.HasMore)
+	done := lane.Columns[2]
+	assert.False(t, done.HasMore)
+	assert.Equal(t, "tsk_3", done.Tasks[0].ID)
+}
+
+func TestNewTaskBoard_EmptyWithoutSwimlane(t *testing.T) {
+	board := NewTaskBoard(TaskSwimlaneNone, nil, nil)
+	require.Len(t, board.Lanes, 1)
+	assert.Zero(t, board.Total)
+	assert.Len(t, board.Lanes[0].Columns, len(BoardStatuses))
+
+	board = NewTaskBoard(TaskSwimlaneAssignee, nil, nil)
+	require.NotNil(t, board.Swimlane)
+	assert.Empty(t, board.Lanes)
+}
+
+func TestNewTaskBoard_AssigneeLanes(t *testing.T) {
+	counts := []TaskBoardCount{
+		{LaneKey: nil, Status: TaskStatusTodo, Count: 1},
+		{LaneKey: strPtr("usr_b"), Status: TaskStatusInProgress, Count: 1},
+		{LaneKey: strPtr("usr_a"), Status: TaskStatusTodo, Count: 2},
+		{LaneKey: strPtr(""), Status: TaskStatusTodo, Count: 1},
+	}
+	tasks := []Task{
+		{ID: "tsk_1", Status: TaskStatusTodo, AssignedTo: strPtr("usr_a")},
+		{ID: "tsk_2", Status: TaskStatusTodo},
+		{ID: "tsk_3", Status: TaskStatusInProgress, AssignedTo: strPtr("usr_b")},
+	}
+
+	board := NewTaskBoard(TaskSwimlaneAssignee, counts, tasks)
+	assert.Equal(t, 5, board.Total)
+	// Ordem por ID; a lane "" é distinta da lane sem responsável, que vem por último
+	assert.Equal(t, []*string{strPtr(""), strPtr("usr_a"), strPtr("usr_b"), nil}, laneKeys(board))
+
+	usrA := board.Lanes[1]
+	assert.Equal(t, 2, usrA.Count)
+	assert.True(t, usrA.Columns[0].HasMore)
+	assert.Equal(t, "tsk_1", usrA.Columns[0].Tasks[0].ID)
+	assert.Equal(t, "tsk_2", board.Lanes[3].Columns[0].Tasks[0].ID)
+}
+
+func TestNewTaskBoard_PriorityLanes(t *testing.T) {
+	counts := []TaskBoardCount{
+		{LaneKey: strPtr(string(PriorityLow)), Status: TaskStatusTodo, Count: 1},
+		{LaneKey: strPtr(string(PriorityUrgent)), Status: TaskStatusDone, Count: 1},
+		{LaneKey: strPtr(string(PriorityMedium)), Status: TaskStatusTodo, Count: 1},
+	}
+	tasks := []Task{
+		{ID: "tsk_1", Status: TaskStatusTodo, Priority: PriorityLow},
+		{ID: "tsk_2", Status: TaskStatusDone, Priority: PriorityUrgent},
+		{ID: "tsk_3", Status: TaskStatusTodo, Priority: PriorityMedium},
+	}
+
+	board := NewTaskBoard(TaskSwimlanePriority, counts, tasks)
+	assert.Equal(t, []*string{strPtr("URGENT"), strPtr("MEDIUM"), strPtr("LOW")}, laneKeys(board))
+	assert.Equal(t, "tsk_2", board.Lanes[0].Columns[2].Tasks[0].ID)
+}
@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxTimeEntryDuration limita um apontamento: entradas maiores quase sempre são timers esquecidos.
+const MaxTimeEntryDuration = 24 * time.Hour
+
+var (
+	errTimeEntryTarget       = errors.New("taskId or dealId is required")
+	errTimeEntryEndAndLength = errors.New("provide either endedAt or durationSeconds, not both")
+
+	// Erros de intervalo retornados por Stop/Apply (o service traduz para 422).
+	ErrTimeEntryEndBeforeStart = errors.New("endedAt must be after startedAt")
+	ErrTimeEntryTooLong        = errors.New("time entry cannot exceed 24 hours")
+)
+
+// TimeEntry é um apontamento de horas de um usuário em uma tarefa e/ou negócio.
+// EndedAt/DurationSeconds nil = timer em andamento (Running).
+type TimeEntry struct {
+	ID              string     `json:"id"`
+	WorkspaceID     string     `json:"workspaceId"`
+	UserID          string     `json:"userId"`
+	TaskID          *string    `json:"taskId"`
+	DealID          *string    `json:"dealId"`
+	Description     *string    `json:"description"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt"`
+	DurationSeconds *int       `json:"durationSeconds"`
+	Billable        bool       `json:"billable"`
+	Running         bool       `json:"running"` // derivado de EndedAt
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	DeletedAt       *time.Time `json:"deletedAt,omitempty"`
+}
+
+// Stop encerra o intervalo em endedAt e calcula a duração.
+func (e *TimeEntry) Stop(endedAt time.Time) error {
+	if endedAt.Before(e.StartedAt) {
+		return ErrTimeEntryEndBeforeStart
+	}
+	duration := endedAt.Sub(e.StartedAt)
+	if duration > MaxTimeEntryDuration {
+		return ErrTimeEntryTooLong
+	}
+
+	seconds := int(duration.Seconds())
+	e.EndedAt = &endedAt
+	e.DurationSeconds = &seconds
+	e.Running = false
+	return nil
+}
+
+// Apply aplica um PATCH já validado, recalculando a duração quando o intervalo muda.
+// Um timer em andamento continua rodando a menos que endedAt/durationSeconds seja enviado.
+func (e *TimeEntry) Apply(req *UpdateTimeEntryRequest) error {
+	if req.Description != nil {
+		e.Description = req.Description
+	}
+	if req.Billable != nil {
+		e.Billable = *req.Billable
+	}
+	if req.StartedAt != nil {
+		e.StartedAt = req.StartedAt.UTC()
+	}
+
+	switch {
+	case req.DurationSeconds != nil:
+		return e.Stop(e.StartedAt.Add(time.Duration(*req.DurationSeconds) * time.Second))
+	case req.EndedAt != nil:
+		return e.Stop(req.EndedAt.UTC())
+	case e.EndedAt != nil:
+		return e.Stop(*e.EndedAt)
+	}
+	return nil
+}
+
+// TimeEntryListResponse resposta da listagem de apontamentos.
+type TimeEntryListResponse struct {
+	Data []TimeEntry `json:"data"`
+}
+
+// ListTimeEntriesParams parâmetros de GET /time-entries.
+// From/To filtram por startedAt (intervalo semiaberto [from, to)).
+type ListTimeEntriesParams struct {
+	WorkspaceID string
+	UserID      *string
+	TaskID      *string
+	DealID      *string
+	From        *time.Time
+	To          *time.Time
+	Running     *bool
+	Limit       int
+}
+
+// Normalize aplica o limite padrão (50) e máximo (500).
+func (p *ListTimeEntriesParams) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = 50
+	}
+	if p.Limit > 500 {
+		p.Limit = 500
+	}
+}
+
+// CreateTimeEntryRequest DTO para criação de apontamento.
+//
+// Modos:
+// - sem endedAt/durationSeconds: inicia um timer (startedAt default agora)
+// - com durationSeconds: apontamento manual a partir de startedAt
+// - com endedAt: intervalo fechado [startedAt, endedAt]
+type CreateTimeEntryRequest struct {
+	TaskID          *string    `json:"taskId,omitempty"`
+	DealID          *string    `json:"dealId,omitempty"`
+	Description     *string    `json:"description,omitempty" validate:"omitempty,max=1000"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	EndedAt         *time.Time `json:"endedAt,omitempty"`
+	DurationSeconds *int       `json:"durationSeconds,omitempty" validate:"omitempty,min=1,max=86400"`
+	Billable        *bool      `json:"billable,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateTimeEntryRequest) Validate() error {
+	if r.Description != nil {
+		trimmed := strings.TrimSpace(*r.Description)
+		r.Description = &trimmed
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if r.TaskID == nil && r.DealID == nil {
+		return errTimeEntryTarget
+	}
+	if r.EndedAt != nil && r.DurationSeconds != nil {
+		return errTimeEntryEndAndLength
+	}
+	return nil
+}
+
+// NewTimeEntry monta o apontamento a partir do request; now é o default de startedAt.
+func (r *CreateTimeEntryRequest) NewTimeEntry(now time.Time) (*TimeEntry, error) {
+	entry := &TimeEntry{
+		TaskID:      r.TaskID,
+		DealID:      r.DealID,
+		Description: r.Description,
+		StartedAt:   now,
+		Billable:    true,
+		Running:     true,
+	}
+	if r.StartedAt != nil {
+		entry.StartedAt = r.StartedAt.UTC()
+	}
+	if r.Billable != nil {
+		entry.Billable = *r.Billable
+	}
+
+	switch {
+	case r.DurationSeconds != nil:
+		return entry, entry.Stop(entry.StartedAt.Add(time.Duration(*r.DurationSeconds) * time.Second))
+	case r.EndedAt != nil:
+		return entry, entry.Stop(r.EndedAt.UTC())
+	}
+	return entry, nil
+}
+
+// UpdateTimeEntryRequest DTO para atualização parcial (nil = não modificar).
+type UpdateTimeEntryRequest struct {
+	Description     *string    `json:"description,omitempty" validate:"omitempty,max=1000"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	EndedAt         *time.Time `json:"endedAt,omitempty"`
+	DurationSeconds *int       `json:"durationSeconds,omitempty" validate:"omitempty,min=1,max=86400"`
+	Billable        *bool      `json:"billable,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateTimeEntryRequest) Validate() error {
+	if r.Description != nil {
+		trimmed := strings.TrimSpace(*r.Description)
+		r.Description = &trimmed
+	}
+
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if r.EndedAt != nil && r.DurationSeconds != nil {
+		return errTimeEntryEndAndLength
+	}
+	return nil
+}
+
+// TimeReportGroupBy define a dimensão de agregação de /reports/time.
+type TimeReportGroupBy string
+
+const (
+	TimeByUser TimeReportGroupBy = "user"
+	TimeByDeal TimeReportGroupBy = "deal"
+	TimeByTask TimeReportGroupBy = "task"
+)
+
+// IsValid valida se a dimensão é suportada.
+func (g TimeReportGroupBy) IsValid() bool {
+	switch g {
+	case TimeByUser, TimeByDeal, TimeByTask:
+		return true
+	}
+	return false
+}
+
+// TimeReportParams parâmetros de /reports/time.
+// Timers em andamento não entram no relatório.
+type TimeReportParams struct {
+	WorkspaceID string
+	GroupBy     TimeReportGroupBy
+	UserID      *string
+	DealID      *string
+	Billable    *bool
+	From        *time.Time
+	To          *time.Time
+}
+
+// TimeReportRow é o total de uma chave da dimensão.
+// Key nil agrupa apontamentos sem a dimensão (ex.: sem negócio em groupBy=deal).
+type TimeReportRow struct {
+	Key             *string `json:"key"`
+	Entries         int     `json:"entries"`
+	TotalSeconds    int64   `json:"totalSeconds"`
+	BillableSeconds int64   `json:"billableSeconds"`
+}
+
+// TimeReport resposta de /reports/time.
+type TimeReport struct {
+	GroupBy         TimeReportGroupBy `json:"groupBy"`
+	Rows            []TimeReportRow   `json:"rows"`
+	TotalSeconds    int64             `json:"totalSeconds"`
+	BillableSeconds int64             `json:"billableSeconds"`
+}
+
+// NewTimeReport soma os totais gerais a partir das linhas.
+func NewTimeReport(groupBy TimeReportGroupBy, rows []TimeReportRow) *TimeReport {
+	report := &TimeReport{GroupBy: groupBy, Rows: rows}
+	for _, row := range rows {
+		report.TotalSeconds += row.TotalSeconds
+		report.BillableSeconds += row.BillableSeconds
+	}
+	return report
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var timeEntryStart = time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+
+func TestTimeEntry_Stop(t *testing.T) {
+	tests := []struct {
+		name    string
+		end     time.Time
+		want    int
+		wantErr error
+	}{
+		{"closes the interval", timeEntryStart.Add(90 * time.Minute), 5400, nil},
+		{"zero length", timeEntryStart, 0, nil},
+		{"exactly 24 hours", timeEntryStart.Add(MaxTimeEntryDuration), 86400, nil},
+		{"ends before it starts", timeEntryStart.Add(-time.Second), 0, ErrTimeEntryEndBeforeStart},
+		{"longer than 24 hours", timeEntryStart.Add(MaxTimeEntryDuration + time.Second), 0, ErrTimeEntryTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &TimeEntry{StartedAt: timeEntryStart, Running: true}
+			err := entry.Stop(tt.end)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, entry.Running, "a failed stop keeps the timer running")
+				assert.Nil(t, entry.EndedAt)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, entry.Running)
+			assert.Equal(t, tt.end, *entry.EndedAt)
+			assert.Equal(t, tt.want, *entry.DurationSeconds)
+		})
+	}
+}
+
+func TestTimeEntry_Apply(t *testing.T) {
+	seconds := func(n int) *int { return &n }
+	at := func(d time.Duration) *time.Time {
+		v := timeEntryStart.Add(d)
+		return &v
+	}
+	stopped := func() *TimeEntry {
+		e := &TimeEntry{StartedAt: timeEntryStart, Billable: true}
+		require.NoError(t, e.Stop(timeEntryStart.Add(time.Hour)))
+		return e
+	}
+
+	t.Run("new start keeps the end and recomputes the duration", func(t *testing.T) {
+		e := stopped()
+		require.NoError(t, e.Apply(&UpdateTimeEntryRequest{StartedAt: at(30 * time.Minute)}))
+		assert.Equal(t, 1800, *e.DurationSeconds)
+		assert.Equal(t, *at(time.Hour), *e.EndedAt)
+	})
+
+	t.Run("duration moves the end", func(t *testing.T) {
+		e := stopped()
+		require.NoError(t, e.Apply(&UpdateTimeEntryRequest{DurationSeconds: seconds(600)}))
+		assert.Equal(t, *at(10 * time.Minute), *e.EndedAt)
+	})
+
+	t.Run("running timer keeps running", func(t *testing.T) {
+		e := &TimeEntry{StartedAt: timeEntryStart, Running: true}
+		billable := false
+		require.NoError(t, e.Apply(&UpdateTimeEntryRequest{Billable: &billable, StartedAt: at(-time.Hour)}))
+		assert.True(t, e.Running)
+		assert.False(t, e.Billable)
+		assert.Nil(t, e.DurationSeconds)
+	})
+
+	t.Run("start after the end", func(t *testing.T) {
+		e := stopped()
+		assert.ErrorIs(t, e.Apply(&UpdateTimeEntryRequest{StartedAt: at(2 * time.Hour)}), ErrTimeEntryEndBeforeStart)
+	})
+}
+
+func TestCreateTimeEntryRequest_Validate(t *testing.T) {
+	taskID := "tsk_1"
+	end := timeEntryStart.Add(time.Hour)
+	seconds := 60
+	zero := 0
+
+	tests := []struct {
+		name    string
+		req     CreateTimeEntryRequest
+		wantErr bool
+	}{
+		{"timer on a task", CreateTimeEntryRequest{TaskID: &taskID}, false},
+		{"closed interval", CreateTimeEntryRequest{TaskID: &taskID, StartedAt: &timeEntryStart, EndedAt: &end}, false},
+		{"manual duration", CreateTimeEntryRequest{DealID: &taskID, DurationSeconds: &seconds}, false},
+		{"no task or deal", CreateTimeEntryRequest{DurationSeconds: &seconds}, true},
+		{"end and duration", CreateTimeEntryRequest{TaskID: &taskID, EndedAt: &end, DurationSeconds: &seconds}, true},
+		{"zero duration", CreateTimeEntryRequest{TaskID: &taskID, DurationSeconds: &zero}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	end2, dur := timeEntryStart.Add(time.Hour), 60
+	assert.Error(t, (&UpdateTimeEntryRequest{EndedAt: &end2, DurationSeconds: &dur}).Validate())
+}
+
+func TestCreateTimeEntryRequest_NewTimeEntry(t *testing.T) {
+	now := timeEntryStart.Add(5 * time.Hour)
+
+	timer, err := (&CreateTimeEntryRequest{}).NewTimeEntry(now)
+	require.NoError(t, err)
+	assert.True(t, timer.Running)
+	assert.True(t, timer.Billable)
+	assert.Equal(t, now, timer.StartedAt)
+
+	seconds := 900
+	notBillable := false
+	manual, err := (&CreateTimeEntryRequest{StartedAt: &timeEntryStart, DurationSeconds: &seconds, Billable: &notBillable}).NewTimeEntry(now)
+	require.NoError(t, err)
+	assert.False(t, manual.Running)
+	assert.False(t, manual.Billable)
+	assert.Equal(t, timeEntryStart.Add(15*time.Minute), *manual.EndedAt)
+
+	tooLong := timeEntryStart.Add(25 * time.Hour)
+	_, err = (&CreateTimeEntryRequest{StartedAt: &timeEntryStart, EndedAt: &tooLong}).NewTimeEntry(now)
+	assert.ErrorIs(t, err, ErrTimeEntryTooLong)
+}
+
+func TestListTimeEntriesParams_Normalize(t *testing.T) {
+	for _, tt := range []struct{ in, want int }{{0, 50}, {-5, 50}, {10, 10}, {500, 500}, {501, 500}} {
+		p := ListTimeEntriesParams{Limit: tt.in}
+		p.Normalize()
+		assert.Equal(t, tt.want, p.Limit, tt.in)
+	}
+}
+
+func TestNewTimeReport(t *testing.T) {
+	user := "usr_1"
+	report := NewTimeReport(TimeByUser, []TimeReportRow{
+		{Key: &user, Entries: 2, TotalSeconds: 7200, BillableSeconds: 3600},
+		{Key: nil, Entries: 1, TotalSeconds: 600, BillableSeconds: 600},
+	})
+	assert.Equal(t, TimeByUser, report.GroupBy)
+	assert.Equal(t, int64(7800), report.TotalSeconds)
+	assert.Equal(t, int64(4200), report.BillableSeconds)
+
+	assert.True(t, TimeByTask.IsValid())
+	assert.False(t, TimeReportGroupBy("company").IsValid())
+}
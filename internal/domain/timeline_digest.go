@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// TimelineDigestParams parâmetros de GET /timeline/:digest.
+// Since é o cursor devolvido pelo digest anterior (nil = desde o início).
+type TimelineDigestParams struct {
+	WorkspaceID string
+	Since       *time.Time
+	ContactID   *string
+	CompanyID   *string
+	DealID      *string
+	PerType     int
+
+	// Visibilidade: nil = sem filtro (admin); senão oculta notas que o usuário não enxerga
+	Viewer *string
+}
+
+// Normalize aplica o limite padrão (3) e máximo (20) de itens por tipo.
+func (p *TimelineDigestParams) Normalize() {
+	if p.PerType <= 0 {
+		p.PerType = 3
+	}
+	if p.PerType > 20 {
+		p.PerType = 20
+	}
+}
+
+// TimelineDigestCount é a contagem de um tipo de atividade desde o cursor.
+type TimelineDigestCount struct {
+	Type     ActivityType
+	Count    int64
+	LatestAt time.Time
+}
+
+// TimelineDigestItem é uma atividade resumida (sem metadata) para o digest.
+type TimelineDigestItem struct {
+	ID         string       `json:"id"`
+	Type       ActivityType `json:"activityType"`
+	ActivityID *string      `json:"activityId"`
+	ContactID  *string      `json:"contactId"`
+	CompanyID  *string      `json:"companyId"`
+	DealID     *string      `json:"dealId"`
+	UserID     string       `json:"userId"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+// TimelineDigestGroup agrupa um tipo de atividade: total desde o cursor e as mais recentes.
+type TimelineDigestGroup struct {
+	Type     ActivityType         `json:"activityType"`
+	Count    int64                `json:"count"`
+	LatestAt time.Time            `json:"latestAt"`
+	Items    []TimelineDigestItem `json:"items"`
+}
+
+// TimelineDigest resposta de GET /timeline/:digest.
+// NextCursor é enviado como cursor no próximo refresh; nil quando a timeline está vazia.
+type TimelineDigest struct {
+	Total      int64                 `json:"total"`
+	Groups     []TimelineDigestGroup `json:"groups"`
+	NextCursor *string               `json:"nextCursor"`
+}
+
+// NewTimelineDigest monta o digest a partir das contagens e dos itens (ordenados por tipo).
+// Sem atividades novas, o cursor recebido é devolvido inalterado.
+func NewTimelineDigest(since *time.Time, counts []TimelineDigestCount, items []TimelineDigestItem) *TimelineDigest {
+	digest := &TimelineDigest{Groups: make([]TimelineDigestGroup, 0, len(counts))}
+
+	byType := make(map[ActivityType][]TimelineDigestItem, len(counts))
+	for _, item := range items {
+		byType[item.Type] = append(byType[item.Type], item)
+	}
+
+	cursor := since
+	for _, c := range counts {
+		group := TimelineDigestGroup{Type: c.Type, Count: c.Count, LatestAt: c.LatestAt, Items: byType[c.Type]}
+		if group.Items == nil {
+			group.Items = []TimelineDigestItem{}
+		}
+		digest.Groups = append(digest.Groups, group)
+		digest.Total += c.Count
+
+		if cursor == nil || c.LatestAt.After(*cursor) {
+			latest := c.LatestAt
+			cursor = &latest
+		}
+	}
+
+	if cursor != nil {
+		s := cursor.UTC().Format(time.RFC3339Nano)
+		digest.NextCursor = &s
+	}
+	return digest
+}
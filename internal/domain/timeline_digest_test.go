@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineDigestParams_Normalize(t *testing.T) {
+	tests := []struct {
+		perType int
+		want    int
+	}{
+		{0, 3},
+		{-1, 3},
+		{5, 5},
+		{20, 20},
+		{21, 20},
+	}
+
+	for _, tt := range tests {
+		p := TimelineDigestParams{PerType: tt.perType}
+		p.Normalize()
+		assert.Equal(t, tt.want, p.PerType, "perType=%d", tt.perType)
+	}
+}
+
+func TestNewTimelineDigest(t *testing.T) {
+	since := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	latestEmail := since.Add(2 * time.Hour)
+	latestCall := since.Add(30 * time.Minute)
+
+	counts := []TimelineDigestCount{
+		{Type: ActivityTypeEmail, Count: 4, LatestAt: latestEmail},
+		{Type: ActivityTypeCall, Count: 1, LatestAt: latestCall},
+		{Type: ActivityTypeMeeting, Count: 2, LatestAt: since.Add(time.Hour)},
+	}
+	items := []TimelineDigestItem{
+		{ID: "act_1", Type: ActivityTypeEmail, CreatedAt: latestEmail},
+		{ID: "act_2", Type: ActivityTypeEmail, CreatedAt: since.Add(time.Hour)},
+		{ID: "act_3", Type: ActivityTypeCall, CreatedAt: latestCall},
+	}
+
+	digest := NewTimelineDigest(&since, counts, items)
+	assert.Equal(t, int64(7), digest.Total)
+	require.Len(t, digest.Groups, 3)
+
+	assert.Equal(t, ActivityTypeEmail, digest.Groups[0].Type)
+	assert.Equal(t, int64(4), digest.Groups[0].Count)
+	assert.Equal(t, []string{"act_1", "act_2"}, []string{digest.Groups[0].Items[0].ID, digest.Groups[0].Items[1].ID})
+	assert.Len(t, digest.Groups[1].Items, 1)
+	// Contagem sem itens (ex.: todos ocultos) ainda serializa como lista vazia
+	assert.NotNil(t, digest.Groups[2].Items)
+	assert.Empty(t, digest.Groups[2].Items)
+
+	require.NotNil(t, digest.NextCursor)
+	assert.Equal(t, latestEmail.Format(time.RFC3339Nano), *digest.NextCursor)
+}
+
+func TestNewTimelineDigest_NoNewActivity(t *testing.T) {
+	digest := NewTimelineDigest(nil, nil, nil)
+	assert.Zero(t, digest.Total)
+	assert.NotNil(t, digest.Groups)
+	assert.Nil(t, digest.NextCursor)
+
+	// Sem novidades, o cursor recebido volta inalterado (em UTC)
+	since := time.Date(2026, 5, 1, 6, 0, 0, 0, time.FixedZone("BRT", -3*3600))
+	digest = NewTimelineDigest(&since, nil, nil)
+	require.NotNil(t, digest.NextCursor)
+	assert.Equal(t, "2026-05-01T09:00:00Z", *digest.NextCursor)
+}
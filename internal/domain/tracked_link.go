@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SourceTrackedLink origem registrada nos cliques de links rastreados
+const SourceTrackedLink = "link"
+
+// TrackedLink link curto rastreado (propostas, campanhas). Cada clique em /l/{code} é registrado
+// e, quando o contato é conhecido (contactId do link ou contact token), vira LINK_CLICK na timeline.
+type TrackedLink struct {
+	ID             string     `json:"id"`
+	WorkspaceID    string     `json:"workspaceId"`
+	Code           string     `json:"code"`
+	Name           string     `json:"name"`
+	DestinationURL string     `json:"destinationUrl"`
+	UTMSource      *string    `json:"utmSource"`
+	UTMMedium      *string    `json:"utmMedium"`
+	UTMCampaign    *string    `json:"utmCampaign"`
+	UTMTerm        *string    `json:"utmTerm"`
+	UTMContent     *string    `json:"utmContent"`
+	ContactID      *string    `json:"contactId"`
+	DealID         *string    `json:"dealId"`
+	ClickCount     int64      `json:"clickCount"`
+	LastClickedAt  *time.Time `json:"lastClickedAt"`
+	ShortURL       string     `json:"shortUrl"`
+	CreatedByID    string     `json:"createdById"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+
+	// TokenSecret chave HMAC dos contact tokens deste link (nunca exposta)
+	TokenSecret []byte `json:"-"`
+}
+
+// TrackedLinkListResponse resposta da listagem (sem paginação).
+type TrackedLinkListResponse struct {
+	Data []TrackedLink `json:"data"`
+}
+
+// CreateTrackedLinkRequest POST /v1/workspaces/{workspaceId}/links.
+// contactId/dealId ligam o link a um registro (ex.: proposta enviada a um contato).
+type CreateTrackedLinkRequest struct {
+	Name           string  `json:"name" validate:"required,min=1,max=255"`
+	DestinationURL string  `json:"destinationUrl" validate:"required,max=2048"`
+	UTMSource      *string `json:"utmSource,omitempty" validate:"omitempty,max=255"`
+	UTMMedium      *string `json:"utmMedium,omitempty" validate:"omitempty,max=255"`
+	UTMCampaign    *string `json:"utmCampaign,omitempty" validate:"omitempty,max=255"`
+	UTMTerm        *string `json:"utmTerm,omitempty" validate:"omitempty,max=255"`
+	UTMContent     *string `json:"utmContent,omitempty" validate:"omitempty,max=255"`
+	ContactID      *string `json:"contactId,omitempty" validate:"omitempty,id"`
+	DealID         *string `json:"dealId,omitempty" validate:"omitempty,id"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *CreateTrackedLinkRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.DestinationURL = strings.TrimSpace(r.DestinationURL)
+	for _, s := range []**string{&r.UTMSource, &r.UTMMedium, &r.UTMCampaign, &r.UTMTerm, &r.UTMContent} {
+		*s = emptyToNil(trimOptional(*s))
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateDestinationURL(r.DestinationURL)
+}
+
+// UpdateTrackedLinkRequest PATCH /v1/workspaces/{workspaceId}/links/{linkId}.
+// O code (e a URL curta já distribuída) não muda. "" limpa os parâmetros UTM, contactId e dealId.
+type UpdateTrackedLinkRequest struct {
+	Name           *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	DestinationURL *string `json:"destinationUrl,omitempty" validate:"omitempty,max=2048"`
+	UTMSource      *string `json:"utmSource,omitempty" validate:"omitempty,max=255"`
+	UTMMedium      *string `json:"utmMedium,omitempty" validate:"omitempty,max=255"`
+	UTMCampaign    *string `json:"utmCampaign,omitempty" validate:"omitempty,max=255"`
+	UTMTerm        *string `json:"utmTerm,omitempty" validate:"omitempty,max=255"`
+	UTMContent     *string `json:"utmContent,omitempty" validate:"omitempty,max=255"`
+	ContactID      *string `json:"contactId,omitempty"`
+	DealID         *string `json:"dealId,omitempty"`
+}
+
+// Validate sanitiza e valida o request.
+func (r *UpdateTrackedLinkRequest) Validate() error {
+	r.Name = trimOptional(r.Name)
+	r.DestinationURL = trimOptional(r.DestinationURL)
+	// "" é preservado: no PATCH ele limpa o valor (ver Apply)
+	for _, s := range []**string{&r.UTMSource, &r.UTMMedium, &r.UTMCampaign, &r.UTMTerm, &r.UTMContent, &r.ContactID, &r.DealID} {
+		if *s != nil {
+			trimmed := strings.TrimSpace(**s)
+			*s = &trimmed
+		}
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if r.DestinationURL != nil {
+		return validateDestinationURL(*r.DestinationURL)
+	}
+	return nil
+}
+
+// Apply mescla o PATCH no link.
+func (r *UpdateTrackedLinkRequest) Apply(l *TrackedLink) {
+	if r.Name != nil {
+		l.Name = *r.Name
+	}
+	if r.DestinationURL != nil {
+		l.DestinationURL = *r.DestinationURL
+	}
+	for _, p := range []struct{ src, dst **string }{
+		{&r.UTMSource, &l.UTMSource},
+		{&r.UTMMedium, &l.UTMMedium},
+		{&r.UTMCampaign, &l.UTMCampaign},
+		{&r.UTMTerm, &l.UTMTerm},
+		{&r.UTMContent, &l.UTMContent},
+		{&r.ContactID, &l.ContactID},
+		{&r.DealID, &l.DealID},
+	} {
+		if *p.src != nil {
+			*p.dst = emptyToNil(*p.src)
+		}
+	}
+}
+
+// RedirectURL destino com os parâmetros UTM do link (valores já presentes na URL prevalecem).
+func (l *TrackedLink) RedirectURL() string {
+	u, err := url.Parse(l.DestinationURL)
+	if err != nil {
+		return l.DestinationURL
+	}
+	q := u.Query()
+	for key, value := range l.utmParams() {
+		if q.Get(key) == "" {
+			q.Set(key, value)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// Attribution atribuição do clique (source=link, sourceDetail=code e os UTMs do link).
+func (l *TrackedLink) Attribution() Attribution {
+	source := SourceTrackedLink
+	code := l.Code
+	return Attribution{
+		Source:       &source,
+		SourceDetail: &code,
+		UTMSource:    l.UTMSource,
+		UTMMedium:    l.UTMMedium,
+		UTMCampaign:  l.UTMCampaign,
+		UTMTerm:      l.UTMTerm,
+		UTMContent:   l.UTMContent,
+	}
+}
+
+func (l *TrackedLink) utmParams() map[string]string {
+	params := make(map[string]string, 5)
+	for key, value := range map[string]*string{
+		"utm_source":   l.UTMSource,
+		"utm_medium":   l.UTMMedium,
+		"utm_campaign": l.UTMCampaign,
+		"utm_term":     l.UTMTerm,
+		"utm_content":  l.UTMContent,
+	} {
+		if value != nil {
+			params[key] = *value
+		}
+	}
+	return params
+}
+
+// TrackedLinkClick clique registrado em /l/{code}. ContactID vem do link ou do contact token.
+type TrackedLinkClick struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspaceId"`
+	LinkID      string    `json:"linkId"`
+	ContactID   *string   `json:"contactId"`
+	Referrer    *string   `json:"referrer"`
+	UserAgent   *string   `json:"userAgent"`
+	ClickedAt   time.Time `json:"clickedAt"`
+}
+
+// ListTrackedLinkClicksParams parâmetros de GET /links/{linkId}/clicks.
+// Cursor é o clickedAt do último clique da página anterior (mais recentes primeiro).
+type ListTrackedLinkClicksParams struct {
+	WorkspaceID string
+	LinkID      string
+	ContactID   *string
+	Cursor      *time.Time
+	Limit       int
+}
+
+// Normalize aplica o limite padrão (50, máx. 200).
+func (p *ListTrackedLinkClicksParams) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = 50
+	}
+	if p.Limit > 200 {
+		p.Limit = 200
+	}
+}
+
+// TrackedLinkClickListResponse resposta da listagem de cliques.
+type TrackedLinkClickListResponse struct {
+	Data []TrackedLinkClick `json:"data"`
+	Meta struct {
+		HasNextPage bool    `json:"hasNextPage"`
+		NextCursor  *string `json:"nextCursor,omitempty"`
+	} `json:"meta"`
+}
+
+// TrackedLinkContactURL URL curta personalizada para um contato (GET /links/{linkId}/contact-url).
+type TrackedLinkContactURL struct {
+	LinkID    string `json:"linkId"`
+	ContactID string `json:"contactId"`
+	Token     string `json:"token"`
+	ShortURL  string `json:"shortUrl"`
+}
+
+// validateDestinationURL exige URL absoluta http(s)
+func validateDestinationURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("destinationUrl must be an absolute http(s) URL")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTrackedLinkRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateTrackedLinkRequest
+		wantErr bool
+	}{
+		{"valid", CreateTrackedLinkRequest{Name: "Proposta", DestinationURL: "https://example.com/proposta"}, false},
+		{"http destination", CreateTrackedLinkRequest{Name: "Proposta", DestinationURL: " http://example.com "}, false},
+		{"relative destination", CreateTrackedLinkRequest{Name: "Proposta", DestinationURL: "/proposta"}, true},
+		{"javascript destination", CreateTrackedLinkRequest{Name: "Proposta", DestinationURL: "javascript:alert(1)"}, true},
+		{"missing name", CreateTrackedLinkRequest{Name: "  ", DestinationURL: "https://example.com"}, true},
+		{"invalid contact id", CreateTrackedLinkRequest{Name: "Proposta", DestinationURL: "https://example.com", ContactID: strPtr("ctc 1")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	req := CreateTrackedLinkRequest{Name: "Proposta", DestinationURL: "https://example.com", UTMSource: strPtr("  "), UTMMedium: strPtr(" email ")}
+	require.NoError(t, req.Validate())
+	assert.Nil(t, req.UTMSource, "blank UTM values are dropped")
+	assert.Equal(t, "email", *req.UTMMedium)
+}
+
+func TestUpdateTrackedLinkRequest_Apply(t *testing.T) {
+	link := &TrackedLink{
+		Name:           "Proposta",
+		DestinationURL: "https://example.com/v1",
+		UTMSource:      strPtr("newsletter"),
+		UTMCampaign:    strPtr("março"),
+		ContactID:      strPtr("ctc_1"),
+	}
+	req := UpdateTrackedLinkRequest{
+		DestinationURL: strPtr(" https://example.com/v2 "),
+		UTMSource:      strPtr(""),
+		DealID:         strPtr("deal_1"),
+	}
+	require.NoError(t, req.Validate())
+	req.Apply(link)
+
+	assert.Equal(t, "Proposta", link.Name)
+	assert.Equal(t, "https://example.com/v2", link.DestinationURL)
+	assert.Nil(t, link.UTMSource, `"" clears the value`)
+	assert.Equal(t, "março", *link.UTMCampaign)
+	assert.Equal(t, "ctc_1", *link.ContactID)
+	assert.Equal(t, "deal_1", *link.DealID)
+
+	assert.Error(t, (&UpdateTrackedLinkRequest{DestinationURL: strPtr("ftp://example.com")}).Validate())
+}
+
+func TestTrackedLink_RedirectURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link TrackedLink
+		want url.Values
+	}{
+		{"no UTM", TrackedLink{DestinationURL: "https://example.com/p"}, url.Values{}},
+		{"adds UTM", TrackedLink{DestinationURL: "https://example.com/p?ref=crm", UTMSource: strPtr("crm"), UTMCampaign: strPtr("q1")},
+			url.Values{"ref": {"crm"}, "utm_source": {"crm"}, "utm_campaign": {"q1"}}},
+		{"destination values win", TrackedLink{DestinationURL: "https://example.com/p?utm_source=site", UTMSource: strPtr("crm"), UTMMedium: strPtr("email")},
+			url.Values{"utm_source": {"site"}, "utm_medium": {"email"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.link.RedirectURL())
+			require.NoError(t, err)
+			assert.Equal(t, "example.com", u.Host)
+			assert.Equal(t, "/p", u.Path)
+			assert.Equal(t, tt.want, u.Query())
+		})
+	}
+}
+
+func TestTrackedLink_Attribution(t *testing.T) {
+	link := TrackedLink{Code: "abc123defg", UTMSource: strPtr("crm")}
+	attribution := link.Attribution()
+	assert.Equal(t, SourceTrackedLink, *attribution.Source)
+	assert.Equal(t, "abc123defg", *attribution.SourceDetail)
+	assert.Equal(t, "crm", *attribution.UTMSource)
+	assert.Nil(t, attribution.UTMMedium)
+}
+
+func TestListTrackedLinkClicksParams_Normalize(t *testing.T) {
+	for _, tt := range []struct{ in, want int }{{0, 50}, {20, 20}, {201, 200}} {
+		p := ListTrackedLinkClicksParams{Limit: tt.in}
+		p.Normalize()
+		assert.Equal(t, tt.want, p.Limit, tt.in)
+	}
+}
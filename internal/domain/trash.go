@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// TrashEntityType entidades com soft delete que aparecem na lixeira do workspace.
+type TrashEntityType string
+
+const (
+	TrashEntityContact  TrashEntityType = "contact"
+	TrashEntityCompany  TrashEntityType = "company"
+	TrashEntityDeal     TrashEntityType = "deal"
+	TrashEntityTask     TrashEntityType = "task"
+	TrashEntityPipeline TrashEntityType = "pipeline"
+)
+
+// IsValid checks if the entity type is one of the supported values
+func (t TrashEntityType) IsValid() bool {
+	switch t {
+	case TrashEntityContact, TrashEntityCompany, TrashEntityDeal, TrashEntityTask, TrashEntityPipeline:
+		return true
+	}
+	return false
+}
+
+// TrashItem registro excluído ainda recuperável. Após PurgeAt (deletedAt + TRASH_RETENTION_DAYS)
+// o `linkko-api cleanup` remove o registro definitivamente.
+type TrashItem struct {
+	EntityType      TrashEntityType `json:"entityType"`
+	EntityID        string          `json:"entityId"`
+	Name            string          `json:"name"`
+	DeletedAt       time.Time       `json:"deletedAt"`
+	DeletedByID     *string         `json:"deletedById"` // nil em exclusões anteriores à lixeira
+	PurgeAt         time.Time       `json:"purgeAt"`
+	PurgesInSeconds int64           `json:"purgesInSeconds"`
+}
+
+// ListTrashParams parâmetros de GET /trash (mais recentes primeiro).
+type ListTrashParams struct {
+	WorkspaceID  string
+	EntityType   *TrashEntityType
+	DeletedAfter time.Time  // início da janela de retenção: registros mais antigos já são purgáveis
+	Cursor       *time.Time // deletedAt do último item da página anterior
+	Limit        int
+	Viewer       *string // filtro de visibilidade das tarefas (nil = admin, sem filtro)
+}
+
+// TrashListResponse resposta paginada da lixeira.
+type TrashListResponse struct {
+	Data []TrashItem `json:"data"`
+	Meta struct {
+		HasNextPage bool    `json:"hasNextPage"`
+		NextCursor  *string `json:"nextCursor,omitempty"`
+	} `json:"meta"`
+}
+
+// TrashRestoreResult resposta de POST /trash/{entityType}/{entityId}/:restore com o registro restaurado.
+type TrashRestoreResult struct {
+	EntityType TrashEntityType `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	Data       any             `json:"data"`
+}
+
+// TrashPurgeResult registros removidos definitivamente por tipo (cleanup).
+type TrashPurgeResult map[TrashEntityType]int64
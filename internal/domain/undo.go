@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// UndoEntityType entidades cujo DELETE (soft delete) pode ser desfeito.
+type UndoEntityType string
+
+const (
+	UndoEntityContact UndoEntityType = "contact"
+	UndoEntityCompany UndoEntityType = "company"
+	UndoEntityTask    UndoEntityType = "task"
+)
+
+// UndoToken registro persistido de um token de desfazer (só o hash do token).
+type UndoToken struct {
+	TokenHash   string
+	WorkspaceID string
+	EntityType  UndoEntityType
+	EntityID    string
+	ActorID     string
+	ExpiresAt   time.Time
+}
+
+// UndoReceipt corpo da resposta de DELETE: o token vale até UndoExpiresAt e é de uso único.
+type UndoReceipt struct {
+	UndoToken     string    `json:"undoToken"`
+	UndoExpiresAt time.Time `json:"undoExpiresAt"`
+}
+
+// UndoRequest DTO de POST /:undo.
+type UndoRequest struct {
+	UndoToken string `json:"undoToken" validate:"required,max=128"`
+}
+
+// Validate valida o UndoRequest.
+func (r *UndoRequest) Validate() error {
+	r.UndoToken = strings.TrimSpace(r.UndoToken)
+	return validate.Struct(r)
+}
+
+// UndoResult resposta de POST /:undo com o registro restaurado em Data.
+type UndoResult struct {
+	EntityType UndoEntityType `json:"entityType"`
+	EntityID   string         `json:"entityId"`
+	Data       any            `json:"data"`
+}
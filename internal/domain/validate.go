@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate é compartilhado pelos requests: o validator faz cache das structs
+// e é seguro para uso concorrente. Erros usam o nome JSON do campo
+// (fullName em vez de FullName) para que possam ser devolvidos ao cliente.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	// id: mesmo formato aceito nos paths (ver IsValidID); combine com omitempty em campos opcionais
+	v.RegisterValidation("id", func(fl validator.FieldLevel) bool {
+		return IsValidID(fl.Field().String())
+	})
+	return v
+}
@@ -0,0 +1,35 @@
+package domain
+
+// Visibility quem enxerga uma nota ou tarefa dentro do workspace.
+// Schema: "Note"."visibility" / "Task".visibility ('PRIVATE', 'TEAM', 'WORKSPACE')
+//
+// - PRIVATE: só o autor (nota) ou o dono/responsável (tarefa)
+// - TEAM: também quem trabalha no registro - donos, responsáveis e seguidores
+// - WORKSPACE: todos os membros (padrão)
+//
+// Admins enxergam tudo, independente da visibilidade.
+type Visibility string
+
+const (
+	VisibilityPrivate   Visibility = "PRIVATE"
+	VisibilityTeam      Visibility = "TEAM"
+	VisibilityWorkspace Visibility = "WORKSPACE"
+)
+
+// IsValid checks if the visibility is one of the supported values
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPrivate, VisibilityTeam, VisibilityWorkspace:
+		return true
+	}
+	return false
+}
+
+// VisibilityViewer retorna o usuário cujo acesso filtra as queries de notas e tarefas.
+// Nil = sem filtro (admins enxergam registros privados de todo o workspace).
+func VisibilityViewer(actorID string, role Role) *string {
+	if role == RoleAdmin {
+		return nil
+	}
+	return &actorID
+}
@@ -40,6 +40,19 @@ func (r Role) IsValid() bool {
 	}
 }
 
+// =====================================================
+// Workspace Status
+// =====================================================
+
+// WorkspaceStatus situação do workspace, checada pelo WorkspaceStatusMiddleware
+type WorkspaceStatus string
+
+const (
+	WorkspaceStatusActive WorkspaceStatus = "ACTIVE"
+	// WorkspaceStatusSuspended todas as rotas do workspace respondem 423 WORKSPACE_SUSPENDED
+	WorkspaceStatusSuspended WorkspaceStatus = "SUSPENDED"
+)
+
 // =====================================================
 // Workspace Role Entity (DB Model)
 // =====================================================
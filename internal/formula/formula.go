@@ -0,0 +1,584 @@
+// Package formula implementa a mini-linguagem dos campos calculados (computed fields).
+//
+// Uma fórmula é uma expressão numérica ou de data sobre os campos da entidade e agregações
+// das coleções relacionadas:
+//
+//	sum(deals.value, stage = "OPEN")
+//	days_since(max(activities.createdAt))
+//	count(deals, stage = "WON") / count(deals) * 100
+//	coalesce(revenue, 0) - sum(deals.value, stage = "WON" and closedAt >= "2026-01-01")
+//
+// Gramática:
+//
+//	expr      = term { ("+" | "-") term }
+//	term      = unary { ("*" | "/") unary }
+//	unary     = "-" unary | primary
+//	primary   = number | field | call | "(" expr ")"
+//	call      = ident "(" [ args ] ")"
+//	aggregate = ("sum" | "avg" | "min" | "max") "(" collection "." field [ "," cond ] ")"
+//	          | "count" "(" collection [ "," cond ] ")"
+//	cond      = andCond { "or" andCond }
+//	andCond   = compare { "and" compare }
+//	compare   = field op literal | "(" cond ")"
+//
+// Parse só valida a sintaxe e os tipos contra um Schema; a tradução para SQL fica no repo.
+package formula
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxLength tamanho máximo do texto de uma fórmula.
+const MaxLength = 1000
+
+// maxDepth limita o aninhamento (parênteses e chamadas) para manter o SQL gerado pequeno.
+const maxDepth = 16
+
+// Type tipo de um valor da linguagem.
+type Type string
+
+const (
+	Number Type = "number"
+	Date   Type = "date"
+	String Type = "string" // só em literais e campos de condições
+)
+
+// Collection coleção relacionada e seus campos.
+type Collection struct {
+	Fields map[string]Type
+}
+
+// Schema campos da entidade e coleções relacionadas que uma fórmula pode referenciar.
+type Schema struct {
+	Fields      map[string]Type
+	Collections map[string]Collection
+}
+
+// Expr nó de expressão tipado.
+type Expr interface {
+	Type() Type
+}
+
+// NumberLit literal numérico.
+type NumberLit struct{ Value float64 }
+
+// FieldRef campo da própria entidade.
+type FieldRef struct {
+	Name string
+	typ  Type
+}
+
+// Binary operação aritmética (+ - * /); divisão por zero resulta em null.
+type Binary struct {
+	Op          byte
+	Left, Right Expr
+}
+
+// Neg negação aritmética.
+type Neg struct{ X Expr }
+
+// Call função escalar: days_since, days_until, coalesce, round, abs.
+type Call struct {
+	Func string
+	Args []Expr
+	typ  Type
+}
+
+// Aggregate agregação sobre uma coleção. Field é vazio em count; Where é opcional.
+// sum e count de uma coleção vazia valem 0; avg, min e max valem null.
+type Aggregate struct {
+	Func       string
+	Collection string
+	Field      string
+	Where      Cond
+	typ        Type
+}
+
+func (NumberLit) Type() Type   { return Number }
+func (f FieldRef) Type() Type  { return f.typ }
+func (Binary) Type() Type      { return Number }
+func (Neg) Type() Type         { return Number }
+func (c Call) Type() Type      { return c.typ }
+func (a Aggregate) Type() Type { return a.typ }
+
+// Cond condição de filtro de uma agregação.
+type Cond interface {
+	cond()
+}
+
+// Compare compara um campo da coleção com um literal. Value é float64, string ou time.Time
+// conforme o tipo do campo.
+type Compare struct {
+	Field string
+	Op    string
+	Value any
+}
+
+// Logical combina condições com and/or.
+type Logical struct {
+	Op          string
+	Left, Right Cond
+}
+
+func (Compare) cond() {}
+func (Logical) cond() {}
+
+// Error erro de sintaxe ou de tipo, com a posição (base 1) no texto da fórmula.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("formula: %s at position %d", e.Msg, e.Pos)
+}
+
+// Parse analisa e tipa a fórmula. O resultado deve ser number ou date.
+func Parse(src string, schema Schema) (Expr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, &Error{Pos: 1, Msg: "empty formula"}
+	}
+	if len(src) > MaxLength {
+		return nil, &Error{Pos: MaxLength, Msg: fmt.Sprintf("formula exceeds %d characters", MaxLength)}
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, schema: schema}
+
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	if expr.Type() == String {
+		return nil, &Error{Pos: 1, Msg: "formula must evaluate to a number or a date"}
+	}
+	return expr, nil
+}
+
+// ============================================================================
+// Lexer
+// ============================================================================
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp // + - * / ( ) , .
+	tokCompare
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), pos: pos})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: pos})
+		case r == '"':
+			i++
+			start := i
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, &Error{Pos: pos, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokString, text: string(runes[start:i]), pos: pos})
+			i++
+		case strings.ContainsRune("+-*/(),.", r):
+			tokens = append(tokens, token{kind: tokOp, text: string(r), pos: pos})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, &Error{Pos: pos, Msg: `unexpected "!"`}
+			}
+			if op == "==" {
+				return nil, &Error{Pos: pos, Msg: `unexpected "==" (use "=" to compare)`}
+			}
+			tokens = append(tokens, token{kind: tokCompare, text: op, pos: pos})
+			i += len(op)
+		default:
+			return nil, &Error{Pos: pos, Msg: fmt.Sprintf("unexpected %q", string(r))}
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes) + 1}), nil
+}
+
+// ============================================================================
+// Parser
+// ============================================================================
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+	schema Schema
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	if tok.kind == tokEOF {
+		return &Error{Pos: tok.pos, Msg: "unexpected end of formula"}
+	}
+	return &Error{Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isOp(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokOp && tok.text == text
+}
+
+func (p *parser) expectOp(text string) error {
+	tok := p.next()
+	if tok.kind != tokOp || tok.text != text {
+		return p.errorf(tok, "expected %q", text)
+	}
+	return nil
+}
+
+func (p *parser) enter(tok token) error {
+	p.depth++
+	if p.depth > maxDepth {
+		return &Error{Pos: tok.pos, Msg: "formula is nested too deeply"}
+	}
+	return nil
+}
+
+func (p *parser) parseExpr() (Expr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = arithmetic(op, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseTerm() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*") || p.isOp("/") {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = arithmetic(op, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func arithmetic(op token, left, right Expr) (Expr, error) {
+	if left.Type() != Number || right.Type() != Number {
+		return nil, &Error{Pos: op.pos, Msg: fmt.Sprintf("%q requires numbers (use days_since/days_until for dates)", op.text)}
+	}
+	return Binary{Op: op.text[0], Left: left, Right: right}, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.isOp("-") {
+		op := p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if x.Type() != Number {
+			return nil, &Error{Pos: op.pos, Msg: `"-" requires a number`}
+		}
+		return Neg{X: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid number %q", tok.text)
+		}
+		return NumberLit{Value: v}, nil
+	case tokIdent:
+		if p.isOp("(") {
+			return p.parseCall(tok)
+		}
+		typ, ok := p.schema.Fields[tok.text]
+		if !ok {
+			if _, isCollection := p.schema.Collections[tok.text]; isCollection {
+				return nil, p.errorf(tok, "collection %q must be used inside an aggregate", tok.text)
+			}
+			return nil, p.errorf(tok, "unknown field %q", tok.text)
+		}
+		return FieldRef{Name: tok.text, typ: typ}, nil
+	case tokOp:
+		if tok.text == "(" {
+			if err := p.enter(tok); err != nil {
+				return nil, err
+			}
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			p.depth--
+			return expr, p.expectOp(")")
+		}
+	}
+	return nil, p.errorf(tok, "unexpected %q", tok.text)
+}
+
+func (p *parser) parseCall(name token) (Expr, error) {
+	if err := p.enter(name); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	switch name.text {
+	case "sum", "avg", "min", "max", "count":
+		return p.parseAggregate(name)
+	}
+
+	if err := p.expectOp("("); err != nil {
+		return nil, err
+	}
+	var args []Expr
+	if !p.isOp(")") {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if !p.isOp(",") {
+				break
+			}
+			p.next()
+		}
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+
+	argErr := func(want string) error {
+		return &Error{Pos: name.pos, Msg: fmt.Sprintf("%s expects %s", name.text, want)}
+	}
+	switch name.text {
+	case "days_since", "days_until":
+		if len(args) != 1 || args[0].Type() != Date {
+			return nil, argErr("one date argument")
+		}
+		return Call{Func: name.text, Args: args, typ: Number}, nil
+	case "abs":
+		if len(args) != 1 || args[0].Type() != Number {
+			return nil, argErr("one number argument")
+		}
+		return Call{Func: name.text, Args: args, typ: Number}, nil
+	case "round":
+		if len(args) == 0 || len(args) > 2 || args[0].Type() != Number {
+			return nil, argErr("a number and optional decimal places")
+		}
+		if len(args) == 2 {
+			places, ok := args[1].(NumberLit)
+			if !ok || places.Value < 0 || places.Value > 10 || places.Value != float64(int(places.Value)) {
+				return nil, argErr("decimal places as an integer literal between 0 and 10")
+			}
+		}
+		return Call{Func: name.text, Args: args, typ: Number}, nil
+	case "coalesce":
+		if len(args) < 2 {
+			return nil, argErr("at least two arguments")
+		}
+		for _, arg := range args[1:] {
+			if arg.Type() != args[0].Type() {
+				return nil, argErr("arguments of the same type")
+			}
+		}
+		return Call{Func: name.text, Args: args, typ: args[0].Type()}, nil
+	}
+	return nil, p.errorf(name, "unknown function %q", name.text)
+}
+
+func (p *parser) parseAggregate(name token) (Expr, error) {
+	if err := p.expectOp("("); err != nil {
+		return nil, err
+	}
+
+	collTok := p.next()
+	if collTok.kind != tokIdent {
+		return nil, p.errorf(collTok, "%s expects a collection", name.text)
+	}
+	coll, ok := p.schema.Collections[collTok.text]
+	if !ok {
+		return nil, p.errorf(collTok, "unknown collection %q", collTok.text)
+	}
+
+	agg := Aggregate{Func: name.text, Collection: collTok.text, typ: Number}
+	if name.text != "count" {
+		if err := p.expectOp("."); err != nil {
+			return nil, err
+		}
+		fieldTok := p.next()
+		typ, ok := coll.Fields[fieldTok.text]
+		if fieldTok.kind != tokIdent || !ok {
+			return nil, p.errorf(fieldTok, "unknown field %q in %s", fieldTok.text, collTok.text)
+		}
+		switch {
+		case typ == Number:
+		case typ == Date && (name.text == "min" || name.text == "max"):
+			agg.typ = Date
+		default:
+			return nil, p.errorf(fieldTok, "%s cannot aggregate %s field %q", name.text, typ, fieldTok.text)
+		}
+		agg.Field = fieldTok.text
+	}
+
+	if p.isOp(",") {
+		p.next()
+		where, err := p.parseCond(coll)
+		if err != nil {
+			return nil, err
+		}
+		agg.Where = where
+	}
+	return agg, p.expectOp(")")
+}
+
+func (p *parser) parseCond(coll Collection) (Cond, error) {
+	left, err := p.parseAndCond(coll)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokIdent && p.peek().text == "or" {
+		p.next()
+		right, err := p.parseAndCond(coll)
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAndCond(coll Collection) (Cond, error) {
+	left, err := p.parseCompare(coll)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokIdent && p.peek().text == "and" {
+		p.next()
+		right, err := p.parseCompare(coll)
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseCompare(coll Collection) (Cond, error) {
+	if p.isOp("(") {
+		tok := p.next()
+		if err := p.enter(tok); err != nil {
+			return nil, err
+		}
+		cond, err := p.parseCond(coll)
+		if err != nil {
+			return nil, err
+		}
+		p.depth--
+		return cond, p.expectOp(")")
+	}
+
+	fieldTok := p.next()
+	typ, ok := coll.Fields[fieldTok.text]
+	if fieldTok.kind != tokIdent || !ok {
+		return nil, p.errorf(fieldTok, "unknown field %q in condition", fieldTok.text)
+	}
+	opTok := p.next()
+	if opTok.kind != tokCompare {
+		return nil, p.errorf(opTok, "expected a comparison operator")
+	}
+	if typ == String && opTok.text != "=" && opTok.text != "!=" {
+		return nil, p.errorf(opTok, "text field %q only supports = and !=", fieldTok.text)
+	}
+
+	litTok := p.next()
+	cmp := Compare{Field: fieldTok.text, Op: opTok.text}
+	switch {
+	case typ == Number && litTok.kind == tokNumber:
+		v, err := strconv.ParseFloat(litTok.text, 64)
+		if err != nil {
+			return nil, p.errorf(litTok, "invalid number %q", litTok.text)
+		}
+		cmp.Value = v
+	case typ == Date && litTok.kind == tokString:
+		d, err := time.Parse(time.DateOnly, litTok.text)
+		if err != nil {
+			return nil, p.errorf(litTok, "dates must be written as \"YYYY-MM-DD\"")
+		}
+		cmp.Value = d
+	case typ == String && litTok.kind == tokString:
+		cmp.Value = litTok.text
+	default:
+		return nil, p.errorf(litTok, "field %q must be compared with a %s literal", fieldTok.text, typ)
+	}
+	return cmp, nil
+}
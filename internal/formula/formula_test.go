@@ -0,0 +1,121 @@
+package formula
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = Schema{
+	Fields: map[string]Type{
+		"revenue":   Number,
+		"createdAt": Date,
+	},
+	Collections: map[string]Collection{
+		"deals": {Fields: map[string]Type{
+			"value":    Number,
+			"stage":    String,
+			"closedAt": Date,
+		}},
+	},
+}
+
+func TestParse_Valid(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula string
+		want    Type
+	}{
+		{"number literal", "42", Number},
+		{"field", "revenue", Number},
+		{"date field", "createdAt", Date},
+		{"arithmetic precedence", "1 + 2 * revenue / 4", Number},
+		{"unary minus", "-(revenue - 1)", Number},
+		{"sum with condition", `sum(deals.value, stage = "WON")`, Number},
+		{"count without condition", "count(deals)", Number},
+		{"max of dates", "max(deals.closedAt)", Date},
+		{"days_since of aggregate", "days_since(max(deals.closedAt))", Number},
+		{"not equal", `count(deals, stage != "LOST")`, Number},
+		{"and/or with parentheses", `count(deals, (stage = "WON" or stage = "OPEN") and value >= 10)`, Number},
+		{"date literal", `count(deals, closedAt < "2026-01-01")`, Number},
+		{"round with places", "round(revenue / 3, 2)", Number},
+		{"coalesce of dates", "coalesce(max(deals.closedAt), createdAt)", Date},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.formula, testSchema)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.Type())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula string
+		pos     int
+	}{
+		{"empty", "  ", 1},
+		{"double equals", `count(deals, stage == "WON")`, 20},
+		{"lone bang", `count(deals, stage ! "WON")`, 20},
+		{"unknown field", "profit", 1},
+		{"collection outside aggregate", "deals + 1", 1},
+		{"unknown function", "sqrt(revenue)", 1},
+		{"unterminated string", `count(deals, stage = "WON)`, 22},
+		{"date arithmetic", "createdAt + 1", 11},
+		{"string result", `coalesce(revenue, createdAt)`, 1},
+		{"text field ordering", `count(deals, stage > "A")`, 20},
+		{"wrong literal type", `count(deals, value = "10")`, 22},
+		{"bad date literal", `count(deals, closedAt = "01/02/2026")`, 25},
+		{"sum of dates", "sum(deals.closedAt)", 11},
+		{"trailing token", "revenue revenue", 9},
+		{"unexpected end", "revenue +", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.formula, testSchema)
+			var formulaErr *Error
+			require.True(t, errors.As(err, &formulaErr), "expected *Error, got %v", err)
+			assert.Equal(t, tt.pos, formulaErr.Pos, formulaErr.Msg)
+		})
+	}
+}
+
+func TestParse_Limits(t *testing.T) {
+	long := "1"
+	for len(long) <= MaxLength {
+		long += " + 1"
+	}
+	_, err := Parse(long, testSchema)
+	assert.Error(t, err)
+
+	nested := "revenue"
+	for i := 0; i <= maxDepth; i++ {
+		nested = "(" + nested + ")"
+	}
+	_, err = Parse(nested, testSchema)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nested too deeply")
+}
+
+func TestParse_CompareValues(t *testing.T) {
+	expr, err := Parse(`count(deals, stage = "WON" and closedAt >= "2026-01-01" or value != 5)`, testSchema)
+	require.NoError(t, err)
+
+	agg := expr.(Aggregate)
+	assert.Equal(t, Logical{
+		Op: "or",
+		Left: Logical{
+			Op:    "and",
+			Left:  Compare{Field: "stage", Op: "=", Value: "WON"},
+			Right: Compare{Field: "closedAt", Op: ">=", Value: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Right: Compare{Field: "value", Op: "!=", Value: 5.0},
+	}, agg.Where)
+}
@@ -2,8 +2,11 @@ package docs
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"linkko-api/internal/apperr"
 )
 
 // OpenAPISpec contém os bytes do arquivo embutido.
@@ -25,6 +28,19 @@ func OpenAPIHandler() http.Handler {
 	})
 }
 
+// ErrorCatalogHandler lista o catálogo de erros (apperr) em JSON.
+// É o destino dos links docs_url enviados nas respostas de erro.
+func ErrorCatalogHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":   true,
+			"data": apperr.Catalog(),
+		})
+	})
+}
+
 // ScalarDocsHandler retorna um HTML mínimo com Scalar API Reference via CDN.
 func ScalarDocsHandler(specURL string) http.Handler {
 	html := fmt.Sprintf(`<!doctype html>
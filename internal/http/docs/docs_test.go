@@ -1,10 +1,13 @@
 package docs
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"linkko-api/internal/apperr"
 )
 
 func TestOpenAPIHandler(t *testing.T) {
@@ -53,3 +56,38 @@ func TestScalarDocsHandler(t *testing.T) {
 		t.Errorf("expected body to contain '/openapi.yaml', got %s", body)
 	}
 }
+
+func TestErrorCatalogHandler(t *testing.T) {
+	apperr.Define("TEST_ERROR", http.StatusTeapot, "test error", "")
+
+	handler := ErrorCatalogHandler()
+	req := httptest.NewRequest("GET", apperr.DocsPath, nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+
+	var body struct {
+		OK   bool                  `json:"ok"`
+		Data []apperr.CatalogEntry `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	found := false
+	for _, entry := range body.Data {
+		if entry.Code == "TEST_ERROR" {
+			found = true
+			if entry.DocsURL != apperr.DocsPath+"#TEST_ERROR" {
+				t.Errorf("unexpected docsUrl %q", entry.DocsURL)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected TEST_ERROR in catalog")
+	}
+}
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"linkko-api/internal/domain"
@@ -13,7 +11,6 @@ import (
 	"linkko-api/internal/service"
 
 	"github.com/go-chi/chi/v5"
-	"go.uber.org/zap"
 )
 
 type ActivityHandler struct {
@@ -38,9 +35,14 @@ func (h *ActivityHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := req.Validate(); err != nil {
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
 	note, err := h.service.CreateNote(ctx, workspaceID, actorID, &req)
 	if err != nil {
-		handleActivityError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -61,15 +63,48 @@ func (h *ActivityHandler) CreateCall(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := req.Validate(); err != nil {
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
 	call, err := h.service.CreateCall(ctx, workspaceID, actorID, &req)
 	if err != nil {
-		handleActivityError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
 	writeOK(w, http.StatusCreated, call)
 }
 
+func (h *ActivityHandler) CreateMeeting(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	claims, _ := auth.GetClaims(ctx)
+	actorID := claims.ActorID
+
+	var req domain.CreateMeetingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid JSON body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	meeting, err := h.service.CreateMeeting(ctx, workspaceID, actorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeOK(w, http.StatusCreated, meeting)
+}
+
 func (h *ActivityHandler) ListTimeline(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := logger.GetLogger(ctx)
@@ -87,22 +122,35 @@ func (h *ActivityHandler) ListTimeline(w http.ResponseWriter, r *http.Request) {
 	if companyID != "" { cpID = &companyID }
 	if dealID != "" { dID = &dealID }
 
-	activities, err := h.service.ListTimeline(ctx, workspaceID, actorID, ctID, cpID, dID)
+	// participantId: chamadas/reuniões de que o usuário ou contato participou;
+	// attended=true é o atalho para o usuário autenticado ("reuniões de que participei")
+	var pID *string
+	if participantID := r.URL.Query().Get("participantId"); participantID != "" {
+		pID = &participantID
+	}
+	if r.URL.Query().Get("attended") == "true" {
+		pID = &actorID
+	}
+
+	var activityType *domain.ActivityType
+	if typeStr := r.URL.Query().Get("activityType"); typeStr != "" {
+		t := domain.ActivityType(typeStr)
+		activityType = &t
+	}
+
+	// Accept: application/x-ndjson transmite a timeline inteira linha a linha (timelines enormes)
+	if wantsNDJSON(r) {
+		streamNDJSON(w, r, "timeline", func(emit func(*domain.Activity) error) error {
+			return h.service.StreamTimeline(ctx, workspaceID, actorID, ctID, cpID, dID, pID, activityType, emit)
+		})
+		return
+	}
+
+	activities, err := h.service.ListTimeline(ctx, workspaceID, actorID, ctID, cpID, dID, pID, activityType)
 	if err != nil {
-		handleActivityError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
 	writeOK(w, http.StatusOK, activities)
 }
-
-// Helpers
-func handleActivityError(w http.ResponseWriter, ctx context.Context, log *logger.Logger, err error) {
-	switch {
-	case errors.Is(err, service.ErrUnauthorized):
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions")
-	default:
-		log.Error(ctx, "internal error", zap.Error(err))
-		httperr.InternalError500(w, ctx, "an internal error occurred")
-	}
-}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/integrations/stripe"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// maxStripeWebhookBytes limita o payload aceito do webhook (eventos do Stripe têm poucos KB)
+const maxStripeWebhookBytes = 1 << 20
+
+type BillingHandler struct {
+	service *service.BillingService
+}
+
+func NewBillingHandler(service *service.BillingService) *BillingHandler {
+	return &BillingHandler{service: service}
+}
+
+// GetBilling handles GET /v1/workspaces/{workspaceId}/billing
+func (h *BillingHandler) GetBilling(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	billing, err := h.service.GetBilling(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, billing)
+}
+
+// StripeWebhook handles POST /v1/webhooks/stripe
+// A assinatura (Stripe-Signature) substitui o JWT; o corpo é lido cru para a verificação.
+func (h *BillingHandler) StripeWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxStripeWebhookBytes)
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Warn(ctx, "invalid stripe webhook body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body could not be read")
+		return
+	}
+
+	if err := h.service.HandleStripeWebhook(ctx, payload, r.Header.Get(stripe.SignatureHeader)); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]bool{"received": true})
+}
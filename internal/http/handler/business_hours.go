@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type BusinessHoursHandler struct {
+	service *service.BusinessHoursService
+}
+
+func NewBusinessHoursHandler(service *service.BusinessHoursService) *BusinessHoursHandler {
+	return &BusinessHoursHandler{service: service}
+}
+
+// GetBusinessHours handles GET /v1/workspaces/{workspaceId}/business-hours
+func (h *BusinessHoursHandler) GetBusinessHours(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	hours, err := h.service.GetBusinessHours(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, hours)
+}
+
+// SetBusinessHours handles PUT /v1/workspaces/{workspaceId}/business-hours
+func (h *BusinessHoursHandler) SetBusinessHours(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.SetBusinessHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	hours, err := h.service.SetBusinessHours(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, hours)
+}
+
+// DeleteBusinessHours handles DELETE /v1/workspaces/{workspaceId}/business-hours
+func (h *BusinessHoursHandler) DeleteBusinessHours(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteBusinessHours(ctx, workspaceID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListHolidays handles GET /v1/workspaces/{workspaceId}/holidays
+func (h *BusinessHoursHandler) ListHolidays(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var params domain.ListHolidaysParams
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil || year < 1900 || year > 9999 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "year must be a four-digit year")
+			return
+		}
+		params.Year = &year
+	}
+
+	holidays, err := h.service.ListHolidays(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.HolidayListResponse{Data: holidays})
+}
+
+// CreateHoliday handles POST /v1/workspaces/{workspaceId}/holidays
+func (h *BusinessHoursHandler) CreateHoliday(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateHolidayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	holiday, err := h.service.CreateHoliday(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, holiday)
+}
+
+// UpdateHoliday handles PATCH /v1/workspaces/{workspaceId}/holidays/{holidayId}
+func (h *BusinessHoursHandler) UpdateHoliday(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	holidayID := chi.URLParam(r, "holidayId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateHolidayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	holiday, err := h.service.UpdateHoliday(ctx, workspaceID, holidayID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, holiday)
+}
+
+// DeleteHoliday handles DELETE /v1/workspaces/{workspaceId}/holidays/{holidayId}
+func (h *BusinessHoursHandler) DeleteHoliday(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	holidayID := chi.URLParam(r, "holidayId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteHoliday(ctx, workspaceID, holidayID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -48,6 +46,11 @@ func (h *CompanyHandler) ListCompanies(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	linked, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+
 	params := domain.ListCompaniesParams{
 		WorkspaceID: workspaceID,
 		Limit:       50, // Default
@@ -103,6 +106,21 @@ func (h *CompanyHandler) ListCompanies(w http.ResponseWriter, r *http.Request) {
 		params.Query = &search
 	}
 
+	if wantsCSV(r) {
+		writeCSV(w, r, "companies.csv", companyCSVSchema, params.Cursor, func(cursor *string) ([]domain.Company, *string, error) {
+			params.Cursor = cursor
+			page, err := h.service.ListCompanies(ctx, workspaceID, actorID, params)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !page.Meta.HasNextPage {
+				return page.Data, nil, nil
+			}
+			return page.Data, page.Meta.NextCursor, nil
+		})
+		return
+	}
+
 	log.Info(ctx, "listing companies",
 		zap.String("workspaceId", workspaceID),
 		zap.String("actorId", actorID),
@@ -111,7 +129,7 @@ func (h *CompanyHandler) ListCompanies(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.ListCompanies(ctx, workspaceID, actorID, params)
 	if err != nil {
-		handleCompanyServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -121,6 +139,15 @@ func (h *CompanyHandler) ListCompanies(w http.ResponseWriter, r *http.Request) {
 		zap.Bool("hasNextPage", response.Meta.HasNextPage),
 	)
 
+	if linked {
+		writeJSON(w, http.StatusOK, linkedPage{
+			Data:  newLinkBuilder(r).companies(response.Data),
+			Meta:  response.Meta,
+			Links: pageLinks(r, response.Meta.HasNextPage, response.Meta.NextCursor),
+		})
+		return
+	}
+
 	writeJSON(w, http.StatusOK, response)
 }
 
@@ -144,6 +171,11 @@ func (h *CompanyHandler) GetCompany(w http.ResponseWriter, r *http.Request) {
 
 	actorID := claims.ActorID
 
+	linked, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+
 	log.Info(ctx, "fetching company",
 		zap.String("workspaceId", workspaceID),
 		zap.String("companyId", companyID),
@@ -152,7 +184,7 @@ func (h *CompanyHandler) GetCompany(w http.ResponseWriter, r *http.Request) {
 
 	company, err := h.service.GetCompany(ctx, workspaceID, companyID, actorID)
 	if err != nil {
-		handleCompanyServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -160,6 +192,11 @@ func (h *CompanyHandler) GetCompany(w http.ResponseWriter, r *http.Request) {
 		zap.String("companyId", company.ID),
 	)
 
+	if linked {
+		writeJSON(w, http.StatusOK, newLinkBuilder(r).company(company))
+		return
+	}
+
 	writeJSON(w, http.StatusOK, company)
 }
 
@@ -201,7 +238,7 @@ func (h *CompanyHandler) CreateCompany(w http.ResponseWriter, r *http.Request) {
 
 	company, err := h.service.CreateCompany(ctx, workspaceID, actorID, &req)
 	if err != nil {
-		handleCompanyServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -212,6 +249,56 @@ func (h *CompanyHandler) CreateCompany(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, company)
 }
 
+// UpsertCompanyByExternalID handles PUT /v1/workspaces/{workspaceId}/companies/by-external-id/{externalId}
+// Corpo igual ao de CreateCompany. 201 quando cria, 200 quando atualiza a empresa existente.
+func (h *CompanyHandler) UpsertCompanyByExternalID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication claims not found")
+		return
+	}
+
+	actorID := claims.ActorID
+	if actorID == "" {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "actorID not found in claims")
+		return
+	}
+
+	var req domain.CreateCompanyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error(ctx, "failed to decode request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	externalID, ok := upsertExternalID(w, r, req.ExternalID)
+	if !ok {
+		return
+	}
+	system, ok := upsertSystem(w, r)
+	if !ok {
+		return
+	}
+
+	company, created, err := h.service.UpsertCompanyByExternalID(ctx, workspaceID, externalID, actorID, system, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	log.Info(ctx, "company upserted by external id",
+		zap.String("companyId", company.ID),
+		zap.Bool("created", created),
+	)
+
+	writeJSON(w, upsertStatus(created), company)
+}
+
 // UpdateCompany handles PATCH /v1/workspaces/{workspaceId}/companies/{companyId}
 func (h *CompanyHandler) UpdateCompany(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -251,7 +338,7 @@ func (h *CompanyHandler) UpdateCompany(w http.ResponseWriter, r *http.Request) {
 
 	company, err := h.service.UpdateCompany(ctx, workspaceID, companyID, actorID, &req)
 	if err != nil {
-		handleCompanyServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -292,9 +379,9 @@ func (h *CompanyHandler) DeleteCompany(w http.ResponseWriter, r *http.Request) {
 		zap.String("actorId", actorID),
 	)
 
-	err := h.service.DeleteCompany(ctx, workspaceID, companyID, actorID)
+	receipt, err := h.service.DeleteCompany(ctx, workspaceID, companyID, actorID)
 	if err != nil {
-		handleCompanyServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -302,25 +389,82 @@ func (h *CompanyHandler) DeleteCompany(w http.ResponseWriter, r *http.Request) {
 		zap.String("companyId", companyID),
 	)
 
-	w.WriteHeader(http.StatusNoContent)
+	writeDeleted(w, receipt)
+}
+
+// FindDuplicates handles GET /v1/workspaces/{workspaceId}/companies/:duplicates
+func (h *CompanyHandler) FindDuplicates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var params domain.CompanyDuplicatesParams
+	if thresholdStr := r.URL.Query().Get("threshold"); thresholdStr != "" {
+		threshold, err := strconv.ParseFloat(thresholdStr, 64)
+		if err != nil || threshold <= 0 || threshold > 1 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "threshold must be greater than 0 and at most 1")
+			return
+		}
+		params.Threshold = threshold
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 200")
+			return
+		}
+		params.Limit = limit
+	}
+
+	duplicates, err := h.service.FindDuplicates(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, duplicates)
 }
 
-// handleCompanyServiceError maps service errors to HTTP responses
-func handleCompanyServiceError(w http.ResponseWriter, ctx context.Context, log *logger.Logger, err error) {
-	// Tarefa B: Capturar o erro real para observabilidade
-	logger.SetRootError(ctx, err)
-
-	switch {
-	case errors.Is(err, service.ErrMemberNotFound):
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions for this workspace")
-	case errors.Is(err, service.ErrUnauthorized):
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions for this action")
-	case errors.Is(err, service.ErrCompanyNotFound):
-		httperr.WriteError(w, ctx, http.StatusNotFound, httperr.ErrCodeNotFound, "company not found")
-	case errors.Is(err, service.ErrCompanyDomainConflict):
-		httperr.WriteError(w, ctx, http.StatusConflict, httperr.ErrCodeConflict, "company with this domain already exists")
-	default:
-		log.Error(ctx, "unexpected service error", zap.Error(err))
-		httperr.InternalError(w, ctx)
+// MergeCompanies handles POST /v1/workspaces/{workspaceId}/companies/{companyId}/:merge
+// A empresa do path sobrevive; as de sourceCompanyIds são absorvidas e removidas.
+func (h *CompanyHandler) MergeCompanies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	companyID := chi.URLParam(r, "companyId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.MergeCompaniesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
 	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	result, err := h.service.MergeCompanies(ctx, workspaceID, companyID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
 }
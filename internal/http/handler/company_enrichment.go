@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type CompanyEnrichmentHandler struct {
+	service *service.CompanyEnrichmentService
+}
+
+func NewCompanyEnrichmentHandler(service *service.CompanyEnrichmentService) *CompanyEnrichmentHandler {
+	return &CompanyEnrichmentHandler{service: service}
+}
+
+// EnrichCompany handles POST /v1/workspaces/{workspaceId}/companies/{companyId}/:enrich
+// Enfileira o enriquecimento; responde 202 com o job PENDING e Location apontando para ele.
+func (h *CompanyEnrichmentHandler) EnrichCompany(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	companyID := chi.URLParam(r, "companyId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	// Corpo opcional: sem corpo equivale a {"overwrite": false}
+	var req domain.EnrichCompanyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	job, err := h.service.EnrichCompany(ctx, workspaceID, companyID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v1/workspaces/"+workspaceID+"/companies/"+companyID+"/enrichments/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// GetEnrichmentJob handles GET /v1/workspaces/{workspaceId}/companies/{companyId}/enrichments/{jobId}
+func (h *CompanyEnrichmentHandler) GetEnrichmentJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	companyID := chi.URLParam(r, "companyId")
+	jobID := chi.URLParam(r, "jobId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	job, err := h.service.GetEnrichmentJob(ctx, workspaceID, companyID, jobID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
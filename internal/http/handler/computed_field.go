@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ComputedFieldHandler struct {
+	service *service.ComputedFieldService
+}
+
+func NewComputedFieldHandler(service *service.ComputedFieldService) *ComputedFieldHandler {
+	return &ComputedFieldHandler{service: service}
+}
+
+// ListComputedFields handles GET /v1/workspaces/{workspaceId}/computed-fields
+func (h *ComputedFieldHandler) ListComputedFields(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var entity *domain.ComputedFieldEntity
+	if v := r.URL.Query().Get("entityType"); v != "" {
+		e := domain.ComputedFieldEntity(v)
+		if !e.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid entityType value")
+			return
+		}
+		entity = &e
+	}
+
+	fields, err := h.service.ListComputedFields(ctx, workspaceID, claims.ActorID, entity)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.ComputedFieldListResponse{Data: fields})
+}
+
+// CreateComputedField handles POST /v1/workspaces/{workspaceId}/computed-fields
+func (h *ComputedFieldHandler) CreateComputedField(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateComputedFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	field, err := h.service.CreateComputedField(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, field)
+}
+
+// GetComputedField handles GET /v1/workspaces/{workspaceId}/computed-fields/{fieldId}
+func (h *ComputedFieldHandler) GetComputedField(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	fieldID := chi.URLParam(r, "fieldId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	field, err := h.service.GetComputedField(ctx, workspaceID, fieldID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, field)
+}
+
+// UpdateComputedField handles PATCH /v1/workspaces/{workspaceId}/computed-fields/{fieldId}
+func (h *ComputedFieldHandler) UpdateComputedField(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	fieldID := chi.URLParam(r, "fieldId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateComputedFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	// A fórmula é validada contra a entidade do campo
+	if req.Formula != nil {
+		current, err := h.service.GetComputedField(ctx, workspaceID, fieldID, claims.ActorID)
+		if err != nil {
+			handleServiceError(w, ctx, log, err)
+			return
+		}
+		if err := req.ValidateFormula(current.EntityType); err != nil {
+			log.Warn(ctx, "validation failed", zap.Error(err))
+			httperr.ValidationError422(w, ctx, err)
+			return
+		}
+	}
+
+	field, err := h.service.UpdateComputedField(ctx, workspaceID, fieldID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, field)
+}
+
+// DeleteComputedField handles DELETE /v1/workspaces/{workspaceId}/computed-fields/{fieldId}
+func (h *ComputedFieldHandler) DeleteComputedField(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	fieldID := chi.URLParam(r, "fieldId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteComputedField(ctx, workspaceID, fieldID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
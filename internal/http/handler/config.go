@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"linkko-api/internal/config"
+	"linkko-api/internal/http/httperr"
+)
+
+// ConfigReloadFunc relê a configuração e aplica as variáveis recarregáveis; source identifica a
+// origem nos logs (sighup, admin_endpoint)
+type ConfigReloadFunc func(ctx context.Context, source string) ([]config.Change, error)
+
+// ConfigHandler operações de configuração da instância (fora dos workspaces, protegidas por ADMIN_TOKEN)
+type ConfigHandler struct {
+	reload ConfigReloadFunc
+}
+
+func NewConfigHandler(reload ConfigReloadFunc) *ConfigHandler {
+	return &ConfigHandler{reload: reload}
+}
+
+// Reload handles POST /admin/config:reload
+// Mesma operação do SIGHUP: devolve as variáveis alteradas; applied=false exige restart.
+func (h *ConfigHandler) Reload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	changes, err := h.reload(ctx, "admin_endpoint")
+	if err != nil {
+		httperr.WriteErrorWithFields(w, ctx, http.StatusUnprocessableEntity, httperr.ErrCodeInvalidConfig,
+			"invalid configuration, nothing was reloaded", map[string]string{"config": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{"changes": changes},
+	})
+}
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -40,6 +38,11 @@ func (h *ContactHandler) ListContacts(w http.ResponseWriter, r *http.Request) {
 
 	actorID := claims.ActorID
 
+	linked, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+
 	params := domain.ListContactsParams{
 		Limit: 50, // default
 	}
@@ -65,10 +68,50 @@ func (h *ContactHandler) ListContacts(w http.ResponseWriter, r *http.Request) {
 		params.CompanyID = &companyId
 	}
 
+	// following=true: somente contatos seguidos pelo usuário autenticado
+	if r.URL.Query().Get("following") == "true" {
+		params.FollowerID = &actorID
+	}
+
+	if stageStr := r.URL.Query().Get("lifecycleStage"); stageStr != "" {
+		stage := domain.ContactLifecycleStage(stageStr)
+		if !stage.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid lifecycleStage value")
+			return
+		}
+		params.LifecycleStage = &stage
+	}
+
+	if statusStr := r.URL.Query().Get("emailStatus"); statusStr != "" {
+		status := domain.EmailStatus(statusStr)
+		if !status.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid emailStatus value")
+			return
+		}
+		params.EmailStatus = &status
+	}
+
+	params.Attribution = parseAttributionFilter(r)
+
 	if search := r.URL.Query().Get("q"); search != "" {
 		params.Query = &search
 	}
 
+	if wantsCSV(r) {
+		writeCSV(w, r, "contacts.csv", contactCSVSchema, params.Cursor, func(cursor *string) ([]domain.Contact, *string, error) {
+			params.Cursor = cursor
+			page, err := h.service.ListContacts(ctx, workspaceID, actorID, params)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !page.Meta.HasNextPage {
+				return page.Data, nil, nil
+			}
+			return page.Data, page.Meta.NextCursor, nil
+		})
+		return
+	}
+
 	log.Info(ctx, "listing contacts",
 		zap.String("workspaceId", workspaceID),
 		zap.String("actorId", actorID),
@@ -93,6 +136,15 @@ func (h *ContactHandler) ListContacts(w http.ResponseWriter, r *http.Request) {
 		zap.Bool("hasNextPage", response.Meta.HasNextPage),
 	)
 
+	if linked {
+		writeJSON(w, http.StatusOK, linkedPage{
+			Data:  newLinkBuilder(r).contacts(response.Data),
+			Meta:  response.Meta,
+			Links: pageLinks(r, response.Meta.HasNextPage, response.Meta.NextCursor),
+		})
+		return
+	}
+
 	writeJSON(w, http.StatusOK, response)
 }
 
@@ -112,6 +164,11 @@ func (h *ContactHandler) GetContact(w http.ResponseWriter, r *http.Request) {
 
 	actorID := claims.ActorID
 
+	linked, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+
 	log.Info(ctx, "fetching contact",
 		zap.String("workspaceId", workspaceID),
 		zap.String("contactId", contactID),
@@ -136,6 +193,11 @@ func (h *ContactHandler) GetContact(w http.ResponseWriter, r *http.Request) {
 		zap.String("contactId", contact.ID),
 	)
 
+	if linked {
+		writeJSON(w, http.StatusOK, newLinkBuilder(r).contact(contact))
+		return
+	}
+
 	writeJSON(w, http.StatusOK, contact)
 }
 
@@ -163,10 +225,12 @@ func (h *ContactHandler) CreateContact(w http.ResponseWriter, r *http.Request) {
 
 	if err := req.Validate(); err != nil {
 		log.Warn(ctx, "validation failed", zap.Error(err))
-		httperr.WriteError(w, ctx, http.StatusUnprocessableEntity, httperr.ErrCodeValidationError, err.Error())
+		httperr.ValidationError422(w, ctx, err)
 		return
 	}
 
+	applyClientSource(ctx, &req.Attribution)
+
 	log.Info(ctx, "creating contact",
 		zap.String("workspaceId", workspaceID),
 		zap.String("email", req.Email),
@@ -196,6 +260,63 @@ func (h *ContactHandler) CreateContact(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, contact)
 }
 
+// UpsertContactByExternalID handles PUT /v1/workspaces/{workspaceId}/contacts/by-external-id/{externalId}
+// Corpo igual ao de CreateContact. 201 quando cria, 200 quando atualiza o contato existente.
+func (h *ContactHandler) UpsertContactByExternalID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var req domain.CreateContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	externalID, ok := upsertExternalID(w, r, req.ExternalID)
+	if !ok {
+		return
+	}
+	system, ok := upsertSystem(w, r)
+	if !ok {
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	applyClientSource(ctx, &req.Attribution)
+
+	contact, created, err := h.service.UpsertContactByExternalID(ctx, workspaceID, externalID, actorID, system, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	log.Info(ctx, "contact upserted by external id",
+		zap.String("contactId", contact.ID),
+		zap.Bool("created", created),
+	)
+
+	if created {
+		w.Header().Set("Location", "/v1/workspaces/"+workspaceID+"/contacts/"+contact.ID)
+	}
+	writeJSON(w, upsertStatus(created), contact)
+}
+
 // UpdateContact handles PATCH /v1/workspaces/{workspaceId}/contacts/{contactId}
 func (h *ContactHandler) UpdateContact(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -221,7 +342,7 @@ func (h *ContactHandler) UpdateContact(w http.ResponseWriter, r *http.Request) {
 
 	if err := req.Validate(); err != nil {
 		log.Warn(ctx, "validation failed", zap.Error(err))
-		httperr.WriteError(w, ctx, http.StatusUnprocessableEntity, httperr.ErrCodeValidationError, err.Error())
+		httperr.ValidationError422(w, ctx, err)
 		return
 	}
 
@@ -275,7 +396,7 @@ func (h *ContactHandler) DeleteContact(w http.ResponseWriter, r *http.Request) {
 	)
 
 	// Service now fetches role from database internally and validates delete permission
-	err := h.service.DeleteContact(ctx, workspaceID, contactID, actorID)
+	receipt, err := h.service.DeleteContact(ctx, workspaceID, contactID, actorID)
 	if err != nil {
 		log.Error(ctx, "failed to delete contact",
 			zap.Error(err),
@@ -292,7 +413,102 @@ func (h *ContactHandler) DeleteContact(w http.ResponseWriter, r *http.Request) {
 		zap.String("contactId", contactID),
 	)
 
-	w.WriteHeader(http.StatusNoContent)
+	writeDeleted(w, receipt)
+}
+
+// TransitionLifecycleStage handles POST /v1/workspaces/{workspaceId}/contacts/{contactId}/:transition-stage
+func (h *ContactHandler) TransitionLifecycleStage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	contactID := chi.URLParam(r, "contactId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var req domain.TransitionLifecycleStageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	log.Info(ctx, "transitioning contact lifecycle stage",
+		zap.String("workspaceId", workspaceID),
+		zap.String("contactId", contactID),
+		zap.String("toStage", string(req.ToStage)),
+		zap.String("actorId", actorID),
+	)
+
+	contact, err := h.service.TransitionLifecycleStage(ctx, workspaceID, contactID, actorID, &req)
+	if err != nil {
+		log.Error(ctx, "failed to transition contact lifecycle stage",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("contactId", contactID),
+			zap.String("actorId", actorID),
+			zap.String("error_details", err.Error()),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	log.Info(ctx, "contact lifecycle stage transitioned successfully",
+		zap.String("contactId", contact.ID),
+		zap.String("lifecycleStage", string(contact.LifecycleStage)),
+	)
+
+	writeJSON(w, http.StatusOK, contact)
+}
+
+// LifecycleReport handles GET /v1/workspaces/{workspaceId}/reports/lifecycle
+func (h *ContactHandler) LifecycleReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var ownerID, companyID *string
+	if actorId := r.URL.Query().Get("actorId"); actorId != "" {
+		ownerID = &actorId
+	}
+	if companyId := r.URL.Query().Get("companyId"); companyId != "" {
+		companyID = &companyId
+	}
+
+	report, err := h.service.LifecycleReport(ctx, workspaceID, actorID, ownerID, companyID)
+	if err != nil {
+		log.Error(ctx, "failed to build lifecycle report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+			zap.String("error_details", err.Error()),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
 }
 
 // Helper functions for standardized responses
@@ -305,47 +521,25 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	}
 }
 
-func handleServiceError(w http.ResponseWriter, ctx context.Context, log *logger.Logger, err error) {
-	// Tarefa B: Capture the real error for observability
-	logger.SetRootError(ctx, err)
+// AssociateCompanies handles POST /v1/workspaces/{workspaceId}/contacts/:associate-companies
+// Backfill: associa os contatos sem empresa pelo domínio do e-mail.
+func (h *ContactHandler) AssociateCompanies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
 
-	// Log error details for debugging before handling
-	unwrappedErr := errors.Unwrap(err)
-	errorType := "unknown"
-	if unwrappedErr != nil {
-		errorType = unwrappedErr.Error()
-	}
+	workspaceID := chi.URLParam(r, "workspaceId")
 
-	log.Error(ctx, "service error occurred",
-		zap.Error(err),
-		zap.String("error_type", errorType),
-		zap.String("error_details", err.Error()),
-	)
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
 
-	switch {
-	case errors.Is(err, service.ErrMemberNotFound):
-		log.Warn(ctx, "member not found in workspace", zap.Error(err))
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions for this workspace")
-	case errors.Is(err, service.ErrUnauthorized):
-		log.Warn(ctx, "unauthorized action", zap.Error(err))
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions for this action")
-	case errors.Is(err, service.ErrContactNotFound):
-		log.Debug(ctx, "contact not found", zap.Error(err))
-		httperr.WriteError(w, ctx, http.StatusNotFound, httperr.ErrCodeNotFound, "contact not found")
-	case errors.Is(err, service.ErrEmailConflict):
-		log.Warn(ctx, "email conflict", zap.Error(err))
-		httperr.WriteError(w, ctx, http.StatusConflict, httperr.ErrCodeConflict, "contact with this email already exists")
-	case errors.Is(err, service.ErrConcurrencyConflict):
-		log.Warn(ctx, "concurrency conflict", zap.Error(err))
-		httperr.WriteError(w, ctx, http.StatusConflict, httperr.ErrCodeConflict, "contact was modified by another request")
-	case errors.Is(err, service.ErrInvalidOwner):
-		log.Warn(ctx, "invalid owner", zap.Error(err))
-		httperr.WriteError(w, ctx, http.StatusUnprocessableEntity, httperr.ErrCodeValidationError, "owner does not belong to workspace")
-	case errors.Is(err, service.ErrInvalidCompany):
-		log.Warn(ctx, "invalid company", zap.Error(err))
-		httperr.WriteError(w, ctx, http.StatusUnprocessableEntity, httperr.ErrCodeValidationError, "company does not belong to workspace")
-	default:
-		log.Error(ctx, "unhandled internal server error", zap.Error(err), zap.String("error_details", err.Error()))
-		httperr.InternalError500(w, ctx, "an internal error occurred")
+	result, err := h.service.AssociateCompanies(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
 	}
+
+	writeJSON(w, http.StatusOK, result)
 }
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ContactBulkHandler struct {
+	service *service.ContactBulkService
+}
+
+func NewContactBulkHandler(service *service.ContactBulkService) *ContactBulkHandler {
+	return &ContactBulkHandler{service: service}
+}
+
+// BulkUpdateContacts handles POST /v1/workspaces/{workspaceId}/contacts/:bulk-update
+// Enfileira o patch; responde 202 com o job PENDING e Location apontando para ele.
+func (h *ContactBulkHandler) BulkUpdateContacts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.BulkUpdateContactsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	job, err := h.service.BulkUpdateContacts(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v1/workspaces/"+workspaceID+"/contacts/bulk-updates/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// GetBulkUpdateJob handles GET /v1/workspaces/{workspaceId}/contacts/bulk-updates/{jobId}
+func (h *ContactBulkHandler) GetBulkUpdateJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	jobID := chi.URLParam(r, "jobId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	job, err := h.service.GetBulkUpdateJob(ctx, workspaceID, jobID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CounterHandler struct {
+	service *service.CounterService
+}
+
+func NewCounterHandler(service *service.CounterService) *CounterHandler {
+	return &CounterHandler{service: service}
+}
+
+// GetCounters handles GET /v1/workspaces/{workspaceId}/counters
+func (h *CounterHandler) GetCounters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	counters, err := h.service.GetCounters(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, counters)
+}
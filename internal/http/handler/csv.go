@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"encoding/csv"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// Content negotiation de CSV nas listagens (Accept: text/csv).
+//
+// Query params aceitos junto com text/csv:
+//   - columns: lista separada por vírgula das colunas exportadas (default: conjunto padrão do recurso)
+//   - all=true: percorre todas as páginas a partir do cursor em vez de só a página atual
+//
+// A resposta é transmitida página a página; com all=false o cursor da próxima página
+// vai no header X-Next-Cursor (não há envelope JSON para carregá-lo).
+
+const csvContentType = "text/csv; charset=utf-8"
+
+// csvColumn é uma coluna exportável: Name é o cabeçalho e o valor aceito em ?columns=.
+type csvColumn[T any] struct {
+	Name  string
+	Value func(*T) string
+}
+
+// csvSchema lista as colunas suportadas de um recurso e o conjunto padrão.
+type csvSchema[T any] struct {
+	Columns  []csvColumn[T]
+	Defaults []string
+}
+
+// selectColumns resolve ?columns= (vazio = Defaults); ok é false se algum nome não existir.
+func (s csvSchema[T]) selectColumns(param string) ([]csvColumn[T], bool) {
+	names := s.Defaults
+	if strings.TrimSpace(param) != "" {
+		names = strings.Split(param, ",")
+	}
+
+	selected := make([]csvColumn[T], 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, col := range s.Columns {
+			if col.Name == name {
+				selected = append(selected, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return selected, true
+}
+
+// wantsCSV reporta se o cliente pediu text/csv no Accept (ignora q=0 e curingas).
+func wantsCSV(r *http.Request) bool {
+	return acceptsMediaType(r, "text/csv")
+}
+
+// acceptsMediaType reporta se o Accept lista mediaType explicitamente (ignora q=0 e curingas).
+func acceptsMediaType(r *http.Request, want string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != want {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// csvPageFetcher busca uma página a partir do cursor (nil = primeira página) e devolve
+// os itens e o cursor da próxima página (nil = fim).
+type csvPageFetcher[T any] func(cursor *string) ([]T, *string, error)
+
+// writeCSV valida columns/all, busca a primeira página e transmite o CSV.
+// Erros antes do primeiro byte seguem o formato JSON padrão; depois disso o stream
+// é interrompido e o erro apenas logado.
+func writeCSV[T any](w http.ResponseWriter, r *http.Request, filename string, schema csvSchema[T], cursor *string, fetch csvPageFetcher[T]) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	cols, ok := schema.selectColumns(r.URL.Query().Get("columns"))
+	if !ok {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "columns must list supported column names")
+		return
+	}
+
+	all := false
+	if v := r.URL.Query().Get("all"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "all must be true or false")
+			return
+		}
+		all = parsed
+	}
+
+	items, next, err := fetch(cursor)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", csvContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Vary", "Accept")
+	if !all && next != nil {
+		w.Header().Set("X-Next-Cursor", *next)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		header[i] = col.Name
+	}
+	_ = cw.Write(header)
+
+	rows := 0
+	for {
+		record := make([]string, len(cols))
+		for i := range items {
+			for j, col := range cols {
+				record[j] = col.Value(&items[i])
+			}
+			if err := cw.Write(record); err != nil {
+				log.Error(ctx, "failed to write csv row", zap.Error(err))
+				return
+			}
+		}
+		rows += len(items)
+
+		cw.Flush()
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if !all || next == nil || ctx.Err() != nil {
+			break
+		}
+		items, next, err = fetch(next)
+		if err != nil {
+			log.Error(ctx, "csv export aborted", zap.String("file", filename), zap.Int("rows", rows), zap.Error(err))
+			return
+		}
+	}
+
+	log.Info(ctx, "csv export completed", zap.String("file", filename), zap.Int("rows", rows), zap.Bool("all", all))
+}
+
+// Formatadores de célula
+
+// csvText neutraliza fórmulas (=, +, -, @) em texto livre para planilhas.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func csvTextPtr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return csvText(*s)
+}
+
+func csvID(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func csvTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return csvTime(*t)
+}
+
+func csvFloatPtr(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func csvTags(tags []string) string {
+	return csvText(strings.Join(tags, ";"))
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"strconv"
+
+	"linkko-api/internal/domain"
+)
+
+// Colunas CSV por recurso. Novas colunas entram no fim; Defaults mantém o export enxuto.
+
+var contactCSVSchema = csvSchema[domain.Contact]{
+	Columns: []csvColumn[domain.Contact]{
+		{"id", func(c *domain.Contact) string { return c.ID }},
+		{"fullName", func(c *domain.Contact) string { return csvText(c.FullName) }},
+		{"email", func(c *domain.Contact) string { return csvText(c.Email) }},
+		{"phone", func(c *domain.Contact) string { return csvTextPtr(c.Phone) }},
+		{"lifecycleStage", func(c *domain.Contact) string { return string(c.LifecycleStage) }},
+		{"companyId", func(c *domain.Contact) string { return csvID(c.CompanyID) }},
+		{"actorId", func(c *domain.Contact) string { return c.ActorID }},
+		{"tags", func(c *domain.Contact) string { return csvTags(c.Tags) }},
+		{"source", func(c *domain.Contact) string { return csvTextPtr(c.Source) }},
+		{"utmSource", func(c *domain.Contact) string { return csvTextPtr(c.UTMSource) }},
+		{"utmMedium", func(c *domain.Contact) string { return csvTextPtr(c.UTMMedium) }},
+		{"utmCampaign", func(c *domain.Contact) string { return csvTextPtr(c.UTMCampaign) }},
+		{"createdAt", func(c *domain.Contact) string { return csvTime(c.CreatedAt) }},
+		{"updatedAt", func(c *domain.Contact) string { return csvTime(c.UpdatedAt) }},
+		{"emailStatus", func(c *domain.Contact) string {
+			if c.EmailStatus == nil {
+				return ""
+			}
+			return string(*c.EmailStatus)
+		}},
+	},
+	Defaults: []string{"id", "fullName", "email", "phone", "lifecycleStage", "companyId", "actorId", "createdAt"},
+}
+
+var companyCSVSchema = csvSchema[domain.Company]{
+	Columns: []csvColumn[domain.Company]{
+		{"id", func(c *domain.Company) string { return c.ID }},
+		{"name", func(c *domain.Company) string { return csvText(c.Name) }},
+		{"domain", func(c *domain.Company) string { return csvTextPtr(c.Domain) }},
+		{"industry", func(c *domain.Company) string { return csvTextPtr(c.Industry) }},
+		{"lifecycleStage", func(c *domain.Company) string { return string(c.LifecycleStage) }},
+		{"companySize", func(c *domain.Company) string { return string(c.CompanySize) }},
+		{"phone", func(c *domain.Company) string { return csvTextPtr(c.Phone) }},
+		{"email", func(c *domain.Company) string { return csvTextPtr(c.Email) }},
+		{"website", func(c *domain.Company) string { return csvTextPtr(c.Website) }},
+		{"annualRevenue", func(c *domain.Company) string { return csvFloatPtr(c.AnnualRevenue) }},
+		{"employeeCount", func(c *domain.Company) string {
+			if c.EmployeeCount == nil {
+				return ""
+			}
+			return strconv.Itoa(*c.EmployeeCount)
+		}},
+		{"ownerId", func(c *domain.Company) string { return c.OwnerID }},
+		{"tags", func(c *domain.Company) string { return csvTags(c.Tags) }},
+		{"createdAt", func(c *domain.Company) string { return csvTime(c.CreatedAt) }},
+		{"updatedAt", func(c *domain.Company) string { return csvTime(c.UpdatedAt) }},
+	},
+	Defaults: []string{"id", "name", "domain", "industry", "lifecycleStage", "companySize", "ownerId", "createdAt"},
+}
+
+var dealCSVSchema = csvSchema[domain.Deal]{
+	Columns: []csvColumn[domain.Deal]{
+		{"id", func(d *domain.Deal) string { return d.ID }},
+		{"name", func(d *domain.Deal) string { return csvText(d.Name) }},
+		{"pipelineId", func(d *domain.Deal) string { return d.PipelineID }},
+		{"stageId", func(d *domain.Deal) string { return csvID(d.StageID) }},
+		{"stage", func(d *domain.Deal) string { return string(d.Stage) }},
+		{"value", func(d *domain.Deal) string { return csvFloatPtr(d.Value) }},
+		{"currency", func(d *domain.Deal) string { return d.Currency }},
+		{"probability", func(d *domain.Deal) string {
+			if d.Probability == nil {
+				return ""
+			}
+			return strconv.Itoa(int(*d.Probability))
+		}},
+		{"forecastCategory", func(d *domain.Deal) string { return string(d.ForecastCategory) }},
+		{"expectedCloseDate", func(d *domain.Deal) string { return csvTimePtr(d.ExpectedCloseDate) }},
+		{"closedAt", func(d *domain.Deal) string { return csvTimePtr(d.ClosedAt) }},
+		{"rottingSince", func(d *domain.Deal) string { return csvTimePtr(d.RottingSince) }},
+		{"ownerId", func(d *domain.Deal) string { return csvID(d.OwnerID) }},
+		{"contactId", func(d *domain.Deal) string { return csvID(d.ContactID) }},
+		{"companyId", func(d *domain.Deal) string { return csvID(d.CompanyID) }},
+		{"source", func(d *domain.Deal) string { return csvTextPtr(d.Source) }},
+		{"createdAt", func(d *domain.Deal) string { return csvTime(d.CreatedAt) }},
+		{"updatedAt", func(d *domain.Deal) string { return csvTime(d.UpdatedAt) }},
+	},
+	Defaults: []string{"id", "name", "pipelineId", "stageId", "stage", "value", "currency", "ownerId", "expectedCloseDate", "createdAt"},
+}
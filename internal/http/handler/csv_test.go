@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsCSV(t *testing.T) {
+	cases := map[string]bool{
+		"":                                 false,
+		"application/json":                 false,
+		"*/*":                              false,
+		"text/csv":                         true,
+		"application/json, text/csv;q=0.9": true,
+		"text/csv;q=0":                     false,
+	}
+	for accept, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+		req.Header.Set("Accept", accept)
+		assert.Equal(t, want, wantsCSV(req), "Accept: %q", accept)
+	}
+}
+
+func TestWriteCSV_AllPagesAndColumns(t *testing.T) {
+	pages := map[string][]domain.Contact{
+		"":   {{ID: "ct_1", FullName: "Ana", Email: "ana@example.com"}},
+		"c2": {{ID: "ct_2", FullName: "=HYPERLINK()", Email: "x@example.com"}},
+	}
+	next := map[string]*string{"": strPtr("c2"), "c2": nil}
+	fetch := func(cursor *string) ([]domain.Contact, *string, error) {
+		key := ""
+		if cursor != nil {
+			key = *cursor
+		}
+		return pages[key], next[key], nil
+	}
+
+	t.Run("single page exposes next cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/contacts?columns=id,fullName", nil)
+		rec := httptest.NewRecorder()
+
+		writeCSV(rec, req, "contacts.csv", contactCSVSchema, nil, fetch)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, csvContentType, rec.Header().Get("Content-Type"))
+		assert.Equal(t, "c2", rec.Header().Get("X-Next-Cursor"))
+		assert.Equal(t, "id,fullName\nct_1,Ana\n", rec.Body.String())
+	})
+
+	t.Run("all=true streams every page and neutralizes formulas", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/contacts?columns=id,fullName&all=true", nil)
+		rec := httptest.NewRecorder()
+
+		writeCSV(rec, req, "contacts.csv", contactCSVSchema, nil, fetch)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("X-Next-Cursor"))
+		assert.Equal(t, "id,fullName\nct_1,Ana\nct_2,'=HYPERLINK()\n", rec.Body.String())
+	})
+
+	t.Run("unknown column is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/contacts?columns=id,password", nil)
+		rec := httptest.NewRecorder()
+
+		writeCSV(rec, req, "contacts.csv", contactCSVSchema, nil, fetch)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func strPtr(s string) *string { return &s }
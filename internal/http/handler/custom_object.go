@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type CustomObjectHandler struct {
+	service *service.CustomObjectService
+}
+
+func NewCustomObjectHandler(service *service.CustomObjectService) *CustomObjectHandler {
+	return &CustomObjectHandler{service: service}
+}
+
+// ListObjectTypes handles GET /v1/workspaces/{workspaceId}/object-types
+func (h *CustomObjectHandler) ListObjectTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	objectTypes, err := h.service.ListObjectTypes(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.CustomObjectTypeListResponse{Data: objectTypes})
+}
+
+// CreateObjectType handles POST /v1/workspaces/{workspaceId}/object-types
+func (h *CustomObjectHandler) CreateObjectType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateCustomObjectTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	objectType, err := h.service.CreateObjectType(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, objectType)
+}
+
+// GetObjectType handles GET /v1/workspaces/{workspaceId}/object-types/{objectKey}
+func (h *CustomObjectHandler) GetObjectType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	objectType, err := h.service.GetObjectType(ctx, workspaceID, objectKey, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, objectType)
+}
+
+// UpdateObjectType handles PATCH /v1/workspaces/{workspaceId}/object-types/{objectKey}
+func (h *CustomObjectHandler) UpdateObjectType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateCustomObjectTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	objectType, err := h.service.UpdateObjectType(ctx, workspaceID, objectKey, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, objectType)
+}
+
+// DeleteObjectType handles DELETE /v1/workspaces/{workspaceId}/object-types/{objectKey}
+func (h *CustomObjectHandler) DeleteObjectType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteObjectType(ctx, workspaceID, objectKey, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListRecords handles GET /v1/workspaces/{workspaceId}/objects/{objectKey}
+func (h *CustomObjectHandler) ListRecords(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	var params domain.ListCustomObjectRecordsParams
+
+	if search := q.Get("q"); search != "" {
+		params.Query = &search
+	}
+
+	if cursorStr := q.Get("cursor"); cursorStr != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, cursorStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "cursor must be an RFC3339 timestamp")
+			return
+		}
+		params.Cursor = &cursor
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 200")
+			return
+		}
+		params.Limit = limit
+	}
+
+	// ?filter[campo]=valor compara o valor do campo por igualdade
+	filters := map[string]string{}
+	for name, values := range q {
+		if key, ok := strings.CutPrefix(name, "filter["); ok && strings.HasSuffix(key, "]") {
+			filters[strings.TrimSuffix(key, "]")] = values[0]
+		}
+	}
+
+	records, err := h.service.ListRecords(ctx, workspaceID, objectKey, claims.ActorID, params, filters)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, records)
+}
+
+// CreateRecord handles POST /v1/workspaces/{workspaceId}/objects/{objectKey}
+func (h *CustomObjectHandler) CreateRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateCustomObjectRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	// Os campos de data dependem da definição do objeto
+	objectType, err := h.service.GetObjectType(ctx, workspaceID, objectKey, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+	if err := objectType.ValidateData(req.Data, false); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	record, err := h.service.CreateRecord(ctx, workspaceID, claims.ActorID, objectType, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, record)
+}
+
+// GetRecord handles GET /v1/workspaces/{workspaceId}/objects/{objectKey}/{recordId}
+func (h *CustomObjectHandler) GetRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+	recordID := chi.URLParam(r, "recordId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	record, err := h.service.GetRecord(ctx, workspaceID, objectKey, recordID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, record)
+}
+
+// UpdateRecord handles PATCH /v1/workspaces/{workspaceId}/objects/{objectKey}/{recordId}
+func (h *CustomObjectHandler) UpdateRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+	recordID := chi.URLParam(r, "recordId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateCustomObjectRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	objectType, err := h.service.GetObjectType(ctx, workspaceID, objectKey, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+	if err := objectType.ValidateData(req.Data, true); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	record, err := h.service.UpdateRecord(ctx, workspaceID, recordID, claims.ActorID, objectType, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, record)
+}
+
+// DeleteRecord handles DELETE /v1/workspaces/{workspaceId}/objects/{objectKey}/{recordId}
+func (h *CustomObjectHandler) DeleteRecord(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	objectKey := chi.URLParam(r, "objectKey")
+	recordID := chi.URLParam(r, "recordId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteRecord(ctx, workspaceID, objectKey, recordID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
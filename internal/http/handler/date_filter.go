@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+)
+
+// dateFilter lê os filtros de data da query. Instantes RFC3339 valem como estão; datas YYYY-MM-DD
+// usam o fuso da request: ?tz= (IANA) ou, sem ele, o fuso padrão (consultado só quando alguma data
+// precisa dele). Os limites saem em UTC, como as colunas TIMESTAMP são gravadas.
+type dateFilter struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	fallback func(ctx context.Context) (*time.Location, error)
+	loc      *time.Location
+}
+
+// newDateFilter valida ?tz=. fallback é o fuso do workspace (nil = UTC).
+func newDateFilter(w http.ResponseWriter, r *http.Request, fallback func(ctx context.Context) (*time.Location, error)) (*dateFilter, bool) {
+	f := &dateFilter{w: w, r: r, fallback: fallback}
+	if tz := r.URL.Query().Get("tz"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter, "tz must be a valid IANA time zone")
+			return nil, false
+		}
+		f.loc = loc
+	}
+	return f, true
+}
+
+// location fuso da request.
+func (f *dateFilter) location() (*time.Location, bool) {
+	if f.loc != nil {
+		return f.loc, true
+	}
+	if f.fallback == nil {
+		f.loc = time.UTC
+		return f.loc, true
+	}
+	ctx := f.r.Context()
+	loc, err := f.fallback(ctx)
+	if err != nil {
+		handleServiceError(f.w, ctx, logger.GetLogger(ctx), err)
+		return nil, false
+	}
+	f.loc = loc
+	return loc, true
+}
+
+// start lê o parâmetro como limite inicial (inclusivo); nil quando ausente.
+func (f *dateFilter) start(name string) (*time.Time, bool) {
+	return f.bound(name, false)
+}
+
+// end lê o parâmetro como limite final (exclusivo); uma data inclui o dia inteiro.
+func (f *dateFilter) end(name string) (*time.Time, bool) {
+	return f.bound(name, true)
+}
+
+func (f *dateFilter) bound(name string, end bool) (*time.Time, bool) {
+	raw := f.r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, true
+	}
+	b, err := domain.ParseDateBound(raw)
+	if err != nil {
+		httperr.BadRequest400(f.w, f.r.Context(), httperr.ErrCodeInvalidParameter, name+" must be an RFC3339 timestamp or a YYYY-MM-DD date")
+		return nil, false
+	}
+
+	var loc *time.Location
+	if b.IsDate() {
+		var ok bool
+		if loc, ok = f.location(); !ok {
+			return nil, false
+		}
+	}
+	t := b.Start(loc)
+	if end {
+		t = b.End(loc)
+	}
+	return &t, true
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateFilter_DatesUseRequestTimezone(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/reports/revenue?from=2026-10-18&to=2026-10-18&tz=America/Sao_Paulo", nil)
+	dates, ok := newDateFilter(httptest.NewRecorder(), req, nil)
+	require.True(t, ok)
+
+	from, ok := dates.start("from")
+	require.True(t, ok)
+	to, ok := dates.end("to")
+	require.True(t, ok)
+
+	// Dia inteiro em BRT (UTC-3)
+	assert.Equal(t, time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC), *from)
+	assert.Equal(t, time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC), *to)
+}
+
+func TestDateFilter_FallbackOnlyForDates(t *testing.T) {
+	calls := 0
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	fallback := func(context.Context) (*time.Location, error) {
+		calls++
+		return tokyo, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/time-entries?from=2026-10-18T12:00:00-03:00", nil)
+	dates, ok := newDateFilter(httptest.NewRecorder(), req, fallback)
+	require.True(t, ok)
+	from, ok := dates.start("from")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC), *from)
+	assert.Equal(t, 0, calls)
+
+	req = httptest.NewRequest(http.MethodGet, "/time-entries?from=2026-10-18", nil)
+	dates, ok = newDateFilter(httptest.NewRecorder(), req, fallback)
+	require.True(t, ok)
+	from, ok = dates.start("from")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC), *from)
+	assert.Equal(t, 1, calls)
+
+	missing, ok := dates.end("to")
+	assert.True(t, ok)
+	assert.Nil(t, missing)
+}
+
+func TestDateFilter_RejectsInvalidValues(t *testing.T) {
+	rec := httptest.NewRecorder()
+	_, ok := newDateFilter(rec, httptest.NewRequest(http.MethodGet, "/reports/time?tz=Mars/Olympus", nil), nil)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	dates, ok := newDateFilter(rec, httptest.NewRequest(http.MethodGet, "/reports/time?from=18/10/2026", nil), nil)
+	require.True(t, ok)
+	_, ok = dates.start("from")
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "from must be an RFC3339 timestamp or a YYYY-MM-DD date")
+}
@@ -3,8 +3,9 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"linkko-api/internal/domain"
 	"linkko-api/internal/auth"
@@ -13,7 +14,6 @@ import (
 	"linkko-api/internal/service"
 
 	"github.com/go-chi/chi/v5"
-	"go.uber.org/zap"
 )
 
 type DealHandler struct {
@@ -38,15 +38,54 @@ func (h *DealHandler) CreateDeal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	applyClientSource(ctx, &req.Attribution)
+
 	deal, err := h.service.CreateDeal(ctx, workspaceID, actorID, &req)
 	if err != nil {
-		handleDealError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
 	writeOK(w, http.StatusCreated, deal)
 }
 
+// UpsertDealByExternalID handles PUT /v1/workspaces/{workspaceId}/deals/by-external-id/{externalId}
+// Corpo igual ao de CreateDeal. 201 quando cria, 200 quando atualiza o deal existente; num deal
+// existente pipeline e etapa não mudam (use :move).
+func (h *DealHandler) UpsertDealByExternalID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	claims, _ := auth.GetClaims(ctx)
+	actorID := claims.ActorID
+
+	var req domain.CreateDealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid JSON body")
+		return
+	}
+
+	externalID, ok := upsertExternalID(w, r, req.ExternalID)
+	if !ok {
+		return
+	}
+	system, ok := upsertSystem(w, r)
+	if !ok {
+		return
+	}
+
+	applyClientSource(ctx, &req.Attribution)
+
+	deal, created, err := h.service.UpsertDealByExternalID(ctx, workspaceID, externalID, actorID, system, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeOK(w, upsertStatus(created), deal)
+}
+
 func (h *DealHandler) GetDeal(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := logger.GetLogger(ctx)
@@ -56,9 +95,30 @@ func (h *DealHandler) GetDeal(w http.ResponseWriter, r *http.Request) {
 	claims, _ := auth.GetClaims(ctx)
 	actorID := claims.ActorID
 
+	linked, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+	expandParticipants, ok := parseDealExpand(w, r)
+	if !ok {
+		return
+	}
+
 	deal, err := h.service.GetDeal(ctx, workspaceID, dealID, actorID)
 	if err != nil {
-		handleDealError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	if expandParticipants {
+		if err := h.service.AttachParticipants(ctx, workspaceID, []*domain.Deal{deal}); err != nil {
+			handleServiceError(w, ctx, log, err)
+			return
+		}
+	}
+
+	if linked {
+		writeOK(w, http.StatusOK, newLinkBuilder(r).deal(deal))
 		return
 	}
 
@@ -82,15 +142,126 @@ func (h *DealHandler) ListDeals(w http.ResponseWriter, r *http.Request) {
 	if stageID != "" { sID = &stageID }
 	if ownerID != "" { oID = &ownerID }
 
-	deals, err := h.service.ListDeals(ctx, workspaceID, actorID, pID, sID, oID)
+	// rotting=true: somente deals parados (rottingSince preenchido)
+	rottingOnly := r.URL.Query().Get("rotting") == "true"
+
+	// following=true: somente deals seguidos pelo usuário autenticado
+	var fID *string
+	if r.URL.Query().Get("following") == "true" {
+		fID = &actorID
+	}
+
+	// q: busca no nome do deal e nos nomes do contato e da empresa
+	var query *string
+	if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
+		query = &q
+	}
+
+	// groupBy: buckets agregados no SQL em vez das linhas (somente JSON)
+	if groupBy := r.URL.Query().Get("groupBy"); groupBy != "" {
+		params := domain.DealAggregateParams{
+			GroupBy:     domain.DealGroupBy(groupBy),
+			PipelineID:  pID,
+			StageID:     sID,
+			OwnerID:     oID,
+			FollowerID:  fID,
+			Query:       query,
+			RottingOnly: rottingOnly,
+			Attribution: parseAttributionFilter(r),
+		}
+		h.aggregateDeals(w, r, workspaceID, actorID, params)
+		return
+	}
+	if r.URL.Query().Get("aggregate") != "" {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "aggregate requires groupBy")
+		return
+	}
+
+	// Listagem de deals não é paginada: o CSV já contém o conjunto completo
+	if wantsCSV(r) {
+		writeCSV(w, r, "deals.csv", dealCSVSchema, nil, func(_ *string) ([]domain.Deal, *string, error) {
+			deals, err := h.service.ListDeals(ctx, workspaceID, actorID, pID, sID, oID, fID, query, rottingOnly, parseAttributionFilter(r))
+			return deals, nil, err
+		})
+		return
+	}
+
+	linked, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+	expandParticipants, ok := parseDealExpand(w, r)
+	if !ok {
+		return
+	}
+
+	deals, err := h.service.ListDeals(ctx, workspaceID, actorID, pID, sID, oID, fID, query, rottingOnly, parseAttributionFilter(r))
 	if err != nil {
-		handleDealError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	if expandParticipants {
+		ptrs := make([]*domain.Deal, len(deals))
+		for i := range deals {
+			ptrs[i] = &deals[i]
+		}
+		if err := h.service.AttachParticipants(ctx, workspaceID, ptrs); err != nil {
+			handleServiceError(w, ctx, log, err)
+			return
+		}
+	}
+
+	if linked {
+		writeOK(w, http.StatusOK, newLinkBuilder(r).deals(deals))
 		return
 	}
 
 	writeOK(w, http.StatusOK, deals)
 }
 
+// aggregateDeals responde GET /deals?groupBy=owner|stage|month&aggregate=count,sum(value).
+func (h *DealHandler) aggregateDeals(w http.ResponseWriter, r *http.Request, workspaceID, actorID string, params domain.DealAggregateParams) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	if !params.GroupBy.IsValid() {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "groupBy must be one of: owner, stage, month")
+		return
+	}
+	if wantsCSV(r) {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "groupBy is not supported with CSV output")
+		return
+	}
+	aggregates, err := domain.ParseDealAggregates(r.URL.Query().Get("aggregate"))
+	if err != nil {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	params.Aggregates = aggregates
+
+	// Meses no fuso de ?tz= ou do workspace
+	dates, ok := newDateFilter(w, r, func(ctx context.Context) (*time.Location, error) {
+		return h.service.WorkspaceLocation(ctx, workspaceID)
+	})
+	if !ok {
+		return
+	}
+	if params.GroupBy == domain.DealGroupByMonth {
+		if params.Location, ok = dates.location(); !ok {
+			return
+		}
+	}
+
+	aggregation, err := h.service.AggregateDeals(ctx, workspaceID, actorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeOK(w, http.StatusOK, aggregation)
+}
+
 func (h *DealHandler) UpdateDeal(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := logger.GetLogger(ctx)
@@ -100,15 +271,30 @@ func (h *DealHandler) UpdateDeal(w http.ResponseWriter, r *http.Request) {
 	claims, _ := auth.GetClaims(ctx)
 	actorID := claims.ActorID
 
+	extended, ok := parseReturn(w, r)
+	if !ok {
+		return
+	}
+
 	var req domain.UpdateDealRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid JSON body")
 		return
 	}
 
+	if extended {
+		result, err := h.service.UpdateDealExtended(ctx, workspaceID, dealID, actorID, &req)
+		if err != nil {
+			handleServiceError(w, ctx, log, err)
+			return
+		}
+		writeOK(w, http.StatusOK, result)
+		return
+	}
+
 	deal, err := h.service.UpdateDeal(ctx, workspaceID, dealID, actorID, &req)
 	if err != nil {
-		handleDealError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -124,15 +310,30 @@ func (h *DealHandler) UpdateDealStage(w http.ResponseWriter, r *http.Request) {
 	claims, _ := auth.GetClaims(ctx)
 	actorID := claims.ActorID
 
+	extended, ok := parseReturn(w, r)
+	if !ok {
+		return
+	}
+
 	var req domain.UpdateDealStageRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid JSON body")
 		return
 	}
 
+	if extended {
+		result, err := h.service.UpdateDealStageExtended(ctx, workspaceID, dealID, actorID, &req)
+		if err != nil {
+			handleServiceError(w, ctx, log, err)
+			return
+		}
+		writeOK(w, http.StatusOK, result)
+		return
+	}
+
 	deal, err := h.service.UpdateDealStage(ctx, workspaceID, dealID, actorID, &req)
 	if err != nil {
-		handleDealError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -148,16 +349,3 @@ func writeOK(w http.ResponseWriter, status int, data interface{}) {
 		"data": data,
 	})
 }
-
-func handleDealError(w http.ResponseWriter, ctx context.Context, log *logger.Logger, err error) {
-	switch {
-	case errors.Is(err, service.ErrDealNotFound):
-		httperr.WriteError(w, ctx, http.StatusNotFound, "NOT_FOUND", "deal not found")
-	case errors.Is(err, service.ErrUnauthorized):
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions")
-	default:
-		log.Error(ctx, "internal error", zap.Error(err))
-		httperr.InternalError500(w, ctx, "an internal error occurred")
-	}
-}
-
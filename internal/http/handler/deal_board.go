@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// GetDealBoard handles GET /v1/workspaces/{workspaceId}/deals/board?pipelineId=
+// Colunas por etapa do pipeline com as preferências do usuário (ordem, recolhidas, ocultas).
+func (h *DealHandler) GetDealBoard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	params := domain.DealBoardParams{PipelineID: r.URL.Query().Get("pipelineId")}
+	if params.PipelineID == "" {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "pipelineId is required")
+		return
+	}
+
+	if limitStr := r.URL.Query().Get("limitPerColumn"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limitPerColumn must be between 1 and 100")
+			return
+		}
+		params.Limit = limit
+	}
+
+	board, err := h.service.GetDealBoard(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeOK(w, http.StatusOK, board)
+}
+
+// UpdateBoardPreferences handles PUT /v1/workspaces/{workspaceId}/deals/board/preferences
+func (h *DealHandler) UpdateBoardPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateBoardPreferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	prefs, err := h.service.UpdateBoardPreferences(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeOK(w, http.StatusOK, prefs)
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type DealParticipantHandler struct {
+	service *service.DealParticipantService
+}
+
+func NewDealParticipantHandler(service *service.DealParticipantService) *DealParticipantHandler {
+	return &DealParticipantHandler{service: service}
+}
+
+// ListParticipants handles GET /v1/workspaces/{workspaceId}/deals/{dealId}/participants
+func (h *DealParticipantHandler) ListParticipants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	dealID := chi.URLParam(r, "dealId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	participants, err := h.service.ListParticipants(ctx, workspaceID, dealID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.DealParticipantListResponse{Data: participants})
+}
+
+// AddParticipant handles POST /v1/workspaces/{workspaceId}/deals/{dealId}/participants
+func (h *DealParticipantHandler) AddParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	dealID := chi.URLParam(r, "dealId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.AddDealParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	participant, err := h.service.AddParticipant(ctx, workspaceID, dealID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, participant)
+}
+
+// UpdateParticipant handles PATCH /v1/workspaces/{workspaceId}/deals/{dealId}/participants/{contactId}
+func (h *DealParticipantHandler) UpdateParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	dealID := chi.URLParam(r, "dealId")
+	contactID := chi.URLParam(r, "contactId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateDealParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	participant, err := h.service.UpdateParticipant(ctx, workspaceID, dealID, contactID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, participant)
+}
+
+// RemoveParticipant handles DELETE /v1/workspaces/{workspaceId}/deals/{dealId}/participants/{contactId}
+func (h *DealParticipantHandler) RemoveParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	dealID := chi.URLParam(r, "dealId")
+	contactID := chi.URLParam(r, "contactId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.RemoveParticipant(ctx, workspaceID, dealID, contactID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseDealExpand lê ?expand= (lista separada por vírgula) dos endpoints de deals;
+// ok é false (400 já escrito) para valores desconhecidos.
+func parseDealExpand(w http.ResponseWriter, r *http.Request) (participants bool, ok bool) {
+	raw := r.URL.Query().Get("expand")
+	if raw == "" {
+		return false, true
+	}
+	for _, v := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(v) {
+		case domain.DealExpandParticipants:
+			participants = true
+		default:
+			httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter, "expand must be participants")
+			return false, false
+		}
+	}
+	return participants, true
+}
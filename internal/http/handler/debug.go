@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-	"os"
 	"time"
 
 	"linkko-api/internal/auth"
@@ -30,9 +29,8 @@ type DebugHandler struct {
 	pool   DBPool
 }
 
-// NewDebugHandler creates a new debug handler
-func NewDebugHandler(pool *pgxpool.Pool) *DebugHandler {
-	appEnv := os.Getenv("APP_ENV")
+// NewDebugHandler creates a new debug handler; appEnv is the configured APP_ENV
+func NewDebugHandler(pool *pgxpool.Pool, appEnv string) *DebugHandler {
 	if appEnv == "" {
 		appEnv = "production" // default to production for safety
 	}
@@ -50,15 +48,16 @@ type DebugAuthResponse struct {
 
 // DebugAuthData contains authentication information for debugging
 type DebugAuthData struct {
-	AuthMethod              string  `json:"authMethod"`                      // "jwt" or "s2s"
-	Client                  *string `json:"client,omitempty"`                // S2S client name (e.g., "crm", "mcp")
-	ActorID                 string  `json:"actorId"`                         // User or service ID
-	ActorType               string  `json:"actorType"`                       // "user" or "service"
-	WorkspaceIDFromToken    *string `json:"workspaceIdFromToken,omitempty"`  // From JWT claim
-	WorkspaceIDFromHeader   *string `json:"workspaceIdFromHeader,omitempty"` // From X-Workspace-Id header (S2S)
-	WorkspaceIDFromPath     *string `json:"workspaceIdFromPath,omitempty"`   // From URL path parameter
-	TokenIssuer             *string `json:"tokenIssuer,omitempty"`           // JWT issuer
-	WorkspaceValidationPass bool    `json:"workspaceValidationPass"`         // Whether workspace middleware validated successfully
+	AuthMethod              string   `json:"authMethod"`                      // "jwt" or "s2s"
+	Client                  *string  `json:"client,omitempty"`                // S2S client name (e.g., "crm", "mcp")
+	ActorID                 string   `json:"actorId"`                         // User or service ID
+	ActorType               string   `json:"actorType"`                       // "user" or "service"
+	WorkspaceIDFromToken    *string  `json:"workspaceIdFromToken,omitempty"`  // From JWT claim
+	WorkspacesFromToken     []string `json:"workspacesFromToken,omitempty"`   // All workspaces granted by the JWT
+	WorkspaceIDFromHeader   *string  `json:"workspaceIdFromHeader,omitempty"` // From X-Workspace-Id header (S2S)
+	WorkspaceIDFromPath     *string  `json:"workspaceIdFromPath,omitempty"`   // From URL path parameter
+	TokenIssuer             *string  `json:"tokenIssuer,omitempty"`           // JWT issuer
+	WorkspaceValidationPass bool     `json:"workspaceValidationPass"`         // Whether workspace middleware validated successfully
 }
 
 // GetAuthDebug returns authentication information for debugging
@@ -103,6 +102,7 @@ func (h *DebugHandler) GetAuthDebug(w http.ResponseWriter, r *http.Request) {
 	// Populate fields based on auth method
 	if authCtx.AuthMethod == "jwt" {
 		data.WorkspaceIDFromToken = &authCtx.WorkspaceID
+		data.WorkspacesFromToken = authCtx.Workspaces
 		if authCtx.Issuer != "" {
 			data.TokenIssuer = &authCtx.Issuer
 		}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 
 	"linkko-api/internal/auth"
@@ -19,13 +18,7 @@ import (
 )
 
 func TestDebugHandler_GetAuthDebug_ProductionBlocked(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "production")
-
-	handler := NewDebugHandler(nil)
+	handler := NewDebugHandler(nil, "production")
 
 	log, _ := logger.New("test", "info")
 	req := httptest.NewRequest("GET", "/debug/auth", nil)
@@ -48,13 +41,7 @@ func TestDebugHandler_GetAuthDebug_ProductionBlocked(t *testing.T) {
 }
 
 func TestDebugHandler_GetAuthDebug_DevAllowed(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "dev")
-
-	handler := NewDebugHandler(nil)
+	handler := NewDebugHandler(nil, "dev")
 
 	log, _ := logger.New("test", "info")
 	req := httptest.NewRequest("GET", "/debug/auth", nil)
@@ -92,13 +79,7 @@ func TestDebugHandler_GetAuthDebug_DevAllowed(t *testing.T) {
 }
 
 func TestDebugHandler_GetAuthDebug_NoAuth(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "dev")
-
-	handler := NewDebugHandler(nil)
+	handler := NewDebugHandler(nil, "dev")
 
 	log, _ := logger.New("test", "info")
 	req := httptest.NewRequest("GET", "/debug/auth", nil)
@@ -121,13 +102,7 @@ func TestDebugHandler_GetAuthDebug_NoAuth(t *testing.T) {
 }
 
 func TestDebugHandler_GetAuthDebug_JWTAuth(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "development") // Test with "development" as well
-
-	handler := NewDebugHandler(nil)
+	handler := NewDebugHandler(nil, "development") // Test with "development" as well
 
 	log, _ := logger.New("test", "info")
 	req := httptest.NewRequest("GET", "/debug/auth", nil)
@@ -165,13 +140,7 @@ func TestDebugHandler_GetAuthDebug_JWTAuth(t *testing.T) {
 }
 
 func TestDebugHandler_GetAuthDebug_S2SAuth(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "dev")
-
-	handler := NewDebugHandler(nil)
+	handler := NewDebugHandler(nil, "dev")
 
 	log, _ := logger.New("test", "info")
 	req := httptest.NewRequest("GET", "/debug/auth", nil)
@@ -209,13 +178,7 @@ func TestDebugHandler_GetAuthDebug_S2SAuth(t *testing.T) {
 }
 
 func TestDebugHandler_GetAuthDebugWithWorkspace(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "dev")
-
-	handler := NewDebugHandler(nil)
+	handler := NewDebugHandler(nil, "dev")
 
 	// Create router to test path parameter extraction
 	r := chi.NewRouter()
@@ -254,20 +217,8 @@ func TestDebugHandler_GetAuthDebugWithWorkspace(t *testing.T) {
 }
 
 func TestDebugHandler_DefaultAppEnv(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer func() {
-		if originalEnv != "" {
-			os.Setenv("APP_ENV", originalEnv)
-		} else {
-			os.Unsetenv("APP_ENV")
-		}
-	}()
-
-	// Unset APP_ENV to test default behavior
-	os.Unsetenv("APP_ENV")
-
-	handler := NewDebugHandler(nil)
+	// Empty APP_ENV to test default behavior
+	handler := NewDebugHandler(nil, "")
 
 	// Default should be "production" for safety
 	assert.Equal(t, "production", handler.appEnv)
@@ -292,12 +243,6 @@ func TestDebugHandler_DefaultAppEnv(t *testing.T) {
 }
 
 func TestPingDB_Success_DevMode(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "dev")
-
 	// Create a mock pool that succeeds
 	mockPool := &mockPgxPool{
 		shouldFail: false,
@@ -326,12 +271,6 @@ func TestPingDB_Success_DevMode(t *testing.T) {
 }
 
 func TestPingDB_Failure_DevMode(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "dev")
-
 	// Create a mock pool that fails
 	mockPool := &mockPgxPool{
 		shouldFail: true,
@@ -361,12 +300,6 @@ func TestPingDB_Failure_DevMode(t *testing.T) {
 }
 
 func TestPingDB_ProductionMode(t *testing.T) {
-	// Save original APP_ENV and restore after test
-	originalEnv := os.Getenv("APP_ENV")
-	defer os.Setenv("APP_ENV", originalEnv)
-
-	os.Setenv("APP_ENV", "production")
-
 	mockPool := &mockPgxPool{
 		shouldFail: false,
 	}
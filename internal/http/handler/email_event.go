@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type EmailEventHandler struct {
+	service *service.EmailEventService
+}
+
+func NewEmailEventHandler(service *service.EmailEventService) *EmailEventHandler {
+	return &EmailEventHandler{service: service}
+}
+
+// IngestEvents handles POST /v1/workspaces/{workspaceId}/email-events.
+// Somente credenciais S2S (o provedor de envio); o cliente S2S é o provider padrão.
+func (h *EmailEventHandler) IngestEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+	authCtx, ok := auth.GetAuthContext(ctx)
+	if !ok || authCtx.AuthMethod != "s2s" {
+		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "email events can only be ingested with service credentials")
+		return
+	}
+
+	var req domain.IngestEmailEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	result, err := h.service.IngestEvents(ctx, workspaceID, claims.ActorID, authCtx.Client, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// handleServiceError é o único mapeamento de erros de serviço usado pelos handlers.
+// Os erros de domínio carregam código/status via apperr; o que não está no catálogo
+// é logado como erro inesperado e vira 500.
+func handleServiceError(w http.ResponseWriter, ctx context.Context, log *logger.Logger, err error) {
+	if _, ok := apperr.From(err); !ok {
+		log.Error(ctx, "unexpected service error", zap.Error(err))
+	}
+	httperr.WriteAppError(w, ctx, err)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// upsertExternalID lê o {externalId} de PUT .../by-external-id/{externalId}. O externalId do
+// corpo é opcional, mas se enviado precisa ser o mesmo do path. Responde 400 e retorna
+// ok=false quando inválido. Com path codificado (ex.: %2F), o chi entrega o parâmetro ainda
+// codificado; a chave gravada é a decodificada.
+func upsertExternalID(w http.ResponseWriter, r *http.Request, bodyExternalID *string) (string, bool) {
+	raw, err := url.PathUnescape(chi.URLParam(r, "externalId"))
+	if err != nil {
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter, "externalId is not a valid path segment")
+		return "", false
+	}
+	externalID, ok := domain.NormalizeExternalID(raw)
+	if !ok {
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter,
+			"externalId must have between 1 and "+strconv.Itoa(domain.ExternalIDMaxLength)+" characters")
+		return "", false
+	}
+	if bodyExternalID != nil && strings.TrimSpace(*bodyExternalID) != externalID {
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter, "externalId in the body must match the path")
+		return "", false
+	}
+	return externalID, true
+}
+
+// upsertSystem lê o ?system= opcional do upsert: a integração que envia o registro, cuja política
+// de conflito (/sync-policies) decide os campos gravados por outros sistemas. Vazio quando
+// ausente; responde 400 e retorna ok=false quando inválido.
+func upsertSystem(w http.ResponseWriter, r *http.Request) (string, bool) {
+	raw := r.URL.Query().Get("system")
+	if raw == "" {
+		return "", true
+	}
+	system, ok := domain.NormalizeSyncSystem(raw)
+	if !ok {
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter,
+			"system must have between 1 and "+strconv.Itoa(domain.SyncSystemMaxLength)+" characters")
+		return "", false
+	}
+	return system, true
+}
+
+// upsertStatus 201 quando o upsert criou o registro, 200 quando atualizou
+func upsertStatus(created bool) int {
+	if created {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertExternalID(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		body     *string
+		wantID   string
+		wantCode int
+	}{
+		{name: "path only", path: "crm-42", wantID: "crm-42", wantCode: http.StatusOK},
+		{name: "trimmed", path: "  crm-42 ", wantID: "crm-42", wantCode: http.StatusOK},
+		{name: "matching body", path: "crm-42", body: strPtr(" crm-42"), wantID: "crm-42", wantCode: http.StatusOK},
+		{name: "different body", path: "crm-42", body: strPtr("crm-43"), wantCode: http.StatusBadRequest},
+		{name: "blank", path: "   ", wantCode: http.StatusBadRequest},
+		{name: "dotted", path: "erp.cliente.42", wantID: "erp.cliente.42", wantCode: http.StatusOK},
+		{name: "email style", path: "ana@example.com", wantID: "ana@example.com", wantCode: http.StatusOK},
+		{name: "namespaced", path: "sap:0001/42", wantID: "sap:0001/42", wantCode: http.StatusOK},
+		{name: "percent encoded", path: "sap%3A0001%2F42", wantID: "sap:0001/42", wantCode: http.StatusOK},
+		{name: "bad escape", path: "crm%zz", wantCode: http.StatusBadRequest},
+		{name: "200 chars", path: strings.Repeat("x", 200), wantID: strings.Repeat("x", 200), wantCode: http.StatusOK},
+		{name: "max length", path: strings.Repeat("x", 255), wantID: strings.Repeat("x", 255), wantCode: http.StatusOK},
+		{name: "too long", path: strings.Repeat("x", 256), wantCode: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("externalId", tc.path)
+			req := httptest.NewRequest(http.MethodPut, "/contacts/by-external-id/x", nil)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			externalID, ok := upsertExternalID(rec, req, tc.body)
+
+			assert.Equal(t, tc.wantCode == http.StatusOK, ok)
+			assert.Equal(t, tc.wantID, externalID)
+			assert.Equal(t, tc.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestUpsertSystem(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		wantSystem string
+		wantCode   int
+	}{
+		{name: "absent", query: "", wantSystem: "", wantCode: http.StatusOK},
+		{name: "normalized", query: "?system=%20ERP%20", wantSystem: "erp", wantCode: http.StatusOK},
+		{name: "blank", query: "?system=%20%20", wantCode: http.StatusBadRequest},
+		{name: "too long", query: "?system=" + strings.Repeat("x", 101), wantCode: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/contacts/by-external-id/x"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			system, ok := upsertSystem(rec, req)
+
+			assert.Equal(t, tc.wantCode == http.StatusOK, ok)
+			assert.Equal(t, tc.wantSystem, system)
+			assert.Equal(t, tc.wantCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type FieldHistoryHandler struct {
+	service *service.FieldHistoryService
+}
+
+func NewFieldHistoryHandler(service *service.FieldHistoryService) *FieldHistoryHandler {
+	return &FieldHistoryHandler{service: service}
+}
+
+// ContactHistory handles GET /v1/workspaces/{workspaceId}/contacts/{contactId}/history
+func (h *FieldHistoryHandler) ContactHistory(w http.ResponseWriter, r *http.Request) {
+	h.listHistory(w, r, domain.HistoryEntityContact, chi.URLParam(r, "contactId"))
+}
+
+// CompanyHistory handles GET /v1/workspaces/{workspaceId}/companies/{companyId}/history
+func (h *FieldHistoryHandler) CompanyHistory(w http.ResponseWriter, r *http.Request) {
+	h.listHistory(w, r, domain.HistoryEntityCompany, chi.URLParam(r, "companyId"))
+}
+
+// DealHistory handles GET /v1/workspaces/{workspaceId}/deals/{dealId}/history
+func (h *FieldHistoryHandler) DealHistory(w http.ResponseWriter, r *http.Request) {
+	h.listHistory(w, r, domain.HistoryEntityDeal, chi.URLParam(r, "dealId"))
+}
+
+func (h *FieldHistoryHandler) listHistory(w http.ResponseWriter, r *http.Request, entityType domain.HistoryEntityType, entityID string) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	params := domain.ListFieldHistoryParams{EntityType: entityType, EntityID: entityID}
+	if field := q.Get("field"); field != "" {
+		params.Field = &field
+	}
+
+	if cursorStr := q.Get("cursor"); cursorStr != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, cursorStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "cursor must be an RFC3339 timestamp")
+			return
+		}
+		params.Cursor = &cursor
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 200")
+			return
+		}
+		params.Limit = limit
+	}
+
+	history, err := h.service.ListHistory(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+}
@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type FollowerHandler struct {
+	service *service.FollowerService
+}
+
+func NewFollowerHandler(service *service.FollowerService) *FollowerHandler {
+	return &FollowerHandler{service: service}
+}
+
+// ContactFollowers handles GET /v1/workspaces/{workspaceId}/contacts/{contactId}/followers
+func (h *FollowerHandler) ContactFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollowers(w, r, domain.FollowEntityContact, chi.URLParam(r, "contactId"))
+}
+
+// FollowContact handles POST /v1/workspaces/{workspaceId}/contacts/{contactId}/:follow
+func (h *FollowerHandler) FollowContact(w http.ResponseWriter, r *http.Request) {
+	h.follow(w, r, domain.FollowEntityContact, chi.URLParam(r, "contactId"))
+}
+
+// UnfollowContact handles POST /v1/workspaces/{workspaceId}/contacts/{contactId}/:unfollow
+func (h *FollowerHandler) UnfollowContact(w http.ResponseWriter, r *http.Request) {
+	h.unfollow(w, r, domain.FollowEntityContact, chi.URLParam(r, "contactId"))
+}
+
+// DealFollowers handles GET /v1/workspaces/{workspaceId}/deals/{dealId}/followers
+func (h *FollowerHandler) DealFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollowers(w, r, domain.FollowEntityDeal, chi.URLParam(r, "dealId"))
+}
+
+// FollowDeal handles POST /v1/workspaces/{workspaceId}/deals/{dealId}/:follow
+func (h *FollowerHandler) FollowDeal(w http.ResponseWriter, r *http.Request) {
+	h.follow(w, r, domain.FollowEntityDeal, chi.URLParam(r, "dealId"))
+}
+
+// UnfollowDeal handles POST /v1/workspaces/{workspaceId}/deals/{dealId}/:unfollow
+func (h *FollowerHandler) UnfollowDeal(w http.ResponseWriter, r *http.Request) {
+	h.unfollow(w, r, domain.FollowEntityDeal, chi.URLParam(r, "dealId"))
+}
+
+// TaskFollowers handles GET /v1/workspaces/{workspaceId}/tasks/{taskId}/followers
+func (h *FollowerHandler) TaskFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollowers(w, r, domain.FollowEntityTask, chi.URLParam(r, "taskId"))
+}
+
+// FollowTask handles POST /v1/workspaces/{workspaceId}/tasks/{taskId}/:follow
+func (h *FollowerHandler) FollowTask(w http.ResponseWriter, r *http.Request) {
+	h.follow(w, r, domain.FollowEntityTask, chi.URLParam(r, "taskId"))
+}
+
+// UnfollowTask handles POST /v1/workspaces/{workspaceId}/tasks/{taskId}/:unfollow
+func (h *FollowerHandler) UnfollowTask(w http.ResponseWriter, r *http.Request) {
+	h.unfollow(w, r, domain.FollowEntityTask, chi.URLParam(r, "taskId"))
+}
+
+func (h *FollowerHandler) listFollowers(w http.ResponseWriter, r *http.Request, entityType domain.FollowEntityType, entityID string) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	followers, err := h.service.ListFollowers(ctx, workspaceID, entityType, entityID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.FollowerListResponse{Data: followers})
+}
+
+func (h *FollowerHandler) follow(w http.ResponseWriter, r *http.Request, entityType domain.FollowEntityType, entityID string) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	follower, err := h.service.Follow(ctx, workspaceID, entityType, entityID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, follower)
+}
+
+func (h *FollowerHandler) unfollow(w http.ResponseWriter, r *http.Request, entityType domain.FollowEntityType, entityID string) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.Unfollow(ctx, workspaceID, entityType, entityID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListNotifications handles GET /v1/workspaces/{workspaceId}/notifications
+func (h *FollowerHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	params := domain.ListNotificationsParams{UnreadOnly: q.Get("unread") == "true"}
+
+	if cursorStr := q.Get("cursor"); cursorStr != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, cursorStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "cursor must be an RFC3339 timestamp")
+			return
+		}
+		params.Cursor = &cursor
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 200")
+			return
+		}
+		params.Limit = limit
+	}
+
+	notifications, err := h.service.ListNotifications(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, notifications)
+}
+
+// MarkNotificationsRead handles POST /v1/workspaces/{workspaceId}/notifications/:mark-read
+// Body opcional: sem ids, marca todas as notificações do usuário.
+func (h *FollowerHandler) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.MarkNotificationsReadRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn(ctx, "invalid request body", zap.Error(err))
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	result, err := h.service.MarkNotificationsRead(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// GetNotificationPreferences handles GET /v1/workspaces/{workspaceId}/me/notification-preferences
+func (h *FollowerHandler) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	prefs, err := h.service.GetNotificationPreferences(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prefs)
+}
+
+// UpdateNotificationPreferences handles PATCH /v1/workspaces/{workspaceId}/me/notification-preferences
+func (h *FollowerHandler) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateNotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	prefs, err := h.service.UpdateNotificationPreferences(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prefs)
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type InvoiceHandler struct {
+	service *service.InvoiceService
+}
+
+func NewInvoiceHandler(service *service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{service: service}
+}
+
+// ListInvoices handles GET /v1/workspaces/{workspaceId}/invoices
+func (h *InvoiceHandler) ListInvoices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	var params domain.InvoiceListParams
+	if v := q.Get("dealId"); v != "" {
+		params.DealID = &v
+	}
+	if v := q.Get("status"); v != "" {
+		status := domain.InvoiceStatus(v)
+		if !status.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "status must be one of: OPEN, PAID, VOID, UNCOLLECTIBLE")
+			return
+		}
+		params.Status = &status
+	}
+
+	invoices, err := h.service.ListInvoices(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.InvoiceListResponse{Data: invoices})
+}
+
+// CreateInvoice handles POST /v1/workspaces/{workspaceId}/invoices
+func (h *InvoiceHandler) CreateInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateInvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	invoice, err := h.service.CreateInvoice(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, invoice)
+}
+
+// GetInvoice handles GET /v1/workspaces/{workspaceId}/invoices/{invoiceId}
+func (h *InvoiceHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	invoice, err := h.service.GetInvoice(ctx, workspaceID, invoiceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, invoice)
+}
+
+// UpdateInvoice handles PATCH /v1/workspaces/{workspaceId}/invoices/{invoiceId}
+func (h *InvoiceHandler) UpdateInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateInvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	invoice, err := h.service.UpdateInvoice(ctx, workspaceID, invoiceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, invoice)
+}
+
+// DeleteInvoice handles DELETE /v1/workspaces/{workspaceId}/invoices/{invoiceId}
+func (h *InvoiceHandler) DeleteInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteInvoice(ctx, workspaceID, invoiceID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReconcileInvoices handles POST /v1/workspaces/{workspaceId}/invoices/:reconcile.
+// Somente credenciais S2S (o provedor de pagamento); o cliente S2S é o provider padrão.
+func (h *InvoiceHandler) ReconcileInvoices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+	authCtx, ok := auth.GetAuthContext(ctx)
+	if !ok || authCtx.AuthMethod != "s2s" {
+		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "invoices can only be reconciled with service credentials")
+		return
+	}
+
+	var req domain.ReconcileInvoicesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	result, err := h.service.ReconcileInvoices(ctx, workspaceID, claims.ActorID, authCtx.Client, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
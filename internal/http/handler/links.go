@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Modo format=linked (opt-in): contatos, empresas e negócios ganham um objeto _links
+// no estilo HAL com self e relacionamentos (company, contact, pipeline, owner).
+// Listas paginadas também trazem _links.self/_links.next.
+// Os hrefs são relativos ao host e respeitam a versão da rota (/v1, /v2).
+//
+// Não há endpoint de usuários: o link owner aponta para a coleção filtrada pelo responsável.
+
+// halLink é um link hipermídia.
+type halLink struct {
+	Href string `json:"href"`
+}
+
+// halLinks mapeia relação → link.
+type halLinks map[string]halLink
+
+// parseFormat lê ?format=; ok é false (400 já escrito) para valores desconhecidos.
+func parseFormat(w http.ResponseWriter, r *http.Request) (linked bool, ok bool) {
+	switch r.URL.Query().Get("format") {
+	case "":
+		return false, true
+	case "linked":
+		return true, true
+	default:
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter, "format must be linked")
+		return false, false
+	}
+}
+
+// linkBuilder monta hrefs sob /{version}/workspaces/{workspaceId}.
+type linkBuilder struct {
+	base string
+}
+
+func newLinkBuilder(r *http.Request) linkBuilder {
+	version, ok := middleware.GetAPIVersion(r.Context())
+	if !ok {
+		version = "v1"
+	}
+	return linkBuilder{base: "/" + version + "/workspaces/" + url.PathEscape(chi.URLParam(r, "workspaceId"))}
+}
+
+func (b linkBuilder) resource(collection, id string) halLink {
+	return halLink{Href: b.base + "/" + collection + "/" + url.PathEscape(id)}
+}
+
+func (b linkBuilder) filtered(collection, param, value string) halLink {
+	return halLink{Href: b.base + "/" + collection + "?" + url.Values{param: {value}}.Encode()}
+}
+
+// pageLinks retorna self e, havendo próxima página, next (mesma query com o novo cursor).
+func pageLinks(r *http.Request, hasNext bool, nextCursor *string) halLinks {
+	links := halLinks{"self": {Href: r.URL.RequestURI()}}
+	if hasNext && nextCursor != nil {
+		q := r.URL.Query()
+		q.Set("cursor", *nextCursor)
+		links["next"] = halLink{Href: r.URL.Path + "?" + q.Encode()}
+	}
+	return links
+}
+
+// Recursos com links
+
+type linkedContact struct {
+	*domain.Contact
+	Links halLinks `json:"_links"`
+}
+
+func (b linkBuilder) contact(c *domain.Contact) linkedContact {
+	links := halLinks{
+		"self":     b.resource("contacts", c.ID),
+		"timeline": b.filtered("timeline", "contactId", c.ID),
+	}
+	if c.CompanyID != nil {
+		links["company"] = b.resource("companies", *c.CompanyID)
+	}
+	if c.ActorID != "" {
+		links["owner"] = b.filtered("contacts", "actorId", c.ActorID)
+	}
+	return linkedContact{Contact: c, Links: links}
+}
+
+func (b linkBuilder) contacts(cs []domain.Contact) []linkedContact {
+	out := make([]linkedContact, len(cs))
+	for i := range cs {
+		out[i] = b.contact(&cs[i])
+	}
+	return out
+}
+
+type linkedCompany struct {
+	*domain.Company
+	Links halLinks `json:"_links"`
+}
+
+func (b linkBuilder) company(c *domain.Company) linkedCompany {
+	links := halLinks{
+		"self":     b.resource("companies", c.ID),
+		"contacts": b.filtered("contacts", "companyId", c.ID),
+		"timeline": b.filtered("timeline", "companyId", c.ID),
+	}
+	if c.OwnerID != "" {
+		links["owner"] = b.filtered("companies", "ownerId", c.OwnerID)
+	}
+	return linkedCompany{Company: c, Links: links}
+}
+
+func (b linkBuilder) companies(cs []domain.Company) []linkedCompany {
+	out := make([]linkedCompany, len(cs))
+	for i := range cs {
+		out[i] = b.company(&cs[i])
+	}
+	return out
+}
+
+type linkedDeal struct {
+	*domain.Deal
+	Links halLinks `json:"_links"`
+}
+
+func (b linkBuilder) deal(d *domain.Deal) linkedDeal {
+	links := halLinks{
+		"self":         b.resource("deals", d.ID),
+		"pipeline":     b.resource("pipelines", d.PipelineID),
+		"timeline":     b.filtered("timeline", "dealId", d.ID),
+		"participants": halLink{Href: b.resource("deals", d.ID).Href + "/participants"},
+	}
+	if d.CompanyID != nil {
+		links["company"] = b.resource("companies", *d.CompanyID)
+	}
+	if d.ContactID != nil {
+		links["contact"] = b.resource("contacts", *d.ContactID)
+	}
+	if d.OwnerID != nil {
+		links["owner"] = b.filtered("deals", "ownerId", *d.OwnerID)
+	}
+	return linkedDeal{Deal: d, Links: links}
+}
+
+func (b linkBuilder) deals(ds []domain.Deal) []linkedDeal {
+	out := make([]linkedDeal, len(ds))
+	for i := range ds {
+		out[i] = b.deal(&ds[i])
+	}
+	return out
+}
+
+// linkedPage é a resposta de listas paginadas no modo linked.
+type linkedPage struct {
+	Data  interface{} `json:"data"`
+	Meta  interface{} `json:"meta"`
+	Links halLinks    `json:"_links"`
+}
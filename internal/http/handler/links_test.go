@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func linkedRequest(target string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("workspaceId", "ws_1")
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestLinkBuilder_Deal(t *testing.T) {
+	b := newLinkBuilder(linkedRequest("/v1/workspaces/ws_1/deals/dl_1?format=linked"))
+	companyID, ownerID := "co_1", "usr_1"
+
+	body, err := json.Marshal(b.deal(&domain.Deal{ID: "dl_1", PipelineID: "pl_1", CompanyID: &companyID, OwnerID: &ownerID}))
+	require.NoError(t, err)
+
+	var got struct {
+		ID    string   `json:"id"`
+		Links halLinks `json:"_links"`
+	}
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "dl_1", got.ID)
+	assert.Equal(t, "/v1/workspaces/ws_1/deals/dl_1", got.Links["self"].Href)
+	assert.Equal(t, "/v1/workspaces/ws_1/pipelines/pl_1", got.Links["pipeline"].Href)
+	assert.Equal(t, "/v1/workspaces/ws_1/companies/co_1", got.Links["company"].Href)
+	assert.Equal(t, "/v1/workspaces/ws_1/deals?ownerId=usr_1", got.Links["owner"].Href)
+	assert.NotContains(t, got.Links, "contact")
+}
+
+func TestPageLinks(t *testing.T) {
+	req := linkedRequest("/v1/workspaces/ws_1/contacts?format=linked&limit=10")
+	next := "c2"
+
+	links := pageLinks(req, true, &next)
+	assert.Equal(t, "/v1/workspaces/ws_1/contacts?format=linked&limit=10", links["self"].Href)
+	assert.Equal(t, "/v1/workspaces/ws_1/contacts?cursor=c2&format=linked&limit=10", links["next"].Href)
+
+	assert.NotContains(t, pageLinks(req, false, nil), "next")
+}
+
+func TestParseFormat_RejectsUnknownValue(t *testing.T) {
+	rec := httptest.NewRecorder()
+	_, ok := parseFormat(rec, linkedRequest("/v1/workspaces/ws_1/contacts?format=hal"))
+
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// MaintenanceHandler liga e desliga o modo de manutenção (rotas /admin, protegidas por ADMIN_TOKEN)
+type MaintenanceHandler struct {
+	service *service.MaintenanceService
+}
+
+func NewMaintenanceHandler(service *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service: service}
+}
+
+// ListMaintenance handles GET /admin/maintenance
+func (h *MaintenanceHandler) ListMaintenance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	maintenances, err := h.service.List(ctx)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": maintenances})
+}
+
+// EnableMaintenance handles PUT /admin/maintenance and PUT /admin/workspaces/{workspaceId}/maintenance
+func (h *MaintenanceHandler) EnableMaintenance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	scope, ok := maintenanceScope(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.SetMaintenanceRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn(ctx, "invalid request body", zap.Error(err))
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	maintenance, err := h.service.Enable(ctx, scope, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": maintenance})
+}
+
+// DisableMaintenance handles DELETE /admin/maintenance and DELETE /admin/workspaces/{workspaceId}/maintenance
+func (h *MaintenanceHandler) DisableMaintenance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	scope, ok := maintenanceScope(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Disable(ctx, scope); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// maintenanceScope o workspace do path ou, nas rotas sem workspace, a manutenção global
+func maintenanceScope(w http.ResponseWriter, r *http.Request) (string, bool) {
+	workspaceID := chi.URLParam(r, "workspaceId")
+	if workspaceID == "" {
+		return domain.MaintenanceScopeGlobal, true
+	}
+	if !domain.IsValidID(workspaceID) {
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidWorkspaceID, "invalid ID format in path")
+		return "", false
+	}
+	return workspaceID, true
+}
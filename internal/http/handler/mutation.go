@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+)
+
+// Modo return=extended (opt-in): mutações de board (mover/editar negócio, mover tarefa)
+// devolvem, além do registro alterado, os totais das colunas de origem e destino em
+// "aggregates", para a UI otimista não recarregar o board inteiro.
+
+// parseReturn lê ?return=; ok é false (400 já escrito) para valores desconhecidos.
+func parseReturn(w http.ResponseWriter, r *http.Request) (extended bool, ok bool) {
+	switch r.URL.Query().Get("return") {
+	case "":
+		return false, true
+	case domain.ReturnExtended:
+		return true, true
+	default:
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter, "return must be extended")
+		return false, false
+	}
+}
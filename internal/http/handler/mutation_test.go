@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReturn(t *testing.T) {
+	tests := []struct {
+		query    string
+		extended bool
+		ok       bool
+	}{
+		{"", false, true},
+		{"return=extended", true, true},
+		{"return=", false, true},
+		{"return=EXTENDED", false, false},
+		{"return=minimal", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/v1/workspaces/ws_1/deals/deal_1?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			extended, ok := parseReturn(rec, req)
+			assert.Equal(t, tt.extended, extended)
+			assert.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MyWorkHandler struct {
+	service *service.MyWorkService
+}
+
+func NewMyWorkHandler(service *service.MyWorkService) *MyWorkHandler {
+	return &MyWorkHandler{service: service}
+}
+
+// GetMyWork handles GET /v1/workspaces/{workspaceId}/me/work
+func (h *MyWorkHandler) GetMyWork(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var params domain.MyWorkParams
+	if tz := r.URL.Query().Get("tz"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "tz must be a valid IANA time zone")
+			return
+		}
+		params.Location = loc
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 100")
+			return
+		}
+		params.Limit = limit
+	}
+
+	work, err := h.service.GetMyWork(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, work)
+}
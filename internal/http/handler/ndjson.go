@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/i18n"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// Streaming NDJSON (Accept: application/x-ndjson) para listagens grandes demais para um
+// envelope JSON: um objeto por linha, enviado com chunked transfer encoding à medida que as
+// linhas saem do banco, sem montar a lista inteira em memória.
+//
+// Erros antes da primeira linha seguem o formato JSON padrão. Depois disso o status 200 já
+// foi enviado: o stream termina com uma linha de erro no formato do envelope
+// ({"ok":false,"error":{...}}), que o cliente distingue dos itens pela chave "ok".
+
+const ndjsonContentType = "application/x-ndjson"
+
+// ndjsonFlushEvery linhas entre flushes (o último flush acontece ao final do stream).
+const ndjsonFlushEvery = 100
+
+// wantsNDJSON reporta se o cliente pediu application/x-ndjson no Accept.
+func wantsNDJSON(r *http.Request) bool {
+	return acceptsMediaType(r, "application/x-ndjson")
+}
+
+// ndjsonWriter codifica um item por linha; o primeiro item envia os headers e o 200.
+type ndjsonWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	enc     *json.Encoder
+	started bool
+	lines   int
+}
+
+func newNDJSONWriter(w http.ResponseWriter) *ndjsonWriter {
+	return &ndjsonWriter{w: w, rc: http.NewResponseController(w), enc: json.NewEncoder(w)}
+}
+
+func (nw *ndjsonWriter) start() {
+	if nw.started {
+		return
+	}
+	nw.started = true
+	nw.w.Header().Set("Content-Type", ndjsonContentType)
+	nw.w.Header().Set("Vary", "Accept")
+	nw.w.WriteHeader(http.StatusOK)
+}
+
+// Write codifica v como uma linha e faz flush a cada ndjsonFlushEvery linhas.
+func (nw *ndjsonWriter) Write(v any) error {
+	nw.start()
+	if err := nw.enc.Encode(v); err != nil {
+		return err
+	}
+	nw.lines++
+	if nw.lines%ndjsonFlushEvery == 0 {
+		return nw.flush()
+	}
+	return nil
+}
+
+func (nw *ndjsonWriter) flush() error {
+	if err := nw.rc.Flush(); err != nil && err != http.ErrNotSupported {
+		return err
+	}
+	return nil
+}
+
+// streamNDJSON executa stream, que chama emit uma vez por item, e trata o erro conforme
+// o stream já tenha começado ou não. name identifica o stream nos logs.
+func streamNDJSON[T any](w http.ResponseWriter, r *http.Request, name string, stream func(emit func(*T) error) error) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	nw := newNDJSONWriter(w)
+	err := stream(func(item *T) error { return nw.Write(item) })
+
+	switch {
+	case err == nil:
+		nw.start() // stream vazio: 200 sem linhas
+		_ = nw.flush()
+		log.Info(ctx, "ndjson stream completed", zap.String("stream", name), zap.Int("lines", nw.lines))
+	case !nw.started:
+		handleServiceError(w, ctx, log, err)
+	case ctx.Err() != nil:
+		// Cliente desconectou ou o deadline da request expirou: não há a quem responder
+		log.Warn(ctx, "ndjson stream interrupted", zap.String("stream", name), zap.Int("lines", nw.lines), zap.Error(ctx.Err()))
+	default:
+		log.Error(ctx, "ndjson stream aborted", zap.String("stream", name), zap.Int("lines", nw.lines), zap.Error(err))
+		detail := &httperr.ErrorDetail{Code: httperr.ErrCodeInternalError, Message: i18n.T(ctx, "Internal Server Error")}
+		if httperr.ExposeErrorID {
+			detail.ErrorID = logger.GetRequestIDFromContext(ctx)
+		}
+		_ = nw.enc.Encode(httperr.ErrorResponse{OK: false, Error: detail})
+		_ = nw.flush()
+	}
+}
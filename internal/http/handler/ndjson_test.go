@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsNDJSON(t *testing.T) {
+	cases := map[string]bool{
+		"":                                       false,
+		"application/json":                       false,
+		"*/*":                                    false,
+		"application/x-ndjson":                   true,
+		"application/json, application/x-ndjson": true,
+		"application/x-ndjson;q=0":               false,
+	}
+	for accept, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req.Header.Set("Accept", accept)
+		assert.Equal(t, want, wantsNDJSON(req), "Accept: %q", accept)
+	}
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	items := []domain.Activity{{ID: "act_1"}, {ID: "act_2"}}
+
+	t.Run("one object per line", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		rec := httptest.NewRecorder()
+
+		streamNDJSON(rec, req, "timeline", func(emit func(*domain.Activity) error) error {
+			for i := range items {
+				if err := emit(&items[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, ndjsonContentType, rec.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"id":"act_1"`)
+		assert.Contains(t, lines[1], `"id":"act_2"`)
+	})
+
+	t.Run("empty stream", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		rec := httptest.NewRecorder()
+
+		streamNDJSON(rec, req, "timeline", func(emit func(*domain.Activity) error) error { return nil })
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("error before first line uses the JSON envelope", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		rec := httptest.NewRecorder()
+
+		streamNDJSON(rec, req, "timeline", func(emit func(*domain.Activity) error) error {
+			return service.ErrUnauthorized
+		})
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("error mid-stream ends with an error line", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		rec := httptest.NewRecorder()
+
+		streamNDJSON(rec, req, "timeline", func(emit func(*domain.Activity) error) error {
+			if err := emit(&items[0]); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.JSONEq(t, `{"ok":false,"error":{"code":"INTERNAL_ERROR","message":"Internal Server Error"}}`, lines[1])
+	})
+}
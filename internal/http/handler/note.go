@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// GetNote handles GET /v1/workspaces/{workspaceId}/timeline/notes/{noteId}
+func (h *ActivityHandler) GetNote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	noteID := chi.URLParam(r, "noteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	note, err := h.service.GetNote(ctx, workspaceID, noteID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, note)
+}
+
+// UpdateNote handles PATCH /v1/workspaces/{workspaceId}/timeline/notes/{noteId}
+func (h *ActivityHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	noteID := chi.URLParam(r, "noteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	note, err := h.service.UpdateNote(ctx, workspaceID, noteID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, note)
+}
+
+// PinNote handles POST /v1/workspaces/{workspaceId}/timeline/notes/{noteId}/:pin
+func (h *ActivityHandler) PinNote(w http.ResponseWriter, r *http.Request) {
+	h.setNotePinned(w, r, true)
+}
+
+// UnpinNote handles POST /v1/workspaces/{workspaceId}/timeline/notes/{noteId}/:unpin
+func (h *ActivityHandler) UnpinNote(w http.ResponseWriter, r *http.Request) {
+	h.setNotePinned(w, r, false)
+}
+
+func (h *ActivityHandler) setNotePinned(w http.ResponseWriter, r *http.Request, pinned bool) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	noteID := chi.URLParam(r, "noteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	note, err := h.service.SetNotePinned(ctx, workspaceID, noteID, claims.ActorID, pinned)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, note)
+}
+
+// UploadNoteAttachment handles POST /v1/workspaces/{workspaceId}/timeline/notes/{noteId}/attachments
+// Recebe multipart/form-data com o arquivo no campo "file" e o transmite ao storage sem
+// carregá-lo em memória.
+func (h *ActivityHandler) UploadNoteAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	noteID := chi.URLParam(r, "noteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request must be multipart/form-data with a file field")
+		return
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Warn(ctx, "invalid multipart body", zap.Error(err))
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request must be multipart/form-data with a file field")
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		contentType := part.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		attachment, err := h.service.UploadNoteAttachment(ctx, workspaceID, noteID, claims.ActorID, part.FileName(), contentType, part)
+		part.Close()
+		if err != nil {
+			handleServiceError(w, ctx, log, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, attachment)
+		return
+	}
+
+	httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request must be multipart/form-data with a file field")
+}
+
+// DownloadNoteAttachment handles GET /v1/workspaces/{workspaceId}/timeline/notes/{noteId}/attachments/{attachmentId}
+// Sempre servido como download (Content-Disposition attachment + nosniff): o conteúdo
+// enviado pelo usuário nunca é renderizado inline pela API.
+func (h *ActivityHandler) DownloadNoteAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	noteID := chi.URLParam(r, "noteId")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	attachment, body, err := h.service.OpenNoteAttachment(ctx, workspaceID, noteID, attachmentID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+attachment.FileName+`"`)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, body); err != nil {
+		// Cabeçalhos já enviados: só resta registrar.
+		log.Warn(ctx, "note attachment download interrupted", zap.String("attachment_id", attachment.ID), zap.Error(err))
+	}
+}
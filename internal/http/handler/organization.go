@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type OrganizationHandler struct {
+	service *service.OrganizationService
+}
+
+func NewOrganizationHandler(service *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{service: service}
+}
+
+// ListOrganizations handles GET /v1/orgs
+func (h *OrganizationHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	orgs, err := h.service.ListOrganizations(ctx, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	// Token vinculado a uma organização só enxerga ela
+	if claims.OrgID != "" {
+		filtered := []domain.Organization{}
+		for _, org := range orgs {
+			if org.ID == claims.OrgID {
+				filtered = append(filtered, org)
+			}
+		}
+		orgs = filtered
+	}
+
+	writeJSON(w, http.StatusOK, &domain.OrganizationListResponse{Data: orgs})
+}
+
+// GetOrganization handles GET /v1/orgs/{orgId}
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	org, err := h.service.GetOrganization(ctx, orgID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, org)
+}
+
+// UpdateOrganization handles PATCH /v1/orgs/{orgId}
+func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	org, err := h.service.UpdateOrganization(ctx, orgID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, org)
+}
+
+// GetBilling handles GET /v1/orgs/{orgId}/billing
+func (h *OrganizationHandler) GetBilling(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	billing, err := h.service.GetBilling(ctx, orgID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, billing)
+}
+
+// ListWorkspaces handles GET /v1/orgs/{orgId}/workspaces
+func (h *OrganizationHandler) ListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	workspaces, err := h.service.ListWorkspaces(ctx, orgID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.OrganizationWorkspaceListResponse{Data: workspaces})
+}
+
+// ListMembers handles GET /v1/orgs/{orgId}/members
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	members, err := h.service.ListMembers(ctx, orgID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.OrganizationMemberListResponse{Data: members})
+}
+
+// SetMemberRole handles PUT /v1/orgs/{orgId}/members/{userId}
+func (h *OrganizationHandler) SetMemberRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+	userID := chi.URLParam(r, "userId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.SetOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	member, err := h.service.SetMemberRole(ctx, orgID, userID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, member)
+}
+
+// RemoveMember handles DELETE /v1/orgs/{orgId}/members/{userId}
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+	userID := chi.URLParam(r, "userId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.RemoveMember(ctx, orgID, userID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// OverviewReport handles GET /v1/orgs/{orgId}/reports/overview
+func (h *OrganizationHandler) OverviewReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	orgID := chi.URLParam(r, "orgId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	// Organização abrange vários workspaces: datas YYYY-MM-DD usam ?tz= ou UTC
+	dates, ok := newDateFilter(w, r, nil)
+	if !ok {
+		return
+	}
+	since := time.Now().UTC().AddDate(0, 0, -domain.DefaultOrganizationOverviewDays)
+	parsed, ok := dates.start("since")
+	if !ok {
+		return
+	}
+	if parsed != nil {
+		since = *parsed
+	}
+
+	report, err := h.service.OverviewReport(ctx, orgID, claims.ActorID, since)
+	if err != nil {
+		log.Error(ctx, "failed to build organization overview report",
+			zap.Error(err),
+			zap.String("orgId", orgID),
+			zap.String("actorId", claims.ActorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
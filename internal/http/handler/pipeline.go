@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -78,6 +76,24 @@ func (h *PipelineHandler) ListPipelines(w http.ResponseWriter, r *http.Request)
 		params.IsDefault = &isDefaultBool
 	}
 
+	if pipelineType := r.URL.Query().Get("pipelineType"); pipelineType != "" {
+		t := domain.PipelineType(pipelineType)
+		if !t.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "pipelineType must be one of: TASK, DEAL, TICKET, CONTACT")
+			return
+		}
+		params.PipelineType = &t
+	}
+
+	if isActive := r.URL.Query().Get("isActive"); isActive != "" {
+		isActiveBool := isActive == "true"
+		params.IsActive = &isActiveBool
+	}
+
+	if ownerID := r.URL.Query().Get("ownerId"); ownerID != "" {
+		params.OwnerID = &ownerID
+	}
+
 	if search := r.URL.Query().Get("q"); search != "" {
 		params.Query = &search
 	}
@@ -91,7 +107,7 @@ func (h *PipelineHandler) ListPipelines(w http.ResponseWriter, r *http.Request)
 
 	response, err := h.service.ListPipelines(ctx, workspaceID, actorID, params)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -139,9 +155,11 @@ func (h *PipelineHandler) GetPipeline(w http.ResponseWriter, r *http.Request) {
 		zap.String("actorId", actorID),
 	)
 
-	pipeline, err := h.service.GetPipeline(ctx, workspaceID, pipelineID, actorID)
+	includeMetrics := r.URL.Query().Get("includeMetrics") == "true"
+
+	pipeline, err := h.service.GetPipeline(ctx, workspaceID, pipelineID, actorID, includeMetrics)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -182,6 +200,12 @@ func (h *PipelineHandler) CreatePipeline(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
 	log.Info(ctx, "creating pipeline",
 		zap.String("workspaceId", workspaceID),
 		zap.String("actorId", actorID),
@@ -190,7 +214,7 @@ func (h *PipelineHandler) CreatePipeline(w http.ResponseWriter, r *http.Request)
 
 	pipeline, err := h.service.CreatePipeline(ctx, workspaceID, actorID, &req)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -231,6 +255,12 @@ func (h *PipelineHandler) CreatePipelineWithStages(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if err := req.Pipeline.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
 	log.Info(ctx, "creating pipeline with stages",
 		zap.String("workspaceId", workspaceID),
 		zap.String("actorId", actorID),
@@ -240,7 +270,7 @@ func (h *PipelineHandler) CreatePipelineWithStages(w http.ResponseWriter, r *htt
 
 	pipeline, err := h.service.CreatePipelineWithStages(ctx, workspaceID, actorID, &req)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -282,6 +312,12 @@ func (h *PipelineHandler) UpdatePipeline(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
 	log.Info(ctx, "updating pipeline",
 		zap.String("workspaceId", workspaceID),
 		zap.String("pipelineId", pipelineID),
@@ -290,7 +326,7 @@ func (h *PipelineHandler) UpdatePipeline(w http.ResponseWriter, r *http.Request)
 
 	pipeline, err := h.service.UpdatePipeline(ctx, workspaceID, pipelineID, actorID, &req)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -333,7 +369,7 @@ func (h *PipelineHandler) DeletePipeline(w http.ResponseWriter, r *http.Request)
 
 	err := h.service.DeletePipeline(ctx, workspaceID, pipelineID, actorID)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -374,7 +410,7 @@ func (h *PipelineHandler) SeedDefaultPipeline(w http.ResponseWriter, r *http.Req
 
 	pipeline, err := h.service.SeedDefaultPipeline(ctx, workspaceID, actorID)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -411,15 +447,18 @@ func (h *PipelineHandler) ListStages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	includeMetrics := r.URL.Query().Get("includeMetrics") == "true"
+
 	log.Info(ctx, "listing stages",
 		zap.String("workspaceId", workspaceID),
 		zap.String("pipelineId", pipelineID),
 		zap.String("actorId", actorID),
+		zap.Bool("includeMetrics", includeMetrics),
 	)
 
-	stages, err := h.service.ListStages(ctx, workspaceID, pipelineID, actorID)
+	stages, err := h.service.ListStages(ctx, workspaceID, pipelineID, actorID, includeMetrics)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -428,9 +467,7 @@ func (h *PipelineHandler) ListStages(w http.ResponseWriter, r *http.Request) {
 		zap.Int("count", len(stages)),
 	)
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"data": stages,
-	})
+	writeJSON(w, http.StatusOK, domain.PipelineStageListResponse{Data: stages})
 }
 
 // CreateStage handles POST /v1/workspaces/{workspaceId}/pipelines/{pipelineId}/stages
@@ -473,7 +510,7 @@ func (h *PipelineHandler) CreateStage(w http.ResponseWriter, r *http.Request) {
 
 	stage, err := h.service.CreateStage(ctx, workspaceID, pipelineID, actorID, &req)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -523,7 +560,7 @@ func (h *PipelineHandler) UpdateStage(w http.ResponseWriter, r *http.Request) {
 
 	stage, err := h.service.UpdateStage(ctx, workspaceID, stageID, actorID, &req)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -566,7 +603,7 @@ func (h *PipelineHandler) DeleteStage(w http.ResponseWriter, r *http.Request) {
 
 	err := h.service.DeleteStage(ctx, workspaceID, stageID, actorID)
 	if err != nil {
-		handlePipelineServiceError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -576,29 +613,3 @@ func (h *PipelineHandler) DeleteStage(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
-
-// handlePipelineServiceError maps service errors to HTTP responses
-func handlePipelineServiceError(w http.ResponseWriter, ctx context.Context, log *logger.Logger, err error) {
-	switch {
-	case errors.Is(err, service.ErrMemberNotFound):
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions for this workspace")
-	case errors.Is(err, service.ErrUnauthorized):
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions for this action")
-	case errors.Is(err, service.ErrPipelineNotFound):
-		httperr.WriteError(w, ctx, http.StatusNotFound, "NOT_FOUND", "pipeline not found")
-	case errors.Is(err, service.ErrPipelineNameConflict):
-		httperr.WriteError(w, ctx, http.StatusConflict, "CONFLICT", "pipeline with this name already exists")
-	case errors.Is(err, service.ErrStageNotFound):
-		httperr.WriteError(w, ctx, http.StatusNotFound, "NOT_FOUND", "stage not found")
-	case errors.Is(err, service.ErrStageNameConflict):
-		httperr.WriteError(w, ctx, http.StatusConflict, "CONFLICT", "stage with this name already exists in pipeline")
-	case errors.Is(err, service.ErrDefaultPipelineExists):
-		httperr.WriteError(w, ctx, http.StatusConflict, "CONFLICT", "another pipeline is already set as default")
-	case errors.Is(err, service.ErrCannotDeleteDefault):
-		httperr.WriteError(w, ctx, http.StatusUnprocessableEntity, "CANNOT_DELETE_DEFAULT", "cannot delete default pipeline; set another as default first")
-	default:
-		log.Error(ctx, "unexpected service error", zap.Error(err))
-		httperr.InternalError500(w, ctx, "an unexpected error occurred")
-	}
-}
-
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"linkko-api/internal/auth"
@@ -13,7 +11,6 @@ import (
 	"linkko-api/internal/service"
 
 	"github.com/go-chi/chi/v5"
-	"go.uber.org/zap"
 )
 
 type PortfolioHandler struct {
@@ -42,7 +39,7 @@ func (h *PortfolioHandler) CreatePortfolioItem(w http.ResponseWriter, r *http.Re
 
 	item, err := h.service.CreatePortfolioItem(ctx, workspaceID, actorID, &req)
 	if err != nil {
-		handlePortfolioError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -60,7 +57,7 @@ func (h *PortfolioHandler) GetPortfolioItem(w http.ResponseWriter, r *http.Reque
 
 	item, err := h.service.GetPortfolioItem(ctx, workspaceID, itemID, actorID)
 	if err != nil {
-		handlePortfolioError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -99,7 +96,7 @@ func (h *PortfolioHandler) ListPortfolioItems(w http.ResponseWriter, r *http.Req
 
 	items, err := h.service.ListPortfolioItems(ctx, workspaceID, actorID, status, category, query)
 	if err != nil {
-		handlePortfolioError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -123,7 +120,7 @@ func (h *PortfolioHandler) UpdatePortfolioItem(w http.ResponseWriter, r *http.Re
 
 	item, err := h.service.UpdatePortfolioItem(ctx, workspaceID, itemID, actorID, &req)
 	if err != nil {
-		handlePortfolioError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -140,7 +137,7 @@ func (h *PortfolioHandler) DeletePortfolioItem(w http.ResponseWriter, r *http.Re
 	actorID := claims.ActorID
 
 	if err := h.service.DeletePortfolioItem(ctx, workspaceID, itemID, actorID); err != nil {
-		handlePortfolioError(w, ctx, log, err)
+		handleServiceError(w, ctx, log, err)
 		return
 	}
 
@@ -156,13 +153,3 @@ func writeOKPortfolio(w http.ResponseWriter, status int, data interface{}) {
 		"data": data,
 	})
 }
-
-func handlePortfolioError(w http.ResponseWriter, ctx context.Context, log *logger.Logger, err error) {
-	switch {
-	case errors.Is(err, service.ErrUnauthorized):
-		httperr.Forbidden403(w, ctx, httperr.ErrCodeForbidden, "insufficient permissions")
-	default:
-		log.Error(ctx, "internal error", zap.Error(err))
-		httperr.InternalError500(w, ctx, "an internal error occurred")
-	}
-}
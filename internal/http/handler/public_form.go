@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// maxPublicFormBodyBytes limita o corpo aceito de visitantes anônimos
+const maxPublicFormBodyBytes = 64 << 10
+
+// Campos de controle da submissão; não entram no mapeamento
+const (
+	formTokenHeader = "X-Form-Token"
+	formTokenField  = "_formToken"
+	// renderedAtField instante em que o formulário foi exibido (unix em segundos ou milissegundos)
+	renderedAtField = "_renderedAt"
+)
+
+// captchaFields nomes usados pelos widgets para o token do desafio, em ordem de preferência
+var captchaFields = []string{"_captchaToken", "cf-turnstile-response", "h-captcha-response", "g-recaptcha-response"}
+
+type PublicFormHandler struct {
+	service *service.PublicFormService
+}
+
+func NewPublicFormHandler(service *service.PublicFormService) *PublicFormHandler {
+	return &PublicFormHandler{service: service}
+}
+
+// IssueToken handles POST /v1/workspaces/{workspaceId}/public-form-tokens
+func (h *PublicFormHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreatePublicFormTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	token, err := h.service.IssueToken(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusCreated, token)
+}
+
+// Submit handles POST /v1/public/forms/{formId}/submissions (anônimo: Form cadastrado ou form token).
+// Aceita JSON ou application/x-www-form-urlencoded (form HTML sem JavaScript); no segundo caso,
+// successRedirectUrl do formulário vira um 303.
+func (h *PublicFormHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	formID := chi.URLParam(r, "formId")
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPublicFormBodyBytes)
+	fields, err := readFormFields(r)
+	if err != nil {
+		log.Warn(ctx, "invalid form submission body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be a JSON object or an urlencoded form")
+		return
+	}
+
+	sub := &domain.PublicFormSubmission{
+		Token:    r.Header.Get(formTokenHeader),
+		RemoteIP: remoteIP(r),
+		Fields:   fields,
+	}
+	if sub.Token == "" {
+		sub.Token = fields[formTokenField]
+	}
+	delete(fields, formTokenField)
+	sub.RenderedAt = parseRenderedAt(fields[renderedAtField])
+	delete(fields, renderedAtField)
+	for _, name := range captchaFields {
+		if sub.CaptchaToken == "" {
+			sub.CaptchaToken = fields[name]
+		}
+		delete(fields, name)
+	}
+
+	result, err := h.service.Submit(ctx, formID, sub)
+	if err != nil {
+		var validationErr *service.FormValidationError
+		if errors.As(err, &validationErr) {
+			httperr.ValidationError422(w, ctx, validationErr.Err)
+			return
+		}
+		if errors.Is(err, service.ErrFormRateLimited) {
+			w.Header().Set("Retry-After", "60")
+		}
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	if result.RedirectURL != nil && isURLEncoded(r) {
+		http.Redirect(w, r, *result.RedirectURL, http.StatusSeeOther)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, result)
+}
+
+func isURLEncoded(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "application/x-www-form-urlencoded"
+}
+
+// parseRenderedAt aceita unix em segundos ou milissegundos; valor inválido é ignorado
+func parseRenderedAt(raw string) *time.Time {
+	n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || n <= 0 {
+		return nil
+	}
+	if n > 1e12 {
+		t := time.UnixMilli(n)
+		return &t
+	}
+	t := time.Unix(n, 0)
+	return &t
+}
+
+// readFormFields lê os campos como strings; valores JSON escalares são convertidos, objetos e listas rejeitados
+func readFormFields(r *http.Request) (map[string]string, error) {
+	if isURLEncoded(r) {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		fields := make(map[string]string, len(r.PostForm))
+		for name := range r.PostForm {
+			fields[name] = r.PostForm.Get(name)
+		}
+		return fields, nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string, len(raw))
+	for name, value := range raw {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			fields[name] = v
+		case float64:
+			fields[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			fields[name] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("field %q must be a scalar", name)
+		}
+	}
+	return fields, nil
+}
+
+// remoteIP IP do visitante repassado ao provedor de captcha
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
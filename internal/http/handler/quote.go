@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type QuoteHandler struct {
+	service *service.QuoteService
+}
+
+func NewQuoteHandler(service *service.QuoteService) *QuoteHandler {
+	return &QuoteHandler{service: service}
+}
+
+// ListQuotes handles GET /v1/workspaces/{workspaceId}/quotes
+func (h *QuoteHandler) ListQuotes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var dealID *string
+	if v := r.URL.Query().Get("dealId"); v != "" {
+		dealID = &v
+	}
+
+	quotes, err := h.service.ListQuotes(ctx, workspaceID, claims.ActorID, dealID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.QuoteListResponse{Data: quotes})
+}
+
+// CreateQuote handles POST /v1/workspaces/{workspaceId}/quotes
+func (h *QuoteHandler) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	quote, err := h.service.CreateQuote(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, quote)
+}
+
+// GetQuote handles GET /v1/workspaces/{workspaceId}/quotes/{quoteId}
+func (h *QuoteHandler) GetQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	quoteID := chi.URLParam(r, "quoteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	quote, err := h.service.GetQuote(ctx, workspaceID, quoteID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quote)
+}
+
+// DeleteQuote handles DELETE /v1/workspaces/{workspaceId}/quotes/{quoteId}
+func (h *QuoteHandler) DeleteQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	quoteID := chi.URLParam(r, "quoteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteQuote(ctx, workspaceID, quoteID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SendQuote handles POST /v1/workspaces/{workspaceId}/quotes/{quoteId}/:send
+func (h *QuoteHandler) SendQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	quoteID := chi.URLParam(r, "quoteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	quote, err := h.service.SendQuote(ctx, workspaceID, quoteID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quote)
+}
+
+// DownloadQuote handles GET /v1/workspaces/{workspaceId}/quotes/{quoteId}/:download
+func (h *QuoteHandler) DownloadQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	quoteID := chi.URLParam(r, "quoteId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	quote, body, err := h.service.OpenPDF(ctx, workspaceID, quoteID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+	defer body.Close()
+
+	writeQuotePDF(w, r, quote, body)
+}
+
+// ViewSharedQuote handles GET /v1/public/quotes/{token}
+// Primeira abertura marca o orçamento como VIEWED.
+func (h *QuoteHandler) ViewSharedQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	quote, err := h.service.ViewSharedQuote(ctx, chi.URLParam(r, "token"))
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, quote)
+}
+
+// DownloadSharedQuote handles GET /v1/public/quotes/{token}/:download
+func (h *QuoteHandler) DownloadSharedQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	quote, body, err := h.service.OpenSharedPDF(ctx, chi.URLParam(r, "token"))
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeQuotePDF(w, r, quote, body)
+}
+
+// AcceptSharedQuote handles POST /v1/public/quotes/{token}/:accept
+func (h *QuoteHandler) AcceptSharedQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	var req domain.AcceptQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	quote, err := h.service.AcceptSharedQuote(ctx, chi.URLParam(r, "token"), &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quote)
+}
+
+func writeQuotePDF(w http.ResponseWriter, r *http.Request, quote *domain.Quote, body io.Reader) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="quote-`+strconv.FormatInt(quote.Number, 10)+`.pdf"`)
+	if quote.PDFSizeBytes != nil {
+		w.Header().Set("Content-Length", strconv.FormatInt(*quote.PDFSizeBytes, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, body); err != nil {
+		// Cabeçalhos já enviados: só resta registrar.
+		logger.GetLogger(r.Context()).Warn(r.Context(), "quote download interrupted", zap.String("quote_id", quote.ID), zap.Error(err))
+	}
+}
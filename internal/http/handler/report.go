@@ -0,0 +1,427 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	service *service.ReportService
+}
+
+func NewReportHandler(service *service.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// dateFilter filtros de data no fuso de ?tz= ou do workspace.
+func (h *ReportHandler) dateFilter(w http.ResponseWriter, r *http.Request, workspaceID string) (*dateFilter, bool) {
+	return newDateFilter(w, r, func(ctx context.Context) (*time.Location, error) {
+		return h.service.WorkspaceLocation(ctx, workspaceID)
+	})
+}
+
+// AttributionReport handles GET /v1/workspaces/{workspaceId}/reports/attribution
+func (h *ReportHandler) AttributionReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	params := domain.AttributionReportParams{GroupBy: domain.AttributionBySource}
+	if groupBy := r.URL.Query().Get("groupBy"); groupBy != "" {
+		params.GroupBy = domain.AttributionGroupBy(groupBy)
+		if !params.GroupBy.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "groupBy must be one of: source, utmSource, utmMedium, utmCampaign")
+			return
+		}
+	}
+
+	dates, ok := h.dateFilter(w, r, workspaceID)
+	if !ok {
+		return
+	}
+	if params.From, ok = dates.start("from"); !ok {
+		return
+	}
+	if params.To, ok = dates.end("to"); !ok {
+		return
+	}
+
+	report, err := h.service.AttributionReport(ctx, workspaceID, actorID, params)
+	if err != nil {
+		log.Error(ctx, "failed to build attribution report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// OverdueReport handles GET /v1/workspaces/{workspaceId}/reports/overdue
+func (h *ReportHandler) OverdueReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var params domain.OverdueReportParams
+	if ownerID := r.URL.Query().Get("ownerId"); ownerID != "" {
+		params.OwnerID = &ownerID
+	}
+	if typeStr := r.URL.Query().Get("type"); typeStr != "" {
+		recordType := domain.OverdueRecordType(typeStr)
+		if !recordType.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "type must be one of: contact, deal")
+			return
+		}
+		params.Type = &recordType
+	}
+	if asOfStr := r.URL.Query().Get("asOf"); asOfStr != "" {
+		asOf, err := time.Parse(time.RFC3339, asOfStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "asOf must be an RFC3339 timestamp")
+			return
+		}
+		params.AsOf = asOf.UTC()
+	}
+
+	report, err := h.service.OverdueReport(ctx, workspaceID, actorID, params)
+	if err != nil {
+		log.Error(ctx, "failed to build overdue report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// TimeReport handles GET /v1/workspaces/{workspaceId}/reports/time
+func (h *ReportHandler) TimeReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	q := r.URL.Query()
+	params := domain.TimeReportParams{GroupBy: domain.TimeByUser}
+	if groupBy := q.Get("groupBy"); groupBy != "" {
+		params.GroupBy = domain.TimeReportGroupBy(groupBy)
+		if !params.GroupBy.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "groupBy must be one of: user, deal, task")
+			return
+		}
+	}
+	if userID := q.Get("userId"); userID != "" {
+		params.UserID = &userID
+	}
+	if dealID := q.Get("dealId"); dealID != "" {
+		params.DealID = &dealID
+	}
+	if billableStr := q.Get("billable"); billableStr != "" {
+		billable, err := strconv.ParseBool(billableStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "billable must be true or false")
+			return
+		}
+		params.Billable = &billable
+	}
+
+	dates, ok := h.dateFilter(w, r, workspaceID)
+	if !ok {
+		return
+	}
+	if params.From, ok = dates.start("from"); !ok {
+		return
+	}
+	if params.To, ok = dates.end("to"); !ok {
+		return
+	}
+
+	report, err := h.service.TimeReport(ctx, workspaceID, actorID, params)
+	if err != nil {
+		log.Error(ctx, "failed to build time report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// parseAttributionFilter lê os filtros de atribuição comuns às listagens.
+func parseAttributionFilter(r *http.Request) domain.AttributionFilter {
+	q := r.URL.Query()
+	optional := func(key string) *string {
+		if v := q.Get(key); v != "" {
+			return &v
+		}
+		return nil
+	}
+	return domain.AttributionFilter{
+		Source:      optional("source"),
+		UTMSource:   optional("utmSource"),
+		UTMMedium:   optional("utmMedium"),
+		UTMCampaign: optional("utmCampaign"),
+	}
+}
+
+// applyClientSource deriva o source do cliente S2S quando o payload não informou.
+// Requests JWT mantêm o default do service ("manual").
+func applyClientSource(ctx context.Context, a *domain.Attribution) {
+	authCtx, ok := auth.GetAuthContext(ctx)
+	if !ok || authCtx.AuthMethod != "s2s" || authCtx.Client == "" {
+		return
+	}
+	a.DefaultSource(authCtx.Client)
+}
+
+// SLABreachReport handles GET /v1/workspaces/{workspaceId}/reports/sla-breaches
+func (h *ReportHandler) SLABreachReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var params domain.SLABreachReportParams
+	if pipelineID := r.URL.Query().Get("pipelineId"); pipelineID != "" {
+		params.PipelineID = &pipelineID
+	}
+	if ownerID := r.URL.Query().Get("ownerId"); ownerID != "" {
+		params.OwnerID = &ownerID
+	}
+	if timerStr := r.URL.Query().Get("timer"); timerStr != "" {
+		timer := domain.SLATimerKind(timerStr)
+		if !timer.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "timer must be one of: firstResponse, resolution")
+			return
+		}
+		params.Timer = &timer
+	}
+	if asOfStr := r.URL.Query().Get("asOf"); asOfStr != "" {
+		asOf, err := time.Parse(time.RFC3339, asOfStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "asOf must be an RFC3339 timestamp")
+			return
+		}
+		params.AsOf = asOf.UTC()
+	}
+
+	report, err := h.service.SLABreachReport(ctx, workspaceID, actorID, params)
+	if err != nil {
+		log.Error(ctx, "failed to build sla breach report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// PipelineSummaryReport handles GET /v1/workspaces/{workspaceId}/reports/pipeline-summary
+func (h *ReportHandler) PipelineSummaryReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var params domain.PipelineSummaryParams
+	if pipelineID := r.URL.Query().Get("pipelineId"); pipelineID != "" {
+		params.PipelineID = &pipelineID
+	}
+	if ownerID := r.URL.Query().Get("ownerId"); ownerID != "" {
+		params.OwnerID = &ownerID
+	}
+	dates, ok := h.dateFilter(w, r, workspaceID)
+	if !ok {
+		return
+	}
+	since, ok := dates.start("since")
+	if !ok {
+		return
+	}
+	if since != nil {
+		params.Since = *since
+	}
+
+	report, err := h.service.PipelineSummaryReport(ctx, workspaceID, actorID, params)
+	if err != nil {
+		log.Error(ctx, "failed to build pipeline summary report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// RevenueReport handles GET /v1/workspaces/{workspaceId}/reports/revenue
+func (h *ReportHandler) RevenueReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	q := r.URL.Query()
+	params := domain.RevenueReportParams{GroupBy: domain.RevenueByMonth}
+	if groupBy := q.Get("groupBy"); groupBy != "" {
+		params.GroupBy = domain.RevenueReportGroupBy(groupBy)
+		if !params.GroupBy.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "groupBy must be one of: month, owner")
+			return
+		}
+	}
+
+	dates, ok := h.dateFilter(w, r, workspaceID)
+	if !ok {
+		return
+	}
+	if params.From, ok = dates.start("from"); !ok {
+		return
+	}
+	if params.To, ok = dates.end("to"); !ok {
+		return
+	}
+
+	// Meses no fuso da request
+	if params.GroupBy == domain.RevenueByMonth {
+		if params.Location, ok = dates.location(); !ok {
+			return
+		}
+	}
+
+	report, err := h.service.RevenueReport(ctx, workspaceID, actorID, params)
+	if err != nil {
+		log.Error(ctx, "failed to build revenue report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// ForecastReport handles GET /v1/workspaces/{workspaceId}/reports/forecast
+func (h *ReportHandler) ForecastReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var params domain.ForecastReportParams
+	if pipelineID := r.URL.Query().Get("pipelineId"); pipelineID != "" {
+		params.PipelineID = &pipelineID
+	}
+	if ownerID := r.URL.Query().Get("ownerId"); ownerID != "" {
+		params.OwnerID = &ownerID
+	}
+	dates, ok := h.dateFilter(w, r, workspaceID)
+	if !ok {
+		return
+	}
+	if params.From, ok = dates.start("from"); !ok {
+		return
+	}
+	if params.To, ok = dates.end("to"); !ok {
+		return
+	}
+
+	report, err := h.service.ForecastReport(ctx, workspaceID, actorID, params)
+	if err != nil {
+		log.Error(ctx, "failed to build forecast report",
+			zap.Error(err),
+			zap.String("workspaceId", workspaceID),
+			zap.String("actorId", actorID),
+		)
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
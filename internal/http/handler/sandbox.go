@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SandboxHandler struct {
+	service *service.SandboxService
+}
+
+func NewSandboxHandler(service *service.SandboxService) *SandboxHandler {
+	return &SandboxHandler{service: service}
+}
+
+// CloneWorkspace handles POST /v1/workspaces/{workspaceId}/:clone-to-sandbox
+func (h *SandboxHandler) CloneWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var req domain.CloneWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	log.Info(ctx, "cloning workspace to sandbox",
+		zap.String("workspaceId", workspaceID),
+		zap.String("targetWorkspaceId", req.TargetWorkspaceID),
+		zap.String("actorId", actorID),
+		zap.Bool("includeSampleData", req.IncludeSampleData),
+	)
+
+	result, err := h.service.CloneWorkspace(ctx, workspaceID, actorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	log.Info(ctx, "workspace cloned successfully",
+		zap.String("targetWorkspaceId", result.TargetWorkspaceID),
+		zap.Int("pipelinesCloned", result.PipelinesCloned),
+		zap.Int("contactsCloned", result.ContactsCloned),
+	)
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// PreviewPromotion handles GET /v1/workspaces/{workspaceId}/promotion-preview?targetWorkspaceId=
+func (h *SandboxHandler) PreviewPromotion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	req := domain.PromoteWorkspaceRequest{TargetWorkspaceID: r.URL.Query().Get("targetWorkspaceId")}
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	plan, err := h.service.PreviewPromotion(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, plan)
+}
+
+// Promote handles POST /v1/workspaces/{workspaceId}/:promote
+func (h *SandboxHandler) Promote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	actorID := claims.ActorID
+
+	var req domain.PromoteWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	log.Info(ctx, "promoting sandbox configuration",
+		zap.String("workspaceId", workspaceID),
+		zap.String("targetWorkspaceId", req.TargetWorkspaceID),
+		zap.String("actorId", actorID),
+	)
+
+	plan, err := h.service.Promote(ctx, workspaceID, actorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	log.Info(ctx, "sandbox configuration promoted",
+		zap.String("targetWorkspaceId", plan.TargetWorkspaceID),
+		zap.Int("created", plan.Summary.Create),
+		zap.Int("updated", plan.Summary.Update),
+	)
+
+	writeJSON(w, http.StatusOK, plan)
+}
@@ -0,0 +1,469 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/http/middleware"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ScimHandler struct {
+	service *service.ScimService
+}
+
+func NewScimHandler(service *service.ScimService) *ScimHandler {
+	return &ScimHandler{service: service}
+}
+
+// =====================================================
+// Configuração (/v1, JWT do admin do workspace)
+// =====================================================
+
+// GetConfig handles GET /v1/workspaces/{workspaceId}/scim
+func (h *ScimHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	cfg, err := h.service.GetConfig(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, cfg)
+}
+
+// RotateToken handles POST /v1/workspaces/{workspaceId}/scim/token
+func (h *ScimHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	// Corpo opcional: sem defaultRole mantém o atual
+	var req domain.RotateScimTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	result, err := h.service.RotateToken(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// RevokeToken handles DELETE /v1/workspaces/{workspaceId}/scim/token
+func (h *ScimHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.RevokeToken(ctx, workspaceID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListGroupRoles handles GET /v1/workspaces/{workspaceId}/scim/groups
+func (h *ScimHandler) ListGroupRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	groups, err := h.service.ListGroupRoles(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.ScimGroupListResponse{Data: groups})
+}
+
+// SetGroupRole handles PUT /v1/workspaces/{workspaceId}/scim/groups/{groupId}/role
+func (h *ScimHandler) SetGroupRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	groupID := chi.URLParam(r, "groupId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateScimGroupRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	group, err := h.service.SetGroupRole(ctx, workspaceID, groupID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, group)
+}
+
+// =====================================================
+// Diretório (/scim/v2, token SCIM via ScimAuthMiddleware)
+// =====================================================
+
+// ServiceProviderConfig handles GET /scim/v2/ServiceProviderConfig
+func (h *ScimHandler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeScimJSON(w, http.StatusOK, domain.NewScimServiceProviderConfig())
+}
+
+// ListUsers handles GET /scim/v2/Users
+func (h *ScimHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	params, ok := parseScimListParams(w, r, "userName", "externalId", "emails.value")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListUsers(ctx, workspaceID, params)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, result)
+}
+
+// CreateUser handles POST /scim/v2/Users
+func (h *ScimHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	in, ok := decodeScimUser(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.CreateUser(ctx, workspaceID, in)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", user.Meta.Location)
+	writeScimJSON(w, http.StatusCreated, user)
+}
+
+// GetUser handles GET /scim/v2/Users/{userId}
+func (h *ScimHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	user, err := h.service.GetUser(ctx, workspaceID, chi.URLParam(r, "userId"))
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, user)
+}
+
+// ReplaceUser handles PUT /scim/v2/Users/{userId}
+func (h *ScimHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	in, ok := decodeScimUser(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.ReplaceUser(ctx, workspaceID, chi.URLParam(r, "userId"), in)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, user)
+}
+
+// PatchUser handles PATCH /scim/v2/Users/{userId}
+func (h *ScimHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req domain.ScimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidSyntax, "request body must be a valid SCIM PatchOp")
+		return
+	}
+
+	user, err := h.service.PatchUser(ctx, workspaceID, chi.URLParam(r, "userId"), &req)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, user)
+}
+
+// DeleteUser handles DELETE /scim/v2/Users/{userId}
+func (h *ScimHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := h.service.DeleteUser(ctx, workspaceID, chi.URLParam(r, "userId")); err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListGroups handles GET /scim/v2/Groups
+func (h *ScimHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	params, ok := parseScimListParams(w, r, "displayName", "externalId")
+	if !ok {
+		return
+	}
+	withMembers := !strings.Contains(strings.ToLower(r.URL.Query().Get("excludedAttributes")), "members")
+
+	result, err := h.service.ListGroups(ctx, workspaceID, params, withMembers)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, result)
+}
+
+// CreateGroup handles POST /scim/v2/Groups
+func (h *ScimHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	in, ok := decodeScimGroup(w, r)
+	if !ok {
+		return
+	}
+
+	group, err := h.service.CreateGroup(ctx, workspaceID, in)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", group.Meta.Location)
+	writeScimJSON(w, http.StatusCreated, group)
+}
+
+// GetGroup handles GET /scim/v2/Groups/{groupId}
+func (h *ScimHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	group, err := h.service.GetGroup(ctx, workspaceID, chi.URLParam(r, "groupId"))
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, group)
+}
+
+// ReplaceGroup handles PUT /scim/v2/Groups/{groupId}
+func (h *ScimHandler) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	in, ok := decodeScimGroup(w, r)
+	if !ok {
+		return
+	}
+
+	group, err := h.service.ReplaceGroup(ctx, workspaceID, chi.URLParam(r, "groupId"), in)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, group)
+}
+
+// PatchGroup handles PATCH /scim/v2/Groups/{groupId}
+func (h *ScimHandler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req domain.ScimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidSyntax, "request body must be a valid SCIM PatchOp")
+		return
+	}
+
+	group, err := h.service.PatchGroup(ctx, workspaceID, chi.URLParam(r, "groupId"), &req)
+	if err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	writeScimJSON(w, http.StatusOK, group)
+}
+
+// DeleteGroup handles DELETE /scim/v2/Groups/{groupId}
+func (h *ScimHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := h.service.DeleteGroup(ctx, workspaceID, chi.URLParam(r, "groupId")); err != nil {
+		writeScimServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseScimListParams lê filter, startIndex e count. allowed lista os atributos filtráveis.
+func parseScimListParams(w http.ResponseWriter, r *http.Request, allowed ...string) (domain.ScimListParams, bool) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	var params domain.ScimListParams
+	if v := q.Get("filter"); v != "" {
+		filter, err := domain.ParseScimFilter(v, allowed...)
+		if err != nil {
+			httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidFilter, err.Error())
+			return params, false
+		}
+		params.Filter = filter
+	}
+
+	var startIndex, count int
+	for name, dst := range map[string]*int{"startIndex": &startIndex, "count": &count} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidValue, name+" must be an integer")
+				return params, false
+			}
+			*dst = n
+		}
+	}
+	params.StartIndex, params.Count = domain.NormalizeScimPaging(startIndex, count)
+	return params, true
+}
+
+func decodeScimUser(w http.ResponseWriter, r *http.Request) (*domain.ScimUserInput, bool) {
+	ctx := r.Context()
+
+	var res domain.ScimUserResource
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidSyntax, "request body must be a valid SCIM User")
+		return nil, false
+	}
+
+	in := res.Input()
+	if err := in.Validate(); err != nil {
+		httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidValue, err.Error())
+		return nil, false
+	}
+	return &in, true
+}
+
+func decodeScimGroup(w http.ResponseWriter, r *http.Request) (*domain.ScimGroupInput, bool) {
+	ctx := r.Context()
+
+	var res domain.ScimGroupResource
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidSyntax, "request body must be a valid SCIM Group")
+		return nil, false
+	}
+
+	in := res.Input()
+	if err := in.Validate(); err != nil {
+		httperr.WriteScimError(w, ctx, http.StatusBadRequest, domain.ScimTypeInvalidValue, err.Error())
+		return nil, false
+	}
+	return &in, true
+}
+
+// writeScimServiceError traduz erros do service para o formato SCIM.
+func writeScimServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	var invalid *domain.ScimInvalidValueError
+	if errors.As(err, &invalid) {
+		httperr.WriteScimError(w, r.Context(), http.StatusBadRequest, domain.ScimTypeInvalidValue, invalid.Error())
+		return
+	}
+	httperr.WriteScimAppError(w, r.Context(), err)
+}
+
+func writeScimJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", httperr.ScimContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
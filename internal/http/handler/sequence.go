@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SequenceHandler struct {
+	service *service.SequenceService
+}
+
+func NewSequenceHandler(service *service.SequenceService) *SequenceHandler {
+	return &SequenceHandler{service: service}
+}
+
+// ListSequences handles GET /v1/workspaces/{workspaceId}/sequences
+func (h *SequenceHandler) ListSequences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	sequences, err := h.service.ListSequences(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.SequenceListResponse{Data: sequences})
+}
+
+// CreateSequence handles POST /v1/workspaces/{workspaceId}/sequences
+func (h *SequenceHandler) CreateSequence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateSequenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	sequence, err := h.service.CreateSequence(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, sequence)
+}
+
+// GetSequence handles GET /v1/workspaces/{workspaceId}/sequences/{sequenceId}
+func (h *SequenceHandler) GetSequence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	sequenceID := chi.URLParam(r, "sequenceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	sequence, err := h.service.GetSequence(ctx, workspaceID, sequenceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, sequence)
+}
+
+// UpdateSequence handles PATCH /v1/workspaces/{workspaceId}/sequences/{sequenceId}
+func (h *SequenceHandler) UpdateSequence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	sequenceID := chi.URLParam(r, "sequenceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateSequenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	sequence, err := h.service.UpdateSequence(ctx, workspaceID, sequenceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, sequence)
+}
+
+// DeleteSequence handles DELETE /v1/workspaces/{workspaceId}/sequences/{sequenceId}
+func (h *SequenceHandler) DeleteSequence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	sequenceID := chi.URLParam(r, "sequenceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteSequence(ctx, workspaceID, sequenceID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListEnrollments handles GET /v1/workspaces/{workspaceId}/sequences/{sequenceId}/enrollments
+func (h *SequenceHandler) ListEnrollments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	sequenceID := chi.URLParam(r, "sequenceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var status *domain.EnrollmentStatus
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		s := domain.EnrollmentStatus(statusStr)
+		if !s.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "status must be one of: ACTIVE, PAUSED, COMPLETED, EXITED")
+			return
+		}
+		status = &s
+	}
+
+	enrollments, err := h.service.ListEnrollments(ctx, workspaceID, sequenceID, claims.ActorID, status)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.SequenceEnrollmentListResponse{Data: enrollments})
+}
+
+// EnrollContact handles POST /v1/workspaces/{workspaceId}/sequences/{sequenceId}/enrollments
+func (h *SequenceHandler) EnrollContact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	sequenceID := chi.URLParam(r, "sequenceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.EnrollContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	enrollment, err := h.service.EnrollContact(ctx, workspaceID, sequenceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, enrollment)
+}
+
+// PauseEnrollment handles POST /v1/workspaces/{workspaceId}/sequences/{sequenceId}/enrollments/{enrollmentId}/:pause
+func (h *SequenceHandler) PauseEnrollment(w http.ResponseWriter, r *http.Request) {
+	h.changeEnrollmentStatus(w, r, h.service.PauseEnrollment)
+}
+
+// ResumeEnrollment handles POST /v1/workspaces/{workspaceId}/sequences/{sequenceId}/enrollments/{enrollmentId}/:resume
+func (h *SequenceHandler) ResumeEnrollment(w http.ResponseWriter, r *http.Request) {
+	h.changeEnrollmentStatus(w, r, h.service.ResumeEnrollment)
+}
+
+// UnenrollContact handles POST /v1/workspaces/{workspaceId}/sequences/{sequenceId}/enrollments/{enrollmentId}/:unenroll
+func (h *SequenceHandler) UnenrollContact(w http.ResponseWriter, r *http.Request) {
+	h.changeEnrollmentStatus(w, r, h.service.UnenrollContact)
+}
+
+// changeEnrollmentStatus executa uma ação sem body sobre uma inscrição e devolve o estado atualizado.
+func (h *SequenceHandler) changeEnrollmentStatus(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, workspaceID, sequenceID, enrollmentID, actorID string) (*domain.SequenceEnrollment, error)) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	sequenceID := chi.URLParam(r, "sequenceId")
+	enrollmentID := chi.URLParam(r, "enrollmentId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	enrollment, err := action(ctx, workspaceID, sequenceID, enrollmentID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, enrollment)
+}
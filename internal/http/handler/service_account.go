@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// maxServiceAccountTokenBodyBytes limita o corpo do endpoint de token (anônimo)
+const maxServiceAccountTokenBodyBytes = 8 << 10
+
+type ServiceAccountHandler struct {
+	service *service.ServiceAccountService
+}
+
+func NewServiceAccountHandler(service *service.ServiceAccountService) *ServiceAccountHandler {
+	return &ServiceAccountHandler{service: service}
+}
+
+// List handles GET /v1/workspaces/{workspaceId}/service-accounts
+func (h *ServiceAccountHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	accounts, err := h.service.List(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.ServiceAccountListResponse{Data: accounts})
+}
+
+// Create handles POST /v1/workspaces/{workspaceId}/service-accounts
+func (h *ServiceAccountHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateServiceAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	credentials, err := h.service.Create(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	// O secret só aparece nesta resposta
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusCreated, credentials)
+}
+
+// Get handles GET /v1/workspaces/{workspaceId}/service-accounts/{serviceAccountId}
+func (h *ServiceAccountHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	account, err := h.service.Get(ctx, workspaceID, chi.URLParam(r, "serviceAccountId"), claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+// Update handles PATCH /v1/workspaces/{workspaceId}/service-accounts/{serviceAccountId}
+func (h *ServiceAccountHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateServiceAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	account, err := h.service.Update(ctx, workspaceID, chi.URLParam(r, "serviceAccountId"), claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+// Delete handles DELETE /v1/workspaces/{workspaceId}/service-accounts/{serviceAccountId}
+func (h *ServiceAccountHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.Delete(ctx, workspaceID, chi.URLParam(r, "serviceAccountId"), claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateSecret handles POST /v1/workspaces/{workspaceId}/service-accounts/{serviceAccountId}/secret
+func (h *ServiceAccountHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	credentials, err := h.service.RotateSecret(ctx, workspaceID, chi.URLParam(r, "serviceAccountId"), claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, credentials)
+}
+
+// Token handles POST /v1/auth/token (grant client_credentials, sem JWT). Corpo
+// application/x-www-form-urlencoded; client_id/client_secret no corpo ou em HTTP Basic.
+// Erros no formato OAuth 2.0 (RFC 6749 §5.2).
+func (h *ServiceAccountHandler) Token(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxServiceAccountTokenBodyBytes)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" {
+		httperr.WriteOAuthError(w, ctx, http.StatusBadRequest, httperr.OAuthInvalidRequest, "content type must be application/x-www-form-urlencoded")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		log.Warn(ctx, "invalid token request body", zap.Error(err))
+		httperr.WriteOAuthError(w, ctx, http.StatusBadRequest, httperr.OAuthInvalidRequest, "request body must be a valid form")
+		return
+	}
+
+	req := domain.ServiceAccountTokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		Scope:        r.PostForm.Get("scope"),
+	}
+	if user, password, ok := r.BasicAuth(); ok {
+		// Só um método de autenticação do client por request (RFC 6749 §2.3)
+		if req.ClientID != "" || req.ClientSecret != "" {
+			httperr.WriteOAuthError(w, ctx, http.StatusBadRequest, httperr.OAuthInvalidRequest, "client credentials must be sent either in the body or in the Authorization header")
+			return
+		}
+		// Em Basic, id e secret são form-urlencoded (RFC 6749 §2.3.1)
+		id, idErr := url.QueryUnescape(user)
+		secret, secretErr := url.QueryUnescape(password)
+		if idErr != nil || secretErr != nil {
+			httperr.WriteOAuthError(w, ctx, http.StatusBadRequest, httperr.OAuthInvalidRequest, "malformed client credentials")
+			return
+		}
+		req.ClientID, req.ClientSecret = id, secret
+	}
+
+	token, err := h.service.IssueToken(ctx, &req)
+	if err != nil {
+		httperr.WriteOAuthAppError(w, ctx, err)
+		return
+	}
+
+	// O token não pode ficar em cache de proxies/browsers (RFC 6749 §5.1)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	writeJSON(w, http.StatusOK, token)
+}
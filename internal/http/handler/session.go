@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+)
+
+type SessionHandler struct {
+	service *service.SessionService
+}
+
+func NewSessionHandler(service *service.SessionService) *SessionHandler {
+	return &SessionHandler{service: service}
+}
+
+// GetMe handles GET /v1/me
+func (h *SessionHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	authCtx, ok := auth.GetAuthContext(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+	// S2S não tem claims JWT
+	claims, _ := auth.GetClaims(ctx)
+
+	session, err := h.service.GetSession(ctx, authCtx, claims)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, session)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SnapshotHandler struct {
+	service *service.SnapshotService
+}
+
+func NewSnapshotHandler(service *service.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{service: service}
+}
+
+// ListSnapshots handles GET /v1/workspaces/{workspaceId}/snapshots
+func (h *SnapshotHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var params domain.ListSnapshotsParams
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 100")
+			return
+		}
+		params.Limit = limit
+	}
+
+	snapshots, err := h.service.ListSnapshots(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.SnapshotListResponse{Data: snapshots})
+}
+
+// CreateSnapshot handles POST /v1/workspaces/{workspaceId}/snapshots
+// Enfileira a exportação; responde 202 com o job PENDING.
+func (h *SnapshotHandler) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	snap, err := h.service.CreateSnapshot(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, snap)
+}
+
+// RestoreSnapshot handles POST /v1/workspaces/{workspaceId}/snapshots/:restore
+// Enfileira a restauração; responde 202 com o job PENDING.
+func (h *SnapshotHandler) RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.RestoreSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	snap, err := h.service.RestoreSnapshot(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, snap)
+}
+
+// GetSnapshot handles GET /v1/workspaces/{workspaceId}/snapshots/{snapshotId}
+func (h *SnapshotHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	snapshotID := chi.URLParam(r, "snapshotId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	snap, err := h.service.GetSnapshot(ctx, workspaceID, snapshotID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snap)
+}
+
+// DownloadSnapshot handles GET /v1/workspaces/{workspaceId}/snapshots/{snapshotId}/:download
+// Transmite o tar.gz da exportação sem carregá-lo em memória.
+func (h *SnapshotHandler) DownloadSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	snapshotID := chi.URLParam(r, "snapshotId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	snap, body, err := h.service.OpenDownload(ctx, workspaceID, snapshotID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+snap.ID+`.tar.gz"`)
+	if snap.SizeBytes != nil {
+		w.Header().Set("Content-Length", strconv.FormatInt(*snap.SizeBytes, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, body); err != nil {
+		// Cabeçalhos já enviados: só resta registrar.
+		log.Warn(ctx, "snapshot download interrupted", zap.String("snapshot_id", snap.ID), zap.Error(err))
+	}
+}
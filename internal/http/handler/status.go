@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"linkko-api/internal/http/middleware"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type StatusHandler struct {
+	service *service.StatusService
+}
+
+func NewStatusHandler(service *service.StatusService) *StatusHandler {
+	return &StatusHandler{service: service}
+}
+
+// GetStatus handles GET /v1/status (público). Responde 200 mesmo com componentes fora: o
+// cliente lê o campo status em vez do código HTTP.
+func (h *StatusHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status := h.service.Status(r.Context())
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, status)
+}
+
+// GetWorkspaceStatus handles GET /v1/workspaces/{workspaceId}/status
+// Montada antes do RateLimitMiddleware: consultar o status não consome a cota.
+func (h *StatusHandler) GetWorkspaceStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	// Planos com limite próprio (QuotaMiddleware) substituem o limite global
+	var limitPerMin int
+	if quota, ok := middleware.GetPlanQuota(ctx); ok {
+		limitPerMin = quota.RequestsPerMinute
+	}
+
+	status := h.service.WorkspaceStatus(ctx, workspaceID, limitPerMin)
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, status)
+}
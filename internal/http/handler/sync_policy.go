@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SyncPolicyHandler struct {
+	service *service.SyncPolicyService
+}
+
+func NewSyncPolicyHandler(service *service.SyncPolicyService) *SyncPolicyHandler {
+	return &SyncPolicyHandler{service: service}
+}
+
+// ListPolicies handles GET /v1/workspaces/{workspaceId}/sync-policies
+func (h *SyncPolicyHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	policies, err := h.service.ListPolicies(ctx, workspaceID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.SyncPolicyListResponse{Data: policies})
+}
+
+// PutPolicy handles PUT /v1/workspaces/{workspaceId}/sync-policies/{system}
+func (h *SyncPolicyHandler) PutPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	system, ok := syncPolicySystem(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.PutSyncPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	policy, err := h.service.PutPolicy(ctx, workspaceID, system, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, policy)
+}
+
+// DeletePolicy handles DELETE /v1/workspaces/{workspaceId}/sync-policies/{system}
+func (h *SyncPolicyHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	system, ok := syncPolicySystem(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePolicy(ctx, workspaceID, system, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListConflicts handles GET /v1/workspaces/{workspaceId}/sync-conflicts
+func (h *SyncPolicyHandler) ListConflicts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	var params domain.ListSyncConflictsParams
+	if raw := q.Get("system"); raw != "" {
+		system, ok := domain.NormalizeSyncSystem(raw)
+		if !ok {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter,
+				"system must have between 1 and "+strconv.Itoa(domain.SyncSystemMaxLength)+" characters")
+			return
+		}
+		params.System = &system
+	}
+	if raw := q.Get("entityType"); raw != "" {
+		entityType := domain.IdentityEntityType(raw)
+		switch entityType {
+		case domain.IdentityEntityContact, domain.IdentityEntityCompany, domain.IdentityEntityDeal:
+		default:
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "entityType must be contact, company or deal")
+			return
+		}
+		params.EntityType = &entityType
+	}
+	if entityID := q.Get("entityId"); entityID != "" {
+		if !domain.IsValidID(entityID) {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "entityId is not a valid id")
+			return
+		}
+		params.EntityID = &entityID
+	}
+
+	if cursorStr := q.Get("cursor"); cursorStr != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, cursorStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "cursor must be an RFC3339 timestamp")
+			return
+		}
+		params.Cursor = &cursor
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 200")
+			return
+		}
+		params.Limit = limit
+	}
+
+	conflicts, err := h.service.ListConflicts(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, conflicts)
+}
+
+// syncPolicySystem lê o {system} do path, normalizado como o ?system= dos upserts.
+func syncPolicySystem(w http.ResponseWriter, r *http.Request) (string, bool) {
+	system, ok := domain.NormalizeSyncSystem(chi.URLParam(r, "system"))
+	if !ok {
+		httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidParameter,
+			"system must have between 1 and "+strconv.Itoa(domain.SyncSystemMaxLength)+" characters")
+		return "", false
+	}
+	return system, true
+}
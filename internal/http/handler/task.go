@@ -73,11 +73,80 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 		params.Status = &status
 	}
 
+	if !parseTaskFilters(w, r, &params) {
+		return
+	}
+
+	log.Info(ctx, "listing tasks",
+		zap.String("workspaceId", workspaceID),
+		zap.String("actorId", actorID),
+		zap.Int("limit", params.Limit),
+	)
+
+	response, err := h.service.ListTasks(ctx, workspaceID, actorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetTaskBoard handles GET /v1/workspaces/{workspaceId}/tasks/board
+// Projeção Kanban: colunas por status e, opcionalmente, swimlanes por assignee ou priority.
+func (h *TaskHandler) GetTaskBoard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var params domain.TaskBoardParams
+	if swimlane := r.URL.Query().Get("swimlane"); swimlane != "" {
+		params.Swimlane = domain.TaskSwimlane(swimlane)
+		if !params.Swimlane.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "swimlane must be one of: assignee, priority")
+			return
+		}
+	}
+
+	if limitStr := r.URL.Query().Get("limitPerColumn"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limitPerColumn must be between 1 and 100")
+			return
+		}
+		params.Limit = limit
+	}
+
+	if !parseTaskFilters(w, r, &params.ListTasksParams) {
+		return
+	}
+
+	board, err := h.service.GetTaskBoard(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, board)
+}
+
+// parseTaskFilters lê os filtros comuns à listagem e ao board (exceto status).
+// Responde 400 e retorna false quando um filtro é inválido.
+func parseTaskFilters(w http.ResponseWriter, r *http.Request, params *domain.ListTasksParams) bool {
+	ctx := r.Context()
+
 	if priorityStr := r.URL.Query().Get("priority"); priorityStr != "" {
 		priority := domain.Priority(priorityStr)
 		if !priority.IsValid() {
 			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "priority must be one of: LOW, MEDIUM, HIGH, URGENT")
-			return
+			return false
 		}
 		params.Priority = &priority
 	}
@@ -86,7 +155,7 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 		taskType := domain.TaskType(typeStr)
 		if !taskType.IsValid() {
 			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "type must be one of: task, bug, feature, improvement, research")
-			return
+			return false
 		}
 		params.Type = &taskType
 	}
@@ -103,23 +172,19 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 		params.ContactID = &contactID
 	}
 
-	if search := r.URL.Query().Get("q"); search != "" {
-		params.Query = &search
+	// following=true: somente tarefas seguidas pelo usuário autenticado
+	if r.URL.Query().Get("following") == "true" {
+		if claims, ok := auth.GetClaims(ctx); ok {
+			actorID := claims.ActorID
+			params.FollowerID = &actorID
+		}
 	}
 
-	log.Info(ctx, "listing tasks",
-		zap.String("workspaceId", workspaceID),
-		zap.String("actorId", actorID),
-		zap.Int("limit", params.Limit),
-	)
-
-	response, err := h.service.ListTasks(ctx, workspaceID, actorID, params)
-	if err != nil {
-		handleServiceError(w, ctx, log, err)
-		return
+	if search := r.URL.Query().Get("q"); search != "" {
+		params.Query = &search
 	}
 
-	writeJSON(w, http.StatusOK, response)
+	return true
 }
 
 // GetTask handles GET /v1/workspaces/{workspaceId}/tasks/{taskId}
@@ -280,13 +345,13 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		zap.String("actorId", actorID),
 	)
 
-	err := h.service.DeleteTask(ctx, workspaceID, taskID, actorID)
+	receipt, err := h.service.DeleteTask(ctx, workspaceID, taskID, actorID)
 	if err != nil {
 		handleServiceError(w, ctx, log, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	writeDeleted(w, receipt)
 }
 
 // MoveTask handles POST /v1/workspaces/{workspaceId}/tasks/{taskId}:move
@@ -314,6 +379,11 @@ func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	extended, ok := parseReturn(w, r)
+	if !ok {
+		return
+	}
+
 	var req domain.MoveTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "invalid JSON body")
@@ -333,6 +403,16 @@ func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
 		zap.String("toStatus", string(req.ToStatus)),
 	)
 
+	if extended {
+		result, err := h.service.MoveTaskExtended(ctx, workspaceID, taskID, actorID, &req)
+		if err != nil {
+			handleServiceError(w, ctx, log, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+
 	task, err := h.service.MoveTask(ctx, workspaceID, taskID, actorID, &req)
 	if err != nil {
 		handleServiceError(w, ctx, log, err)
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"linkko-api/internal/domain"
+)
+
+// FuzzParseTaskFilters: qualquer query string termina em filtros válidos ou em 400, sem panic.
+func FuzzParseTaskFilters(f *testing.F) {
+	f.Add("priority=HIGH&type=CALL&assignedTo=usr_1&q=proposta")
+	f.Add("priority=high")
+	f.Add("type=&contactId=%27%3B--&following=true")
+	f.Add("q=%00&priority=URGENT&priority=LOW")
+	f.Add("%zz=1&actorId=")
+
+	f.Fuzz(func(t *testing.T, rawQuery string) {
+		if _, err := url.ParseQuery(rawQuery); err != nil {
+			return // o net/http já rejeita antes do handler
+		}
+		req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws_1/tasks", nil)
+		req.URL.RawQuery = rawQuery
+		rec := httptest.NewRecorder()
+
+		var params domain.ListTasksParams
+		if !parseTaskFilters(rec, req, &params) {
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("rejected %q with status %d", rawQuery, rec.Code)
+			}
+			return
+		}
+
+		if params.Priority != nil && !params.Priority.IsValid() {
+			t.Fatalf("accepted invalid priority %q", *params.Priority)
+		}
+		if params.Type != nil && !params.Type.IsValid() {
+			t.Fatalf("accepted invalid type %q", *params.Type)
+		}
+		for name, value := range map[string]*string{"assignedTo": params.AssignedTo, "actorId": params.ActorID, "contactId": params.ContactID, "q": params.Query} {
+			if value != nil && *value == "" {
+				t.Fatalf("empty %s kept as a filter", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type TimeEntryHandler struct {
+	service *service.TimeEntryService
+}
+
+func NewTimeEntryHandler(service *service.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{service: service}
+}
+
+// ListTimeEntries handles GET /v1/workspaces/{workspaceId}/time-entries
+func (h *TimeEntryHandler) ListTimeEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	var params domain.ListTimeEntriesParams
+	if userID := q.Get("userId"); userID != "" {
+		params.UserID = &userID
+	}
+	if taskID := q.Get("taskId"); taskID != "" {
+		params.TaskID = &taskID
+	}
+	if dealID := q.Get("dealId"); dealID != "" {
+		params.DealID = &dealID
+	}
+
+	dates, ok := newDateFilter(w, r, func(ctx context.Context) (*time.Location, error) {
+		return h.service.WorkspaceLocation(ctx, workspaceID)
+	})
+	if !ok {
+		return
+	}
+	if params.From, ok = dates.start("from"); !ok {
+		return
+	}
+	if params.To, ok = dates.end("to"); !ok {
+		return
+	}
+
+	if runningStr := q.Get("running"); runningStr != "" {
+		running, err := strconv.ParseBool(runningStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "running must be true or false")
+			return
+		}
+		params.Running = &running
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 500 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 500")
+			return
+		}
+		params.Limit = limit
+	}
+
+	entries, err := h.service.ListTimeEntries(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.TimeEntryListResponse{Data: entries})
+}
+
+// CreateTimeEntry handles POST /v1/workspaces/{workspaceId}/time-entries
+func (h *TimeEntryHandler) CreateTimeEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateTimeEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	entry, err := h.service.CreateTimeEntry(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// GetTimeEntry handles GET /v1/workspaces/{workspaceId}/time-entries/{timeEntryId}
+func (h *TimeEntryHandler) GetTimeEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	entryID := chi.URLParam(r, "timeEntryId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	entry, err := h.service.GetTimeEntry(ctx, workspaceID, entryID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+}
+
+// UpdateTimeEntry handles PATCH /v1/workspaces/{workspaceId}/time-entries/{timeEntryId}
+func (h *TimeEntryHandler) UpdateTimeEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	entryID := chi.URLParam(r, "timeEntryId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateTimeEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	entry, err := h.service.UpdateTimeEntry(ctx, workspaceID, entryID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+}
+
+// StopTimeEntry handles POST /v1/workspaces/{workspaceId}/time-entries/{timeEntryId}/:stop
+func (h *TimeEntryHandler) StopTimeEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	entryID := chi.URLParam(r, "timeEntryId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	entry, err := h.service.StopTimeEntry(ctx, workspaceID, entryID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+}
+
+// DeleteTimeEntry handles DELETE /v1/workspaces/{workspaceId}/time-entries/{timeEntryId}
+func (h *TimeEntryHandler) DeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	entryID := chi.URLParam(r, "timeEntryId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteTimeEntry(ctx, workspaceID, entryID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TimelineDigest handles GET /v1/workspaces/{workspaceId}/timeline/:digest
+// Resumo condensado para pull-to-refresh no mobile: contagens por tipo e as
+// últimas perType atividades de cada tipo desde o cursor.
+func (h *ActivityHandler) TimelineDigest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	var params domain.TimelineDigestParams
+
+	if v := q.Get("cursor"); v != "" {
+		since, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "cursor must be an RFC3339 timestamp")
+			return
+		}
+		params.Since = &since
+	}
+	if v := q.Get("perType"); v != "" {
+		perType, err := strconv.Atoi(v)
+		if err != nil || perType < 1 || perType > 20 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "perType must be between 1 and 20")
+			return
+		}
+		params.PerType = perType
+	}
+	if v := q.Get("contactId"); v != "" {
+		params.ContactID = &v
+	}
+	if v := q.Get("companyId"); v != "" {
+		params.CompanyID = &v
+	}
+	if v := q.Get("dealId"); v != "" {
+		params.DealID = &v
+	}
+
+	digest, err := h.service.TimelineDigest(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeOK(w, http.StatusOK, digest)
+}
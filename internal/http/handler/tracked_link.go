@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// linkContactTokenParam query string da URL curta com o contact token
+const linkContactTokenParam = "c"
+
+type TrackedLinkHandler struct {
+	service *service.TrackedLinkService
+}
+
+func NewTrackedLinkHandler(service *service.TrackedLinkService) *TrackedLinkHandler {
+	return &TrackedLinkHandler{service: service}
+}
+
+// ListLinks handles GET /v1/workspaces/{workspaceId}/links
+func (h *TrackedLinkHandler) ListLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	var contactID, dealID *string
+	if v := q.Get("contactId"); v != "" {
+		contactID = &v
+	}
+	if v := q.Get("dealId"); v != "" {
+		dealID = &v
+	}
+
+	links, err := h.service.ListLinks(ctx, workspaceID, claims.ActorID, contactID, dealID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &domain.TrackedLinkListResponse{Data: links})
+}
+
+// CreateLink handles POST /v1/workspaces/{workspaceId}/links
+func (h *TrackedLinkHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.CreateTrackedLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	link, err := h.service.CreateLink(ctx, workspaceID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, link)
+}
+
+// GetLink handles GET /v1/workspaces/{workspaceId}/links/{linkId}
+func (h *TrackedLinkHandler) GetLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	linkID := chi.URLParam(r, "linkId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	link, err := h.service.GetLink(ctx, workspaceID, linkID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, link)
+}
+
+// UpdateLink handles PATCH /v1/workspaces/{workspaceId}/links/{linkId}
+func (h *TrackedLinkHandler) UpdateLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	linkID := chi.URLParam(r, "linkId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	var req domain.UpdateTrackedLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn(ctx, "invalid request body", zap.Error(err))
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "request body must be valid JSON")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "validation failed", zap.Error(err))
+		httperr.ValidationError422(w, ctx, err)
+		return
+	}
+
+	link, err := h.service.UpdateLink(ctx, workspaceID, linkID, claims.ActorID, &req)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, link)
+}
+
+// DeleteLink handles DELETE /v1/workspaces/{workspaceId}/links/{linkId}
+func (h *TrackedLinkHandler) DeleteLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	linkID := chi.URLParam(r, "linkId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if err := h.service.DeleteLink(ctx, workspaceID, linkID, claims.ActorID); err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListClicks handles GET /v1/workspaces/{workspaceId}/links/{linkId}/clicks
+func (h *TrackedLinkHandler) ListClicks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	params := domain.ListTrackedLinkClicksParams{LinkID: chi.URLParam(r, "linkId")}
+	if contactID := q.Get("contactId"); contactID != "" {
+		params.ContactID = &contactID
+	}
+
+	if cursorStr := q.Get("cursor"); cursorStr != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, cursorStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "cursor must be an RFC3339 timestamp")
+			return
+		}
+		params.Cursor = &cursor
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 200")
+			return
+		}
+		params.Limit = limit
+	}
+
+	clicks, err := h.service.ListClicks(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, clicks)
+}
+
+// ContactURL handles GET /v1/workspaces/{workspaceId}/links/{linkId}/contact-url?contactId=
+func (h *TrackedLinkHandler) ContactURL(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	linkID := chi.URLParam(r, "linkId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	contactID := r.URL.Query().Get("contactId")
+	if contactID == "" {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "contactId is required")
+		return
+	}
+
+	contactURL, err := h.service.ContactURL(ctx, workspaceID, linkID, contactID, claims.ActorID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, contactURL)
+}
+
+// Redirect handles GET/HEAD /l/{code} (público). Registra o clique (só em GET, para não contar
+// verificações de link) e redireciona com 302 sem cache, para cada clique chegar à API.
+func (h *TrackedLinkHandler) Redirect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	link, err := h.service.ResolveLink(ctx, chi.URLParam(r, "code"))
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		h.service.RecordClick(ctx, link, r.URL.Query().Get(linkContactTokenParam), r.Referer(), r.UserAgent())
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	http.Redirect(w, r, link.RedirectURL(), http.StatusFound)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type TrashHandler struct {
+	service *service.TrashService
+}
+
+func NewTrashHandler(service *service.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// trashItemView item da lixeira com o link de restauração.
+type trashItemView struct {
+	domain.TrashItem
+	Links halLinks `json:"_links"`
+}
+
+// trashListView resposta de GET /trash.
+type trashListView struct {
+	Data []trashItemView `json:"data"`
+	Meta interface{}     `json:"meta"`
+}
+
+// ListTrash handles GET /v1/workspaces/{workspaceId}/trash
+func (h *TrashHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	var params domain.ListTrashParams
+
+	if typeStr := q.Get("entityType"); typeStr != "" {
+		entityType := domain.TrashEntityType(typeStr)
+		if !entityType.IsValid() {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "entityType must be one of contact, company, deal, task, pipeline")
+			return
+		}
+		params.EntityType = &entityType
+	}
+
+	if cursorStr := q.Get("cursor"); cursorStr != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, cursorStr)
+		if err != nil {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "cursor must be an RFC3339 timestamp")
+			return
+		}
+		params.Cursor = &cursor
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 200 {
+			httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "limit must be between 1 and 200")
+			return
+		}
+		params.Limit = limit
+	}
+
+	trash, err := h.service.ListTrash(ctx, workspaceID, claims.ActorID, params)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	b := newLinkBuilder(r)
+	items := make([]trashItemView, len(trash.Data))
+	for i, item := range trash.Data {
+		items[i] = trashItemView{
+			TrashItem: item,
+			Links: halLinks{
+				"restore": {Href: b.base + "/trash/" + string(item.EntityType) + "/" + url.PathEscape(item.EntityID) + "/:restore"},
+			},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, trashListView{Data: items, Meta: trash.Meta})
+}
+
+// RestoreTrashItem handles POST /v1/workspaces/{workspaceId}/trash/{entityType}/{entityId}/:restore
+func (h *TrashHandler) RestoreTrashItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.GetLogger(ctx)
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	entityType := domain.TrashEntityType(chi.URLParam(r, "entityType"))
+	entityID := chi.URLParam(r, "entityId")
+
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		httperr.Unauthorized401(w, ctx, httperr.ErrCodeInvalidToken, "authentication required")
+		return
+	}
+
+	if !entityType.IsValid() {
+		httperr.BadRequest400(w, ctx, httperr.ErrCodeInvalidParameter, "entityType must be one of contact, company, deal, task, pipeline")
+		return
+	}
+
+	result, err := h.service.Restore(ctx, workspaceID, claims.ActorID, entityType, entityID)
+	if err != nil {
+		handleServiceError(w, ctx, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
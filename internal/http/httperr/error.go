@@ -3,14 +3,21 @@ package httperr
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
-	"os"
+	"strconv"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/i18n"
 	"linkko-api/internal/observability/logger"
 
+	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
 )
 
+// ExposeErrorID inclui o request ID (errorId) nas respostas 500; ligado em APP_ENV=dev na subida
+var ExposeErrorID = false
+
 // ErrorResponse represents a standardized error response
 type ErrorResponse struct {
 	OK    bool         `json:"ok"`
@@ -23,6 +30,7 @@ type ErrorDetail struct {
 	Message string            `json:"message"`
 	Fields  map[string]string `json:"fields,omitempty"`
 	ErrorID string            `json:"error_id,omitempty"`
+	DocsURL string            `json:"docs_url,omitempty"`
 }
 
 // Error codes for 401 Unauthorized (authentication failures)
@@ -39,14 +47,25 @@ const (
 // Error codes for 403 Forbidden (authorized but insufficient permissions)
 const (
 	ErrCodeWorkspaceMismatch = "WORKSPACE_MISMATCH"
+	ErrCodeOrgMismatch       = "ORG_MISMATCH"
 	ErrCodeForbidden         = "FORBIDDEN"
 	ErrCodeInsufficientScope = "INSUFFICIENT_SCOPE"
 	ErrCodeNotFound          = "NOT_FOUND" // Added
+	// ErrCodeImpersonationReadOnly mutation blocked while IMPERSONATION_BLOCK_MUTATIONS is on
+	ErrCodeImpersonationReadOnly = "IMPERSONATION_READ_ONLY"
+)
+
+// Error codes for 404 Not Found / 423 Locked (WorkspaceStatusMiddleware)
+const (
+	ErrCodeWorkspaceNotFound  = "WORKSPACE_NOT_FOUND"
+	ErrCodeWorkspaceSuspended = "WORKSPACE_SUSPENDED"
 )
 
 // Error codes for 400 Bad Request (validation errors)
 const (
 	ErrCodeInvalidWorkspaceID = "INVALID_WORKSPACE_ID"
+	ErrCodeInvalidOrgID       = "INVALID_ORG_ID"
+	ErrCodeInvalidID          = "INVALID_ID"
 	ErrCodeInvalidParameter   = "INVALID_PARAMETER"
 	ErrCodeInvalidFormat      = "INVALID_FORMAT"
 	ErrCodeMissingParameter   = "MISSING_PARAMETER"
@@ -56,6 +75,8 @@ const (
 	ErrCodeInvalidPriority    = "INVALID_PRIORITY"
 	ErrCodeInvalidType        = "INVALID_TYPE"
 	ErrCodeConflict           = "CONFLICT" // Added
+	ErrCodeUnsupportedVersion = "UNSUPPORTED_API_VERSION"
+	ErrCodeInvalidConfig      = "INVALID_CONFIG"
 )
 
 // Error codes for 500 Internal Server Error
@@ -63,6 +84,18 @@ const (
 	ErrCodeInternalError = "INTERNAL_ERROR"
 )
 
+// Error codes for 503 Service Unavailable
+const (
+	ErrCodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	// ErrCodeMaintenanceMode write blocked while maintenance is enabled (global or workspace)
+	ErrCodeMaintenanceMode = "MAINTENANCE_MODE"
+)
+
+// Error codes for 504 Gateway Timeout
+const (
+	ErrCodeTimeout = "REQUEST_TIMEOUT"
+)
+
 // WriteError writes a standardized error response
 func WriteError(w http.ResponseWriter, ctx context.Context, status int, code, message string) {
 	log := logger.GetLogger(ctx)
@@ -79,7 +112,7 @@ func WriteError(w http.ResponseWriter, ctx context.Context, status int, code, me
 		OK: false,
 		Error: &ErrorDetail{
 			Code:    code,
-			Message: message,
+			Message: i18n.T(ctx, message),
 		},
 	}
 
@@ -104,12 +137,17 @@ func WriteErrorWithFields(w http.ResponseWriter, ctx context.Context, status int
 
 	log.Error(ctx, "request failed with field errors", fieldPairs...)
 
+	localizedFields := make(map[string]string, len(fields))
+	for k, v := range fields {
+		localizedFields[k] = i18n.T(ctx, v)
+	}
+
 	response := ErrorResponse{
 		OK: false,
 		Error: &ErrorDetail{
 			Code:    code,
-			Message: message,
-			Fields:  fields,
+			Message: i18n.T(ctx, message),
+			Fields:  localizedFields,
 		},
 	}
 
@@ -138,8 +176,84 @@ func BadRequest400WithFields(w http.ResponseWriter, ctx context.Context, code, m
 	WriteErrorWithFields(w, ctx, http.StatusBadRequest, code, message, fields)
 }
 
-// InternalError500 writes a 500 Internal Server Error response
+// WriteAppError é a tradução única de erros de serviço para HTTP.
+// Erros do catálogo (apperr) usam código/status/mensagem pública definidos no sentinela;
+// qualquer outro erro vira 500 (ou 504 se o deadline da request expirou).
+func WriteAppError(w http.ResponseWriter, ctx context.Context, err error) {
+	logger.SetRootError(ctx, err)
+
+	appErr, ok := apperr.From(err)
+	if !ok {
+		InternalError500(w, ctx, err.Error())
+		return
+	}
+
+	if appErr.Status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "5")
+	}
+
+	log := logger.GetLogger(ctx)
+	log.Warn(ctx, "request failed",
+		zap.Int("status_code", appErr.Status),
+		zap.String("error_code", appErr.Code),
+		zap.String("message", appErr.Public),
+		zap.Error(err),
+		zap.String("request_id", logger.GetRequestIDFromContext(ctx)),
+	)
+
+	response := ErrorResponse{
+		OK: false,
+		Error: &ErrorDetail{
+			Code:    appErr.Code,
+			Message: i18n.T(ctx, appErr.Public),
+			DocsURL: appErr.DocsURL(),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// ServiceUnavailable503 writes a 503 Service Unavailable response with Retry-After (seconds)
+func ServiceUnavailable503(w http.ResponseWriter, ctx context.Context, message string, retryAfterSeconds int) {
+	if retryAfterSeconds > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	}
+	WriteError(w, ctx, http.StatusServiceUnavailable, ErrCodeServiceUnavailable, message)
+}
+
+// ValidationError422 writes a 422 VALIDATION_ERROR response.
+// Erros do go-playground/validator viram mensagens por campo no idioma da request.
+func ValidationError422(w http.ResponseWriter, ctx context.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		WriteError(w, ctx, http.StatusUnprocessableEntity, ErrCodeValidationError, err.Error())
+		return
+	}
+
+	locale := i18n.FromContext(ctx)
+	fields := make(map[string]string, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields[fe.Field()] = i18n.ValidationMessage(locale, fe.Field(), fe.Tag(), fe.Param())
+	}
+	WriteErrorWithFields(w, ctx, http.StatusUnprocessableEntity, ErrCodeValidationError, "validation failed", fields)
+}
+
+// GatewayTimeout504 writes a 504 Gateway Timeout response
+func GatewayTimeout504(w http.ResponseWriter, ctx context.Context, message string) {
+	WriteError(w, ctx, http.StatusGatewayTimeout, ErrCodeTimeout, message)
+}
+
+// InternalError500 writes a 500 Internal Server Error response.
+// If the request deadline already expired (TimeoutMiddleware), the failure is
+// reported as 504 since the root cause is the deadline, not a server bug.
 func InternalError500(w http.ResponseWriter, ctx context.Context, message string) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		GatewayTimeout504(w, ctx, "request exceeded its deadline")
+		return
+	}
+
 	reqID := logger.GetRequestIDFromContext(ctx)
 
 	log := logger.GetLogger(ctx)
@@ -153,12 +267,11 @@ func InternalError500(w http.ResponseWriter, ctx context.Context, message string
 		OK: false,
 		Error: &ErrorDetail{
 			Code:    ErrCodeInternalError,
-			Message: "Internal Server Error",
+			Message: i18n.T(ctx, "Internal Server Error"),
 		},
 	}
 
-	// APP_ENV detection
-	if os.Getenv("APP_ENV") == "dev" {
+	if ExposeErrorID {
 		response.Error.ErrorID = reqID
 	}
 
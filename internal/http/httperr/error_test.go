@@ -3,11 +3,20 @@ package httperr
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/i18n"
 	"linkko-api/internal/observability/logger"
+
+	"github.com/go-playground/validator/v10"
 )
 
 func TestWriteError(t *testing.T) {
@@ -207,3 +216,139 @@ func TestInternalError500(t *testing.T) {
 		t.Errorf("expected code %s, got %s", ErrCodeInternalError, response.Error.Code)
 	}
 }
+
+func TestInternalError500_DeadlineExceededBecomes504(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	rr := httptest.NewRecorder()
+	InternalError500(rr, ctx, "list contacts: context deadline exceeded")
+
+	if rr.Code != http.StatusGatewayTimeout {
+		t.Errorf("expected status 504, got %d", rr.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Error.Code != ErrCodeTimeout {
+		t.Errorf("expected code %s, got %s", ErrCodeTimeout, response.Error.Code)
+	}
+}
+
+func TestServiceUnavailable503(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+
+	rr := httptest.NewRecorder()
+	ServiceUnavailable503(rr, ctx, "idempotency store temporarily unavailable", 5)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Retry-After"); got != "5" {
+		t.Errorf("expected Retry-After 5, got %q", got)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Error.Code != ErrCodeServiceUnavailable {
+		t.Errorf("expected code %s, got %s", ErrCodeServiceUnavailable, response.Error.Code)
+	}
+}
+
+func TestWriteAppError(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+	errWidgetNotFound := apperr.NotFound("widget not found in workspace", "widget not found")
+
+	t.Run("CatalogedError", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		WriteAppError(rr, ctx, fmt.Errorf("get widget: %w", errWidgetNotFound))
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rr.Code)
+		}
+
+		var response ErrorResponse
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Error.Code != apperr.CodeNotFound {
+			t.Errorf("expected code %s, got %s", apperr.CodeNotFound, response.Error.Code)
+		}
+		if response.Error.Message != "widget not found" {
+			t.Errorf("expected public message, got %q", response.Error.Message)
+		}
+		if response.Error.DocsURL != apperr.DocsPath+"#NOT_FOUND" {
+			t.Errorf("unexpected docs_url %q", response.Error.DocsURL)
+		}
+	})
+
+	t.Run("UncatalogedErrorIs500", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		WriteAppError(rr, ctx, errors.New("boom"))
+
+		if rr.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", rr.Code)
+		}
+	})
+}
+
+func TestWriteError_Localized(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+	ctx = i18n.WithLocale(ctx, i18n.PtBR)
+
+	rr := httptest.NewRecorder()
+	WriteAppError(rr, ctx, apperr.NotFound("gadget not found in workspace", "contact not found"))
+
+	var response ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Error.Message != "contato não encontrado" {
+		t.Errorf("expected pt-BR message, got %q", response.Error.Message)
+	}
+}
+
+func TestValidationError422(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+	ctx = i18n.WithLocale(ctx, i18n.PtBR)
+
+	type request struct {
+		FullName string `json:"fullName" validate:"required"`
+	}
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string { return strings.Split(f.Tag.Get("json"), ",")[0] })
+	err := v.Struct(&request{})
+
+	rr := httptest.NewRecorder()
+	ValidationError422(rr, ctx, err)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", rr.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Error.Code != ErrCodeValidationError {
+		t.Errorf("expected code %s, got %s", ErrCodeValidationError, response.Error.Code)
+	}
+	if response.Error.Message != "falha de validação" {
+		t.Errorf("expected localized message, got %q", response.Error.Message)
+	}
+	if got := response.Error.Fields["fullName"]; got != "fullName é obrigatório" {
+		t.Errorf("expected localized field error, got %q", got)
+	}
+}
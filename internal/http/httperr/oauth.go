@@ -0,0 +1,77 @@
+package httperr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// Códigos de erro do endpoint de token (RFC 6749 §5.2)
+const (
+	OAuthInvalidRequest         = "invalid_request"
+	OAuthInvalidClient          = "invalid_client"
+	OAuthInvalidScope           = "invalid_scope"
+	OAuthUnsupportedGrantType   = "unsupported_grant_type"
+	OAuthServerError            = "server_error"
+	OAuthTemporarilyUnavailable = "temporarily_unavailable"
+)
+
+// OAuthErrorResponse corpo de erro do endpoint de token. Clientes OAuth (bibliotecas de
+// client credentials) não entendem o envelope padrão da API.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// WriteOAuthError writes an OAuth 2.0 error response
+func WriteOAuthError(w http.ResponseWriter, ctx context.Context, status int, code, description string) {
+	log := logger.GetLogger(ctx)
+	log.Warn(ctx, "oauth request failed",
+		zap.Int("status_code", status),
+		zap.String("oauth_error", code),
+		zap.String("message", description),
+		zap.String("request_id", logger.GetRequestIDFromContext(ctx)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if code == OAuthInvalidClient {
+		w.Header().Set("WWW-Authenticate", `Basic realm="linkko"`)
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(OAuthErrorResponse{Error: code, ErrorDescription: description})
+}
+
+// WriteOAuthAppError é a versão OAuth de WriteAppError: INVALID_CLIENT, INVALID_SCOPE e
+// UNSUPPORTED_GRANT_TYPE viram os códigos da RFC; os demais erros do catálogo, invalid_request
+// (5xx viram temporarily_unavailable); erros fora do catálogo, 500 server_error.
+func WriteOAuthAppError(w http.ResponseWriter, ctx context.Context, err error) {
+	logger.SetRootError(ctx, err)
+
+	appErr, ok := apperr.From(err)
+	if !ok {
+		logger.GetLogger(ctx).Error(ctx, "unexpected oauth error", zap.Error(err))
+		WriteOAuthError(w, ctx, http.StatusInternalServerError, OAuthServerError, "internal server error")
+		return
+	}
+
+	status, code := appErr.Status, OAuthInvalidRequest
+	switch {
+	case appErr.Code == "INVALID_CLIENT":
+		status, code = http.StatusUnauthorized, OAuthInvalidClient
+	case appErr.Code == "INVALID_SCOPE":
+		status, code = http.StatusBadRequest, OAuthInvalidScope
+	case appErr.Code == "UNSUPPORTED_GRANT_TYPE":
+		status, code = http.StatusBadRequest, OAuthUnsupportedGrantType
+	case status >= http.StatusInternalServerError:
+		code = OAuthTemporarilyUnavailable
+	default:
+		status = http.StatusBadRequest
+	}
+	WriteOAuthError(w, ctx, status, code, appErr.Public)
+}
@@ -0,0 +1,65 @@
+package httperr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/observability/logger"
+)
+
+func TestWriteOAuthAppError(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+
+	errClient := apperr.Define("INVALID_CLIENT", http.StatusUnauthorized, "client rejected", "invalid client credentials")
+	errScope := apperr.Define("INVALID_SCOPE", http.StatusBadRequest, "scope rejected", "requested scope is not allowed")
+	errGadgetInvalid := apperr.Unprocessable(apperr.CodeValidationError, "gadget is invalid", "")
+
+	tests := []struct {
+		name                string
+		err                 error
+		expectedStatus      int
+		expectedError       string
+		expectedDescription string
+		expectAuthenticate  bool
+	}{
+		{name: "InvalidClient", err: fmt.Errorf("issue token: %w", errClient), expectedStatus: http.StatusUnauthorized, expectedError: OAuthInvalidClient, expectedDescription: "invalid client credentials", expectAuthenticate: true},
+		{name: "InvalidScope", err: errScope, expectedStatus: http.StatusBadRequest, expectedError: OAuthInvalidScope, expectedDescription: "requested scope is not allowed"},
+		{name: "CatalogedErrorIsInvalidRequest", err: errGadgetInvalid, expectedStatus: http.StatusBadRequest, expectedError: OAuthInvalidRequest, expectedDescription: "gadget is invalid"},
+		{name: "UncatalogedErrorIs500", err: errors.New("boom"), expectedStatus: http.StatusInternalServerError, expectedError: OAuthServerError, expectedDescription: "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			WriteOAuthAppError(rr, ctx, tt.err)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if cc := rr.Header().Get("Cache-Control"); cc != "no-store" {
+				t.Errorf("expected Cache-Control no-store, got %q", cc)
+			}
+			if got := rr.Header().Get("WWW-Authenticate") != ""; got != tt.expectAuthenticate {
+				t.Errorf("expected WWW-Authenticate present=%v", tt.expectAuthenticate)
+			}
+
+			var response OAuthErrorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Error != tt.expectedError {
+				t.Errorf("expected error %q, got %q", tt.expectedError, response.Error)
+			}
+			if response.ErrorDescription != tt.expectedDescription {
+				t.Errorf("expected error_description %q, got %q", tt.expectedDescription, response.ErrorDescription)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package httperr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// ScimContentType media type das respostas de /scim/v2 (RFC 7644 §3.1)
+const ScimContentType = "application/scim+json"
+
+const scimErrorSchema = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+// ScimErrorResponse corpo de erro SCIM; status é uma string (RFC 7644 §3.12).
+// Os diretórios (Okta, Azure AD) não entendem o envelope padrão da API.
+type ScimErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// WriteScimError writes a SCIM error response
+func WriteScimError(w http.ResponseWriter, ctx context.Context, status int, scimType, detail string) {
+	log := logger.GetLogger(ctx)
+	log.Warn(ctx, "scim request failed",
+		zap.Int("status_code", status),
+		zap.String("scim_type", scimType),
+		zap.String("message", detail),
+		zap.String("request_id", logger.GetRequestIDFromContext(ctx)),
+	)
+
+	w.Header().Set("Content-Type", ScimContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ScimErrorResponse{
+		Schemas:  []string{scimErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// WriteScimAppError é a versão SCIM de WriteAppError: erros do catálogo usam o status e a
+// mensagem pública (409 vira scimType uniqueness, 422 vira 400 invalidValue); os demais, 500.
+func WriteScimAppError(w http.ResponseWriter, ctx context.Context, err error) {
+	logger.SetRootError(ctx, err)
+
+	appErr, ok := apperr.From(err)
+	if !ok {
+		logger.GetLogger(ctx).Error(ctx, "unexpected scim error", zap.Error(err))
+		WriteScimError(w, ctx, http.StatusInternalServerError, "", "internal server error")
+		return
+	}
+
+	status, scimType := appErr.Status, ""
+	switch status {
+	case http.StatusConflict:
+		scimType = "uniqueness"
+	case http.StatusUnprocessableEntity, http.StatusBadRequest:
+		status, scimType = http.StatusBadRequest, "invalidValue"
+	}
+	WriteScimError(w, ctx, status, scimType, appErr.Public)
+}
@@ -0,0 +1,65 @@
+package httperr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/observability/logger"
+)
+
+func TestWriteScimAppError(t *testing.T) {
+	log, _ := logger.New("test", "info")
+	ctx := logger.SetLoggerInContext(context.Background(), log)
+
+	errGadgetConflict := apperr.Conflict("gadget already exists", "a gadget with this name already exists")
+	errGadgetInvalid := apperr.Unprocessable(apperr.CodeValidationError, "gadget is invalid", "")
+
+	tests := []struct {
+		name             string
+		err              error
+		expectedStatus   int
+		expectedScimType string
+		expectedDetail   string
+	}{
+		{name: "ConflictIsUniqueness", err: fmt.Errorf("create gadget: %w", errGadgetConflict), expectedStatus: http.StatusConflict, expectedScimType: "uniqueness", expectedDetail: "a gadget with this name already exists"},
+		{name: "UnprocessableIsInvalidValue", err: errGadgetInvalid, expectedStatus: http.StatusBadRequest, expectedScimType: "invalidValue", expectedDetail: "gadget is invalid"},
+		{name: "UncatalogedErrorIs500", err: errors.New("boom"), expectedStatus: http.StatusInternalServerError, expectedDetail: "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			WriteScimAppError(rr, ctx, tt.err)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != ScimContentType {
+				t.Errorf("expected content type %s, got %s", ScimContentType, ct)
+			}
+
+			var response ScimErrorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(response.Schemas) != 1 || response.Schemas[0] != scimErrorSchema {
+				t.Errorf("unexpected schemas %v", response.Schemas)
+			}
+			if response.Status != fmt.Sprint(tt.expectedStatus) {
+				t.Errorf("expected status %q, got %q", fmt.Sprint(tt.expectedStatus), response.Status)
+			}
+			if response.ScimType != tt.expectedScimType {
+				t.Errorf("expected scimType %q, got %q", tt.expectedScimType, response.ScimType)
+			}
+			if response.Detail != tt.expectedDetail {
+				t.Errorf("expected detail %q, got %q", tt.expectedDetail, response.Detail)
+			}
+		})
+	}
+}
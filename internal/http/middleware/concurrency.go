@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// workspaceSemaphore é um semáforo por workspace com contagem de referências
+// para que o mapa não cresça indefinidamente com workspaces inativos.
+type workspaceSemaphore struct {
+	slots chan struct{}
+	refs  int
+}
+
+// ConcurrencyLimiter limita requests simultâneas por workspace (em memória, por instância).
+// Complementa o rate limit por minuto: um tenant rodando um import pesado não
+// consegue ocupar todas as conexões do pool do Postgres.
+type ConcurrencyLimiter struct {
+	limit   int
+	maxWait time.Duration
+
+	mu         sync.Mutex
+	workspaces map[string]*workspaceSemaphore
+}
+
+// NewConcurrencyLimiter creates a limiter allowing limit in-flight requests per workspace.
+// maxWait é quanto uma request espera por um slot antes de ser rejeitada (0 = não espera).
+func NewConcurrencyLimiter(limit int, maxWait time.Duration) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		limit:      limit,
+		maxWait:    maxWait,
+		workspaces: make(map[string]*workspaceSemaphore),
+	}
+}
+
+// Acquire reserva um slot para o workspace. Retorna false se nenhum slot
+// foi liberado dentro de maxWait ou se o contexto foi cancelado.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, workspaceID string) bool {
+	l.mu.Lock()
+	sem, ok := l.workspaces[workspaceID]
+	if !ok {
+		sem = &workspaceSemaphore{slots: make(chan struct{}, l.limit)}
+		l.workspaces[workspaceID] = sem
+	}
+	sem.refs++
+	l.mu.Unlock()
+
+	select {
+	case sem.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if l.maxWait > 0 {
+		timer := time.NewTimer(l.maxWait)
+		defer timer.Stop()
+		select {
+		case sem.slots <- struct{}{}:
+			return true
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
+	l.unref(workspaceID, sem)
+	return false
+}
+
+// Release libera o slot obtido com Acquire
+func (l *ConcurrencyLimiter) Release(workspaceID string) {
+	l.mu.Lock()
+	sem, ok := l.workspaces[workspaceID]
+	l.mu.Unlock()
+	if !ok {
+		return
+	}
+	<-sem.slots
+	l.unref(workspaceID, sem)
+}
+
+// InFlight returns the number of requests currently holding a slot for the workspace
+func (l *ConcurrencyLimiter) InFlight(workspaceID string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sem, ok := l.workspaces[workspaceID]; ok {
+		return len(sem.slots)
+	}
+	return 0
+}
+
+func (l *ConcurrencyLimiter) unref(workspaceID string, sem *workspaceSemaphore) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sem.refs--
+	if sem.refs == 0 {
+		delete(l.workspaces, workspaceID)
+	}
+}
+
+// ConcurrencyLimitMiddleware enforces the in-flight request limit per workspace.
+// Um limiter nil desabilita a checagem. Deve rodar após WorkspaceMiddleware.
+func ConcurrencyLimitMiddleware(limiter *ConcurrencyLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil || limiter.limit <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := logger.GetLogger(r.Context())
+
+			workspaceID, ok := GetWorkspaceID(r.Context())
+			if !ok {
+				log.Error(r.Context(), "workspace_id not found in context for concurrency limiting")
+				httperr.InternalError(w, r.Context())
+				return
+			}
+
+			w.Header().Set("X-Concurrency-Limit", fmt.Sprintf("%d", limiter.limit))
+
+			if !limiter.Acquire(r.Context(), workspaceID) {
+				trace.SpanFromContext(r.Context()).AddEvent("concurrency_limit_exceeded")
+
+				log.Warn(r.Context(), "concurrency limit exceeded",
+					zap.String("workspace_id", workspaceID),
+					zap.Int("limit", limiter.limit),
+				)
+
+				w.Header().Set("Retry-After", "1")
+				httperr.WriteError(w, r.Context(), http.StatusTooManyRequests, "CONCURRENCY_LIMIT_EXCEEDED", "too many concurrent requests for this workspace")
+				return
+			}
+			defer limiter.Release(workspaceID)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimiter_AcquireRelease(t *testing.T) {
+	l := NewConcurrencyLimiter(2, 0)
+	ctx := context.Background()
+
+	if !l.Acquire(ctx, "ws-1") || !l.Acquire(ctx, "ws-1") {
+		t.Fatal("expected first two acquisitions to succeed")
+	}
+	if l.Acquire(ctx, "ws-1") {
+		t.Fatal("expected third acquisition to fail")
+	}
+	// Outro workspace não é afetado
+	if !l.Acquire(ctx, "ws-2") {
+		t.Fatal("expected acquisition for another workspace to succeed")
+	}
+
+	l.Release("ws-1")
+	if got := l.InFlight("ws-1"); got != 1 {
+		t.Errorf("expected 1 in-flight request, got %d", got)
+	}
+	l.Release("ws-1")
+	l.Release("ws-2")
+
+	if len(l.workspaces) != 0 {
+		t.Errorf("expected idle workspaces to be evicted, got %d", len(l.workspaces))
+	}
+}
+
+func TestConcurrencyLimiter_WaitsForSlot(t *testing.T) {
+	l := NewConcurrencyLimiter(1, time.Second)
+	ctx := context.Background()
+
+	if !l.Acquire(ctx, "ws-1") {
+		t.Fatal("expected acquisition to succeed")
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		l.Release("ws-1")
+	}()
+	if !l.Acquire(ctx, "ws-1") {
+		t.Fatal("expected queued acquisition to succeed after release")
+	}
+	l.Release("ws-1")
+}
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 0)
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	handler := ConcurrencyLimitMiddleware(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("block") == "1" {
+			close(started)
+			<-unblock
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	newRequest := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		return req.WithContext(context.WithValue(setupTestContext(), workspaceIDKey, "ws-1"))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest("/v1/workspaces/ws-1/contacts:import?block=1"))
+	}()
+	<-started
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("/v1/workspaces/ws-1/contacts"))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected Retry-After 1, got %q", w.Header().Get("Retry-After"))
+	}
+	validateErrorResponse(t, w.Body.String(), "CONCURRENCY_LIMIT_EXCEEDED")
+
+	close(unblock)
+	wg.Wait()
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("/v1/workspaces/ws-1/contacts"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 after release, got %d", w.Code)
+	}
+}
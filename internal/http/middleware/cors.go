@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// PublicCORSMiddleware libera chamadas cross-origin para rotas anônimas (formulários embutidos em sites
+// de clientes). Não envia Allow-Credentials: a autenticação é o form token, nunca cookies.
+// Preflight (OPTIONS) responde 204 sem chegar ao handler.
+func PublicCORSMiddleware(allowedHeaders ...string) func(http.Handler) http.Handler {
+	headers := strings.Join(append([]string{"Content-Type"}, allowedHeaders...), ", ")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Add("Vary", "Origin")
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+				w.Header().Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
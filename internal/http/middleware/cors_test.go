@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicCORSMiddleware(t *testing.T) {
+	called := false
+	handler := PublicCORSMiddleware("X-Form-Token")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	t.Run("Preflight", func(t *testing.T) {
+		called = false
+		req := httptest.NewRequest(http.MethodOptions, "/v1/public/forms/f1/submissions", nil)
+		req.Header.Set("Origin", "https://site.example")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.False(t, called)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Content-Type, X-Form-Token", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("SimpleRequest", func(t *testing.T) {
+		called = false
+		req := httptest.NewRequest(http.MethodPost, "/v1/public/forms/f1/submissions", nil)
+		req.Header.Set("Origin", "https://site.example")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.True(t, called)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -3,12 +3,14 @@ package middleware
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 
 	"linkko-api/internal/http/httperr"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/resilience"
 
 	"go.uber.org/zap"
 )
@@ -57,6 +59,13 @@ func IdempotencyMiddleware(idempotencyRepo *repo.IdempotencyRepo) func(http.Hand
 			// Check if key exists
 			cached, err := idempotencyRepo.CheckKey(r.Context(), workspaceID, keyHash)
 			if err != nil {
+				// Sem a checagem não dá para garantir exactly-once: com o breaker aberto
+				// pedimos retry ao cliente em vez de processar a mutação duas vezes.
+				if errors.Is(err, resilience.ErrCircuitOpen) {
+					log.Warn(r.Context(), "idempotency store unavailable (circuit open)", zap.Error(err))
+					httperr.ServiceUnavailable503(w, r.Context(), "idempotency store temporarily unavailable", 5)
+					return
+				}
 				log.Error(r.Context(), "failed to check idempotency key", zap.Error(err))
 				httperr.InternalError(w, r.Context())
 				return
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/logger"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ImpersonatedByHeader é ecoado na response de toda request feita com token de impersonation
+const ImpersonatedByHeader = "X-Impersonated-By"
+
+// ImpersonationMiddleware marca requests feitas com token de impersonation (header, span e log)
+// e, com blockMutations (lido a cada request), só deixa passar leituras: o suporte reproduz o problema sem alterar dados.
+// Deve rodar depois do AuthMiddleware.
+func ImpersonationMiddleware(blockMutations func() bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authCtx, ok := auth.GetAuthContext(r.Context())
+			if !ok || !authCtx.IsImpersonated() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			log := logger.GetLogger(r.Context())
+			w.Header().Set(ImpersonatedByHeader, authCtx.ImpersonatorID)
+			trace.SpanFromContext(r.Context()).SetAttributes(
+				attribute.String("impersonator_id", authCtx.ImpersonatorID),
+			)
+
+			if blockMutations() && !isReadOnlyMethod(r.Method) {
+				log.Warn("mutation blocked under impersonation",
+					zap.String("impersonator_id", authCtx.ImpersonatorID),
+					zap.String("actor_id", authCtx.ActorID),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+				)
+				httperr.Forbidden403(w, r.Context(), httperr.ErrCodeImpersonationReadOnly, "mutations are not allowed under impersonation")
+				return
+			}
+
+			log.Info("impersonated request",
+				zap.String("impersonator_id", authCtx.ImpersonatorID),
+				zap.String("actor_id", authCtx.ActorID),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+			)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+)
+
+func TestImpersonationMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		impersonatorID string
+		blockMutations bool
+		method         string
+		expectedStatus int
+		expectedHeader string
+	}{
+		{name: "RegularTokenWrite", method: http.MethodPost, blockMutations: true, expectedStatus: http.StatusOK},
+		{name: "ImpersonatedRead", impersonatorID: "admin-1", method: http.MethodGet, blockMutations: true, expectedStatus: http.StatusOK, expectedHeader: "admin-1"},
+		{name: "ImpersonatedWriteAllowed", impersonatorID: "admin-1", method: http.MethodPatch, expectedStatus: http.StatusOK, expectedHeader: "admin-1"},
+		{name: "ImpersonatedWriteBlocked", impersonatorID: "admin-1", method: http.MethodDelete, blockMutations: true, expectedStatus: http.StatusForbidden, expectedHeader: "admin-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.SetAuthContextForTesting(setupTestContext(), &auth.AuthContext{
+				WorkspaceID:    "ws-123",
+				ActorID:        "user-123",
+				ImpersonatorID: tt.impersonatorID,
+				ActorType:      "user",
+				AuthMethod:     "jwt",
+				Issuer:         "linkko-crm-web",
+			})
+
+			handler := ImpersonationMiddleware(func() bool { return tt.blockMutations })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/v1/workspaces/ws-123/contacts", nil).WithContext(ctx)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d, body: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if got := rr.Header().Get(ImpersonatedByHeader); got != tt.expectedHeader {
+				t.Errorf("expected %s %q, got %q", ImpersonatedByHeader, tt.expectedHeader, got)
+			}
+			if tt.expectedStatus == http.StatusForbidden {
+				validateErrorResponse(t, rr.Body.String(), httperr.ErrCodeImpersonationReadOnly)
+			}
+		})
+	}
+}
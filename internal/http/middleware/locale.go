@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"linkko-api/internal/i18n"
+)
+
+// LocaleMiddleware negocia o idioma das mensagens de erro via Accept-Language
+// (pt-BR e en) e injeta o resultado no contexto para o httperr.
+// A resposta informa o idioma escolhido em Content-Language.
+func LocaleMiddleware(defaultLocale i18n.Locale) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := i18n.Negotiate(r.Header.Get("Accept-Language"), defaultLocale)
+
+			w.Header().Set("Content-Language", string(locale))
+			w.Header().Add("Vary", "Accept-Language")
+
+			next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+		})
+	}
+}
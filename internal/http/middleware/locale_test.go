@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/i18n"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected i18n.Locale
+	}{
+		{name: "Default", header: "", expected: i18n.EN},
+		{name: "Portuguese", header: "pt-BR,pt;q=0.9,en;q=0.8", expected: i18n.PtBR},
+		{name: "Unsupported", header: "es-ES", expected: i18n.EN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got i18n.Locale
+			handler := LocaleMiddleware(i18n.EN)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = i18n.FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if got != tt.expected {
+				t.Errorf("expected locale %q, got %q", tt.expected, got)
+			}
+			if w.Header().Get("Content-Language") != string(tt.expected) {
+				t.Errorf("expected Content-Language %q, got %q", tt.expected, w.Header().Get("Content-Language"))
+			}
+		})
+	}
+}
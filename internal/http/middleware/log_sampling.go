@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// LogSampling controla quantas requests bem-sucedidas o RequestLoggingMiddleware registra.
+// Requests com status >= 400 e requests lentas são sempre registradas; as demais entram na
+// amostra conforme a fração da rota. A decisão é determinística pelo request_id, então todos
+// os serviços que compartilham o X-Request-Id amostram a mesma request.
+type LogSampling struct {
+	// Rate fração dos 2xx/3xx registrados (0 a 1). 1 registra todas.
+	Rate float64
+	// SlowThreshold a partir desta latência a request é sempre registrada, em Warn (0 desliga).
+	SlowThreshold time.Duration
+	// RouteRates override da fração por rota: "GET /v1/workspaces/{workspaceId}/deals"
+	// (método + padrão chi) ou só o padrão, para qualquer método.
+	RouteRates map[string]float64
+}
+
+// LogAll registra todas as requests (comportamento sem amostragem).
+var LogAll = LogSampling{Rate: 1}
+
+// rateFor fração aplicada à rota: override com método, override só do padrão ou Rate.
+func (s LogSampling) rateFor(method, route string) float64 {
+	if rate, ok := s.RouteRates[method+" "+route]; ok {
+		return rate
+	}
+	if rate, ok := s.RouteRates[route]; ok {
+		return rate
+	}
+	return s.Rate
+}
+
+// decide informa se a request deve ser registrada, se é lenta e a fração usada (1 para
+// requests registradas por erro ou lentidão).
+func (s LogSampling) decide(requestID, method, route string, status int, latency time.Duration) (logIt, slow bool, rate float64) {
+	slow = s.SlowThreshold > 0 && latency >= s.SlowThreshold
+	if status >= 400 || slow {
+		return true, slow, 1
+	}
+
+	rate = s.rateFor(method, route)
+	switch {
+	case rate >= 1:
+		return true, false, 1
+	case rate <= 0:
+		return false, false, 0
+	}
+	return sampleKey(requestID) < rate, false, rate
+}
+
+// sampleKey mapeia o request_id para [0, 1).
+func sampleKey(requestID string) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(requestID))
+	return float64(h.Sum32()) / (math.MaxUint32 + 1.0)
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogSampling_AlwaysLogsErrorsAndSlowRequests(t *testing.T) {
+	s := LogSampling{Rate: 0, SlowThreshold: time.Second}
+
+	logIt, slow, rate := s.decide("req_1", http.MethodGet, "/v1/workspaces/{workspaceId}/deals", http.StatusNotFound, 10*time.Millisecond)
+	assert.True(t, logIt)
+	assert.False(t, slow)
+	assert.Equal(t, 1.0, rate)
+
+	logIt, slow, _ = s.decide("req_1", http.MethodGet, "/v1/workspaces/{workspaceId}/deals", http.StatusOK, 2*time.Second)
+	assert.True(t, logIt)
+	assert.True(t, slow)
+
+	logIt, _, _ = s.decide("req_1", http.MethodGet, "/v1/workspaces/{workspaceId}/deals", http.StatusOK, 10*time.Millisecond)
+	assert.False(t, logIt)
+}
+
+func TestLogSampling_RouteOverrides(t *testing.T) {
+	s := LogSampling{
+		Rate: 0,
+		RouteRates: map[string]float64{
+			"GET /health":                             0,
+			"/v1/workspaces/{workspaceId}/deals":      1,
+			"POST /v1/workspaces/{workspaceId}/deals": 0,
+		},
+	}
+
+	assert.Equal(t, 1.0, s.rateFor(http.MethodGet, "/v1/workspaces/{workspaceId}/deals"))
+	assert.Equal(t, 0.0, s.rateFor(http.MethodPost, "/v1/workspaces/{workspaceId}/deals"))
+	assert.Equal(t, 0.0, s.rateFor(http.MethodGet, "/health"))
+	assert.Equal(t, 0.0, s.rateFor(http.MethodGet, "/v1/workspaces/{workspaceId}/tasks"))
+}
+
+func TestLogSampling_SamplesDeterministicallyByRequestID(t *testing.T) {
+	s := LogSampling{Rate: 0.25}
+
+	logged := 0
+	for i := 0; i < 10000; i++ {
+		reqID := fmt.Sprintf("req_%d", i)
+		first, _, rate := s.decide(reqID, http.MethodGet, "/v1/workspaces/{workspaceId}/deals", http.StatusOK, time.Millisecond)
+		again, _, _ := s.decide(reqID, http.MethodGet, "/v1/workspaces/{workspaceId}/deals", http.StatusOK, time.Millisecond)
+		assert.Equal(t, first, again)
+		assert.Equal(t, 0.25, rate)
+		if first {
+			logged++
+		}
+	}
+	assert.InDelta(t, 2500, logged, 300)
+}
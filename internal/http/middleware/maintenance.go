@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// MaintenanceLookup is implemented by service.MaintenanceService
+type MaintenanceLookup interface {
+	// Maintenance retorna a manutenção que vale para o workspace (vazio = só a global) ou nil
+	Maintenance(ctx context.Context, workspaceID string) (*domain.Maintenance, error)
+}
+
+// MaintenanceMiddleware responde 503 MAINTENANCE_MODE com Retry-After às escritas enquanto há
+// manutenção global ou do workspace; leituras (GET/HEAD/OPTIONS) passam. Fora das rotas de
+// workspace só a global vale. Falhas na consulta liberam a request (fail-open); lookup nil
+// desabilita a checagem.
+func MaintenanceMiddleware(lookup MaintenanceLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if lookup == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isReadOnlyMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			log := logger.GetLogger(ctx)
+			workspaceID, _ := GetWorkspaceID(ctx)
+
+			maintenance, err := lookup.Maintenance(ctx, workspaceID)
+			if err != nil {
+				log.Warn(ctx, "maintenance lookup failed, allowing request",
+					zap.String("workspace_id", workspaceID),
+					zap.Error(err),
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if maintenance == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(maintenance.RetryAfter(time.Now()))))
+			var fields map[string]string
+			if maintenance.Message != nil {
+				fields = map[string]string{"maintenance": *maintenance.Message}
+			}
+			httperr.WriteErrorWithFields(w, ctx, http.StatusServiceUnavailable, httperr.ErrCodeMaintenanceMode,
+				"the API is under maintenance, write operations are temporarily disabled", fields)
+		})
+	}
+}
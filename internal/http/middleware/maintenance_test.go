@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+)
+
+type fakeMaintenanceLookup struct {
+	global    *domain.Maintenance
+	workspace map[string]*domain.Maintenance
+	err       error
+}
+
+func (f *fakeMaintenanceLookup) Maintenance(ctx context.Context, workspaceID string) (*domain.Maintenance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.global != nil {
+		return f.global, nil
+	}
+	return f.workspace[workspaceID], nil
+}
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	message := "migrating deals"
+	endsAt := time.Now().Add(90 * time.Second)
+	global := &fakeMaintenanceLookup{global: &domain.Maintenance{Scope: domain.MaintenanceScopeGlobal, Message: &message}}
+	workspace := &fakeMaintenanceLookup{workspace: map[string]*domain.Maintenance{"ws-1": {Scope: "ws-1", EndsAt: &endsAt}}}
+
+	tests := []struct {
+		name               string
+		lookup             *fakeMaintenanceLookup
+		method             string
+		workspaceID        string
+		expectedStatus     int
+		expectedRetryAfter string
+	}{
+		{name: "NoMaintenance", lookup: &fakeMaintenanceLookup{}, method: http.MethodPost, workspaceID: "ws-1", expectedStatus: http.StatusOK},
+		{name: "GlobalBlocksWrites", lookup: global, method: http.MethodPatch, workspaceID: "ws-1", expectedStatus: http.StatusServiceUnavailable, expectedRetryAfter: "60"},
+		{name: "GlobalOutsideWorkspace", lookup: global, method: http.MethodPost, expectedStatus: http.StatusServiceUnavailable, expectedRetryAfter: "60"},
+		{name: "GlobalAllowsReads", lookup: global, method: http.MethodGet, workspaceID: "ws-1", expectedStatus: http.StatusOK},
+		{name: "WorkspaceRetryAfterUntilEndsAt", lookup: workspace, method: http.MethodDelete, workspaceID: "ws-1", expectedStatus: http.StatusServiceUnavailable, expectedRetryAfter: "90"},
+		{name: "OtherWorkspace", lookup: workspace, method: http.MethodPost, workspaceID: "ws-2", expectedStatus: http.StatusOK},
+		{name: "LookupErrorFailsOpen", lookup: &fakeMaintenanceLookup{err: errors.New("connection refused")}, method: http.MethodPost, workspaceID: "ws-1", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := MaintenanceMiddleware(tt.lookup)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			ctx := setupTestContext()
+			if tt.workspaceID != "" {
+				ctx = context.WithValue(ctx, workspaceIDKey, tt.workspaceID)
+			}
+			req := httptest.NewRequest(tt.method, "/v1/workspaces/ws-1/contacts", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.expectedRetryAfter {
+				t.Errorf("expected Retry-After %q, got %q", tt.expectedRetryAfter, got)
+			}
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				validateErrorResponse(t, w.Body.String(), "MAINTENANCE_MODE")
+			}
+			if tt.lookup == global && tt.expectedStatus == http.StatusServiceUnavailable && !strings.Contains(w.Body.String(), message) {
+				t.Errorf("expected maintenance message in body, got %s", w.Body.String())
+			}
+		})
+	}
+}
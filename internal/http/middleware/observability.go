@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,11 +11,14 @@ import (
 
 	"github.com/jackc/pgx/v5/pgconn"
 
+	"linkko-api/internal/auth"
 	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/integrations/errortracker"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/observability/requestid"
 
 	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -49,6 +53,13 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 // MUST include: request_id, route, method, status, latency_ms
 // MUST NOT include: sensitive headers, request/response bodies
 func RequestLoggingMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
+	return SampledRequestLoggingMiddleware(log, LogAll)
+}
+
+// SampledRequestLoggingMiddleware igual ao RequestLoggingMiddleware, mas registra só a amostra
+// dos 2xx/3xx definida em sampling; erros e requests lentas são sempre registrados. route é o
+// padrão chi (baixa cardinalidade); o caminho concreto fica em path.
+func SampledRequestLoggingMiddleware(log *logger.Logger, sampling LogSampling) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -70,21 +81,32 @@ func RequestLoggingMiddleware(log *logger.Logger) func(http.Handler) http.Handle
 			latency := time.Since(start)
 			latencyMs := float64(latency.Milliseconds())
 
-			// Log request summary
-			log.Info(
-				ctx,
-				"http request completed",
-				logger.Module("http"),
-				logger.Action("request"),
-				zap.String("method", r.Method),
-				zap.String("route", r.URL.Path),
-				zap.String("path", r.URL.Path),
-				zap.String("query", sanitizeQuery(r.URL.RawQuery)),
-				zap.Int("status", wrapped.statusCode),
-				zap.Float64("latency_ms", latencyMs),
-				zap.String("remote_addr", sanitizeRemoteAddr(r.RemoteAddr)),
-				zap.String("user_agent", sanitizeUserAgent(r.UserAgent())),
-			)
+			// Log request summary (amostrado para 2xx/3xx rápidos)
+			route := getRoutePattern(r)
+			logIt, slow, rate := sampling.decide(requestid.GetRequestID(ctx), r.Method, route, wrapped.statusCode, latency)
+			if logIt {
+				fields := []zap.Field{
+					logger.Module("http"),
+					logger.Action("request"),
+					zap.String("method", r.Method),
+					zap.String("route", route),
+					zap.String("path", r.URL.Path),
+					zap.String("query", sanitizeQuery(r.URL.RawQuery)),
+					zap.Int("status", wrapped.statusCode),
+					zap.Float64("latency_ms", latencyMs),
+					zap.String("remote_addr", sanitizeRemoteAddr(r.RemoteAddr)),
+					zap.String("user_agent", sanitizeUserAgent(r.UserAgent())),
+				}
+				if rate < 1 {
+					// Cada linha representa 1/rate requests nas agregações
+					fields = append(fields, zap.Float64("sample_rate", rate))
+				}
+				if slow {
+					log.Warn(ctx, "http request completed", append(fields, zap.Bool("slow", true))...)
+				} else {
+					log.Info(ctx, "http request completed", fields...)
+				}
+			}
 
 			// Tarefa B: Log detailed http_error for 5xx
 			if wrapped.statusCode >= 500 {
@@ -121,19 +143,36 @@ func RequestLoggingMiddleware(log *logger.Logger) func(http.Handler) http.Handle
 // RecoveryMiddleware recovers from panics and logs with stack trace
 // Prevents service crash while preserving error context
 func RecoveryMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
+	return RecoveryMiddlewareWithTracker(log, nil)
+}
+
+// RecoveryMiddlewareWithTracker igual ao RecoveryMiddleware e, com tracker, envia também um
+// relatório estruturado do panic (pilha, request, ator autenticado, trace) ao rastreador de
+// erros, sem dados pessoais (errortracker.Scrub). O envio é assíncrono e não atrasa o 500.
+func RecoveryMiddlewareWithTracker(log *logger.Logger, tracker errortracker.Tracker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// O AuthMiddleware roda depois: o gravador expõe aqui o ator autenticado
+			ctx := auth.WithAuthRecorder(r.Context())
+			r = r.WithContext(ctx)
+
 			defer func() {
 				if err := recover(); err != nil {
 					// Get stack trace
 					stack := string(debug.Stack())
-					ctx := r.Context()
 					reqID := logger.GetRequestIDFromContext(ctx)
 
 					// Capture panic as root error for logging
 					recoveredErr := fmt.Errorf("panic: %v", err)
 					logger.SetRootError(ctx, recoveredErr)
 
+					var eventID string
+					if tracker != nil {
+						ev := panicEvent(ctx, r, err, errortracker.CaptureStack(2))
+						eventID = ev.ID
+						go reportPanic(context.WithoutCancel(ctx), log, tracker, ev)
+					}
+
 					// Log panic_recovered event as required
 					log.Error(
 						ctx,
@@ -146,6 +185,7 @@ func RecoveryMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
 						zap.String("path", r.URL.Path),
 						zap.String("route", getRoutePattern(r)),
 						zap.String("request_id", reqID),
+						zap.String("error_event_id", eventID),
 					)
 
 					// Return standardized error via httperr
@@ -158,6 +198,64 @@ func RecoveryMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// panicEvent monta o relatório do panic com os dados da request e do ator autenticado, já
+// sem dados pessoais.
+func panicEvent(ctx context.Context, r *http.Request, recovered any, stack []errortracker.Frame) *errortracker.Event {
+	headers := make(map[string]string, len(r.Header))
+	for k := range r.Header {
+		headers[k] = r.Header.Get(k)
+	}
+
+	ev := &errortracker.Event{
+		ID:        errortracker.NewEventID(),
+		Timestamp: time.Now().UTC(),
+		Level:     "fatal",
+		Type:      "panic",
+		Message:   fmt.Sprint(recovered),
+		Stack:     stack,
+		Request: &errortracker.Request{
+			Method:  r.Method,
+			URL:     r.URL.Path,
+			Route:   getRoutePattern(r),
+			Query:   r.URL.RawQuery,
+			Headers: headers,
+		},
+		RequestID: logger.GetRequestIDFromContext(ctx),
+	}
+	if e, ok := recovered.(error); ok {
+		ev.Type = fmt.Sprintf("panic(%T)", e)
+	}
+
+	if authCtx, ok := auth.RecordedAuthContext(ctx); ok {
+		ev.User = &errortracker.User{ID: authCtx.ActorID, Type: authCtx.ActorType, ImpersonatorID: authCtx.ImpersonatorID}
+		ev.WorkspaceID = authCtx.WorkspaceID
+		ev.AuthMethod = authCtx.AuthMethod
+	}
+	if workspaceID := chi.URLParamFromCtx(ctx, "workspaceId"); workspaceID != "" {
+		ev.WorkspaceID = workspaceID
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		ev.TraceID, ev.SpanID = sc.TraceID().String(), sc.SpanID().String()
+	}
+
+	errortracker.Scrub(ev)
+	return ev
+}
+
+func reportPanic(ctx context.Context, log *logger.Logger, tracker errortracker.Tracker, ev *errortracker.Event) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := tracker.Capture(ctx, ev); err != nil {
+		log.Warn(ctx, "failed to report panic to error tracker",
+			logger.Module("http"),
+			logger.Action("panic_report"),
+			zap.String("error_event_id", ev.ID),
+			zap.Error(err),
+		)
+	}
+}
+
 // responseWriter wraps http.ResponseWriter to capture status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -173,6 +271,11 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return rw.ResponseWriter.Write(b)
 }
 
+// Unwrap expõe o writer original ao http.ResponseController (Flush do streaming).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // sanitizeQuery removes sensitive query parameters
 // SECURITY: prevent logging tokens, passwords in query strings
 func sanitizeQuery(query string) string {
@@ -245,6 +348,10 @@ func classifyError(err error) string {
 		return "scan"
 	}
 
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+
 	// Database errors (pgx)
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
@@ -1,14 +1,21 @@
 package middleware_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
 	"linkko-api/internal/http/middleware"
+	"linkko-api/internal/integrations/errortracker"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/observability/requestid"
+
+	"github.com/go-chi/chi/v5"
 )
 
 func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
@@ -164,7 +171,8 @@ func TestRecoveryMiddleware_RecoversPanic(t *testing.T) {
 }
 
 func TestRecoveryMiddleware_DevModeIncludeErrorID(t *testing.T) {
-	t.Setenv("APP_ENV", "dev")
+	httperr.ExposeErrorID = true
+	defer func() { httperr.ExposeErrorID = false }()
 
 	log, err := logger.New("test-service", "info")
 	if err != nil {
@@ -200,6 +208,86 @@ func TestRecoveryMiddleware_DevModeIncludeErrorID(t *testing.T) {
 	}
 }
 
+type fakeTracker struct {
+	events chan *errortracker.Event
+}
+
+func (f *fakeTracker) Capture(ctx context.Context, ev *errortracker.Event) error {
+	f.events <- ev
+	return nil
+}
+
+func TestRecoveryMiddlewareWithTracker_ReportsPanic(t *testing.T) {
+	log, err := logger.New("test-service", "info")
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer log.Sync()
+
+	tracker := &fakeTracker{events: make(chan *errortracker.Event, 1)}
+	r := chi.NewRouter()
+	r.Use(middleware.RequestIDMiddleware)
+	r.Use(middleware.RecoveryMiddlewareWithTracker(log, tracker))
+	r.Use(func(next http.Handler) http.Handler {
+		// Simula o AuthMiddleware, que roda depois do RecoveryMiddleware
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := auth.SetAuthContextForTesting(r.Context(), &auth.AuthContext{
+				WorkspaceID: "ws_1",
+				ActorID:     "usr_1",
+				ActorType:   auth.ActorTypeUser,
+				AuthMethod:  "jwt",
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Get("/v1/workspaces/{workspaceId}/deals", func(w http.ResponseWriter, r *http.Request) {
+		panic("lookup failed for maria@example.com")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws_1/deals?email=maria%40example.com&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("User-Agent", "test-agent")
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", rec.Code)
+	}
+
+	var ev *errortracker.Event
+	select {
+	case ev = <-tracker.events:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected panic to be reported to the error tracker")
+	}
+
+	if ev.Level != "fatal" || ev.Message != "lookup failed for [email]" {
+		t.Errorf("unexpected level/message: %q %q", ev.Level, ev.Message)
+	}
+	if ev.Request.Route != "/v1/workspaces/{workspaceId}/deals" || ev.Request.Method != http.MethodGet {
+		t.Errorf("unexpected request metadata: %+v", ev.Request)
+	}
+	if _, ok := ev.Request.Headers["Authorization"]; ok {
+		t.Error("expected Authorization header to be scrubbed")
+	}
+	if ev.Request.Headers["User-Agent"] != "test-agent" {
+		t.Errorf("expected User-Agent to be kept, got %+v", ev.Request.Headers)
+	}
+	if strings.Contains(ev.Request.Query, "maria") {
+		t.Errorf("expected email to be scrubbed from query, got %q", ev.Request.Query)
+	}
+	if ev.User == nil || ev.User.ID != "usr_1" || ev.WorkspaceID != "ws_1" || ev.AuthMethod != "jwt" {
+		t.Errorf("expected auth context in report, got user=%+v workspace=%q", ev.User, ev.WorkspaceID)
+	}
+	if ev.RequestID == "" || ev.RequestID != rec.Header().Get("X-Request-Id") {
+		t.Errorf("expected request id %q, got %q", rec.Header().Get("X-Request-Id"), ev.RequestID)
+	}
+	if len(ev.Stack) == 0 {
+		t.Error("expected stack frames")
+	}
+}
+
 func TestRecoveryMiddleware_DoesNotAffectNormalFlow(t *testing.T) {
 	log, err := logger.New("test-service", "info")
 	if err != nil {
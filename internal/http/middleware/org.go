@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const orgIDKey contextKey = "org_id"
+
+// OrgMiddleware validates organization access on /v1/orgs/{orgId} routes, mirroring
+// WorkspaceMiddleware: a token bound to an organization (orgId claim) cannot reach another one.
+// Membership and the org role are checked by the service (OrganizationMember).
+func OrgMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log := logger.GetLogger(r.Context())
+
+		orgID := chi.URLParam(r, "orgId")
+		if orgID == "" {
+			httperr.BadRequest400(w, r.Context(), httperr.ErrCodeMissingParameter, "orgId is required in path")
+			return
+		}
+		if !validateWorkspaceIDFormat(orgID) {
+			log.Warn("invalid org_id format", zap.String("org_id", orgID))
+			httperr.BadRequest400(w, r.Context(), httperr.ErrCodeInvalidOrgID, "orgId must contain only alphanumeric characters, hyphens, and underscores (max 64 chars)")
+			return
+		}
+
+		authCtx, ok := auth.GetAuthContext(r.Context())
+		if !ok {
+			log.Error("auth context not found")
+			httperr.Unauthorized401(w, r.Context(), httperr.ErrCodeInvalidToken, "authentication required")
+			return
+		}
+
+		if authCtx.OrgID != "" && authCtx.OrgID != orgID {
+			log.Warn("organization access denied - mismatch detected",
+				zap.String("auth_failure_reason", "org_mismatch"),
+				zap.String("auth_type", authCtx.AuthMethod),
+				zap.String("authenticated_org_id", authCtx.OrgID),
+				zap.String("path_org_id", orgID),
+				zap.String("actor_id", authCtx.ActorID),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+			)
+			httperr.Forbidden403(w, r.Context(), httperr.ErrCodeOrgMismatch, "organization access denied")
+			return
+		}
+
+		trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("org_id", orgID))
+
+		ctx := context.WithValue(r.Context(), orgIDKey, orgID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetOrgID retrieves validated organization ID from context
+func GetOrgID(ctx context.Context) (string, bool) {
+	orgID, ok := ctx.Value(orgIDKey).(string)
+	return orgID, ok
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestOrgMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		tokenOrgID     string
+		pathOrgID      string
+		noAuth         bool
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "BoundOrgMatch", tokenOrgID: "org-123", pathOrgID: "org-123", expectedStatus: http.StatusOK},
+		{name: "BoundOrgMismatch", tokenOrgID: "org-123", pathOrgID: "org-456", expectedStatus: http.StatusForbidden, expectedCode: httperr.ErrCodeOrgMismatch},
+		{name: "UnboundToken", pathOrgID: "org-456", expectedStatus: http.StatusOK},
+		{name: "InvalidFormat", pathOrgID: "org@456", expectedStatus: http.StatusBadRequest, expectedCode: httperr.ErrCodeInvalidOrgID},
+		{name: "MissingAuthContext", pathOrgID: "org-123", noAuth: true, expectedStatus: http.StatusUnauthorized, expectedCode: httperr.ErrCodeInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := setupTestContext()
+			if !tt.noAuth {
+				ctx = auth.SetAuthContextForTesting(ctx, &auth.AuthContext{
+					WorkspaceID: "ws-123",
+					OrgID:       tt.tokenOrgID,
+					ActorID:     "user-123",
+					ActorType:   "user",
+					AuthMethod:  "jwt",
+					Issuer:      "linkko-crm-web",
+				})
+			}
+
+			var gotOrgID string
+			r := chi.NewRouter()
+			r.Route("/v1/orgs/{orgId}", func(r chi.Router) {
+				r.Use(OrgMiddleware)
+				r.Get("/test", func(w http.ResponseWriter, r *http.Request) {
+					gotOrgID, _ = GetOrgID(r.Context())
+					w.WriteHeader(http.StatusOK)
+				})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/orgs/"+tt.pathOrgID+"/test", nil)
+			req = req.WithContext(ctx)
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d, body: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedCode != "" {
+				validateErrorResponse(t, rr.Body.String(), tt.expectedCode)
+			}
+			if tt.expectedStatus == http.StatusOK && gotOrgID != tt.pathOrgID {
+				t.Errorf("expected org id %q in context, got %q", tt.pathOrgID, gotOrgID)
+			}
+		})
+	}
+}
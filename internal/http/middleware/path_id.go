@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// invalidIDMessage detalhe por parâmetro devolvido em error.fields
+const invalidIDMessage = "must contain only alphanumeric characters, hyphens, and underscores (max 64 chars)"
+
+// PathIDMiddleware valida o formato de todo parâmetro de path terminado em "Id"/"ID"
+// ({contactId}, {itemID}...) com domain.IsValidID e responde 400 INVALID_ID antes de chegar
+// aos handlers, que leem os IDs com chi.URLParam sem checar formato. workspaceId fica com o
+// WorkspaceMiddleware. Como os middlewares de um subrouter rodam antes do match das rotas
+// internas, a rota é resolvida aqui com Routes.Match num contexto próprio; o roteamento real
+// não é afetado.
+func PathIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rctx := chi.RouteContext(ctx)
+		if rctx == nil || rctx.Routes == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		path := r.URL.RawPath
+		if path == "" {
+			path = r.URL.Path
+		}
+		matched := chi.NewRouteContext()
+		if !rctx.Routes.Match(matched, r.Method, path) {
+			// 404/405 ficam com o roteador
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		fields := map[string]string{}
+		for i, key := range matched.URLParams.Keys {
+			if !isPathIDParam(key) {
+				continue
+			}
+			if !domain.IsValidID(matched.URLParams.Values[i]) {
+				fields[key] = invalidIDMessage
+			}
+		}
+		if len(fields) > 0 {
+			logger.GetLogger(ctx).Warn(ctx, "invalid id format in path",
+				zap.String("path", r.URL.Path),
+				zap.Any("params", fields),
+			)
+			httperr.BadRequest400WithFields(w, ctx, httperr.ErrCodeInvalidID, "invalid ID format in path", fields)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isPathIDParam parâmetros de path que carregam IDs de recurso. externalId é a chave do
+// sistema de origem (até domain.ExternalIDMaxLength, qualquer conteúdo) e é validado pelo handler.
+func isPathIDParam(key string) bool {
+	if key == "workspaceId" || key == "externalId" {
+		return false
+	}
+	return strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "ID")
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newPathIDTestRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Route("/v1/workspaces/{workspaceId}", func(r chi.Router) {
+		r.Use(PathIDMiddleware)
+		r.Route("/contacts/{contactId}", func(r chi.Router) {
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(chi.URLParam(r, "contactId")))
+			})
+		})
+		r.Get("/deals/{dealId}/participants/{contactId}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		r.Get("/portfolio/{itemID}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		r.Get("/object-types/{objectKey}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		r.Put("/contacts/by-external-id/{externalId}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+	return r
+}
+
+func TestPathIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "Cuid", path: "/v1/workspaces/ws-1/contacts/cm1a2b3c4d5e6f7g8h9i0jklm", expectedStatus: http.StatusOK},
+		{name: "UUID", path: "/v1/workspaces/ws-1/contacts/123e4567-e89b-12d3-a456-426614174000", expectedStatus: http.StatusOK},
+		{name: "Prefixed", path: "/v1/workspaces/ws-1/contacts/sa_01hzx8k2m4n6p8q0r2s4t6v8w0", expectedStatus: http.StatusOK},
+		{name: "NestedInvalid", path: "/v1/workspaces/ws-1/deals/d1/participants/c%20x", expectedStatus: http.StatusBadRequest},
+		{name: "UppercaseSuffixInvalid", path: "/v1/workspaces/ws-1/portfolio/a.b", expectedStatus: http.StatusBadRequest},
+		{name: "TooLong", path: "/v1/workspaces/ws-1/contacts/" + strings.Repeat("a", 65), expectedStatus: http.StatusBadRequest},
+		{name: "NonIDParamIgnored", path: "/v1/workspaces/ws-1/object-types/a.b", expectedStatus: http.StatusOK},
+		{name: "ExternalIDDotted", method: http.MethodPut, path: "/v1/workspaces/ws-1/contacts/by-external-id/erp.cliente.42", expectedStatus: http.StatusOK},
+		{name: "ExternalIDEmail", method: http.MethodPut, path: "/v1/workspaces/ws-1/contacts/by-external-id/ana@example.com", expectedStatus: http.StatusOK},
+		{name: "ExternalIDLong", method: http.MethodPut, path: "/v1/workspaces/ws-1/contacts/by-external-id/" + strings.Repeat("x", 200), expectedStatus: http.StatusOK},
+		{name: "UnknownRoute", path: "/v1/workspaces/ws-1/unknown/a.b", expectedStatus: http.StatusNotFound},
+	}
+
+	router := newPathIDTestRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			req.URL.Path = tt.path
+			req = req.WithContext(setupTestContext())
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d (%s)", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == http.StatusBadRequest {
+				validateErrorResponse(t, w.Body.String(), "INVALID_ID")
+			}
+		})
+	}
+}
+
+func TestPathIDMiddleware_PreservesRouting(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts/c123", nil)
+	w := httptest.NewRecorder()
+
+	newPathIDTestRouter().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Body.String() != "c123" {
+		t.Fatalf("expected handler to see contactId c123, got %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// RequestPriorityHeader permite que clientes de sync/integração se declarem batch.
+// O header só rebaixa a prioridade: rotas batch nunca viram interativas.
+const RequestPriorityHeader = "X-Request-Priority"
+
+// batchLaneKey é a chave única do ConcurrencyLimiter usado como fila da lane batch
+const batchLaneKey = "lane:batch"
+
+// classifyLane decide a lane pela rota (ações de import/lote) ou pelo header.
+func classifyLane(r *http.Request) database.Lane {
+	path := r.URL.Path
+	if strings.HasSuffix(path, ":import") || strings.Contains(path, "/:bulk") || strings.Contains(path, "/:import") ||
+		strings.HasSuffix(path, "/:clone-to-sandbox") || strings.HasSuffix(path, "/:promote") {
+		return database.LaneBatch
+	}
+	if strings.EqualFold(strings.TrimSpace(r.Header.Get(RequestPriorityHeader)), string(database.LaneBatch)) {
+		return database.LaneBatch
+	}
+	return database.LaneInteractive
+}
+
+// PriorityMiddleware classifica a request em interactive/batch e injeta a lane no contexto.
+// Repositórios construídos sobre database.LanePool usam o pool batch para essas requests.
+// batchQueue (opcional) limita quantas requests batch rodam ao mesmo tempo na instância;
+// as excedentes esperam na fila e, se o tempo acabar, recebem 429.
+func PriorityMiddleware(batchQueue *ConcurrencyLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lane := classifyLane(r)
+			ctx := database.WithLane(r.Context(), lane)
+
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("request.lane", string(lane)))
+			w.Header().Set(RequestPriorityHeader, string(lane))
+
+			if lane == database.LaneBatch && batchQueue != nil && batchQueue.limit > 0 {
+				if !batchQueue.Acquire(ctx, batchLaneKey) {
+					logger.GetLogger(ctx).Warn(ctx, "batch lane saturated",
+						logger.Module("http"),
+						logger.Action("priority"),
+						zap.String("route", getRoutePattern(r)),
+						zap.Int("limit", batchQueue.limit),
+					)
+					w.Header().Set("Retry-After", "5")
+					httperr.WriteError(w, ctx, http.StatusTooManyRequests, "BATCH_CAPACITY_EXCEEDED", "batch lane is at capacity, retry later")
+					return
+				}
+				defer batchQueue.Release(batchLaneKey)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
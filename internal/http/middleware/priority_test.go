@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/database"
+)
+
+func TestPriorityMiddleware_Classification(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		header   string
+		expected database.Lane
+	}{
+		{name: "ListIsInteractive", method: http.MethodGet, path: "/v1/workspaces/ws-1/contacts", expected: database.LaneInteractive},
+		{name: "ImportIsBatch", method: http.MethodPost, path: "/v1/workspaces/ws-1/contacts/:import", expected: database.LaneBatch},
+		{name: "BulkIsBatch", method: http.MethodPost, path: "/v1/workspaces/ws-1/contacts/:bulk-update", expected: database.LaneBatch},
+		{name: "CloneIsBatch", method: http.MethodPost, path: "/v1/workspaces/ws-1/:clone-to-sandbox", expected: database.LaneBatch},
+		{name: "PromoteIsBatch", method: http.MethodPost, path: "/v1/workspaces/ws-1/:promote", expected: database.LaneBatch},
+		{name: "HeaderDowngrades", method: http.MethodGet, path: "/v1/workspaces/ws-1/contacts", header: "batch", expected: database.LaneBatch},
+		{name: "HeaderCannotUpgrade", method: http.MethodPost, path: "/v1/workspaces/ws-1/contacts/:import", header: "interactive", expected: database.LaneBatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got database.Lane
+			handler := PriorityMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = database.LaneFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req = req.WithContext(setupTestContext())
+			if tt.header != "" {
+				req.Header.Set(RequestPriorityHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if got != tt.expected {
+				t.Errorf("expected lane %q, got %q", tt.expected, got)
+			}
+			if w.Header().Get(RequestPriorityHeader) != string(tt.expected) {
+				t.Errorf("expected %s response header %q, got %q", RequestPriorityHeader, tt.expected, w.Header().Get(RequestPriorityHeader))
+			}
+		})
+	}
+}
+
+func TestPriorityMiddleware_BatchQueueSaturated(t *testing.T) {
+	queue := NewConcurrencyLimiter(1, 0)
+	if !queue.Acquire(setupTestContext(), batchLaneKey) {
+		t.Fatal("expected to occupy the only batch slot")
+	}
+	defer queue.Release(batchLaneKey)
+
+	handler := PriorityMiddleware(queue)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Batch é rejeitado com a fila cheia
+	req := httptest.NewRequest(http.MethodPost, "/v1/workspaces/ws-1/contacts/:import", nil)
+	req = req.WithContext(setupTestContext())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	validateErrorResponse(t, w.Body.String(), "BATCH_CAPACITY_EXCEEDED")
+
+	// Interativo não passa pela fila batch
+	req = httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+	req = req.WithContext(setupTestContext())
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for interactive request, got %d", w.Code)
+	}
+}
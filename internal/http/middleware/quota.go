@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const planQuotaKey contextKey = "plan_quota"
+
+// QuotaEnforcer is implemented by service.BillingService
+type QuotaEnforcer interface {
+	Quota(ctx context.Context, workspaceID string) (domain.PlanQuota, error)
+	CheckQuota(ctx context.Context, workspaceID string, quota domain.PlanQuota, resource domain.QuotaResource) error
+}
+
+// GetPlanQuota retorna as quotas do plano resolvidas pelo QuotaMiddleware
+func GetPlanQuota(ctx context.Context) (domain.PlanQuota, bool) {
+	q, ok := ctx.Value(planQuotaKey).(domain.PlanQuota)
+	return q, ok
+}
+
+// quotaResource identifica as requests que criam registros com limite por plano.
+func quotaResource(r *http.Request) (domain.QuotaResource, bool) {
+	if r.Method != http.MethodPost {
+		return "", false
+	}
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case strings.HasSuffix(path, "/contacts"):
+		return domain.QuotaContacts, true
+	case strings.HasSuffix(path, "/deals"):
+		return domain.QuotaDeals, true
+	}
+	return "", false
+}
+
+// QuotaMiddleware resolve as quotas do plano do workspace e as injeta no contexto
+// (o RateLimitMiddleware usa o limite por minuto do plano). Criações de contatos e negócios
+// acima do limite de registros do plano recebem 402 QUOTA_EXCEEDED.
+// Falhas ao consultar o billing liberam a request (fail-open), como no rate limit.
+// Um enforcer nil desabilita a checagem. Deve rodar após WorkspaceMiddleware e antes do rate limit.
+func QuotaMiddleware(enforcer QuotaEnforcer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if enforcer == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			log := logger.GetLogger(ctx)
+
+			workspaceID, ok := GetWorkspaceID(ctx)
+			if !ok {
+				log.Error(ctx, "workspace_id not found in context for quota enforcement")
+				httperr.InternalError(w, ctx)
+				return
+			}
+
+			quota, err := enforcer.Quota(ctx, workspaceID)
+			if err != nil {
+				log.Warn(ctx, "plan quota lookup failed, allowing request",
+					zap.String("workspace_id", workspaceID),
+					zap.Error(err),
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx = context.WithValue(ctx, planQuotaKey, quota)
+
+			if resource, ok := quotaResource(r); ok {
+				if err := enforcer.CheckQuota(ctx, workspaceID, quota, resource); err != nil {
+					if _, known := apperr.From(err); known {
+						trace.SpanFromContext(ctx).AddEvent("plan_quota_exceeded")
+						log.Warn(ctx, "plan quota exceeded",
+							zap.String("workspace_id", workspaceID),
+							zap.String("resource", string(resource)),
+							zap.Int64("limit", quota.Limit(resource)),
+						)
+						httperr.WriteAppError(w, ctx, err)
+						return
+					}
+					log.Warn(ctx, "plan quota check failed, allowing request",
+						zap.String("workspace_id", workspaceID),
+						zap.String("resource", string(resource)),
+						zap.Error(err),
+					)
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+)
+
+var errTestQuotaExceeded = apperr.Define("QUOTA_EXCEEDED", http.StatusPaymentRequired, "test quota exceeded", "")
+
+type fakeQuotaEnforcer struct {
+	quota    domain.PlanQuota
+	quotaErr error
+	checkErr error
+	checked  []domain.QuotaResource
+}
+
+func (f *fakeQuotaEnforcer) Quota(ctx context.Context, workspaceID string) (domain.PlanQuota, error) {
+	return f.quota, f.quotaErr
+}
+
+func (f *fakeQuotaEnforcer) CheckQuota(ctx context.Context, workspaceID string, quota domain.PlanQuota, resource domain.QuotaResource) error {
+	f.checked = append(f.checked, resource)
+	return f.checkErr
+}
+
+func TestQuotaMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		enforcer       *fakeQuotaEnforcer
+		method         string
+		path           string
+		expectedStatus int
+		expectChecked  domain.QuotaResource
+		expectQuota    bool
+	}{
+		{name: "CreateContactWithinQuota", enforcer: &fakeQuotaEnforcer{}, method: http.MethodPost, path: "/v1/workspaces/ws-1/contacts", expectedStatus: http.StatusOK, expectChecked: domain.QuotaContacts, expectQuota: true},
+		{name: "CreateDealTrailingSlash", enforcer: &fakeQuotaEnforcer{}, method: http.MethodPost, path: "/v1/workspaces/ws-1/deals/", expectedStatus: http.StatusOK, expectChecked: domain.QuotaDeals, expectQuota: true},
+		{name: "QuotaExceeded", enforcer: &fakeQuotaEnforcer{checkErr: errTestQuotaExceeded}, method: http.MethodPost, path: "/v1/workspaces/ws-1/contacts", expectedStatus: http.StatusPaymentRequired, expectChecked: domain.QuotaContacts},
+		{name: "ReadsAreNotChecked", enforcer: &fakeQuotaEnforcer{}, method: http.MethodGet, path: "/v1/workspaces/ws-1/contacts", expectedStatus: http.StatusOK, expectQuota: true},
+		{name: "ContactActionsAreNotChecked", enforcer: &fakeQuotaEnforcer{}, method: http.MethodPost, path: "/v1/workspaces/ws-1/contacts/:bulk-update", expectedStatus: http.StatusOK, expectQuota: true},
+		{name: "LookupErrorFailsOpen", enforcer: &fakeQuotaEnforcer{quotaErr: errors.New("connection refused")}, method: http.MethodPost, path: "/v1/workspaces/ws-1/contacts", expectedStatus: http.StatusOK},
+		{name: "CountErrorFailsOpen", enforcer: &fakeQuotaEnforcer{checkErr: errors.New("connection refused")}, method: http.MethodPost, path: "/v1/workspaces/ws-1/deals", expectedStatus: http.StatusOK, expectChecked: domain.QuotaDeals, expectQuota: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuota bool
+			handler := QuotaMiddleware(tt.enforcer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, gotQuota = GetPlanQuota(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req = req.WithContext(context.WithValue(setupTestContext(), workspaceIDKey, "ws-1"))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusPaymentRequired {
+				validateErrorResponse(t, w.Body.String(), "QUOTA_EXCEEDED")
+			}
+			if gotQuota != tt.expectQuota {
+				t.Errorf("expected plan quota in context=%v, got %v", tt.expectQuota, gotQuota)
+			}
+			if tt.expectChecked == "" && len(tt.enforcer.checked) > 0 {
+				t.Errorf("expected no quota check, got %v", tt.enforcer.checked)
+			}
+			if tt.expectChecked != "" && (len(tt.enforcer.checked) != 1 || tt.enforcer.checked[0] != tt.expectChecked) {
+				t.Errorf("expected check of %q, got %v", tt.expectChecked, tt.enforcer.checked)
+			}
+		})
+	}
+}
+
+func TestQuotaMiddleware_PlanRateLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		quota         domain.PlanQuota
+		expectedLimit string
+	}{
+		{name: "PlanLimit", quota: domain.PlanQuota{RequestsPerMinute: 300}, expectedLimit: "300"},
+		{name: "GlobalLimitWhenPlanHasNone", quota: domain.PlanQuota{}, expectedLimit: "100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := QuotaMiddleware(&fakeQuotaEnforcer{quota: tt.quota})(
+				RateLimitMiddleware(&fakeRateLimiter{allowed: true, remaining: 1}, staticLimit(100))(next),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+			req = req.WithContext(context.WithValue(setupTestContext(), workspaceIDKey, "ws-1"))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if got := w.Header().Get("X-RateLimit-Limit"); got != tt.expectedLimit {
+				t.Errorf("expected X-RateLimit-Limit %s, got %s", tt.expectedLimit, got)
+			}
+		})
+	}
+}
+
+func TestQuotaMiddleware_NilEnforcer(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	handler := QuotaMiddleware(nil)(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/workspaces/ws-1/contacts", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected nil enforcer to pass through, got %d", w.Code)
+	}
+}
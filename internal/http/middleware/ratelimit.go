@@ -1,26 +1,46 @@
 package middleware
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"linkko-api/internal/http/httperr"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/ratelimit"
+	"linkko-api/internal/resilience"
 
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-// RateLimitMiddleware enforces rate limiting per workspace
-func RateLimitMiddleware(limiter *ratelimit.RedisRateLimiter, limitPerMin int) func(http.Handler) http.Handler {
+// rateLimitWindowSeconds janela dos limites por workspace (RATE_LIMIT_PER_WORKSPACE_PER_MIN)
+const rateLimitWindowSeconds = 60
+
+// RateLimiter is implemented by ratelimit.RedisRateLimiter
+type RateLimiter interface {
+	AllowRequest(ctx context.Context, workspaceID string, limit int, windowSeconds int) (ratelimit.Decision, error)
+}
+
+// RateLimitPeeker lê a cota sem consumi-la. Implemented by ratelimit.RedisRateLimiter.
+type RateLimitPeeker interface {
+	Peek(ctx context.Context, workspaceID string, limit int, windowSeconds int) (ratelimit.Decision, error)
+}
+
+// RateLimitMiddleware enforces rate limiting per workspace.
+// limitPerMin (lido a cada request: RATE_LIMIT_PER_WORKSPACE_PER_MIN é recarregável) vale para
+// workspaces cujo plano não define RequestsPerMinute (ver QuotaMiddleware).
+// Com Redis fora ou circuit breaker aberto o limiter decide em memória (WithLocalFallback); se
+// mesmo assim retornar erro, a request é liberada (fail-open).
+func RateLimitMiddleware(limiter RateLimiter, limitPerMin func() int) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			log := logger.GetLogger(r.Context())
 
-			// Get workspace ID from context (set by WorkspaceMiddleware)
-			workspaceID, ok := GetWorkspaceID(r.Context())
+			workspaceID, limit, ok := rateLimitTarget(r, limitPerMin)
 			if !ok {
 				log.Error(r.Context(), "workspace_id not found in context for rate limiting")
 				httperr.InternalError(w, r.Context())
@@ -28,29 +48,33 @@ func RateLimitMiddleware(limiter *ratelimit.RedisRateLimiter, limitPerMin int) f
 			}
 
 			// Check rate limit
-			allowed, remaining, err := limiter.AllowRequest(r.Context(), workspaceID, limitPerMin, 60)
+			decision, err := limiter.AllowRequest(r.Context(), workspaceID, limit, rateLimitWindowSeconds)
 			if err != nil {
-				log.Error(r.Context(), "rate limit check failed", zap.Error(err))
-				httperr.InternalError(w, r.Context())
+				// Fail-open: indisponibilidade do Redis não pode derrubar a API inteira.
+				// Quando o breaker está aberto o Redis nem é chamado.
+				log.Warn(r.Context(), "rate limit check failed, allowing request",
+					zap.String("workspace_id", workspaceID),
+					zap.Bool("circuit_open", errors.Is(err, resilience.ErrCircuitOpen)),
+					zap.Error(err),
+				)
+				trace.SpanFromContext(r.Context()).AddEvent("rate_limit_fail_open")
+				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Add rate limit headers
-			w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", limitPerMin))
-			w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
-			w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(60*time.Second).Unix()))
+			setRateLimitHeaders(w, decision)
 
-			if !allowed {
+			if !decision.Allowed {
 				// Add span event for rate limit exceeded
 				span := trace.SpanFromContext(r.Context())
 				span.AddEvent("rate_limit_exceeded")
 
 				log.Warn(r.Context(), "rate limit exceeded",
 					zap.String("workspace_id", workspaceID),
-					zap.Int("limit", limitPerMin),
+					zap.Int("limit", limit),
+					zap.Duration("retry_after", decision.RetryAfter),
 				)
 
-				w.Header().Set("Retry-After", "60")
 				httperr.WriteError(w, r.Context(), http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "rate limit exceeded")
 				return
 			}
@@ -59,3 +83,65 @@ func RateLimitMiddleware(limiter *ratelimit.RedisRateLimiter, limitPerMin int) f
 		})
 	}
 }
+
+// RateLimitStatusHandler HEAD /v1/workspaces/{workspaceId}/rate-limit: os mesmos headers
+// X-RateLimit-* (e Retry-After sem cota) do RateLimitMiddleware, sem consumir a cota, para clientes
+// regularem importações. Monte antes do RateLimitMiddleware e depois do QuotaMiddleware.
+func RateLimitStatusHandler(limiter RateLimitPeeker, limitPerMin func() int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		workspaceID, limit, ok := rateLimitTarget(r, limitPerMin)
+		if !ok {
+			logger.GetLogger(r.Context()).Error(r.Context(), "workspace_id not found in context for rate limit status")
+			httperr.InternalError(w, r.Context())
+			return
+		}
+
+		decision, err := limiter.Peek(r.Context(), workspaceID, limit, rateLimitWindowSeconds)
+		if err != nil {
+			logger.GetLogger(r.Context()).Warn(r.Context(), "rate limit status unavailable",
+				zap.String("workspace_id", workspaceID),
+				zap.Error(err),
+			)
+			httperr.ServiceUnavailable503(w, r.Context(), "rate limit status unavailable", 5)
+			return
+		}
+
+		setRateLimitHeaders(w, decision)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// rateLimitTarget chave e limite da request: o workspace (WorkspaceMiddleware) ou, nas rotas de
+// organização, o orgId prefixado (OrgMiddleware). Planos com limite próprio (QuotaMiddleware)
+// substituem o limite global.
+func rateLimitTarget(r *http.Request, limitPerMin func() int) (string, int, bool) {
+	workspaceID, ok := GetWorkspaceID(r.Context())
+	if !ok {
+		orgID, isOrg := GetOrgID(r.Context())
+		if !isOrg {
+			return "", 0, false
+		}
+		workspaceID = "org:" + orgID
+	}
+
+	limit := limitPerMin()
+	if quota, ok := GetPlanQuota(r.Context()); ok && quota.RequestsPerMinute > 0 {
+		limit = quota.RequestsPerMinute
+	}
+	return workspaceID, limit, true
+}
+
+// setRateLimitHeaders X-RateLimit-Reset é quando a cota volta inteira (epoch em segundos);
+// Retry-After só vai sem cota, arredondado para cima para o cliente não voltar cedo demais.
+func setRateLimitHeaders(w http.ResponseWriter, decision ratelimit.Decision) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(decision.ResetAfter).Add(time.Second-1).Unix(), 10))
+	if !decision.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(decision.RetryAfter)))
+	}
+}
+
+func retryAfterSeconds(d time.Duration) int {
+	return max(1, int(math.Ceil(d.Seconds())))
+}
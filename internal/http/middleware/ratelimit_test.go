@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"linkko-api/internal/ratelimit"
+	"linkko-api/internal/resilience"
+)
+
+type fakeRateLimiter struct {
+	allowed    bool
+	remaining  int
+	retryAfter time.Duration
+	err        error
+
+	peeked   bool
+	consumed int
+}
+
+func staticLimit(n int) func() int {
+	return func() int { return n }
+}
+
+func (f *fakeRateLimiter) AllowRequest(ctx context.Context, workspaceID string, limit int, windowSeconds int) (ratelimit.Decision, error) {
+	f.consumed++
+	return f.decision(limit), f.err
+}
+
+func (f *fakeRateLimiter) Peek(ctx context.Context, workspaceID string, limit int, windowSeconds int) (ratelimit.Decision, error) {
+	f.peeked = true
+	return f.decision(limit), f.err
+}
+
+func (f *fakeRateLimiter) decision(limit int) ratelimit.Decision {
+	if f.err != nil {
+		return ratelimit.Decision{}
+	}
+	return ratelimit.Decision{Allowed: f.allowed, Limit: limit, Remaining: f.remaining, RetryAfter: f.retryAfter, ResetAfter: time.Minute}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		limiter        *fakeRateLimiter
+		expectedStatus int
+		expectHeaders  bool
+		retryAfter     string
+	}{
+		{name: "Allowed", limiter: &fakeRateLimiter{allowed: true, remaining: 9}, expectedStatus: http.StatusOK, expectHeaders: true},
+		{name: "Rejected", limiter: &fakeRateLimiter{allowed: false, retryAfter: 12300 * time.Millisecond}, expectedStatus: http.StatusTooManyRequests, expectHeaders: true, retryAfter: "13"},
+		{name: "RejectedRetryAfterAtLeastOneSecond", limiter: &fakeRateLimiter{allowed: false, retryAfter: time.Millisecond}, expectedStatus: http.StatusTooManyRequests, expectHeaders: true, retryAfter: "1"},
+		{name: "RedisErrorFailsOpen", limiter: &fakeRateLimiter{err: errors.New("dial tcp: connection refused")}, expectedStatus: http.StatusOK},
+		{name: "CircuitOpenFailsOpen", limiter: &fakeRateLimiter{err: resilience.ErrCircuitOpen}, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RateLimitMiddleware(tt.limiter, staticLimit(10))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+			ctx := context.WithValue(setupTestContext(), workspaceIDKey, "ws-1")
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if hasHeader := w.Header().Get("X-RateLimit-Limit") != ""; hasHeader != tt.expectHeaders {
+				t.Errorf("expected X-RateLimit-Limit present=%v, got %v", tt.expectHeaders, hasHeader)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("expected Retry-After %q, got %q", tt.retryAfter, got)
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests {
+				validateErrorResponse(t, w.Body.String(), "RATE_LIMIT_EXCEEDED")
+			}
+		})
+	}
+}
+
+func TestRateLimitStatusHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		limiter        *fakeRateLimiter
+		expectedStatus int
+		remaining      string
+		retryAfter     string
+	}{
+		{name: "QuotaLeft", limiter: &fakeRateLimiter{allowed: true, remaining: 40}, expectedStatus: http.StatusOK, remaining: "40"},
+		{name: "Exhausted", limiter: &fakeRateLimiter{allowed: false, retryAfter: 2500 * time.Millisecond}, expectedStatus: http.StatusOK, remaining: "0", retryAfter: "3"},
+		{name: "LimiterUnavailable", limiter: &fakeRateLimiter{err: errors.New("dial tcp: connection refused")}, expectedStatus: http.StatusServiceUnavailable, retryAfter: "5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RateLimitStatusHandler(tt.limiter, staticLimit(100))
+
+			req := httptest.NewRequest(http.MethodHead, "/v1/workspaces/ws-1/rate-limit", nil)
+			req = req.WithContext(context.WithValue(setupTestContext(), workspaceIDKey, "ws-1"))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if got := w.Header().Get("X-RateLimit-Remaining"); got != tt.remaining {
+				t.Errorf("expected X-RateLimit-Remaining %q, got %q", tt.remaining, got)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("expected Retry-After %q, got %q", tt.retryAfter, got)
+			}
+			if !tt.limiter.peeked || tt.limiter.consumed != 0 {
+				t.Errorf("expected a peek without consuming quota, got peeked=%v consumed=%d", tt.limiter.peeked, tt.limiter.consumed)
+			}
+		})
+	}
+}
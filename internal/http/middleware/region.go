@@ -0,0 +1,20 @@
+package middleware
+
+import "net/http"
+
+// RegionHeader região que atendeu a request (REGION). Clientes e load balancers podem usá-la para
+// manter as requests seguintes na mesma região, onde ficam o rate limit e a idempotência.
+const RegionHeader = "X-Linkko-Region"
+
+// RegionMiddleware adiciona RegionHeader a todas as responses; region vazia desliga o header.
+func RegionMiddleware(region string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if region == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(RegionHeader, region)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegionMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		region   string
+		expected string
+	}{
+		{name: "Region", region: "sa-east-1", expected: "sa-east-1"},
+		{name: "SingleRegion", region: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RegionMiddleware(tt.region)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			if got := w.Header().Get(RegionHeader); got != tt.expected {
+				t.Errorf("expected %s %q, got %q", RegionHeader, tt.expected, got)
+			}
+			if w.Code != http.StatusNoContent {
+				t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ScimTokenResolver is implemented by service.ScimService
+type ScimTokenResolver interface {
+	ResolveToken(ctx context.Context, token string) (string, error)
+}
+
+// ScimAuthMiddleware autentica /scim/v2 com o bearer token SCIM do workspace (não um JWT) e
+// injeta o workspace no contexto, como o WorkspaceMiddleware: o rate limit por workspace vale
+// também para o diretório. Erros seguem o formato SCIM.
+func ScimAuthMiddleware(resolver ScimTokenResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			log := logger.GetLogger(ctx)
+
+			header := r.Header.Get("Authorization")
+			scheme, token, found := strings.Cut(header, " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+				httperr.WriteScimError(w, ctx, http.StatusUnauthorized, "", "bearer SCIM token required")
+				return
+			}
+
+			workspaceID, err := resolver.ResolveToken(ctx, strings.TrimSpace(token))
+			if err != nil {
+				if appErr, known := apperr.From(err); known && appErr.Status == http.StatusUnauthorized {
+					log.Warn(ctx, "scim authentication failed",
+						zap.String("auth_failure_reason", "invalid_scim_token"),
+						zap.String("method", r.Method),
+						zap.String("path", r.URL.Path),
+					)
+					w.Header().Set("WWW-Authenticate", `Bearer realm="scim", error="invalid_token"`)
+					httperr.WriteScimError(w, ctx, http.StatusUnauthorized, "", appErr.Public)
+					return
+				}
+				httperr.WriteScimAppError(w, ctx, err)
+				return
+			}
+
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("workspace_id", workspaceID))
+
+			ctx = context.WithValue(ctx, workspaceIDKey, workspaceID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/http/httperr"
+)
+
+var errTestScimToken = apperr.Define("INVALID_TOKEN", http.StatusUnauthorized, "test scim token not found", "invalid SCIM token")
+
+type fakeScimTokenResolver struct {
+	tokens map[string]string
+	err    error
+	got    string
+}
+
+func (f *fakeScimTokenResolver) ResolveToken(ctx context.Context, token string) (string, error) {
+	f.got = token
+	if f.err != nil {
+		return "", f.err
+	}
+	ws, ok := f.tokens[token]
+	if !ok {
+		return "", errTestScimToken
+	}
+	return ws, nil
+}
+
+func TestScimAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name              string
+		authorization     string
+		resolverErr       error
+		expectedStatus    int
+		expectedWorkspace string
+	}{
+		{name: "ValidToken", authorization: "Bearer tok-1", expectedStatus: http.StatusOK, expectedWorkspace: "ws-1"},
+		{name: "SchemeIsCaseInsensitive", authorization: "bearer tok-1", expectedStatus: http.StatusOK, expectedWorkspace: "ws-1"},
+		{name: "MissingHeader", expectedStatus: http.StatusUnauthorized},
+		{name: "WrongScheme", authorization: "Basic dXNlcjpwYXNz", expectedStatus: http.StatusUnauthorized},
+		{name: "UnknownToken", authorization: "Bearer tok-2", expectedStatus: http.StatusUnauthorized},
+		{name: "ResolverFailureIs500", authorization: "Bearer tok-1", resolverErr: errors.New("connection refused"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeScimTokenResolver{tokens: map[string]string{"tok-1": "ws-1"}, err: tt.resolverErr}
+
+			var gotWorkspace string
+			handler := ScimAuthMiddleware(resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotWorkspace, _ = GetWorkspaceID(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			req = req.WithContext(setupTestContext())
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if gotWorkspace != tt.expectedWorkspace {
+				t.Errorf("expected workspace %q in context, got %q", tt.expectedWorkspace, gotWorkspace)
+			}
+			if rr.Code == http.StatusOK {
+				return
+			}
+
+			if ct := rr.Header().Get("Content-Type"); ct != httperr.ScimContentType {
+				t.Errorf("expected SCIM content type, got %q", ct)
+			}
+			var body httperr.ScimErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to parse SCIM error: %v, body: %s", err, rr.Body.String())
+			}
+			if len(body.Schemas) != 1 || body.Schemas[0] != "urn:ietf:params:scim:api:messages:2.0:Error" {
+				t.Errorf("unexpected schemas %v", body.Schemas)
+			}
+			if rr.Code == http.StatusUnauthorized && rr.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected WWW-Authenticate header on 401")
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// ScopeMiddleware restringe tokens com escopo (service accounts) aos recursos do escopo: leituras
+// pedem <recurso>:read, o resto <recurso>:write. O recurso é o primeiro segmento depois de
+// /workspaces/{workspaceId}; rotas fora de domain.ServiceAccountScopeResources (billing, sso,
+// service-accounts...) e fora de workspace são sempre negadas. Tokens sem escopo passam direto.
+// Deve rodar depois do AuthMiddleware; o papel continua sendo checado nos services.
+func ScopeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authCtx, ok := auth.GetAuthContext(r.Context())
+		if !ok || !authCtx.IsScoped() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		resource := scopeResource(r.URL.Path)
+		// O uso do rate limit (HEAD /rate-limit) e o status da API (GET /status) valem para qualquer
+		// escopo: é como a integração se regula
+		if (resource == rateLimitStatusResource && r.Method == http.MethodHead) ||
+			(resource == apiStatusResource && r.Method == http.MethodGet) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		action := domain.ScopeActionWrite
+		if isReadOnlyMethod(r.Method) {
+			action = domain.ScopeActionRead
+		}
+
+		if !domain.ScopesAllow(authCtx.Scopes, resource, action) {
+			logger.GetLogger(r.Context()).Warn("request outside token scope",
+				zap.String("actor_id", authCtx.ActorID),
+				zap.String("actor_type", authCtx.ActorType),
+				zap.Strings("scopes", authCtx.Scopes),
+				zap.String("required_scope", resource+":"+action),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+			)
+			httperr.Forbidden403(w, r.Context(), httperr.ErrCodeInsufficientScope, "token scope does not allow this operation")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitStatusResource segmento de RateLimitStatusHandler
+const rateLimitStatusResource = "rate-limit"
+
+// apiStatusResource segmento de GET /v1/workspaces/{workspaceId}/status
+const apiStatusResource = "status"
+
+// scopeResource /v1/workspaces/ws_1/contacts/c_1 -> "contacts"; "" fora de workspace.
+// Ações de coleção (/deals/:bulk) mantêm o recurso; ações do workspace (/:undo) ficam vazias.
+func scopeResource(path string) string {
+	_, rest, found := strings.Cut(path, "/workspaces/")
+	if !found {
+		return ""
+	}
+	segments := strings.SplitN(rest, "/", 3)
+	if len(segments) < 2 {
+		return ""
+	}
+	resource, _, _ := strings.Cut(segments[1], ":")
+	return resource
+}
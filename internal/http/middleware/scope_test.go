@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/http/httperr"
+)
+
+func TestScopeMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		actorType      string
+		scopes         []string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "UnscopedUserToken", actorType: auth.ActorTypeUser, method: http.MethodDelete, path: "/v1/workspaces/ws-123/billing", expectedStatus: http.StatusOK},
+		{name: "ReadScopeAllowsGet", actorType: auth.ActorTypeServiceAccount, scopes: []string{"contacts:read"}, method: http.MethodGet, path: "/v1/workspaces/ws-123/contacts/c-1", expectedStatus: http.StatusOK},
+		{name: "ReadScopeBlocksWrite", actorType: auth.ActorTypeServiceAccount, scopes: []string{"contacts:read"}, method: http.MethodPatch, path: "/v1/workspaces/ws-123/contacts/c-1", expectedStatus: http.StatusForbidden},
+		{name: "WriteImpliesRead", actorType: auth.ActorTypeServiceAccount, scopes: []string{"deals:write"}, method: http.MethodGet, path: "/v1/workspaces/ws-123/deals", expectedStatus: http.StatusOK},
+		{name: "CollectionAction", actorType: auth.ActorTypeServiceAccount, scopes: []string{"deals:write"}, method: http.MethodPost, path: "/v1/workspaces/ws-123/deals/d-1/:move", expectedStatus: http.StatusOK},
+		{name: "OtherResourceBlocked", actorType: auth.ActorTypeServiceAccount, scopes: []string{"contacts:write"}, method: http.MethodGet, path: "/v1/workspaces/ws-123/deals", expectedStatus: http.StatusForbidden},
+		{name: "WildcardRead", actorType: auth.ActorTypeServiceAccount, scopes: []string{"*:read"}, method: http.MethodGet, path: "/v1/workspaces/ws-123/tasks", expectedStatus: http.StatusOK},
+		{name: "WildcardNeverCoversAdminRoutes", actorType: auth.ActorTypeServiceAccount, scopes: []string{"*:write"}, method: http.MethodGet, path: "/v1/workspaces/ws-123/service-accounts", expectedStatus: http.StatusForbidden},
+		{name: "WildcardNeverCoversWorkspaceActions", actorType: auth.ActorTypeServiceAccount, scopes: []string{"*:write"}, method: http.MethodPost, path: "/v1/workspaces/ws-123/:undo", expectedStatus: http.StatusForbidden},
+		{name: "OutsideWorkspaceBlocked", actorType: auth.ActorTypeServiceAccount, scopes: []string{"*:read"}, method: http.MethodGet, path: "/v1/orgs/org-1", expectedStatus: http.StatusForbidden},
+		{name: "StatusAllowedForAnyScope", actorType: auth.ActorTypeServiceAccount, scopes: []string{"contacts:read"}, method: http.MethodGet, path: "/v1/workspaces/ws-123/status", expectedStatus: http.StatusOK},
+		{name: "ServiceAccountWithoutScopes", actorType: auth.ActorTypeServiceAccount, method: http.MethodGet, path: "/v1/workspaces/ws-123/contacts", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.SetAuthContextForTesting(setupTestContext(), &auth.AuthContext{
+				WorkspaceID: "ws-123",
+				ActorID:     "sa_123",
+				ActorType:   tt.actorType,
+				AuthMethod:  "jwt",
+				Scopes:      tt.scopes,
+			})
+
+			handler := ScopeMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.path, nil).WithContext(ctx)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d, body: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus == http.StatusForbidden {
+				validateErrorResponse(t, rr.Body.String(), httperr.ErrCodeInsufficientScope)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// ShardResolver is implemented by service.ShardService
+type ShardResolver interface {
+	// ShardFor retorna o shard de dados do workspace (database.DefaultShard fora do mapa)
+	ShardFor(ctx context.Context, workspaceID string) (string, error)
+}
+
+// ShardMiddleware grava no contexto o shard de dados do workspace (residência de dados), usado
+// pelo database.ShardRouter dos repositórios. Roda depois do WorkspaceMiddleware. Falhas na
+// consulta respondem 503 (fail-closed: seguir no banco padrão gravaria fora do shard);
+// resolver nil desabilita (sem DATABASE_SHARDS).
+func ShardMiddleware(resolver ShardResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if resolver == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			workspaceID, ok := GetWorkspaceID(ctx)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			shard, err := resolver.ShardFor(ctx, workspaceID)
+			if err != nil {
+				logger.GetLogger(ctx).Error(ctx, "shard lookup failed",
+					zap.String("workspace_id", workspaceID),
+					zap.Error(err),
+				)
+				httperr.ServiceUnavailable503(w, ctx, "workspace data is temporarily unavailable", 5)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(database.WithShard(ctx, shard)))
+		})
+	}
+}
+
+// ShardProbeMiddleware rotas públicas sem workspace no path (formulários, links curtos,
+// orçamentos compartilhados): a primeira consulta que encontra o registro fixa o shard da
+// request (database.WithShardProbe). enabled false (sem DATABASE_SHARDS) desabilita.
+func ShardProbeMiddleware(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(database.WithShardProbe(r.Context())))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/database"
+)
+
+type fakeShardResolver struct {
+	shards map[string]string
+	err    error
+}
+
+func (f *fakeShardResolver) ShardFor(ctx context.Context, workspaceID string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	if shard, ok := f.shards[workspaceID]; ok {
+		return shard, nil
+	}
+	return database.DefaultShard, nil
+}
+
+func TestShardMiddleware(t *testing.T) {
+	resolver := &fakeShardResolver{shards: map[string]string{"ws-eu": "eu"}}
+
+	tests := []struct {
+		name           string
+		resolver       ShardResolver
+		workspaceID    string
+		expectedStatus int
+		expectedShard  string
+	}{
+		{name: "MappedWorkspace", resolver: resolver, workspaceID: "ws-eu", expectedStatus: http.StatusOK, expectedShard: "eu"},
+		{name: "UnmappedWorkspace", resolver: resolver, workspaceID: "ws-1", expectedStatus: http.StatusOK, expectedShard: database.DefaultShard},
+		{name: "NoWorkspace", resolver: resolver, expectedStatus: http.StatusOK, expectedShard: database.DefaultShard},
+		{name: "Disabled", resolver: nil, workspaceID: "ws-eu", expectedStatus: http.StatusOK, expectedShard: database.DefaultShard},
+		{name: "LookupErrorFailsClosed", resolver: &fakeShardResolver{err: errors.New("connection refused")}, workspaceID: "ws-eu", expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotShard string
+			handler := ShardMiddleware(tt.resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotShard = database.ShardFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			ctx := setupTestContext()
+			if tt.workspaceID != "" {
+				ctx = context.WithValue(ctx, workspaceIDKey, tt.workspaceID)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-eu/contacts", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				validateErrorResponse(t, w.Body.String(), "SERVICE_UNAVAILABLE")
+				return
+			}
+			if gotShard != tt.expectedShard {
+				t.Errorf("expected shard %q, got %q", tt.expectedShard, gotShard)
+			}
+		})
+	}
+}
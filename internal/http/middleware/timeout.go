@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// TimeoutConfig define os deadlines por classe de rota.
+// Devem ficar abaixo do WriteTimeout do http.Server para que o cliente receba
+// um 504 estruturado em vez de uma conexão cortada.
+type TimeoutConfig struct {
+	Read   time.Duration // GET/HEAD
+	Write  time.Duration // POST/PATCH/PUT/DELETE
+	Import time.Duration // ações de importação/lote (":import", ":bulk-*") e streams NDJSON
+}
+
+// timeoutFor escolhe o deadline da request conforme método e sufixo de ação.
+func (c TimeoutConfig) timeoutFor(r *http.Request) time.Duration {
+	path := r.URL.Path
+	if strings.HasSuffix(path, ":import") || strings.Contains(path, "/:bulk") || strings.Contains(path, "/:import") {
+		return c.Import
+	}
+	// Streams NDJSON percorrem a listagem inteira: mesmo deadline das ações em lote
+	if strings.Contains(r.Header.Get("Accept"), "application/x-ndjson") {
+		return c.Import
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return c.Read
+	}
+	return c.Write
+}
+
+// timeoutResponseWriter registra se a resposta já começou a ser escrita.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (tw *timeoutResponseWriter) WriteHeader(statusCode int) {
+	tw.wroteHeader = true
+	tw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (tw *timeoutResponseWriter) Write(b []byte) (int, error) {
+	tw.wroteHeader = true
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap expõe o writer original ao http.ResponseController (Flush do streaming).
+func (tw *timeoutResponseWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
+
+// TimeoutMiddleware aplica um deadline em r.Context() para que queries lentas sejam
+// canceladas pelo pgx/redis em vez de segurar a conexão até o WriteTimeout.
+// Se o deadline expirar e o handler não tiver respondido, retorna 504.
+// Handlers que já mapearam o erro passam por httperr.InternalError500, que
+// também traduz context.DeadlineExceeded em 504.
+func TimeoutMiddleware(cfg TimeoutConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := cfg.timeoutFor(r)
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				logger.GetLogger(ctx).Warn(ctx, "request deadline exceeded",
+					logger.Module("http"),
+					logger.Action("timeout"),
+					zap.String("method", r.Method),
+					zap.String("route", getRoutePattern(r)),
+					zap.Duration("timeout", timeout),
+					zap.Bool("response_written", tw.wroteHeader),
+				)
+				if !tw.wroteHeader {
+					logger.SetRootError(ctx, ctx.Err())
+					httperr.GatewayTimeout504(w, ctx, "request exceeded its deadline")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"linkko-api/internal/http/httperr"
+)
+
+func TestTimeoutConfig_TimeoutFor(t *testing.T) {
+	cfg := TimeoutConfig{Read: 1 * time.Second, Write: 2 * time.Second, Import: 3 * time.Second}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		accept   string
+		expected time.Duration
+	}{
+		{name: "GetUsesRead", method: http.MethodGet, path: "/v1/workspaces/ws/contacts", expected: cfg.Read},
+		{name: "HeadUsesRead", method: http.MethodHead, path: "/v1/workspaces/ws/contacts", expected: cfg.Read},
+		{name: "PostUsesWrite", method: http.MethodPost, path: "/v1/workspaces/ws/contacts", expected: cfg.Write},
+		{name: "DeleteUsesWrite", method: http.MethodDelete, path: "/v1/workspaces/ws/contacts/c1", expected: cfg.Write},
+		{name: "ImportAction", method: http.MethodPost, path: "/v1/workspaces/ws/contacts/:import", expected: cfg.Import},
+		{name: "BulkAction", method: http.MethodPost, path: "/v1/workspaces/ws/contacts/:bulk-update", expected: cfg.Import},
+		{name: "NDJSONStream", method: http.MethodGet, path: "/v1/workspaces/ws/timeline", accept: "application/x-ndjson", expected: cfg.Import},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if got := cfg.timeoutFor(req); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddleware_SetsDeadline(t *testing.T) {
+	var hasDeadline bool
+	handler := TimeoutMiddleware(TimeoutConfig{Read: time.Second})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws/contacts", nil)
+	req = req.WithContext(setupTestContext())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if !hasDeadline {
+		t.Error("expected request context to carry a deadline")
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestTimeoutMiddleware_ZeroDisables(t *testing.T) {
+	var hasDeadline bool
+	handler := TimeoutMiddleware(TimeoutConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws/contacts", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(setupTestContext()))
+
+	if hasDeadline {
+		t.Error("expected no deadline when timeout is zero")
+	}
+}
+
+func TestTimeoutMiddleware_Returns504WhenHandlerSilent(t *testing.T) {
+	handler := TimeoutMiddleware(TimeoutConfig{Read: 10 * time.Millisecond})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simula uma query lenta que respeita o cancelamento do contexto
+		<-r.Context().Done()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws/contacts", nil)
+	req = req.WithContext(setupTestContext())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", w.Code)
+	}
+	validateErrorResponse(t, w.Body.String(), httperr.ErrCodeTimeout)
+}
+
+func TestTimeoutMiddleware_HandlerErrorMappedTo504(t *testing.T) {
+	handler := TimeoutMiddleware(TimeoutConfig{Read: 10 * time.Millisecond})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		// Handler mapeia o erro do repo para 500 genérico
+		httperr.InternalError500(w, r.Context(), "query contacts: context deadline exceeded")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws/contacts", nil)
+	req = req.WithContext(setupTestContext())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", w.Code)
+	}
+	validateErrorResponse(t, w.Body.String(), httperr.ErrCodeTimeout)
+}
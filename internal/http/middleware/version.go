@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// Versões de API suportadas. A versão é definida pelo prefixo do path (/v1, /v2);
+// o header X-API-Version é opcional e, quando enviado, precisa bater com o path.
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+
+	// APIVersionHeader é lido na request e ecoado na response
+	APIVersionHeader = "X-API-Version"
+)
+
+const apiVersionKey contextKey = "api_version"
+
+// normalizeAPIVersion aceita "v2", "V2" ou "2" e retorna "v2".
+func normalizeAPIVersion(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v != "" && !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return v
+}
+
+// APIVersionMiddleware fixa a versão da API montada no sub-router e a injeta no contexto.
+// - Ecoa X-API-Version na response
+// - Rejeita com 400 quando o header X-API-Version diverge da versão do path
+func APIVersionMiddleware(version string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(APIVersionHeader, version)
+
+			if requested := r.Header.Get(APIVersionHeader); requested != "" {
+				if normalizeAPIVersion(requested) != version {
+					httperr.BadRequest400(w, r.Context(), httperr.ErrCodeUnsupportedVersion,
+						fmt.Sprintf("%s header %q does not match path version %s", APIVersionHeader, requested, version))
+					return
+				}
+			}
+
+			ctx := context.WithValue(r.Context(), apiVersionKey, version)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetAPIVersion retrieves the negotiated API version from context
+func GetAPIVersion(ctx context.Context) (string, bool) {
+	version, ok := ctx.Value(apiVersionKey).(string)
+	return version, ok
+}
+
+// DeprecationPolicy descreve um endpoint marcado para remoção.
+type DeprecationPolicy struct {
+	// Since é quando o endpoint foi marcado como deprecated (zero = "true")
+	Since time.Time
+	// Sunset é a data a partir da qual o endpoint pode ser removido
+	Sunset time.Time
+	// Successor é o path (ou URL) que substitui o endpoint, enviado como Link rel="successor-version"
+	Successor string
+	// SuccessorVersion aponta o Link para o mesmo path na versão indicada (ex.: /v1/... -> /v2/...);
+	// usado quando a política cobre uma versão inteira em vez de um único endpoint
+	SuccessorVersion string
+}
+
+// DeprecationMiddleware emite os headers Deprecation (RFC 9745), Sunset (RFC 8594)
+// e Link para endpoints marcados para remoção. Não altera o comportamento da rota.
+func DeprecationMiddleware(policy DeprecationPolicy) func(http.Handler) http.Handler {
+	deprecation := "true"
+	if !policy.Since.IsZero() {
+		deprecation = fmt.Sprintf("@%d", policy.Since.Unix())
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			if !policy.Sunset.IsZero() {
+				w.Header().Set("Sunset", policy.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if policy.Successor != "" {
+				w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", policy.Successor))
+			}
+			if successor, ok := successorPath(r, policy.SuccessorVersion); ok {
+				w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+			}
+
+			logger.GetLogger(r.Context()).Debug(r.Context(), "deprecated endpoint called",
+				logger.Module("http"),
+				logger.Action("deprecation"),
+				zap.String("method", r.Method),
+				zap.String("route", getRoutePattern(r)),
+			)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// successorPath troca o prefixo de versão do path da request pela versão sucessora.
+func successorPath(r *http.Request, successor string) (string, bool) {
+	version, ok := GetAPIVersion(r.Context())
+	if successor == "" || !ok {
+		return "", false
+	}
+	rest, found := strings.CutPrefix(r.URL.EscapedPath(), "/"+version+"/")
+	if !found {
+		return "", false
+	}
+	return "/" + successor + "/" + rest, true
+}
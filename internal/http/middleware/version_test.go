@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"linkko-api/internal/http/httperr"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{name: "NoHeader", header: "", expectedStatus: http.StatusOK},
+		{name: "MatchingHeader", header: "v2", expectedStatus: http.StatusOK},
+		{name: "MatchingHeaderWithoutPrefix", header: "2", expectedStatus: http.StatusOK},
+		{name: "MatchingHeaderUppercase", header: "V2", expectedStatus: http.StatusOK},
+		{name: "MismatchedHeader", header: "v1", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotVersion string
+			handler := APIVersionMiddleware(APIVersionV2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotVersion, _ = GetAPIVersion(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v2/workspaces/ws-1/contacts", nil)
+			req = req.WithContext(setupTestContext())
+			if tt.header != "" {
+				req.Header.Set(APIVersionHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if got := w.Header().Get(APIVersionHeader); got != APIVersionV2 {
+				t.Errorf("expected %s response header %q, got %q", APIVersionHeader, APIVersionV2, got)
+			}
+			if tt.expectedStatus == http.StatusOK && gotVersion != APIVersionV2 {
+				t.Errorf("expected version %q in context, got %q", APIVersionV2, gotVersion)
+			}
+			if tt.expectedStatus == http.StatusBadRequest {
+				validateErrorResponse(t, w.Body.String(), httperr.ErrCodeUnsupportedVersion)
+			}
+		})
+	}
+}
+
+func TestDeprecationMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("FullPolicy", func(t *testing.T) {
+		since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		sunset := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+		handler := DeprecationMiddleware(DeprecationPolicy{
+			Since:     since,
+			Sunset:    sunset,
+			Successor: "/v2/workspaces/ws-1/contacts",
+		})(next)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+		req = req.WithContext(setupTestContext())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+		if got := w.Header().Get("Deprecation"); got != "@1767225600" {
+			t.Errorf("unexpected Deprecation header: %q", got)
+		}
+		if got := w.Header().Get("Sunset"); got != "Wed, 01 Jul 2026 00:00:00 GMT" {
+			t.Errorf("unexpected Sunset header: %q", got)
+		}
+		if got := w.Header().Get("Link"); got != `</v2/workspaces/ws-1/contacts>; rel="successor-version"` {
+			t.Errorf("unexpected Link header: %q", got)
+		}
+	})
+
+	t.Run("EmptyPolicy", func(t *testing.T) {
+		handler := DeprecationMiddleware(DeprecationPolicy{})(next)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+		req = req.WithContext(setupTestContext())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Deprecation"); got != "true" {
+			t.Errorf("expected Deprecation=true, got %q", got)
+		}
+		if w.Header().Get("Sunset") != "" || w.Header().Get("Link") != "" {
+			t.Error("expected no Sunset/Link headers for empty policy")
+		}
+	})
+	t.Run("SuccessorVersion", func(t *testing.T) {
+		handler := APIVersionMiddleware(APIVersionV1)(DeprecationMiddleware(DeprecationPolicy{
+			SuccessorVersion: APIVersionV2,
+		})(next))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts?limit=10", nil)
+		req = req.WithContext(setupTestContext())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Link"); got != `</v2/workspaces/ws-1/contacts>; rel="successor-version"` {
+			t.Errorf("unexpected Link header: %q", got)
+		}
+	})
+
+	t.Run("SuccessorVersionWithoutAPIVersion", func(t *testing.T) {
+		handler := DeprecationMiddleware(DeprecationPolicy{SuccessorVersion: APIVersionV2})(next)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+		req = req.WithContext(setupTestContext())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Link"); got != "" {
+			t.Errorf("expected no Link header outside a versioned router, got %q", got)
+		}
+	})
+}
@@ -3,9 +3,9 @@ package middleware
 import (
 	"context"
 	"net/http"
-	"regexp"
 
 	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
 	"linkko-api/internal/http/httperr"
 	"linkko-api/internal/logger"
 
@@ -19,18 +19,9 @@ type contextKey string
 
 const workspaceIDKey contextKey = "workspace_id"
 
-// workspaceIDPattern validates workspace ID format: alphanumeric, underscore, hyphen
-var workspaceIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
-
-// validateWorkspaceIDFormat checks if workspaceID is a valid string format
+// validateWorkspaceIDFormat checks if workspaceID is a valid string format (same rule as every other resource ID)
 func validateWorkspaceIDFormat(workspaceID string) bool {
-	if workspaceID == "" {
-		return false
-	}
-	if len(workspaceID) > 64 {
-		return false
-	}
-	return workspaceIDPattern.MatchString(workspaceID)
+	return domain.IsValidID(workspaceID)
 }
 
 // WorkspaceMiddleware validates workspace access and prevents IDOR attacks
@@ -64,14 +55,15 @@ func WorkspaceMiddleware(next http.Handler) http.Handler {
 		}
 
 		// IDOR Prevention: Verify workspace_id matches authenticated context
-		// For JWT: claims.workspaceId must match path
+		// For JWT: path must be claims.workspaceId or one of claims.workspaces (agency tokens)
 		// For S2S: X-Workspace-Id header (if present) must match path
-		if authCtx.WorkspaceID != "" && authCtx.WorkspaceID != workspaceID {
+		if !authCtx.CanAccessWorkspace(workspaceID) {
 			// Use structured observability logging
 			fields := []zap.Field{
 				zap.String("auth_failure_reason", "workspace_mismatch"),
 				zap.String("auth_type", authCtx.AuthMethod),
 				zap.String("authenticated_workspace_id", authCtx.WorkspaceID),
+				zap.Strings("authenticated_workspaces", authCtx.Workspaces),
 				zap.String("path_workspace_id", workspaceID),
 				zap.String("actor_id", authCtx.ActorID),
 				zap.String("remote_addr", r.RemoteAddr),
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/http/httperr"
+	"linkko-api/internal/observability/logger"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Motivos de rejeição (atributo "reason" de workspace_rejections_total)
+const (
+	workspaceRejectionNotFound  = "not_found"
+	workspaceRejectionSuspended = "suspended"
+)
+
+// WorkspaceStatusLookup is implemented by service.WorkspaceStatusService
+type WorkspaceStatusLookup interface {
+	// WorkspaceStatus retorna found=false quando o workspace não existe
+	WorkspaceStatus(ctx context.Context, workspaceID string) (domain.WorkspaceStatus, bool, error)
+}
+
+// WorkspaceStatusMiddleware responde 404 WORKSPACE_NOT_FOUND para workspaces inexistentes e
+// 423 WORKSPACE_SUSPENDED para suspensos antes de a request chegar aos services (onde apareceria
+// como membro não encontrado). Falhas na consulta liberam a request (fail-open), como no
+// QuotaMiddleware. rejections conta as respostas por motivo (pode ser nil); um lookup nil
+// desabilita a checagem. Deve rodar depois do WorkspaceMiddleware: só quem passou na checagem
+// de IDOR descobre se o workspace existe.
+func WorkspaceStatusMiddleware(lookup WorkspaceStatusLookup, rejections metric.Int64Counter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if lookup == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			log := logger.GetLogger(ctx)
+
+			workspaceID, ok := GetWorkspaceID(ctx)
+			if !ok {
+				log.Error(ctx, "workspace_id not found in context for workspace status check")
+				httperr.InternalError(w, ctx)
+				return
+			}
+
+			status, found, err := lookup.WorkspaceStatus(ctx, workspaceID)
+			if err != nil {
+				log.Warn(ctx, "workspace status lookup failed, allowing request",
+					zap.String("workspace_id", workspaceID),
+					zap.Error(err),
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reason := ""
+			switch {
+			case !found:
+				reason = workspaceRejectionNotFound
+			case status == domain.WorkspaceStatusSuspended:
+				reason = workspaceRejectionSuspended
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if rejections != nil {
+				rejections.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+			}
+			trace.SpanFromContext(ctx).AddEvent("workspace_rejected", trace.WithAttributes(attribute.String("reason", reason)))
+			log.Warn(ctx, "workspace rejected",
+				zap.String("workspace_id", workspaceID),
+				zap.String("reason", reason),
+			)
+
+			if reason == workspaceRejectionNotFound {
+				httperr.WriteError(w, ctx, http.StatusNotFound, httperr.ErrCodeWorkspaceNotFound, "workspace not found")
+				return
+			}
+			httperr.WriteError(w, ctx, http.StatusLocked, httperr.ErrCodeWorkspaceSuspended, "workspace is suspended")
+		})
+	}
+}
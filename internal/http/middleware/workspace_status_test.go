@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkko-api/internal/domain"
+)
+
+type fakeWorkspaceStatusLookup struct {
+	status domain.WorkspaceStatus
+	found  bool
+	err    error
+}
+
+func (f *fakeWorkspaceStatusLookup) WorkspaceStatus(ctx context.Context, workspaceID string) (domain.WorkspaceStatus, bool, error) {
+	return f.status, f.found, f.err
+}
+
+func TestWorkspaceStatusMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		lookup         *fakeWorkspaceStatusLookup
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Active", lookup: &fakeWorkspaceStatusLookup{status: domain.WorkspaceStatusActive, found: true}, expectedStatus: http.StatusOK},
+		{name: "NotFound", lookup: &fakeWorkspaceStatusLookup{}, expectedStatus: http.StatusNotFound, expectedCode: "WORKSPACE_NOT_FOUND"},
+		{name: "Suspended", lookup: &fakeWorkspaceStatusLookup{status: domain.WorkspaceStatusSuspended, found: true}, expectedStatus: http.StatusLocked, expectedCode: "WORKSPACE_SUSPENDED"},
+		{name: "LookupErrorFailsOpen", lookup: &fakeWorkspaceStatusLookup{err: errors.New("connection refused")}, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := WorkspaceStatusMiddleware(tt.lookup, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+			req = req.WithContext(context.WithValue(setupTestContext(), workspaceIDKey, "ws-1"))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedCode != "" {
+				validateErrorResponse(t, w.Body.String(), tt.expectedCode)
+			}
+		})
+	}
+}
+
+func TestWorkspaceStatusMiddleware_NilLookup(t *testing.T) {
+	handler := WorkspaceStatusMiddleware(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/ws-1/contacts", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+}
@@ -282,6 +282,52 @@ func TestWorkspaceMiddleware_Mismatch_JWT(t *testing.T) {
 	}
 }
 
+func TestWorkspaceMiddleware_MultiWorkspaceToken(t *testing.T) {
+	tests := []struct {
+		name            string
+		pathWorkspaceID string
+		expectedStatus  int
+	}{
+		{name: "PrimaryWorkspace", pathWorkspaceID: "ws-123", expectedStatus: http.StatusOK},
+		{name: "AdditionalWorkspace", pathWorkspaceID: "ws-456", expectedStatus: http.StatusOK},
+		{name: "OutsideSet", pathWorkspaceID: "ws-789", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authCtx := auth.AuthContext{
+				WorkspaceID: "ws-123",
+				Workspaces:  []string{"ws-123", "ws-456"},
+				ActorID:     "user-123",
+				ActorType:   "user",
+				AuthMethod:  "jwt",
+				Issuer:      "linkko-crm-web",
+			}
+
+			r := chi.NewRouter()
+			r.Route("/v1/workspaces/{workspaceId}", func(r chi.Router) {
+				r.Use(WorkspaceMiddleware)
+				r.Get("/test", func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/workspaces/"+tt.pathWorkspaceID+"/test", nil)
+			req = req.WithContext(auth.SetAuthContextForTesting(setupTestContext(), &authCtx))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d, body: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus == http.StatusForbidden {
+				validateErrorResponse(t, rr.Body.String(), httperr.ErrCodeWorkspaceMismatch)
+			}
+		})
+	}
+}
+
 func TestWorkspaceMiddleware_Mismatch_S2S(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -0,0 +1,104 @@
+// Package i18n renderiza mensagens de erro da API no idioma negociado via Accept-Language.
+//
+// As mensagens continuam escritas em inglês no código; o catálogo mapeia o texto em
+// inglês para as traduções. Mensagem sem tradução é devolvida como está.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale é um idioma suportado pela API
+type Locale string
+
+const (
+	PtBR Locale = "pt-BR"
+	EN   Locale = "en"
+)
+
+// Supported lista os idiomas com catálogo, na ordem de preferência do produto
+var Supported = []Locale{PtBR, EN}
+
+// DefaultLocale é usado quando a request não envia Accept-Language suportado
+var DefaultLocale = EN
+
+// Parse converte uma tag ("pt", "pt-br", "en-US") para um Locale suportado
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	switch {
+	case tag == "pt" || strings.HasPrefix(tag, "pt-") || strings.HasPrefix(tag, "pt_"):
+		return PtBR, true
+	case tag == "en" || strings.HasPrefix(tag, "en-") || strings.HasPrefix(tag, "en_"):
+		return EN, true
+	}
+	return "", false
+}
+
+// Negotiate escolhe o Locale a partir do header Accept-Language (respeitando q-values).
+func Negotiate(acceptLanguage string, fallback Locale) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+		order  int
+	}
+
+	var candidates []candidate
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		if locale, ok := Parse(tag); ok {
+			candidates = append(candidates, candidate{locale: locale, q: q, order: i})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return fallback
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale
+}
+
+type localeKey struct{}
+
+// WithLocale stores the negotiated locale in context
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the negotiated locale (DefaultLocale when unset)
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Translate retorna a mensagem no idioma pedido (ou a original, se não houver tradução)
+func Translate(locale Locale, message string) string {
+	if catalog, ok := catalogs[locale]; ok {
+		if translated, ok := catalog[message]; ok {
+			return translated
+		}
+	}
+	return message
+}
+
+// T traduz a mensagem para o idioma do contexto
+func T(ctx context.Context, message string) string {
+	return Translate(FromContext(ctx), message)
+}
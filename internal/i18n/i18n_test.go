@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected Locale
+	}{
+		{name: "Empty", header: "", expected: EN},
+		{name: "PortugueseBrazil", header: "pt-BR", expected: PtBR},
+		{name: "PortugueseGeneric", header: "pt", expected: PtBR},
+		{name: "English", header: "en-US,en;q=0.9", expected: EN},
+		{name: "QValuesPreferPortuguese", header: "en;q=0.5, pt-BR;q=0.9", expected: PtBR},
+		{name: "UnsupportedFallsBack", header: "fr-FR, de", expected: EN},
+		{name: "UnsupportedFirstThenPortuguese", header: "fr-FR, pt-BR;q=0.8", expected: PtBR},
+		{name: "ZeroQualityIgnored", header: "pt-BR;q=0, en", expected: EN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.header, EN))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "contato não encontrado", Translate(PtBR, "contact not found"))
+	assert.Equal(t, "contact not found", Translate(EN, "contact not found"))
+	// Sem tradução: devolve a original
+	assert.Equal(t, "something unexpected", Translate(PtBR, "something unexpected"))
+
+	ctx := WithLocale(context.Background(), PtBR)
+	assert.Equal(t, "autenticação obrigatória", T(ctx, "authentication required"))
+	assert.Equal(t, DefaultLocale, FromContext(context.Background()))
+}
+
+func TestValidationMessage(t *testing.T) {
+	assert.Equal(t, "fullName is required", ValidationMessage(EN, "fullName", "required", ""))
+	assert.Equal(t, "fullName é obrigatório", ValidationMessage(PtBR, "fullName", "required", ""))
+	assert.Equal(t, "sampleSize deve ser menor ou igual a 100", ValidationMessage(PtBR, "sampleSize", "lte", "100"))
+	assert.Equal(t, "email is invalid", ValidationMessage(EN, "email", "e164", ""))
+}
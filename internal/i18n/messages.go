@@ -0,0 +1,340 @@
+package i18n
+
+import "strings"
+
+// catalogs mapeia a mensagem original (inglês) para a tradução por idioma.
+// EN não precisa de catálogo: as mensagens já são escritas em inglês.
+var catalogs = map[Locale]map[string]string{
+	PtBR: {
+		// Genéricas / infraestrutura
+		"Internal Server Error":                                "Erro interno do servidor",
+		"internal server error":                                "erro interno do servidor",
+		"validation failed":                                    "falha de validação",
+		"request body must be valid JSON":                      "o corpo da requisição deve ser um JSON válido",
+		"invalid JSON body":                                    "corpo JSON inválido",
+		"request exceeded its deadline":                        "a requisição excedeu o tempo limite",
+		"rate limit exceeded":                                  "limite de requisições excedido",
+		"too many concurrent requests for this workspace":      "muitas requisições simultâneas para este workspace",
+		"batch lane is at capacity, retry later":               "a fila de processamento em lote está cheia, tente novamente mais tarde",
+		"idempotency store temporarily unavailable":            "armazenamento de idempotência temporariamente indisponível",
+		"idempotency key must be 255 characters or less":       "a chave de idempotência deve ter no máximo 255 caracteres",
+		"a dependency is temporarily unavailable, retry later": "um serviço dependente está temporariamente indisponível, tente novamente mais tarde",
+
+		// Autenticação / autorização
+		"authentication required":                                        "autenticação obrigatória",
+		"authentication claims not found":                                "credenciais de autenticação não encontradas",
+		"actorID not found in claims":                                    "actorID não encontrado nas credenciais",
+		"invalid token":                                                  "token inválido",
+		"token is invalid":                                               "token inválido",
+		"invalid or expired token":                                       "token inválido ou expirado",
+		"invalid S2S token":                                              "token S2S inválido",
+		"missing authorization header":                                   "header Authorization ausente",
+		"invalid authorization scheme, expected Bearer":                  "esquema de autorização inválido, esperado Bearer",
+		"invalid X-Workspace-Id or X-Actor-Id header":                    "header X-Workspace-Id ou X-Actor-Id inválido",
+		"workspace access denied":                                        "acesso ao workspace negado",
+		"insufficient permissions for this workspace":                    "permissões insuficientes para este workspace",
+		"insufficient permissions for this action":                       "permissões insuficientes para esta ação",
+		"insufficient permissions":                                       "permissões insuficientes",
+		"token scope does not allow this operation":                      "o escopo do token não permite esta operação",
+		"invalid workspace ID format":                                    "formato de ID de workspace inválido",
+		"workspaceId is required":                                        "workspaceId é obrigatório",
+		"workspaceId is required in path":                                "workspaceId é obrigatório no path",
+		"workspaceId and taskId are required":                            "workspaceId e taskId são obrigatórios",
+		"workspaceId and pipelineId are required":                        "workspaceId e pipelineId são obrigatórios",
+		"workspaceId and companyId are required":                         "workspaceId e companyId são obrigatórios",
+		"workspaceId and stageId are required":                           "workspaceId e stageId são obrigatórios",
+		"pipelineId is required":                                         "pipelineId é obrigatório",
+		"limit must be between 1 and 100":                                "limit deve estar entre 1 e 100",
+		"pipelineType must be one of: TASK, DEAL, TICKET, CONTACT":       "pipelineType deve ser um de: TASK, DEAL, TICKET, CONTACT",
+		"invalid lifecycleStage value":                                   "valor de lifecycleStage inválido",
+		"invalid entityType value":                                       "valor de entityType inválido",
+		"invalid emailStatus value":                                      "valor de emailStatus inválido",
+		"invalid companySize value":                                      "valor de companySize inválido",
+		"status must be one of: TODO, IN_PROGRESS, DONE, CANCELLED":      "status deve ser um de: TODO, IN_PROGRESS, DONE, CANCELLED",
+		"toStatus must be one of: TODO, IN_PROGRESS, DONE, CANCELLED":    "toStatus deve ser um de: TODO, IN_PROGRESS, DONE, CANCELLED",
+		"priority must be one of: LOW, MEDIUM, HIGH, URGENT":             "priority deve ser um de: LOW, MEDIUM, HIGH, URGENT",
+		"type must be one of: task, bug, feature, improvement, research": "type deve ser um de: task, bug, feature, improvement, research",
+		"workspaceId must contain only alphanumeric characters, hyphens, and underscores (max 64 chars)": "workspaceId deve conter apenas letras, números, hífens e underscores (máx. 64 caracteres)",
+		"organization access denied":                                                               "acesso à organização negado",
+		"mutations are not allowed under impersonation":                                            "alterações não são permitidas sob impersonation",
+		"cannot impersonate an admin":                                                              "não é possível impersonar um admin",
+		"not allowed under impersonation":                                                          "não permitido sob impersonation",
+		"workspace is suspended":                                                                   "workspace suspenso",
+		"insufficient permissions for this organization":                                           "permissões insuficientes para esta organização",
+		"orgId is required in path":                                                                "orgId é obrigatório no path",
+		"invalid ID format in path":                                                                "formato de ID inválido no path",
+		"invalid configuration, nothing was reloaded":                                              "configuração inválida, nada foi recarregado",
+		"invalid or missing admin token":                                                           "token de admin inválido ou ausente",
+		"maintenance is not enabled":                                                               "a manutenção não está ligada",
+		"the API is under maintenance, write operations are temporarily disabled":                  "a API está em manutenção, operações de escrita estão temporariamente desativadas",
+		"must contain only alphanumeric characters, hyphens, and underscores (max 64 chars)":       "deve conter apenas letras, números, hífens e underscores (máx. 64 caracteres)",
+		"orgId must contain only alphanumeric characters, hyphens, and underscores (max 64 chars)": "orgId deve conter apenas letras, números, hífens e underscores (máx. 64 caracteres)",
+
+		// Relatórios
+		"groupBy must be one of: source, utmSource, utmMedium, utmCampaign":         "groupBy deve ser um de: source, utmSource, utmMedium, utmCampaign",
+		"from must be an RFC3339 timestamp":                                         "from deve ser um timestamp RFC3339",
+		"to must be an RFC3339 timestamp":                                           "to deve ser um timestamp RFC3339",
+		"type must be one of: contact, deal":                                        "type deve ser um de: contact, deal",
+		"asOf must be an RFC3339 timestamp":                                         "asOf deve ser um timestamp RFC3339",
+		"since must be an RFC3339 timestamp":                                        "since deve ser um timestamp RFC3339",
+		"at least one of recipients or webhookUrl is required":                      "informe recipients e/ou webhookUrl",
+		"cron must not run more than once per hour":                                 "o cron não pode executar mais de uma vez por hora",
+		"cron never matches a date":                                                 "o cron nunca corresponde a uma data",
+		"timezone must be a valid IANA time zone (e.g. America/Sao_Paulo)":          "timezone deve ser um fuso IANA válido (ex.: America/Sao_Paulo)",
+		"filters.pipelineId is only supported by pipeline_summary reports":          "filters.pipelineId só é suportado por relatórios pipeline_summary",
+		"filters.ownerId is not supported by attribution reports":                   "filters.ownerId não é suportado por relatórios attribution",
+		"filters.periodDays is not supported by overdue reports":                    "filters.periodDays não é suportado por relatórios overdue",
+		"filters.groupBy must be one of: source, utmSource, utmMedium, utmCampaign": "filters.groupBy deve ser um de: source, utmSource, utmMedium, utmCampaign",
+		"filters.groupBy must be one of: user, deal, task":                          "filters.groupBy deve ser um de: user, deal, task",
+		"filters.groupBy is only supported by attribution and time reports":         "filters.groupBy só é suportado por relatórios attribution e time",
+		"timer must be one of: firstResponse, resolution":                           "timer deve ser um de: firstResponse, resolution",
+
+		// Tarefas (board)
+		"swimlane must be one of: assignee, priority": "swimlane deve ser um de: assignee, priority",
+		"limitPerColumn must be between 1 and 100":    "limitPerColumn deve estar entre 1 e 100",
+
+		// Apontamento de horas
+		"running must be true or false":                       "running deve ser true ou false",
+		"limit must be between 1 and 500":                     "limit deve estar entre 1 e 500",
+		"groupBy must be one of: user, deal, task":            "groupBy deve ser um de: user, deal, task",
+		"billable must be true or false":                      "billable deve ser true ou false",
+		"taskId or dealId is required":                        "taskId ou dealId é obrigatório",
+		"provide either endedAt or durationSeconds, not both": "informe endedAt ou durationSeconds, não ambos",
+
+		// Meu trabalho
+		"tz must be a valid IANA time zone": "tz deve ser um fuso horário IANA válido",
+
+		// Calendário comercial
+		"year must be a four-digit year": "year deve ser um ano com quatro dígitos",
+
+		// Empresas duplicadas
+		"threshold must be greater than 0 and at most 1": "threshold deve ser maior que 0 e no máximo 1",
+
+		// Participantes do negócio
+		"expand must be participants": "expand deve ser participants",
+
+		// Notas
+		"request must be multipart/form-data with a file field": "a requisição deve ser multipart/form-data com o campo file",
+
+		// Timeline (digest)
+		"cursor must be an RFC3339 timestamp": "cursor deve ser um timestamp RFC3339",
+		"perType must be between 1 and 20":    "perType deve estar entre 1 e 20",
+
+		// Export CSV
+		"columns must list supported column names": "columns deve listar apenas colunas suportadas",
+		"all must be true or false":                "all deve ser true ou false",
+
+		// Links hipermídia
+		"format must be linked": "format deve ser linked",
+
+		// Respostas extended (UI otimista)
+		"return must be extended": "return deve ser extended",
+
+		// Histórico por campo
+		"limit must be between 1 and 200": "limit deve estar entre 1 e 200",
+
+		// Snapshots
+		"provide either snapshotId or objectKey": "informe snapshotId ou objectKey",
+
+		// Atualização em massa de contatos
+		"exactly one of ids or filter is required":              "informe exatamente um entre ids e filter",
+		"ids must have at most 10000 items; use filter instead": "ids deve ter no máximo 10000 itens; use filter",
+		"patch must change at least one field":                  "patch deve alterar ao menos um campo",
+
+		// Lixeira
+		"entityType must be one of contact, company, deal, task, pipeline": "entityType deve ser um de contact, company, deal, task, pipeline",
+
+		// Sequências
+		"status must be one of: ACTIVE, PAUSED, COMPLETED, EXITED": "status deve ser um de: ACTIVE, PAUSED, COMPLETED, EXITED",
+
+		// Erros de domínio (apperr)
+		"contact not found":                                                           "contato não encontrado",
+		"contact with this email already exists":                                      "já existe um contato com este email",
+		"contact was modified by another request":                                     "o contato foi modificado por outra requisição",
+		"invalid lifecycle stage transition":                                          "transição de estágio do funil inválida",
+		"open deals require a future next step":                                       "negócios abertos exigem um próximo passo futuro",
+		"owner does not belong to workspace":                                          "o responsável não pertence ao workspace",
+		"company does not belong to workspace":                                        "a empresa não pertence ao workspace",
+		"company not found":                                                           "empresa não encontrada",
+		"company with this domain already exists":                                     "já existe uma empresa com este domínio",
+		"task not found":                                                              "tarefa não encontrada",
+		"invalid status transition":                                                   "transição de status inválida",
+		"invalid position: beforeTaskID and afterTaskID must be in same status":       "posição inválida: beforeTaskID e afterTaskID devem estar no mesmo status",
+		"position difference too small, consider renormalizing positions":             "diferença de posição muito pequena, renormalize as posições",
+		"pipeline not found":                                                          "pipeline não encontrado",
+		"pipeline with this name already exists":                                      "já existe um pipeline com este nome",
+		"stage not found":                                                             "etapa não encontrada",
+		"stage with this name already exists in pipeline":                             "já existe uma etapa com este nome no pipeline",
+		"another pipeline is already set as default":                                  "outro pipeline já está definido como padrão",
+		"cannot delete default pipeline; set another as default first":                "não é possível excluir o pipeline padrão; defina outro como padrão primeiro",
+		"stage has deals; move them to another stage before deleting it":              "o estágio tem negócios; mova-os para outro estágio antes de excluí-lo",
+		"the deal's pipeline is deleted; restore the pipeline first":                  "o pipeline do negócio está excluído; restaure o pipeline primeiro",
+		"deal not found":                                                              "negócio não encontrado",
+		"stage ids must belong to the pipeline":                                       "os ids de etapa devem pertencer ao pipeline",
+		"invalid deal stage for this operation":                                       "etapa do negócio inválida para esta operação",
+		"pipeline/stage does not belong to workspace":                                 "pipeline/etapa não pertence ao workspace",
+		"targetWorkspaceId must differ from source workspace":                         "targetWorkspaceId deve ser diferente do workspace de origem",
+		"configuration has duplicated names; rename them before promoting":            "a configuração tem nomes duplicados; renomeie-os antes de promover",
+		"sequence step references an email template that no longer exists":            "o passo da sequência referencia um template de email que não existe mais",
+		"cursor is invalid; send the nextCursor of the previous page":                 "cursor inválido; envie o nextCursor da página anterior",
+		"email template not found":                                                    "template de email não encontrado",
+		"email template with this name already exists":                                "já existe um template de email com este nome",
+		"sequence not found":                                                          "sequência não encontrada",
+		"sequence enrollment not found":                                               "inscrição na sequência não encontrada",
+		"contact is already enrolled in this sequence":                                "o contato já está inscrito nesta sequência",
+		"email template referenced by sequence step not found":                        "template de email referenciado pelo passo da sequência não encontrado",
+		"contact email failed verification and cannot receive sequence emails":        "o email do contato falhou na verificação e não pode receber emails da sequência",
+		"enrollment status does not allow this operation":                             "o status da inscrição não permite esta operação",
+		"custom object not found":                                                     "objeto customizado não encontrado",
+		"custom object with this key already exists":                                  "já existe um objeto customizado com esta key",
+		"custom object record not found":                                              "registro do objeto customizado não encontrado",
+		"custom object field type cannot be changed":                                  "o tipo de um campo do objeto customizado não pode ser alterado",
+		"record referenced by a relation field not found":                             "registro referenciado por um campo de relacionamento não encontrado",
+		"filter must reference a field of the custom object with a value of its type": "o filtro deve referenciar um campo do objeto customizado com um valor do seu tipo",
+		"computed field not found":                                                    "campo calculado não encontrado",
+		"computed field with this key already exists":                                 "já existe um campo calculado com esta key",
+		"an entity can have at most 20 computed fields":                               "uma entidade pode ter no máximo 20 campos calculados",
+		"report schedule not found":                                                   "relatório agendado não encontrado",
+		"time entry not found":                                                        "apontamento de horas não encontrado",
+		"a timer is already running, stop it before starting another":                 "já existe um timer em andamento, pare-o antes de iniciar outro",
+		"time entry is not running":                                                   "o apontamento não está em andamento",
+		"endedAt must be after startedAt":                                             "endedAt deve ser posterior a startedAt",
+		"time entry cannot exceed 24 hours":                                           "o apontamento não pode exceder 24 horas",
+		"snapshot not found":                                                          "snapshot não encontrado",
+		"another snapshot job is in progress for this workspace":                      "já existe um job de snapshot em andamento neste workspace",
+		"only completed exports can be downloaded":                                    "apenas exportações concluídas podem ser baixadas",
+		"only completed exports can be restored":                                      "apenas exportações concluídas podem ser restauradas",
+		"objectKey must be under imports/{workspaceId}/":                              "objectKey deve estar sob imports/{workspaceId}/",
+		"snapshot archive not found in object storage":                                "arquivo do snapshot não encontrado no object storage",
+		"undo token is invalid or expired":                                            "token de desfazer inválido ou expirado",
+		"SLA targets can only be set on TICKET stages":                                "metas de SLA só podem ser definidas em estágios TICKET",
+		"business hours not configured":                                               "expediente não configurado",
+		"holiday not found":                                                           "feriado não encontrado",
+		"a holiday already exists for this date":                                      "já existe um feriado nesta data",
+		"enrichment job not found":                                                    "job de enriquecimento não encontrado",
+		"an enrichment job is already in progress for this company":                   "já existe um enriquecimento em andamento para esta empresa",
+		"company needs a domain to be enriched":                                       "a empresa precisa de um domínio para ser enriquecida",
+		"a company cannot be merged into itself":                                      "uma empresa não pode ser mesclada nela mesma",
+		"participant not found":                                                       "participante não encontrado",
+		"contact is already a participant of this deal":                               "o contato já participa deste negócio",
+		"contact does not belong to workspace":                                        "o contato não pertence ao workspace",
+		"note not found":                                                              "nota não encontrada",
+		"attachment not found":                                                        "anexo não encontrado",
+		"attachment file not found":                                                   "arquivo do anexo não encontrado",
+		"note body references an unknown attachment":                                  "o corpo da nota referencia um anexo desconhecido",
+		"attachment exceeds the maximum size":                                         "o anexo excede o tamanho máximo",
+		"attachment was rejected by the malware scan":                                 "o anexo foi bloqueado pela verificação de vírus",
+		"attachment scanning is temporarily unavailable, retry later":                 "verificação de vírus dos anexos temporariamente indisponível, tente novamente",
+		"bulk update job not found":                                                   "job de atualização em massa não encontrado",
+		"organization not found":                                                      "organização não encontrada",
+		"user not found in organization":                                              "usuário não encontrado na organização",
+		"organization must keep at least one admin":                                   "a organização precisa manter ao menos um admin",
+		"user not found in workspace":                                                 "usuário não encontrado no workspace",
+		"cannot impersonate yourself":                                                 "não é possível impersonar a si mesmo",
+		"invalid form token":                                                          "form token inválido",
+		"captcha verification failed":                                                 "falha na verificação do captcha",
+		"captcha verification is temporarily unavailable, retry later":                "verificação de captcha temporariamente indisponível, tente novamente",
+		"request body must be a JSON object or an urlencoded form":                    "o corpo da requisição deve ser um objeto JSON ou um formulário urlencoded",
+		"public form tokens are not enabled":                                          "form tokens não estão habilitados",
+		"form not found":                                                              "formulário não encontrado",
+		"pipeline does not belong to workspace":                                       "o pipeline não pertence ao workspace",
+		"stage does not belong to pipeline":                                           "o estágio não pertence ao pipeline",
+		"owner must be allowed to create contacts":                                    "o dono precisa ter permissão para criar contatos",
+		"link not found":                                                              "link não encontrado",
+		"deal does not belong to workspace":                                           "o negócio não pertence ao workspace",
+		"contactId is required":                                                       "contactId é obrigatório",
+		"email events can only be ingested with service credentials":                  "eventos de email só podem ser ingeridos com credenciais de serviço",
+		"document template not found":                                                 "template de documento não encontrado",
+		"document template with this name already exists":                             "já existe um template de documento com este nome",
+		"document template does not belong to workspace":                              "o template de documento não pertence ao workspace",
+		"quote not found":                                                             "orçamento não encontrado",
+		"quote can no longer be accepted":                                             "o orçamento não pode mais ser aceito",
+		"accepted quotes cannot be sent again":                                        "orçamentos aceitos não podem ser enviados novamente",
+		"quote PDF is not ready yet":                                                  "o PDF do orçamento ainda não está pronto",
+		"quote PDF not found":                                                         "PDF do orçamento não encontrado",
+		"workspace not found":                                                         "workspace não encontrado",
+		"invalid webhook signature":                                                   "assinatura do webhook inválida",
+		"contact limit of the current plan reached":                                   "limite de contatos do plano atual atingido",
+		"deal limit of the current plan reached":                                      "limite de negócios do plano atual atingido",
+		"member limit of the current plan reached":                                    "limite de membros do plano atual atingido",
+		"request body could not be read":                                              "não foi possível ler o corpo da requisição",
+		"invoice not found":                                                           "fatura não encontrada",
+		"an invoice with this external ID already exists":                             "já existe uma fatura com este ID externo",
+		"externalId is already in use":                                                "o externalId já está em uso",
+		"record was modified by another request, retry":                               "o registro foi modificado por outra requisição, tente novamente",
+		"identity mapping not found":                                                  "identity mapping não encontrado",
+		"mapped record not found in workspace":                                        "registro mapeado não encontrado no workspace",
+		"sync policy not found":                                                       "política de sincronização não encontrada",
+		"invoices can only be created for won deals":                                  "faturas só podem ser criadas para negócios ganhos",
+		"amount is required when the deal has no value":                               "amount é obrigatório quando o negócio não tem valor",
+		"provider is required when externalId is set":                                 "provider é obrigatório quando externalId é informado",
+		"invoices can only be reconciled with service credentials":                    "faturas só podem ser reconciliadas com credenciais de serviço",
+		"status must be one of: OPEN, PAID, VOID, UNCOLLECTIBLE":                      "status deve ser um de: OPEN, PAID, VOID, UNCOLLECTIBLE",
+		"groupBy must be one of: month, owner":                                        "groupBy deve ser um de: month, owner",
+		"user not found":                                                              "usuário não encontrado",
+		"group not found":                                                             "grupo não encontrado",
+		"a user with this userName already exists":                                    "já existe um usuário com este userName",
+		"a group with this displayName already exists":                                "já existe um grupo com este displayName",
+		"group members must be users provisioned in this workspace":                   "membros do grupo devem ser usuários provisionados neste workspace",
+		"invalid SCIM token":                                                          "token SCIM inválido",
+		"identity provider not found":                                                 "provedor de identidade não encontrado",
+		"an identity provider with this issuer already exists":                        "já existe um provedor de identidade com este issuer",
+		"invalid SSO token":                                                           "token SSO inválido",
+		"the identity provider did not assert a verified email":                       "o provedor de identidade não informou um email verificado",
+		"email domain not allowed for this workspace":                                 "domínio de email não permitido neste workspace",
+		"user is not a member of this workspace":                                      "usuário não é membro deste workspace",
+		"service account not found":                                                   "service account não encontrada",
+		"a service account with this name already exists":                             "já existe uma service account com este nome",
+		"invalid client credentials":                                                  "credenciais do client inválidas",
+		"requested scope is invalid or not granted to this client":                    "escopo pedido inválido ou não concedido a este client",
+		"grant_type must be client_credentials":                                       "grant_type deve ser client_credentials",
+	},
+}
+
+// validationTemplates renderiza erros do go-playground/validator por tag.
+// {field} e {param} são substituídos pelo nome do campo e o parâmetro da regra.
+var validationTemplates = map[Locale]map[string]string{
+	EN: {
+		"required":         "{field} is required",
+		"required_without": "{field} is required when {param} is not set",
+		"email":            "{field} must be a valid email",
+		"url":              "{field} must be a valid URL",
+		"min":              "{field} must have at least {param} characters",
+		"max":              "{field} must have at most {param} characters",
+		"gte":              "{field} must be greater than or equal to {param}",
+		"lte":              "{field} must be less than or equal to {param}",
+		"gt":               "{field} must be greater than {param}",
+		"lt":               "{field} must be less than {param}",
+		"oneof":            "{field} must be one of: {param}",
+		"id":               "{field} must be a valid ID (alphanumeric, hyphens and underscores, max 64 chars)",
+		"":                 "{field} is invalid",
+	},
+	PtBR: {
+		"required":         "{field} é obrigatório",
+		"required_without": "{field} é obrigatório quando {param} não é informado",
+		"email":            "{field} deve ser um email válido",
+		"url":              "{field} deve ser uma URL válida",
+		"min":              "{field} deve ter no mínimo {param} caracteres",
+		"max":              "{field} deve ter no máximo {param} caracteres",
+		"gte":              "{field} deve ser maior ou igual a {param}",
+		"lte":              "{field} deve ser menor ou igual a {param}",
+		"gt":               "{field} deve ser maior que {param}",
+		"lt":               "{field} deve ser menor que {param}",
+		"oneof":            "{field} deve ser um de: {param}",
+		"id":               "{field} deve ser um ID válido (letras, números, hífens e underscores, máx. 64 caracteres)",
+		"":                 "{field} é inválido",
+	},
+}
+
+// ValidationMessage renderiza a mensagem de uma regra de validação que falhou
+func ValidationMessage(locale Locale, field, tag, param string) string {
+	templates, ok := validationTemplates[locale]
+	if !ok {
+		templates = validationTemplates[EN]
+	}
+	tmpl, ok := templates[tag]
+	if !ok {
+		tmpl = templates[""]
+	}
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(tmpl)
+}
@@ -0,0 +1,199 @@
+// Package id gera os IDs dos recursos criados pela API: ULIDs (48 bits de timestamp em ms +
+// 80 bits aleatórios, Crockford base32 em minúsculas) com prefixo por entidade, ex.:
+// cnt_01jah3m9x4k7v2q8r5t6w0y1za. IDs do mesmo prefixo ordenam pela criação, inclusive dentro
+// do mesmo milissegundo (a parte aleatória é incrementada). IDs antigos (cuid) continuam válidos:
+// o formato só importa para domain.IsValidID.
+package id
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix identifica a entidade dona do ID
+type Prefix string
+
+// Prefixos por entidade. Entidades que antes usavam o cuid genérico ("c...") ganharam prefixo próprio.
+const (
+	Contact             Prefix = "cnt"
+	Company             Prefix = "cmp"
+	Deal                Prefix = "dl"
+	DealStageHistory    Prefix = "dsh"
+	DealParticipant     Prefix = "dpt"
+	DealSplit           Prefix = "dsp"
+	Pipeline            Prefix = "pip"
+	PipelineStage       Prefix = "stg"
+	Task                Prefix = "tsk"
+	Activity            Prefix = "act"
+	Note                Prefix = "nte"
+	NoteAttachment      Prefix = "att"
+	Call                Prefix = "cal"
+	Meeting             Prefix = "mtg"
+	ActivityParticipant Prefix = "apt"
+	EnrichmentJob       Prefix = "enr"
+	BulkUpdateJob       Prefix = "blk"
+	Holiday             Prefix = "hol"
+	Follower            Prefix = "fol"
+	Notification        Prefix = "ntf"
+	EmailTemplate       Prefix = "etp"
+	EmailEvent          Prefix = "eme"
+	Snapshot            Prefix = "snp"
+	OrgMember           Prefix = "orm"
+	SsoProvider         Prefix = "idp"
+	User                Prefix = "usr"
+	ScimGroup           Prefix = "scg"
+	ComputedField       Prefix = "cfd"
+	ReportSchedule      Prefix = "rsc"
+	DocumentTemplate    Prefix = "dtp"
+	TrackedLink         Prefix = "lnk"
+	TrackedLinkClick    Prefix = "lkc"
+	Form                Prefix = "frm"
+	ServiceAccount      Prefix = "sa"
+	Quote               Prefix = "quo"
+	Invoice             Prefix = "inv"
+	PortfolioItem       Prefix = "pit"
+	CustomObjectType    Prefix = "obj"
+	CustomObjectRecord  Prefix = "rec"
+	TimeEntry           Prefix = "tme"
+	Sequence            Prefix = "seq"
+	SequenceEnrollment  Prefix = "sqe"
+	FieldRevision       Prefix = "rev"
+	IdentityMapping     Prefix = "idm"
+	SyncPolicy          Prefix = "syp"
+	SyncConflict        Prefix = "syc"
+)
+
+// ulidLength tamanho do ULID codificado (128 bits em base32)
+const ulidLength = 26
+
+// maxTimestamp maior timestamp representável em 48 bits (ano 10889)
+const maxTimestamp = 1<<48 - 1
+
+// alphabet Crockford base32 em minúsculas: preserva a ordem lexicográfica do ULID canônico
+const alphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+var (
+	// ErrEntropy is returned when the random source fails
+	ErrEntropy = errors.New("id: failed to read entropy")
+	// ErrMonotonicOverflow is returned when 2^80 IDs were generated in the same millisecond
+	ErrMonotonicOverflow = errors.New("id: monotonic entropy overflow")
+)
+
+// Generator gera ULIDs monotônicos; é seguro para uso concorrente.
+type Generator struct {
+	entropy io.Reader
+	now     func() time.Time
+
+	mu      sync.Mutex
+	lastMs  uint64
+	lastRnd [10]byte
+}
+
+// NewGenerator entropy nil usa crypto/rand.
+func NewGenerator(entropy io.Reader) *Generator {
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+	return &Generator{entropy: entropy, now: time.Now}
+}
+
+var defaultGenerator = NewGenerator(nil)
+
+// New gera um ID com o gerador padrão (crypto/rand).
+func New(prefix Prefix) (string, error) {
+	return defaultGenerator.New(prefix)
+}
+
+// New retorna prefix + "_" + ULID. Se o relógio voltar, reaproveita o último timestamp para
+// manter a ordem.
+func (g *Generator) New(prefix Prefix) (string, error) {
+	ulid, err := g.next()
+	if err != nil {
+		return "", err
+	}
+	return string(prefix) + "_" + ulid, nil
+}
+
+func (g *Generator) next() (string, error) {
+	ms := uint64(g.now().UnixMilli())
+	if ms > maxTimestamp {
+		return "", fmt.Errorf("id: timestamp %d out of range", ms)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		if !increment(&g.lastRnd) {
+			return "", ErrMonotonicOverflow
+		}
+	} else {
+		if _, err := io.ReadFull(g.entropy, g.lastRnd[:]); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrEntropy, err)
+		}
+		g.lastMs = ms
+	}
+
+	var b [16]byte
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(b[6:], g.lastRnd[:])
+	return encode(b), nil
+}
+
+// increment soma 1 à parte aleatória; false quando ela dá a volta
+func increment(rnd *[10]byte) bool {
+	for i := len(rnd) - 1; i >= 0; i-- {
+		rnd[i]++
+		if rnd[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode 128 bits em 26 caracteres; o primeiro carrega só 3 bits (2 zeros à esquerda)
+func encode(b [16]byte) string {
+	var out [ulidLength]byte
+	for i := range out {
+		var v byte
+		for bit := 5*i - 2; bit < 5*i+3; bit++ {
+			v <<= 1
+			if bit >= 0 && b[bit/8]&(0x80>>(bit%8)) != 0 {
+				v |= 1
+			}
+		}
+		out[i] = alphabet[v]
+	}
+	return string(out[:])
+}
+
+// Time retorna o instante de criação de um ID gerado por este pacote; ok é false para outros
+// formatos (cuid, UUID).
+func Time(s string) (t time.Time, ok bool) {
+	_, ulid, found := strings.Cut(s, "_")
+	if !found || len(ulid) != ulidLength || ulid[0] > '7' {
+		return time.Time{}, false
+	}
+	var ms uint64
+	for i := 0; i < 10; i++ {
+		v := strings.IndexByte(alphabet, ulid[i])
+		if v < 0 {
+			return time.Time{}, false
+		}
+		ms = ms<<5 | uint64(v)
+	}
+	for i := 10; i < ulidLength; i++ {
+		if strings.IndexByte(alphabet, ulid[i]) < 0 {
+			return time.Time{}, false
+		}
+	}
+	return time.UnixMilli(int64(ms)), true
+}
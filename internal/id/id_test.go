@@ -0,0 +1,108 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("entropy unavailable") }
+
+func newTestGenerator(entropy []byte, now *time.Time) *Generator {
+	g := NewGenerator(bytes.NewReader(entropy))
+	g.now = func() time.Time { return *now }
+	return g
+}
+
+func TestNew_Format(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	got, err := New(Contact)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(got, "cnt_"))
+	assert.Len(t, got, len("cnt_")+ulidLength)
+	assert.True(t, domain.IsValidID(got))
+
+	created, ok := Time(got)
+	require.True(t, ok)
+	assert.False(t, created.Before(before))
+	assert.WithinDuration(t, time.Now(), created, time.Second)
+}
+
+func TestEncode_MatchesULIDSpec(t *testing.T) {
+	// Timestamp do exemplo da especificação (01ARZ3NDEK...), aleatório todo 0xff
+	var b [16]byte
+	ms := uint64(1469922850259)
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	for i := 6; i < 16; i++ {
+		b[i] = 0xff
+	}
+	assert.Equal(t, strings.ToLower("01ARZ3NDEKZZZZZZZZZZZZZZZZ"), encode(b))
+}
+
+func TestGenerator_SortableAndMonotonic(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	g := newTestGenerator(bytes.Repeat([]byte{0xaa}, 100), &now)
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		got, err := g.New(Deal)
+		require.NoError(t, err)
+		ids = append(ids, got)
+	}
+	now = now.Add(time.Millisecond)
+	got, err := g.New(Deal)
+	require.NoError(t, err)
+	ids = append(ids, got)
+
+	// Relógio voltando não quebra a ordem
+	now = now.Add(-time.Second)
+	got, err = g.New(Deal)
+	require.NoError(t, err)
+	ids = append(ids, got)
+
+	assert.True(t, sort.StringsAreSorted(ids), "ids must sort by creation: %v", ids)
+	assert.Len(t, uniq(ids), len(ids))
+}
+
+func TestGenerator_Errors(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	g := NewGenerator(failingReader{})
+	g.now = func() time.Time { return now }
+	_, err := g.New(Task)
+	assert.ErrorIs(t, err, ErrEntropy)
+
+	g = newTestGenerator(bytes.Repeat([]byte{0xff}, 10), &now)
+	_, err = g.New(Task)
+	require.NoError(t, err)
+	_, err = g.New(Task)
+	assert.ErrorIs(t, err, ErrMonotonicOverflow)
+}
+
+func TestTime_RejectsOtherFormats(t *testing.T) {
+	for _, s := range []string{"c" + strings.Repeat("a", 24), "123e4567-e89b-12d3-a456-426614174000", "cnt_" + strings.Repeat("8", ulidLength), "cnt_short"} {
+		_, ok := Time(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func uniq(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, s := range ids {
+		set[s] = struct{}{}
+	}
+	return set
+}
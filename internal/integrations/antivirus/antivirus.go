@@ -0,0 +1,285 @@
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// chunkSize tamanho dos blocos enviados ao scanner (INSTREAM do clamd, chunks do ICAP)
+const chunkSize = 32 << 10
+
+// maxReplyBytes limita a resposta lida do clamd
+const maxReplyBytes = 4 << 10
+
+// Result é o veredito de um scan.
+type Result struct {
+	Infected bool
+	// Signature nome da ameaça encontrada (vazio se limpo ou se o scanner não informou)
+	Signature string
+}
+
+// Scanner verifica o conteúdo de um arquivo enviado antes de ele ficar disponível para download.
+type Scanner interface {
+	// Name identifica o scanner nos logs e no audit log.
+	Name() string
+	// Scan lê r até o fim; erro = scanner indisponível ou resposta inesperada (sem veredito).
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Open cria o scanner configurado por nome.
+// Suportado: none (nil: sem scan), stub (detecta o arquivo de teste EICAR, para desenvolvimento e
+// testes), clamav (clamd via INSTREAM; address = host:porta) e icap (RESPMOD; address =
+// icap://host:porta/servico). timeout limita cada scan, do dial à resposta.
+func Open(name, address string, timeout time.Duration) (Scanner, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "stub":
+		return Stub{}, nil
+	}
+
+	if address == "" {
+		return nil, fmt.Errorf("attachment scanner %q requires an address", name)
+	}
+	switch name {
+	case "clamav":
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, fmt.Errorf("clamav address must be host:port: %w", err)
+		}
+		return &ClamAV{address: address, timeout: timeout}, nil
+	case "icap":
+		u, err := url.Parse(address)
+		if err != nil || u.Scheme != "icap" || u.Host == "" {
+			return nil, fmt.Errorf("icap address must be an icap://host:port/service URL")
+		}
+		host := u.Host
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "1344")
+		}
+		return &ICAP{service: u.String(), host: host, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("unsupported attachment scanner %q", name)
+	}
+}
+
+// dial abre a conexão com o scanner; a conexão inteira (envio e resposta) respeita timeout
+// e o deadline do ctx, o que vier primeiro.
+func dial(ctx context.Context, address string, timeout time.Duration) (net.Conn, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// ClamAV envia o arquivo ao clamd pelo comando INSTREAM.
+type ClamAV struct {
+	address string
+	timeout time.Duration
+}
+
+func (c *ClamAV) Name() string { return "clamav" }
+
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, err := dial(ctx, c.address, c.timeout)
+	if err != nil {
+		return Result{}, fmt.Errorf("connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	w := bufio.NewWriterSize(conn, chunkSize+4)
+	if _, err := w.WriteString("zINSTREAM\x00"); err != nil {
+		return Result{}, fmt.Errorf("write clamd command: %w", err)
+	}
+
+	// Cada bloco vai prefixado pelo tamanho (uint32 big-endian); tamanho 0 encerra o stream
+	buf := make([]byte, chunkSize)
+	var size [4]byte
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size[:], uint32(n))
+			if _, err := w.Write(size[:]); err != nil {
+				return Result{}, fmt.Errorf("write clamd chunk: %w", err)
+			}
+			if _, err := w.Write(buf[:n]); err != nil {
+				return Result{}, fmt.Errorf("write clamd chunk: %w", err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return Result{}, fmt.Errorf("read content: %w", readErr)
+		}
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	if _, err := w.Write(size[:]); err != nil {
+		return Result{}, fmt.Errorf("write clamd chunk: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return Result{}, fmt.Errorf("write clamd stream: %w", err)
+	}
+
+	reply, err := bufio.NewReader(io.LimitReader(conn, maxReplyBytes)).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("read clamd reply: %w", err)
+	}
+	return parseClamdReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamdReply interpreta "stream: OK", "stream: <assinatura> FOUND" ou "... ERROR".
+func parseClamdReply(reply string) (Result, error) {
+	status := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case status == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd replied %q", reply)
+	}
+}
+
+// ICAP envia o arquivo como corpo de uma resposta HTTP encapsulada num RESPMOD (RFC 3507),
+// o modo suportado pelos gateways antivírus ICAP em geral.
+type ICAP struct {
+	service string // icap://host:porta/servico
+	host    string // host:porta para o dial
+	timeout time.Duration
+}
+
+func (c *ICAP) Name() string { return "icap" }
+
+// icapResponseHeader cabeçalho da resposta HTTP encapsulada
+const icapResponseHeader = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+
+func (c *ICAP) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, err := dial(ctx, c.host, c.timeout)
+	if err != nil {
+		return Result{}, fmt.Errorf("connect to icap server: %w", err)
+	}
+	defer conn.Close()
+
+	w := bufio.NewWriterSize(conn, chunkSize+16)
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\nHost: %s\r\nAllow: 204\r\nEncapsulated: res-hdr=0, res-body=%d\r\n\r\n",
+		c.service, c.host, len(icapResponseHeader))
+	w.WriteString(icapResponseHeader)
+
+	// Corpo em chunked encoding, encerrado pelo chunk vazio
+	buf := make([]byte, chunkSize)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			fmt.Fprintf(w, "%x\r\n", n)
+			w.Write(buf[:n])
+			w.WriteString("\r\n")
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return Result{}, fmt.Errorf("read content: %w", readErr)
+		}
+	}
+	w.WriteString("0\r\n\r\n")
+	if err := w.Flush(); err != nil {
+		return Result{}, fmt.Errorf("write icap request: %w", err)
+	}
+
+	tp := textproto.NewReader(bufio.NewReader(conn))
+	statusLine, err := tp.ReadLine()
+	if err != nil {
+		return Result{}, fmt.Errorf("read icap status: %w", err)
+	}
+	header, err := tp.ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("read icap headers: %w", err)
+	}
+	return parseICAPResponse(statusLine, header)
+}
+
+// parseICAPResponse interpreta a resposta do RESPMOD: 204 = conteúdo não modificado (limpo);
+// 200 com X-Infection-Found, X-Virus-ID ou X-Violations-Found = infectado (o gateway devolve
+// uma página de bloqueio no lugar do arquivo); 200 sem esses cabeçalhos = limpo.
+func parseICAPResponse(statusLine string, header textproto.MIMEHeader) (Result, error) {
+	fields := strings.Fields(statusLine)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ICAP/") {
+		return Result{}, fmt.Errorf("invalid icap status line %q", statusLine)
+	}
+	code, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid icap status line %q", statusLine)
+	}
+
+	switch code {
+	case 204:
+		return Result{}, nil
+	case 200:
+		if found := header.Get("X-Infection-Found"); found != "" {
+			return Result{Infected: true, Signature: infectionThreat(found)}, nil
+		}
+		if virus := header.Get("X-Virus-ID"); virus != "" {
+			return Result{Infected: true, Signature: strings.TrimSpace(virus)}, nil
+		}
+		if violations := header.Get("X-Violations-Found"); violations != "" {
+			return Result{Infected: true}, nil
+		}
+		return Result{}, nil
+	default:
+		return Result{}, fmt.Errorf("icap server replied %q", statusLine)
+	}
+}
+
+// infectionThreat extrai o Threat= de "Type=0; Resolution=2; Threat=Eicar-Test-Signature;".
+func infectionThreat(found string) string {
+	for _, part := range strings.Split(found, ";") {
+		if threat, ok := strings.CutPrefix(strings.TrimSpace(part), "Threat="); ok {
+			return threat
+		}
+	}
+	return ""
+}
+
+// EICAR arquivo de teste padrão de antivírus (inofensivo; detectado por qualquer scanner).
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// Stub considera infectado o conteúdo que contém o arquivo de teste EICAR, sem chamadas externas.
+type Stub struct{}
+
+func (Stub) Name() string { return "stub" }
+
+func (Stub) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return Result{}, fmt.Errorf("read content: %w", err)
+	}
+	if bytes.Contains(content, []byte(EICAR)) {
+		return Result{Infected: true, Signature: "Eicar-Test-Signature"}, nil
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,173 @@
+package antivirus
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	scanner, err := Open("none", "", time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, scanner)
+
+	scanner, err = Open("stub", "", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "stub", scanner.Name())
+
+	_, err = Open("clamav", "", time.Second)
+	assert.Error(t, err)
+	_, err = Open("clamav", "clamd", time.Second)
+	assert.Error(t, err)
+	_, err = Open("icap", "http://icap:1344/avscan", time.Second)
+	assert.Error(t, err)
+	_, err = Open("sophos", "host:1", time.Second)
+	assert.Error(t, err)
+
+	scanner, err = Open("icap", "icap://icap/avscan", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "icap:1344", scanner.(*ICAP).host)
+}
+
+func TestStub(t *testing.T) {
+	result, err := Stub{}.Scan(context.Background(), strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = Stub{}.Scan(context.Background(), strings.NewReader("prefix "+EICAR))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", result.Signature)
+}
+
+// serveOnce aceita uma conexão e a entrega a handle.
+func serveOnce(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}()
+	return ln.Addr().String()
+}
+
+// fakeClamd lê o INSTREAM e responde FOUND quando o conteúdo contém EICAR.
+func fakeClamd(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	if cmd, err := r.ReadString(0); err != nil || cmd != "zINSTREAM\x00" {
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+	var content []byte
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(size[:])
+		if n == 0 {
+			break
+		}
+		chunk := make([]byte, n)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return
+		}
+		content = append(content, chunk...)
+	}
+	if strings.Contains(string(content), EICAR) {
+		conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		return
+	}
+	conn.Write([]byte("stream: OK\x00"))
+}
+
+func TestClamAVScan(t *testing.T) {
+	scanner, err := Open("clamav", serveOnce(t, fakeClamd), 5*time.Second)
+	require.NoError(t, err)
+	result, err := scanner.Scan(context.Background(), strings.NewReader(strings.Repeat("a", 3*chunkSize)))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	scanner, err = Open("clamav", serveOnce(t, fakeClamd), 5*time.Second)
+	require.NoError(t, err)
+	result, err = scanner.Scan(context.Background(), strings.NewReader(strings.Repeat("a", chunkSize-10)+EICAR))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", result.Signature)
+}
+
+func TestParseClamdReply(t *testing.T) {
+	_, err := parseClamdReply("INSTREAM size limit exceeded. ERROR")
+	assert.Error(t, err)
+}
+
+func TestICAPScan(t *testing.T) {
+	requests := make(chan string, 1)
+	addr := serveOnce(t, func(conn net.Conn) {
+		tp := textproto.NewReader(bufio.NewReader(conn))
+		request, _ := tp.ReadLine()
+		requests <- request
+		if _, err := tp.ReadMIMEHeader(); err != nil {
+			return
+		}
+		// Cabeçalho HTTP encapsulado e corpo chunked até o chunk vazio
+		if status, err := tp.ReadLine(); err != nil || status != "HTTP/1.1 200 OK" {
+			return
+		}
+		if _, err := tp.ReadMIMEHeader(); err != nil {
+			return
+		}
+		for {
+			line, err := tp.ReadLine()
+			if err != nil || line == "0" {
+				break
+			}
+		}
+		conn.Write([]byte("ICAP/1.0 200 OK\r\nX-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;\r\nEncapsulated: null-body=0\r\n\r\n"))
+	})
+
+	scanner, err := Open("icap", "icap://"+addr+"/avscan", 5*time.Second)
+	require.NoError(t, err)
+	result, err := scanner.Scan(context.Background(), strings.NewReader(EICAR))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", result.Signature)
+	assert.Equal(t, "RESPMOD icap://"+addr+"/avscan ICAP/1.0", <-requests)
+}
+
+func TestParseICAPResponse(t *testing.T) {
+	result, err := parseICAPResponse("ICAP/1.0 204 No Content", nil)
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = parseICAPResponse("ICAP/1.0 200 OK", textproto.MIMEHeader{"X-Virus-Id": {"Win.Test.EICAR_HDB-1"}})
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", result.Signature)
+
+	result, err = parseICAPResponse("ICAP/1.0 200 OK", textproto.MIMEHeader{})
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	_, err = parseICAPResponse("ICAP/1.0 500 Server Error", nil)
+	assert.Error(t, err)
+	_, err = parseICAPResponse("HTTP/1.1 200 OK", nil)
+	assert.Error(t, err)
+}
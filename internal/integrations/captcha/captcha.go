@@ -0,0 +1,122 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxResponseBytes limita o corpo lido do endpoint siteverify
+const maxResponseBytes = 64 << 10
+
+// Endpoints siteverify dos provedores suportados (mesmo contrato: POST form secret/response/remoteip → {"success": bool}).
+const (
+	TurnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	HCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	ReCaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+)
+
+// Provider verifica o desafio resolvido pelo visitante de um formulário público.
+type Provider interface {
+	// Name identifica o provedor nos logs.
+	Name() string
+	// Verify valida o token do desafio; erro = provedor indisponível (a submissão é recusada).
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// Open cria o provedor configurado por nome.
+// Suportado: none (nil: formulários com captcha obrigatório são recusados), stub (determinístico,
+// para desenvolvimento e testes), turnstile, hcaptcha e recaptcha (exigem secret).
+func Open(name, secret string, httpClient *http.Client) (Provider, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "stub":
+		return Stub{}, nil
+	}
+
+	var endpoint string
+	switch name {
+	case "turnstile":
+		endpoint = TurnstileVerifyURL
+	case "hcaptcha":
+		endpoint = HCaptchaVerifyURL
+	case "recaptcha":
+		endpoint = ReCaptchaVerifyURL
+	default:
+		return nil, fmt.Errorf("unsupported captcha provider %q", name)
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("captcha provider %q requires a secret", name)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &SiteVerify{name: name, endpoint: endpoint, secret: secret, httpClient: httpClient}, nil
+}
+
+// SiteVerify implementa o contrato siteverify comum a Turnstile, hCaptcha e reCAPTCHA.
+type SiteVerify struct {
+	name       string
+	endpoint   string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewSiteVerify creates a siteverify provider for a custom endpoint (self-hosted or tests)
+func NewSiteVerify(name, endpoint, secret string, httpClient *http.Client) *SiteVerify {
+	return &SiteVerify{name: name, endpoint: endpoint, secret: secret, httpClient: httpClient}
+}
+
+func (p *SiteVerify) Name() string { return p.name }
+
+func (p *SiteVerify) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{}
+	form.Set("secret", p.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("build %s request: %w", p.name, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%s siteverify: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%s siteverify returned %d", p.name, resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return false, fmt.Errorf("decode %s response: %w", p.name, err)
+	}
+	return result.Success, nil
+}
+
+// Stub aceita qualquer token não vazio, exceto os iniciados por "fail".
+type Stub struct{}
+
+func (Stub) Name() string { return "stub" }
+
+func (Stub) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	return token != "" && !strings.HasPrefix(token, "fail"), nil
+}
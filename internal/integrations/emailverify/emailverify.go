@@ -0,0 +1,174 @@
+package emailverify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+)
+
+// Status é o veredito da verificação (mesmos valores de domain.EmailStatus).
+type Status string
+
+const (
+	StatusValid   Status = "VALID"
+	StatusRisky   Status = "RISKY"
+	StatusInvalid Status = "INVALID"
+)
+
+// Motivos devolvidos em Result.Reason.
+const (
+	ReasonSyntax      = "syntax"
+	ReasonNoMX        = "no_mx"
+	ReasonDNSError    = "dns_error"
+	ReasonDisposable  = "disposable"
+	ReasonCatchAll    = "catch_all"
+	ReasonRejected    = "rejected"
+	ReasonDeliverable = "deliverable"
+)
+
+// Result resultado de uma verificação.
+type Result struct {
+	Status Status
+	Reason string
+}
+
+// Provider é um serviço externo de verificação de caixa postal (estilo ZeroBounce/Kickbox),
+// consultado só depois que sintaxe e MX passaram.
+type Provider interface {
+	// Name identifica o provedor nos logs.
+	Name() string
+	// Verify consulta o endereço; erro = provedor indisponível (o resultado de sintaxe/MX prevalece).
+	Verify(ctx context.Context, email string) (Result, error)
+}
+
+// Open cria o provedor configurado por nome.
+// Suportado: none (nil: só sintaxe e MX) e stub (respostas determinísticas, para desenvolvimento e testes).
+func Open(name string) (Provider, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return nil, nil
+	case "stub":
+		return Stub{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported email verification provider %q", name)
+	}
+}
+
+// MXResolver consulta os registros DNS do domínio (net.Resolver satisfaz a interface).
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Verifier executa o pipeline sintaxe → domínio descartável → MX → provedor (opcional).
+type Verifier struct {
+	resolver MXResolver
+	provider Provider
+}
+
+// NewVerifier cria o verificador. provider nil = sem consulta externa.
+func NewVerifier(resolver MXResolver, provider Provider) *Verifier {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Verifier{resolver: resolver, provider: provider}
+}
+
+// Verify verifica o endereço. Nunca falha: indisponibilidade de DNS ou do provedor resulta em RISKY.
+func (v *Verifier) Verify(ctx context.Context, email string) Result {
+	domain, ok := parseDomain(email)
+	if !ok {
+		return Result{Status: StatusInvalid, Reason: ReasonSyntax}
+	}
+	if disposableDomains[domain] {
+		return Result{Status: StatusRisky, Reason: ReasonDisposable}
+	}
+
+	if result, ok := v.checkMX(ctx, domain); !ok {
+		return result
+	}
+
+	if v.provider != nil {
+		if result, err := v.provider.Verify(ctx, email); err == nil {
+			return result
+		}
+	}
+	return Result{Status: StatusValid, Reason: ReasonDeliverable}
+}
+
+// checkMX verifica se o domínio recebe email. Sem MX, vale o registro A/AAAA (MX implícito, RFC 5321);
+// MX nulo (RFC 7505) indica que o domínio não aceita email.
+func (v *Verifier) checkMX(ctx context.Context, domain string) (Result, bool) {
+	records, err := v.resolver.LookupMX(ctx, domain)
+	if err == nil && len(records) > 0 {
+		if len(records) == 1 && (records[0].Host == "." || records[0].Host == "") {
+			return Result{Status: StatusInvalid, Reason: ReasonNoMX}, false
+		}
+		return Result{}, true
+	}
+	if err != nil && !isNotFound(err) {
+		return Result{Status: StatusRisky, Reason: ReasonDNSError}, false
+	}
+
+	if _, err := v.resolver.LookupHost(ctx, domain); err != nil {
+		if isNotFound(err) {
+			return Result{Status: StatusInvalid, Reason: ReasonNoMX}, false
+		}
+		return Result{Status: StatusRisky, Reason: ReasonDNSError}, false
+	}
+	return Result{}, true
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// parseDomain valida a sintaxe (endereço simples, sem display name) e devolve o domínio em minúsculas.
+func parseDomain(email string) (string, bool) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || addr.Name != "" {
+		return "", false
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	domain := strings.ToLower(addr.Address[at+1:])
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, "[") {
+		return "", false
+	}
+	return domain, true
+}
+
+// disposableDomains provedores de email temporário mais comuns.
+var disposableDomains = map[string]bool{
+	"10minutemail.com":  true,
+	"guerrillamail.com": true,
+	"mailinator.com":    true,
+	"sharklasers.com":   true,
+	"temp-mail.org":     true,
+	"tempmail.com":      true,
+	"throwawaymail.com": true,
+	"trashmail.com":     true,
+	"yopmail.com":       true,
+}
+
+// Stub responde sem chamadas externas, a partir do endereço:
+// caixa iniciada por "bounce" → rejeitada; domínio iniciado por "catchall" → catch-all; demais → entregável.
+type Stub struct{}
+
+func (Stub) Name() string { return "stub" }
+
+func (Stub) Verify(ctx context.Context, email string) (Result, error) {
+	at := strings.LastIndex(email, "@")
+	local, domain := strings.ToLower(email[:at]), strings.ToLower(email[at+1:])
+	switch {
+	case strings.HasPrefix(local, "bounce"):
+		return Result{Status: StatusInvalid, Reason: ReasonRejected}, nil
+	case strings.HasPrefix(domain, "catchall"):
+		return Result{Status: StatusRisky, Reason: ReasonCatchAll}, nil
+	}
+	return Result{Status: StatusValid, Reason: ReasonDeliverable}, nil
+}
@@ -0,0 +1,137 @@
+package emailverify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver responde MX e A/AAAA por domínio; domínios ausentes são NXDOMAIN.
+type fakeResolver struct {
+	mx      map[string][]*net.MX
+	hosts   map[string][]string
+	failing map[string]bool // timeout de DNS
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if r.failing[name] {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// fakeProvider devolve um resultado fixo ou erro (provedor fora do ar).
+type fakeProvider struct {
+	result Result
+	err    error
+	calls  int
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Verify(ctx context.Context, email string) (Result, error) {
+	p.calls++
+	return p.result, p.err
+}
+
+func newResolver() *fakeResolver {
+	return &fakeResolver{
+		mx: map[string][]*net.MX{
+			"acme.com":     {{Host: "mx1.acme.com.", Pref: 10}},
+			"nullmx.com":   {{Host: ".", Pref: 0}},
+			"catchall.com": {{Host: "mx.catchall.com.", Pref: 10}},
+		},
+		hosts:   map[string][]string{"implicit.com": {"203.0.113.10"}},
+		failing: map[string]bool{"slow.com": true},
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	tests := []struct {
+		email  string
+		status Status
+		reason string
+	}{
+		{"ana@acme.com", StatusValid, ReasonDeliverable},
+		{"Ana@ACME.com", StatusValid, ReasonDeliverable},
+		{"ana@implicit.com", StatusValid, ReasonDeliverable},
+		{"ana@nullmx.com", StatusInvalid, ReasonNoMX},
+		{"ana@nowhere.com", StatusInvalid, ReasonNoMX},
+		{"ana@slow.com", StatusRisky, ReasonDNSError},
+		{"ana@mailinator.com", StatusRisky, ReasonDisposable},
+		{"ana@YopMail.com", StatusRisky, ReasonDisposable},
+		{"ana", StatusInvalid, ReasonSyntax},
+		{"ana@localhost", StatusInvalid, ReasonSyntax},
+		{"Ana <ana@acme.com>", StatusInvalid, ReasonSyntax},
+		{"ana@[192.0.2.1]", StatusInvalid, ReasonSyntax},
+		{"ana @acme.com", StatusInvalid, ReasonSyntax},
+	}
+
+	v := NewVerifier(newResolver(), nil)
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got := v.Verify(context.Background(), tt.email)
+			assert.Equal(t, Result{Status: tt.status, Reason: tt.reason}, got)
+		})
+	}
+}
+
+func TestVerifier_Provider(t *testing.T) {
+	ctx := context.Background()
+
+	rejected := &fakeProvider{result: Result{Status: StatusInvalid, Reason: ReasonRejected}}
+	v := NewVerifier(newResolver(), rejected)
+	assert.Equal(t, Result{Status: StatusInvalid, Reason: ReasonRejected}, v.Verify(ctx, "ana@acme.com"))
+	assert.Equal(t, 1, rejected.calls)
+
+	// O provedor só é consultado quando sintaxe e MX passaram
+	assert.Equal(t, StatusInvalid, v.Verify(ctx, "ana@nowhere.com").Status)
+	assert.Equal(t, StatusRisky, v.Verify(ctx, "ana@mailinator.com").Status)
+	assert.Equal(t, 1, rejected.calls)
+
+	// Provedor indisponível: vale o resultado de sintaxe/MX
+	down := &fakeProvider{err: errors.New("503 service unavailable")}
+	v = NewVerifier(newResolver(), down)
+	assert.Equal(t, Result{Status: StatusValid, Reason: ReasonDeliverable}, v.Verify(ctx, "ana@acme.com"))
+	assert.Equal(t, 1, down.calls)
+}
+
+func TestStub(t *testing.T) {
+	v := NewVerifier(newResolver(), Stub{})
+	ctx := context.Background()
+
+	assert.Equal(t, Result{Status: StatusInvalid, Reason: ReasonRejected}, v.Verify(ctx, "Bounce.test@acme.com"))
+	assert.Equal(t, Result{Status: StatusRisky, Reason: ReasonCatchAll}, v.Verify(ctx, "ana@catchall.com"))
+	assert.Equal(t, Result{Status: StatusValid, Reason: ReasonDeliverable}, v.Verify(ctx, "ana@acme.com"))
+}
+
+func TestOpen(t *testing.T) {
+	provider, err := Open("none")
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = Open("")
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = Open(" Stub ")
+	require.NoError(t, err)
+	assert.Equal(t, "stub", provider.Name())
+
+	_, err = Open("zerobounce")
+	assert.Error(t, err)
+}
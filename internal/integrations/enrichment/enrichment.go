@@ -0,0 +1,93 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// ErrNotFound é retornado pelo provedor quando não há dados para a empresa consultada.
+var ErrNotFound = errors.New("company not found by enrichment provider")
+
+// Query identifica a empresa a enriquecer. Domain é a chave principal (estilo Clearbit);
+// Name é apenas uma dica para provedores que fazem busca por nome.
+type Query struct {
+	Domain string
+	Name   string
+}
+
+// CompanyData são os atributos firmográficos devolvidos pelo provedor.
+// Campos nil não foram encontrados e não são aplicados.
+type CompanyData struct {
+	Industry      *string
+	Size          *string // STARTUP, SMB, MID_MARKET ou ENTERPRISE
+	AnnualRevenue *float64
+	LogoURL       *string
+}
+
+// Provider é um provedor de enriquecimento de empresas.
+type Provider interface {
+	// Name identifica o provedor na proveniência gravada em customFields.
+	Name() string
+	// EnrichCompany consulta os dados da empresa; ErrNotFound quando o provedor não a conhece.
+	EnrichCompany(ctx context.Context, q Query) (*CompanyData, error)
+}
+
+// Open cria o provedor configurado por nome.
+// Suportado: stub (dados determinísticos derivados do domínio, para desenvolvimento e testes).
+func Open(name string) (Provider, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "stub":
+		return Stub{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported enrichment provider %q", name)
+	}
+}
+
+// Stub gera dados plausíveis e estáveis a partir do domínio, sem chamadas externas.
+type Stub struct{}
+
+var (
+	stubIndustries = []string{"Software", "Financial Services", "Retail", "Healthcare", "Manufacturing", "Education", "Logistics", "Media"}
+	stubSizes      = []string{"STARTUP", "SMB", "MID_MARKET", "ENTERPRISE"}
+	stubRevenues   = []float64{500_000, 5_000_000, 50_000_000, 500_000_000}
+)
+
+func (Stub) Name() string { return "stub" }
+
+func (Stub) EnrichCompany(ctx context.Context, q Query) (*CompanyData, error) {
+	domain := normalizeDomain(q.Domain)
+	if !strings.Contains(domain, ".") {
+		return nil, ErrNotFound
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(domain))
+	sum := h.Sum32()
+
+	industry := stubIndustries[sum%uint32(len(stubIndustries))]
+	tier := (sum / 7) % uint32(len(stubSizes))
+	size := stubSizes[tier]
+	revenue := stubRevenues[tier]
+	logo := "https://logo.clearbit.com/" + domain
+
+	return &CompanyData{
+		Industry:      &industry,
+		Size:          &size,
+		AnnualRevenue: &revenue,
+		LogoURL:       &logo,
+	}, nil
+}
+
+// normalizeDomain reduz website/URL ao host em minúsculas ("https://www.acme.com/x" → "acme.com").
+func normalizeDomain(raw string) string {
+	d := strings.ToLower(strings.TrimSpace(raw))
+	d = strings.TrimPrefix(d, "https://")
+	d = strings.TrimPrefix(d, "http://")
+	if i := strings.IndexAny(d, "/?#"); i >= 0 {
+		d = d[:i]
+	}
+	return strings.TrimPrefix(d, "www.")
+}
@@ -0,0 +1,325 @@
+// Package errortracker encaminha falhas graves (panics recuperados) a um rastreador de erros
+// compatível com o Sentry, com os dados pessoais removidos antes do envio.
+package errortracker
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Frame linha da pilha, da chamada mais externa para a mais interna (ordem do Sentry).
+type Frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	File     string `json:"filename"`
+	Line     int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// Request dados da request HTTP em que o erro ocorreu.
+type Request struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"` // caminho, sem host nem query
+	Route   string            `json:"-"`   // padrão chi, enviado como tag
+	Query   string            `json:"query_string,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// User ator autenticado da request (sem email, nome ou IP).
+type User struct {
+	ID             string `json:"id,omitempty"`
+	Type           string `json:"actor_type,omitempty"`
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+}
+
+// Event falha a ser reportada.
+type Event struct {
+	ID          string
+	Timestamp   time.Time
+	Level       string // "fatal" para panics
+	Type        string // ex.: "panic"
+	Message     string
+	Stack       []Frame
+	Request     *Request
+	User        *User
+	WorkspaceID string
+	AuthMethod  string
+	RequestID   string
+	TraceID     string
+	SpanID      string
+	Tags        map[string]string
+}
+
+// Tracker recebe os eventos. Implementações recebem eventos já passados por Scrub.
+type Tracker interface {
+	Capture(ctx context.Context, ev *Event) error
+}
+
+// Config parâmetros do rastreador (ERROR_TRACKER_DSN).
+type Config struct {
+	DSN         string
+	Environment string
+	ServerName  string
+}
+
+// Open cria o rastreador configurado. DSN vazio (ou "none") retorna nil: panics ficam só no
+// log. "stub" aceita os eventos sem enviar (desenvolvimento). Uma URL é tratada como DSN do
+// Sentry (https://<chave>@<host>/<projeto>), também aceito por serviços compatíveis.
+func Open(cfg Config, client *http.Client) (Tracker, error) {
+	dsn := strings.TrimSpace(cfg.DSN)
+	switch strings.ToLower(dsn) {
+	case "", "none":
+		return nil, nil
+	case "stub":
+		return Stub{}, nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("invalid error tracker DSN (expected https://<key>@<host>/<project>)")
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	projectID := path[i+1:]
+	if projectID == "" {
+		return nil, fmt.Errorf("invalid error tracker DSN: missing project id")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &Sentry{
+		endpoint:    fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:i], projectID),
+		publicKey:   u.User.Username(),
+		environment: cfg.Environment,
+		serverName:  cfg.ServerName,
+		client:      client,
+	}, nil
+}
+
+// Stub descarta os eventos.
+type Stub struct{}
+
+func (Stub) Capture(ctx context.Context, ev *Event) error { return nil }
+
+// Sentry envia eventos pelo endpoint de envelopes do Sentry.
+type Sentry struct {
+	endpoint    string
+	publicKey   string
+	environment string
+	serverName  string
+	client      *http.Client
+}
+
+func (s *Sentry) Capture(ctx context.Context, ev *Event) error {
+	if ev.ID == "" {
+		ev.ID = NewEventID()
+	}
+	payload, err := json.Marshal(s.sentryEvent(ev))
+	if err != nil {
+		return fmt.Errorf("marshal error event: %w", err)
+	}
+
+	var body bytes.Buffer
+	header, _ := json.Marshal(map[string]string{"event_id": ev.ID, "sent_at": time.Now().UTC().Format(time.RFC3339)})
+	body.Write(header)
+	body.WriteString("\n{\"type\":\"event\"}\n")
+	body.Write(payload)
+	body.WriteString("\n")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=linkko-api/1.0, sentry_key=%s", s.publicKey))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send error event: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("error tracker responded %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *Sentry) sentryEvent(ev *Event) map[string]any {
+	tags := map[string]string{}
+	for k, v := range ev.Tags {
+		tags[k] = v
+	}
+	if ev.WorkspaceID != "" {
+		tags["workspace_id"] = ev.WorkspaceID
+	}
+	if ev.AuthMethod != "" {
+		tags["auth_method"] = ev.AuthMethod
+	}
+	if ev.RequestID != "" {
+		tags["request_id"] = ev.RequestID
+	}
+	if ev.Request != nil && ev.Request.Route != "" {
+		tags["route"] = ev.Request.Route
+	}
+
+	out := map[string]any{
+		"event_id":  ev.ID,
+		"timestamp": ev.Timestamp.UTC().Format(time.RFC3339Nano),
+		"level":     ev.Level,
+		"platform":  "go",
+		"logger":    "linkko-api",
+		"message":   map[string]string{"formatted": ev.Message},
+		"exception": map[string]any{"values": []map[string]any{{
+			"type":       ev.Type,
+			"value":      ev.Message,
+			"stacktrace": map[string]any{"frames": ev.Stack},
+			"mechanism":  map[string]any{"type": "http.recovery", "handled": true},
+		}}},
+		"tags": tags,
+	}
+	if s.environment != "" {
+		out["environment"] = s.environment
+	}
+	if s.serverName != "" {
+		out["server_name"] = s.serverName
+	}
+	if ev.Request != nil {
+		out["request"] = ev.Request
+	}
+	if ev.User != nil {
+		out["user"] = ev.User
+	}
+	if ev.TraceID != "" {
+		out["contexts"] = map[string]any{"trace": map[string]string{"trace_id": ev.TraceID, "span_id": ev.SpanID}}
+	}
+	return out
+}
+
+// CaptureStack captura a pilha de quem chamou, pulando skip frames além do próprio CaptureStack,
+// na ordem do Sentry (mais interna por último). Frames do runtime são descartados.
+func CaptureStack(skip int) []Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []Frame
+	for {
+		f, more := frames.Next()
+		if f.Function != "" && !strings.HasPrefix(f.Function, "runtime.") {
+			module := f.Function
+			if i := strings.LastIndex(module, "/"); i >= 0 {
+				if j := strings.Index(module[i:], "."); j >= 0 {
+					module = module[:i+j]
+				}
+			} else if j := strings.Index(module, "."); j >= 0 {
+				module = module[:j]
+			}
+			stack = append(stack, Frame{
+				Function: f.Function,
+				Module:   module,
+				File:     f.File,
+				Line:     f.Line,
+				InApp:    strings.HasPrefix(f.Function, "linkko-api/"),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack
+}
+
+// NewEventID gera o identificador do evento (32 caracteres hex, formato do Sentry).
+func NewEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// allowedHeaders únicos headers encaminhados; os demais (Authorization, Cookie, X-Api-Key,
+// X-Forwarded-For...) nunca saem do processo.
+var allowedHeaders = map[string]bool{
+	"Accept":          true,
+	"Accept-Language": true,
+	"Content-Type":    true,
+	"Content-Length":  true,
+	"User-Agent":      true,
+	"X-Request-Id":    true,
+	"Idempotency-Key": true,
+	"Traceparent":     true,
+}
+
+// sensitiveQueryKeys parâmetros de query cujo valor é removido.
+var sensitiveQueryKeys = []string{"token", "secret", "password", "key", "auth", "email", "phone", "q", "search", "cpf", "cnpj"}
+
+var (
+	emailPattern    = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	bearerPattern   = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`)
+	documentPattern = regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b|\b\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2}\b`)
+	phonePattern    = regexp.MustCompile(`\+?\d[\d\s().\-]{8,}\d`)
+)
+
+// Scrub remove dados pessoais e credenciais do evento: headers fora da allowlist, valores de
+// parâmetros sensíveis da query e emails, tokens bearer, CPF/CNPJ e telefones da mensagem.
+func Scrub(ev *Event) {
+	ev.Message = ScrubText(ev.Message)
+	if ev.Request == nil {
+		return
+	}
+
+	headers := make(map[string]string, len(ev.Request.Headers))
+	for k, v := range ev.Request.Headers {
+		canonical := http.CanonicalHeaderKey(k)
+		if allowedHeaders[canonical] {
+			headers[canonical] = v
+		}
+	}
+	ev.Request.Headers = headers
+
+	if ev.Request.Query != "" {
+		values, err := url.ParseQuery(ev.Request.Query)
+		if err != nil {
+			ev.Request.Query = "[REDACTED]"
+		} else {
+			for key := range values {
+				if isSensitiveQueryKey(key) {
+					values[key] = []string{"[REDACTED]"}
+				}
+			}
+			ev.Request.Query = values.Encode()
+		}
+	}
+}
+
+// ScrubText mascara emails, tokens bearer, CPF/CNPJ e telefones em texto livre.
+func ScrubText(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "Bearer [REDACTED]")
+	s = emailPattern.ReplaceAllString(s, "[email]")
+	s = documentPattern.ReplaceAllString(s, "[document]")
+	return phonePattern.ReplaceAllString(s, "[phone]")
+}
+
+func isSensitiveQueryKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveQueryKeys {
+		if lower == sensitive || (len(sensitive) > 2 && strings.Contains(lower, sensitive)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package errortracker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	tracker, err := Open(Config{DSN: "none"}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, tracker)
+
+	tracker, err = Open(Config{DSN: "stub"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Stub{}, tracker)
+
+	tracker, err = Open(Config{DSN: "https://abc123@o1.ingest.example.com/sub/42"}, nil)
+	require.NoError(t, err)
+	sentry := tracker.(*Sentry)
+	assert.Equal(t, "https://o1.ingest.example.com/sub/api/42/envelope/", sentry.endpoint)
+	assert.Equal(t, "abc123", sentry.publicKey)
+
+	for _, dsn := range []string{"ftp://k@host/1", "https://host/1", "https://k@host/"} {
+		_, err := Open(Config{DSN: dsn}, nil)
+		assert.Error(t, err, dsn)
+	}
+}
+
+func TestScrub(t *testing.T) {
+	ev := &Event{
+		Message: "user maria@example.com cpf 123.456.789-09 tel +55 11 91234-5678 Bearer abc.def",
+		Request: &Request{
+			Query: "email=maria%40example.com&page=2&accessToken=xyz",
+			Headers: map[string]string{
+				"Authorization": "Bearer abc",
+				"Cookie":        "session=1",
+				"user-agent":    "curl/8",
+				"X-Request-Id":  "req_1",
+			},
+		},
+	}
+
+	Scrub(ev)
+
+	assert.Equal(t, "user [email] cpf [document] tel [phone] Bearer [REDACTED]", ev.Message)
+	assert.Equal(t, map[string]string{"User-Agent": "curl/8", "X-Request-Id": "req_1"}, ev.Request.Headers)
+	assert.Equal(t, "accessToken=%5BREDACTED%5D&email=%5BREDACTED%5D&page=2", ev.Request.Query)
+}
+
+func TestSentryCapture(t *testing.T) {
+	var auth string
+	var lines []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("X-Sentry-Auth")
+		sc := bufio.NewScanner(r.Body)
+		for sc.Scan() {
+			lines = append(lines, sc.Text())
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tracker, err := Open(Config{
+		DSN:         strings.Replace(srv.URL, "://", "://pub@", 1) + "/7",
+		Environment: "staging",
+	}, srv.Client())
+	require.NoError(t, err)
+
+	ev := &Event{
+		ID:          NewEventID(),
+		Level:       "fatal",
+		Type:        "panic",
+		Message:     "boom",
+		Stack:       CaptureStack(0),
+		Request:     &Request{Method: "GET", URL: "/v1/workspaces/ws_1/deals", Route: "/v1/workspaces/{workspaceId}/deals"},
+		User:        &User{ID: "usr_1", Type: "user"},
+		WorkspaceID: "ws_1",
+		TraceID:     "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:      "00f067aa0ba902b7",
+	}
+	require.NoError(t, tracker.Capture(context.Background(), ev))
+
+	assert.Contains(t, auth, "sentry_key=pub")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], ev.ID)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &payload))
+	assert.Equal(t, "staging", payload["environment"])
+	assert.Equal(t, "fatal", payload["level"])
+	tags := payload["tags"].(map[string]any)
+	assert.Equal(t, "ws_1", tags["workspace_id"])
+	assert.Equal(t, "/v1/workspaces/{workspaceId}/deals", tags["route"])
+	assert.Equal(t, "usr_1", payload["user"].(map[string]any)["id"])
+	trace := payload["contexts"].(map[string]any)["trace"].(map[string]any)
+	assert.Equal(t, ev.TraceID, trace["trace_id"])
+
+	frames := payload["exception"].(map[string]any)["values"].([]any)[0].(map[string]any)["stacktrace"].(map[string]any)["frames"].([]any)
+	last := frames[len(frames)-1].(map[string]any)
+	assert.Contains(t, last["function"], "TestSentryCapture")
+}
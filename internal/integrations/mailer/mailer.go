@@ -0,0 +1,165 @@
+// Package mailer envia emails transacionais (ex.: relatórios agendados) com anexos.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured é retornado quando não há provedor de email (MAIL_PROVIDER=none).
+var ErrNotConfigured = errors.New("email delivery is not configured")
+
+// Attachment arquivo anexado à mensagem.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Message email em texto simples com anexos opcionais.
+type Message struct {
+	To          []string
+	Subject     string
+	Text        string
+	Attachments []Attachment
+}
+
+// Mailer envia mensagens.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config parâmetros do provedor (SMTP_* e MAIL_FROM).
+type Config struct {
+	Provider string
+	From     string
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+// Open cria o mailer configurado. "none" (ou vazio) retorna nil: entregas por email falham com
+// ErrNotConfigured. "stub" aceita as mensagens sem enviar (desenvolvimento).
+func Open(cfg Config) (Mailer, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", "none":
+		return nil, nil
+	case "stub":
+		return Stub{}, nil
+	case "smtp":
+		if cfg.Host == "" || cfg.From == "" {
+			return nil, fmt.Errorf("smtp mailer requires SMTP_HOST and MAIL_FROM")
+		}
+		return &SMTP{cfg: cfg}, nil
+	default:
+		return nil, fmt.Errorf("unsupported mail provider %q", cfg.Provider)
+	}
+}
+
+// Stub descarta as mensagens.
+type Stub struct{}
+
+func (Stub) Send(ctx context.Context, msg Message) error { return nil }
+
+// SMTP envia via servidor SMTP com STARTTLS (quando oferecido) e AUTH PLAIN opcional.
+type SMTP struct {
+	cfg Config
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	body, err := buildMIME(s.cfg.From, msg)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	// net/smtp não aceita context: o envio roda em goroutine e o ctx só limita a espera
+	done := make(chan error, 1)
+	go func() { done <- smtp.SendMail(addr, auth, s.cfg.From, msg.To, body) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp send: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// buildMIME monta a mensagem multipart/mixed (texto + anexos em base64).
+func buildMIME(from string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	headers := []string{
+		"From: " + from,
+		"To: " + strings.Join(msg.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().UTC().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/mixed; boundary="` + w.Boundary() + `"`,
+	}
+	var out bytes.Buffer
+	out.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	textPart, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := textPart.Write(wrapBase64(msg.Text)); err != nil {
+		return nil, err
+	}
+
+	for _, a := range msg.Attachments {
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(wrapBase64(string(a.Data))); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	out.Write(buf.Bytes())
+	return out.Bytes(), nil
+}
+
+// wrapBase64 codifica em base64 com linhas de 76 caracteres (RFC 2045).
+func wrapBase64(s string) []byte {
+	encoded := base64.StdEncoding.EncodeToString([]byte(s))
+	var buf bytes.Buffer
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
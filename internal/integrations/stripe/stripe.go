@@ -0,0 +1,180 @@
+// Package stripe recebe os webhooks de cobrança do Stripe: verifica a assinatura
+// (header Stripe-Signature) e decodifica os objetos usados pelo billing dos workspaces.
+// Só o lado de entrada é implementado; a API não chama o Stripe.
+package stripe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carrega o timestamp e as assinaturas HMAC do payload
+const SignatureHeader = "Stripe-Signature"
+
+// DefaultTolerance é a diferença máxima aceita entre o timestamp assinado e o relógio local
+// (mesmo default das bibliotecas oficiais; limita replays).
+const DefaultTolerance = 5 * time.Minute
+
+// Tipos de evento tratados pelo billing; os demais são aceitos e ignorados.
+const (
+	EventCheckoutSessionCompleted = "checkout.session.completed"
+	EventSubscriptionCreated      = "customer.subscription.created"
+	EventSubscriptionUpdated      = "customer.subscription.updated"
+	EventSubscriptionDeleted      = "customer.subscription.deleted"
+)
+
+// MetadataWorkspaceID é a chave de metadata (assinatura ou checkout) com o id do workspace
+const MetadataWorkspaceID = "workspaceId"
+
+var (
+	ErrMissingSignature = errors.New("missing stripe signature")
+	ErrInvalidSignature = errors.New("invalid stripe signature")
+	ErrSignatureExpired = errors.New("stripe signature timestamp outside tolerance")
+)
+
+// Event envelope do webhook. Data.Object é decodificado conforme Type.
+type Event struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// CreatedAt retorna o momento em que o Stripe gerou o evento
+func (e *Event) CreatedAt() time.Time {
+	return time.Unix(e.Created, 0).UTC()
+}
+
+// Subscription campos usados do objeto subscription.
+type Subscription struct {
+	ID                string            `json:"id"`
+	Customer          string            `json:"customer"`
+	Status            string            `json:"status"`
+	CancelAtPeriodEnd bool              `json:"cancel_at_period_end"`
+	CurrentPeriodEnd  int64             `json:"current_period_end"`
+	Metadata          map[string]string `json:"metadata"`
+	Items             struct {
+		Data []struct {
+			Price struct {
+				ID string `json:"id"`
+			} `json:"price"`
+			CurrentPeriodEnd int64 `json:"current_period_end"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+// PriceID retorna o price do primeiro item (assinaturas do Linkko têm um item só)
+func (s *Subscription) PriceID() string {
+	if len(s.Items.Data) == 0 {
+		return ""
+	}
+	return s.Items.Data[0].Price.ID
+}
+
+// PeriodEnd retorna o fim do período corrente. Versões recentes da API movem o campo
+// para o item da assinatura; o da assinatura tem precedência quando presente.
+func (s *Subscription) PeriodEnd() *time.Time {
+	end := s.CurrentPeriodEnd
+	if end == 0 && len(s.Items.Data) > 0 {
+		end = s.Items.Data[0].CurrentPeriodEnd
+	}
+	if end == 0 {
+		return nil
+	}
+	t := time.Unix(end, 0).UTC()
+	return &t
+}
+
+// CheckoutSession campos usados do objeto checkout.session.
+// O front cria o checkout com client_reference_id = workspaceId.
+type CheckoutSession struct {
+	ID                string            `json:"id"`
+	Mode              string            `json:"mode"`
+	Customer          string            `json:"customer"`
+	Subscription      string            `json:"subscription"`
+	ClientReferenceID string            `json:"client_reference_id"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+// WorkspaceID retorna o workspace do checkout (client_reference_id ou metadata)
+func (c *CheckoutSession) WorkspaceID() string {
+	if c.ClientReferenceID != "" {
+		return c.ClientReferenceID
+	}
+	return c.Metadata[MetadataWorkspaceID]
+}
+
+// ConstructEvent verifica a assinatura do payload e decodifica o evento.
+// O header tem o formato "t=<unix>,v1=<hex>[,v1=<hex>...]"; qualquer v1 válido basta
+// (o Stripe assina com os dois segredos durante a rotação).
+func ConstructEvent(payload []byte, header, secret string, tolerance time.Duration, now time.Time) (*Event, error) {
+	if header == "" {
+		return nil, ErrMissingSignature
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	valid := false
+	for _, sig := range signatures {
+		decoded, err := hex.DecodeString(sig)
+		if err == nil && hmac.Equal(decoded, expected) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(ts, 0))
+		if age > tolerance || age < -tolerance {
+			return nil, ErrSignatureExpired
+		}
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode stripe event: %w", err)
+	}
+	if event.ID == "" || event.Type == "" {
+		return nil, fmt.Errorf("decode stripe event: missing id or type")
+	}
+	return &event, nil
+}
@@ -0,0 +1,63 @@
+package loadtest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Regression passo cuja métrica piorou além da tolerância em relação ao baseline.
+type Regression struct {
+	Step     string
+	Metric   string
+	Baseline string
+	Current  string
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%s %s: %s -> %s", r.Step, r.Metric, r.Baseline, r.Current)
+}
+
+// GateOptions limites do gate de regressão.
+type GateOptions struct {
+	// Tolerance aumento relativo aceito no p95 (0.2 = 20%)
+	Tolerance float64
+	// MinDelta diferença absoluta abaixo da qual o p95 não é comparado (ruído em passos rápidos)
+	MinDelta time.Duration
+	// MaxErrorRateIncrease aumento absoluto aceito na taxa de erros (0.01 = 1 ponto percentual)
+	MaxErrorRateIncrease float64
+}
+
+// Compare compara p95 e taxa de erros de cada passo presente nos dois resultados.
+// Passos novos ou removidos são ignorados.
+func Compare(baseline, current *Result, opts GateOptions) []Regression {
+	var regressions []Regression
+
+	names := make([]string, 0, len(current.Steps))
+	for name := range current.Steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cur := current.Steps[name]
+		base, ok := baseline.Steps[name]
+		if !ok || base.Count == 0 || cur.Count == 0 {
+			continue
+		}
+
+		limit := time.Duration(float64(base.P95) * (1 + opts.Tolerance))
+		if cur.P95 > limit && cur.P95-base.P95 > opts.MinDelta {
+			regressions = append(regressions, Regression{Step: name, Metric: "p95", Baseline: ms(base.P95), Current: ms(cur.P95)})
+		}
+		if cur.ErrorRate() > base.ErrorRate()+opts.MaxErrorRateIncrease {
+			regressions = append(regressions, Regression{
+				Step:     name,
+				Metric:   "error rate",
+				Baseline: fmt.Sprintf("%.2f%%", base.ErrorRate()*100),
+				Current:  fmt.Sprintf("%.2f%%", cur.ErrorRate()*100),
+			})
+		}
+	}
+	return regressions
+}
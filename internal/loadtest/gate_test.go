@@ -0,0 +1,88 @@
+package loadtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stepResult(steps map[string]Stats) *Result {
+	return &Result{Steps: steps}
+}
+
+func TestCompare(t *testing.T) {
+	opts := GateOptions{Tolerance: 0.2, MinDelta: 5 * time.Millisecond, MaxErrorRateIncrease: 0.01}
+	base := map[string]Stats{
+		"board":  {Count: 1000, Errors: 0, P95: 100 * time.Millisecond},
+		"fast":   {Count: 1000, Errors: 0, P95: 2 * time.Millisecond},
+		"errors": {Count: 1000, Errors: 10, P95: 50 * time.Millisecond},
+	}
+
+	tests := []struct {
+		name    string
+		current map[string]Stats
+		want    []Regression
+	}{
+		{
+			name:    "within tolerance",
+			current: map[string]Stats{"board": {Count: 1000, P95: 120 * time.Millisecond}},
+		},
+		{
+			name:    "p95 beyond tolerance",
+			current: map[string]Stats{"board": {Count: 1000, P95: 121 * time.Millisecond}},
+			want:    []Regression{{Step: "board", Metric: "p95", Baseline: "100.0ms", Current: "121.0ms"}},
+		},
+		{
+			name:    "relative regression below the absolute noise floor",
+			current: map[string]Stats{"fast": {Count: 1000, P95: 6 * time.Millisecond}},
+		},
+		{
+			name:    "relative regression above the absolute noise floor",
+			current: map[string]Stats{"fast": {Count: 1000, P95: 8 * time.Millisecond}},
+			want:    []Regression{{Step: "fast", Metric: "p95", Baseline: "2.0ms", Current: "8.0ms"}},
+		},
+		{
+			name:    "error rate within the allowed increase",
+			current: map[string]Stats{"errors": {Count: 1000, Errors: 20, P95: 50 * time.Millisecond}},
+		},
+		{
+			name:    "error rate beyond the allowed increase",
+			current: map[string]Stats{"errors": {Count: 1000, Errors: 21, P95: 50 * time.Millisecond}},
+			want:    []Regression{{Step: "errors", Metric: "error rate", Baseline: "1.00%", Current: "2.10%"}},
+		},
+		{
+			name:    "faster and without errors",
+			current: map[string]Stats{"board": {Count: 1000, P95: 80 * time.Millisecond}},
+		},
+		{
+			name: "new steps and empty steps are ignored",
+			current: map[string]Stats{
+				"new":   {Count: 10, Errors: 10, P95: time.Second},
+				"board": {Count: 0},
+			},
+		},
+		{
+			name: "p95 and error rate on several steps, sorted by step",
+			current: map[string]Stats{
+				"fast":  {Count: 1000, Errors: 100, P95: 2 * time.Millisecond},
+				"board": {Count: 1000, P95: 200 * time.Millisecond},
+			},
+			want: []Regression{
+				{Step: "board", Metric: "p95", Baseline: "100.0ms", Current: "200.0ms"},
+				{Step: "fast", Metric: "error rate", Baseline: "0.00%", Current: "10.00%"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Compare(stepResult(base), stepResult(tt.current), opts))
+		})
+	}
+}
+
+func TestRegression_String(t *testing.T) {
+	r := Regression{Step: "board", Metric: "p95", Baseline: "100.0ms", Current: "121.0ms"}
+	assert.Equal(t, "board p95: 100.0ms -> 121.0ms", r.String())
+}
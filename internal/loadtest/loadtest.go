@@ -0,0 +1,172 @@
+// Package loadtest reproduz perfis de tráfego sintético contra a API e mede latência por passo
+// (p50/p90/p95/p99). É usado pelo comando `linkko-api bench` como gate de regressão de
+// performance: o resultado pode ser salvo como baseline e comparado em PRs seguintes.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Client executa requests autenticadas contra uma instância da API.
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// Do envia a request e retorna status e corpo. body nil envia sem corpo; POST/PATCH recebem
+// um Idempotency-Key único, como os clientes reais.
+func (c *Client) Do(ctx context.Context, method, path string, body any) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, fmt.Errorf("marshal body: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.BaseURL, "/")+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if method == http.MethodPost || method == http.MethodPatch {
+		req.Header.Set("Idempotency-Key", fmt.Sprintf("bench-%d", nextSeq()))
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, data, err
+}
+
+var seq atomic.Int64
+
+// nextSeq número único no processo (idempotency keys, emails sintéticos).
+func nextSeq() int64 {
+	return time.Now().UnixNano()/1000 + seq.Add(1)
+}
+
+// Vars variáveis substituídas nos paths ({workspaceId}, {pipelineId}...).
+type Vars map[string]string
+
+func (v Vars) expand(path string) string {
+	for k, val := range v {
+		path = strings.ReplaceAll(path, "{"+k+"}", val)
+	}
+	return path
+}
+
+// Step request de um perfil. Weight define a proporção do passo no tráfego.
+type Step struct {
+	Name   string
+	Method string
+	Path   string
+	Weight int
+	// Body gera o corpo da n-ésima execução do passo (nil = sem corpo)
+	Body func(n int64) any
+}
+
+// Profile perfil de tráfego. Setup prepara as variáveis (ex.: descobre um pipeline) antes da carga.
+type Profile struct {
+	Name        string
+	Description string
+	Setup       func(ctx context.Context, c *Client, vars Vars) error
+	Steps       []Step
+}
+
+// Options parâmetros da execução.
+type Options struct {
+	Concurrency int           // workers simultâneos
+	Duration    time.Duration // duração da carga (após o warmup)
+	Warmup      time.Duration // requests descartadas das estatísticas
+}
+
+// Run executa o perfil até Duration (ou cancelamento do ctx) e agrega as latências por passo.
+func Run(ctx context.Context, c *Client, p *Profile, vars Vars, opts Options) (*Result, error) {
+	if opts.Concurrency <= 0 {
+		return nil, fmt.Errorf("concurrency must be positive")
+	}
+	if len(p.Steps) == 0 {
+		return nil, fmt.Errorf("profile %q has no steps", p.Name)
+	}
+	if p.Setup != nil {
+		if err := p.Setup(ctx, c, vars); err != nil {
+			return nil, fmt.Errorf("setup profile %q: %w", p.Name, err)
+		}
+	}
+
+	// Sequência ponderada: cada worker percorre os passos na proporção dos pesos
+	var schedule []int
+	for i, step := range p.Steps {
+		for w := 0; w < max(step.Weight, 1); w++ {
+			schedule = append(schedule, i)
+		}
+	}
+
+	recorders := make([]*recorder, len(p.Steps))
+	for i := range recorders {
+		recorders[i] = &recorder{statuses: map[int]int64{}}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Warmup+opts.Duration)
+	defer cancel()
+	measureFrom := time.Now().Add(opts.Warmup)
+
+	var counter atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				n := counter.Add(1)
+				idx := schedule[int(n)%len(schedule)]
+				step := p.Steps[idx]
+
+				var body any
+				if step.Body != nil {
+					body = step.Body(n)
+				}
+				start := time.Now()
+				status, _, err := c.Do(ctx, step.Method, vars.expand(step.Path), body)
+				elapsed := time.Since(start)
+
+				// Requests interrompidas pelo fim da janela não entram nas estatísticas
+				if ctx.Err() != nil || start.Before(measureFrom) {
+					continue
+				}
+				recorders[idx].record(elapsed, status, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := &Result{Profile: p.Name, Concurrency: opts.Concurrency, Duration: opts.Duration, Steps: map[string]Stats{}}
+	all := &recorder{statuses: map[int]int64{}}
+	for i, r := range recorders {
+		result.Steps[p.Steps[i].Name] = r.stats()
+		all.merge(r)
+	}
+	result.Overall = all.stats()
+	if opts.Duration > 0 {
+		result.Throughput = float64(result.Overall.Count) / opts.Duration.Seconds()
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"linkko-api/internal/sdk"
+)
+
+// Profiles perfis disponíveis no `linkko-api bench`, por nome.
+var Profiles = map[string]*Profile{
+	"board-polling": boardPollingProfile,
+	"import":        importProfile,
+}
+
+// ProfileNames nomes dos perfis em ordem alfabética.
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// boardPollingProfile simula o quadro de negócios aberto em várias abas: polling das colunas,
+// do cabeçalho de contadores e do "meu trabalho".
+var boardPollingProfile = &Profile{
+	Name:        "board-polling",
+	Description: "Deal board polling (deals per pipeline, stages, dashboard counters, my work)",
+	Setup:       discoverPipeline,
+	Steps: []Step{
+		{Name: "list deals", Method: http.MethodGet, Path: sdk.RouteDeals + "?pipelineId={pipelineId}&limit=50", Weight: 5},
+		{Name: "list stages", Method: http.MethodGet, Path: sdk.RoutePipelineStages, Weight: 2},
+		{Name: "counters", Method: http.MethodGet, Path: "/v1/workspaces/{workspaceId}/counters", Weight: 2},
+		{Name: "my work", Method: http.MethodGet, Path: "/v1/workspaces/{workspaceId}/me/work", Weight: 1},
+	},
+}
+
+// importProfile simula uma importação de contatos (criação contínua) com a listagem aberta.
+var importProfile = &Profile{
+	Name:        "import",
+	Description: "Contact import (sustained creates with the contact list open)",
+	Steps: []Step{
+		{Name: "create contact", Method: http.MethodPost, Path: sdk.RouteContacts, Weight: 8, Body: func(n int64) any {
+			id := nextSeq()
+			return map[string]any{
+				"fullName": fmt.Sprintf("Bench Contact %d", n),
+				"email":    fmt.Sprintf("bench+%d@example.com", id),
+			}
+		}},
+		{Name: "list contacts", Method: http.MethodGet, Path: sdk.RouteContacts + "?limit=50", Weight: 2},
+	},
+}
+
+// discoverPipeline preenche {pipelineId} com o primeiro pipeline do workspace, criando o
+// pipeline padrão quando o workspace ainda não tem nenhum.
+func discoverPipeline(ctx context.Context, c *Client, vars Vars) error {
+	if vars["pipelineId"] != "" {
+		return nil
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		status, body, err := c.Do(ctx, http.MethodGet, vars.expand(sdk.RoutePipelines), nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("list pipelines: status %d: %s", status, body)
+		}
+		var list sdk.PipelineListResponse
+		if err := json.Unmarshal(body, &list); err != nil {
+			return fmt.Errorf("decode pipelines: %w", err)
+		}
+		if len(list.Data) > 0 {
+			vars["pipelineId"] = list.Data[0].ID
+			return nil
+		}
+
+		status, body, err = c.Do(ctx, http.MethodPost, vars.expand(sdk.RoutePipelineSeedDefault), map[string]any{})
+		if err != nil {
+			return err
+		}
+		if status >= 300 {
+			return fmt.Errorf("seed default pipeline: status %d: %s", status, body)
+		}
+	}
+	return fmt.Errorf("workspace has no pipelines")
+}
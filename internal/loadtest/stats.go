@@ -0,0 +1,148 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Stats latências de um passo (ou do perfil inteiro). Erros são falhas de transporte e
+// respostas 5xx; 4xx contam como sucesso do ponto de vista da carga, mas aparecem em Statuses.
+type Stats struct {
+	Count    int64         `json:"count"`
+	Errors   int64         `json:"errors"`
+	Statuses map[int]int64 `json:"statuses"`
+	Mean     time.Duration `json:"mean"`
+	P50      time.Duration `json:"p50"`
+	P90      time.Duration `json:"p90"`
+	P95      time.Duration `json:"p95"`
+	P99      time.Duration `json:"p99"`
+	Max      time.Duration `json:"max"`
+}
+
+// ErrorRate fração de requests com erro.
+func (s Stats) ErrorRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Count)
+}
+
+// Result resultado de uma execução de perfil.
+type Result struct {
+	Profile     string           `json:"profile"`
+	Concurrency int              `json:"concurrency"`
+	Duration    time.Duration    `json:"duration"`
+	Throughput  float64          `json:"throughput"` // requests/s
+	Overall     Stats            `json:"overall"`
+	Steps       map[string]Stats `json:"steps"`
+}
+
+// recorder acumula as amostras de um passo (uma mutex por passo: a contenção é desprezível
+// perto da latência das requests).
+type recorder struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    int64
+	statuses  map[int]int64
+}
+
+func (r *recorder) record(d time.Duration, status int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies = append(r.latencies, d)
+	if err != nil || status >= 500 {
+		r.errors++
+	}
+	r.statuses[status]++ // 0 = falha de transporte
+}
+
+func (r *recorder) merge(other *recorder) {
+	r.latencies = append(r.latencies, other.latencies...)
+	r.errors += other.errors
+	for status, n := range other.statuses {
+		r.statuses[status] += n
+	}
+}
+
+func (r *recorder) stats() Stats {
+	s := Stats{Count: int64(len(r.latencies)), Errors: r.errors, Statuses: r.statuses}
+	if len(r.latencies) == 0 {
+		return s
+	}
+
+	sorted := append([]time.Duration(nil), r.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	s.Mean = total / time.Duration(len(sorted))
+	s.P50 = percentile(sorted, 0.50)
+	s.P90 = percentile(sorted, 0.90)
+	s.P95 = percentile(sorted, 0.95)
+	s.P99 = percentile(sorted, 0.99)
+	s.Max = sorted[len(sorted)-1]
+	return s
+}
+
+// percentile nearest-rank sobre amostras ordenadas.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(p*float64(len(sorted))+0.999999) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// WriteText imprime o resultado em tabela.
+func (r *Result) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "profile=%s concurrency=%d duration=%s throughput=%.1f req/s\n\n",
+		r.Profile, r.Concurrency, r.Duration, r.Throughput)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "step\tcount\terrors\tmean\tp50\tp90\tp95\tp99\tmax\t")
+	names := make([]string, 0, len(r.Steps))
+	for name := range r.Steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	row := func(name string, s Stats) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t\n", name, s.Count, s.Errors,
+			ms(s.Mean), ms(s.P50), ms(s.P90), ms(s.P95), ms(s.P99), ms(s.Max))
+	}
+	for _, name := range names {
+		row(name, r.Steps[name])
+	}
+	row("TOTAL", r.Overall)
+	return tw.Flush()
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
+
+// SaveResult grava o resultado em JSON (baseline para execuções futuras).
+func SaveResult(path string, r *Result) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadResult lê um resultado salvo por SaveResult.
+func LoadResult(path string) (*Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Result
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return &r, nil
+}
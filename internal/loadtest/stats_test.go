@@ -0,0 +1,110 @@
+package loadtest
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func millis(values ...int) []time.Duration {
+	out := make([]time.Duration, len(values))
+	for i, v := range values {
+		out[i] = time.Duration(v) * time.Millisecond
+	}
+	return out
+}
+
+func TestPercentile(t *testing.T) {
+	hundred := make([]int, 100)
+	for i := range hundred {
+		hundred[i] = i + 1
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{"single sample", millis(7), 0.99, 7 * time.Millisecond},
+		{"p50 of 1..100", millis(hundred...), 0.50, 50 * time.Millisecond},
+		{"p90 of 1..100", millis(hundred...), 0.90, 90 * time.Millisecond},
+		{"p95 of 1..100", millis(hundred...), 0.95, 95 * time.Millisecond},
+		{"p99 of 1..100", millis(hundred...), 0.99, 99 * time.Millisecond},
+		{"nearest rank rounds up", millis(10, 20, 30), 0.50, 20 * time.Millisecond},
+		{"p95 of few samples is the max", millis(10, 20, 30, 40), 0.95, 40 * time.Millisecond},
+		{"p0 is the min", millis(10, 20, 30), 0, 10 * time.Millisecond},
+		{"p100 is the max", millis(10, 20, 30), 1, 30 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, percentile(tt.sorted, tt.p))
+		})
+	}
+}
+
+func TestRecorder_Stats(t *testing.T) {
+	r := &recorder{statuses: map[int]int64{}}
+	for _, d := range millis(40, 10, 30, 20) {
+		r.record(d, 200, nil)
+	}
+	r.record(100*time.Millisecond, 503, nil)
+	r.record(50*time.Millisecond, 404, nil)
+	r.record(2*time.Millisecond, 0, errors.New("connection refused"))
+
+	s := r.stats()
+	assert.Equal(t, int64(7), s.Count)
+	assert.Equal(t, int64(2), s.Errors, "5xx and transport failures are errors; 4xx is not")
+	assert.Equal(t, map[int]int64{200: 4, 503: 1, 404: 1, 0: 1}, s.Statuses)
+	assert.Equal(t, 36*time.Millisecond, s.Mean)
+	assert.Equal(t, 30*time.Millisecond, s.P50)
+	assert.Equal(t, 100*time.Millisecond, s.P99)
+	assert.Equal(t, 100*time.Millisecond, s.Max)
+	assert.InDelta(t, 2.0/7, s.ErrorRate(), 1e-9)
+
+	// As amostras originais continuam na ordem de chegada
+	assert.Equal(t, 40*time.Millisecond, r.latencies[0])
+}
+
+func TestRecorder_StatsEmpty(t *testing.T) {
+	s := (&recorder{statuses: map[int]int64{}}).stats()
+	assert.Zero(t, s.Count)
+	assert.Zero(t, s.P95)
+	assert.Zero(t, s.ErrorRate())
+}
+
+func TestRecorder_Merge(t *testing.T) {
+	a := &recorder{statuses: map[int]int64{}}
+	b := &recorder{statuses: map[int]int64{}}
+	a.record(10*time.Millisecond, 200, nil)
+	b.record(20*time.Millisecond, 200, nil)
+	b.record(30*time.Millisecond, 500, nil)
+
+	a.merge(b)
+	s := a.stats()
+	assert.Equal(t, int64(3), s.Count)
+	assert.Equal(t, int64(1), s.Errors)
+	assert.Equal(t, map[int]int64{200: 2, 500: 1}, s.Statuses)
+}
+
+func TestSaveLoadResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	want := &Result{
+		Profile:     "board-polling",
+		Concurrency: 4,
+		Duration:    30 * time.Second,
+		Throughput:  120.5,
+		Overall:     Stats{Count: 10, Statuses: map[int]int64{200: 10}, P95: 12 * time.Millisecond},
+		Steps:       map[string]Stats{"board": {Count: 10, Statuses: map[int]int64{200: 10}, P95: 12 * time.Millisecond}},
+	}
+	require.NoError(t, SaveResult(path, want))
+
+	got, err := LoadResult(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,163 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound é retornado por Get/Exists quando a chave não existe.
+var ErrNotFound = errors.New("object not found")
+
+// Store é um armazenamento de objetos por chave ("snapshots/ws_1/snp_1.tar.gz").
+type Store interface {
+	// Put grava o conteúdo de r na chave, substituindo o objeto existente, e retorna o tamanho.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Get abre o objeto para leitura; o chamador fecha o reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Exists reporta se a chave existe.
+	Exists(ctx context.Context, key string) (bool, error)
+	// Move renomeia o objeto de src para dst, substituindo dst; ErrNotFound se src não existe.
+	Move(ctx context.Context, src, dst string) error
+	// Delete remove o objeto; chave inexistente não é erro.
+	Delete(ctx context.Context, key string) error
+}
+
+// Open cria o Store a partir de uma URL de configuração.
+// Suportado: file:///caminho/absoluto (diretório local ou volume montado de um bucket).
+func Open(rawURL string) (Store, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse object store url: %w", err)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" || !filepath.IsAbs(u.Path) {
+			return nil, fmt.Errorf("file object store requires an absolute path")
+		}
+		return &FileStore{root: filepath.Clean(u.Path)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported object store scheme %q", u.Scheme)
+	}
+}
+
+// FileStore guarda objetos como arquivos sob root.
+// Put escreve em um arquivo temporário e renomeia, então leitores nunca veem objetos parciais.
+type FileStore struct {
+	root string
+}
+
+// ValidKey reporta se a chave é relativa e não escapa da raiz do store.
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	clean := path.Clean(key)
+	return clean == key && clean != "." && !strings.HasPrefix(clean, "../") && clean != ".."
+}
+
+func (s *FileStore) path(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *FileStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return 0, fmt.Errorf("create object dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("create temp object: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("close object: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return 0, fmt.Errorf("commit object: %w", err)
+	}
+	return n, nil
+}
+
+func (s *FileStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("open object: %w", err)
+	}
+	return f, nil
+}
+
+func (s *FileStore) Exists(_ context.Context, key string) (bool, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("stat object: %w", err)
+	}
+	return true, nil
+}
+
+func (s *FileStore) Move(_ context.Context, src, dst string) error {
+	from, err := s.path(src)
+	if err != nil {
+		return err
+	}
+	to, err := s.path(dst)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o750); err != nil {
+		return fmt.Errorf("create object dir: %w", err)
+	}
+	if err := os.Rename(from, to); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("move object: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	return nil
+}
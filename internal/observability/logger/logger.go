@@ -29,6 +29,7 @@ type rootErrorContainer struct {
 type Logger struct {
 	zap         *zap.Logger
 	serviceName string
+	level       zap.AtomicLevel // compartilhado com os loggers derivados (WithContext)
 }
 
 // Field represents a structured log field
@@ -41,11 +42,11 @@ func New(serviceName string, level string) (*Logger, error) {
 		return nil, fmt.Errorf("serviceName is required")
 	}
 
-	zapLevel := parseLevel(level)
+	atomicLevel := zap.NewAtomicLevelAt(parseLevel(level))
 
 	// Configure zap to output JSON with RFC3339Nano timestamps
 	config := zap.Config{
-		Level:            zap.NewAtomicLevelAt(zapLevel),
+		Level:            atomicLevel,
 		Encoding:         "json",
 		OutputPaths:      []string{"stdout"},
 		ErrorOutputPaths: []string{"stderr"},
@@ -75,9 +76,20 @@ func New(serviceName string, level string) (*Logger, error) {
 	return &Logger{
 		zap:         z,
 		serviceName: serviceName,
+		level:       atomicLevel,
 	}, nil
 }
 
+// SetLevel changes the level of this logger and of every logger derived from it (LOG_LEVEL reload)
+func (l *Logger) SetLevel(level string) {
+	l.level.SetLevel(parseLevel(level))
+}
+
+// Level returns the current level ("debug", "info", "warn", "error")
+func (l *Logger) Level() string {
+	return l.level.String()
+}
+
 // WithContext returns a logger that includes context values (request_id, workspace_id, user_id)
 func (l *Logger) WithContext(ctx context.Context) *Logger {
 	fields := []Field{}
@@ -101,6 +113,7 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 	return &Logger{
 		zap:         l.zap.With(fields...),
 		serviceName: l.serviceName,
+		level:       l.level,
 	}
 }
 
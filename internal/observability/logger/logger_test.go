@@ -143,6 +143,21 @@ func TestLogger_LevelParsing(t *testing.T) {
 	}
 }
 
+func TestLogger_SetLevel(t *testing.T) {
+	log, err := logger.New("test-service", "info")
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer log.Sync()
+
+	derived := log.WithContext(requestid.SetRequestID(context.Background(), "test-123"))
+	log.SetLevel("debug")
+
+	if log.Level() != "debug" || derived.Level() != "debug" {
+		t.Errorf("expected debug on root and derived loggers, got %q and %q", log.Level(), derived.Level())
+	}
+}
+
 func TestLogger_WithContext(t *testing.T) {
 	log, err := logger.New("test-service", "info")
 	if err != nil {
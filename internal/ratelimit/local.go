@@ -0,0 +1,163 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// LocalRateLimiter limitador em memória usado quando o Redis está indisponível.
+// É aproximado: usa sliding window counter (janela atual + fração da anterior) e cada réplica conta
+// só as próprias requests, por isso o limite é dividido por instances.
+type LocalRateLimiter struct {
+	instances int
+	now       func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*localWindow
+	lastSweep time.Time
+}
+
+type localWindow struct {
+	start    time.Time
+	window   time.Duration
+	current  int
+	previous int
+}
+
+// NewLocalRateLimiter creates a new in-process rate limiter. instances é o número de réplicas da
+// API (RATE_LIMIT_FALLBACK_INSTANCES); valores < 1 valem 1.
+func NewLocalRateLimiter(instances int) *LocalRateLimiter {
+	if instances < 1 {
+		instances = 1
+	}
+	return &LocalRateLimiter{
+		instances: instances,
+		now:       time.Now,
+		windows:   make(map[string]*localWindow),
+	}
+}
+
+// AllowRequest mesma assinatura de RedisRateLimiter.AllowRequest; nunca retorna erro.
+func (l *LocalRateLimiter) AllowRequest(ctx context.Context, workspaceID string, limit int, windowSeconds int) (Decision, error) {
+	return l.check(workspaceID, limit, windowSeconds, true), nil
+}
+
+// Peek mesma assinatura de RedisRateLimiter.Peek; nunca retorna erro.
+func (l *LocalRateLimiter) Peek(ctx context.Context, workspaceID string, limit int, windowSeconds int) (Decision, error) {
+	return l.check(workspaceID, limit, windowSeconds, false), nil
+}
+
+// check consome uma unidade da cota quando consume e a request é admitida
+func (l *LocalRateLimiter) check(workspaceID string, limit int, windowSeconds int, consume bool) Decision {
+	window := time.Duration(windowSeconds) * time.Second
+	localLimit := l.localLimit(limit)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now, window)
+
+	w := l.windows[workspaceID]
+	if w == nil || w.window != window {
+		w = &localWindow{start: now.Truncate(window), window: window}
+		if consume {
+			l.windows[workspaceID] = w
+		}
+	}
+	w.advance(now)
+
+	decision := Decision{Limit: limit, Allowed: w.estimate(now) < localLimit}
+	if decision.Allowed && consume {
+		w.current++
+	}
+	decision.Remaining = max(0, localLimit-w.estimate(now))
+	if !decision.Allowed {
+		decision.RetryAfter = w.retryAfter(now, localLimit)
+	}
+	decision.ResetAfter = w.resetAfter(now)
+	return decision
+}
+
+// Usage retorna o consumo estimado da janela nesta réplica.
+func (l *LocalRateLimiter) Usage(ctx context.Context, workspaceID string, windowSeconds int) (int, error) {
+	window := time.Duration(windowSeconds) * time.Second
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w := l.windows[workspaceID]
+	if w == nil || w.window != window {
+		return 0, nil
+	}
+	now := l.now()
+	w.advance(now)
+	return w.estimate(now) * l.instances, nil
+}
+
+// localLimit cota desta réplica: ao menos 1 para não bloquear workspaces com limite baixo
+func (l *LocalRateLimiter) localLimit(limit int) int {
+	return max(1, int(math.Ceil(float64(limit)/float64(l.instances))))
+}
+
+// sweep descarta janelas sem requests há mais de 2 janelas; roda no máximo uma vez por janela.
+// must be called with l.mu held
+func (l *LocalRateLimiter) sweep(now time.Time, window time.Duration) {
+	if now.Sub(l.lastSweep) < window {
+		return
+	}
+	l.lastSweep = now
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= 2*w.window {
+			delete(l.windows, key)
+		}
+	}
+}
+
+// advance move a janela atual para a de now, levando a contagem para previous quando adjacente
+func (w *localWindow) advance(now time.Time) {
+	start := now.Truncate(w.window)
+	if !start.After(w.start) {
+		return
+	}
+	if start.Sub(w.start) == w.window {
+		w.previous = w.current
+	} else {
+		w.previous = 0
+	}
+	w.current = 0
+	w.start = start
+}
+
+// retryAfter quando estimate fica abaixo de limit: ainda nesta janela, conforme a anterior perde
+// peso, ou na próxima, quando a atual passa a ser a anterior
+func (w *localWindow) retryAfter(now time.Time, limit int) time.Duration {
+	var at time.Time
+	if w.current < limit && w.previous > 0 {
+		elapsed := 1 - float64(limit-w.current)/float64(w.previous)
+		at = w.start.Add(time.Duration(elapsed * float64(w.window)))
+	} else {
+		elapsed := 1 - float64(limit)/float64(max(w.current, 1))
+		at = w.start.Add(w.window).Add(time.Duration(elapsed * float64(w.window)))
+	}
+	return max(at.Sub(now), time.Millisecond)
+}
+
+// resetAfter quando as requests contadas saem do estimate
+func (w *localWindow) resetAfter(now time.Time) time.Duration {
+	switch {
+	case w.current > 0:
+		return w.start.Add(2 * w.window).Sub(now)
+	case w.previous > 0:
+		return w.start.Add(w.window).Sub(now)
+	}
+	return 0
+}
+
+// estimate requests nos últimos w.window: a janela atual mais a parte da anterior ainda coberta
+func (w *localWindow) estimate(now time.Time) int {
+	elapsed := float64(now.Sub(w.start)) / float64(w.window)
+	return w.current + int(float64(w.previous)*(1-elapsed))
+}
@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalLimiter(instances int, now *time.Time) *LocalRateLimiter {
+	l := NewLocalRateLimiter(instances)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLocalRateLimiter_AllowRequest(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	limiter := newTestLocalLimiter(1, &now)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		decision, err := limiter.AllowRequest(ctx, "ws-1", 3, 60)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 2-i, decision.Remaining)
+		assert.Zero(t, decision.RetryAfter)
+	}
+	decision, _ := limiter.AllowRequest(ctx, "ws-1", 3, 60)
+	assert.False(t, decision.Allowed)
+	// A janela atual vira a anterior em 60s e pesa menos que o limite logo depois
+	assert.Equal(t, 60*time.Second, decision.RetryAfter)
+	assert.Equal(t, 2*time.Minute, decision.ResetAfter)
+
+	// Workspaces são independentes
+	decision, _ = limiter.AllowRequest(ctx, "ws-2", 3, 60)
+	assert.True(t, decision.Allowed)
+
+	used, err := limiter.Usage(ctx, "ws-1", 60)
+	require.NoError(t, err)
+	assert.Equal(t, 3, used)
+}
+
+func TestLocalRateLimiter_WindowBoundary(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 50, 0, time.UTC)
+	limiter := newTestLocalLimiter(1, &now)
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		decision, _ := limiter.AllowRequest(ctx, "ws-1", 10, 60)
+		require.True(t, decision.Allowed)
+	}
+
+	// 15s depois da virada a janela anterior ainda pesa 75%: não há rajada de 2x
+	now = now.Add(25 * time.Second)
+	admitted := 0
+	for i := 0; i < 10; i++ {
+		if decision, _ := limiter.AllowRequest(ctx, "ws-1", 10, 60); decision.Allowed {
+			admitted++
+		}
+	}
+	assert.Equal(t, 3, admitted)
+
+	// Duas janelas depois a contagem zera
+	now = now.Add(2 * time.Minute)
+	decision, _ := limiter.AllowRequest(ctx, "ws-1", 10, 60)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 9, decision.Remaining)
+}
+
+func TestLocalRateLimiter_SplitsLimitAcrossInstances(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	limiter := newTestLocalLimiter(4, &now)
+	ctx := context.Background()
+
+	admitted := 0
+	for i := 0; i < 100; i++ {
+		if decision, _ := limiter.AllowRequest(ctx, "ws-1", 10, 60); decision.Allowed {
+			admitted++
+		}
+	}
+	assert.Equal(t, 3, admitted, "ceil(10/4) per instance")
+
+	// Limite menor que o número de réplicas ainda admite 1
+	decision, _ := limiter.AllowRequest(ctx, "ws-2", 2, 60)
+	assert.True(t, decision.Allowed)
+}
+
+func TestLocalRateLimiter_Peek(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	limiter := newTestLocalLimiter(1, &now)
+	ctx := context.Background()
+
+	decision, err := limiter.Peek(ctx, "ws-1", 2, 60)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Remaining)
+	assert.Empty(t, limiter.windows, "peeking an unknown workspace does not track it")
+
+	limiter.AllowRequest(ctx, "ws-1", 2, 60)
+	limiter.AllowRequest(ctx, "ws-1", 2, 60)
+	for i := 0; i < 3; i++ {
+		decision, _ = limiter.Peek(ctx, "ws-1", 2, 60)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, 0, decision.Remaining)
+	}
+	assert.Equal(t, 60*time.Second, decision.RetryAfter)
+
+	// Com a anterior cheia, a vaga abre conforme ela perde peso dentro da nova janela
+	now = now.Add(time.Minute + time.Second)
+	decision, _ = limiter.Peek(ctx, "ws-1", 2, 60)
+	assert.True(t, decision.Allowed)
+	limiter.AllowRequest(ctx, "ws-1", 2, 60)
+	decision, _ = limiter.Peek(ctx, "ws-1", 2, 60)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 29*time.Second, decision.RetryAfter, "the previous window weighs less than 1 request after 30s")
+}
+
+func TestLocalRateLimiter_SweepsIdleWorkspaces(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	limiter := newTestLocalLimiter(1, &now)
+	ctx := context.Background()
+
+	limiter.AllowRequest(ctx, "ws-idle", 10, 60)
+	now = now.Add(3 * time.Minute)
+	limiter.AllowRequest(ctx, "ws-active", 10, 60)
+
+	assert.NotContains(t, limiter.windows, "ws-idle")
+	assert.Contains(t, limiter.windows, "ws-active")
+}
+
+func TestRedisRateLimiter_LocalFallback(t *testing.T) {
+	// Nada escuta na porta 1: toda chamada ao Redis falha na conexão
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: time.Second})
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	withoutFallback := NewRedisRateLimiter(client, AlgorithmSlidingWindowLog, nil, nil)
+	_, err := withoutFallback.AllowRequest(ctx, "ws-1", 2, 60)
+	assert.Error(t, err)
+	_, err = withoutFallback.Peek(ctx, "ws-1", 2, 60)
+	assert.Error(t, err)
+
+	limiter := NewRedisRateLimiter(client, AlgorithmSlidingWindowLog, nil, nil).
+		WithLocalFallback(NewLocalRateLimiter(1), nil, nil)
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.AllowRequest(ctx, "ws-1", 2, 60)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	}
+	decision, err := limiter.AllowRequest(ctx, "ws-1", 2, 60)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed, "the local limiter still enforces the limit")
+	assert.Positive(t, decision.RetryAfter)
+	assert.True(t, limiter.degraded.Load())
+
+	decision, err = limiter.Peek(ctx, "ws-1", 2, 60)
+	require.NoError(t, err)
+	assert.Equal(t, 0, decision.Remaining)
+
+	used, err := limiter.Usage(ctx, "ws-1", 60)
+	require.NoError(t, err)
+	assert.Equal(t, 2, used)
+}
@@ -2,75 +2,256 @@ package ratelimit
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/resilience"
+
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// Algorithm algoritmo de rate limit (RATE_LIMIT_ALGORITHM)
+type Algorithm string
+
+const (
+	// AlgorithmSlidingWindowLog conta os timestamps dos últimos windowSeconds: nunca mais de
+	// limit requests em qualquer janela, inclusive nas viradas de minuto.
+	AlgorithmSlidingWindowLog Algorithm = "sliding_window_log"
+	// AlgorithmTokenBucket balde de limit fichas recarregado a limit/windowSeconds: rajadas de até
+	// limit e depois vazão constante.
+	AlgorithmTokenBucket Algorithm = "token_bucket"
 )
 
-// RedisRateLimiter implements rate limiting using Redis sliding window algorithm
+// ParseAlgorithm valida o nome do algoritmo
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch Algorithm(name) {
+	case AlgorithmSlidingWindowLog, AlgorithmTokenBucket:
+		return Algorithm(name), nil
+	}
+	return "", fmt.Errorf("unknown rate limit algorithm %q (want %s or %s)", name, AlgorithmSlidingWindowLog, AlgorithmTokenBucket)
+}
+
+// Decision resultado de uma checagem (AllowRequest) ou consulta (Peek) de rate limit
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter espera até a próxima request ser admitida (0 enquanto há cota)
+	RetryAfter time.Duration
+	// ResetAfter espera até a cota voltar inteira
+	ResetAfter time.Duration
+}
+
+func newDecision(limit int, allowed bool, remaining, retryAfterMs, resetMs int64) Decision {
+	return Decision{
+		Allowed:    allowed,
+		Limit:      limit,
+		Remaining:  int(max(0, remaining)),
+		RetryAfter: time.Duration(retryAfterMs) * time.Millisecond,
+		ResetAfter: time.Duration(resetMs) * time.Millisecond,
+	}
+}
+
+// RedisRateLimiter implements distributed rate limiting in Redis.
+// Each check runs as a single Lua script, so concurrent instances never overshoot the limit.
 type RedisRateLimiter struct {
 	client              *redis.Client
+	algorithm           Algorithm
+	namespace           string // prefixo das chaves (WithNamespace)
 	rateLimitRejections metric.Int64Counter
+	breaker             *resilience.Breaker
+
+	// Fallback local (WithLocalFallback); degraded evita um log por request enquanto o Redis está fora
+	fallback  *LocalRateLimiter
+	fallbacks metric.Int64Counter
+	log       *logger.Logger
+	degraded  atomic.Bool
 }
 
-// NewRedisRateLimiter creates a new Redis-based rate limiter
-func NewRedisRateLimiter(client *redis.Client, rateLimitRejections metric.Int64Counter) *RedisRateLimiter {
+// NewRedisRateLimiter creates a new Redis-based rate limiter.
+// breaker pode ser nil; quando aberto, AllowRequest retorna resilience.ErrCircuitOpen
+// sem tocar no Redis. Sem WithLocalFallback o erro chega ao middleware (fail-open).
+func NewRedisRateLimiter(client *redis.Client, algorithm Algorithm, rateLimitRejections metric.Int64Counter, breaker *resilience.Breaker) *RedisRateLimiter {
 	return &RedisRateLimiter{
 		client:              client,
+		algorithm:           algorithm,
 		rateLimitRejections: rateLimitRejections,
+		breaker:             breaker,
 	}
 }
 
-// AllowRequest checks if a request is allowed based on rate limit
-// Returns (allowed, remaining, error)
-func (rl *RedisRateLimiter) AllowRequest(ctx context.Context, workspaceID string, limit int, windowSeconds int) (bool, int, error) {
-	now := time.Now()
-	windowStart := now.Add(-time.Duration(windowSeconds) * time.Second)
-	
-	key := fmt.Sprintf("ratelimit:workspace:%s", workspaceID)
-	
-	// Use Redis pipeline for atomic operations
-	pipe := rl.client.Pipeline()
-	
-	// Remove old entries outside the sliding window
-	pipe.ZRemRangeByScore(ctx, key, "0", fmt.Sprintf("%d", windowStart.UnixMilli()))
-	
-	// Add current request timestamp
-	pipe.ZAdd(ctx, key, redis.Z{
-		Score:  float64(now.UnixMilli()),
-		Member: fmt.Sprintf("%d", now.UnixNano()),
+// WithLocalFallback passa a decidir com local quando o Redis falha (erro ou breaker aberto), em vez
+// de devolver o erro. fallbacks (pode ser nil) conta as decisões locais; a entrada e a saída do
+// modo degradado são logadas uma vez cada.
+func (rl *RedisRateLimiter) WithLocalFallback(local *LocalRateLimiter, fallbacks metric.Int64Counter, log *logger.Logger) *RedisRateLimiter {
+	rl.fallback = local
+	rl.fallbacks = fallbacks
+	rl.log = log
+	return rl
+}
+
+// WithNamespace prefixa as chaves com namespace + ":". Deploys de regiões diferentes usam a própria
+// região: mesmo com um Redis compartilhado, cada região conta só as próprias requests.
+func (rl *RedisRateLimiter) WithNamespace(namespace string) *RedisRateLimiter {
+	rl.namespace = namespace
+	return rl
+}
+
+// Algorithm returns the configured algorithm
+func (rl *RedisRateLimiter) Algorithm() Algorithm {
+	return rl.algorithm
+}
+
+// AllowRequest checks if a request is allowed based on rate limit and consumes one unit of quota
+// when it is. Com fallback local configurado, falhas do Redis não retornam erro.
+func (rl *RedisRateLimiter) AllowRequest(ctx context.Context, workspaceID string, limit int, windowSeconds int) (Decision, error) {
+	window := time.Duration(windowSeconds) * time.Second
+
+	var result []int64
+	// Sem retry: o script consome a cota e não é idempotente
+	err := rl.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		switch rl.algorithm {
+		case AlgorithmTokenBucket:
+			result, err = tokenBucketScript.Run(ctx, rl.client, []string{rl.namespaced(tokenBucketKey(workspaceID))},
+				limit, window.Milliseconds()).Int64Slice()
+		default:
+			result, err = slidingWindowLogScript.Run(ctx, rl.client, []string{rl.namespaced(rateLimitKey(workspaceID))},
+				limit, window.Milliseconds(), time.Now().UnixNano()).Int64Slice()
+		}
+		return err
 	})
-	
-	// Count requests in current window
-	countCmd := pipe.ZCount(ctx, key, "-inf", "+inf")
-	
-	// Set expiration to twice the window size to ensure cleanup
-	pipe.Expire(ctx, key, time.Duration(windowSeconds*2)*time.Second)
-	
-	// Execute pipeline
-	_, err := pipe.Exec(ctx)
-	if err != nil {
-		return false, 0, fmt.Errorf("failed to execute rate limit check: %w", err)
+	if err == nil && len(result) != 4 {
+		err = fmt.Errorf("unexpected rate limit script result %v", result)
 	}
-	
-	count, err := countCmd.Result()
 	if err != nil {
-		return false, 0, fmt.Errorf("failed to get count: %w", err)
+		err = fmt.Errorf("failed to execute rate limit check: %w", err)
+		if rl.fallback == nil {
+			return Decision{}, err
+		}
+		rl.enterFallback(ctx, err)
+		decision, _ := rl.fallback.AllowRequest(ctx, workspaceID, limit, windowSeconds)
+		rl.recordRejection(ctx, decision)
+		return decision, nil
+	}
+	rl.leaveFallback(ctx)
+
+	decision := newDecision(limit, result[0] == 1, result[1], result[2], result[3])
+	rl.recordRejection(ctx, decision)
+	return decision, nil
+}
+
+// Peek retorna a situação da cota sem consumi-la (HEAD /rate-limit). Allowed indica se a próxima
+// request seria admitida.
+func (rl *RedisRateLimiter) Peek(ctx context.Context, workspaceID string, limit int, windowSeconds int) (Decision, error) {
+	window := time.Duration(windowSeconds) * time.Second
+
+	var result []int64
+	err := rl.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		switch rl.algorithm {
+		case AlgorithmTokenBucket:
+			result, err = tokenBucketPeekScript.Run(ctx, rl.client, []string{rl.namespaced(tokenBucketKey(workspaceID))},
+				limit, window.Milliseconds()).Int64Slice()
+		default:
+			result, err = slidingWindowLogPeekScript.Run(ctx, rl.client, []string{rl.namespaced(rateLimitKey(workspaceID))},
+				limit, window.Milliseconds()).Int64Slice()
+		}
+		return err
+	})
+	if err == nil && len(result) != 3 {
+		err = fmt.Errorf("unexpected rate limit script result %v", result)
 	}
-	
-	remaining := limit - int(count)
-	if remaining < 0 {
-		remaining = 0
+	if err != nil {
+		if rl.fallback == nil {
+			return Decision{}, fmt.Errorf("failed to read rate limit status: %w", err)
+		}
+		return rl.fallback.Peek(ctx, workspaceID, limit, windowSeconds)
 	}
-	
-	allowed := count <= int64(limit)
-	
-	// Record rejection metric
-	if !allowed && rl.rateLimitRejections != nil {
+	return newDecision(limit, result[0] > 0, result[0], result[1], result[2]), nil
+}
+
+func (rl *RedisRateLimiter) recordRejection(ctx context.Context, decision Decision) {
+	if !decision.Allowed && rl.rateLimitRejections != nil {
 		rl.rateLimitRejections.Add(ctx, 1)
 	}
-	
-	return allowed, remaining, nil
+}
+
+// Usage returns how many requests the workspace consumed from the current window without
+// counting a new one (used by GET /v1/me). No token bucket, são as fichas que faltam no balde.
+func (rl *RedisRateLimiter) Usage(ctx context.Context, workspaceID string, windowSeconds int) (int, error) {
+	window := time.Duration(windowSeconds) * time.Second
+
+	var used int64
+	err := rl.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		switch rl.algorithm {
+		case AlgorithmTokenBucket:
+			used, err = tokenBucketUsageScript.Run(ctx, rl.client, []string{rl.namespaced(tokenBucketKey(workspaceID))},
+				window.Milliseconds()).Int64()
+		default:
+			windowStart := time.Now().Add(-window)
+			used, err = rl.client.ZCount(ctx, rl.namespaced(rateLimitKey(workspaceID)), fmt.Sprintf("(%d", windowStart.UnixMilli()), "+inf").Result()
+		}
+		return err
+	})
+	if err != nil {
+		if rl.fallback == nil {
+			return 0, fmt.Errorf("failed to read rate limit usage: %w", err)
+		}
+		return rl.fallback.Usage(ctx, workspaceID, windowSeconds)
+	}
+	return int(used), nil
+}
+
+// enterFallback registra uma decisão local; loga só na primeira desde que o Redis voltou
+func (rl *RedisRateLimiter) enterFallback(ctx context.Context, err error) {
+	circuitOpen := errors.Is(err, resilience.ErrCircuitOpen)
+	if rl.fallbacks != nil {
+		rl.fallbacks.Add(ctx, 1, metric.WithAttributes(attribute.Bool("circuit_open", circuitOpen)))
+	}
+	if rl.degraded.CompareAndSwap(false, true) && rl.log != nil {
+		rl.log.Warn(ctx, "redis rate limit unavailable, using local fallback limiter",
+			logger.Module("ratelimit"),
+			zap.Bool("circuit_open", circuitOpen),
+			zap.Error(err),
+		)
+	}
+}
+
+// leaveFallback loga a volta ao Redis depois de um período degradado
+func (rl *RedisRateLimiter) leaveFallback(ctx context.Context) {
+	if rl.degraded.CompareAndSwap(true, false) && rl.log != nil {
+		rl.log.Info(ctx, "redis rate limit recovered, local fallback limiter disabled",
+			logger.Module("ratelimit"),
+		)
+	}
+}
+
+// Degraded indica que o Redis está fora: decisões pelo fallback local ou breaker aberto (GET /v1/status)
+func (rl *RedisRateLimiter) Degraded() bool {
+	return rl.degraded.Load() || rl.breaker.State() == resilience.StateOpen
+}
+
+// namespaced aplica o namespace (WithNamespace); sem namespace as chaves não mudam
+func (rl *RedisRateLimiter) namespaced(key string) string {
+	if rl.namespace == "" {
+		return key
+	}
+	return rl.namespace + ":" + key
+}
+
+func rateLimitKey(workspaceID string) string {
+	return fmt.Sprintf("ratelimit:workspace:%s", workspaceID)
+}
+
+// tokenBucketKey chave própria: trocar de algoritmo não encontra um tipo Redis diferente (WRONGTYPE)
+func tokenBucketKey(workspaceID string) string {
+	return fmt.Sprintf("ratelimit:bucket:workspace:%s", workspaceID)
 }
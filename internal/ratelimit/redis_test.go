@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, name := range []string{"sliding_window_log", "token_bucket"} {
+		algorithm, err := ParseAlgorithm(name)
+		require.NoError(t, err)
+		assert.Equal(t, Algorithm(name), algorithm)
+	}
+
+	_, err := ParseAlgorithm("fixed_window")
+	assert.Error(t, err)
+}
+
+func TestRedisRateLimiter_WithNamespace(t *testing.T) {
+	limiter := NewRedisRateLimiter(nil, AlgorithmSlidingWindowLog, nil, nil)
+	assert.Equal(t, "ratelimit:workspace:ws-1", limiter.namespaced(rateLimitKey("ws-1")))
+
+	limiter.WithNamespace("sa-east-1")
+	assert.Equal(t, "sa-east-1:ratelimit:workspace:ws-1", limiter.namespaced(rateLimitKey("ws-1")))
+	assert.Equal(t, "sa-east-1:ratelimit:bucket:workspace:ws-1", limiter.namespaced(tokenBucketKey("ws-1")))
+}
+
+// newIntegrationLimiter connects to REDIS_URL; the scripts need a real Redis (TIME, EVALSHA).
+//
+// Run with: REDIS_URL=redis://localhost:6379/15 go test -v ./internal/ratelimit
+func newIntegrationLimiter(t *testing.T, algorithm Algorithm) (*RedisRateLimiter, string) {
+	t.Helper()
+	if os.Getenv("REDIS_URL") == "" {
+		t.Skip("REDIS_URL not set, skipping integration test")
+	}
+	opts, err := redis.ParseURL(os.Getenv("REDIS_URL"))
+	require.NoError(t, err)
+	client := redis.NewClient(opts)
+	t.Cleanup(func() { client.Close() })
+
+	workspaceID := fmt.Sprintf("ratelimit-test-%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		client.Del(context.Background(), rateLimitKey(workspaceID), tokenBucketKey(workspaceID))
+	})
+	return NewRedisRateLimiter(client, algorithm, nil, nil), workspaceID
+}
+
+func TestSlidingWindowLog_Integration(t *testing.T) {
+	limiter, workspaceID := newIntegrationLimiter(t, AlgorithmSlidingWindowLog)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		decision, err := limiter.AllowRequest(ctx, workspaceID, 3, 2)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 2-i, decision.Remaining)
+		assert.Zero(t, decision.RetryAfter)
+	}
+
+	// Rejected requests do not occupy the window
+	for i := 0; i < 5; i++ {
+		decision, err := limiter.AllowRequest(ctx, workspaceID, 3, 2)
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		// The oldest request leaves the 2s window first
+		assert.Positive(t, decision.RetryAfter)
+		assert.LessOrEqual(t, decision.RetryAfter, 2*time.Second)
+	}
+	used, err := limiter.Usage(ctx, workspaceID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, used)
+
+	peek, err := limiter.Peek(ctx, workspaceID, 3, 2)
+	require.NoError(t, err)
+	assert.False(t, peek.Allowed)
+	assert.Equal(t, 0, peek.Remaining)
+	assert.Positive(t, peek.RetryAfter)
+
+	time.Sleep(2100 * time.Millisecond)
+	decision, err := limiter.AllowRequest(ctx, workspaceID, 3, 2)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed, "the window slid past the first requests")
+}
+
+func TestTokenBucket_Integration(t *testing.T) {
+	limiter, workspaceID := newIntegrationLimiter(t, AlgorithmTokenBucket)
+	ctx := context.Background()
+
+	// Full bucket: burst up to the limit
+	for i := 0; i < 4; i++ {
+		decision, err := limiter.AllowRequest(ctx, workspaceID, 4, 2)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	}
+	decision, err := limiter.AllowRequest(ctx, workspaceID, 4, 2)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	// 4 tokens per 2s: the next token is at most 500ms away
+	assert.Positive(t, decision.RetryAfter)
+	assert.LessOrEqual(t, decision.RetryAfter, 500*time.Millisecond)
+
+	used, err := limiter.Usage(ctx, workspaceID, 2)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, used, 3)
+
+	peek, err := limiter.Peek(ctx, workspaceID, 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 4, peek.Limit)
+	assert.LessOrEqual(t, peek.ResetAfter, 2*time.Second)
+
+	// 4 tokens per 2s: one token back after 500ms
+	time.Sleep(600 * time.Millisecond)
+	decision, err = limiter.AllowRequest(ctx, workspaceID, 4, 2)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
@@ -0,0 +1,139 @@
+package ratelimit
+
+import "github.com/redis/go-redis/v9"
+
+// Os scripts usam o relógio do Redis (TIME): todas as instâncias da API medem a janela igual.
+
+// slidingWindowLogScript remove os timestamps fora da janela e só registra a request quando
+// ainda há cota: requests rejeitadas não ocupam a janela.
+// KEYS[1] chave; ARGV[1] limite; ARGV[2] janela (ms); ARGV[3] sufixo único do membro.
+// Retorna {allowed (0/1), remaining, retry_after_ms, reset_ms}: retry_after é quando sai da janela
+// o timestamp que libera a próxima vaga; reset, quando sai o mais recente.
+var slidingWindowLogScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, now .. '-' .. ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+
+local retry = 0
+local reset = 0
+if count > 0 then
+	if allowed == 0 then
+		local freeing = redis.call('ZRANGE', KEYS[1], count - limit, count - limit, 'WITHSCORES')
+		retry = math.max(1, tonumber(freeing[2]) + window - now)
+	end
+	local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+	reset = math.max(0, tonumber(newest[2]) + window - now)
+end
+redis.call('PEXPIRE', KEYS[1], window)
+return {allowed, limit - count, retry, reset}
+`)
+
+// slidingWindowLogPeekScript igual a slidingWindowLogScript sem registrar a request (HEAD
+// /rate-limit). KEYS[1] chave; ARGV[1] limite; ARGV[2] janela (ms).
+// Retorna {remaining, retry_after_ms, reset_ms}.
+var slidingWindowLogPeekScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local min = '(' .. (now - window)
+
+local count = redis.call('ZCOUNT', KEYS[1], min, '+inf')
+local retry = 0
+local reset = 0
+if count > 0 then
+	if count >= limit then
+		local freeing = redis.call('ZRANGEBYSCORE', KEYS[1], min, '+inf', 'WITHSCORES', 'LIMIT', count - limit, 1)
+		retry = math.max(1, tonumber(freeing[2]) + window - now)
+	end
+	local newest = redis.call('ZREVRANGEBYSCORE', KEYS[1], '+inf', min, 'WITHSCORES', 'LIMIT', 0, 1)
+	reset = math.max(0, tonumber(newest[2]) + window - now)
+end
+return {limit - count, retry, reset}
+`)
+
+// tokenBucketScript recarrega o balde (capacidade ARGV[1], recarga completa em ARGV[2] ms) desde
+// a última request e consome uma ficha. Guarda a capacidade para tokenBucketUsageScript.
+// Retorna {allowed (0/1), fichas restantes, retry_after_ms (até a próxima ficha), reset_ms (até
+// o balde encher)}.
+var tokenBucketScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = capacity / window
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now, 'cap', capacity)
+redis.call('PEXPIRE', KEYS[1], window * 2)
+
+local retry = 0
+if allowed == 0 then
+	retry = math.max(1, math.ceil((1 - tokens) / rate))
+end
+return {allowed, math.floor(tokens), retry, math.ceil((capacity - tokens) / rate)}
+`)
+
+// tokenBucketPeekScript recarga de tokenBucketScript sem consumir nem gravar (HEAD /rate-limit).
+// KEYS[1] chave; ARGV[1] capacidade; ARGV[2] janela (ms).
+// Retorna {fichas restantes, retry_after_ms, reset_ms}; balde inexistente está cheio.
+var tokenBucketPeekScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = capacity / window
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	return {capacity, 0, 0}
+end
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local retry = 0
+if tokens < 1 then
+	retry = math.max(1, math.ceil((1 - tokens) / rate))
+end
+return {math.floor(tokens), retry, math.ceil((capacity - tokens) / rate)}
+`)
+
+// tokenBucketUsageScript fichas consumidas (capacidade - fichas após a recarga), sem consumir.
+// KEYS[1] chave; ARGV[1] janela (ms). Balde inexistente = 0.
+var tokenBucketUsageScript = redis.NewScript(`
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts', 'cap')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+local capacity = tonumber(state[3])
+if tokens == nil or ts == nil or capacity == nil then
+	return 0
+end
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * capacity / tonumber(ARGV[1]))
+return math.floor(capacity - tokens)
+`)
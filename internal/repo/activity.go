@@ -2,20 +2,22 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
 	"linkko-api/internal/repo/sqlc"
 
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type ActivityRepository struct {
-	pool    *pgxpool.Pool
+	pool    database.DB
 	queries *sqlc.Queries
 }
 
-func NewActivityRepository(pool *pgxpool.Pool) *ActivityRepository {
+func NewActivityRepository(pool database.DB) *ActivityRepository {
 	return &ActivityRepository{
 		pool:    pool,
 		queries: sqlc.New(pool),
@@ -44,6 +46,11 @@ func (r *ActivityRepository) CreateActivity(ctx context.Context, a *domain.Activ
 }
 
 func (r *ActivityRepository) CreateNote(ctx context.Context, n *domain.Note) (*domain.Note, error) {
+	body, err := json.Marshal(n.Body)
+	if err != nil {
+		return nil, fmt.Errorf("encode note body: %w", err)
+	}
+
 	params := sqlc.CreateNoteParams{
 		ID:          n.ID,
 		WorkspaceId: n.WorkspaceID,
@@ -53,6 +60,8 @@ func (r *ActivityRepository) CreateNote(ctx context.Context, n *domain.Note) (*d
 		Content:     n.Content,
 		IsPinned:    n.IsPinned,
 		UserId:      n.UserID,
+		Body:        body,
+		Visibility:  string(n.Visibility),
 	}
 
 	row, err := r.queries.CreateNote(ctx, params)
@@ -85,24 +94,182 @@ func (r *ActivityRepository) CreateCall(ctx context.Context, c *domain.Call) (*d
 	return r.sqlcCallToDomain(&row), nil
 }
 
-func (r *ActivityRepository) List(ctx context.Context, workspaceID string, contactID, companyID, dealID *string) ([]domain.Activity, error) {
-	rows, err := r.queries.ListActivities(ctx, sqlc.ListActivitiesParams{
-		WorkspaceId: workspaceID,
-		ContactId:   contactID,
-		CompanyId:   companyID,
-		DealId:      dealID,
-	})
+func (r *ActivityRepository) CreateMeeting(ctx context.Context, m *domain.Meeting) (*domain.Meeting, error) {
+	params := sqlc.CreateMeetingParams{
+		ID:          m.ID,
+		WorkspaceId: m.WorkspaceID,
+		Title:       m.Title,
+		Description: m.Description,
+		MeetingType: sqlc.MeetingType(m.MeetingType),
+		StartTime:   pgtype.Timestamp{Time: m.StartTime, Valid: true},
+		EndTime:     pgtype.Timestamp{Time: m.EndTime, Valid: true},
+		Location:    m.Location,
+		MeetingUrl:  m.MeetingURL,
+		UserId:      m.UserID,
+	}
+
+	row, err := r.queries.CreateMeeting(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcMeetingToDomain(&row), nil
+}
+
+// List retorna a timeline; viewer (nil para admins) oculta as notas que o usuário não enxerga.
+// participantID restringe às chamadas/reuniões de que o usuário ou contato participou.
+func (r *ActivityRepository) List(ctx context.Context, workspaceID string, contactID, companyID, dealID, participantID *string, activityType *domain.ActivityType, viewer *string) ([]domain.Activity, error) {
+	rows, err := r.queries.ListActivities(ctx, listActivitiesParams(workspaceID, contactID, companyID, dealID, participantID, activityType, viewer))
 	if err != nil {
 		return nil, err
 	}
 
 	activities := make([]domain.Activity, len(rows))
-	for i, row := range rows {
-		activities[i] = *r.sqlcActivityToDomain(&row)
+	for i := range rows {
+		activities[i] = *r.listActivityRowToDomain(&rows[i])
 	}
 	return activities, nil
 }
 
+// Each percorre a mesma timeline de List linha a linha (cursor do pgx), sem montar o slice:
+// para o streaming NDJSON de timelines com milhões de atividades. Um erro de emit interrompe
+// a iteração e é devolvido.
+func (r *ActivityRepository) Each(ctx context.Context, workspaceID string, contactID, companyID, dealID, participantID *string, activityType *domain.ActivityType, viewer *string, emit func(*domain.Activity) error) error {
+	// sqlc não gera iteradores: mesma query e mesmo Scan de ListActivities, direto no pool
+	arg := listActivitiesParams(workspaceID, contactID, companyID, dealID, participantID, activityType, viewer)
+	rows, err := r.pool.Query(ctx, sqlc.ListActivities,
+		arg.WorkspaceId,
+		arg.ContactId,
+		arg.CompanyId,
+		arg.DealId,
+		arg.ViewerId,
+		arg.ParticipantId,
+		arg.ActivityType,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row sqlc.ListActivitiesRow
+		if err := rows.Scan(
+			&row.ID,
+			&row.WorkspaceId,
+			&row.CompanyId,
+			&row.ContactId,
+			&row.DealId,
+			&row.ActivityType,
+			&row.ActivityId,
+			&row.UserId,
+			&row.Metadata,
+			&row.CreatedAt,
+			&row.PinnedAt,
+		); err != nil {
+			return err
+		}
+		if err := emit(r.listActivityRowToDomain(&row)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func listActivitiesParams(workspaceID string, contactID, companyID, dealID, participantID *string, activityType *domain.ActivityType, viewer *string) sqlc.ListActivitiesParams {
+	var typeFilter *string
+	if activityType != nil {
+		t := string(*activityType)
+		typeFilter = &t
+	}
+
+	return sqlc.ListActivitiesParams{
+		WorkspaceId:   workspaceID,
+		ContactId:     contactID,
+		CompanyId:     companyID,
+		DealId:        dealID,
+		ViewerId:      viewer,
+		ParticipantId: participantID,
+		ActivityType:  typeFilter,
+	}
+}
+
+func (r *ActivityRepository) listActivityRowToDomain(row *sqlc.ListActivitiesRow) *domain.Activity {
+	a := r.sqlcActivityToDomain(&sqlc.Activity{
+		ID:           row.ID,
+		WorkspaceId:  row.WorkspaceId,
+		CompanyId:    row.CompanyId,
+		ContactId:    row.ContactId,
+		DealId:       row.DealId,
+		ActivityType: row.ActivityType,
+		ActivityId:   row.ActivityId,
+		UserId:       row.UserId,
+		Metadata:     row.Metadata,
+		CreatedAt:    row.CreatedAt,
+	})
+	a.PinnedAt = toTimePtr(row.PinnedAt)
+	return a
+}
+
+// Digest retorna contagens por tipo e as últimas atividades de cada tipo desde params.Since.
+func (r *ActivityRepository) Digest(ctx context.Context, params domain.TimelineDigestParams) (*domain.TimelineDigest, error) {
+	var since pgtype.Timestamp
+	if params.Since != nil {
+		since = pgtype.Timestamp{Time: params.Since.UTC(), Valid: true}
+	}
+
+	countRows, err := r.queries.CountActivitiesByTypeSince(ctx, sqlc.CountActivitiesByTypeSinceParams{
+		WorkspaceId: params.WorkspaceID,
+		Since:       since,
+		ContactId:   params.ContactID,
+		CompanyId:   params.CompanyID,
+		DealId:      params.DealID,
+		ViewerId:    params.Viewer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]domain.TimelineDigestCount, len(countRows))
+	for i, row := range countRows {
+		counts[i] = domain.TimelineDigestCount{
+			Type:     domain.ActivityType(row.ActivityType),
+			Count:    row.Total,
+			LatestAt: row.LatestAt.Time,
+		}
+	}
+
+	var items []domain.TimelineDigestItem
+	if len(counts) > 0 {
+		itemRows, err := r.queries.ListLatestActivitiesByTypeSince(ctx, sqlc.ListLatestActivitiesByTypeSinceParams{
+			WorkspaceId: params.WorkspaceID,
+			Since:       since,
+			ContactId:   params.ContactID,
+			CompanyId:   params.CompanyID,
+			DealId:      params.DealID,
+			ViewerId:    params.Viewer,
+			PerType:     int32(params.PerType),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items = make([]domain.TimelineDigestItem, len(itemRows))
+		for i, row := range itemRows {
+			items[i] = domain.TimelineDigestItem{
+				ID:         row.ID,
+				Type:       domain.ActivityType(row.ActivityType),
+				ActivityID: row.ActivityId,
+				ContactID:  row.ContactId,
+				CompanyID:  row.CompanyId,
+				DealID:     row.DealId,
+				UserID:     row.UserId,
+				CreatedAt:  row.CreatedAt.Time,
+			}
+		}
+	}
+
+	return domain.NewTimelineDigest(params.Since, counts, items), nil
+}
+
 // Mappers
 func (r *ActivityRepository) sqlcActivityToDomain(row *sqlc.Activity) *domain.Activity {
 	return &domain.Activity{
@@ -132,6 +299,26 @@ func (r *ActivityRepository) sqlcNoteToDomain(row *sqlc.Note) *domain.Note {
 		CreatedAt:   row.CreatedAt.Time,
 		UpdatedAt:   row.UpdatedAt.Time,
 		DeletedAt:   toTimePtr(row.DeletedAt),
+		Body:        decodeNoteBody(row.Body, row.Content),
+		PinnedAt:    toTimePtr(row.PinnedAt),
+		PinnedByID:  row.PinnedById,
+		Visibility:  domain.Visibility(row.Visibility),
+	}
+}
+
+func (r *ActivityRepository) sqlcMeetingToDomain(row *sqlc.Meeting) *domain.Meeting {
+	return &domain.Meeting{
+		ID:          row.ID,
+		WorkspaceID: row.WorkspaceId,
+		Title:       row.Title,
+		Description: row.Description,
+		MeetingType: domain.MeetingType(row.MeetingType),
+		StartTime:   row.StartTime.Time,
+		EndTime:     row.EndTime.Time,
+		Location:    row.Location,
+		MeetingURL:  row.MeetingUrl,
+		UserID:      row.UserId,
+		CreatedAt:   row.CreatedAt.Time,
 	}
 }
 
@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrActivityParticipantInvalid participante que não é membro do workspace nem contato ativo dele.
+var ErrActivityParticipantInvalid = apperr.Unprocessable(apperr.CodeValidationError,
+	"activity participant is not a workspace member or contact", "participants must be members or contacts of this workspace")
+
+const activityParticipantColumns = `id, "activityType", "activityId", "userId", "contactId", role, "createdAt"`
+
+// WithTx retorna uma instância do repositório vinculada a uma transação.
+func (r *ActivityRepository) WithTx(tx pgx.Tx) *ActivityRepository {
+	return &ActivityRepository{
+		pool:    tx,
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+// BeginTx inicia uma transação no pool.
+func (r *ActivityRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// AddParticipants grava os participantes da chamada/reunião activityID. Usuários precisam ser
+// membros do workspace e contatos precisam existir nele (não excluídos); caso contrário nada é
+// gravado e o erro é ErrActivityParticipantInvalid. Use dentro da transação que cria o registro.
+func (r *ActivityRepository) AddParticipants(ctx context.Context, workspaceID string, activityType domain.ActivityType, activityID string, in []domain.ActivityParticipantInput) ([]domain.ActivityParticipant, error) {
+	if len(in) == 0 {
+		return []domain.ActivityParticipant{}, nil
+	}
+
+	ids := make([]string, len(in))
+	userIDs := make([]*string, len(in))
+	contactIDs := make([]*string, len(in))
+	roles := make([]string, len(in))
+	for i, p := range in {
+		participantID, err := id.New(id.ActivityParticipant)
+		if err != nil {
+			return nil, err
+		}
+		ids[i], userIDs[i], contactIDs[i], roles[i] = participantID, p.UserID, p.ContactID, string(p.Role)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		INSERT INTO public."ActivityParticipant" (id, "workspaceId", "activityType", "activityId", "userId", "contactId", role)
+		SELECT p.id, $1, $2, $3, p.user_id, p.contact_id, p.role
+		FROM unnest($4::TEXT[], $5::TEXT[], $6::TEXT[], $7::TEXT[]) WITH ORDINALITY AS p(id, user_id, contact_id, role, ord)
+		WHERE (p.user_id IS NULL OR EXISTS (
+		          SELECT 1 FROM public."WorkspaceMember" m WHERE m."workspaceId" = $1 AND m."userId" = p.user_id))
+		  AND (p.contact_id IS NULL OR EXISTS (
+		          SELECT 1 FROM public."Contact" c WHERE c."workspaceId" = $1 AND c.id = p.contact_id AND c."deletedAt" IS NULL))
+		ORDER BY p.ord
+		RETURNING `+activityParticipantColumns,
+		workspaceID, string(activityType), activityID, ids, userIDs, contactIDs, roles,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert activity participants: %w", err)
+	}
+	participants, err := collectActivityParticipants(rows)
+	if err != nil {
+		return nil, fmt.Errorf("insert activity participants: %w", err)
+	}
+	if len(participants) != len(in) {
+		return nil, ErrActivityParticipantInvalid
+	}
+	return participants, nil
+}
+
+// ListParticipantsForActivities retorna os participantes das chamadas/reuniões informadas,
+// agrupados por activityId (o autor primeiro, depois a ordem informada na criação).
+func (r *ActivityRepository) ListParticipantsForActivities(ctx context.Context, workspaceID string, activityIDs []string) (map[string][]domain.ActivityParticipant, error) {
+	out := make(map[string][]domain.ActivityParticipant, len(activityIDs))
+	if len(activityIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+activityParticipantColumns+`
+		FROM public."ActivityParticipant"
+		WHERE "workspaceId" = $1 AND "activityId" = ANY($2::TEXT[])
+		ORDER BY "activityId", id`,
+		workspaceID, activityIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query activity participants: %w", err)
+	}
+	participants, err := collectActivityParticipants(rows)
+	if err != nil {
+		return nil, fmt.Errorf("query activity participants: %w", err)
+	}
+	for _, p := range participants {
+		out[p.ActivityID] = append(out[p.ActivityID], p)
+	}
+	return out, nil
+}
+
+func collectActivityParticipants(rows pgx.Rows) ([]domain.ActivityParticipant, error) {
+	defer rows.Close()
+	participants := []domain.ActivityParticipant{}
+	for rows.Next() {
+		var p domain.ActivityParticipant
+		if err := rows.Scan(&p.ID, &p.ActivityType, &p.ActivityID, &p.UserID, &p.ContactID, &p.Role, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		participants = append(participants, p)
+	}
+	return participants, rows.Err()
+}
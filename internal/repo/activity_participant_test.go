@@ -0,0 +1,102 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestActivityRepository_Participants_Integration
+func TestActivityRepository_Participants_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	activities := repo.NewActivityRepository(pool)
+
+	contact := f.Contact()
+	guest := f.Contact()
+	member := f.Member(domain.RoleUser)
+
+	// Entrada da timeline de uma reunião; o registro "Meeting" não é necessário aqui
+	meeting := func(t *testing.T) string {
+		t.Helper()
+		meetingID, err := id.New(id.Meeting)
+		require.NoError(t, err)
+		activityID, err := id.New(id.Activity)
+		require.NoError(t, err)
+		_, err = activities.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: f.WorkspaceID,
+			ContactID:   &contact.ID,
+			Type:        domain.ActivityTypeMeeting,
+			ActivityID:  &meetingID,
+			UserID:      f.UserID,
+			Metadata:    []byte(`{}`),
+		})
+		require.NoError(t, err)
+		return meetingID
+	}
+
+	withMember := meeting(t)
+	withGuest := meeting(t)
+	rejected := meeting(t)
+
+	added, err := activities.AddParticipants(ctx, f.WorkspaceID, domain.ActivityTypeMeeting, withMember,
+		domain.NormalizeActivityParticipants([]domain.ActivityParticipantInput{{UserID: &member}}, f.UserID))
+	require.NoError(t, err)
+	require.Len(t, added, 2)
+	assert.Equal(t, f.UserID, *added[0].UserID)
+	assert.Equal(t, domain.ActivityParticipantOrganizer, added[0].Role)
+	assert.Equal(t, member, *added[1].UserID)
+	assert.Equal(t, domain.ActivityParticipantAttendee, added[1].Role)
+
+	_, err = activities.AddParticipants(ctx, f.WorkspaceID, domain.ActivityTypeMeeting, withGuest,
+		[]domain.ActivityParticipantInput{{ContactID: &guest.ID, Role: domain.ActivityParticipantOptional}})
+	require.NoError(t, err)
+
+	t.Run("outsiders are rejected and nothing is written", func(t *testing.T) {
+		other := factory.New(t, pool)
+		foreignContact := other.Contact()
+
+		for _, p := range []domain.ActivityParticipantInput{
+			{UserID: &other.UserID, Role: domain.ActivityParticipantAttendee},
+			{ContactID: &foreignContact.ID, Role: domain.ActivityParticipantAttendee},
+		} {
+			_, err := activities.AddParticipants(ctx, f.WorkspaceID, domain.ActivityTypeMeeting, rejected,
+				[]domain.ActivityParticipantInput{{ContactID: &guest.ID, Role: domain.ActivityParticipantAttendee}, p})
+			assert.ErrorIs(t, err, repo.ErrActivityParticipantInvalid)
+		}
+
+		byActivity, err := activities.ListParticipantsForActivities(ctx, f.WorkspaceID, []string{rejected})
+		require.NoError(t, err)
+		assert.Empty(t, byActivity[rejected])
+	})
+
+	t.Run("participants grouped by activity", func(t *testing.T) {
+		byActivity, err := activities.ListParticipantsForActivities(ctx, f.WorkspaceID, []string{withMember, withGuest})
+		require.NoError(t, err)
+		assert.Len(t, byActivity[withMember], 2)
+		require.Len(t, byActivity[withGuest], 1)
+		assert.Equal(t, guest.ID, *byActivity[withGuest][0].ContactID)
+	})
+
+	t.Run("timeline filtered by participant", func(t *testing.T) {
+		meetingType := domain.ActivityTypeMeeting
+		for participant, want := range map[string]string{member: withMember, guest.ID: withGuest} {
+			listed, err := activities.List(ctx, f.WorkspaceID, nil, nil, nil, &participant, &meetingType, nil)
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			assert.Equal(t, want, *listed[0].ActivityID)
+		}
+
+		all, err := activities.List(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, &meetingType, nil)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+}
@@ -0,0 +1,60 @@
+package repo_test
+
+import (
+	"errors"
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestActivityRepository_Each_Integration
+func TestActivityRepository_Each_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	activities := repo.NewActivityRepository(pool)
+
+	contact := f.Contact()
+	for range 3 {
+		activityID, err := id.New(id.Activity)
+		require.NoError(t, err)
+		_, err = activities.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: f.WorkspaceID,
+			ContactID:   &contact.ID,
+			Type:        domain.ActivityTypeEmail,
+			UserID:      f.UserID,
+			Metadata:    []byte(`{}`),
+		})
+		require.NoError(t, err)
+	}
+
+	listed, err := activities.List(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+
+	// Each percorre as mesmas linhas, na mesma ordem
+	var streamed []domain.Activity
+	err = activities.Each(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, nil, nil, func(a *domain.Activity) error {
+		streamed = append(streamed, *a)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, listed, streamed)
+
+	// Um erro de emit interrompe a iteração
+	stop := errors.New("client gone")
+	calls := 0
+	err = activities.Each(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, nil, nil, func(a *domain.Activity) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
@@ -5,20 +5,26 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"linkko-api/internal/auth"
+	"linkko-api/internal/database"
+	"linkko-api/internal/observability/requestid"
+	"linkko-api/internal/telemetry"
 )
 
 // AuditRepo handles audit log storage
 type AuditRepo struct {
-	pool *pgxpool.Pool
+	pool database.DB
 }
 
 // NewAuditRepo creates a new AuditRepo
-func NewAuditRepo(pool *pgxpool.Pool) *AuditRepo {
+func NewAuditRepo(pool database.DB) *AuditRepo {
 	return &AuditRepo{pool: pool}
 }
 
-// LogAction logs an action to the audit log
+// LogAction logs an action to the audit log.
+// Under an impersonation token the admin is recorded in impersonator_id and metadata.impersonatedBy;
+// when actorID is the authenticated actor, its type (user, service_account) goes to actor_type.
+// The request ID and the OTel trace ID are read from ctx into request_id and trace_id.
 func (r *AuditRepo) LogAction(
 	ctx context.Context,
 	workspaceID, actorID, action, resourceType string,
@@ -29,6 +35,25 @@ func (r *AuditRepo) LogAction(
 	var metadataJSON []byte
 	var err error
 
+	var impersonatorID *string
+	if id, ok := auth.ImpersonatorIDFromContext(ctx); ok {
+		impersonatorID = &id
+		flagged := make(map[string]interface{}, len(metadata)+1)
+		for k, v := range metadata {
+			flagged[k] = v
+		}
+		flagged["impersonatedBy"] = id
+		metadata = flagged
+	}
+
+	var actorType *string
+	if t, ok := auth.ActorTypeFromContext(ctx, actorID); ok {
+		actorType = &t
+	}
+
+	requestID := nullIfEmpty(requestid.GetRequestID(ctx))
+	traceID := nullIfEmpty(telemetry.TraceIDFromContext(ctx))
+
 	if metadata != nil {
 		metadataJSON, err = json.Marshal(metadata)
 		if err != nil {
@@ -39,13 +64,15 @@ func (r *AuditRepo) LogAction(
 	query := `
 		INSERT INTO audit_log (
 			workspace_id, actor_id, action, resource_type, resource_id,
-			metadata, ip_address, user_agent
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			metadata, ip_address, user_agent, impersonator_id, actor_type,
+			request_id, trace_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err = r.pool.Exec(ctx, query,
 		workspaceID, actorID, action, resourceType, resourceID,
-		metadataJSON, ipAddress, userAgent,
+		metadataJSON, ipAddress, userAgent, impersonatorID, actorType,
+		requestID, traceID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to log action: %w", err)
@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"linkko-api/internal/observability/requestid"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// execRecorder guarda os argumentos do último Exec; o AuditRepo não faz outras chamadas.
+type execRecorder struct {
+	args []any
+}
+
+func (db *execRecorder) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db.args = args
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func (db *execRecorder) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (db *execRecorder) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return nil
+}
+
+func (db *execRecorder) Begin(ctx context.Context) (pgx.Tx, error) {
+	return nil, errors.New("unexpected transaction")
+}
+
+func TestAuditRepo_LogAction_Correlation(t *testing.T) {
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	traced := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		wantRequestID *string
+		wantTraceID   *string
+	}{
+		{"outside a request", context.Background(), nil, nil},
+		{"request without a sampled trace", requestid.SetRequestID(context.Background(), "req_1"), strPtr("req_1"), nil},
+		{"request with trace", requestid.SetRequestID(traced, "req_1"), strPtr("req_1"), strPtr("4bf92f3577b34da6a3ce929d0e0e4736")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &execRecorder{}
+			err := NewAuditRepo(db).LogAction(tt.ctx, "ws_1", "usr_1", "update", "contact", nil, nil, "", "")
+			require.NoError(t, err)
+			require.Len(t, db.args, 12)
+			assert.Equal(t, tt.wantRequestID, db.args[10], "request_id")
+			assert.Equal(t, tt.wantTraceID, db.args[11], "trace_id")
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
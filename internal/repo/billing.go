@@ -0,0 +1,210 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrBillingWorkspaceNotFound = apperr.NotFound("workspace not found", "workspace not found")
+)
+
+// BillingRepository persiste o estado da assinatura dos workspaces e os eventos do Stripe já aplicados.
+type BillingRepository struct {
+	pool database.DB
+	data database.DB // contatos e negócios (shard do workspace); padrão: pool
+}
+
+func NewBillingRepository(pool database.DB) *BillingRepository {
+	return &BillingRepository{pool: pool, data: pool}
+}
+
+// WithDataDB conta contatos e negócios no banco de dados dos workspaces (database.ShardRouter);
+// assinatura, eventos do Stripe e membros continuam em pool.
+func (r *BillingRepository) WithDataDB(data database.DB) *BillingRepository {
+	r.data = data
+	return r
+}
+
+// GetSubscription retorna o estado da assinatura do workspace.
+func (r *BillingRepository) GetSubscription(ctx context.Context, workspaceID string) (*domain.WorkspaceSubscription, error) {
+	query := `
+		SELECT id, "billingPlan", "billingStatus", stripe_customer_id, stripe_subscription_id, stripe_price_id,
+		       stripe_current_period_end, stripe_cancel_at_period_end, "billingUpdatedAt"
+		FROM public."Workspace"
+		WHERE id = $1`
+
+	var s domain.WorkspaceSubscription
+	err := r.pool.QueryRow(ctx, query, workspaceID).Scan(&s.WorkspaceID, &s.Plan, &s.Status,
+		&s.StripeCustomerID, &s.StripeSubscriptionID, &s.StripePriceID,
+		&s.CurrentPeriodEnd, &s.CancelAtPeriodEnd, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBillingWorkspaceNotFound
+		}
+		return nil, fmt.Errorf("query workspace subscription: %w", err)
+	}
+	return &s, nil
+}
+
+// Usage conta os registros ativos dos recursos com quota (contatos e negócios fora da lixeira, membros).
+func (r *BillingRepository) Usage(ctx context.Context, workspaceID string) (*domain.BillingUsage, error) {
+	query := `
+		SELECT (SELECT COUNT(*) FROM public."Contact" WHERE "workspaceId" = $1 AND "deletedAt" IS NULL),
+		       (SELECT COUNT(*) FROM public."Deal" WHERE "workspaceId" = $1 AND "deletedAt" IS NULL)`
+
+	var u domain.BillingUsage
+	if err := r.data.QueryRow(ctx, query, workspaceID).Scan(&u.Contacts, &u.Deals); err != nil {
+		return nil, fmt.Errorf("query billing usage: %w", err)
+	}
+	members, err := r.Count(ctx, workspaceID, domain.QuotaMembers)
+	if err != nil {
+		return nil, err
+	}
+	u.Members = members
+	return &u, nil
+}
+
+// Count conta os registros ativos de um recurso com quota.
+func (r *BillingRepository) Count(ctx context.Context, workspaceID string, resource domain.QuotaResource) (int64, error) {
+	var query string
+	db := r.data
+	switch resource {
+	case domain.QuotaContacts:
+		query = `SELECT COUNT(*) FROM public."Contact" WHERE "workspaceId" = $1 AND "deletedAt" IS NULL`
+	case domain.QuotaDeals:
+		query = `SELECT COUNT(*) FROM public."Deal" WHERE "workspaceId" = $1 AND "deletedAt" IS NULL`
+	case domain.QuotaMembers:
+		query = `SELECT COUNT(*) FROM public."WorkspaceMember" WHERE "workspaceId" = $1`
+		db = r.pool
+	default:
+		return 0, fmt.Errorf("unknown quota resource %q", resource)
+	}
+
+	var n int64
+	if err := db.QueryRow(ctx, query, workspaceID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count %s: %w", resource, err)
+	}
+	return n, nil
+}
+
+// WorkspaceByCustomer resolve o workspace pelo customer do Stripe ("" se nenhum).
+func (r *BillingRepository) WorkspaceByCustomer(ctx context.Context, customerID string) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx,
+		`SELECT id FROM public."Workspace" WHERE stripe_customer_id = $1 ORDER BY "createdAt" LIMIT 1`,
+		customerID,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("query workspace by stripe customer: %w", err)
+	}
+	return id, nil
+}
+
+// ApplySubscription registra o evento e grava o estado da assinatura na mesma transação.
+// Retorna false sem alterar nada se o evento já foi aplicado (reentrega do Stripe). Eventos
+// mais antigos que o último aplicado são registrados mas não sobrescrevem o estado.
+// sub.Plan vazio mantém o plano atual (price sem mapeamento).
+func (r *BillingRepository) ApplySubscription(ctx context.Context, eventID, eventType string, createdAt time.Time, sub *domain.WorkspaceSubscription) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	recorded, err := recordStripeEvent(ctx, tx, eventID, eventType, createdAt, sub.WorkspaceID)
+	if err != nil || !recorded {
+		return false, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE public."Workspace"
+		SET "billingPlan" = COALESCE(NULLIF($2, ''), "billingPlan"),
+		    "billingStatus" = $3,
+		    stripe_customer_id = COALESCE($4, stripe_customer_id),
+		    stripe_subscription_id = $5,
+		    stripe_price_id = $6,
+		    stripe_current_period_end = $7,
+		    stripe_cancel_at_period_end = $8,
+		    "billingUpdatedAt" = $9,
+		    "updatedAt" = NOW()
+		WHERE id = $1 AND ("billingUpdatedAt" IS NULL OR "billingUpdatedAt" <= $9)`,
+		sub.WorkspaceID, string(sub.Plan), string(sub.Status), sub.StripeCustomerID, sub.StripeSubscriptionID,
+		sub.StripePriceID, sub.CurrentPeriodEnd, sub.CancelAtPeriodEnd, createdAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("update workspace subscription: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// LinkCustomer registra o evento de checkout e associa o customer e a assinatura ao workspace.
+// Plano e status chegam pelos eventos customer.subscription.*.
+func (r *BillingRepository) LinkCustomer(ctx context.Context, eventID, eventType string, createdAt time.Time, workspaceID, customerID string, subscriptionID *string) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	recorded, err := recordStripeEvent(ctx, tx, eventID, eventType, createdAt, workspaceID)
+	if err != nil || !recorded {
+		return false, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE public."Workspace"
+		SET stripe_customer_id = $2,
+		    stripe_subscription_id = COALESCE($3, stripe_subscription_id),
+		    "updatedAt" = NOW()
+		WHERE id = $1`,
+		workspaceID, customerID, subscriptionID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("link stripe customer: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// recordStripeEvent insere o evento; false = já registrado.
+func recordStripeEvent(ctx context.Context, tx pgx.Tx, eventID, eventType string, createdAt time.Time, workspaceID string) (bool, error) {
+	var id string
+	err := tx.QueryRow(ctx, `
+		INSERT INTO public."StripeEvent" (id, type, "workspaceId", "createdAt")
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING id`,
+		eventID, eventType, workspaceID, createdAt,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return false, ErrBillingWorkspaceNotFound
+		}
+		return false, fmt.Errorf("insert stripe event: %w", err)
+	}
+	return true, nil
+}
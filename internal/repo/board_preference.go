@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BoardPreferenceRepository persiste a personalização do board de negócios por usuário.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type BoardPreferenceRepository struct {
+	pool database.DB
+}
+
+func NewBoardPreferenceRepository(pool database.DB) *BoardPreferenceRepository {
+	return &BoardPreferenceRepository{pool: pool}
+}
+
+const boardPreferenceColumns = `"pipelineId", "stageOrder", "collapsedStageIds", "hiddenStageIds", "updatedAt"`
+
+// Get retorna as preferências do usuário no pipeline (vazias, com UpdatedAt nil, quando ele
+// nunca configurou).
+func (r *BoardPreferenceRepository) Get(ctx context.Context, workspaceID, userID, pipelineID string) (*domain.BoardPreference, error) {
+	query := `
+		SELECT ` + boardPreferenceColumns + `
+		FROM public."UserBoardPreference"
+		WHERE "workspaceId" = $1 AND "userId" = $2 AND "pipelineId" = $3`
+
+	p, err := scanBoardPreference(r.pool.QueryRow(ctx, query, workspaceID, userID, pipelineID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.BoardPreference{PipelineID: pipelineID}, nil
+		}
+		return nil, fmt.Errorf("query board preference: %w", err)
+	}
+	return p, nil
+}
+
+// Upsert grava (substitui) as preferências do usuário no pipeline.
+func (r *BoardPreferenceRepository) Upsert(ctx context.Context, workspaceID, userID string, p *domain.BoardPreference) (*domain.BoardPreference, error) {
+	query := `
+		INSERT INTO public."UserBoardPreference" ("workspaceId", "userId", "pipelineId", "stageOrder", "collapsedStageIds", "hiddenStageIds")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ("workspaceId", "userId", "pipelineId") DO UPDATE
+		SET "stageOrder" = EXCLUDED."stageOrder",
+		    "collapsedStageIds" = EXCLUDED."collapsedStageIds",
+		    "hiddenStageIds" = EXCLUDED."hiddenStageIds",
+		    "updatedAt" = NOW()
+		RETURNING ` + boardPreferenceColumns
+
+	saved, err := scanBoardPreference(r.pool.QueryRow(ctx, query,
+		workspaceID, userID, p.PipelineID, p.StageOrder, p.CollapsedStageIDs, p.HiddenStageIDs,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("upsert board preference: %w", err)
+	}
+	return saved, nil
+}
+
+func scanBoardPreference(row pgx.Row) (*domain.BoardPreference, error) {
+	var p domain.BoardPreference
+	var updatedAt time.Time
+	if err := row.Scan(&p.PipelineID, &p.StageOrder, &p.CollapsedStageIDs, &p.HiddenStageIDs, &updatedAt); err != nil {
+		return nil, err
+	}
+	p.UpdatedAt = &updatedAt
+	return &p, nil
+}
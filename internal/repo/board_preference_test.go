@@ -0,0 +1,62 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestBoardPreferenceRepository_Integration
+func TestBoardPreferenceRepository_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	prefs := repo.NewBoardPreferenceRepository(pool)
+
+	pipeline := f.Pipeline()
+	lead, proposal, closing := pipeline.Stages[0].ID, pipeline.Stages[1].ID, pipeline.Stages[2].ID
+	member := f.Member(domain.RoleUser)
+
+	t.Run("empty until the user configures", func(t *testing.T) {
+		got, err := prefs.Get(ctx, f.WorkspaceID, member, pipeline.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.BoardPreference{PipelineID: pipeline.ID}, got)
+	})
+
+	t.Run("upsert replaces the preferences", func(t *testing.T) {
+		saved, err := prefs.Upsert(ctx, f.WorkspaceID, member, &domain.BoardPreference{
+			PipelineID:        pipeline.ID,
+			StageOrder:        []string{closing, lead, proposal},
+			CollapsedStageIDs: []string{lead},
+			HiddenStageIDs:    []string{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, saved.UpdatedAt)
+
+		saved, err = prefs.Upsert(ctx, f.WorkspaceID, member, &domain.BoardPreference{
+			PipelineID:        pipeline.ID,
+			StageOrder:        []string{proposal},
+			CollapsedStageIDs: []string{},
+			HiddenStageIDs:    []string{closing},
+		})
+		require.NoError(t, err)
+
+		got, err := prefs.Get(ctx, f.WorkspaceID, member, pipeline.ID)
+		require.NoError(t, err)
+		assert.Equal(t, saved, got)
+		assert.Equal(t, []string{proposal}, got.StageOrder)
+		assert.Empty(t, got.CollapsedStageIDs)
+		assert.Equal(t, []string{closing}, got.HiddenStageIDs)
+	})
+
+	t.Run("preferences are per user", func(t *testing.T) {
+		got, err := prefs.Get(ctx, f.WorkspaceID, f.UserID, pipeline.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.UpdatedAt)
+	})
+}
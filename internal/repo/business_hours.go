@@ -0,0 +1,219 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrBusinessHoursNotFound = apperr.NotFound("business hours not configured for workspace", "business hours not configured")
+	ErrHolidayNotFound       = apperr.NotFound("holiday not found in workspace", "holiday not found")
+	ErrHolidayDateConflict   = apperr.Conflict("holiday already exists for this date in workspace", "a holiday already exists for this date")
+)
+
+// BusinessHoursRepository persiste o expediente e os feriados do workspace.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type BusinessHoursRepository struct {
+	pool database.DB
+}
+
+func NewBusinessHoursRepository(pool database.DB) *BusinessHoursRepository {
+	return &BusinessHoursRepository{pool: pool}
+}
+
+const holidayColumns = `id, "workspaceId", to_char("date", 'YYYY-MM-DD'), name, "createdById", "createdAt", "updatedAt"`
+
+// Get retorna o expediente do workspace.
+func (r *BusinessHoursRepository) Get(ctx context.Context, workspaceID string) (*domain.BusinessHours, error) {
+	query := `
+		SELECT "workspaceId", timezone, intervals, "updatedById", "createdAt", "updatedAt"
+		FROM public."BusinessHours"
+		WHERE "workspaceId" = $1`
+
+	h, err := scanBusinessHours(r.pool.QueryRow(ctx, query, workspaceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBusinessHoursNotFound
+		}
+		return nil, fmt.Errorf("query business hours: %w", err)
+	}
+	return h, nil
+}
+
+// Upsert define (substitui) o expediente do workspace.
+func (r *BusinessHoursRepository) Upsert(ctx context.Context, workspaceID string, req *domain.SetBusinessHoursRequest, actorID string) (*domain.BusinessHours, error) {
+	intervals, err := json.Marshal(req.Intervals)
+	if err != nil {
+		return nil, fmt.Errorf("marshal business intervals: %w", err)
+	}
+
+	query := `
+		INSERT INTO public."BusinessHours" ("workspaceId", timezone, intervals, "updatedById")
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("workspaceId") DO UPDATE
+		SET timezone = EXCLUDED.timezone, intervals = EXCLUDED.intervals,
+		    "updatedById" = EXCLUDED."updatedById", "updatedAt" = CURRENT_TIMESTAMP
+		RETURNING "workspaceId", timezone, intervals, "updatedById", "createdAt", "updatedAt"`
+
+	h, err := scanBusinessHours(r.pool.QueryRow(ctx, query, workspaceID, req.Timezone, intervals, actorID))
+	if err != nil {
+		return nil, fmt.Errorf("upsert business hours: %w", err)
+	}
+	return h, nil
+}
+
+// Delete remove o expediente: os timers voltam a correr 24x7 (feriados continuam valendo).
+func (r *BusinessHoursRepository) Delete(ctx context.Context, workspaceID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."BusinessHours" WHERE "workspaceId" = $1`, workspaceID)
+	if err != nil {
+		return fmt.Errorf("delete business hours: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrBusinessHoursNotFound
+	}
+	return nil
+}
+
+// ListHolidays retorna os feriados do workspace em ordem de data.
+func (r *BusinessHoursRepository) ListHolidays(ctx context.Context, params domain.ListHolidaysParams) ([]domain.Holiday, error) {
+	query := `
+		SELECT ` + holidayColumns + `
+		FROM public."Holiday"
+		WHERE "workspaceId" = $1
+		  AND ($2::INTEGER IS NULL OR EXTRACT(YEAR FROM "date") = $2)
+		ORDER BY "date"`
+
+	rows, err := r.pool.Query(ctx, query, params.WorkspaceID, params.Year)
+	if err != nil {
+		return nil, fmt.Errorf("list holidays: %w", err)
+	}
+	defer rows.Close()
+
+	holidays := []domain.Holiday{}
+	for rows.Next() {
+		h, err := scanHoliday(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan holiday: %w", err)
+		}
+		holidays = append(holidays, *h)
+	}
+	return holidays, rows.Err()
+}
+
+// GetHoliday retorna um feriado do workspace.
+func (r *BusinessHoursRepository) GetHoliday(ctx context.Context, workspaceID, holidayID string) (*domain.Holiday, error) {
+	query := `
+		SELECT ` + holidayColumns + `
+		FROM public."Holiday"
+		WHERE id = $1 AND "workspaceId" = $2`
+
+	h, err := scanHoliday(r.pool.QueryRow(ctx, query, holidayID, workspaceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrHolidayNotFound
+		}
+		return nil, fmt.Errorf("query holiday: %w", err)
+	}
+	return h, nil
+}
+
+// CreateHoliday insere um feriado. Falha com ErrHolidayDateConflict se a data já existe.
+func (r *BusinessHoursRepository) CreateHoliday(ctx context.Context, h *domain.Holiday) (*domain.Holiday, error) {
+	query := `
+		INSERT INTO public."Holiday" (id, "workspaceId", "date", name, "createdById")
+		VALUES ($1, $2, $3::DATE, $4, $5)
+		RETURNING ` + holidayColumns
+
+	created, err := scanHoliday(r.pool.QueryRow(ctx, query, h.ID, h.WorkspaceID, h.Date, h.Name, h.CreatedByID))
+	if err != nil {
+		if isHolidayDateConflict(err) {
+			return nil, ErrHolidayDateConflict
+		}
+		return nil, fmt.Errorf("insert holiday: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateHoliday aplica a atualização parcial de um feriado.
+func (r *BusinessHoursRepository) UpdateHoliday(ctx context.Context, workspaceID, holidayID string, req *domain.UpdateHolidayRequest) (*domain.Holiday, error) {
+	query := `
+		UPDATE public."Holiday"
+		SET "date" = COALESCE($3::DATE, "date"),
+		    name = COALESCE($4, name),
+		    "updatedAt" = CURRENT_TIMESTAMP
+		WHERE id = $1 AND "workspaceId" = $2
+		RETURNING ` + holidayColumns
+
+	updated, err := scanHoliday(r.pool.QueryRow(ctx, query, holidayID, workspaceID, req.Date, req.Name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrHolidayNotFound
+		}
+		if isHolidayDateConflict(err) {
+			return nil, ErrHolidayDateConflict
+		}
+		return nil, fmt.Errorf("update holiday: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteHoliday remove um feriado (hard delete: não há histórico a preservar).
+func (r *BusinessHoursRepository) DeleteHoliday(ctx context.Context, workspaceID, holidayID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."Holiday" WHERE id = $1 AND "workspaceId" = $2`, holidayID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("delete holiday: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrHolidayNotFound
+	}
+	return nil
+}
+
+// GetCalendar monta o calendário do workspace (nil quando não há expediente nem feriados).
+func (r *BusinessHoursRepository) GetCalendar(ctx context.Context, workspaceID string) (*domain.BusinessCalendar, error) {
+	hours, err := r.Get(ctx, workspaceID)
+	if err != nil && !errors.Is(err, ErrBusinessHoursNotFound) {
+		return nil, err
+	}
+
+	holidays, err := r.ListHolidays(ctx, domain.ListHolidaysParams{WorkspaceID: workspaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewBusinessCalendar(hours, holidays)
+}
+
+func scanBusinessHours(row pgx.Row) (*domain.BusinessHours, error) {
+	var h domain.BusinessHours
+	var intervals []byte
+	if err := row.Scan(&h.WorkspaceID, &h.Timezone, &intervals, &h.UpdatedByID, &h.CreatedAt, &h.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(intervals, &h.Intervals); err != nil {
+		return nil, fmt.Errorf("unmarshal business intervals: %w", err)
+	}
+	return &h, nil
+}
+
+func scanHoliday(row pgx.Row) (*domain.Holiday, error) {
+	var h domain.Holiday
+	if err := row.Scan(&h.ID, &h.WorkspaceID, &h.Date, &h.Name, &h.CreatedByID, &h.CreatedAt, &h.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func isHolidayDateConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "Holiday_workspaceId_date_key"
+}
@@ -4,27 +4,29 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
 	"linkko-api/internal/repo/sqlc"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
-	ErrCompanyNotFound       = errors.New("company not found in workspace")
-	ErrCompanyDomainConflict = errors.New("company with this domain already exists in workspace")
+	ErrCompanyNotFound       = apperr.NotFound("company not found in workspace", "company not found")
+	ErrCompanyDomainConflict = apperr.Conflict("company with this domain already exists in workspace", "company with this domain already exists")
 )
 
 type CompanyRepository struct {
-	pool    *pgxpool.Pool
+	pool    database.DB
 	queries *sqlc.Queries
 }
 
-func NewCompanyRepository(pool *pgxpool.Pool) *CompanyRepository {
+func NewCompanyRepository(pool database.DB) *CompanyRepository {
 	return &CompanyRepository{
 		pool:    pool,
 		queries: sqlc.New(pool),
@@ -33,37 +35,34 @@ func NewCompanyRepository(pool *pgxpool.Pool) *CompanyRepository {
 
 // List retrieves companies for a workspace with optional filters.
 func (r *CompanyRepository) List(ctx context.Context, params domain.ListCompaniesParams) ([]domain.Company, string, error) {
-	// Prepare SQLc params
+	// Prepare SQLc params (nil = sem filtro)
 	sqlcParams := sqlc.ListCompaniesParams{
 		WorkspaceId: params.WorkspaceID,
-		Column2:     "",
-		Column3:     "",
-		Column4:     "",
-		Column5:     "",
+		OwnerId:     params.OwnerID,
 		Limit:       int32(params.Limit + 1), // +1 to check next page
 	}
 
 	if params.LifecycleStage != nil {
 		stage := string(*params.LifecycleStage)
-		sqlcParams.Column2 = stage
+		sqlcParams.LifecycleStage = &stage
 	}
 
 	if params.Size != nil {
 		size := string(*params.Size)
-		sqlcParams.Column3 = size
+		sqlcParams.Size = &size
 	}
 
-	if params.OwnerID != nil {
-		sqlcParams.Column4 = *params.OwnerID
-	}
-
-	if params.Query != nil {
-		sqlcParams.Column5 = *params.Query
+	if params.Query != nil && *params.Query != "" {
+		sqlcParams.QueryText = params.Query
 	}
 
 	if params.Cursor != nil && *params.Cursor != "" {
-		// Parse cursor as timestamp
-		// TODO: Parse cursor properly
+		cursorTime, cursorID, err := decodeTimeCursor(*params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		sqlcParams.CursorTime = pgtype.Timestamp{Time: cursorTime, Valid: true}
+		sqlcParams.CursorId = cursorID
 	}
 
 	rows, err := r.queries.ListCompanies(ctx, sqlcParams)
@@ -78,7 +77,8 @@ func (r *CompanyRepository) List(ctx context.Context, params domain.ListCompanie
 
 	var nextCursor string
 	if len(companies) > params.Limit {
-		nextCursor = companies[params.Limit-1].CreatedAt.Format("2006-01-02T15:04:05Z07:00")
+		last := companies[params.Limit-1]
+		nextCursor = encodeTimeCursor(last.CreatedAt, last.ID)
 		companies = companies[:params.Limit]
 	}
 
@@ -150,7 +150,11 @@ func (r *CompanyRepository) Create(ctx context.Context, company *domain.Company)
 		UpdatedById:    &company.OwnerID,
 		CreatedAt:      now,
 		UpdatedAt:      now,
+		ExternalId:     company.ExternalID,
 	})
+	if isExternalIDViolation(err) {
+		return ErrExternalIDConflict
+	}
 
 	return err
 }
@@ -241,12 +245,16 @@ func (r *CompanyRepository) Update(ctx context.Context, workspaceID, companyID s
 		UpdatedById:    assignedToId,
 		UpdatedAt:      now,
 		UpdatedAt_2:    pgtype.Timestamp{Time: current.UpdatedAt, Valid: true}, // optimistic lock
+		ExternalId:     req.ExternalID,
 	})
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return ErrCompanyNotFound
 		}
+		if isExternalIDViolation(err) {
+			return ErrExternalIDConflict
+		}
 		return err
 	}
 
@@ -258,18 +266,143 @@ func (r *CompanyRepository) Update(ctx context.Context, workspaceID, companyID s
 	return nil
 }
 
-// SoftDelete marca uma empresa como deletada (soft delete).
-func (r *CompanyRepository) SoftDelete(ctx context.Context, workspaceID, companyID string) error {
-	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+// SoftDelete marca uma empresa como deletada (soft delete) e, na mesma transação, desassocia
+// os contatos e negócios dela (companyDetachedReferences).
+func (r *CompanyRepository) SoftDelete(ctx context.Context, workspaceID, companyID, deletedByID string) error {
+	now := time.Now().UTC()
 
-	err := r.queries.SoftDeleteCompany(ctx, sqlc.SoftDeleteCompanyParams{
-		ID:          companyID,
-		WorkspaceId: workspaceID,
-		DeletedAt:   now,
-		DeletedById: nil, // TODO: passar userID do context
-	})
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE public."Company"
+		SET "deletedAt" = $3, "deletedById" = $4, "updatedAt" = $3
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`,
+		companyID, workspaceID, now, deletedByID,
+	)
+	if err != nil {
+		return fmt.Errorf("soft delete company: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCompanyNotFound
+	}
 
-	return err
+	if err := detachReferences(ctx, tx, workspaceID, "company", companyID, companyDetachedReferences, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Restore desfaz o soft delete de uma empresa (janela de undo ou lixeira) e reassocia os
+// contatos e negócios desassociados no soft delete.
+func (r *CompanyRepository) Restore(ctx context.Context, workspaceID, companyID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE public."Company"
+		SET "deletedAt" = NULL, "deletedById" = NULL, "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NOT NULL`,
+		companyID, workspaceID,
+	)
+	if err != nil {
+		// Outra empresa ativa passou a usar o mesmo externalId enquanto esta estava na lixeira
+		if isExternalIDViolation(err) {
+			return ErrExternalIDConflict
+		}
+		return fmt.Errorf("restore company: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrCompanyNotFound
+	}
+
+	if err := reattachReferences(ctx, tx, workspaceID, "company", companyID, companyDetachedReferences); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// companyDomainExpr normaliza o website da empresa para o domínio (mesma regra de
+// domain.NormalizeDomain): minúsculas, sem esquema, "www.", porta ou caminho.
+const companyDomainExpr = `rtrim(regexp_replace(lower(btrim(website)), '^([a-z][a-z0-9+.-]*://)?(www\.)?([^/?#:]*).*$', '\3'), '.')`
+
+// FindIDByDomain retorna a empresa ativa do workspace cujo website tem o domínio informado
+// (a mais antiga, se houver mais de uma). nil quando nenhuma corresponde.
+func (r *CompanyRepository) FindIDByDomain(ctx context.Context, workspaceID, domainName string) (*string, error) {
+	query := `
+		SELECT id FROM public."Company"
+		WHERE "workspaceId" = $1 AND "deletedAt" IS NULL AND website IS NOT NULL
+		  AND ` + companyDomainExpr + ` = $2
+		ORDER BY "createdAt", id
+		LIMIT 1`
+
+	var id string
+	if err := r.pool.QueryRow(ctx, query, workspaceID, domainName).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find company by domain: %w", err)
+	}
+	return &id, nil
+}
+
+// FindIDByExternalID retorna a empresa ativa do workspace com o externalId informado.
+// nil quando nenhuma corresponde (o índice único garante no máximo uma).
+func (r *CompanyRepository) FindIDByExternalID(ctx context.Context, workspaceID, externalID string) (*string, error) {
+	query := `
+		SELECT id FROM public."Company"
+		WHERE "workspaceId" = $1 AND "externalId" = $2 AND "deletedAt" IS NULL`
+
+	var id string
+	if err := r.pool.QueryRow(ctx, query, workspaceID, externalID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find company by external id: %w", err)
+	}
+	return &id, nil
+}
+
+// AssociateContactsByEmailDomain associa (backfill) os contatos sem empresa à empresa cujo
+// domínio coincide com o do e-mail, ignorando os domínios de denied. Retorna quantos foram associados.
+func (r *CompanyRepository) AssociateContactsByEmailDomain(ctx context.Context, workspaceID, actorID string, denied []string) (int64, error) {
+	query := `
+		WITH companies AS (
+			SELECT DISTINCT ON (dom) dom, id
+			FROM (
+				SELECT id, "createdAt", ` + companyDomainExpr + ` AS dom
+				FROM public."Company"
+				WHERE "workspaceId" = $1 AND "deletedAt" IS NULL AND website IS NOT NULL
+			) c
+			WHERE dom LIKE '%.%' AND NOT (dom = ANY($3::TEXT[]))
+			ORDER BY dom, "createdAt", id
+		)
+		UPDATE public."Contact" ct
+		SET "companyId" = companies.id, "updatedById" = $2, "updatedAt" = NOW()
+		FROM companies
+		WHERE ct."workspaceId" = $1 AND ct."deletedAt" IS NULL AND ct."companyId" IS NULL
+		  AND lower(split_part(ct.email, '@', 2)) = companies.dom`
+
+	result, err := r.pool.Exec(ctx, query, workspaceID, actorID, denied)
+	if err != nil {
+		return 0, fmt.Errorf("associate contacts by email domain: %w", err)
+	}
+	return result.RowsAffected(), nil
 }
 
 // ExistsInWorkspace verifica se uma empresa existe no workspace.
@@ -295,8 +428,11 @@ func sqlcRowToDomainCompany(row interface{}) domain.Company {
 		c.Phone = r.Phone
 		c.Website = r.Website
 		c.AnnualRevenue = r.Revenue
+		c.Industry = r.Industry
+		c.LogoURL = r.LogoUrl
+		c.ExternalID = r.ExternalId
 		c.Tags = []string{}
-		c.CustomFields = map[string]interface{}{}
+		c.CustomFields = decodeCompanyCustomFields(r.CustomFields)
 		c.Address = map[string]interface{}{}
 
 		// Convert ENUMs
@@ -331,8 +467,11 @@ func sqlcRowToDomainCompany(row interface{}) domain.Company {
 		c.Phone = r.Phone
 		c.Website = r.Website
 		c.AnnualRevenue = r.Revenue
+		c.Industry = r.Industry
+		c.LogoURL = r.LogoUrl
+		c.ExternalID = r.ExternalId
 		c.Tags = []string{}
-		c.CustomFields = map[string]interface{}{}
+		c.CustomFields = decodeCompanyCustomFields(r.CustomFields)
 		c.Address = map[string]interface{}{}
 
 		// Convert ENUMs
@@ -361,3 +500,12 @@ func sqlcRowToDomainCompany(row interface{}) domain.Company {
 
 	return c
 }
+
+// decodeCompanyCustomFields decodifica o JSONB customFields (mapa vazio quando ausente ou inválido).
+func decodeCompanyCustomFields(raw []byte) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &fields)
+	}
+	return fields
+}
@@ -0,0 +1,85 @@
+package repo_test
+
+import (
+	"strings"
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestCompanyRepository_EmailDomain_Integration
+func TestCompanyRepository_EmailDomain_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	companies := repo.NewCompanyRepository(pool)
+	contacts := repo.NewContactRepository(pool)
+
+	// Domínio único por workspace (a expressão SQL devolve o domínio em minúsculas)
+	acmeDomain := strings.ToLower(f.WorkspaceID) + ".acme.com"
+	website := func(site string) func(*domain.Company) {
+		return func(c *domain.Company) { c.Domain = &site }
+	}
+	acme := f.Company(website("https://www." + acmeDomain + "/sobre"))
+	f.Company(website("http://" + acmeDomain)) // duplicada mais nova: perde para a mais antiga
+	partner := f.Company(website("parceiro.com.br"))
+	f.Company(website("gmail.com"))
+
+	email := func(address string) func(*domain.Contact) {
+		return func(c *domain.Contact) { c.Email = address }
+	}
+	employee := f.Contact(email("ana@" + acmeDomain))
+	shouting := f.Contact(email("bia@" + acmeDomain))
+	_, err := pool.Exec(ctx, `UPDATE public."Contact" SET email = upper(email) WHERE id = $1`, shouting.ID)
+	require.NoError(t, err)
+	freeMail := f.Contact(email("carla@gmail.com"))
+	denied := f.Contact(email("duda@parceiro.com.br"))
+	unknown := f.Contact(email("edu@" + f.WorkspaceID + ".nowhere.com"))
+	linked := f.Contact(email("fabi@"+acmeDomain), func(c *domain.Contact) { c.CompanyID = &partner.ID })
+
+	t.Run("find by domain picks the oldest company", func(t *testing.T) {
+		got, err := companies.FindIDByDomain(ctx, f.WorkspaceID, acmeDomain)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, acme.ID, *got)
+
+		got, err = companies.FindIDByDomain(ctx, f.WorkspaceID, "nowhere.example")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		other := factory.New(t, pool)
+		got, err = companies.FindIDByDomain(ctx, other.WorkspaceID, acmeDomain)
+		require.NoError(t, err)
+		assert.Nil(t, got, "companies of other workspaces never match")
+	})
+
+	t.Run("backfill associates contacts without company", func(t *testing.T) {
+		deny := domain.NewCompanyAssociationPolicy(true, []string{"parceiro.com.br"}).DeniedDomains()
+		associated, err := companies.AssociateContactsByEmailDomain(ctx, f.WorkspaceID, f.UserID, deny)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), associated)
+
+		companyOf := func(contactID string) *string {
+			t.Helper()
+			c, err := contacts.Get(ctx, f.WorkspaceID, contactID)
+			require.NoError(t, err)
+			return c.CompanyID
+		}
+		assert.Equal(t, &acme.ID, companyOf(employee.ID))
+		assert.Equal(t, &acme.ID, companyOf(shouting.ID))
+		assert.Nil(t, companyOf(freeMail.ID))
+		assert.Nil(t, companyOf(denied.ID))
+		assert.Nil(t, companyOf(unknown.ID))
+		assert.Equal(t, &partner.ID, companyOf(linked.ID), "existing association is kept")
+
+		// Idempotente
+		associated, err = companies.AssociateContactsByEmailDomain(ctx, f.WorkspaceID, f.UserID, deny)
+		require.NoError(t, err)
+		assert.Zero(t, associated)
+	})
+}
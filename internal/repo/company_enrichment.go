@@ -0,0 +1,192 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrEnrichmentJobNotFound = apperr.NotFound("company enrichment job not found in workspace", "enrichment job not found")
+	ErrEnrichmentJobActive   = apperr.Conflict("company already has a pending or running enrichment job", "an enrichment job is already in progress for this company")
+)
+
+// CompanyEnrichmentRepository persiste os jobs de enriquecimento e grava o resultado na empresa.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type CompanyEnrichmentRepository struct {
+	pool database.DB
+}
+
+func NewCompanyEnrichmentRepository(pool database.DB) *CompanyEnrichmentRepository {
+	return &CompanyEnrichmentRepository{pool: pool}
+}
+
+const enrichmentJobColumns = `id, "workspaceId", "companyId", provider, overwrite, status, "appliedFields",
+	error, "requestedById", "createdAt", "updatedAt", "startedAt", "completedAt"`
+
+// Create insere um job PENDING. Falha com ErrEnrichmentJobActive se a empresa já tem um job ativo.
+func (r *CompanyEnrichmentRepository) Create(ctx context.Context, job *domain.CompanyEnrichmentJob) (*domain.CompanyEnrichmentJob, error) {
+	query := `
+		INSERT INTO public."CompanyEnrichmentJob" (id, "workspaceId", "companyId", provider, overwrite, status, "requestedById")
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + enrichmentJobColumns
+
+	created, err := scanEnrichmentJob(r.pool.QueryRow(ctx, query,
+		job.ID, job.WorkspaceID, job.CompanyID, job.Provider, job.Overwrite, domain.EnrichmentStatusPending, job.RequestedByID,
+	))
+	if err != nil {
+		if isActiveEnrichmentViolation(err) {
+			return nil, ErrEnrichmentJobActive
+		}
+		return nil, fmt.Errorf("insert company enrichment job: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um job de uma empresa do workspace.
+func (r *CompanyEnrichmentRepository) Get(ctx context.Context, workspaceID, companyID, jobID string) (*domain.CompanyEnrichmentJob, error) {
+	query := `
+		SELECT ` + enrichmentJobColumns + `
+		FROM public."CompanyEnrichmentJob"
+		WHERE id = $1 AND "workspaceId" = $2 AND "companyId" = $3`
+
+	job, err := scanEnrichmentJob(r.pool.QueryRow(ctx, query, jobID, workspaceID, companyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEnrichmentJobNotFound
+		}
+		return nil, fmt.Errorf("query company enrichment job: %w", err)
+	}
+	return job, nil
+}
+
+// ClaimNext marca como RUNNING o job PENDING mais antigo (ou um RUNNING iniciado antes de
+// staleBefore, abandonado por um worker que caiu) e o retorna. nil quando a fila está vazia.
+func (r *CompanyEnrichmentRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.CompanyEnrichmentJob, error) {
+	query := `
+		UPDATE public."CompanyEnrichmentJob"
+		SET status = 'RUNNING', "startedAt" = NOW(), "updatedAt" = NOW(), error = NULL
+		WHERE id = (
+			SELECT id FROM public."CompanyEnrichmentJob"
+			WHERE status = 'PENDING' OR (status = 'RUNNING' AND "startedAt" < $1)
+			ORDER BY "createdAt"
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + enrichmentJobColumns
+
+	job, err := scanEnrichmentJob(r.pool.QueryRow(ctx, query, staleBefore))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim company enrichment job: %w", err)
+	}
+	return job, nil
+}
+
+// ApplyEnrichment grava o patch na empresa e registra a proveniência em
+// customFields.enrichment (provedor, job, data e campos aplicados).
+func (r *CompanyEnrichmentRepository) ApplyEnrichment(ctx context.Context, job *domain.CompanyEnrichmentJob, patch *domain.CompanyEnrichmentPatch, enrichedAt time.Time) error {
+	provenance, err := json.Marshal(map[string]interface{}{
+		"provider":   job.Provider,
+		"jobId":      job.ID,
+		"enrichedAt": enrichedAt.UTC().Format(time.RFC3339),
+		"fields":     patch.Fields(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal enrichment provenance: %w", err)
+	}
+
+	var size *string
+	if patch.Size != nil {
+		s := string(*patch.Size)
+		size = &s
+	}
+
+	query := `
+		UPDATE public."Company"
+		SET industry = COALESCE($3, industry),
+		    size = COALESCE($4::"CompanySize", size),
+		    revenue = COALESCE($5, revenue),
+		    "logoUrl" = COALESCE($6, "logoUrl"),
+		    "customFields" = COALESCE("customFields", '{}'::jsonb) || jsonb_build_object('` + domain.EnrichmentCustomFieldKey + `', $7::jsonb),
+		    "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`
+
+	result, err := r.pool.Exec(ctx, query,
+		job.CompanyID, job.WorkspaceID, patch.Industry, size, patch.AnnualRevenue, patch.LogoURL, string(provenance),
+	)
+	if err != nil {
+		return fmt.Errorf("apply company enrichment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCompanyNotFound
+	}
+	return nil
+}
+
+// Complete finaliza o job com a lista de campos aplicados.
+func (r *CompanyEnrichmentRepository) Complete(ctx context.Context, jobID string, appliedFields []string) error {
+	fields, err := json.Marshal(appliedFields)
+	if err != nil {
+		return fmt.Errorf("marshal applied fields: %w", err)
+	}
+	_, err = r.pool.Exec(ctx, `
+		UPDATE public."CompanyEnrichmentJob"
+		SET status = 'COMPLETED', "appliedFields" = $2::jsonb, "completedAt" = NOW(), "updatedAt" = NOW()
+		WHERE id = $1`,
+		jobID, string(fields),
+	)
+	if err != nil {
+		return fmt.Errorf("complete company enrichment job: %w", err)
+	}
+	return nil
+}
+
+// Fail finaliza o job com a mensagem de erro.
+func (r *CompanyEnrichmentRepository) Fail(ctx context.Context, jobID, reason string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."CompanyEnrichmentJob"
+		SET status = 'FAILED', error = $2, "completedAt" = NOW(), "updatedAt" = NOW()
+		WHERE id = $1`,
+		jobID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("fail company enrichment job: %w", err)
+	}
+	return nil
+}
+
+func isActiveEnrichmentViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_active_enrichment_per_company"
+}
+
+func scanEnrichmentJob(row pgx.Row) (*domain.CompanyEnrichmentJob, error) {
+	var j domain.CompanyEnrichmentJob
+	var fields []byte
+	err := row.Scan(
+		&j.ID, &j.WorkspaceID, &j.CompanyID, &j.Provider, &j.Overwrite, &j.Status, &fields,
+		&j.Error, &j.RequestedByID, &j.CreatedAt, &j.UpdatedAt, &j.StartedAt, &j.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	j.AppliedFields = []string{}
+	if len(fields) > 0 {
+		if err := json.Unmarshal(fields, &j.AppliedFields); err != nil {
+			return nil, fmt.Errorf("decode applied fields: %w", err)
+		}
+	}
+	return &j, nil
+}
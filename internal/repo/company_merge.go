@@ -0,0 +1,139 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/domain"
+)
+
+// companyReferences tabelas com "companyId" reassociadas no merge, na ordem de execução.
+// CompanyTag é tratada à parte (chave única companyId+tagId).
+var companyReferences = []string{"Contact", "Deal", "Activity", "Note", "Message", "Email", "Call"}
+
+// ListDuplicateCandidates retorna id, nome, website e criação das empresas ativas do
+// workspace para a detecção de duplicatas.
+func (r *CompanyRepository) ListDuplicateCandidates(ctx context.Context, workspaceID string) ([]domain.DuplicateCandidate, error) {
+	query := `
+		SELECT id, name, website, "createdAt"
+		FROM public."Company"
+		WHERE "workspaceId" = $1 AND "deletedAt" IS NULL
+		ORDER BY "createdAt", id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query duplicate candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []domain.DuplicateCandidate{}
+	for rows.Next() {
+		var c domain.DuplicateCandidate
+		if err := rows.Scan(&c.ID, &c.Name, &c.Domain, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan duplicate candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// Merge absorve sourceIDs na empresa survivorID em uma transação: reassocia contatos,
+// negócios, timeline e tags, preenche campos vazios do sobrevivente com os das origens
+// (na ordem de sourceIDs) e aplica soft delete nas origens.
+// Retorna quantos registros foram reassociados por tabela. Falha com ErrCompanyNotFound
+// se alguma empresa não existe (ou já foi removida) no workspace.
+func (r *CompanyRepository) Merge(ctx context.Context, workspaceID, survivorID string, sourceIDs []string, actorID string) (map[string]int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Trava sobrevivente e origens (ordem por id evita deadlock entre merges concorrentes)
+	var locked int
+	err = tx.QueryRow(ctx, `
+		SELECT count(*) FROM (
+			SELECT id FROM public."Company"
+			WHERE "workspaceId" = $1 AND "deletedAt" IS NULL AND (id = $2 OR id = ANY($3::TEXT[]))
+			ORDER BY id
+			FOR UPDATE
+		) locked`,
+		workspaceID, survivorID, sourceIDs,
+	).Scan(&locked)
+	if err != nil {
+		return nil, fmt.Errorf("lock companies: %w", err)
+	}
+	if locked != len(sourceIDs)+1 {
+		return nil, ErrCompanyNotFound
+	}
+
+	reassigned := make(map[string]int64, len(companyReferences)+1)
+	for _, table := range companyReferences {
+		result, err := tx.Exec(ctx, `
+			UPDATE public."`+table+`"
+			SET "companyId" = $2
+			WHERE "workspaceId" = $1 AND "companyId" = ANY($3::TEXT[])`,
+			workspaceID, survivorID, sourceIDs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("reassign %s: %w", table, err)
+		}
+		reassigned[table] = result.RowsAffected()
+	}
+
+	// Tags: descarta as que o sobrevivente já tem (ou repetidas entre origens) e move as demais
+	_, err = tx.Exec(ctx, `
+		DELETE FROM public."CompanyTag" t
+		WHERE t."companyId" = ANY($2::TEXT[])
+		  AND (EXISTS (SELECT 1 FROM public."CompanyTag" s WHERE s."companyId" = $1 AND s."tagId" = t."tagId")
+		       OR EXISTS (SELECT 1 FROM public."CompanyTag" o
+		                  WHERE o."companyId" = ANY($2::TEXT[]) AND o."tagId" = t."tagId" AND o.id < t.id))`,
+		survivorID, sourceIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dedupe company tags: %w", err)
+	}
+	result, err := tx.Exec(ctx, `UPDATE public."CompanyTag" SET "companyId" = $1 WHERE "companyId" = ANY($2::TEXT[])`, survivorID, sourceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("reassign company tags: %w", err)
+	}
+	reassigned["CompanyTag"] = result.RowsAffected()
+
+	// Origens saem antes de o sobrevivente herdar o website (unicidade de domínio)
+	_, err = tx.Exec(ctx, `
+		UPDATE public."Company"
+		SET "deletedAt" = NOW(), "deletedById" = $3, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = ANY($2::TEXT[])`,
+		workspaceID, sourceIDs, actorID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("soft delete merged companies: %w", err)
+	}
+
+	// Campos vazios do sobrevivente herdam o primeiro valor não nulo das origens
+	_, err = tx.Exec(ctx, `
+		WITH src AS (
+			SELECT c.*, o.pos FROM public."Company" c
+			JOIN unnest($3::TEXT[]) WITH ORDINALITY AS o(id, pos) ON o.id = c.id
+		)
+		UPDATE public."Company" s
+		SET website  = COALESCE(s.website,  (SELECT website   FROM src WHERE website   IS NOT NULL ORDER BY pos LIMIT 1)),
+		    phone    = COALESCE(s.phone,    (SELECT phone     FROM src WHERE phone     IS NOT NULL ORDER BY pos LIMIT 1)),
+		    industry = COALESCE(s.industry, (SELECT industry  FROM src WHERE industry  IS NOT NULL ORDER BY pos LIMIT 1)),
+		    size     = COALESCE(s.size,     (SELECT size      FROM src WHERE size      IS NOT NULL ORDER BY pos LIMIT 1)),
+		    revenue  = COALESCE(s.revenue,  (SELECT revenue   FROM src WHERE revenue   IS NOT NULL ORDER BY pos LIMIT 1)),
+		    "logoUrl" = COALESCE(s."logoUrl", (SELECT "logoUrl" FROM src WHERE "logoUrl" IS NOT NULL ORDER BY pos LIMIT 1)),
+		    "updatedById" = $4,
+		    "updatedAt" = NOW()
+		WHERE s.id = $2 AND s."workspaceId" = $1`,
+		workspaceID, survivorID, sourceIDs, actorID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("merge company fields: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return reassigned, nil
+}
@@ -0,0 +1,88 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestCompanyRepository_Merge_Integration
+func TestCompanyRepository_Merge_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	companies := repo.NewCompanyRepository(pool)
+	contacts := repo.NewContactRepository(pool)
+	deals := repo.NewDealRepository(pool)
+
+	phone := "+55 11 4000-0000"
+	website := "https://acme-" + f.WorkspaceID + ".example.com"
+	survivor := f.Company(func(c *domain.Company) { c.Name = "ACME Ltda" })
+	withSite := f.Company(func(c *domain.Company) { c.Name = "Acme S.A."; c.Domain = &website })
+	withPhone := f.Company(func(c *domain.Company) { c.Name = "Acme"; c.Phone = &phone })
+	bystander := f.Company()
+
+	inCompany := func(companyID string) func(*domain.Contact) {
+		return func(c *domain.Contact) { c.CompanyID = &companyID }
+	}
+	movedContact := f.Contact(inCompany(withSite.ID))
+	keptContact := f.Contact(inCompany(bystander.ID))
+	movedDeal := f.Deal(func(d *domain.Deal) { d.CompanyID = &withPhone.ID })
+
+	t.Run("unknown source aborts without changes", func(t *testing.T) {
+		_, err := companies.Merge(ctx, f.WorkspaceID, survivor.ID, []string{withSite.ID, "cmp_missing"}, f.UserID)
+		assert.ErrorIs(t, err, repo.ErrCompanyNotFound)
+
+		_, err = companies.Get(ctx, f.WorkspaceID, withSite.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("company of another workspace is not merged", func(t *testing.T) {
+		other := factory.New(t, pool)
+		foreign := other.Company()
+		_, err := companies.Merge(ctx, f.WorkspaceID, survivor.ID, []string{foreign.ID}, f.UserID)
+		assert.ErrorIs(t, err, repo.ErrCompanyNotFound)
+	})
+
+	t.Run("merge reassigns references and inherits empty fields", func(t *testing.T) {
+		reassigned, err := companies.Merge(ctx, f.WorkspaceID, survivor.ID, []string{withSite.ID, withPhone.ID}, f.UserID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), reassigned["Contact"])
+		assert.Equal(t, int64(1), reassigned["Deal"])
+
+		contact, err := contacts.Get(ctx, f.WorkspaceID, movedContact.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &survivor.ID, contact.CompanyID)
+		contact, err = contacts.Get(ctx, f.WorkspaceID, keptContact.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &bystander.ID, contact.CompanyID)
+
+		deal, err := deals.Get(ctx, f.WorkspaceID, movedDeal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &survivor.ID, deal.CompanyID)
+
+		merged, err := companies.Get(ctx, f.WorkspaceID, survivor.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ACME Ltda", merged.Name)
+		assert.Equal(t, &website, merged.Website)
+		assert.Equal(t, &phone, merged.Phone)
+
+		for _, id := range []string{withSite.ID, withPhone.ID} {
+			_, err := companies.Get(ctx, f.WorkspaceID, id)
+			assert.ErrorIs(t, err, repo.ErrCompanyNotFound)
+		}
+
+		candidates, err := companies.ListDuplicateCandidates(ctx, f.WorkspaceID)
+		require.NoError(t, err)
+		var ids []string
+		for _, c := range candidates {
+			ids = append(ids, c.ID)
+		}
+		assert.Equal(t, []string{survivor.ID, bystander.ID}, ids)
+	})
+}
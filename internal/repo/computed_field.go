@@ -0,0 +1,424 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/formula"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrComputedFieldNotFound    = apperr.NotFound("computed field not found in workspace", "computed field not found")
+	ErrComputedFieldKeyConflict = apperr.Conflict("computed field with this key already exists for the entity", "computed field with this key already exists")
+)
+
+// ComputedFieldRepository persiste as definições de campos calculados e avalia as fórmulas,
+// traduzindo cada uma para uma subquery SQL correlacionada com a entidade.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type ComputedFieldRepository struct {
+	pool database.DB
+}
+
+func NewComputedFieldRepository(pool database.DB) *ComputedFieldRepository {
+	return &ComputedFieldRepository{pool: pool}
+}
+
+const computedFieldColumns = `id, "workspaceId", "entityType", key, label, formula, "resultType",
+	"createdById", "updatedById", "createdAt", "updatedAt"`
+
+// Create insere uma definição. Falha com ErrComputedFieldKeyConflict se a chave já existe na entidade.
+func (r *ComputedFieldRepository) Create(ctx context.Context, f *domain.ComputedField) (*domain.ComputedField, error) {
+	query := `
+		INSERT INTO public."ComputedField" (id, "workspaceId", "entityType", key, label, formula, "resultType", "createdById")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + computedFieldColumns
+
+	created, err := scanComputedField(r.pool.QueryRow(ctx, query,
+		f.ID, f.WorkspaceID, f.EntityType, f.Key, f.Label, f.Formula, f.ResultType, f.CreatedByID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrComputedFieldKeyConflict
+		}
+		return nil, fmt.Errorf("insert computed field: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna uma definição do workspace.
+func (r *ComputedFieldRepository) Get(ctx context.Context, workspaceID, fieldID string) (*domain.ComputedField, error) {
+	query := `
+		SELECT ` + computedFieldColumns + `
+		FROM public."ComputedField"
+		WHERE "workspaceId" = $1 AND id = $2`
+
+	f, err := scanComputedField(r.pool.QueryRow(ctx, query, workspaceID, fieldID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrComputedFieldNotFound
+		}
+		return nil, fmt.Errorf("query computed field: %w", err)
+	}
+	return f, nil
+}
+
+// List retorna as definições do workspace (opcionalmente de uma entidade) em ordem de criação.
+func (r *ComputedFieldRepository) List(ctx context.Context, workspaceID string, entity *domain.ComputedFieldEntity) ([]domain.ComputedField, error) {
+	query := `
+		SELECT ` + computedFieldColumns + `
+		FROM public."ComputedField"
+		WHERE "workspaceId" = $1 AND ($2::TEXT IS NULL OR "entityType" = $2)
+		ORDER BY "createdAt" ASC, id ASC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, entity)
+	if err != nil {
+		return nil, fmt.Errorf("query computed fields: %w", err)
+	}
+	defer rows.Close()
+
+	fields := []domain.ComputedField{}
+	for rows.Next() {
+		f, err := scanComputedField(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan computed field: %w", err)
+		}
+		fields = append(fields, *f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate computed fields: %w", err)
+	}
+
+	return fields, nil
+}
+
+// Update aplica um PATCH na definição (campos nil não são alterados).
+func (r *ComputedFieldRepository) Update(ctx context.Context, workspaceID, fieldID string, req *domain.UpdateComputedFieldRequest, actorID string) (*domain.ComputedField, error) {
+	var resultType *formula.Type
+	if req.Formula != nil {
+		resultType = &req.ResultType
+	}
+
+	query := `
+		UPDATE public."ComputedField" SET
+			label = COALESCE($3, label),
+			formula = COALESCE($4, formula),
+			"resultType" = COALESCE($5, "resultType"),
+			"updatedById" = $6,
+			"updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + computedFieldColumns
+
+	f, err := scanComputedField(r.pool.QueryRow(ctx, query,
+		workspaceID, fieldID, req.Label, req.Formula, resultType, actorID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrComputedFieldNotFound
+		}
+		return nil, fmt.Errorf("update computed field: %w", err)
+	}
+	return f, nil
+}
+
+// Delete remove a definição (não há valores persistidos: o campo some das leituras).
+func (r *ComputedFieldRepository) Delete(ctx context.Context, workspaceID, fieldID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."ComputedField" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, fieldID)
+	if err != nil {
+		return fmt.Errorf("delete computed field: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrComputedFieldNotFound
+	}
+	return nil
+}
+
+// CountByEntity conta as definições de uma entidade (limite MaxComputedFieldsPerEntity).
+func (r *ComputedFieldRepository) CountByEntity(ctx context.Context, workspaceID string, entity domain.ComputedFieldEntity) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM public."ComputedField" WHERE "workspaceId" = $1 AND "entityType" = $2`,
+		workspaceID, entity,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count computed fields: %w", err)
+	}
+	return count, nil
+}
+
+// Evaluate calcula os campos para os IDs informados em uma única query e retorna
+// id -> key -> valor (float64, time.Time ou nil). Fórmulas que não compilam mais contra o
+// schema atual valem nil.
+func (r *ComputedFieldRepository) Evaluate(ctx context.Context, workspaceID string, entity domain.ComputedFieldEntity, fields []domain.ComputedField, ids []string) (map[string]map[string]any, error) {
+	table, ok := computedEntityTables[entity]
+	if !ok || len(fields) == 0 || len(ids) == 0 {
+		return map[string]map[string]any{}, nil
+	}
+
+	c := &formulaCompiler{entity: entity, args: []any{workspaceID, ids}}
+	selects := make([]string, 0, len(fields))
+	types := make([]formula.Type, 0, len(fields))
+	for _, f := range fields {
+		expr, err := formula.Parse(f.Formula, domain.ComputedFieldSchemas[entity])
+		if err != nil {
+			selects = append(selects, "NULL::DOUBLE PRECISION")
+			types = append(types, formula.Number)
+			continue
+		}
+		selects = append(selects, c.expr(expr))
+		types = append(types, expr.Type())
+	}
+
+	query := `
+		SELECT e.id, ` + strings.Join(selects, ",\n\t\t\t") + `
+		FROM public."` + table + `" e
+		WHERE e."workspaceId" = $1 AND e.id = ANY($2)`
+
+	rows, err := r.pool.Query(ctx, query, c.args...)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate computed fields: %w", err)
+	}
+	defer rows.Close()
+
+	values := make(map[string]map[string]any, len(ids))
+	for rows.Next() {
+		var id string
+		numbers := make([]*float64, len(fields))
+		dates := make([]*time.Time, len(fields))
+		dest := make([]any, 0, len(fields)+1)
+		dest = append(dest, &id)
+		for i, t := range types {
+			if t == formula.Date {
+				dest = append(dest, &dates[i])
+			} else {
+				dest = append(dest, &numbers[i])
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan computed fields: %w", err)
+		}
+
+		computed := make(map[string]any, len(fields))
+		for i, f := range fields {
+			switch {
+			case types[i] == formula.Date && dates[i] != nil:
+				computed[f.Key] = *dates[i]
+			case types[i] != formula.Date && numbers[i] != nil:
+				computed[f.Key] = *numbers[i]
+			default:
+				computed[f.Key] = nil
+			}
+		}
+		values[id] = computed
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate computed fields: %w", err)
+	}
+
+	return values, nil
+}
+
+// ============================================================================
+// Tradução de fórmulas para SQL
+// ============================================================================
+
+var computedEntityTables = map[domain.ComputedFieldEntity]string{
+	domain.ComputedFieldCompany: "Company",
+	domain.ComputedFieldDeal:    "Deal",
+}
+
+// computedEntityColumns mapeia os campos de domain.ComputedFieldSchemas para colunas (alias e).
+var computedEntityColumns = map[domain.ComputedFieldEntity]map[string]string{
+	domain.ComputedFieldCompany: {
+		"revenue":   `e."revenue"`,
+		"score":     `e."companyScore"`,
+		"createdAt": `e."createdAt"`,
+	},
+	domain.ComputedFieldDeal: {
+		"value":             `e."value"`,
+		"probability":       `e."probability"`,
+		"createdAt":         `e."createdAt"`,
+		"expectedCloseDate": `e."expectedCloseDate"`,
+		"closedAt":          `e."closedAt"`,
+	},
+}
+
+// computedCollection origem de uma coleção relacionada (alias c) e suas colunas.
+type computedCollection struct {
+	from    string
+	columns map[string]string
+}
+
+var (
+	computedDealColumns = map[string]string{
+		"value":             `c."value"`,
+		"probability":       `c."probability"`,
+		"stage":             `c."stage"::TEXT`,
+		"currency":          `c."currency"`,
+		"ownerId":           `c."ownerId"`,
+		"createdAt":         `c."createdAt"`,
+		"expectedCloseDate": `c."expectedCloseDate"`,
+		"closedAt":          `c."closedAt"`,
+	}
+	computedActivityColumns = map[string]string{
+		"activityType": `c."activityType"::TEXT`,
+		"createdAt":    `c."createdAt"`,
+	}
+)
+
+var computedCollections = map[domain.ComputedFieldEntity]map[string]computedCollection{
+	domain.ComputedFieldCompany: {
+		"deals": {
+			from:    `public."Deal" c WHERE c."companyId" = e.id AND c."workspaceId" = e."workspaceId" AND c."deletedAt" IS NULL`,
+			columns: computedDealColumns,
+		},
+		"activities": {
+			from:    `public."Activity" c WHERE c."companyId" = e.id AND c."workspaceId" = e."workspaceId"`,
+			columns: computedActivityColumns,
+		},
+	},
+	domain.ComputedFieldDeal: {
+		"activities": {
+			from:    `public."Activity" c WHERE c."dealId" = e.id AND c."workspaceId" = e."workspaceId"`,
+			columns: computedActivityColumns,
+		},
+	},
+}
+
+// sqlCompareOps operadores de comparação de formula.Compare e seus equivalentes em SQL.
+var sqlCompareOps = map[string]string{
+	"=":  "=",
+	"!=": "<>",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+}
+
+// formulaCompiler gera SQL a partir da AST já tipada por formula.Parse. Identificadores vêm
+// apenas dos mapas acima; literais de texto e datas viram parâmetros ($3...).
+type formulaCompiler struct {
+	entity domain.ComputedFieldEntity
+	args   []any
+}
+
+func (c *formulaCompiler) param(v any) string {
+	c.args = append(c.args, v)
+	return "$" + strconv.Itoa(len(c.args))
+}
+
+func (c *formulaCompiler) expr(e formula.Expr) string {
+	switch n := e.(type) {
+	case formula.NumberLit:
+		return strconv.FormatFloat(n.Value, 'f', -1, 64) + "::DOUBLE PRECISION"
+	case formula.FieldRef:
+		col := computedEntityColumns[c.entity][n.Name]
+		if n.Type() == formula.Number {
+			return col + "::DOUBLE PRECISION"
+		}
+		return col
+	case formula.Neg:
+		return "(-(" + c.expr(n.X) + "))"
+	case formula.Binary:
+		if n.Op == '/' {
+			// Divisão por zero resulta em null em vez de erro na leitura
+			return "(" + c.expr(n.Left) + " / NULLIF(" + c.expr(n.Right) + ", 0))"
+		}
+		return "(" + c.expr(n.Left) + " " + string(n.Op) + " " + c.expr(n.Right) + ")"
+	case formula.Call:
+		return c.call(n)
+	case formula.Aggregate:
+		return c.aggregate(n)
+	}
+	return "NULL"
+}
+
+func (c *formulaCompiler) call(n formula.Call) string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = c.expr(arg)
+	}
+	switch n.Func {
+	case "days_since":
+		return "FLOOR(EXTRACT(EPOCH FROM (NOW() - " + args[0] + ")) / 86400)::DOUBLE PRECISION"
+	case "days_until":
+		return "FLOOR(EXTRACT(EPOCH FROM (" + args[0] + " - NOW())) / 86400)::DOUBLE PRECISION"
+	case "abs":
+		return "ABS(" + args[0] + ")"
+	case "round":
+		places := "0"
+		if len(n.Args) == 2 {
+			places = strconv.Itoa(int(n.Args[1].(formula.NumberLit).Value))
+		}
+		return "ROUND((" + args[0] + ")::NUMERIC, " + places + ")::DOUBLE PRECISION"
+	case "coalesce":
+		return "COALESCE(" + strings.Join(args, ", ") + ")"
+	}
+	return "NULL"
+}
+
+func (c *formulaCompiler) aggregate(n formula.Aggregate) string {
+	coll := computedCollections[c.entity][n.Collection]
+	where := ""
+	if n.Where != nil {
+		where = " AND " + c.cond(coll, n.Where)
+	}
+
+	if n.Func == "count" {
+		return "(SELECT COUNT(*) FROM " + coll.from + where + ")::DOUBLE PRECISION"
+	}
+
+	col := coll.columns[n.Field]
+	if n.Type() == formula.Number {
+		col += "::DOUBLE PRECISION"
+	}
+	sub := "(SELECT " + strings.ToUpper(n.Func) + "(" + col + ") FROM " + coll.from + where + ")"
+	if n.Func == "sum" {
+		return "COALESCE(" + sub + ", 0)"
+	}
+	return sub
+}
+
+func (c *formulaCompiler) cond(coll computedCollection, cond formula.Cond) string {
+	switch n := cond.(type) {
+	case formula.Logical:
+		return "(" + c.cond(coll, n.Left) + " " + strings.ToUpper(n.Op) + " " + c.cond(coll, n.Right) + ")"
+	case formula.Compare:
+		col := coll.columns[n.Field]
+		op, ok := sqlCompareOps[n.Op]
+		if !ok {
+			return "FALSE"
+		}
+		switch v := n.Value.(type) {
+		case float64:
+			return col + "::DOUBLE PRECISION " + op + " " + c.param(v) + "::DOUBLE PRECISION"
+		case time.Time:
+			return col + " " + op + " " + c.param(v) + "::TIMESTAMP(3)"
+		default:
+			return col + " " + op + " " + c.param(v) + "::TEXT"
+		}
+	}
+	return "TRUE"
+}
+
+func scanComputedField(row pgx.Row) (*domain.ComputedField, error) {
+	var f domain.ComputedField
+	err := row.Scan(
+		&f.ID, &f.WorkspaceID, &f.EntityType, &f.Key, &f.Label, &f.Formula, &f.ResultType,
+		&f.CreatedByID, &f.UpdatedByID, &f.CreatedAt, &f.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
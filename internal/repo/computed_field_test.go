@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/formula"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compileFormula(t *testing.T, entity domain.ComputedFieldEntity, src string) (string, []any) {
+	t.Helper()
+	expr, err := formula.Parse(src, domain.ComputedFieldSchemas[entity])
+	require.NoError(t, err)
+	c := &formulaCompiler{entity: entity, args: []any{"ws_1", []string{"id_1"}}}
+	return c.expr(expr), c.args[2:]
+}
+
+func TestFormulaCompiler_CompareOperators(t *testing.T) {
+	tests := []struct {
+		cond string
+		want string
+	}{
+		{`stage = "WON"`, `c."stage"::TEXT = $3::TEXT`},
+		{`stage != "WON"`, `c."stage"::TEXT <> $3::TEXT`},
+		{`value < 10`, `c."value"::DOUBLE PRECISION < $3::DOUBLE PRECISION`},
+		{`value <= 10`, `c."value"::DOUBLE PRECISION <= $3::DOUBLE PRECISION`},
+		{`value > 10`, `c."value"::DOUBLE PRECISION > $3::DOUBLE PRECISION`},
+		{`closedAt >= "2026-01-01"`, `c."closedAt" >= $3::TIMESTAMP(3)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cond, func(t *testing.T) {
+			sql, _ := compileFormula(t, domain.ComputedFieldCompany, "count(deals, "+tt.cond+")")
+			assert.Contains(t, sql, " AND "+tt.want+")")
+		})
+	}
+}
+
+func TestFormulaCompiler_UnknownCompareOperator(t *testing.T) {
+	// Operadores fora do mapa nunca chegam ao SQL
+	c := &formulaCompiler{entity: domain.ComputedFieldCompany}
+	coll := computedCollections[domain.ComputedFieldCompany]["deals"]
+	assert.Equal(t, "FALSE", c.cond(coll, formula.Compare{Field: "stage", Op: "==", Value: "WON"}))
+	assert.Empty(t, c.args)
+}
+
+func TestFormulaCompiler_Expressions(t *testing.T) {
+	sql, args := compileFormula(t, domain.ComputedFieldCompany,
+		`sum(deals.value, stage = "WON" and closedAt >= "2026-01-01") / count(deals)`)
+
+	assert.Equal(t, `(COALESCE((SELECT SUM(c."value"::DOUBLE PRECISION) FROM `+
+		computedCollections[domain.ComputedFieldCompany]["deals"].from+
+		` AND (c."stage"::TEXT = $3::TEXT AND c."closedAt" >= $4::TIMESTAMP(3))), 0) / NULLIF((SELECT COUNT(*) FROM `+
+		computedCollections[domain.ComputedFieldCompany]["deals"].from+`)::DOUBLE PRECISION, 0))`, sql)
+	assert.Equal(t, []any{"WON", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, args)
+
+	sql, args = compileFormula(t, domain.ComputedFieldDeal, "round(-value * probability, 2)")
+	assert.Equal(t, `ROUND((((-(e."value"::DOUBLE PRECISION)) * e."probability"::DOUBLE PRECISION))::NUMERIC, 2)::DOUBLE PRECISION`, sql)
+	assert.Empty(t, args)
+
+	sql, _ = compileFormula(t, domain.ComputedFieldDeal, "days_until(coalesce(expectedCloseDate, createdAt))")
+	assert.Equal(t, `FLOOR(EXTRACT(EPOCH FROM (COALESCE(e."expectedCloseDate", e."createdAt") - NOW())) / 86400)::DOUBLE PRECISION`, sql)
+}
+
+func TestFormulaCompiler_LiteralsAreParameters(t *testing.T) {
+	hostile := `'; DROP TABLE public.Deal; --`
+	sql, args := compileFormula(t, domain.ComputedFieldCompany, `count(deals, currency = "`+hostile+`")`)
+	assert.NotContains(t, sql, hostile)
+	assert.Equal(t, []any{hostile}, args)
+}
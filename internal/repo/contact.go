@@ -6,31 +6,40 @@ import (
 	"fmt"
 	"time"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
 	"linkko-api/internal/repo/sqlc"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
-	ErrContactNotFound      = errors.New("contact not found in workspace")
-	ErrContactEmailConflict = errors.New("contact with this email already exists in workspace")
+	ErrContactNotFound      = apperr.NotFound("contact not found in workspace", "contact not found")
+	ErrContactEmailConflict = apperr.Conflict("contact with this email already exists in workspace", "contact with this email already exists")
 )
 
 type ContactRepository struct {
-	pool    *pgxpool.Pool
+	pool    database.DB
 	queries *sqlc.Queries
 }
 
-func NewContactRepository(pool *pgxpool.Pool) *ContactRepository {
+func NewContactRepository(pool database.DB) *ContactRepository {
 	return &ContactRepository{
 		pool:    pool,
 		queries: sqlc.New(pool),
 	}
 }
 
+// WithTx retorna uma instância do repositório vinculada a uma transação.
+func (r *ContactRepository) WithTx(tx pgx.Tx) *ContactRepository {
+	return &ContactRepository{
+		pool:    tx,
+		queries: r.queries.WithTx(tx),
+	}
+}
+
 // Helper: converte sqlc row para domain.Contact
 func sqlcRowToDomainContact(row interface{}) *domain.Contact {
 	var c domain.Contact
@@ -48,6 +57,11 @@ func sqlcRowToDomainContact(row interface{}) *domain.Contact {
 			c.ActorID = *r.OwnerId
 		}
 		c.CompanyID = r.CompanyId
+		c.LifecycleStage = domain.ContactLifecycleStage(r.LifecycleStage)
+		c.Attribution = attributionFromColumns(r.Source, r.SourceDetail, r.UtmSource, r.UtmMedium, r.UtmCampaign, r.UtmTerm, r.UtmContent)
+		c.NextStep = nextStepFromColumns(r.NextStepAt, r.NextStepNote)
+		setContactEmailVerification(&c, r.EmailStatus, r.EmailStatusReason, r.EmailCheckedAt)
+		c.ExternalID = r.ExternalId
 		c.Tags = r.TagLabels
 		// TODO: converter SocialUrls ([]byte) para map[string]interface{}
 		c.CustomFields = make(map[string]interface{})
@@ -68,6 +82,11 @@ func sqlcRowToDomainContact(row interface{}) *domain.Contact {
 			c.ActorID = *r.OwnerId
 		}
 		c.CompanyID = r.CompanyId
+		c.LifecycleStage = domain.ContactLifecycleStage(r.LifecycleStage)
+		c.Attribution = attributionFromColumns(r.Source, r.SourceDetail, r.UtmSource, r.UtmMedium, r.UtmCampaign, r.UtmTerm, r.UtmContent)
+		c.NextStep = nextStepFromColumns(r.NextStepAt, r.NextStepNote)
+		setContactEmailVerification(&c, r.EmailStatus, r.EmailStatusReason, r.EmailCheckedAt)
+		c.ExternalID = r.ExternalId
 		c.Tags = r.TagLabels
 		c.CustomFields = make(map[string]interface{})
 		c.CreatedAt = r.CreatedAt.Time
@@ -87,6 +106,35 @@ func sqlcRowToDomainContact(row interface{}) *domain.Contact {
 			c.ActorID = *r.OwnerId
 		}
 		c.CompanyID = r.CompanyId
+		c.LifecycleStage = domain.ContactLifecycleStage(r.LifecycleStage)
+		c.Attribution = attributionFromColumns(r.Source, r.SourceDetail, r.UtmSource, r.UtmMedium, r.UtmCampaign, r.UtmTerm, r.UtmContent)
+		c.NextStep = nextStepFromColumns(r.NextStepAt, r.NextStepNote)
+		setContactEmailVerification(&c, r.EmailStatus, r.EmailStatusReason, r.EmailCheckedAt)
+		c.ExternalID = r.ExternalId
+		c.Tags = r.TagLabels
+		c.CustomFields = make(map[string]interface{})
+		c.CreatedAt = r.CreatedAt.Time
+		c.UpdatedAt = r.UpdatedAt.Time
+		if r.DeletedAt.Valid {
+			c.DeletedAt = &r.DeletedAt.Time
+		}
+	case sqlc.TransitionContactLifecycleStageRow:
+		c.ID = r.ID
+		c.WorkspaceID = r.WorkspaceId
+		c.FullName = r.FullName
+		if r.Email != nil {
+			c.Email = *r.Email
+		}
+		c.Phone = r.Phone
+		if r.OwnerId != nil {
+			c.ActorID = *r.OwnerId
+		}
+		c.CompanyID = r.CompanyId
+		c.LifecycleStage = domain.ContactLifecycleStage(r.LifecycleStage)
+		c.Attribution = attributionFromColumns(r.Source, r.SourceDetail, r.UtmSource, r.UtmMedium, r.UtmCampaign, r.UtmTerm, r.UtmContent)
+		c.NextStep = nextStepFromColumns(r.NextStepAt, r.NextStepNote)
+		setContactEmailVerification(&c, r.EmailStatus, r.EmailStatusReason, r.EmailCheckedAt)
+		c.ExternalID = r.ExternalId
 		c.Tags = r.TagLabels
 		c.CustomFields = make(map[string]interface{})
 		c.CreatedAt = r.CreatedAt.Time
@@ -106,6 +154,11 @@ func sqlcRowToDomainContact(row interface{}) *domain.Contact {
 			c.ActorID = *r.OwnerId
 		}
 		c.CompanyID = r.CompanyId
+		c.LifecycleStage = domain.ContactLifecycleStage(r.LifecycleStage)
+		c.Attribution = attributionFromColumns(r.Source, r.SourceDetail, r.UtmSource, r.UtmMedium, r.UtmCampaign, r.UtmTerm, r.UtmContent)
+		c.NextStep = nextStepFromColumns(r.NextStepAt, r.NextStepNote)
+		setContactEmailVerification(&c, r.EmailStatus, r.EmailStatusReason, r.EmailCheckedAt)
+		c.ExternalID = r.ExternalId
 		c.Tags = r.TagLabels
 		c.CustomFields = make(map[string]interface{})
 		c.CreatedAt = r.CreatedAt.Time
@@ -122,8 +175,9 @@ func sqlcRowToDomainContact(row interface{}) *domain.Contact {
 // Multi-tenant isolation enforced by workspace_id filter.
 func (r *ContactRepository) List(ctx context.Context, params domain.ListContactsParams) ([]domain.Contact, string, error) {
 	// Preparar parâmetros opcionais usando ponteiros para nil quando vazios
-	var ownerID, companyID, queryText *string
+	var ownerID, companyID, lifecycleStage, queryText *string
 	var cursorTime pgtype.Timestamp
+	var cursorID string
 
 	if params.ActorID != nil && *params.ActorID != "" {
 		ownerID = params.ActorID
@@ -131,25 +185,42 @@ func (r *ContactRepository) List(ctx context.Context, params domain.ListContacts
 	if params.CompanyID != nil && *params.CompanyID != "" {
 		companyID = params.CompanyID
 	}
+	if params.LifecycleStage != nil && *params.LifecycleStage != "" {
+		stage := string(*params.LifecycleStage)
+		lifecycleStage = &stage
+	}
 	if params.Cursor != nil && *params.Cursor != "" {
-		t, err := time.Parse(time.RFC3339, *params.Cursor)
+		t, id, err := decodeTimeCursor(*params.Cursor)
 		if err != nil {
-			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
+			return nil, "", err
 		}
 		cursorTime = pgtype.Timestamp{Time: t, Valid: true}
+		cursorID = id
 	}
 	if params.Query != nil && *params.Query != "" {
 		queryText = params.Query
 	}
+	var emailStatus *string
+	if params.EmailStatus != nil {
+		status := string(*params.EmailStatus)
+		emailStatus = &status
+	}
 
 	// Chamar SQLc query com campos nomeados semanticamente
 	rows, err := r.queries.ListContacts(ctx, sqlc.ListContactsParams{
 		WorkspaceId:    params.WorkspaceID,
 		OwnerId:        ownerID,
 		CompanyId:      companyID,
-		LifecycleStage: nil, // não temos este campo no domain ainda
+		LifecycleStage: lifecycleStage,
+		Source:         params.Attribution.Source,
+		UtmSource:      params.Attribution.UTMSource,
+		UtmMedium:      params.Attribution.UTMMedium,
+		UtmCampaign:    params.Attribution.UTMCampaign,
 		QueryText:      queryText,
+		FollowerId:     params.FollowerID,
+		EmailStatus:    emailStatus,
 		CursorTime:     cursorTime,
+		CursorId:       cursorID,
 		Limit:          int32(params.Limit + 1), // +1 para detectar se há próxima página
 	})
 	if err != nil {
@@ -166,7 +237,8 @@ func (r *ContactRepository) List(ctx context.Context, params domain.ListContacts
 	// Calcular nextCursor
 	var nextCursor string
 	if len(contacts) > params.Limit {
-		nextCursor = contacts[params.Limit-1].CreatedAt.Format(time.RFC3339)
+		last := contacts[params.Limit-1]
+		nextCursor = encodeTimeCursor(last.CreatedAt, last.ID)
 		contacts = contacts[:params.Limit]
 	}
 
@@ -192,6 +264,10 @@ func (r *ContactRepository) Get(ctx context.Context, workspaceID, contactID stri
 
 // Create inserts a new contact with workspace isolation.
 func (r *ContactRepository) Create(ctx context.Context, contact *domain.Contact) error {
+	if contact.LifecycleStage == "" {
+		contact.LifecycleStage = domain.ContactStageLead
+	}
+
 	row, err := r.queries.CreateContact(ctx, sqlc.CreateContactParams{
 		ID:                contact.ID,
 		FullName:          contact.FullName,
@@ -213,20 +289,32 @@ func (r *ContactRepository) Create(ctx context.Context, contact *domain.Contact)
 		Department:        nil,
 		DecisionRole:      nil,
 		TagLabels:         contact.Tags,
-		Source:            nil,
+		Source:            contact.Source,
 		LastInteractionAt: pgtype.Timestamp{},
 		OwnerId:           &contact.ActorID,
 		SocialUrls:        nil, // TODO: converter map para JSONB
 		CompanyId:         contact.CompanyID,
 		ContactScore:      0,
-		LifecycleStage:    sqlc.ContactLifecycleStageLEAD,
+		LifecycleStage:    sqlc.ContactLifecycleStage(contact.LifecycleStage),
 		AssignedToId:      nil,
 		CreatedById:       &contact.ActorID,
 		UpdatedById:       &contact.ActorID,
 		CreatedAt:         pgtype.Timestamp{Time: contact.CreatedAt, Valid: true},
 		UpdatedAt:         pgtype.Timestamp{Time: contact.UpdatedAt, Valid: true},
+		SourceDetail:      contact.SourceDetail,
+		UtmSource:         contact.UTMSource,
+		UtmMedium:         contact.UTMMedium,
+		UtmCampaign:       contact.UTMCampaign,
+		UtmTerm:           contact.UTMTerm,
+		UtmContent:        contact.UTMContent,
+		NextStepAt:        pgtype.Timestamp{Time: getTime(contact.NextStepAt), Valid: contact.NextStepAt != nil},
+		NextStepNote:      contact.NextStepNote,
+		ExternalId:        contact.ExternalID,
 	})
 	if err != nil {
+		if isExternalIDViolation(err) {
+			return ErrExternalIDConflict
+		}
 		return fmt.Errorf("insert contact: %w", err)
 	}
 
@@ -275,40 +363,156 @@ func (r *ContactRepository) Update(ctx context.Context, workspaceID, contactID s
 		SocialUrls:        nil, // TODO: converter map para JSONB
 		CompanyId:         updates.CompanyID,
 		ContactScore:      0,
-		LifecycleStage:    sqlc.ContactLifecycleStageLEAD,
 		AssignedToId:      nil,
+		NextStepAt:        pgtype.Timestamp{Time: getTime(updates.NextStepAt), Valid: updates.NextStepAt != nil},
+		NextStepNote:      updates.NextStepNote,
 		UpdatedById:       updates.ActorID,
 		UpdatedAt:         pgtype.Timestamp{Time: now, Valid: true},
 		UpdatedAt_2:       pgtype.Timestamp{Time: expectedUpdatedAt, Valid: true},
+		ExternalId:        updates.ExternalID,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrContactNotFound
 		}
+		if isExternalIDViolation(err) {
+			return nil, ErrExternalIDConflict
+		}
 		return nil, fmt.Errorf("update contact: %w", err)
 	}
 
 	return sqlcRowToDomainContact(row), nil
 }
 
-// SoftDelete marks a contact as deleted without removing from database.
-// Preserves data for audit and potential recovery.
-func (r *ContactRepository) SoftDelete(ctx context.Context, workspaceID, contactID string) error {
-	now := time.Now()
-
-	err := r.queries.SoftDeleteContact(ctx, sqlc.SoftDeleteContactParams{
+// TransitionLifecycleStage moves a contact from one funnel stage to another.
+// Returns ErrContactNotFound if the contact is gone or is no longer in fromStage.
+func (r *ContactRepository) TransitionLifecycleStage(ctx context.Context, workspaceID, contactID string, fromStage, toStage domain.ContactLifecycleStage, actorID string) (*domain.Contact, error) {
+	row, err := r.queries.TransitionContactLifecycleStage(ctx, sqlc.TransitionContactLifecycleStageParams{
+		ToStage:     sqlc.ContactLifecycleStage(toStage),
+		UpdatedById: &actorID,
+		UpdatedAt:   pgtype.Timestamp{Time: time.Now(), Valid: true},
 		ID:          contactID,
 		WorkspaceId: workspaceID,
-		DeletedAt:   pgtype.Timestamp{Time: now, Valid: true},
-		DeletedById: nil, // TODO: adicionar actorID quando disponível
+		FromStage:   sqlc.ContactLifecycleStage(fromStage),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrContactNotFound
+		}
+		return nil, fmt.Errorf("transition contact lifecycle stage: %w", err)
+	}
+
+	return sqlcRowToDomainContact(row), nil
+}
+
+// CountByLifecycleStage counts active contacts per lifecycle stage.
+// Stages without contacts are absent from the map.
+func (r *ContactRepository) CountByLifecycleStage(ctx context.Context, workspaceID string, ownerID, companyID *string) (map[domain.ContactLifecycleStage]int64, error) {
+	rows, err := r.queries.CountContactsByLifecycleStage(ctx, sqlc.CountContactsByLifecycleStageParams{
+		WorkspaceId: workspaceID,
+		OwnerId:     ownerID,
+		CompanyId:   companyID,
 	})
+	if err != nil {
+		return nil, fmt.Errorf("count contacts by lifecycle stage: %w", err)
+	}
+
+	counts := make(map[domain.ContactLifecycleStage]int64, len(rows))
+	for _, row := range rows {
+		counts[domain.ContactLifecycleStage(row.LifecycleStage)] = row.Total
+	}
+	return counts, nil
+}
+
+// SoftDelete marks a contact as deleted without removing from database.
+// Preserves data for audit and potential recovery (workspace trash). Deals and tasks of the
+// contact are detached in the same transaction (contactDetachedReferences).
+func (r *ContactRepository) SoftDelete(ctx context.Context, workspaceID, contactID, deletedByID string) error {
+	now := time.Now().UTC()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE public."Contact"
+		SET "deletedAt" = $3, "deletedById" = $4, "updatedAt" = $3
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`,
+		contactID, workspaceID, now, deletedByID,
+	)
 	if err != nil {
 		return fmt.Errorf("soft delete contact: %w", err)
 	}
+	if result.RowsAffected() == 0 {
+		return ErrContactNotFound
+	}
+
+	if err := detachReferences(ctx, tx, workspaceID, "contact", contactID, contactDetachedReferences, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Restore desfaz o soft delete de um contato (janela de undo ou lixeira) e reassocia os
+// negócios e tarefas desassociados no soft delete.
+func (r *ContactRepository) Restore(ctx context.Context, workspaceID, contactID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE public."Contact"
+		SET "deletedAt" = NULL, "deletedById" = NULL, "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NOT NULL`,
+		contactID, workspaceID,
+	)
+	if err != nil {
+		// Outro contato ativo passou a usar o mesmo externalId enquanto este estava na lixeira
+		if isExternalIDViolation(err) {
+			return ErrExternalIDConflict
+		}
+		return fmt.Errorf("restore contact: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrContactNotFound
+	}
+
+	if err := reattachReferences(ctx, tx, workspaceID, "contact", contactID, contactDetachedReferences); err != nil {
+		return err
+	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
+// FindIDByExternalID retorna o contato ativo do workspace com o externalId informado.
+// nil quando nenhum corresponde (o índice único garante no máximo um).
+func (r *ContactRepository) FindIDByExternalID(ctx context.Context, workspaceID, externalID string) (*string, error) {
+	query := `
+		SELECT id FROM public."Contact"
+		WHERE "workspaceId" = $1 AND "externalId" = $2 AND "deletedAt" IS NULL`
+
+	var id string
+	if err := r.pool.QueryRow(ctx, query, workspaceID, externalID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find contact by external id: %w", err)
+	}
+	return &id, nil
+}
+
 // Helper: retorna string vazia se pointer nil
 func getStringOrEmpty(s *string) string {
 	if s == nil {
@@ -0,0 +1,307 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrBulkUpdateJobNotFound = apperr.NotFound("contact bulk update job not found in workspace", "bulk update job not found")
+
+// ContactBulkRepository persiste os jobs de atualização em massa e aplica o patch aos contatos.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type ContactBulkRepository struct {
+	pool database.DB
+}
+
+func NewContactBulkRepository(pool database.DB) *ContactBulkRepository {
+	return &ContactBulkRepository{pool: pool}
+}
+
+const bulkUpdateJobColumns = `id, "workspaceId", "contactIds", filter, patch, status, total, processed, updated, "cursor",
+	error, "requestedById", "createdAt", "updatedAt", "startedAt", "completedAt"`
+
+// Create insere um job PENDING.
+func (r *ContactBulkRepository) Create(ctx context.Context, job *domain.ContactBulkUpdateJob) (*domain.ContactBulkUpdateJob, error) {
+	patch, err := json.Marshal(job.Patch)
+	if err != nil {
+		return nil, fmt.Errorf("marshal bulk update patch: %w", err)
+	}
+	var filter *string
+	if job.Filter != nil {
+		b, err := json.Marshal(job.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("marshal bulk update filter: %w", err)
+		}
+		f := string(b)
+		filter = &f
+	}
+	var contactIDs []string
+	if len(job.ContactIDs) > 0 {
+		contactIDs = job.ContactIDs
+	}
+
+	query := `
+		INSERT INTO public."ContactBulkUpdateJob" (id, "workspaceId", "contactIds", filter, patch, status, "requestedById")
+		VALUES ($1, $2, $3, $4::jsonb, $5::jsonb, $6, $7)
+		RETURNING ` + bulkUpdateJobColumns
+
+	created, err := scanBulkUpdateJob(r.pool.QueryRow(ctx, query,
+		job.ID, job.WorkspaceID, contactIDs, filter, string(patch), domain.BulkUpdateStatusPending, job.RequestedByID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert contact bulk update job: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um job do workspace.
+func (r *ContactBulkRepository) Get(ctx context.Context, workspaceID, jobID string) (*domain.ContactBulkUpdateJob, error) {
+	query := `
+		SELECT ` + bulkUpdateJobColumns + `
+		FROM public."ContactBulkUpdateJob"
+		WHERE id = $1 AND "workspaceId" = $2`
+
+	job, err := scanBulkUpdateJob(r.pool.QueryRow(ctx, query, jobID, workspaceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBulkUpdateJobNotFound
+		}
+		return nil, fmt.Errorf("query contact bulk update job: %w", err)
+	}
+	return job, nil
+}
+
+// ClaimNext marca como RUNNING o job PENDING mais antigo (ou um RUNNING sem progresso desde
+// staleBefore, abandonado por um worker que caiu) e o retorna. nil quando a fila está vazia.
+// Cada lote gravado renova "updatedAt", então jobs longos não são tomados por outro worker.
+func (r *ContactBulkRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.ContactBulkUpdateJob, error) {
+	query := `
+		UPDATE public."ContactBulkUpdateJob"
+		SET status = 'RUNNING', "startedAt" = COALESCE("startedAt", NOW()), "updatedAt" = NOW(), error = NULL
+		WHERE id = (
+			SELECT id FROM public."ContactBulkUpdateJob"
+			WHERE status = 'PENDING' OR (status = 'RUNNING' AND "updatedAt" < $1)
+			ORDER BY "createdAt"
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + bulkUpdateJobColumns
+
+	job, err := scanBulkUpdateJob(r.pool.QueryRow(ctx, query, staleBefore))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim contact bulk update job: %w", err)
+	}
+	return job, nil
+}
+
+// CountTargets conta os contatos (não excluídos) selecionados pelo job.
+func (r *ContactBulkRepository) CountTargets(ctx context.Context, job *domain.ContactBulkUpdateJob) (int, error) {
+	where, args := bulkTargetPredicate(job)
+
+	var total int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM public."Contact" WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("count bulk update targets: %w", err)
+	}
+	return total, nil
+}
+
+// NextBatch retorna os próximos ids selecionados pelo job após o cursor, em ordem de id.
+func (r *ContactBulkRepository) NextBatch(ctx context.Context, job *domain.ContactBulkUpdateJob, limit int) ([]string, error) {
+	where, args := bulkTargetPredicate(job)
+	args = append(args, job.Cursor, limit)
+	n := len(args)
+
+	query := fmt.Sprintf(`
+		SELECT id FROM public."Contact"
+		WHERE %s AND ($%d::TEXT IS NULL OR id > $%d)
+		ORDER BY id
+		LIMIT $%d`, where, n-1, n-1, n)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query bulk update batch: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan bulk update batch: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ApplyPatch aplica o patch do job aos contatos do lote e retorna quantos foram de fato alterados.
+// Tags preservam a ordem existente (sem duplicatas); o estágio só muda nos contatos cuja
+// transição é permitida (ContactStagesAllowedInto).
+func (r *ContactBulkRepository) ApplyPatch(ctx context.Context, job *domain.ContactBulkUpdateJob, contactIDs []string) (int, error) {
+	patch := job.Patch
+
+	var toStage *string
+	allowedFrom := []string{}
+	if patch.LifecycleStage != nil {
+		stage := string(*patch.LifecycleStage)
+		toStage = &stage
+		allowedFrom = domain.ContactStagesAllowedInto(*patch.LifecycleStage)
+	}
+
+	query := `
+		WITH planned AS (
+			SELECT c.id,
+			       CASE WHEN cardinality(COALESCE($3::TEXT[], '{}')) + cardinality(COALESCE($4::TEXT[], '{}')) = 0 THEN c."tagLabels"
+			            ELSE ARRAY(
+			                SELECT t FROM unnest(COALESCE(c."tagLabels", '{}') || COALESCE($3::TEXT[], '{}')) WITH ORDINALITY AS u(t, n)
+			                WHERE NOT t = ANY(COALESCE($4::TEXT[], '{}'))
+			                GROUP BY t
+			                ORDER BY MIN(n))
+			       END AS tags,
+			       COALESCE($5::TEXT, c."ownerId") AS owner,
+			       CASE WHEN c."lifecycleStage"::TEXT = ANY($7::TEXT[]) THEN $6::"ContactLifecycleStage" ELSE c."lifecycleStage" END AS stage
+			FROM public."Contact" c
+			WHERE c."workspaceId" = $1 AND c.id = ANY($2) AND c."deletedAt" IS NULL
+		)
+		UPDATE public."Contact" c
+		SET "tagLabels" = p.tags, "ownerId" = p.owner, "lifecycleStage" = p.stage,
+		    "updatedById" = $8, "updatedAt" = NOW()
+		FROM planned p
+		WHERE c.id = p.id
+		  AND (c."tagLabels" IS DISTINCT FROM p.tags OR c."ownerId" IS DISTINCT FROM p.owner OR c."lifecycleStage" IS DISTINCT FROM p.stage)`
+
+	result, err := r.pool.Exec(ctx, query,
+		job.WorkspaceID, contactIDs, patch.AddTags, patch.RemoveTags, patch.ActorID, toStage, allowedFrom, job.RequestedByID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("apply contact bulk patch: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// SaveProgress grava o avanço do job após um lote (cursor = último id processado).
+func (r *ContactBulkRepository) SaveProgress(ctx context.Context, jobID, cursor string, processed, updated int) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."ContactBulkUpdateJob"
+		SET "cursor" = $2, processed = processed + $3, updated = updated + $4, "updatedAt" = NOW()
+		WHERE id = $1`,
+		jobID, cursor, processed, updated,
+	)
+	if err != nil {
+		return fmt.Errorf("save contact bulk update progress: %w", err)
+	}
+	return nil
+}
+
+// SetTotal grava o total de contatos selecionados (calculado no início da execução).
+func (r *ContactBulkRepository) SetTotal(ctx context.Context, jobID string, total int) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."ContactBulkUpdateJob"
+		SET total = $2, "updatedAt" = NOW()
+		WHERE id = $1`,
+		jobID, total,
+	)
+	if err != nil {
+		return fmt.Errorf("set contact bulk update total: %w", err)
+	}
+	return nil
+}
+
+// Complete finaliza o job.
+func (r *ContactBulkRepository) Complete(ctx context.Context, jobID string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."ContactBulkUpdateJob"
+		SET status = 'COMPLETED', "completedAt" = NOW(), "updatedAt" = NOW()
+		WHERE id = $1`,
+		jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("complete contact bulk update job: %w", err)
+	}
+	return nil
+}
+
+// Fail finaliza o job com a mensagem de erro. Lotes já aplicados permanecem.
+func (r *ContactBulkRepository) Fail(ctx context.Context, jobID, reason string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."ContactBulkUpdateJob"
+		SET status = 'FAILED', error = $2, "completedAt" = NOW(), "updatedAt" = NOW()
+		WHERE id = $1`,
+		jobID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("fail contact bulk update job: %w", err)
+	}
+	return nil
+}
+
+// bulkTargetPredicate monta o WHERE que seleciona os contatos do job: lista de ids ou
+// os mesmos filtros da listagem de contatos. Sempre escopado ao workspace.
+func bulkTargetPredicate(job *domain.ContactBulkUpdateJob) (string, []interface{}) {
+	conditions := []string{`"workspaceId" = $1`, `"deletedAt" IS NULL`}
+	args := []interface{}{job.WorkspaceID}
+
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+
+	if len(job.ContactIDs) > 0 {
+		add(`id = ANY($%d)`, job.ContactIDs)
+	}
+	if f := job.Filter; f != nil {
+		if f.Query != nil && *f.Query != "" {
+			add(`to_tsvector('simple', "fullName" || ' ' || COALESCE("email", '')) @@ plainto_tsquery('simple', $%d)`, *f.Query)
+		}
+		if f.ActorID != nil && *f.ActorID != "" {
+			add(`"ownerId" = $%d`, *f.ActorID)
+		}
+		if f.CompanyID != nil && *f.CompanyID != "" {
+			add(`"companyId" = $%d`, *f.CompanyID)
+		}
+		if f.LifecycleStage != nil && *f.LifecycleStage != "" {
+			add(`"lifecycleStage"::TEXT = $%d`, string(*f.LifecycleStage))
+		}
+		if f.Tag != nil && *f.Tag != "" {
+			add(`$%d = ANY("tagLabels")`, *f.Tag)
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func scanBulkUpdateJob(row pgx.Row) (*domain.ContactBulkUpdateJob, error) {
+	var j domain.ContactBulkUpdateJob
+	var filter, patch []byte
+	err := row.Scan(
+		&j.ID, &j.WorkspaceID, &j.ContactIDs, &filter, &patch, &j.Status, &j.Total, &j.Processed, &j.Updated, &j.Cursor,
+		&j.Error, &j.RequestedByID, &j.CreatedAt, &j.UpdatedAt, &j.StartedAt, &j.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(filter) > 0 {
+		if err := json.Unmarshal(filter, &j.Filter); err != nil {
+			return nil, fmt.Errorf("decode bulk update filter: %w", err)
+		}
+	}
+	if err := json.Unmarshal(patch, &j.Patch); err != nil {
+		return nil, fmt.Errorf("decode bulk update patch: %w", err)
+	}
+	j.ComputeProgress()
+	return &j, nil
+}
@@ -0,0 +1,100 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestContactBulkRepository_ApplyPatch_Integration
+func TestContactBulkRepository_ApplyPatch_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+	bulk := repo.NewContactBulkRepository(pool)
+	contacts := repo.NewContactRepository(pool)
+
+	get := func(workspaceID, contactID string) *domain.Contact {
+		t.Helper()
+		c, err := contacts.Get(ctx, workspaceID, contactID)
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("tags are added and removed without duplicates, keeping the existing order", func(t *testing.T) {
+		tagged := f.Contact(func(c *domain.Contact) { c.Tags = []string{"vip", "old", "b2b"} })
+		untagged := f.Contact()
+		job := &domain.ContactBulkUpdateJob{
+			WorkspaceID:   f.WorkspaceID,
+			Patch:         domain.ContactBulkPatch{AddTags: []string{"b2b", "q3"}, RemoveTags: []string{"old"}},
+			RequestedByID: f.UserID,
+		}
+
+		updated, err := bulk.ApplyPatch(ctx, job, []string{tagged.ID, untagged.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated)
+		assert.Equal(t, []string{"vip", "b2b", "q3"}, get(f.WorkspaceID, tagged.ID).Tags)
+		assert.Equal(t, []string{"b2b", "q3"}, get(f.WorkspaceID, untagged.ID).Tags)
+
+		updated, err = bulk.ApplyPatch(ctx, job, []string{tagged.ID, untagged.ID})
+		require.NoError(t, err)
+		assert.Zero(t, updated, "re-applying the same patch changes nothing")
+	})
+
+	t.Run("applies the stage only where the transition is allowed", func(t *testing.T) {
+		lead := f.Contact()
+		customer := f.Contact(func(c *domain.Contact) { c.LifecycleStage = domain.ContactStageCustomer })
+		manager := f.Member(domain.RoleManager)
+		job := &domain.ContactBulkUpdateJob{
+			WorkspaceID: f.WorkspaceID,
+			Patch: domain.ContactBulkPatch{
+				LifecycleStage: factory.Ptr(domain.ContactStageMQL),
+				ActorID:        &manager,
+			},
+			RequestedByID: f.UserID,
+		}
+
+		updated, err := bulk.ApplyPatch(ctx, job, []string{lead.ID, customer.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated, "both change owner")
+
+		gotLead, gotCustomer := get(f.WorkspaceID, lead.ID), get(f.WorkspaceID, customer.ID)
+		assert.Equal(t, domain.ContactStageMQL, gotLead.LifecycleStage)
+		assert.Equal(t, domain.ContactStageCustomer, gotCustomer.LifecycleStage, "CUSTOMER cannot go back to MQL")
+		assert.Equal(t, manager, gotLead.ActorID)
+		assert.Equal(t, manager, gotCustomer.ActorID)
+	})
+
+	t.Run("skips deleted contacts and ids from other workspaces", func(t *testing.T) {
+		mine := f.Contact()
+		deleted := f.Contact()
+		require.NoError(t, contacts.SoftDelete(ctx, f.WorkspaceID, deleted.ID, f.UserID))
+		foreign := other.Contact(func(c *domain.Contact) { c.Tags = []string{"theirs"} })
+
+		job := &domain.ContactBulkUpdateJob{
+			WorkspaceID:   f.WorkspaceID,
+			ContactIDs:    []string{mine.ID, deleted.ID, foreign.ID, "ctc_missing"},
+			Patch:         domain.ContactBulkPatch{AddTags: []string{"imported"}},
+			RequestedByID: f.UserID,
+		}
+
+		total, err := bulk.CountTargets(ctx, job)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		batch, err := bulk.NextBatch(ctx, job, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{mine.ID}, batch)
+
+		updated, err := bulk.ApplyPatch(ctx, job, job.ContactIDs)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated)
+		assert.Equal(t, []string{"imported"}, get(f.WorkspaceID, mine.ID).Tags)
+		assert.Equal(t, []string{"theirs"}, get(other.WorkspaceID, foreign.ID).Tags)
+	})
+}
@@ -0,0 +1,62 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestContactLifecycle_Integration
+func TestContactLifecycle_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	contacts := repo.NewContactRepository(pool)
+
+	member := f.Member(domain.RoleUser)
+	contact := f.Contact()
+	f.Contact(func(c *domain.Contact) { c.LifecycleStage = domain.ContactStageSQL })
+	f.Contact(func(c *domain.Contact) { c.LifecycleStage = domain.ContactStageSQL; c.ActorID = member })
+	deleted := f.Contact(func(c *domain.Contact) { c.LifecycleStage = domain.ContactStageCustomer })
+	require.NoError(t, contacts.SoftDelete(ctx, f.WorkspaceID, deleted.ID, f.UserID))
+
+	t.Run("transition moves from the expected stage", func(t *testing.T) {
+		got, err := contacts.TransitionLifecycleStage(ctx, f.WorkspaceID, contact.ID, domain.ContactStageLead, domain.ContactStageMQL, member)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactStageMQL, got.LifecycleStage)
+	})
+
+	t.Run("stale from stage is not applied", func(t *testing.T) {
+		// Outra request já tirou o contato de LEAD
+		_, err := contacts.TransitionLifecycleStage(ctx, f.WorkspaceID, contact.ID, domain.ContactStageLead, domain.ContactStageSQL, f.UserID)
+		assert.ErrorIs(t, err, repo.ErrContactNotFound)
+
+		got, err := contacts.Get(ctx, f.WorkspaceID, contact.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactStageMQL, got.LifecycleStage)
+	})
+
+	t.Run("other workspaces are not touched", func(t *testing.T) {
+		other := factory.New(t, pool)
+		_, err := contacts.TransitionLifecycleStage(ctx, other.WorkspaceID, contact.ID, domain.ContactStageMQL, domain.ContactStageSQL, f.UserID)
+		assert.ErrorIs(t, err, repo.ErrContactNotFound)
+	})
+
+	t.Run("funnel counts active contacts per stage", func(t *testing.T) {
+		counts, err := contacts.CountByLifecycleStage(ctx, f.WorkspaceID, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.ContactLifecycleStage]int64{
+			domain.ContactStageMQL: 1,
+			domain.ContactStageSQL: 2,
+		}, counts)
+
+		counts, err = contacts.CountByLifecycleStage(ctx, f.WorkspaceID, &member, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.ContactLifecycleStage]int64{domain.ContactStageSQL: 1}, counts)
+	})
+}
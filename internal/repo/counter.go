@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+)
+
+// CounterRepository calcula os contadores do dashboard direto no Postgres.
+// Usado na reconciliação do cache (miss ou expiração); o caminho quente lê do Redis.
+type CounterRepository struct {
+	pool database.DB
+}
+
+func NewCounterRepository(pool database.DB) *CounterRepository {
+	return &CounterRepository{pool: pool}
+}
+
+// tasksDueTodaySQL tarefas abertas com vencimento no dia. $1 = workspace, $2/$3 = dia.
+const tasksDueTodaySQL = `
+	SELECT COUNT(*) FROM public."Task"
+	WHERE workspace_id = $1 AND deleted_at IS NULL
+	  AND status IN ('TODO', 'IN_PROGRESS')
+	  AND due_date >= $2 AND due_date < $3`
+
+// Count retorna os contadores do workspace para o período informado. tasksDueToday conta
+// todas as tarefas do workspace (visão do admin); é o valor mantido no cache.
+func (r *CounterRepository) Count(ctx context.Context, workspaceID string, period domain.CounterPeriod) (*domain.WorkspaceCounters, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM public."Deal"
+			 WHERE "workspaceId" = $1 AND "deletedAt" IS NULL AND stage = 'OPEN'),
+			(` + tasksDueTodaySQL + `),
+			(SELECT COUNT(*) FROM public."Contact"
+			 WHERE "workspaceId" = $1 AND "deletedAt" IS NULL AND "createdAt" >= $4)`
+
+	c := &domain.WorkspaceCounters{ReconciledAt: time.Now().UTC()}
+	err := r.pool.QueryRow(ctx, query, workspaceID, period.DayStart, period.DayEnd, period.WeekStart).
+		Scan(&c.OpenDeals, &c.TasksDueToday, &c.NewLeadsThisWeek)
+	if err != nil {
+		return nil, fmt.Errorf("count workspace counters: %w", err)
+	}
+	return c, nil
+}
+
+// TasksDueToday conta as tarefas abertas com vencimento no dia que o viewer enxerga, com o
+// mesmo filtro de visibilidade da listagem de tarefas. viewer nil (admin) conta todas.
+func (r *CounterRepository) TasksDueToday(ctx context.Context, workspaceID string, period domain.CounterPeriod, viewer *string) (int64, error) {
+	query := tasksDueTodaySQL
+	args := []interface{}{workspaceID, period.DayStart, period.DayEnd}
+	if viewer != nil {
+		query += " AND " + taskVisibleTo(4)
+		args = append(args, *viewer)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count tasks due today: %w", err)
+	}
+	return count, nil
+}
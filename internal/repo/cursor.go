@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"encoding/base64"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+)
+
+// Cursores das listagens paginadas (contatos, empresas, pipelines, tarefas): o valor de
+// ordenação da última linha da página + o id dela, em base64url. O id desempata linhas com o
+// mesmo valor (ex.: criadas no mesmo milissegundo), então a próxima página começa exatamente
+// depois da anterior, sem perder nem repetir registros.
+
+// ErrInvalidCursor cursor que não foi emitido pela listagem.
+var ErrInvalidCursor = apperr.Define(apperr.CodeInvalidParameter, http.StatusBadRequest,
+	"invalid pagination cursor", "cursor is invalid; send the nextCursor of the previous page")
+
+const cursorSeparator = "|"
+
+func encodeCursor(value, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value + cursorSeparator + id))
+}
+
+func decodeCursor(cursor string) (value, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", ErrInvalidCursor
+	}
+	value, id, ok := strings.Cut(string(raw), cursorSeparator)
+	if !ok || value == "" || id == "" {
+		return "", "", ErrInvalidCursor
+	}
+	return value, id, nil
+}
+
+// encodeTimeCursor cursor das listagens em createdAt DESC, id DESC.
+func encodeTimeCursor(t time.Time, id string) string {
+	return encodeCursor(t.UTC().Format(time.RFC3339Nano), id)
+}
+
+// decodeTimeCursor aceita também o formato antigo (createdAt RFC3339 sem id): o id vazio faz
+// a comparação ("createdAt", id) < (t, id vazio) equivaler ao antigo "createdAt" < t.
+func decodeTimeCursor(cursor string) (time.Time, string, error) {
+	if t, err := time.Parse(time.RFC3339, cursor); err == nil {
+		return t.UTC(), "", nil
+	}
+
+	value, id, err := decodeCursor(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	return t.UTC(), id, nil
+}
+
+// encodePositionCursor cursor das tarefas (position ASC, id ASC).
+func encodePositionCursor(position float64, id string) string {
+	return encodeCursor(strconv.FormatFloat(position, 'g', -1, 64), id)
+}
+
+func decodePositionCursor(cursor string) (float64, string, error) {
+	value, id, err := decodeCursor(cursor)
+	if err != nil {
+		return 0, "", err
+	}
+	position, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(position) || math.IsInf(position, 0) {
+		return 0, "", ErrInvalidCursor
+	}
+	return position, id, nil
+}
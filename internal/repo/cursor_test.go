@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeCursor_RoundTrip(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 30, 45, 123_000_000, time.UTC)
+
+	cursor := encodeTimeCursor(at, "cnt_01jah3m9x4k7v2q8r5t6w0y1za")
+	gotTime, gotID, err := decodeTimeCursor(cursor)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(gotTime))
+	assert.Equal(t, "cnt_01jah3m9x4k7v2q8r5t6w0y1za", gotID)
+}
+
+func TestTimeCursor_AcceptsLegacyTimestamp(t *testing.T) {
+	gotTime, gotID, err := decodeTimeCursor("2026-10-18T12:30:45-03:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 15, 30, 45, 0, time.UTC), gotTime)
+	assert.Empty(t, gotID)
+}
+
+func TestPositionCursor_RoundTrip(t *testing.T) {
+	for _, position := range []float64{0, 1000, -1, 1500.5, 0.0000001, 1e15} {
+		cursor := encodePositionCursor(position, "tsk_1")
+		got, id, err := decodePositionCursor(cursor)
+		require.NoError(t, err)
+		assert.Equal(t, position, got)
+		assert.Equal(t, "tsk_1", id)
+	}
+}
+
+func TestCursor_RejectsInvalid(t *testing.T) {
+	invalid := []string{
+		"not base64!",
+		encodeCursor("", "id"),
+		encodeCursor("2026-10-18T12:30:45Z", ""),
+		"MjAyNi0xMC0xOFQxMjozMDo0NVo", // timestamp sem id
+	}
+	for _, cursor := range invalid {
+		_, _, err := decodeTimeCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+		_, _, err = decodePositionCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+
+	for _, value := range []string{"NaN", "Inf", "-Inf", "1e400", "abc"} {
+		_, _, err := decodePositionCursor(encodeCursor(value, "tsk_1"))
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
+
+// FuzzDecodeTimeCursor: qualquer cursor vindo do request decodifica sem panic, e o que é
+// aceito reencoda para a mesma posição.
+func FuzzDecodeTimeCursor(f *testing.F) {
+	f.Add("")
+	f.Add("2026-10-18T12:30:45Z")
+	f.Add(encodeTimeCursor(time.Date(2026, 10, 18, 0, 0, 0, 1_000_000, time.UTC), "cnt_1"))
+	f.Add(encodeCursor("2026-10-18T12:30:45Z", "id|with|pipes"))
+	f.Add("'; DROP TABLE \"Contact\"; --")
+
+	f.Fuzz(func(t *testing.T, cursor string) {
+		at, id, err := decodeTimeCursor(cursor)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("unexpected error type: %v", err)
+			}
+			return
+		}
+		if id == "" {
+			return // formato antigo
+		}
+		gotTime, gotID, err := decodeTimeCursor(encodeTimeCursor(at, id))
+		if err != nil || !gotTime.Equal(at) || gotID != id {
+			t.Fatalf("round trip of %q: got (%v, %q, %v), want (%v, %q)", cursor, gotTime, gotID, err, at, id)
+		}
+	})
+}
+
+func FuzzDecodePositionCursor(f *testing.F) {
+	f.Add("")
+	f.Add(encodePositionCursor(1000, "tsk_1"))
+	f.Add(encodePositionCursor(-0.5, "tsk_2"))
+	f.Add(encodeCursor("NaN", "tsk_3"))
+	f.Add("2026-10-18T12:30:45Z")
+
+	f.Fuzz(func(t *testing.T, cursor string) {
+		position, id, err := decodePositionCursor(cursor)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("unexpected error type: %v", err)
+			}
+			return
+		}
+		if math.IsNaN(position) || math.IsInf(position, 0) || id == "" {
+			t.Fatalf("accepted unusable cursor %q: (%v, %q)", cursor, position, id)
+		}
+		gotPosition, gotID, err := decodePositionCursor(encodePositionCursor(position, id))
+		if err != nil || gotPosition != position || gotID != id {
+			t.Fatalf("round trip of %q: got (%v, %q, %v), want (%v, %q)", cursor, gotPosition, gotID, err, position, id)
+		}
+	})
+}
@@ -0,0 +1,335 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrCustomObjectTypeNotFound   = apperr.NotFound("custom object not found in workspace", "custom object not found")
+	ErrCustomObjectKeyConflict    = apperr.Conflict("custom object with this key already exists in workspace", "custom object with this key already exists")
+	ErrCustomObjectRecordNotFound = apperr.NotFound("custom object record not found in workspace", "custom object record not found")
+)
+
+// CustomObjectRepository persiste as definições de objetos customizados e seus registros.
+// IMPORTANT: Uses camelCase column names with double quotes; fields e data são JSONB.
+type CustomObjectRepository struct {
+	pool database.DB
+}
+
+func NewCustomObjectRepository(pool database.DB) *CustomObjectRepository {
+	return &CustomObjectRepository{pool: pool}
+}
+
+const customObjectTypeColumns = `id, "workspaceId", key, name, description, fields,
+	"createdById", "updatedById", "createdAt", "updatedAt", "deletedAt"`
+
+// As colunas de registro são lidas com o JOIN em CustomObjectType (alias t) para devolver objectKey.
+const customObjectRecordColumns = `r.id, r."workspaceId", r."objectTypeId", t.key, r.name, r.data,
+	r."createdById", r."updatedById", r."createdAt", r."updatedAt"`
+
+// CreateType insere uma nova definição. Falha com ErrCustomObjectKeyConflict se a chave já existe.
+func (r *CustomObjectRepository) CreateType(ctx context.Context, t *domain.CustomObjectType) (*domain.CustomObjectType, error) {
+	fields, err := json.Marshal(t.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("marshal custom object fields: %w", err)
+	}
+
+	query := `
+		INSERT INTO public."CustomObjectType" (id, "workspaceId", key, name, description, fields, "createdById")
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + customObjectTypeColumns
+
+	created, err := scanCustomObjectType(r.pool.QueryRow(ctx, query,
+		t.ID, t.WorkspaceID, t.Key, t.Name, t.Description, fields, t.CreatedByID,
+	))
+	if err != nil {
+		if isCustomObjectKeyViolation(err) {
+			return nil, ErrCustomObjectKeyConflict
+		}
+		return nil, fmt.Errorf("insert custom object type: %w", err)
+	}
+	return created, nil
+}
+
+// GetTypeByKey retorna a definição ativa do objeto pela chave.
+func (r *CustomObjectRepository) GetTypeByKey(ctx context.Context, workspaceID, key string) (*domain.CustomObjectType, error) {
+	query := `
+		SELECT ` + customObjectTypeColumns + `
+		FROM public."CustomObjectType"
+		WHERE "workspaceId" = $1 AND key = $2 AND "deletedAt" IS NULL`
+
+	t, err := scanCustomObjectType(r.pool.QueryRow(ctx, query, workspaceID, key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomObjectTypeNotFound
+		}
+		return nil, fmt.Errorf("query custom object type: %w", err)
+	}
+	return t, nil
+}
+
+// ListTypes retorna as definições do workspace ordenadas por nome.
+func (r *CustomObjectRepository) ListTypes(ctx context.Context, workspaceID string) ([]domain.CustomObjectType, error) {
+	query := `
+		SELECT ` + customObjectTypeColumns + `
+		FROM public."CustomObjectType"
+		WHERE "workspaceId" = $1 AND "deletedAt" IS NULL
+		ORDER BY name ASC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query custom object types: %w", err)
+	}
+	defer rows.Close()
+
+	types := []domain.CustomObjectType{}
+	for rows.Next() {
+		t, err := scanCustomObjectType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan custom object type: %w", err)
+		}
+		types = append(types, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate custom object types: %w", err)
+	}
+
+	return types, nil
+}
+
+// UpdateType aplica um PATCH na definição (campos nil não são alterados).
+func (r *CustomObjectRepository) UpdateType(ctx context.Context, workspaceID, key string, req *domain.UpdateCustomObjectTypeRequest, actorID string) (*domain.CustomObjectType, error) {
+	var fields []byte
+	if req.Fields != nil {
+		var err error
+		if fields, err = json.Marshal(req.Fields); err != nil {
+			return nil, fmt.Errorf("marshal custom object fields: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE public."CustomObjectType" SET
+			name = COALESCE($3, name),
+			description = COALESCE($4, description),
+			fields = COALESCE($5::JSONB, fields),
+			"updatedById" = $6,
+			"updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND key = $2 AND "deletedAt" IS NULL
+		RETURNING ` + customObjectTypeColumns
+
+	t, err := scanCustomObjectType(r.pool.QueryRow(ctx, query,
+		workspaceID, key, req.Name, req.Description, fields, actorID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomObjectTypeNotFound
+		}
+		return nil, fmt.Errorf("update custom object type: %w", err)
+	}
+	return t, nil
+}
+
+// DeleteType faz soft delete da definição. Os registros ficam inacessíveis junto com ela
+// (as rotas /objects/{objectKey} resolvem só objetos ativos) e a chave pode ser reutilizada.
+func (r *CustomObjectRepository) DeleteType(ctx context.Context, workspaceID, key, actorID string) error {
+	query := `
+		UPDATE public."CustomObjectType"
+		SET "deletedAt" = NOW(), "updatedById" = $3, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND key = $2 AND "deletedAt" IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, workspaceID, key, actorID)
+	if err != nil {
+		return fmt.Errorf("delete custom object type: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCustomObjectTypeNotFound
+	}
+	return nil
+}
+
+// CreateRecord insere um registro do objeto.
+func (r *CustomObjectRepository) CreateRecord(ctx context.Context, rec *domain.CustomObjectRecord) (*domain.CustomObjectRecord, error) {
+	data, err := json.Marshal(rec.Data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal custom object data: %w", err)
+	}
+
+	query := `
+		WITH r AS (
+			INSERT INTO public."CustomObjectRecord" (id, "workspaceId", "objectTypeId", name, data, "createdById")
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING *
+		)
+		SELECT ` + customObjectRecordColumns + `
+		FROM r JOIN public."CustomObjectType" t ON t.id = r."objectTypeId"`
+
+	created, err := scanCustomObjectRecord(r.pool.QueryRow(ctx, query,
+		rec.ID, rec.WorkspaceID, rec.ObjectTypeID, rec.Name, data, rec.CreatedByID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert custom object record: %w", err)
+	}
+	return created, nil
+}
+
+// GetRecord retorna um registro ativo do objeto.
+func (r *CustomObjectRepository) GetRecord(ctx context.Context, workspaceID, objectTypeID, recordID string) (*domain.CustomObjectRecord, error) {
+	query := `
+		SELECT ` + customObjectRecordColumns + `
+		FROM public."CustomObjectRecord" r
+		JOIN public."CustomObjectType" t ON t.id = r."objectTypeId"
+		WHERE r.id = $1 AND r."workspaceId" = $2 AND r."objectTypeId" = $3 AND r."deletedAt" IS NULL`
+
+	rec, err := scanCustomObjectRecord(r.pool.QueryRow(ctx, query, recordID, workspaceID, objectTypeID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomObjectRecordNotFound
+		}
+		return nil, fmt.Errorf("query custom object record: %w", err)
+	}
+	return rec, nil
+}
+
+// ListRecords lista os registros do objeto, mais recentes primeiro.
+// Busca Limit+1 linhas para que o chamador detecte a próxima página.
+func (r *CustomObjectRepository) ListRecords(ctx context.Context, params domain.ListCustomObjectRecordsParams) ([]domain.CustomObjectRecord, error) {
+	var filters []byte
+	if len(params.Filters) > 0 {
+		var err error
+		if filters, err = json.Marshal(params.Filters); err != nil {
+			return nil, fmt.Errorf("marshal custom object filters: %w", err)
+		}
+	}
+
+	query := `
+		SELECT ` + customObjectRecordColumns + `
+		FROM public."CustomObjectRecord" r
+		JOIN public."CustomObjectType" t ON t.id = r."objectTypeId"
+		WHERE r."workspaceId" = $1 AND r."objectTypeId" = $2 AND r."deletedAt" IS NULL
+		  AND ($3::TEXT IS NULL OR r.name ILIKE '%' || $3 || '%')
+		  AND ($4::JSONB IS NULL OR r.data @> $4)
+		  AND ($5::TIMESTAMP IS NULL OR r."createdAt" < $5)
+		ORDER BY r."createdAt" DESC, r.id DESC
+		LIMIT $6`
+
+	rows, err := r.pool.Query(ctx, query,
+		params.WorkspaceID, params.ObjectTypeID, params.Query, filters, params.Cursor, params.Limit+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query custom object records: %w", err)
+	}
+	defer rows.Close()
+
+	records := []domain.CustomObjectRecord{}
+	for rows.Next() {
+		rec, err := scanCustomObjectRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan custom object record: %w", err)
+		}
+		records = append(records, *rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate custom object records: %w", err)
+	}
+
+	return records, nil
+}
+
+// UpdateRecord aplica um PATCH no registro. data é mesclado ao existente e chaves com null são removidas.
+func (r *CustomObjectRepository) UpdateRecord(ctx context.Context, workspaceID, objectTypeID, recordID string, req *domain.UpdateCustomObjectRecordRequest, actorID string) (*domain.CustomObjectRecord, error) {
+	var data []byte
+	if req.Data != nil {
+		var err error
+		if data, err = json.Marshal(req.Data); err != nil {
+			return nil, fmt.Errorf("marshal custom object data: %w", err)
+		}
+	}
+
+	query := `
+		WITH r AS (
+			UPDATE public."CustomObjectRecord" SET
+				name = COALESCE($4, name),
+				data = CASE WHEN $5::JSONB IS NULL THEN data ELSE jsonb_strip_nulls(data || $5::JSONB) END,
+				"updatedById" = $6,
+				"updatedAt" = NOW()
+			WHERE id = $1 AND "workspaceId" = $2 AND "objectTypeId" = $3 AND "deletedAt" IS NULL
+			RETURNING *
+		)
+		SELECT ` + customObjectRecordColumns + `
+		FROM r JOIN public."CustomObjectType" t ON t.id = r."objectTypeId"`
+
+	rec, err := scanCustomObjectRecord(r.pool.QueryRow(ctx, query,
+		recordID, workspaceID, objectTypeID, req.Name, data, actorID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomObjectRecordNotFound
+		}
+		return nil, fmt.Errorf("update custom object record: %w", err)
+	}
+	return rec, nil
+}
+
+// DeleteRecord faz soft delete do registro.
+func (r *CustomObjectRepository) DeleteRecord(ctx context.Context, workspaceID, objectTypeID, recordID, actorID string) error {
+	query := `
+		UPDATE public."CustomObjectRecord"
+		SET "deletedAt" = NOW(), "deletedById" = $4
+		WHERE id = $1 AND "workspaceId" = $2 AND "objectTypeId" = $3 AND "deletedAt" IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, recordID, workspaceID, objectTypeID, actorID)
+	if err != nil {
+		return fmt.Errorf("delete custom object record: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCustomObjectRecordNotFound
+	}
+	return nil
+}
+
+func isCustomObjectKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "CustomObjectType_workspaceId_key_key"
+}
+
+func scanCustomObjectType(row pgx.Row) (*domain.CustomObjectType, error) {
+	var t domain.CustomObjectType
+	var fields []byte
+	err := row.Scan(
+		&t.ID, &t.WorkspaceID, &t.Key, &t.Name, &t.Description, &fields,
+		&t.CreatedByID, &t.UpdatedByID, &t.CreatedAt, &t.UpdatedAt, &t.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(fields, &t.Fields); err != nil {
+		return nil, fmt.Errorf("unmarshal custom object fields: %w", err)
+	}
+	return &t, nil
+}
+
+func scanCustomObjectRecord(row pgx.Row) (*domain.CustomObjectRecord, error) {
+	var rec domain.CustomObjectRecord
+	var data []byte
+	err := row.Scan(
+		&rec.ID, &rec.WorkspaceID, &rec.ObjectTypeID, &rec.ObjectKey, &rec.Name, &data,
+		&rec.CreatedByID, &rec.UpdatedByID, &rec.CreatedAt, &rec.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &rec.Data); err != nil {
+		return nil, fmt.Errorf("unmarshal custom object data: %w", err)
+	}
+	return &rec, nil
+}
@@ -3,25 +3,30 @@ package repo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
 	"linkko-api/internal/repo/sqlc"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
-	ErrDealNotFound = errors.New("deal not found in workspace")
+	ErrDealNotFound = apperr.NotFound("deal not found in workspace", "deal not found")
+	// ErrDealPipelineDeleted o negócio foi excluído junto com o pipeline e só volta com ele
+	ErrDealPipelineDeleted = apperr.Conflict("deal pipeline is deleted", "the deal's pipeline is deleted; restore the pipeline first")
 )
 
 type DealRepository struct {
-	pool    *pgxpool.Pool
+	pool    database.DB
 	queries *sqlc.Queries
 }
 
-func NewDealRepository(pool *pgxpool.Pool) *DealRepository {
+func NewDealRepository(pool database.DB) *DealRepository {
 	return &DealRepository{
 		pool:    pool,
 		queries: sqlc.New(pool),
@@ -58,6 +63,22 @@ func (r *DealRepository) Create(ctx context.Context, d *domain.Deal) (*domain.De
 		OwnerId:           d.OwnerID,
 		CreatedById:       d.CreatedByID,
 		Description:       d.Description,
+		Source:            d.Source,
+		SourceDetail:      d.SourceDetail,
+		UtmSource:         d.UTMSource,
+		UtmMedium:         d.UTMMedium,
+		UtmCampaign:       d.UTMCampaign,
+		UtmTerm:           d.UTMTerm,
+		UtmContent:        d.UTMContent,
+		NextStepAt:        pgtype.Timestamp{Time: getTime(d.NextStepAt), Valid: d.NextStepAt != nil},
+		NextStepNote:      d.NextStepNote,
+		ExternalId:        d.ExternalID,
+	}
+
+	// Categoria manual; sem ela o banco deriva da etapa
+	if d.ForecastCategoryOverridden {
+		category := string(d.ForecastCategory)
+		params.ForecastCategory = &category
 	}
 
 	if d.ExpectedCloseDate != nil {
@@ -66,6 +87,9 @@ func (r *DealRepository) Create(ctx context.Context, d *domain.Deal) (*domain.De
 
 	row, err := r.queries.CreateDeal(ctx, params)
 	if err != nil {
+		if isExternalIDViolation(err) {
+			return nil, ErrExternalIDConflict
+		}
 		return nil, err
 	}
 
@@ -87,12 +111,21 @@ func (r *DealRepository) Get(ctx context.Context, workspaceID, dealID string) (*
 	return r.sqlcGetDealRowToDomain(&row), nil
 }
 
-func (r *DealRepository) List(ctx context.Context, workspaceID string, pipelineID, stageID, ownerID *string) ([]domain.Deal, error) {
+// rottingOnly restringe aos deals marcados como parados (rottingSince preenchido).
+// query busca no nome do deal e nos nomes do contato e da empresa vinculados.
+func (r *DealRepository) List(ctx context.Context, workspaceID string, pipelineID, stageID, ownerID, followerID, query *string, rottingOnly bool, attribution domain.AttributionFilter) ([]domain.Deal, error) {
 	rows, err := r.queries.ListDeals(ctx, sqlc.ListDealsParams{
 		WorkspaceId: workspaceID,
 		PipelineId:  pipelineID,
 		StageId:     stageID,
 		OwnerId:     ownerID,
+		Source:      attribution.Source,
+		UtmSource:   attribution.UTMSource,
+		UtmMedium:   attribution.UTMMedium,
+		UtmCampaign: attribution.UTMCampaign,
+		RottingOnly: rottingOnly,
+		FollowerId:  followerID,
+		QueryText:   query,
 	})
 	if err != nil {
 		return nil, err
@@ -133,12 +166,33 @@ func (r *DealRepository) Update(ctx context.Context, workspaceID, dealID string,
 	if d.OwnerID != nil {
 		params.OwnerId = d.OwnerID
 	}
+	if d.NextStepAt != nil {
+		params.NextStepAt = pgtype.Timestamp{Time: *d.NextStepAt, Valid: true}
+	}
+	if d.NextStepNote != nil {
+		params.NextStepNote = d.NextStepNote
+	}
+	if d.ExternalID != nil {
+		params.ExternalId = d.ExternalID
+	}
+	// AUTO volta a derivar a categoria da etapa
+	if d.ForecastCategory != nil {
+		overridden := *d.ForecastCategory != domain.ForecastAuto
+		params.ForecastCategoryOverridden = &overridden
+		if overridden {
+			category := string(*d.ForecastCategory)
+			params.ForecastCategory = &category
+		}
+	}
 
 	row, err := r.queries.UpdateDeal(ctx, params)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrDealNotFound
 		}
+		if isExternalIDViolation(err) {
+			return nil, ErrExternalIDConflict
+		}
 		return nil, err
 	}
 
@@ -185,6 +239,64 @@ func (r *DealRepository) CreateHistory(ctx context.Context, h *domain.DealStageH
 	return err
 }
 
+// Restore desfaz o soft delete de um negócio (lixeira do workspace). Falha com
+// ErrDealPipelineDeleted se o pipeline do negócio está excluído.
+func (r *DealRepository) Restore(ctx context.Context, workspaceID, dealID string) error {
+	var pipelineDeleted bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT p."deletedAt" IS NOT NULL
+		FROM public."Deal" d
+		JOIN public."Pipeline" p ON p.id = d."pipelineId"
+		WHERE d.id = $1 AND d."workspaceId" = $2 AND d."deletedAt" IS NOT NULL`,
+		dealID, workspaceID,
+	).Scan(&pipelineDeleted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrDealNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get deal pipeline: %w", err)
+	}
+	if pipelineDeleted {
+		return ErrDealPipelineDeleted
+	}
+
+	result, err := r.pool.Exec(ctx, `
+		UPDATE public."Deal"
+		SET "deletedAt" = NULL, "deletedById" = NULL, "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NOT NULL`,
+		dealID, workspaceID,
+	)
+	if err != nil {
+		// Outro deal ativo passou a usar o mesmo externalId enquanto este estava na lixeira
+		if isExternalIDViolation(err) {
+			return ErrExternalIDConflict
+		}
+		return fmt.Errorf("restore deal: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDealNotFound
+	}
+	return nil
+}
+
+// FindIDByExternalID retorna o deal ativo do workspace com o externalId informado.
+// nil quando nenhum corresponde (o índice único garante no máximo um).
+func (r *DealRepository) FindIDByExternalID(ctx context.Context, workspaceID, externalID string) (*string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id FROM public."Deal"
+		WHERE "workspaceId" = $1 AND "externalId" = $2 AND "deletedAt" IS NULL`,
+		workspaceID, externalID,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find deal by external id: %w", err)
+	}
+	return &id, nil
+}
+
 // Mappers
 func (r *DealRepository) sqlcDealToDomain(row *sqlc.Deal) *domain.Deal {
 	return &domain.Deal{
@@ -208,6 +320,13 @@ func (r *DealRepository) sqlcDealToDomain(row *sqlc.Deal) *domain.Deal {
 		UpdatedByID:       row.UpdatedById,
 		CreatedAt:         row.CreatedAt.Time,
 		UpdatedAt:         row.UpdatedAt.Time,
+		Attribution:       attributionFromColumns(row.Source, row.SourceDetail, row.UtmSource, row.UtmMedium, row.UtmCampaign, row.UtmTerm, row.UtmContent),
+		NextStep:          nextStepFromColumns(row.NextStepAt, row.NextStepNote),
+		RottingSince:      toTimePtr(row.RottingSince),
+		ExternalID:        row.ExternalId,
+
+		ForecastCategory:           domain.ForecastCategory(row.ForecastCategory),
+		ForecastCategoryOverridden: row.ForecastCategoryOverridden,
 	}
 }
 
@@ -233,8 +352,15 @@ func (r *DealRepository) sqlcGetDealRowToDomain(row *sqlc.GetDealRow) *domain.De
 		UpdatedByID:       row.UpdatedById,
 		CreatedAt:         row.CreatedAt.Time,
 		UpdatedAt:         row.UpdatedAt.Time,
+		Attribution:       attributionFromColumns(row.Source, row.SourceDetail, row.UtmSource, row.UtmMedium, row.UtmCampaign, row.UtmTerm, row.UtmContent),
+		NextStep:          nextStepFromColumns(row.NextStepAt, row.NextStepNote),
+		RottingSince:      toTimePtr(row.RottingSince),
+		ExternalID:        row.ExternalId,
 		ContactName:       row.Contactname,
 		CompanyName:       row.Companyname,
+
+		ForecastCategory:           domain.ForecastCategory(row.ForecastCategory),
+		ForecastCategoryOverridden: row.ForecastCategoryOverridden,
 	}
 }
 
@@ -260,8 +386,15 @@ func (r *DealRepository) sqlcListDealsRowToDomain(row *sqlc.ListDealsRow) *domai
 		UpdatedByID:       row.UpdatedById,
 		CreatedAt:         row.CreatedAt.Time,
 		UpdatedAt:         row.UpdatedAt.Time,
+		Attribution:       attributionFromColumns(row.Source, row.SourceDetail, row.UtmSource, row.UtmMedium, row.UtmCampaign, row.UtmTerm, row.UtmContent),
+		NextStep:          nextStepFromColumns(row.NextStepAt, row.NextStepNote),
+		RottingSince:      toTimePtr(row.RottingSince),
+		ExternalID:        row.ExternalId,
 		ContactName:       row.Contactname,
 		CompanyName:       row.Companyname,
+
+		ForecastCategory:           domain.ForecastCategory(row.ForecastCategory),
+		ForecastCategoryOverridden: row.ForecastCategoryOverridden,
 	}
 }
 
@@ -272,3 +405,178 @@ func toFloat64PtrDeal(f pgtype.Float8) *float64 {
 	}
 	return nil
 }
+
+// dealRottingSQL calcula, para cada deal OPEN em estágio com rottingDays, a última
+// atividade (criação, mudança de estágio ou atividade na timeline, exceto o próprio
+// DEAL_ROTTING) e o momento em que o deal passa a ficar parado contando dias corridos.
+// Atividades anteriores à criação do deal não mudam o GREATEST; o limite em "createdAt"
+// permite o partition pruning em "Activity".
+const dealRottingSQL = `
+	SELECT d.id, s."rottingDays", la.at AS "lastActivityAt",
+	       la.at + make_interval(days => s."rottingDays") AS "rottingSince"
+	FROM public."Deal" d
+	JOIN public."PipelineStage" s
+	  ON s.id = d."stageId" AND s."deletedAt" IS NULL AND s."rottingDays" IS NOT NULL
+	CROSS JOIN LATERAL (
+		SELECT GREATEST(
+			d."createdAt",
+			(SELECT MAX(h."createdAt") FROM public."DealStageHistory" h WHERE h."dealId" = d.id),
+			(SELECT MAX(a."createdAt") FROM public."Activity" a
+			 WHERE a."dealId" = d.id AND a."activityType" <> 'DEAL_ROTTING'
+			   AND a."createdAt" > d."createdAt")
+		) AS at
+	) la
+	WHERE d."deletedAt" IS NULL AND d.stage = 'OPEN'`
+
+// ClearRecovered desmarca deals que deixaram de estar parados: tiveram atividade ou mudaram
+// de estágio depois de rottingSince, foram fechados, o estágio não tem mais rottingDays ou o
+// prazo do estágio aumentou. Deals que voltaram a ficar parados depois de nova atividade
+// também são desmarcados para que o worker os marque (e emita o evento) de novo.
+// rottingSince gravado conta dias úteis, por isso nunca é anterior ao prazo em dias corridos.
+func (r *DealRepository) ClearRecovered(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		WITH current AS (` + dealRottingSQL + ` AND d."rottingSince" IS NOT NULL)
+		UPDATE public."Deal" d
+		SET "rottingSince" = NULL
+		WHERE d."rottingSince" IS NOT NULL AND d."deletedAt" IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM current c
+			WHERE c.id = d.id AND c."lastActivityAt" < d."rottingSince"
+			  AND c."rottingSince" <= d."rottingSince" AND d."rottingSince" <= $1
+		  )`
+
+	result, err := r.pool.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("clear recovered deals: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// ListRottingCandidates retorna até limit deals ainda não marcados cujo prazo em dias
+// corridos (lastActivityAt + rottingDays) já passou, prazo mais antigo primeiro.
+// RottingSince vem em dias corridos: quem chama aplica o calendário do workspace.
+func (r *DealRepository) ListRottingCandidates(ctx context.Context, now time.Time, limit int) ([]domain.RottingDeal, error) {
+	query := `
+		SELECT d.id, d."workspaceId", d."pipelineId", d."stageId", d."contactId", d."companyId",
+		       d."ownerId", d."createdById", c."rottingDays", c."lastActivityAt", c."rottingSince"
+		FROM (` + dealRottingSQL + ` AND d."rottingSince" IS NULL) c
+		JOIN public."Deal" d ON d.id = c.id
+		WHERE c."rottingSince" <= $1
+		ORDER BY c."rottingSince"
+		LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list rotting candidates: %w", err)
+	}
+	defer rows.Close()
+
+	deals := []domain.RottingDeal{}
+	for rows.Next() {
+		var d domain.RottingDeal
+		if err := rows.Scan(
+			&d.ID, &d.WorkspaceID, &d.PipelineID, &d.StageID, &d.ContactID, &d.CompanyID,
+			&d.OwnerID, &d.CreatedByID, &d.RottingDays, &d.LastActivityAt, &d.RottingSince,
+		); err != nil {
+			return nil, fmt.Errorf("scan rotting deal: %w", err)
+		}
+		deals = append(deals, d)
+	}
+	return deals, rows.Err()
+}
+
+// MarkRotting grava rottingSince se o deal ainda não estiver marcado. Retorna false se
+// outro worker marcou antes. Não altera updatedAt: a marcação não é uma edição do deal.
+func (r *DealRepository) MarkRotting(ctx context.Context, dealID string, rottingSince time.Time) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE public."Deal"
+		SET "rottingSince" = $2
+		WHERE id = $1 AND "rottingSince" IS NULL AND "deletedAt" IS NULL`,
+		dealID, rottingSince,
+	)
+	if err != nil {
+		return false, fmt.Errorf("mark rotting deal: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListSLAInputs retorna os dados para calcular os timers de SLA dos deals em estágio TICKET
+// com ao menos uma meta configurada. A primeira resposta é a primeira interação a partir da
+// abertura do ticket (o limite em "createdAt" também permite o partition pruning). dealIDs
+// nil não filtra por id; openOnly restringe a tickets ainda OPEN.
+func (r *DealRepository) ListSLAInputs(ctx context.Context, workspaceID string, dealIDs []string, pipelineID, ownerID *string, openOnly bool) ([]domain.DealSLAInput, error) {
+	query := `
+		SELECT d.id, d.name, d."pipelineId", d."stageId", d."ownerId", d."createdAt",
+		       s."firstResponseSlaMinutes", s."resolutionSlaMinutes",
+		       (SELECT MIN(a."createdAt") FROM public."Activity" a
+		        WHERE a."dealId" = d.id AND a."activityType" IN ('EMAIL', 'CALL', 'MESSAGE', 'MEETING')
+		          AND a."createdAt" >= d."createdAt") AS "firstResponseAt",
+		       CASE WHEN d.stage <> 'OPEN' THEN d."closedAt" END AS "resolvedAt"
+		FROM public."Deal" d
+		JOIN public."PipelineStage" s
+		  ON s.id = d."stageId" AND s."deletedAt" IS NULL AND s."type" = 'TICKET'
+		 AND (s."firstResponseSlaMinutes" IS NOT NULL OR s."resolutionSlaMinutes" IS NOT NULL)
+		WHERE d."workspaceId" = $1 AND d."deletedAt" IS NULL
+		  AND ($2::TEXT[] IS NULL OR d.id = ANY($2))
+		  AND ($3::TEXT IS NULL OR d."pipelineId" = $3)
+		  AND ($4::TEXT IS NULL OR d."ownerId" = $4)
+		  AND (NOT $5::BOOLEAN OR d.stage = 'OPEN')
+		ORDER BY d."createdAt"`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, dealIDs, pipelineID, ownerID, openOnly)
+	if err != nil {
+		return nil, fmt.Errorf("list deal sla inputs: %w", err)
+	}
+	defer rows.Close()
+
+	inputs := []domain.DealSLAInput{}
+	for rows.Next() {
+		var in domain.DealSLAInput
+		var firstResponse, resolution *int32
+		if err := rows.Scan(
+			&in.DealID, &in.Name, &in.PipelineID, &in.StageID, &in.OwnerID, &in.OpenedAt,
+			&firstResponse, &resolution, &in.FirstResponseAt, &in.ResolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan deal sla input: %w", err)
+		}
+		in.FirstResponseSLAMinutes = int32PtrToInt(firstResponse)
+		in.ResolutionSLAMinutes = int32PtrToInt(resolution)
+		inputs = append(inputs, in)
+	}
+	return inputs, rows.Err()
+}
+
+// StageAggregates retorna quantidade e valor dos negócios de cada estágio, na ordem pedida
+// (estágios sem negócios vêm zerados).
+func (r *DealRepository) StageAggregates(ctx context.Context, workspaceID string, stageIDs []string) ([]domain.StageAggregate, error) {
+	query := `
+		SELECT s.id, COUNT(d.id), COALESCE(SUM(d.value), 0)::DOUBLE PRECISION
+		FROM unnest($2::TEXT[]) WITH ORDINALITY AS s(id, ord)
+		LEFT JOIN public."Deal" d ON d."stageId" = s.id AND d."workspaceId" = $1 AND d."deletedAt" IS NULL
+		GROUP BY s.id, s.ord
+		ORDER BY s.ord`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, stageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query stage aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	aggregates := []domain.StageAggregate{}
+	for rows.Next() {
+		var a domain.StageAggregate
+		if err := rows.Scan(&a.StageID, &a.Count, &a.Value); err != nil {
+			return nil, fmt.Errorf("scan stage aggregate: %w", err)
+		}
+		aggregates = append(aggregates, a)
+	}
+	return aggregates, rows.Err()
+}
+
+func int32PtrToInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/domain"
+)
+
+// dealGroupSQL trecho de SQL de uma dimensão de GET /deals?groupBy= (whitelist: entra via Sprintf).
+// Os meses usam o fuso de $12 (colunas gravadas em UTC).
+type dealGroupSQL struct {
+	key   string
+	label string
+	joins string
+	group string
+	order string
+}
+
+var dealGroups = map[domain.DealGroupBy]dealGroupSQL{
+	domain.DealGroupByOwner: {
+		key: `d."ownerId"`, label: `NULL::TEXT`, group: `1`, order: `3 DESC, 1 NULLS LAST`,
+	},
+	domain.DealGroupByMonth: {
+		key: `to_char(d."createdAt" AT TIME ZONE 'UTC' AT TIME ZONE $12::TEXT, 'YYYY-MM')`, label: `NULL::TEXT`, group: `1`, order: `1`,
+	},
+	domain.DealGroupByStage: {
+		key:   `d."stageId"`,
+		label: `s.name`,
+		joins: `LEFT JOIN "PipelineStage" s ON s.id = d."stageId"
+LEFT JOIN "Pipeline" p ON p.id = s."pipelineId"`,
+		group: `d."stageId", s.name, p.name, p.id, s."orderIndex"`,
+		order: `p.name, p.id, s."orderIndex"`,
+	},
+}
+
+// Filtros idênticos aos de ListDeals (queries/deals.sql)
+const dealAggregateSQL = `
+SELECT %s AS key, %s AS label, COUNT(*), COALESCE(SUM(d.value), 0)::DOUBLE PRECISION
+FROM "Deal" d
+%s
+WHERE d."workspaceId" = $1
+    AND ($2::TEXT IS NULL OR d."pipelineId" = $2)
+    AND ($3::TEXT IS NULL OR d."stageId" = $3)
+    AND ($4::TEXT IS NULL OR d."ownerId" = $4)
+    AND ($5::TEXT IS NULL OR d.source = $5)
+    AND ($6::TEXT IS NULL OR d."utmSource" = $6)
+    AND ($7::TEXT IS NULL OR d."utmMedium" = $7)
+    AND ($8::TEXT IS NULL OR d."utmCampaign" = $8)
+    AND (NOT $9::BOOLEAN OR d."rottingSince" IS NOT NULL)
+    AND ($10::TEXT IS NULL OR EXISTS (
+        SELECT 1 FROM "Follower" f
+        WHERE f."entityType" = 'DEAL' AND f."entityId" = d.id AND f."userId" = $10
+    ))
+    AND ($11::TEXT IS NULL
+        OR to_tsvector('simple', d.name) @@ plainto_tsquery('simple', $11)
+        OR d."contactId" IN (
+            SELECT qc.id FROM "Contact" qc
+            WHERE qc."workspaceId" = $1
+              AND to_tsvector('simple', qc."fullName" || ' ' || COALESCE(qc."email", '')) @@ plainto_tsquery('simple', $11)
+        )
+        OR d."companyId" IN (
+            SELECT qco.id FROM "Company" qco
+            WHERE qco."workspaceId" = $1
+              AND to_tsvector('simple', qco."name" || ' ' || COALESCE(qco."website", '')) @@ plainto_tsquery('simple', $11)
+        ))
+    AND d."deletedAt" IS NULL
+GROUP BY %s
+ORDER BY %s`
+
+// Aggregate agrupa os deals filtrados pela dimensão, com contagem e soma de value por bucket.
+func (r *DealRepository) Aggregate(ctx context.Context, params domain.DealAggregateParams) ([]domain.DealBucket, error) {
+	g, ok := dealGroups[params.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported deal groupBy: %q", params.GroupBy)
+	}
+
+	args := []any{
+		params.WorkspaceID, params.PipelineID, params.StageID, params.OwnerID,
+		params.Attribution.Source, params.Attribution.UTMSource, params.Attribution.UTMMedium, params.Attribution.UTMCampaign,
+		params.RottingOnly, params.FollowerID, params.Query,
+	}
+	if params.GroupBy == domain.DealGroupByMonth {
+		args = append(args, domain.LocationName(params.Location))
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(dealAggregateSQL, g.key, g.label, g.joins, g.group, g.order), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query deal aggregation: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []domain.DealBucket{}
+	for rows.Next() {
+		var b domain.DealBucket
+		var count int64
+		var sum float64
+		if err := rows.Scan(&b.Key, &b.Label, &count, &sum); err != nil {
+			return nil, fmt.Errorf("scan deal aggregation row: %w", err)
+		}
+		b.Count, b.SumValue = &count, &sum
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate deal aggregation rows: %w", err)
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,99 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestDealRepository_Aggregate_Integration
+func TestDealRepository_Aggregate_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	deals := repo.NewDealRepository(pool)
+
+	pipeline := f.Pipeline()
+	manager := f.Member(domain.RoleManager)
+	deal := func(stage int, value float64, ownerID *string, createdAt time.Time) {
+		d := f.Deal(func(d *domain.Deal) {
+			d.PipelineID = pipeline.ID
+			d.StageID = &pipeline.Stages[stage].ID
+			d.Value = &value
+			d.OwnerID = ownerID
+		})
+		_, err := pool.Exec(ctx, `UPDATE public."Deal" SET "createdAt" = $2 WHERE id = $1`, d.ID, createdAt)
+		require.NoError(t, err)
+	}
+
+	// 01/02 01:00 UTC ainda é 31/01 em São Paulo
+	deal(0, 100, &f.UserID, time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	deal(0, 250.5, &f.UserID, time.Date(2026, 2, 1, 1, 0, 0, 0, time.UTC))
+	deal(1, 400, &manager, time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC))
+	deal(1, 600, &manager, time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC))
+	deal(1, 50, &manager, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	deal(0, 75, nil, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+	// Fora do filtro de pipeline
+	f.Deal()
+
+	aggregate := func(t *testing.T, groupBy domain.DealGroupBy, loc *time.Location) []domain.DealBucket {
+		t.Helper()
+		buckets, err := deals.Aggregate(ctx, domain.DealAggregateParams{
+			WorkspaceID: f.WorkspaceID,
+			GroupBy:     groupBy,
+			Location:    loc,
+			PipelineID:  &pipeline.ID,
+		})
+		require.NoError(t, err)
+		return buckets
+	}
+	bucket := func(key *string, label *string, count int64, sum float64) domain.DealBucket {
+		return domain.DealBucket{Key: key, Label: label, Count: &count, SumValue: &sum}
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		// Maior contagem primeiro, sem dono por último
+		assert.Equal(t, []domain.DealBucket{
+			bucket(&manager, nil, 3, 1050),
+			bucket(&f.UserID, nil, 2, 350.5),
+			bucket(nil, nil, 1, 75),
+		}, aggregate(t, domain.DealGroupByOwner, nil))
+	})
+
+	t.Run("stage in pipeline order", func(t *testing.T) {
+		lead, proposal := pipeline.Stages[0], pipeline.Stages[1]
+		assert.Equal(t, []domain.DealBucket{
+			bucket(&lead.ID, &lead.Name, 3, 425.5),
+			bucket(&proposal.ID, &proposal.Name, 3, 1050),
+		}, aggregate(t, domain.DealGroupByStage, nil))
+	})
+
+	t.Run("month in UTC", func(t *testing.T) {
+		assert.Equal(t, []domain.DealBucket{
+			bucket(factory.Ptr("2026-01"), nil, 1, 100),
+			bucket(factory.Ptr("2026-02"), nil, 3, 1250.5),
+			bucket(factory.Ptr("2026-03"), nil, 2, 125),
+		}, aggregate(t, domain.DealGroupByMonth, nil))
+	})
+
+	t.Run("month in the request time zone", func(t *testing.T) {
+		saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.DealBucket{
+			bucket(factory.Ptr("2026-01"), nil, 2, 350.5),
+			bucket(factory.Ptr("2026-02"), nil, 2, 1000),
+			bucket(factory.Ptr("2026-03"), nil, 2, 125),
+		}, aggregate(t, domain.DealGroupByMonth, saoPaulo))
+	})
+
+	t.Run("unsupported dimension", func(t *testing.T) {
+		_, err := deals.Aggregate(ctx, domain.DealAggregateParams{WorkspaceID: f.WorkspaceID, GroupBy: "pipeline"})
+		assert.Error(t, err)
+	})
+}
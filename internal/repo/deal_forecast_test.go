@@ -0,0 +1,128 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestDealRepository_ForecastCategory_Integration
+func TestDealRepository_ForecastCategory_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	deals := repo.NewDealRepository(pool)
+	pipelines := repo.NewPipelineRepository(pool)
+	reports := repo.NewReportRepository(pool)
+
+	// Etapas da factory: Lead 10%, Proposta 50%, Fechado 100%
+	pipeline := f.Pipeline()
+	lead, proposal, closing := pipeline.Stages[0].ID, pipeline.Stages[1].ID, pipeline.Stages[2].ID
+	closeDate := time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC)
+	deal := func(stageID string, value float64, opts ...func(*domain.Deal)) *domain.Deal {
+		return f.Deal(append([]func(*domain.Deal){func(d *domain.Deal) {
+			d.PipelineID = pipeline.ID
+			d.StageID = &stageID
+			d.Value = &value
+			d.Probability = nil
+			d.ExpectedCloseDate = &closeDate
+		}}, opts...)...)
+	}
+	get := func(t *testing.T, dealID string) *domain.Deal {
+		t.Helper()
+		d, err := deals.Get(ctx, f.WorkspaceID, dealID)
+		require.NoError(t, err)
+		return d
+	}
+
+	inLead := deal(lead, 1000)
+	inProposal := deal(proposal, 2000)
+	closingOpen := deal(closing, 3000)
+	won := deal(closing, 4000, func(d *domain.Deal) {
+		d.Stage = domain.DealStageWon
+		d.ClosedAt = &closeDate
+	})
+	lost := deal(lead, 500, func(d *domain.Deal) {
+		d.Stage = domain.DealStageLost
+		d.ClosedAt = &closeDate
+	})
+	pinned := deal(proposal, 800, func(d *domain.Deal) {
+		d.ForecastCategory = domain.ForecastOmitted
+		d.ForecastCategoryOverridden = true
+	})
+
+	t.Run("derived from the stage probability", func(t *testing.T) {
+		for _, tt := range []struct {
+			deal *domain.Deal
+			want domain.ForecastCategory
+		}{
+			{inLead, domain.ForecastPipeline},
+			{inProposal, domain.ForecastPipeline},
+			{closingOpen, domain.ForecastCommit},
+			{won, domain.ForecastCommit},
+			{lost, domain.ForecastOmitted},
+		} {
+			got := get(t, tt.deal.ID)
+			assert.Equal(t, tt.want, got.ForecastCategory, got.Name)
+			assert.False(t, got.ForecastCategoryOverridden, got.Name)
+		}
+		got := get(t, pinned.ID)
+		assert.Equal(t, domain.ForecastOmitted, got.ForecastCategory)
+		assert.True(t, got.ForecastCategoryOverridden)
+	})
+
+	t.Run("stage probability change recalculates non-manual deals", func(t *testing.T) {
+		require.NoError(t, pipelines.UpdateStage(ctx, proposal, &domain.UpdateStageRequest{Probability: factory.Ptr(70)}))
+		n, err := pipelines.RecalculateStageForecast(ctx, proposal)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		assert.Equal(t, domain.ForecastBestCase, get(t, inProposal.ID).ForecastCategory)
+		assert.Equal(t, domain.ForecastOmitted, get(t, pinned.ID).ForecastCategory)
+	})
+
+	t.Run("AUTO follows the stage again", func(t *testing.T) {
+		updated, err := deals.Update(ctx, f.WorkspaceID, pinned.ID,
+			&domain.UpdateDealRequest{ForecastCategory: factory.Ptr(domain.ForecastAuto)}, f.UserID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ForecastBestCase, updated.ForecastCategory)
+		assert.False(t, updated.ForecastCategoryOverridden)
+
+		updated, err = deals.Update(ctx, f.WorkspaceID, inLead.ID,
+			&domain.UpdateDealRequest{ForecastCategory: factory.Ptr(domain.ForecastCommit)}, f.UserID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ForecastCommit, updated.ForecastCategory)
+		assert.True(t, updated.ForecastCategoryOverridden)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 1, 0)
+		params := domain.ForecastReportParams{WorkspaceID: f.WorkspaceID, PipelineID: &pipeline.ID, From: &from, To: &to}
+
+		rows, err := reports.Forecast(ctx, params)
+		require.NoError(t, err)
+		report := domain.NewForecastReport(params, rows)
+
+		// COMMIT: inLead (manual, 10%), closingOpen (100%), won; BEST_CASE: inProposal e pinned (70%)
+		assert.Equal(t, []domain.ForecastReportRow{
+			{Category: domain.ForecastCommit, Deals: 3, Value: 8000, WeightedValue: 7100},
+			{Category: domain.ForecastBestCase, Deals: 2, Value: 2800, WeightedValue: 1960},
+			{Category: domain.ForecastPipeline},
+			{Category: domain.ForecastOmitted, Deals: 1, Value: 500},
+		}, report.Categories)
+
+		// Fora do período
+		later := to.AddDate(0, 1, 0)
+		params.From, params.To = &to, &later
+		rows, err = reports.Forecast(ctx, params)
+		require.NoError(t, err)
+		assert.Empty(t, rows)
+	})
+}
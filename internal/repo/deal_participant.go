@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrDealParticipantNotFound = apperr.NotFound("deal participant not found in workspace", "participant not found")
+	ErrDealParticipantConflict = apperr.Conflict("contact is already a participant of this deal", "contact is already a participant of this deal")
+)
+
+// DealParticipantRepository persiste os contatos participantes de negócios.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type DealParticipantRepository struct {
+	pool database.DB
+}
+
+func NewDealParticipantRepository(pool database.DB) *DealParticipantRepository {
+	return &DealParticipantRepository{pool: pool}
+}
+
+const dealParticipantColumns = `p.id, p."workspaceId", p."dealId", p."contactId", p.role, p."createdById",
+	p."createdAt", p."updatedAt", c."fullName", c.email`
+
+// ListForDeals retorna os participantes dos negócios informados, agrupados por dealId
+// (ordem de inclusão). Contatos removidos não aparecem.
+func (r *DealParticipantRepository) ListForDeals(ctx context.Context, workspaceID string, dealIDs []string) (map[string][]domain.DealParticipant, error) {
+	query := `
+		SELECT ` + dealParticipantColumns + `
+		FROM public."DealParticipant" p
+		JOIN public."Contact" c ON c.id = p."contactId" AND c."deletedAt" IS NULL
+		WHERE p."workspaceId" = $1 AND p."dealId" = ANY($2::TEXT[])
+		ORDER BY p."createdAt", p.id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, dealIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query deal participants: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string][]domain.DealParticipant, len(dealIDs))
+	for rows.Next() {
+		p, err := scanDealParticipant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan deal participant: %w", err)
+		}
+		out[p.DealID] = append(out[p.DealID], *p)
+	}
+	return out, rows.Err()
+}
+
+// List retorna os participantes de um negócio.
+func (r *DealParticipantRepository) List(ctx context.Context, workspaceID, dealID string) ([]domain.DealParticipant, error) {
+	byDeal, err := r.ListForDeals(ctx, workspaceID, []string{dealID})
+	if err != nil {
+		return nil, err
+	}
+	if participants := byDeal[dealID]; participants != nil {
+		return participants, nil
+	}
+	return []domain.DealParticipant{}, nil
+}
+
+// Add inclui o contato no negócio. Falha com ErrDealParticipantConflict se ele já participa.
+func (r *DealParticipantRepository) Add(ctx context.Context, p *domain.DealParticipant) (*domain.DealParticipant, error) {
+	query := `
+		WITH p AS (
+			INSERT INTO public."DealParticipant" (id, "workspaceId", "dealId", "contactId", role, "createdById")
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING *
+		)
+		SELECT ` + dealParticipantColumns + `
+		FROM p JOIN public."Contact" c ON c.id = p."contactId"`
+
+	created, err := scanDealParticipant(r.pool.QueryRow(ctx, query,
+		p.ID, p.WorkspaceID, p.DealID, p.ContactID, p.Role, p.CreatedByID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_deal_participant" {
+			return nil, ErrDealParticipantConflict
+		}
+		return nil, fmt.Errorf("insert deal participant: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateRole troca o papel do contato no negócio.
+func (r *DealParticipantRepository) UpdateRole(ctx context.Context, workspaceID, dealID, contactID string, role domain.DealParticipantRole) (*domain.DealParticipant, error) {
+	query := `
+		WITH p AS (
+			UPDATE public."DealParticipant"
+			SET role = $4, "updatedAt" = NOW()
+			WHERE "workspaceId" = $1 AND "dealId" = $2 AND "contactId" = $3
+			RETURNING *
+		)
+		SELECT ` + dealParticipantColumns + `
+		FROM p JOIN public."Contact" c ON c.id = p."contactId"`
+
+	updated, err := scanDealParticipant(r.pool.QueryRow(ctx, query, workspaceID, dealID, contactID, role))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDealParticipantNotFound
+		}
+		return nil, fmt.Errorf("update deal participant: %w", err)
+	}
+	return updated, nil
+}
+
+// Remove retira o contato do negócio.
+func (r *DealParticipantRepository) Remove(ctx context.Context, workspaceID, dealID, contactID string) error {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM public."DealParticipant"
+		WHERE "workspaceId" = $1 AND "dealId" = $2 AND "contactId" = $3`,
+		workspaceID, dealID, contactID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete deal participant: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDealParticipantNotFound
+	}
+	return nil
+}
+
+func scanDealParticipant(row pgx.Row) (*domain.DealParticipant, error) {
+	var p domain.DealParticipant
+	err := row.Scan(
+		&p.ID, &p.WorkspaceID, &p.DealID, &p.ContactID, &p.Role, &p.CreatedByID,
+		&p.CreatedAt, &p.UpdatedAt, &p.ContactName, &p.ContactEmail,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,62 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestDealRepository_ListQuery_Integration
+func TestDealRepository_ListQuery_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	deals := repo.NewDealRepository(pool)
+
+	contact := f.Contact(func(c *domain.Contact) { c.FullName = "Marina Quaresma" })
+	company := f.Company(func(c *domain.Company) { c.Name = "Vidraçaria Horizonte" })
+
+	byName := f.Deal(func(d *domain.Deal) { d.Name = "Renovação anual Quaresma" })
+	byContact := f.Deal(func(d *domain.Deal) { d.ContactID = &contact.ID })
+	byCompany := f.Deal(func(d *domain.Deal) { d.CompanyID = &company.ID })
+	other := f.Deal(func(d *domain.Deal) { d.Name = "Licença extra" })
+
+	// Empresa homônima em outro workspace não entra no resultado
+	foreign := factory.New(t, pool)
+	foreignCompany := foreign.Company(func(c *domain.Company) { c.Name = "Horizonte Distribuidora" })
+	foreign.Deal(func(d *domain.Deal) { d.CompanyID = &foreignCompany.ID })
+
+	list := func(t *testing.T, q *string) []string {
+		t.Helper()
+		got, err := deals.List(ctx, f.WorkspaceID, nil, nil, nil, nil, q, false, domain.AttributionFilter{})
+		require.NoError(t, err)
+		ids := make([]string, len(got))
+		for i, d := range got {
+			ids[i] = d.ID
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name string
+		q    *string
+		want []string
+	}{
+		{"no query lists every deal", nil, []string{byName.ID, byContact.ID, byCompany.ID, other.ID}},
+		{"deal name and contact name", factory.Ptr("quaresma"), []string{byName.ID, byContact.ID}},
+		{"company name", factory.Ptr("Horizonte"), []string{byCompany.ID}},
+		{"every term must match", factory.Ptr("Marina Horizonte"), []string{}},
+		{"no match", factory.Ptr("inexistente"), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, list(t, tt.q))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+)
+
+// DealSplitRepository persiste a divisão de crédito dos negócios entre usuários.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type DealSplitRepository struct {
+	pool database.DB
+}
+
+func NewDealSplitRepository(pool database.DB) *DealSplitRepository {
+	return &DealSplitRepository{pool: pool}
+}
+
+const dealSplitColumns = `id, "workspaceId", "dealId", "userId", percentage, "createdById", "createdAt"`
+
+// List retorna os splits de um negócio (ordem de inclusão).
+func (r *DealSplitRepository) List(ctx context.Context, workspaceID, dealID string) ([]domain.DealSplit, error) {
+	return listDealSplits(ctx, r.pool, workspaceID, dealID)
+}
+
+// Replace substitui todos os splits do negócio numa transação; lista vazia remove os splits.
+func (r *DealSplitRepository) Replace(ctx context.Context, workspaceID, dealID string, splits []domain.DealSplit) ([]domain.DealSplit, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM public."DealSplit"
+		WHERE "workspaceId" = $1 AND "dealId" = $2`,
+		workspaceID, dealID,
+	); err != nil {
+		return nil, fmt.Errorf("delete deal splits: %w", err)
+	}
+
+	for _, s := range splits {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO public."DealSplit" (id, "workspaceId", "dealId", "userId", percentage, "createdById", "createdAt")
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			s.ID, workspaceID, dealID, s.UserID, s.Percentage, s.CreatedByID, s.CreatedAt.UTC(),
+		); err != nil {
+			return nil, fmt.Errorf("insert deal split: %w", err)
+		}
+	}
+
+	replaced, err := listDealSplits(ctx, tx, workspaceID, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return replaced, nil
+}
+
+func listDealSplits(ctx context.Context, q database.DB, workspaceID, dealID string) ([]domain.DealSplit, error) {
+	rows, err := q.Query(ctx, `
+		SELECT `+dealSplitColumns+`
+		FROM public."DealSplit"
+		WHERE "workspaceId" = $1 AND "dealId" = $2
+		ORDER BY "createdAt", id`,
+		workspaceID, dealID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query deal splits: %w", err)
+	}
+	defer rows.Close()
+
+	splits := []domain.DealSplit{}
+	for rows.Next() {
+		var s domain.DealSplit
+		if err := rows.Scan(&s.ID, &s.WorkspaceID, &s.DealID, &s.UserID, &s.Percentage, &s.CreatedByID, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan deal split: %w", err)
+		}
+		splits = append(splits, s)
+	}
+	return splits, rows.Err()
+}
@@ -0,0 +1,101 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestDealSplitRepository_Replace_Integration
+func TestDealSplitRepository_Replace_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	splits := repo.NewDealSplitRepository(pool)
+
+	sdr := f.Member(domain.RoleUser)
+	ae := f.Member(domain.RoleUser)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	split := func(userID string, percentage float64, offset time.Duration) domain.DealSplit {
+		t.Helper()
+		splitID, err := id.New(id.DealSplit)
+		require.NoError(t, err)
+		return domain.DealSplit{ID: splitID, UserID: userID, Percentage: percentage, CreatedByID: f.UserID, CreatedAt: now.Add(offset)}
+	}
+	percentages := func(list []domain.DealSplit) map[string]float64 {
+		out := map[string]float64{}
+		for _, s := range list {
+			out[s.UserID] = s.Percentage
+		}
+		return out
+	}
+
+	deal := f.Deal()
+	other := f.Deal()
+	_, err := splits.Replace(ctx, f.WorkspaceID, other.ID, []domain.DealSplit{split(f.UserID, 100, 0)})
+	require.NoError(t, err)
+
+	t.Run("replaces the whole list", func(t *testing.T) {
+		first, err := splits.Replace(ctx, f.WorkspaceID, deal.ID, []domain.DealSplit{
+			split(sdr, 33.33, 0), split(ae, 66.67, time.Millisecond),
+		})
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		assert.Equal(t, sdr, first[0].UserID, "ordered by inclusion")
+		assert.Equal(t, deal.ID, first[0].DealID)
+		assert.Equal(t, f.WorkspaceID, first[0].WorkspaceID)
+
+		second, err := splits.Replace(ctx, f.WorkspaceID, deal.ID, []domain.DealSplit{
+			split(ae, 70, 0), split(f.UserID, 30, time.Millisecond),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{ae: 70, f.UserID: 30}, percentages(second))
+
+		listed, err := splits.List(ctx, f.WorkspaceID, deal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, second, listed)
+	})
+
+	t.Run("keeps the previous splits when the replacement fails", func(t *testing.T) {
+		_, err := splits.Replace(ctx, f.WorkspaceID, deal.ID, []domain.DealSplit{
+			split(sdr, 50, 0), split(sdr, 50, time.Millisecond),
+		})
+		assert.Error(t, err, "unique (dealId, userId)")
+
+		listed, err := splits.List(ctx, f.WorkspaceID, deal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{ae: 70, f.UserID: 30}, percentages(listed))
+	})
+
+	t.Run("empty list clears only this deal", func(t *testing.T) {
+		cleared, err := splits.Replace(ctx, f.WorkspaceID, deal.ID, []domain.DealSplit{})
+		require.NoError(t, err)
+		assert.Empty(t, cleared)
+
+		untouched, err := splits.List(ctx, f.WorkspaceID, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{f.UserID: 100}, percentages(untouched))
+	})
+
+	t.Run("is scoped to the workspace", func(t *testing.T) {
+		foreign := factory.New(t, pool)
+
+		_, err := splits.Replace(ctx, foreign.WorkspaceID, other.ID, []domain.DealSplit{})
+		require.NoError(t, err)
+
+		untouched, err := splits.List(ctx, f.WorkspaceID, other.ID)
+		require.NoError(t, err)
+		assert.Len(t, untouched, 1)
+
+		leaked, err := splits.List(ctx, foreign.WorkspaceID, other.ID)
+		require.NoError(t, err)
+		assert.Empty(t, leaked)
+	})
+}
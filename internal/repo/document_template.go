@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrDocumentTemplateNotFound     = apperr.NotFound("document template not found in workspace", "document template not found")
+	ErrDocumentTemplateNameConflict = apperr.Conflict("document template with this name already exists in workspace", "document template with this name already exists")
+)
+
+// DocumentTemplateRepository persiste os templates de documentos (orçamentos).
+// IMPORTANT: Uses camelCase column names with double quotes.
+type DocumentTemplateRepository struct {
+	pool database.DB
+}
+
+func NewDocumentTemplateRepository(pool database.DB) *DocumentTemplateRepository {
+	return &DocumentTemplateRepository{pool: pool}
+}
+
+const documentTemplateColumns = `id, "workspaceId", name, title, body, description, "createdById", "updatedById", "createdAt", "updatedAt"`
+
+// Create insere o template. Retorna ErrDocumentTemplateNameConflict se o nome já existir no workspace.
+func (r *DocumentTemplateRepository) Create(ctx context.Context, t *domain.DocumentTemplate) (*domain.DocumentTemplate, error) {
+	query := `
+		INSERT INTO public."DocumentTemplate" (id, "workspaceId", name, title, body, description, "createdById")
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + documentTemplateColumns
+
+	created, err := scanDocumentTemplate(r.pool.QueryRow(ctx, query,
+		t.ID, t.WorkspaceID, t.Name, t.Title, t.Body, t.Description, t.CreatedByID,
+	))
+	if err != nil {
+		if isDocumentTemplateNameConflict(err) {
+			return nil, ErrDocumentTemplateNameConflict
+		}
+		return nil, fmt.Errorf("insert document template: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um template do workspace.
+func (r *DocumentTemplateRepository) Get(ctx context.Context, workspaceID, templateID string) (*domain.DocumentTemplate, error) {
+	query := `
+		SELECT ` + documentTemplateColumns + `
+		FROM public."DocumentTemplate"
+		WHERE "workspaceId" = $1 AND id = $2`
+
+	t, err := scanDocumentTemplate(r.pool.QueryRow(ctx, query, workspaceID, templateID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDocumentTemplateNotFound
+		}
+		return nil, fmt.Errorf("query document template: %w", err)
+	}
+	return t, nil
+}
+
+// List retorna os templates do workspace ordenados por nome.
+func (r *DocumentTemplateRepository) List(ctx context.Context, workspaceID string) ([]domain.DocumentTemplate, error) {
+	query := `
+		SELECT ` + documentTemplateColumns + `
+		FROM public."DocumentTemplate"
+		WHERE "workspaceId" = $1
+		ORDER BY name, id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query document templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []domain.DocumentTemplate{}
+	for rows.Next() {
+		t, err := scanDocumentTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan document template: %w", err)
+		}
+		templates = append(templates, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate document templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Update grava o template já mesclado pelo service.
+func (r *DocumentTemplateRepository) Update(ctx context.Context, t *domain.DocumentTemplate) (*domain.DocumentTemplate, error) {
+	query := `
+		UPDATE public."DocumentTemplate" SET
+			name = $3,
+			title = $4,
+			body = $5,
+			description = $6,
+			"updatedById" = $7,
+			"updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + documentTemplateColumns
+
+	updated, err := scanDocumentTemplate(r.pool.QueryRow(ctx, query,
+		t.WorkspaceID, t.ID, t.Name, t.Title, t.Body, t.Description, t.UpdatedByID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDocumentTemplateNotFound
+		}
+		if isDocumentTemplateNameConflict(err) {
+			return nil, ErrDocumentTemplateNameConflict
+		}
+		return nil, fmt.Errorf("update document template: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete remove o template; orçamentos gerados com ele ficam com templateId nulo.
+func (r *DocumentTemplateRepository) Delete(ctx context.Context, workspaceID, templateID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."DocumentTemplate" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, templateID)
+	if err != nil {
+		return fmt.Errorf("delete document template: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDocumentTemplateNotFound
+	}
+	return nil
+}
+
+func isDocumentTemplateNameConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "DocumentTemplate_workspaceId_name_key"
+}
+
+// Scanners
+func scanDocumentTemplate(row pgx.Row) (*domain.DocumentTemplate, error) {
+	var t domain.DocumentTemplate
+	err := row.Scan(
+		&t.ID, &t.WorkspaceID, &t.Name, &t.Title, &t.Body, &t.Description,
+		&t.CreatedByID, &t.UpdatedByID, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.MergeFields = domain.ExtractMergeFields(t.Title, t.Body)
+	return &t, nil
+}
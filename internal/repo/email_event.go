@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// EmailEventRepository persiste os eventos de engajamento de email.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type EmailEventRepository struct {
+	pool database.DB
+}
+
+func NewEmailEventRepository(pool database.DB) *EmailEventRepository {
+	return &EmailEventRepository{pool: pool}
+}
+
+// Create grava o evento. Retorna false quando o providerEventId já foi recebido (reenvio).
+func (r *EmailEventRepository) Create(ctx context.Context, e *domain.EmailEvent) (bool, error) {
+	query := `
+		INSERT INTO public."EmailEvent" (
+			id, "workspaceId", "contactId", type, email, provider, "providerEventId", "messageId",
+			url, "bounceType", reason, "occurredAt"
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT ("workspaceId", provider, "providerEventId") WHERE "providerEventId" IS NOT NULL DO NOTHING
+		RETURNING "createdAt"`
+
+	err := r.pool.QueryRow(ctx, query,
+		e.ID, e.WorkspaceID, e.ContactID, e.Type, e.Email, e.Provider, e.ProviderEventID, e.MessageID,
+		e.URL, e.BounceType, e.Reason, e.OccurredAt,
+	).Scan(&e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("insert email event: %w", err)
+	}
+	return true, nil
+}
+
+// FindContactByEmail retorna o contato ativo mais recente do workspace com o email
+// (comparação sem maiúsculas). nil quando nenhum contato tem o email.
+func (r *EmailEventRepository) FindContactByEmail(ctx context.Context, workspaceID, email string) (*string, error) {
+	query := `
+		SELECT id
+		FROM public."Contact"
+		WHERE "workspaceId" = $1 AND LOWER(email) = $2 AND "deletedAt" IS NULL
+		ORDER BY "createdAt" DESC
+		LIMIT 1`
+
+	var id string
+	if err := r.pool.QueryRow(ctx, query, workspaceID, email).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find contact by email: %w", err)
+	}
+	return &id, nil
+}
@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrEmailTemplateNotFound     = apperr.NotFound("email template not found in workspace", "email template not found")
+	ErrEmailTemplateNameConflict = apperr.Conflict("email template with this name already exists in workspace", "email template with this name already exists")
+)
+
+type EmailTemplateRepository struct {
+	pool    database.DB
+	queries *sqlc.Queries
+}
+
+func NewEmailTemplateRepository(pool database.DB) *EmailTemplateRepository {
+	return &EmailTemplateRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *EmailTemplateRepository) Create(ctx context.Context, t *domain.EmailTemplate) (*domain.EmailTemplate, error) {
+	row, err := r.queries.CreateEmailTemplate(ctx, sqlc.CreateEmailTemplateParams{
+		ID:          t.ID,
+		WorkspaceId: t.WorkspaceID,
+		Name:        t.Name,
+		Subject:     t.Subject,
+		Body:        t.Body,
+		Description: t.Description,
+		CreatedById: t.CreatedByID,
+	})
+	if err != nil {
+		if isEmailTemplateNameConflict(err) {
+			return nil, ErrEmailTemplateNameConflict
+		}
+		return nil, fmt.Errorf("insert email template: %w", err)
+	}
+	return sqlcEmailTemplateToDomain(&row), nil
+}
+
+func (r *EmailTemplateRepository) Get(ctx context.Context, workspaceID, id string) (*domain.EmailTemplate, error) {
+	row, err := r.queries.GetEmailTemplate(ctx, sqlc.GetEmailTemplateParams{
+		WorkspaceId: workspaceID,
+		ID:          id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, fmt.Errorf("query email template: %w", err)
+	}
+	return sqlcEmailTemplateToDomain(&row), nil
+}
+
+func (r *EmailTemplateRepository) List(ctx context.Context, workspaceID string, query *string) ([]domain.EmailTemplate, error) {
+	rows, err := r.queries.ListEmailTemplates(ctx, sqlc.ListEmailTemplatesParams{
+		WorkspaceId: workspaceID,
+		Query:       query,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list email templates: %w", err)
+	}
+
+	templates := make([]domain.EmailTemplate, len(rows))
+	for i, row := range rows {
+		templates[i] = *sqlcEmailTemplateToDomain(&row)
+	}
+	return templates, nil
+}
+
+func (r *EmailTemplateRepository) Update(ctx context.Context, workspaceID, id string, req *domain.UpdateEmailTemplateRequest, actorID string) (*domain.EmailTemplate, error) {
+	row, err := r.queries.UpdateEmailTemplate(ctx, sqlc.UpdateEmailTemplateParams{
+		WorkspaceId: workspaceID,
+		ID:          id,
+		UpdatedById: &actorID,
+		Name:        req.Name,
+		Subject:     req.Subject,
+		Body:        req.Body,
+		Description: req.Description,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		if isEmailTemplateNameConflict(err) {
+			return nil, ErrEmailTemplateNameConflict
+		}
+		return nil, fmt.Errorf("update email template: %w", err)
+	}
+	return sqlcEmailTemplateToDomain(&row), nil
+}
+
+func (r *EmailTemplateRepository) Delete(ctx context.Context, workspaceID, id, actorID string) error {
+	affected, err := r.queries.DeleteEmailTemplate(ctx, sqlc.DeleteEmailTemplateParams{
+		WorkspaceId: workspaceID,
+		ID:          id,
+		UpdatedById: &actorID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete email template: %w", err)
+	}
+	if affected == 0 {
+		return ErrEmailTemplateNotFound
+	}
+	return nil
+}
+
+func isEmailTemplateNameConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_email_template_name_per_workspace"
+}
+
+// Mapper
+func sqlcEmailTemplateToDomain(row *sqlc.EmailTemplate) *domain.EmailTemplate {
+	return &domain.EmailTemplate{
+		ID:          row.ID,
+		WorkspaceID: row.WorkspaceId,
+		Name:        row.Name,
+		Subject:     row.Subject,
+		Body:        row.Body,
+		Description: row.Description,
+		MergeFields: domain.ExtractMergeFields(row.Subject, row.Body),
+		CreatedByID: row.CreatedById,
+		UpdatedByID: row.UpdatedById,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+		DeletedAt:   toTimePtr(row.DeletedAt),
+	}
+}
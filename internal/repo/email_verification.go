@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+)
+
+// EmailVerificationRepository lê a fila de emails de contatos a verificar e grava o resultado.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type EmailVerificationRepository struct {
+	pool database.DB
+}
+
+func NewEmailVerificationRepository(pool database.DB) *EmailVerificationRepository {
+	return &EmailVerificationRepository{pool: pool}
+}
+
+// ListPending retorna contatos ativos (de todos os workspaces) cujo email ainda não foi verificado,
+// mais antigos primeiro. UpdateContact limpa emailCheckedAt quando o email muda.
+func (r *EmailVerificationRepository) ListPending(ctx context.Context, limit int) ([]domain.PendingEmailVerification, error) {
+	query := `
+		SELECT id, "workspaceId", email
+		FROM public."Contact"
+		WHERE "deletedAt" IS NULL AND email IS NOT NULL AND "emailCheckedAt" IS NULL
+		ORDER BY "createdAt"
+		LIMIT $1`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query pending email verifications: %w", err)
+	}
+	defer rows.Close()
+
+	pending := []domain.PendingEmailVerification{}
+	for rows.Next() {
+		var p domain.PendingEmailVerification
+		if err := rows.Scan(&p.ContactID, &p.WorkspaceID, &p.Email); err != nil {
+			return nil, fmt.Errorf("scan pending email verification: %w", err)
+		}
+		pending = append(pending, p)
+	}
+	return pending, rows.Err()
+}
+
+// SetResult grava o resultado da verificação se o email ainda é o verificado (o contato pode ter
+// sido editado durante a consulta). Não altera updatedAt: o resultado não é uma edição do usuário
+// e não deve invalidar o If-Match de quem está editando o contato.
+// Retorna false quando o email mudou ou outro worker já gravou o resultado.
+func (r *EmailVerificationRepository) SetResult(ctx context.Context, p domain.PendingEmailVerification, status domain.EmailStatus, reason string) (bool, error) {
+	query := `
+		UPDATE public."Contact"
+		SET "emailStatus" = $4, "emailStatusReason" = $5, "emailCheckedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND email = $3 AND "emailCheckedAt" IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, p.ContactID, p.WorkspaceID, p.Email, string(status), reason)
+	if err != nil {
+		return false, fmt.Errorf("update contact email status: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// MarkInvalid marca o email do contato como INVALID a partir de um bounce HARD do provedor de
+// envio, se o contato ainda tem o email que recebeu o bounce. Como SetResult, não altera updatedAt.
+func (r *EmailVerificationRepository) MarkInvalid(ctx context.Context, workspaceID, contactID, email, reason string) error {
+	query := `
+		UPDATE public."Contact"
+		SET "emailStatus" = $4, "emailStatusReason" = $5, "emailCheckedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND LOWER(email) = $3`
+
+	_, err := r.pool.Exec(ctx, query, contactID, workspaceID, email, string(domain.EmailStatusInvalid), reason)
+	if err != nil {
+		return fmt.Errorf("mark contact email invalid: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestEmailVerificationRepository_Integration
+func TestEmailVerificationRepository_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	verification := repo.NewEmailVerificationRepository(pool)
+	contacts := repo.NewContactRepository(pool)
+
+	valid := f.Contact()
+	invalid := f.Contact()
+	deleted := f.Contact()
+	require.NoError(t, contacts.SoftDelete(ctx, f.WorkspaceID, deleted.ID, f.UserID))
+
+	// A fila é global: filtra os contatos deste workspace
+	pendingHere := func() map[string]domain.PendingEmailVerification {
+		t.Helper()
+		pending, err := verification.ListPending(ctx, 100000)
+		require.NoError(t, err)
+		out := map[string]domain.PendingEmailVerification{}
+		for _, p := range pending {
+			if p.WorkspaceID == f.WorkspaceID {
+				out[p.ContactID] = p
+			}
+		}
+		return out
+	}
+
+	pending := pendingHere()
+	require.Len(t, pending, 2, "deleted contacts are not verified")
+	assert.Equal(t, valid.Email, pending[valid.ID].Email)
+
+	t.Run("result is saved once", func(t *testing.T) {
+		saved, err := verification.SetResult(ctx, pending[valid.ID], domain.EmailStatusValid, "deliverable")
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		saved, err = verification.SetResult(ctx, pending[valid.ID], domain.EmailStatusInvalid, "rejected")
+		require.NoError(t, err)
+		assert.False(t, saved, "another worker already saved the result")
+
+		got, err := contacts.Get(ctx, f.WorkspaceID, valid.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.EmailStatus)
+		assert.Equal(t, domain.EmailStatusValid, *got.EmailStatus)
+		assert.NotNil(t, got.EmailCheckedAt)
+		assert.NotContains(t, pendingHere(), valid.ID)
+	})
+
+	t.Run("result for an outdated email is discarded", func(t *testing.T) {
+		stale := pending[invalid.ID]
+		stale.Email = "old-" + stale.Email
+		saved, err := verification.SetResult(ctx, stale, domain.EmailStatusInvalid, "no_mx")
+		require.NoError(t, err)
+		assert.False(t, saved)
+		assert.Contains(t, pendingHere(), invalid.ID)
+
+		saved, err = verification.SetResult(ctx, pending[invalid.ID], domain.EmailStatusInvalid, "no_mx")
+		require.NoError(t, err)
+		assert.True(t, saved)
+	})
+
+	t.Run("list filters by email status", func(t *testing.T) {
+		status := domain.EmailStatusInvalid
+		listed, _, err := contacts.List(ctx, domain.ListContactsParams{WorkspaceID: f.WorkspaceID, Limit: 50, EmailStatus: &status})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, invalid.ID, listed[0].ID)
+	})
+
+	t.Run("changing the email queues it again", func(t *testing.T) {
+		current, err := contacts.Get(ctx, f.WorkspaceID, invalid.ID)
+		require.NoError(t, err)
+		email := "fixed-" + invalid.Email
+		updated, err := contacts.Update(ctx, f.WorkspaceID, invalid.ID, &domain.UpdateContactRequest{Email: &email}, current.UpdatedAt)
+		require.NoError(t, err)
+		assert.Nil(t, updated.EmailStatus)
+		assert.Nil(t, updated.EmailCheckedAt)
+
+		queued, ok := pendingHere()[invalid.ID]
+		require.True(t, ok)
+		assert.Equal(t, email, queued.Email)
+	})
+}
@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"errors"
+
+	"linkko-api/internal/apperr"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrExternalIDConflict outro registro ativo do workspace já usa o externalId (contato, empresa ou deal)
+var ErrExternalIDConflict = apperr.Conflict("externalId already used by another record in workspace", "externalId is already in use")
+
+// Índices únicos parciais da migration 000052: um registro ativo por (workspaceId, externalId)
+var externalIDConstraints = map[string]bool{
+	"Contact_workspaceId_externalId_key": true,
+	"Company_workspaceId_externalId_key": true,
+	"Deal_workspaceId_externalId_key":    true,
+}
+
+func isExternalIDViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && externalIDConstraints[pgErr.ConstraintName]
+}
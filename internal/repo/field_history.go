@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+)
+
+// FieldHistoryRepository persiste as revisões por campo de contatos, empresas e negócios.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type FieldHistoryRepository struct {
+	pool database.DB
+}
+
+func NewFieldHistoryRepository(pool database.DB) *FieldHistoryRepository {
+	return &FieldHistoryRepository{pool: pool}
+}
+
+// Create insere uma revisão.
+func (r *FieldHistoryRepository) Create(ctx context.Context, rev *domain.FieldRevision) error {
+	changes, err := json.Marshal(rev.Changes)
+	if err != nil {
+		return fmt.Errorf("marshal field changes: %w", err)
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO public."FieldRevision" (id, "workspaceId", "entityType", "entityId", action, changes, "actorId")
+		VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7)`,
+		rev.ID, rev.WorkspaceID, rev.EntityType, rev.EntityID, rev.Action, string(changes), rev.ActorID,
+	)
+	if err != nil {
+		return fmt.Errorf("insert field revision: %w", err)
+	}
+	return nil
+}
+
+// List retorna as revisões do registro, mais recentes primeiro. Busca Limit+1 linhas
+// para que o service saiba se há próxima página.
+// Field filtra revisões que alteraram o campo (operador ? do JSONB).
+func (r *FieldHistoryRepository) List(ctx context.Context, params domain.ListFieldHistoryParams) ([]domain.FieldRevision, error) {
+	query := `
+		SELECT id, "workspaceId", "entityType", "entityId", action, changes, "actorId", "createdAt"
+		FROM public."FieldRevision"
+		WHERE "workspaceId" = $1 AND "entityType" = $2 AND "entityId" = $3
+		  AND ($4::TEXT IS NULL OR changes ? $4)
+		  AND ($5::TIMESTAMP IS NULL OR "createdAt" < $5)
+		ORDER BY "createdAt" DESC, id DESC
+		LIMIT $6`
+
+	rows, err := r.pool.Query(ctx, query,
+		params.WorkspaceID, params.EntityType, params.EntityID, params.Field, params.Cursor, params.Limit+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query field history: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []domain.FieldRevision{}
+	for rows.Next() {
+		var rev domain.FieldRevision
+		var changes []byte
+		err := rows.Scan(&rev.ID, &rev.WorkspaceID, &rev.EntityType, &rev.EntityID, &rev.Action, &changes, &rev.ActorID, &rev.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan field revision: %w", err)
+		}
+		if err := json.Unmarshal(changes, &rev.Changes); err != nil {
+			return nil, fmt.Errorf("decode field changes: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate field history: %w", err)
+	}
+
+	return revisions, nil
+}
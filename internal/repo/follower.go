@@ -0,0 +1,301 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// FollowerRepository persiste seguidores de entidades e as notificações entregues a eles.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type FollowerRepository struct {
+	pool database.DB
+}
+
+func NewFollowerRepository(pool database.DB) *FollowerRepository {
+	return &FollowerRepository{pool: pool}
+}
+
+const followerColumns = `id, "workspaceId", "entityType", "entityId", "userId", reason, "createdAt"`
+
+const notificationColumns = `id, "workspaceId", "userId", "entityType", "entityId", event, "actorId", "readAt", "createdAt"`
+
+const notificationPreferenceColumns = `"workspaceId", "userId", events, "quietHoursStart", "quietHoursEnd", "quietHoursTimezone", "updatedAt"`
+
+// Follow inclui o usuário como seguidor da entidade. Se ele já segue, o vínculo é mantido;
+// um follow MANUAL sobre um vínculo automático passa a valer como MANUAL.
+func (r *FollowerRepository) Follow(ctx context.Context, f *domain.Follower) (*domain.Follower, error) {
+	query := `
+		INSERT INTO public."Follower" (id, "workspaceId", "entityType", "entityId", "userId", reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ("entityType", "entityId", "userId") DO UPDATE
+		SET reason = CASE WHEN EXCLUDED.reason = 'MANUAL' THEN 'MANUAL' ELSE "Follower".reason END
+		RETURNING ` + followerColumns
+
+	created, err := scanFollower(r.pool.QueryRow(ctx, query,
+		f.ID, f.WorkspaceID, f.EntityType, f.EntityID, f.UserID, f.Reason,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("upsert follower: %w", err)
+	}
+	return created, nil
+}
+
+// Unfollow remove o usuário dos seguidores da entidade. Idempotente.
+func (r *FollowerRepository) Unfollow(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID, userID string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM public."Follower"
+		WHERE "workspaceId" = $1 AND "entityType" = $2 AND "entityId" = $3 AND "userId" = $4`,
+		workspaceID, entityType, entityID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete follower: %w", err)
+	}
+	return nil
+}
+
+// List retorna os seguidores da entidade em ordem de inclusão.
+func (r *FollowerRepository) List(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID string) ([]domain.Follower, error) {
+	query := `
+		SELECT ` + followerColumns + `
+		FROM public."Follower"
+		WHERE "workspaceId" = $1 AND "entityType" = $2 AND "entityId" = $3
+		ORDER BY "createdAt", id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("query followers: %w", err)
+	}
+	defer rows.Close()
+
+	followers := []domain.Follower{}
+	for rows.Next() {
+		f, err := scanFollower(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan follower: %w", err)
+		}
+		followers = append(followers, *f)
+	}
+	return followers, rows.Err()
+}
+
+// CreateNotifications grava as notificações em um único INSERT.
+func (r *FollowerRepository) CreateNotifications(ctx context.Context, notifications []domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	n := len(notifications)
+	ids, workspaceIDs, userIDs := make([]string, n), make([]string, n), make([]string, n)
+	entityTypes, entityIDs, events, actorIDs := make([]string, n), make([]string, n), make([]string, n), make([]string, n)
+	for i, notif := range notifications {
+		ids[i], workspaceIDs[i], userIDs[i] = notif.ID, notif.WorkspaceID, notif.UserID
+		entityTypes[i], entityIDs[i] = string(notif.EntityType), notif.EntityID
+		events[i], actorIDs[i] = string(notif.Event), notif.ActorID
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO public."Notification" (id, "workspaceId", "userId", "entityType", "entityId", event, "actorId")
+		SELECT * FROM unnest($1::TEXT[], $2::TEXT[], $3::TEXT[], $4::TEXT[], $5::TEXT[], $6::TEXT[], $7::TEXT[])`,
+		ids, workspaceIDs, userIDs, entityTypes, entityIDs, events, actorIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("insert notifications: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications retorna a caixa de entrada do usuário, mais recentes primeiro.
+// Busca Limit+1 linhas para que o chamador detecte a próxima página.
+func (r *FollowerRepository) ListNotifications(ctx context.Context, params domain.ListNotificationsParams) ([]domain.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM public."Notification"
+		WHERE "workspaceId" = $1 AND "userId" = $2
+		  AND (NOT $3::BOOLEAN OR "readAt" IS NULL)
+		  AND ($4::TIMESTAMP IS NULL OR "createdAt" < $4)
+		ORDER BY "createdAt" DESC, id DESC
+		LIMIT $5`
+
+	rows, err := r.pool.Query(ctx, query,
+		params.WorkspaceID, params.UserID, params.UnreadOnly, params.Cursor, params.Limit+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.Notification{}
+	for rows.Next() {
+		var n domain.Notification
+		err := rows.Scan(&n.ID, &n.WorkspaceID, &n.UserID, &n.EntityType, &n.EntityID, &n.Event, &n.ActorID, &n.ReadAt, &n.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// MarkNotificationsRead marca como lidas as notificações do usuário (todas, se ids for vazio).
+// IDs de outros usuários ou já lidas são ignorados.
+func (r *FollowerRepository) MarkNotificationsRead(ctx context.Context, workspaceID, userID string, ids []string) (int64, error) {
+	var idFilter []string
+	if len(ids) > 0 {
+		idFilter = ids
+	}
+
+	result, err := r.pool.Exec(ctx, `
+		UPDATE public."Notification"
+		SET "readAt" = NOW()
+		WHERE "workspaceId" = $1 AND "userId" = $2 AND "readAt" IS NULL
+		  AND ($3::TEXT[] IS NULL OR id = ANY($3))`,
+		workspaceID, userID, idFilter,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("mark notifications read: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// GetNotificationPreferences retorna as preferências do usuário no workspace (o padrão quando
+// ele nunca configurou).
+func (r *FollowerRepository) GetNotificationPreferences(ctx context.Context, workspaceID, userID string) (*domain.NotificationPreferences, error) {
+	query := `
+		SELECT ` + notificationPreferenceColumns + `
+		FROM public."NotificationPreference"
+		WHERE "workspaceId" = $1 AND "userId" = $2`
+
+	p, err := scanNotificationPreferences(r.pool.QueryRow(ctx, query, workspaceID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.DefaultNotificationPreferences(workspaceID, userID), nil
+		}
+		return nil, fmt.Errorf("query notification preferences: %w", err)
+	}
+	return p, nil
+}
+
+// ListNotificationPreferences retorna as preferências configuradas dos usuários informados,
+// por userId. Usuários sem linha ficam fora do mapa (valem os padrões).
+func (r *FollowerRepository) ListNotificationPreferences(ctx context.Context, workspaceID string, userIDs []string) (map[string]*domain.NotificationPreferences, error) {
+	out := make(map[string]*domain.NotificationPreferences, len(userIDs))
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+notificationPreferenceColumns+`
+		FROM public."NotificationPreference"
+		WHERE "workspaceId" = $1 AND "userId" = ANY($2::TEXT[])`,
+		workspaceID, userIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanNotificationPreferences(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan notification preferences: %w", err)
+		}
+		out[p.UserID] = p
+	}
+	return out, rows.Err()
+}
+
+// UpsertNotificationPreferences grava (substitui) as preferências do usuário no workspace.
+func (r *FollowerRepository) UpsertNotificationPreferences(ctx context.Context, p *domain.NotificationPreferences) (*domain.NotificationPreferences, error) {
+	events, err := json.Marshal(p.Events)
+	if err != nil {
+		return nil, fmt.Errorf("marshal notification events: %w", err)
+	}
+	var start, end, timezone *string
+	if p.QuietHours.Enabled {
+		start, end, timezone = &p.QuietHours.Start, &p.QuietHours.End, &p.QuietHours.Timezone
+	}
+
+	query := `
+		INSERT INTO public."NotificationPreference" ("workspaceId", "userId", events, "quietHoursStart", "quietHoursEnd", "quietHoursTimezone")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ("workspaceId", "userId") DO UPDATE
+		SET events = EXCLUDED.events,
+		    "quietHoursStart" = EXCLUDED."quietHoursStart",
+		    "quietHoursEnd" = EXCLUDED."quietHoursEnd",
+		    "quietHoursTimezone" = EXCLUDED."quietHoursTimezone",
+		    "updatedAt" = NOW()
+		RETURNING ` + notificationPreferenceColumns
+
+	saved, err := scanNotificationPreferences(r.pool.QueryRow(ctx, query,
+		p.WorkspaceID, p.UserID, events, start, end, timezone,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("upsert notification preferences: %w", err)
+	}
+	return saved, nil
+}
+
+// ListUserEmails retorna o email dos usuários informados (não excluídos), por userId.
+func (r *FollowerRepository) ListUserEmails(ctx context.Context, userIDs []string) (map[string]string, error) {
+	out := make(map[string]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, email FROM public."User"
+		WHERE id = ANY($1::TEXT[]) AND "deletedAt" IS NULL`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query user emails: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, email string
+		if err := rows.Scan(&userID, &email); err != nil {
+			return nil, fmt.Errorf("scan user email: %w", err)
+		}
+		out[userID] = email
+	}
+	return out, rows.Err()
+}
+
+func scanNotificationPreferences(row pgx.Row) (*domain.NotificationPreferences, error) {
+	var p domain.NotificationPreferences
+	var events []byte
+	var start, end, timezone *string
+	var updatedAt time.Time
+	if err := row.Scan(&p.WorkspaceID, &p.UserID, &events, &start, &end, &timezone, &updatedAt); err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		if err := json.Unmarshal(events, &p.Events); err != nil {
+			return nil, fmt.Errorf("decode notification events: %w", err)
+		}
+	}
+	if start != nil && end != nil && timezone != nil {
+		p.QuietHours = domain.QuietHours{Enabled: true, Start: *start, End: *end, Timezone: *timezone}
+	}
+	p.UpdatedAt = &updatedAt
+	p.Normalize()
+	return &p, nil
+}
+
+func scanFollower(row pgx.Row) (*domain.Follower, error) {
+	var f domain.Follower
+	err := row.Scan(&f.ID, &f.WorkspaceID, &f.EntityType, &f.EntityID, &f.UserID, &f.Reason, &f.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
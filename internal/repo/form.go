@@ -0,0 +1,220 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrFormNotFound = apperr.NotFound("form not found in workspace", "form not found")
+
+// FormRepository persiste os formulários de captura de leads.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type FormRepository struct {
+	pool database.DB
+}
+
+func NewFormRepository(pool database.DB) *FormRepository {
+	return &FormRepository{pool: pool}
+}
+
+const formColumns = `id, "workspaceId", name, description, fields, "pipelineId", "stageId", "ownerId",
+	"successRedirectUrl", "successMessage", "spamProtection", enabled, "submissionCount",
+	"lastSubmissionAt", "createdById", "updatedById", "createdAt", "updatedAt"`
+
+// Create insere o formulário.
+func (r *FormRepository) Create(ctx context.Context, f *domain.Form) (*domain.Form, error) {
+	fields, spam, err := marshalFormJSON(f)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO public."Form" (
+			id, "workspaceId", name, description, fields, "pipelineId", "stageId", "ownerId",
+			"successRedirectUrl", "successMessage", "spamProtection", enabled, "createdById"
+		)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7, $8, $9, $10, $11::jsonb, $12, $13)
+		RETURNING ` + formColumns
+
+	created, err := scanForm(r.pool.QueryRow(ctx, query,
+		f.ID, f.WorkspaceID, f.Name, f.Description, fields, f.PipelineID, f.StageID, f.OwnerID,
+		f.SuccessRedirectURL, f.SuccessMessage, spam, f.Enabled, f.CreatedByID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert form: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um formulário do workspace.
+func (r *FormRepository) Get(ctx context.Context, workspaceID, formID string) (*domain.Form, error) {
+	query := `
+		SELECT ` + formColumns + `
+		FROM public."Form"
+		WHERE "workspaceId" = $1 AND id = $2`
+
+	f, err := scanForm(r.pool.QueryRow(ctx, query, workspaceID, formID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFormNotFound
+		}
+		return nil, fmt.Errorf("query form: %w", err)
+	}
+	return f, nil
+}
+
+// GetEnabled retorna um formulário ativo pelo id, sem escopo de workspace: usado pelo endpoint
+// público, onde o id do formulário é a única referência do visitante.
+func (r *FormRepository) GetEnabled(ctx context.Context, formID string) (*domain.Form, error) {
+	query := `
+		SELECT ` + formColumns + `
+		FROM public."Form"
+		WHERE id = $1 AND enabled = true`
+
+	f, err := scanForm(r.pool.QueryRow(ctx, query, formID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFormNotFound
+		}
+		return nil, fmt.Errorf("query form: %w", err)
+	}
+	return f, nil
+}
+
+// List retorna os formulários do workspace, mais recentes primeiro.
+func (r *FormRepository) List(ctx context.Context, workspaceID string) ([]domain.Form, error) {
+	query := `
+		SELECT ` + formColumns + `
+		FROM public."Form"
+		WHERE "workspaceId" = $1
+		ORDER BY "createdAt" DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query forms: %w", err)
+	}
+	defer rows.Close()
+
+	forms := []domain.Form{}
+	for rows.Next() {
+		f, err := scanForm(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan form: %w", err)
+		}
+		forms = append(forms, *f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate forms: %w", err)
+	}
+
+	return forms, nil
+}
+
+// Update grava o formulário já mesclado pelo service.
+func (r *FormRepository) Update(ctx context.Context, f *domain.Form, actorID string) (*domain.Form, error) {
+	fields, spam, err := marshalFormJSON(f)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE public."Form" SET
+			name = $3,
+			description = $4,
+			fields = $5::jsonb,
+			"pipelineId" = $6,
+			"stageId" = $7,
+			"ownerId" = $8,
+			"successRedirectUrl" = $9,
+			"successMessage" = $10,
+			"spamProtection" = $11::jsonb,
+			enabled = $12,
+			"updatedById" = $13,
+			"updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + formColumns
+
+	updated, err := scanForm(r.pool.QueryRow(ctx, query,
+		f.WorkspaceID, f.ID, f.Name, f.Description, fields, f.PipelineID, f.StageID, f.OwnerID,
+		f.SuccessRedirectURL, f.SuccessMessage, spam, f.Enabled, actorID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFormNotFound
+		}
+		return nil, fmt.Errorf("update form: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete remove o formulário. Contatos e negócios já criados permanecem.
+func (r *FormRepository) Delete(ctx context.Context, workspaceID, formID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."Form" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, formID)
+	if err != nil {
+		return fmt.Errorf("delete form: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFormNotFound
+	}
+	return nil
+}
+
+// RecordSubmission incrementa o contador de submissões aceitas.
+func (r *FormRepository) RecordSubmission(ctx context.Context, formID string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."Form"
+		SET "submissionCount" = "submissionCount" + 1, "lastSubmissionAt" = NOW()
+		WHERE id = $1`, formID)
+	if err != nil {
+		return fmt.Errorf("record form submission: %w", err)
+	}
+	return nil
+}
+
+func marshalFormJSON(f *domain.Form) (string, string, error) {
+	fields, err := json.Marshal(f.Fields)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal form fields: %w", err)
+	}
+	spam, err := json.Marshal(f.SpamProtection)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal form spam protection: %w", err)
+	}
+	return string(fields), string(spam), nil
+}
+
+// Scanners
+func scanForm(row pgx.Row) (*domain.Form, error) {
+	var f domain.Form
+	var fields, spam []byte
+	err := row.Scan(
+		&f.ID, &f.WorkspaceID, &f.Name, &f.Description, &fields, &f.PipelineID, &f.StageID, &f.OwnerID,
+		&f.SuccessRedirectURL, &f.SuccessMessage, &spam, &f.Enabled, &f.SubmissionCount,
+		&f.LastSubmissionAt, &f.CreatedByID, &f.UpdatedByID, &f.CreatedAt, &f.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		if err := json.Unmarshal(fields, &f.Fields); err != nil {
+			return nil, fmt.Errorf("decode form fields: %w", err)
+		}
+	}
+	if len(spam) > 0 {
+		if err := json.Unmarshal(spam, &f.SpamProtection); err != nil {
+			return nil, fmt.Errorf("decode form spam protection: %w", err)
+		}
+	}
+	if f.Fields == nil {
+		f.Fields = []domain.FormField{}
+	}
+	return &f, nil
+}
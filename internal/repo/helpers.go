@@ -3,6 +3,8 @@ package repo
 import (
 	"time"
 
+	"linkko-api/internal/domain"
+
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -35,6 +37,37 @@ func toTimePtr(ts pgtype.Timestamp) *time.Time {
 	return nil
 }
 
+// attributionFromColumns monta domain.Attribution a partir das colunas source/utm*
+func attributionFromColumns(source, sourceDetail, utmSource, utmMedium, utmCampaign, utmTerm, utmContent *string) domain.Attribution {
+	return domain.Attribution{
+		Source:       source,
+		SourceDetail: sourceDetail,
+		UTMSource:    utmSource,
+		UTMMedium:    utmMedium,
+		UTMCampaign:  utmCampaign,
+		UTMTerm:      utmTerm,
+		UTMContent:   utmContent,
+	}
+}
+
+// nextStepFromColumns monta domain.NextStep a partir das colunas nextStepAt/nextStepNote
+func nextStepFromColumns(at pgtype.Timestamp, note *string) domain.NextStep {
+	return domain.NextStep{
+		NextStepAt:   toTimePtr(at),
+		NextStepNote: note,
+	}
+}
+
+// setContactEmailVerification preenche os campos de verificação do email do contato.
+func setContactEmailVerification(c *domain.Contact, status, reason *string, checkedAt pgtype.Timestamp) {
+	if status != nil {
+		s := domain.EmailStatus(*status)
+		c.EmailStatus = &s
+	}
+	c.EmailStatusReason = reason
+	c.EmailCheckedAt = toTimePtr(checkedAt)
+}
+
 func getString(s *string) string {
 	if s == nil {
 		return ""
@@ -48,3 +81,11 @@ func getTime(t *time.Time) time.Time {
 	}
 	return *t
 }
+
+// nullIfEmpty grava NULL no lugar de string vazia.
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -7,18 +7,31 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"linkko-api/internal/database"
+	"linkko-api/internal/resilience"
+
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // IdempotencyRepo handles idempotency key storage and retrieval
 type IdempotencyRepo struct {
-	pool *pgxpool.Pool
+	pool    database.DB
+	breaker *resilience.Breaker
+	retry   resilience.RetryPolicy
+	region  string // REGION; chaves de outras regiões não são reaproveitadas
+}
+
+// NewIdempotencyRepo creates a new IdempotencyRepo.
+// breaker pode ser nil. CheckKey e StoreResult são idempotentes e usam retry
+// para erros transitórios do Postgres.
+func NewIdempotencyRepo(pool database.DB, breaker *resilience.Breaker) *IdempotencyRepo {
+	return &IdempotencyRepo{pool: pool, breaker: breaker, retry: resilience.DefaultPgRetryPolicy}
 }
 
-// NewIdempotencyRepo creates a new IdempotencyRepo
-func NewIdempotencyRepo(pool *pgxpool.Pool) *IdempotencyRepo {
-	return &IdempotencyRepo{pool: pool}
+// WithRegion restringe as chaves à região do deploy (coluna region). Vazio = região única.
+func (r *IdempotencyRepo) WithRegion(region string) *IdempotencyRepo {
+	r.region = region
+	return r
 }
 
 // CachedResponse represents a cached response from an idempotent request
@@ -39,15 +52,23 @@ func (r *IdempotencyRepo) CheckKey(ctx context.Context, workspaceID, keyHash str
 	query := `
 		SELECT response_status, response_body, response_headers
 		FROM idempotency_keys
-		WHERE workspace_id = $1 AND key_hash = $2 AND expires_at > NOW()
+		WHERE workspace_id = $1 AND region = $2 AND key_hash = $3 AND expires_at > NOW()
 	`
 
 	var status int
 	var body json.RawMessage
 	var headersJSON []byte
+	found := false
 
-	err := r.pool.QueryRow(ctx, query, workspaceID, keyHash).Scan(&status, &body, &headersJSON)
-	if err == pgx.ErrNoRows {
+	err := resilience.Do(ctx, r.breaker, r.retry, func(ctx context.Context) error {
+		err := r.pool.QueryRow(ctx, query, workspaceID, r.region, keyHash).Scan(&status, &body, &headersJSON)
+		if err == pgx.ErrNoRows {
+			return nil // ausência da chave não é falha da dependência
+		}
+		found = err == nil
+		return err
+	})
+	if err == nil && !found {
 		return nil, nil // Key not found
 	}
 	if err != nil {
@@ -84,16 +105,20 @@ func (r *IdempotencyRepo) StoreResult(
 
 	query := `
 		INSERT INTO idempotency_keys (
-			key_hash, workspace_id, original_key, request_method, request_path,
+			key_hash, workspace_id, region, original_key, request_method, request_path,
 			request_payload, response_status, response_body, response_headers, expires_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW() + INTERVAL '24 hours')
-		ON CONFLICT (workspace_id, key_hash) DO NOTHING
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW() + INTERVAL '24 hours')
+		ON CONFLICT (workspace_id, region, key_hash) DO NOTHING
 	`
 
-	_, err = r.pool.Exec(ctx, query,
-		keyHash, workspaceID, originalKey, method, path,
-		requestPayload, status, responseBody, headersJSON,
-	)
+	// ON CONFLICT DO NOTHING torna o INSERT seguro para retry
+	err = resilience.Do(ctx, r.breaker, r.retry, func(ctx context.Context) error {
+		_, err := r.pool.Exec(ctx, query,
+			keyHash, workspaceID, r.region, originalKey, method, path,
+			requestPayload, status, responseBody, headersJSON,
+		)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to store idempotency result: %w", err)
 	}
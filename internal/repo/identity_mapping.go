@@ -0,0 +1,192 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrIdentityMappingNotFound = apperr.NotFound("identity mapping not found in workspace", "identity mapping not found")
+
+// IdentityMappingRepository persiste os ids dos registros em sistemas externos (multi-sistema).
+// IMPORTANT: Uses camelCase column names with double quotes.
+type IdentityMappingRepository struct {
+	pool database.DB
+}
+
+func NewIdentityMappingRepository(pool database.DB) *IdentityMappingRepository {
+	return &IdentityMappingRepository{pool: pool}
+}
+
+const identityMappingColumns = `id, "workspaceId", system, "entityType", "externalId", "entityId", "createdById", "createdAt", "updatedAt"`
+
+// identityMappingEntityExists casa o mapping (alias m) com o registro Linkko; não há FK porque
+// entityId é polimórfico. activeOnly ignora registros na lixeira.
+func identityMappingEntityExists(activeOnly bool) string {
+	deleted := ""
+	if activeOnly {
+		deleted = ` AND e."deletedAt" IS NULL`
+	}
+	return `EXISTS (
+			SELECT 1 FROM public."Contact" e WHERE m."entityType" = 'contact' AND e.id = m."entityId"` + deleted + `
+			UNION ALL
+			SELECT 1 FROM public."Company" e WHERE m."entityType" = 'company' AND e.id = m."entityId"` + deleted + `
+			UNION ALL
+			SELECT 1 FROM public."Deal" e WHERE m."entityType" = 'deal' AND e.id = m."entityId"` + deleted + `
+		)`
+}
+
+// Upsert grava o lote numa transação. Uma chave (system, entityType, externalId) já registrada
+// passa a apontar para o novo entityId; id, createdById e createdAt originais são mantidos.
+func (r *IdentityMappingRepository) Upsert(ctx context.Context, workspaceID string, mappings []domain.IdentityMapping) ([]domain.IdentityMapping, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	saved := make([]domain.IdentityMapping, 0, len(mappings))
+	for _, m := range mappings {
+		row := tx.QueryRow(ctx, `
+			INSERT INTO public."IdentityMapping" (id, "workspaceId", system, "entityType", "externalId", "entityId", "createdById", "createdAt", "updatedAt")
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+			ON CONFLICT ("workspaceId", system, "entityType", "externalId")
+			DO UPDATE SET "entityId" = EXCLUDED."entityId", "updatedAt" = EXCLUDED."updatedAt"
+			RETURNING `+identityMappingColumns,
+			m.ID, workspaceID, m.System, string(m.EntityType), m.ExternalID, m.EntityID, m.CreatedByID, m.CreatedAt.UTC(),
+		)
+		mapping, err := scanIdentityMapping(row)
+		if err != nil {
+			return nil, fmt.Errorf("upsert identity mapping: %w", err)
+		}
+		saved = append(saved, *mapping)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// ResolveExternalIDs retorna os mappings de externalIds de um sistema.
+// Mappings de registros na lixeira não são resolvidos (voltam com o registro restaurado).
+func (r *IdentityMappingRepository) ResolveExternalIDs(ctx context.Context, workspaceID, system string, entityType domain.IdentityEntityType, externalIDs []string) ([]domain.IdentityMapping, error) {
+	return r.list(ctx, `
+		SELECT `+identityMappingColumns+`
+		FROM public."IdentityMapping" m
+		WHERE "workspaceId" = $1 AND system = $2 AND "entityType" = $3 AND "externalId" = ANY($4::TEXT[])
+		  AND `+identityMappingEntityExists(true)+`
+		ORDER BY "externalId"`,
+		workspaceID, system, string(entityType), externalIDs,
+	)
+}
+
+// ResolveEntityIDs retorna os mappings de registros Linkko; system vazio busca em todos os sistemas.
+// Como em ResolveExternalIDs, registros na lixeira não têm mappings.
+func (r *IdentityMappingRepository) ResolveEntityIDs(ctx context.Context, workspaceID, system string, entityType domain.IdentityEntityType, entityIDs []string) ([]domain.IdentityMapping, error) {
+	return r.list(ctx, `
+		SELECT `+identityMappingColumns+`
+		FROM public."IdentityMapping" m
+		WHERE "workspaceId" = $1 AND ($2 = '' OR system = $2) AND "entityType" = $3 AND "entityId" = ANY($4::TEXT[])
+		  AND `+identityMappingEntityExists(true)+`
+		ORDER BY "entityId", system`,
+		workspaceID, system, string(entityType), entityIDs,
+	)
+}
+
+// Delete remove um mapping (o registro Linkko não é afetado).
+func (r *IdentityMappingRepository) Delete(ctx context.Context, workspaceID, mappingID string) error {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM public."IdentityMapping"
+		WHERE "workspaceId" = $1 AND id = $2`,
+		workspaceID, mappingID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete identity mapping: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrIdentityMappingNotFound
+	}
+	return nil
+}
+
+// DeleteOrphaned remove os mappings cujo registro foi removido definitivamente (purga da
+// lixeira). Usado pelo comando cleanup.
+func (r *IdentityMappingRepository) DeleteOrphaned(ctx context.Context) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM public."IdentityMapping" m
+		WHERE NOT `+identityMappingEntityExists(false))
+	if err != nil {
+		return 0, fmt.Errorf("delete orphaned identity mappings: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// ExistingEntityIDs retorna quais entityIds existem (não excluídos) no workspace.
+func (r *IdentityMappingRepository) ExistingEntityIDs(ctx context.Context, workspaceID string, entityType domain.IdentityEntityType, entityIDs []string) (map[string]bool, error) {
+	var table string
+	switch entityType {
+	case domain.IdentityEntityContact:
+		table = "Contact"
+	case domain.IdentityEntityCompany:
+		table = "Company"
+	case domain.IdentityEntityDeal:
+		table = "Deal"
+	default:
+		return nil, fmt.Errorf("unknown identity entity type %q", entityType)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id
+		FROM public."`+table+`"
+		WHERE "workspaceId" = $1 AND id = ANY($2::TEXT[]) AND "deletedAt" IS NULL`,
+		workspaceID, entityIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query %s ids: %w", entityType, err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool, len(entityIDs))
+	for rows.Next() {
+		var entityID string
+		if err := rows.Scan(&entityID); err != nil {
+			return nil, fmt.Errorf("scan %s id: %w", entityType, err)
+		}
+		existing[entityID] = true
+	}
+	return existing, rows.Err()
+}
+
+func (r *IdentityMappingRepository) list(ctx context.Context, query string, args ...any) ([]domain.IdentityMapping, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query identity mappings: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := []domain.IdentityMapping{}
+	for rows.Next() {
+		m, err := scanIdentityMapping(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan identity mapping: %w", err)
+		}
+		mappings = append(mappings, *m)
+	}
+	return mappings, rows.Err()
+}
+
+func scanIdentityMapping(row pgx.Row) (*domain.IdentityMapping, error) {
+	var m domain.IdentityMapping
+	var entityType string
+	if err := row.Scan(&m.ID, &m.WorkspaceID, &m.System, &entityType, &m.ExternalID, &m.EntityID, &m.CreatedByID, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	m.EntityType = domain.IdentityEntityType(entityType)
+	return &m, nil
+}
@@ -0,0 +1,128 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestIdentityMappingRepository_Integration
+func TestIdentityMappingRepository_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+	mappings := repo.NewIdentityMappingRepository(pool)
+	contacts := repo.NewContactRepository(pool)
+
+	mapping := func(f *factory.Factory, system, externalID, entityID string) domain.IdentityMapping {
+		t.Helper()
+		mappingID, err := id.New(id.IdentityMapping)
+		require.NoError(t, err)
+		return domain.IdentityMapping{
+			ID: mappingID, System: system, EntityType: domain.IdentityEntityContact,
+			ExternalID: externalID, EntityID: entityID, CreatedByID: f.UserID, CreatedAt: time.Now().UTC(),
+		}
+	}
+	upsert := func(f *factory.Factory, batch ...domain.IdentityMapping) []domain.IdentityMapping {
+		t.Helper()
+		saved, err := mappings.Upsert(ctx, f.WorkspaceID, batch)
+		require.NoError(t, err)
+		return saved
+	}
+	externalIDs := func(list []domain.IdentityMapping) []string {
+		out := []string{}
+		for _, m := range list {
+			out = append(out, m.System+":"+m.ExternalID)
+		}
+		return out
+	}
+
+	t.Run("one record per workspace, system and externalId", func(t *testing.T) {
+		ana, bia := f.Contact(), f.Contact()
+
+		first := upsert(f, mapping(f, "erp", "C-1", ana.ID))[0]
+		moved := upsert(f, mapping(f, "erp", "C-1", bia.ID))[0]
+		assert.Equal(t, first.ID, moved.ID, "the key is updated in place")
+		assert.Equal(t, bia.ID, moved.EntityID)
+		assert.Equal(t, first.CreatedAt, moved.CreatedAt)
+
+		otherSystem := upsert(f, mapping(f, "shop", "C-1", ana.ID))[0]
+		assert.NotEqual(t, first.ID, otherSystem.ID)
+
+		foreign := upsert(other, mapping(other, "erp", "C-1", other.Contact().ID))[0]
+		assert.NotEqual(t, first.ID, foreign.ID, "other workspaces have their own keys")
+
+		resolved, err := mappings.ResolveExternalIDs(ctx, f.WorkspaceID, "erp", domain.IdentityEntityContact, []string{"C-1"})
+		require.NoError(t, err)
+		require.Len(t, resolved, 1)
+		assert.Equal(t, bia.ID, resolved[0].EntityID)
+	})
+
+	t.Run("resolves in both directions", func(t *testing.T) {
+		carla := f.Contact()
+		upsert(f, mapping(f, "erp", "C-2", carla.ID), mapping(f, "zendesk", "Z-2", carla.ID))
+
+		byExternal, err := mappings.ResolveExternalIDs(ctx, f.WorkspaceID, "zendesk", domain.IdentityEntityContact, []string{"Z-2", "Z-404"})
+		require.NoError(t, err)
+		require.Len(t, byExternal, 1)
+		assert.Equal(t, carla.ID, byExternal[0].EntityID)
+
+		byEntity, err := mappings.ResolveEntityIDs(ctx, f.WorkspaceID, "", domain.IdentityEntityContact, []string{carla.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"erp:C-2", "zendesk:Z-2"}, externalIDs(byEntity))
+
+		oneSystem, err := mappings.ResolveEntityIDs(ctx, f.WorkspaceID, "erp", domain.IdentityEntityContact, []string{carla.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"erp:C-2"}, externalIDs(oneSystem))
+
+		wrongType, err := mappings.ResolveEntityIDs(ctx, f.WorkspaceID, "", domain.IdentityEntityCompany, []string{carla.ID})
+		require.NoError(t, err)
+		assert.Empty(t, wrongType)
+
+		foreign, err := mappings.ResolveEntityIDs(ctx, other.WorkspaceID, "", domain.IdentityEntityContact, []string{carla.ID})
+		require.NoError(t, err)
+		assert.Empty(t, foreign)
+	})
+
+	t.Run("deleted records stop resolving and purged ones are cleaned up", func(t *testing.T) {
+		dora := f.Contact()
+		kept := f.Contact()
+		upsert(f, mapping(f, "erp", "C-3", dora.ID), mapping(f, "erp", "C-4", kept.ID))
+		resolve := func() []string {
+			t.Helper()
+			resolved, err := mappings.ResolveExternalIDs(ctx, f.WorkspaceID, "erp", domain.IdentityEntityContact, []string{"C-3", "C-4"})
+			require.NoError(t, err)
+			return externalIDs(resolved)
+		}
+
+		require.NoError(t, contacts.SoftDelete(ctx, f.WorkspaceID, dora.ID, f.UserID))
+		assert.Equal(t, []string{"erp:C-4"}, resolve(), "records in the trash do not resolve")
+		byEntity, err := mappings.ResolveEntityIDs(ctx, f.WorkspaceID, "", domain.IdentityEntityContact, []string{dora.ID})
+		require.NoError(t, err)
+		assert.Empty(t, byEntity)
+
+		require.NoError(t, contacts.Restore(ctx, f.WorkspaceID, dora.ID))
+		assert.Equal(t, []string{"erp:C-3", "erp:C-4"}, resolve(), "restoring brings the mapping back")
+
+		// Purga da lixeira: o registro some e o cleanup remove o mapping
+		_, err = pool.Exec(ctx, `DELETE FROM public."Contact" WHERE id = $1`, dora.ID)
+		require.NoError(t, err)
+		deleted, err := mappings.DeleteOrphaned(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		var remaining int
+		err = pool.QueryRow(ctx, `SELECT COUNT(*) FROM public."IdentityMapping" WHERE "entityId" = $1`, dora.ID).Scan(&remaining)
+		require.NoError(t, err)
+		assert.Zero(t, remaining)
+		assert.Equal(t, []string{"erp:C-4"}, resolve())
+	})
+}
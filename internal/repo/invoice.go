@@ -0,0 +1,200 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrInvoiceNotFound = apperr.NotFound("invoice not found in workspace", "invoice not found")
+
+	// ErrInvoiceExternalIDConflict outra fatura do workspace já usa o provider + externalId
+	ErrInvoiceExternalIDConflict = apperr.Conflict("invoice with this provider externalId already exists in workspace", "an invoice with this external ID already exists")
+)
+
+// InvoiceRepository persiste as faturas dos negócios ganhos.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type InvoiceRepository struct {
+	pool database.DB
+}
+
+func NewInvoiceRepository(pool database.DB) *InvoiceRepository {
+	return &InvoiceRepository{pool: pool}
+}
+
+const invoiceColumns = `id, "workspaceId", "dealId", number, status, amount, "amountPaid", currency, "dueDate",
+	"paidAt", provider, "externalId", "providerUpdatedAt", "createdById", "createdAt", "updatedAt"`
+
+// Create insere a fatura.
+func (r *InvoiceRepository) Create(ctx context.Context, inv *domain.Invoice) (*domain.Invoice, error) {
+	query := `
+		INSERT INTO public."Invoice" (
+			id, "workspaceId", "dealId", number, status, amount, "amountPaid", currency, "dueDate",
+			"paidAt", provider, "externalId", "createdById"
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING ` + invoiceColumns
+
+	created, err := scanInvoice(r.pool.QueryRow(ctx, query,
+		inv.ID, inv.WorkspaceID, inv.DealID, inv.Number, inv.Status, inv.Amount, inv.AmountPaid, inv.Currency, inv.DueDate,
+		inv.PaidAt, inv.Provider, inv.ExternalID, inv.CreatedByID,
+	))
+	if err != nil {
+		if isInvoiceExternalIDViolation(err) {
+			return nil, ErrInvoiceExternalIDConflict
+		}
+		return nil, fmt.Errorf("insert invoice: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna uma fatura do workspace.
+func (r *InvoiceRepository) Get(ctx context.Context, workspaceID, invoiceID string) (*domain.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM public."Invoice"
+		WHERE "workspaceId" = $1 AND id = $2`
+
+	inv, err := scanInvoice(r.pool.QueryRow(ctx, query, workspaceID, invoiceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("query invoice: %w", err)
+	}
+	return inv, nil
+}
+
+// GetByExternalID retorna a fatura do provedor com o id externo (nil se nenhuma).
+func (r *InvoiceRepository) GetByExternalID(ctx context.Context, workspaceID, provider, externalID string) (*domain.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM public."Invoice"
+		WHERE "workspaceId" = $1 AND provider = $2 AND "externalId" = $3`
+
+	inv, err := scanInvoice(r.pool.QueryRow(ctx, query, workspaceID, provider, externalID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("query invoice by external id: %w", err)
+	}
+	return inv, nil
+}
+
+// List retorna as faturas do workspace, mais recentes primeiro.
+func (r *InvoiceRepository) List(ctx context.Context, workspaceID string, params domain.InvoiceListParams) ([]domain.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM public."Invoice"
+		WHERE "workspaceId" = $1
+		  AND ($2::TEXT IS NULL OR "dealId" = $2)
+		  AND ($3::TEXT IS NULL OR status = $3)
+		ORDER BY "createdAt" DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, params.DealID, params.Status)
+	if err != nil {
+		return nil, fmt.Errorf("query invoices: %w", err)
+	}
+	defer rows.Close()
+
+	invoices := []domain.Invoice{}
+	for rows.Next() {
+		inv, err := scanInvoice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan invoice: %w", err)
+		}
+		invoices = append(invoices, *inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate invoices: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// Update grava os campos editáveis da fatura.
+func (r *InvoiceRepository) Update(ctx context.Context, inv *domain.Invoice) (*domain.Invoice, error) {
+	query := `
+		UPDATE public."Invoice"
+		SET number = $3, status = $4, amount = $5, "amountPaid" = $6, "dueDate" = $7, "paidAt" = $8,
+		    provider = $9, "externalId" = $10, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + invoiceColumns
+
+	updated, err := scanInvoice(r.pool.QueryRow(ctx, query,
+		inv.WorkspaceID, inv.ID, inv.Number, inv.Status, inv.Amount, inv.AmountPaid, inv.DueDate, inv.PaidAt,
+		inv.Provider, inv.ExternalID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvoiceNotFound
+		}
+		if isInvoiceExternalIDViolation(err) {
+			return nil, ErrInvoiceExternalIDConflict
+		}
+		return nil, fmt.Errorf("update invoice: %w", err)
+	}
+	return updated, nil
+}
+
+// ApplyProviderState grava o estado reportado pelo provedor. Retorna nil sem alterar nada se a
+// fatura já tem um evento mais recente que occurredAt (entrega fora de ordem).
+func (r *InvoiceRepository) ApplyProviderState(ctx context.Context, inv *domain.Invoice, occurredAt time.Time) (*domain.Invoice, error) {
+	query := `
+		UPDATE public."Invoice"
+		SET status = $3, amount = $4, "amountPaid" = $5, "dueDate" = $6, "paidAt" = $7,
+		    "providerUpdatedAt" = $8, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		  AND ("providerUpdatedAt" IS NULL OR "providerUpdatedAt" <= $8)
+		RETURNING ` + invoiceColumns
+
+	updated, err := scanInvoice(r.pool.QueryRow(ctx, query,
+		inv.WorkspaceID, inv.ID, inv.Status, inv.Amount, inv.AmountPaid, inv.DueDate, inv.PaidAt, occurredAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("apply invoice provider state: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete remove a fatura.
+func (r *InvoiceRepository) Delete(ctx context.Context, workspaceID, invoiceID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."Invoice" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, invoiceID)
+	if err != nil {
+		return fmt.Errorf("delete invoice: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrInvoiceNotFound
+	}
+	return nil
+}
+
+func isInvoiceExternalIDViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "Invoice_workspaceId_provider_externalId_key"
+}
+
+func scanInvoice(row pgx.Row) (*domain.Invoice, error) {
+	var inv domain.Invoice
+	err := row.Scan(
+		&inv.ID, &inv.WorkspaceID, &inv.DealID, &inv.Number, &inv.Status, &inv.Amount, &inv.AmountPaid, &inv.Currency, &inv.DueDate,
+		&inv.PaidAt, &inv.Provider, &inv.ExternalID, &inv.ProviderUpdatedAt, &inv.CreatedByID, &inv.CreatedAt, &inv.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
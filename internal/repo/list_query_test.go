@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Propriedades das listagens montadas com Sprintf (tarefas e pipelines), verificadas para
+// combinações aleatórias de filtros/cursor com seed fixa (falha reproduzível):
+//   - os placeholders são exatamente $1..$len(args) (nenhum argumento sobrando ou faltando);
+//   - nenhum valor do request aparece no texto do SQL (só entra como argumento);
+//   - a query termina em LIMIT $len(args) com Limit+1 e ordena por uma chave única (id no fim).
+
+const listQueryIterations = 500
+
+var placeholderRe = regexp.MustCompile(`\$(\d+)`)
+
+// hostileValues valores de filtro que quebrariam o SQL se fossem interpolados.
+var hostileValues = []string{
+	"usr_1",
+	"",
+	"'; DROP TABLE \"Task\"; --",
+	"$1",
+	"$99",
+	"%_\\",
+	"ação çã",
+	"a\x00b",
+	"\" OR 1=1 --",
+	strings.Repeat("x", 300),
+}
+
+func assertListQuery(t *testing.T, query string, args []interface{}, limit int, hostile []string) {
+	t.Helper()
+
+	used := map[int]bool{}
+	for _, m := range placeholderRe.FindAllStringSubmatch(query, -1) {
+		n, err := strconv.Atoi(m[1])
+		require.NoError(t, err)
+		used[n] = true
+	}
+	for n := 1; n <= len(args); n++ {
+		assert.True(t, used[n], "argument $%d is never referenced\n%s", n, query)
+	}
+	for n := range used {
+		assert.True(t, n >= 1 && n <= len(args), "placeholder $%d without argument (%d args)\n%s", n, len(args), query)
+	}
+
+	for _, value := range hostile {
+		if len(value) > 3 { // valores curtos ("", "$1") colidem com o próprio SQL
+			assert.NotContains(t, query, value, "request value interpolated into SQL")
+		}
+	}
+
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(query), fmt.Sprintf("LIMIT $%d", len(args))), query)
+	assert.Equal(t, limit+1, args[len(args)-1])
+	assert.Regexp(t, `ORDER BY .*\bid (ASC|DESC) LIMIT`, query, "ordering must end on the unique id")
+}
+
+func pick[T any](rng *rand.Rand, values []T) *T {
+	if rng.IntN(2) == 0 {
+		return nil
+	}
+	v := values[rng.IntN(len(values))]
+	return &v
+}
+
+func randomCursor(rng *rand.Rand, valid func() string) *string {
+	var cursor string
+	switch rng.IntN(4) {
+	case 0:
+		return nil
+	case 1:
+		cursor = valid()
+	case 2:
+		cursor = hostileValues[rng.IntN(len(hostileValues))]
+	default:
+		cursor = time.Unix(rng.Int64N(2e9), 0).UTC().Format(time.RFC3339) // formato antigo
+	}
+	return &cursor
+}
+
+func TestBuildTaskListQuery_RandomParams(t *testing.T) {
+	rng := rand.New(rand.NewPCG(4969, 1))
+	statuses := []domain.TaskStatus{domain.TaskStatusTodo, domain.TaskStatusInProgress, domain.TaskStatusDone, domain.TaskStatusBacklog}
+	priorities := []domain.Priority{domain.PriorityLow, domain.PriorityHigh}
+	types := []domain.TaskType{domain.TaskTypeTask, domain.TaskTypeCall}
+
+	for i := 0; i < listQueryIterations; i++ {
+		params := domain.ListTasksParams{
+			WorkspaceID: "wks_1",
+			Status:      pick(rng, statuses),
+			Priority:    pick(rng, priorities),
+			Type:        pick(rng, types),
+			AssignedTo:  pick(rng, hostileValues),
+			ActorID:     pick(rng, hostileValues),
+			ContactID:   pick(rng, hostileValues),
+			FollowerID:  pick(rng, hostileValues),
+			Viewer:      pick(rng, hostileValues),
+			Query:       pick(rng, hostileValues),
+			Limit:       1 + rng.IntN(100),
+			Cursor: randomCursor(rng, func() string {
+				return encodePositionCursor(rng.Float64()*1e6, hostileValues[rng.IntN(len(hostileValues))])
+			}),
+		}
+
+		query, args, err := buildTaskListQuery(params)
+		if err != nil {
+			assert.ErrorIs(t, err, ErrInvalidCursor, "iteration %d", i)
+			continue
+		}
+		assertListQuery(t, query, args, params.Limit, hostileValues)
+		if t.Failed() {
+			t.Fatalf("iteration %d: params %+v", i, params)
+		}
+	}
+}
+
+func TestBuildPipelineListQuery_RandomParams(t *testing.T) {
+	rng := rand.New(rand.NewPCG(4969, 2))
+	bools := []bool{true, false}
+	pipelineTypes := []domain.PipelineType{domain.PipelineTypeDeal, domain.PipelineTypeTicket}
+
+	for i := 0; i < listQueryIterations; i++ {
+		params := domain.ListPipelinesParams{
+			WorkspaceID:  "wks_1",
+			IsDefault:    pick(rng, bools),
+			PipelineType: pick(rng, pipelineTypes),
+			IsActive:     pick(rng, bools),
+			OwnerID:      pick(rng, hostileValues),
+			Query:        pick(rng, hostileValues),
+			Limit:        1 + rng.IntN(100),
+			Cursor: randomCursor(rng, func() string {
+				return encodeTimeCursor(time.UnixMilli(rng.Int64N(4e12)), hostileValues[rng.IntN(len(hostileValues))])
+			}),
+		}
+
+		query, args, err := buildPipelineListQuery(params)
+		if err != nil {
+			assert.ErrorIs(t, err, ErrInvalidCursor, "iteration %d", i)
+			continue
+		}
+		assertListQuery(t, query, args, params.Limit, hostileValues)
+		if t.Failed() {
+			t.Fatalf("iteration %d: params %+v", i, params)
+		}
+	}
+}
+
+// FuzzBuildTaskListQuery: filtros e cursor arbitrários nunca geram panic nem SQL com
+// placeholders inconsistentes.
+func FuzzBuildTaskListQuery(f *testing.F) {
+	f.Add("usr_1", "cnt_1", "proposta", "", 50)
+	f.Add("'; --", "$2", "a & b | !c", encodePositionCursor(1000, "tsk_1"), 1)
+	f.Add("", "", "", "2026-10-18T12:30:45Z", 100)
+
+	f.Fuzz(func(t *testing.T, assignedTo, contactID, query, cursor string, limit int) {
+		if limit < 1 || limit > 100 {
+			limit = 50 // Normalize
+		}
+		params := domain.ListTasksParams{
+			WorkspaceID: "wks_1",
+			AssignedTo:  &assignedTo,
+			ContactID:   &contactID,
+			Viewer:      &assignedTo,
+			Query:       &query,
+			Cursor:      &cursor,
+			Limit:       limit,
+		}
+
+		sql, args, err := buildTaskListQuery(params)
+		if err != nil {
+			if !assert.ErrorIs(t, err, ErrInvalidCursor) {
+				t.FailNow()
+			}
+			return
+		}
+		assertListQuery(t, sql, args, limit, nil)
+	})
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrMaintenanceNotFound = apperr.NotFound("maintenance not enabled for scope", "maintenance is not enabled")
+
+// MaintenanceRepository persiste os modos de manutenção (global e por workspace).
+// IMPORTANT: Uses camelCase column names with double quotes.
+type MaintenanceRepository struct {
+	pool database.DB
+}
+
+func NewMaintenanceRepository(pool database.DB) *MaintenanceRepository {
+	return &MaintenanceRepository{pool: pool}
+}
+
+const maintenanceColumns = `"scope", "message", "endsAt", "startedAt"`
+
+// List retorna todas as manutenções ligadas, a global primeiro.
+func (r *MaintenanceRepository) List(ctx context.Context) ([]domain.Maintenance, error) {
+	query := `
+		SELECT ` + maintenanceColumns + `
+		FROM public."Maintenance"
+		ORDER BY "scope" <> $1, "startedAt"`
+
+	rows, err := r.pool.Query(ctx, query, domain.MaintenanceScopeGlobal)
+	if err != nil {
+		return nil, fmt.Errorf("list maintenance: %w", err)
+	}
+	defer rows.Close()
+
+	maintenances := []domain.Maintenance{}
+	for rows.Next() {
+		m, err := scanMaintenance(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan maintenance: %w", err)
+		}
+		maintenances = append(maintenances, *m)
+	}
+	return maintenances, rows.Err()
+}
+
+// Upsert liga a manutenção do escopo ou atualiza mensagem e previsão; "startedAt" é preservado.
+func (r *MaintenanceRepository) Upsert(ctx context.Context, scope string, req *domain.SetMaintenanceRequest) (*domain.Maintenance, error) {
+	query := `
+		INSERT INTO public."Maintenance" ("scope", "message", "endsAt")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("scope") DO UPDATE
+		SET "message" = EXCLUDED."message", "endsAt" = EXCLUDED."endsAt"
+		RETURNING ` + maintenanceColumns
+
+	m, err := scanMaintenance(r.pool.QueryRow(ctx, query, scope, req.Message, req.EndsAt))
+	if err != nil {
+		return nil, fmt.Errorf("upsert maintenance: %w", err)
+	}
+	return m, nil
+}
+
+// Delete desliga a manutenção do escopo.
+func (r *MaintenanceRepository) Delete(ctx context.Context, scope string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."Maintenance" WHERE "scope" = $1`, scope)
+	if err != nil {
+		return fmt.Errorf("delete maintenance: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrMaintenanceNotFound
+	}
+	return nil
+}
+
+func scanMaintenance(row pgx.Row) (*domain.Maintenance, error) {
+	var m domain.Maintenance
+	if err := row.Scan(&m.Scope, &m.Message, &m.EndsAt, &m.StartedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
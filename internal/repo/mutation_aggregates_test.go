@@ -0,0 +1,67 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestMutationAggregates_Integration
+func TestMutationAggregates_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	t.Run("stage aggregates", func(t *testing.T) {
+		deals := repo.NewDealRepository(pool)
+		pipeline := f.Pipeline()
+		lead, proposal, won := pipeline.Stages[0].ID, pipeline.Stages[1].ID, pipeline.Stages[2].ID
+		inStage := func(stageID string, value float64) func(*domain.Deal) {
+			return func(d *domain.Deal) { d.PipelineID = pipeline.ID; d.StageID = &stageID; d.Value = &value }
+		}
+		f.Deal(inStage(lead, 1000))
+		f.Deal(inStage(lead, 250.5))
+		deleted := f.Deal(inStage(lead, 9999))
+		_, err := pool.Exec(ctx, `UPDATE public."Deal" SET "deletedAt" = NOW() WHERE id = $1`, deleted.ID)
+		require.NoError(t, err)
+		f.Deal(inStage(proposal, 400))
+
+		// Na ordem pedida; estágio sem negócios vem zerado
+		got, err := deals.StageAggregates(ctx, f.WorkspaceID, []string{won, lead, proposal})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.StageAggregate{
+			{StageID: won, Count: 0, Value: 0},
+			{StageID: lead, Count: 2, Value: 1250.5},
+			{StageID: proposal, Count: 1, Value: 400},
+		}, got)
+
+		// Outro workspace não enxerga os negócios
+		other := factory.New(t, pool)
+		got, err = deals.StageAggregates(ctx, other.WorkspaceID, []string{lead})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.StageAggregate{{StageID: lead}}, got)
+	})
+
+	t.Run("task status aggregates", func(t *testing.T) {
+		tasks := repo.NewTaskRepository(pool)
+		status := func(s domain.TaskStatus) func(*domain.Task) {
+			return func(task *domain.Task) { task.Status = s }
+		}
+		f.Task(status(domain.TaskStatusTodo))
+		f.Task(status(domain.TaskStatusTodo))
+		f.Task(status(domain.TaskStatusDone))
+
+		got, err := tasks.StatusAggregates(ctx, f.WorkspaceID, []domain.TaskStatus{domain.TaskStatusInProgress, domain.TaskStatusTodo, domain.TaskStatusDone}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskStatusAggregate{
+			{Status: domain.TaskStatusInProgress, Count: 0},
+			{Status: domain.TaskStatusTodo, Count: 2},
+			{Status: domain.TaskStatusDone, Count: 1},
+		}, got)
+	})
+}
@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+)
+
+// MyWorkRepository lê as seções de GET /me/work.
+// Cada query usa um índice parcial dedicado (migration 000010) e devolve o total
+// da seção via COUNT(*) OVER (), evitando uma segunda query de contagem.
+type MyWorkRepository struct {
+	pool database.DB
+}
+
+func NewMyWorkRepository(pool database.DB) *MyWorkRepository {
+	return &MyWorkRepository{pool: pool}
+}
+
+// TasksDueBetween retorna as tarefas abertas atribuídas ao usuário com vencimento em [from, to).
+// from nil = sem limite inferior (tarefas atrasadas).
+func (r *MyWorkRepository) TasksDueBetween(ctx context.Context, workspaceID, userID string, from *time.Time, to time.Time, limit int) (*domain.MyWorkTasks, error) {
+	query := `
+		SELECT id, workspace_id, title, description, status, priority, type,
+		       position, visibility, owner_id, assigned_to, contact_id,
+		       due_date, completed_at, created_at, updated_at, deleted_at,
+		       COUNT(*) OVER ()
+		FROM public."Task"
+		WHERE workspace_id = $1 AND assigned_to = $2
+		  AND deleted_at IS NULL
+		  AND status IN ('TODO', 'IN_PROGRESS')
+		  AND due_date IS NOT NULL
+		  AND ($3::TIMESTAMP IS NULL OR due_date >= $3)
+		  AND due_date < $4
+		ORDER BY due_date ASC, position ASC
+		LIMIT $5`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, userID, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query my work tasks: %w", err)
+	}
+	defer rows.Close()
+
+	section := &domain.MyWorkTasks{Items: []domain.Task{}}
+	for rows.Next() {
+		var t domain.Task
+		var deletedAt sql.NullTime
+		err := rows.Scan(
+			&t.ID, &t.WorkspaceID, &t.Title, &t.Description,
+			&t.Status, &t.Priority, &t.Type, &t.Position, &t.Visibility,
+			&t.ActorID, &t.AssignedTo, &t.ContactID,
+			&t.DueDate, &t.CompletedAt,
+			&t.CreatedAt, &t.UpdatedAt, &deletedAt,
+			&section.Count,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan my work task: %w", err)
+		}
+		if deletedAt.Valid {
+			t.DeletedAt = &deletedAt.Time
+		}
+		section.Items = append(section.Items, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate my work tasks: %w", err)
+	}
+
+	return section, nil
+}
+
+// UpcomingMeetings retorna as reuniões do usuário com início em [from, to).
+func (r *MyWorkRepository) UpcomingMeetings(ctx context.Context, workspaceID, userID string, from, to time.Time, limit int) (*domain.MyWorkMeetings, error) {
+	query := `
+		SELECT id, title, "meetingType"::TEXT, "startTime", "endTime", location, "meetingUrl",
+		       COUNT(*) OVER ()
+		FROM public."Meeting"
+		WHERE "workspaceId" = $1 AND "userId" = $2
+		  AND "deletedAt" IS NULL
+		  AND "startTime" >= $3 AND "startTime" < $4
+		ORDER BY "startTime" ASC, id
+		LIMIT $5`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, userID, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query my work meetings: %w", err)
+	}
+	defer rows.Close()
+
+	section := &domain.MyWorkMeetings{Items: []domain.MyWorkMeeting{}}
+	for rows.Next() {
+		var m domain.MyWorkMeeting
+		if err := rows.Scan(&m.ID, &m.Title, &m.MeetingType, &m.StartTime, &m.EndTime, &m.Location, &m.MeetingURL, &section.Count); err != nil {
+			return nil, fmt.Errorf("scan my work meeting: %w", err)
+		}
+		section.Items = append(section.Items, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate my work meetings: %w", err)
+	}
+
+	return section, nil
+}
+
+// DealsRequiringAttention retorna os negócios OPEN do usuário com próximo passo vencido,
+// sem próximo passo ou com data prevista de fechamento anterior a today.
+func (r *MyWorkRepository) DealsRequiringAttention(ctx context.Context, workspaceID, userID string, now, today time.Time, limit int) (*domain.MyWorkDeals, error) {
+	query := `
+		SELECT id, name, "pipelineId", "stageId", value, currency, "nextStepAt", "expectedCloseDate",
+		       CASE
+		           WHEN "nextStepAt" < $3 THEN 'next_step_overdue'
+		           WHEN "nextStepAt" IS NULL THEN 'no_next_step'
+		           ELSE 'close_date_passed'
+		       END,
+		       COUNT(*) OVER ()
+		FROM public."Deal"
+		WHERE "workspaceId" = $1 AND "ownerId" = $2
+		  AND "deletedAt" IS NULL
+		  AND stage = 'OPEN'
+		  AND ("nextStepAt" IS NULL OR "nextStepAt" < $3 OR "expectedCloseDate" < $4)
+		ORDER BY "nextStepAt" ASC NULLS LAST, "expectedCloseDate" ASC NULLS LAST, id
+		LIMIT $5`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, userID, now, today, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query my work deals: %w", err)
+	}
+	defer rows.Close()
+
+	section := &domain.MyWorkDeals{Items: []domain.MyWorkDeal{}}
+	for rows.Next() {
+		var d domain.MyWorkDeal
+		var reason string
+		err := rows.Scan(
+			&d.ID, &d.Name, &d.PipelineID, &d.StageID, &d.Value, &d.Currency, &d.NextStepAt, &d.ExpectedCloseDate,
+			&reason, &section.Count,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan my work deal: %w", err)
+		}
+		d.Reason = domain.DealAttentionReason(reason)
+		section.Items = append(section.Items, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate my work deals: %w", err)
+	}
+
+	return section, nil
+}
@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNoteNotFound           = apperr.NotFound("note not found in workspace", "note not found")
+	ErrNoteAttachmentNotFound = apperr.NotFound("note attachment not found in workspace", "attachment not found")
+)
+
+const noteColumns = `id, "workspaceId", "companyId", "contactId", "dealId", content, "isPinned", "userId",
+	"createdAt", "updatedAt", "deletedAt", body, "pinnedAt", "pinnedById", "visibility"`
+
+const noteAttachmentColumns = `id, "workspaceId", "noteId", "fileName", "contentType", size, "storageKey",
+	"uploadedById", "createdAt"`
+
+// noteVisibleTo monta o predicado de visibilidade da nota (alias n) para o usuário no
+// parâmetro $argIdx: notas WORKSPACE, as do próprio autor e as TEAM de contatos/negócios
+// que ele segue ou dos quais é dono.
+func noteVisibleTo(n string, argIdx int) string {
+	return fmt.Sprintf(`(%[1]s."visibility" = 'WORKSPACE' OR %[1]s."userId" = $%[2]d
+		OR (%[1]s."visibility" = 'TEAM' AND (
+			EXISTS (SELECT 1 FROM public."Follower" f WHERE f."userId" = $%[2]d
+				AND ((f."entityType" = 'CONTACT' AND f."entityId" = %[1]s."contactId") OR (f."entityType" = 'DEAL' AND f."entityId" = %[1]s."dealId")))
+			OR EXISTS (SELECT 1 FROM public."Contact" c WHERE c.id = %[1]s."contactId" AND c."ownerId" = $%[2]d)
+			OR EXISTS (SELECT 1 FROM public."Deal" d WHERE d.id = %[1]s."dealId" AND d."ownerId" = $%[2]d))))`, n, argIdx)
+}
+
+// GetNote retorna uma nota ativa do workspace. Nota que o viewer não enxerga é reportada
+// como inexistente; viewer nil (admin) não filtra.
+func (r *ActivityRepository) GetNote(ctx context.Context, workspaceID, noteID string, viewer *string) (*domain.Note, error) {
+	query := `
+		SELECT ` + noteColumns + `
+		FROM public."Note" n
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`
+	args := []interface{}{noteID, workspaceID}
+	if viewer != nil {
+		query += " AND " + noteVisibleTo("n", 3)
+		args = append(args, *viewer)
+	}
+
+	note, err := scanNote(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("query note: %w", err)
+	}
+	return note, nil
+}
+
+// UpdateNoteBody grava o texto rico e a versão texto puro da nota (e a visibilidade, se informada).
+func (r *ActivityRepository) UpdateNoteBody(ctx context.Context, workspaceID, noteID string, body *domain.RichTextNode, content string, visibility *domain.Visibility) (*domain.Note, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encode note body: %w", err)
+	}
+
+	query := `
+		UPDATE public."Note"
+		SET body = $3, content = $4, "visibility" = COALESCE($5, "visibility"), "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
+		RETURNING ` + noteColumns
+
+	note, err := scanNote(r.pool.QueryRow(ctx, query, noteID, workspaceID, raw, content, visibility))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("update note: %w", err)
+	}
+	return note, nil
+}
+
+// SetNotePinned fixa (pinnedByID != nil) ou desafixa a nota no topo da timeline.
+// Fixar de novo uma nota já fixada preserva o pinnedAt original.
+func (r *ActivityRepository) SetNotePinned(ctx context.Context, workspaceID, noteID string, pinnedByID *string) (*domain.Note, error) {
+	query := `
+		UPDATE public."Note"
+		SET "isPinned" = $3::TEXT IS NOT NULL,
+		    "pinnedAt" = CASE WHEN $3::TEXT IS NULL THEN NULL ELSE COALESCE("pinnedAt", NOW()) END,
+		    "pinnedById" = CASE WHEN $3::TEXT IS NULL THEN NULL ELSE COALESCE("pinnedById", $3) END,
+		    "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
+		RETURNING ` + noteColumns
+
+	note, err := scanNote(r.pool.QueryRow(ctx, query, noteID, workspaceID, pinnedByID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("pin note: %w", err)
+	}
+	return note, nil
+}
+
+// CreateNoteAttachment registra um anexo já gravado no object storage.
+func (r *ActivityRepository) CreateNoteAttachment(ctx context.Context, a *domain.NoteAttachment) (*domain.NoteAttachment, error) {
+	query := `
+		INSERT INTO public."NoteAttachment" (id, "workspaceId", "noteId", "fileName", "contentType", size, "storageKey", "uploadedById")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + noteAttachmentColumns
+
+	created, err := scanNoteAttachment(r.pool.QueryRow(ctx, query,
+		a.ID, a.WorkspaceID, a.NoteID, a.FileName, a.ContentType, a.Size, a.StorageKey, a.UploadedByID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert note attachment: %w", err)
+	}
+	return created, nil
+}
+
+// ListNoteAttachments lista os anexos da nota em ordem de envio.
+func (r *ActivityRepository) ListNoteAttachments(ctx context.Context, workspaceID, noteID string) ([]domain.NoteAttachment, error) {
+	query := `
+		SELECT ` + noteAttachmentColumns + `
+		FROM public."NoteAttachment"
+		WHERE "workspaceId" = $1 AND "noteId" = $2
+		ORDER BY "createdAt", id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("query note attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []domain.NoteAttachment{}
+	for rows.Next() {
+		a, err := scanNoteAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan note attachment: %w", err)
+		}
+		attachments = append(attachments, *a)
+	}
+	return attachments, rows.Err()
+}
+
+// GetNoteAttachment retorna um anexo de uma nota ativa do workspace, visível ao viewer
+// (nil = sem filtro).
+func (r *ActivityRepository) GetNoteAttachment(ctx context.Context, workspaceID, noteID, attachmentID string, viewer *string) (*domain.NoteAttachment, error) {
+	query := `
+		SELECT a.id, a."workspaceId", a."noteId", a."fileName", a."contentType", a.size, a."storageKey",
+			a."uploadedById", a."createdAt"
+		FROM public."NoteAttachment" a
+		JOIN public."Note" n ON n.id = a."noteId" AND n."deletedAt" IS NULL
+		WHERE a.id = $1 AND a."workspaceId" = $2 AND a."noteId" = $3`
+	args := []interface{}{attachmentID, workspaceID, noteID}
+	if viewer != nil {
+		query += " AND " + noteVisibleTo("n", 4)
+		args = append(args, *viewer)
+	}
+
+	a, err := scanNoteAttachment(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoteAttachmentNotFound
+		}
+		return nil, fmt.Errorf("query note attachment: %w", err)
+	}
+	return a, nil
+}
+
+func scanNote(row pgx.Row) (*domain.Note, error) {
+	var n domain.Note
+	var body []byte
+	err := row.Scan(
+		&n.ID, &n.WorkspaceID, &n.CompanyID, &n.ContactID, &n.DealID, &n.Content, &n.IsPinned, &n.UserID,
+		&n.CreatedAt, &n.UpdatedAt, &n.DeletedAt, &body, &n.PinnedAt, &n.PinnedByID, &n.Visibility,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n.Body = decodeNoteBody(body, n.Content)
+	return &n, nil
+}
+
+func scanNoteAttachment(row pgx.Row) (*domain.NoteAttachment, error) {
+	var a domain.NoteAttachment
+	err := row.Scan(
+		&a.ID, &a.WorkspaceID, &a.NoteID, &a.FileName, &a.ContentType, &a.Size, &a.StorageKey,
+		&a.UploadedByID, &a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// decodeNoteBody lê o body JSONB; notas sem body (ou com body ilegível) usam o texto puro.
+func decodeNoteBody(raw []byte, content string) *domain.RichTextNode {
+	if len(raw) > 0 {
+		var body domain.RichTextNode
+		if err := json.Unmarshal(raw, &body); err == nil && body.Type == "doc" {
+			return &body
+		}
+	}
+	return domain.NewPlainTextDoc(content)
+}
@@ -0,0 +1,69 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestFollowerRepository_NotificationPreferences_Integration
+func TestFollowerRepository_NotificationPreferences_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	followers := repo.NewFollowerRepository(pool)
+
+	member := f.Member(domain.RoleUser)
+
+	t.Run("defaults until the user configures", func(t *testing.T) {
+		got, err := followers.GetNotificationPreferences(ctx, f.WorkspaceID, member)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultNotificationPreferences(f.WorkspaceID, member), got)
+
+		listed, err := followers.ListNotificationPreferences(ctx, f.WorkspaceID, []string{member})
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+	})
+
+	prefs := domain.DefaultNotificationPreferences(f.WorkspaceID, member)
+	prefs.Events[domain.NotificationMentioned] = domain.NotificationChannelEmail
+	prefs.Events[domain.NotificationUpdated] = domain.NotificationChannelNone
+	prefs.QuietHours = domain.QuietHours{Enabled: true, Start: "22:00", End: "07:00", Timezone: "America/Sao_Paulo"}
+
+	t.Run("upsert round-trips", func(t *testing.T) {
+		saved, err := followers.UpsertNotificationPreferences(ctx, prefs)
+		require.NoError(t, err)
+		require.NotNil(t, saved.UpdatedAt)
+		assert.Equal(t, prefs.Events, saved.Events)
+		assert.Equal(t, prefs.QuietHours, saved.QuietHours)
+
+		got, err := followers.GetNotificationPreferences(ctx, f.WorkspaceID, member)
+		require.NoError(t, err)
+		assert.Equal(t, saved, got)
+
+		listed, err := followers.ListNotificationPreferences(ctx, f.WorkspaceID, []string{member, f.UserID})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, saved, listed[member])
+	})
+
+	t.Run("disabling quiet hours clears the range", func(t *testing.T) {
+		prefs.QuietHours = domain.QuietHours{}
+		saved, err := followers.UpsertNotificationPreferences(ctx, prefs)
+		require.NoError(t, err)
+		assert.False(t, saved.QuietHours.Enabled)
+		assert.Equal(t, domain.NotificationChannelEmail, saved.Channel(domain.NotificationMentioned))
+	})
+
+	t.Run("preferences are per workspace", func(t *testing.T) {
+		other := factory.New(t, pool)
+		got, err := followers.GetNotificationPreferences(ctx, other.WorkspaceID, member)
+		require.NoError(t, err)
+		assert.Nil(t, got.UpdatedAt)
+	})
+}
@@ -0,0 +1,328 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrOrganizationNotFound = apperr.NotFound("organization not found", "organization not found")
+
+	// ErrOrgMemberNotFound o ator não tem papel na organização
+	ErrOrgMemberNotFound = apperr.Forbidden("user is not a member of this organization", "insufficient permissions for this organization")
+)
+
+// OrganizationRepository acessa organizações, papéis de organização e os agregados entre
+// workspaces (diretório, cobrança, relatórios).
+// IMPORTANT: Uses camelCase column names with double quotes.
+type OrganizationRepository struct {
+	pool database.DB
+}
+
+func NewOrganizationRepository(pool database.DB) *OrganizationRepository {
+	return &OrganizationRepository{pool: pool}
+}
+
+const organizationColumns = `o.id, o.name, o.document, o."billingEmail",
+	(SELECT COUNT(*) FROM public."Workspace" w WHERE w."organizationId" = o.id),
+	o."createdAt", o."updatedAt"`
+
+func scanOrganization(row pgx.Row, extra ...any) (*domain.Organization, error) {
+	var o domain.Organization
+	dest := append([]any{&o.ID, &o.Name, &o.Document, &o.BillingEmail, &o.WorkspaceCount, &o.CreatedAt, &o.UpdatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// GetMemberRole retorna o papel do usuário na organização (ErrOrgMemberNotFound se não tiver).
+func (r *OrganizationRepository) GetMemberRole(ctx context.Context, userID, orgID string) (domain.OrgRole, error) {
+	query := `
+		SELECT role
+		FROM public."OrganizationMember"
+		WHERE "organizationId" = $1 AND "userId" = $2`
+
+	var role domain.OrgRole
+	if err := r.pool.QueryRow(ctx, query, orgID, userID).Scan(&role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrOrgMemberNotFound
+		}
+		return "", fmt.Errorf("query organization member role: %w", err)
+	}
+	if !role.IsValid() {
+		return "", fmt.Errorf("invalid org role '%s' for user %s in organization %s: %w", role, userID, orgID, ErrInvalidRole)
+	}
+	return role, nil
+}
+
+// ListByUser retorna as organizações em que o usuário tem papel, com o papel preenchido.
+func (r *OrganizationRepository) ListByUser(ctx context.Context, userID string) ([]domain.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `, om.role
+		FROM public."Organization" o
+		JOIN public."OrganizationMember" om ON om."organizationId" = o.id
+		WHERE om."userId" = $1
+		ORDER BY o.name, o.id`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query organizations: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.Organization{}
+	for rows.Next() {
+		var role domain.OrgRole
+		o, err := scanOrganization(rows, &role)
+		if err != nil {
+			return nil, fmt.Errorf("scan organization: %w", err)
+		}
+		o.Role = role
+		result = append(result, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate organizations: %w", err)
+	}
+	return result, nil
+}
+
+// Get retorna a organização (sem o papel do ator).
+func (r *OrganizationRepository) Get(ctx context.Context, orgID string) (*domain.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM public."Organization" o
+		WHERE o.id = $1`
+
+	o, err := scanOrganization(r.pool.QueryRow(ctx, query, orgID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("query organization: %w", err)
+	}
+	return o, nil
+}
+
+// Update aplica nome e email de cobrança (nil = mantém; billingEmail "" = remove).
+func (r *OrganizationRepository) Update(ctx context.Context, orgID string, req *domain.UpdateOrganizationRequest) (*domain.Organization, error) {
+	query := `
+		UPDATE public."Organization" o
+		SET name = COALESCE($2, o.name),
+		    "billingEmail" = CASE WHEN $3::TEXT IS NULL THEN o."billingEmail" ELSE NULLIF($3::TEXT, '') END,
+		    "updatedAt" = NOW()
+		WHERE o.id = $1
+		RETURNING ` + organizationColumns
+
+	o, err := scanOrganization(r.pool.QueryRow(ctx, query, orgID, req.Name, req.BillingEmail))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("update organization: %w", err)
+	}
+	return o, nil
+}
+
+// Billing retorna a cobrança compartilhada: ids do Stripe, workspaces e assentos (usuários
+// distintos com acesso a algum workspace da organização).
+func (r *OrganizationRepository) Billing(ctx context.Context, orgID string) (*domain.OrganizationBilling, error) {
+	query := `
+		SELECT o.id, o."billingEmail", o.stripe_customer_id, o.stripe_subscription_id,
+		       (SELECT COUNT(*) FROM public."Workspace" w WHERE w."organizationId" = o.id),
+		       (SELECT COUNT(DISTINCT m."userId")
+		          FROM public."WorkspaceMember" m
+		          JOIN public."Workspace" w ON w.id = m."workspaceId"
+		         WHERE w."organizationId" = o.id)
+		FROM public."Organization" o
+		WHERE o.id = $1`
+
+	var b domain.OrganizationBilling
+	err := r.pool.QueryRow(ctx, query, orgID).Scan(&b.OrganizationID, &b.BillingEmail,
+		&b.StripeCustomerID, &b.StripeSubscriptionID, &b.WorkspaceCount, &b.Seats)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("query organization billing: %w", err)
+	}
+	return &b, nil
+}
+
+// ListWorkspaces retorna os workspaces da organização com o número de membros.
+func (r *OrganizationRepository) ListWorkspaces(ctx context.Context, orgID string) ([]domain.OrganizationWorkspace, error) {
+	query := `
+		SELECT w.id, w.name, w.slug, w."ownerId",
+		       (SELECT COUNT(*) FROM public."WorkspaceMember" m WHERE m."workspaceId" = w.id),
+		       w."createdAt"
+		FROM public."Workspace" w
+		WHERE w."organizationId" = $1
+		ORDER BY w.name, w.id`
+
+	rows, err := r.pool.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("query organization workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.OrganizationWorkspace{}
+	for rows.Next() {
+		var w domain.OrganizationWorkspace
+		if err := rows.Scan(&w.ID, &w.Name, &w.Slug, &w.OwnerID, &w.MemberCount, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan organization workspace: %w", err)
+		}
+		result = append(result, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate organization workspaces: %w", err)
+	}
+	return result, nil
+}
+
+const organizationDirectorySQL = `
+WITH org_ws AS (
+    SELECT id, name FROM public."Workspace" WHERE "organizationId" = $1
+),
+people AS (
+    SELECT "userId" FROM public."OrganizationMember" WHERE "organizationId" = $1
+    UNION
+    SELECT m."userId" FROM public."WorkspaceMember" m JOIN org_ws ON org_ws.id = m."workspaceId"
+)
+SELECT p."userId", u.name, u.email, u.image, om.role, w.id, w.name, wr.name
+FROM people p
+JOIN public."User" u ON u.id = p."userId" AND u."deletedAt" IS NULL
+LEFT JOIN public."OrganizationMember" om ON om."organizationId" = $1 AND om."userId" = p."userId"
+LEFT JOIN public."WorkspaceMember" m ON m."userId" = p."userId" AND m."workspaceId" IN (SELECT id FROM org_ws)
+LEFT JOIN org_ws w ON w.id = m."workspaceId"
+LEFT JOIN public."WorkspaceRole" wr ON wr.id = m."workspaceRoleId"
+WHERE ($2::TEXT IS NULL OR p."userId" = $2)
+ORDER BY lower(COALESCE(u.name, u.email, u.id)), p."userId", w.name`
+
+// ListDirectory retorna o diretório compartilhado: usuários com papel na organização ou em
+// algum workspace dela, com os papéis por workspace. userID filtra um único usuário.
+func (r *OrganizationRepository) ListDirectory(ctx context.Context, orgID string, userID *string) ([]domain.OrganizationMember, error) {
+	rows, err := r.pool.Query(ctx, organizationDirectorySQL, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query organization directory: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.OrganizationMember{}
+	for rows.Next() {
+		var (
+			m                    domain.OrganizationMember
+			orgRole              *domain.OrgRole
+			wsID, wsName, wsRole *string
+		)
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &m.Image, &orgRole, &wsID, &wsName, &wsRole); err != nil {
+			return nil, fmt.Errorf("scan organization member: %w", err)
+		}
+		// Linhas do mesmo usuário chegam em sequência (ORDER BY)
+		if n := len(result); n == 0 || result[n-1].UserID != m.UserID {
+			m.OrgRole = orgRole
+			m.Workspaces = []domain.OrganizationMemberWorkspace{}
+			result = append(result, m)
+		}
+		if wsID != nil {
+			last := &result[len(result)-1]
+			ws := domain.OrganizationMemberWorkspace{WorkspaceID: *wsID}
+			if wsName != nil {
+				ws.WorkspaceName = *wsName
+			}
+			if wsRole != nil {
+				ws.Role = domain.Role(*wsRole)
+			}
+			last.Workspaces = append(last.Workspaces, ws)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate organization directory: %w", err)
+	}
+	return result, nil
+}
+
+// SetMemberRole cria ou altera o papel do usuário na organização.
+func (r *OrganizationRepository) SetMemberRole(ctx context.Context, id, orgID, userID string, role domain.OrgRole) error {
+	query := `
+		INSERT INTO public."OrganizationMember" (id, "organizationId", "userId", role)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("organizationId", "userId")
+		DO UPDATE SET role = EXCLUDED.role, "updatedAt" = NOW()`
+
+	if _, err := r.pool.Exec(ctx, query, id, orgID, userID, role); err != nil {
+		return fmt.Errorf("upsert organization member: %w", err)
+	}
+	return nil
+}
+
+// DeleteMember remove o papel do usuário na organização (os papéis nos workspaces ficam).
+func (r *OrganizationRepository) DeleteMember(ctx context.Context, orgID, userID string) (bool, error) {
+	query := `DELETE FROM public."OrganizationMember" WHERE "organizationId" = $1 AND "userId" = $2`
+
+	tag, err := r.pool.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete organization member: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CountAdmins conta os org_admin da organização.
+func (r *OrganizationRepository) CountAdmins(ctx context.Context, orgID string) (int, error) {
+	query := `SELECT COUNT(*) FROM public."OrganizationMember" WHERE "organizationId" = $1 AND role = 'org_admin'`
+
+	var n int
+	if err := r.pool.QueryRow(ctx, query, orgID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count organization admins: %w", err)
+	}
+	return n, nil
+}
+
+const organizationOverviewSQL = `
+SELECT w.id, w.name,
+       (SELECT COUNT(*) FROM public."Contact" c WHERE c."workspaceId" = w.id AND c."deletedAt" IS NULL),
+       (SELECT COUNT(*) FROM public."Company" c WHERE c."workspaceId" = w.id AND c."deletedAt" IS NULL),
+       d.open_deals, d.open_value, d.won_deals, d.won_value,
+       (SELECT COUNT(*) FROM public."Task" t
+         WHERE t.workspace_id = w.id AND t.deleted_at IS NULL AND t.status IN ('TODO', 'IN_PROGRESS'))
+FROM public."Workspace" w
+LEFT JOIN LATERAL (
+    SELECT COUNT(*) FILTER (WHERE d.stage = 'OPEN') AS open_deals,
+           COALESCE(SUM(d.value) FILTER (WHERE d.stage = 'OPEN'), 0)::DOUBLE PRECISION AS open_value,
+           COUNT(*) FILTER (WHERE d.stage = 'WON' AND d."closedAt" >= $2) AS won_deals,
+           COALESCE(SUM(d.value) FILTER (WHERE d.stage = 'WON' AND d."closedAt" >= $2), 0)::DOUBLE PRECISION AS won_value
+    FROM public."Deal" d
+    WHERE d."workspaceId" = w.id AND d."deletedAt" IS NULL
+) d ON true
+WHERE w."organizationId" = $1
+ORDER BY w.name, w.id`
+
+// Overview totaliza cada workspace da organização para o relatório entre workspaces.
+func (r *OrganizationRepository) Overview(ctx context.Context, orgID string, since time.Time) ([]domain.OrganizationWorkspaceStats, error) {
+	rows, err := r.pool.Query(ctx, organizationOverviewSQL, orgID, since)
+	if err != nil {
+		return nil, fmt.Errorf("query organization overview: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.OrganizationWorkspaceStats{}
+	for rows.Next() {
+		var s domain.OrganizationWorkspaceStats
+		if err := rows.Scan(&s.WorkspaceID, &s.WorkspaceName, &s.Contacts, &s.Companies,
+			&s.OpenDeals, &s.OpenValue, &s.WonDeals, &s.WonValue, &s.OpenTasks); err != nil {
+			return nil, fmt.Errorf("scan organization overview row: %w", err)
+		}
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate organization overview rows: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,36 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestOrganizationOverview_Integration
+func TestOrganizationOverview_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	orgs := repo.NewOrganizationRepository(pool)
+
+	f.Contact()
+	f.Task(func(task *domain.Task) { task.Status = domain.TaskStatusTodo })
+	// Tarefas em backlog, concluídas ou excluídas não contam como abertas
+	f.Task()
+	f.Task(func(task *domain.Task) { task.Status = domain.TaskStatusDone })
+	deleted := f.Task(func(task *domain.Task) { task.Status = domain.TaskStatusInProgress })
+	require.NoError(t, repo.NewTaskRepository(pool).SoftDelete(ctx, f.WorkspaceID, deleted.ID, f.UserID))
+
+	stats, err := orgs.Overview(ctx, f.OrganizationID, time.Now().AddDate(0, -1, 0))
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, f.WorkspaceID, stats[0].WorkspaceID)
+	assert.Equal(t, 1, stats[0].Contacts)
+	assert.Equal(t, 1, stats[0].OpenTasks)
+}
@@ -0,0 +1,254 @@
+package repo_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Testes de regressão da paginação: para combinações aleatórias (seed fixa) de filtros e
+// tamanho de página, percorrer todas as páginas pelo nextCursor deve devolver exatamente a
+// listagem sem paginação, na mesma ordem, sem perder nem repetir registros. Os dados têm
+// empates de propósito (mesmo createdAt / mesma position), o caso que o cursor só por
+// timestamp quebrava.
+//
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run Pagination
+
+const (
+	paginationRounds = 40
+	unpagedLimit     = 10000
+)
+
+// walkPages segue o nextCursor até o fim e retorna os ids na ordem recebida.
+func walkPages[T any](t *testing.T, limit int, list func(cursor *string) ([]T, string, error), id func(T) string) []string {
+	t.Helper()
+
+	var ids []string
+	var cursor *string
+	for page := 0; ; page++ {
+		require.Less(t, page, unpagedLimit, "pagination does not terminate")
+
+		items, next, err := list(cursor)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(items), limit)
+		for _, item := range items {
+			ids = append(ids, id(item))
+		}
+		if next == "" {
+			return ids
+		}
+		require.Len(t, items, limit, "short page with a next cursor")
+		cursor = &next
+	}
+}
+
+func assertSamePages(t *testing.T, all, paged []string, round int, params any) {
+	t.Helper()
+
+	seen := map[string]bool{}
+	for _, id := range paged {
+		assert.False(t, seen[id], "round %d: %s returned twice (%+v)", round, id, params)
+		seen[id] = true
+	}
+	assert.Equal(t, all, paged, "round %d: paged listing differs from unpaged (%+v)", round, params)
+}
+
+func randomOf[T any](rng *rand.Rand, values ...T) T {
+	return values[rng.IntN(len(values))]
+}
+
+func optional[T any](rng *rand.Rand, values ...T) *T {
+	if rng.IntN(2) == 0 {
+		return nil
+	}
+	v := randomOf(rng, values...)
+	return &v
+}
+
+func TestContactPagination_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	rng := rand.New(rand.NewPCG(4969, 10))
+
+	memberID := f.Member(domain.RoleUser)
+	companies := []string{f.Company().ID, f.Company().ID}
+	stages := []domain.ContactLifecycleStage{domain.ContactStageLead, domain.ContactStageCustomer}
+	tie := time.Now().UTC().Truncate(time.Millisecond)
+	for i := 0; i < 30; i++ {
+		f.Contact(func(c *domain.Contact) {
+			c.ActorID = randomOf(rng, f.UserID, memberID)
+			c.LifecycleStage = randomOf(rng, stages...)
+			c.CompanyID = optional(rng, companies...)
+			if i%2 == 0 {
+				c.CreatedAt = tie
+			}
+		})
+	}
+
+	contacts := repo.NewContactRepository(pool)
+	for round := 0; round < paginationRounds; round++ {
+		params := domain.ListContactsParams{
+			WorkspaceID:    f.WorkspaceID,
+			ActorID:        optional(rng, f.UserID, memberID),
+			CompanyID:      optional(rng, companies...),
+			LifecycleStage: optional(rng, stages...),
+		}
+
+		params.Limit = unpagedLimit
+		unpaged, next, err := contacts.List(f.Context(), params)
+		require.NoError(t, err)
+		require.Empty(t, next)
+		all := make([]string, len(unpaged))
+		for i, c := range unpaged {
+			all[i] = c.ID
+		}
+
+		params.Limit = 1 + rng.IntN(7)
+		paged := walkPages(t, params.Limit, func(cursor *string) ([]domain.Contact, string, error) {
+			params.Cursor = cursor
+			return contacts.List(f.Context(), params)
+		}, func(c domain.Contact) string { return c.ID })
+
+		assertSamePages(t, all, paged, round, params)
+	}
+}
+
+func TestCompanyPagination_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	rng := rand.New(rand.NewPCG(4969, 11))
+
+	memberID := f.Member(domain.RoleUser)
+	stages := []domain.CompanyLifecycleStage{domain.LifecycleLead, domain.LifecycleCustomer}
+	sizes := []domain.CompanySize{domain.SizeSMB, domain.SizeEnterprise}
+	for i := 0; i < 20; i++ {
+		f.Company(func(c *domain.Company) {
+			c.OwnerID = randomOf(rng, f.UserID, memberID)
+			c.LifecycleStage = randomOf(rng, stages...)
+			c.Size = randomOf(rng, sizes...)
+		})
+	}
+
+	companies := repo.NewCompanyRepository(pool)
+
+	// Sem filtros a listagem traz todas as empresas do workspace
+	everything, _, err := companies.List(f.Context(), domain.ListCompaniesParams{WorkspaceID: f.WorkspaceID, Limit: unpagedLimit})
+	require.NoError(t, err)
+	assert.Len(t, everything, 20)
+
+	for round := 0; round < paginationRounds; round++ {
+		params := domain.ListCompaniesParams{
+			WorkspaceID:    f.WorkspaceID,
+			OwnerID:        optional(rng, f.UserID, memberID),
+			LifecycleStage: optional(rng, stages...),
+			Size:           optional(rng, sizes...),
+		}
+
+		params.Limit = unpagedLimit
+		unpaged, _, err := companies.List(f.Context(), params)
+		require.NoError(t, err)
+		all := make([]string, len(unpaged))
+		for i, c := range unpaged {
+			all[i] = c.ID
+		}
+
+		params.Limit = 1 + rng.IntN(5)
+		paged := walkPages(t, params.Limit, func(cursor *string) ([]domain.Company, string, error) {
+			params.Cursor = cursor
+			return companies.List(f.Context(), params)
+		}, func(c domain.Company) string { return c.ID })
+
+		assertSamePages(t, all, paged, round, params)
+	}
+}
+
+func TestPipelinePagination_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	rng := rand.New(rand.NewPCG(4969, 12))
+
+	memberID := f.Member(domain.RoleManager)
+	for i := 0; i < 12; i++ {
+		f.Pipeline(func(p *domain.Pipeline) {
+			p.OwnerID = randomOf(rng, f.UserID, memberID)
+			p.IsActive = rng.IntN(3) > 0
+		})
+	}
+
+	pipelines := repo.NewPipelineRepository(pool)
+	for round := 0; round < paginationRounds; round++ {
+		params := domain.ListPipelinesParams{
+			WorkspaceID: f.WorkspaceID,
+			OwnerID:     optional(rng, f.UserID, memberID),
+			IsActive:    optional(rng, true, false),
+		}
+
+		params.Limit = unpagedLimit
+		unpaged, _, err := pipelines.List(f.Context(), params)
+		require.NoError(t, err)
+		all := make([]string, len(unpaged))
+		for i, p := range unpaged {
+			all[i] = p.ID
+		}
+
+		params.Limit = 1 + rng.IntN(4)
+		paged := walkPages(t, params.Limit, func(cursor *string) ([]domain.Pipeline, string, error) {
+			params.Cursor = cursor
+			return pipelines.List(f.Context(), params)
+		}, func(p domain.Pipeline) string { return p.ID })
+
+		assertSamePages(t, all, paged, round, params)
+	}
+}
+
+func TestTaskPagination_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	rng := rand.New(rand.NewPCG(4969, 13))
+
+	memberID := f.Member(domain.RoleUser)
+	statuses := []domain.TaskStatus{domain.TaskStatusTodo, domain.TaskStatusDone}
+	priorities := []domain.Priority{domain.PriorityLow, domain.PriorityHigh}
+	for i := 0; i < 30; i++ {
+		f.Task(func(task *domain.Task) {
+			task.Status = randomOf(rng, statuses...)
+			task.Priority = randomOf(rng, priorities...)
+			task.AssignedTo = optional(rng, f.UserID, memberID)
+			task.Position = randomOf(rng, 1000.0, 2000.0, 2500.5) // empates de posição
+		})
+	}
+
+	tasks := repo.NewTaskRepository(pool)
+	for round := 0; round < paginationRounds; round++ {
+		params := domain.ListTasksParams{
+			WorkspaceID: f.WorkspaceID,
+			Status:      optional(rng, statuses...),
+			Priority:    optional(rng, priorities...),
+			AssignedTo:  optional(rng, f.UserID, memberID),
+			Viewer:      optional(rng, memberID),
+		}
+
+		params.Limit = unpagedLimit
+		unpaged, _, err := tasks.List(f.Context(), params)
+		require.NoError(t, err)
+		all := make([]string, len(unpaged))
+		for i, task := range unpaged {
+			all[i] = task.ID
+		}
+
+		params.Limit = 1 + rng.IntN(7)
+		paged := walkPages(t, params.Limit, func(cursor *string) ([]domain.Task, string, error) {
+			params.Cursor = cursor
+			return tasks.List(f.Context(), params)
+		}, func(task domain.Task) string { return task.ID })
+
+		assertSamePages(t, all, paged, round, params)
+	}
+}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PartitionedTable tabela particionada por mês (migração 000047): partições
+// <Name>_AAAA_MM com limites em UTC e <Name>_default para o restante.
+type PartitionedTable struct {
+	Name   string
+	Column string // coluna de partição
+}
+
+// PartitionedTables tabelas mantidas pelo job de manutenção.
+var PartitionedTables = []PartitionedTable{
+	{Name: "Activity", Column: "createdAt"},
+	{Name: "audit_log", Column: "created_at"},
+}
+
+// PartitionName nome da partição do mês de t (UTC).
+func (t PartitionedTable) PartitionName(month time.Time) string {
+	return fmt.Sprintf("%s_%s", t.Name, month.UTC().Format("2006_01"))
+}
+
+func (t PartitionedTable) defaultPartition() string {
+	return t.Name + "_default"
+}
+
+// PartitionRepository cria as partições mensais à frente do tempo.
+type PartitionRepository struct {
+	pool database.DB
+}
+
+func NewPartitionRepository(pool database.DB) *PartitionRepository {
+	return &PartitionRepository{pool: pool}
+}
+
+// EnsureMonthly garante a partição do mês de month. Linhas desse mês que caíram na partição
+// default (partição ainda inexistente quando foram gravadas) são movidas para a partição
+// nova antes de anexá-la, na mesma transação. created é false se a partição já existia.
+func (r *PartitionRepository) EnsureMonthly(ctx context.Context, t PartitionedTable, month time.Time) (created bool, moved int64, err error) {
+	from := time.Date(month.UTC().Year(), month.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	name := t.PartitionName(from)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, 0, fmt.Errorf("begin partition %s: %w", name, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Limites das partições em UTC (audit_log.created_at é TIMESTAMPTZ)
+	if _, err := tx.Exec(ctx, `SET LOCAL timezone = 'UTC'`); err != nil {
+		return false, 0, fmt.Errorf("set partition timezone: %w", err)
+	}
+	// Serializa jobs concorrentes na mesma tabela sem bloquear as escritas
+	if _, err := tx.Exec(ctx, `LOCK TABLE ONLY public.`+quoteIdent(t.Name)+` IN SHARE UPDATE EXCLUSIVE MODE`); err != nil {
+		return false, 0, fmt.Errorf("lock %s: %w", t.Name, err)
+	}
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, "public."+quoteIdent(name)).Scan(&exists); err != nil {
+		return false, 0, fmt.Errorf("check partition %s: %w", name, err)
+	}
+	if exists {
+		return false, 0, nil
+	}
+
+	// Tabela avulsa com a mesma estrutura; vira partição no ATTACH (índices são anexados)
+	if _, err := tx.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE public.%s (LIKE public.%s INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING STORAGE)`,
+		quoteIdent(name), quoteIdent(t.Name))); err != nil {
+		return false, 0, fmt.Errorf("create partition %s: %w", name, err)
+	}
+
+	tag, err := tx.Exec(ctx, fmt.Sprintf(
+		`WITH moved AS (DELETE FROM public.%[1]s WHERE %[2]s >= $1 AND %[2]s < $2 RETURNING *)
+		 INSERT INTO public.%[3]s SELECT * FROM moved`,
+		quoteIdent(t.defaultPartition()), quoteIdent(t.Column), quoteIdent(name)),
+		from, to)
+	if err != nil {
+		return false, 0, fmt.Errorf("move default rows to %s: %w", name, err)
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(
+		`ALTER TABLE public.%s ATTACH PARTITION public.%s FOR VALUES FROM ('%s') TO ('%s')`,
+		quoteIdent(t.Name), quoteIdent(name), from.Format("2006-01-02"), to.Format("2006-01-02"))); err != nil {
+		return false, 0, fmt.Errorf("attach partition %s: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, 0, fmt.Errorf("commit partition %s: %w", name, err)
+	}
+	return true, tag.RowsAffected(), nil
+}
+
+func quoteIdent(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
@@ -0,0 +1,78 @@
+package repo_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionedTable_PartitionName(t *testing.T) {
+	activity := repo.PartitionedTable{Name: "Activity", Column: "createdAt"}
+	assert.Equal(t, "Activity_2026_03", activity.PartitionName(time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)))
+
+	// O mês é o de UTC: 21h de 31/03 em São Paulo já é abril
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	assert.Equal(t, "Activity_2026_04", activity.PartitionName(time.Date(2026, 3, 31, 21, 0, 0, 0, saoPaulo)))
+}
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestPartitionRepository_EnsureMonthly_Integration
+func TestPartitionRepository_EnsureMonthly_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	partitions := repo.NewPartitionRepository(pool)
+	activities := repo.NewActivityRepository(pool)
+
+	// Mês distante, sem partição: as linhas dele caem na partição default
+	month := time.Date(2090+rand.IntN(100), time.Month(1+rand.IntN(12)), 1, 0, 0, 0, 0, time.UTC)
+	table := repo.PartitionedTables[0]
+	require.Equal(t, "Activity", table.Name)
+	name := table.PartitionName(month)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DROP TABLE IF EXISTS public."`+name+`"`)
+	})
+
+	activityID, err := id.New(id.Activity)
+	require.NoError(t, err)
+	_, err = activities.CreateActivity(ctx, &domain.Activity{
+		ID:          activityID,
+		WorkspaceID: f.WorkspaceID,
+		Type:        domain.ActivityTypeEmail,
+		UserID:      f.UserID,
+		Metadata:    []byte(`{}`),
+	})
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `UPDATE public."Activity" SET "createdAt" = $2 WHERE id = $1`, activityID, month.AddDate(0, 0, 14))
+	require.NoError(t, err)
+
+	partitionOf := func(t *testing.T) string {
+		t.Helper()
+		var partition string
+		require.NoError(t, pool.QueryRow(ctx,
+			`SELECT tableoid::regclass::TEXT FROM public."Activity" WHERE id = $1`, activityID).Scan(&partition))
+		return partition
+	}
+	assert.Equal(t, `"Activity_default"`, partitionOf(t))
+
+	created, moved, err := partitions.EnsureMonthly(ctx, table, month.AddDate(0, 0, 20))
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int64(1), moved)
+	assert.Equal(t, `"`+name+`"`, partitionOf(t))
+
+	// Idempotente
+	created, moved, err = partitions.EnsureMonthly(ctx, table, month)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Zero(t, moved)
+}
@@ -7,26 +7,32 @@ import (
 	"fmt"
 	"time"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
-	ErrPipelineNotFound      = errors.New("pipeline not found in workspace")
-	ErrPipelineNameConflict  = errors.New("pipeline with this name already exists in workspace")
-	ErrStageNotFound         = errors.New("stage not found in pipeline")
-	ErrStageNameConflict     = errors.New("stage with this name already exists in pipeline")
-	ErrDefaultPipelineExists = errors.New("another pipeline is already set as default")
+	ErrPipelineNotFound      = apperr.NotFound("pipeline not found in workspace", "pipeline not found")
+	ErrPipelineNameConflict  = apperr.Conflict("pipeline with this name already exists in workspace", "pipeline with this name already exists")
+	ErrStageNotFound         = apperr.NotFound("stage not found in pipeline", "stage not found")
+	ErrStageNameConflict     = apperr.Conflict("stage with this name already exists in pipeline", "")
+	ErrDefaultPipelineExists = apperr.Conflict("another pipeline is already set as default", "")
+	ErrStageHasDeals         = apperr.Conflict("stage has active deals", "stage has deals; move them to another stage before deleting it")
 )
 
+// pipelineColumns é a lista de colunas lida por scanPipeline.
+const pipelineColumns = `id, "workspaceId", name, description, "pipelineType", "isActive", "isDefault",
+	COALESCE("ownerId", ''), "createdAt", "updatedAt", "deletedAt"`
+
 type PipelineRepository struct {
-	pool *pgxpool.Pool
+	pool database.DB
 }
 
-func NewPipelineRepository(pool *pgxpool.Pool) *PipelineRepository {
+func NewPipelineRepository(pool database.DB) *PipelineRepository {
 	return &PipelineRepository{pool: pool}
 }
 
@@ -38,44 +44,11 @@ func (r *PipelineRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 // List retrieves pipelines for a workspace with optional filters.
 // IMPORTANT: Uses camelCase column names with double quotes.
 func (r *PipelineRepository) List(ctx context.Context, params domain.ListPipelinesParams) ([]domain.Pipeline, string, error) {
-	query := `
-		SELECT id, "workspaceId", name, description, "isDefault",
-		       "createdAt", "updatedAt", "deletedAt"
-		FROM public."Pipeline"
-		WHERE "workspaceId" = $1 AND "deletedAt" IS NULL
-	`
-	args := []interface{}{params.WorkspaceID}
-	argIdx := 2
-
-	// Filtros opcionais
-	if params.IsDefault != nil {
-		query += fmt.Sprintf(` AND "isDefault" = $%d`, argIdx)
-		args = append(args, *params.IsDefault)
-		argIdx++
-	}
-
-	// Busca textual
-	if params.Query != nil && *params.Query != "" {
-		query += fmt.Sprintf(` AND to_tsvector('simple', name || ' ' || COALESCE(description, '')) @@ plainto_tsquery('simple', $%d)`, argIdx)
-		args = append(args, *params.Query)
-		argIdx++
-	}
-
-	// Cursor-based pagination
-	if params.Cursor != nil && *params.Cursor != "" {
-		cursorTime, err := time.Parse(time.RFC3339, *params.Cursor)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
-		}
-		query += fmt.Sprintf(` AND "createdAt" < $%d`, argIdx)
-		args = append(args, cursorTime)
-		argIdx++
+	query, args, err := buildPipelineListQuery(params)
+	if err != nil {
+		return nil, "", err
 	}
 
-	query += ` ORDER BY "createdAt" DESC`
-	query += fmt.Sprintf(` LIMIT $%d`, argIdx)
-	args = append(args, params.Limit+1)
-
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("query pipelines: %w", err)
@@ -84,19 +57,11 @@ func (r *PipelineRepository) List(ctx context.Context, params domain.ListPipelin
 
 	pipelines := make([]domain.Pipeline, 0, params.Limit)
 	for rows.Next() {
-		var p domain.Pipeline
-		var deletedAt sql.NullTime
-		err := rows.Scan(
-			&p.ID, &p.WorkspaceID, &p.Name, &p.Description, &p.IsDefault,
-			&p.CreatedAt, &p.UpdatedAt, &deletedAt,
-		)
+		p, err := scanPipeline(rows)
 		if err != nil {
 			return nil, "", fmt.Errorf("scan pipeline: %w", err)
 		}
-		if deletedAt.Valid {
-			p.DeletedAt = &deletedAt.Time
-		}
-		pipelines = append(pipelines, p)
+		pipelines = append(pipelines, *p)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -106,7 +71,7 @@ func (r *PipelineRepository) List(ctx context.Context, params domain.ListPipelin
 	// Load stages if requested
 	if params.IncludeStages && len(pipelines) > 0 {
 		for i := range pipelines {
-			stages, err := r.ListStagesByPipeline(ctx, pipelines[i].WorkspaceID, &pipelines[i].ID)
+			stages, err := r.ListStagesByPipeline(ctx, pipelines[i].WorkspaceID, &pipelines[i].ID, false)
 			if err != nil {
 				return nil, "", fmt.Errorf("load stages for pipeline %s: %w", pipelines[i].ID, err)
 			}
@@ -116,29 +81,78 @@ func (r *PipelineRepository) List(ctx context.Context, params domain.ListPipelin
 
 	var nextCursor string
 	if len(pipelines) > params.Limit {
-		nextCursor = pipelines[params.Limit-1].CreatedAt.Format(time.RFC3339)
+		last := pipelines[params.Limit-1]
+		nextCursor = encodeTimeCursor(last.CreatedAt, last.ID)
 		pipelines = pipelines[:params.Limit]
 	}
 
 	return pipelines, nextCursor, nil
 }
 
+// buildPipelineListQuery monta o SELECT da listagem; os valores do request só entram como argumentos.
+func buildPipelineListQuery(params domain.ListPipelinesParams) (string, []interface{}, error) {
+	query := `SELECT ` + pipelineColumns + `
+		FROM public."Pipeline"
+		WHERE "workspaceId" = $1 AND "deletedAt" IS NULL
+	`
+	args := []interface{}{params.WorkspaceID}
+	argIdx := 2
+
+	// Filtros opcionais
+	if params.IsDefault != nil {
+		query += fmt.Sprintf(` AND "isDefault" = $%d`, argIdx)
+		args = append(args, *params.IsDefault)
+		argIdx++
+	}
+	if params.PipelineType != nil {
+		query += fmt.Sprintf(` AND "pipelineType" = $%d`, argIdx)
+		args = append(args, *params.PipelineType)
+		argIdx++
+	}
+	if params.IsActive != nil {
+		query += fmt.Sprintf(` AND "isActive" = $%d`, argIdx)
+		args = append(args, *params.IsActive)
+		argIdx++
+	}
+	if params.OwnerID != nil {
+		query += fmt.Sprintf(` AND "ownerId" = $%d`, argIdx)
+		args = append(args, *params.OwnerID)
+		argIdx++
+	}
+
+	// Busca textual
+	if params.Query != nil && *params.Query != "" {
+		query += fmt.Sprintf(` AND to_tsvector('simple', name || ' ' || COALESCE(description, '')) @@ plainto_tsquery('simple', $%d)`, argIdx)
+		args = append(args, *params.Query)
+		argIdx++
+	}
+
+	// Cursor-based pagination (keyset em createdAt, id)
+	if params.Cursor != nil && *params.Cursor != "" {
+		cursorTime, cursorID, err := decodeTimeCursor(*params.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		query += fmt.Sprintf(` AND ("createdAt", id) < ($%d, $%d::TEXT)`, argIdx, argIdx+1)
+		args = append(args, cursorTime, cursorID)
+		argIdx += 2
+	}
+
+	query += ` ORDER BY "createdAt" DESC, id DESC`
+	query += fmt.Sprintf(` LIMIT $%d`, argIdx)
+	args = append(args, params.Limit+1)
+
+	return query, args, nil
+}
+
 // Get retrieves a single pipeline by ID, scoped to workspace.
 func (r *PipelineRepository) Get(ctx context.Context, workspaceID, pipelineID string) (*domain.Pipeline, error) {
-	query := `
-		SELECT id, "workspaceId", name, description, "isDefault",
-		       "createdAt", "updatedAt", "deletedAt"
+	query := `SELECT ` + pipelineColumns + `
 		FROM public."Pipeline"
 		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
 	`
 
-	var p domain.Pipeline
-	var deletedAt sql.NullTime
-	err := r.pool.QueryRow(ctx, query, pipelineID, workspaceID).Scan(
-		&p.ID, &p.WorkspaceID, &p.Name, &p.Description, &p.IsDefault,
-		&p.CreatedAt, &p.UpdatedAt, &deletedAt,
-	)
-
+	p, err := scanPipeline(r.pool.QueryRow(ctx, query, pipelineID, workspaceID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrPipelineNotFound
@@ -146,21 +160,35 @@ func (r *PipelineRepository) Get(ctx context.Context, workspaceID, pipelineID st
 		return nil, fmt.Errorf("query pipeline: %w", err)
 	}
 
+	return p, nil
+}
+
+// scanPipeline lê uma linha com as colunas de pipelineColumns.
+func scanPipeline(row pgx.Row) (*domain.Pipeline, error) {
+	var p domain.Pipeline
+	var deletedAt sql.NullTime
+	err := row.Scan(
+		&p.ID, &p.WorkspaceID, &p.Name, &p.Description, &p.PipelineType, &p.IsActive, &p.IsDefault,
+		&p.OwnerID, &p.CreatedAt, &p.UpdatedAt, &deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
 	if deletedAt.Valid {
 		p.DeletedAt = &deletedAt.Time
 	}
-
 	return &p, nil
 }
 
 // GetWithStages retrieves pipeline with all its stages ordered by orderIndex.
-func (r *PipelineRepository) GetWithStages(ctx context.Context, workspaceID, pipelineID string) (*domain.Pipeline, error) {
+// includeMetrics adds per-stage open deal totals (see ListStagesByPipeline).
+func (r *PipelineRepository) GetWithStages(ctx context.Context, workspaceID, pipelineID string, includeMetrics bool) (*domain.Pipeline, error) {
 	pipeline, err := r.Get(ctx, workspaceID, pipelineID)
 	if err != nil {
 		return nil, err
 	}
 
-	stages, err := r.ListStagesByPipeline(ctx, workspaceID, &pipelineID)
+	stages, err := r.ListStagesByPipeline(ctx, workspaceID, &pipelineID, includeMetrics)
 	if err != nil {
 		return nil, fmt.Errorf("load stages: %w", err)
 	}
@@ -173,13 +201,14 @@ func (r *PipelineRepository) GetWithStages(ctx context.Context, workspaceID, pip
 func (r *PipelineRepository) Create(ctx context.Context, pipeline *domain.Pipeline) error {
 	query := `
 		INSERT INTO public."Pipeline" (
-			id, "workspaceId", name, description, "isDefault"
+			id, "workspaceId", name, description, "isDefault", "pipelineType", "isActive", "ownerId"
 		)
-		VALUES ($1, $2, $3, $4, $5)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
 	`
 
 	_, err := r.pool.Exec(ctx, query,
 		pipeline.ID, pipeline.WorkspaceID, pipeline.Name, pipeline.Description, pipeline.IsDefault,
+		pipeline.PipelineType, pipeline.IsActive, pipeline.OwnerID,
 	)
 
 	if err != nil {
@@ -250,6 +279,24 @@ func (r *PipelineRepository) Update(ctx context.Context, workspaceID, pipelineID
 		argIdx++
 	}
 
+	if req.PipelineType != nil {
+		query += fmt.Sprintf(`, "pipelineType" = $%d`, argIdx)
+		args = append(args, *req.PipelineType)
+		argIdx++
+	}
+
+	if req.IsActive != nil {
+		query += fmt.Sprintf(`, "isActive" = $%d`, argIdx)
+		args = append(args, *req.IsActive)
+		argIdx++
+	}
+
+	if req.OwnerID != nil {
+		query += fmt.Sprintf(`, "ownerId" = $%d`, argIdx)
+		args = append(args, *req.OwnerID)
+		argIdx++
+	}
+
 	query += fmt.Sprintf(` WHERE id = $%d AND "workspaceId" = $%d AND "deletedAt" IS NULL`, argIdx, argIdx+1)
 	args = append(args, pipelineID, workspaceID)
 
@@ -271,44 +318,151 @@ func (r *PipelineRepository) Update(ctx context.Context, workspaceID, pipelineID
 	return nil
 }
 
-// SoftDelete marca um pipeline como deletado (CASCADE deleta stages via FK).
-func (r *PipelineRepository) SoftDelete(ctx context.Context, workspaceID, pipelineID string) error {
-	query := `
+// SoftDelete marca um pipeline como deletado e, na mesma transação, os stages e negócios ativos
+// dele, com o mesmo deletedAt: é por ele que Restore sabe o que foi excluído junto.
+func (r *PipelineRepository) SoftDelete(ctx context.Context, workspaceID, pipelineID, deletedByID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, `
 		UPDATE public."Pipeline"
-		SET "deletedAt" = NOW(), "updatedAt" = NOW()
+		SET "deletedAt" = NOW(), "deletedById" = $3, "updatedAt" = NOW()
 		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
-	`
-
-	result, err := r.pool.Exec(ctx, query, pipelineID, workspaceID)
+		RETURNING "deletedAt"`,
+		pipelineID, workspaceID, deletedByID,
+	).Scan(&deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPipelineNotFound
+	}
 	if err != nil {
 		return fmt.Errorf("soft delete pipeline: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
+	if _, err := tx.Exec(ctx, `
+		UPDATE public."PipelineStage"
+		SET "deletedAt" = $2, "updatedAt" = NOW()
+		WHERE "pipelineId" = $1 AND "deletedAt" IS NULL`,
+		pipelineID, deletedAt,
+	); err != nil {
+		return fmt.Errorf("soft delete pipeline stages: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE public."Deal"
+		SET "deletedAt" = $3, "deletedById" = $4, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND "pipelineId" = $2 AND "deletedAt" IS NULL`,
+		workspaceID, pipelineID, deletedAt, deletedByID,
+	); err != nil {
+		return fmt.Errorf("soft delete pipeline deals: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Restore desfaz o soft delete de um pipeline (lixeira do workspace), com os stages e negócios
+// excluídos junto com ele. Os excluídos antes, individualmente, continuam excluídos.
+func (r *PipelineRepository) Restore(ctx context.Context, workspaceID, pipelineID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT "deletedAt"
+		FROM public."Pipeline"
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NOT NULL
+		FOR UPDATE`,
+		pipelineID, workspaceID,
+	).Scan(&deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
 		return ErrPipelineNotFound
 	}
+	if err != nil {
+		return fmt.Errorf("lock pipeline: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE public."Pipeline"
+		SET "deletedAt" = NULL, "deletedById" = NULL, "updatedAt" = NOW()
+		WHERE id = $1`,
+		pipelineID,
+	); err != nil {
+		return fmt.Errorf("restore pipeline: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE public."PipelineStage"
+		SET "deletedAt" = NULL, "updatedAt" = NOW()
+		WHERE "pipelineId" = $1 AND "deletedAt" = $2`,
+		pipelineID, deletedAt,
+	); err != nil {
+		return fmt.Errorf("restore pipeline stages: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE public."Deal"
+		SET "deletedAt" = NULL, "deletedById" = NULL, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND "pipelineId" = $2 AND "deletedAt" = $3`,
+		workspaceID, pipelineID, deletedAt,
+	); err != nil {
+		// Outro deal ativo passou a usar o mesmo externalId enquanto este estava na lixeira
+		if isExternalIDViolation(err) {
+			return ErrExternalIDConflict
+		}
+		return fmt.Errorf("restore pipeline deals: %w", err)
+	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
 // ===== PIPELINE STAGE METHODS =====
 
 // ListStagesByPipeline retorna todos os stages de um pipeline ordenados por orderIndex.
-func (r *PipelineRepository) ListStagesByPipeline(ctx context.Context, workspaceID string, pipelineID *string) ([]domain.PipelineStage, error) {
+// includeMetrics preenche Metrics com os negócios em aberto de cada stage na mesma query
+// (LEFT JOIN + GROUP BY), para o cabeçalho do quadro não precisar de outra chamada.
+func (r *PipelineRepository) ListStagesByPipeline(ctx context.Context, workspaceID string, pipelineID *string, includeMetrics bool) ([]domain.PipelineStage, error) {
 	query := `
-		SELECT id, "workspaceId", "pipelineId", name, description, "group", "type", color,
-		       "isLocked", "orderIndex", "createdAt", "updatedAt", "deletedAt"
-		FROM public."PipelineStage"
-		WHERE "workspaceId" = $1
-	`
+		SELECT s.id, s."workspaceId", s."pipelineId", s.name, s.description, s."group", s."type", s.color,
+		       s."isLocked", s."orderIndex", s."rottingDays", s."firstResponseSlaMinutes", s."resolutionSlaMinutes",
+		       s.probability, s."createdAt", s."updatedAt", s."deletedAt"`
+	if includeMetrics {
+		query += `,
+		       COUNT(d.id), COALESCE(SUM(d.value), 0)::DOUBLE PRECISION
+		FROM public."PipelineStage" s
+		LEFT JOIN public."Deal" d ON d."stageId" = s.id
+		                         AND d."workspaceId" = s."workspaceId"
+		                         AND d.stage = 'OPEN'
+		                         AND d."deletedAt" IS NULL`
+	} else {
+		query += `
+		FROM public."PipelineStage" s`
+	}
+	query += `
+		WHERE s."workspaceId" = $1`
 	args := []interface{}{workspaceID}
 
 	if pipelineID != nil {
-		query += ` AND "pipelineId" = $2`
+		query += ` AND s."pipelineId" = $2`
 		args = append(args, *pipelineID)
 	}
 
-	query += ` AND "deletedAt" IS NULL ORDER BY "orderIndex" ASC`
+	query += ` AND s."deletedAt" IS NULL`
+	if includeMetrics {
+		query += ` GROUP BY s.id`
+	}
+	query += ` ORDER BY s."orderIndex" ASC`
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -320,12 +474,17 @@ func (r *PipelineRepository) ListStagesByPipeline(ctx context.Context, workspace
 	for rows.Next() {
 		var s domain.PipelineStage
 		var deletedAt sql.NullTime
-		err := rows.Scan(
+		dest := []interface{}{
 			&s.ID, &s.WorkspaceID, &s.PipelineID, &s.Name, &s.Description,
-			&s.Group, &s.Type, &s.Color, &s.IsLocked, &s.OrderIndex,
-			&s.CreatedAt, &s.UpdatedAt, &deletedAt,
-		)
-		if err != nil {
+			&s.Group, &s.Type, &s.Color, &s.IsLocked, &s.OrderIndex, &s.RottingDays,
+			&s.FirstResponseSLAMinutes, &s.ResolutionSLAMinutes,
+			&s.Probability, &s.CreatedAt, &s.UpdatedAt, &deletedAt,
+		}
+		if includeMetrics {
+			s.Metrics = &domain.StageMetrics{}
+			dest = append(dest, &s.Metrics.OpenDeals, &s.Metrics.OpenValue)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("scan stage: %w", err)
 		}
 		if deletedAt.Valid {
@@ -341,7 +500,8 @@ func (r *PipelineRepository) ListStagesByPipeline(ctx context.Context, workspace
 func (r *PipelineRepository) GetStage(ctx context.Context, stageID string) (*domain.PipelineStage, error) {
 	query := `
 		SELECT id, "workspaceId", "pipelineId", name, description, "group", "type", color,
-		       "isLocked", "orderIndex", "createdAt", "updatedAt", "deletedAt"
+		       "isLocked", "orderIndex", "rottingDays", "firstResponseSlaMinutes", "resolutionSlaMinutes",
+		       probability, "createdAt", "updatedAt", "deletedAt"
 		FROM public."PipelineStage"
 		WHERE id = $1 AND "deletedAt" IS NULL
 	`
@@ -350,8 +510,9 @@ func (r *PipelineRepository) GetStage(ctx context.Context, stageID string) (*dom
 	var deletedAt sql.NullTime
 	err := r.pool.QueryRow(ctx, query, stageID).Scan(
 		&s.ID, &s.WorkspaceID, &s.PipelineID, &s.Name, &s.Description,
-		&s.Group, &s.Type, &s.Color, &s.IsLocked, &s.OrderIndex,
-		&s.CreatedAt, &s.UpdatedAt, &deletedAt,
+		&s.Group, &s.Type, &s.Color, &s.IsLocked, &s.OrderIndex, &s.RottingDays,
+		&s.FirstResponseSLAMinutes, &s.ResolutionSLAMinutes,
+		&s.Probability, &s.CreatedAt, &s.UpdatedAt, &deletedAt,
 	)
 
 	if err != nil {
@@ -372,14 +533,16 @@ func (r *PipelineRepository) GetStage(ctx context.Context, stageID string) (*dom
 func (r *PipelineRepository) CreateStage(ctx context.Context, stage *domain.PipelineStage) error {
 	query := `
 		INSERT INTO public."PipelineStage" (
-			id, "workspaceId", "pipelineId", name, description, "group", "type", color, "isLocked", "orderIndex"
+			id, "workspaceId", "pipelineId", name, description, "group", "type", color, "isLocked", "orderIndex", "rottingDays",
+			probability
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
 		stage.ID, stage.WorkspaceID, stage.PipelineID, stage.Name, stage.Description,
-		stage.Group, stage.Type, stage.Color, stage.IsLocked, stage.OrderIndex,
+		stage.Group, stage.Type, stage.Color, stage.IsLocked, stage.OrderIndex, stage.RottingDays,
+		stage.Probability,
 	)
 
 	if err != nil {
@@ -446,6 +609,32 @@ func (r *PipelineRepository) UpdateStage(ctx context.Context, stageID string, re
 		argIdx++
 	}
 
+	// 0 desativa a detecção de deals parados no estágio
+	if req.RottingDays != nil {
+		query += fmt.Sprintf(`, "rottingDays" = NULLIF($%d, 0)`, argIdx)
+		args = append(args, *req.RottingDays)
+		argIdx++
+	}
+
+	if req.Probability != nil {
+		query += fmt.Sprintf(`, probability = $%d`, argIdx)
+		args = append(args, *req.Probability)
+		argIdx++
+	}
+
+	// SLA: 0 desativa o timer
+	if req.FirstResponseSLAMinutes != nil {
+		query += fmt.Sprintf(`, "firstResponseSlaMinutes" = NULLIF($%d, 0)`, argIdx)
+		args = append(args, *req.FirstResponseSLAMinutes)
+		argIdx++
+	}
+
+	if req.ResolutionSLAMinutes != nil {
+		query += fmt.Sprintf(`, "resolutionSlaMinutes" = NULLIF($%d, 0)`, argIdx)
+		args = append(args, *req.ResolutionSLAMinutes)
+		argIdx++
+	}
+
 	query += fmt.Sprintf(` WHERE id = $%d AND "deletedAt" IS NULL`, argIdx)
 	args = append(args, stageID)
 
@@ -467,12 +656,38 @@ func (r *PipelineRepository) UpdateStage(ctx context.Context, stageID string, re
 	return nil
 }
 
-// SoftDeleteStage marca um stage como deletado.
+// RecalculateStageForecast recalcula a categoria de forecast dos deals da etapa após mudar a
+// probabilidade dela. Deals com categoria manual não mudam; updatedAt é mantido (campo derivado).
+func (r *PipelineRepository) RecalculateStageForecast(ctx context.Context, stageID string) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE public."Deal" d
+		SET "forecastCategory" = deal_forecast_category(d.stage, COALESCE(s.probability, d.probability))
+		FROM public."PipelineStage" s
+		WHERE s.id = $1
+		  AND d."stageId" = s.id
+		  AND d."workspaceId" = s."workspaceId"
+		  AND d."deletedAt" IS NULL
+		  AND NOT d."forecastCategoryOverridden"
+		  AND d."forecastCategory" IS DISTINCT FROM deal_forecast_category(d.stage, COALESCE(s.probability, d.probability))`,
+		stageID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("recalculate stage forecast: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// SoftDeleteStage marca um stage como deletado. Falha com ErrStageHasDeals enquanto houver
+// negócios ativos no stage: eles sumiriam do quadro.
 func (r *PipelineRepository) SoftDeleteStage(ctx context.Context, stageID string) error {
 	query := `
-		UPDATE public."PipelineStage"
+		UPDATE public."PipelineStage" s
 		SET "deletedAt" = NOW(), "updatedAt" = NOW()
-		WHERE id = $1 AND "deletedAt" IS NULL
+		WHERE s.id = $1 AND s."deletedAt" IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM public."Deal" d
+			WHERE d."stageId" = s.id AND d."workspaceId" = s."workspaceId" AND d."deletedAt" IS NULL
+		  )
 	`
 
 	result, err := r.pool.Exec(ctx, query, stageID)
@@ -481,6 +696,17 @@ func (r *PipelineRepository) SoftDeleteStage(ctx context.Context, stageID string
 	}
 
 	if result.RowsAffected() == 0 {
+		var exists bool
+		err := r.pool.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM public."PipelineStage" WHERE id = $1 AND "deletedAt" IS NULL)`,
+			stageID,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("check stage: %w", err)
+		}
+		if exists {
+			return ErrStageHasDeals
+		}
 		return ErrStageNotFound
 	}
 
@@ -0,0 +1,84 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestPipelineRepository_TypeActiveOwner_Integration
+func TestPipelineRepository_TypeActiveOwner_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	pipelines := repo.NewPipelineRepository(pool)
+
+	manager := f.Member(domain.RoleManager)
+	sales := f.Pipeline()
+	support := f.Pipeline(func(p *domain.Pipeline) {
+		p.PipelineType = domain.PipelineTypeTicket
+		p.OwnerID = manager
+	})
+	archived := f.Pipeline(func(p *domain.Pipeline) {
+		p.IsActive = false
+		p.OwnerID = ""
+	})
+
+	t.Run("columns round-trip", func(t *testing.T) {
+		got, err := pipelines.Get(ctx, f.WorkspaceID, support.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PipelineTypeTicket, got.PipelineType)
+		assert.True(t, got.IsActive)
+		assert.Equal(t, manager, got.OwnerID)
+
+		got, err = pipelines.Get(ctx, f.WorkspaceID, archived.ID)
+		require.NoError(t, err)
+		assert.False(t, got.IsActive)
+		assert.Empty(t, got.OwnerID, "no owner")
+	})
+
+	list := func(params domain.ListPipelinesParams) []string {
+		t.Helper()
+		params.WorkspaceID = f.WorkspaceID
+		params.Limit = 50
+		listed, _, err := pipelines.List(ctx, params)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(listed))
+		for _, p := range listed {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	t.Run("list filters", func(t *testing.T) {
+		ticket, deal := domain.PipelineTypeTicket, domain.PipelineTypeDeal
+		active, inactive := true, false
+
+		assert.Equal(t, []string{support.ID}, list(domain.ListPipelinesParams{PipelineType: &ticket}))
+		assert.ElementsMatch(t, []string{sales.ID, archived.ID}, list(domain.ListPipelinesParams{PipelineType: &deal}))
+		assert.ElementsMatch(t, []string{sales.ID, support.ID}, list(domain.ListPipelinesParams{IsActive: &active}))
+		assert.Equal(t, []string{archived.ID}, list(domain.ListPipelinesParams{IsActive: &inactive}))
+		assert.Equal(t, []string{support.ID}, list(domain.ListPipelinesParams{OwnerID: &manager}))
+	})
+
+	t.Run("update changes type, active flag and owner", func(t *testing.T) {
+		contact, inactive := domain.PipelineTypeContact, false
+		require.NoError(t, pipelines.Update(ctx, f.WorkspaceID, sales.ID, &domain.UpdatePipelineRequest{
+			PipelineType: &contact,
+			IsActive:     &inactive,
+			OwnerID:      &manager,
+		}))
+
+		got, err := pipelines.Get(ctx, f.WorkspaceID, sales.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PipelineTypeContact, got.PipelineType)
+		assert.False(t, got.IsActive)
+		assert.Equal(t, manager, got.OwnerID)
+		assert.Equal(t, sales.Name, got.Name, "omitted fields are kept")
+	})
+}
@@ -3,18 +3,17 @@ package repo
 import (
 	"context"
 
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
 	"linkko-api/internal/repo/sqlc"
-
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type PortfolioRepository struct {
-	pool    *pgxpool.Pool
+	pool    database.DB
 	queries *sqlc.Queries
 }
 
-func NewPortfolioRepository(pool *pgxpool.Pool) *PortfolioRepository {
+func NewPortfolioRepository(pool database.DB) *PortfolioRepository {
 	return &PortfolioRepository{
 		pool:    pool,
 		queries: sqlc.New(pool),
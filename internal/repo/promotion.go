@@ -0,0 +1,458 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrPromotionAmbiguousKey = apperr.Unprocessable(apperr.CodeValidationError,
+		"configuration key is duplicated in workspace, promotion cannot match it",
+		"configuration has duplicated names; rename them before promoting")
+	ErrPromotionUnresolvedReference = apperr.Unprocessable(apperr.CodeValidationError,
+		"promoted configuration references a resource that is not promoted",
+		"sequence step references an email template that no longer exists")
+)
+
+// PromotionRepository lê a configuração (não os dados) de dois workspaces, calcula o diff
+// pela chave natural de cada recurso e aplica as criações/atualizações no destino.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type PromotionRepository struct {
+	pool database.DB
+}
+
+func NewPromotionRepository(pool database.DB) *PromotionRepository {
+	return &PromotionRepository{pool: pool}
+}
+
+// promotionEntity descreve como ler, casar e gravar um recurso de configuração.
+// Os nomes de tabela/coluna são fixos (viram identificadores SQL); valores só entram como argumento.
+type promotionEntity struct {
+	resource  domain.PromotionResource
+	table     string
+	prefix    id.Prefix
+	key       string   // expressão SQL da chave natural
+	from      string   // FROM com o filtro de linhas ativas ($1 = workspace)
+	fields    []string // colunas copiadas e comparadas
+	createdBy string   // coluna preenchida com o ator no INSERT ("" = nenhuma)
+	updatedBy string   // coluna preenchida com o ator no UPDATE ("" = nenhuma)
+	parent    string   // coluna que referencia outro recurso promovido ("" = nenhuma)
+	remap     func(values map[string]interface{}, ids map[string]string) error
+}
+
+// promotionEntities em ordem de dependência: stages depois dos pipelines e sequências depois
+// dos templates de email que os passos referenciam.
+var promotionEntities = []promotionEntity{
+	{
+		resource:  domain.PromotionPipeline,
+		table:     "Pipeline",
+		prefix:    id.Pipeline,
+		key:       `t.name`,
+		from:      `public."Pipeline" t WHERE t."workspaceId" = $1 AND t."deletedAt" IS NULL`,
+		fields:    []string{"name", "description", "pipelineType", "isActive"},
+		createdBy: "ownerId",
+	},
+	{
+		resource: domain.PromotionStage,
+		table:    "PipelineStage",
+		prefix:   id.PipelineStage,
+		key:      `p.name || ' / ' || t.name`,
+		from: `public."PipelineStage" t JOIN public."Pipeline" p ON p.id = t."pipelineId"
+			WHERE t."workspaceId" = $1 AND p."deletedAt" IS NULL`,
+		fields: []string{"name", "orderIndex", "color", "group", "type", "isLocked", "rottingDays",
+			"firstResponseSlaMinutes", "resolutionSlaMinutes", "probability"},
+		parent: "pipelineId",
+	},
+	{
+		resource:  domain.PromotionComputedField,
+		table:     "ComputedField",
+		prefix:    id.ComputedField,
+		key:       `t."entityType" || '.' || t.key`,
+		from:      `public."ComputedField" t WHERE t."workspaceId" = $1`,
+		fields:    []string{"entityType", "key", "label", "formula", "resultType"},
+		createdBy: "createdById",
+		updatedBy: "updatedById",
+	},
+	{
+		resource:  domain.PromotionCustomObjectType,
+		table:     "CustomObjectType",
+		prefix:    id.CustomObjectType,
+		key:       `t.key`,
+		from:      `public."CustomObjectType" t WHERE t."workspaceId" = $1 AND t."deletedAt" IS NULL`,
+		fields:    []string{"key", "name", "description", "fields"},
+		createdBy: "createdById",
+		updatedBy: "updatedById",
+	},
+	{
+		resource:  domain.PromotionEmailTemplate,
+		table:     "EmailTemplate",
+		prefix:    id.EmailTemplate,
+		key:       `t.name`,
+		from:      `public."EmailTemplate" t WHERE t."workspaceId" = $1 AND t."deletedAt" IS NULL`,
+		fields:    []string{"name", "subject", "body", "description"},
+		createdBy: "createdById",
+		updatedBy: "updatedById",
+	},
+	{
+		resource:  domain.PromotionDocumentTemplate,
+		table:     "DocumentTemplate",
+		prefix:    id.DocumentTemplate,
+		key:       `t.name`,
+		from:      `public."DocumentTemplate" t WHERE t."workspaceId" = $1`,
+		fields:    []string{"name", "title", "body", "description"},
+		createdBy: "createdById",
+		updatedBy: "updatedById",
+	},
+	{
+		resource:  domain.PromotionSequence,
+		table:     "Sequence",
+		prefix:    id.Sequence,
+		key:       `t.name`,
+		from:      `public."Sequence" t WHERE t."workspaceId" = $1 AND t."deletedAt" IS NULL`,
+		fields:    []string{"name", "description", "steps", "onDealWon", "onReply"},
+		createdBy: "createdById",
+		updatedBy: "updatedById",
+		remap:     remapSequenceSteps,
+	},
+}
+
+// remapSequenceSteps troca o emailTemplateId dos passos pelo id do template equivalente no destino.
+func remapSequenceSteps(values map[string]interface{}, ids map[string]string) error {
+	steps, _ := values["steps"].([]interface{})
+	for i, raw := range steps {
+		step, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		templateID, ok := step["emailTemplateId"].(string)
+		if !ok || templateID == "" {
+			continue
+		}
+		targetID, ok := ids[templateID]
+		if !ok {
+			return fmt.Errorf("%w: steps[%d] emailTemplateId %s", ErrPromotionUnresolvedReference, i, templateID)
+		}
+		step["emailTemplateId"] = targetID
+	}
+	return nil
+}
+
+// promotionRow linha de configuração lida de um workspace; values só tem as colunas de fields.
+type promotionRow struct {
+	id       string
+	key      string
+	parentID string
+	values   map[string]interface{}
+}
+
+// promotionStep mudança planejada para um recurso. values tem todos os campos em CREATE
+// e só os alterados em UPDATE.
+type promotionStep struct {
+	entity   promotionEntity
+	change   domain.PromotionChange
+	targetID string
+	parentID string
+	values   map[string]interface{}
+}
+
+func loadPromotionRows(ctx context.Context, db database.DB, e promotionEntity, workspaceID string) ([]promotionRow, error) {
+	parent := `''`
+	if e.parent != "" {
+		parent = `COALESCE(t."` + e.parent + `", '')`
+	}
+	query := `SELECT t.id, ` + e.key + `, ` + parent + `, to_jsonb(t) FROM ` + e.from + ` ORDER BY 2, t.id`
+
+	rows, err := db.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", e.table, err)
+	}
+	defer rows.Close()
+
+	var result []promotionRow
+	for rows.Next() {
+		var row promotionRow
+		var raw []byte
+		if err := rows.Scan(&row.id, &row.key, &row.parentID, &raw); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", e.table, err)
+		}
+		var all map[string]interface{}
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", e.table, err)
+		}
+		row.values = make(map[string]interface{}, len(e.fields))
+		for _, field := range e.fields {
+			row.values[field] = all[field]
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate %s: %w", e.table, err)
+	}
+	return result, nil
+}
+
+// promotionPlanner casa os recursos da origem com os do destino, na ordem de promotionEntities,
+// lembrando o id de destino de cada recurso para remapear as referências dos seguintes.
+type promotionPlanner struct {
+	ids   map[string]string // id na origem → id no destino
+	newID func(id.Prefix) (string, error)
+}
+
+func newPromotionPlanner() *promotionPlanner {
+	return &promotionPlanner{ids: map[string]string{}, newID: id.New}
+}
+
+func indexPromotionRows(e promotionEntity, rows []promotionRow, workspaceID string) (map[string]promotionRow, error) {
+	byKey := make(map[string]promotionRow, len(rows))
+	for _, row := range rows {
+		if _, dup := byKey[row.key]; dup {
+			return nil, fmt.Errorf("%w: %s %q in workspace %s", ErrPromotionAmbiguousKey, e.resource, row.key, workspaceID)
+		}
+		byKey[row.key] = row
+	}
+	return byKey, nil
+}
+
+func (pl *promotionPlanner) plan(e promotionEntity, sources, targets []promotionRow, sourceWorkspaceID, targetWorkspaceID string) ([]promotionStep, error) {
+	if _, err := indexPromotionRows(e, sources, sourceWorkspaceID); err != nil {
+		return nil, err
+	}
+	targetByKey, err := indexPromotionRows(e, targets, targetWorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]promotionStep, 0, len(sources))
+	for _, src := range sources {
+		if e.remap != nil {
+			if err := e.remap(src.values, pl.ids); err != nil {
+				return nil, fmt.Errorf("%s %q: %w", e.resource, src.key, err)
+			}
+		}
+
+		step := promotionStep{
+			entity: e,
+			change: domain.PromotionChange{Resource: e.resource, Key: src.key, SourceID: src.id},
+		}
+		if e.parent != "" {
+			parentID, ok := pl.ids[src.parentID]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s %q %s %s", ErrPromotionUnresolvedReference, e.resource, src.key, e.parent, src.parentID)
+			}
+			step.parentID = parentID
+		}
+
+		if tgt, ok := targetByKey[src.key]; ok {
+			step.targetID = tgt.id
+			step.values = map[string]interface{}{}
+			for _, field := range e.fields {
+				if reflect.DeepEqual(src.values[field], tgt.values[field]) {
+					continue
+				}
+				step.values[field] = src.values[field]
+				step.change.Fields = append(step.change.Fields, domain.PromotionFieldChange{
+					Field:  field,
+					Before: tgt.values[field],
+					After:  src.values[field],
+				})
+			}
+			step.change.Action = domain.PromotionUnchanged
+			if len(step.values) > 0 {
+				step.change.Action = domain.PromotionUpdate
+			}
+		} else {
+			step.targetID, err = pl.newID(e.prefix)
+			if err != nil {
+				return nil, err
+			}
+			step.values = src.values
+			step.change.Action = domain.PromotionCreate
+		}
+
+		pl.ids[src.id] = step.targetID
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// planPromotion lê a configuração dos dois workspaces com db e monta os passos da promoção.
+func planPromotion(ctx context.Context, db database.DB, sourceWorkspaceID, targetWorkspaceID string) ([]promotionStep, error) {
+	planner := newPromotionPlanner()
+
+	var steps []promotionStep
+	for _, e := range promotionEntities {
+		sources, err := loadPromotionRows(ctx, db, e, sourceWorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		targets, err := loadPromotionRows(ctx, db, e, targetWorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		entitySteps, err := planner.plan(e, sources, targets, sourceWorkspaceID, targetWorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, entitySteps...)
+	}
+	return steps, nil
+}
+
+func buildPromotionPlan(sourceWorkspaceID, targetWorkspaceID string, steps []promotionStep, applied bool) *domain.PromotionPlan {
+	plan := &domain.PromotionPlan{
+		SourceWorkspaceID: sourceWorkspaceID,
+		TargetWorkspaceID: targetWorkspaceID,
+		Applied:           applied,
+		Changes:           make([]domain.PromotionChange, 0, len(steps)),
+	}
+	for _, s := range steps {
+		change := s.change
+		if applied || change.Action != domain.PromotionCreate {
+			targetID := s.targetID
+			change.TargetID = &targetID
+		}
+		plan.Add(change)
+	}
+	return plan
+}
+
+// Preview calcula o diff sem gravar nada. As leituras dos dois workspaces rodam no mesmo
+// snapshot (TxManager.ReadSnapshot), então o plano é coerente mesmo com edições concorrentes.
+func (r *PromotionRepository) Preview(ctx context.Context, sourceWorkspaceID, targetWorkspaceID string) (*domain.PromotionPlan, error) {
+	snapshots := database.NewTxManager(r.pool)
+
+	var steps []promotionStep
+	err := snapshots.ReadSnapshot(ctx, func(ctx context.Context) error {
+		var err error
+		steps, err = planPromotion(ctx, snapshots, sourceWorkspaceID, targetWorkspaceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildPromotionPlan(sourceWorkspaceID, targetWorkspaceID, steps, false), nil
+}
+
+// BeginTx inicia uma transação para ApplyTx.
+func (r *PromotionRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// Apply recalcula o plano e grava todas as criações/atualizações em uma única transação:
+// qualquer falha desfaz a promoção inteira. Nada é removido do destino.
+func (r *PromotionRepository) Apply(ctx context.Context, sourceWorkspaceID, targetWorkspaceID, actorID string) (*domain.PromotionPlan, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin promotion: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	plan, err := r.ApplyTx(ctx, tx, sourceWorkspaceID, targetWorkspaceID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit promotion: %w", err)
+	}
+	return plan, nil
+}
+
+// ApplyTx faz o trabalho de Apply dentro de tx; o commit fica com o chamador (a clonagem para
+// sandbox grava os contatos de exemplo na mesma transação). O lock na linha do workspace de
+// destino serializa promoções e clonagens concorrentes para o mesmo destino.
+func (r *PromotionRepository) ApplyTx(ctx context.Context, tx pgx.Tx, sourceWorkspaceID, targetWorkspaceID, actorID string) (*domain.PromotionPlan, error) {
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM public."Workspace" WHERE id = $1 FOR UPDATE`, targetWorkspaceID); err != nil {
+		return nil, fmt.Errorf("lock target workspace: %w", err)
+	}
+
+	steps, err := planPromotion(ctx, tx, sourceWorkspaceID, targetWorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range steps {
+		if err := applyPromotionStep(ctx, tx, s, targetWorkspaceID, actorID); err != nil {
+			return nil, err
+		}
+	}
+	return buildPromotionPlan(sourceWorkspaceID, targetWorkspaceID, steps, true), nil
+}
+
+// quotedColumns retorna as colunas de record em ordem estável, entre aspas e com o prefixo dado.
+func quotedColumns(record map[string]interface{}, prefix string) string {
+	columns := make([]string, 0, len(record))
+	for column := range record {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for i, column := range columns {
+		columns[i] = prefix + `"` + column + `"`
+	}
+	return strings.Join(columns, ", ")
+}
+
+// applyPromotionStep grava um passo. Os valores passam por jsonb_populate_record, que converte
+// o JSON lido da origem para os tipos das colunas (enums, JSONB, inteiros).
+func applyPromotionStep(ctx context.Context, db database.DB, s promotionStep, workspaceID, actorID string) error {
+	e := s.entity
+	record := make(map[string]interface{}, len(s.values)+4)
+	for field, value := range s.values {
+		record[field] = value
+	}
+
+	var query string
+	var args []interface{}
+	switch s.change.Action {
+	case domain.PromotionCreate:
+		record["id"] = s.targetID
+		record["workspaceId"] = workspaceID
+		if e.createdBy != "" {
+			record[e.createdBy] = actorID
+		}
+		if e.parent != "" {
+			record[e.parent] = s.parentID
+		}
+		query = `
+			INSERT INTO public."` + e.table + `" (` + quotedColumns(record, "") + `, "createdAt", "updatedAt")
+			SELECT ` + quotedColumns(record, "r.") + `, NOW(), NOW()
+			FROM jsonb_populate_record(NULL::public."` + e.table + `", $1::jsonb) r`
+	case domain.PromotionUpdate:
+		if e.updatedBy != "" {
+			record[e.updatedBy] = actorID
+		}
+		query = `
+			UPDATE public."` + e.table + `"
+			SET (` + quotedColumns(record, "") + `) = (
+				SELECT ` + quotedColumns(record, "r.") + `
+				FROM jsonb_populate_record(NULL::public."` + e.table + `", $1::jsonb) r
+			), "updatedAt" = NOW()
+			WHERE id = $2 AND "workspaceId" = $3`
+		args = []interface{}{s.targetID, workspaceID}
+	default:
+		return nil
+	}
+
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal %s %q: %w", e.resource, s.change.Key, err)
+	}
+	tag, err := db.Exec(ctx, query, append([]interface{}{string(payload)}, args...)...)
+	if err != nil {
+		return fmt.Errorf("promote %s %q: %w", e.resource, s.change.Key, err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("promote %s %q: %d rows affected", e.resource, s.change.Key, tag.RowsAffected())
+	}
+	return nil
+}
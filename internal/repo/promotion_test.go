@@ -0,0 +1,124 @@
+package repo
+
+import (
+	"fmt"
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func promotionEntityFor(t *testing.T, resource domain.PromotionResource) promotionEntity {
+	t.Helper()
+	for _, e := range promotionEntities {
+		if e.resource == resource {
+			return e
+		}
+	}
+	t.Fatalf("no promotion entity for %s", resource)
+	return promotionEntity{}
+}
+
+func testPlanner() *promotionPlanner {
+	n := 0
+	return &promotionPlanner{ids: map[string]string{}, newID: func(prefix id.Prefix) (string, error) {
+		n++
+		return fmt.Sprintf("%s_new%d", prefix, n), nil
+	}}
+}
+
+func TestPromotionPlanner_MatchesByNaturalKey(t *testing.T) {
+	pipelines := promotionEntityFor(t, domain.PromotionPipeline)
+	pl := testPlanner()
+
+	sources := []promotionRow{
+		{id: "pip_s1", key: "Vendas", values: map[string]interface{}{"name": "Vendas", "description": "novo", "pipelineType": "DEAL", "isActive": true}},
+		{id: "pip_s2", key: "Suporte", values: map[string]interface{}{"name": "Suporte", "description": nil, "pipelineType": "TICKET", "isActive": true}},
+		{id: "pip_s3", key: "Parcerias", values: map[string]interface{}{"name": "Parcerias", "description": nil, "pipelineType": "DEAL", "isActive": false}},
+	}
+	targets := []promotionRow{
+		{id: "pip_t1", key: "Vendas", values: map[string]interface{}{"name": "Vendas", "description": "antigo", "pipelineType": "DEAL", "isActive": true}},
+		{id: "pip_t2", key: "Suporte", values: map[string]interface{}{"name": "Suporte", "description": nil, "pipelineType": "TICKET", "isActive": true}},
+		{id: "pip_t9", key: "Só em produção", values: map[string]interface{}{"name": "Só em produção"}},
+	}
+
+	steps, err := pl.plan(pipelines, sources, targets, "wks_sandbox", "wks_prod")
+	require.NoError(t, err)
+	require.Len(t, steps, 3, "production-only resources are never part of the plan")
+
+	assert.Equal(t, domain.PromotionUpdate, steps[0].change.Action)
+	assert.Equal(t, "pip_t1", steps[0].targetID)
+	assert.Equal(t, map[string]interface{}{"description": "novo"}, steps[0].values, "only changed fields are written")
+	assert.Equal(t, []domain.PromotionFieldChange{{Field: "description", Before: "antigo", After: "novo"}}, steps[0].change.Fields)
+
+	assert.Equal(t, domain.PromotionUnchanged, steps[1].change.Action)
+	assert.Empty(t, steps[1].change.Fields)
+
+	assert.Equal(t, domain.PromotionCreate, steps[2].change.Action)
+	assert.Equal(t, "pip_new1", steps[2].targetID)
+	assert.Equal(t, sources[2].values, steps[2].values)
+
+	assert.Equal(t, map[string]string{"pip_s1": "pip_t1", "pip_s2": "pip_t2", "pip_s3": "pip_new1"}, pl.ids)
+}
+
+func TestPromotionPlanner_RemapsReferences(t *testing.T) {
+	pl := testPlanner()
+	pl.ids["pip_s1"] = "pip_t1"
+	pl.ids["etp_s1"] = "etp_t1"
+
+	stages, err := pl.plan(promotionEntityFor(t, domain.PromotionStage), []promotionRow{
+		{id: "stg_s1", key: "Vendas / Lead", parentID: "pip_s1", values: map[string]interface{}{"name": "Lead"}},
+	}, nil, "wks_sandbox", "wks_prod")
+	require.NoError(t, err)
+	assert.Equal(t, "pip_t1", stages[0].parentID)
+
+	steps := []interface{}{
+		map[string]interface{}{"type": "email", "emailTemplateId": "etp_s1"},
+		map[string]interface{}{"type": "wait", "waitDays": float64(2)},
+	}
+	targetSteps := []interface{}{
+		map[string]interface{}{"type": "email", "emailTemplateId": "etp_t1"},
+		map[string]interface{}{"type": "wait", "waitDays": float64(2)},
+	}
+	sequences, err := pl.plan(promotionEntityFor(t, domain.PromotionSequence),
+		[]promotionRow{{id: "seq_s1", key: "Onboarding", values: map[string]interface{}{"name": "Onboarding", "steps": steps}}},
+		[]promotionRow{{id: "seq_t1", key: "Onboarding", values: map[string]interface{}{"name": "Onboarding", "steps": targetSteps}}},
+		"wks_sandbox", "wks_prod")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PromotionUnchanged, sequences[0].change.Action, "template ids are compared after remapping")
+}
+
+func TestPromotionPlanner_Errors(t *testing.T) {
+	pl := testPlanner()
+
+	_, err := pl.plan(promotionEntityFor(t, domain.PromotionEmailTemplate), nil, []promotionRow{
+		{id: "etp_t1", key: "Boas-vindas"},
+		{id: "etp_t2", key: "Boas-vindas"},
+	}, "wks_sandbox", "wks_prod")
+	assert.ErrorIs(t, err, ErrPromotionAmbiguousKey)
+
+	_, err = pl.plan(promotionEntityFor(t, domain.PromotionSequence), []promotionRow{{
+		id: "seq_s1", key: "Onboarding",
+		values: map[string]interface{}{"steps": []interface{}{map[string]interface{}{"type": "email", "emailTemplateId": "etp_deleted"}}},
+	}}, nil, "wks_sandbox", "wks_prod")
+	assert.ErrorIs(t, err, ErrPromotionUnresolvedReference)
+}
+
+func TestBuildPromotionPlan_HidesUnsavedIDs(t *testing.T) {
+	steps := []promotionStep{
+		{targetID: "pip_new1", change: domain.PromotionChange{Action: domain.PromotionCreate}},
+		{targetID: "pip_t1", change: domain.PromotionChange{Action: domain.PromotionUpdate}},
+	}
+
+	preview := buildPromotionPlan("wks_sandbox", "wks_prod", steps, false)
+	assert.Nil(t, preview.Changes[0].TargetID)
+	assert.Equal(t, "pip_t1", *preview.Changes[1].TargetID)
+	assert.Equal(t, domain.PromotionSummary{Create: 1, Update: 1}, preview.Summary)
+
+	applied := buildPromotionPlan("wks_sandbox", "wks_prod", steps, true)
+	assert.Equal(t, "pip_new1", *applied.Changes[0].TargetID)
+	assert.True(t, applied.Applied)
+}
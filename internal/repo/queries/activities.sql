@@ -9,9 +9,9 @@ INSERT INTO "Activity" (
 -- name: CreateNote :one
 INSERT INTO "Note" (
     id, "workspaceId", "companyId", "contactId", "dealId",
-    content, "isPinned", "userId"
+    content, "isPinned", "userId", body, "visibility"
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: CreateCall :one
@@ -39,9 +39,78 @@ INSERT INTO "Message" (
 ) RETURNING *;
 
 -- name: ListActivities :many
-SELECT * FROM "Activity"
+-- Notas fixadas (pinnedAt) vêm no topo, as mais recentemente fixadas primeiro.
+-- viewerId (nulo para admins) oculta as notas PRIVATE/TEAM que o usuário não pode ver.
+SELECT a.*, n."pinnedAt"
+FROM "Activity" a
+LEFT JOIN "Note" n ON a."activityType" = 'NOTE' AND n.id = a."activityId" AND n."deletedAt" IS NULL
+WHERE a."workspaceId" = $1
+    AND (sqlc.narg('contactId')::TEXT IS NULL OR a."contactId" = sqlc.narg('contactId'))
+    AND (sqlc.narg('companyId')::TEXT IS NULL OR a."companyId" = sqlc.narg('companyId'))
+    AND (sqlc.narg('dealId')::TEXT IS NULL OR a."dealId" = sqlc.narg('dealId'))
+    AND (sqlc.narg('viewerId')::TEXT IS NULL OR a."activityType" <> 'NOTE' OR NOT EXISTS (
+        SELECT 1 FROM "Note" vn
+        WHERE vn.id = a."activityId" AND vn."visibility" <> 'WORKSPACE' AND vn."userId" <> sqlc.narg('viewerId')
+            AND NOT (vn."visibility" = 'TEAM' AND (
+                EXISTS (SELECT 1 FROM "Follower" f WHERE f."userId" = sqlc.narg('viewerId')
+                    AND ((f."entityType" = 'CONTACT' AND f."entityId" = vn."contactId") OR (f."entityType" = 'DEAL' AND f."entityId" = vn."dealId")))
+                OR EXISTS (SELECT 1 FROM "Contact" c WHERE c.id = vn."contactId" AND c."ownerId" = sqlc.narg('viewerId'))
+                OR EXISTS (SELECT 1 FROM "Deal" d WHERE d.id = vn."dealId" AND d."ownerId" = sqlc.narg('viewerId'))
+            ))
+    ))
+    -- participantId: chamadas/reuniões de que o usuário ou contato participou
+    AND (sqlc.narg('participantId')::TEXT IS NULL OR EXISTS (
+        SELECT 1 FROM "ActivityParticipant" ap
+        WHERE ap."workspaceId" = a."workspaceId" AND ap."activityId" = a."activityId"
+            AND (ap."userId" = sqlc.narg('participantId') OR ap."contactId" = sqlc.narg('participantId'))
+    ))
+    AND (sqlc.narg('activityType')::TEXT IS NULL OR a."activityType"::TEXT = sqlc.narg('activityType'))
+ORDER BY n."pinnedAt" DESC NULLS LAST, a."createdAt" DESC;
+
+-- name: CountActivitiesByTypeSince :many
+-- Contagem por tipo para o digest da timeline (somente atividades posteriores ao cursor).
+-- Comparação direta com "createdAt" (sem "IS NULL OR") para permitir partition pruning.
+SELECT "activityType", COUNT(*) AS total, MAX("createdAt")::TIMESTAMP AS latest_at
+FROM "Activity"
 WHERE "workspaceId" = $1
+    AND "createdAt" > COALESCE(sqlc.narg('since')::TIMESTAMP, '-infinity')
     AND (sqlc.narg('contactId')::TEXT IS NULL OR "contactId" = sqlc.narg('contactId'))
     AND (sqlc.narg('companyId')::TEXT IS NULL OR "companyId" = sqlc.narg('companyId'))
     AND (sqlc.narg('dealId')::TEXT IS NULL OR "dealId" = sqlc.narg('dealId'))
-ORDER BY "createdAt" DESC;
+    AND (sqlc.narg('viewerId')::TEXT IS NULL OR "Activity"."activityType" <> 'NOTE' OR NOT EXISTS (
+        SELECT 1 FROM "Note" vn
+        WHERE vn.id = "Activity"."activityId" AND vn."visibility" <> 'WORKSPACE' AND vn."userId" <> sqlc.narg('viewerId')
+            AND NOT (vn."visibility" = 'TEAM' AND (
+                EXISTS (SELECT 1 FROM "Follower" f WHERE f."userId" = sqlc.narg('viewerId')
+                    AND ((f."entityType" = 'CONTACT' AND f."entityId" = vn."contactId") OR (f."entityType" = 'DEAL' AND f."entityId" = vn."dealId")))
+                OR EXISTS (SELECT 1 FROM "Contact" c WHERE c.id = vn."contactId" AND c."ownerId" = sqlc.narg('viewerId'))
+                OR EXISTS (SELECT 1 FROM "Deal" d WHERE d.id = vn."dealId" AND d."ownerId" = sqlc.narg('viewerId'))
+            ))
+    ))
+GROUP BY "activityType"
+ORDER BY "activityType";
+
+-- name: ListLatestActivitiesByTypeSince :many
+-- Últimas perType atividades de cada tipo posteriores ao cursor (sem metadata: payload mínimo).
+SELECT id, "workspaceId", "companyId", "contactId", "dealId", "activityType", "activityId", "userId", "createdAt"
+FROM (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY "activityType" ORDER BY "createdAt" DESC, id DESC) AS rn
+    FROM "Activity"
+    WHERE "workspaceId" = $1
+        AND "createdAt" > COALESCE(sqlc.narg('since')::TIMESTAMP, '-infinity')
+        AND (sqlc.narg('contactId')::TEXT IS NULL OR "contactId" = sqlc.narg('contactId'))
+        AND (sqlc.narg('companyId')::TEXT IS NULL OR "companyId" = sqlc.narg('companyId'))
+        AND (sqlc.narg('dealId')::TEXT IS NULL OR "dealId" = sqlc.narg('dealId'))
+        AND (sqlc.narg('viewerId')::TEXT IS NULL OR "Activity"."activityType" <> 'NOTE' OR NOT EXISTS (
+            SELECT 1 FROM "Note" vn
+            WHERE vn.id = "Activity"."activityId" AND vn."visibility" <> 'WORKSPACE' AND vn."userId" <> sqlc.narg('viewerId')
+                AND NOT (vn."visibility" = 'TEAM' AND (
+                    EXISTS (SELECT 1 FROM "Follower" f WHERE f."userId" = sqlc.narg('viewerId')
+                        AND ((f."entityType" = 'CONTACT' AND f."entityId" = vn."contactId") OR (f."entityType" = 'DEAL' AND f."entityId" = vn."dealId")))
+                    OR EXISTS (SELECT 1 FROM "Contact" c WHERE c.id = vn."contactId" AND c."ownerId" = sqlc.narg('viewerId'))
+                    OR EXISTS (SELECT 1 FROM "Deal" d WHERE d.id = vn."dealId" AND d."ownerId" = sqlc.narg('viewerId'))
+                ))
+        ))
+) latest
+WHERE rn <= sqlc.arg('perType')::INT
+ORDER BY "activityType", "createdAt" DESC, id DESC;
@@ -15,7 +15,8 @@ SELECT
     "currency", "locale", "businessHours", "supportHours",
     "deletedAt", "deletedById", "size", "revenue",
     "companyScore", "lifecycleStage", "assignedToId",
-    "createdById", "updatedById", "createdAt", "updatedAt"
+    "createdById", "updatedById", "createdAt", "updatedAt",
+    "industry", "logoUrl", "customFields", "externalId"
 FROM "Company"
 WHERE "id" = $1
   AND "workspaceId" = $2
@@ -29,17 +30,18 @@ SELECT
     "currency", "locale", "businessHours", "supportHours",
     "deletedAt", "deletedById", "size", "revenue",
     "companyScore", "lifecycleStage", "assignedToId",
-    "createdById", "updatedById", "createdAt", "updatedAt"
+    "createdById", "updatedById", "createdAt", "updatedAt",
+    "industry", "logoUrl", "customFields", "externalId"
 FROM "Company"
-WHERE "workspaceId" = $1
+WHERE "workspaceId" = sqlc.arg('workspaceId')
   AND "deletedAt" IS NULL
-  AND ($2::TEXT IS NULL OR "lifecycleStage"::TEXT = $2)
-  AND ($3::TEXT IS NULL OR "size"::TEXT = $3)
-  AND ($4::TEXT IS NULL OR "assignedToId" = $4)
-  AND ($5::TEXT IS NULL OR to_tsvector('simple', "name" || ' ' || COALESCE("website", '')) @@ plainto_tsquery('simple', $5))
-  AND ($6::TIMESTAMP IS NULL OR "createdAt" < $6)
-ORDER BY "createdAt" DESC
-LIMIT $7;
+  AND (sqlc.narg('lifecycleStage')::TEXT IS NULL OR "lifecycleStage"::TEXT = sqlc.narg('lifecycleStage'))
+  AND (sqlc.narg('size')::TEXT IS NULL OR "size"::TEXT = sqlc.narg('size'))
+  AND (sqlc.narg('ownerId')::TEXT IS NULL OR "assignedToId" = sqlc.narg('ownerId'))
+  AND (sqlc.narg('queryText')::TEXT IS NULL OR to_tsvector('simple', "name" || ' ' || COALESCE("website", '')) @@ plainto_tsquery('simple', sqlc.narg('queryText')))
+  AND (sqlc.narg('cursorTime')::TIMESTAMP IS NULL OR ("createdAt", "id") < (sqlc.narg('cursorTime'), sqlc.arg('cursorId')::TEXT))
+ORDER BY "createdAt" DESC, "id" DESC
+LIMIT sqlc.arg('limit');
 
 -- name: CreateCompany :one
 INSERT INTO "Company" (
@@ -49,7 +51,7 @@ INSERT INTO "Company" (
     "currency", "locale", "businessHours", "supportHours",
     "size", "revenue", "companyScore", "lifecycleStage",
     "assignedToId", "createdById", "updatedById",
-    "createdAt", "updatedAt"
+    "createdAt", "updatedAt", "externalId"
 ) VALUES (
     $1, $2, $3, $4, $5,
     $6, $7, $8, $9, $10,
@@ -57,7 +59,7 @@ INSERT INTO "Company" (
     $16, $17, $18, $19,
     $20, $21, $22, $23,
     $24, $25, $26,
-    $27, $28
+    $27, $28, $29
 )
 RETURNING 
     "id", "workspaceId", "name", "website", "linkedin",
@@ -93,6 +95,7 @@ SET
     "companyScore" = COALESCE($22, "companyScore"),
     "lifecycleStage" = COALESCE($23, "lifecycleStage"),
     "assignedToId" = COALESCE($24, "assignedToId"),
+    "externalId" = COALESCE($28, "externalId"),
     "updatedById" = $25,
     "updatedAt" = $26
 WHERE "id" = $1
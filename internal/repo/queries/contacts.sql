@@ -42,15 +42,28 @@ SELECT
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById"
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId"
 FROM "Contact"
 WHERE "id" = $1
   AND "workspaceId" = $2
   AND "deletedAt" IS NULL;
 
 -- name: ListContacts :many
--- Lista contatos de um workspace com paginação cursor-based (created_at DESC).
--- Filtros opcionais: ownerId, companyId, lifecycleStage, query (fulltext search).
+-- Lista contatos de um workspace com paginação cursor-based (created_at DESC, id DESC).
+-- Filtros opcionais: ownerId, companyId, lifecycleStage, atribuição (source/utm*), query (fulltext search),
+-- followerId (contatos seguidos pelo usuário), emailStatus (resultado da verificação do email).
 SELECT 
     "id",
     "fullName",
@@ -85,16 +98,37 @@ SELECT
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById"
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId"
 FROM "Contact"
 WHERE "workspaceId" = sqlc.arg('workspaceId')
   AND "deletedAt" IS NULL
   AND (sqlc.narg('ownerId')::TEXT IS NULL OR "ownerId" = sqlc.narg('ownerId'))
   AND (sqlc.narg('companyId')::TEXT IS NULL OR "companyId" = sqlc.narg('companyId'))
   AND (sqlc.narg('lifecycleStage')::TEXT IS NULL OR "lifecycleStage"::TEXT = sqlc.narg('lifecycleStage'))
+  AND (sqlc.narg('source')::TEXT IS NULL OR "source" = sqlc.narg('source'))
+  AND (sqlc.narg('utmSource')::TEXT IS NULL OR "utmSource" = sqlc.narg('utmSource'))
+  AND (sqlc.narg('utmMedium')::TEXT IS NULL OR "utmMedium" = sqlc.narg('utmMedium'))
+  AND (sqlc.narg('utmCampaign')::TEXT IS NULL OR "utmCampaign" = sqlc.narg('utmCampaign'))
   AND (sqlc.narg('queryText')::TEXT IS NULL OR to_tsvector('simple', "fullName" || ' ' || COALESCE("email", '')) @@ plainto_tsquery('simple', sqlc.narg('queryText')))
-  AND (sqlc.narg('cursorTime')::TIMESTAMP IS NULL OR "createdAt" < sqlc.narg('cursorTime'))
-ORDER BY "createdAt" DESC
+  AND (sqlc.narg('followerId')::TEXT IS NULL OR EXISTS (
+    SELECT 1 FROM "Follower" f
+    WHERE f."entityType" = 'CONTACT' AND f."entityId" = "Contact"."id" AND f."userId" = sqlc.narg('followerId')
+  ))
+  AND (sqlc.narg('emailStatus')::TEXT IS NULL OR "emailStatus" = sqlc.narg('emailStatus'))
+  AND (sqlc.narg('cursorTime')::TIMESTAMP IS NULL OR ("createdAt", "id") < (sqlc.narg('cursorTime'), sqlc.arg('cursorId')::TEXT))
+ORDER BY "createdAt" DESC, "id" DESC
 LIMIT sqlc.arg('limit');
 
 -- name: CreateContact :one
@@ -131,7 +165,16 @@ INSERT INTO "Contact" (
     "createdById",
     "updatedById",
     "createdAt",
-    "updatedAt"
+    "updatedAt",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "externalId"
 ) VALUES (
     $1,  -- id
     $2,  -- fullName
@@ -164,7 +207,16 @@ INSERT INTO "Contact" (
     $29, -- createdById
     $30, -- updatedById
     $31, -- createdAt
-    $32  -- updatedAt
+    $32, -- updatedAt
+    $33, -- sourceDetail
+    $34, -- utmSource
+    $35, -- utmMedium
+    $36, -- utmCampaign
+    $37, -- utmTerm
+    $38, -- utmContent
+    $39, -- nextStepAt
+    $40, -- nextStepNote
+    $41  -- externalId
 )
 RETURNING 
     "id",
@@ -200,14 +252,31 @@ RETURNING
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById";
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId";
 
 -- name: UpdateContact :one
 -- Atualiza um contato existente (IDOR protection + optimistic locking via updatedAt).
+-- lifecycleStage não é alterado aqui: mudanças de estágio passam por TransitionContactLifecycleStage.
 UPDATE "Contact"
 SET
     "fullName" = COALESCE($3, "fullName"),
     "email" = COALESCE($4, "email"),
+    -- Email alterado: volta para a fila de verificação
+    "emailStatus" = CASE WHEN $4 IS NOT NULL AND $4 IS DISTINCT FROM "email" THEN NULL ELSE "emailStatus" END,
+    "emailStatusReason" = CASE WHEN $4 IS NOT NULL AND $4 IS DISTINCT FROM "email" THEN NULL ELSE "emailStatusReason" END,
+    "emailCheckedAt" = CASE WHEN $4 IS NOT NULL AND $4 IS DISTINCT FROM "email" THEN NULL ELSE "emailCheckedAt" END,
     "phone" = COALESCE($5, "phone"),
     "whatsapp" = COALESCE($6, "whatsapp"),
     "notes" = COALESCE($7, "notes"),
@@ -230,14 +299,16 @@ SET
     "socialUrls" = COALESCE($24, "socialUrls"),
     "companyId" = COALESCE($25, "companyId"),
     "contactScore" = COALESCE($26, "contactScore"),
-    "lifecycleStage" = COALESCE($27, "lifecycleStage"),
-    "assignedToId" = COALESCE($28, "assignedToId"),
-    "updatedById" = $29,
-    "updatedAt" = $30
+    "assignedToId" = COALESCE($27, "assignedToId"),
+    "nextStepAt" = COALESCE($28, "nextStepAt"),
+    "nextStepNote" = COALESCE($29, "nextStepNote"),
+    "externalId" = COALESCE($33, "externalId"),
+    "updatedById" = $30,
+    "updatedAt" = $31
 WHERE "id" = $1
   AND "workspaceId" = $2
   AND "deletedAt" IS NULL
-  AND "updatedAt" = $31  -- Optimistic locking
+  AND "updatedAt" = $32  -- Optimistic locking
 RETURNING 
     "id",
     "fullName",
@@ -272,7 +343,19 @@ RETURNING
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById";
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId";
 
 -- name: SoftDeleteContact :exec
 -- Soft delete de um contato (marca deletedAt + deletedById).
@@ -311,3 +394,76 @@ SELECT EXISTS(
       AND "workspaceId" = $2
       AND "deletedAt" IS NULL
 ) AS "exists";
+
+-- name: TransitionContactLifecycleStage :one
+-- Move o contato para outro estágio do funil.
+-- Compare-and-set em fromStage: duas transições concorrentes não sobrescrevem uma à outra.
+UPDATE "Contact"
+SET
+    "lifecycleStage" = sqlc.arg('toStage'),
+    "updatedById" = sqlc.arg('updatedById'),
+    "updatedAt" = sqlc.arg('updatedAt')
+WHERE "id" = sqlc.arg('id')
+  AND "workspaceId" = sqlc.arg('workspaceId')
+  AND "deletedAt" IS NULL
+  AND "lifecycleStage" = sqlc.arg('fromStage')
+RETURNING 
+    "id",
+    "fullName",
+    "workspaceId",
+    "email",
+    "phone",
+    "whatsapp",
+    "notes",
+    "firstName",
+    "lastName",
+    "image",
+    "linkedinUrl",
+    "language",
+    "timezone",
+    "city",
+    "state",
+    "country",
+    "jobTitle",
+    "department",
+    "decisionRole",
+    "tagLabels",
+    "source",
+    "lastInteractionAt",
+    "ownerId",
+    "socialUrls",
+    "companyId",
+    "contactScore",
+    "lifecycleStage",
+    "assignedToId",
+    "createdById",
+    "updatedById",
+    "createdAt",
+    "updatedAt",
+    "deletedAt",
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId";
+
+-- name: CountContactsByLifecycleStage :many
+-- Conta contatos ativos por estágio do funil (relatório de lifecycle).
+-- Filtros opcionais: ownerId, companyId.
+SELECT
+    "lifecycleStage",
+    COUNT(*) AS "total"
+FROM "Contact"
+WHERE "workspaceId" = sqlc.arg('workspaceId')
+  AND "deletedAt" IS NULL
+  AND (sqlc.narg('ownerId')::TEXT IS NULL OR "ownerId" = sqlc.narg('ownerId'))
+  AND (sqlc.narg('companyId')::TEXT IS NULL OR "companyId" = sqlc.narg('companyId'))
+GROUP BY "lifecycleStage";
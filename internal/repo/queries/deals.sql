@@ -20,6 +20,28 @@ WHERE d."workspaceId" = $1
     AND (sqlc.narg('pipelineId')::TEXT IS NULL OR d."pipelineId" = sqlc.narg('pipelineId'))
     AND (sqlc.narg('stageId')::TEXT IS NULL OR d."stageId" = sqlc.narg('stageId'))
     AND (sqlc.narg('ownerId')::TEXT IS NULL OR d."ownerId" = sqlc.narg('ownerId'))
+    AND (sqlc.narg('source')::TEXT IS NULL OR d.source = sqlc.narg('source'))
+    AND (sqlc.narg('utmSource')::TEXT IS NULL OR d."utmSource" = sqlc.narg('utmSource'))
+    AND (sqlc.narg('utmMedium')::TEXT IS NULL OR d."utmMedium" = sqlc.narg('utmMedium'))
+    AND (sqlc.narg('utmCampaign')::TEXT IS NULL OR d."utmCampaign" = sqlc.narg('utmCampaign'))
+    AND (NOT sqlc.arg('rottingOnly')::BOOLEAN OR d."rottingSince" IS NOT NULL)
+    AND (sqlc.narg('followerId')::TEXT IS NULL OR EXISTS (
+        SELECT 1 FROM "Follower" f
+        WHERE f."entityType" = 'DEAL' AND f."entityId" = d.id AND f."userId" = sqlc.narg('followerId')
+    ))
+    -- q: nome do deal, do contato ou da empresa; as subqueries usam os índices GIN de cada tabela
+    AND (sqlc.narg('queryText')::TEXT IS NULL
+        OR to_tsvector('simple', d.name) @@ plainto_tsquery('simple', sqlc.narg('queryText'))
+        OR d."contactId" IN (
+            SELECT qc.id FROM "Contact" qc
+            WHERE qc."workspaceId" = $1
+              AND to_tsvector('simple', qc."fullName" || ' ' || COALESCE(qc."email", '')) @@ plainto_tsquery('simple', sqlc.narg('queryText'))
+        )
+        OR d."companyId" IN (
+            SELECT qco.id FROM "Company" qco
+            WHERE qco."workspaceId" = $1
+              AND to_tsvector('simple', qco."name" || ' ' || COALESCE(qco."website", '')) @@ plainto_tsquery('simple', sqlc.narg('queryText'))
+        ))
     AND d."deletedAt" IS NULL
 ORDER BY d."createdAt" DESC;
 
@@ -27,9 +49,16 @@ ORDER BY d."createdAt" DESC;
 INSERT INTO "Deal" (
     id, "workspaceId", "pipelineId", "stageId", "contactId", "companyId",
     name, value, currency, stage, probability, 
-    "expectedCloseDate", "ownerId", "createdById", description
+    "expectedCloseDate", "ownerId", "createdById", description,
+    source, "sourceDetail", "utmSource", "utmMedium", "utmCampaign", "utmTerm", "utmContent", "nextStepAt", "nextStepNote",
+    "forecastCategory", "forecastCategoryOverridden", "externalId"
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+    $16, $17, $18, $19, $20, $21, $22, $23, $24,
+    -- sem categoria manual, derivada do estágio e da probabilidade da etapa (ou a do deal)
+    COALESCE(sqlc.narg('forecastCategory')::TEXT, deal_forecast_category($10, COALESCE((SELECT s.probability FROM "PipelineStage" s WHERE s.id = $4), $11))),
+    sqlc.narg('forecastCategory')::TEXT IS NOT NULL,
+    sqlc.narg('externalId')
 ) RETURNING *;
 
 -- name: UpdateDeal :one
@@ -47,6 +76,20 @@ SET
     "lostReason" = COALESCE(sqlc.narg('lostReason'), "lostReason"),
     "ownerId" = COALESCE(sqlc.narg('ownerId'), "ownerId"),
     description = COALESCE(sqlc.narg('description'), description),
+    "nextStepAt" = COALESCE(sqlc.narg('nextStepAt'), "nextStepAt"),
+    "nextStepNote" = COALESCE(sqlc.narg('nextStepNote'), "nextStepNote"),
+    -- categoria manual ou derivada do estágio/probabilidade resultantes
+    "forecastCategoryOverridden" = COALESCE(sqlc.narg('forecastCategoryOverridden'), "forecastCategoryOverridden"),
+    "forecastCategory" = CASE
+        WHEN COALESCE(sqlc.narg('forecastCategoryOverridden'), "forecastCategoryOverridden")
+            THEN COALESCE(sqlc.narg('forecastCategory'), "forecastCategory")
+        ELSE deal_forecast_category(
+            COALESCE(sqlc.narg('stage'), stage),
+            COALESCE((SELECT s.probability FROM "PipelineStage" s WHERE s.id = COALESCE(sqlc.narg('stageId'), "stageId")),
+                     COALESCE(sqlc.narg('probability'), probability)))
+    END,
+    "rottingSince" = CASE WHEN sqlc.narg('stageId')::TEXT IS NULL THEN "rottingSince" END,
+    "externalId" = COALESCE(sqlc.narg('externalId'), "externalId"),
     "updatedAt" = CURRENT_TIMESTAMP,
     "updatedById" = sqlc.narg('updatedById')
 WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
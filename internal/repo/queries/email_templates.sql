@@ -0,0 +1,42 @@
+-- name: CreateEmailTemplate :one
+INSERT INTO "EmailTemplate" (
+    "id",
+    "workspaceId",
+    "name",
+    "subject",
+    "body",
+    "description",
+    "createdById"
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetEmailTemplate :one
+SELECT * FROM "EmailTemplate"
+WHERE "workspaceId" = $1 AND "id" = $2 AND "deletedAt" IS NULL;
+
+-- name: ListEmailTemplates :many
+SELECT * FROM "EmailTemplate"
+WHERE "workspaceId" = $1
+  AND "deletedAt" IS NULL
+  AND (sqlc.narg('query')::TEXT IS NULL OR "name" ILIKE '%' || sqlc.narg('query') || '%')
+ORDER BY "name" ASC;
+
+-- name: UpdateEmailTemplate :one
+UPDATE "EmailTemplate"
+SET
+    "name" = COALESCE(sqlc.narg('name'), "name"),
+    "subject" = COALESCE(sqlc.narg('subject'), "subject"),
+    "body" = COALESCE(sqlc.narg('body'), "body"),
+    "description" = COALESCE(sqlc.narg('description'), "description"),
+    "updatedById" = $3,
+    "updatedAt" = CURRENT_TIMESTAMP
+WHERE "workspaceId" = $1 AND "id" = $2 AND "deletedAt" IS NULL
+RETURNING *;
+
+-- name: DeleteEmailTemplate :execrows
+UPDATE "EmailTemplate"
+SET "deletedAt" = CURRENT_TIMESTAMP,
+    "updatedById" = $3
+WHERE "workspaceId" = $1 AND "id" = $2 AND "deletedAt" IS NULL;
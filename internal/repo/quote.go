@@ -0,0 +1,290 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrQuoteNotFound = apperr.NotFound("quote not found in workspace", "quote not found")
+
+	// ErrQuoteNotAcceptable o orçamento não está enviado ou já venceu (aceite pelo link)
+	ErrQuoteNotAcceptable = apperr.Unprocessable(apperr.CodeInvalidStatus, "quote can no longer be accepted", "")
+
+	// ErrQuoteNumberTaken outro orçamento pegou o número ao mesmo tempo; o service tenta o próximo
+	ErrQuoteNumberTaken = errors.New("quote number already exists")
+)
+
+// QuoteRepository persiste orçamentos e a fila de geração de PDF.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type QuoteRepository struct {
+	pool database.DB
+}
+
+func NewQuoteRepository(pool database.DB) *QuoteRepository {
+	return &QuoteRepository{pool: pool}
+}
+
+const quoteColumns = `id, "workspaceId", number, "dealId", "contactId", "companyId", "templateId", title, body,
+	currency, "lineItems", subtotal, discount, total, "validUntil", status, "sentAt", "viewedAt", "acceptedAt",
+	"acceptedByName", "pdfStatus", "pdfObjectKey", "pdfSizeBytes", "pdfError", "createdById", "createdAt", "updatedAt"`
+
+// NextNumber próximo número sequencial de orçamento do workspace.
+func (r *QuoteRepository) NextNumber(ctx context.Context, workspaceID string) (int64, error) {
+	var next int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(MAX(number), 0) + 1 FROM public."Quote" WHERE "workspaceId" = $1`,
+		workspaceID,
+	).Scan(&next)
+	if err != nil {
+		return 0, fmt.Errorf("query next quote number: %w", err)
+	}
+	return next, nil
+}
+
+// Create insere o orçamento com o PDF pendente. Retorna ErrQuoteNumberTaken se o número já existir.
+func (r *QuoteRepository) Create(ctx context.Context, q *domain.Quote) (*domain.Quote, error) {
+	lineItems, err := json.Marshal(q.LineItems)
+	if err != nil {
+		return nil, fmt.Errorf("marshal quote line items: %w", err)
+	}
+
+	query := `
+		INSERT INTO public."Quote" (
+			id, "workspaceId", number, "dealId", "contactId", "companyId", "templateId", title, body,
+			currency, "lineItems", subtotal, discount, total, "validUntil", "pdfObjectKey", "createdById", "createdAt"
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11::jsonb, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING ` + quoteColumns
+
+	created, err := scanQuote(r.pool.QueryRow(ctx, query,
+		q.ID, q.WorkspaceID, q.Number, q.DealID, q.ContactID, q.CompanyID, q.TemplateID, q.Title, q.Body,
+		q.Currency, string(lineItems), q.Subtotal, q.Discount, q.Total, q.ValidUntil, q.PDFObjectKey, q.CreatedByID, q.CreatedAt,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "Quote_workspaceId_number_key" {
+			return nil, ErrQuoteNumberTaken
+		}
+		return nil, fmt.Errorf("insert quote: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um orçamento do workspace.
+func (r *QuoteRepository) Get(ctx context.Context, workspaceID, quoteID string) (*domain.Quote, error) {
+	query := `
+		SELECT ` + quoteColumns + `
+		FROM public."Quote"
+		WHERE "workspaceId" = $1 AND id = $2`
+
+	q, err := scanQuote(r.pool.QueryRow(ctx, query, workspaceID, quoteID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrQuoteNotFound
+		}
+		return nil, fmt.Errorf("query quote: %w", err)
+	}
+	return q, nil
+}
+
+// GetByShareTokenHash retorna o orçamento do link público, sem escopo de workspace.
+func (r *QuoteRepository) GetByShareTokenHash(ctx context.Context, tokenHash []byte) (*domain.Quote, error) {
+	query := `
+		SELECT ` + quoteColumns + `
+		FROM public."Quote"
+		WHERE "shareTokenHash" = $1`
+
+	q, err := scanQuote(r.pool.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrQuoteNotFound
+		}
+		return nil, fmt.Errorf("query quote: %w", err)
+	}
+	return q, nil
+}
+
+// List retorna os orçamentos do workspace, mais recentes primeiro; dealID filtra (nil = todos).
+func (r *QuoteRepository) List(ctx context.Context, workspaceID string, dealID *string) ([]domain.Quote, error) {
+	query := `
+		SELECT ` + quoteColumns + `
+		FROM public."Quote"
+		WHERE "workspaceId" = $1
+		  AND ($2::TEXT IS NULL OR "dealId" = $2)
+		ORDER BY "createdAt" DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("query quotes: %w", err)
+	}
+	defer rows.Close()
+
+	quotes := []domain.Quote{}
+	for rows.Next() {
+		q, err := scanQuote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan quote: %w", err)
+		}
+		quotes = append(quotes, *q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate quotes: %w", err)
+	}
+
+	return quotes, nil
+}
+
+// Delete remove o orçamento; o link público passa a responder 404.
+func (r *QuoteRepository) Delete(ctx context.Context, workspaceID, quoteID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."Quote" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, quoteID)
+	if err != nil {
+		return fmt.Errorf("delete quote: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrQuoteNotFound
+	}
+	return nil
+}
+
+// MarkSent grava o hash do novo token (o link anterior deixa de valer) e volta o status para SENT.
+func (r *QuoteRepository) MarkSent(ctx context.Context, workspaceID, quoteID string, tokenHash []byte) (*domain.Quote, error) {
+	query := `
+		UPDATE public."Quote"
+		SET "shareTokenHash" = $3, status = 'SENT', "sentAt" = NOW(), "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + quoteColumns
+
+	q, err := scanQuote(r.pool.QueryRow(ctx, query, workspaceID, quoteID, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrQuoteNotFound
+		}
+		return nil, fmt.Errorf("mark quote sent: %w", err)
+	}
+	return q, nil
+}
+
+// MarkViewed registra a primeira abertura do link (SENT -> VIEWED). Retorna nil se o orçamento
+// já não estava SENT.
+func (r *QuoteRepository) MarkViewed(ctx context.Context, quoteID string) (*domain.Quote, error) {
+	query := `
+		UPDATE public."Quote"
+		SET status = 'VIEWED', "viewedAt" = COALESCE("viewedAt", NOW()), "updatedAt" = NOW()
+		WHERE id = $1 AND status = 'SENT'
+		RETURNING ` + quoteColumns
+
+	q, err := scanQuote(r.pool.QueryRow(ctx, query, quoteID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("mark quote viewed: %w", err)
+	}
+	return q, nil
+}
+
+// Accept registra o aceite pelo link. Retorna ErrQuoteNotAcceptable se o orçamento não estiver
+// SENT/VIEWED ou já tiver vencido.
+func (r *QuoteRepository) Accept(ctx context.Context, quoteID, acceptedByName string) (*domain.Quote, error) {
+	query := `
+		UPDATE public."Quote"
+		SET status = 'ACCEPTED', "acceptedAt" = NOW(), "acceptedByName" = $2,
+		    "viewedAt" = COALESCE("viewedAt", NOW()), "updatedAt" = NOW()
+		WHERE id = $1 AND status IN ('SENT', 'VIEWED')
+		  AND ("validUntil" IS NULL OR "validUntil" >= NOW())
+		RETURNING ` + quoteColumns
+
+	q, err := scanQuote(r.pool.QueryRow(ctx, query, quoteID, acceptedByName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrQuoteNotAcceptable
+		}
+		return nil, fmt.Errorf("accept quote: %w", err)
+	}
+	return q, nil
+}
+
+// ClaimNextPDF marca como RUNNING o PDF PENDING mais antigo (ou um RUNNING iniciado antes de
+// staleBefore, abandonado por um worker que caiu) e retorna o orçamento. nil quando a fila está vazia.
+func (r *QuoteRepository) ClaimNextPDF(ctx context.Context, staleBefore time.Time) (*domain.Quote, error) {
+	query := `
+		UPDATE public."Quote"
+		SET "pdfStatus" = 'RUNNING', "pdfStartedAt" = NOW(), "pdfError" = NULL
+		WHERE id = (
+			SELECT id FROM public."Quote"
+			WHERE "pdfStatus" = 'PENDING' OR ("pdfStatus" = 'RUNNING' AND "pdfStartedAt" < $1)
+			ORDER BY "createdAt"
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + quoteColumns
+
+	q, err := scanQuote(r.pool.QueryRow(ctx, query, staleBefore))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim quote pdf: %w", err)
+	}
+	return q, nil
+}
+
+// CompletePDF marca o PDF como gerado.
+func (r *QuoteRepository) CompletePDF(ctx context.Context, quoteID string, sizeBytes int64) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."Quote"
+		SET "pdfStatus" = 'COMPLETED', "pdfSizeBytes" = $2, "pdfError" = NULL
+		WHERE id = $1`,
+		quoteID, sizeBytes,
+	)
+	if err != nil {
+		return fmt.Errorf("complete quote pdf: %w", err)
+	}
+	return nil
+}
+
+// FailPDF registra a falha na geração do PDF.
+func (r *QuoteRepository) FailPDF(ctx context.Context, quoteID, message string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."Quote"
+		SET "pdfStatus" = 'FAILED', "pdfError" = $2
+		WHERE id = $1`,
+		quoteID, message,
+	)
+	if err != nil {
+		return fmt.Errorf("fail quote pdf: %w", err)
+	}
+	return nil
+}
+
+// Scanners
+func scanQuote(row pgx.Row) (*domain.Quote, error) {
+	var q domain.Quote
+	var lineItems []byte
+	err := row.Scan(
+		&q.ID, &q.WorkspaceID, &q.Number, &q.DealID, &q.ContactID, &q.CompanyID, &q.TemplateID, &q.Title, &q.Body,
+		&q.Currency, &lineItems, &q.Subtotal, &q.Discount, &q.Total, &q.ValidUntil, &q.Status, &q.SentAt, &q.ViewedAt, &q.AcceptedAt,
+		&q.AcceptedByName, &q.PDFStatus, &q.PDFObjectKey, &q.PDFSizeBytes, &q.PDFError, &q.CreatedByID, &q.CreatedAt, &q.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	q.LineItems = []domain.QuoteLineItem{}
+	if len(lineItems) > 0 {
+		if err := json.Unmarshal(lineItems, &q.LineItems); err != nil {
+			return nil, fmt.Errorf("unmarshal quote line items: %w", err)
+		}
+	}
+	return &q, nil
+}
@@ -0,0 +1,398 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+)
+
+// ReportRepository executa agregações de leitura que cruzam várias tabelas.
+// SQL manual: a dimensão de agrupamento é dinâmica (não cabe em uma query sqlc).
+type ReportRepository struct {
+	pool database.DB
+}
+
+func NewReportRepository(pool database.DB) *ReportRepository {
+	return &ReportRepository{pool: pool}
+}
+
+// attributionColumns é a whitelist de colunas agrupáveis.
+// A coluna entra no SQL via Sprintf, então nunca use o valor vindo do request diretamente.
+var attributionColumns = map[domain.AttributionGroupBy]string{
+	domain.AttributionBySource:      `"source"`,
+	domain.AttributionByUTMSource:   `"utmSource"`,
+	domain.AttributionByUTMMedium:   `"utmMedium"`,
+	domain.AttributionByUTMCampaign: `"utmCampaign"`,
+}
+
+const attributionReportSQL = `
+WITH contacts AS (
+	SELECT %[1]s AS key, COUNT(*) AS total
+	FROM "Contact"
+	WHERE "workspaceId" = $1
+	  AND "deletedAt" IS NULL
+	  AND ($2::TIMESTAMP IS NULL OR "createdAt" >= $2)
+	  AND ($3::TIMESTAMP IS NULL OR "createdAt" < $3)
+	GROUP BY 1
+), deals AS (
+	SELECT %[1]s AS key,
+	       COUNT(*) AS total,
+	       COUNT(*) FILTER (WHERE stage = 'WON') AS won,
+	       COALESCE(SUM(value) FILTER (WHERE stage = 'OPEN'), 0) AS pipeline_value,
+	       COALESCE(SUM(value) FILTER (WHERE stage = 'WON'), 0) AS won_value
+	FROM "Deal"
+	WHERE "workspaceId" = $1
+	  AND "deletedAt" IS NULL
+	  AND ($2::TIMESTAMP IS NULL OR "createdAt" >= $2)
+	  AND ($3::TIMESTAMP IS NULL OR "createdAt" < $3)
+	GROUP BY 1
+)
+SELECT
+	COALESCE(c.key, d.key),
+	COALESCE(c.total, 0),
+	COALESCE(d.total, 0),
+	COALESCE(d.won, 0),
+	COALESCE(d.pipeline_value, 0)::DOUBLE PRECISION,
+	COALESCE(d.won_value, 0)::DOUBLE PRECISION
+FROM contacts c
+FULL OUTER JOIN deals d ON c.key IS NOT DISTINCT FROM d.key
+ORDER BY 6 DESC, 5 DESC, 2 DESC`
+
+// Attribution agrega contatos e negócios por canal de origem.
+func (r *ReportRepository) Attribution(ctx context.Context, params domain.AttributionReportParams) ([]domain.AttributionRow, error) {
+	column, ok := attributionColumns[params.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported attribution groupBy: %q", params.GroupBy)
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(attributionReportSQL, column), params.WorkspaceID, params.From, params.To)
+	if err != nil {
+		return nil, fmt.Errorf("query attribution report: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.AttributionRow{}
+	for rows.Next() {
+		var row domain.AttributionRow
+		if err := rows.Scan(&row.Key, &row.Contacts, &row.Deals, &row.WonDeals, &row.PipelineValue, &row.WonValue); err != nil {
+			return nil, fmt.Errorf("scan attribution row: %w", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate attribution rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// overdueReportLimit limita o relatório de atrasados (ordenado do mais antigo).
+const overdueReportLimit = 1000
+
+const overdueReportSQL = `
+SELECT type, id, name, "ownerId", "nextStepAt", "nextStepNote"
+FROM (
+	SELECT 'contact' AS type, id, "fullName" AS name, "ownerId", "nextStepAt", "nextStepNote"
+	FROM "Contact"
+	WHERE "workspaceId" = $1
+	  AND "deletedAt" IS NULL
+	  AND "nextStepAt" < $2
+	UNION ALL
+	SELECT 'deal' AS type, id, name, "ownerId", "nextStepAt", "nextStepNote"
+	FROM "Deal"
+	WHERE "workspaceId" = $1
+	  AND "deletedAt" IS NULL
+	  AND stage = 'OPEN'
+	  AND "nextStepAt" < $2
+) overdue
+WHERE ($3::TEXT IS NULL OR "ownerId" = $3)
+  AND ($4::TEXT IS NULL OR type = $4)
+ORDER BY "nextStepAt" ASC, id
+LIMIT $5`
+
+// Overdue lista contatos e negócios OPEN com próximo passo anterior a params.AsOf.
+func (r *ReportRepository) Overdue(ctx context.Context, params domain.OverdueReportParams) ([]domain.OverdueItem, error) {
+	var recordType *string
+	if params.Type != nil {
+		t := string(*params.Type)
+		recordType = &t
+	}
+
+	rows, err := r.pool.Query(ctx, overdueReportSQL, params.WorkspaceID, params.AsOf, params.OwnerID, recordType, overdueReportLimit)
+	if err != nil {
+		return nil, fmt.Errorf("query overdue report: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.OverdueItem{}
+	for rows.Next() {
+		var item domain.OverdueItem
+		var typ string
+		if err := rows.Scan(&typ, &item.ID, &item.Name, &item.OwnerID, &item.NextStepAt, &item.NextStepNote); err != nil {
+			return nil, fmt.Errorf("scan overdue row: %w", err)
+		}
+		item.Type = domain.OverdueRecordType(typ)
+		result = append(result, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate overdue rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// timeReportColumns é a whitelist de dimensões de /reports/time (entra no SQL via Sprintf).
+var timeReportColumns = map[domain.TimeReportGroupBy]string{
+	domain.TimeByUser: `"userId"`,
+	domain.TimeByDeal: `"dealId"`,
+	domain.TimeByTask: `"taskId"`,
+}
+
+const timeReportSQL = `
+SELECT %s AS key,
+       COUNT(*),
+       COALESCE(SUM("durationSeconds"), 0)::BIGINT,
+       COALESCE(SUM("durationSeconds") FILTER (WHERE billable), 0)::BIGINT
+FROM "TimeEntry"
+WHERE "workspaceId" = $1
+  AND "deletedAt" IS NULL
+  AND "endedAt" IS NOT NULL
+  AND ($2::TEXT IS NULL OR "userId" = $2)
+  AND ($3::TEXT IS NULL OR "dealId" = $3)
+  AND ($4::BOOLEAN IS NULL OR billable = $4)
+  AND ($5::TIMESTAMP IS NULL OR "startedAt" >= $5)
+  AND ($6::TIMESTAMP IS NULL OR "startedAt" < $6)
+GROUP BY 1
+ORDER BY 3 DESC, 1`
+
+// Time soma as horas apontadas (timers encerrados) pela dimensão escolhida.
+func (r *ReportRepository) Time(ctx context.Context, params domain.TimeReportParams) ([]domain.TimeReportRow, error) {
+	column, ok := timeReportColumns[params.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported time report groupBy: %q", params.GroupBy)
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(timeReportSQL, column),
+		params.WorkspaceID, params.UserID, params.DealID, params.Billable, params.From, params.To,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query time report: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.TimeReportRow{}
+	for rows.Next() {
+		var row domain.TimeReportRow
+		if err := rows.Scan(&row.Key, &row.Entries, &row.TotalSeconds, &row.BillableSeconds); err != nil {
+			return nil, fmt.Errorf("scan time report row: %w", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate time report rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// dealCreditSQL distribui o crédito de cada deal "d" entre os donos: uma linha por DealSplit
+// (share = percentage / 100) ou, sem splits, o ownerId com share 1. Somar value × share por dono
+// credita SDR e AE pela mesma venda sem duplicar o total; contagens usam COUNT(DISTINCT d.id).
+const dealCreditSQL = `CROSS JOIN LATERAL (
+    SELECT ds."userId" AS "ownerId", ds.percentage / 100.0 AS share
+    FROM "DealSplit" ds
+    WHERE ds."dealId" = d.id
+    UNION ALL
+    SELECT d."ownerId", 1.0::DOUBLE PRECISION
+    WHERE NOT EXISTS (SELECT 1 FROM "DealSplit" ds WHERE ds."dealId" = d.id)
+) c`
+
+const pipelineSummarySQL = `
+SELECT p.id, p.name, s.id, s.name,
+       COUNT(DISTINCT d.id) FILTER (WHERE d.stage = 'OPEN'),
+       COALESCE(SUM(d.value) FILTER (WHERE d.stage = 'OPEN'), 0)::DOUBLE PRECISION,
+       COUNT(DISTINCT d.id) FILTER (WHERE d.stage = 'WON' AND d."closedAt" >= $3),
+       COALESCE(SUM(d.value) FILTER (WHERE d.stage = 'WON' AND d."closedAt" >= $3), 0)::DOUBLE PRECISION,
+       COUNT(DISTINCT d.id) FILTER (WHERE d.stage = 'LOST' AND d."closedAt" >= $3)
+FROM "Pipeline" p
+JOIN "PipelineStage" s ON s."pipelineId" = p.id
+LEFT JOIN (
+    SELECT d.id, d."stageId", d.stage, d."closedAt", d.value * c.share AS value
+    FROM "Deal" d
+    ` + dealCreditSQL + `
+    WHERE d."workspaceId" = $1
+      AND d."deletedAt" IS NULL
+      AND ($4::TEXT IS NULL OR c."ownerId" = $4)
+) d ON d."stageId" = s.id
+WHERE p."workspaceId" = $1
+  AND ($2::TEXT IS NULL OR p.id = $2)
+GROUP BY p.id, p.name, s.id, s.name, s."orderIndex"
+ORDER BY p.name, p.id, s."orderIndex"`
+
+// PipelineSummary totaliza negócios por etapa de cada pipeline. Etapas sem negócios aparecem zeradas.
+// Com ownerId, os valores são a parte do dono nos splits.
+func (r *ReportRepository) PipelineSummary(ctx context.Context, params domain.PipelineSummaryParams) ([]domain.PipelineSummaryRow, error) {
+	rows, err := r.pool.Query(ctx, pipelineSummarySQL, params.WorkspaceID, params.PipelineID, params.Since, params.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("query pipeline summary: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.PipelineSummaryRow{}
+	for rows.Next() {
+		var row domain.PipelineSummaryRow
+		if err := rows.Scan(&row.PipelineID, &row.PipelineName, &row.StageID, &row.StageName,
+			&row.OpenDeals, &row.OpenValue, &row.WonDeals, &row.WonValue, &row.LostDeals); err != nil {
+			return nil, fmt.Errorf("scan pipeline summary row: %w", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pipeline summary rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// revenueReportKeys é a whitelist de dimensões de /reports/revenue (entra no SQL via Sprintf):
+// chave dos negócios ganhos (closedAt) e das faturas pagas (paidAt). Por dono, cada dono dos splits recebe
+// a sua parte (dealCreditSQL); negócios sem dono usam a string vazia para o FULL JOIN casar as duas metades.
+// Os meses usam o fuso de $4 (colunas gravadas em UTC).
+var revenueReportKeys = map[domain.RevenueReportGroupBy][2]string{
+	domain.RevenueByMonth: {`to_char(d."closedAt" AT TIME ZONE 'UTC' AT TIME ZONE $4::TEXT, 'YYYY-MM')`, `to_char(i."paidAt" AT TIME ZONE 'UTC' AT TIME ZONE $4::TEXT, 'YYYY-MM')`},
+	domain.RevenueByOwner: {`COALESCE(c."ownerId", '')`, `COALESCE(c."ownerId", '')`},
+}
+
+const revenueReportSQL = `
+WITH booked AS (
+    SELECT %s AS key, COUNT(DISTINCT d.id) AS deals, COALESCE(SUM(d.value * c.share), 0) AS amount
+    FROM "Deal" d
+    ` + dealCreditSQL + `
+    WHERE d."workspaceId" = $1
+      AND d."deletedAt" IS NULL
+      AND d.stage = 'WON'
+      AND d."closedAt" IS NOT NULL
+      AND ($2::TIMESTAMP IS NULL OR d."closedAt" >= $2)
+      AND ($3::TIMESTAMP IS NULL OR d."closedAt" < $3)
+    GROUP BY 1
+), collected AS (
+    SELECT %s AS key, COALESCE(SUM(i."amountPaid" * c.share), 0) AS amount
+    FROM "Invoice" i
+    JOIN "Deal" d ON d.id = i."dealId" AND d."deletedAt" IS NULL
+    ` + dealCreditSQL + `
+    WHERE i."workspaceId" = $1
+      AND i.status = 'PAID'
+      AND i."paidAt" IS NOT NULL
+      AND ($2::TIMESTAMP IS NULL OR i."paidAt" >= $2)
+      AND ($3::TIMESTAMP IS NULL OR i."paidAt" < $3)
+    GROUP BY 1
+)
+SELECT NULLIF(COALESCE(b.key, c.key), ''),
+       COALESCE(b.deals, 0),
+       COALESCE(b.amount, 0)::DOUBLE PRECISION,
+       COALESCE(c.amount, 0)::DOUBLE PRECISION
+FROM booked b
+FULL OUTER JOIN collected c ON c.key = b.key
+ORDER BY %s`
+
+// Revenue soma a receita booked (negócios ganhos) e collected (faturas pagas) pela dimensão escolhida.
+func (r *ReportRepository) Revenue(ctx context.Context, params domain.RevenueReportParams) ([]domain.RevenueReportRow, error) {
+	keys, ok := revenueReportKeys[params.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported revenue report groupBy: %q", params.GroupBy)
+	}
+	order := "1"
+	if params.GroupBy != domain.RevenueByMonth {
+		order = "3 DESC, 1"
+	}
+
+	args := []any{params.WorkspaceID, params.From, params.To}
+	if params.GroupBy == domain.RevenueByMonth {
+		args = append(args, domain.LocationName(params.Location))
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(revenueReportSQL, keys[0], keys[1], order), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query revenue report: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.RevenueReportRow{}
+	for rows.Next() {
+		var row domain.RevenueReportRow
+		if err := rows.Scan(&row.Key, &row.WonDeals, &row.Booked, &row.Collected); err != nil {
+			return nil, fmt.Errorf("scan revenue report row: %w", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate revenue report rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// OutstandingRevenue saldo em aberto das faturas OPEN de negócios ativos.
+func (r *ReportRepository) OutstandingRevenue(ctx context.Context, workspaceID string) (float64, error) {
+	var outstanding float64
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(GREATEST(i.amount - i."amountPaid", 0)), 0)::DOUBLE PRECISION
+		FROM "Invoice" i
+		JOIN "Deal" d ON d.id = i."dealId" AND d."deletedAt" IS NULL
+		WHERE i."workspaceId" = $1 AND i.status = 'OPEN'`,
+		workspaceID,
+	).Scan(&outstanding)
+	if err != nil {
+		return 0, fmt.Errorf("query outstanding revenue: %w", err)
+	}
+	return outstanding, nil
+}
+
+// forecastReportSQL totaliza os deals por categoria de forecast. A data de fechamento é closedAt dos
+// deals ganhos/perdidos e expectedCloseDate dos em aberto. WeightedValue pondera pela probabilidade do
+// deal (ganhos = 100%, perdidos = 0%), para comparar com a previsão por categoria. Com ownerId, os
+// valores são a parte do dono nos splits.
+const forecastReportSQL = `
+SELECT d."forecastCategory",
+       COUNT(DISTINCT d.id),
+       COALESCE(SUM(d.value * c.share), 0)::DOUBLE PRECISION,
+       COALESCE(SUM(d.value * c.share * CASE d.stage
+           WHEN 'WON' THEN 100
+           WHEN 'LOST' THEN 0
+           ELSE COALESCE(d.probability, s.probability, 0)
+       END / 100.0), 0)::DOUBLE PRECISION
+FROM "Deal" d
+LEFT JOIN "PipelineStage" s ON s.id = d."stageId"
+` + dealCreditSQL + `
+WHERE d."workspaceId" = $1
+  AND d."deletedAt" IS NULL
+  AND ($2::TEXT IS NULL OR d."pipelineId" = $2)
+  AND ($3::TEXT IS NULL OR c."ownerId" = $3)
+  AND ($4::TIMESTAMP IS NULL OR CASE WHEN d.stage = 'OPEN' THEN d."expectedCloseDate" ELSE d."closedAt" END >= $4)
+  AND ($5::TIMESTAMP IS NULL OR CASE WHEN d.stage = 'OPEN' THEN d."expectedCloseDate" ELSE d."closedAt" END < $5)
+GROUP BY 1`
+
+// Forecast soma os deals de cada categoria de forecast no período.
+func (r *ReportRepository) Forecast(ctx context.Context, params domain.ForecastReportParams) ([]domain.ForecastReportRow, error) {
+	rows, err := r.pool.Query(ctx, forecastReportSQL, params.WorkspaceID, params.PipelineID, params.OwnerID, params.From, params.To)
+	if err != nil {
+		return nil, fmt.Errorf("query forecast report: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.ForecastReportRow{}
+	for rows.Next() {
+		var row domain.ForecastReportRow
+		if err := rows.Scan(&row.Category, &row.Deals, &row.Value, &row.WeightedValue); err != nil {
+			return nil, fmt.Errorf("scan forecast report row: %w", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate forecast report rows: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,218 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrReportScheduleNotFound = apperr.NotFound("report schedule not found in workspace", "report schedule not found")
+
+// ReportScheduleRepository persiste os agendamentos de relatórios e a fila do worker.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type ReportScheduleRepository struct {
+	pool database.DB
+}
+
+func NewReportScheduleRepository(pool database.DB) *ReportScheduleRepository {
+	return &ReportScheduleRepository{pool: pool}
+}
+
+const reportScheduleColumns = `id, "workspaceId", name, "reportType", filters, format, recipients,
+	"webhookUrl", "webhookSecret", cron, timezone, enabled, "nextRunAt", "lastRunAt", "lastStatus",
+	"lastError", "createdById", "updatedById", "createdAt", "updatedAt"`
+
+// Create insere o agendamento (nextRunAt já calculado pelo service).
+func (r *ReportScheduleRepository) Create(ctx context.Context, s *domain.ReportSchedule) (*domain.ReportSchedule, error) {
+	filters, err := json.Marshal(s.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("marshal report filters: %w", err)
+	}
+
+	query := `
+		INSERT INTO public."ReportSchedule" (
+			id, "workspaceId", name, "reportType", filters, format, recipients, "webhookUrl",
+			"webhookSecret", cron, timezone, enabled, "nextRunAt", "createdById"
+		)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING ` + reportScheduleColumns
+
+	created, err := scanReportSchedule(r.pool.QueryRow(ctx, query,
+		s.ID, s.WorkspaceID, s.Name, s.ReportType, string(filters), s.Format, s.Recipients, s.WebhookURL,
+		s.WebhookSecret, s.Cron, s.Timezone, s.Enabled, s.NextRunAt, s.CreatedByID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert report schedule: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um agendamento do workspace.
+func (r *ReportScheduleRepository) Get(ctx context.Context, workspaceID, scheduleID string) (*domain.ReportSchedule, error) {
+	query := `
+		SELECT ` + reportScheduleColumns + `
+		FROM public."ReportSchedule"
+		WHERE "workspaceId" = $1 AND id = $2`
+
+	s, err := scanReportSchedule(r.pool.QueryRow(ctx, query, workspaceID, scheduleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReportScheduleNotFound
+		}
+		return nil, fmt.Errorf("query report schedule: %w", err)
+	}
+	return s, nil
+}
+
+// List retorna os agendamentos do workspace, mais recentes primeiro.
+func (r *ReportScheduleRepository) List(ctx context.Context, workspaceID string) ([]domain.ReportSchedule, error) {
+	query := `
+		SELECT ` + reportScheduleColumns + `
+		FROM public."ReportSchedule"
+		WHERE "workspaceId" = $1
+		ORDER BY "createdAt" DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []domain.ReportSchedule{}
+	for rows.Next() {
+		s, err := scanReportSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan report schedule: %w", err)
+		}
+		schedules = append(schedules, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate report schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// Update grava o agendamento já mesclado pelo service.
+func (r *ReportScheduleRepository) Update(ctx context.Context, s *domain.ReportSchedule, actorID string) (*domain.ReportSchedule, error) {
+	filters, err := json.Marshal(s.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("marshal report filters: %w", err)
+	}
+
+	query := `
+		UPDATE public."ReportSchedule" SET
+			name = $3,
+			filters = $4::jsonb,
+			format = $5,
+			recipients = $6,
+			"webhookUrl" = $7,
+			"webhookSecret" = $8,
+			cron = $9,
+			timezone = $10,
+			enabled = $11,
+			"nextRunAt" = $12,
+			"updatedById" = $13,
+			"updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + reportScheduleColumns
+
+	updated, err := scanReportSchedule(r.pool.QueryRow(ctx, query,
+		s.WorkspaceID, s.ID, s.Name, string(filters), s.Format, s.Recipients, s.WebhookURL,
+		s.WebhookSecret, s.Cron, s.Timezone, s.Enabled, s.NextRunAt, actorID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReportScheduleNotFound
+		}
+		return nil, fmt.Errorf("update report schedule: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete remove o agendamento.
+func (r *ReportScheduleRepository) Delete(ctx context.Context, workspaceID, scheduleID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."ReportSchedule" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, scheduleID)
+	if err != nil {
+		return fmt.Errorf("delete report schedule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrReportScheduleNotFound
+	}
+	return nil
+}
+
+// ClaimDue reserva o agendamento ativo mais atrasado (nextRunAt <= now), empurrando nextRunAt
+// para leaseUntil: se o worker cair, o agendamento volta à fila depois do lease. nil quando
+// não há nada a executar. SKIP LOCKED permite vários workers sem disputar o mesmo agendamento.
+func (r *ReportScheduleRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time) (*domain.ReportSchedule, error) {
+	query := `
+		UPDATE public."ReportSchedule"
+		SET "nextRunAt" = $2
+		WHERE id = (
+			SELECT id FROM public."ReportSchedule"
+			WHERE enabled = true AND "nextRunAt" <= $1
+			ORDER BY "nextRunAt"
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + reportScheduleColumns
+
+	s, err := scanReportSchedule(r.pool.QueryRow(ctx, query, now, leaseUntil))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim report schedule: %w", err)
+	}
+	return s, nil
+}
+
+// Finish registra o resultado da execução e agenda a próxima. Se o agendamento foi editado
+// durante a execução (nextRunAt diferente do lease), mantém o nextRunAt da edição.
+func (r *ReportScheduleRepository) Finish(ctx context.Context, scheduleID string, leaseUntil time.Time, nextRunAt *time.Time, status domain.ReportRunStatus, runErr *string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."ReportSchedule"
+		SET "lastRunAt" = NOW(),
+		    "lastStatus" = $3,
+		    "lastError" = $4,
+		    "nextRunAt" = CASE WHEN "nextRunAt" = $2 THEN $5 ELSE "nextRunAt" END
+		WHERE id = $1`,
+		scheduleID, leaseUntil, status, runErr, nextRunAt,
+	)
+	if err != nil {
+		return fmt.Errorf("finish report schedule: %w", err)
+	}
+	return nil
+}
+
+// Scanners
+func scanReportSchedule(row pgx.Row) (*domain.ReportSchedule, error) {
+	var s domain.ReportSchedule
+	var filters []byte
+	err := row.Scan(
+		&s.ID, &s.WorkspaceID, &s.Name, &s.ReportType, &filters, &s.Format, &s.Recipients,
+		&s.WebhookURL, &s.WebhookSecret, &s.Cron, &s.Timezone, &s.Enabled, &s.NextRunAt, &s.LastRunAt,
+		&s.LastStatus, &s.LastError, &s.CreatedByID, &s.UpdatedByID, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) > 0 {
+		if err := json.Unmarshal(filters, &s.Filters); err != nil {
+			return nil, fmt.Errorf("decode report filters: %w", err)
+		}
+	}
+	if s.Recipients == nil {
+		s.Recipients = []string{}
+	}
+	return &s, nil
+}
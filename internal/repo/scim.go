@@ -0,0 +1,812 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrScimTokenInvalid token ausente, revogado ou de outro workspace
+	ErrScimTokenInvalid = apperr.Define("INVALID_TOKEN", http.StatusUnauthorized, "scim token not found or revoked", "invalid SCIM token")
+
+	ErrScimUserNotFound  = apperr.NotFound("scim user not found in workspace", "user not found")
+	ErrScimGroupNotFound = apperr.NotFound("scim group not found in workspace", "group not found")
+
+	// ErrScimUserConflict userName já provisionado, ou o usuário (email) já está vinculado ao SCIM do workspace
+	ErrScimUserConflict = apperr.Conflict("scim user with this userName or email already exists in workspace", "a user with this userName already exists")
+	// ErrScimGroupConflict displayName já usado por outro grupo do workspace
+	ErrScimGroupConflict = apperr.Conflict("scim group with this displayName already exists in workspace", "a group with this displayName already exists")
+	// ErrScimGroupMemberNotFound membro do grupo que não é um usuário provisionado no workspace
+	ErrScimGroupMemberNotFound = apperr.Unprocessable(apperr.CodeValidationError, "scim group member is not a provisioned user of the workspace", "group members must be users provisioned in this workspace")
+)
+
+// ScimRepository persiste a configuração SCIM, os usuários e grupos provisionados e sincroniza
+// "WorkspaceMember" com o estado do diretório.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type ScimRepository struct {
+	pool database.DB
+}
+
+func NewScimRepository(pool database.DB) *ScimRepository {
+	return &ScimRepository{pool: pool}
+}
+
+// =====================================================
+// Configuração e token
+// =====================================================
+
+const scimConfigColumns = `"workspaceId", "tokenHash" IS NOT NULL, "defaultRole", "tokenCreatedAt", "tokenLastUsedAt", "updatedAt"`
+
+// GetConfig retorna a configuração SCIM (desativada com work_user quando nunca configurada).
+func (r *ScimRepository) GetConfig(ctx context.Context, workspaceID string) (*domain.ScimConfig, error) {
+	query := `SELECT ` + scimConfigColumns + ` FROM public."ScimConfig" WHERE "workspaceId" = $1`
+
+	cfg, err := scanScimConfig(r.pool.QueryRow(ctx, query, workspaceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.ScimConfig{WorkspaceID: workspaceID, DefaultRole: domain.RoleUser}, nil
+		}
+		return nil, fmt.Errorf("query scim config: %w", err)
+	}
+	return cfg, nil
+}
+
+// RotateToken grava o hash do novo token (o anterior deixa de valer) e o defaultRole, se informado.
+func (r *ScimRepository) RotateToken(ctx context.Context, workspaceID, actorID string, tokenHash []byte, defaultRole *domain.Role) (*domain.ScimConfig, error) {
+	query := `
+		INSERT INTO public."ScimConfig" ("workspaceId", "tokenHash", "defaultRole", "tokenCreatedAt", "createdById")
+		VALUES ($1, $2, COALESCE($3::TEXT, 'work_user'), NOW(), $4)
+		ON CONFLICT ("workspaceId") DO UPDATE
+		SET "tokenHash" = EXCLUDED."tokenHash",
+		    "defaultRole" = COALESCE($3::TEXT, "ScimConfig"."defaultRole"),
+		    "tokenCreatedAt" = NOW(), "tokenLastUsedAt" = NULL, "updatedAt" = NOW()
+		RETURNING ` + scimConfigColumns
+
+	cfg, err := scanScimConfig(r.pool.QueryRow(ctx, query, workspaceID, tokenHash, defaultRole, actorID))
+	if err != nil {
+		return nil, fmt.Errorf("rotate scim token: %w", err)
+	}
+	return cfg, nil
+}
+
+// RevokeToken desativa o provisionamento (os membros já provisionados ficam).
+func (r *ScimRepository) RevokeToken(ctx context.Context, workspaceID string) error {
+	query := `
+		UPDATE public."ScimConfig"
+		SET "tokenHash" = NULL, "tokenCreatedAt" = NULL, "tokenLastUsedAt" = NULL, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1`
+
+	if _, err := r.pool.Exec(ctx, query, workspaceID); err != nil {
+		return fmt.Errorf("revoke scim token: %w", err)
+	}
+	return nil
+}
+
+// ResolveToken retorna o workspace do token e registra o uso (no máximo uma escrita por minuto).
+func (r *ScimRepository) ResolveToken(ctx context.Context, tokenHash []byte) (string, error) {
+	query := `
+		UPDATE public."ScimConfig"
+		SET "tokenLastUsedAt" = CASE
+		        WHEN "tokenLastUsedAt" IS NULL OR "tokenLastUsedAt" < NOW() - INTERVAL '1 minute' THEN NOW()
+		        ELSE "tokenLastUsedAt"
+		    END
+		WHERE "tokenHash" = $1
+		RETURNING "workspaceId"`
+
+	var workspaceID string
+	if err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&workspaceID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrScimTokenInvalid
+		}
+		return "", fmt.Errorf("resolve scim token: %w", err)
+	}
+	return workspaceID, nil
+}
+
+// =====================================================
+// Usuários
+// =====================================================
+
+const scimUserColumns = `u."workspaceId", u."userId", u."userName", u."externalId", u."givenName", u."familyName",
+	u."displayName", u.email, u.active, u."createdAt", u."updatedAt"`
+
+// scimUserFilters colunas dos atributos filtráveis em GET /scim/v2/Users
+var scimUserFilters = map[string]string{
+	"userName":     `lower(u."userName") = lower($2)`,
+	"externalId":   `u."externalId" = $2`,
+	"emails.value": `u.email = lower($2)`,
+}
+
+// ListUsers retorna a página de usuários provisionados e o total.
+func (r *ScimRepository) ListUsers(ctx context.Context, workspaceID string, params domain.ScimListParams) ([]domain.ScimUser, int, error) {
+	where, args := `u."workspaceId" = $1`, []interface{}{workspaceID}
+	if params.Filter != nil {
+		cond, ok := scimUserFilters[params.Filter.Attribute]
+		if !ok {
+			return nil, 0, fmt.Errorf("unsupported scim user filter %q", params.Filter.Attribute)
+		}
+		where += ` AND ` + cond
+		args = append(args, params.Filter.Value)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM public."ScimUser" u WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count scim users: %w", err)
+	}
+
+	n := len(args)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM public."ScimUser" u
+		WHERE %s
+		ORDER BY u."createdAt", u."userId"
+		OFFSET $%d LIMIT $%d`, scimUserColumns, where, n+1, n+2)
+
+	rows, err := r.pool.Query(ctx, query, append(args, params.StartIndex-1, params.Count)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query scim users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []domain.ScimUser{}
+	for rows.Next() {
+		u, err := scanScimUser(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan scim user: %w", err)
+		}
+		users = append(users, *u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate scim users: %w", err)
+	}
+
+	if err := r.loadUserGroups(ctx, workspaceID, users); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// GetUser retorna o usuário provisionado com seus grupos.
+func (r *ScimRepository) GetUser(ctx context.Context, workspaceID, userID string) (*domain.ScimUser, error) {
+	query := `SELECT ` + scimUserColumns + ` FROM public."ScimUser" u WHERE u."workspaceId" = $1 AND u."userId" = $2`
+
+	u, err := scanScimUser(r.pool.QueryRow(ctx, query, workspaceID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScimUserNotFound
+		}
+		return nil, fmt.Errorf("query scim user: %w", err)
+	}
+
+	users := []domain.ScimUser{*u}
+	if err := r.loadUserGroups(ctx, workspaceID, users); err != nil {
+		return nil, err
+	}
+	return &users[0], nil
+}
+
+// FindUserByEmail retorna o id do "User" ativo com o email (vazio se nenhum).
+func (r *ScimRepository) FindUserByEmail(ctx context.Context, email string) (string, error) {
+	query := `
+		SELECT id FROM public."User"
+		WHERE lower(email) = lower($1) AND "deletedAt" IS NULL
+		ORDER BY "createdAt"
+		LIMIT 1`
+
+	var id string
+	if err := r.pool.QueryRow(ctx, query, email).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("query user by email: %w", err)
+	}
+	return id, nil
+}
+
+// CreateUser vincula o usuário ao SCIM do workspace e sincroniza a associação. Sem existingUserID,
+// cria o "User" com newUserID.
+func (r *ScimRepository) CreateUser(ctx context.Context, workspaceID, existingUserID, newUserID string, in *domain.ScimUserInput) (string, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	userID := existingUserID
+	if userID == "" {
+		userID = newUserID
+		_, err := tx.Exec(ctx, `
+			INSERT INTO public."User" (id, name, email, "updatedAt")
+			VALUES ($1, $2, $3, NOW())`, userID, in.FullName(), in.Email)
+		if err != nil {
+			return "", fmt.Errorf("insert user: %w", err)
+		}
+	} else {
+		// Usuário existente sem nome recebe o do diretório
+		_, err := tx.Exec(ctx, `
+			UPDATE public."User" SET name = $2, "updatedAt" = NOW()
+			WHERE id = $1 AND name IS NULL AND $2::TEXT IS NOT NULL`, userID, in.FullName())
+		if err != nil {
+			return "", fmt.Errorf("update user name: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO public."ScimUser" (
+			"workspaceId", "userId", "userName", "externalId", "givenName", "familyName", "displayName", email, active
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		workspaceID, userID, in.UserName, in.ExternalID, in.GivenName, in.FamilyName, in.DisplayName, in.Email, in.Active,
+	)
+	if err != nil {
+		if isScimUniqueViolation(err) {
+			return "", ErrScimUserConflict
+		}
+		return "", fmt.Errorf("insert scim user: %w", err)
+	}
+
+	if err := syncScimMembers(ctx, tx, workspaceID, []string{userID}); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("commit transaction: %w", err)
+	}
+	return userID, nil
+}
+
+// UpdateUser grava os atributos do diretório e sincroniza a associação (active = false remove o membro).
+func (r *ScimRepository) UpdateUser(ctx context.Context, workspaceID, userID string, in *domain.ScimUserInput) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE public."ScimUser"
+		SET "userName" = $3, "externalId" = $4, "givenName" = $5, "familyName" = $6, "displayName" = $7,
+		    email = $8, active = $9, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND "userId" = $2`,
+		workspaceID, userID, in.UserName, in.ExternalID, in.GivenName, in.FamilyName, in.DisplayName, in.Email, in.Active,
+	)
+	if err != nil {
+		if isScimUniqueViolation(err) {
+			return ErrScimUserConflict
+		}
+		return fmt.Errorf("update scim user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrScimUserNotFound
+	}
+
+	if err := syncScimMembers(ctx, tx, workspaceID, []string{userID}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteUser desvincula o usuário do SCIM e remove a associação ao workspace (o "User" fica).
+func (r *ScimRepository) DeleteUser(ctx context.Context, workspaceID, userID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM public."ScimUser" WHERE "workspaceId" = $1 AND "userId" = $2`, workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("delete scim user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrScimUserNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM public."WorkspaceMember" m
+		WHERE m."workspaceId" = $1 AND m."userId" = $2
+		  AND m."userId" <> (SELECT "ownerId" FROM public."Workspace" WHERE id = $1)`, workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("delete workspace member: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// loadUserGroups preenche os grupos de cada usuário.
+func (r *ScimRepository) loadUserGroups(ctx context.Context, workspaceID string, users []domain.ScimUser) error {
+	if len(users) == 0 {
+		return nil
+	}
+	ids := make([]string, len(users))
+	index := make(map[string]int, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+		index[u.UserID] = i
+	}
+
+	query := `
+		SELECT gm."userId", g.id, g."displayName"
+		FROM public."ScimGroupMember" gm
+		JOIN public."ScimGroup" g ON g.id = gm."groupId"
+		WHERE gm."workspaceId" = $1 AND gm."userId" = ANY($2)
+		ORDER BY g."displayName", g.id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, ids)
+	if err != nil {
+		return fmt.Errorf("query scim user groups: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var ref domain.ScimMemberRef
+		if err := rows.Scan(&userID, &ref.Value, &ref.Display); err != nil {
+			return fmt.Errorf("scan scim user group: %w", err)
+		}
+		i := index[userID]
+		users[i].Groups = append(users[i].Groups, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate scim user groups: %w", err)
+	}
+	return nil
+}
+
+// =====================================================
+// Grupos
+// =====================================================
+
+const scimGroupColumns = `g.id, g."workspaceId", g."displayName", g."externalId", g.role, g."createdAt", g."updatedAt"`
+
+// scimGroupFilters colunas dos atributos filtráveis em GET /scim/v2/Groups
+var scimGroupFilters = map[string]string{
+	"displayName": `lower(g."displayName") = lower($2)`,
+	"externalId":  `g."externalId" = $2`,
+}
+
+// ListGroups retorna a página de grupos e o total. withMembers = false omite os membros
+// (excludedAttributes=members, usado pelo Okta e pelo Azure AD em grupos grandes).
+func (r *ScimRepository) ListGroups(ctx context.Context, workspaceID string, params domain.ScimListParams, withMembers bool) ([]domain.ScimGroup, int, error) {
+	where, args := `g."workspaceId" = $1`, []interface{}{workspaceID}
+	if params.Filter != nil {
+		cond, ok := scimGroupFilters[params.Filter.Attribute]
+		if !ok {
+			return nil, 0, fmt.Errorf("unsupported scim group filter %q", params.Filter.Attribute)
+		}
+		where += ` AND ` + cond
+		args = append(args, params.Filter.Value)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM public."ScimGroup" g WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count scim groups: %w", err)
+	}
+
+	n := len(args)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM public."ScimGroup" g
+		WHERE %s
+		ORDER BY g."createdAt", g.id
+		OFFSET $%d LIMIT $%d`, scimGroupColumns, where, n+1, n+2)
+
+	rows, err := r.pool.Query(ctx, query, append(args, params.StartIndex-1, params.Count)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query scim groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []domain.ScimGroup{}
+	for rows.Next() {
+		g, err := scanScimGroup(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan scim group: %w", err)
+		}
+		groups = append(groups, *g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate scim groups: %w", err)
+	}
+
+	if withMembers {
+		if err := r.loadGroupMembers(ctx, workspaceID, groups); err != nil {
+			return nil, 0, err
+		}
+	}
+	return groups, total, nil
+}
+
+// GetGroup retorna o grupo com seus membros.
+func (r *ScimRepository) GetGroup(ctx context.Context, workspaceID, groupID string) (*domain.ScimGroup, error) {
+	query := `SELECT ` + scimGroupColumns + ` FROM public."ScimGroup" g WHERE g."workspaceId" = $1 AND g.id = $2`
+
+	g, err := scanScimGroup(r.pool.QueryRow(ctx, query, workspaceID, groupID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScimGroupNotFound
+		}
+		return nil, fmt.Errorf("query scim group: %w", err)
+	}
+
+	groups := []domain.ScimGroup{*g}
+	if err := r.loadGroupMembers(ctx, workspaceID, groups); err != nil {
+		return nil, err
+	}
+	return &groups[0], nil
+}
+
+// CreateGroup insere o grupo e seus membros.
+func (r *ScimRepository) CreateGroup(ctx context.Context, workspaceID, groupID string, in *domain.ScimGroupInput) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO public."ScimGroup" (id, "workspaceId", "displayName", "externalId")
+		VALUES ($1, $2, $3, $4)`, groupID, workspaceID, in.DisplayName, in.ExternalID)
+	if err != nil {
+		if isScimUniqueViolation(err) {
+			return ErrScimGroupConflict
+		}
+		return fmt.Errorf("insert scim group: %w", err)
+	}
+
+	if err := addScimGroupMembers(ctx, tx, workspaceID, groupID, in.MemberIDs); err != nil {
+		return err
+	}
+	if err := syncScimMembers(ctx, tx, workspaceID, in.MemberIDs); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// PatchGroup aplica as alterações ao grupo e recalcula o papel dos membros afetados.
+func (r *ScimRepository) PatchGroup(ctx context.Context, workspaceID, groupID string, patch *domain.ScimGroupPatch) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Trava o grupo e confirma que ele existe no workspace
+	var locked int
+	err = tx.QueryRow(ctx, `
+		SELECT 1 FROM public."ScimGroup" WHERE "workspaceId" = $1 AND id = $2 FOR UPDATE`,
+		workspaceID, groupID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrScimGroupNotFound
+		}
+		return fmt.Errorf("lock scim group: %w", err)
+	}
+
+	if patch.DisplayName != nil || patch.ExternalID != nil {
+		_, err := tx.Exec(ctx, `
+			UPDATE public."ScimGroup"
+			SET "displayName" = COALESCE($3, "displayName"), "externalId" = COALESCE($4, "externalId"), "updatedAt" = NOW()
+			WHERE "workspaceId" = $1 AND id = $2`, workspaceID, groupID, patch.DisplayName, patch.ExternalID)
+		if err != nil {
+			if isScimUniqueViolation(err) {
+				return ErrScimGroupConflict
+			}
+			return fmt.Errorf("update scim group: %w", err)
+		}
+	}
+
+	affected := append(append([]string{}, patch.AddMembers...), patch.RemoveMembers...)
+	if patch.MembersReplaced {
+		current, err := groupMemberIDs(ctx, tx, groupID)
+		if err != nil {
+			return err
+		}
+		affected = append(append(affected, current...), patch.ReplaceMembers...)
+		if _, err := tx.Exec(ctx, `DELETE FROM public."ScimGroupMember" WHERE "groupId" = $1`, groupID); err != nil {
+			return fmt.Errorf("clear scim group members: %w", err)
+		}
+		if err := addScimGroupMembers(ctx, tx, workspaceID, groupID, patch.ReplaceMembers); err != nil {
+			return err
+		}
+	}
+	if err := addScimGroupMembers(ctx, tx, workspaceID, groupID, patch.AddMembers); err != nil {
+		return err
+	}
+	if len(patch.RemoveMembers) > 0 {
+		_, err := tx.Exec(ctx, `
+			DELETE FROM public."ScimGroupMember" WHERE "groupId" = $1 AND "userId" = ANY($2)`, groupID, patch.RemoveMembers)
+		if err != nil {
+			return fmt.Errorf("remove scim group members: %w", err)
+		}
+	}
+
+	if len(affected) > 0 {
+		if _, err := tx.Exec(ctx, `UPDATE public."ScimGroup" SET "updatedAt" = NOW() WHERE id = $1`, groupID); err != nil {
+			return fmt.Errorf("touch scim group: %w", err)
+		}
+	}
+	if err := syncScimMembers(ctx, tx, workspaceID, affected); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteGroup remove o grupo e recalcula o papel dos ex-membros.
+func (r *ScimRepository) DeleteGroup(ctx context.Context, workspaceID, groupID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	members, err := groupMemberIDs(ctx, tx, groupID)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM public."ScimGroup" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, groupID)
+	if err != nil {
+		return fmt.Errorf("delete scim group: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrScimGroupNotFound
+	}
+
+	if err := syncScimMembers(ctx, tx, workspaceID, members); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListGroupSummaries lista os grupos com o papel mapeado e o número de membros.
+func (r *ScimRepository) ListGroupSummaries(ctx context.Context, workspaceID string) ([]domain.ScimGroupSummary, error) {
+	query := `
+		SELECT g.id, g."displayName", g."externalId", g.role,
+		       (SELECT COUNT(*) FROM public."ScimGroupMember" gm WHERE gm."groupId" = g.id),
+		       g."updatedAt"
+		FROM public."ScimGroup" g
+		WHERE g."workspaceId" = $1
+		ORDER BY lower(g."displayName"), g.id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query scim group summaries: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []domain.ScimGroupSummary{}
+	for rows.Next() {
+		var g domain.ScimGroupSummary
+		if err := rows.Scan(&g.ID, &g.DisplayName, &g.ExternalID, &g.Role, &g.MemberCount, &g.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan scim group summary: %w", err)
+		}
+		groups = append(groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scim group summaries: %w", err)
+	}
+	return groups, nil
+}
+
+// SetGroupRole mapeia o grupo para um papel (nil remove) e recalcula o papel dos membros.
+func (r *ScimRepository) SetGroupRole(ctx context.Context, workspaceID, groupID string, role *domain.Role) (*domain.ScimGroupSummary, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var g domain.ScimGroupSummary
+	err = tx.QueryRow(ctx, `
+		UPDATE public."ScimGroup" g SET role = $3, "updatedAt" = NOW()
+		WHERE g."workspaceId" = $1 AND g.id = $2
+		RETURNING g.id, g."displayName", g."externalId", g.role,
+		          (SELECT COUNT(*) FROM public."ScimGroupMember" gm WHERE gm."groupId" = g.id), g."updatedAt"`,
+		workspaceID, groupID, role,
+	).Scan(&g.ID, &g.DisplayName, &g.ExternalID, &g.Role, &g.MemberCount, &g.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScimGroupNotFound
+		}
+		return nil, fmt.Errorf("update scim group role: %w", err)
+	}
+
+	members, err := groupMemberIDs(ctx, tx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if err := syncScimMembers(ctx, tx, workspaceID, members); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return &g, nil
+}
+
+// loadGroupMembers preenche os membros de cada grupo (display = userName).
+func (r *ScimRepository) loadGroupMembers(ctx context.Context, workspaceID string, groups []domain.ScimGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	ids := make([]string, len(groups))
+	index := make(map[string]int, len(groups))
+	for i, g := range groups {
+		ids[i] = g.ID
+		index[g.ID] = i
+	}
+
+	query := `
+		SELECT gm."groupId", u."userId", u."userName"
+		FROM public."ScimGroupMember" gm
+		JOIN public."ScimUser" u ON u."workspaceId" = gm."workspaceId" AND u."userId" = gm."userId"
+		WHERE gm."workspaceId" = $1 AND gm."groupId" = ANY($2)
+		ORDER BY u."userName", u."userId"`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, ids)
+	if err != nil {
+		return fmt.Errorf("query scim group members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupID string
+		var ref domain.ScimMemberRef
+		if err := rows.Scan(&groupID, &ref.Value, &ref.Display); err != nil {
+			return fmt.Errorf("scan scim group member: %w", err)
+		}
+		i := index[groupID]
+		groups[i].Members = append(groups[i].Members, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate scim group members: %w", err)
+	}
+	return nil
+}
+
+// =====================================================
+// Sincronização de WorkspaceMember
+// =====================================================
+
+// syncScimMembers aplica o estado do diretório a "WorkspaceMember" para os usuários informados:
+// inativos perdem a associação; ativos recebem o papel mais alto entre os grupos mapeados
+// (ou o defaultRole). O dono do workspace nunca é alterado.
+func syncScimMembers(ctx context.Context, tx pgx.Tx, workspaceID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		DELETE FROM public."WorkspaceMember" m
+		USING public."ScimUser" u
+		WHERE u."workspaceId" = $1 AND u."userId" = ANY($2) AND NOT u.active
+		  AND m."workspaceId" = u."workspaceId" AND m."userId" = u."userId"
+		  AND m."userId" <> (SELECT "ownerId" FROM public."Workspace" WHERE id = $1)`, workspaceID, userIDs)
+	if err != nil {
+		return fmt.Errorf("remove deprovisioned members: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO public."WorkspaceMember" ("userId", "workspaceId", "workspaceRoleId", accepted_at)
+		SELECT u."userId", u."workspaceId", wr.id, NOW()
+		FROM public."ScimUser" u
+		LEFT JOIN public."ScimConfig" c ON c."workspaceId" = u."workspaceId"
+		CROSS JOIN LATERAL (
+		    SELECT COALESCE((
+		        SELECT g.role
+		        FROM public."ScimGroupMember" gm
+		        JOIN public."ScimGroup" g ON g.id = gm."groupId"
+		        WHERE gm."workspaceId" = u."workspaceId" AND gm."userId" = u."userId" AND g.role IS NOT NULL
+		        ORDER BY CASE g.role
+		            WHEN 'work_admin' THEN 1 WHEN 'work_manager' THEN 2 WHEN 'work_user' THEN 3 ELSE 4
+		        END
+		        LIMIT 1
+		    ), c."defaultRole", 'work_user') AS name
+		) role
+		JOIN public."WorkspaceRole" wr ON wr.name = role.name
+		WHERE u."workspaceId" = $1 AND u."userId" = ANY($2) AND u.active
+		  AND u."userId" <> (SELECT "ownerId" FROM public."Workspace" WHERE id = $1)
+		ON CONFLICT ("userId", "workspaceId") DO UPDATE
+		SET "workspaceRoleId" = EXCLUDED."workspaceRoleId", updated_at = NOW()
+		WHERE "WorkspaceMember"."workspaceRoleId" IS DISTINCT FROM EXCLUDED."workspaceRoleId"`, workspaceID, userIDs)
+	if err != nil {
+		return fmt.Errorf("sync provisioned members: %w", err)
+	}
+	return nil
+}
+
+func addScimGroupMembers(ctx context.Context, tx pgx.Tx, workspaceID, groupID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO public."ScimGroupMember" ("groupId", "workspaceId", "userId")
+		SELECT $1, $2, unnest($3::TEXT[])
+		ON CONFLICT ("groupId", "userId") DO NOTHING`, groupID, workspaceID, userIDs)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "ScimGroupMember_user_fkey" {
+			return ErrScimGroupMemberNotFound
+		}
+		return fmt.Errorf("insert scim group members: %w", err)
+	}
+	return nil
+}
+
+func groupMemberIDs(ctx context.Context, tx pgx.Tx, groupID string) ([]string, error) {
+	rows, err := tx.Query(ctx, `SELECT "userId" FROM public."ScimGroupMember" WHERE "groupId" = $1`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("query scim group member ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan scim group member id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// isScimUniqueViolation userName (ScimUser), displayName (ScimGroup) ou usuário já vinculado
+func isScimUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func scanScimConfig(row pgx.Row) (*domain.ScimConfig, error) {
+	var cfg domain.ScimConfig
+	var updatedAt time.Time
+	if err := row.Scan(&cfg.WorkspaceID, &cfg.Enabled, &cfg.DefaultRole, &cfg.TokenCreatedAt, &cfg.TokenLastUsedAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	cfg.UpdatedAt = &updatedAt
+	return &cfg, nil
+}
+
+func scanScimUser(row pgx.Row) (*domain.ScimUser, error) {
+	var u domain.ScimUser
+	err := row.Scan(
+		&u.WorkspaceID, &u.UserID, &u.UserName, &u.ExternalID, &u.GivenName, &u.FamilyName,
+		&u.DisplayName, &u.Email, &u.Active, &u.CreatedAt, &u.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func scanScimGroup(row pgx.Row) (*domain.ScimGroup, error) {
+	var g domain.ScimGroup
+	if err := row.Scan(&g.ID, &g.WorkspaceID, &g.DisplayName, &g.ExternalID, &g.Role, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
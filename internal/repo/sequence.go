@@ -0,0 +1,378 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrSequenceNotFound           = apperr.NotFound("sequence not found in workspace", "sequence not found")
+	ErrSequenceEnrollmentNotFound = apperr.NotFound("sequence enrollment not found in workspace", "sequence enrollment not found")
+	ErrContactAlreadyEnrolled     = apperr.Conflict("contact already has an active enrollment in this sequence", "contact is already enrolled in this sequence")
+)
+
+// SequenceRepository persiste sequências e inscrições.
+// IMPORTANT: Uses camelCase column names with double quotes; steps é JSONB.
+type SequenceRepository struct {
+	pool database.DB
+}
+
+func NewSequenceRepository(pool database.DB) *SequenceRepository {
+	return &SequenceRepository{pool: pool}
+}
+
+const sequenceColumns = `id, "workspaceId", name, description, steps, "onDealWon", "onReply",
+	"createdById", "updatedById", "createdAt", "updatedAt", "deletedAt"`
+
+const enrollmentColumns = `id, "workspaceId", "sequenceId", "contactId", "dealId", status, "statusReason",
+	"statusChangedAt", "currentStep", "nextRunAt", "enrolledById", "createdAt", "updatedAt"`
+
+// Create insere uma nova sequência.
+func (r *SequenceRepository) Create(ctx context.Context, s *domain.Sequence) (*domain.Sequence, error) {
+	steps, err := json.Marshal(s.Steps)
+	if err != nil {
+		return nil, fmt.Errorf("marshal sequence steps: %w", err)
+	}
+
+	query := `
+		INSERT INTO public."Sequence" (id, "workspaceId", name, description, steps, "onDealWon", "onReply", "createdById")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + sequenceColumns
+
+	return scanSequence(r.pool.QueryRow(ctx, query,
+		s.ID, s.WorkspaceID, s.Name, s.Description, steps, s.OnDealWon, s.OnReply, s.CreatedByID,
+	))
+}
+
+// Get retorna uma sequência ativa (não deletada) do workspace.
+func (r *SequenceRepository) Get(ctx context.Context, workspaceID, sequenceID string) (*domain.Sequence, error) {
+	query := `
+		SELECT ` + sequenceColumns + `
+		FROM public."Sequence"
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`
+
+	s, err := scanSequence(r.pool.QueryRow(ctx, query, sequenceID, workspaceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSequenceNotFound
+		}
+		return nil, fmt.Errorf("query sequence: %w", err)
+	}
+	return s, nil
+}
+
+// List retorna as sequências do workspace ordenadas por nome.
+func (r *SequenceRepository) List(ctx context.Context, workspaceID string) ([]domain.Sequence, error) {
+	query := `
+		SELECT ` + sequenceColumns + `
+		FROM public."Sequence"
+		WHERE "workspaceId" = $1 AND "deletedAt" IS NULL
+		ORDER BY name ASC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query sequences: %w", err)
+	}
+	defer rows.Close()
+
+	sequences := []domain.Sequence{}
+	for rows.Next() {
+		s, err := scanSequence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan sequence: %w", err)
+		}
+		sequences = append(sequences, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate sequences: %w", err)
+	}
+
+	return sequences, nil
+}
+
+// Update aplica um PATCH na sequência (campos nil não são alterados).
+func (r *SequenceRepository) Update(ctx context.Context, workspaceID, sequenceID string, req *domain.UpdateSequenceRequest, actorID string) (*domain.Sequence, error) {
+	var steps []byte
+	if req.Steps != nil {
+		var err error
+		if steps, err = json.Marshal(req.Steps); err != nil {
+			return nil, fmt.Errorf("marshal sequence steps: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE public."Sequence" SET
+			name = COALESCE($3, name),
+			description = COALESCE($4, description),
+			steps = COALESCE($5::JSONB, steps),
+			"onDealWon" = COALESCE($6, "onDealWon"),
+			"onReply" = COALESCE($7, "onReply"),
+			"updatedById" = $8,
+			"updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
+		RETURNING ` + sequenceColumns
+
+	s, err := scanSequence(r.pool.QueryRow(ctx, query,
+		sequenceID, workspaceID, req.Name, req.Description, steps, req.OnDealWon, req.OnReply, actorID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSequenceNotFound
+		}
+		return nil, fmt.Errorf("update sequence: %w", err)
+	}
+	return s, nil
+}
+
+// Delete faz soft delete da sequência e encerra as inscrições em andamento.
+func (r *SequenceRepository) Delete(ctx context.Context, workspaceID, sequenceID, actorID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE public."Sequence"
+		SET "deletedAt" = NOW(), "updatedById" = $3, "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`,
+		sequenceID, workspaceID, actorID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete sequence: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSequenceNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE public."SequenceEnrollment"
+		SET status = 'EXITED', "statusReason" = $3, "statusChangedAt" = NOW(), "nextRunAt" = NULL, "updatedAt" = NOW()
+		WHERE "sequenceId" = $1 AND "workspaceId" = $2 AND status IN ('ACTIVE', 'PAUSED')`,
+		sequenceID, workspaceID, domain.EnrollmentReasonSequenceDeleted,
+	)
+	if err != nil {
+		return fmt.Errorf("exit sequence enrollments: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CreateEnrollment inscreve um contato. Falha com ErrContactAlreadyEnrolled se já houver
+// inscrição ACTIVE/PAUSED do contato na mesma sequência.
+func (r *SequenceRepository) CreateEnrollment(ctx context.Context, e *domain.SequenceEnrollment) (*domain.SequenceEnrollment, error) {
+	query := `
+		INSERT INTO public."SequenceEnrollment" (id, "workspaceId", "sequenceId", "contactId", "dealId", status, "nextRunAt", "enrolledById")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + enrollmentColumns
+
+	created, err := scanEnrollment(r.pool.QueryRow(ctx, query,
+		e.ID, e.WorkspaceID, e.SequenceID, e.ContactID, e.DealID, e.Status, e.NextRunAt, e.EnrolledByID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_active_enrollment_per_sequence" {
+			return nil, ErrContactAlreadyEnrolled
+		}
+		return nil, fmt.Errorf("insert sequence enrollment: %w", err)
+	}
+	return created, nil
+}
+
+// GetEnrollment retorna uma inscrição da sequência.
+func (r *SequenceRepository) GetEnrollment(ctx context.Context, workspaceID, sequenceID, enrollmentID string) (*domain.SequenceEnrollment, error) {
+	query := `
+		SELECT ` + enrollmentColumns + `
+		FROM public."SequenceEnrollment"
+		WHERE id = $1 AND "workspaceId" = $2 AND "sequenceId" = $3`
+
+	e, err := scanEnrollment(r.pool.QueryRow(ctx, query, enrollmentID, workspaceID, sequenceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSequenceEnrollmentNotFound
+		}
+		return nil, fmt.Errorf("query sequence enrollment: %w", err)
+	}
+	return e, nil
+}
+
+// ListEnrollments lista as inscrições de uma sequência, opcionalmente por status.
+func (r *SequenceRepository) ListEnrollments(ctx context.Context, workspaceID, sequenceID string, status *domain.EnrollmentStatus) ([]domain.SequenceEnrollment, error) {
+	query := `
+		SELECT ` + enrollmentColumns + `
+		FROM public."SequenceEnrollment"
+		WHERE "workspaceId" = $1 AND "sequenceId" = $2
+		  AND ($3::TEXT IS NULL OR status = $3)
+		ORDER BY "createdAt" DESC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, sequenceID, status)
+	if err != nil {
+		return nil, fmt.Errorf("query sequence enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	return collectEnrollments(rows)
+}
+
+// ListDueEnrollments retorna inscrições ACTIVE com passo vencido, de todos os workspaces.
+// Usado pelo worker; a posse de cada item é garantida por AdvanceEnrollment.
+func (r *SequenceRepository) ListDueEnrollments(ctx context.Context, now time.Time, limit int) ([]domain.SequenceEnrollment, error) {
+	query := `
+		SELECT ` + enrollmentColumns + `
+		FROM public."SequenceEnrollment"
+		WHERE status = 'ACTIVE' AND "nextRunAt" <= $1
+		ORDER BY "nextRunAt" ASC
+		LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	return collectEnrollments(rows)
+}
+
+// SetEnrollmentStatus muda o status de uma inscrição se o status atual estiver em from.
+// nextRunAt nil limpa o agendamento. Retorna ErrSequenceEnrollmentNotFound se a inscrição
+// não existir ou não estiver em nenhum dos status de origem.
+func (r *SequenceRepository) SetEnrollmentStatus(ctx context.Context, workspaceID, enrollmentID string, from []domain.EnrollmentStatus, to domain.EnrollmentStatus, reason *string, nextRunAt *time.Time) (*domain.SequenceEnrollment, error) {
+	fromStatuses := make([]string, len(from))
+	for i, s := range from {
+		fromStatuses[i] = string(s)
+	}
+
+	query := `
+		UPDATE public."SequenceEnrollment"
+		SET status = $3, "statusReason" = $4, "statusChangedAt" = NOW(), "nextRunAt" = $5, "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND status = ANY($6::TEXT[])
+		RETURNING ` + enrollmentColumns
+
+	e, err := scanEnrollment(r.pool.QueryRow(ctx, query, enrollmentID, workspaceID, to, reason, nextRunAt, fromStatuses))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSequenceEnrollmentNotFound
+		}
+		return nil, fmt.Errorf("update sequence enrollment status: %w", err)
+	}
+	return e, nil
+}
+
+// AdvanceEnrollment move a inscrição do passo e.CurrentStep para nextStep.
+// A condição em currentStep/status faz o papel de lock otimista entre workers:
+// retorna false quando outro worker já avançou (ou a inscrição saiu de ACTIVE).
+// Com completed=true a inscrição vai para COMPLETED.
+func (r *SequenceRepository) AdvanceEnrollment(ctx context.Context, e *domain.SequenceEnrollment, nextStep int, nextRunAt *time.Time, completed bool) (bool, error) {
+	status := domain.EnrollmentActive
+	if completed {
+		status = domain.EnrollmentCompleted
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public."SequenceEnrollment"
+		SET "currentStep" = $3, "nextRunAt" = $4, status = $5, "updatedAt" = NOW(),
+		    "statusChangedAt" = CASE WHEN $5 <> status THEN NOW() ELSE "statusChangedAt" END
+		WHERE id = $1 AND "currentStep" = $2 AND status = 'ACTIVE'`,
+		e.ID, e.CurrentStep, nextStep, nextRunAt, status,
+	)
+	if err != nil {
+		return false, fmt.Errorf("advance sequence enrollment: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// HasDealWonSince indica se o negócio da inscrição (ou, sem dealId, qualquer negócio
+// do contato) foi ganho depois de since.
+func (r *SequenceRepository) HasDealWonSince(ctx context.Context, e *domain.SequenceEnrollment, since time.Time) (bool, error) {
+	var won bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM public."Deal"
+			WHERE "workspaceId" = $1
+			  AND "deletedAt" IS NULL
+			  AND stage = 'WON'
+			  AND "updatedAt" >= $4
+			  AND (CASE WHEN $3::TEXT IS NULL THEN "contactId" = $2 ELSE id = $3 END)
+		)`,
+		e.WorkspaceID, e.ContactID, e.DealID, since,
+	).Scan(&won)
+	if err != nil {
+		return false, fmt.Errorf("query deal won: %w", err)
+	}
+	return won, nil
+}
+
+// HasReplySince indica se o contato enviou alguma mensagem (INBOUND) depois de since.
+func (r *SequenceRepository) HasReplySince(ctx context.Context, workspaceID, contactID string, since time.Time) (bool, error) {
+	var replied bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM public."Message"
+			WHERE "workspaceId" = $1
+			  AND "contactId" = $2
+			  AND direction = 'INBOUND'
+			  AND "sentAt" >= $3
+		)`,
+		workspaceID, contactID, since,
+	).Scan(&replied)
+	if err != nil {
+		return false, fmt.Errorf("query contact reply: %w", err)
+	}
+	return replied, nil
+}
+
+// Scanners
+func scanSequence(row pgx.Row) (*domain.Sequence, error) {
+	var s domain.Sequence
+	var steps []byte
+	err := row.Scan(
+		&s.ID, &s.WorkspaceID, &s.Name, &s.Description, &steps, &s.OnDealWon, &s.OnReply,
+		&s.CreatedByID, &s.UpdatedByID, &s.CreatedAt, &s.UpdatedAt, &s.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(steps, &s.Steps); err != nil {
+		return nil, fmt.Errorf("unmarshal sequence steps: %w", err)
+	}
+	return &s, nil
+}
+
+func scanEnrollment(row pgx.Row) (*domain.SequenceEnrollment, error) {
+	var e domain.SequenceEnrollment
+	var currentStep int32
+	err := row.Scan(
+		&e.ID, &e.WorkspaceID, &e.SequenceID, &e.ContactID, &e.DealID, &e.Status, &e.StatusReason,
+		&e.StatusChangedAt, &currentStep, &e.NextRunAt, &e.EnrolledByID, &e.CreatedAt, &e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.CurrentStep = int(currentStep)
+	return &e, nil
+}
+
+func collectEnrollments(rows pgx.Rows) ([]domain.SequenceEnrollment, error) {
+	enrollments := []domain.SequenceEnrollment{}
+	for rows.Next() {
+		e, err := scanEnrollment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan sequence enrollment: %w", err)
+		}
+		enrollments = append(enrollments, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate sequence enrollments: %w", err)
+	}
+	return enrollments, nil
+}
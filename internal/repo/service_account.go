@@ -0,0 +1,253 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrServiceAccountNotFound = apperr.NotFound("service account not found in workspace", "service account not found")
+
+	// ErrServiceAccountConflict o workspace já tem uma service account com o mesmo nome
+	ErrServiceAccountConflict = apperr.Conflict("service account with this name already exists in workspace", "a service account with this name already exists")
+)
+
+// ServiceAccountRepository persiste as service accounts e mantém o vínculo delas com o workspace:
+// enquanto ativa, a conta é um WorkspaceMember com o papel configurado, então a autorização por
+// papel dos services vale sem mudanças.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type ServiceAccountRepository struct {
+	pool database.DB
+}
+
+func NewServiceAccountRepository(pool database.DB) *ServiceAccountRepository {
+	return &ServiceAccountRepository{pool: pool}
+}
+
+const serviceAccountColumns = `id, "workspaceId", name, description, scopes, role, enabled, "secretCreatedAt",
+	"lastTokenIssuedAt", "createdById", "createdAt", "updatedAt"`
+
+// List retorna as service accounts do workspace, mais antigas primeiro.
+func (r *ServiceAccountRepository) List(ctx context.Context, workspaceID string) ([]domain.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM public."ServiceAccount" WHERE "workspaceId" = $1 ORDER BY "createdAt", id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []domain.ServiceAccount{}
+	for rows.Next() {
+		sa, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan service account: %w", err)
+		}
+		accounts = append(accounts, *sa)
+	}
+	return accounts, rows.Err()
+}
+
+// Get retorna a service account do workspace.
+func (r *ServiceAccountRepository) Get(ctx context.Context, workspaceID, accountID string) (*domain.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM public."ServiceAccount" WHERE "workspaceId" = $1 AND id = $2`
+
+	sa, err := scanServiceAccount(r.pool.QueryRow(ctx, query, workspaceID, accountID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("query service account: %w", err)
+	}
+	return sa, nil
+}
+
+// GetCredentials retorna a conta pelo client_id (de qualquer workspace) e o hash do secret.
+func (r *ServiceAccountRepository) GetCredentials(ctx context.Context, accountID string) (*domain.ServiceAccount, []byte, error) {
+	query := `SELECT ` + serviceAccountColumns + `, "clientSecretHash" FROM public."ServiceAccount" WHERE id = $1`
+
+	var sa domain.ServiceAccount
+	var secretHash []byte
+	err := r.pool.QueryRow(ctx, query, accountID).Scan(
+		&sa.ID, &sa.WorkspaceID, &sa.Name, &sa.Description, &sa.Scopes, &sa.Role, &sa.Enabled, &sa.SecretCreatedAt,
+		&sa.LastTokenIssuedAt, &sa.CreatedByID, &sa.CreatedAt, &sa.UpdatedAt, &secretHash,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrServiceAccountNotFound
+		}
+		return nil, nil, fmt.Errorf("query service account credentials: %w", err)
+	}
+	return &sa, secretHash, nil
+}
+
+// Create cadastra a conta e, se ativa, a adiciona ao workspace em uma transação.
+func (r *ServiceAccountRepository) Create(ctx context.Context, sa *domain.ServiceAccount, secretHash []byte) (*domain.ServiceAccount, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO public."ServiceAccount" (
+			id, "workspaceId", name, description, "clientSecretHash", scopes, role, enabled, "createdById"
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + serviceAccountColumns
+
+	created, err := scanServiceAccount(tx.QueryRow(ctx, query,
+		sa.ID, sa.WorkspaceID, sa.Name, sa.Description, secretHash, sa.Scopes, sa.Role, sa.Enabled, sa.CreatedByID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrServiceAccountConflict
+		}
+		return nil, fmt.Errorf("insert service account: %w", err)
+	}
+
+	if err := syncServiceAccountMember(ctx, tx, created); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+// Update grava os campos editáveis e ajusta o WorkspaceMember (papel; removido quando desativada).
+func (r *ServiceAccountRepository) Update(ctx context.Context, sa *domain.ServiceAccount) (*domain.ServiceAccount, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE public."ServiceAccount"
+		SET name = $3, description = $4, scopes = $5, role = $6, enabled = $7, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + serviceAccountColumns
+
+	updated, err := scanServiceAccount(tx.QueryRow(ctx, query,
+		sa.WorkspaceID, sa.ID, sa.Name, sa.Description, sa.Scopes, sa.Role, sa.Enabled,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrServiceAccountNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrServiceAccountConflict
+		}
+		return nil, fmt.Errorf("update service account: %w", err)
+	}
+
+	if err := syncServiceAccountMember(ctx, tx, updated); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return updated, nil
+}
+
+// RotateSecret troca o hash do secret; o anterior deixa de valer imediatamente.
+func (r *ServiceAccountRepository) RotateSecret(ctx context.Context, workspaceID, accountID string, secretHash []byte) (*domain.ServiceAccount, error) {
+	query := `
+		UPDATE public."ServiceAccount"
+		SET "clientSecretHash" = $3, "secretCreatedAt" = NOW(), "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + serviceAccountColumns
+
+	sa, err := scanServiceAccount(r.pool.QueryRow(ctx, query, workspaceID, accountID, secretHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("rotate service account secret: %w", err)
+	}
+	return sa, nil
+}
+
+// Delete remove a conta e o vínculo com o workspace; tokens já emitidos param de passar na
+// checagem de papel.
+func (r *ServiceAccountRepository) Delete(ctx context.Context, workspaceID, accountID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM public."ServiceAccount" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, accountID)
+	if err != nil {
+		return fmt.Errorf("delete service account: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrServiceAccountNotFound
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM public."WorkspaceMember" WHERE "userId" = $1 AND "workspaceId" = $2`, accountID, workspaceID); err != nil {
+		return fmt.Errorf("delete service account member: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// MarkTokenIssued registra a emissão do último token.
+func (r *ServiceAccountRepository) MarkTokenIssued(ctx context.Context, accountID string) error {
+	if _, err := r.pool.Exec(ctx, `UPDATE public."ServiceAccount" SET "lastTokenIssuedAt" = NOW() WHERE id = $1`, accountID); err != nil {
+		return fmt.Errorf("update service account last token: %w", err)
+	}
+	return nil
+}
+
+// syncServiceAccountMember mantém o WorkspaceMember da conta igual a enabled/role.
+func syncServiceAccountMember(ctx context.Context, tx pgx.Tx, sa *domain.ServiceAccount) error {
+	if !sa.Enabled {
+		if _, err := tx.Exec(ctx, `DELETE FROM public."WorkspaceMember" WHERE "userId" = $1 AND "workspaceId" = $2`, sa.ID, sa.WorkspaceID); err != nil {
+			return fmt.Errorf("delete service account member: %w", err)
+		}
+		return nil
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO public."WorkspaceMember" ("userId", "workspaceId", "workspaceRoleId", accepted_at)
+		SELECT $1, $2, wr.id, NOW()
+		FROM public."WorkspaceRole" wr
+		WHERE wr.name = $3
+		ON CONFLICT ("userId", "workspaceId") DO UPDATE
+		SET "workspaceRoleId" = EXCLUDED."workspaceRoleId"`, sa.ID, sa.WorkspaceID, sa.Role)
+	if err != nil {
+		return fmt.Errorf("upsert service account member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("workspace role %s not found", sa.Role)
+	}
+	return nil
+}
+
+func scanServiceAccount(row pgx.Row) (*domain.ServiceAccount, error) {
+	var sa domain.ServiceAccount
+	err := row.Scan(
+		&sa.ID, &sa.WorkspaceID, &sa.Name, &sa.Description, &sa.Scopes, &sa.Role, &sa.Enabled, &sa.SecretCreatedAt,
+		&sa.LastTokenIssuedAt, &sa.CreatedByID, &sa.CreatedAt, &sa.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if sa.Scopes == nil {
+		sa.Scopes = []string{}
+	}
+	return &sa, nil
+}
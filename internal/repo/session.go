@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSessionUserNotFound o ator não é um usuário cadastrado (ex.: serviço S2S ou agente)
+var ErrSessionUserNotFound = apperr.NotFound("session actor is not a registered user", "user not found")
+
+// SessionRepository resolve o perfil do ator autenticado para GET /v1/me.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type SessionRepository struct {
+	pool database.DB
+}
+
+func NewSessionRepository(pool database.DB) *SessionRepository {
+	return &SessionRepository{pool: pool}
+}
+
+// GetActor retorna nome, email e imagem do usuário.
+func (r *SessionRepository) GetActor(ctx context.Context, actorID string) (*domain.SessionActor, error) {
+	query := `
+		SELECT u.id, u.name, u.email, u.image
+		FROM public."User" u
+		WHERE u.id = $1 AND u."deletedAt" IS NULL`
+
+	var a domain.SessionActor
+	if err := r.pool.QueryRow(ctx, query, actorID).Scan(&a.ID, &a.Name, &a.Email, &a.Image); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionUserNotFound
+		}
+		return nil, fmt.Errorf("query session actor: %w", err)
+	}
+	return &a, nil
+}
+
+// ListWorkspaces retorna os workspaces em que o ator é membro mais os informados em
+// workspaceIDs (liberados pela credencial), com o papel quando há associação.
+func (r *SessionRepository) ListWorkspaces(ctx context.Context, actorID string, workspaceIDs []string) ([]domain.SessionWorkspace, error) {
+	query := `
+		SELECT w.id, w.name, wr.name
+		FROM public."Workspace" w
+		LEFT JOIN public."WorkspaceMember" m ON m."workspaceId" = w.id AND m."userId" = $1
+		LEFT JOIN public."WorkspaceRole" wr ON wr.id = m."workspaceRoleId"
+		WHERE m."userId" IS NOT NULL OR w.id = ANY($2)
+		ORDER BY w.name, w.id`
+
+	rows, err := r.pool.Query(ctx, query, actorID, workspaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query session workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	result := []domain.SessionWorkspace{}
+	for rows.Next() {
+		var (
+			ws       domain.SessionWorkspace
+			name     string
+			roleName *string
+		)
+		if err := rows.Scan(&ws.WorkspaceID, &name, &roleName); err != nil {
+			return nil, fmt.Errorf("scan session workspace: %w", err)
+		}
+		ws.WorkspaceName = &name
+		if roleName != nil {
+			if role := domain.Role(*roleName); role.IsValid() {
+				ws.Role = &role
+			}
+		}
+		result = append(result, ws)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate session workspaces: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,644 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrShardCopyMismatch contagens diferentes entre origem e destino depois da cópia (escritas
+// durante a migração, ex. workers na origem)
+var ErrShardCopyMismatch = errors.New("shard copy row count mismatch")
+
+// ShardRepository mapa workspace → shard de dados (tabela "WorkspaceShard", no banco padrão).
+// IMPORTANT: Uses camelCase column names with double quotes.
+type ShardRepository struct {
+	pool database.DB
+}
+
+func NewShardRepository(pool database.DB) *ShardRepository {
+	return &ShardRepository{pool: pool}
+}
+
+// List retorna os workspaces mapeados para fora do banco padrão.
+func (r *ShardRepository) List(ctx context.Context) ([]domain.WorkspaceShard, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT "workspaceId", "shard", "updatedAt"
+		FROM public."WorkspaceShard"
+		ORDER BY "workspaceId"`)
+	if err != nil {
+		return nil, fmt.Errorf("list workspace shards: %w", err)
+	}
+	defer rows.Close()
+
+	shards := []domain.WorkspaceShard{}
+	for rows.Next() {
+		var s domain.WorkspaceShard
+		if err := rows.Scan(&s.WorkspaceID, &s.Shard, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan workspace shard: %w", err)
+		}
+		shards = append(shards, s)
+	}
+	return shards, rows.Err()
+}
+
+// Get retorna o shard do workspace (database.DefaultShard sem entrada).
+func (r *ShardRepository) Get(ctx context.Context, workspaceID string) (string, error) {
+	var shard string
+	err := r.pool.QueryRow(ctx, `SELECT "shard" FROM public."WorkspaceShard" WHERE "workspaceId" = $1`, workspaceID).Scan(&shard)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.DefaultShard, nil
+		}
+		return "", fmt.Errorf("get workspace shard: %w", err)
+	}
+	return shard, nil
+}
+
+// Set grava o shard do workspace; database.DefaultShard remove a entrada.
+func (r *ShardRepository) Set(ctx context.Context, workspaceID, shard string) error {
+	if shard == database.DefaultShard {
+		if _, err := r.pool.Exec(ctx, `DELETE FROM public."WorkspaceShard" WHERE "workspaceId" = $1`, workspaceID); err != nil {
+			return fmt.Errorf("delete workspace shard: %w", err)
+		}
+		return nil
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO public."WorkspaceShard" ("workspaceId", "shard")
+		VALUES ($1, $2)
+		ON CONFLICT ("workspaceId") DO UPDATE
+		SET "shard" = EXCLUDED."shard", "updatedAt" = CURRENT_TIMESTAMP`, workspaceID, shard)
+	if err != nil {
+		return fmt.Errorf("set workspace shard: %w", err)
+	}
+	return nil
+}
+
+// shardControlTables plano de controle: ficam sempre no banco padrão e não são movidos.
+// Workspace, organização, papéis, usuários e membros são copiados para os shards como âncoras
+// das FKs (shardAnchors).
+var shardControlTables = map[string]bool{
+	"schema_migrations":  true,
+	"WorkspaceShard":     true,
+	"Maintenance":        true,
+	"StripeEvent":        true,
+	"AdminRole":          true,
+	"User":               true,
+	"Organization":       true,
+	"OrganizationMember": true,
+	"Workspace":          true,
+	"WorkspaceRole":      true,
+	"WorkspaceMember":    true,
+	"ServiceAccount":     true,
+	"ScimConfig":         true,
+	"ScimUser":           true,
+	"ScimGroup":          true,
+	"ScimGroupMember":    true,
+	"SsoProvider":        true,
+	"SsoIdentity":        true,
+}
+
+// shardAnchor linha do plano de controle copiada (upsert) para o shard: os dados do workspace
+// têm FKs para elas. byUsers: o filtro usa os usuários referenciados ($1 = ids) em vez do workspace.
+type shardAnchor struct {
+	table   string
+	scope   string
+	byUsers bool
+}
+
+// shardAnchors em ordem de dependência.
+var shardAnchors = []shardAnchor{
+	{table: "Organization", scope: `id = (SELECT "organizationId" FROM public."Workspace" WHERE id = $1)`},
+	{table: "WorkspaceRole", scope: `TRUE`},
+	{table: "User", scope: `id = ANY($1::text[])`, byUsers: true},
+	{table: "Workspace", scope: `id = $1`},
+	{table: "WorkspaceMember", scope: `"workspaceId" = $1`},
+}
+
+// shardTable tabela com dados do workspace, movida entre shards.
+type shardTable struct {
+	name    string
+	scope   string // filtro com $1 = workspace
+	selfRef bool   // FK para a própria tabela: copiada em um único INSERT (FKs checadas no fim do comando)
+}
+
+// shardSchema tabelas do workspace em ordem de dependência (pais antes dos filhos) e as
+// subconsultas que listam os usuários referenciados por elas ($1 = workspace).
+type shardSchema struct {
+	tables   []shardTable
+	userRefs []string
+}
+
+type shardForeignKey struct {
+	table, column, parent, parentColumn string
+}
+
+// loadShardSchema descobre no catálogo as tabelas com dados de workspace: as que têm coluna
+// "workspaceId"/workspace_id e as de junção sem ela, escopadas pela FK para uma tabela do
+// workspace. Partições ficam de fora (a tabela particionada cobre todas).
+func loadShardSchema(ctx context.Context, db database.DB) (*shardSchema, error) {
+	rows, err := db.Query(ctx, `
+		SELECT c.relname, COALESCE(min(a.attname::text), '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attribute a ON a.attrelid = c.oid AND NOT a.attisdropped
+		     AND a.attname IN ('workspaceId', 'workspace_id')
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+		GROUP BY c.relname
+		ORDER BY c.relname`)
+	if err != nil {
+		return nil, fmt.Errorf("query workspace tables: %w", err)
+	}
+	wsColumns := map[string]string{}
+	var names []string
+	for rows.Next() {
+		var name, wsColumn string
+		if err := rows.Scan(&name, &wsColumn); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan workspace table: %w", err)
+		}
+		if shardControlTables[name] {
+			continue
+		}
+		names = append(names, name)
+		wsColumns[name] = wsColumn
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate workspace tables: %w", err)
+	}
+
+	// FKs de uma coluna (as herdadas pelas partições ficam de fora)
+	rows, err = db.Query(ctx, `
+		SELECT ch.relname, ca.attname, pa.relname, pc.attname
+		FROM pg_constraint k
+		JOIN pg_class ch ON ch.oid = k.conrelid
+		JOIN pg_namespace n ON n.oid = ch.relnamespace
+		JOIN pg_class pa ON pa.oid = k.confrelid
+		JOIN pg_attribute ca ON ca.attrelid = k.conrelid AND ca.attnum = k.conkey[1]
+		JOIN pg_attribute pc ON pc.attrelid = k.confrelid AND pc.attnum = k.confkey[1]
+		WHERE k.contype = 'f' AND k.conparentid = 0 AND n.nspname = 'public'
+		  AND NOT ch.relispartition AND cardinality(k.conkey) = 1
+		ORDER BY ch.relname, ca.attname`)
+	if err != nil {
+		return nil, fmt.Errorf("query workspace foreign keys: %w", err)
+	}
+	var fks []shardForeignKey
+	for rows.Next() {
+		var fk shardForeignKey
+		if err := rows.Scan(&fk.table, &fk.column, &fk.parent, &fk.parentColumn); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan workspace foreign key: %w", err)
+		}
+		fks = append(fks, fk)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate workspace foreign keys: %w", err)
+	}
+
+	return buildShardSchema(names, wsColumns, fks), nil
+}
+
+// buildShardSchema monta os filtros e a ordem de cópia a partir do catálogo.
+func buildShardSchema(names []string, wsColumns map[string]string, fks []shardForeignKey) *shardSchema {
+	scopes := map[string]string{}
+	for _, name := range names {
+		if col := wsColumns[name]; col != "" {
+			scopes[name] = quoteIdent(col) + ` = $1`
+		}
+	}
+	// Tabelas de junção (inclusive junção de junção): escopo pela primeira FK para uma tabela já escopada
+	for changed := true; changed; {
+		changed = false
+		for _, fk := range fks {
+			if _, done := scopes[fk.table]; done || fk.table == fk.parent || shardControlTables[fk.table] {
+				continue
+			}
+			parentScope, ok := scopes[fk.parent]
+			if !ok || wsColumns[fk.table] != "" || !contains(names, fk.table) {
+				continue
+			}
+			scopes[fk.table] = fmt.Sprintf(`%s IN (SELECT %s FROM public.%s WHERE %s)`,
+				quoteIdent(fk.column), quoteIdent(fk.parentColumn), quoteIdent(fk.parent), parentScope)
+			changed = true
+		}
+	}
+
+	deps := map[string]map[string]bool{}
+	selfRef := map[string]bool{}
+	schema := &shardSchema{}
+	for _, fk := range fks {
+		if _, ok := scopes[fk.table]; !ok {
+			continue
+		}
+		if fk.parent == "User" {
+			schema.userRefs = append(schema.userRefs, fmt.Sprintf(`SELECT %s FROM public.%s WHERE %s`,
+				quoteIdent(fk.column), quoteIdent(fk.table), scopes[fk.table]))
+		}
+		if fk.table == fk.parent {
+			selfRef[fk.table] = true
+			continue
+		}
+		if _, ok := scopes[fk.parent]; ok {
+			if deps[fk.table] == nil {
+				deps[fk.table] = map[string]bool{}
+			}
+			deps[fk.table][fk.parent] = true
+		}
+	}
+
+	// Ordem topológica estável (Kahn, por nome); ciclos vão para o fim na ordem alfabética
+	var pending []string
+	for _, name := range names {
+		if _, ok := scopes[name]; ok {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+	placed := map[string]bool{}
+	for len(pending) > 0 {
+		var next []string
+		progressed := false
+		for _, name := range pending {
+			ready := true
+			for parent := range deps[name] {
+				if !placed[parent] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				schema.tables = append(schema.tables, shardTable{name: name, scope: scopes[name], selfRef: selfRef[name]})
+				placed[name] = true
+				progressed = true
+			} else {
+				next = append(next, name)
+			}
+		}
+		if !progressed {
+			for _, name := range next {
+				schema.tables = append(schema.tables, shardTable{name: name, scope: scopes[name], selfRef: selfRef[name]})
+			}
+			break
+		}
+		pending = next
+	}
+	return schema
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// shardCopyBatch linhas por INSERT na cópia entre shards
+const shardCopyBatch = 500
+
+// ShardCopier move os dados de um workspace entre dois bancos com o mesmo schema. control é o
+// banco padrão (plano de controle, origem das âncoras).
+type ShardCopier struct {
+	control  database.DB
+	from, to database.DB
+	toName   string
+}
+
+func NewShardCopier(control, from, to database.DB, toName string) *ShardCopier {
+	return &ShardCopier{control: control, from: from, to: to, toName: toName}
+}
+
+// SyncAnchors copia (upsert) para o shard de destino o workspace, a organização, os papéis, os
+// membros e os usuários referenciados pelos dados do workspace na origem. Membros removidos no
+// banco padrão são removidos do shard. No-op quando o destino é o banco padrão.
+func (c *ShardCopier) SyncAnchors(ctx context.Context, workspaceID string) (map[string]int64, error) {
+	synced := map[string]int64{}
+	if c.toName == database.DefaultShard {
+		return synced, nil
+	}
+	schema, err := loadShardSchema(ctx, c.from)
+	if err != nil {
+		return nil, err
+	}
+	users, err := c.anchorUsers(ctx, schema, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range shardAnchors {
+		arg := any(workspaceID)
+		if a.byUsers {
+			arg = users
+		}
+		rows, err := selectJSON(ctx, c.control, a.table, a.scope, arg)
+		if err != nil {
+			return nil, err
+		}
+		if a.table == "WorkspaceMember" {
+			if err := c.pruneMembers(ctx, workspaceID, rows); err != nil {
+				return nil, err
+			}
+		}
+		n, err := upsertJSON(ctx, c.to, a.table, rows)
+		if err != nil {
+			return nil, err
+		}
+		synced[a.table] = n
+	}
+	return synced, nil
+}
+
+// anchorUsers membros e dono do workspace (banco padrão) mais os usuários referenciados pelos
+// dados na origem (autores antigos que já saíram do workspace, por exemplo)
+func (c *ShardCopier) anchorUsers(ctx context.Context, schema *shardSchema, workspaceID string) ([]string, error) {
+	seen := map[string]bool{}
+	collect := func(db database.DB, query string) error {
+		rows, err := db.Query(ctx, query, workspaceID)
+		if err != nil {
+			return fmt.Errorf("query anchor users: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id *string
+			if err := rows.Scan(&id); err != nil {
+				return fmt.Errorf("scan anchor user: %w", err)
+			}
+			if id != nil {
+				seen[*id] = true
+			}
+		}
+		return rows.Err()
+	}
+
+	if err := collect(c.control, `
+		SELECT "userId" FROM public."WorkspaceMember" WHERE "workspaceId" = $1
+		UNION SELECT "ownerId" FROM public."Workspace" WHERE id = $1`); err != nil {
+		return nil, err
+	}
+	if len(schema.userRefs) > 0 {
+		if err := collect(c.from, strings.Join(schema.userRefs, "\nUNION ")); err != nil {
+			return nil, err
+		}
+	}
+
+	users := make([]string, 0, len(seen))
+	for id := range seen {
+		users = append(users, id)
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// pruneMembers remove do shard os membros que não existem mais no banco padrão (um membro
+// readicionado tem outro id e colidiria no par usuário/workspace)
+func (c *ShardCopier) pruneMembers(ctx context.Context, workspaceID string, rows []string) error {
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		var m struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(row), &m); err != nil {
+			return fmt.Errorf("decode workspace member: %w", err)
+		}
+		ids = append(ids, m.ID)
+	}
+	_, err := c.to.Exec(ctx, `DELETE FROM public."WorkspaceMember" WHERE "workspaceId" = $1 AND NOT (id = ANY($2::text[]))`, workspaceID, ids)
+	if err != nil {
+		return fmt.Errorf("prune workspace members: %w", err)
+	}
+	return nil
+}
+
+// Copy copia todas as linhas do workspace para o destino. Lê a origem em uma transação
+// REPEATABLE READ somente leitura e grava o destino em uma única transação, depois de remover
+// sobras de uma migração anterior; rodar de novo após uma falha é seguro. As âncoras são
+// sincronizadas antes.
+func (c *ShardCopier) Copy(ctx context.Context, workspaceID string) (map[string]int64, error) {
+	schema, err := loadShardSchema(ctx, c.from)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.SyncAnchors(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+
+	src, err := c.from.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin shard copy source: %w", err)
+	}
+	defer src.Rollback(ctx)
+	if _, err := src.Exec(ctx, `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`); err != nil {
+		return nil, fmt.Errorf("set shard copy isolation: %w", err)
+	}
+
+	dst, err := c.to.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin shard copy target: %w", err)
+	}
+	defer dst.Rollback(ctx)
+
+	if _, err := purgeWorkspace(ctx, dst, schema, workspaceID); err != nil {
+		return nil, err
+	}
+
+	copied := make(map[string]int64, len(schema.tables))
+	for _, t := range schema.tables {
+		n, err := copyShardTable(ctx, src, dst, t, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		copied[t.name] = n
+	}
+
+	if err := dst.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit shard copy: %w", err)
+	}
+	return copied, nil
+}
+
+func copyShardTable(ctx context.Context, src, dst pgx.Tx, t shardTable, workspaceID string) (int64, error) {
+	rows, err := src.Query(ctx, `SELECT row_to_json(t)::text FROM public.`+quoteIdent(t.name)+` t WHERE `+t.scope, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("query %s: %w", t.name, err)
+	}
+	defer rows.Close()
+
+	var copied int64
+	batch := make([]string, 0, shardCopyBatch)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		tag, err := dst.Exec(ctx, fmt.Sprintf(
+			`INSERT INTO public.%[1]s SELECT r.* FROM jsonb_populate_recordset(NULL::public.%[1]s, $1::jsonb) r`,
+			quoteIdent(t.name)), "["+strings.Join(batch, ",")+"]")
+		if err != nil {
+			return fmt.Errorf("copy %s: %w", t.name, err)
+		}
+		copied += tag.RowsAffected()
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return 0, fmt.Errorf("scan %s: %w", t.name, err)
+		}
+		batch = append(batch, row)
+		if len(batch) >= shardCopyBatch && !t.selfRef {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate %s: %w", t.name, err)
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return copied, nil
+}
+
+// Verify compara as contagens de cada tabela do workspace na origem e no destino.
+func (c *ShardCopier) Verify(ctx context.Context, workspaceID string) error {
+	schema, err := loadShardSchema(ctx, c.from)
+	if err != nil {
+		return err
+	}
+	for _, t := range schema.tables {
+		query := `SELECT count(*) FROM public.` + quoteIdent(t.name) + ` WHERE ` + t.scope
+		var from, to int64
+		if err := c.from.QueryRow(ctx, query, workspaceID).Scan(&from); err != nil {
+			return fmt.Errorf("count source %s: %w", t.name, err)
+		}
+		if err := c.to.QueryRow(ctx, query, workspaceID).Scan(&to); err != nil {
+			return fmt.Errorf("count target %s: %w", t.name, err)
+		}
+		if from != to {
+			return fmt.Errorf("%w: %s has %d rows in the source and %d in the target", ErrShardCopyMismatch, t.name, from, to)
+		}
+	}
+	return nil
+}
+
+// PurgeSource remove os dados do workspace da origem (as âncoras do plano de controle ficam).
+func (c *ShardCopier) PurgeSource(ctx context.Context, workspaceID string) (map[string]int64, error) {
+	schema, err := loadShardSchema(ctx, c.from)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.from.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin shard purge: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	purged, err := purgeWorkspace(ctx, tx, schema, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit shard purge: %w", err)
+	}
+	return purged, nil
+}
+
+// purgeWorkspace apaga as linhas do workspace em ordem inversa de dependência
+func purgeWorkspace(ctx context.Context, tx pgx.Tx, schema *shardSchema, workspaceID string) (map[string]int64, error) {
+	purged := make(map[string]int64, len(schema.tables))
+	for i := len(schema.tables) - 1; i >= 0; i-- {
+		t := schema.tables[i]
+		tag, err := tx.Exec(ctx, `DELETE FROM public.`+quoteIdent(t.name)+` WHERE `+t.scope, workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("purge %s: %w", t.name, err)
+		}
+		purged[t.name] = tag.RowsAffected()
+	}
+	return purged, nil
+}
+
+// selectJSON lê as linhas do filtro como JSON
+func selectJSON(ctx context.Context, db database.DB, table, scope string, arg any) ([]string, error) {
+	rows, err := db.Query(ctx, `SELECT row_to_json(t)::text FROM public.`+quoteIdent(table)+` t WHERE `+scope, arg)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", table, err)
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate %s: %w", table, err)
+	}
+	return out, nil
+}
+
+// upsertJSON grava as linhas pela chave primária, atualizando as demais colunas
+func upsertJSON(ctx context.Context, db database.DB, table string, rows []string) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	cols, err := db.Query(ctx, `
+		SELECT a.attname, COALESCE(a.attnum = ANY(i.indkey), false)
+		FROM pg_attribute a
+		LEFT JOIN pg_index i ON i.indrelid = a.attrelid AND i.indisprimary
+		WHERE a.attrelid = to_regclass($1) AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, "public."+quoteIdent(table))
+	if err != nil {
+		return 0, fmt.Errorf("query %s columns: %w", table, err)
+	}
+	var pk, set []string
+	for cols.Next() {
+		var name string
+		var primary bool
+		if err := cols.Scan(&name, &primary); err != nil {
+			cols.Close()
+			return 0, fmt.Errorf("scan %s columns: %w", table, err)
+		}
+		if primary {
+			pk = append(pk, quoteIdent(name))
+		} else {
+			set = append(set, quoteIdent(name)+` = EXCLUDED.`+quoteIdent(name))
+		}
+	}
+	cols.Close()
+	if err := cols.Err(); err != nil {
+		return 0, fmt.Errorf("iterate %s columns: %w", table, err)
+	}
+	if len(pk) == 0 {
+		return 0, fmt.Errorf("upsert %s: table has no primary key", table)
+	}
+
+	onConflict := `DO NOTHING`
+	if len(set) > 0 {
+		onConflict = `DO UPDATE SET ` + strings.Join(set, ", ")
+	}
+	tag, err := db.Exec(ctx, fmt.Sprintf(
+		`INSERT INTO public.%[1]s SELECT r.* FROM jsonb_populate_recordset(NULL::public.%[1]s, $1::jsonb) r
+		 ON CONFLICT (%[2]s) %[3]s`,
+		quoteIdent(table), strings.Join(pk, ", "), onConflict), "["+strings.Join(rows, ",")+"]")
+	if err != nil {
+		return 0, fmt.Errorf("upsert %s: %w", table, err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,361 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrSnapshotNotFound      = apperr.NotFound("workspace snapshot not found in workspace", "snapshot not found")
+	ErrSnapshotJobActive     = apperr.Conflict("workspace already has a pending or running snapshot job", "another snapshot job is in progress for this workspace")
+	ErrSnapshotUnknownEntity = errors.New("snapshot contains an unknown entity")
+)
+
+// SnapshotRepository persiste os jobs de snapshot e lê/grava os dados do workspace
+// exportados por eles.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type SnapshotRepository struct {
+	pool database.DB
+}
+
+func NewSnapshotRepository(pool database.DB) *SnapshotRepository {
+	return &SnapshotRepository{pool: pool}
+}
+
+const snapshotColumns = `id, "workspaceId", kind, status, "objectKey", "sizeBytes", "entityCounts",
+	error, "requestedById", "createdAt", "updatedAt", "startedAt", "completedAt"`
+
+// Create insere um job PENDING.
+func (r *SnapshotRepository) Create(ctx context.Context, s *domain.WorkspaceSnapshot) (*domain.WorkspaceSnapshot, error) {
+	query := `
+		INSERT INTO public."WorkspaceSnapshot" (id, "workspaceId", kind, status, "objectKey", "requestedById")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + snapshotColumns
+
+	created, err := scanSnapshot(r.pool.QueryRow(ctx, query,
+		s.ID, s.WorkspaceID, s.Kind, domain.SnapshotStatusPending, s.ObjectKey, s.RequestedByID,
+	))
+	if err != nil {
+		if isActiveSnapshotViolation(err) {
+			return nil, ErrSnapshotJobActive
+		}
+		return nil, fmt.Errorf("insert workspace snapshot: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um job do workspace.
+func (r *SnapshotRepository) Get(ctx context.Context, workspaceID, snapshotID string) (*domain.WorkspaceSnapshot, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM public."WorkspaceSnapshot"
+		WHERE id = $1 AND "workspaceId" = $2`
+
+	s, err := scanSnapshot(r.pool.QueryRow(ctx, query, snapshotID, workspaceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSnapshotNotFound
+		}
+		return nil, fmt.Errorf("query workspace snapshot: %w", err)
+	}
+	return s, nil
+}
+
+// List retorna os jobs do workspace, mais recentes primeiro.
+func (r *SnapshotRepository) List(ctx context.Context, params domain.ListSnapshotsParams) ([]domain.WorkspaceSnapshot, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM public."WorkspaceSnapshot"
+		WHERE "workspaceId" = $1
+		ORDER BY "createdAt" DESC, id
+		LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, params.WorkspaceID, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("query workspace snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []domain.WorkspaceSnapshot{}
+	for rows.Next() {
+		s, err := scanSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan workspace snapshot: %w", err)
+		}
+		snapshots = append(snapshots, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate workspace snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// ClaimNext marca como RUNNING o job PENDING mais antigo (ou um RUNNING iniciado antes de
+// staleBefore, abandonado por um worker que caiu) e o retorna. nil quando a fila está vazia.
+// SKIP LOCKED permite vários workers sem disputar o mesmo job.
+func (r *SnapshotRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.WorkspaceSnapshot, error) {
+	query := `
+		UPDATE public."WorkspaceSnapshot"
+		SET status = 'RUNNING', "startedAt" = NOW(), "updatedAt" = NOW(), error = NULL
+		WHERE id = (
+			SELECT id FROM public."WorkspaceSnapshot"
+			WHERE status = 'PENDING' OR (status = 'RUNNING' AND "startedAt" < $1)
+			ORDER BY "createdAt"
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + snapshotColumns
+
+	s, err := scanSnapshot(r.pool.QueryRow(ctx, query, staleBefore))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim workspace snapshot: %w", err)
+	}
+	return s, nil
+}
+
+// Complete finaliza o job com as contagens por entidade (e o tamanho do arquivo, na exportação).
+func (r *SnapshotRepository) Complete(ctx context.Context, snapshotID string, sizeBytes *int64, counts map[string]int64) error {
+	countsJSON, err := json.Marshal(counts)
+	if err != nil {
+		return fmt.Errorf("marshal entity counts: %w", err)
+	}
+	_, err = r.pool.Exec(ctx, `
+		UPDATE public."WorkspaceSnapshot"
+		SET status = 'COMPLETED', "sizeBytes" = $2, "entityCounts" = $3::jsonb,
+		    "completedAt" = NOW(), "updatedAt" = NOW()
+		WHERE id = $1`,
+		snapshotID, sizeBytes, string(countsJSON),
+	)
+	if err != nil {
+		return fmt.Errorf("complete workspace snapshot: %w", err)
+	}
+	return nil
+}
+
+// Fail finaliza o job com a mensagem de erro.
+func (r *SnapshotRepository) Fail(ctx context.Context, snapshotID, reason string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE public."WorkspaceSnapshot"
+		SET status = 'FAILED', error = $2, "completedAt" = NOW(), "updatedAt" = NOW()
+		WHERE id = $1`,
+		snapshotID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("fail workspace snapshot: %w", err)
+	}
+	return nil
+}
+
+// snapshotEntity descreve como exportar e restaurar uma tabela do workspace.
+// Tabelas de junção não têm coluna de workspace: o escopo vem da entidade pai
+// (scope na exportação, guard na restauração, ambos com o workspace em $1/$2).
+type snapshotEntity struct {
+	table    string
+	wsColumn string // coluna sobrescrita com o workspace de destino; "" = tabela de junção
+	scope    string // filtro da exportação ($1 = workspace)
+	guard    string // filtro da restauração sobre r ($2 = workspace)
+}
+
+func workspaceEntity(table, wsColumn string) snapshotEntity {
+	return snapshotEntity{
+		table:    table,
+		wsColumn: wsColumn,
+		scope:    `"` + wsColumn + `" = $1`,
+		guard:    "TRUE",
+	}
+}
+
+func joinEntity(table, parentColumn, parentTable string) snapshotEntity {
+	return snapshotEntity{
+		table: table,
+		scope: `"` + parentColumn + `" IN (SELECT id FROM public."` + parentTable + `" WHERE "workspaceId" = $1)`,
+		guard: `EXISTS (SELECT 1 FROM public."` + parentTable + `" p WHERE p.id = r."` + parentColumn + `" AND p."workspaceId" = $2)`,
+	}
+}
+
+// snapshotEntities em ordem de dependência: a restauração insere nessa ordem.
+// Ficam de fora membros (WorkspaceMember), auditoria, notificações, chaves de idempotência e os próprios jobs.
+var snapshotEntities = []snapshotEntity{
+	workspaceEntity("Company", "workspaceId"),
+	workspaceEntity("Contact", "workspaceId"),
+	workspaceEntity("Tag", "workspaceId"),
+	workspaceEntity("Pipeline", "workspaceId"),
+	workspaceEntity("PipelineStage", "workspaceId"),
+	workspaceEntity("Deal", "workspaceId"),
+	joinEntity("CompanyTag", "tagId", "Tag"),
+	joinEntity("ContactTag", "tagId", "Tag"),
+	joinEntity("DealTag", "tagId", "Tag"),
+	workspaceEntity("DealStageHistory", "workspaceId"),
+	workspaceEntity("DealParticipant", "workspaceId"),
+	workspaceEntity("DealSplit", "workspaceId"),
+	workspaceEntity("Task", "workspace_id"), // NOTE: colunas snake_case, como lidas pelo TaskRepository
+	workspaceEntity("Activity", "workspaceId"),
+	workspaceEntity("Note", "workspaceId"),
+	workspaceEntity("Message", "workspaceId"),
+	workspaceEntity("Email", "workspaceId"),
+	workspaceEntity("Call", "workspaceId"),
+	workspaceEntity("Meeting", "workspaceId"),
+	joinEntity("MeetingAttendee", "meetingId", "Meeting"),
+	workspaceEntity("ActivityParticipant", "workspaceId"),
+	workspaceEntity("PortfolioItem", "workspaceId"),
+	workspaceEntity("EmailTemplate", "workspaceId"),
+	workspaceEntity("Sequence", "workspaceId"),
+	workspaceEntity("SequenceEnrollment", "workspaceId"),
+	workspaceEntity("TimeEntry", "workspaceId"),
+	workspaceEntity("Follower", "workspaceId"),
+}
+
+// SnapshotEntityNames lista as entidades exportadas, em ordem de restauração.
+func SnapshotEntityNames() []string {
+	names := make([]string, len(snapshotEntities))
+	for i, e := range snapshotEntities {
+		names[i] = e.table
+	}
+	return names
+}
+
+// ExportWorkspace lê todas as linhas do workspace como JSON (uma chamada de emit por linha).
+// Roda em um snapshot REPEATABLE READ somente leitura (TxManager.ReadSnapshot), então o export
+// é consistente entre as tabelas mesmo com escritas concorrentes.
+func (r *SnapshotRepository) ExportWorkspace(ctx context.Context, workspaceID string, emit func(entity string, row []byte) error) error {
+	snapshots := database.NewTxManager(r.pool)
+	return snapshots.ReadSnapshot(ctx, func(ctx context.Context) error {
+		for _, e := range snapshotEntities {
+			if err := exportEntity(ctx, snapshots, e, workspaceID, emit); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func exportEntity(ctx context.Context, db database.DB, e snapshotEntity, workspaceID string, emit func(entity string, row []byte) error) error {
+	query := `SELECT row_to_json(t)::text FROM public."` + e.table + `" t WHERE ` + e.scope + ` ORDER BY id`
+
+	rows, err := db.Query(ctx, query, workspaceID)
+	if err != nil {
+		return fmt.Errorf("query %s: %w", e.table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("scan %s: %w", e.table, err)
+		}
+		if err := emit(e.table, row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate %s: %w", e.table, err)
+	}
+	return nil
+}
+
+// SnapshotRestore é uma restauração em andamento: todas as linhas entram em uma única
+// transação, confirmada por Commit. IDs são preservados; linhas cujo id já existe
+// (em qualquer workspace) são ignoradas, então restaurar duas vezes é seguro.
+type SnapshotRestore struct {
+	tx          pgx.Tx
+	workspaceID string
+	entities    map[string]snapshotEntity
+	inserted    map[string]int64
+}
+
+// BeginRestore inicia a restauração no workspace de destino.
+func (r *SnapshotRepository) BeginRestore(ctx context.Context, workspaceID string) (*SnapshotRestore, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin restore: %w", err)
+	}
+	entities := make(map[string]snapshotEntity, len(snapshotEntities))
+	inserted := make(map[string]int64, len(snapshotEntities))
+	for _, e := range snapshotEntities {
+		entities[e.table] = e
+		inserted[e.table] = 0
+	}
+	return &SnapshotRestore{tx: tx, workspaceID: workspaceID, entities: entities, inserted: inserted}, nil
+}
+
+// Insert grava uma linha exportada, forçando a coluna de workspace para o destino.
+// Entidades fora de snapshotEntities são rejeitadas (o nome vira identificador SQL).
+func (s *SnapshotRestore) Insert(ctx context.Context, entity string, row []byte) error {
+	e, ok := s.entities[entity]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrSnapshotUnknownEntity, entity)
+	}
+
+	record := `$1::jsonb`
+	if e.wsColumn != "" {
+		record = `$1::jsonb || jsonb_build_object('` + e.wsColumn + `', $2::text)`
+	}
+	query := `
+		INSERT INTO public."` + e.table + `"
+		SELECT r.* FROM jsonb_populate_record(NULL::public."` + e.table + `", ` + record + `) r
+		WHERE ` + e.guard + `
+		ON CONFLICT DO NOTHING`
+
+	tag, err := s.tx.Exec(ctx, query, string(row), s.workspaceID)
+	if err != nil {
+		return fmt.Errorf("restore %s: %w", entity, err)
+	}
+	s.inserted[entity] += tag.RowsAffected()
+	return nil
+}
+
+// Inserted retorna quantas linhas foram inseridas por entidade.
+func (s *SnapshotRestore) Inserted() map[string]int64 {
+	return s.inserted
+}
+
+func (s *SnapshotRestore) Commit(ctx context.Context) error {
+	if err := s.tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit restore: %w", err)
+	}
+	return nil
+}
+
+// Rollback descarta a restauração; seguro após Commit.
+func (s *SnapshotRestore) Rollback(ctx context.Context) {
+	_ = s.tx.Rollback(ctx)
+}
+
+func isActiveSnapshotViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_active_snapshot_per_workspace"
+}
+
+// Scanners
+func scanSnapshot(row pgx.Row) (*domain.WorkspaceSnapshot, error) {
+	var s domain.WorkspaceSnapshot
+	var counts []byte
+	err := row.Scan(
+		&s.ID, &s.WorkspaceID, &s.Kind, &s.Status, &s.ObjectKey, &s.SizeBytes, &counts,
+		&s.Error, &s.RequestedByID, &s.CreatedAt, &s.UpdatedAt, &s.StartedAt, &s.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.EntityCounts = map[string]int64{}
+	if len(counts) > 0 {
+		if err := json.Unmarshal(counts, &s.EntityCounts); err != nil {
+			return nil, fmt.Errorf("decode entity counts: %w", err)
+		}
+	}
+	return &s, nil
+}
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Propagação do soft delete para os registros relacionados:
+//
+//   - Company -> Contact/Deal ("companyId"): detach. A referência é removida (como o ON DELETE
+//     SET NULL da FK num DELETE) e volta no restore; timeline e tags continuam apontando para a
+//     empresa e voltam com ela.
+//   - Contact -> Deal ("contactId") e Task (contact_id): detach, como acima.
+//   - Pipeline -> PipelineStage e Deal: cascade. Stages e negócios ativos são excluídos com o
+//     mesmo deletedAt do pipeline e restaurados com ele; um negócio excluído junto com o
+//     pipeline só é restaurado sozinho depois do pipeline (ErrDealPipelineDeleted).
+//   - PipelineStage -> Deal: block. O stage com negócios ativos não é excluído (ErrStageHasDeals).
+
+// softDeleteReference coluna de outra tabela que aponta para o registro excluído.
+type softDeleteReference struct {
+	childType       string // SoftDeleteDetachment."childType"
+	table           string
+	column          string // coluna da referência, já entre aspas quando camelCase
+	field           string // nome da referência na API (SoftDeleteDetachment.field)
+	workspaceColumn string
+	updatedColumn   string
+}
+
+// companyDetachedReferences referências removidas no soft delete de uma empresa.
+var companyDetachedReferences = []softDeleteReference{
+	{childType: "contact", table: "Contact", column: `"companyId"`, field: "companyId", workspaceColumn: `"workspaceId"`, updatedColumn: `"updatedAt"`},
+	{childType: "deal", table: "Deal", column: `"companyId"`, field: "companyId", workspaceColumn: `"workspaceId"`, updatedColumn: `"updatedAt"`},
+}
+
+// contactDetachedReferences referências removidas no soft delete de um contato.
+// NOTE: colunas snake_case em Task, como lidas pelo TaskRepository
+var contactDetachedReferences = []softDeleteReference{
+	{childType: "deal", table: "Deal", column: `"contactId"`, field: "contactId", workspaceColumn: `"workspaceId"`, updatedColumn: `"updatedAt"`},
+	{childType: "task", table: "Task", column: "contact_id", field: "contactId", workspaceColumn: "workspace_id", updatedColumn: "updated_at"},
+}
+
+// detachReferences remove as referências para parentID (inclusive de registros já excluídos,
+// que poderiam ser restaurados antes do pai) e guarda cada uma em SoftDeleteDetachment.
+// Roda na transação do soft delete do pai.
+func detachReferences(ctx context.Context, tx pgx.Tx, workspaceID, parentType, parentID string, refs []softDeleteReference, at time.Time) error {
+	for _, ref := range refs {
+		_, err := tx.Exec(ctx, `
+			WITH detached AS (
+				UPDATE public."`+ref.table+`"
+				SET `+ref.column+` = NULL, `+ref.updatedColumn+` = $4
+				WHERE `+ref.workspaceColumn+` = $1 AND `+ref.column+` = $3
+				RETURNING id
+			)
+			INSERT INTO public."SoftDeleteDetachment" ("workspaceId", "parentType", "parentId", "childType", "childId", field, "detachedAt")
+			SELECT $1, $2, $3, $5, id, $6, $4 FROM detached
+			ON CONFLICT ("parentId", "childType", "childId", field)
+			DO UPDATE SET "detachedAt" = EXCLUDED."detachedAt"`,
+			workspaceID, parentType, parentID, at.UTC(), ref.childType, ref.field,
+		)
+		if err != nil {
+			return fmt.Errorf("detach %s references: %w", ref.table, err)
+		}
+	}
+	return nil
+}
+
+// reattachReferences devolve as referências removidas por detachReferences. Registros que
+// ganharam outra referência enquanto o pai estava excluído não são alterados. Roda na
+// transação do restore do pai.
+func reattachReferences(ctx context.Context, tx pgx.Tx, workspaceID, parentType, parentID string, refs []softDeleteReference) error {
+	for _, ref := range refs {
+		_, err := tx.Exec(ctx, `
+			UPDATE public."`+ref.table+`" c
+			SET `+ref.column+` = d."parentId", `+ref.updatedColumn+` = NOW()
+			FROM public."SoftDeleteDetachment" d
+			WHERE d."workspaceId" = $1 AND d."parentType" = $2 AND d."parentId" = $3
+			  AND d."childType" = $4 AND d.field = $5
+			  AND c.id = d."childId" AND c.`+ref.column+` IS NULL`,
+			workspaceID, parentType, parentID, ref.childType, ref.field,
+		)
+		if err != nil {
+			return fmt.Errorf("reattach %s references: %w", ref.table, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM public."SoftDeleteDetachment"
+		WHERE "workspaceId" = $1 AND "parentType" = $2 AND "parentId" = $3`,
+		workspaceID, parentType, parentID,
+	); err != nil {
+		return fmt.Errorf("delete detachments: %w", err)
+	}
+	return nil
+}
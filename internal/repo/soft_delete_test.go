@@ -0,0 +1,151 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Propagação do soft delete (ver soft_delete.go): detach de contatos/negócios/tarefas de
+// empresas e contatos excluídos, cascade de stages e negócios do pipeline e block do stage
+// com negócios.
+//
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run SoftDelete
+
+func TestCompanySoftDelete_DetachesAndReattaches_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	companies := repo.NewCompanyRepository(pool)
+	contacts := repo.NewContactRepository(pool)
+	deals := repo.NewDealRepository(pool)
+
+	company := f.Company()
+	other := f.Company()
+	contact := f.Contact(func(c *domain.Contact) { c.CompanyID = &company.ID })
+	moved := f.Contact(func(c *domain.Contact) { c.CompanyID = &company.ID })
+	deal := f.Deal(func(d *domain.Deal) { d.CompanyID = &company.ID })
+
+	require.NoError(t, companies.SoftDelete(ctx, f.WorkspaceID, company.ID, f.UserID))
+
+	got, err := contacts.Get(ctx, f.WorkspaceID, contact.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.CompanyID, "contact still points to the deleted company")
+	gotDeal, err := deals.Get(ctx, f.WorkspaceID, deal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gotDeal.CompanyID, "deal still points to the deleted company")
+
+	// Reassociado a outra empresa enquanto a original estava na lixeira: o restore não mexe
+	_, err = pool.Exec(ctx, `UPDATE public."Contact" SET "companyId" = $2 WHERE id = $1`, moved.ID, other.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, companies.Restore(ctx, f.WorkspaceID, company.ID))
+
+	got, err = contacts.Get(ctx, f.WorkspaceID, contact.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.CompanyID)
+	assert.Equal(t, company.ID, *got.CompanyID)
+	gotDeal, err = deals.Get(ctx, f.WorkspaceID, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, gotDeal.CompanyID)
+	assert.Equal(t, company.ID, *gotDeal.CompanyID)
+	got, err = contacts.Get(ctx, f.WorkspaceID, moved.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.CompanyID)
+	assert.Equal(t, other.ID, *got.CompanyID)
+
+	var left int
+	require.NoError(t, pool.QueryRow(ctx, `
+		SELECT count(*) FROM public."SoftDeleteDetachment" WHERE "parentId" = $1`, company.ID).Scan(&left))
+	assert.Zero(t, left, "detachments are removed on restore")
+
+	assert.ErrorIs(t, companies.SoftDelete(ctx, f.WorkspaceID, "cmp_missing", f.UserID), repo.ErrCompanyNotFound)
+}
+
+func TestContactSoftDelete_DetachesDealsAndTasks_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	contacts := repo.NewContactRepository(pool)
+	deals := repo.NewDealRepository(pool)
+	tasks := repo.NewTaskRepository(pool)
+
+	contact := f.Contact()
+	deal := f.Deal(func(d *domain.Deal) { d.ContactID = &contact.ID })
+	task := f.Task(func(tk *domain.Task) { tk.ContactID = &contact.ID })
+
+	require.NoError(t, contacts.SoftDelete(ctx, f.WorkspaceID, contact.ID, f.UserID))
+
+	gotDeal, err := deals.Get(ctx, f.WorkspaceID, deal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gotDeal.ContactID)
+	gotTask, err := tasks.Get(ctx, f.WorkspaceID, task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gotTask.ContactID)
+
+	require.NoError(t, contacts.Restore(ctx, f.WorkspaceID, contact.ID))
+
+	gotDeal, err = deals.Get(ctx, f.WorkspaceID, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, gotDeal.ContactID)
+	assert.Equal(t, contact.ID, *gotDeal.ContactID)
+	gotTask, err = tasks.Get(ctx, f.WorkspaceID, task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, gotTask.ContactID)
+	assert.Equal(t, contact.ID, *gotTask.ContactID)
+}
+
+func TestPipelineSoftDelete_CascadesStagesAndDeals_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	pipelines := repo.NewPipelineRepository(pool)
+	deals := repo.NewDealRepository(pool)
+
+	pipeline := f.Pipeline()
+	deal := f.Deal(func(d *domain.Deal) {
+		d.PipelineID = pipeline.ID
+		d.StageID = &pipeline.Stages[0].ID
+	})
+	// Excluído antes, sozinho: continua excluído depois do restore do pipeline
+	removedStage := pipeline.Stages[2].ID
+	require.NoError(t, pipelines.SoftDeleteStage(ctx, removedStage))
+
+	require.NoError(t, pipelines.SoftDelete(ctx, f.WorkspaceID, pipeline.ID, f.UserID))
+
+	_, err := pipelines.GetStage(ctx, pipeline.Stages[0].ID)
+	assert.ErrorIs(t, err, repo.ErrStageNotFound)
+	_, err = deals.Get(ctx, f.WorkspaceID, deal.ID)
+	assert.ErrorIs(t, err, repo.ErrDealNotFound)
+	assert.ErrorIs(t, deals.Restore(ctx, f.WorkspaceID, deal.ID), repo.ErrDealPipelineDeleted)
+
+	require.NoError(t, pipelines.Restore(ctx, f.WorkspaceID, pipeline.ID))
+
+	_, err = pipelines.GetStage(ctx, pipeline.Stages[0].ID)
+	assert.NoError(t, err)
+	_, err = pipelines.GetStage(ctx, removedStage)
+	assert.ErrorIs(t, err, repo.ErrStageNotFound)
+	_, err = deals.Get(ctx, f.WorkspaceID, deal.ID)
+	assert.NoError(t, err)
+}
+
+func TestStageSoftDelete_BlockedByDeals_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	pipelines := repo.NewPipelineRepository(pool)
+
+	deal := f.Deal()
+
+	assert.ErrorIs(t, pipelines.SoftDeleteStage(ctx, *deal.StageID), repo.ErrStageHasDeals)
+
+	_, err := pool.Exec(ctx, `UPDATE public."Deal" SET "deletedAt" = NOW() WHERE id = $1`, deal.ID)
+	require.NoError(t, err)
+	assert.NoError(t, pipelines.SoftDeleteStage(ctx, *deal.StageID))
+	assert.ErrorIs(t, pipelines.SoftDeleteStage(ctx, *deal.StageID), repo.ErrStageNotFound)
+}
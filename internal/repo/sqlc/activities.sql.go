@@ -11,7 +11,72 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createActivity = `-- name: CreateActivity :one
+const CountActivitiesByTypeSince = `-- name: CountActivitiesByTypeSince :many
+SELECT "activityType", COUNT(*) AS total, MAX("createdAt")::TIMESTAMP AS latest_at
+FROM "Activity"
+WHERE "workspaceId" = $1
+    AND "createdAt" > COALESCE($2::TIMESTAMP, '-infinity')
+    AND ($3::TEXT IS NULL OR "contactId" = $3)
+    AND ($4::TEXT IS NULL OR "companyId" = $4)
+    AND ($5::TEXT IS NULL OR "dealId" = $5)
+    AND ($6::TEXT IS NULL OR "Activity"."activityType" <> 'NOTE' OR NOT EXISTS (
+        SELECT 1 FROM "Note" vn
+        WHERE vn.id = "Activity"."activityId" AND vn."visibility" <> 'WORKSPACE' AND vn."userId" <> $6
+            AND NOT (vn."visibility" = 'TEAM' AND (
+                EXISTS (SELECT 1 FROM "Follower" f WHERE f."userId" = $6
+                    AND ((f."entityType" = 'CONTACT' AND f."entityId" = vn."contactId") OR (f."entityType" = 'DEAL' AND f."entityId" = vn."dealId")))
+                OR EXISTS (SELECT 1 FROM "Contact" c WHERE c.id = vn."contactId" AND c."ownerId" = $6)
+                OR EXISTS (SELECT 1 FROM "Deal" d WHERE d.id = vn."dealId" AND d."ownerId" = $6)
+            ))
+    ))
+GROUP BY "activityType"
+ORDER BY "activityType"
+`
+
+type CountActivitiesByTypeSinceParams struct {
+	WorkspaceId string           `json:"workspaceId"`
+	Since       pgtype.Timestamp `json:"since"`
+	ContactId   *string          `json:"contactId"`
+	CompanyId   *string          `json:"companyId"`
+	DealId      *string          `json:"dealId"`
+	ViewerId    *string          `json:"viewerId"`
+}
+
+type CountActivitiesByTypeSinceRow struct {
+	ActivityType ActivityType     `json:"activityType"`
+	Total        int64            `json:"total"`
+	LatestAt     pgtype.Timestamp `json:"latestAt"`
+}
+
+// Contagem por tipo para o digest da timeline (somente atividades posteriores ao cursor).
+func (q *Queries) CountActivitiesByTypeSince(ctx context.Context, arg CountActivitiesByTypeSinceParams) ([]CountActivitiesByTypeSinceRow, error) {
+	rows, err := q.db.Query(ctx, CountActivitiesByTypeSince,
+		arg.WorkspaceId,
+		arg.Since,
+		arg.ContactId,
+		arg.CompanyId,
+		arg.DealId,
+		arg.ViewerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountActivitiesByTypeSinceRow{}
+	for rows.Next() {
+		var i CountActivitiesByTypeSinceRow
+		if err := rows.Scan(&i.ActivityType, &i.Total, &i.LatestAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const CreateActivity = `-- name: CreateActivity :one
 INSERT INTO "Activity" (
     id, "workspaceId", "companyId", "contactId", "dealId",
     "activityType", "activityId", "userId", metadata
@@ -33,7 +98,7 @@ type CreateActivityParams struct {
 }
 
 func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams) (Activity, error) {
-	row := q.db.QueryRow(ctx, createActivity,
+	row := q.db.QueryRow(ctx, CreateActivity,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.CompanyId,
@@ -60,7 +125,7 @@ func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams)
 	return i, err
 }
 
-const createCall = `-- name: CreateCall :one
+const CreateCall = `-- name: CreateCall :one
 INSERT INTO "Call" (
     id, "workspaceId", "contactId", "companyId",
     direction, duration, "recordingUrl", summary, "userId", "calledAt"
@@ -83,7 +148,7 @@ type CreateCallParams struct {
 }
 
 func (q *Queries) CreateCall(ctx context.Context, arg CreateCallParams) (Call, error) {
-	row := q.db.QueryRow(ctx, createCall,
+	row := q.db.QueryRow(ctx, CreateCall,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.ContactId,
@@ -112,7 +177,7 @@ func (q *Queries) CreateCall(ctx context.Context, arg CreateCallParams) (Call, e
 	return i, err
 }
 
-const createMeeting = `-- name: CreateMeeting :one
+const CreateMeeting = `-- name: CreateMeeting :one
 INSERT INTO "Meeting" (
     id, "workspaceId", title, description, "meetingType",
     "startTime", "endTime", location, "meetingUrl", "externalId", "userId"
@@ -136,7 +201,7 @@ type CreateMeetingParams struct {
 }
 
 func (q *Queries) CreateMeeting(ctx context.Context, arg CreateMeetingParams) (Meeting, error) {
-	row := q.db.QueryRow(ctx, createMeeting,
+	row := q.db.QueryRow(ctx, CreateMeeting,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.Title,
@@ -169,7 +234,7 @@ func (q *Queries) CreateMeeting(ctx context.Context, arg CreateMeetingParams) (M
 	return i, err
 }
 
-const createMessage = `-- name: CreateMessage :one
+const CreateMessage = `-- name: CreateMessage :one
 INSERT INTO "Message" (
     id, "workspaceId", "contactId", "companyId",
     direction, platform, content, status, "sentAt", "userId"
@@ -192,7 +257,7 @@ type CreateMessageParams struct {
 }
 
 func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error) {
-	row := q.db.QueryRow(ctx, createMessage,
+	row := q.db.QueryRow(ctx, CreateMessage,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.ContactId,
@@ -221,13 +286,13 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 	return i, err
 }
 
-const createNote = `-- name: CreateNote :one
+const CreateNote = `-- name: CreateNote :one
 INSERT INTO "Note" (
     id, "workspaceId", "companyId", "contactId", "dealId",
-    content, "isPinned", "userId"
+    content, "isPinned", "userId", body, "visibility"
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, "workspaceId", "companyId", "contactId", "dealId", content, "isPinned", "userId", "deletedAt", "createdAt", "updatedAt"
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, "workspaceId", "companyId", "contactId", "dealId", content, "isPinned", "userId", "deletedAt", "createdAt", "updatedAt", body, "pinnedAt", "pinnedById", visibility
 `
 
 type CreateNoteParams struct {
@@ -239,10 +304,12 @@ type CreateNoteParams struct {
 	Content     string  `json:"content"`
 	IsPinned    bool    `json:"isPinned"`
 	UserId      string  `json:"userId"`
+	Body        []byte  `json:"body"`
+	Visibility  string  `json:"visibility"`
 }
 
 func (q *Queries) CreateNote(ctx context.Context, arg CreateNoteParams) (Note, error) {
-	row := q.db.QueryRow(ctx, createNote,
+	row := q.db.QueryRow(ctx, CreateNote,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.CompanyId,
@@ -251,6 +318,8 @@ func (q *Queries) CreateNote(ctx context.Context, arg CreateNoteParams) (Note, e
 		arg.Content,
 		arg.IsPinned,
 		arg.UserId,
+		arg.Body,
+		arg.Visibility,
 	)
 	var i Note
 	err := row.Scan(
@@ -265,40 +334,82 @@ func (q *Queries) CreateNote(ctx context.Context, arg CreateNoteParams) (Note, e
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Body,
+		&i.PinnedAt,
+		&i.PinnedById,
+		&i.Visibility,
 	)
 	return i, err
 }
 
-const listActivities = `-- name: ListActivities :many
-SELECT id, "workspaceId", "companyId", "contactId", "dealId", "activityType", "activityId", "userId", metadata, "createdAt" FROM "Activity"
-WHERE "workspaceId" = $1
-    AND ($2::TEXT IS NULL OR "contactId" = $2)
-    AND ($3::TEXT IS NULL OR "companyId" = $3)
-    AND ($4::TEXT IS NULL OR "dealId" = $4)
-ORDER BY "createdAt" DESC
+const ListActivities = `-- name: ListActivities :many
+SELECT a.id, a."workspaceId", a."companyId", a."contactId", a."dealId", a."activityType", a."activityId", a."userId", a.metadata, a."createdAt", n."pinnedAt"
+FROM "Activity" a
+LEFT JOIN "Note" n ON a."activityType" = 'NOTE' AND n.id = a."activityId" AND n."deletedAt" IS NULL
+WHERE a."workspaceId" = $1
+    AND ($2::TEXT IS NULL OR a."contactId" = $2)
+    AND ($3::TEXT IS NULL OR a."companyId" = $3)
+    AND ($4::TEXT IS NULL OR a."dealId" = $4)
+    AND ($5::TEXT IS NULL OR a."activityType" <> 'NOTE' OR NOT EXISTS (
+        SELECT 1 FROM "Note" vn
+        WHERE vn.id = a."activityId" AND vn."visibility" <> 'WORKSPACE' AND vn."userId" <> $5
+            AND NOT (vn."visibility" = 'TEAM' AND (
+                EXISTS (SELECT 1 FROM "Follower" f WHERE f."userId" = $5
+                    AND ((f."entityType" = 'CONTACT' AND f."entityId" = vn."contactId") OR (f."entityType" = 'DEAL' AND f."entityId" = vn."dealId")))
+                OR EXISTS (SELECT 1 FROM "Contact" c WHERE c.id = vn."contactId" AND c."ownerId" = $5)
+                OR EXISTS (SELECT 1 FROM "Deal" d WHERE d.id = vn."dealId" AND d."ownerId" = $5)
+            ))
+    ))
+    AND ($6::TEXT IS NULL OR EXISTS (
+        SELECT 1 FROM "ActivityParticipant" ap
+        WHERE ap."workspaceId" = a."workspaceId" AND ap."activityId" = a."activityId"
+            AND (ap."userId" = $6 OR ap."contactId" = $6)
+    ))
+    AND ($7::TEXT IS NULL OR a."activityType"::TEXT = $7)
+ORDER BY n."pinnedAt" DESC NULLS LAST, a."createdAt" DESC
 `
 
 type ListActivitiesParams struct {
-	WorkspaceId string  `json:"workspaceId"`
-	ContactId   *string `json:"contactId"`
-	CompanyId   *string `json:"companyId"`
-	DealId      *string `json:"dealId"`
+	WorkspaceId   string  `json:"workspaceId"`
+	ContactId     *string `json:"contactId"`
+	CompanyId     *string `json:"companyId"`
+	DealId        *string `json:"dealId"`
+	ViewerId      *string `json:"viewerId"`
+	ParticipantId *string `json:"participantId"`
+	ActivityType  *string `json:"activityType"`
+}
+
+type ListActivitiesRow struct {
+	ID           string           `json:"id"`
+	WorkspaceId  string           `json:"workspaceId"`
+	CompanyId    *string          `json:"companyId"`
+	ContactId    *string          `json:"contactId"`
+	DealId       *string          `json:"dealId"`
+	ActivityType ActivityType     `json:"activityType"`
+	ActivityId   *string          `json:"activityId"`
+	UserId       string           `json:"userId"`
+	Metadata     []byte           `json:"metadata"`
+	CreatedAt    pgtype.Timestamp `json:"createdAt"`
+	PinnedAt     pgtype.Timestamp `json:"pinnedAt"`
 }
 
-func (q *Queries) ListActivities(ctx context.Context, arg ListActivitiesParams) ([]Activity, error) {
-	rows, err := q.db.Query(ctx, listActivities,
+func (q *Queries) ListActivities(ctx context.Context, arg ListActivitiesParams) ([]ListActivitiesRow, error) {
+	rows, err := q.db.Query(ctx, ListActivities,
 		arg.WorkspaceId,
 		arg.ContactId,
 		arg.CompanyId,
 		arg.DealId,
+		arg.ViewerId,
+		arg.ParticipantId,
+		arg.ActivityType,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Activity{}
+	items := []ListActivitiesRow{}
 	for rows.Next() {
-		var i Activity
+		var i ListActivitiesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.WorkspaceId,
@@ -310,6 +421,93 @@ func (q *Queries) ListActivities(ctx context.Context, arg ListActivitiesParams)
 			&i.UserId,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.PinnedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListLatestActivitiesByTypeSince = `-- name: ListLatestActivitiesByTypeSince :many
+SELECT id, "workspaceId", "companyId", "contactId", "dealId", "activityType", "activityId", "userId", "createdAt"
+FROM (
+    SELECT id, "workspaceId", "companyId", "contactId", "dealId", "activityType", "activityId", "userId", metadata, "createdAt", ROW_NUMBER() OVER (PARTITION BY "activityType" ORDER BY "createdAt" DESC, id DESC) AS rn
+    FROM "Activity"
+    WHERE "workspaceId" = $1
+        AND "createdAt" > COALESCE($2::TIMESTAMP, '-infinity')
+        AND ($3::TEXT IS NULL OR "contactId" = $3)
+        AND ($4::TEXT IS NULL OR "companyId" = $4)
+        AND ($5::TEXT IS NULL OR "dealId" = $5)
+        AND ($6::TEXT IS NULL OR "Activity"."activityType" <> 'NOTE' OR NOT EXISTS (
+            SELECT 1 FROM "Note" vn
+            WHERE vn.id = "Activity"."activityId" AND vn."visibility" <> 'WORKSPACE' AND vn."userId" <> $6
+                AND NOT (vn."visibility" = 'TEAM' AND (
+                    EXISTS (SELECT 1 FROM "Follower" f WHERE f."userId" = $6
+                        AND ((f."entityType" = 'CONTACT' AND f."entityId" = vn."contactId") OR (f."entityType" = 'DEAL' AND f."entityId" = vn."dealId")))
+                    OR EXISTS (SELECT 1 FROM "Contact" c WHERE c.id = vn."contactId" AND c."ownerId" = $6)
+                    OR EXISTS (SELECT 1 FROM "Deal" d WHERE d.id = vn."dealId" AND d."ownerId" = $6)
+                ))
+        ))
+) latest
+WHERE rn <= $7::INT
+ORDER BY "activityType", "createdAt" DESC, id DESC
+`
+
+type ListLatestActivitiesByTypeSinceParams struct {
+	WorkspaceId string           `json:"workspaceId"`
+	Since       pgtype.Timestamp `json:"since"`
+	ContactId   *string          `json:"contactId"`
+	CompanyId   *string          `json:"companyId"`
+	DealId      *string          `json:"dealId"`
+	ViewerId    *string          `json:"viewerId"`
+	PerType     int32            `json:"perType"`
+}
+
+type ListLatestActivitiesByTypeSinceRow struct {
+	ID           string           `json:"id"`
+	WorkspaceId  string           `json:"workspaceId"`
+	CompanyId    *string          `json:"companyId"`
+	ContactId    *string          `json:"contactId"`
+	DealId       *string          `json:"dealId"`
+	ActivityType ActivityType     `json:"activityType"`
+	ActivityId   *string          `json:"activityId"`
+	UserId       string           `json:"userId"`
+	CreatedAt    pgtype.Timestamp `json:"createdAt"`
+}
+
+// Últimas perType atividades de cada tipo posteriores ao cursor (sem metadata: payload mínimo).
+func (q *Queries) ListLatestActivitiesByTypeSince(ctx context.Context, arg ListLatestActivitiesByTypeSinceParams) ([]ListLatestActivitiesByTypeSinceRow, error) {
+	rows, err := q.db.Query(ctx, ListLatestActivitiesByTypeSince,
+		arg.WorkspaceId,
+		arg.Since,
+		arg.ContactId,
+		arg.CompanyId,
+		arg.DealId,
+		arg.ViewerId,
+		arg.PerType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLatestActivitiesByTypeSinceRow{}
+	for rows.Next() {
+		var i ListLatestActivitiesByTypeSinceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceId,
+			&i.CompanyId,
+			&i.ContactId,
+			&i.DealId,
+			&i.ActivityType,
+			&i.ActivityId,
+			&i.UserId,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
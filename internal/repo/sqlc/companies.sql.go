@@ -11,7 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const companyExistsInWorkspace = `-- name: CompanyExistsInWorkspace :one
+const CompanyExistsInWorkspace = `-- name: CompanyExistsInWorkspace :one
 SELECT EXISTS(
     SELECT 1
     FROM "Company"
@@ -27,13 +27,13 @@ type CompanyExistsInWorkspaceParams struct {
 }
 
 func (q *Queries) CompanyExistsInWorkspace(ctx context.Context, arg CompanyExistsInWorkspaceParams) (bool, error) {
-	row := q.db.QueryRow(ctx, companyExistsInWorkspace, arg.ID, arg.WorkspaceId)
+	row := q.db.QueryRow(ctx, CompanyExistsInWorkspace, arg.ID, arg.WorkspaceId)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
 }
 
-const createCompany = `-- name: CreateCompany :one
+const CreateCompany = `-- name: CreateCompany :one
 INSERT INTO "Company" (
     "id", "workspaceId", "name", "website", "linkedin",
     "legalName", "phone", "instagram", "policyUrl", "socialUrls",
@@ -41,7 +41,7 @@ INSERT INTO "Company" (
     "currency", "locale", "businessHours", "supportHours",
     "size", "revenue", "companyScore", "lifecycleStage",
     "assignedToId", "createdById", "updatedById",
-    "createdAt", "updatedAt"
+    "createdAt", "updatedAt", "externalId"
 ) VALUES (
     $1, $2, $3, $4, $5,
     $6, $7, $8, $9, $10,
@@ -49,7 +49,7 @@ INSERT INTO "Company" (
     $16, $17, $18, $19,
     $20, $21, $22, $23,
     $24, $25, $26,
-    $27, $28
+    $27, $28, $29
 )
 RETURNING 
     "id", "workspaceId", "name", "website", "linkedin",
@@ -90,6 +90,7 @@ type CreateCompanyParams struct {
 	UpdatedById    *string               `json:"updatedById"`
 	CreatedAt      pgtype.Timestamp      `json:"createdAt"`
 	UpdatedAt      pgtype.Timestamp      `json:"updatedAt"`
+	ExternalId     *string               `json:"externalId"`
 }
 
 type CreateCompanyRow struct {
@@ -126,7 +127,7 @@ type CreateCompanyRow struct {
 }
 
 func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (CreateCompanyRow, error) {
-	row := q.db.QueryRow(ctx, createCompany,
+	row := q.db.QueryRow(ctx, CreateCompany,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.Name,
@@ -155,6 +156,7 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 		arg.UpdatedById,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.ExternalId,
 	)
 	var i CreateCompanyRow
 	err := row.Scan(
@@ -192,7 +194,7 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 	return i, err
 }
 
-const getCompany = `-- name: GetCompany :one
+const GetCompany = `-- name: GetCompany :one
 
 SELECT 
     "id", "workspaceId", "name", "website", "linkedin",
@@ -201,7 +203,8 @@ SELECT
     "currency", "locale", "businessHours", "supportHours",
     "deletedAt", "deletedById", "size", "revenue",
     "companyScore", "lifecycleStage", "assignedToId",
-    "createdById", "updatedById", "createdAt", "updatedAt"
+    "createdById", "updatedById", "createdAt", "updatedAt",
+    "industry", "logoUrl", "customFields", "externalId"
 FROM "Company"
 WHERE "id" = $1
   AND "workspaceId" = $2
@@ -244,6 +247,10 @@ type GetCompanyRow struct {
 	UpdatedById    *string               `json:"updatedById"`
 	CreatedAt      pgtype.Timestamp      `json:"createdAt"`
 	UpdatedAt      pgtype.Timestamp      `json:"updatedAt"`
+	Industry       *string               `json:"industry"`
+	LogoUrl        *string               `json:"logoUrl"`
+	CustomFields   []byte                `json:"customFields"`
+	ExternalId     *string               `json:"externalId"`
 }
 
 // =====================================================
@@ -255,7 +262,7 @@ type GetCompanyRow struct {
 // ENUMs: CompanyLifecycleStage, CompanySize (UPPERCASE)
 // =====================================================
 func (q *Queries) GetCompany(ctx context.Context, arg GetCompanyParams) (GetCompanyRow, error) {
-	row := q.db.QueryRow(ctx, getCompany, arg.ID, arg.WorkspaceId)
+	row := q.db.QueryRow(ctx, GetCompany, arg.ID, arg.WorkspaceId)
 	var i GetCompanyRow
 	err := row.Scan(
 		&i.ID,
@@ -288,11 +295,15 @@ func (q *Queries) GetCompany(ctx context.Context, arg GetCompanyParams) (GetComp
 		&i.UpdatedById,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Industry,
+		&i.LogoUrl,
+		&i.CustomFields,
+		&i.ExternalId,
 	)
 	return i, err
 }
 
-const listCompanies = `-- name: ListCompanies :many
+const ListCompanies = `-- name: ListCompanies :many
 SELECT 
     "id", "workspaceId", "name", "website", "linkedin",
     "legalName", "phone", "instagram", "policyUrl", "socialUrls",
@@ -300,7 +311,8 @@ SELECT
     "currency", "locale", "businessHours", "supportHours",
     "deletedAt", "deletedById", "size", "revenue",
     "companyScore", "lifecycleStage", "assignedToId",
-    "createdById", "updatedById", "createdAt", "updatedAt"
+    "createdById", "updatedById", "createdAt", "updatedAt",
+    "industry", "logoUrl", "customFields", "externalId"
 FROM "Company"
 WHERE "workspaceId" = $1
   AND "deletedAt" IS NULL
@@ -308,19 +320,20 @@ WHERE "workspaceId" = $1
   AND ($3::TEXT IS NULL OR "size"::TEXT = $3)
   AND ($4::TEXT IS NULL OR "assignedToId" = $4)
   AND ($5::TEXT IS NULL OR to_tsvector('simple', "name" || ' ' || COALESCE("website", '')) @@ plainto_tsquery('simple', $5))
-  AND ($6::TIMESTAMP IS NULL OR "createdAt" < $6)
-ORDER BY "createdAt" DESC
-LIMIT $7
+  AND ($6::TIMESTAMP IS NULL OR ("createdAt", "id") < ($6, $7::TEXT))
+ORDER BY "createdAt" DESC, "id" DESC
+LIMIT $8
 `
 
 type ListCompaniesParams struct {
-	WorkspaceId string           `json:"workspaceId"`
-	Column2     string           `json:"column2"`
-	Column3     string           `json:"column3"`
-	Column4     string           `json:"column4"`
-	Column5     string           `json:"column5"`
-	Column6     pgtype.Timestamp `json:"column6"`
-	Limit       int32            `json:"limit"`
+	WorkspaceId    string           `json:"workspaceId"`
+	LifecycleStage *string          `json:"lifecycleStage"`
+	Size           *string          `json:"size"`
+	OwnerId        *string          `json:"ownerId"`
+	QueryText      *string          `json:"queryText"`
+	CursorTime     pgtype.Timestamp `json:"cursorTime"`
+	CursorId       string           `json:"cursorId"`
+	Limit          int32            `json:"limit"`
 }
 
 type ListCompaniesRow struct {
@@ -354,16 +367,21 @@ type ListCompaniesRow struct {
 	UpdatedById    *string               `json:"updatedById"`
 	CreatedAt      pgtype.Timestamp      `json:"createdAt"`
 	UpdatedAt      pgtype.Timestamp      `json:"updatedAt"`
+	Industry       *string               `json:"industry"`
+	LogoUrl        *string               `json:"logoUrl"`
+	CustomFields   []byte                `json:"customFields"`
+	ExternalId     *string               `json:"externalId"`
 }
 
 func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([]ListCompaniesRow, error) {
-	rows, err := q.db.Query(ctx, listCompanies,
+	rows, err := q.db.Query(ctx, ListCompanies,
 		arg.WorkspaceId,
-		arg.Column2,
-		arg.Column3,
-		arg.Column4,
-		arg.Column5,
-		arg.Column6,
+		arg.LifecycleStage,
+		arg.Size,
+		arg.OwnerId,
+		arg.QueryText,
+		arg.CursorTime,
+		arg.CursorId,
 		arg.Limit,
 	)
 	if err != nil {
@@ -404,6 +422,10 @@ func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([
 			&i.UpdatedById,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Industry,
+			&i.LogoUrl,
+			&i.CustomFields,
+			&i.ExternalId,
 		); err != nil {
 			return nil, err
 		}
@@ -415,7 +437,7 @@ func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([
 	return items, nil
 }
 
-const softDeleteCompany = `-- name: SoftDeleteCompany :exec
+const SoftDeleteCompany = `-- name: SoftDeleteCompany :exec
 UPDATE "Company"
 SET
     "deletedAt" = $3,
@@ -434,7 +456,7 @@ type SoftDeleteCompanyParams struct {
 }
 
 func (q *Queries) SoftDeleteCompany(ctx context.Context, arg SoftDeleteCompanyParams) error {
-	_, err := q.db.Exec(ctx, softDeleteCompany,
+	_, err := q.db.Exec(ctx, SoftDeleteCompany,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.DeletedAt,
@@ -443,7 +465,7 @@ func (q *Queries) SoftDeleteCompany(ctx context.Context, arg SoftDeleteCompanyPa
 	return err
 }
 
-const updateCompany = `-- name: UpdateCompany :one
+const UpdateCompany = `-- name: UpdateCompany :one
 UPDATE "Company"
 SET
     "name" = COALESCE($3, "name"),
@@ -468,6 +490,7 @@ SET
     "companyScore" = COALESCE($22, "companyScore"),
     "lifecycleStage" = COALESCE($23, "lifecycleStage"),
     "assignedToId" = COALESCE($24, "assignedToId"),
+    "externalId" = COALESCE($28, "externalId"),
     "updatedById" = $25,
     "updatedAt" = $26
 WHERE "id" = $1
@@ -512,6 +535,7 @@ type UpdateCompanyParams struct {
 	UpdatedById    *string               `json:"updatedById"`
 	UpdatedAt      pgtype.Timestamp      `json:"updatedAt"`
 	UpdatedAt_2    pgtype.Timestamp      `json:"updatedAt2"`
+	ExternalId     *string               `json:"externalId"`
 }
 
 type UpdateCompanyRow struct {
@@ -548,7 +572,7 @@ type UpdateCompanyRow struct {
 }
 
 func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (UpdateCompanyRow, error) {
-	row := q.db.QueryRow(ctx, updateCompany,
+	row := q.db.QueryRow(ctx, UpdateCompany,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.Name,
@@ -576,6 +600,7 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (U
 		arg.UpdatedById,
 		arg.UpdatedAt,
 		arg.UpdatedAt_2,
+		arg.ExternalId,
 	)
 	var i UpdateCompanyRow
 	err := row.Scan(
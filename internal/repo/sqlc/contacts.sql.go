@@ -11,7 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const contactExistsInWorkspace = `-- name: ContactExistsInWorkspace :one
+const ContactExistsInWorkspace = `-- name: ContactExistsInWorkspace :one
 SELECT EXISTS(
     SELECT 1
     FROM "Contact"
@@ -28,13 +28,58 @@ type ContactExistsInWorkspaceParams struct {
 
 // Verifica se um contato existe no workspace (usado por validações).
 func (q *Queries) ContactExistsInWorkspace(ctx context.Context, arg ContactExistsInWorkspaceParams) (bool, error) {
-	row := q.db.QueryRow(ctx, contactExistsInWorkspace, arg.ID, arg.WorkspaceId)
+	row := q.db.QueryRow(ctx, ContactExistsInWorkspace, arg.ID, arg.WorkspaceId)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
 }
 
-const createContact = `-- name: CreateContact :one
+const CountContactsByLifecycleStage = `-- name: CountContactsByLifecycleStage :many
+SELECT
+    "lifecycleStage",
+    COUNT(*) AS "total"
+FROM "Contact"
+WHERE "workspaceId" = $1
+  AND "deletedAt" IS NULL
+  AND ($2::TEXT IS NULL OR "ownerId" = $2)
+  AND ($3::TEXT IS NULL OR "companyId" = $3)
+GROUP BY "lifecycleStage"
+`
+
+type CountContactsByLifecycleStageParams struct {
+	WorkspaceId string  `json:"workspaceId"`
+	OwnerId     *string `json:"ownerId"`
+	CompanyId   *string `json:"companyId"`
+}
+
+type CountContactsByLifecycleStageRow struct {
+	LifecycleStage ContactLifecycleStage `json:"lifecycleStage"`
+	Total          int64                 `json:"total"`
+}
+
+// Conta contatos ativos por estágio do funil (relatório de lifecycle).
+// Filtros opcionais: ownerId, companyId.
+func (q *Queries) CountContactsByLifecycleStage(ctx context.Context, arg CountContactsByLifecycleStageParams) ([]CountContactsByLifecycleStageRow, error) {
+	rows, err := q.db.Query(ctx, CountContactsByLifecycleStage, arg.WorkspaceId, arg.OwnerId, arg.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountContactsByLifecycleStageRow{}
+	for rows.Next() {
+		var i CountContactsByLifecycleStageRow
+		if err := rows.Scan(&i.LifecycleStage, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const CreateContact = `-- name: CreateContact :one
 INSERT INTO "Contact" (
     "id",
     "fullName",
@@ -67,7 +112,16 @@ INSERT INTO "Contact" (
     "createdById",
     "updatedById",
     "createdAt",
-    "updatedAt"
+    "updatedAt",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "externalId"
 ) VALUES (
     $1,  -- id
     $2,  -- fullName
@@ -100,7 +154,16 @@ INSERT INTO "Contact" (
     $29, -- createdById
     $30, -- updatedById
     $31, -- createdAt
-    $32  -- updatedAt
+    $32, -- updatedAt
+    $33, -- sourceDetail
+    $34, -- utmSource
+    $35, -- utmMedium
+    $36, -- utmCampaign
+    $37, -- utmTerm
+    $38, -- utmContent
+    $39, -- nextStepAt
+    $40, -- nextStepNote
+    $41  -- externalId
 )
 RETURNING 
     "id",
@@ -136,7 +199,19 @@ RETURNING
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById"
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId"
 `
 
 type CreateContactParams struct {
@@ -172,6 +247,15 @@ type CreateContactParams struct {
 	UpdatedById       *string               `json:"updatedById"`
 	CreatedAt         pgtype.Timestamp      `json:"createdAt"`
 	UpdatedAt         pgtype.Timestamp      `json:"updatedAt"`
+	SourceDetail      *string               `json:"sourceDetail"`
+	UtmSource         *string               `json:"utmSource"`
+	UtmMedium         *string               `json:"utmMedium"`
+	UtmCampaign       *string               `json:"utmCampaign"`
+	UtmTerm           *string               `json:"utmTerm"`
+	UtmContent        *string               `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp      `json:"nextStepAt"`
+	NextStepNote      *string               `json:"nextStepNote"`
+	ExternalId        *string               `json:"externalId"`
 }
 
 type CreateContactRow struct {
@@ -209,11 +293,23 @@ type CreateContactRow struct {
 	UpdatedAt         pgtype.Timestamp      `json:"updatedAt"`
 	DeletedAt         pgtype.Timestamp      `json:"deletedAt"`
 	DeletedById       *string               `json:"deletedById"`
+	SourceDetail      *string               `json:"sourceDetail"`
+	UtmSource         *string               `json:"utmSource"`
+	UtmMedium         *string               `json:"utmMedium"`
+	UtmCampaign       *string               `json:"utmCampaign"`
+	UtmTerm           *string               `json:"utmTerm"`
+	UtmContent        *string               `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp      `json:"nextStepAt"`
+	NextStepNote      *string               `json:"nextStepNote"`
+	EmailStatus       *string               `json:"emailStatus"`
+	EmailStatusReason *string               `json:"emailStatusReason"`
+	EmailCheckedAt    pgtype.Timestamp      `json:"emailCheckedAt"`
+	ExternalId        *string               `json:"externalId"`
 }
 
 // Cria um novo contato no workspace (ID gerado pela aplicação).
 func (q *Queries) CreateContact(ctx context.Context, arg CreateContactParams) (CreateContactRow, error) {
-	row := q.db.QueryRow(ctx, createContact,
+	row := q.db.QueryRow(ctx, CreateContact,
 		arg.ID,
 		arg.FullName,
 		arg.WorkspaceId,
@@ -246,6 +342,15 @@ func (q *Queries) CreateContact(ctx context.Context, arg CreateContactParams) (C
 		arg.UpdatedById,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.SourceDetail,
+		arg.UtmSource,
+		arg.UtmMedium,
+		arg.UtmCampaign,
+		arg.UtmTerm,
+		arg.UtmContent,
+		arg.NextStepAt,
+		arg.NextStepNote,
+		arg.ExternalId,
 	)
 	var i CreateContactRow
 	err := row.Scan(
@@ -283,11 +388,23 @@ func (q *Queries) CreateContact(ctx context.Context, arg CreateContactParams) (C
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.DeletedById,
+		&i.SourceDetail,
+		&i.UtmSource,
+		&i.UtmMedium,
+		&i.UtmCampaign,
+		&i.UtmTerm,
+		&i.UtmContent,
+		&i.NextStepAt,
+		&i.NextStepNote,
+		&i.EmailStatus,
+		&i.EmailStatusReason,
+		&i.EmailCheckedAt,
+		&i.ExternalId,
 	)
 	return i, err
 }
 
-const getContact = `-- name: GetContact :one
+const GetContact = `-- name: GetContact :one
 
 SELECT 
     "id",
@@ -323,7 +440,19 @@ SELECT
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById"
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId"
 FROM "Contact"
 WHERE "id" = $1
   AND "workspaceId" = $2
@@ -370,6 +499,18 @@ type GetContactRow struct {
 	UpdatedAt         pgtype.Timestamp      `json:"updatedAt"`
 	DeletedAt         pgtype.Timestamp      `json:"deletedAt"`
 	DeletedById       *string               `json:"deletedById"`
+	SourceDetail      *string               `json:"sourceDetail"`
+	UtmSource         *string               `json:"utmSource"`
+	UtmMedium         *string               `json:"utmMedium"`
+	UtmCampaign       *string               `json:"utmCampaign"`
+	UtmTerm           *string               `json:"utmTerm"`
+	UtmContent        *string               `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp      `json:"nextStepAt"`
+	NextStepNote      *string               `json:"nextStepNote"`
+	EmailStatus       *string               `json:"emailStatus"`
+	EmailStatusReason *string               `json:"emailStatusReason"`
+	EmailCheckedAt    pgtype.Timestamp      `json:"emailCheckedAt"`
+	ExternalId        *string               `json:"externalId"`
 }
 
 // =====================================================
@@ -381,7 +522,7 @@ type GetContactRow struct {
 // =====================================================
 // Retorna um contato específico de um workspace (IDOR protection).
 func (q *Queries) GetContact(ctx context.Context, arg GetContactParams) (GetContactRow, error) {
-	row := q.db.QueryRow(ctx, getContact, arg.ID, arg.WorkspaceId)
+	row := q.db.QueryRow(ctx, GetContact, arg.ID, arg.WorkspaceId)
 	var i GetContactRow
 	err := row.Scan(
 		&i.ID,
@@ -418,11 +559,23 @@ func (q *Queries) GetContact(ctx context.Context, arg GetContactParams) (GetCont
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.DeletedById,
+		&i.SourceDetail,
+		&i.UtmSource,
+		&i.UtmMedium,
+		&i.UtmCampaign,
+		&i.UtmTerm,
+		&i.UtmContent,
+		&i.NextStepAt,
+		&i.NextStepNote,
+		&i.EmailStatus,
+		&i.EmailStatusReason,
+		&i.EmailCheckedAt,
+		&i.ExternalId,
 	)
 	return i, err
 }
 
-const listContacts = `-- name: ListContacts :many
+const ListContacts = `-- name: ListContacts :many
 SELECT 
     "id",
     "fullName",
@@ -457,17 +610,38 @@ SELECT
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById"
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId"
 FROM "Contact"
 WHERE "workspaceId" = $1
   AND "deletedAt" IS NULL
   AND ($2::TEXT IS NULL OR "ownerId" = $2)
   AND ($3::TEXT IS NULL OR "companyId" = $3)
   AND ($4::TEXT IS NULL OR "lifecycleStage"::TEXT = $4)
-  AND ($5::TEXT IS NULL OR to_tsvector('simple', "fullName" || ' ' || COALESCE("email", '')) @@ plainto_tsquery('simple', $5))
-  AND ($6::TIMESTAMP IS NULL OR "createdAt" < $6)
-ORDER BY "createdAt" DESC
-LIMIT $7
+  AND ($5::TEXT IS NULL OR "source" = $5)
+  AND ($6::TEXT IS NULL OR "utmSource" = $6)
+  AND ($7::TEXT IS NULL OR "utmMedium" = $7)
+  AND ($8::TEXT IS NULL OR "utmCampaign" = $8)
+  AND ($9::TEXT IS NULL OR to_tsvector('simple', "fullName" || ' ' || COALESCE("email", '')) @@ plainto_tsquery('simple', $9))
+  AND ($10::TEXT IS NULL OR EXISTS (
+    SELECT 1 FROM "Follower" f
+    WHERE f."entityType" = 'CONTACT' AND f."entityId" = "Contact"."id" AND f."userId" = $10
+  ))
+  AND ($11::TEXT IS NULL OR "emailStatus" = $11)
+  AND ($12::TIMESTAMP IS NULL OR ("createdAt", "id") < ($12, $13::TEXT))
+ORDER BY "createdAt" DESC, "id" DESC
+LIMIT $14
 `
 
 type ListContactsParams struct {
@@ -475,8 +649,15 @@ type ListContactsParams struct {
 	OwnerId        *string          `json:"ownerId"`
 	CompanyId      *string          `json:"companyId"`
 	LifecycleStage *string          `json:"lifecycleStage"`
+	Source         *string          `json:"source"`
+	UtmSource      *string          `json:"utmSource"`
+	UtmMedium      *string          `json:"utmMedium"`
+	UtmCampaign    *string          `json:"utmCampaign"`
 	QueryText      *string          `json:"queryText"`
+	FollowerId     *string          `json:"followerId"`
+	EmailStatus    *string          `json:"emailStatus"`
 	CursorTime     pgtype.Timestamp `json:"cursorTime"`
+	CursorId       string           `json:"cursorId"`
 	Limit          int32            `json:"limit"`
 }
 
@@ -515,18 +696,38 @@ type ListContactsRow struct {
 	UpdatedAt         pgtype.Timestamp      `json:"updatedAt"`
 	DeletedAt         pgtype.Timestamp      `json:"deletedAt"`
 	DeletedById       *string               `json:"deletedById"`
+	SourceDetail      *string               `json:"sourceDetail"`
+	UtmSource         *string               `json:"utmSource"`
+	UtmMedium         *string               `json:"utmMedium"`
+	UtmCampaign       *string               `json:"utmCampaign"`
+	UtmTerm           *string               `json:"utmTerm"`
+	UtmContent        *string               `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp      `json:"nextStepAt"`
+	NextStepNote      *string               `json:"nextStepNote"`
+	EmailStatus       *string               `json:"emailStatus"`
+	EmailStatusReason *string               `json:"emailStatusReason"`
+	EmailCheckedAt    pgtype.Timestamp      `json:"emailCheckedAt"`
+	ExternalId        *string               `json:"externalId"`
 }
 
-// Lista contatos de um workspace com paginação cursor-based (created_at DESC).
-// Filtros opcionais: ownerId, companyId, lifecycleStage, query (fulltext search).
+// Lista contatos de um workspace com paginação cursor-based (created_at DESC, id DESC).
+// Filtros opcionais: ownerId, companyId, lifecycleStage, atribuição (source/utm*), query (fulltext search),
+// followerId (contatos seguidos pelo usuário), emailStatus (resultado da verificação do email).
 func (q *Queries) ListContacts(ctx context.Context, arg ListContactsParams) ([]ListContactsRow, error) {
-	rows, err := q.db.Query(ctx, listContacts,
+	rows, err := q.db.Query(ctx, ListContacts,
 		arg.WorkspaceId,
 		arg.OwnerId,
 		arg.CompanyId,
 		arg.LifecycleStage,
+		arg.Source,
+		arg.UtmSource,
+		arg.UtmMedium,
+		arg.UtmCampaign,
 		arg.QueryText,
+		arg.FollowerId,
+		arg.EmailStatus,
 		arg.CursorTime,
+		arg.CursorId,
 		arg.Limit,
 	)
 	if err != nil {
@@ -571,6 +772,18 @@ func (q *Queries) ListContacts(ctx context.Context, arg ListContactsParams) ([]L
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.DeletedById,
+			&i.SourceDetail,
+			&i.UtmSource,
+			&i.UtmMedium,
+			&i.UtmCampaign,
+			&i.UtmTerm,
+			&i.UtmContent,
+			&i.NextStepAt,
+			&i.NextStepNote,
+			&i.EmailStatus,
+			&i.EmailStatusReason,
+			&i.EmailCheckedAt,
+			&i.ExternalId,
 		); err != nil {
 			return nil, err
 		}
@@ -582,7 +795,7 @@ func (q *Queries) ListContacts(ctx context.Context, arg ListContactsParams) ([]L
 	return items, nil
 }
 
-const searchContactsByText = `-- name: SearchContactsByText :many
+const SearchContactsByText = `-- name: SearchContactsByText :many
 SELECT 
     "id",
     "fullName",
@@ -617,7 +830,7 @@ type SearchContactsByTextRow struct {
 
 // Busca fulltext em contatos (usado por autocomplete/search).
 func (q *Queries) SearchContactsByText(ctx context.Context, arg SearchContactsByTextParams) ([]SearchContactsByTextRow, error) {
-	rows, err := q.db.Query(ctx, searchContactsByText, arg.WorkspaceId, arg.PlaintoTsquery, arg.Limit)
+	rows, err := q.db.Query(ctx, SearchContactsByText, arg.WorkspaceId, arg.PlaintoTsquery, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -644,7 +857,7 @@ func (q *Queries) SearchContactsByText(ctx context.Context, arg SearchContactsBy
 	return items, nil
 }
 
-const softDeleteContact = `-- name: SoftDeleteContact :exec
+const SoftDeleteContact = `-- name: SoftDeleteContact :exec
 UPDATE "Contact"
 SET
     "deletedAt" = $3,
@@ -664,7 +877,7 @@ type SoftDeleteContactParams struct {
 
 // Soft delete de um contato (marca deletedAt + deletedById).
 func (q *Queries) SoftDeleteContact(ctx context.Context, arg SoftDeleteContactParams) error {
-	_, err := q.db.Exec(ctx, softDeleteContact,
+	_, err := q.db.Exec(ctx, SoftDeleteContact,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.DeletedAt,
@@ -673,41 +886,16 @@ func (q *Queries) SoftDeleteContact(ctx context.Context, arg SoftDeleteContactPa
 	return err
 }
 
-const updateContact = `-- name: UpdateContact :one
+const TransitionContactLifecycleStage = `-- name: TransitionContactLifecycleStage :one
 UPDATE "Contact"
 SET
-    "fullName" = COALESCE($3, "fullName"),
-    "email" = COALESCE($4, "email"),
-    "phone" = COALESCE($5, "phone"),
-    "whatsapp" = COALESCE($6, "whatsapp"),
-    "notes" = COALESCE($7, "notes"),
-    "firstName" = COALESCE($8, "firstName"),
-    "lastName" = COALESCE($9, "lastName"),
-    "image" = COALESCE($10, "image"),
-    "linkedinUrl" = COALESCE($11, "linkedinUrl"),
-    "language" = COALESCE($12, "language"),
-    "timezone" = COALESCE($13, "timezone"),
-    "city" = COALESCE($14, "city"),
-    "state" = COALESCE($15, "state"),
-    "country" = COALESCE($16, "country"),
-    "jobTitle" = COALESCE($17, "jobTitle"),
-    "department" = COALESCE($18, "department"),
-    "decisionRole" = COALESCE($19, "decisionRole"),
-    "tagLabels" = COALESCE($20, "tagLabels"),
-    "source" = COALESCE($21, "source"),
-    "lastInteractionAt" = COALESCE($22, "lastInteractionAt"),
-    "ownerId" = COALESCE($23, "ownerId"),
-    "socialUrls" = COALESCE($24, "socialUrls"),
-    "companyId" = COALESCE($25, "companyId"),
-    "contactScore" = COALESCE($26, "contactScore"),
-    "lifecycleStage" = COALESCE($27, "lifecycleStage"),
-    "assignedToId" = COALESCE($28, "assignedToId"),
-    "updatedById" = $29,
-    "updatedAt" = $30
-WHERE "id" = $1
-  AND "workspaceId" = $2
+    "lifecycleStage" = $1,
+    "updatedById" = $2,
+    "updatedAt" = $3
+WHERE "id" = $4
+  AND "workspaceId" = $5
   AND "deletedAt" IS NULL
-  AND "updatedAt" = $31  -- Optimistic locking
+  AND "lifecycleStage" = $6
 RETURNING 
     "id",
     "fullName",
@@ -742,13 +930,34 @@ RETURNING
     "createdAt",
     "updatedAt",
     "deletedAt",
-    "deletedById"
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId"
 `
 
-type UpdateContactParams struct {
+type TransitionContactLifecycleStageParams struct {
+	ToStage     ContactLifecycleStage `json:"toStage"`
+	UpdatedById *string               `json:"updatedById"`
+	UpdatedAt   pgtype.Timestamp      `json:"updatedAt"`
+	ID          string                `json:"id"`
+	WorkspaceId string                `json:"workspaceId"`
+	FromStage   ContactLifecycleStage `json:"fromStage"`
+}
+
+type TransitionContactLifecycleStageRow struct {
 	ID                string                `json:"id"`
-	WorkspaceId       string                `json:"workspaceId"`
 	FullName          string                `json:"fullName"`
+	WorkspaceId       string                `json:"workspaceId"`
 	Email             *string               `json:"email"`
 	Phone             *string               `json:"phone"`
 	Whatsapp          *string               `json:"whatsapp"`
@@ -774,9 +983,213 @@ type UpdateContactParams struct {
 	ContactScore      int32                 `json:"contactScore"`
 	LifecycleStage    ContactLifecycleStage `json:"lifecycleStage"`
 	AssignedToId      *string               `json:"assignedToId"`
+	CreatedById       *string               `json:"createdById"`
 	UpdatedById       *string               `json:"updatedById"`
+	CreatedAt         pgtype.Timestamp      `json:"createdAt"`
 	UpdatedAt         pgtype.Timestamp      `json:"updatedAt"`
-	UpdatedAt_2       pgtype.Timestamp      `json:"updatedAt2"`
+	DeletedAt         pgtype.Timestamp      `json:"deletedAt"`
+	DeletedById       *string               `json:"deletedById"`
+	SourceDetail      *string               `json:"sourceDetail"`
+	UtmSource         *string               `json:"utmSource"`
+	UtmMedium         *string               `json:"utmMedium"`
+	UtmCampaign       *string               `json:"utmCampaign"`
+	UtmTerm           *string               `json:"utmTerm"`
+	UtmContent        *string               `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp      `json:"nextStepAt"`
+	NextStepNote      *string               `json:"nextStepNote"`
+	EmailStatus       *string               `json:"emailStatus"`
+	EmailStatusReason *string               `json:"emailStatusReason"`
+	EmailCheckedAt    pgtype.Timestamp      `json:"emailCheckedAt"`
+	ExternalId        *string               `json:"externalId"`
+}
+
+// Move o contato para outro estágio do funil.
+// Compare-and-set em fromStage: duas transições concorrentes não sobrescrevem uma à outra.
+func (q *Queries) TransitionContactLifecycleStage(ctx context.Context, arg TransitionContactLifecycleStageParams) (TransitionContactLifecycleStageRow, error) {
+	row := q.db.QueryRow(ctx, TransitionContactLifecycleStage,
+		arg.ToStage,
+		arg.UpdatedById,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.WorkspaceId,
+		arg.FromStage,
+	)
+	var i TransitionContactLifecycleStageRow
+	err := row.Scan(
+		&i.ID,
+		&i.FullName,
+		&i.WorkspaceId,
+		&i.Email,
+		&i.Phone,
+		&i.Whatsapp,
+		&i.Notes,
+		&i.FirstName,
+		&i.LastName,
+		&i.Image,
+		&i.LinkedinUrl,
+		&i.Language,
+		&i.Timezone,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.JobTitle,
+		&i.Department,
+		&i.DecisionRole,
+		&i.TagLabels,
+		&i.Source,
+		&i.LastInteractionAt,
+		&i.OwnerId,
+		&i.SocialUrls,
+		&i.CompanyId,
+		&i.ContactScore,
+		&i.LifecycleStage,
+		&i.AssignedToId,
+		&i.CreatedById,
+		&i.UpdatedById,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DeletedById,
+		&i.SourceDetail,
+		&i.UtmSource,
+		&i.UtmMedium,
+		&i.UtmCampaign,
+		&i.UtmTerm,
+		&i.UtmContent,
+		&i.NextStepAt,
+		&i.NextStepNote,
+		&i.EmailStatus,
+		&i.EmailStatusReason,
+		&i.EmailCheckedAt,
+		&i.ExternalId,
+	)
+	return i, err
+}
+
+const UpdateContact = `-- name: UpdateContact :one
+UPDATE "Contact"
+SET
+    "fullName" = COALESCE($3, "fullName"),
+    "email" = COALESCE($4, "email"),
+    -- Email alterado: volta para a fila de verificação
+    "emailStatus" = CASE WHEN $4 IS NOT NULL AND $4 IS DISTINCT FROM "email" THEN NULL ELSE "emailStatus" END,
+    "emailStatusReason" = CASE WHEN $4 IS NOT NULL AND $4 IS DISTINCT FROM "email" THEN NULL ELSE "emailStatusReason" END,
+    "emailCheckedAt" = CASE WHEN $4 IS NOT NULL AND $4 IS DISTINCT FROM "email" THEN NULL ELSE "emailCheckedAt" END,
+    "phone" = COALESCE($5, "phone"),
+    "whatsapp" = COALESCE($6, "whatsapp"),
+    "notes" = COALESCE($7, "notes"),
+    "firstName" = COALESCE($8, "firstName"),
+    "lastName" = COALESCE($9, "lastName"),
+    "image" = COALESCE($10, "image"),
+    "linkedinUrl" = COALESCE($11, "linkedinUrl"),
+    "language" = COALESCE($12, "language"),
+    "timezone" = COALESCE($13, "timezone"),
+    "city" = COALESCE($14, "city"),
+    "state" = COALESCE($15, "state"),
+    "country" = COALESCE($16, "country"),
+    "jobTitle" = COALESCE($17, "jobTitle"),
+    "department" = COALESCE($18, "department"),
+    "decisionRole" = COALESCE($19, "decisionRole"),
+    "tagLabels" = COALESCE($20, "tagLabels"),
+    "source" = COALESCE($21, "source"),
+    "lastInteractionAt" = COALESCE($22, "lastInteractionAt"),
+    "ownerId" = COALESCE($23, "ownerId"),
+    "socialUrls" = COALESCE($24, "socialUrls"),
+    "companyId" = COALESCE($25, "companyId"),
+    "contactScore" = COALESCE($26, "contactScore"),
+    "assignedToId" = COALESCE($27, "assignedToId"),
+    "nextStepAt" = COALESCE($28, "nextStepAt"),
+    "nextStepNote" = COALESCE($29, "nextStepNote"),
+    "externalId" = COALESCE($33, "externalId"),
+    "updatedById" = $30,
+    "updatedAt" = $31
+WHERE "id" = $1
+  AND "workspaceId" = $2
+  AND "deletedAt" IS NULL
+  AND "updatedAt" = $32  -- Optimistic locking
+RETURNING 
+    "id",
+    "fullName",
+    "workspaceId",
+    "email",
+    "phone",
+    "whatsapp",
+    "notes",
+    "firstName",
+    "lastName",
+    "image",
+    "linkedinUrl",
+    "language",
+    "timezone",
+    "city",
+    "state",
+    "country",
+    "jobTitle",
+    "department",
+    "decisionRole",
+    "tagLabels",
+    "source",
+    "lastInteractionAt",
+    "ownerId",
+    "socialUrls",
+    "companyId",
+    "contactScore",
+    "lifecycleStage",
+    "assignedToId",
+    "createdById",
+    "updatedById",
+    "createdAt",
+    "updatedAt",
+    "deletedAt",
+    "deletedById",
+    "sourceDetail",
+    "utmSource",
+    "utmMedium",
+    "utmCampaign",
+    "utmTerm",
+    "utmContent",
+    "nextStepAt",
+    "nextStepNote",
+    "emailStatus",
+    "emailStatusReason",
+    "emailCheckedAt",
+    "externalId"
+`
+
+type UpdateContactParams struct {
+	ID                string           `json:"id"`
+	WorkspaceId       string           `json:"workspaceId"`
+	FullName          string           `json:"fullName"`
+	Email             *string          `json:"email"`
+	Phone             *string          `json:"phone"`
+	Whatsapp          *string          `json:"whatsapp"`
+	Notes             *string          `json:"notes"`
+	FirstName         *string          `json:"firstName"`
+	LastName          *string          `json:"lastName"`
+	Image             *string          `json:"image"`
+	LinkedinUrl       *string          `json:"linkedinUrl"`
+	Language          *string          `json:"language"`
+	Timezone          *string          `json:"timezone"`
+	City              *string          `json:"city"`
+	State             *string          `json:"state"`
+	Country           *string          `json:"country"`
+	JobTitle          *string          `json:"jobTitle"`
+	Department        *string          `json:"department"`
+	DecisionRole      *string          `json:"decisionRole"`
+	TagLabels         []string         `json:"tagLabels"`
+	Source            *string          `json:"source"`
+	LastInteractionAt pgtype.Timestamp `json:"lastInteractionAt"`
+	OwnerId           *string          `json:"ownerId"`
+	SocialUrls        []byte           `json:"socialUrls"`
+	CompanyId         *string          `json:"companyId"`
+	ContactScore      int32            `json:"contactScore"`
+	AssignedToId      *string          `json:"assignedToId"`
+	NextStepAt        pgtype.Timestamp `json:"nextStepAt"`
+	NextStepNote      *string          `json:"nextStepNote"`
+	UpdatedById       *string          `json:"updatedById"`
+	UpdatedAt         pgtype.Timestamp `json:"updatedAt"`
+	UpdatedAt_2       pgtype.Timestamp `json:"updatedAt2"`
+	ExternalId        *string          `json:"externalId"`
 }
 
 type UpdateContactRow struct {
@@ -814,11 +1227,24 @@ type UpdateContactRow struct {
 	UpdatedAt         pgtype.Timestamp      `json:"updatedAt"`
 	DeletedAt         pgtype.Timestamp      `json:"deletedAt"`
 	DeletedById       *string               `json:"deletedById"`
+	SourceDetail      *string               `json:"sourceDetail"`
+	UtmSource         *string               `json:"utmSource"`
+	UtmMedium         *string               `json:"utmMedium"`
+	UtmCampaign       *string               `json:"utmCampaign"`
+	UtmTerm           *string               `json:"utmTerm"`
+	UtmContent        *string               `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp      `json:"nextStepAt"`
+	NextStepNote      *string               `json:"nextStepNote"`
+	EmailStatus       *string               `json:"emailStatus"`
+	EmailStatusReason *string               `json:"emailStatusReason"`
+	EmailCheckedAt    pgtype.Timestamp      `json:"emailCheckedAt"`
+	ExternalId        *string               `json:"externalId"`
 }
 
 // Atualiza um contato existente (IDOR protection + optimistic locking via updatedAt).
+// lifecycleStage não é alterado aqui: mudanças de estágio passam por TransitionContactLifecycleStage.
 func (q *Queries) UpdateContact(ctx context.Context, arg UpdateContactParams) (UpdateContactRow, error) {
-	row := q.db.QueryRow(ctx, updateContact,
+	row := q.db.QueryRow(ctx, UpdateContact,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.FullName,
@@ -845,11 +1271,13 @@ func (q *Queries) UpdateContact(ctx context.Context, arg UpdateContactParams) (U
 		arg.SocialUrls,
 		arg.CompanyId,
 		arg.ContactScore,
-		arg.LifecycleStage,
 		arg.AssignedToId,
+		arg.NextStepAt,
+		arg.NextStepNote,
 		arg.UpdatedById,
 		arg.UpdatedAt,
 		arg.UpdatedAt_2,
+		arg.ExternalId,
 	)
 	var i UpdateContactRow
 	err := row.Scan(
@@ -887,6 +1315,18 @@ func (q *Queries) UpdateContact(ctx context.Context, arg UpdateContactParams) (U
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.DeletedById,
+		&i.SourceDetail,
+		&i.UtmSource,
+		&i.UtmMedium,
+		&i.UtmCampaign,
+		&i.UtmTerm,
+		&i.UtmContent,
+		&i.NextStepAt,
+		&i.NextStepNote,
+		&i.EmailStatus,
+		&i.EmailStatusReason,
+		&i.EmailCheckedAt,
+		&i.ExternalId,
 	)
 	return i, err
 }
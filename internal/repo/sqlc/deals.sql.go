@@ -11,14 +11,21 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createDeal = `-- name: CreateDeal :one
+const CreateDeal = `-- name: CreateDeal :one
 INSERT INTO "Deal" (
     id, "workspaceId", "pipelineId", "stageId", "contactId", "companyId",
     name, value, currency, stage, probability, 
-    "expectedCloseDate", "ownerId", "createdById", description
+    "expectedCloseDate", "ownerId", "createdById", description,
+    source, "sourceDetail", "utmSource", "utmMedium", "utmCampaign", "utmTerm", "utmContent", "nextStepAt", "nextStepNote",
+    "forecastCategory", "forecastCategoryOverridden", "externalId"
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
-) RETURNING id, "workspaceId", "pipelineId", "stageId", "contactId", name, value, "createdAt", "updatedAt", "deletedAt", "deletedById", description, currency, stage, probability, "expectedCloseDate", "closedAt", "lostReason", "companyId", "ownerId", "createdById", "updatedById"
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+    $16, $17, $18, $19, $20, $21, $22, $23, $24,
+    -- sem categoria manual, derivada do estágio e da probabilidade da etapa (ou a do deal)
+    COALESCE($25::TEXT, deal_forecast_category($10, COALESCE((SELECT s.probability FROM "PipelineStage" s WHERE s.id = $4), $11))),
+    $25::TEXT IS NOT NULL,
+    $26
+) RETURNING id, "workspaceId", "pipelineId", "stageId", "contactId", name, value, "createdAt", "updatedAt", "deletedAt", "deletedById", description, currency, stage, probability, "expectedCloseDate", "closedAt", "lostReason", "companyId", "ownerId", "createdById", "updatedById", source, "sourceDetail", "utmSource", "utmMedium", "utmCampaign", "utmTerm", "utmContent", "nextStepAt", "nextStepNote", "rottingSince", "forecastCategory", "forecastCategoryOverridden", "externalId"
 `
 
 type CreateDealParams struct {
@@ -37,10 +44,21 @@ type CreateDealParams struct {
 	OwnerId           *string          `json:"ownerId"`
 	CreatedById       string           `json:"createdById"`
 	Description       *string          `json:"description"`
+	Source            *string          `json:"source"`
+	SourceDetail      *string          `json:"sourceDetail"`
+	UtmSource         *string          `json:"utmSource"`
+	UtmMedium         *string          `json:"utmMedium"`
+	UtmCampaign       *string          `json:"utmCampaign"`
+	UtmTerm           *string          `json:"utmTerm"`
+	UtmContent        *string          `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp `json:"nextStepAt"`
+	NextStepNote      *string          `json:"nextStepNote"`
+	ForecastCategory  *string          `json:"forecastCategory"`
+	ExternalId        *string          `json:"externalId"`
 }
 
 func (q *Queries) CreateDeal(ctx context.Context, arg CreateDealParams) (Deal, error) {
-	row := q.db.QueryRow(ctx, createDeal,
+	row := q.db.QueryRow(ctx, CreateDeal,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.PipelineId,
@@ -56,6 +74,17 @@ func (q *Queries) CreateDeal(ctx context.Context, arg CreateDealParams) (Deal, e
 		arg.OwnerId,
 		arg.CreatedById,
 		arg.Description,
+		arg.Source,
+		arg.SourceDetail,
+		arg.UtmSource,
+		arg.UtmMedium,
+		arg.UtmCampaign,
+		arg.UtmTerm,
+		arg.UtmContent,
+		arg.NextStepAt,
+		arg.NextStepNote,
+		arg.ForecastCategory,
+		arg.ExternalId,
 	)
 	var i Deal
 	err := row.Scan(
@@ -81,11 +110,24 @@ func (q *Queries) CreateDeal(ctx context.Context, arg CreateDealParams) (Deal, e
 		&i.OwnerId,
 		&i.CreatedById,
 		&i.UpdatedById,
+		&i.Source,
+		&i.SourceDetail,
+		&i.UtmSource,
+		&i.UtmMedium,
+		&i.UtmCampaign,
+		&i.UtmTerm,
+		&i.UtmContent,
+		&i.NextStepAt,
+		&i.NextStepNote,
+		&i.RottingSince,
+		&i.ForecastCategory,
+		&i.ForecastCategoryOverridden,
+		&i.ExternalId,
 	)
 	return i, err
 }
 
-const createDealHistory = `-- name: CreateDealHistory :one
+const CreateDealHistory = `-- name: CreateDealHistory :one
 INSERT INTO "DealStageHistory" (
     id, "workspaceId", "dealId", "fromStage", "toStage", reason, "userId"
 ) VALUES (
@@ -104,7 +146,7 @@ type CreateDealHistoryParams struct {
 }
 
 func (q *Queries) CreateDealHistory(ctx context.Context, arg CreateDealHistoryParams) (DealStageHistory, error) {
-	row := q.db.QueryRow(ctx, createDealHistory,
+	row := q.db.QueryRow(ctx, CreateDealHistory,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.DealId,
@@ -127,7 +169,7 @@ func (q *Queries) CreateDealHistory(ctx context.Context, arg CreateDealHistoryPa
 	return i, err
 }
 
-const deleteDeal = `-- name: DeleteDeal :exec
+const DeleteDeal = `-- name: DeleteDeal :exec
 UPDATE "Deal"
 SET 
     "deletedAt" = CURRENT_TIMESTAMP,
@@ -142,13 +184,13 @@ type DeleteDealParams struct {
 }
 
 func (q *Queries) DeleteDeal(ctx context.Context, arg DeleteDealParams) error {
-	_, err := q.db.Exec(ctx, deleteDeal, arg.ID, arg.WorkspaceId, arg.DeletedById)
+	_, err := q.db.Exec(ctx, DeleteDeal, arg.ID, arg.WorkspaceId, arg.DeletedById)
 	return err
 }
 
-const getDeal = `-- name: GetDeal :one
+const GetDeal = `-- name: GetDeal :one
 SELECT 
-    d.id, d."workspaceId", d."pipelineId", d."stageId", d."contactId", d.name, d.value, d."createdAt", d."updatedAt", d."deletedAt", d."deletedById", d.description, d.currency, d.stage, d.probability, d."expectedCloseDate", d."closedAt", d."lostReason", d."companyId", d."ownerId", d."createdById", d."updatedById",
+    d.id, d."workspaceId", d."pipelineId", d."stageId", d."contactId", d.name, d.value, d."createdAt", d."updatedAt", d."deletedAt", d."deletedById", d.description, d.currency, d.stage, d.probability, d."expectedCloseDate", d."closedAt", d."lostReason", d."companyId", d."ownerId", d."createdById", d."updatedById", d.source, d."sourceDetail", d."utmSource", d."utmMedium", d."utmCampaign", d."utmTerm", d."utmContent", d."nextStepAt", d."nextStepNote", d."rottingSince", d."forecastCategory", d."forecastCategoryOverridden", d."externalId",
     c."fullName" as contactName,
     co.name as companyName
 FROM "Deal" d
@@ -163,34 +205,47 @@ type GetDealParams struct {
 }
 
 type GetDealRow struct {
-	ID                string           `json:"id"`
-	WorkspaceId       string           `json:"workspaceId"`
-	PipelineId        string           `json:"pipelineId"`
-	StageId           *string          `json:"stageId"`
-	ContactId         *string          `json:"contactId"`
-	Name              string           `json:"name"`
-	Value             *float64         `json:"value"`
-	CreatedAt         pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt         pgtype.Timestamp `json:"updatedAt"`
-	DeletedAt         pgtype.Timestamp `json:"deletedAt"`
-	DeletedById       *string          `json:"deletedById"`
-	Description       *string          `json:"description"`
-	Currency          string           `json:"currency"`
-	Stage             DealStage        `json:"stage"`
-	Probability       *int32           `json:"probability"`
-	ExpectedCloseDate pgtype.Timestamp `json:"expectedCloseDate"`
-	ClosedAt          pgtype.Timestamp `json:"closedAt"`
-	LostReason        *string          `json:"lostReason"`
-	CompanyId         *string          `json:"companyId"`
-	OwnerId           *string          `json:"ownerId"`
-	CreatedById       string           `json:"createdById"`
-	UpdatedById       *string          `json:"updatedById"`
-	Contactname       *string          `json:"contactname"`
-	Companyname       *string          `json:"companyname"`
+	ID                         string           `json:"id"`
+	WorkspaceId                string           `json:"workspaceId"`
+	PipelineId                 string           `json:"pipelineId"`
+	StageId                    *string          `json:"stageId"`
+	ContactId                  *string          `json:"contactId"`
+	Name                       string           `json:"name"`
+	Value                      *float64         `json:"value"`
+	CreatedAt                  pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt                  pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt                  pgtype.Timestamp `json:"deletedAt"`
+	DeletedById                *string          `json:"deletedById"`
+	Description                *string          `json:"description"`
+	Currency                   string           `json:"currency"`
+	Stage                      DealStage        `json:"stage"`
+	Probability                *int32           `json:"probability"`
+	ExpectedCloseDate          pgtype.Timestamp `json:"expectedCloseDate"`
+	ClosedAt                   pgtype.Timestamp `json:"closedAt"`
+	LostReason                 *string          `json:"lostReason"`
+	CompanyId                  *string          `json:"companyId"`
+	OwnerId                    *string          `json:"ownerId"`
+	CreatedById                string           `json:"createdById"`
+	UpdatedById                *string          `json:"updatedById"`
+	Source                     *string          `json:"source"`
+	SourceDetail               *string          `json:"sourceDetail"`
+	UtmSource                  *string          `json:"utmSource"`
+	UtmMedium                  *string          `json:"utmMedium"`
+	UtmCampaign                *string          `json:"utmCampaign"`
+	UtmTerm                    *string          `json:"utmTerm"`
+	UtmContent                 *string          `json:"utmContent"`
+	NextStepAt                 pgtype.Timestamp `json:"nextStepAt"`
+	NextStepNote               *string          `json:"nextStepNote"`
+	RottingSince               pgtype.Timestamp `json:"rottingSince"`
+	ForecastCategory           string           `json:"forecastCategory"`
+	ForecastCategoryOverridden bool             `json:"forecastCategoryOverridden"`
+	ExternalId                 *string          `json:"externalId"`
+	Contactname                *string          `json:"contactname"`
+	Companyname                *string          `json:"companyname"`
 }
 
 func (q *Queries) GetDeal(ctx context.Context, arg GetDealParams) (GetDealRow, error) {
-	row := q.db.QueryRow(ctx, getDeal, arg.ID, arg.WorkspaceId)
+	row := q.db.QueryRow(ctx, GetDeal, arg.ID, arg.WorkspaceId)
 	var i GetDealRow
 	err := row.Scan(
 		&i.ID,
@@ -215,15 +270,28 @@ func (q *Queries) GetDeal(ctx context.Context, arg GetDealParams) (GetDealRow, e
 		&i.OwnerId,
 		&i.CreatedById,
 		&i.UpdatedById,
+		&i.Source,
+		&i.SourceDetail,
+		&i.UtmSource,
+		&i.UtmMedium,
+		&i.UtmCampaign,
+		&i.UtmTerm,
+		&i.UtmContent,
+		&i.NextStepAt,
+		&i.NextStepNote,
+		&i.RottingSince,
+		&i.ForecastCategory,
+		&i.ForecastCategoryOverridden,
+		&i.ExternalId,
 		&i.Contactname,
 		&i.Companyname,
 	)
 	return i, err
 }
 
-const listDeals = `-- name: ListDeals :many
+const ListDeals = `-- name: ListDeals :many
 SELECT 
-    d.id, d."workspaceId", d."pipelineId", d."stageId", d."contactId", d.name, d.value, d."createdAt", d."updatedAt", d."deletedAt", d."deletedById", d.description, d.currency, d.stage, d.probability, d."expectedCloseDate", d."closedAt", d."lostReason", d."companyId", d."ownerId", d."createdById", d."updatedById",
+    d.id, d."workspaceId", d."pipelineId", d."stageId", d."contactId", d.name, d.value, d."createdAt", d."updatedAt", d."deletedAt", d."deletedById", d.description, d.currency, d.stage, d.probability, d."expectedCloseDate", d."closedAt", d."lostReason", d."companyId", d."ownerId", d."createdById", d."updatedById", d.source, d."sourceDetail", d."utmSource", d."utmMedium", d."utmCampaign", d."utmTerm", d."utmContent", d."nextStepAt", d."nextStepNote", d."rottingSince", d."forecastCategory", d."forecastCategoryOverridden", d."externalId",
     c."fullName" as contactName,
     co.name as companyName
 FROM "Deal" d
@@ -233,6 +301,27 @@ WHERE d."workspaceId" = $1
     AND ($2::TEXT IS NULL OR d."pipelineId" = $2)
     AND ($3::TEXT IS NULL OR d."stageId" = $3)
     AND ($4::TEXT IS NULL OR d."ownerId" = $4)
+    AND ($5::TEXT IS NULL OR d.source = $5)
+    AND ($6::TEXT IS NULL OR d."utmSource" = $6)
+    AND ($7::TEXT IS NULL OR d."utmMedium" = $7)
+    AND ($8::TEXT IS NULL OR d."utmCampaign" = $8)
+    AND (NOT $9::BOOLEAN OR d."rottingSince" IS NOT NULL)
+    AND ($10::TEXT IS NULL OR EXISTS (
+        SELECT 1 FROM "Follower" f
+        WHERE f."entityType" = 'DEAL' AND f."entityId" = d.id AND f."userId" = $10
+    ))
+    AND ($11::TEXT IS NULL
+        OR to_tsvector('simple', d.name) @@ plainto_tsquery('simple', $11)
+        OR d."contactId" IN (
+            SELECT qc.id FROM "Contact" qc
+            WHERE qc."workspaceId" = $1
+              AND to_tsvector('simple', qc."fullName" || ' ' || COALESCE(qc."email", '')) @@ plainto_tsquery('simple', $11)
+        )
+        OR d."companyId" IN (
+            SELECT qco.id FROM "Company" qco
+            WHERE qco."workspaceId" = $1
+              AND to_tsvector('simple', qco."name" || ' ' || COALESCE(qco."website", '')) @@ plainto_tsquery('simple', $11)
+        ))
     AND d."deletedAt" IS NULL
 ORDER BY d."createdAt" DESC
 `
@@ -242,41 +331,68 @@ type ListDealsParams struct {
 	PipelineId  *string `json:"pipelineId"`
 	StageId     *string `json:"stageId"`
 	OwnerId     *string `json:"ownerId"`
+	Source      *string `json:"source"`
+	UtmSource   *string `json:"utmSource"`
+	UtmMedium   *string `json:"utmMedium"`
+	UtmCampaign *string `json:"utmCampaign"`
+	RottingOnly bool    `json:"rottingOnly"`
+	FollowerId  *string `json:"followerId"`
+	QueryText   *string `json:"queryText"`
 }
 
 type ListDealsRow struct {
-	ID                string           `json:"id"`
-	WorkspaceId       string           `json:"workspaceId"`
-	PipelineId        string           `json:"pipelineId"`
-	StageId           *string          `json:"stageId"`
-	ContactId         *string          `json:"contactId"`
-	Name              string           `json:"name"`
-	Value             *float64         `json:"value"`
-	CreatedAt         pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt         pgtype.Timestamp `json:"updatedAt"`
-	DeletedAt         pgtype.Timestamp `json:"deletedAt"`
-	DeletedById       *string          `json:"deletedById"`
-	Description       *string          `json:"description"`
-	Currency          string           `json:"currency"`
-	Stage             DealStage        `json:"stage"`
-	Probability       *int32           `json:"probability"`
-	ExpectedCloseDate pgtype.Timestamp `json:"expectedCloseDate"`
-	ClosedAt          pgtype.Timestamp `json:"closedAt"`
-	LostReason        *string          `json:"lostReason"`
-	CompanyId         *string          `json:"companyId"`
-	OwnerId           *string          `json:"ownerId"`
-	CreatedById       string           `json:"createdById"`
-	UpdatedById       *string          `json:"updatedById"`
-	Contactname       *string          `json:"contactname"`
-	Companyname       *string          `json:"companyname"`
+	ID                         string           `json:"id"`
+	WorkspaceId                string           `json:"workspaceId"`
+	PipelineId                 string           `json:"pipelineId"`
+	StageId                    *string          `json:"stageId"`
+	ContactId                  *string          `json:"contactId"`
+	Name                       string           `json:"name"`
+	Value                      *float64         `json:"value"`
+	CreatedAt                  pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt                  pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt                  pgtype.Timestamp `json:"deletedAt"`
+	DeletedById                *string          `json:"deletedById"`
+	Description                *string          `json:"description"`
+	Currency                   string           `json:"currency"`
+	Stage                      DealStage        `json:"stage"`
+	Probability                *int32           `json:"probability"`
+	ExpectedCloseDate          pgtype.Timestamp `json:"expectedCloseDate"`
+	ClosedAt                   pgtype.Timestamp `json:"closedAt"`
+	LostReason                 *string          `json:"lostReason"`
+	CompanyId                  *string          `json:"companyId"`
+	OwnerId                    *string          `json:"ownerId"`
+	CreatedById                string           `json:"createdById"`
+	UpdatedById                *string          `json:"updatedById"`
+	Source                     *string          `json:"source"`
+	SourceDetail               *string          `json:"sourceDetail"`
+	UtmSource                  *string          `json:"utmSource"`
+	UtmMedium                  *string          `json:"utmMedium"`
+	UtmCampaign                *string          `json:"utmCampaign"`
+	UtmTerm                    *string          `json:"utmTerm"`
+	UtmContent                 *string          `json:"utmContent"`
+	NextStepAt                 pgtype.Timestamp `json:"nextStepAt"`
+	NextStepNote               *string          `json:"nextStepNote"`
+	RottingSince               pgtype.Timestamp `json:"rottingSince"`
+	ForecastCategory           string           `json:"forecastCategory"`
+	ForecastCategoryOverridden bool             `json:"forecastCategoryOverridden"`
+	ExternalId                 *string          `json:"externalId"`
+	Contactname                *string          `json:"contactname"`
+	Companyname                *string          `json:"companyname"`
 }
 
 func (q *Queries) ListDeals(ctx context.Context, arg ListDealsParams) ([]ListDealsRow, error) {
-	rows, err := q.db.Query(ctx, listDeals,
+	rows, err := q.db.Query(ctx, ListDeals,
 		arg.WorkspaceId,
 		arg.PipelineId,
 		arg.StageId,
 		arg.OwnerId,
+		arg.Source,
+		arg.UtmSource,
+		arg.UtmMedium,
+		arg.UtmCampaign,
+		arg.RottingOnly,
+		arg.FollowerId,
+		arg.QueryText,
 	)
 	if err != nil {
 		return nil, err
@@ -308,6 +424,19 @@ func (q *Queries) ListDeals(ctx context.Context, arg ListDealsParams) ([]ListDea
 			&i.OwnerId,
 			&i.CreatedById,
 			&i.UpdatedById,
+			&i.Source,
+			&i.SourceDetail,
+			&i.UtmSource,
+			&i.UtmMedium,
+			&i.UtmCampaign,
+			&i.UtmTerm,
+			&i.UtmContent,
+			&i.NextStepAt,
+			&i.NextStepNote,
+			&i.RottingSince,
+			&i.ForecastCategory,
+			&i.ForecastCategoryOverridden,
+			&i.ExternalId,
 			&i.Contactname,
 			&i.Companyname,
 		); err != nil {
@@ -321,7 +450,7 @@ func (q *Queries) ListDeals(ctx context.Context, arg ListDealsParams) ([]ListDea
 	return items, nil
 }
 
-const updateDeal = `-- name: UpdateDeal :one
+const UpdateDeal = `-- name: UpdateDeal :one
 UPDATE "Deal"
 SET 
     "pipelineId" = COALESCE($3, "pipelineId"),
@@ -336,32 +465,51 @@ SET
     "lostReason" = COALESCE($12, "lostReason"),
     "ownerId" = COALESCE($13, "ownerId"),
     description = COALESCE($14, description),
+    "nextStepAt" = COALESCE($15, "nextStepAt"),
+    "nextStepNote" = COALESCE($16, "nextStepNote"),
+    -- categoria manual ou derivada do estágio/probabilidade resultantes
+    "forecastCategoryOverridden" = COALESCE($17, "forecastCategoryOverridden"),
+    "forecastCategory" = CASE
+        WHEN COALESCE($17, "forecastCategoryOverridden")
+            THEN COALESCE($18, "forecastCategory")
+        ELSE deal_forecast_category(
+            COALESCE($8, stage),
+            COALESCE((SELECT s.probability FROM "PipelineStage" s WHERE s.id = COALESCE($4, "stageId")),
+                     COALESCE($9, probability)))
+    END,
+    "rottingSince" = CASE WHEN $4::TEXT IS NULL THEN "rottingSince" END,
+    "externalId" = COALESCE($19, "externalId"),
     "updatedAt" = CURRENT_TIMESTAMP,
-    "updatedById" = $15
+    "updatedById" = $20
 WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
-RETURNING id, "workspaceId", "pipelineId", "stageId", "contactId", name, value, "createdAt", "updatedAt", "deletedAt", "deletedById", description, currency, stage, probability, "expectedCloseDate", "closedAt", "lostReason", "companyId", "ownerId", "createdById", "updatedById"
+RETURNING id, "workspaceId", "pipelineId", "stageId", "contactId", name, value, "createdAt", "updatedAt", "deletedAt", "deletedById", description, currency, stage, probability, "expectedCloseDate", "closedAt", "lostReason", "companyId", "ownerId", "createdById", "updatedById", source, "sourceDetail", "utmSource", "utmMedium", "utmCampaign", "utmTerm", "utmContent", "nextStepAt", "nextStepNote", "rottingSince", "forecastCategory", "forecastCategoryOverridden", "externalId"
 `
 
 type UpdateDealParams struct {
-	ID                string           `json:"id"`
-	WorkspaceId       string           `json:"workspaceId"`
-	PipelineId        *string          `json:"pipelineId"`
-	StageId           *string          `json:"stageId"`
-	Name              *string          `json:"name"`
-	Value             *float64         `json:"value"`
-	Currency          *string          `json:"currency"`
-	Stage             NullDealStage    `json:"stage"`
-	Probability       *int32           `json:"probability"`
-	ExpectedCloseDate pgtype.Timestamp `json:"expectedCloseDate"`
-	ClosedAt          pgtype.Timestamp `json:"closedAt"`
-	LostReason        *string          `json:"lostReason"`
-	OwnerId           *string          `json:"ownerId"`
-	Description       *string          `json:"description"`
-	UpdatedById       *string          `json:"updatedById"`
+	ID                         string           `json:"id"`
+	WorkspaceId                string           `json:"workspaceId"`
+	PipelineId                 *string          `json:"pipelineId"`
+	StageId                    *string          `json:"stageId"`
+	Name                       *string          `json:"name"`
+	Value                      *float64         `json:"value"`
+	Currency                   *string          `json:"currency"`
+	Stage                      NullDealStage    `json:"stage"`
+	Probability                *int32           `json:"probability"`
+	ExpectedCloseDate          pgtype.Timestamp `json:"expectedCloseDate"`
+	ClosedAt                   pgtype.Timestamp `json:"closedAt"`
+	LostReason                 *string          `json:"lostReason"`
+	OwnerId                    *string          `json:"ownerId"`
+	Description                *string          `json:"description"`
+	NextStepAt                 pgtype.Timestamp `json:"nextStepAt"`
+	NextStepNote               *string          `json:"nextStepNote"`
+	ForecastCategoryOverridden *bool            `json:"forecastCategoryOverridden"`
+	ForecastCategory           *string          `json:"forecastCategory"`
+	ExternalId                 *string          `json:"externalId"`
+	UpdatedById                *string          `json:"updatedById"`
 }
 
 func (q *Queries) UpdateDeal(ctx context.Context, arg UpdateDealParams) (Deal, error) {
-	row := q.db.QueryRow(ctx, updateDeal,
+	row := q.db.QueryRow(ctx, UpdateDeal,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.PipelineId,
@@ -376,6 +524,11 @@ func (q *Queries) UpdateDeal(ctx context.Context, arg UpdateDealParams) (Deal, e
 		arg.LostReason,
 		arg.OwnerId,
 		arg.Description,
+		arg.NextStepAt,
+		arg.NextStepNote,
+		arg.ForecastCategoryOverridden,
+		arg.ForecastCategory,
+		arg.ExternalId,
 		arg.UpdatedById,
 	)
 	var i Deal
@@ -402,6 +555,19 @@ func (q *Queries) UpdateDeal(ctx context.Context, arg UpdateDealParams) (Deal, e
 		&i.OwnerId,
 		&i.CreatedById,
 		&i.UpdatedById,
+		&i.Source,
+		&i.SourceDetail,
+		&i.UtmSource,
+		&i.UtmMedium,
+		&i.UtmCampaign,
+		&i.UtmTerm,
+		&i.UtmContent,
+		&i.NextStepAt,
+		&i.NextStepNote,
+		&i.RottingSince,
+		&i.ForecastCategory,
+		&i.ForecastCategoryOverridden,
+		&i.ExternalId,
 	)
 	return i, err
 }
@@ -15,13 +15,19 @@ import (
 type ActivityType string
 
 const (
-	ActivityTypeNOTE            ActivityType = "NOTE"
-	ActivityTypeTASK            ActivityType = "TASK"
-	ActivityTypeEMAIL           ActivityType = "EMAIL"
-	ActivityTypeCALL            ActivityType = "CALL"
-	ActivityTypeMEETING         ActivityType = "MEETING"
-	ActivityTypeMESSAGE         ActivityType = "MESSAGE"
-	ActivityTypeLIFECYCLECHANGE ActivityType = "LIFECYCLE_CHANGE"
+	ActivityTypeNOTE              ActivityType = "NOTE"
+	ActivityTypeTASK              ActivityType = "TASK"
+	ActivityTypeEMAIL             ActivityType = "EMAIL"
+	ActivityTypeCALL              ActivityType = "CALL"
+	ActivityTypeMEETING           ActivityType = "MEETING"
+	ActivityTypeMESSAGE           ActivityType = "MESSAGE"
+	ActivityTypeLIFECYCLECHANGE   ActivityType = "LIFECYCLE_CHANGE"
+	ActivityTypeDEALROTTING       ActivityType = "DEAL_ROTTING"
+	ActivityTypeLINKCLICK         ActivityType = "LINK_CLICK"
+	ActivityTypeEMAILOPENED       ActivityType = "EMAIL_OPENED"
+	ActivityTypeEMAILCLICKED      ActivityType = "EMAIL_CLICKED"
+	ActivityTypeEMAILBOUNCED      ActivityType = "EMAIL_BOUNCED"
+	ActivityTypeEMAILUNSUBSCRIBED ActivityType = "EMAIL_UNSUBSCRIBED"
 )
 
 func (e *ActivityType) Scan(src interface{}) error {
@@ -203,6 +209,7 @@ const (
 	ContactLifecycleStageOPPORTUNITY ContactLifecycleStage = "OPPORTUNITY"
 	ContactLifecycleStageCUSTOMER    ContactLifecycleStage = "CUSTOMER"
 	ContactLifecycleStageEVANGELIST  ContactLifecycleStage = "EVANGELIST"
+	ContactLifecycleStageCHURNED     ContactLifecycleStage = "CHURNED"
 )
 
 func (e *ContactLifecycleStage) Scan(src interface{}) error {
@@ -919,6 +926,17 @@ type Activity struct {
 	CreatedAt    pgtype.Timestamp `json:"createdAt"`
 }
 
+type ActivityParticipant struct {
+	ID           string           `json:"id"`
+	WorkspaceId  string           `json:"workspaceId"`
+	ActivityType string           `json:"activityType"`
+	ActivityId   string           `json:"activityId"`
+	UserId       *string          `json:"userId"`
+	ContactId    *string          `json:"contactId"`
+	Role         string           `json:"role"`
+	CreatedAt    pgtype.Timestamp `json:"createdAt"`
+}
+
 type AdminRole struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -937,6 +955,15 @@ type AuditLog struct {
 	CreatedAt   pgtype.Timestamp `json:"createdAt"`
 }
 
+type BusinessHours struct {
+	WorkspaceId string           `json:"workspaceId"`
+	Timezone    string           `json:"timezone"`
+	Intervals   []byte           `json:"intervals"`
+	UpdatedById *string          `json:"updatedById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
 type Call struct {
 	ID           string           `json:"id"`
 	WorkspaceId  string           `json:"workspaceId"`
@@ -982,6 +1009,26 @@ type Company struct {
 	AssignedToId   *string               `json:"assignedToId"`
 	CreatedById    *string               `json:"createdById"`
 	UpdatedById    *string               `json:"updatedById"`
+	Industry       *string               `json:"industry"`
+	LogoUrl        *string               `json:"logoUrl"`
+	CustomFields   []byte                `json:"customFields"`
+	ExternalId     *string               `json:"externalId"`
+}
+
+type CompanyEnrichmentJob struct {
+	ID            string           `json:"id"`
+	WorkspaceId   string           `json:"workspaceId"`
+	CompanyId     string           `json:"companyId"`
+	Provider      string           `json:"provider"`
+	Overwrite     bool             `json:"overwrite"`
+	Status        string           `json:"status"`
+	AppliedFields []byte           `json:"appliedFields"`
+	Error         *string          `json:"error"`
+	RequestedById string           `json:"requestedById"`
+	CreatedAt     pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamp `json:"updatedAt"`
+	StartedAt     pgtype.Timestamp `json:"startedAt"`
+	CompletedAt   pgtype.Timestamp `json:"completedAt"`
 }
 
 type CompanyTag struct {
@@ -992,6 +1039,20 @@ type CompanyTag struct {
 	CreatedAt pgtype.Timestamp `json:"createdAt"`
 }
 
+type ComputedField struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	EntityType  string           `json:"entityType"`
+	Key         string           `json:"key"`
+	Label       string           `json:"label"`
+	Formula     string           `json:"formula"`
+	ResultType  string           `json:"resultType"`
+	CreatedById string           `json:"createdById"`
+	UpdatedById *string          `json:"updatedById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
 type Contact struct {
 	ID                string                `json:"id"`
 	FullName          string                `json:"fullName"`
@@ -1027,6 +1088,37 @@ type Contact struct {
 	AssignedToId      *string               `json:"assignedToId"`
 	CreatedById       *string               `json:"createdById"`
 	UpdatedById       *string               `json:"updatedById"`
+	SourceDetail      *string               `json:"sourceDetail"`
+	UtmSource         *string               `json:"utmSource"`
+	UtmMedium         *string               `json:"utmMedium"`
+	UtmCampaign       *string               `json:"utmCampaign"`
+	UtmTerm           *string               `json:"utmTerm"`
+	UtmContent        *string               `json:"utmContent"`
+	NextStepAt        pgtype.Timestamp      `json:"nextStepAt"`
+	NextStepNote      *string               `json:"nextStepNote"`
+	EmailStatus       *string               `json:"emailStatus"`
+	EmailStatusReason *string               `json:"emailStatusReason"`
+	EmailCheckedAt    pgtype.Timestamp      `json:"emailCheckedAt"`
+	ExternalId        *string               `json:"externalId"`
+}
+
+type ContactBulkUpdateJob struct {
+	ID            string           `json:"id"`
+	WorkspaceId   string           `json:"workspaceId"`
+	ContactIds    []string         `json:"contactIds"`
+	Filter        []byte           `json:"filter"`
+	Patch         []byte           `json:"patch"`
+	Status        string           `json:"status"`
+	Total         *int32           `json:"total"`
+	Processed     int32            `json:"processed"`
+	Updated       int32            `json:"updated"`
+	Cursor        *string          `json:"cursor"`
+	Error         *string          `json:"error"`
+	RequestedById string           `json:"requestedById"`
+	CreatedAt     pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamp `json:"updatedAt"`
+	StartedAt     pgtype.Timestamp `json:"startedAt"`
+	CompletedAt   pgtype.Timestamp `json:"completedAt"`
 }
 
 type ContactTag struct {
@@ -1037,29 +1129,91 @@ type ContactTag struct {
 	CreatedAt pgtype.Timestamp `json:"createdAt"`
 }
 
+type CustomObjectRecord struct {
+	ID           string           `json:"id"`
+	WorkspaceId  string           `json:"workspaceId"`
+	ObjectTypeId string           `json:"objectTypeId"`
+	Name         string           `json:"name"`
+	Data         []byte           `json:"data"`
+	CreatedById  string           `json:"createdById"`
+	UpdatedById  *string          `json:"updatedById"`
+	CreatedAt    pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt    pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt    pgtype.Timestamp `json:"deletedAt"`
+	DeletedById  *string          `json:"deletedById"`
+}
+
+type CustomObjectType struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	Key         string           `json:"key"`
+	Name        string           `json:"name"`
+	Description *string          `json:"description"`
+	Fields      []byte           `json:"fields"`
+	CreatedById string           `json:"createdById"`
+	UpdatedById *string          `json:"updatedById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt   pgtype.Timestamp `json:"deletedAt"`
+}
+
 type Deal struct {
-	ID                string           `json:"id"`
-	WorkspaceId       string           `json:"workspaceId"`
-	PipelineId        string           `json:"pipelineId"`
-	StageId           *string          `json:"stageId"`
-	ContactId         *string          `json:"contactId"`
-	Name              string           `json:"name"`
-	Value             *float64         `json:"value"`
-	CreatedAt         pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt         pgtype.Timestamp `json:"updatedAt"`
-	DeletedAt         pgtype.Timestamp `json:"deletedAt"`
-	DeletedById       *string          `json:"deletedById"`
-	Description       *string          `json:"description"`
-	Currency          string           `json:"currency"`
-	Stage             DealStage        `json:"stage"`
-	Probability       *int32           `json:"probability"`
-	ExpectedCloseDate pgtype.Timestamp `json:"expectedCloseDate"`
-	ClosedAt          pgtype.Timestamp `json:"closedAt"`
-	LostReason        *string          `json:"lostReason"`
-	CompanyId         *string          `json:"companyId"`
-	OwnerId           *string          `json:"ownerId"`
-	CreatedById       string           `json:"createdById"`
-	UpdatedById       *string          `json:"updatedById"`
+	ID                         string           `json:"id"`
+	WorkspaceId                string           `json:"workspaceId"`
+	PipelineId                 string           `json:"pipelineId"`
+	StageId                    *string          `json:"stageId"`
+	ContactId                  *string          `json:"contactId"`
+	Name                       string           `json:"name"`
+	Value                      *float64         `json:"value"`
+	CreatedAt                  pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt                  pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt                  pgtype.Timestamp `json:"deletedAt"`
+	DeletedById                *string          `json:"deletedById"`
+	Description                *string          `json:"description"`
+	Currency                   string           `json:"currency"`
+	Stage                      DealStage        `json:"stage"`
+	Probability                *int32           `json:"probability"`
+	ExpectedCloseDate          pgtype.Timestamp `json:"expectedCloseDate"`
+	ClosedAt                   pgtype.Timestamp `json:"closedAt"`
+	LostReason                 *string          `json:"lostReason"`
+	CompanyId                  *string          `json:"companyId"`
+	OwnerId                    *string          `json:"ownerId"`
+	CreatedById                string           `json:"createdById"`
+	UpdatedById                *string          `json:"updatedById"`
+	Source                     *string          `json:"source"`
+	SourceDetail               *string          `json:"sourceDetail"`
+	UtmSource                  *string          `json:"utmSource"`
+	UtmMedium                  *string          `json:"utmMedium"`
+	UtmCampaign                *string          `json:"utmCampaign"`
+	UtmTerm                    *string          `json:"utmTerm"`
+	UtmContent                 *string          `json:"utmContent"`
+	NextStepAt                 pgtype.Timestamp `json:"nextStepAt"`
+	NextStepNote               *string          `json:"nextStepNote"`
+	RottingSince               pgtype.Timestamp `json:"rottingSince"`
+	ForecastCategory           string           `json:"forecastCategory"`
+	ForecastCategoryOverridden bool             `json:"forecastCategoryOverridden"`
+	ExternalId                 *string          `json:"externalId"`
+}
+
+type DealParticipant struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	DealId      string           `json:"dealId"`
+	ContactId   string           `json:"contactId"`
+	Role        string           `json:"role"`
+	CreatedById string           `json:"createdById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
+type DealSplit struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	DealId      string           `json:"dealId"`
+	UserId      string           `json:"userId"`
+	Percentage  float64          `json:"percentage"`
+	CreatedById string           `json:"createdById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
 }
 
 type DealStageHistory struct {
@@ -1100,6 +1254,51 @@ type Email struct {
 	CreatedAt   pgtype.Timestamp `json:"createdAt"`
 }
 
+type EmailTemplate struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	Name        string           `json:"name"`
+	Subject     string           `json:"subject"`
+	Body        string           `json:"body"`
+	Description *string          `json:"description"`
+	CreatedById string           `json:"createdById"`
+	UpdatedById *string          `json:"updatedById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt   pgtype.Timestamp `json:"deletedAt"`
+}
+
+type FieldRevision struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	EntityType  string           `json:"entityType"`
+	EntityId    string           `json:"entityId"`
+	Action      string           `json:"action"`
+	Changes     []byte           `json:"changes"`
+	ActorId     string           `json:"actorId"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+}
+
+type Follower struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	EntityType  string           `json:"entityType"`
+	EntityId    string           `json:"entityId"`
+	UserId      string           `json:"userId"`
+	Reason      string           `json:"reason"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+}
+
+type Holiday struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	Date        pgtype.Date      `json:"date"`
+	Name        string           `json:"name"`
+	CreatedById string           `json:"createdById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
 type IdempotencyKey struct {
 	ID          string           `json:"id"`
 	Key         string           `json:"key"`
@@ -1164,39 +1363,86 @@ type Note struct {
 	DeletedAt   pgtype.Timestamp `json:"deletedAt"`
 	CreatedAt   pgtype.Timestamp `json:"createdAt"`
 	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	Body        []byte           `json:"body"`
+	PinnedAt    pgtype.Timestamp `json:"pinnedAt"`
+	PinnedById  *string          `json:"pinnedById"`
+	Visibility  string           `json:"visibility"`
 }
 
-type Organization struct {
-	ID               string           `json:"id"`
-	Name             string           `json:"name"`
-	Document         *string          `json:"document"`
-	BillingAddressId *string          `json:"billingAddressId"`
-	CreatedAt        pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt        pgtype.Timestamp `json:"updatedAt"`
+type NoteAttachment struct {
+	ID           string           `json:"id"`
+	WorkspaceId  string           `json:"workspaceId"`
+	NoteId       string           `json:"noteId"`
+	FileName     string           `json:"fileName"`
+	ContentType  string           `json:"contentType"`
+	Size         int64            `json:"size"`
+	StorageKey   string           `json:"storageKey"`
+	UploadedById string           `json:"uploadedById"`
+	CreatedAt    pgtype.Timestamp `json:"createdAt"`
 }
 
-type Pipeline struct {
+type Notification struct {
 	ID          string           `json:"id"`
 	WorkspaceId string           `json:"workspaceId"`
-	Name        string           `json:"name"`
-	Description *string          `json:"description"`
-	IsDefault   bool             `json:"isDefault"`
+	UserId      string           `json:"userId"`
+	EntityType  string           `json:"entityType"`
+	EntityId    string           `json:"entityId"`
+	Event       string           `json:"event"`
+	ActorId     string           `json:"actorId"`
+	ReadAt      pgtype.Timestamp `json:"readAt"`
 	CreatedAt   pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
+type Organization struct {
+	ID                   string           `json:"id"`
+	Name                 string           `json:"name"`
+	Document             *string          `json:"document"`
+	BillingAddressId     *string          `json:"billingAddressId"`
+	CreatedAt            pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt            pgtype.Timestamp `json:"updatedAt"`
+	BillingEmail         *string          `json:"billingEmail"`
+	StripeCustomerID     *string          `json:"stripeCustomerId"`
+	StripeSubscriptionID *string          `json:"stripeSubscriptionId"`
+}
+
+type OrganizationMember struct {
+	ID             string           `json:"id"`
+	OrganizationId string           `json:"organizationId"`
+	UserId         string           `json:"userId"`
+	Role           string           `json:"role"`
+	CreatedAt      pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt      pgtype.Timestamp `json:"updatedAt"`
+}
+
+type Pipeline struct {
+	ID           string           `json:"id"`
+	WorkspaceId  string           `json:"workspaceId"`
+	Name         string           `json:"name"`
+	Description  *string          `json:"description"`
+	IsDefault    bool             `json:"isDefault"`
+	CreatedAt    pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt    pgtype.Timestamp `json:"updatedAt"`
+	DeletedById  *string          `json:"deletedById"`
+	PipelineType PipelineType     `json:"pipelineType"`
+	IsActive     bool             `json:"isActive"`
+	OwnerId      *string          `json:"ownerId"`
 }
 
 type PipelineStage struct {
-	ID          string           `json:"id"`
-	PipelineId  *string          `json:"pipelineId"`
-	WorkspaceId string           `json:"workspaceId"`
-	Name        string           `json:"name"`
-	OrderIndex  int32            `json:"orderIndex"`
-	Color       *string          `json:"color"`
-	Group       StageGroup       `json:"group"`
-	Type        PipelineType     `json:"type"`
-	IsLocked    bool             `json:"isLocked"`
-	CreatedAt   pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	ID                      string           `json:"id"`
+	PipelineId              *string          `json:"pipelineId"`
+	WorkspaceId             string           `json:"workspaceId"`
+	Name                    string           `json:"name"`
+	OrderIndex              int32            `json:"orderIndex"`
+	Color                   *string          `json:"color"`
+	Group                   StageGroup       `json:"group"`
+	Type                    PipelineType     `json:"type"`
+	IsLocked                bool             `json:"isLocked"`
+	CreatedAt               pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt               pgtype.Timestamp `json:"updatedAt"`
+	RottingDays             *int32           `json:"rottingDays"`
+	FirstResponseSlaMinutes *int32           `json:"firstResponseSlaMinutes"`
+	ResolutionSlaMinutes    *int32           `json:"resolutionSlaMinutes"`
 }
 
 type PortfolioItem struct {
@@ -1221,6 +1467,60 @@ type PortfolioItem struct {
 	DeletedAt   **time.Time           `json:"deletedAt"`
 }
 
+type ReportSchedule struct {
+	ID            string           `json:"id"`
+	WorkspaceId   string           `json:"workspaceId"`
+	Name          string           `json:"name"`
+	ReportType    string           `json:"reportType"`
+	Filters       []byte           `json:"filters"`
+	Format        string           `json:"format"`
+	Recipients    []string         `json:"recipients"`
+	WebhookUrl    *string          `json:"webhookUrl"`
+	WebhookSecret *string          `json:"webhookSecret"`
+	Cron          string           `json:"cron"`
+	Timezone      string           `json:"timezone"`
+	Enabled       bool             `json:"enabled"`
+	NextRunAt     pgtype.Timestamp `json:"nextRunAt"`
+	LastRunAt     pgtype.Timestamp `json:"lastRunAt"`
+	LastStatus    *string          `json:"lastStatus"`
+	LastError     *string          `json:"lastError"`
+	CreatedById   string           `json:"createdById"`
+	UpdatedById   *string          `json:"updatedById"`
+	CreatedAt     pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamp `json:"updatedAt"`
+}
+
+type Sequence struct {
+	ID          string           `json:"id"`
+	WorkspaceId string           `json:"workspaceId"`
+	Name        string           `json:"name"`
+	Description *string          `json:"description"`
+	Steps       []byte           `json:"steps"`
+	OnDealWon   string           `json:"onDealWon"`
+	OnReply     string           `json:"onReply"`
+	CreatedById string           `json:"createdById"`
+	UpdatedById *string          `json:"updatedById"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt   pgtype.Timestamp `json:"deletedAt"`
+}
+
+type SequenceEnrollment struct {
+	ID              string           `json:"id"`
+	WorkspaceId     string           `json:"workspaceId"`
+	SequenceId      string           `json:"sequenceId"`
+	ContactId       string           `json:"contactId"`
+	DealId          *string          `json:"dealId"`
+	Status          string           `json:"status"`
+	StatusReason    *string          `json:"statusReason"`
+	StatusChangedAt pgtype.Timestamp `json:"statusChangedAt"`
+	CurrentStep     int32            `json:"currentStep"`
+	NextRunAt       pgtype.Timestamp `json:"nextRunAt"`
+	EnrolledById    string           `json:"enrolledById"`
+	CreatedAt       pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamp `json:"updatedAt"`
+}
+
 type Tag struct {
 	ID          string           `json:"id"`
 	WorkspaceId string           `json:"workspaceId"`
@@ -1253,6 +1553,35 @@ type Task struct {
 	DealId       *string          `json:"dealId"`
 	AssignedToId *string          `json:"assignedToId"`
 	StageId      *string          `json:"stageId"`
+	Visibility   string           `json:"visibility"`
+	DeletedById  *string          `json:"deletedById"`
+}
+
+type TimeEntry struct {
+	ID              string           `json:"id"`
+	WorkspaceId     string           `json:"workspaceId"`
+	UserId          string           `json:"userId"`
+	TaskId          *string          `json:"taskId"`
+	DealId          *string          `json:"dealId"`
+	Description     *string          `json:"description"`
+	StartedAt       pgtype.Timestamp `json:"startedAt"`
+	EndedAt         pgtype.Timestamp `json:"endedAt"`
+	DurationSeconds *int32           `json:"durationSeconds"`
+	Billable        bool             `json:"billable"`
+	CreatedAt       pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamp `json:"updatedAt"`
+	DeletedAt       pgtype.Timestamp `json:"deletedAt"`
+}
+
+type UndoToken struct {
+	TokenHash   string           `json:"tokenHash"`
+	WorkspaceId string           `json:"workspaceId"`
+	EntityType  string           `json:"entityType"`
+	EntityId    string           `json:"entityId"`
+	ActorId     string           `json:"actorId"`
+	ExpiresAt   pgtype.Timestamp `json:"expiresAt"`
+	UsedAt      pgtype.Timestamp `json:"usedAt"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
 }
 
 type User struct {
@@ -1272,18 +1601,22 @@ type User struct {
 }
 
 type Workspace struct {
-	ID                     string           `json:"id"`
-	Name                   string           `json:"name"`
-	Slug                   string           `json:"slug"`
-	OwnerId                string           `json:"ownerId"`
-	OrganizationId         string           `json:"organizationId"`
-	CreatedAt              pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt              pgtype.Timestamp `json:"updatedAt"`
-	StripeCustomerID       *string          `json:"stripeCustomerId"`
-	StripeSubscriptionID   *string          `json:"stripeSubscriptionId"`
-	StripePriceID          *string          `json:"stripePriceId"`
-	StripeCurrentPeriodEnd pgtype.Timestamp `json:"stripeCurrentPeriodEnd"`
-	GeocodingUsage         int32            `json:"geocodingUsage"`
+	ID                      string           `json:"id"`
+	Name                    string           `json:"name"`
+	Slug                    string           `json:"slug"`
+	OwnerId                 string           `json:"ownerId"`
+	OrganizationId          string           `json:"organizationId"`
+	CreatedAt               pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt               pgtype.Timestamp `json:"updatedAt"`
+	StripeCustomerID        *string          `json:"stripeCustomerId"`
+	StripeSubscriptionID    *string          `json:"stripeSubscriptionId"`
+	StripePriceID           *string          `json:"stripePriceId"`
+	StripeCurrentPeriodEnd  pgtype.Timestamp `json:"stripeCurrentPeriodEnd"`
+	GeocodingUsage          int32            `json:"geocodingUsage"`
+	BillingPlan             string           `json:"billingPlan"`
+	BillingStatus           string           `json:"billingStatus"`
+	StripeCancelAtPeriodEnd bool             `json:"stripeCancelAtPeriodEnd"`
+	BillingUpdatedAt        pgtype.Timestamp `json:"billingUpdatedAt"`
 }
 
 type WorkspaceMember struct {
@@ -1299,3 +1632,19 @@ type WorkspaceRole struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
+
+type WorkspaceSnapshot struct {
+	ID            string           `json:"id"`
+	WorkspaceId   string           `json:"workspaceId"`
+	Kind          string           `json:"kind"`
+	Status        string           `json:"status"`
+	ObjectKey     string           `json:"objectKey"`
+	SizeBytes     *int64           `json:"sizeBytes"`
+	EntityCounts  []byte           `json:"entityCounts"`
+	Error         *string          `json:"error"`
+	RequestedById string           `json:"requestedById"`
+	CreatedAt     pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamp `json:"updatedAt"`
+	StartedAt     pgtype.Timestamp `json:"startedAt"`
+	CompletedAt   pgtype.Timestamp `json:"completedAt"`
+}
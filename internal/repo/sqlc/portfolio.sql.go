@@ -9,7 +9,7 @@ import (
 	"context"
 )
 
-const createPortfolioItem = `-- name: CreatePortfolioItem :one
+const CreatePortfolioItem = `-- name: CreatePortfolioItem :one
 INSERT INTO "PortfolioItem" (
     "id",
     "workspaceId",
@@ -52,7 +52,7 @@ type CreatePortfolioItemParams struct {
 }
 
 func (q *Queries) CreatePortfolioItem(ctx context.Context, arg CreatePortfolioItemParams) (PortfolioItem, error) {
-	row := q.db.QueryRow(ctx, createPortfolioItem,
+	row := q.db.QueryRow(ctx, CreatePortfolioItem,
 		arg.ID,
 		arg.WorkspaceId,
 		arg.Name,
@@ -94,7 +94,7 @@ func (q *Queries) CreatePortfolioItem(ctx context.Context, arg CreatePortfolioIt
 	return i, err
 }
 
-const deletePortfolioItem = `-- name: DeletePortfolioItem :exec
+const DeletePortfolioItem = `-- name: DeletePortfolioItem :exec
 UPDATE "PortfolioItem"
 SET "deletedAt" = CURRENT_TIMESTAMP
 WHERE "workspaceId" = $1 AND "id" = $2
@@ -106,11 +106,11 @@ type DeletePortfolioItemParams struct {
 }
 
 func (q *Queries) DeletePortfolioItem(ctx context.Context, arg DeletePortfolioItemParams) error {
-	_, err := q.db.Exec(ctx, deletePortfolioItem, arg.WorkspaceId, arg.ID)
+	_, err := q.db.Exec(ctx, DeletePortfolioItem, arg.WorkspaceId, arg.ID)
 	return err
 }
 
-const getPortfolioItem = `-- name: GetPortfolioItem :one
+const GetPortfolioItem = `-- name: GetPortfolioItem :one
 SELECT id, "workspaceId", name, description, sku, category, vertical, status, visibility, "basePrice", currency, "imageUrl", metadata, tags, "createdById", "updatedById", "createdAt", "updatedAt", "deletedAt" FROM "PortfolioItem"
 WHERE "workspaceId" = $1 AND "id" = $2 AND "deletedAt" IS NULL
 `
@@ -121,7 +121,7 @@ type GetPortfolioItemParams struct {
 }
 
 func (q *Queries) GetPortfolioItem(ctx context.Context, arg GetPortfolioItemParams) (PortfolioItem, error) {
-	row := q.db.QueryRow(ctx, getPortfolioItem, arg.WorkspaceId, arg.ID)
+	row := q.db.QueryRow(ctx, GetPortfolioItem, arg.WorkspaceId, arg.ID)
 	var i PortfolioItem
 	err := row.Scan(
 		&i.ID,
@@ -147,7 +147,7 @@ func (q *Queries) GetPortfolioItem(ctx context.Context, arg GetPortfolioItemPara
 	return i, err
 }
 
-const listPortfolioItems = `-- name: ListPortfolioItems :many
+const ListPortfolioItems = `-- name: ListPortfolioItems :many
 SELECT id, "workspaceId", name, description, sku, category, vertical, status, visibility, "basePrice", currency, "imageUrl", metadata, tags, "createdById", "updatedById", "createdAt", "updatedAt", "deletedAt" FROM "PortfolioItem"
 WHERE "workspaceId" = $1
   AND "deletedAt" IS NULL
@@ -165,7 +165,7 @@ type ListPortfolioItemsParams struct {
 }
 
 func (q *Queries) ListPortfolioItems(ctx context.Context, arg ListPortfolioItemsParams) ([]PortfolioItem, error) {
-	rows, err := q.db.Query(ctx, listPortfolioItems,
+	rows, err := q.db.Query(ctx, ListPortfolioItems,
 		arg.WorkspaceId,
 		arg.Status,
 		arg.Category,
@@ -209,7 +209,7 @@ func (q *Queries) ListPortfolioItems(ctx context.Context, arg ListPortfolioItems
 	return items, nil
 }
 
-const updatePortfolioItem = `-- name: UpdatePortfolioItem :one
+const UpdatePortfolioItem = `-- name: UpdatePortfolioItem :one
 UPDATE "PortfolioItem"
 SET
     "name" = COALESCE($4, "name"),
@@ -249,7 +249,7 @@ type UpdatePortfolioItemParams struct {
 }
 
 func (q *Queries) UpdatePortfolioItem(ctx context.Context, arg UpdatePortfolioItemParams) (PortfolioItem, error) {
-	row := q.db.QueryRow(ctx, updatePortfolioItem,
+	row := q.db.QueryRow(ctx, UpdatePortfolioItem,
 		arg.WorkspaceId,
 		arg.ID,
 		arg.UpdatedById,
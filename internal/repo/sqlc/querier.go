@@ -12,6 +12,11 @@ type Querier interface {
 	CompanyExistsInWorkspace(ctx context.Context, arg CompanyExistsInWorkspaceParams) (bool, error)
 	// Verifica se um contato existe no workspace (usado por validações).
 	ContactExistsInWorkspace(ctx context.Context, arg ContactExistsInWorkspaceParams) (bool, error)
+	// Contagem por tipo para o digest da timeline (somente atividades posteriores ao cursor).
+	CountActivitiesByTypeSince(ctx context.Context, arg CountActivitiesByTypeSinceParams) ([]CountActivitiesByTypeSinceRow, error)
+	// Conta contatos ativos por estágio do funil (relatório de lifecycle).
+	// Filtros opcionais: ownerId, companyId.
+	CountContactsByLifecycleStage(ctx context.Context, arg CountContactsByLifecycleStageParams) ([]CountContactsByLifecycleStageRow, error)
 	CreateActivity(ctx context.Context, arg CreateActivityParams) (Activity, error)
 	CreateCall(ctx context.Context, arg CreateCallParams) (Call, error)
 	CreateCompany(ctx context.Context, arg CreateCompanyParams) (CreateCompanyRow, error)
@@ -19,6 +24,7 @@ type Querier interface {
 	CreateContact(ctx context.Context, arg CreateContactParams) (CreateContactRow, error)
 	CreateDeal(ctx context.Context, arg CreateDealParams) (Deal, error)
 	CreateDealHistory(ctx context.Context, arg CreateDealHistoryParams) (DealStageHistory, error)
+	CreateEmailTemplate(ctx context.Context, arg CreateEmailTemplateParams) (EmailTemplate, error)
 	CreateMeeting(ctx context.Context, arg CreateMeetingParams) (Meeting, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
 	CreateNote(ctx context.Context, arg CreateNoteParams) (Note, error)
@@ -26,6 +32,7 @@ type Querier interface {
 	// Criar nova task retornando o registro completo
 	CreateTask(ctx context.Context, arg CreateTaskParams) (CreateTaskRow, error)
 	DeleteDeal(ctx context.Context, arg DeleteDealParams) error
+	DeleteEmailTemplate(ctx context.Context, arg DeleteEmailTemplateParams) (int64, error)
 	DeletePortfolioItem(ctx context.Context, arg DeletePortfolioItemParams) error
 	// =====================================================
 	// COMPANIES QUERIES - SQLc Generated
@@ -46,6 +53,7 @@ type Querier interface {
 	// Retorna um contato específico de um workspace (IDOR protection).
 	GetContact(ctx context.Context, arg GetContactParams) (GetContactRow, error)
 	GetDeal(ctx context.Context, arg GetDealParams) (GetDealRow, error)
+	GetEmailTemplate(ctx context.Context, arg GetEmailTemplateParams) (EmailTemplate, error)
 	GetPortfolioItem(ctx context.Context, arg GetPortfolioItemParams) (PortfolioItem, error)
 	// =====================================================
 	// Task Queries (Schema Real Sincronizado)
@@ -55,12 +63,15 @@ type Querier interface {
 	// =====================================================
 	// Buscar task por ID com isolamento multi-tenant
 	GetTask(ctx context.Context, arg GetTaskParams) (GetTaskRow, error)
-	ListActivities(ctx context.Context, arg ListActivitiesParams) ([]Activity, error)
+	ListActivities(ctx context.Context, arg ListActivitiesParams) ([]ListActivitiesRow, error)
 	ListCompanies(ctx context.Context, arg ListCompaniesParams) ([]ListCompaniesRow, error)
 	// Lista contatos de um workspace com paginação cursor-based (created_at DESC).
-	// Filtros opcionais: ownerId, companyId, lifecycleStage, query (fulltext search).
+	// Filtros opcionais: ownerId, companyId, lifecycleStage, atribuição (source/utm*), query (fulltext search).
 	ListContacts(ctx context.Context, arg ListContactsParams) ([]ListContactsRow, error)
 	ListDeals(ctx context.Context, arg ListDealsParams) ([]ListDealsRow, error)
+	ListEmailTemplates(ctx context.Context, arg ListEmailTemplatesParams) ([]EmailTemplate, error)
+	// Últimas perType atividades de cada tipo posteriores ao cursor (sem metadata: payload mínimo).
+	ListLatestActivitiesByTypeSince(ctx context.Context, arg ListLatestActivitiesByTypeSinceParams) ([]ListLatestActivitiesByTypeSinceRow, error)
 	ListPortfolioItems(ctx context.Context, arg ListPortfolioItemsParams) ([]PortfolioItem, error)
 	// Listar tasks com filtros opcionais
 	ListTasks(ctx context.Context, arg ListTasksParams) ([]ListTasksRow, error)
@@ -69,10 +80,15 @@ type Querier interface {
 	SoftDeleteCompany(ctx context.Context, arg SoftDeleteCompanyParams) error
 	// Soft delete de um contato (marca deletedAt + deletedById).
 	SoftDeleteContact(ctx context.Context, arg SoftDeleteContactParams) error
+	// Move o contato para outro estágio do funil.
+	// Compare-and-set em fromStage: duas transições concorrentes não sobrescrevem uma à outra.
+	TransitionContactLifecycleStage(ctx context.Context, arg TransitionContactLifecycleStageParams) (TransitionContactLifecycleStageRow, error)
 	UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (UpdateCompanyRow, error)
 	// Atualiza um contato existente (IDOR protection + optimistic locking via updatedAt).
+	// lifecycleStage não é alterado aqui: mudanças de estágio passam por TransitionContactLifecycleStage.
 	UpdateContact(ctx context.Context, arg UpdateContactParams) (UpdateContactRow, error)
 	UpdateDeal(ctx context.Context, arg UpdateDealParams) (Deal, error)
+	UpdateEmailTemplate(ctx context.Context, arg UpdateEmailTemplateParams) (EmailTemplate, error)
 	UpdatePortfolioItem(ctx context.Context, arg UpdatePortfolioItemParams) (PortfolioItem, error)
 }
 
@@ -20,7 +20,7 @@ CREATE EXTENSION IF NOT EXISTS "vector";
 -- Company & Contact Lifecycle
 CREATE TYPE "CompanySize" AS ENUM ('STARTUP', 'SMB', 'MID_MARKET', 'ENTERPRISE');
 CREATE TYPE "CompanyLifecycleStage" AS ENUM ('LEAD', 'MQL', 'SQL', 'CUSTOMER', 'CHURNED');
-CREATE TYPE "ContactLifecycleStage" AS ENUM ('LEAD', 'MQL', 'SQL', 'OPPORTUNITY', 'CUSTOMER', 'EVANGELIST');
+CREATE TYPE "ContactLifecycleStage" AS ENUM ('LEAD', 'MQL', 'SQL', 'OPPORTUNITY', 'CUSTOMER', 'EVANGELIST', 'CHURNED');
 
 -- Deals & Pipeline
 CREATE TYPE "DealStage" AS ENUM ('OPEN', 'WON', 'LOST');
@@ -36,7 +36,7 @@ CREATE TYPE "Priority" AS ENUM ('LOW', 'MEDIUM', 'HIGH', 'URGENT');
 CREATE TYPE "TagCategory" AS ENUM ('PRIORITY', 'STATUS', 'TEMPERATURE', 'TYPE', 'QUALIFICATION');
 
 -- Activities & Communication
-CREATE TYPE "ActivityType" AS ENUM ('NOTE', 'TASK', 'EMAIL', 'CALL', 'MEETING', 'MESSAGE', 'LIFECYCLE_CHANGE');
+CREATE TYPE "ActivityType" AS ENUM ('NOTE', 'TASK', 'EMAIL', 'CALL', 'MEETING', 'MESSAGE', 'LIFECYCLE_CHANGE', 'DEAL_ROTTING', 'LINK_CLICK', 'EMAIL_OPENED', 'EMAIL_CLICKED', 'EMAIL_BOUNCED', 'EMAIL_UNSUBSCRIBED');
 CREATE TYPE "MessageDirection" AS ENUM ('INBOUND', 'OUTBOUND');
 CREATE TYPE "MessageStatus" AS ENUM ('SENT', 'DELIVERED', 'READ', 'FAILED');
 CREATE TYPE "EmailStatus" AS ENUM ('DRAFT', 'SENT', 'DELIVERED', 'OPENED', 'CLICKED', 'BOUNCED');
@@ -100,10 +100,25 @@ CREATE TABLE "Organization" (
     "billingAddressId" TEXT,
     "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
     "updatedAt" TIMESTAMP(3) NOT NULL,
+    "billingEmail" TEXT,
+    "stripe_customer_id" TEXT,
+    "stripe_subscription_id" TEXT,
 
     CONSTRAINT "Organization_pkey" PRIMARY KEY ("id")
 );
 
+-- Papel do usuário na organização (migration 000028)
+CREATE TABLE "OrganizationMember" (
+    "id" TEXT NOT NULL,
+    "organizationId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "role" TEXT NOT NULL DEFAULT 'org_member',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "OrganizationMember_pkey" PRIMARY KEY ("id")
+);
+
 CREATE TABLE "Workspace" (
     "id" TEXT NOT NULL,
     "name" TEXT NOT NULL,
@@ -117,6 +132,10 @@ CREATE TABLE "Workspace" (
     "stripe_price_id" TEXT,
     "stripe_current_period_end" TIMESTAMP(3),
     "geocodingUsage" INTEGER NOT NULL DEFAULT 0,
+    "billingPlan" TEXT NOT NULL DEFAULT 'FREE',
+    "billingStatus" TEXT NOT NULL DEFAULT 'NONE',
+    "stripe_cancel_at_period_end" BOOLEAN NOT NULL DEFAULT false,
+    "billingUpdatedAt" TIMESTAMP(3),
 
     CONSTRAINT "Workspace_pkey" PRIMARY KEY ("id")
 );
@@ -184,6 +203,26 @@ CREATE TABLE "Contact" (
     "createdById" TEXT,
     "updatedById" TEXT,
 
+    -- Attribution (migration 000005)
+    "sourceDetail" TEXT,
+    "utmSource" TEXT,
+    "utmMedium" TEXT,
+    "utmCampaign" TEXT,
+    "utmTerm" TEXT,
+    "utmContent" TEXT,
+
+    -- Next step (migration 000006)
+    "nextStepAt" TIMESTAMP(3),
+    "nextStepNote" TEXT,
+
+    -- Email verification (migration 000024)
+    "emailStatus" TEXT,
+    "emailStatusReason" TEXT,
+    "emailCheckedAt" TIMESTAMP(3),
+
+    -- Chave do integrador (migration 000052)
+    "externalId" TEXT,
+
     CONSTRAINT "Contact_pkey" PRIMARY KEY ("id")
 );
 
@@ -233,6 +272,14 @@ CREATE TABLE "Company" (
     "createdById" TEXT,
     "updatedById" TEXT,
 
+    -- Enrichment (migration 000017)
+    "industry" TEXT,
+    "logoUrl" TEXT,
+    "customFields" JSONB NOT NULL DEFAULT '{}',
+
+    -- Chave do integrador (migration 000052)
+    "externalId" TEXT,
+
     CONSTRAINT "Company_pkey" PRIMARY KEY ("id")
 );
 
@@ -248,6 +295,10 @@ CREATE TABLE "Pipeline" (
     "isDefault" BOOLEAN NOT NULL DEFAULT false,
     "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
     "updatedAt" TIMESTAMP(3) NOT NULL,
+    "deletedById" TEXT,
+    "pipelineType" "PipelineType" NOT NULL DEFAULT 'DEAL',
+    "isActive" BOOLEAN NOT NULL DEFAULT true,
+    "ownerId" TEXT,
 
     CONSTRAINT "Pipeline_pkey" PRIMARY KEY ("id")
 );
@@ -265,6 +316,16 @@ CREATE TABLE "PipelineStage" (
     "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
     "updatedAt" TIMESTAMP(3) NOT NULL,
 
+    -- Deal rotting (migration 000014)
+    "rottingDays" INTEGER,
+
+    -- Ticket SLA (migration 000015)
+    "firstResponseSlaMinutes" INTEGER,
+    "resolutionSlaMinutes" INTEGER,
+
+    -- Forecast (migration 000049)
+    "probability" INTEGER,
+
     CONSTRAINT "PipelineStage_pkey" PRIMARY KEY ("id")
 );
 
@@ -298,9 +359,44 @@ CREATE TABLE "Deal" (
     "createdById" TEXT NOT NULL,
     "updatedById" TEXT,
 
+    -- Attribution (migration 000005)
+    "source" TEXT DEFAULT 'manual',
+    "sourceDetail" TEXT,
+    "utmSource" TEXT,
+    "utmMedium" TEXT,
+    "utmCampaign" TEXT,
+    "utmTerm" TEXT,
+    "utmContent" TEXT,
+    "nextStepAt" TIMESTAMP(3),
+    "nextStepNote" TEXT,
+
+    -- Deal rotting (migration 000014)
+    "rottingSince" TIMESTAMP(3),
+
+    -- Forecast (migration 000049)
+    "forecastCategory" TEXT NOT NULL DEFAULT 'PIPELINE',
+    "forecastCategoryOverridden" BOOLEAN NOT NULL DEFAULT false,
+
+    -- Chave do integrador (migration 000052)
+    "externalId" TEXT,
+
     CONSTRAINT "Deal_pkey" PRIMARY KEY ("id")
 );
 
+-- Categoria de forecast padrão (migration 000049)
+CREATE FUNCTION deal_forecast_category(stage "DealStage", probability INTEGER) RETURNS TEXT
+LANGUAGE sql IMMUTABLE
+AS $$
+    SELECT CASE
+        WHEN stage = 'WON' THEN 'COMMIT'
+        WHEN stage = 'LOST' THEN 'OMITTED'
+        WHEN COALESCE(probability, 0) >= 90 THEN 'COMMIT'
+        WHEN COALESCE(probability, 0) >= 60 THEN 'BEST_CASE'
+        WHEN COALESCE(probability, 0) > 0 THEN 'PIPELINE'
+        ELSE 'OMITTED'
+    END
+$$;
+
 -- -----------------------------------------------------
 -- TASKS
 -- -----------------------------------------------------
@@ -327,6 +423,8 @@ CREATE TABLE "Task" (
     "dealId" TEXT,
     "assignedToId" TEXT,
     "stageId" TEXT,
+    "visibility" TEXT NOT NULL DEFAULT 'WORKSPACE',
+    "deletedById" TEXT,
 
     CONSTRAINT "Task_pkey" PRIMARY KEY ("id")
 );
@@ -395,8 +493,9 @@ CREATE TABLE "Activity" (
     "metadata" JSONB,
     "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
 
-    CONSTRAINT "Activity_pkey" PRIMARY KEY ("id")
-);
+    -- Particionada por mês (migração 000047): a PK inclui a coluna de partição
+    CONSTRAINT "Activity_pkey" PRIMARY KEY ("id", "createdAt")
+) PARTITION BY RANGE ("createdAt");
 
 -- -----------------------------------------------------
 -- NOTES
@@ -414,10 +513,28 @@ CREATE TABLE "Note" (
     "deletedAt" TIMESTAMP(3),
     "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
     "updatedAt" TIMESTAMP(3) NOT NULL,
+    "body" JSONB,
+    "pinnedAt" TIMESTAMP(3),
+    "pinnedById" TEXT,
+    "visibility" TEXT NOT NULL DEFAULT 'WORKSPACE',
 
     CONSTRAINT "Note_pkey" PRIMARY KEY ("id")
 );
 
+CREATE TABLE "NoteAttachment" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "noteId" TEXT NOT NULL,
+    "fileName" TEXT NOT NULL,
+    "contentType" TEXT NOT NULL,
+    "size" BIGINT NOT NULL,
+    "storageKey" TEXT NOT NULL,
+    "uploadedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "NoteAttachment_pkey" PRIMARY KEY ("id")
+);
+
 -- -----------------------------------------------------
 -- MESSAGES
 -- -----------------------------------------------------
@@ -601,6 +718,430 @@ CREATE TABLE "PortfolioItem" (
     CONSTRAINT "PortfolioItem_pkey" PRIMARY KEY ("id")
 );
 
+-- -----------------------------------------------------
+-- EMAIL TEMPLATES (migration 000007)
+-- -----------------------------------------------------
+CREATE TABLE "EmailTemplate" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "subject" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "description" TEXT,
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "EmailTemplate_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- SEQUENCES (migration 000008)
+-- -----------------------------------------------------
+CREATE TABLE "Sequence" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "steps" JSONB NOT NULL DEFAULT '[]',
+    "onDealWon" TEXT NOT NULL DEFAULT 'UNENROLL',
+    "onReply" TEXT NOT NULL DEFAULT 'PAUSE',
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "Sequence_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "SequenceEnrollment" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "sequenceId" TEXT NOT NULL,
+    "contactId" TEXT NOT NULL,
+    "dealId" TEXT,
+    "status" TEXT NOT NULL DEFAULT 'ACTIVE',
+    "statusReason" TEXT,
+    "statusChangedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "currentStep" INTEGER NOT NULL DEFAULT 0,
+    "nextRunAt" TIMESTAMP(3),
+    "enrolledById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SequenceEnrollment_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- TIME TRACKING (migration 000009)
+-- -----------------------------------------------------
+CREATE TABLE "TimeEntry" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "taskId" TEXT,
+    "dealId" TEXT,
+    "description" TEXT,
+    "startedAt" TIMESTAMP(3) NOT NULL,
+    "endedAt" TIMESTAMP(3),
+    "durationSeconds" INTEGER,
+    "billable" BOOLEAN NOT NULL DEFAULT TRUE,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "TimeEntry_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "WorkspaceSnapshot" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "kind" TEXT NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'PENDING',
+    "objectKey" TEXT NOT NULL,
+    "sizeBytes" BIGINT,
+    "entityCounts" JSONB NOT NULL DEFAULT '{}',
+    "error" TEXT,
+    "requestedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "startedAt" TIMESTAMP(3),
+    "completedAt" TIMESTAMP(3),
+
+    CONSTRAINT "WorkspaceSnapshot_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "FieldRevision" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "action" TEXT NOT NULL,
+    "changes" JSONB NOT NULL,
+    "actorId" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "FieldRevision_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "UndoToken" (
+    "tokenHash" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "actorId" TEXT NOT NULL,
+    "expiresAt" TIMESTAMP(3) NOT NULL,
+    "usedAt" TIMESTAMP(3),
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "UndoToken_pkey" PRIMARY KEY ("tokenHash")
+);
+
+CREATE TABLE "BusinessHours" (
+    "workspaceId" TEXT NOT NULL,
+    "timezone" TEXT NOT NULL DEFAULT 'UTC',
+    "intervals" JSONB NOT NULL DEFAULT '[]',
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "BusinessHours_pkey" PRIMARY KEY ("workspaceId")
+);
+
+CREATE TABLE "Holiday" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "date" DATE NOT NULL,
+    "name" TEXT NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Holiday_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "CompanyEnrichmentJob" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "companyId" TEXT NOT NULL,
+    "provider" TEXT NOT NULL,
+    "overwrite" BOOLEAN NOT NULL DEFAULT false,
+    "status" TEXT NOT NULL DEFAULT 'PENDING',
+    "appliedFields" JSONB NOT NULL DEFAULT '[]',
+    "error" TEXT,
+    "requestedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "startedAt" TIMESTAMP(3),
+    "completedAt" TIMESTAMP(3),
+
+    CONSTRAINT "CompanyEnrichmentJob_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "ContactBulkUpdateJob" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "contactIds" TEXT[],
+    "filter" JSONB,
+    "patch" JSONB NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'PENDING',
+    "total" INTEGER,
+    "processed" INTEGER NOT NULL DEFAULT 0,
+    "updated" INTEGER NOT NULL DEFAULT 0,
+    "cursor" TEXT,
+    "error" TEXT,
+    "requestedById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "startedAt" TIMESTAMP(3),
+    "completedAt" TIMESTAMP(3),
+
+    CONSTRAINT "ContactBulkUpdateJob_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "DealParticipant" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "dealId" TEXT NOT NULL,
+    "contactId" TEXT NOT NULL,
+    "role" TEXT NOT NULL DEFAULT 'OTHER',
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "DealParticipant_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- CUSTOM OBJECTS (migration 000025)
+-- -----------------------------------------------------
+
+CREATE TABLE "CustomObjectType" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "key" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "fields" JSONB NOT NULL DEFAULT '[]',
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+
+    CONSTRAINT "CustomObjectType_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "CustomObjectRecord" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "objectTypeId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "data" JSONB NOT NULL DEFAULT '{}',
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deletedAt" TIMESTAMP(3),
+    "deletedById" TEXT,
+
+    CONSTRAINT "CustomObjectRecord_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- COMPUTED FIELDS (migration 000026)
+-- -----------------------------------------------------
+
+CREATE TABLE "ComputedField" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "key" TEXT NOT NULL,
+    "label" TEXT NOT NULL,
+    "formula" TEXT NOT NULL,
+    "resultType" TEXT NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ComputedField_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- REPORT SCHEDULES (migration 000027)
+-- -----------------------------------------------------
+
+CREATE TABLE "ReportSchedule" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "reportType" TEXT NOT NULL,
+    "filters" JSONB NOT NULL DEFAULT '{}',
+    "format" TEXT NOT NULL DEFAULT 'csv',
+    "recipients" TEXT[] NOT NULL DEFAULT '{}',
+    "webhookUrl" TEXT,
+    "webhookSecret" TEXT,
+    "cron" TEXT NOT NULL,
+    "timezone" TEXT NOT NULL DEFAULT 'UTC',
+    "enabled" BOOLEAN NOT NULL DEFAULT true,
+    "nextRunAt" TIMESTAMP(3),
+    "lastRunAt" TIMESTAMP(3),
+    "lastStatus" TEXT,
+    "lastError" TEXT,
+    "createdById" TEXT NOT NULL,
+    "updatedById" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ReportSchedule_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- FOLLOWERS & NOTIFICATIONS
+-- -----------------------------------------------------
+
+CREATE TABLE "Follower" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "reason" TEXT NOT NULL DEFAULT 'MANUAL',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Follower_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "Notification" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "event" TEXT NOT NULL,
+    "actorId" TEXT NOT NULL,
+    "readAt" TIMESTAMP(3),
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Notification_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- ACTIVITY PARTICIPANTS (migration 000044)
+-- -----------------------------------------------------
+
+CREATE TABLE "ActivityParticipant" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "activityType" TEXT NOT NULL,
+    "activityId" TEXT NOT NULL,
+    "userId" TEXT,
+    "contactId" TEXT,
+    "role" TEXT NOT NULL DEFAULT 'ATTENDEE',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ActivityParticipant_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- DEAL SPLITS (migration 000050)
+-- -----------------------------------------------------
+
+CREATE TABLE "DealSplit" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "dealId" TEXT NOT NULL,
+    "userId" TEXT NOT NULL,
+    "percentage" DOUBLE PRECISION NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "DealSplit_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- IDENTITY MAPPINGS (migration 000053)
+-- -----------------------------------------------------
+
+CREATE TABLE "IdentityMapping" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "externalId" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "IdentityMapping_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- SYNC POLICIES (migration 000054)
+-- -----------------------------------------------------
+
+CREATE TABLE "SyncPolicy" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "strategy" TEXT NOT NULL DEFAULT 'LAST_WRITER_WINS',
+    "priority" INTEGER NOT NULL DEFAULT 0,
+    "fieldPriorities" JSONB NOT NULL DEFAULT '{}',
+    "createdById" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SyncPolicy_pkey" PRIMARY KEY ("id")
+);
+
+CREATE TABLE "SyncFieldSource" (
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "field" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SyncFieldSource_pkey" PRIMARY KEY ("workspaceId", "entityType", "entityId", "field")
+);
+
+CREATE TABLE "SyncConflict" (
+    "id" TEXT NOT NULL,
+    "workspaceId" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityId" TEXT NOT NULL,
+    "field" TEXT NOT NULL,
+    "system" TEXT NOT NULL,
+    "currentSystem" TEXT NOT NULL,
+    "incomingValue" JSONB,
+    "currentValue" JSONB,
+    "resolution" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SyncConflict_pkey" PRIMARY KEY ("id")
+);
+
+-- -----------------------------------------------------
+-- SOFT DELETE DETACHMENTS (migration 000055)
+-- -----------------------------------------------------
+
+CREATE TABLE "SoftDeleteDetachment" (
+    "workspaceId" TEXT NOT NULL,
+    "parentType" TEXT NOT NULL,
+    "parentId" TEXT NOT NULL,
+    "childType" TEXT NOT NULL,
+    "childId" TEXT NOT NULL,
+    "field" TEXT NOT NULL,
+    "detachedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "SoftDeleteDetachment_pkey" PRIMARY KEY ("parentId", "childType", "childId", "field")
+);
+
 -- =====================================================
 -- ÍNDICES (Principais para Performance)
 -- =====================================================
@@ -627,12 +1168,17 @@ CREATE INDEX "Contact_ownerId_idx" ON "Contact"("ownerId");
 CREATE INDEX "Contact_workspaceId_lifecycleStage_idx" ON "Contact"("workspaceId", "lifecycleStage");
 CREATE INDEX "Contact_assignedToId_idx" ON "Contact"("assignedToId");
 CREATE INDEX "Contact_deletedAt_idx" ON "Contact"("deletedAt");
+CREATE INDEX "Contact_workspaceId_source_idx" ON "Contact"("workspaceId", "source");
+CREATE INDEX "Contact_workspaceId_utmCampaign_idx" ON "Contact"("workspaceId", "utmCampaign");
+CREATE INDEX "Contact_workspaceId_nextStepAt_idx" ON "Contact"("workspaceId", "nextStepAt") WHERE "deletedAt" IS NULL AND "nextStepAt" IS NOT NULL;
+CREATE UNIQUE INDEX "Contact_workspaceId_externalId_key" ON "Contact"("workspaceId", "externalId") WHERE "externalId" IS NOT NULL AND "deletedAt" IS NULL;
 
 -- Company
 CREATE INDEX "Company_workspaceId_idx" ON "Company"("workspaceId");
 CREATE INDEX "Company_workspaceId_lifecycleStage_idx" ON "Company"("workspaceId", "lifecycleStage");
 CREATE INDEX "Company_assignedToId_idx" ON "Company"("assignedToId");
 CREATE INDEX "Company_deletedAt_idx" ON "Company"("deletedAt");
+CREATE UNIQUE INDEX "Company_workspaceId_externalId_key" ON "Company"("workspaceId", "externalId") WHERE "externalId" IS NOT NULL AND "deletedAt" IS NULL;
 
 -- Pipeline
 CREATE INDEX "Pipeline_workspaceId_idx" ON "Pipeline"("workspaceId");
@@ -652,6 +1198,40 @@ CREATE INDEX "Deal_contactId_idx" ON "Deal"("contactId");
 CREATE INDEX "Deal_companyId_idx" ON "Deal"("companyId");
 CREATE INDEX "Deal_ownerId_idx" ON "Deal"("ownerId");
 CREATE INDEX "Deal_deletedAt_idx" ON "Deal"("deletedAt");
+CREATE INDEX "Deal_workspaceId_source_idx" ON "Deal"("workspaceId", "source");
+CREATE UNIQUE INDEX "Deal_workspaceId_externalId_key" ON "Deal"("workspaceId", "externalId") WHERE "externalId" IS NOT NULL AND "deletedAt" IS NULL;
+CREATE INDEX "Deal_workspaceId_utmCampaign_idx" ON "Deal"("workspaceId", "utmCampaign");
+CREATE INDEX "Deal_workspaceId_nextStepAt_idx" ON "Deal"("workspaceId", "nextStepAt") WHERE "deletedAt" IS NULL AND "nextStepAt" IS NOT NULL;
+CREATE INDEX "Deal_workspaceId_rottingSince_idx" ON "Deal"("workspaceId", "rottingSince") WHERE "deletedAt" IS NULL AND "rottingSince" IS NOT NULL;
+CREATE INDEX "Deal_workspaceId_ownerId_open_idx" ON "Deal"("workspaceId", "ownerId") WHERE "deletedAt" IS NULL AND "stage" = 'OPEN';
+
+-- IdentityMapping
+CREATE UNIQUE INDEX "IdentityMapping_workspaceId_system_entityType_externalId_key" ON "IdentityMapping"("workspaceId", "system", "entityType", "externalId");
+CREATE INDEX "IdentityMapping_workspaceId_entityType_entityId_idx" ON "IdentityMapping"("workspaceId", "entityType", "entityId");
+
+-- Sync
+CREATE UNIQUE INDEX "SyncPolicy_workspaceId_system_key" ON "SyncPolicy"("workspaceId", "system");
+CREATE INDEX "SyncConflict_workspaceId_createdAt_idx" ON "SyncConflict"("workspaceId", "createdAt" DESC);
+CREATE INDEX "SyncConflict_workspaceId_entityType_entityId_idx" ON "SyncConflict"("workspaceId", "entityType", "entityId");
+
+-- SoftDeleteDetachment
+CREATE INDEX "SoftDeleteDetachment_detachedAt_idx" ON "SoftDeleteDetachment"("detachedAt");
+
+-- EmailTemplate
+CREATE INDEX "EmailTemplate_workspaceId_idx" ON "EmailTemplate"("workspaceId");
+CREATE UNIQUE INDEX "unique_email_template_name_per_workspace" ON "EmailTemplate"("workspaceId", "name") WHERE "deletedAt" IS NULL;
+
+-- Sequence
+CREATE INDEX "Sequence_workspaceId_idx" ON "Sequence"("workspaceId");
+CREATE INDEX "SequenceEnrollment_workspaceId_sequenceId_idx" ON "SequenceEnrollment"("workspaceId", "sequenceId");
+CREATE INDEX "SequenceEnrollment_nextRunAt_idx" ON "SequenceEnrollment"("nextRunAt") WHERE "status" = 'ACTIVE';
+CREATE UNIQUE INDEX "unique_active_enrollment_per_sequence" ON "SequenceEnrollment"("sequenceId", "contactId") WHERE "status" IN ('ACTIVE', 'PAUSED');
+
+-- TimeEntry
+CREATE INDEX "TimeEntry_workspaceId_startedAt_idx" ON "TimeEntry"("workspaceId", "startedAt") WHERE "deletedAt" IS NULL;
+CREATE INDEX "TimeEntry_taskId_idx" ON "TimeEntry"("taskId") WHERE "deletedAt" IS NULL;
+CREATE INDEX "TimeEntry_dealId_idx" ON "TimeEntry"("dealId") WHERE "deletedAt" IS NULL;
+CREATE UNIQUE INDEX "unique_running_time_entry_per_user" ON "TimeEntry"("workspaceId", "userId") WHERE "endedAt" IS NULL AND "deletedAt" IS NULL;
 
 -- Task
 CREATE INDEX "Task_workspaceId_idx" ON "Task"("workspaceId");
@@ -697,6 +1277,7 @@ CREATE INDEX "PortfolioItem_deletedAt_idx" ON "PortfolioItem"("deletedAt");
 CREATE INDEX "Activity_contactId_createdAt_idx" ON "Activity"("contactId", "createdAt" DESC);
 CREATE INDEX "Activity_companyId_activityType_idx" ON "Activity"("companyId", "activityType");
 CREATE INDEX "Activity_dealId_createdAt_idx" ON "Activity"("dealId", "createdAt" DESC);
+CREATE INDEX "Activity_companyId_createdAt_idx" ON "Activity"("companyId", "createdAt" DESC);
 CREATE INDEX "Activity_workspaceId_activityType_idx" ON "Activity"("workspaceId", "activityType");
 CREATE INDEX "Activity_userId_idx" ON "Activity"("userId");
 
@@ -724,6 +1305,7 @@ CREATE INDEX "Call_calledAt_idx" ON "Call"("calledAt" DESC);
 -- Meeting
 CREATE INDEX "Meeting_workspaceId_idx" ON "Meeting"("workspaceId");
 CREATE INDEX "Meeting_startTime_idx" ON "Meeting"("startTime");
+CREATE INDEX "Meeting_workspaceId_userId_startTime_idx" ON "Meeting"("workspaceId", "userId", "startTime") WHERE "deletedAt" IS NULL;
 
 -- MeetingAttendee
 CREATE INDEX "MeetingAttendee_meetingId_idx" ON "MeetingAttendee"("meetingId");
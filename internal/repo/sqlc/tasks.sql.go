@@ -11,7 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createTask = `-- name: CreateTask :one
+const CreateTask = `-- name: CreateTask :one
 INSERT INTO "Task" (
     "id", "title", "workspaceId", "description",
     "status", "priority", "type", "dueDate", "assignedToId"
@@ -62,7 +62,7 @@ type CreateTaskRow struct {
 
 // Criar nova task retornando o registro completo
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (CreateTaskRow, error) {
-	row := q.db.QueryRow(ctx, createTask,
+	row := q.db.QueryRow(ctx, CreateTask,
 		arg.ID,
 		arg.Title,
 		arg.WorkspaceId,
@@ -98,7 +98,7 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (CreateT
 	return i, err
 }
 
-const getTask = `-- name: GetTask :one
+const GetTask = `-- name: GetTask :one
 
 SELECT 
     "id", "title", "workspaceId", "description",
@@ -147,7 +147,7 @@ type GetTaskRow struct {
 // =====================================================
 // Buscar task por ID com isolamento multi-tenant
 func (q *Queries) GetTask(ctx context.Context, arg GetTaskParams) (GetTaskRow, error) {
-	row := q.db.QueryRow(ctx, getTask, arg.ID, arg.WorkspaceId)
+	row := q.db.QueryRow(ctx, GetTask, arg.ID, arg.WorkspaceId)
 	var i GetTaskRow
 	err := row.Scan(
 		&i.ID,
@@ -173,7 +173,7 @@ func (q *Queries) GetTask(ctx context.Context, arg GetTaskParams) (GetTaskRow, e
 	return i, err
 }
 
-const listTasks = `-- name: ListTasks :many
+const ListTasks = `-- name: ListTasks :many
 SELECT 
     "id", "title", "workspaceId", "description",
     "status", "priority", "type", "taskType", "reminderType",
@@ -220,7 +220,7 @@ type ListTasksRow struct {
 
 // Listar tasks com filtros opcionais
 func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]ListTasksRow, error) {
-	rows, err := q.db.Query(ctx, listTasks,
+	rows, err := q.db.Query(ctx, ListTasks,
 		arg.WorkspaceId,
 		arg.Limit,
 		arg.FilterStatus,
@@ -0,0 +1,257 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrSsoProviderNotFound = apperr.NotFound("sso provider not found in workspace", "identity provider not found")
+
+	// ErrSsoProviderConflict o workspace já tem um IdP com o mesmo issuer
+	ErrSsoProviderConflict = apperr.Conflict("sso provider with this issuer already exists in workspace", "an identity provider with this issuer already exists")
+
+	// ErrSsoTokenInvalid id_token de issuer não configurado (ou desativado), com assinatura, audiência
+	// ou validade inválidas. A mensagem não distingue os casos para não revelar a configuração.
+	ErrSsoTokenInvalid = apperr.Define("INVALID_TOKEN", http.StatusUnauthorized, "sso id_token rejected", "invalid SSO token")
+)
+
+// SsoRepository persiste os IdPs OIDC dos workspaces e as identidades vinculadas aos usuários.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type SsoRepository struct {
+	pool database.DB
+}
+
+func NewSsoRepository(pool database.DB) *SsoRepository {
+	return &SsoRepository{pool: pool}
+}
+
+const ssoProviderColumns = `id, "workspaceId", name, issuer, "clientId", "allowedDomains", "defaultRole",
+	"jitProvisioning", enabled, "lastLoginAt", "createdById", "createdAt", "updatedAt"`
+
+// ListProviders retorna os IdPs do workspace, mais antigos primeiro.
+func (r *SsoRepository) ListProviders(ctx context.Context, workspaceID string) ([]domain.SsoProvider, error) {
+	query := `SELECT ` + ssoProviderColumns + ` FROM public."SsoProvider" WHERE "workspaceId" = $1 ORDER BY "createdAt", id`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query sso providers: %w", err)
+	}
+	defer rows.Close()
+
+	providers := []domain.SsoProvider{}
+	for rows.Next() {
+		p, err := scanSsoProvider(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan sso provider: %w", err)
+		}
+		providers = append(providers, *p)
+	}
+	return providers, rows.Err()
+}
+
+// GetProvider retorna o IdP do workspace.
+func (r *SsoRepository) GetProvider(ctx context.Context, workspaceID, providerID string) (*domain.SsoProvider, error) {
+	query := `SELECT ` + ssoProviderColumns + ` FROM public."SsoProvider" WHERE "workspaceId" = $1 AND id = $2`
+
+	p, err := scanSsoProvider(r.pool.QueryRow(ctx, query, workspaceID, providerID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSsoProviderNotFound
+		}
+		return nil, fmt.Errorf("query sso provider: %w", err)
+	}
+	return p, nil
+}
+
+// GetEnabledProviderByIssuer retorna o IdP ativo do workspace para o issuer do id_token.
+func (r *SsoRepository) GetEnabledProviderByIssuer(ctx context.Context, workspaceID, issuer string) (*domain.SsoProvider, error) {
+	query := `SELECT ` + ssoProviderColumns + ` FROM public."SsoProvider" WHERE "workspaceId" = $1 AND issuer = $2 AND enabled`
+
+	p, err := scanSsoProvider(r.pool.QueryRow(ctx, query, workspaceID, issuer))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSsoTokenInvalid
+		}
+		return nil, fmt.Errorf("query sso provider by issuer: %w", err)
+	}
+	return p, nil
+}
+
+// CreateProvider cadastra o IdP.
+func (r *SsoRepository) CreateProvider(ctx context.Context, p *domain.SsoProvider) (*domain.SsoProvider, error) {
+	query := `
+		INSERT INTO public."SsoProvider" (
+			id, "workspaceId", name, issuer, "clientId", "allowedDomains", "defaultRole", "jitProvisioning", enabled, "createdById"
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + ssoProviderColumns
+
+	created, err := scanSsoProvider(r.pool.QueryRow(ctx, query,
+		p.ID, p.WorkspaceID, p.Name, p.Issuer, p.ClientID, p.AllowedDomains, p.DefaultRole, p.JITProvisioning, p.Enabled, p.CreatedByID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrSsoProviderConflict
+		}
+		return nil, fmt.Errorf("insert sso provider: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateProvider grava os campos editáveis do IdP.
+func (r *SsoRepository) UpdateProvider(ctx context.Context, p *domain.SsoProvider) (*domain.SsoProvider, error) {
+	query := `
+		UPDATE public."SsoProvider"
+		SET name = $3, "clientId" = $4, "allowedDomains" = $5, "defaultRole" = $6, "jitProvisioning" = $7,
+		    enabled = $8, "updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + ssoProviderColumns
+
+	updated, err := scanSsoProvider(r.pool.QueryRow(ctx, query,
+		p.WorkspaceID, p.ID, p.Name, p.ClientID, p.AllowedDomains, p.DefaultRole, p.JITProvisioning, p.Enabled,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSsoProviderNotFound
+		}
+		return nil, fmt.Errorf("update sso provider: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteProvider remove o IdP e as identidades vinculadas (os membros continuam no workspace).
+func (r *SsoRepository) DeleteProvider(ctx context.Context, workspaceID, providerID string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM public."SsoProvider" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, providerID)
+	if err != nil {
+		return fmt.Errorf("delete sso provider: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSsoProviderNotFound
+	}
+	return nil
+}
+
+// FindLinkedUser retorna o "User" vinculado ao sub do IdP; sem vínculo, o usuário ativo com o
+// email entre os membros do workspace do IdP ou, com anyWorkspace, em qualquer workspace
+// (vazio se nenhum). anyWorkspace só deve valer para domínios controlados pelo workspace:
+// um IdP não pode assumir a conta de quem não pertence a ele.
+func (r *SsoRepository) FindLinkedUser(ctx context.Context, p *domain.SsoProvider, subject, email string, anyWorkspace bool) (string, error) {
+	query := `
+		SELECT id FROM (
+		    SELECT u.id, 0 AS rank, u."createdAt"
+		    FROM public."SsoIdentity" i
+		    JOIN public."User" u ON u.id = i."userId"
+		    WHERE i."providerId" = $1 AND i.subject = $2 AND u."deletedAt" IS NULL
+		    UNION ALL
+		    SELECT u.id, CASE WHEN m."userId" IS NOT NULL THEN 1 ELSE 2 END, u."createdAt"
+		    FROM public."User" u
+		    LEFT JOIN public."WorkspaceMember" m ON m."userId" = u.id AND m."workspaceId" = $4
+		    WHERE $3 <> '' AND lower(u.email) = lower($3) AND u."deletedAt" IS NULL
+		      AND (m."userId" IS NOT NULL OR $5)
+		) candidates
+		ORDER BY rank, "createdAt"
+		LIMIT 1`
+
+	var id string
+	if err := r.pool.QueryRow(ctx, query, p.ID, subject, email, p.WorkspaceID, anyWorkspace).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("query sso linked user: %w", err)
+	}
+	return id, nil
+}
+
+// RecordLogin registra o login em uma transação: cria o "User" (sem userID, com newUserID),
+// vincula o sub ao usuário e, com addMember, o adiciona ao workspace com o defaultRole do IdP.
+// Retorna o usuário, o papel no workspace e se ele entrou no workspace agora.
+func (r *SsoRepository) RecordLogin(ctx context.Context, p *domain.SsoProvider, userID, newUserID string, login *domain.SsoLogin, addMember bool) (string, domain.Role, bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return "", "", false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if userID == "" {
+		userID = newUserID
+		// Email já usado por uma conta que o IdP não pode assumir (FindLinkedUser): o usuário novo
+		// fica sem email ("User".email é único) e o endereço segue na SsoIdentity
+		_, err := tx.Exec(ctx, `
+			INSERT INTO public."User" (id, name, email, "updatedAt")
+			SELECT $1, $2, CASE WHEN EXISTS (SELECT 1 FROM public."User" WHERE lower(email) = lower($3)) THEN NULL ELSE $3 END, NOW()`,
+			userID, login.Name, login.Email)
+		if err != nil {
+			return "", "", false, fmt.Errorf("insert user: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO public."SsoIdentity" ("providerId", subject, "userId", email)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("providerId", subject) DO UPDATE
+		SET email = EXCLUDED.email, "lastLoginAt" = NOW()`, p.ID, login.Subject, userID, login.Email)
+	if err != nil {
+		return "", "", false, fmt.Errorf("upsert sso identity: %w", err)
+	}
+
+	provisioned := false
+	if addMember {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO public."WorkspaceMember" ("userId", "workspaceId", "workspaceRoleId", accepted_at)
+			SELECT $1, $2, wr.id, NOW()
+			FROM public."WorkspaceRole" wr
+			WHERE wr.name = $3
+			ON CONFLICT ("userId", "workspaceId") DO NOTHING`, userID, p.WorkspaceID, p.DefaultRole)
+		if err != nil {
+			return "", "", false, fmt.Errorf("insert workspace member: %w", err)
+		}
+		provisioned = tag.RowsAffected() > 0
+	}
+
+	var roleName string
+	err = tx.QueryRow(ctx, `
+		SELECT r.name
+		FROM public."WorkspaceMember" m
+		JOIN public."WorkspaceRole" r ON m."workspaceRoleId" = r.id
+		WHERE m."userId" = $1 AND m."workspaceId" = $2`, userID, p.WorkspaceID).Scan(&roleName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", false, ErrMemberNotFound
+		}
+		return "", "", false, fmt.Errorf("query member role: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE public."SsoProvider" SET "lastLoginAt" = NOW() WHERE id = $1`, p.ID); err != nil {
+		return "", "", false, fmt.Errorf("update sso provider last login: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", "", false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return userID, domain.Role(roleName), provisioned, nil
+}
+
+func scanSsoProvider(row pgx.Row) (*domain.SsoProvider, error) {
+	var p domain.SsoProvider
+	err := row.Scan(
+		&p.ID, &p.WorkspaceID, &p.Name, &p.Issuer, &p.ClientID, &p.AllowedDomains, &p.DefaultRole,
+		&p.JITProvisioning, &p.Enabled, &p.LastLoginAt, &p.CreatedByID, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if p.AllowedDomains == nil {
+		p.AllowedDomains = []string{}
+	}
+	return &p, nil
+}
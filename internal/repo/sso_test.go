@@ -0,0 +1,88 @@
+package repo_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestSsoRepository_FindLinkedUser_Integration
+func TestSsoRepository_FindLinkedUser_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+
+	// Usuário criado pelo login; removido depois do workspace (WorkspaceMember é RESTRICT)
+	newUserID, err := id.New(id.User)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM public."User" WHERE id = $1`, newUserID)
+	})
+
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+	sso := repo.NewSsoRepository(pool)
+
+	providerID, err := id.New(id.SsoProvider)
+	require.NoError(t, err)
+	provider, err := sso.CreateProvider(ctx, &domain.SsoProvider{
+		ID:              providerID,
+		WorkspaceID:     f.WorkspaceID,
+		Name:            "Okta",
+		Issuer:          "https://idp.example.com",
+		ClientID:        "client",
+		AllowedDomains:  []string{},
+		DefaultRole:     domain.RoleUser,
+		JITProvisioning: true,
+		Enabled:         true,
+		CreatedByID:     f.UserID,
+	})
+	require.NoError(t, err)
+
+	member := f.Member(domain.RoleUser)
+	memberEmail := member + "@test.linkko.local"
+	outsiderEmail := other.UserID + "@test.linkko.local"
+
+	t.Run("member of the workspace is linked by email", func(t *testing.T) {
+		userID, err := sso.FindLinkedUser(ctx, provider, "sub-member", strings.ToUpper(memberEmail), false)
+		require.NoError(t, err)
+		assert.Equal(t, member, userID)
+	})
+
+	t.Run("user of another workspace is not linked by email", func(t *testing.T) {
+		userID, err := sso.FindLinkedUser(ctx, provider, "sub-outsider", outsiderEmail, false)
+		require.NoError(t, err)
+		assert.Empty(t, userID)
+	})
+
+	t.Run("user of another workspace is linked when the domain belongs to the provider", func(t *testing.T) {
+		userID, err := sso.FindLinkedUser(ctx, provider, "sub-outsider", outsiderEmail, true)
+		require.NoError(t, err)
+		assert.Equal(t, other.UserID, userID)
+	})
+
+	t.Run("first login with a taken email creates a new user", func(t *testing.T) {
+		login := &domain.SsoLogin{Subject: "sub-outsider", Email: outsiderEmail}
+		userID, role, provisioned, err := sso.RecordLogin(ctx, provider, "", newUserID, login, true)
+		require.NoError(t, err)
+		assert.Equal(t, newUserID, userID)
+		assert.Equal(t, domain.RoleUser, role)
+		assert.True(t, provisioned)
+
+		var email *string
+		require.NoError(t, pool.QueryRow(ctx, `SELECT email FROM public."User" WHERE id = $1`, newUserID).Scan(&email))
+		assert.Nil(t, email, "the email stays with the existing account")
+
+		// O sub agora aponta para o usuário novo, não para a conta com o email
+		linked, err := sso.FindLinkedUser(ctx, provider, "sub-outsider", outsiderEmail, true)
+		require.NoError(t, err)
+		assert.Equal(t, newUserID, linked)
+	})
+}
@@ -0,0 +1,75 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestPipelineRepository_StageMetrics_Integration
+func TestPipelineRepository_StageMetrics_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	pipelines := repo.NewPipelineRepository(pool)
+
+	pipeline := f.Pipeline()
+	lead, proposal := pipeline.Stages[0].ID, pipeline.Stages[1].ID
+	deal := func(stageID string, value float64, opts ...func(*domain.Deal)) *domain.Deal {
+		return f.Deal(append([]func(*domain.Deal){func(d *domain.Deal) {
+			d.PipelineID = pipeline.ID
+			d.StageID = &stageID
+			d.Value = &value
+		}}, opts...)...)
+	}
+
+	deal(lead, 1000)
+	deal(lead, 500.25)
+	// Fora das métricas: negócio ganho e negócio excluído
+	deal(lead, 7000, func(d *domain.Deal) { d.Stage = domain.DealStageWon })
+	deleted := deal(lead, 3000)
+	_, err := pool.Exec(ctx, `UPDATE public."Deal" SET "deletedAt" = NOW() WHERE id = $1`, deleted.ID)
+	require.NoError(t, err)
+	deal(proposal, 200)
+	// Negócio de outro pipeline não afeta as etapas deste
+	f.Deal()
+
+	t.Run("stages carry open deal totals", func(t *testing.T) {
+		stages, err := pipelines.ListStagesByPipeline(ctx, f.WorkspaceID, &pipeline.ID, true)
+		require.NoError(t, err)
+		require.Len(t, stages, 3)
+
+		metrics := make([]domain.StageMetrics, len(stages))
+		for i, s := range stages {
+			require.NotNil(t, s.Metrics, s.Name)
+			metrics[i] = *s.Metrics
+		}
+		assert.Equal(t, []domain.StageMetrics{
+			{OpenDeals: 2, OpenValue: 1500.25},
+			{OpenDeals: 1, OpenValue: 200},
+			{OpenDeals: 0, OpenValue: 0},
+		}, metrics)
+	})
+
+	t.Run("metrics are opt-in", func(t *testing.T) {
+		stages, err := pipelines.ListStagesByPipeline(ctx, f.WorkspaceID, &pipeline.ID, false)
+		require.NoError(t, err)
+		require.Len(t, stages, 3)
+		for _, s := range stages {
+			assert.Nil(t, s.Metrics)
+		}
+	})
+
+	t.Run("pipeline with stages", func(t *testing.T) {
+		got, err := pipelines.GetWithStages(ctx, f.WorkspaceID, pipeline.ID, true)
+		require.NoError(t, err)
+		require.Len(t, got.Stages, 3)
+		require.NotNil(t, got.Stages[0].Metrics)
+		assert.Equal(t, int64(2), got.Stages[0].Metrics.OpenDeals)
+	})
+}
@@ -0,0 +1,220 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrSyncPolicyNotFound = apperr.NotFound("sync policy not found in workspace", "sync policy not found")
+
+// SyncPolicyRepository persiste as políticas de conflito das integrações, a origem (sistema) de
+// cada campo gravado por upsert e o log de conflitos.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type SyncPolicyRepository struct {
+	pool database.DB
+}
+
+func NewSyncPolicyRepository(pool database.DB) *SyncPolicyRepository {
+	return &SyncPolicyRepository{pool: pool}
+}
+
+const syncPolicyColumns = `id, "workspaceId", system, strategy, priority, "fieldPriorities", "createdById", "createdAt", "updatedAt"`
+
+const syncConflictColumns = `id, "workspaceId", "entityType", "entityId", field, system, "currentSystem", "incomingValue", "currentValue", resolution, "createdAt"`
+
+// ListPolicies retorna as políticas do workspace, por system.
+func (r *SyncPolicyRepository) ListPolicies(ctx context.Context, workspaceID string) ([]domain.SyncPolicy, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+syncPolicyColumns+`
+		FROM public."SyncPolicy"
+		WHERE "workspaceId" = $1
+		ORDER BY system`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query sync policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []domain.SyncPolicy{}
+	for rows.Next() {
+		p, err := scanSyncPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan sync policy: %w", err)
+		}
+		policies = append(policies, *p)
+	}
+	return policies, rows.Err()
+}
+
+// PutPolicy cria ou substitui a política do system; id, createdById e createdAt originais são mantidos.
+func (r *SyncPolicyRepository) PutPolicy(ctx context.Context, p *domain.SyncPolicy) (*domain.SyncPolicy, error) {
+	fieldPriorities, err := json.Marshal(p.FieldPriorities)
+	if err != nil {
+		return nil, fmt.Errorf("encode field priorities: %w", err)
+	}
+
+	saved, err := scanSyncPolicy(r.pool.QueryRow(ctx, `
+		INSERT INTO public."SyncPolicy" (id, "workspaceId", system, strategy, priority, "fieldPriorities", "createdById", "createdAt", "updatedAt")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT ("workspaceId", system)
+		DO UPDATE SET strategy = EXCLUDED.strategy, priority = EXCLUDED.priority,
+			"fieldPriorities" = EXCLUDED."fieldPriorities", "updatedAt" = EXCLUDED."updatedAt"
+		RETURNING `+syncPolicyColumns,
+		p.ID, p.WorkspaceID, p.System, string(p.Strategy), p.Priority, fieldPriorities, p.CreatedByID, p.CreatedAt.UTC(),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("put sync policy: %w", err)
+	}
+	return saved, nil
+}
+
+// DeletePolicy remove a política: o system volta ao padrão (LAST_WRITER_WINS, prioridade 0).
+func (r *SyncPolicyRepository) DeletePolicy(ctx context.Context, workspaceID, system string) error {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM public."SyncPolicy"
+		WHERE "workspaceId" = $1 AND system = $2`,
+		workspaceID, system,
+	)
+	if err != nil {
+		return fmt.Errorf("delete sync policy: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSyncPolicyNotFound
+	}
+	return nil
+}
+
+// FieldSources retorna o último system que gravou cada campo do registro (campo -> system).
+func (r *SyncPolicyRepository) FieldSources(ctx context.Context, workspaceID string, entityType domain.IdentityEntityType, entityID string) (map[string]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT field, system
+		FROM public."SyncFieldSource"
+		WHERE "workspaceId" = $1 AND "entityType" = $2 AND "entityId" = $3`,
+		workspaceID, string(entityType), entityID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query sync field sources: %w", err)
+	}
+	defer rows.Close()
+
+	sources := map[string]string{}
+	for rows.Next() {
+		var field, system string
+		if err := rows.Scan(&field, &system); err != nil {
+			return nil, fmt.Errorf("scan sync field source: %w", err)
+		}
+		sources[field] = system
+	}
+	return sources, rows.Err()
+}
+
+// RecordSync grava numa transação a nova origem dos campos aplicados e os conflitos do upsert.
+func (r *SyncPolicyRepository) RecordSync(ctx context.Context, workspaceID string, entityType domain.IdentityEntityType, entityID, system string, fields []string, conflicts []domain.SyncConflict, at time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if len(fields) > 0 {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO public."SyncFieldSource" ("workspaceId", "entityType", "entityId", field, system, "updatedAt")
+			SELECT $1, $2, $3, field, $4, $5
+			FROM unnest($6::TEXT[]) AS field
+			ON CONFLICT ("workspaceId", "entityType", "entityId", field)
+			DO UPDATE SET system = EXCLUDED.system, "updatedAt" = EXCLUDED."updatedAt"`,
+			workspaceID, string(entityType), entityID, system, at.UTC(), fields,
+		); err != nil {
+			return fmt.Errorf("upsert sync field sources: %w", err)
+		}
+	}
+
+	for _, c := range conflicts {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO public."SyncConflict" (id, "workspaceId", "entityType", "entityId", field, system, "currentSystem", "incomingValue", "currentValue", resolution, "createdAt")
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			c.ID, workspaceID, string(entityType), entityID, c.Field, c.System, c.CurrentSystem,
+			[]byte(c.IncomingValue), []byte(c.CurrentValue), string(c.Resolution), c.CreatedAt.UTC(),
+		); err != nil {
+			return fmt.Errorf("insert sync conflict: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListConflicts lista o log de conflitos, mais recentes primeiro (até Limit+1 para paginação).
+func (r *SyncPolicyRepository) ListConflicts(ctx context.Context, params domain.ListSyncConflictsParams) ([]domain.SyncConflict, error) {
+	var entityType *string
+	if params.EntityType != nil {
+		t := string(*params.EntityType)
+		entityType = &t
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+syncConflictColumns+`
+		FROM public."SyncConflict"
+		WHERE "workspaceId" = $1
+		  AND ($2::TEXT IS NULL OR system = $2 OR "currentSystem" = $2)
+		  AND ($3::TEXT IS NULL OR "entityType" = $3)
+		  AND ($4::TEXT IS NULL OR "entityId" = $4)
+		  AND ($5::TIMESTAMP IS NULL OR "createdAt" < $5)
+		ORDER BY "createdAt" DESC, id DESC
+		LIMIT $6`,
+		params.WorkspaceID, params.System, entityType, params.EntityID, params.Cursor, params.Limit+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query sync conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := []domain.SyncConflict{}
+	for rows.Next() {
+		var c domain.SyncConflict
+		var entityType, resolution string
+		var incoming, current []byte
+		if err := rows.Scan(&c.ID, &c.WorkspaceID, &entityType, &c.EntityID, &c.Field, &c.System, &c.CurrentSystem,
+			&incoming, &current, &resolution, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan sync conflict: %w", err)
+		}
+		c.EntityType = domain.IdentityEntityType(entityType)
+		c.Resolution = domain.SyncResolution(resolution)
+		c.IncomingValue = incoming
+		c.CurrentValue = current
+		conflicts = append(conflicts, c)
+	}
+	return conflicts, rows.Err()
+}
+
+func scanSyncPolicy(row pgx.Row) (*domain.SyncPolicy, error) {
+	var p domain.SyncPolicy
+	var strategy string
+	var fieldPriorities []byte
+	if err := row.Scan(&p.ID, &p.WorkspaceID, &p.System, &strategy, &p.Priority, &fieldPriorities, &p.CreatedByID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSyncPolicyNotFound
+		}
+		return nil, err
+	}
+	p.Strategy = domain.SyncStrategy(strategy)
+	p.FieldPriorities = map[string]int{}
+	if len(fieldPriorities) > 0 {
+		if err := json.Unmarshal(fieldPriorities, &p.FieldPriorities); err != nil {
+			return nil, fmt.Errorf("decode field priorities: %w", err)
+		}
+	}
+	return &p, nil
+}
@@ -5,24 +5,24 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"time"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
-	ErrTaskNotFound = errors.New("task not found in workspace")
+	ErrTaskNotFound = apperr.NotFound("task not found in workspace", "task not found")
 )
 
 type TaskRepository struct {
-	pool *pgxpool.Pool
+	pool database.DB
 }
 
-func NewTaskRepository(pool *pgxpool.Pool) *TaskRepository {
+func NewTaskRepository(pool database.DB) *TaskRepository {
 	return &TaskRepository{pool: pool}
 }
 
@@ -36,17 +36,192 @@ func (r *TaskRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 // Multi-tenant isolation enforced by workspace_id filter.
 // Default ordering: position ASC (Kanban order within each status).
 func (r *TaskRepository) List(ctx context.Context, params domain.ListTasksParams) ([]domain.Task, string, error) {
+	query, args, err := buildTaskListQuery(params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.Task, 0, params.Limit)
+	for rows.Next() {
+		var t domain.Task
+		var deletedAt sql.NullTime
+		err := rows.Scan(
+			&t.ID, &t.WorkspaceID, &t.Title, &t.Description,
+			&t.Status, &t.Priority, &t.Type, &t.Position, &t.Visibility,
+			&t.ActorID, &t.AssignedTo, &t.ContactID,
+			&t.DueDate, &t.CompletedAt,
+			&t.CreatedAt, &t.UpdatedAt, &deletedAt,
+		)
+		if err != nil {
+			return nil, "", fmt.Errorf("scan task: %w", err)
+		}
+		if deletedAt.Valid {
+			t.DeletedAt = &deletedAt.Time
+		}
+		tasks = append(tasks, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("iterate tasks: %w", err)
+	}
+
+	var nextCursor string
+	if len(tasks) > params.Limit {
+		last := tasks[params.Limit-1]
+		nextCursor = encodePositionCursor(last.Position, last.ID)
+		tasks = tasks[:params.Limit]
+	}
+
+	return tasks, nextCursor, nil
+}
+
+// buildTaskListQuery monta o SELECT da listagem (filtros de appendTaskFilters + keyset em
+// position, id); os valores do request só entram como argumentos.
+func buildTaskListQuery(params domain.ListTasksParams) (string, []interface{}, error) {
 	query := `
 		SELECT id, workspace_id, title, description, status, priority, type, 
-		       position, owner_id, assigned_to, contact_id, 
+		       position, visibility, owner_id, assigned_to, contact_id, 
 		       due_date, completed_at, created_at, updated_at, deleted_at
 		FROM public."Task"
 		WHERE workspace_id = $1 AND deleted_at IS NULL
 	`
 	args := []interface{}{params.WorkspaceID}
-	argIdx := 2
+	query, args = appendTaskFilters(query, args, params)
+	argIdx := len(args) + 1
+
+	// Cursor-based pagination na mesma ordem do board (position ASC, id ASC)
+	if params.Cursor != nil && *params.Cursor != "" {
+		position, cursorID, err := decodePositionCursor(*params.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		query += fmt.Sprintf(" AND (position, id) > ($%d, $%d::TEXT)", argIdx, argIdx+1)
+		args = append(args, position, cursorID)
+		argIdx += 2
+	}
+
+	query += " ORDER BY position ASC, id ASC"
+	query += fmt.Sprintf(" LIMIT $%d", argIdx)
+	args = append(args, params.Limit+1) // +1 to check if there's next page
+
+	return query, args, nil
+}
+
+// taskSwimlaneColumns é a whitelist da expressão de lane do board.
+// Entra no SQL via Sprintf, então nunca use o valor vindo do request diretamente.
+var taskSwimlaneColumns = map[domain.TaskSwimlane]string{
+	domain.TaskSwimlaneNone:     "NULL::TEXT",
+	domain.TaskSwimlaneAssignee: "assigned_to",
+	domain.TaskSwimlanePriority: "priority::TEXT",
+}
+
+// BoardCounts conta as tarefas por lane e status (células do board), com os filtros de listagem.
+func (r *TaskRepository) BoardCounts(ctx context.Context, params domain.TaskBoardParams) ([]domain.TaskBoardCount, error) {
+	lane, ok := taskSwimlaneColumns[params.Swimlane]
+	if !ok {
+		return nil, fmt.Errorf("unsupported task swimlane: %q", params.Swimlane)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS lane, status, COUNT(*)
+		FROM public."Task"
+		WHERE workspace_id = $1 AND deleted_at IS NULL
+	`, lane)
+	args := []interface{}{params.WorkspaceID}
+	query, args = appendTaskFilters(query, args, params.ListTasksParams)
+	query += " GROUP BY 1, 2"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query task board counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []domain.TaskBoardCount{}
+	for rows.Next() {
+		var c domain.TaskBoardCount
+		if err := rows.Scan(&c.LaneKey, &c.Status, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan task board count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate task board counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// BoardTasks retorna até params.Limit tarefas por célula (lane × status), em ordem de position.
+func (r *TaskRepository) BoardTasks(ctx context.Context, params domain.TaskBoardParams) ([]domain.Task, error) {
+	lane, ok := taskSwimlaneColumns[params.Swimlane]
+	if !ok {
+		return nil, fmt.Errorf("unsupported task swimlane: %q", params.Swimlane)
+	}
+
+	inner := fmt.Sprintf(`
+		SELECT id, workspace_id, title, description, status, priority, type,
+		       position, visibility, owner_id, assigned_to, contact_id,
+		       due_date, completed_at, created_at, updated_at, deleted_at,
+		       ROW_NUMBER() OVER (PARTITION BY %s, status ORDER BY position ASC) AS rn
+		FROM public."Task"
+		WHERE workspace_id = $1 AND deleted_at IS NULL
+	`, lane)
+	args := []interface{}{params.WorkspaceID}
+	inner, args = appendTaskFilters(inner, args, params.ListTasksParams)
+
+	query := fmt.Sprintf(`
+		SELECT id, workspace_id, title, description, status, priority, type,
+		       position, visibility, owner_id, assigned_to, contact_id,
+		       due_date, completed_at, created_at, updated_at, deleted_at
+		FROM (%s) board
+		WHERE rn <= $%d
+		ORDER BY position ASC
+	`, inner, len(args)+1)
+	args = append(args, params.Limit)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query task board: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []domain.Task{}
+	for rows.Next() {
+		var t domain.Task
+		var deletedAt sql.NullTime
+		err := rows.Scan(
+			&t.ID, &t.WorkspaceID, &t.Title, &t.Description,
+			&t.Status, &t.Priority, &t.Type, &t.Position, &t.Visibility,
+			&t.ActorID, &t.AssignedTo, &t.ContactID,
+			&t.DueDate, &t.CompletedAt,
+			&t.CreatedAt, &t.UpdatedAt, &deletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan task: %w", err)
+		}
+		if deletedAt.Valid {
+			t.DeletedAt = &deletedAt.Time
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate task board: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// appendTaskFilters adiciona os filtros opcionais de listagem (compartilhados por List e Board).
+func appendTaskFilters(query string, args []interface{}, params domain.ListTasksParams) (string, []interface{}) {
+	argIdx := len(args) + 1
 
-	// Filtros opcionais
 	if params.Status != nil {
 		query += fmt.Sprintf(" AND status = $%d", argIdx)
 		args = append(args, *params.Status)
@@ -83,83 +258,61 @@ func (r *TaskRepository) List(ctx context.Context, params domain.ListTasksParams
 		argIdx++
 	}
 
-	if params.Query != nil && *params.Query != "" {
-		query += fmt.Sprintf(" AND to_tsvector('simple', title || ' ' || COALESCE(description, '')) @@ plainto_tsquery('simple', $%d)", argIdx)
-		args = append(args, *params.Query)
+	if params.FollowerID != nil {
+		query += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM public."Follower" f WHERE f."entityType" = 'TASK' AND f."entityId" = "Task".id AND f."userId" = $%d)`, argIdx)
+		args = append(args, *params.FollowerID)
 		argIdx++
 	}
 
-	// Cursor-based pagination (default: position ASC for Kanban)
-	if params.Cursor != nil && *params.Cursor != "" {
-		cursorTime, err := time.Parse(time.RFC3339, *params.Cursor)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
-		}
-		query += fmt.Sprintf(" AND created_at < $%d", argIdx)
-		args = append(args, cursorTime)
+	if params.Viewer != nil {
+		query += " AND " + taskVisibleTo(argIdx)
+		args = append(args, *params.Viewer)
 		argIdx++
 	}
 
-	// Ordenação (default: position ASC para Kanban)
-	query += " ORDER BY position ASC"
-	query += fmt.Sprintf(" LIMIT $%d", argIdx)
-	args = append(args, params.Limit+1) // +1 to check if there's next page
-
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, "", fmt.Errorf("query tasks: %w", err)
-	}
-	defer rows.Close()
-
-	tasks := make([]domain.Task, 0, params.Limit)
-	for rows.Next() {
-		var t domain.Task
-		var deletedAt sql.NullTime
-		err := rows.Scan(
-			&t.ID, &t.WorkspaceID, &t.Title, &t.Description,
-			&t.Status, &t.Priority, &t.Type, &t.Position,
-			&t.ActorID, &t.AssignedTo, &t.ContactID,
-			&t.DueDate, &t.CompletedAt,
-			&t.CreatedAt, &t.UpdatedAt, &deletedAt,
-		)
-		if err != nil {
-			return nil, "", fmt.Errorf("scan task: %w", err)
-		}
-		if deletedAt.Valid {
-			t.DeletedAt = &deletedAt.Time
-		}
-		tasks = append(tasks, t)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, "", fmt.Errorf("iterate tasks: %w", err)
+	if params.Query != nil && *params.Query != "" {
+		query += fmt.Sprintf(" AND to_tsvector('simple', title || ' ' || COALESCE(description, '')) @@ plainto_tsquery('simple', $%d)", argIdx)
+		args = append(args, *params.Query)
+		argIdx++
 	}
 
-	var nextCursor string
-	if len(tasks) > params.Limit {
-		nextCursor = tasks[params.Limit-1].CreatedAt.Format(time.RFC3339)
-		tasks = tasks[:params.Limit]
-	}
+	return query, args
+}
 
-	return tasks, nextCursor, nil
+// taskVisibleTo monta o predicado de visibilidade para o usuário no parâmetro $argIdx:
+// tarefas WORKSPACE, as que ele criou ou recebeu, e as TEAM que ele segue.
+func taskVisibleTo(argIdx int) string {
+	return fmt.Sprintf(`(visibility = 'WORKSPACE' OR owner_id = $%[1]d OR assigned_to = $%[1]d
+		OR (visibility = 'TEAM' AND EXISTS (SELECT 1 FROM public."Follower" f WHERE f."entityType" = 'TASK' AND f."entityId" = "Task".id AND f."userId" = $%[1]d)))`, argIdx)
 }
 
 // Get retrieves a single task by ID, scoped to workspace.
 // IDOR protection: returns not found if task exists but belongs to another workspace.
 func (r *TaskRepository) Get(ctx context.Context, workspaceID, taskID string) (*domain.Task, error) {
+	return r.GetVisible(ctx, workspaceID, taskID, nil)
+}
+
+// GetVisible é o Get respeitando a visibilidade: tarefa que o viewer não enxerga é
+// reportada como inexistente. viewer nil (admin) não filtra.
+func (r *TaskRepository) GetVisible(ctx context.Context, workspaceID, taskID string, viewer *string) (*domain.Task, error) {
 	query := `
 		SELECT id, workspace_id, title, description, status, priority, type, 
-		       position, owner_id, assigned_to, contact_id, 
+		       position, visibility, owner_id, assigned_to, contact_id, 
 		       due_date, completed_at, created_at, updated_at, deleted_at
 		FROM public."Task"
 		WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 	`
+	args := []interface{}{taskID, workspaceID}
+	if viewer != nil {
+		query += " AND " + taskVisibleTo(3)
+		args = append(args, *viewer)
+	}
 
 	var t domain.Task
 	var deletedAt sql.NullTime
-	err := r.pool.QueryRow(ctx, query, taskID, workspaceID).Scan(
+	err := r.pool.QueryRow(ctx, query, args...).Scan(
 		&t.ID, &t.WorkspaceID, &t.Title, &t.Description,
-		&t.Status, &t.Priority, &t.Type, &t.Position,
+		&t.Status, &t.Priority, &t.Type, &t.Position, &t.Visibility,
 		&t.ActorID, &t.AssignedTo, &t.ContactID,
 		&t.DueDate, &t.CompletedAt,
 		&t.CreatedAt, &t.UpdatedAt, &deletedAt,
@@ -185,7 +338,7 @@ func (r *TaskRepository) Get(ctx context.Context, workspaceID, taskID string) (*
 func (r *TaskRepository) GetForUpdate(ctx context.Context, tx pgx.Tx, workspaceID, taskID string) (*domain.Task, error) {
 	query := `
 		SELECT id, workspace_id, title, description, status, priority, type, 
-		       position, owner_id, assigned_to, contact_id, 
+		       position, visibility, owner_id, assigned_to, contact_id, 
 		       due_date, completed_at, created_at, updated_at, deleted_at
 		FROM public."Task"
 		WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
@@ -196,7 +349,7 @@ func (r *TaskRepository) GetForUpdate(ctx context.Context, tx pgx.Tx, workspaceI
 	var deletedAt sql.NullTime
 	err := tx.QueryRow(ctx, query, taskID, workspaceID).Scan(
 		&t.ID, &t.WorkspaceID, &t.Title, &t.Description,
-		&t.Status, &t.Priority, &t.Type, &t.Position,
+		&t.Status, &t.Priority, &t.Type, &t.Position, &t.Visibility,
 		&t.ActorID, &t.AssignedTo, &t.ContactID,
 		&t.DueDate, &t.CompletedAt,
 		&t.CreatedAt, &t.UpdatedAt, &deletedAt,
@@ -267,13 +420,13 @@ func (r *TaskRepository) GetPositionBounds(ctx context.Context, tx pgx.Tx, works
 func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 	query := `
 		INSERT INTO public."Task" (id, workspace_id, title, description, status, priority, type, 
-		                           position, owner_id, assigned_to, contact_id, due_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		                           position, visibility, owner_id, assigned_to, contact_id, due_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
 		task.ID, task.WorkspaceID, task.Title, task.Description,
-		task.Status, task.Priority, task.Type, task.Position,
+		task.Status, task.Priority, task.Type, task.Position, task.Visibility,
 		task.ActorID, task.AssignedTo, task.ContactID, task.DueDate,
 	)
 
@@ -321,6 +474,12 @@ func (r *TaskRepository) Update(ctx context.Context, workspaceID, taskID string,
 		argIdx++
 	}
 
+	if req.Visibility != nil {
+		query += fmt.Sprintf(", visibility = $%d", argIdx)
+		args = append(args, *req.Visibility)
+		argIdx++
+	}
+
 	if req.AssignedTo != nil {
 		query += fmt.Sprintf(", assigned_to = $%d", argIdx)
 		args = append(args, *req.AssignedTo)
@@ -383,14 +542,14 @@ func (r *TaskRepository) UpdatePosition(ctx context.Context, tx pgx.Tx, workspac
 }
 
 // SoftDelete marca uma tarefa como deletada (soft delete).
-func (r *TaskRepository) SoftDelete(ctx context.Context, workspaceID, taskID string) error {
+func (r *TaskRepository) SoftDelete(ctx context.Context, workspaceID, taskID, deletedByID string) error {
 	query := `
 		UPDATE public."Task"
-		SET deleted_at = NOW(), updated_at = NOW()
+		SET deleted_at = NOW(), deleted_by_id = $3, updated_at = NOW()
 		WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query, taskID, workspaceID)
+	result, err := r.pool.Exec(ctx, query, taskID, workspaceID, deletedByID)
 	if err != nil {
 		return fmt.Errorf("soft delete task: %w", err)
 	}
@@ -402,6 +561,72 @@ func (r *TaskRepository) SoftDelete(ctx context.Context, workspaceID, taskID str
 	return nil
 }
 
+// Restore desfaz o soft delete de uma tarefa (janela de undo).
+func (r *TaskRepository) Restore(ctx context.Context, workspaceID, taskID string) error {
+	return r.RestoreVisible(ctx, workspaceID, taskID, nil)
+}
+
+// RestoreVisible é o Restore respeitando a visibilidade: tarefa excluída que o viewer não
+// enxerga é reportada como inexistente. viewer nil (admin) não filtra.
+func (r *TaskRepository) RestoreVisible(ctx context.Context, workspaceID, taskID string, viewer *string) error {
+	query := `
+		UPDATE public."Task"
+		SET deleted_at = NULL, deleted_by_id = NULL, updated_at = NOW()
+		WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL`
+	args := []interface{}{taskID, workspaceID}
+	if viewer != nil {
+		query += " AND " + taskVisibleTo(3)
+		args = append(args, *viewer)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("restore task: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// StatusAggregates conta as tarefas de cada status, na ordem pedida (colunas vazias vêm
+// zeradas). viewer aplica o filtro de visibilidade, como na listagem.
+func (r *TaskRepository) StatusAggregates(ctx context.Context, workspaceID string, statuses []domain.TaskStatus, viewer *string) ([]domain.TaskStatusAggregate, error) {
+	cols := make([]string, len(statuses))
+	for i, st := range statuses {
+		cols[i] = string(st)
+	}
+
+	query := `
+		SELECT s.col, COUNT("Task".id)
+		FROM unnest($2::TEXT[]) WITH ORDINALITY AS s(col, ord)
+		LEFT JOIN public."Task" ON "Task".status::TEXT = s.col AND "Task".workspace_id = $1 AND "Task".deleted_at IS NULL`
+	args := []interface{}{workspaceID, cols}
+	if viewer != nil {
+		query += " AND " + taskVisibleTo(3)
+		args = append(args, *viewer)
+	}
+	query += " GROUP BY s.col, s.ord ORDER BY s.ord"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query task status aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	aggregates := []domain.TaskStatusAggregate{}
+	for rows.Next() {
+		var a domain.TaskStatusAggregate
+		if err := rows.Scan(&a.Status, &a.Count); err != nil {
+			return nil, fmt.Errorf("scan task status aggregate: %w", err)
+		}
+		aggregates = append(aggregates, a)
+	}
+	return aggregates, rows.Err()
+}
+
 // GetMaxPosition retorna a maior position em um status específico.
 // Usado para adicionar novas tarefas ao final da coluna.
 func (r *TaskRepository) GetMaxPosition(ctx context.Context, workspaceID string, status domain.TaskStatus) (float64, error) {
@@ -0,0 +1,94 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestTaskRepository_Board_Integration
+func TestTaskRepository_Board_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	tasks := repo.NewTaskRepository(pool)
+
+	seller := f.Member(domain.RoleUser)
+	todo := func(priority domain.Priority, assignee *string) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.Status = domain.TaskStatusTodo
+			task.Priority = priority
+			task.AssignedTo = assignee
+		}
+	}
+	first := f.Task(todo(domain.PriorityHigh, &seller))
+	second := f.Task(todo(domain.PriorityLow, &seller))
+	third := f.Task(todo(domain.PriorityHigh, nil))
+	doing := f.Task(func(task *domain.Task) { task.Status = domain.TaskStatusInProgress; task.AssignedTo = &seller })
+	deleted := f.Task(todo(domain.PriorityHigh, &seller))
+	require.NoError(t, tasks.SoftDelete(ctx, f.WorkspaceID, deleted.ID, f.UserID))
+
+	board := func(swimlane domain.TaskSwimlane, limit int) domain.TaskBoardParams {
+		return domain.TaskBoardParams{
+			ListTasksParams: domain.ListTasksParams{WorkspaceID: f.WorkspaceID, Limit: limit},
+			Swimlane:        swimlane,
+		}
+	}
+
+	t.Run("limit applies per cell in position order", func(t *testing.T) {
+		got, err := tasks.BoardTasks(ctx, board(domain.TaskSwimlaneNone, 2))
+		require.NoError(t, err)
+		var ids []string
+		for _, task := range got {
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, []string{first.ID, second.ID, doing.ID}, ids)
+
+		counts, err := tasks.BoardCounts(ctx, board(domain.TaskSwimlaneNone, 2))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.TaskBoardCount{
+			{Status: domain.TaskStatusTodo, Count: 3},
+			{Status: domain.TaskStatusInProgress, Count: 1},
+		}, counts)
+	})
+
+	t.Run("assignee lanes", func(t *testing.T) {
+		got, err := tasks.BoardTasks(ctx, board(domain.TaskSwimlaneAssignee, 1))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{first.ID, third.ID, doing.ID}, taskIDs(got))
+
+		counts, err := tasks.BoardCounts(ctx, board(domain.TaskSwimlaneAssignee, 1))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.TaskBoardCount{
+			{LaneKey: &seller, Status: domain.TaskStatusTodo, Count: 2},
+			{LaneKey: nil, Status: domain.TaskStatusTodo, Count: 1},
+			{LaneKey: &seller, Status: domain.TaskStatusInProgress, Count: 1},
+		}, counts)
+	})
+
+	t.Run("priority lanes with filters", func(t *testing.T) {
+		params := board(domain.TaskSwimlanePriority, 20)
+		params.AssignedTo = &seller
+		counts, err := tasks.BoardCounts(ctx, params)
+		require.NoError(t, err)
+
+		high, low, medium := string(domain.PriorityHigh), string(domain.PriorityLow), string(domain.PriorityMedium)
+		assert.ElementsMatch(t, []domain.TaskBoardCount{
+			{LaneKey: &high, Status: domain.TaskStatusTodo, Count: 1},
+			{LaneKey: &low, Status: domain.TaskStatusTodo, Count: 1},
+			{LaneKey: &medium, Status: domain.TaskStatusInProgress, Count: 1},
+		}, counts)
+	})
+
+	t.Run("unknown swimlane is rejected", func(t *testing.T) {
+		_, err := tasks.BoardCounts(ctx, board("status", 20))
+		assert.Error(t, err)
+		_, err = tasks.BoardTasks(ctx, board("status", 20))
+		assert.Error(t, err)
+	})
+}
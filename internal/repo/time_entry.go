@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrTimeEntryNotFound   = apperr.NotFound("time entry not found in workspace", "time entry not found")
+	ErrTimerAlreadyRunning = apperr.Conflict("user already has a running time entry in workspace", "a timer is already running, stop it before starting another")
+)
+
+// TimeEntryRepository persiste apontamentos de horas.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type TimeEntryRepository struct {
+	pool database.DB
+}
+
+func NewTimeEntryRepository(pool database.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{pool: pool}
+}
+
+const timeEntryColumns = `id, "workspaceId", "userId", "taskId", "dealId", description, "startedAt",
+	"endedAt", "durationSeconds", billable, "createdAt", "updatedAt", "deletedAt"`
+
+// Create insere um apontamento. Falha com ErrTimerAlreadyRunning ao iniciar um segundo
+// timer para o mesmo usuário no workspace.
+func (r *TimeEntryRepository) Create(ctx context.Context, e *domain.TimeEntry) (*domain.TimeEntry, error) {
+	query := `
+		INSERT INTO public."TimeEntry" (id, "workspaceId", "userId", "taskId", "dealId", description, "startedAt", "endedAt", "durationSeconds", billable)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + timeEntryColumns
+
+	created, err := scanTimeEntry(r.pool.QueryRow(ctx, query,
+		e.ID, e.WorkspaceID, e.UserID, e.TaskID, e.DealID, e.Description, e.StartedAt, e.EndedAt, e.DurationSeconds, e.Billable,
+	))
+	if err != nil {
+		if isRunningTimerViolation(err) {
+			return nil, ErrTimerAlreadyRunning
+		}
+		return nil, fmt.Errorf("insert time entry: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um apontamento ativo (não deletado) do workspace.
+func (r *TimeEntryRepository) Get(ctx context.Context, workspaceID, entryID string) (*domain.TimeEntry, error) {
+	query := `
+		SELECT ` + timeEntryColumns + `
+		FROM public."TimeEntry"
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`
+
+	e, err := scanTimeEntry(r.pool.QueryRow(ctx, query, entryID, workspaceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTimeEntryNotFound
+		}
+		return nil, fmt.Errorf("query time entry: %w", err)
+	}
+	return e, nil
+}
+
+// List retorna os apontamentos do workspace, mais recentes primeiro.
+func (r *TimeEntryRepository) List(ctx context.Context, params domain.ListTimeEntriesParams) ([]domain.TimeEntry, error) {
+	query := `
+		SELECT ` + timeEntryColumns + `
+		FROM public."TimeEntry"
+		WHERE "workspaceId" = $1 AND "deletedAt" IS NULL
+		  AND ($2::TEXT IS NULL OR "userId" = $2)
+		  AND ($3::TEXT IS NULL OR "taskId" = $3)
+		  AND ($4::TEXT IS NULL OR "dealId" = $4)
+		  AND ($5::TIMESTAMP IS NULL OR "startedAt" >= $5)
+		  AND ($6::TIMESTAMP IS NULL OR "startedAt" < $6)
+		  AND ($7::BOOLEAN IS NULL OR ("endedAt" IS NULL) = $7)
+		ORDER BY "startedAt" DESC, id
+		LIMIT $8`
+
+	rows, err := r.pool.Query(ctx, query,
+		params.WorkspaceID, params.UserID, params.TaskID, params.DealID, params.From, params.To, params.Running, params.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query time entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.TimeEntry{}
+	for rows.Next() {
+		e, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan time entry: %w", err)
+		}
+		entries = append(entries, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate time entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Update grava os campos editáveis de um apontamento já mesclado pelo service.
+func (r *TimeEntryRepository) Update(ctx context.Context, e *domain.TimeEntry) (*domain.TimeEntry, error) {
+	query := `
+		UPDATE public."TimeEntry" SET
+			description = $3,
+			"startedAt" = $4,
+			"endedAt" = $5,
+			"durationSeconds" = $6,
+			billable = $7,
+			"updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL
+		RETURNING ` + timeEntryColumns
+
+	updated, err := scanTimeEntry(r.pool.QueryRow(ctx, query,
+		e.ID, e.WorkspaceID, e.Description, e.StartedAt, e.EndedAt, e.DurationSeconds, e.Billable,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTimeEntryNotFound
+		}
+		return nil, fmt.Errorf("update time entry: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete faz soft delete do apontamento.
+func (r *TimeEntryRepository) Delete(ctx context.Context, workspaceID, entryID string) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public."TimeEntry"
+		SET "deletedAt" = NOW(), "updatedAt" = NOW()
+		WHERE id = $1 AND "workspaceId" = $2 AND "deletedAt" IS NULL`,
+		entryID, workspaceID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete time entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTimeEntryNotFound
+	}
+	return nil
+}
+
+func isRunningTimerViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_running_time_entry_per_user"
+}
+
+// Scanners
+func scanTimeEntry(row pgx.Row) (*domain.TimeEntry, error) {
+	var e domain.TimeEntry
+	var duration *int32
+	err := row.Scan(
+		&e.ID, &e.WorkspaceID, &e.UserID, &e.TaskID, &e.DealID, &e.Description, &e.StartedAt,
+		&e.EndedAt, &duration, &e.Billable, &e.CreatedAt, &e.UpdatedAt, &e.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if duration != nil {
+		d := int(*duration)
+		e.DurationSeconds = &d
+	}
+	e.Running = e.EndedAt == nil
+	return &e, nil
+}
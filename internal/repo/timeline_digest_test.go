@@ -0,0 +1,95 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestActivityRepository_Digest_Integration
+func TestActivityRepository_Digest_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	activities := repo.NewActivityRepository(pool)
+
+	contact := f.Contact()
+	other := f.Contact()
+	record := func(contactID string, activityType domain.ActivityType) *domain.Activity {
+		t.Helper()
+		activityID, err := id.New(id.Activity)
+		require.NoError(t, err)
+		created, err := activities.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: f.WorkspaceID,
+			ContactID:   &contactID,
+			Type:        activityType,
+			UserID:      f.UserID,
+			Metadata:    []byte(`{}`),
+		})
+		require.NoError(t, err)
+		return created
+	}
+
+	var emails []*domain.Activity
+	for range 3 {
+		emails = append(emails, record(contact.ID, domain.ActivityTypeEmail))
+	}
+	call := record(contact.ID, domain.ActivityTypeCall)
+	record(other.ID, domain.ActivityTypeMeeting)
+
+	t.Run("counts every activity and limits items per type", func(t *testing.T) {
+		digest, err := activities.Digest(ctx, domain.TimelineDigestParams{WorkspaceID: f.WorkspaceID, ContactID: &contact.ID, PerType: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), digest.Total)
+
+		groups := map[domain.ActivityType]domain.TimelineDigestGroup{}
+		for _, g := range digest.Groups {
+			groups[g.Type] = g
+		}
+		require.Len(t, groups, 2)
+		assert.Equal(t, int64(3), groups[domain.ActivityTypeEmail].Count)
+		require.Len(t, groups[domain.ActivityTypeEmail].Items, 2)
+		// Mais recentes primeiro
+		assert.Equal(t, emails[2].ID, groups[domain.ActivityTypeEmail].Items[0].ID)
+		assert.Equal(t, emails[1].ID, groups[domain.ActivityTypeEmail].Items[1].ID)
+		assert.Equal(t, call.ID, groups[domain.ActivityTypeCall].Items[0].ID)
+		assert.NotNil(t, digest.NextCursor)
+	})
+
+	t.Run("cursor returns only newer activities", func(t *testing.T) {
+		digest, err := activities.Digest(ctx, domain.TimelineDigestParams{WorkspaceID: f.WorkspaceID, ContactID: &contact.ID, PerType: 3})
+		require.NoError(t, err)
+		require.NotNil(t, digest.NextCursor)
+		cursor, err := time.Parse(time.RFC3339Nano, *digest.NextCursor)
+		require.NoError(t, err)
+
+		empty, err := activities.Digest(ctx, domain.TimelineDigestParams{WorkspaceID: f.WorkspaceID, ContactID: &contact.ID, Since: &cursor, PerType: 3})
+		require.NoError(t, err)
+		assert.Zero(t, empty.Total)
+		assert.Equal(t, digest.NextCursor, empty.NextCursor, "cursor unchanged without new activity")
+
+		// Garante createdAt estritamente posterior ao cursor (precisão de milissegundos)
+		time.Sleep(5 * time.Millisecond)
+		newer := record(contact.ID, domain.ActivityTypeCall)
+
+		refreshed, err := activities.Digest(ctx, domain.TimelineDigestParams{WorkspaceID: f.WorkspaceID, ContactID: &contact.ID, Since: &cursor, PerType: 3})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), refreshed.Total)
+		require.Len(t, refreshed.Groups, 1)
+		assert.Equal(t, newer.ID, refreshed.Groups[0].Items[0].ID)
+	})
+
+	t.Run("workspace-wide digest includes every entity", func(t *testing.T) {
+		digest, err := activities.Digest(ctx, domain.TimelineDigestParams{WorkspaceID: f.WorkspaceID, PerType: 3})
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), digest.Total)
+	})
+}
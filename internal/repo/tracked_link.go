@@ -0,0 +1,242 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrTrackedLinkNotFound = apperr.NotFound("tracked link not found in workspace", "link not found")
+
+	// ErrTrackedLinkCodeTaken colisão do code gerado; o service tenta outro
+	ErrTrackedLinkCodeTaken = errors.New("tracked link code already exists")
+)
+
+// TrackedLinkRepository persiste links rastreados e seus cliques.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type TrackedLinkRepository struct {
+	pool database.DB
+}
+
+func NewTrackedLinkRepository(pool database.DB) *TrackedLinkRepository {
+	return &TrackedLinkRepository{pool: pool}
+}
+
+const trackedLinkColumns = `id, "workspaceId", code, name, "destinationUrl", "utmSource", "utmMedium", "utmCampaign",
+	"utmTerm", "utmContent", "contactId", "dealId", "tokenSecret", "clickCount", "lastClickedAt",
+	"createdById", "createdAt", "updatedAt"`
+
+// Create insere o link. Retorna ErrTrackedLinkCodeTaken se o code já existir.
+func (r *TrackedLinkRepository) Create(ctx context.Context, l *domain.TrackedLink) (*domain.TrackedLink, error) {
+	query := `
+		INSERT INTO public."TrackedLink" (
+			id, "workspaceId", code, name, "destinationUrl", "utmSource", "utmMedium", "utmCampaign",
+			"utmTerm", "utmContent", "contactId", "dealId", "tokenSecret", "createdById"
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING ` + trackedLinkColumns
+
+	created, err := scanTrackedLink(r.pool.QueryRow(ctx, query,
+		l.ID, l.WorkspaceID, l.Code, l.Name, l.DestinationURL, l.UTMSource, l.UTMMedium, l.UTMCampaign,
+		l.UTMTerm, l.UTMContent, l.ContactID, l.DealID, l.TokenSecret, l.CreatedByID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "TrackedLink_code_key" {
+			return nil, ErrTrackedLinkCodeTaken
+		}
+		return nil, fmt.Errorf("insert tracked link: %w", err)
+	}
+	return created, nil
+}
+
+// Get retorna um link do workspace.
+func (r *TrackedLinkRepository) Get(ctx context.Context, workspaceID, linkID string) (*domain.TrackedLink, error) {
+	query := `
+		SELECT ` + trackedLinkColumns + `
+		FROM public."TrackedLink"
+		WHERE "workspaceId" = $1 AND id = $2`
+
+	l, err := scanTrackedLink(r.pool.QueryRow(ctx, query, workspaceID, linkID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTrackedLinkNotFound
+		}
+		return nil, fmt.Errorf("query tracked link: %w", err)
+	}
+	return l, nil
+}
+
+// GetByCode retorna o link pelo code, sem escopo de workspace (redirecionamento público).
+func (r *TrackedLinkRepository) GetByCode(ctx context.Context, code string) (*domain.TrackedLink, error) {
+	query := `
+		SELECT ` + trackedLinkColumns + `
+		FROM public."TrackedLink"
+		WHERE code = $1`
+
+	l, err := scanTrackedLink(r.pool.QueryRow(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTrackedLinkNotFound
+		}
+		return nil, fmt.Errorf("query tracked link: %w", err)
+	}
+	return l, nil
+}
+
+// List retorna os links do workspace, mais recentes primeiro; contactID/dealID filtram (nil = todos).
+func (r *TrackedLinkRepository) List(ctx context.Context, workspaceID string, contactID, dealID *string) ([]domain.TrackedLink, error) {
+	query := `
+		SELECT ` + trackedLinkColumns + `
+		FROM public."TrackedLink"
+		WHERE "workspaceId" = $1
+		  AND ($2::TEXT IS NULL OR "contactId" = $2)
+		  AND ($3::TEXT IS NULL OR "dealId" = $3)
+		ORDER BY "createdAt" DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, workspaceID, contactID, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("query tracked links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []domain.TrackedLink{}
+	for rows.Next() {
+		l, err := scanTrackedLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan tracked link: %w", err)
+		}
+		links = append(links, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tracked links: %w", err)
+	}
+
+	return links, nil
+}
+
+// Update grava o link já mesclado pelo service (code e tokenSecret não mudam).
+func (r *TrackedLinkRepository) Update(ctx context.Context, l *domain.TrackedLink) (*domain.TrackedLink, error) {
+	query := `
+		UPDATE public."TrackedLink" SET
+			name = $3,
+			"destinationUrl" = $4,
+			"utmSource" = $5,
+			"utmMedium" = $6,
+			"utmCampaign" = $7,
+			"utmTerm" = $8,
+			"utmContent" = $9,
+			"contactId" = $10,
+			"dealId" = $11,
+			"updatedAt" = NOW()
+		WHERE "workspaceId" = $1 AND id = $2
+		RETURNING ` + trackedLinkColumns
+
+	updated, err := scanTrackedLink(r.pool.QueryRow(ctx, query,
+		l.WorkspaceID, l.ID, l.Name, l.DestinationURL, l.UTMSource, l.UTMMedium, l.UTMCampaign,
+		l.UTMTerm, l.UTMContent, l.ContactID, l.DealID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTrackedLinkNotFound
+		}
+		return nil, fmt.Errorf("update tracked link: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete remove o link e seus cliques; a URL curta passa a responder 404.
+func (r *TrackedLinkRepository) Delete(ctx context.Context, workspaceID, linkID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."TrackedLink" WHERE "workspaceId" = $1 AND id = $2`, workspaceID, linkID)
+	if err != nil {
+		return fmt.Errorf("delete tracked link: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrTrackedLinkNotFound
+	}
+	return nil
+}
+
+// RecordClick grava o clique e atualiza os contadores do link na mesma transação.
+func (r *TrackedLinkRepository) RecordClick(ctx context.Context, click *domain.TrackedLinkClick) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO public."TrackedLinkClick" (id, "workspaceId", "linkId", "contactId", referrer, "userAgent")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING "clickedAt"`,
+		click.ID, click.WorkspaceID, click.LinkID, click.ContactID, click.Referrer, click.UserAgent,
+	).Scan(&click.ClickedAt)
+	if err != nil {
+		return fmt.Errorf("insert tracked link click: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE public."TrackedLink"
+		SET "clickCount" = "clickCount" + 1, "lastClickedAt" = $2
+		WHERE id = $1`, click.LinkID, click.ClickedAt)
+	if err != nil {
+		return fmt.Errorf("update tracked link counters: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListClicks retorna os cliques do link, mais recentes primeiro. Busca Limit+1 linhas
+// para que o service saiba se há próxima página.
+func (r *TrackedLinkRepository) ListClicks(ctx context.Context, params domain.ListTrackedLinkClicksParams) ([]domain.TrackedLinkClick, error) {
+	query := `
+		SELECT id, "workspaceId", "linkId", "contactId", referrer, "userAgent", "clickedAt"
+		FROM public."TrackedLinkClick"
+		WHERE "workspaceId" = $1 AND "linkId" = $2
+		  AND ($3::TEXT IS NULL OR "contactId" = $3)
+		  AND ($4::TIMESTAMP IS NULL OR "clickedAt" < $4)
+		ORDER BY "clickedAt" DESC, id DESC
+		LIMIT $5`
+
+	rows, err := r.pool.Query(ctx, query, params.WorkspaceID, params.LinkID, params.ContactID, params.Cursor, params.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("query tracked link clicks: %w", err)
+	}
+	defer rows.Close()
+
+	clicks := []domain.TrackedLinkClick{}
+	for rows.Next() {
+		var c domain.TrackedLinkClick
+		if err := rows.Scan(&c.ID, &c.WorkspaceID, &c.LinkID, &c.ContactID, &c.Referrer, &c.UserAgent, &c.ClickedAt); err != nil {
+			return nil, fmt.Errorf("scan tracked link click: %w", err)
+		}
+		clicks = append(clicks, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tracked link clicks: %w", err)
+	}
+
+	return clicks, nil
+}
+
+// Scanners
+func scanTrackedLink(row pgx.Row) (*domain.TrackedLink, error) {
+	var l domain.TrackedLink
+	err := row.Scan(
+		&l.ID, &l.WorkspaceID, &l.Code, &l.Name, &l.DestinationURL, &l.UTMSource, &l.UTMMedium, &l.UTMCampaign,
+		&l.UTMTerm, &l.UTMContent, &l.ContactID, &l.DealID, &l.TokenSecret, &l.ClickCount, &l.LastClickedAt,
+		&l.CreatedByID, &l.CreatedAt, &l.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+)
+
+// TrashRepository lista os registros excluídos (soft delete) de todas as entidades do
+// workspace e remove definitivamente os que passaram da retenção.
+// IMPORTANT: Task usa colunas snake_case; as demais tabelas, camelCase com aspas.
+type TrashRepository struct {
+	pool database.DB
+}
+
+func NewTrashRepository(pool database.DB) *TrashRepository {
+	return &TrashRepository{pool: pool}
+}
+
+// trashSQL une os registros excluídos de cada entidade no mesmo formato.
+// $1 = workspace, $2 = início da janela de retenção, $6 = viewer das tarefas (NULL = todas).
+var trashSQL = `
+	SELECT 'contact' AS "entityType", id, "fullName" AS name, "deletedAt", "deletedById"
+	FROM public."Contact" WHERE "workspaceId" = $1 AND "deletedAt" >= $2
+	UNION ALL
+	SELECT 'company', id, name, "deletedAt", "deletedById"
+	FROM public."Company" WHERE "workspaceId" = $1 AND "deletedAt" >= $2
+	UNION ALL
+	SELECT 'deal', id, name, "deletedAt", "deletedById"
+	FROM public."Deal" WHERE "workspaceId" = $1 AND "deletedAt" >= $2
+	UNION ALL
+	SELECT 'task', id, title, deleted_at, deleted_by_id
+	FROM public."Task" WHERE workspace_id = $1 AND deleted_at >= $2
+	  AND ($6::TEXT IS NULL OR ` + taskVisibleTo(6) + `)
+	UNION ALL
+	SELECT 'pipeline', id, name, "deletedAt", "deletedById"
+	FROM public."Pipeline" WHERE "workspaceId" = $1 AND "deletedAt" >= $2`
+
+// List retorna os registros excluídos dentro da janela de retenção, mais recentes primeiro.
+// Tarefas passam pelo filtro de visibilidade de params.Viewer, como em TaskRepository.List.
+// Busca Limit+1 linhas para que o chamador detecte a próxima página. PurgeAt fica a cargo do service.
+func (r *TrashRepository) List(ctx context.Context, params domain.ListTrashParams) ([]domain.TrashItem, error) {
+	var entityType *string
+	if params.EntityType != nil {
+		t := string(*params.EntityType)
+		entityType = &t
+	}
+
+	query := `
+		SELECT "entityType", id, name, "deletedAt", "deletedById"
+		FROM (` + trashSQL + `) trash
+		WHERE ($3::TEXT IS NULL OR "entityType" = $3)
+		  AND ($4::TIMESTAMP IS NULL OR "deletedAt" < $4)
+		ORDER BY "deletedAt" DESC, id DESC
+		LIMIT $5`
+
+	rows, err := r.pool.Query(ctx, query,
+		params.WorkspaceID, params.DeletedAfter, entityType, params.Cursor, params.Limit+1, params.Viewer,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query trash: %w", err)
+	}
+	defer rows.Close()
+
+	items := []domain.TrashItem{}
+	for rows.Next() {
+		var item domain.TrashItem
+		if err := rows.Scan(&item.EntityType, &item.EntityID, &item.Name, &item.DeletedAt, &item.DeletedByID); err != nil {
+			return nil, fmt.Errorf("scan trash item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Purge remove definitivamente os registros excluídos antes de "before", em todos os workspaces.
+// Pipelines que ainda têm negócios (mesmo excluídos e dentro da retenção) são mantidos até
+// que eles sejam purgados, para que o CASCADE da FK não apague negócios recuperáveis.
+func (r *TrashRepository) Purge(ctx context.Context, before time.Time) (domain.TrashPurgeResult, error) {
+	// Ordem importa: negócios antes de pipelines
+	steps := []struct {
+		entityType domain.TrashEntityType
+		query      string
+	}{
+		{domain.TrashEntityTask, `DELETE FROM public."Task" WHERE deleted_at < $1`},
+		{domain.TrashEntityDeal, `DELETE FROM public."Deal" WHERE "deletedAt" < $1`},
+		{domain.TrashEntityContact, `DELETE FROM public."Contact" WHERE "deletedAt" < $1`},
+		{domain.TrashEntityCompany, `DELETE FROM public."Company" WHERE "deletedAt" < $1`},
+		{domain.TrashEntityPipeline, `
+			DELETE FROM public."Pipeline" p
+			WHERE p."deletedAt" < $1
+			  AND NOT EXISTS (SELECT 1 FROM public."Deal" d WHERE d."pipelineId" = p.id)`},
+	}
+
+	purged := domain.TrashPurgeResult{}
+	for _, step := range steps {
+		result, err := r.pool.Exec(ctx, step.query, before)
+		if err != nil {
+			return purged, fmt.Errorf("purge %s: %w", step.entityType, err)
+		}
+		purged[step.entityType] = result.RowsAffected()
+	}
+
+	// Desassociações dos registros purgados: têm o deletedAt do pai e não serão mais restauradas
+	if _, err := r.pool.Exec(ctx, `DELETE FROM public."SoftDeleteDetachment" WHERE "detachedAt" < $1`, before); err != nil {
+		return purged, fmt.Errorf("purge detachments: %w", err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrUndoTokenInvalid = apperr.Unprocessable(apperr.CodeValidationError, "undo token is unknown, expired, already used or issued to another actor", "undo token is invalid or expired")
+
+// UndoRepository persiste os tokens de desfazer emitidos nos DELETEs.
+// IMPORTANT: Uses camelCase column names with double quotes.
+type UndoRepository struct {
+	pool database.DB
+}
+
+func NewUndoRepository(pool database.DB) *UndoRepository {
+	return &UndoRepository{pool: pool}
+}
+
+// Create insere um token (apenas o hash).
+func (r *UndoRepository) Create(ctx context.Context, t *domain.UndoToken) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO public."UndoToken" ("tokenHash", "workspaceId", "entityType", "entityId", "actorId", "expiresAt")
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		t.TokenHash, t.WorkspaceID, t.EntityType, t.EntityID, t.ActorID, t.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert undo token: %w", err)
+	}
+	return nil
+}
+
+// Consume marca o token como usado de forma atômica e o retorna.
+// Só quem fez o DELETE pode usar o token; tokens de outro workspace ou de outro ator,
+// expirados ou já usados retornam ErrUndoTokenInvalid.
+func (r *UndoRepository) Consume(ctx context.Context, workspaceID, actorID, tokenHash string, now time.Time) (*domain.UndoToken, error) {
+	query := `
+		UPDATE public."UndoToken"
+		SET "usedAt" = $4
+		WHERE "tokenHash" = $1 AND "workspaceId" = $2 AND "actorId" = $3 AND "usedAt" IS NULL AND "expiresAt" > $4
+		RETURNING "tokenHash", "workspaceId", "entityType", "entityId", "actorId", "expiresAt"`
+
+	var t domain.UndoToken
+	err := r.pool.QueryRow(ctx, query, tokenHash, workspaceID, actorID, now).Scan(
+		&t.TokenHash, &t.WorkspaceID, &t.EntityType, &t.EntityID, &t.ActorID, &t.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUndoTokenInvalid
+		}
+		return nil, fmt.Errorf("consume undo token: %w", err)
+	}
+	return &t, nil
+}
+
+// Release devolve um token consumido (restauração falhou) para que possa ser reutilizado
+// dentro da janela.
+func (r *UndoRepository) Release(ctx context.Context, tokenHash string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE public."UndoToken" SET "usedAt" = NULL WHERE "tokenHash" = $1`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("release undo token: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired remove tokens expirados antes de before. Usado pelo comando cleanup.
+func (r *UndoRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM public."UndoToken" WHERE "expiresAt" < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired undo tokens: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,74 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestUndoRepository_Consume_Integration
+func TestUndoRepository_Consume_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+	undo := repo.NewUndoRepository(pool)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	issue := func(actorID string) string {
+		hash := f.WorkspaceID + "-" + actorID
+		require.NoError(t, undo.Create(ctx, &domain.UndoToken{
+			TokenHash:   hash,
+			WorkspaceID: f.WorkspaceID,
+			EntityType:  domain.UndoEntityContact,
+			EntityID:    "ctc_undo",
+			ActorID:     actorID,
+			ExpiresAt:   now.Add(5 * time.Minute),
+		}))
+		return hash
+	}
+
+	t.Run("consumes within the window exactly once", func(t *testing.T) {
+		hash := issue(f.UserID)
+
+		token, err := undo.Consume(ctx, f.WorkspaceID, f.UserID, hash, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, domain.UndoEntityContact, token.EntityType)
+		assert.Equal(t, "ctc_undo", token.EntityID)
+
+		_, err = undo.Consume(ctx, f.WorkspaceID, f.UserID, hash, now.Add(time.Minute))
+		assert.ErrorIs(t, err, repo.ErrUndoTokenInvalid, "tokens are single use")
+
+		require.NoError(t, undo.Release(ctx, hash))
+		_, err = undo.Consume(ctx, f.WorkspaceID, f.UserID, hash, now.Add(time.Minute))
+		assert.NoError(t, err, "a released token is valid again")
+	})
+
+	t.Run("rejects after expiry", func(t *testing.T) {
+		manager := f.Member(domain.RoleManager)
+		hash := issue(manager)
+
+		_, err := undo.Consume(ctx, f.WorkspaceID, manager, hash, now.Add(5*time.Minute))
+		assert.ErrorIs(t, err, repo.ErrUndoTokenInvalid, "the window is exclusive")
+	})
+
+	t.Run("rejects another actor or workspace", func(t *testing.T) {
+		manager := f.Member(domain.RoleManager)
+		hash := issue(manager)
+
+		_, err := undo.Consume(ctx, f.WorkspaceID, f.UserID, hash, now)
+		assert.ErrorIs(t, err, repo.ErrUndoTokenInvalid, "only the actor who deleted can undo")
+
+		_, err = undo.Consume(ctx, other.WorkspaceID, manager, hash, now)
+		assert.ErrorIs(t, err, repo.ErrUndoTokenInvalid)
+
+		_, err = undo.Consume(ctx, f.WorkspaceID, manager, hash, now)
+		assert.NoError(t, err, "failed attempts do not burn the token")
+	})
+}
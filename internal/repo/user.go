@@ -0,0 +1,37 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/database"
+)
+
+// UserRepository consultas sobre usuários (tabela "User", banco de controle).
+// IMPORTANT: Uses camelCase column names with double quotes.
+type UserRepository struct {
+	pool database.DB
+}
+
+func NewUserRepository(pool database.DB) *UserRepository {
+	return &UserRepository{pool: pool}
+}
+
+// ExistsInWorkspace verifica se o usuário existe, não foi excluído e é membro do workspace.
+// Usado para validar referências de dono/responsável (ownerId, actorId, assignedTo) antes de gravar.
+func (r *UserRepository) ExistsInWorkspace(ctx context.Context, workspaceID, userID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1
+			FROM "WorkspaceMember" m
+			JOIN "User" u ON u.id = m."userId"
+			WHERE m."workspaceId" = $1 AND m."userId" = $2 AND u."deletedAt" IS NULL
+		)`,
+		workspaceID, userID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check user in workspace: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,43 @@
+package repo_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestUserRepository_ExistsInWorkspace_Integration
+func TestUserRepository_ExistsInWorkspace_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+	users := repo.NewUserRepository(pool)
+
+	member := f.Member(domain.RoleViewer)
+
+	exists, err := users.ExistsInWorkspace(ctx, f.WorkspaceID, member)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Membro de outro workspace
+	exists, err = users.ExistsInWorkspace(ctx, f.WorkspaceID, other.UserID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = users.ExistsInWorkspace(ctx, f.WorkspaceID, "usr_missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Usuário excluído continua em WorkspaceMember, mas não pode ser dono/responsável
+	_, err = pool.Exec(ctx, `UPDATE public."User" SET "deletedAt" = NOW() WHERE id = $1`, member)
+	require.NoError(t, err)
+	exists, err = users.ExistsInWorkspace(ctx, f.WorkspaceID, member)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,349 @@
+package repo_test
+
+import (
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestNoteVisibility_Integration
+func TestNoteVisibility_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	activities := repo.NewActivityRepository(pool)
+	followers := repo.NewFollowerRepository(pool)
+
+	author := f.Member(domain.RoleUser)
+	outsider := f.Member(domain.RoleUser)
+	follower := f.Member(domain.RoleUser)
+	contact := f.Contact()
+
+	followID, err := id.New(id.Follower)
+	require.NoError(t, err)
+	_, err = followers.Follow(ctx, &domain.Follower{
+		ID:          followID,
+		WorkspaceID: f.WorkspaceID,
+		EntityType:  domain.FollowEntityContact,
+		EntityID:    contact.ID,
+		UserID:      follower,
+		Reason:      domain.FollowReasonManual,
+	})
+	require.NoError(t, err)
+
+	// Uma nota de cada visibilidade, do mesmo autor, com a atividade da timeline
+	notes := map[domain.Visibility]string{}
+	for _, visibility := range []domain.Visibility{domain.VisibilityPrivate, domain.VisibilityTeam, domain.VisibilityWorkspace} {
+		noteID, err := id.New(id.Note)
+		require.NoError(t, err)
+		_, err = activities.CreateNote(ctx, &domain.Note{
+			ID:          noteID,
+			WorkspaceID: f.WorkspaceID,
+			ContactID:   &contact.ID,
+			Content:     string(visibility),
+			UserID:      author,
+			Body:        domain.NewPlainTextDoc(string(visibility)),
+			Visibility:  visibility,
+		})
+		require.NoError(t, err)
+
+		activityID, err := id.New(id.Activity)
+		require.NoError(t, err)
+		_, err = activities.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: f.WorkspaceID,
+			ContactID:   &contact.ID,
+			Type:        domain.ActivityTypeNote,
+			ActivityID:  &noteID,
+			UserID:      author,
+			Metadata:    []byte(`{}`),
+		})
+		require.NoError(t, err)
+		notes[visibility] = noteID
+	}
+
+	viewers := []struct {
+		name    string
+		viewer  *string
+		visible []domain.Visibility
+	}{
+		{"admin sees everything", domain.VisibilityViewer(f.UserID, domain.RoleAdmin),
+			[]domain.Visibility{domain.VisibilityPrivate, domain.VisibilityTeam, domain.VisibilityWorkspace}},
+		{"author sees own private notes", domain.VisibilityViewer(author, domain.RoleUser),
+			[]domain.Visibility{domain.VisibilityPrivate, domain.VisibilityTeam, domain.VisibilityWorkspace}},
+		{"follower of the contact sees team notes", domain.VisibilityViewer(follower, domain.RoleUser),
+			[]domain.Visibility{domain.VisibilityTeam, domain.VisibilityWorkspace}},
+		{"other agent sees workspace notes only", domain.VisibilityViewer(outsider, domain.RoleUser),
+			[]domain.Visibility{domain.VisibilityWorkspace}},
+	}
+
+	for _, v := range viewers {
+		t.Run(v.name, func(t *testing.T) {
+			want := make([]string, len(v.visible))
+			for i, visibility := range v.visible {
+				want[i] = notes[visibility]
+			}
+			sort.Strings(want)
+
+			// Timeline
+			listed, err := activities.List(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, nil, v.viewer)
+			require.NoError(t, err)
+			assert.Equal(t, want, activityNoteIDs(listed), "timeline")
+
+			var streamed []domain.Activity
+			err = activities.Each(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, nil, v.viewer, func(a *domain.Activity) error {
+				streamed = append(streamed, *a)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, want, activityNoteIDs(streamed), "timeline export")
+
+			// Digest
+			digest, err := activities.Digest(ctx, domain.TimelineDigestParams{
+				WorkspaceID: f.WorkspaceID,
+				ContactID:   &contact.ID,
+				PerType:     20,
+				Viewer:      v.viewer,
+			})
+			require.NoError(t, err)
+			require.Len(t, digest.Groups, 1)
+			assert.Equal(t, domain.ActivityTypeNote, digest.Groups[0].Type)
+			assert.Equal(t, int64(len(want)), digest.Groups[0].Count, "digest count")
+			var digestIDs []string
+			for _, item := range digest.Groups[0].Items {
+				digestIDs = append(digestIDs, *item.ActivityID)
+			}
+			sort.Strings(digestIDs)
+			assert.Equal(t, want, digestIDs, "digest items")
+
+			// Leitura direta
+			for visibility, noteID := range notes {
+				note, err := activities.GetNote(ctx, f.WorkspaceID, noteID, v.viewer)
+				if slices.Contains(v.visible, visibility) {
+					require.NoError(t, err, "note %s", visibility)
+					assert.Equal(t, noteID, note.ID)
+				} else {
+					assert.ErrorIs(t, err, repo.ErrNoteNotFound, "note %s", visibility)
+				}
+			}
+		})
+	}
+}
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestTaskVisibility_Integration
+func TestTaskVisibility_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	tasks := repo.NewTaskRepository(pool)
+	followers := repo.NewFollowerRepository(pool)
+
+	owner := f.Member(domain.RoleUser)
+	assignee := f.Member(domain.RoleUser)
+	follower := f.Member(domain.RoleUser)
+	outsider := f.Member(domain.RoleUser)
+
+	withVisibility := func(visibility domain.Visibility) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.ActorID = owner
+			task.Visibility = visibility
+		}
+	}
+	private := f.Task(withVisibility(domain.VisibilityPrivate))
+	assigned := f.Task(withVisibility(domain.VisibilityPrivate), func(task *domain.Task) {
+		task.AssignedTo = &assignee
+	})
+	team := f.Task(withVisibility(domain.VisibilityTeam))
+	open := f.Task(withVisibility(domain.VisibilityWorkspace))
+
+	followID, err := id.New(id.Follower)
+	require.NoError(t, err)
+	_, err = followers.Follow(ctx, &domain.Follower{
+		ID:          followID,
+		WorkspaceID: f.WorkspaceID,
+		EntityType:  domain.FollowEntityTask,
+		EntityID:    team.ID,
+		UserID:      follower,
+		Reason:      domain.FollowReasonManual,
+	})
+	require.NoError(t, err)
+
+	all := []*domain.Task{private, assigned, team, open}
+	viewers := []struct {
+		name    string
+		viewer  *string
+		visible []*domain.Task
+	}{
+		{"admin sees everything", domain.VisibilityViewer(f.UserID, domain.RoleAdmin), all},
+		{"owner sees own private tasks", domain.VisibilityViewer(owner, domain.RoleUser), all},
+		{"assignee sees the assigned private task", domain.VisibilityViewer(assignee, domain.RoleUser), []*domain.Task{assigned, open}},
+		{"follower sees the team task", domain.VisibilityViewer(follower, domain.RoleUser), []*domain.Task{team, open}},
+		{"other agent sees workspace tasks only", domain.VisibilityViewer(outsider, domain.RoleUser), []*domain.Task{open}},
+	}
+
+	for _, v := range viewers {
+		t.Run(v.name, func(t *testing.T) {
+			var want []string
+			for _, task := range v.visible {
+				want = append(want, task.ID)
+			}
+			sort.Strings(want)
+
+			// Listagem
+			listed, _, err := tasks.List(ctx, domain.ListTasksParams{WorkspaceID: f.WorkspaceID, Viewer: v.viewer, Limit: 100})
+			require.NoError(t, err)
+			assert.Equal(t, want, taskIDs(listed), "list")
+
+			// Board
+			board := domain.TaskBoardParams{ListTasksParams: domain.ListTasksParams{WorkspaceID: f.WorkspaceID, Viewer: v.viewer}}
+			board.Normalize()
+			boardTasks, err := tasks.BoardTasks(ctx, board)
+			require.NoError(t, err)
+			assert.Equal(t, want, taskIDs(boardTasks), "board tasks")
+
+			counts, err := tasks.BoardCounts(ctx, board)
+			require.NoError(t, err)
+			total := 0
+			for _, c := range counts {
+				total += c.Count
+			}
+			assert.Equal(t, len(want), total, "board counts")
+
+			aggregates, err := tasks.StatusAggregates(ctx, f.WorkspaceID, []domain.TaskStatus{domain.TaskStatusBacklog}, v.viewer)
+			require.NoError(t, err)
+			require.Len(t, aggregates, 1)
+			assert.Equal(t, int64(len(want)), aggregates[0].Count, "status aggregates")
+
+			// Leitura direta
+			for _, task := range all {
+				got, err := tasks.GetVisible(ctx, f.WorkspaceID, task.ID, v.viewer)
+				if containsTask(v.visible, task) {
+					require.NoError(t, err, "task %s", task.Title)
+					assert.Equal(t, task.ID, got.ID)
+				} else {
+					assert.ErrorIs(t, err, repo.ErrTaskNotFound, "task %s", task.Title)
+				}
+			}
+		})
+	}
+}
+
+// Run with: DATABASE_URL=... go test -v ./internal/repo -run TestTaskVisibility_TrashAndCounters_Integration
+func TestTaskVisibility_TrashAndCounters_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+	tasks := repo.NewTaskRepository(pool)
+	trash := repo.NewTrashRepository(pool)
+	counters := repo.NewCounterRepository(pool)
+
+	owner := f.Member(domain.RoleUser)
+	outsider := f.Member(domain.RoleManager)
+
+	dueToday := func(visibility domain.Visibility) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.ActorID = owner
+			task.Visibility = visibility
+			task.Status = domain.TaskStatusTodo
+			task.DueDate = factory.Ptr(time.Now().UTC())
+		}
+	}
+	private := f.Task(dueToday(domain.VisibilityPrivate))
+	open := f.Task(dueToday(domain.VisibilityWorkspace))
+
+	adminViewer := domain.VisibilityViewer(f.UserID, domain.RoleAdmin)
+	ownerViewer := domain.VisibilityViewer(owner, domain.RoleUser)
+	outsiderViewer := domain.VisibilityViewer(outsider, domain.RoleManager)
+
+	t.Run("tasks due today", func(t *testing.T) {
+		period := domain.NewCounterPeriod(time.Now())
+
+		all, err := counters.Count(ctx, f.WorkspaceID, period)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), all.TasksDueToday, "cached workspace counters are the admin view")
+
+		for _, v := range []struct {
+			name   string
+			viewer *string
+			want   int64
+		}{
+			{"admin", adminViewer, 2},
+			{"owner", ownerViewer, 2},
+			{"other member", outsiderViewer, 1},
+		} {
+			count, err := counters.TasksDueToday(ctx, f.WorkspaceID, period, v.viewer)
+			require.NoError(t, err)
+			assert.Equal(t, v.want, count, v.name)
+		}
+	})
+
+	for _, task := range []*domain.Task{private, open} {
+		require.NoError(t, tasks.SoftDelete(ctx, f.WorkspaceID, task.ID, owner))
+	}
+
+	t.Run("trash", func(t *testing.T) {
+		taskType := domain.TrashEntityTask
+		listTrash := func(viewer *string) []string {
+			items, err := trash.List(ctx, domain.ListTrashParams{
+				WorkspaceID:  f.WorkspaceID,
+				EntityType:   &taskType,
+				DeletedAfter: time.Now().UTC().Add(-time.Hour),
+				Limit:        10,
+				Viewer:       viewer,
+			})
+			require.NoError(t, err)
+			ids := make([]string, 0, len(items))
+			for _, item := range items {
+				ids = append(ids, item.EntityID)
+			}
+			sort.Strings(ids)
+			return ids
+		}
+
+		both := []string{private.ID, open.ID}
+		sort.Strings(both)
+		assert.Equal(t, both, listTrash(adminViewer))
+		assert.Equal(t, both, listTrash(ownerViewer))
+		assert.Equal(t, []string{open.ID}, listTrash(outsiderViewer))
+
+		assert.ErrorIs(t, tasks.RestoreVisible(ctx, f.WorkspaceID, private.ID, outsiderViewer), repo.ErrTaskNotFound)
+		require.NoError(t, tasks.RestoreVisible(ctx, f.WorkspaceID, private.ID, ownerViewer))
+		require.NoError(t, tasks.RestoreVisible(ctx, f.WorkspaceID, open.ID, outsiderViewer))
+	})
+}
+
+func activityNoteIDs(activities []domain.Activity) []string {
+	ids := make([]string, 0, len(activities))
+	for _, a := range activities {
+		ids = append(ids, *a.ActivityID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func taskIDs(tasks []domain.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func containsTask(visible []*domain.Task, task *domain.Task) bool {
+	for _, candidate := range visible {
+		if candidate.ID == task.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/database"
 	"linkko-api/internal/domain"
+	"linkko-api/internal/resilience"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // =====================================================
@@ -17,10 +19,13 @@ import (
 
 var (
 	// ErrMemberNotFound indicates the user is not a member of the workspace
-	ErrMemberNotFound = errors.New("user is not a member of this workspace")
+	ErrMemberNotFound = apperr.Forbidden("user is not a member of this workspace", "insufficient permissions for this workspace")
 
 	// ErrInvalidRole indicates the role ID does not exist in WorkspaceRole table
 	ErrInvalidRole = errors.New("invalid workspace role")
+
+	// ErrWorkspaceNotFound indicates the workspace does not exist
+	ErrWorkspaceNotFound = apperr.NotFound("workspace not found", "workspace not found")
 )
 
 // =====================================================
@@ -30,12 +35,12 @@ var (
 // WorkspaceRepository handles database operations for workspace membership and roles.
 // Follows the repository pattern established in contact.go (concrete struct, no interface).
 type WorkspaceRepository struct {
-	pool *pgxpool.Pool
+	pool database.DB
 }
 
 // NewWorkspaceRepository creates a new WorkspaceRepository instance.
-// Dependency injection pattern: requires a pgxpool.Pool for database access.
-func NewWorkspaceRepository(pool *pgxpool.Pool) *WorkspaceRepository {
+// Dependency injection pattern: requires a database.DB (pgxpool.Pool or database.LanePool).
+func NewWorkspaceRepository(pool database.DB) *WorkspaceRepository {
 	return &WorkspaceRepository{pool: pool}
 }
 
@@ -68,7 +73,10 @@ func (r *WorkspaceRepository) GetMemberRole(ctx context.Context, userID string,
 	`
 
 	var roleName string
-	err := r.pool.QueryRow(ctx, query, userID, workspaceID).Scan(&roleName)
+	// Consulta executada em toda request autenticada: retry em erros transitórios do Postgres
+	err := resilience.Retry(ctx, resilience.DefaultPgRetryPolicy, func(ctx context.Context) error {
+		return r.pool.QueryRow(ctx, query, userID, workspaceID).Scan(&roleName)
+	})
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -117,6 +125,23 @@ func (r *WorkspaceRepository) IsMember(ctx context.Context, userID string, works
 	return exists, nil
 }
 
+// GetStatus returns the workspace status (ACTIVE/SUSPENDED).
+//
+// Returns:
+//   - ErrWorkspaceNotFound if the workspace does not exist
+//   - Other errors for database failures
+func (r *WorkspaceRepository) GetStatus(ctx context.Context, workspaceID string) (domain.WorkspaceStatus, error) {
+	var status domain.WorkspaceStatus
+	err := r.pool.QueryRow(ctx, `SELECT "status" FROM public."Workspace" WHERE id = $1`, workspaceID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrWorkspaceNotFound
+		}
+		return "", fmt.Errorf("query workspace status: %w", err)
+	}
+	return status, nil
+}
+
 // =====================================================
 // Additional Helper Methods (Future Expansion)
 // =====================================================
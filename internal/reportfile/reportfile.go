@@ -0,0 +1,277 @@
+// Package reportfile renderiza relatórios tabulares em CSV ou PDF para entrega por email ou
+// webhook (agendamentos de relatórios) e documentos de texto (orçamentos) em PDF.
+//
+// O PDF é gerado sem dependências: texto em fonte monoespaçada (Courier), tabelas paginadas
+// em A4 paisagem e documentos em A4 retrato. Caracteres fora do Latin-1 viram "?".
+package reportfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format formato do arquivo gerado.
+type Format string
+
+const (
+	CSV Format = "csv"
+	PDF Format = "pdf"
+)
+
+// ContentType MIME type do formato.
+func (f Format) ContentType() string {
+	if f == PDF {
+		return "application/pdf"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Table relatório tabular: título, colunas e linhas já formatadas como texto.
+type Table struct {
+	Title       string
+	GeneratedAt time.Time
+	Columns     []string
+	Rows        [][]string
+}
+
+// Render gera o arquivo no formato pedido.
+func Render(t *Table, format Format) ([]byte, error) {
+	switch format {
+	case CSV:
+		return renderCSV(t)
+	case PDF:
+		return renderPDF(t), nil
+	}
+	return nil, fmt.Errorf("unsupported report format %q", format)
+}
+
+func renderCSV(t *Table) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(t.Columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Layout do PDF: A4 paisagem (842x595 pt), Courier 8pt (cada caractere ocupa 0,6 em)
+const (
+	pageWidth    = 842
+	pageHeight   = 595
+	margin       = 36
+	fontSize     = 8
+	lineHeight   = 11
+	maxColumnLen = 40
+)
+
+// textLines monta as linhas de texto da tabela com colunas alinhadas.
+func (t *Table) textLines() []string {
+	widths := make([]int, len(t.Columns))
+	measure := func(row []string) {
+		for i := range widths {
+			if i < len(row) {
+				widths[i] = max(widths[i], min(utf8.RuneCountInString(row[i]), maxColumnLen))
+			}
+		}
+	}
+	measure(t.Columns)
+	for _, row := range t.Rows {
+		measure(row)
+	}
+
+	format := func(row []string) string {
+		cells := make([]string, len(widths))
+		for i, w := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			if utf8.RuneCountInString(cell) > w {
+				cell = string([]rune(cell)[:w-1]) + "~"
+			}
+			cells[i] = cell + strings.Repeat(" ", w-utf8.RuneCountInString(cell))
+		}
+		return strings.TrimRight(strings.Join(cells, "  "), " ")
+	}
+
+	separator := make([]string, len(widths))
+	for i, w := range widths {
+		separator[i] = strings.Repeat("-", w)
+	}
+
+	lines := []string{format(t.Columns), strings.Join(separator, "  ")}
+	for _, row := range t.Rows {
+		lines = append(lines, format(row))
+	}
+	if len(t.Rows) == 0 {
+		lines = append(lines, "(no rows)")
+	}
+	return lines
+}
+
+func renderPDF(t *Table) []byte {
+	maxChars := (pageWidth - 2*margin) * 10 / (6 * fontSize) // largura útil / (0,6 * fontSize)
+	linesPerPage := (pageHeight-2*margin)/lineHeight - 3     // título + data + linha em branco
+
+	lines := t.textLines()
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) > maxChars {
+			lines[i] = string([]rune(line)[:maxChars])
+		}
+	}
+
+	pages := paginate(lines, linesPerPage)
+	header := []string{t.Title, "Generated at " + t.GeneratedAt.UTC().Format("2006-01-02 15:04 UTC"), ""}
+	for i := range pages {
+		pageHeader := append([]string{}, header...)
+		if len(pages) > 1 {
+			pageHeader[0] = fmt.Sprintf("%s (%d/%d)", t.Title, i+1, len(pages))
+		}
+		pages[i] = append(pageHeader, pages[i]...)
+	}
+	return writePDF(pages, pageWidth, pageHeight, fontSize, lineHeight)
+}
+
+// Document documento de texto corrido (propostas, orçamentos): título e linhas já renderizadas.
+// Linhas longas quebram na largura da página; a fonte é monoespaçada, então tabelas
+// montadas com espaços ficam alinhadas.
+type Document struct {
+	Title string
+	Lines []string
+}
+
+// Layout do documento: A4 retrato (595x842 pt), Courier 10pt
+const (
+	docPageWidth  = 595
+	docPageHeight = 842
+	docFontSize   = 10
+	docLineHeight = 13
+)
+
+// RenderDocument gera o PDF do documento.
+func RenderDocument(d *Document) []byte {
+	maxChars := (docPageWidth - 2*margin) * 10 / (6 * docFontSize)
+	linesPerPage := (docPageHeight-2*margin)/docLineHeight - 2 // título + linha em branco
+
+	var lines []string
+	for _, line := range d.Lines {
+		lines = append(lines, wrapLine(strings.TrimRight(line, " \t\r"), maxChars)...)
+	}
+
+	pages := paginate(lines, linesPerPage)
+	for i := range pages {
+		title := d.Title
+		if len(pages) > 1 {
+			title = fmt.Sprintf("%s (%d/%d)", d.Title, i+1, len(pages))
+		}
+		pages[i] = append([]string{title, ""}, pages[i]...)
+	}
+	return writePDF(pages, docPageWidth, docPageHeight, docFontSize, docLineHeight)
+}
+
+// wrapLine quebra a linha em pedaços de até width caracteres, preferindo quebrar em espaços.
+func wrapLine(line string, width int) []string {
+	runes := []rune(line)
+	if len(runes) <= width {
+		return []string{line}
+	}
+	var out []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > width/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		out = append(out, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	if len(runes) > 0 {
+		out = append(out, string(runes))
+	}
+	return out
+}
+
+// paginate divide as linhas em páginas; sempre há ao menos uma página.
+func paginate(lines []string, perPage int) [][]string {
+	pages := [][]string{}
+	for len(lines) > 0 {
+		n := min(perPage, len(lines))
+		pages = append(pages, lines[:n:n])
+		lines = lines[n:]
+	}
+	if len(pages) == 0 {
+		pages = append(pages, []string{})
+	}
+	return pages
+}
+
+// writePDF monta o arquivo PDF com uma página de texto Courier por item de pages.
+func writePDF(pages [][]string, width, height, size, leading int) []byte {
+	// Objetos: 1 catálogo, 2 páginas, 3 fonte, depois (página, conteúdo) por página
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+	)
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", size, leading, margin, height-margin)
+		for _, line := range page {
+			content.WriteString("(")
+			content.Write(pdfEscape(line))
+			content.WriteString(") Tj T*\n")
+		}
+		content.WriteString("ET")
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				width, height, 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfEscape converte para Latin-1 (WinAnsi) e escapa os delimitadores de string do PDF.
+func pdfEscape(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out = append(out, '\\', byte(r))
+		case r >= 32 && r < 127, r >= 160 && r <= 255:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package reportfile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+		want  []string
+	}{
+		{"fits", "proposta comercial", 20, []string{"proposta comercial"}},
+		{"breaks at spaces", "proposta comercial para a Acme", 12, []string{"proposta", "comercial", "para a Acme"}},
+		{"hard break without spaces", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"counts runes", "ação ação ação", 9, []string{"ação ação", "ação"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wrapLine(tt.line, tt.width))
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	assert.Equal(t, [][]string{{}}, paginate(nil, 10), "always at least one page")
+
+	pages := paginate([]string{"a", "b", "c", "d", "e"}, 2)
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, pages)
+
+	// Anexar o cabeçalho a uma página não pode sobrescrever a seguinte
+	_ = append(pages[0], "x")
+	assert.Equal(t, "c", pages[1][0])
+}
+
+func TestRenderDocument(t *testing.T) {
+	lines := make([]string, 0, 130)
+	for i := 0; i < 130; i++ {
+		lines = append(lines, "Linha do orçamento")
+	}
+	pdf := RenderDocument(&Document{Title: "Orçamento 42", Lines: lines})
+
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+	assert.True(t, bytes.HasSuffix(bytes.TrimSpace(pdf), []byte("%%EOF")))
+	assert.Equal(t, 3, strings.Count(string(pdf), "/Type /Page /Parent"), "130 lines need three A4 portrait pages")
+	assert.Contains(t, string(pdf), "/MediaBox [0 0 595 842]")
+	assert.Contains(t, string(pdf), `42 \(1/3\)`)
+
+	single := RenderDocument(&Document{Title: "Vazio"})
+	assert.Equal(t, 1, strings.Count(string(single), "/Type /Page /Parent"))
+	assert.NotContains(t, string(single), `\(1/1\)`)
+}
@@ -0,0 +1,191 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"linkko-api/internal/apperr"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ErrCircuitOpen é retornado sem chamar a dependência enquanto o breaker está aberto.
+var ErrCircuitOpen = apperr.Define(apperr.CodeServiceUnavailable, http.StatusServiceUnavailable, "circuit breaker is open", "a dependency is temporarily unavailable, retry later")
+
+// State representa o estado do circuit breaker
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerConfig configura um Breaker. Métricas são opcionais (nil = desabilitadas).
+type BreakerConfig struct {
+	// Name identifica a dependência nas métricas (ex.: "redis", "postgres")
+	Name string
+	// FailureThreshold é o número de falhas consecutivas que abre o circuito
+	FailureThreshold int
+	// OpenTimeout é quanto tempo o circuito fica aberto antes de liberar uma chamada de teste
+	OpenTimeout time.Duration
+
+	// Transitions conta mudanças de estado (atributos: breaker, from, to)
+	Transitions metric.Int64Counter
+	// Open vale 1 enquanto o breaker está aberto/meio-aberto e 0 quando fechado (atributo: breaker)
+	Open metric.Int64UpDownCounter
+}
+
+// Breaker é um circuit breaker simples closed → open → half-open → closed.
+// Um *Breaker nil é válido e executa fn diretamente.
+type Breaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu               sync.Mutex
+	state            State
+	failures         int
+	openedAt         time.Time
+	halfOpenInFlight bool
+}
+
+// NewBreaker creates a new Breaker with sane defaults for zero values
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	return &Breaker{cfg: cfg, now: time.Now}
+}
+
+// Name returns the dependency name of the breaker
+func (b *Breaker) Name() string {
+	if b == nil {
+		return ""
+	}
+	return b.cfg.Name
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() State {
+	if b == nil {
+		return StateClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Execute chama fn se o circuito permitir e registra o resultado.
+// Cancelamento pelo cliente (context.Canceled) não conta como falha da dependência.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if b == nil {
+		return fn(ctx)
+	}
+
+	if err := b.allow(ctx); err != nil {
+		return err
+	}
+
+	err := fn(ctx)
+	b.record(ctx, err)
+	return err
+}
+
+func (b *Breaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.transition(ctx, StateHalfOpen)
+		b.halfOpenInFlight = true
+		return nil
+	case StateHalfOpen:
+		// Apenas uma chamada de teste por vez
+		if b.halfOpenInFlight {
+			return ErrCircuitOpen
+		}
+		b.halfOpenInFlight = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.halfOpenInFlight = false
+	}
+
+	if err == nil || errors.Is(err, context.Canceled) {
+		if b.state == StateHalfOpen {
+			b.transition(ctx, StateClosed)
+		}
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.openedAt = b.now()
+		if b.state != StateOpen {
+			b.transition(ctx, StateOpen)
+		}
+	}
+}
+
+// transition must be called with b.mu held
+func (b *Breaker) transition(ctx context.Context, to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+
+	// Métricas usam um contexto sem cancelamento: a request pode já ter expirado
+	ctx = context.WithoutCancel(ctx)
+	name := attribute.String("breaker", b.cfg.Name)
+
+	if b.cfg.Transitions != nil {
+		b.cfg.Transitions.Add(ctx, 1, metric.WithAttributes(
+			name,
+			attribute.String("from", from.String()),
+			attribute.String("to", to.String()),
+		))
+	}
+	if b.cfg.Open != nil {
+		switch {
+		case from == StateClosed:
+			b.cfg.Open.Add(ctx, 1, metric.WithAttributes(name))
+		case to == StateClosed:
+			b.cfg.Open.Add(ctx, -1, metric.WithAttributes(name))
+		}
+	}
+	if to == StateClosed {
+		b.failures = 0
+	}
+}
@@ -0,0 +1,143 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDependency = errors.New("dependency down")
+
+func TestBreaker_OpensAfterThresholdAndRecovers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	b := NewBreaker(BreakerConfig{Name: "redis", FailureThreshold: 3, OpenTimeout: 10 * time.Second})
+	b.now = func() time.Time { return now }
+
+	failing := func(ctx context.Context) error { return errDependency }
+	ok := func(ctx context.Context) error { return nil }
+
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, b.Execute(ctx, failing), errDependency)
+	}
+	require.Equal(t, StateOpen, b.State())
+
+	// Aberto: não chama a dependência
+	called := false
+	err := b.Execute(ctx, func(ctx context.Context) error { called = true; return nil })
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+
+	// Após o OpenTimeout, uma chamada de teste fecha o circuito
+	now = now.Add(11 * time.Second)
+	require.NoError(t, b.Execute(ctx, ok))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenFailureReopens(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	b := NewBreaker(BreakerConfig{Name: "postgres", FailureThreshold: 1, OpenTimeout: time.Second})
+	b.now = func() time.Time { return now }
+
+	_ = b.Execute(ctx, func(ctx context.Context) error { return errDependency })
+	require.Equal(t, StateOpen, b.State())
+
+	now = now.Add(2 * time.Second)
+	assert.ErrorIs(t, b.Execute(ctx, func(ctx context.Context) error { return errDependency }), errDependency)
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, b.Execute(ctx, func(ctx context.Context) error { return nil }), ErrCircuitOpen)
+}
+
+func TestBreaker_CanceledDoesNotCount(t *testing.T) {
+	b := NewBreaker(BreakerConfig{Name: "redis", FailureThreshold: 1})
+	_ = b.Execute(context.Background(), func(ctx context.Context) error { return context.Canceled })
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_NilIsPassthrough(t *testing.T) {
+	var b *Breaker
+	assert.ErrorIs(t, b.Execute(context.Background(), func(ctx context.Context) error { return errDependency }), errDependency)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestRetry(t *testing.T) {
+	transient := &pgconn.PgError{Code: "40001"}
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	t.Run("RetriesTransientUntilSuccess", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), policy, func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("query: %w", transient)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("StopsAtMaxAttempts", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), policy, func(ctx context.Context) error {
+			calls++
+			return transient
+		})
+		assert.ErrorIs(t, err, transient)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("DoesNotRetryPermanent", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), policy, func(ctx context.Context) error {
+			calls++
+			return &pgconn.PgError{Code: "23505"}
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("DoStopsOnOpenCircuit", func(t *testing.T) {
+		b := NewBreaker(BreakerConfig{Name: "postgres", FailureThreshold: 1, OpenTimeout: time.Minute})
+		calls := 0
+		err := Do(context.Background(), b, policy, func(ctx context.Context) error {
+			calls++
+			return transient
+		})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestIsTransientPgError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Nil", err: nil, expected: false},
+		{name: "NoRows", err: pgx.ErrNoRows, expected: false},
+		{name: "UniqueViolation", err: &pgconn.PgError{Code: "23505"}, expected: false},
+		{name: "ConnectionFailure", err: &pgconn.PgError{Code: "08006"}, expected: true},
+		{name: "SerializationFailure", err: &pgconn.PgError{Code: "40001"}, expected: true},
+		{name: "Deadlock", err: fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "40P01"}), expected: true},
+		{name: "AdminShutdown", err: &pgconn.PgError{Code: "57P01"}, expected: true},
+		{name: "DeadlineExceeded", err: context.DeadlineExceeded, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTransientPgError(tt.err))
+		})
+	}
+}
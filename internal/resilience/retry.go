@@ -0,0 +1,118 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RetryPolicy define um retry limitado com backoff exponencial.
+type RetryPolicy struct {
+	// MaxAttempts inclui a primeira tentativa (1 = sem retry)
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Retryable decide se o erro é transitório. nil = IsTransientPgError
+	Retryable func(error) bool
+}
+
+// DefaultPgRetryPolicy é usada nas queries idempotentes de hot path (auth, idempotência)
+var DefaultPgRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   25 * time.Millisecond,
+	MaxDelay:    200 * time.Millisecond,
+	Retryable:   IsTransientPgError,
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// Retry executa fn até MaxAttempts vezes enquanto o erro for transitório.
+// Para imediatamente se o contexto expirar; o último erro de fn é retornado.
+// Use apenas em operações idempotentes.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsTransientPgError
+	}
+	attempts := policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt == attempts || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(policy.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// Do combina breaker e retry: cada tentativa passa pelo breaker e
+// ErrCircuitOpen interrompe o retry imediatamente.
+func Do(ctx context.Context, b *Breaker, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsTransientPgError
+	}
+	policy.Retryable = func(err error) bool {
+		return !errors.Is(err, ErrCircuitOpen) && retryable(err)
+	}
+	return Retry(ctx, policy, func(ctx context.Context) error {
+		return b.Execute(ctx, fn)
+	})
+}
+
+// IsTransientPgError reporta falhas do Postgres que podem ter sucesso numa nova tentativa:
+// conexão perdida/recusada, serialization failure, deadlock e shutdown do servidor.
+// Erros de constraint, sintaxe e pgx.ErrNoRows nunca são transitórios.
+func IsTransientPgError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"): // connection_exception
+			return true
+		case pgErr.Code == "40001", pgErr.Code == "40P01": // serialization_failure, deadlock_detected
+			return true
+		case pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03": // admin/crash shutdown, cannot_connect_now
+			return true
+		case pgErr.Code == "53300": // too_many_connections
+			return true
+		}
+		return false
+	}
+
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,403 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	pathParamRe    = regexp.MustCompile(`\{(\w+)\}`)
+)
+
+// typeEmitter converte os tipos de Endpoints em declarações Go independentes dos pacotes internos.
+type typeEmitter struct {
+	decls map[string]string       // nome → declaração
+	seen  map[string]reflect.Type // nome → tipo de origem (detecta colisões entre pacotes)
+}
+
+func newTypeEmitter() *typeEmitter {
+	return &typeEmitter{decls: map[string]string{}, seen: map[string]reflect.Type{}}
+}
+
+// expr devolve a expressão Go do tipo, registrando a declaração dos tipos nomeados.
+func (e *typeEmitter) expr(t reflect.Type) (string, error) {
+	switch t {
+	case timeType:
+		return "time.Time", nil
+	case rawMessageType:
+		return "json.RawMessage", nil
+	}
+
+	if t.Name() != "" && t.PkgPath() != "" {
+		if prev, ok := e.seen[t.Name()]; ok {
+			if prev != t {
+				return "", fmt.Errorf("type name %s is used by %s and %s", t.Name(), prev.PkgPath(), t.PkgPath())
+			}
+			return t.Name(), nil
+		}
+		e.seen[t.Name()] = t
+
+		underlying, err := e.literal(t)
+		if err != nil {
+			return "", err
+		}
+		e.decls[t.Name()] = fmt.Sprintf("type %s %s", t.Name(), underlying)
+		return t.Name(), nil
+	}
+
+	return e.literal(t)
+}
+
+// literal devolve o tipo sem o nome (struct, slice, map...).
+func (e *typeEmitter) literal(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem, err := e.expr(t.Elem())
+		return "*" + elem, err
+	case reflect.Slice:
+		elem, err := e.expr(t.Elem())
+		return "[]" + elem, err
+	case reflect.Array:
+		elem, err := e.expr(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), err
+	case reflect.Map:
+		key, err := e.expr(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := e.expr(t.Elem())
+		return "map[" + key + "]" + elem, err
+	case reflect.Interface:
+		return "any", nil
+	case reflect.Struct:
+		return e.structLiteral(t)
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return t.Kind().String(), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// structLiteral copia os campos exportados com a tag json; as demais tags (validate...) ficam de fora.
+func (e *typeEmitter) structLiteral(t reflect.Type) (string, error) {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, hasTag := f.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+
+		typ, err := e.expr(f.Type)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+		if f.Anonymous && !hasTag {
+			b.WriteString(typ + "\n")
+			continue
+		}
+		b.WriteString(f.Name + " " + typ)
+		if hasTag {
+			fmt.Fprintf(&b, " `json:%q`", tag)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// genMethod dados de um método do client.
+type genMethod struct {
+	Name       string
+	Doc        string
+	Method     string
+	Params     []string // argumentos do path, na ordem
+	Body       string   // tipo do corpo; "" = sem corpo
+	Out        string   // tipo da resposta; "" = sem corpo
+	OutSlice   bool
+	Envelope   bool
+	Query      bool
+	RouteConst string
+}
+
+type genRoute struct {
+	Name string
+	Path string
+}
+
+// GenerateGo gera o client Go (pacote pkg) com as rotas, os tipos e um método por endpoint.
+func GenerateGo(pkg string) ([]byte, error) {
+	e := newTypeEmitter()
+
+	methods := make([]genMethod, 0, len(Endpoints))
+	for _, ep := range Endpoints {
+		m := genMethod{Name: ep.Name, Doc: ep.Doc, Method: methodConst(ep.Method), Envelope: ep.Envelope, Query: ep.Query}
+		m.RouteConst = routeNames[ep.Path]
+		if m.RouteConst == "" {
+			return nil, fmt.Errorf("%s: path %s is not a Route constant", ep.Name, ep.Path)
+		}
+		for _, match := range pathParamRe.FindAllStringSubmatch(ep.Path, -1) {
+			m.Params = append(m.Params, paramName(match[1]))
+		}
+
+		if ep.Request != nil {
+			body, err := e.expr(reflect.TypeOf(ep.Request))
+			if err != nil {
+				return nil, fmt.Errorf("%s request: %w", ep.Name, err)
+			}
+			m.Body = body
+		}
+		if ep.Response != nil {
+			t := reflect.TypeOf(ep.Response)
+			out, err := e.expr(t)
+			if err != nil {
+				return nil, fmt.Errorf("%s response: %w", ep.Name, err)
+			}
+			m.Out, m.OutSlice = out, t.Kind() == reflect.Slice
+		}
+		methods = append(methods, m)
+	}
+
+	names := make([]string, 0, len(e.decls))
+	for name := range e.decls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	decls := make([]string, 0, len(names))
+	for _, name := range names {
+		decls = append(decls, e.decls[name])
+	}
+
+	routeList := make([]genRoute, 0, len(routeNames))
+	for path, name := range routeNames {
+		routeList = append(routeList, genRoute{Name: name, Path: path})
+	}
+	sort.Slice(routeList, func(i, j int) bool { return routeList[i].Path < routeList[j].Path })
+
+	var buf bytes.Buffer
+	err := clientTemplate.Execute(&buf, map[string]any{
+		"Package": pkg,
+		"Routes":  routeList,
+		"Types":   decls,
+		"Methods": methods,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render client: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format client: %w", err)
+	}
+	return src, nil
+}
+
+// paramName workspaceId → workspaceID.
+func paramName(p string) string {
+	if strings.HasSuffix(p, "Id") {
+		return strings.TrimSuffix(p, "Id") + "ID"
+	}
+	return p
+}
+
+func methodConst(method string) string {
+	m := strings.ToLower(method)
+	return "http.Method" + strings.ToUpper(m[:1]) + m[1:]
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by "linkko-api gen sdk". DO NOT EDIT.
+
+// Package {{.Package}} é o client Go da API Linkko.
+package {{.Package}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Rotas da API; os parâmetros entre chaves são preenchidos na ordem do path.
+const (
+{{- range .Routes}}
+	{{.Name}} = {{printf "%q" .Path}}
+{{- end}}
+)
+
+{{range .Types}}
+{{.}}
+{{end}}
+
+// Client chama a API com um token Bearer (JWT de usuário ou token de service account).
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// New cria o client com timeout de 30s.
+func New(baseURL, token string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// APIError resposta de erro da API ({"ok": false, "error": {...}}).
+type APIError struct {
+	StatusCode int
+	Code       string            ` + "`json:\"code\"`" + `
+	Message    string            ` + "`json:\"message\"`" + `
+	Fields     map[string]string ` + "`json:\"fields,omitempty\"`" + `
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("linkko api: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey envia Idempotency-Key nas escritas feitas com o contexto; retries com a mesma
+// chave devolvem a resposta original.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// BuildPath preenche os parâmetros da rota na ordem em que aparecem.
+func BuildPath(route string, params ...string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(route, '{')
+		if start < 0 || len(params) == 0 {
+			b.WriteString(route)
+			return b.String()
+		}
+		end := strings.IndexByte(route[start:], '}')
+		if end < 0 {
+			b.WriteString(route)
+			return b.String()
+		}
+		b.WriteString(route[:start])
+		b.WriteString(url.PathEscape(params[0]))
+		route, params = route[start+end+1:], params[1:]
+	}
+}
+
+// do executa a chamada; noContent indica resposta sem corpo (204).
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any, envelope bool) (noContent bool, err error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return false, fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, RequestID: resp.Header.Get("X-Request-Id")}
+		var payload struct {
+			Error *APIError ` + "`json:\"error\"`" + `
+		}
+		if json.NewDecoder(resp.Body).Decode(&payload) == nil && payload.Error != nil {
+			apiErr.Code, apiErr.Message, apiErr.Fields = payload.Error.Code, payload.Error.Message, payload.Error.Fields
+		}
+		return false, apiErr
+	}
+	if resp.StatusCode == http.StatusNoContent || out == nil {
+		return resp.StatusCode == http.StatusNoContent, nil
+	}
+
+	if envelope {
+		var payload struct {
+			Data json.RawMessage ` + "`json:\"data\"`" + `
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			return false, fmt.Errorf("decode response: %w", err)
+		}
+		if err := json.Unmarshal(payload.Data, out); err != nil {
+			return false, fmt.Errorf("decode response data: %w", err)
+		}
+		return false, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf("decode response: %w", err)
+	}
+	return false, nil
+}
+{{range .Methods}}
+// {{.Name}} {{.Doc}}
+func (c *Client) {{.Name}}(ctx context.Context{{range .Params}}, {{.}} string{{end}}{{if .Body}}, req *{{.Body}}{{end}}{{if .Query}}, query url.Values{{end}}) {{if .Out}}({{if .OutSlice}}{{.Out}}{{else}}*{{.Out}}{{end}}, error){{else}}error{{end}} {
+	path := BuildPath({{.RouteConst}}{{range .Params}}, {{.}}{{end}})
+{{- if .Out}}
+	var out {{.Out}}
+{{- if .OutSlice}}
+	if _, err := c.do(ctx, {{.Method}}, path, {{if .Query}}query{{else}}nil{{end}}, {{if .Body}}req{{else}}nil{{end}}, &out, {{.Envelope}}); err != nil {
+		return nil, err
+	}
+	return out, nil
+{{- else}}
+	noContent, err := c.do(ctx, {{.Method}}, path, {{if .Query}}query{{else}}nil{{end}}, {{if .Body}}req{{else}}nil{{end}}, &out, {{.Envelope}})
+	if err != nil || noContent {
+		return nil, err
+	}
+	return &out, nil
+{{- end}}
+{{- else}}
+	_, err := c.do(ctx, {{.Method}}, path, {{if .Query}}query{{else}}nil{{end}}, {{if .Body}}req{{else}}nil{{end}}, nil, {{.Envelope}})
+	return err
+{{- end}}
+}
+{{end}}`))
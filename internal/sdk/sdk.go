@@ -0,0 +1,142 @@
+// Package sdk descreve os endpoints da API consumidos por serviços internos: rotas, tipos de
+// request/response e o formato de cada resposta. É a fonte do client Go gerado por
+// "linkko-api gen sdk" (GenerateGo), que copia os structs para que os serviços não dependam
+// dos pacotes internal/ nem escrevam as chamadas HTTP à mão.
+package sdk
+
+import (
+	"net/http"
+
+	"linkko-api/internal/domain"
+)
+
+// Rotas no mesmo formato do OpenAPI; os parâmetros entre chaves são preenchidos na ordem do path.
+const (
+	RouteContacts = "/v1/workspaces/{workspaceId}/contacts"
+	RouteContact  = "/v1/workspaces/{workspaceId}/contacts/{contactId}"
+
+	RouteCompanies = "/v1/workspaces/{workspaceId}/companies"
+	RouteCompany   = "/v1/workspaces/{workspaceId}/companies/{companyId}"
+
+	RouteTasks = "/v1/workspaces/{workspaceId}/tasks"
+	RouteTask  = "/v1/workspaces/{workspaceId}/tasks/{taskId}"
+
+	RoutePipelines           = "/v1/workspaces/{workspaceId}/pipelines"
+	RoutePipelineSeedDefault = "/v1/workspaces/{workspaceId}/pipelines/:seed-default"
+	RoutePipeline            = "/v1/workspaces/{workspaceId}/pipelines/{pipelineId}"
+	RoutePipelineStages      = "/v1/workspaces/{workspaceId}/pipelines/{pipelineId}/stages"
+
+	RouteDeals            = "/v1/workspaces/{workspaceId}/deals"
+	RouteDeal             = "/v1/workspaces/{workspaceId}/deals/{dealId}"
+	RouteDealMove         = "/v1/workspaces/{workspaceId}/deals/{dealId}/:move"
+	RouteDealParticipants = "/v1/workspaces/{workspaceId}/deals/{dealId}/participants"
+	RouteDealSplits       = "/v1/workspaces/{workspaceId}/deals/{dealId}/splits"
+
+	RouteForecastReport = "/v1/workspaces/{workspaceId}/reports/forecast"
+)
+
+// routeNames nome de cada constante Route*, repetido no client gerado (sdk_test confere a lista).
+var routeNames = map[string]string{
+	RouteContacts:            "RouteContacts",
+	RouteContact:             "RouteContact",
+	RouteCompanies:           "RouteCompanies",
+	RouteCompany:             "RouteCompany",
+	RouteTasks:               "RouteTasks",
+	RouteTask:                "RouteTask",
+	RoutePipelines:           "RoutePipelines",
+	RoutePipelineSeedDefault: "RoutePipelineSeedDefault",
+	RoutePipeline:            "RoutePipeline",
+	RoutePipelineStages:      "RoutePipelineStages",
+	RouteDeals:               "RouteDeals",
+	RouteDeal:                "RouteDeal",
+	RouteDealMove:            "RouteDealMove",
+	RouteDealParticipants:    "RouteDealParticipants",
+	RouteDealSplits:          "RouteDealSplits",
+	RouteForecastReport:      "RouteForecastReport",
+}
+
+// Tipos de request/response dos endpoints (os DTOs de domain).
+type (
+	Contact              = domain.Contact
+	ContactListResponse  = domain.ContactListResponse
+	CreateContactRequest = domain.CreateContactRequest
+	UpdateContactRequest = domain.UpdateContactRequest
+
+	Company              = domain.Company
+	CompanyListResponse  = domain.CompanyListResponse
+	CreateCompanyRequest = domain.CreateCompanyRequest
+	UpdateCompanyRequest = domain.UpdateCompanyRequest
+
+	Task              = domain.Task
+	TaskListResponse  = domain.TaskListResponse
+	CreateTaskRequest = domain.CreateTaskRequest
+	UpdateTaskRequest = domain.UpdateTaskRequest
+
+	Pipeline                  = domain.Pipeline
+	PipelineListResponse      = domain.PipelineListResponse
+	PipelineStageListResponse = domain.PipelineStageListResponse
+
+	Deal                        = domain.Deal
+	CreateDealRequest           = domain.CreateDealRequest
+	UpdateDealRequest           = domain.UpdateDealRequest
+	UpdateDealStageRequest      = domain.UpdateDealStageRequest
+	DealParticipant             = domain.DealParticipant
+	DealParticipantListResponse = domain.DealParticipantListResponse
+	AddDealParticipantRequest   = domain.AddDealParticipantRequest
+	DealSplitListResponse       = domain.DealSplitListResponse
+	ReplaceDealSplitsRequest    = domain.ReplaceDealSplitsRequest
+
+	ForecastReport = domain.ForecastReport
+
+	UndoReceipt = domain.UndoReceipt
+)
+
+// Endpoint um método do client gerado.
+type Endpoint struct {
+	Name     string // nome do método (ex.: CreateDeal)
+	Doc      string
+	Method   string
+	Path     string // uma das constantes Route*
+	Request  any    // corpo JSON; nil = sem corpo
+	Response any    // corpo da resposta; nil = sem corpo (204)
+	Envelope bool   // resposta embrulhada em {"ok": true, "data": ...} (handlers de deals)
+	Query    bool   // aceita filtros/paginação na query string (url.Values)
+}
+
+// Endpoints endpoints expostos no client gerado. Um endpoint novo precisa existir no OpenAPI
+// (sdk_test) e o Response precisa ser o tipo que o handler escreve.
+var Endpoints = []Endpoint{
+	{Name: "ListContacts", Doc: "lista os contatos (filtros e cursor na query).", Method: http.MethodGet, Path: RouteContacts, Response: ContactListResponse{}, Query: true},
+	{Name: "GetContact", Doc: "busca um contato.", Method: http.MethodGet, Path: RouteContact, Response: Contact{}},
+	{Name: "CreateContact", Doc: "cria um contato.", Method: http.MethodPost, Path: RouteContacts, Request: CreateContactRequest{}, Response: Contact{}},
+	{Name: "UpdateContact", Doc: "altera os campos enviados do contato.", Method: http.MethodPatch, Path: RouteContact, Request: UpdateContactRequest{}, Response: Contact{}},
+	{Name: "DeleteContact", Doc: "move o contato para a lixeira; o recibo permite desfazer (nil quando não há undo).", Method: http.MethodDelete, Path: RouteContact, Response: UndoReceipt{}},
+
+	{Name: "ListCompanies", Doc: "lista as empresas (filtros e cursor na query).", Method: http.MethodGet, Path: RouteCompanies, Response: CompanyListResponse{}, Query: true},
+	{Name: "GetCompany", Doc: "busca uma empresa.", Method: http.MethodGet, Path: RouteCompany, Response: Company{}},
+	{Name: "CreateCompany", Doc: "cria uma empresa.", Method: http.MethodPost, Path: RouteCompanies, Request: CreateCompanyRequest{}, Response: Company{}},
+	{Name: "UpdateCompany", Doc: "altera os campos enviados da empresa.", Method: http.MethodPatch, Path: RouteCompany, Request: UpdateCompanyRequest{}, Response: Company{}},
+	{Name: "DeleteCompany", Doc: "move a empresa para a lixeira; o recibo permite desfazer (nil quando não há undo).", Method: http.MethodDelete, Path: RouteCompany, Response: UndoReceipt{}},
+
+	{Name: "ListTasks", Doc: "lista as tarefas (filtros e cursor na query).", Method: http.MethodGet, Path: RouteTasks, Response: TaskListResponse{}, Query: true},
+	{Name: "GetTask", Doc: "busca uma tarefa.", Method: http.MethodGet, Path: RouteTask, Response: Task{}},
+	{Name: "CreateTask", Doc: "cria uma tarefa.", Method: http.MethodPost, Path: RouteTasks, Request: CreateTaskRequest{}, Response: Task{}},
+	{Name: "UpdateTask", Doc: "altera os campos enviados da tarefa.", Method: http.MethodPatch, Path: RouteTask, Request: UpdateTaskRequest{}, Response: Task{}},
+	{Name: "DeleteTask", Doc: "move a tarefa para a lixeira; o recibo permite desfazer (nil quando não há undo).", Method: http.MethodDelete, Path: RouteTask, Response: UndoReceipt{}},
+
+	{Name: "ListPipelines", Doc: "lista os pipelines (filtros e cursor na query).", Method: http.MethodGet, Path: RoutePipelines, Response: PipelineListResponse{}, Query: true},
+	{Name: "GetPipeline", Doc: "busca um pipeline com suas etapas.", Method: http.MethodGet, Path: RoutePipeline, Response: Pipeline{}, Query: true},
+	{Name: "ListPipelineStages", Doc: "lista as etapas do pipeline na ordem do board.", Method: http.MethodGet, Path: RoutePipelineStages, Response: PipelineStageListResponse{}, Query: true},
+
+	{Name: "ListDeals", Doc: "lista os negócios (pipelineId, stageId, ownerId... na query).", Method: http.MethodGet, Path: RouteDeals, Response: []Deal{}, Envelope: true, Query: true},
+	{Name: "GetDeal", Doc: "busca um negócio.", Method: http.MethodGet, Path: RouteDeal, Response: Deal{}, Envelope: true, Query: true},
+	{Name: "CreateDeal", Doc: "cria um negócio.", Method: http.MethodPost, Path: RouteDeals, Request: CreateDealRequest{}, Response: Deal{}, Envelope: true},
+	{Name: "UpdateDeal", Doc: "altera os campos enviados do negócio.", Method: http.MethodPatch, Path: RouteDeal, Request: UpdateDealRequest{}, Response: Deal{}, Envelope: true},
+	{Name: "MoveDeal", Doc: "move o negócio de etapa.", Method: http.MethodPost, Path: RouteDealMove, Request: UpdateDealStageRequest{}, Response: Deal{}, Envelope: true},
+	{Name: "ListDealParticipants", Doc: "lista os contatos participantes do negócio.", Method: http.MethodGet, Path: RouteDealParticipants, Response: DealParticipantListResponse{}},
+	{Name: "AddDealParticipant", Doc: "inclui um contato no negócio.", Method: http.MethodPost, Path: RouteDealParticipants, Request: AddDealParticipantRequest{}, Response: DealParticipant{}},
+	{Name: "ListDealSplits", Doc: "lista a divisão de crédito do negócio.", Method: http.MethodGet, Path: RouteDealSplits, Response: DealSplitListResponse{}},
+	{Name: "ReplaceDealSplits", Doc: "substitui a divisão de crédito do negócio.", Method: http.MethodPut, Path: RouteDealSplits, Request: ReplaceDealSplitsRequest{}, Response: DealSplitListResponse{}},
+
+	{Name: "ForecastReport", Doc: "totaliza os negócios por categoria de forecast (from, to, pipelineId, ownerId na query).", Method: http.MethodGet, Path: RouteForecastReport, Response: ForecastReport{}, Query: true},
+}
@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"testing"
+
+	"linkko-api/internal/http/docs"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoints_DocumentedInOpenAPI(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(docs.GetSpecBytes())
+	require.NoError(t, err)
+
+	names := map[string]bool{}
+	for _, ep := range Endpoints {
+		assert.False(t, names[ep.Name], "duplicate endpoint name %s", ep.Name)
+		names[ep.Name] = true
+
+		item := doc.Paths.Find(ep.Path)
+		if !assert.NotNil(t, item, "%s: path %s not in OpenAPI", ep.Name, ep.Path) {
+			continue
+		}
+		assert.NotNil(t, item.GetOperation(ep.Method), "%s: %s %s not in OpenAPI", ep.Name, ep.Method, ep.Path)
+	}
+}
+
+func TestRouteNames_MatchConstants(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "sdk.go", nil, 0)
+	require.NoError(t, err)
+
+	consts := map[string]string{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, "Route") {
+					continue
+				}
+				value, err := strconv.Unquote(vs.Values[i].(*ast.BasicLit).Value)
+				require.NoError(t, err)
+				consts[value] = name.Name
+			}
+		}
+	}
+	assert.Equal(t, consts, routeNames)
+}
+
+func TestGenerateGo_TypeChecks(t *testing.T) {
+	src, err := GenerateGo("linkko")
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", src, 0)
+	require.NoError(t, err)
+
+	// O client gerado só pode depender da stdlib
+	for _, imp := range file.Imports {
+		assert.NotContains(t, imp.Path.Value, "linkko-api", "generated client imports an internal package")
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("linkko", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+
+	client := pkg.Scope().Lookup("Client")
+	require.NotNil(t, client)
+	methods := types.NewMethodSet(types.NewPointer(client.Type()))
+	for _, ep := range Endpoints {
+		assert.NotNil(t, methods.Lookup(pkg, ep.Name), "generated client has no method %s", ep.Name)
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"time"
 
 	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/integrations/antivirus"
+	"linkko-api/internal/objectstore"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -15,21 +20,43 @@ type ActivityService struct {
 	activityRepo  *repo.ActivityRepository
 	workspaceRepo *repo.WorkspaceRepository
 	auditRepo     *repo.AuditRepo
+	attachments   objectstore.Store
+	followers     *FollowerService // Notificações de notas e menções
 	log           *logger.Logger
+
+	// maxAttachmentBytes limite de tamanho de um anexo de nota (NOTE_ATTACHMENT_MAX_BYTES)
+	maxAttachmentBytes int64
+
+	// scanner antivírus dos anexos enviados (nil: sem scan); scanFailOpen aceita o anexo
+	// sem scan quando o scanner está indisponível (ATTACHMENT_SCAN_FAIL_OPEN)
+	scanner      antivirus.Scanner
+	scanFailOpen bool
 }
 
-func NewActivityService(activityRepo *repo.ActivityRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *ActivityService {
+func NewActivityService(activityRepo *repo.ActivityRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, attachments objectstore.Store, followers *FollowerService, log *logger.Logger, maxAttachmentBytes int64) *ActivityService {
 	return &ActivityService{
-		activityRepo:  activityRepo,
-		workspaceRepo: workspaceRepo,
-		auditRepo:     auditRepo,
-		log:           log,
+		activityRepo:       activityRepo,
+		workspaceRepo:      workspaceRepo,
+		auditRepo:          auditRepo,
+		attachments:        attachments,
+		followers:          followers,
+		log:                log,
+		maxAttachmentBytes: maxAttachmentBytes,
 	}
 }
 
+// WithScanner passa os anexos enviados pelo antivírus antes de registrá-los (ver UploadNoteAttachment).
+func (s *ActivityService) WithScanner(scanner antivirus.Scanner, failOpen bool) *ActivityService {
+	s.scanner = scanner
+	s.scanFailOpen = failOpen
+	return s
+}
+
 // getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
 func (s *ActivityService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
 	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		s.log.Error(ctx, "failed to get member role",
 			logger.Module("activity"),
@@ -52,6 +79,9 @@ func (s *ActivityService) getMemberRoleWithLogging(ctx context.Context, actorID,
 }
 
 func (s *ActivityService) CreateNote(ctx context.Context, workspaceID, actorID string, req *domain.CreateNoteRequest) (*domain.Note, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ActivityService.CreateNote")
+	defer span.End()
+
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
 		return nil, err
@@ -60,14 +90,35 @@ func (s *ActivityService) CreateNote(ctx context.Context, workspaceID, actorID s
 		return nil, ErrUnauthorized
 	}
 
+	noteID, err := id.New(id.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	// A atividade da timeline é best-effort: o ID sai antes de criar o registro
+	activityID, err := id.New(id.Activity)
+	if err != nil {
+		return nil, err
+	}
+
 	note := &domain.Note{
-		ID:          generateDealID(), // reuse same cuid gen
+		ID:          noteID,
 		WorkspaceID: workspaceID,
 		CompanyID:   req.CompanyID,
 		ContactID:   req.ContactID,
 		DealID:      req.DealID,
 		Content:     req.Content,
+		Body:        req.Body,
 		UserID:      actorID,
+		Visibility:  domain.VisibilityWorkspace,
+	}
+	if req.Visibility != nil {
+		note.Visibility = *req.Visibility
+	}
+
+	// Anexos só existem depois da nota: referências inline entram via UpdateNote
+	if req.Body != nil && len(req.Body.AttachmentIDs()) > 0 {
+		return nil, ErrUnknownNoteAttachment
 	}
 
 	created, err := s.activityRepo.CreateNote(ctx, note)
@@ -77,7 +128,7 @@ func (s *ActivityService) CreateNote(ctx context.Context, workspaceID, actorID s
 
 	// Create Timeline Activity
 	activity := &domain.Activity{
-		ID:          generateDealID(),
+		ID:          activityID,
 		WorkspaceID: workspaceID,
 		CompanyID:   req.CompanyID,
 		ContactID:   req.ContactID,
@@ -93,10 +144,22 @@ func (s *ActivityService) CreateNote(ctx context.Context, workspaceID, actorID s
 		// Log error but don't fail note creation
 	}
 
+	// Nota privada não gera notificação: ninguém além do autor pode abri-la
+	if created.Visibility != domain.VisibilityPrivate {
+		var mentioned []string
+		if req.Body != nil {
+			mentioned = req.Body.MentionedUserIDs()
+		}
+		s.followers.NoteAdded(ctx, created, actorID, mentioned)
+	}
+
 	return created, nil
 }
 
 func (s *ActivityService) CreateCall(ctx context.Context, workspaceID, actorID string, req *domain.CreateCallRequest) (*domain.Call, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ActivityService.CreateCall")
+	defer span.End()
+
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
 		return nil, err
@@ -105,8 +168,19 @@ func (s *ActivityService) CreateCall(ctx context.Context, workspaceID, actorID s
 		return nil, ErrUnauthorized
 	}
 
+	callID, err := id.New(id.Call)
+	if err != nil {
+		return nil, err
+	}
+
+	// A atividade da timeline é best-effort: o ID sai antes de criar o registro
+	activityID, err := id.New(id.Activity)
+	if err != nil {
+		return nil, err
+	}
+
 	call := &domain.Call{
-		ID:           generateDealID(),
+		ID:           callID,
 		WorkspaceID:  workspaceID,
 		ContactID:    req.ContactID,
 		CompanyID:    req.CompanyID,
@@ -122,14 +196,30 @@ func (s *ActivityService) CreateCall(ctx context.Context, workspaceID, actorID s
 		call.CalledAt = time.Now()
 	}
 
-	created, err := s.activityRepo.CreateCall(ctx, call)
+	// Chamada e participantes na mesma transação: participante inválido não deixa chamada órfã
+	tx, err := s.activityRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repoTx := s.activityRepo.WithTx(tx)
+
+	created, err := repoTx.CreateCall(ctx, call)
 	if err != nil {
 		return nil, err
 	}
+	created.Participants, err = repoTx.AddParticipants(ctx, workspaceID, domain.ActivityTypeCall, created.ID,
+		domain.NormalizeActivityParticipants(req.Participants, actorID))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 
 	// Create Timeline Activity
 	activity := &domain.Activity{
-		ID:          generateDealID(),
+		ID:          activityID,
 		WorkspaceID: workspaceID,
 		CompanyID:   req.CompanyID,
 		ContactID:   &req.ContactID,
@@ -147,7 +237,92 @@ func (s *ActivityService) CreateCall(ctx context.Context, workspaceID, actorID s
 	return created, nil
 }
 
-func (s *ActivityService) ListTimeline(ctx context.Context, workspaceID, actorID string, contactID, companyID, dealID *string) ([]domain.Activity, error) {
+// CreateMeeting registers a meeting with its participants and adds it to the timeline.
+// Permission: same as calls (CanModifyContacts).
+func (s *ActivityService) CreateMeeting(ctx context.Context, workspaceID, actorID string, req *domain.CreateMeetingRequest) (*domain.Meeting, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ActivityService.CreateMeeting")
+	defer span.End()
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	meetingID, err := id.New(id.Meeting)
+	if err != nil {
+		return nil, err
+	}
+
+	// A atividade da timeline é best-effort: o ID sai antes de criar o registro
+	activityID, err := id.New(id.Activity)
+	if err != nil {
+		return nil, err
+	}
+
+	meeting := &domain.Meeting{
+		ID:          meetingID,
+		WorkspaceID: workspaceID,
+		Title:       req.Title,
+		Description: req.Description,
+		MeetingType: req.MeetingType,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Location:    req.Location,
+		MeetingURL:  req.MeetingURL,
+		UserID:      actorID,
+	}
+
+	tx, err := s.activityRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repoTx := s.activityRepo.WithTx(tx)
+
+	created, err := repoTx.CreateMeeting(ctx, meeting)
+	if err != nil {
+		return nil, err
+	}
+	created.Participants, err = repoTx.AddParticipants(ctx, workspaceID, domain.ActivityTypeMeeting, created.ID,
+		domain.NormalizeActivityParticipants(req.Participants, actorID))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	activity := &domain.Activity{
+		ID:          activityID,
+		WorkspaceID: workspaceID,
+		CompanyID:   req.CompanyID,
+		ContactID:   req.ContactID,
+		DealID:      req.DealID,
+		Type:        domain.ActivityTypeMeeting,
+		ActivityID:  &created.ID,
+		UserID:      actorID,
+		CreatedAt:   time.Now(),
+	}
+	if _, err := s.activityRepo.CreateActivity(ctx, activity); err != nil {
+		s.log.Warn(ctx, "failed to create meeting timeline activity",
+			zap.String("workspace_id", workspaceID),
+			zap.String("meeting_id", created.ID),
+			zap.Error(err),
+		)
+	}
+
+	return created, nil
+}
+
+// ListTimeline returns the timeline with participants attached to calls and meetings.
+// participantID narrows to calls/meetings the user or contact took part in.
+func (s *ActivityService) ListTimeline(ctx context.Context, workspaceID, actorID string, contactID, companyID, dealID, participantID *string, activityType *domain.ActivityType) ([]domain.Activity, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ActivityService.ListTimeline")
+	defer span.End()
+
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
 		return nil, err
@@ -156,5 +331,100 @@ func (s *ActivityService) ListTimeline(ctx context.Context, workspaceID, actorID
 		return nil, ErrUnauthorized
 	}
 
-	return s.activityRepo.List(ctx, workspaceID, contactID, companyID, dealID)
+	activities, err := s.activityRepo.List(ctx, workspaceID, contactID, companyID, dealID, participantID, activityType, domain.VisibilityViewer(actorID, role))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachParticipants(ctx, workspaceID, activities); err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// timelineStreamBatch atividades acumuladas por consulta de participantes no streaming.
+const timelineStreamBatch = 500
+
+// StreamTimeline is ListTimeline for very large timelines: rows are read one by one from
+// the database and handed to emit in timeline order, with participants loaded per batch,
+// so memory stays bounded regardless of the timeline size. An emit error stops the stream
+// and is returned as is.
+func (s *ActivityService) StreamTimeline(ctx context.Context, workspaceID, actorID string, contactID, companyID, dealID, participantID *string, activityType *domain.ActivityType, emit func(*domain.Activity) error) error {
+	ctx, span := telemetry.StartSpan(ctx, "ActivityService.StreamTimeline")
+	defer span.End()
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return ErrUnauthorized
+	}
+
+	batch := make([]domain.Activity, 0, timelineStreamBatch)
+	flush := func() error {
+		if err := s.attachParticipants(ctx, workspaceID, batch); err != nil {
+			return err
+		}
+		for i := range batch {
+			if err := emit(&batch[i]); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	streamed := 0
+	err = s.activityRepo.Each(ctx, workspaceID, contactID, companyID, dealID, participantID, activityType, domain.VisibilityViewer(actorID, role), func(a *domain.Activity) error {
+		batch = append(batch, *a)
+		streamed++
+		if len(batch) < timelineStreamBatch {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	span.SetAttributes(attribute.Int("timeline.streamed", streamed))
+	return err
+}
+
+// attachParticipants preenche Participants das chamadas e reuniões.
+func (s *ActivityService) attachParticipants(ctx context.Context, workspaceID string, activities []domain.Activity) error {
+	var ids []string
+	for _, a := range activities {
+		if a.ActivityID != nil && (a.Type == domain.ActivityTypeCall || a.Type == domain.ActivityTypeMeeting) {
+			ids = append(ids, *a.ActivityID)
+		}
+	}
+	participants, err := s.activityRepo.ListParticipantsForActivities(ctx, workspaceID, ids)
+	if err != nil {
+		return err
+	}
+	for i := range activities {
+		if activities[i].ActivityID != nil {
+			activities[i].Participants = participants[*activities[i].ActivityID]
+		}
+	}
+	return nil
+}
+
+// TimelineDigest returns per-type counts and the latest activities of each type
+// created after params.Since (mobile pull-to-refresh).
+// Permission: all workspace members.
+func (s *ActivityService) TimelineDigest(ctx context.Context, workspaceID, actorID string, params domain.TimelineDigestParams) (*domain.TimelineDigest, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	params.Viewer = domain.VisibilityViewer(actorID, role)
+	params.Normalize()
+
+	return s.activityRepo.Digest(ctx, params)
 }
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/integrations/stripe"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// BillingQuotaCacheTTL quanto tempo cada instância reaproveita as quotas resolvidas de um workspace.
+// Mudanças de plano vindas de webhooks processados em outra instância valem após esse prazo.
+const BillingQuotaCacheTTL = time.Minute
+
+var (
+	// ErrInvalidStripeSignature assinatura ausente, inválida ou fora da tolerância
+	ErrInvalidStripeSignature = apperr.Define("INVALID_SIGNATURE", http.StatusBadRequest, "stripe webhook signature verification failed", "invalid webhook signature")
+
+	// Limites de registros do plano atingidos (ver domain.PlanQuotas)
+	ErrContactQuotaExceeded = apperr.Define("QUOTA_EXCEEDED", http.StatusPaymentRequired, "contact quota of the workspace plan reached", "contact limit of the current plan reached")
+	ErrDealQuotaExceeded    = apperr.Define("QUOTA_EXCEEDED", http.StatusPaymentRequired, "deal quota of the workspace plan reached", "deal limit of the current plan reached")
+	ErrMemberQuotaExceeded  = apperr.Define("QUOTA_EXCEEDED", http.StatusPaymentRequired, "member quota of the workspace plan reached", "member limit of the current plan reached")
+)
+
+type cachedQuota struct {
+	quota     domain.PlanQuota
+	expiresAt time.Time
+}
+
+// BillingService mantém o estado da assinatura dos workspaces a partir dos webhooks do Stripe
+// e resolve as quotas do plano para o QuotaMiddleware.
+type BillingService struct {
+	billingRepo   *repo.BillingRepository
+	workspaceRepo *repo.WorkspaceRepository
+	webhookSecret string
+	pricePlans    map[string]domain.BillingPlan
+	defaultRPM    atomic.Int64 // RATE_LIMIT_PER_WORKSPACE_PER_MIN, recarregável
+	log           *logger.Logger
+
+	mu     sync.Mutex
+	quotas map[string]cachedQuota
+}
+
+// NewBillingService cria o service. webhookSecret (STRIPE_WEBHOOK_SECRET) valida os webhooks;
+// pricePlans (STRIPE_PRICE_PLANS) mapeia o price da assinatura para o plano; defaultRPM é o
+// rate limit global usado por planos sem limite próprio.
+func NewBillingService(billingRepo *repo.BillingRepository, workspaceRepo *repo.WorkspaceRepository, webhookSecret string, pricePlans map[string]domain.BillingPlan, defaultRPM int, log *logger.Logger) *BillingService {
+	s := &BillingService{
+		billingRepo:   billingRepo,
+		workspaceRepo: workspaceRepo,
+		webhookSecret: webhookSecret,
+		pricePlans:    pricePlans,
+		log:           log,
+		quotas:        make(map[string]cachedQuota),
+	}
+	s.defaultRPM.Store(int64(defaultRPM))
+	return s
+}
+
+// SetDefaultRPM aplica um novo RATE_LIMIT_PER_WORKSPACE_PER_MIN (reload de configuração)
+func (s *BillingService) SetDefaultRPM(defaultRPM int) {
+	s.defaultRPM.Store(int64(defaultRPM))
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *BillingService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("billing"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// GetBilling returns the current plan, subscription state, quotas and usage of the workspace.
+// Permission: all workspace members.
+func (s *BillingService) GetBilling(ctx context.Context, workspaceID, actorID string) (*domain.WorkspaceBilling, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	sub, err := s.billingRepo.GetSubscription(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.billingRepo.Usage(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := sub.Quota()
+	if quota.RequestsPerMinute == 0 {
+		quota.RequestsPerMinute = int(s.defaultRPM.Load())
+	}
+	return &domain.WorkspaceBilling{
+		WorkspaceID:       workspaceID,
+		Plan:              sub.Plan,
+		EffectivePlan:     sub.EffectivePlan(),
+		Status:            sub.Status,
+		CurrentPeriodEnd:  sub.CurrentPeriodEnd,
+		CancelAtPeriodEnd: sub.CancelAtPeriodEnd,
+		Quota:             quota,
+		Usage:             *usage,
+	}, nil
+}
+
+// Quota resolve as quotas do plano efetivo do workspace (cache local de BillingQuotaCacheTTL).
+func (s *BillingService) Quota(ctx context.Context, workspaceID string) (domain.PlanQuota, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.quotas[workspaceID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.quota, nil
+	}
+
+	sub, err := s.billingRepo.GetSubscription(ctx, workspaceID)
+	if err != nil {
+		return domain.PlanQuota{}, err
+	}
+	quota := sub.Quota()
+
+	s.mu.Lock()
+	s.quotas[workspaceID] = cachedQuota{quota: quota, expiresAt: now.Add(BillingQuotaCacheTTL)}
+	s.mu.Unlock()
+	return quota, nil
+}
+
+// CheckQuota recusa a criação de um registro quando o workspace já atingiu o limite do plano.
+func (s *BillingService) CheckQuota(ctx context.Context, workspaceID string, quota domain.PlanQuota, resource domain.QuotaResource) error {
+	limit := quota.Limit(resource)
+	if limit <= 0 {
+		return nil
+	}
+
+	count, err := s.billingRepo.Count(ctx, workspaceID, resource)
+	if err != nil {
+		return err
+	}
+	if count < limit {
+		return nil
+	}
+
+	switch resource {
+	case domain.QuotaContacts:
+		return ErrContactQuotaExceeded
+	case domain.QuotaDeals:
+		return ErrDealQuotaExceeded
+	default:
+		return ErrMemberQuotaExceeded
+	}
+}
+
+// HandleStripeWebhook valida a assinatura e aplica o evento ao workspace.
+// Eventos de tipos não tratados, repetidos ou de workspaces desconhecidos são aceitos sem efeito
+// (o Stripe só reenvia em respostas != 2xx).
+func (s *BillingService) HandleStripeWebhook(ctx context.Context, payload []byte, signature string) error {
+	if s.webhookSecret == "" {
+		return ErrInvalidStripeSignature
+	}
+
+	event, err := stripe.ConstructEvent(payload, signature, s.webhookSecret, stripe.DefaultTolerance, time.Now())
+	if err != nil {
+		s.log.Warn(ctx, "stripe webhook rejected",
+			logger.Module("billing"),
+			logger.Action("webhook"),
+			zap.Error(err),
+		)
+		return ErrInvalidStripeSignature
+	}
+
+	var workspaceID string
+	var applied bool
+	switch event.Type {
+	case stripe.EventCheckoutSessionCompleted:
+		workspaceID, applied, err = s.applyCheckout(ctx, event)
+	case stripe.EventSubscriptionCreated, stripe.EventSubscriptionUpdated, stripe.EventSubscriptionDeleted:
+		workspaceID, applied, err = s.applySubscription(ctx, event)
+	default:
+		return nil
+	}
+	if errors.Is(err, repo.ErrBillingWorkspaceNotFound) {
+		workspaceID, err = "", nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info(ctx, "stripe webhook processed",
+		logger.Module("billing"),
+		logger.Action("webhook"),
+		zap.String("event_id", event.ID),
+		zap.String("event_type", event.Type),
+		zap.String("workspace_id", workspaceID),
+		zap.Bool("applied", applied),
+	)
+	if applied {
+		s.invalidate(workspaceID)
+	}
+	return nil
+}
+
+// applyCheckout associa o customer criado no checkout ao workspace (client_reference_id).
+func (s *BillingService) applyCheckout(ctx context.Context, event *stripe.Event) (string, bool, error) {
+	var session stripe.CheckoutSession
+	if err := json.Unmarshal(event.Data.Object, &session); err != nil {
+		return "", false, fmt.Errorf("decode checkout session: %w", err)
+	}
+	workspaceID := session.WorkspaceID()
+	if workspaceID == "" || session.Customer == "" {
+		return "", false, nil
+	}
+
+	var subscriptionID *string
+	if session.Subscription != "" {
+		subscriptionID = &session.Subscription
+	}
+	applied, err := s.billingRepo.LinkCustomer(ctx, event.ID, event.Type, event.CreatedAt(), workspaceID, session.Customer, subscriptionID)
+	return workspaceID, applied, err
+}
+
+// applySubscription grava plano e status. O workspace vem de metadata.workspaceId ou do customer.
+func (s *BillingService) applySubscription(ctx context.Context, event *stripe.Event) (string, bool, error) {
+	var subscription stripe.Subscription
+	if err := json.Unmarshal(event.Data.Object, &subscription); err != nil {
+		return "", false, fmt.Errorf("decode subscription: %w", err)
+	}
+
+	workspaceID := subscription.Metadata[stripe.MetadataWorkspaceID]
+	if workspaceID == "" && subscription.Customer != "" {
+		id, err := s.billingRepo.WorkspaceByCustomer(ctx, subscription.Customer)
+		if err != nil {
+			return "", false, err
+		}
+		workspaceID = id
+	}
+	if workspaceID == "" {
+		return "", false, nil
+	}
+
+	sub := &domain.WorkspaceSubscription{
+		WorkspaceID:       workspaceID,
+		Status:            domain.SubscriptionStatusFromStripe(subscription.Status),
+		CurrentPeriodEnd:  subscription.PeriodEnd(),
+		CancelAtPeriodEnd: subscription.CancelAtPeriodEnd,
+	}
+	if subscription.Customer != "" {
+		sub.StripeCustomerID = &subscription.Customer
+	}
+
+	if event.Type == stripe.EventSubscriptionDeleted {
+		// Assinatura encerrada: volta ao FREE e desvincula a assinatura (o customer fica)
+		sub.Plan = domain.PlanFree
+		sub.Status = domain.SubscriptionCanceled
+		sub.CancelAtPeriodEnd = false
+	} else {
+		sub.StripeSubscriptionID = &subscription.ID
+		if priceID := subscription.PriceID(); priceID != "" {
+			sub.StripePriceID = &priceID
+			plan, ok := s.pricePlans[priceID]
+			if !ok {
+				s.log.Warn(ctx, "stripe price without plan mapping, keeping current plan",
+					logger.Module("billing"),
+					logger.Action("webhook"),
+					zap.String("workspace_id", workspaceID),
+					zap.String("price_id", priceID),
+				)
+			}
+			sub.Plan = plan
+		}
+	}
+
+	applied, err := s.billingRepo.ApplySubscription(ctx, event.ID, event.Type, event.CreatedAt(), sub)
+	return workspaceID, applied, err
+}
+
+func (s *BillingService) invalidate(workspaceID string) {
+	s.mu.Lock()
+	delete(s.quotas, workspaceID)
+	s.mu.Unlock()
+}
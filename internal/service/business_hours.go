@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrBusinessHoursNotFound = repo.ErrBusinessHoursNotFound
+	ErrHolidayNotFound       = repo.ErrHolidayNotFound
+	ErrHolidayDateConflict   = repo.ErrHolidayDateConflict
+)
+
+// BusinessHoursService gerencia o calendário comercial do workspace (expediente e feriados).
+type BusinessHoursService struct {
+	businessHoursRepo *repo.BusinessHoursRepository
+	workspaceRepo     *repo.WorkspaceRepository
+	auditRepo         *repo.AuditRepo
+	log               *logger.Logger
+}
+
+func NewBusinessHoursService(businessHoursRepo *repo.BusinessHoursRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *BusinessHoursService {
+	return &BusinessHoursService{
+		businessHoursRepo: businessHoursRepo,
+		workspaceRepo:     workspaceRepo,
+		auditRepo:         auditRepo,
+		log:               log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *BusinessHoursService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("business_hours"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize verifica a associação ao workspace e, para escrita, o papel de admin.
+func (s *BusinessHoursService) authorize(ctx context.Context, workspaceID, actorID string, write bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.IsWorkspaceMember(role) || (write && !domain.CanManageWorkspace(role)) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// GetBusinessHours returns the workspace business hours.
+// Permission: all workspace members.
+func (s *BusinessHoursService) GetBusinessHours(ctx context.Context, workspaceID, actorID string) (*domain.BusinessHours, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, false); err != nil {
+		return nil, err
+	}
+	return s.businessHoursRepo.Get(ctx, workspaceID)
+}
+
+// SetBusinessHours replaces the workspace business hours.
+// Permission: admin only (workspace setting).
+func (s *BusinessHoursService) SetBusinessHours(ctx context.Context, workspaceID, actorID string, req *domain.SetBusinessHoursRequest) (*domain.BusinessHours, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, true); err != nil {
+		return nil, err
+	}
+
+	hours, err := s.businessHoursRepo.Upsert(ctx, workspaceID, req, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logCalendarAction(ctx, workspaceID, actorID, "update", "business_hours", workspaceID)
+
+	return hours, nil
+}
+
+// DeleteBusinessHours removes the business hours so timers run 24x7 again.
+// Permission: admin only.
+func (s *BusinessHoursService) DeleteBusinessHours(ctx context.Context, workspaceID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, true); err != nil {
+		return err
+	}
+
+	if err := s.businessHoursRepo.Delete(ctx, workspaceID); err != nil {
+		return err
+	}
+
+	s.logCalendarAction(ctx, workspaceID, actorID, "delete", "business_hours", workspaceID)
+
+	return nil
+}
+
+// ListHolidays lists the workspace holidays ordered by date.
+// Permission: all workspace members.
+func (s *BusinessHoursService) ListHolidays(ctx context.Context, workspaceID, actorID string, params domain.ListHolidaysParams) ([]domain.Holiday, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, false); err != nil {
+		return nil, err
+	}
+
+	params.WorkspaceID = workspaceID
+	return s.businessHoursRepo.ListHolidays(ctx, params)
+}
+
+// CreateHoliday adds a holiday to the workspace calendar.
+// Permission: admin only.
+func (s *BusinessHoursService) CreateHoliday(ctx context.Context, workspaceID, actorID string, req *domain.CreateHolidayRequest) (*domain.Holiday, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, true); err != nil {
+		return nil, err
+	}
+
+	holidayID, err := id.New(id.Holiday)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.businessHoursRepo.CreateHoliday(ctx, &domain.Holiday{
+		ID:          holidayID,
+		WorkspaceID: workspaceID,
+		Date:        req.Date,
+		Name:        req.Name,
+		CreatedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logCalendarAction(ctx, workspaceID, actorID, "create", "holiday", created.ID)
+
+	return created, nil
+}
+
+// UpdateHoliday updates a holiday.
+// Permission: admin only.
+func (s *BusinessHoursService) UpdateHoliday(ctx context.Context, workspaceID, holidayID, actorID string, req *domain.UpdateHolidayRequest) (*domain.Holiday, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, true); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.businessHoursRepo.UpdateHoliday(ctx, workspaceID, holidayID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logCalendarAction(ctx, workspaceID, actorID, "update", "holiday", holidayID)
+
+	return updated, nil
+}
+
+// DeleteHoliday removes a holiday.
+// Permission: admin only.
+func (s *BusinessHoursService) DeleteHoliday(ctx context.Context, workspaceID, holidayID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, true); err != nil {
+		return err
+	}
+
+	if err := s.businessHoursRepo.DeleteHoliday(ctx, workspaceID, holidayID); err != nil {
+		return err
+	}
+
+	s.logCalendarAction(ctx, workspaceID, actorID, "delete", "holiday", holidayID)
+
+	return nil
+}
+
+func (s *BusinessHoursService) logCalendarAction(ctx context.Context, workspaceID, actorID, action, resource, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, resource, &idStr, nil, "", "")
+}
+
+// workspaceLocation fuso do workspace para filtros e agrupamentos por data (datas YYYY-MM-DD, meses
+// dos relatórios): o fuso do expediente (/business-hours) ou UTC quando não configurado.
+func workspaceLocation(ctx context.Context, businessHours *repo.BusinessHoursRepository, workspaceID string) (*time.Location, error) {
+	if businessHours == nil {
+		return time.UTC, nil
+	}
+	hours, err := businessHours.Get(ctx, workspaceID)
+	if errors.Is(err, repo.ErrBusinessHoursNotFound) {
+		return time.UTC, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load workspace timezone: %w", err)
+	}
+	loc, err := time.LoadLocation(hours.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load workspace timezone %q: %w", hours.Timezone, err)
+	}
+	return loc, nil
+}
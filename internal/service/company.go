@@ -5,37 +5,59 @@ import (
 	"errors"
 	"fmt"
 
+	"linkko-api/internal/apperr"
 	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 var (
 	ErrCompanyNotFound       = repo.ErrCompanyNotFound
 	ErrCompanyDomainConflict = repo.ErrCompanyDomainConflict
+	ErrMergeIntoSelf         = apperr.Unprocessable(apperr.CodeValidationError, "a company cannot be merged into itself", "")
 )
 
 type CompanyService struct {
 	companyRepo   *repo.CompanyRepository
 	auditRepo     *repo.AuditRepo
 	workspaceRepo *repo.WorkspaceRepository
+	userRepo      *repo.UserRepository // Validação do dono (ownerId)
+	history       *FieldHistoryService
+	undo          *UndoService
+	computed      *ComputedFieldService // Campos calculados avaliados na leitura
+	sync          *SyncPolicyService    // Política de conflito dos upserts com ?system=
 	log           *logger.Logger
 }
 
-func NewCompanyService(companyRepo *repo.CompanyRepository, auditRepo *repo.AuditRepo, workspaceRepo *repo.WorkspaceRepository, log *logger.Logger) *CompanyService {
+func NewCompanyService(companyRepo *repo.CompanyRepository, auditRepo *repo.AuditRepo, workspaceRepo *repo.WorkspaceRepository, userRepo *repo.UserRepository, history *FieldHistoryService, undo *UndoService, computed *ComputedFieldService, log *logger.Logger) *CompanyService {
 	return &CompanyService{
 		companyRepo:   companyRepo,
 		auditRepo:     auditRepo,
 		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		history:       history,
+		undo:          undo,
+		computed:      computed,
 		log:           log,
 	}
 }
 
+// WithSyncPolicies aplica as políticas de conflito das integrações aos upserts com system.
+func (s *CompanyService) WithSyncPolicies(sync *SyncPolicyService) *CompanyService {
+	s.sync = sync
+	return s
+}
+
 // getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
 func (s *CompanyService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
 	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		s.log.Error(ctx, "failed to get member role",
 			logger.Module("company"),
@@ -64,6 +86,9 @@ func (s *CompanyService) getMemberRoleWithLogging(ctx context.Context, actorID,
 // Permission: all workspace members can list companies.
 // Role is fetched from database to enforce real-time authorization.
 func (s *CompanyService) ListCompanies(ctx context.Context, workspaceID, actorID string, params domain.ListCompaniesParams) (*domain.CompanyListResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CompanyService.ListCompanies")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -82,6 +107,12 @@ func (s *CompanyService) ListCompanies(ctx context.Context, workspaceID, actorID
 		return nil, fmt.Errorf("list companies: %w", err)
 	}
 
+	ptrs := make([]*domain.Company, len(companies))
+	for i := range companies {
+		ptrs[i] = &companies[i]
+	}
+	s.attachComputed(ctx, workspaceID, ptrs)
+
 	response := &domain.CompanyListResponse{
 		Data: companies,
 	}
@@ -96,6 +127,9 @@ func (s *CompanyService) ListCompanies(ctx context.Context, workspaceID, actorID
 // Permission: all workspace members can view companies.
 // Role is fetched from database to enforce real-time authorization.
 func (s *CompanyService) GetCompany(ctx context.Context, workspaceID, companyID, actorID string) (*domain.Company, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CompanyService.GetCompany")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -112,13 +146,41 @@ func (s *CompanyService) GetCompany(ctx context.Context, workspaceID, companyID,
 		return nil, fmt.Errorf("get company: %w", err)
 	}
 
+	s.attachComputed(ctx, workspaceID, []*domain.Company{company})
 	return company, nil
 }
 
+// attachComputed preenche os campos calculados do workspace.
+// Falha na avaliação não impede a leitura: o campo computed é apenas omitido.
+func (s *CompanyService) attachComputed(ctx context.Context, workspaceID string, companies []*domain.Company) {
+	if s.computed == nil || len(companies) == 0 {
+		return
+	}
+
+	ids := make([]string, len(companies))
+	for i, c := range companies {
+		ids[i] = c.ID
+	}
+
+	values, err := s.computed.Evaluate(ctx, workspaceID, domain.ComputedFieldCompany, ids)
+	if err != nil {
+		s.log.Warn(ctx, "failed to evaluate computed fields", logger.Module("company"), zap.String("workspace_id", workspaceID), zap.Error(err))
+		return
+	}
+	for _, c := range companies {
+		if v, ok := values[c.ID]; ok {
+			c.Computed = v
+		}
+	}
+}
+
 // CreateCompany creates a new company with RBAC and business validation.
 // Permission: admin, manager, user can create companies. Viewer cannot.
 // Role is fetched from database to enforce real-time authorization.
 func (s *CompanyService) CreateCompany(ctx context.Context, workspaceID, actorID string, req *domain.CreateCompanyRequest) (*domain.Company, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CompanyService.CreateCompany")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -130,16 +192,32 @@ func (s *CompanyService) CreateCompany(ctx context.Context, workspaceID, actorID
 		return nil, ErrUnauthorized
 	}
 
+	// Business validation: if owner_id provided, validate it belongs to workspace
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.OwnerID); err != nil {
+		return nil, err
+	}
+
+	companyID, err := id.New(id.Company)
+	if err != nil {
+		return nil, err
+	}
+
 	company := &domain.Company{
-		ID:             generateID(),
+		ID:             companyID,
 		WorkspaceID:    workspaceID,
 		Name:           req.Name,
-		LifecycleStage: *req.LifecycleStage,
-		Size:           *req.CompanySize,
-		OwnerID:        actorID, // Default: creator is owner
+		LifecycleStage: domain.LifecycleLead, // Default do schema; size fica NULL
+		OwnerID:        actorID,              // Default: creator is owner
+		ExternalID:     req.ExternalID,
 	}
 
 	// Optional fields
+	if req.LifecycleStage != nil {
+		company.LifecycleStage = *req.LifecycleStage
+	}
+	if req.CompanySize != nil {
+		company.Size = *req.CompanySize
+	}
 	if req.Domain != nil {
 		company.Domain = req.Domain
 	}
@@ -204,6 +282,9 @@ func (s *CompanyService) CreateCompany(ctx context.Context, workspaceID, actorID
 // Permission: admin, manager, user can update. Viewer cannot.
 // Role is fetched from database to enforce real-time authorization.
 func (s *CompanyService) UpdateCompany(ctx context.Context, workspaceID, companyID, actorID string, req *domain.UpdateCompanyRequest) (*domain.Company, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CompanyService.UpdateCompany")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -215,8 +296,13 @@ func (s *CompanyService) UpdateCompany(ctx context.Context, workspaceID, company
 		return nil, ErrUnauthorized
 	}
 
-	// Verify company exists before update
-	_, err = s.companyRepo.Get(ctx, workspaceID, companyID)
+	// Business validation: if owner_id provided, validate it belongs to workspace
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.OwnerID); err != nil {
+		return nil, err
+	}
+
+	// Verify company exists before update (estado anterior para o histórico)
+	current, err := s.companyRepo.Get(ctx, workspaceID, companyID)
 	if err != nil {
 		return nil, fmt.Errorf("get company: %w", err)
 	}
@@ -232,6 +318,8 @@ func (s *CompanyService) UpdateCompany(ctx context.Context, workspaceID, company
 		return nil, fmt.Errorf("get updated company: %w", err)
 	}
 
+	s.history.Record(ctx, workspaceID, actorID, domain.HistoryEntityCompany, companyID, "update", current, company)
+
 	// Audit: log company update
 	companyIDStr := companyID
 	auditErr := s.auditRepo.LogAction(
@@ -252,24 +340,94 @@ func (s *CompanyService) UpdateCompany(ctx context.Context, workspaceID, company
 	return company, nil
 }
 
+// UpsertCompanyByExternalID cria ou atualiza a empresa identificada pela chave do integrador
+// (PUT .../companies/by-external-id/{externalId}); created indica se a empresa foi criada.
+// Corridas com o mesmo externalId são resolvidas como em ContactService.UpsertContactByExternalID.
+// Com system (a integração de origem), a política de conflito dela decide os campos gravados
+// por outros sistemas (SyncPolicyService).
+// Permission: as de CreateCompany e UpdateCompany.
+func (s *CompanyService) UpsertCompanyByExternalID(ctx context.Context, workspaceID, externalID, actorID, system string, req *domain.CreateCompanyRequest) (*domain.Company, bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CompanyService.UpsertCompanyByExternalID")
+	defer span.End()
+
+	req.ExternalID = &externalID
+
+	for attempt := 0; attempt < upsertMaxAttempts; attempt++ {
+		companyID, err := s.companyRepo.FindIDByExternalID(ctx, workspaceID, externalID)
+		if err != nil {
+			return nil, false, fmt.Errorf("find company by external id: %w", err)
+		}
+
+		if companyID == nil {
+			plan, err := s.sync.planUpsert(ctx, workspaceID, system, domain.IdentityEntityCompany, "", nil, req.UpsertUpdate())
+			if err != nil {
+				return nil, false, err
+			}
+			company, err := s.CreateCompany(ctx, workspaceID, actorID, req)
+			if errors.Is(err, ErrExternalIDConflict) {
+				continue // criada por outra request entre a busca e o insert
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			s.sync.recordUpsert(ctx, plan, company.ID)
+			return company, true, nil
+		}
+
+		update := req.UpsertUpdate()
+		var plan *syncPlan
+		if system != "" {
+			current, err := s.companyRepo.Get(ctx, workspaceID, *companyID)
+			if errors.Is(err, ErrCompanyNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			if plan, err = s.sync.planUpsert(ctx, workspaceID, system, domain.IdentityEntityCompany, *companyID, current, update); err != nil {
+				return nil, false, err
+			}
+			if err := domain.OmitJSONFields(update, plan.rejectedFields()); err != nil {
+				return nil, false, err
+			}
+		}
+
+		company, err := s.UpdateCompany(ctx, workspaceID, *companyID, actorID, update)
+		if errors.Is(err, ErrCompanyNotFound) {
+			continue // removida ou alterada (optimistic locking) entre a busca e o update
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		s.sync.recordUpsert(ctx, plan, company.ID)
+		return company, false, nil
+	}
+
+	return nil, false, ErrUpsertContention
+}
+
 // DeleteCompany soft deletes a company with RBAC validation.
 // Permission: only admin and manager can delete companies.
+// Returns an undo receipt (nil if it could not be issued) to restore the company within the undo window.
 // Role is fetched from database to enforce real-time authorization.
-func (s *CompanyService) DeleteCompany(ctx context.Context, workspaceID, companyID, actorID string) error {
+func (s *CompanyService) DeleteCompany(ctx context.Context, workspaceID, companyID, actorID string) (*domain.UndoReceipt, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CompanyService.DeleteCompany")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// RBAC: only admin and manager can delete
 	if !domain.CanDeleteContacts(role) { // Reusing permission for companies
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
 
-	err = s.companyRepo.SoftDelete(ctx, workspaceID, companyID)
+	err = s.companyRepo.SoftDelete(ctx, workspaceID, companyID, actorID)
 	if err != nil {
-		return fmt.Errorf("delete company: %w", err)
+		return nil, fmt.Errorf("delete company: %w", err)
 	}
 
 	// Audit: log company deletion
@@ -289,5 +447,87 @@ func (s *CompanyService) DeleteCompany(ctx context.Context, workspaceID, company
 		// Log audit failure but don't fail the operation
 	}
 
-	return nil
+	return s.undo.Issue(ctx, workspaceID, actorID, domain.UndoEntityCompany, companyID), nil
+}
+
+// FindDuplicates lista pares de empresas possivelmente duplicadas (mesmo domínio
+// normalizado ou nomes semelhantes).
+// Permission: all workspace members.
+func (s *CompanyService) FindDuplicates(ctx context.Context, workspaceID, actorID string, params domain.CompanyDuplicatesParams) (*domain.CompanyDuplicatesResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.Normalize()
+	candidates, err := s.companyRepo.ListDuplicateCandidates(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CompanyDuplicatesResponse{
+		Data: domain.FindCompanyDuplicates(candidates, params.Threshold, params.Limit),
+	}, nil
+}
+
+// MergeCompanies absorve as empresas de origem na empresa sobrevivente: contatos, negócios,
+// timeline e tags passam para o sobrevivente e as origens são removidas (soft delete).
+// Permission: only admin and manager (remove empresas, como o DELETE).
+func (s *CompanyService) MergeCompanies(ctx context.Context, workspaceID, companyID, actorID string, req *domain.MergeCompaniesRequest) (*domain.MergeCompaniesResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CompanyService.MergeCompanies")
+	defer span.End()
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanDeleteContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	for _, id := range req.SourceCompanyIDs {
+		if id == companyID {
+			return nil, ErrMergeIntoSelf
+		}
+	}
+
+	before, err := s.companyRepo.Get(ctx, workspaceID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	reassigned, err := s.companyRepo.Merge(ctx, workspaceID, companyID, req.SourceCompanyIDs, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := s.companyRepo.Get(ctx, workspaceID, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("get merged company: %w", err)
+	}
+
+	s.history.Record(ctx, workspaceID, actorID, domain.HistoryEntityCompany, companyID, "merge", before, company)
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "merge", "company", &companyID, nil, "", "")
+	for _, id := range req.SourceCompanyIDs {
+		sourceID := id
+		_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "delete", "company", &sourceID, nil, "", "")
+	}
+
+	s.log.Info(ctx, "companies merged",
+		logger.Module("company"),
+		logger.Action("merge"),
+		zap.String("workspace_id", workspaceID),
+		zap.String("company_id", companyID),
+		zap.Strings("merged_company_ids", req.SourceCompanyIDs),
+	)
+
+	return &domain.MergeCompaniesResult{
+		Company:          company,
+		MergedCompanyIDs: req.SourceCompanyIDs,
+		Reassigned:       reassigned,
+	}, nil
 }
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/integrations/enrichment"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// EnrichmentStaleAfter tempo após o qual um job RUNNING é considerado abandonado
+// (worker caiu no meio) e volta a ser processado.
+const EnrichmentStaleAfter = 10 * time.Minute
+
+var (
+	ErrEnrichmentJobNotFound = repo.ErrEnrichmentJobNotFound
+	ErrEnrichmentJobActive   = repo.ErrEnrichmentJobActive
+	ErrCompanyDomainRequired = apperr.Unprocessable(apperr.CodeValidationError, "company needs a domain to be enriched", "")
+)
+
+// CompanyEnrichmentService enfileira e executa o enriquecimento de empresas via provedor
+// plugável. Os jobs são assíncronos; o enrichment-worker os executa via ProcessNext.
+type CompanyEnrichmentService struct {
+	jobRepo       *repo.CompanyEnrichmentRepository
+	companyRepo   *repo.CompanyRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	history       *FieldHistoryService
+	provider      enrichment.Provider
+	log           *logger.Logger
+}
+
+func NewCompanyEnrichmentService(jobRepo *repo.CompanyEnrichmentRepository, companyRepo *repo.CompanyRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, history *FieldHistoryService, provider enrichment.Provider, log *logger.Logger) *CompanyEnrichmentService {
+	return &CompanyEnrichmentService{
+		jobRepo:       jobRepo,
+		companyRepo:   companyRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		history:       history,
+		provider:      provider,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *CompanyEnrichmentService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("company_enrichment"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// EnrichCompany enfileira o enriquecimento da empresa e retorna o job PENDING.
+// Permission: admin, manager, agent (mesma regra de edição de empresas).
+func (s *CompanyEnrichmentService) EnrichCompany(ctx context.Context, workspaceID, companyID, actorID string, req *domain.EnrichCompanyRequest) (*domain.CompanyEnrichmentJob, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	company, err := s.companyRepo.Get(ctx, workspaceID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if company.Domain == nil || strings.TrimSpace(*company.Domain) == "" {
+		return nil, ErrCompanyDomainRequired
+	}
+
+	enrichmentJobID, err := id.New(id.EnrichmentJob)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobRepo.Create(ctx, &domain.CompanyEnrichmentJob{
+		ID:            enrichmentJobID,
+		WorkspaceID:   workspaceID,
+		CompanyID:     companyID,
+		Provider:      s.provider.Name(),
+		Overwrite:     req.Overwrite,
+		RequestedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "enrich_requested", "company", &companyID, nil, "", "")
+
+	return job, nil
+}
+
+// GetEnrichmentJob retorna o status de um job de enriquecimento.
+// Permission: all workspace members.
+func (s *CompanyEnrichmentService) GetEnrichmentJob(ctx context.Context, workspaceID, companyID, jobID, actorID string) (*domain.CompanyEnrichmentJob, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.jobRepo.Get(ctx, workspaceID, companyID, jobID)
+}
+
+// ProcessNext executa o próximo job da fila. Retorna false quando não havia job.
+// Falhas do job (provedor, empresa removida) ficam registradas nele; o erro retornado
+// só reflete falhas ao finalizar o job.
+func (s *CompanyEnrichmentService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.jobRepo.ClaimNext(ctx, time.Now().UTC().Add(-EnrichmentStaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	applied, err := s.runEnrichment(ctx, job)
+
+	fields := []zap.Field{
+		logger.Module("company_enrichment"),
+		logger.Action("enrich"),
+		zap.String("job_id", job.ID),
+		zap.String("company_id", job.CompanyID),
+		zap.String("workspace_id", job.WorkspaceID),
+		zap.String("provider", job.Provider),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		s.log.Error(ctx, "enrichment job failed", append(fields, zap.Error(err))...)
+		return true, s.jobRepo.Fail(ctx, job.ID, err.Error())
+	}
+
+	s.log.Info(ctx, "enrichment job completed", append(fields, zap.Strings("applied_fields", applied))...)
+	return true, s.jobRepo.Complete(ctx, job.ID, applied)
+}
+
+// runEnrichment consulta o provedor e grava na empresa os campos que o job pode aplicar.
+func (s *CompanyEnrichmentService) runEnrichment(ctx context.Context, job *domain.CompanyEnrichmentJob) ([]string, error) {
+	before, err := s.companyRepo.Get(ctx, job.WorkspaceID, job.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if before.Domain == nil || strings.TrimSpace(*before.Domain) == "" {
+		return nil, ErrCompanyDomainRequired
+	}
+
+	data, err := s.provider.EnrichCompany(ctx, enrichment.Query{Domain: *before.Domain, Name: before.Name})
+	if err != nil {
+		if errors.Is(err, enrichment.ErrNotFound) {
+			return nil, fmt.Errorf("provider %s has no data for %s", s.provider.Name(), *before.Domain)
+		}
+		return nil, fmt.Errorf("enrichment provider: %w", err)
+	}
+
+	var size *domain.CompanySize
+	if data.Size != nil {
+		cs := domain.CompanySize(*data.Size)
+		size = &cs
+	}
+	patch := domain.PlanCompanyEnrichment(before, data.Industry, size, data.AnnualRevenue, data.LogoURL, job.Overwrite)
+
+	if err := s.jobRepo.ApplyEnrichment(ctx, job, patch, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	applied := patch.Fields()
+	if len(applied) > 0 {
+		if after, err := s.companyRepo.Get(ctx, job.WorkspaceID, job.CompanyID); err == nil {
+			s.history.Record(ctx, job.WorkspaceID, job.RequestedByID, domain.HistoryEntityCompany, job.CompanyID, "enrich", before, after)
+		}
+		_ = s.auditRepo.LogAction(ctx, job.WorkspaceID, job.RequestedByID, "enrich", "company", &job.CompanyID, nil, "", "")
+	}
+
+	return applied, nil
+}
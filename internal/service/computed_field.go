@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrComputedFieldNotFound     = repo.ErrComputedFieldNotFound
+	ErrComputedFieldKeyConflict  = repo.ErrComputedFieldKeyConflict
+	ErrComputedFieldLimitReached = apperr.Unprocessable(apperr.CodeValidationError, fmt.Sprintf("an entity can have at most %d computed fields", domain.MaxComputedFieldsPerEntity), "")
+)
+
+// ComputedFieldService gerencia os campos calculados de empresas e negócios e os avalia na
+// leitura (CompanyService e DealService chamam Evaluate após buscar os registros).
+type ComputedFieldService struct {
+	fieldRepo     *repo.ComputedFieldRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewComputedFieldService(fieldRepo *repo.ComputedFieldRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *ComputedFieldService {
+	return &ComputedFieldService{
+		fieldRepo:     fieldRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *ComputedFieldService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("computed_field"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *ComputedFieldService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateComputedField cria um campo calculado. A fórmula já foi validada por req.Validate.
+// Permission: admin (definir campos é configuração do workspace).
+func (s *ComputedFieldService) CreateComputedField(ctx context.Context, workspaceID, actorID string, req *domain.CreateComputedFieldRequest) (*domain.ComputedField, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanManageWorkspace); err != nil {
+		return nil, err
+	}
+
+	count, err := s.fieldRepo.CountByEntity(ctx, workspaceID, req.EntityType)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxComputedFieldsPerEntity {
+		return nil, ErrComputedFieldLimitReached
+	}
+
+	computedFieldID, err := id.New(id.ComputedField)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.fieldRepo.Create(ctx, &domain.ComputedField{
+		ID:          computedFieldID,
+		WorkspaceID: workspaceID,
+		EntityType:  req.EntityType,
+		Key:         req.Key,
+		Label:       req.Label,
+		Formula:     req.Formula,
+		ResultType:  req.ResultType,
+		CreatedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", field.ID)
+	return field, nil
+}
+
+// GetComputedField retorna a definição do campo.
+// Permission: all workspace members.
+func (s *ComputedFieldService) GetComputedField(ctx context.Context, workspaceID, fieldID, actorID string) (*domain.ComputedField, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.fieldRepo.Get(ctx, workspaceID, fieldID)
+}
+
+// ListComputedFields lista as definições do workspace, opcionalmente de uma entidade.
+// Permission: all workspace members.
+func (s *ComputedFieldService) ListComputedFields(ctx context.Context, workspaceID, actorID string, entity *domain.ComputedFieldEntity) ([]domain.ComputedField, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.fieldRepo.List(ctx, workspaceID, entity)
+}
+
+// UpdateComputedField atualiza label e/ou fórmula. A fórmula já foi validada contra a
+// entidade do campo (req.ValidateFormula).
+// Permission: admin.
+func (s *ComputedFieldService) UpdateComputedField(ctx context.Context, workspaceID, fieldID, actorID string, req *domain.UpdateComputedFieldRequest) (*domain.ComputedField, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanManageWorkspace); err != nil {
+		return nil, err
+	}
+
+	field, err := s.fieldRepo.Update(ctx, workspaceID, fieldID, req, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "update", field.ID)
+	return field, nil
+}
+
+// DeleteComputedField remove o campo.
+// Permission: admin.
+func (s *ComputedFieldService) DeleteComputedField(ctx context.Context, workspaceID, fieldID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanManageWorkspace); err != nil {
+		return err
+	}
+	if err := s.fieldRepo.Delete(ctx, workspaceID, fieldID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", fieldID)
+	return nil
+}
+
+// Evaluate calcula os campos da entidade para os IDs informados (id -> key -> valor).
+// Retorna nil quando o workspace não tem campos para a entidade. Sem RBAC: o chamador já
+// autorizou a leitura dos registros. Um campo cuja fórmula falha no banco vale null sem
+// afetar os demais.
+func (s *ComputedFieldService) Evaluate(ctx context.Context, workspaceID string, entity domain.ComputedFieldEntity, ids []string) (map[string]map[string]any, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	fields, err := s.fieldRepo.List(ctx, workspaceID, &entity)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	values, err := s.fieldRepo.Evaluate(ctx, workspaceID, entity, fields, ids)
+	if err == nil || ctx.Err() != nil {
+		return values, err
+	}
+
+	// A query única falhou: avalia campo a campo para que uma fórmula com erro em runtime
+	// valha null sem derrubar os demais campos
+	s.log.Warn(ctx, "computed fields query failed, evaluating each field separately",
+		logger.Module("computed_field"),
+		logger.Action("evaluate"),
+		zap.String("workspace_id", workspaceID),
+		zap.String("entity_type", string(entity)),
+		zap.Error(err),
+	)
+	values = make(map[string]map[string]any, len(ids))
+	for _, id := range ids {
+		values[id] = make(map[string]any, len(fields))
+	}
+	for _, f := range fields {
+		fieldValues, err := s.fieldRepo.Evaluate(ctx, workspaceID, entity, []domain.ComputedField{f}, ids)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			s.log.Warn(ctx, "failed to evaluate computed field",
+				logger.Module("computed_field"),
+				logger.Action("evaluate"),
+				zap.String("workspace_id", workspaceID),
+				zap.String("field_id", f.ID),
+				zap.String("field_key", f.Key),
+				zap.Error(err),
+			)
+		}
+		for _, id := range ids {
+			values[id][f.Key] = fieldValues[id][f.Key]
+		}
+	}
+	return values, nil
+}
+
+func (s *ComputedFieldService) logAction(ctx context.Context, workspaceID, actorID, action, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "computed_field", &idStr, nil, "", "")
+}
@@ -2,58 +2,89 @@ package service
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/base32"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"sync/atomic"
+	"time"
 
+	"linkko-api/internal/apperr"
 	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrUnauthorized        = errors.New("user not authorized for this action")
-	ErrInvalidOwner        = errors.New("owner_id does not belong to workspace")
-	ErrInvalidCompany      = errors.New("company_id does not belong to workspace")
+	ErrUnauthorized        = apperr.Forbidden("user not authorized for this action", "insufficient permissions for this action")
+	ErrInvalidOwner        = apperr.Unprocessable(apperr.CodeInvalidOwner, "owner is not a member of the workspace", "owner does not belong to workspace")
+	ErrInvalidCompany      = apperr.Unprocessable(apperr.CodeValidationError, "company_id does not belong to workspace", "company does not belong to workspace")
 	ErrContactNotFound     = repo.ErrContactNotFound
 	ErrEmailConflict       = repo.ErrContactEmailConflict
-	ErrConcurrencyConflict = errors.New("contact was modified by another request")
+	ErrConcurrencyConflict = apperr.Conflict("contact was modified by another request", "")
 	ErrMemberNotFound      = repo.ErrMemberNotFound // Wrap workspace repo error
+
+	ErrInvalidLifecycleTransition = apperr.Unprocessable(apperr.CodeInvalidLifecycleTransition, "contact cannot move from its current lifecycle stage to the requested stage", "invalid lifecycle stage transition")
 )
 
 type ContactService struct {
 	contactRepo   *repo.ContactRepository
 	auditRepo     *repo.AuditRepo
 	workspaceRepo *repo.WorkspaceRepository
-	companyRepo   *repo.CompanyRepository // For CompanyID validation
+	userRepo      *repo.UserRepository     // For ActorID (owner) validation
+	companyRepo   *repo.CompanyRepository  // For CompanyID validation
+	activityRepo  *repo.ActivityRepository // Timeline events (LIFECYCLE_CHANGE)
+	counters      *CounterService          // Dashboard counters (newLeadsThisWeek)
+	history       *FieldHistoryService     // Histórico por campo
+	undo          *UndoService             // Tokens de desfazer do DELETE
+	followers     *FollowerService         // Seguidores e notificações
+	sync          *SyncPolicyService       // Política de conflito dos upserts com ?system=
 	log           *logger.Logger
+
+	// association associa o contato à empresa pelo domínio do e-mail (CONTACT_COMPANY_AUTO_ASSOCIATE,
+	// recarregável)
+	association atomic.Pointer[domain.CompanyAssociationPolicy]
 }
 
-func NewContactService(contactRepo *repo.ContactRepository, auditRepo *repo.AuditRepo, workspaceRepo *repo.WorkspaceRepository, companyRepo *repo.CompanyRepository, log *logger.Logger) *ContactService {
-	return &ContactService{
+func NewContactService(contactRepo *repo.ContactRepository, auditRepo *repo.AuditRepo, workspaceRepo *repo.WorkspaceRepository, userRepo *repo.UserRepository, companyRepo *repo.CompanyRepository, activityRepo *repo.ActivityRepository, counters *CounterService, history *FieldHistoryService, undo *UndoService, followers *FollowerService, log *logger.Logger, association domain.CompanyAssociationPolicy) *ContactService {
+	s := &ContactService{
 		contactRepo:   contactRepo,
 		auditRepo:     auditRepo,
 		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
 		companyRepo:   companyRepo,
+		activityRepo:  activityRepo,
+		counters:      counters,
+		history:       history,
+		undo:          undo,
+		followers:     followers,
 		log:           log,
 	}
+	s.association.Store(&association)
+	return s
 }
 
-// generateID cria um ID compatível com Prisma (cuid-like)
-func generateID() string {
-	b := make([]byte, 16)
-	rand.Read(b)
-	return "c" + strings.ToLower(base32.StdEncoding.EncodeToString(b)[:24])
+// WithSyncPolicies aplica as políticas de conflito das integrações aos upserts com system.
+func (s *ContactService) WithSyncPolicies(sync *SyncPolicyService) *ContactService {
+	s.sync = sync
+	return s
+}
+
+// SetAssociationPolicy aplica novos CONTACT_COMPANY_AUTO_ASSOCIATE/CONTACT_COMPANY_DENY_DOMAINS
+func (s *ContactService) SetAssociationPolicy(association domain.CompanyAssociationPolicy) {
+	s.association.Store(&association)
 }
 
 // getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
 // Logs successful role resolution and authorization failures for security monitoring.
 func (s *ContactService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
 	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		s.log.Error(ctx, "failed to get member role",
 			logger.Module("contact"),
@@ -82,6 +113,9 @@ func (s *ContactService) getMemberRoleWithLogging(ctx context.Context, actorID,
 // Permission: all workspace members can list contacts.
 // Role is fetched from database to enforce real-time authorization.
 func (s *ContactService) ListContacts(ctx context.Context, workspaceID, actorID string, params domain.ListContactsParams) (*domain.ContactListResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ContactService.ListContacts")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -115,6 +149,9 @@ func (s *ContactService) ListContacts(ctx context.Context, workspaceID, actorID
 // Permission: all workspace members can view contacts.
 // Role is fetched from database to enforce real-time authorization.
 func (s *ContactService) GetContact(ctx context.Context, workspaceID, contactID, actorID string) (*domain.Contact, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ContactService.GetContact")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -139,6 +176,9 @@ func (s *ContactService) GetContact(ctx context.Context, workspaceID, contactID,
 // Permission: admin, manager, user can create contacts. Viewer cannot.
 // Role is fetched from database to enforce real-time authorization.
 func (s *ContactService) CreateContact(ctx context.Context, workspaceID, actorID string, req *domain.CreateContactRequest) (*domain.Contact, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ContactService.CreateContact")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -151,9 +191,8 @@ func (s *ContactService) CreateContact(ctx context.Context, workspaceID, actorID
 	}
 
 	// Business validation: if actor_id provided, validate it belongs to workspace
-	if req.ActorID != nil {
-		// Note: In production, this would call UserRepository.ExistsInWorkspace
-		// Skipping for now as UserRepository is not yet implemented
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.ActorID); err != nil {
+		return nil, err
 	}
 
 	// Business validation: if company_id provided, validate it belongs to workspace
@@ -167,8 +206,13 @@ func (s *ContactService) CreateContact(ctx context.Context, workspaceID, actorID
 		}
 	}
 
+	contactID, err := id.New(id.Contact)
+	if err != nil {
+		return nil, err
+	}
+
 	contact := &domain.Contact{
-		ID:          generateID(),
+		ID:          contactID,
 		WorkspaceID: workspaceID,
 		FullName:    req.FullName,
 		Email:       req.Email,
@@ -183,7 +227,16 @@ func (s *ContactService) CreateContact(ctx context.Context, workspaceID, actorID
 	}
 	if req.CompanyID != nil {
 		contact.CompanyID = req.CompanyID
+	} else {
+		contact.CompanyID = s.matchCompanyByEmail(ctx, workspaceID, req.Email)
+	}
+	if req.LifecycleStage != nil {
+		contact.LifecycleStage = *req.LifecycleStage
 	}
+	contact.ExternalID = req.ExternalID
+	contact.Attribution = req.Attribution
+	contact.DefaultSource(domain.SourceManual)
+	contact.NextStep = req.NextStep
 	if req.Tags != nil {
 		contact.Tags = req.Tags
 	} else {
@@ -218,6 +271,9 @@ func (s *ContactService) CreateContact(ctx context.Context, workspaceID, actorID
 		// In production, this should be logged to monitoring system
 	}
 
+	s.counters.ContactCreated(ctx, workspaceID)
+	s.followers.AutoFollow(ctx, workspaceID, domain.FollowEntityContact, contact.ID, &contact.ActorID, domain.FollowReasonAssigned)
+
 	return contact, nil
 }
 
@@ -225,6 +281,9 @@ func (s *ContactService) CreateContact(ctx context.Context, workspaceID, actorID
 // Permission: admin, manager, user can update. Viewer cannot.
 // Role is fetched from database to enforce real-time authorization.
 func (s *ContactService) UpdateContact(ctx context.Context, workspaceID, contactID, actorID string, req *domain.UpdateContactRequest) (*domain.Contact, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ContactService.UpdateContact")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -243,8 +302,8 @@ func (s *ContactService) UpdateContact(ctx context.Context, workspaceID, contact
 	}
 
 	// Business validation: if actor_id provided, validate it belongs to workspace
-	if req.ActorID != nil {
-		// Note: In production, this would call UserRepository.ExistsInWorkspace
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.ActorID); err != nil {
+		return nil, err
 	}
 
 	// Business validation: if company_id provided, validate it belongs to workspace
@@ -258,6 +317,15 @@ func (s *ContactService) UpdateContact(ctx context.Context, workspaceID, contact
 		}
 	}
 
+	// Contato sem empresa: associa pelo domínio do e-mail (novo ou atual)
+	if req.CompanyID == nil && current.CompanyID == nil {
+		email := current.Email
+		if req.Email != nil {
+			email = *req.Email
+		}
+		req.CompanyID = s.matchCompanyByEmail(ctx, workspaceID, email)
+	}
+
 	contact, err := s.contactRepo.Update(ctx, workspaceID, contactID, req, current.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, errors.New("contact was modified by another request")) {
@@ -266,6 +334,9 @@ func (s *ContactService) UpdateContact(ctx context.Context, workspaceID, contact
 		return nil, fmt.Errorf("update contact: %w", err)
 	}
 
+	s.history.Record(ctx, workspaceID, actorID, domain.HistoryEntityContact, contactID, "update", current, contact)
+	s.followers.Changed(ctx, workspaceID, domain.FollowEntityContact, contactID, actorID, &current.ActorID, &contact.ActorID)
+
 	// Audit: log contact update
 	contactIDStr := contactID
 	auditErr := s.auditRepo.LogAction(
@@ -286,24 +357,95 @@ func (s *ContactService) UpdateContact(ctx context.Context, workspaceID, contact
 	return contact, nil
 }
 
+// UpsertContactByExternalID cria ou atualiza o contato identificado pela chave do integrador
+// (PUT .../contacts/by-external-id/{externalId}); created indica se o contato foi criado.
+// Requests concorrentes com o mesmo externalId resultam em um único contato: o índice único
+// rejeita a segunda criação, e quem perde a corrida busca de novo e atualiza o contato criado.
+// Com system (a integração de origem), a política de conflito dela decide os campos gravados
+// por outros sistemas (SyncPolicyService).
+// Permission: as de CreateContact e UpdateContact.
+func (s *ContactService) UpsertContactByExternalID(ctx context.Context, workspaceID, externalID, actorID, system string, req *domain.CreateContactRequest) (*domain.Contact, bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ContactService.UpsertContactByExternalID")
+	defer span.End()
+
+	req.ExternalID = &externalID
+
+	for attempt := 0; attempt < upsertMaxAttempts; attempt++ {
+		contactID, err := s.contactRepo.FindIDByExternalID(ctx, workspaceID, externalID)
+		if err != nil {
+			return nil, false, fmt.Errorf("find contact by external id: %w", err)
+		}
+
+		if contactID == nil {
+			plan, err := s.sync.planUpsert(ctx, workspaceID, system, domain.IdentityEntityContact, "", nil, req.UpsertUpdate())
+			if err != nil {
+				return nil, false, err
+			}
+			contact, err := s.CreateContact(ctx, workspaceID, actorID, req)
+			if errors.Is(err, ErrExternalIDConflict) {
+				continue // criado por outra request entre a busca e o insert
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			s.sync.recordUpsert(ctx, plan, contact.ID)
+			return contact, true, nil
+		}
+
+		update := req.UpsertUpdate()
+		var plan *syncPlan
+		if system != "" {
+			current, err := s.contactRepo.Get(ctx, workspaceID, *contactID)
+			if errors.Is(err, ErrContactNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			if plan, err = s.sync.planUpsert(ctx, workspaceID, system, domain.IdentityEntityContact, *contactID, current, update); err != nil {
+				return nil, false, err
+			}
+			if err := domain.OmitJSONFields(update, plan.rejectedFields()); err != nil {
+				return nil, false, err
+			}
+		}
+
+		contact, err := s.UpdateContact(ctx, workspaceID, *contactID, actorID, update)
+		if errors.Is(err, ErrContactNotFound) {
+			continue // removido ou alterado (optimistic locking) entre a busca e o update
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		s.sync.recordUpsert(ctx, plan, contact.ID)
+		return contact, false, nil
+	}
+
+	return nil, false, ErrUpsertContention
+}
+
 // DeleteContact soft deletes a contact with RBAC validation.
 // Permission: only admin and manager can delete contacts.
+// Returns an undo receipt (nil if it could not be issued) to restore the contact within the undo window.
 // Role is fetched from database to enforce real-time authorization.
-func (s *ContactService) DeleteContact(ctx context.Context, workspaceID, contactID, actorID string) error {
+func (s *ContactService) DeleteContact(ctx context.Context, workspaceID, contactID, actorID string) (*domain.UndoReceipt, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ContactService.DeleteContact")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// RBAC: only admin and manager can delete
 	if !domain.CanDeleteContacts(role) {
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
 
-	err = s.contactRepo.SoftDelete(ctx, workspaceID, contactID)
+	err = s.contactRepo.SoftDelete(ctx, workspaceID, contactID, actorID)
 	if err != nil {
-		return fmt.Errorf("delete contact: %w", err)
+		return nil, fmt.Errorf("delete contact: %w", err)
 	}
 
 	// Audit: log contact deletion
@@ -323,7 +465,144 @@ func (s *ContactService) DeleteContact(ctx context.Context, workspaceID, contact
 		// Log audit failure but don't fail the operation
 	}
 
-	return nil
+	// Sem o createdAt do contato não dá para saber se ele contava na semana
+	s.counters.Invalidate(ctx, workspaceID)
+
+	return s.undo.Issue(ctx, workspaceID, actorID, domain.UndoEntityContact, contactID), nil
+}
+
+// TransitionLifecycleStage moves a contact through the funnel and emits a LIFECYCLE_CHANGE timeline event.
+// Permission: admin, manager, user can transition (viewer cannot).
+// Role is fetched from database to enforce real-time authorization.
+func (s *ContactService) TransitionLifecycleStage(ctx context.Context, workspaceID, contactID, actorID string, req *domain.TransitionLifecycleStageRequest) (*domain.Contact, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ContactService.TransitionLifecycleStage")
+	defer span.End()
+
+	// Fetch user's role in this workspace from database
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// RBAC: admin, manager, user can transition (viewer cannot)
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	current, err := s.contactRepo.Get(ctx, workspaceID, contactID)
+	if err != nil {
+		return nil, fmt.Errorf("get current contact: %w", err)
+	}
+
+	fromStage := current.LifecycleStage
+	if !fromStage.CanTransitionTo(req.ToStage) {
+		return nil, ErrInvalidLifecycleTransition
+	}
+
+	// ID da atividade gerado antes da transição: depois dela a timeline é best-effort
+	activityID, err := id.New(id.Activity)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := s.contactRepo.TransitionLifecycleStage(ctx, workspaceID, contactID, fromStage, req.ToStage, actorID)
+	if err != nil {
+		// Contato existia no Get: se sumiu agora, outra request mudou o estágio (ou deletou) no meio
+		if errors.Is(err, repo.ErrContactNotFound) {
+			return nil, ErrConcurrencyConflict
+		}
+		return nil, fmt.Errorf("transition lifecycle stage: %w", err)
+	}
+
+	s.history.Record(ctx, workspaceID, actorID, domain.HistoryEntityContact, contactID, "transition_stage", current, contact)
+	s.followers.Notify(ctx, workspaceID, domain.FollowEntityContact, contactID, actorID, domain.NotificationStageChanged)
+
+	metadata := map[string]interface{}{
+		"fromStage": string(fromStage),
+		"toStage":   string(req.ToStage),
+	}
+	if req.Reason != nil && *req.Reason != "" {
+		metadata["reason"] = *req.Reason
+	}
+
+	// Event: LIFECYCLE_CHANGE na timeline do contato (e da empresa, se houver)
+	metadataJSON, _ := json.Marshal(metadata)
+	_, activityErr := s.activityRepo.CreateActivity(ctx, &domain.Activity{
+		ID:          activityID,
+		WorkspaceID: workspaceID,
+		CompanyID:   contact.CompanyID,
+		ContactID:   &contact.ID,
+		Type:        domain.ActivityTypeLifecycleChange,
+		UserID:      actorID,
+		Metadata:    metadataJSON,
+		CreatedAt:   time.Now(),
+	})
+	if activityErr != nil {
+		// Timeline é best-effort: a transição já foi persistida
+		s.log.Warn(ctx, "failed to record lifecycle change activity",
+			logger.Module("contact"),
+			logger.Action("transition_stage"),
+			zap.String("contact_id", contactID),
+			zap.Error(activityErr),
+		)
+	}
+
+	// Audit: log lifecycle transition
+	contactIDStr := contactID
+	auditErr := s.auditRepo.LogAction(
+		ctx,
+		workspaceID,
+		actorID,
+		"transition_stage",
+		"contact",
+		&contactIDStr,
+		metadata,
+		"",
+		"",
+	)
+	if auditErr != nil {
+		// Log audit failure but don't fail the operation
+	}
+
+	return contact, nil
+}
+
+// LifecycleReport counts contacts per lifecycle stage (funnel report).
+// Permission: all workspace members can view reports.
+// Role is fetched from database to enforce real-time authorization.
+func (s *ContactService) LifecycleReport(ctx context.Context, workspaceID, actorID string, ownerID, companyID *string) (*domain.ContactLifecycleReport, error) {
+	// Fetch user's role in this workspace from database
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// RBAC: all workspace members can view reports
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	counts, err := s.contactRepo.CountByLifecycleStage(ctx, workspaceID, ownerID, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle report: %w", err)
+	}
+
+	report := &domain.ContactLifecycleReport{
+		Stages: make([]domain.LifecycleStageCount, 0, len(domain.ContactLifecycleStages)),
+	}
+	for _, stage := range domain.ContactLifecycleStages {
+		report.Stages = append(report.Stages, domain.LifecycleStageCount{Stage: stage, Count: counts[stage]})
+		report.Total += counts[stage]
+	}
+	// Estágios legados só aparecem quando ainda há contatos neles
+	for _, stage := range []domain.ContactLifecycleStage{domain.ContactStageOpportunity, domain.ContactStageEvangelist} {
+		if counts[stage] > 0 {
+			report.Stages = append(report.Stages, domain.LifecycleStageCount{Stage: stage, Count: counts[stage]})
+			report.Total += counts[stage]
+		}
+	}
+
+	return report, nil
 }
 
 // getRequestID extracts request_id from context for audit logging.
@@ -335,3 +614,60 @@ func getRequestID(_ context.Context) string {
 	// return requestID
 	return ""
 }
+
+// matchCompanyByEmail retorna a empresa cujo domínio coincide com o do e-mail, conforme a
+// política de associação. Falhas na busca não bloqueiam a escrita do contato.
+func (s *ContactService) matchCompanyByEmail(ctx context.Context, workspaceID, email string) *string {
+	domainName, ok := s.association.Load().MatchDomain(email)
+	if !ok {
+		return nil
+	}
+
+	companyID, err := s.companyRepo.FindIDByDomain(ctx, workspaceID, domainName)
+	if err != nil {
+		s.log.Warn(ctx, "failed to match company by email domain",
+			logger.Module("contact"),
+			logger.Action("associate_company"),
+			zap.String("workspace_id", workspaceID),
+			zap.String("domain", domainName),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return companyID
+}
+
+// AssociateCompanies associa (backfill) os contatos sem empresa às empresas cujo domínio
+// coincide com o do e-mail. Execução única e idempotente: contatos já associados não mudam.
+// Permission: admin only (alteração em massa).
+func (s *ContactService) AssociateCompanies(ctx context.Context, workspaceID, actorID string) (*domain.AssociateCompaniesResult, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanManageWorkspace(role) {
+		return nil, ErrUnauthorized
+	}
+
+	result := &domain.AssociateCompaniesResult{}
+	association := s.association.Load()
+	if !association.Enabled {
+		return result, nil
+	}
+
+	associated, err := s.companyRepo.AssociateContactsByEmailDomain(ctx, workspaceID, actorID, association.DeniedDomains())
+	if err != nil {
+		return nil, err
+	}
+	result.Associated = associated
+
+	s.log.Info(ctx, "contacts associated to companies by email domain",
+		logger.Module("contact"),
+		logger.Action("associate_companies"),
+		zap.String("workspace_id", workspaceID),
+		zap.Int64("associated", associated),
+	)
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "associate_companies", "contact", nil, nil, "", "")
+
+	return result, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const (
+	// BulkUpdateStaleAfter tempo sem progresso após o qual um job RUNNING é considerado
+	// abandonado (worker caiu no meio) e é retomado a partir do último lote gravado.
+	BulkUpdateStaleAfter = 5 * time.Minute
+
+	// BulkUpdateBatchSize contatos atualizados por lote (um UPDATE por lote).
+	BulkUpdateBatchSize = 500
+)
+
+var ErrBulkUpdateJobNotFound = repo.ErrBulkUpdateJobNotFound
+
+// ContactBulkService enfileira e executa atualizações em massa de contatos (tags, dono,
+// estágio do funil). Os jobs são assíncronos; o bulk-update-worker os executa via ProcessNext.
+type ContactBulkService struct {
+	jobRepo       *repo.ContactBulkRepository
+	workspaceRepo *repo.WorkspaceRepository
+	userRepo      *repo.UserRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewContactBulkService(jobRepo *repo.ContactBulkRepository, workspaceRepo *repo.WorkspaceRepository, userRepo *repo.UserRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *ContactBulkService {
+	return &ContactBulkService{
+		jobRepo:       jobRepo,
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *ContactBulkService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("contact_bulk"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// BulkUpdateContacts enfileira o patch para os contatos selecionados e retorna o job PENDING.
+// Permission: admin, manager, agent (mesma regra de edição de contatos).
+func (s *ContactBulkService) BulkUpdateContacts(ctx context.Context, workspaceID, actorID string, req *domain.BulkUpdateContactsRequest) (*domain.ContactBulkUpdateJob, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	// Novo dono precisa ser membro do workspace (validado uma vez, não por contato)
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.Patch.ActorID); err != nil {
+		return nil, err
+	}
+
+	bulkUpdateJobID, err := id.New(id.BulkUpdateJob)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobRepo.Create(ctx, &domain.ContactBulkUpdateJob{
+		ID:            bulkUpdateJobID,
+		WorkspaceID:   workspaceID,
+		ContactIDs:    req.IDs,
+		Filter:        req.Filter,
+		Patch:         req.Patch,
+		RequestedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "bulk_update_requested", "contact_bulk_update", &job.ID, nil, "", "")
+
+	return job, nil
+}
+
+// GetBulkUpdateJob retorna o status e o progresso de um job.
+// Permission: all workspace members.
+func (s *ContactBulkService) GetBulkUpdateJob(ctx context.Context, workspaceID, jobID, actorID string) (*domain.ContactBulkUpdateJob, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.jobRepo.Get(ctx, workspaceID, jobID)
+}
+
+// ProcessNext executa o próximo job da fila até o fim. Retorna false quando não havia job.
+// O progresso é gravado a cada lote; se o worker parar no meio, o job é retomado do
+// último lote após BulkUpdateStaleAfter. O erro retornado só reflete falhas ao finalizar o job.
+func (s *ContactBulkService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.jobRepo.ClaimNext(ctx, time.Now().UTC().Add(-BulkUpdateStaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	err = s.runBulkUpdate(ctx, job)
+
+	fields := []zap.Field{
+		logger.Module("contact_bulk"),
+		logger.Action("bulk_update"),
+		zap.String("job_id", job.ID),
+		zap.String("workspace_id", job.WorkspaceID),
+		zap.Int("processed", job.Processed),
+		zap.Int("updated", job.Updated),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		// Worker encerrando: o job fica RUNNING e é retomado do cursor
+		if ctx.Err() != nil {
+			s.log.Warn(context.Background(), "bulk update job interrupted", fields...)
+			return true, nil
+		}
+		s.log.Error(ctx, "bulk update job failed", append(fields, zap.Error(err))...)
+		return true, s.jobRepo.Fail(ctx, job.ID, err.Error())
+	}
+
+	s.log.Info(ctx, "bulk update job completed", fields...)
+	if err := s.jobRepo.Complete(ctx, job.ID); err != nil {
+		return true, err
+	}
+	_ = s.auditRepo.LogAction(ctx, job.WorkspaceID, job.RequestedByID, "bulk_update", "contact_bulk_update", &job.ID, nil, "", "")
+	return true, nil
+}
+
+// runBulkUpdate aplica o patch em lotes de BulkUpdateBatchSize a partir do cursor do job.
+func (s *ContactBulkService) runBulkUpdate(ctx context.Context, job *domain.ContactBulkUpdateJob) error {
+	if job.Total == nil {
+		total, err := s.jobRepo.CountTargets(ctx, job)
+		if err != nil {
+			return err
+		}
+		if err := s.jobRepo.SetTotal(ctx, job.ID, total); err != nil {
+			return err
+		}
+		job.Total = &total
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ids, err := s.jobRepo.NextBatch(ctx, job, BulkUpdateBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		updated, err := s.jobRepo.ApplyPatch(ctx, job, ids)
+		if err != nil {
+			return err
+		}
+
+		cursor := ids[len(ids)-1]
+		if err := s.jobRepo.SaveProgress(ctx, job.ID, cursor, len(ids), updated); err != nil {
+			return err
+		}
+		job.Cursor = &cursor
+		job.Processed += len(ids)
+		job.Updated += updated
+
+		if len(ids) < BulkUpdateBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestContactBulkService_Integration
+func TestContactBulkService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	contacts := repo.NewContactRepository(pool)
+	svc := service.NewContactBulkService(repo.NewContactBulkRepository(pool), repo.NewWorkspaceRepository(pool),
+		repo.NewUserRepository(pool), repo.NewAuditRepo(pool), log)
+
+	addTag := func(ids ...string) *domain.BulkUpdateContactsRequest {
+		return &domain.BulkUpdateContactsRequest{IDs: ids, Patch: domain.ContactBulkPatch{AddTags: []string{"campaign"}}}
+	}
+
+	// run processa a fila até o job terminar (outros jobs pendentes também são executados)
+	run := func(job *domain.ContactBulkUpdateJob) *domain.ContactBulkUpdateJob {
+		t.Helper()
+		for range 100 {
+			got, err := svc.GetBulkUpdateJob(ctx, f.WorkspaceID, job.ID, f.UserID)
+			require.NoError(t, err)
+			if got.Status == domain.BulkUpdateStatusCompleted || got.Status == domain.BulkUpdateStatusFailed {
+				return got
+			}
+			processed, err := svc.ProcessNext(ctx)
+			require.NoError(t, err)
+			require.True(t, processed, "job %s was not claimed", job.ID)
+		}
+		t.Fatalf("job %s did not finish", job.ID)
+		return nil
+	}
+
+	t.Run("denies roles that cannot modify contacts", func(t *testing.T) {
+		contact := f.Contact()
+
+		_, err := svc.BulkUpdateContacts(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), addTag(contact.ID))
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		_, err = svc.BulkUpdateContacts(ctx, f.WorkspaceID, other.UserID, addTag(contact.ID))
+		assert.ErrorIs(t, err, service.ErrMemberNotFound)
+
+		req := addTag(contact.ID)
+		req.Patch.ActorID = &other.UserID
+		_, err = svc.BulkUpdateContacts(ctx, f.WorkspaceID, f.UserID, req)
+		assert.ErrorIs(t, err, service.ErrInvalidOwner, "new owner must be a workspace member")
+
+		got, err := contacts.Get(ctx, f.WorkspaceID, contact.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got.Tags)
+	})
+
+	t.Run("reports partial updates and leaves other workspaces untouched", func(t *testing.T) {
+		lead := f.Contact()
+		customer := f.Contact(func(c *domain.Contact) { c.LifecycleStage = domain.ContactStageCustomer })
+		foreign := other.Contact()
+
+		job, err := svc.BulkUpdateContacts(ctx, f.WorkspaceID, f.Member(domain.RoleUser), &domain.BulkUpdateContactsRequest{
+			IDs:   []string{lead.ID, customer.ID, foreign.ID},
+			Patch: domain.ContactBulkPatch{LifecycleStage: factory.Ptr(domain.ContactStageSQL)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.BulkUpdateStatusPending, job.Status)
+
+		done := run(job)
+		assert.Equal(t, domain.BulkUpdateStatusCompleted, done.Status)
+		require.NotNil(t, done.Total)
+		assert.Equal(t, 2, *done.Total, "ids from another workspace are not selected")
+		assert.Equal(t, 2, done.Processed)
+		assert.Equal(t, 1, done.Updated, "CUSTOMER cannot move to SQL")
+
+		got, err := contacts.Get(ctx, f.WorkspaceID, lead.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactStageSQL, got.LifecycleStage)
+		got, err = contacts.Get(ctx, f.WorkspaceID, customer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactStageCustomer, got.LifecycleStage)
+		got, err = contacts.Get(ctx, other.WorkspaceID, foreign.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactStageLead, got.LifecycleStage)
+
+		_, err = svc.GetBulkUpdateJob(ctx, other.WorkspaceID, job.ID, other.UserID)
+		assert.ErrorIs(t, err, service.ErrBulkUpdateJobNotFound)
+	})
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/counters"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// CounterService mantém os contadores do cabeçalho do dashboard.
+// Leituras vêm do Redis; na ausência da chave (miss, TTL expirado ou virada do dia)
+// os valores são recalculados no Postgres e regravados. As escritas de negócios,
+// tarefas e contatos aplicam deltas incrementais ao cache.
+// Falhas no Redis nunca propagam: o cache é best-effort e a reconciliação corrige desvios.
+type CounterService struct {
+	counterRepo   *repo.CounterRepository
+	store         *counters.RedisStore
+	workspaceRepo *repo.WorkspaceRepository
+	log           *logger.Logger
+}
+
+func NewCounterService(counterRepo *repo.CounterRepository, store *counters.RedisStore, workspaceRepo *repo.WorkspaceRepository, log *logger.Logger) *CounterService {
+	return &CounterService{
+		counterRepo:   counterRepo,
+		store:         store,
+		workspaceRepo: workspaceRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *CounterService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("counter"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// GetCounters returns open deals, tasks due today and new leads this week.
+// O cache guarda a visão do admin; para os demais papéis tasksDueToday é recontado só com as
+// tarefas que o ator enxerga (privadas de outros usuários ficam de fora, como na listagem).
+// Permission: all workspace members.
+func (s *CounterService) GetCounters(ctx context.Context, workspaceID, actorID string) (*domain.WorkspaceCounters, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	period := domain.NewCounterPeriod(time.Now())
+
+	counters, err := s.workspaceCounters(ctx, workspaceID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewer := domain.VisibilityViewer(actorID, role); viewer != nil {
+		visible, err := s.counterRepo.TasksDueToday(ctx, workspaceID, period, viewer)
+		if err != nil {
+			return nil, err
+		}
+		scoped := *counters
+		scoped.TasksDueToday = visible
+		return &scoped, nil
+	}
+	return counters, nil
+}
+
+// workspaceCounters lê os contadores do workspace do cache ou, na ausência, do Postgres.
+func (s *CounterService) workspaceCounters(ctx context.Context, workspaceID string, period domain.CounterPeriod) (*domain.WorkspaceCounters, error) {
+	cached, ok, err := s.store.Get(ctx, workspaceID, period)
+	if err != nil {
+		s.logCacheError(ctx, "get", workspaceID, err)
+	} else if ok {
+		return cached, nil
+	}
+
+	fresh, err := s.counterRepo.Count(ctx, workspaceID, period)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Set(ctx, workspaceID, period, fresh); err != nil {
+		s.logCacheError(ctx, "set", workspaceID, err)
+	}
+	return fresh, nil
+}
+
+// DealChanged aplica ao cache a variação de openDeals entre dois estados do negócio.
+func (s *CounterService) DealChanged(ctx context.Context, workspaceID string, before, after *domain.Deal) {
+	s.adjust(ctx, workspaceID, domain.NewCounterPeriod(time.Now()), domain.DealCounterDelta(before, after))
+}
+
+// TaskChanged aplica ao cache a variação de tasksDueToday entre dois estados da tarefa.
+func (s *CounterService) TaskChanged(ctx context.Context, workspaceID string, before, after *domain.Task) {
+	period := domain.NewCounterPeriod(time.Now())
+	s.adjust(ctx, workspaceID, period, period.TaskCounterDelta(before, after))
+}
+
+// ContactCreated incrementa newLeadsThisWeek.
+func (s *CounterService) ContactCreated(ctx context.Context, workspaceID string) {
+	s.adjust(ctx, workspaceID, domain.NewCounterPeriod(time.Now()), domain.CounterDeltas{NewLeadsThisWeek: 1})
+}
+
+// Invalidate descarta o cache do workspace quando a variação não é conhecida
+// (ex.: contato excluído sem o estado anterior); a próxima leitura reconcilia.
+func (s *CounterService) Invalidate(ctx context.Context, workspaceID string) {
+	if s == nil {
+		return
+	}
+	if err := s.store.Invalidate(ctx, workspaceID, domain.NewCounterPeriod(time.Now())); err != nil {
+		s.logCacheError(ctx, "invalidate", workspaceID, err)
+	}
+}
+
+// adjust é nil-safe para que services montados sem contadores (sandbox, workers) sigam funcionando.
+func (s *CounterService) adjust(ctx context.Context, workspaceID string, period domain.CounterPeriod, d domain.CounterDeltas) {
+	if s == nil || d.IsZero() {
+		return
+	}
+	if err := s.store.Incr(ctx, workspaceID, period, d); err != nil {
+		s.logCacheError(ctx, "incr", workspaceID, err)
+	}
+}
+
+func (s *CounterService) logCacheError(ctx context.Context, op, workspaceID string, err error) {
+	s.log.Warn(ctx, "counter cache unavailable",
+		logger.Module("counter"),
+		logger.Action(op),
+		zap.String("workspace_id", workspaceID),
+		zap.Error(err),
+	)
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCustomObjectTypeNotFound     = repo.ErrCustomObjectTypeNotFound
+	ErrCustomObjectKeyConflict      = repo.ErrCustomObjectKeyConflict
+	ErrCustomObjectRecordNotFound   = repo.ErrCustomObjectRecordNotFound
+	ErrCustomFieldTypeChanged       = apperr.Unprocessable(apperr.CodeValidationError, "custom object field type cannot be changed", "")
+	ErrCustomObjectRelationNotFound = apperr.Unprocessable(apperr.CodeValidationError, "record referenced by a relation field not found", "")
+	ErrCustomObjectFilterInvalid    = apperr.Unprocessable(apperr.CodeInvalidParameter, "filter must reference a field of the custom object with a value of its type", "")
+)
+
+// CustomObjectService gerencia os objetos customizados do workspace (definição + registros).
+// Os registros são genéricos: o handler valida data contra a definição (ValidateData) e o
+// service confere os relacionamentos com contatos, empresas e negócios.
+type CustomObjectService struct {
+	objectRepo    *repo.CustomObjectRepository
+	contactRepo   *repo.ContactRepository
+	companyRepo   *repo.CompanyRepository
+	dealRepo      *repo.DealRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewCustomObjectService(objectRepo *repo.CustomObjectRepository, contactRepo *repo.ContactRepository, companyRepo *repo.CompanyRepository, dealRepo *repo.DealRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *CustomObjectService {
+	return &CustomObjectService{
+		objectRepo:    objectRepo,
+		contactRepo:   contactRepo,
+		companyRepo:   companyRepo,
+		dealRepo:      dealRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *CustomObjectService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("custom_object"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *CustomObjectService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateObjectType cria a definição de um objeto customizado.
+// Permission: admin (definir objetos é configuração do workspace).
+func (s *CustomObjectService) CreateObjectType(ctx context.Context, workspaceID, actorID string, req *domain.CreateCustomObjectTypeRequest) (*domain.CustomObjectType, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanManageWorkspace); err != nil {
+		return nil, err
+	}
+
+	fields := req.Fields
+	if fields == nil {
+		fields = []domain.CustomObjectField{}
+	}
+
+	customObjectTypeID, err := id.New(id.CustomObjectType)
+	if err != nil {
+		return nil, err
+	}
+
+	objectType, err := s.objectRepo.CreateType(ctx, &domain.CustomObjectType{
+		ID:          customObjectTypeID,
+		WorkspaceID: workspaceID,
+		Key:         req.Key,
+		Name:        req.Name,
+		Description: req.Description,
+		Fields:      fields,
+		CreatedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", "custom_object_type", objectType.ID)
+	return objectType, nil
+}
+
+// GetObjectType retorna a definição do objeto pela chave.
+// Permission: all workspace members.
+func (s *CustomObjectService) GetObjectType(ctx context.Context, workspaceID, objectKey, actorID string) (*domain.CustomObjectType, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.objectRepo.GetTypeByKey(ctx, workspaceID, objectKey)
+}
+
+// ListObjectTypes lista as definições do workspace.
+// Permission: all workspace members.
+func (s *CustomObjectService) ListObjectTypes(ctx context.Context, workspaceID, actorID string) ([]domain.CustomObjectType, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.objectRepo.ListTypes(ctx, workspaceID)
+}
+
+// UpdateObjectType atualiza a definição. Campos existentes não podem mudar de tipo, para que
+// os valores já gravados continuem coerentes.
+// Permission: admin.
+func (s *CustomObjectService) UpdateObjectType(ctx context.Context, workspaceID, objectKey, actorID string, req *domain.UpdateCustomObjectTypeRequest) (*domain.CustomObjectType, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanManageWorkspace); err != nil {
+		return nil, err
+	}
+
+	if req.Fields != nil {
+		current, err := s.objectRepo.GetTypeByKey(ctx, workspaceID, objectKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range req.Fields {
+			if existing, ok := current.Field(field.Key); ok && existing.Type != field.Type {
+				return nil, ErrCustomFieldTypeChanged
+			}
+		}
+	}
+
+	objectType, err := s.objectRepo.UpdateType(ctx, workspaceID, objectKey, req, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "update", "custom_object_type", objectType.ID)
+	return objectType, nil
+}
+
+// DeleteObjectType exclui a definição (soft delete) junto com o acesso aos seus registros.
+// Permission: admin.
+func (s *CustomObjectService) DeleteObjectType(ctx context.Context, workspaceID, objectKey, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanManageWorkspace); err != nil {
+		return err
+	}
+
+	objectType, err := s.objectRepo.GetTypeByKey(ctx, workspaceID, objectKey)
+	if err != nil {
+		return err
+	}
+	if err := s.objectRepo.DeleteType(ctx, workspaceID, objectKey, actorID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", "custom_object_type", objectType.ID)
+	return nil
+}
+
+// CreateRecord cria um registro do objeto. data já foi validado contra objectType.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *CustomObjectService) CreateRecord(ctx context.Context, workspaceID, actorID string, objectType *domain.CustomObjectType, req *domain.CreateCustomObjectRecordRequest) (*domain.CustomObjectRecord, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+	if err := s.checkRelations(ctx, workspaceID, objectType, req.Data); err != nil {
+		return nil, err
+	}
+
+	for key, value := range req.Data {
+		if value == nil {
+			delete(req.Data, key)
+		}
+	}
+
+	customObjectRecordID, err := id.New(id.CustomObjectRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.objectRepo.CreateRecord(ctx, &domain.CustomObjectRecord{
+		ID:           customObjectRecordID,
+		WorkspaceID:  workspaceID,
+		ObjectTypeID: objectType.ID,
+		Name:         req.Name,
+		Data:         req.Data,
+		CreatedByID:  actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", "custom_object_record", record.ID)
+	return record, nil
+}
+
+// GetRecord retorna um registro do objeto.
+// Permission: all workspace members.
+func (s *CustomObjectService) GetRecord(ctx context.Context, workspaceID, objectKey, recordID, actorID string) (*domain.CustomObjectRecord, error) {
+	objectType, err := s.GetObjectType(ctx, workspaceID, objectKey, actorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.objectRepo.GetRecord(ctx, workspaceID, objectType.ID, recordID)
+}
+
+// ListRecords lista os registros do objeto. rawFilters (?filter[campo]=valor) são convertidos
+// para o tipo de cada campo antes de comparar com data.
+// Permission: all workspace members.
+func (s *CustomObjectService) ListRecords(ctx context.Context, workspaceID, objectKey, actorID string, params domain.ListCustomObjectRecordsParams, rawFilters map[string]string) (*domain.CustomObjectRecordListResponse, error) {
+	objectType, err := s.GetObjectType(ctx, workspaceID, objectKey, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Filters, err = parseCustomObjectFilters(objectType, rawFilters)
+	if err != nil {
+		return nil, err
+	}
+	if params.Limit <= 0 {
+		params.Limit = 50
+	}
+	params.WorkspaceID = workspaceID
+	params.ObjectTypeID = objectType.ID
+
+	records, err := s.objectRepo.ListRecords(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &domain.CustomObjectRecordListResponse{}
+	if len(records) > params.Limit {
+		records = records[:params.Limit]
+		cursor := records[len(records)-1].CreatedAt.Format(time.RFC3339Nano)
+		resp.Meta.HasNextPage = true
+		resp.Meta.NextCursor = &cursor
+	}
+	resp.Data = records
+	return resp, nil
+}
+
+// UpdateRecord aplica um PATCH no registro. data já foi validado contra objectType.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *CustomObjectService) UpdateRecord(ctx context.Context, workspaceID, recordID, actorID string, objectType *domain.CustomObjectType, req *domain.UpdateCustomObjectRecordRequest) (*domain.CustomObjectRecord, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+	if err := s.checkRelations(ctx, workspaceID, objectType, req.Data); err != nil {
+		return nil, err
+	}
+
+	record, err := s.objectRepo.UpdateRecord(ctx, workspaceID, objectType.ID, recordID, req, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "update", "custom_object_record", record.ID)
+	return record, nil
+}
+
+// DeleteRecord exclui o registro (soft delete).
+// Permission: admin, manager.
+func (s *CustomObjectService) DeleteRecord(ctx context.Context, workspaceID, objectKey, recordID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return err
+	}
+
+	objectType, err := s.objectRepo.GetTypeByKey(ctx, workspaceID, objectKey)
+	if err != nil {
+		return err
+	}
+	if err := s.objectRepo.DeleteRecord(ctx, workspaceID, objectType.ID, recordID, actorID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", "custom_object_record", recordID)
+	return nil
+}
+
+// checkRelations garante que os IDs dos campos de relacionamento existem no workspace.
+func (s *CustomObjectService) checkRelations(ctx context.Context, workspaceID string, objectType *domain.CustomObjectType, data map[string]any) error {
+	for key, value := range data {
+		id, ok := value.(string)
+		if !ok {
+			continue
+		}
+		field, ok := objectType.Field(key)
+		if !ok || field.Type != domain.CustomFieldRelation {
+			continue
+		}
+
+		var err error
+		switch *field.Target {
+		case domain.CustomRelationContact:
+			_, err = s.contactRepo.Get(ctx, workspaceID, id)
+		case domain.CustomRelationCompany:
+			_, err = s.companyRepo.Get(ctx, workspaceID, id)
+		case domain.CustomRelationDeal:
+			_, err = s.dealRepo.Get(ctx, workspaceID, id)
+		}
+		if err != nil {
+			if errors.Is(err, repo.ErrContactNotFound) || errors.Is(err, repo.ErrCompanyNotFound) || errors.Is(err, repo.ErrDealNotFound) {
+				return ErrCustomObjectRelationNotFound
+			}
+			return fmt.Errorf("check %s relation: %w", *field.Target, err)
+		}
+	}
+	return nil
+}
+
+// parseCustomObjectFilters converte os valores da query string para o tipo de cada campo.
+func parseCustomObjectFilters(objectType *domain.CustomObjectType, raw map[string]string) (map[string]any, error) {
+	filters := make(map[string]any, len(raw))
+	for key, value := range raw {
+		field, ok := objectType.Field(key)
+		if !ok {
+			return nil, ErrCustomObjectFilterInvalid
+		}
+		switch field.Type {
+		case domain.CustomFieldNumber:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, ErrCustomObjectFilterInvalid
+			}
+			filters[key] = n
+		case domain.CustomFieldBoolean:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, ErrCustomObjectFilterInvalid
+			}
+			filters[key] = b
+		default:
+			filters[key] = value
+		}
+	}
+	return filters, nil
+}
+
+func (s *CustomObjectService) logAction(ctx context.Context, workspaceID, actorID, action, resource, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, resource, &idStr, nil, "", "")
+}
@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestCustomObjectService_Integration
+func TestCustomObjectService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	svc := service.NewCustomObjectService(repo.NewCustomObjectRepository(pool), repo.NewContactRepository(pool),
+		repo.NewCompanyRepository(pool), repo.NewDealRepository(pool), repo.NewWorkspaceRepository(pool),
+		repo.NewAuditRepo(pool), log)
+
+	target := domain.CustomRelationCompany
+	req := &domain.CreateCustomObjectTypeRequest{
+		Key:  "project",
+		Name: "Projeto",
+		Fields: []domain.CustomObjectField{
+			{Key: "code", Label: "Código", Type: domain.CustomFieldText, Required: true},
+			{Key: "budget", Label: "Orçamento", Type: domain.CustomFieldNumber},
+			{Key: "active", Label: "Ativo", Type: domain.CustomFieldBoolean},
+			{Key: "client", Label: "Cliente", Type: domain.CustomFieldRelation, Target: &target},
+		},
+	}
+	require.NoError(t, req.Validate())
+
+	t.Run("only admins define objects", func(t *testing.T) {
+		_, err := svc.CreateObjectType(ctx, f.WorkspaceID, f.Member(domain.RoleManager), req)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	objectType, err := svc.CreateObjectType(ctx, f.WorkspaceID, f.UserID, req)
+	require.NoError(t, err)
+	assert.Len(t, objectType.Fields, 4)
+
+	t.Run("keys are unique per workspace", func(t *testing.T) {
+		_, err := svc.CreateObjectType(ctx, f.WorkspaceID, f.UserID, req)
+		assert.ErrorIs(t, err, service.ErrCustomObjectKeyConflict)
+	})
+
+	t.Run("field types are immutable", func(t *testing.T) {
+		_, err := svc.UpdateObjectType(ctx, f.WorkspaceID, "project", f.UserID, &domain.UpdateCustomObjectTypeRequest{
+			Fields: []domain.CustomObjectField{{Key: "budget", Label: "Orçamento", Type: domain.CustomFieldText}},
+		})
+		assert.ErrorIs(t, err, service.ErrCustomFieldTypeChanged)
+	})
+
+	company := f.Company()
+	user := f.Member(domain.RoleUser)
+	create := func(t *testing.T, name string, data map[string]any) *domain.CustomObjectRecord {
+		t.Helper()
+		r := &domain.CreateCustomObjectRecordRequest{Name: name, Data: data}
+		require.NoError(t, r.Validate())
+		require.NoError(t, objectType.ValidateData(r.Data, false))
+		record, err := svc.CreateRecord(ctx, f.WorkspaceID, user, objectType, r)
+		require.NoError(t, err)
+		return record
+	}
+
+	t.Run("relations must exist in the workspace", func(t *testing.T) {
+		_, err := svc.CreateRecord(ctx, f.WorkspaceID, user, objectType, &domain.CreateCustomObjectRecordRequest{
+			Name: "Órfão", Data: map[string]any{"code": "P-0", "client": "cmp_missing"},
+		})
+		assert.ErrorIs(t, err, service.ErrCustomObjectRelationNotFound)
+	})
+
+	t.Run("viewers cannot create records", func(t *testing.T) {
+		_, err := svc.CreateRecord(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), objectType, &domain.CreateCustomObjectRecordRequest{
+			Name: "Leitura", Data: map[string]any{"code": "P-0"},
+		})
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	implantacao := create(t, "Implantação", map[string]any{"code": "P-1", "budget": 1500.0, "active": true, "client": company.ID})
+	create(t, "Migração", map[string]any{"code": "P-2", "budget": 800.0, "active": false})
+	assert.Equal(t, "project", implantacao.ObjectKey)
+	assert.Equal(t, company.ID, implantacao.Data["client"])
+
+	t.Run("filters are typed by field", func(t *testing.T) {
+		list, err := svc.ListRecords(ctx, f.WorkspaceID, "project", user, domain.ListCustomObjectRecordsParams{},
+			map[string]string{"active": "true"})
+		require.NoError(t, err)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, implantacao.ID, list.Data[0].ID)
+
+		list, err = svc.ListRecords(ctx, f.WorkspaceID, "project", user, domain.ListCustomObjectRecordsParams{},
+			map[string]string{"budget": "800"})
+		require.NoError(t, err)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, "Migração", list.Data[0].Name)
+
+		_, err = svc.ListRecords(ctx, f.WorkspaceID, "project", user, domain.ListCustomObjectRecordsParams{},
+			map[string]string{"budget": "muito"})
+		assert.ErrorIs(t, err, service.ErrCustomObjectFilterInvalid)
+
+		_, err = svc.ListRecords(ctx, f.WorkspaceID, "project", user, domain.ListCustomObjectRecordsParams{},
+			map[string]string{"owner": "x"})
+		assert.ErrorIs(t, err, service.ErrCustomObjectFilterInvalid)
+	})
+
+	t.Run("patch merges data and null clears a value", func(t *testing.T) {
+		updated, err := svc.UpdateRecord(ctx, f.WorkspaceID, implantacao.ID, user, objectType, &domain.UpdateCustomObjectRecordRequest{
+			Data: map[string]any{"budget": 2000.0, "client": nil},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "P-1", updated.Data["code"])
+		assert.Equal(t, 2000.0, updated.Data["budget"])
+		assert.NotContains(t, updated.Data, "client")
+	})
+
+	t.Run("only admins and managers delete records", func(t *testing.T) {
+		err := svc.DeleteRecord(ctx, f.WorkspaceID, "project", implantacao.ID, user)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		require.NoError(t, svc.DeleteRecord(ctx, f.WorkspaceID, "project", implantacao.ID, f.Member(domain.RoleManager)))
+		_, err = svc.GetRecord(ctx, f.WorkspaceID, "project", implantacao.ID, user)
+		assert.ErrorIs(t, err, service.ErrCustomObjectRecordNotFound)
+	})
+
+	t.Run("deleting the object hides it", func(t *testing.T) {
+		require.NoError(t, svc.DeleteObjectType(ctx, f.WorkspaceID, "project", f.UserID))
+		_, err := svc.GetObjectType(ctx, f.WorkspaceID, "project", user)
+		assert.ErrorIs(t, err, service.ErrCustomObjectTypeNotFound)
+	})
+}
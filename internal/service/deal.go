@@ -2,46 +2,88 @@ package service
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/base32"
 	"errors"
 	"fmt"
-	"strings"
+	"sync/atomic"
+	"time"
 
+	"linkko-api/internal/apperr"
 	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrDealStageInvalid = errors.New("invalid deal stage for this operation")
-	ErrPipelineConflict = errors.New("pipeline/stage does not belong to workspace")
-	ErrDealNotFound     = errors.New("deal not found")
+	ErrDealStageInvalid = apperr.Unprocessable(apperr.CodeInvalidStage, "invalid deal stage for this operation", "")
+	ErrPipelineConflict = apperr.Unprocessable(apperr.CodeValidationError, "pipeline/stage does not belong to workspace", "")
+	ErrDealNotFound     = apperr.NotFound("deal not found", "")
+	ErrNextStepRequired = apperr.Unprocessable(apperr.CodeNextStepRequired, "open deals require a future next step", "")
+
+	ErrInvalidForecastCategory = apperr.Unprocessable(apperr.CodeValidationError, "invalid forecast category",
+		"forecastCategory must be one of: COMMIT, BEST_CASE, PIPELINE, OMITTED, AUTO")
 )
 
 type DealService struct {
 	dealRepo      *repo.DealRepository
 	pipelineRepo  *repo.PipelineRepository
 	workspaceRepo *repo.WorkspaceRepository
+	userRepo      *repo.UserRepository // Validação do dono (ownerId)
 	auditRepo     *repo.AuditRepo
+	counters      *CounterService
+	history       *FieldHistoryService
+	businessHours *repo.BusinessHoursRepository
+	participants  *repo.DealParticipantRepository
+	boardPrefs    *repo.BoardPreferenceRepository // Personalização do board por usuário
+	followers     *FollowerService                // Seguidores e notificações
+	computed      *ComputedFieldService           // Campos calculados avaliados na leitura
+	sync          *SyncPolicyService              // Política de conflito dos upserts com ?system=
 	log           *logger.Logger
+
+	// requireNextStep exige nextStepAt futuro ao atualizar negócios OPEN (DEAL_REQUIRE_NEXT_STEP, recarregável)
+	requireNextStep atomic.Bool
 }
 
-func NewDealService(dealRepo *repo.DealRepository, pipelineRepo *repo.PipelineRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *DealService {
-	return &DealService{
+func NewDealService(dealRepo *repo.DealRepository, pipelineRepo *repo.PipelineRepository, workspaceRepo *repo.WorkspaceRepository, userRepo *repo.UserRepository, auditRepo *repo.AuditRepo, counters *CounterService, history *FieldHistoryService, businessHours *repo.BusinessHoursRepository, participants *repo.DealParticipantRepository, boardPrefs *repo.BoardPreferenceRepository, followers *FollowerService, computed *ComputedFieldService, log *logger.Logger, requireNextStep bool) *DealService {
+	s := &DealService{
 		dealRepo:      dealRepo,
 		pipelineRepo:  pipelineRepo,
 		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
 		auditRepo:     auditRepo,
+		counters:      counters,
+		history:       history,
+		businessHours: businessHours,
+		participants:  participants,
+		boardPrefs:    boardPrefs,
+		followers:     followers,
+		computed:      computed,
 		log:           log,
 	}
+	s.requireNextStep.Store(requireNextStep)
+	return s
+}
+
+// WithSyncPolicies aplica as políticas de conflito das integrações aos upserts com system.
+func (s *DealService) WithSyncPolicies(sync *SyncPolicyService) *DealService {
+	s.sync = sync
+	return s
+}
+
+// SetRequireNextStep aplica um novo DEAL_REQUIRE_NEXT_STEP (reload de configuração)
+func (s *DealService) SetRequireNextStep(required bool) {
+	s.requireNextStep.Store(required)
 }
 
 // getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
 func (s *DealService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
 	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		s.log.Error(ctx, "failed to get member role",
 			logger.Module("deal"),
@@ -64,6 +106,9 @@ func (s *DealService) getMemberRoleWithLogging(ctx context.Context, actorID, wor
 }
 
 func (s *DealService) CreateDeal(ctx context.Context, workspaceID, actorID string, req *domain.CreateDealRequest) (*domain.Deal, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.CreateDeal")
+	defer span.End()
+
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
 		return nil, err
@@ -71,14 +116,25 @@ func (s *DealService) CreateDeal(ctx context.Context, workspaceID, actorID strin
 	if !domain.CanModifyContacts(role) {
 		return nil, ErrUnauthorized
 	}
+	if err := checkForecastCategory(req.ForecastCategory); err != nil {
+		return nil, err
+	}
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.OwnerID); err != nil {
+		return nil, err
+	}
 
 	// Validate Pipeline/Stage
 	if req.StageID != nil {
 		// In production, validate if StageID belongs to PipelineID and WorkspaceID
 	}
 
+	dealID, err := id.New(id.Deal)
+	if err != nil {
+		return nil, err
+	}
+
 	deal := &domain.Deal{
-		ID:                generateDealID(),
+		ID:                dealID,
 		WorkspaceID:       workspaceID,
 		PipelineID:        req.PipelineID,
 		StageID:           req.StageID,
@@ -93,8 +149,12 @@ func (s *DealService) CreateDeal(ctx context.Context, workspaceID, actorID strin
 		Description:       req.Description,
 		OwnerID:           req.OwnerID,
 		CreatedByID:       actorID,
+		Attribution:       req.Attribution,
+		NextStep:          req.NextStep,
+		ExternalID:        req.ExternalID,
 	}
 
+	deal.DefaultSource(domain.SourceManual)
 	if deal.Currency == "" {
 		deal.Currency = "BRL"
 	}
@@ -102,6 +162,10 @@ func (s *DealService) CreateDeal(ctx context.Context, workspaceID, actorID strin
 		p := int32(50)
 		deal.Probability = &p
 	}
+	if req.ForecastCategory != nil && *req.ForecastCategory != domain.ForecastAuto {
+		deal.ForecastCategory = *req.ForecastCategory
+		deal.ForecastCategoryOverridden = true
+	}
 
 	created, err := s.dealRepo.Create(ctx, deal)
 	if err != nil {
@@ -111,10 +175,16 @@ func (s *DealService) CreateDeal(ctx context.Context, workspaceID, actorID strin
 	// Audit
 	s.logDealAction(ctx, workspaceID, actorID, "create", created.ID)
 
+	s.counters.DealChanged(ctx, workspaceID, nil, created)
+	s.followers.AutoFollow(ctx, workspaceID, domain.FollowEntityDeal, created.ID, created.OwnerID, domain.FollowReasonAssigned)
+
 	return created, nil
 }
 
 func (s *DealService) GetDeal(ctx context.Context, workspaceID, dealID, actorID string) (*domain.Deal, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.GetDeal")
+	defer span.End()
+
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
 		return nil, err
@@ -123,10 +193,20 @@ func (s *DealService) GetDeal(ctx context.Context, workspaceID, dealID, actorID
 		return nil, ErrUnauthorized
 	}
 
-	return s.dealRepo.Get(ctx, workspaceID, dealID)
+	deal, err := s.dealRepo.Get(ctx, workspaceID, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachSLA(ctx, workspaceID, []*domain.Deal{deal})
+	s.attachComputed(ctx, workspaceID, []*domain.Deal{deal})
+	return deal, nil
 }
 
-func (s *DealService) ListDeals(ctx context.Context, workspaceID, actorID string, pipelineID, stageID, ownerID *string) ([]domain.Deal, error) {
+func (s *DealService) ListDeals(ctx context.Context, workspaceID, actorID string, pipelineID, stageID, ownerID, followerID, query *string, rottingOnly bool, attribution domain.AttributionFilter) ([]domain.Deal, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.ListDeals")
+	defer span.End()
+
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
 		return nil, err
@@ -135,54 +215,336 @@ func (s *DealService) ListDeals(ctx context.Context, workspaceID, actorID string
 		return nil, ErrUnauthorized
 	}
 
-	return s.dealRepo.List(ctx, workspaceID, pipelineID, stageID, ownerID)
+	deals, err := s.dealRepo.List(ctx, workspaceID, pipelineID, stageID, ownerID, followerID, query, rottingOnly, attribution)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*domain.Deal, len(deals))
+	for i := range deals {
+		ptrs[i] = &deals[i]
+	}
+	s.attachSLA(ctx, workspaceID, ptrs)
+	s.attachComputed(ctx, workspaceID, ptrs)
+	return deals, nil
 }
 
-func (s *DealService) UpdateDeal(ctx context.Context, workspaceID, dealID, actorID string, req *domain.UpdateDealRequest) (*domain.Deal, error) {
+// WorkspaceLocation fuso padrão dos agrupamentos por mês (sem ?tz=).
+func (s *DealService) WorkspaceLocation(ctx context.Context, workspaceID string) (*time.Location, error) {
+	return workspaceLocation(ctx, s.businessHours, workspaceID)
+}
+
+// AggregateDeals agrupa os deals filtrados (GET /deals?groupBy=) em buckets com os agregados pedidos.
+// Permission: all workspace members.
+func (s *DealService) AggregateDeals(ctx context.Context, workspaceID, actorID string, params domain.DealAggregateParams) (*domain.DealAggregation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.AggregateDeals")
+	defer span.End()
+
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
 		return nil, err
 	}
-	if !domain.CanModifyContacts(role) {
+	if !domain.IsWorkspaceMember(role) {
 		return nil, ErrUnauthorized
 	}
 
+	params.WorkspaceID = workspaceID
+	buckets, err := s.dealRepo.Aggregate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewDealAggregation(params, buckets), nil
+}
+
+// attachComputed preenche os campos calculados do workspace.
+// Falha na avaliação não impede a leitura: o campo computed é apenas omitido.
+func (s *DealService) attachComputed(ctx context.Context, workspaceID string, deals []*domain.Deal) {
+	if s.computed == nil || len(deals) == 0 {
+		return
+	}
+
+	ids := make([]string, len(deals))
+	for i, d := range deals {
+		ids[i] = d.ID
+	}
+
+	values, err := s.computed.Evaluate(ctx, workspaceID, domain.ComputedFieldDeal, ids)
+	if err != nil {
+		s.log.Warn(ctx, "failed to evaluate computed fields", zap.String("workspace_id", workspaceID), zap.Error(err))
+		return
+	}
+	for _, d := range deals {
+		if v, ok := values[d.ID]; ok {
+			d.Computed = v
+		}
+	}
+}
+
+// attachSLA preenche os timers de SLA dos deals em estágio TICKET com metas configuradas.
+// Falha no cálculo não impede a leitura do deal: o campo sla é apenas omitido.
+func (s *DealService) attachSLA(ctx context.Context, workspaceID string, deals []*domain.Deal) {
+	if len(deals) == 0 {
+		return
+	}
+
+	ids := make([]string, len(deals))
+	for i, d := range deals {
+		ids[i] = d.ID
+	}
+
+	inputs, err := s.dealRepo.ListSLAInputs(ctx, workspaceID, ids, nil, nil, false)
+	if err != nil {
+		s.log.Warn(ctx, "failed to compute deal sla", zap.String("workspace_id", workspaceID), zap.Error(err))
+		return
+	}
+	if len(inputs) == 0 {
+		return
+	}
+
+	// Timers pausam fora do expediente do workspace
+	cal, err := s.businessHours.GetCalendar(ctx, workspaceID)
+	if err != nil {
+		s.log.Warn(ctx, "failed to load business calendar", zap.String("workspace_id", workspaceID), zap.Error(err))
+		return
+	}
+
+	now := time.Now().UTC()
+	byID := make(map[string]*domain.DealSLA, len(inputs))
+	for _, in := range inputs {
+		byID[in.DealID] = in.Compute(cal, now)
+	}
+	for _, d := range deals {
+		d.SLA = byID[d.ID]
+	}
+}
+
+// AttachParticipants preenche os participantes dos deals (?expand=participants).
+// Deals sem participantes recebem lista vazia.
+func (s *DealService) AttachParticipants(ctx context.Context, workspaceID string, deals []*domain.Deal) error {
+	if len(deals) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(deals))
+	for i, d := range deals {
+		ids[i] = d.ID
+	}
+
+	byDeal, err := s.participants.ListForDeals(ctx, workspaceID, ids)
+	if err != nil {
+		return err
+	}
+	for _, d := range deals {
+		d.Participants = byDeal[d.ID]
+		if d.Participants == nil {
+			d.Participants = []domain.DealParticipant{}
+		}
+	}
+	return nil
+}
+
+func (s *DealService) UpdateDeal(ctx context.Context, workspaceID, dealID, actorID string, req *domain.UpdateDealRequest) (*domain.Deal, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.UpdateDeal")
+	defer span.End()
+
+	_, updated, err := s.updateDeal(ctx, workspaceID, dealID, actorID, req)
+	return updated, err
+}
+
+// UpdateDealExtended é o UpdateDeal com return=extended: inclui os totais dos estágios
+// afetados (o de antes e, se mudou, o novo).
+func (s *DealService) UpdateDealExtended(ctx context.Context, workspaceID, dealID, actorID string, req *domain.UpdateDealRequest) (*domain.DealMutationResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.UpdateDealExtended")
+	defer span.End()
+
+	current, updated, err := s.updateDeal(ctx, workspaceID, dealID, actorID, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.withStageAggregates(ctx, workspaceID, current, updated)
+}
+
+func (s *DealService) updateDeal(ctx context.Context, workspaceID, dealID, actorID string, req *domain.UpdateDealRequest) (*domain.Deal, *domain.Deal, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, nil, ErrUnauthorized
+	}
+	if err := checkForecastCategory(req.ForecastCategory); err != nil {
+		return nil, nil, err
+	}
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.OwnerID); err != nil {
+		return nil, nil, err
+	}
+
+	// Estado anterior: regra de próximo passo e histórico por campo
+	current, err := s.dealRepo.Get(ctx, workspaceID, dealID)
+	if err != nil {
+		if errors.Is(err, repo.ErrDealNotFound) {
+			return nil, nil, ErrDealNotFound
+		}
+		return nil, nil, err
+	}
+
+	if s.requireNextStep.Load() {
+		if err := checkNextStep(current, req); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	updated, err := s.dealRepo.Update(ctx, workspaceID, dealID, req, actorID)
 	if err != nil {
 		if errors.Is(err, repo.ErrDealNotFound) {
-			return nil, ErrDealNotFound
+			return nil, nil, ErrDealNotFound
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
 	s.logDealAction(ctx, workspaceID, actorID, "update", dealID)
 
-	return updated, nil
+	s.history.Record(ctx, workspaceID, actorID, domain.HistoryEntityDeal, dealID, "update", current, updated)
+	s.followers.Changed(ctx, workspaceID, domain.FollowEntityDeal, dealID, actorID, current.OwnerID, updated.OwnerID)
+
+	return current, updated, nil
+}
+
+// UpsertDealByExternalID cria ou atualiza o deal identificado pela chave do integrador
+// (PUT .../deals/by-external-id/{externalId}); created indica se o deal foi criado.
+// Corridas com o mesmo externalId são resolvidas como em ContactService.UpsertContactByExternalID.
+// Com system (a integração de origem), a política de conflito dela decide os campos gravados
+// por outros sistemas (SyncPolicyService).
+// Permission: as de CreateDeal e UpdateDeal.
+func (s *DealService) UpsertDealByExternalID(ctx context.Context, workspaceID, externalID, actorID, system string, req *domain.CreateDealRequest) (*domain.Deal, bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.UpsertDealByExternalID")
+	defer span.End()
+
+	req.ExternalID = &externalID
+
+	for attempt := 0; attempt < upsertMaxAttempts; attempt++ {
+		dealID, err := s.dealRepo.FindIDByExternalID(ctx, workspaceID, externalID)
+		if err != nil {
+			return nil, false, fmt.Errorf("find deal by external id: %w", err)
+		}
+
+		if dealID == nil {
+			plan, err := s.sync.planUpsert(ctx, workspaceID, system, domain.IdentityEntityDeal, "", nil, req.UpsertUpdate())
+			if err != nil {
+				return nil, false, err
+			}
+			deal, err := s.CreateDeal(ctx, workspaceID, actorID, req)
+			if errors.Is(err, ErrExternalIDConflict) {
+				continue // criado por outra request entre a busca e o insert
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			s.sync.recordUpsert(ctx, plan, deal.ID)
+			return deal, true, nil
+		}
+
+		update := req.UpsertUpdate()
+		var plan *syncPlan
+		if system != "" {
+			current, err := s.dealRepo.Get(ctx, workspaceID, *dealID)
+			if errors.Is(err, repo.ErrDealNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			if plan, err = s.sync.planUpsert(ctx, workspaceID, system, domain.IdentityEntityDeal, *dealID, current, update); err != nil {
+				return nil, false, err
+			}
+			if err := domain.OmitJSONFields(update, plan.rejectedFields()); err != nil {
+				return nil, false, err
+			}
+		}
+
+		_, deal, err := s.updateDeal(ctx, workspaceID, *dealID, actorID, update)
+		if errors.Is(err, ErrDealNotFound) {
+			continue // removido entre a busca e o update
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		s.sync.recordUpsert(ctx, plan, deal.ID)
+		return deal, false, nil
+	}
+
+	return nil, false, ErrUpsertContention
+}
+
+// checkNextStep garante que um negócio OPEN continue com próximo passo futuro após o update.
+// O nextStepAt do request tem precedência sobre o valor atual.
+func checkNextStep(current *domain.Deal, req *domain.UpdateDealRequest) error {
+	if current.Stage != domain.DealStageOpen {
+		return nil
+	}
+
+	next := current.NextStep
+	if req.NextStepAt != nil {
+		next.NextStepAt = req.NextStepAt
+	}
+	if !next.IsScheduledAfter(time.Now()) {
+		return ErrNextStepRequired
+	}
+	return nil
+}
+
+// checkForecastCategory valida a categoria manual (AUTO volta a derivar da etapa).
+func checkForecastCategory(category *domain.ForecastCategory) error {
+	if category == nil || *category == domain.ForecastAuto || category.IsValid() {
+		return nil
+	}
+	return ErrInvalidForecastCategory
 }
 
 // UpdateDealStage handles the transactional movement of a deal through the funnel.
 func (s *DealService) UpdateDealStage(ctx context.Context, workspaceID, dealID, actorID string, req *domain.UpdateDealStageRequest) (*domain.Deal, error) {
-	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	ctx, span := telemetry.StartSpan(ctx, "DealService.UpdateDealStage")
+	defer span.End()
+
+	_, updated, err := s.moveDealStage(ctx, workspaceID, dealID, actorID, req)
+	return updated, err
+}
+
+// UpdateDealStageExtended é o UpdateDealStage com return=extended: inclui os totais dos
+// estágios de origem e destino.
+func (s *DealService) UpdateDealStageExtended(ctx context.Context, workspaceID, dealID, actorID string, req *domain.UpdateDealStageRequest) (*domain.DealMutationResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.UpdateDealStageExtended")
+	defer span.End()
+
+	current, updated, err := s.moveDealStage(ctx, workspaceID, dealID, actorID, req)
 	if err != nil {
 		return nil, err
 	}
+	return s.withStageAggregates(ctx, workspaceID, current, updated)
+}
+
+func (s *DealService) moveDealStage(ctx context.Context, workspaceID, dealID, actorID string, req *domain.UpdateDealStageRequest) (*domain.Deal, *domain.Deal, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
 	if !domain.CanModifyContacts(role) {
-		return nil, ErrUnauthorized
+		return nil, nil, ErrUnauthorized
 	}
 
 	// 1. Get current deal to know fromStage
 	current, err := s.dealRepo.Get(ctx, workspaceID, dealID)
 	if err != nil {
 		if errors.Is(err, repo.ErrDealNotFound) {
-			return nil, ErrDealNotFound
+			return nil, nil, ErrDealNotFound
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 2. Start Transaction
 	tx, err := s.dealRepo.BeginTx(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer tx.Rollback(ctx)
 
@@ -191,12 +553,17 @@ func (s *DealService) UpdateDealStage(ctx context.Context, workspaceID, dealID,
 	// 3. Update Deal Stage
 	updated, err := repoTx.MoveStage(ctx, workspaceID, dealID, req, actorID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 4. Record History
+	dealStageHistoryID, err := id.New(id.DealStageHistory)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	history := &domain.DealStageHistory{
-		ID:          generateDealID(),
+		ID:          dealStageHistoryID,
 		WorkspaceID: workspaceID,
 		DealID:      dealID,
 		FromStage:   current.Stage,
@@ -205,24 +572,44 @@ func (s *DealService) UpdateDealStage(ctx context.Context, workspaceID, dealID,
 		UserID:      actorID,
 	}
 	if err := repoTx.CreateHistory(ctx, history); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 5. Commit
 	if err := tx.Commit(ctx); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	s.logDealAction(ctx, workspaceID, actorID, "move_stage", dealID)
 
-	return updated, nil
+	s.counters.DealChanged(ctx, workspaceID, current, updated)
+
+	s.history.Record(ctx, workspaceID, actorID, domain.HistoryEntityDeal, dealID, "move_stage", current, updated)
+	s.followers.Notify(ctx, workspaceID, domain.FollowEntityDeal, dealID, actorID, domain.NotificationStageChanged)
+
+	return current, updated, nil
 }
 
-// Helpers
-func generateDealID() string {
-	b := make([]byte, 16)
-	rand.Read(b)
-	return "c" + strings.ToLower(base32.StdEncoding.EncodeToString(b)[:24])
+// withStageAggregates monta a resposta extended com os estágios de antes e depois da mutação.
+func (s *DealService) withStageAggregates(ctx context.Context, workspaceID string, before, after *domain.Deal) (*domain.DealMutationResult, error) {
+	var stageIDs []string
+	for _, id := range []*string{before.StageID, after.StageID} {
+		if id != nil && (len(stageIDs) == 0 || stageIDs[0] != *id) {
+			stageIDs = append(stageIDs, *id)
+		}
+	}
+
+	result := &domain.DealMutationResult{Deal: after}
+	if len(stageIDs) == 0 {
+		return result, nil
+	}
+
+	stages, err := s.dealRepo.StageAggregates(ctx, workspaceID, stageIDs)
+	if err != nil {
+		return nil, err
+	}
+	result.Aggregates.Stages = stages
+	return result, nil
 }
 
 func (s *DealService) logDealAction(ctx context.Context, workspaceID, actorID, action, dealID string) {
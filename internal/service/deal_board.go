@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/telemetry"
+
+	"go.uber.org/zap"
+)
+
+var ErrBoardPreferenceStageInvalid = apperr.Unprocessable(apperr.CodeValidationError,
+	"board preference references a stage outside the pipeline", "stage ids must belong to the pipeline")
+
+// GetDealBoard returns the deal board of a pipeline with the actor's column preferences applied:
+// stages in the actor's order, hidden stages left out and collapsed stages with totals only.
+// Permission: all workspace members.
+func (s *DealService) GetDealBoard(ctx context.Context, workspaceID, actorID string, params domain.DealBoardParams) (*domain.DealBoard, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.GetDealBoard")
+	defer span.End()
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+	params.Normalize()
+
+	stages, err := s.boardStages(ctx, workspaceID, params.PipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.boardPrefs.Get(ctx, workspaceID, actorID, params.PipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("get board preference: %w", err)
+	}
+	prefs.Normalize(stages)
+
+	pipelineID := params.PipelineID
+	deals, err := s.dealRepo.List(ctx, workspaceID, &pipelineID, nil, nil, nil, nil, false, domain.AttributionFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list board deals: %w", err)
+	}
+
+	board := domain.NewDealBoard(params.PipelineID, stages, *prefs, deals, params.Limit)
+
+	var visible []*domain.Deal
+	for i := range board.Columns {
+		for j := range board.Columns[i].Deals {
+			visible = append(visible, &board.Columns[i].Deals[j])
+		}
+	}
+	s.attachSLA(ctx, workspaceID, visible)
+	s.attachComputed(ctx, workspaceID, visible)
+	return board, nil
+}
+
+// UpdateBoardPreferences replaces the actor's board preferences for a pipeline.
+// Permission: all workspace members (each user only edits their own preferences).
+func (s *DealService) UpdateBoardPreferences(ctx context.Context, workspaceID, actorID string, req *domain.UpdateBoardPreferenceRequest) (*domain.BoardPreference, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DealService.UpdateBoardPreferences")
+	defer span.End()
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	stages, err := s.boardStages(ctx, workspaceID, req.PipelineID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(stages))
+	for _, stage := range stages {
+		known[stage.ID] = true
+	}
+	for _, ids := range [][]string{req.StageOrder, req.CollapsedStageIDs, req.HiddenStageIDs} {
+		for _, stageID := range ids {
+			if !known[stageID] {
+				return nil, fmt.Errorf("%w: %s", ErrBoardPreferenceStageInvalid, stageID)
+			}
+		}
+	}
+
+	prefs := &domain.BoardPreference{
+		PipelineID:        req.PipelineID,
+		StageOrder:        req.StageOrder,
+		CollapsedStageIDs: req.CollapsedStageIDs,
+		HiddenStageIDs:    req.HiddenStageIDs,
+	}
+	prefs.Normalize(stages)
+
+	saved, err := s.boardPrefs.Upsert(ctx, workspaceID, actorID, prefs)
+	if err != nil {
+		return nil, err
+	}
+	saved.Normalize(stages)
+
+	s.log.Info(ctx, "board preferences updated",
+		logger.Module("deal"),
+		logger.Action("update_board_preferences"),
+		zap.String("workspace_id", workspaceID),
+		zap.String("pipeline_id", req.PipelineID),
+		zap.String("actor_id", actorID),
+	)
+	return saved, nil
+}
+
+// boardStages retorna as etapas do pipeline (em orderIndex), validando que ele pertence ao workspace.
+func (s *DealService) boardStages(ctx context.Context, workspaceID, pipelineID string) ([]domain.PipelineStage, error) {
+	if _, err := s.pipelineRepo.Get(ctx, workspaceID, pipelineID); err != nil {
+		return nil, fmt.Errorf("get pipeline: %w", err)
+	}
+	stages, err := s.pipelineRepo.ListStagesByPipeline(ctx, workspaceID, &pipelineID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list stages: %w", err)
+	}
+	return stages, nil
+}
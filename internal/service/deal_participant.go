@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrDealParticipantNotFound = repo.ErrDealParticipantNotFound
+	ErrDealParticipantConflict = repo.ErrDealParticipantConflict
+	ErrInvalidContact          = apperr.Unprocessable(apperr.CodeValidationError, "contact_id does not belong to workspace", "contact does not belong to workspace")
+)
+
+// DealParticipantService gerencia os contatos participantes de um negócio (decisor,
+// champion, financeiro...). Negócios B2B envolvem várias pessoas além do contato principal.
+type DealParticipantService struct {
+	participantRepo *repo.DealParticipantRepository
+	dealRepo        *repo.DealRepository
+	contactRepo     *repo.ContactRepository
+	workspaceRepo   *repo.WorkspaceRepository
+	auditRepo       *repo.AuditRepo
+	log             *logger.Logger
+}
+
+func NewDealParticipantService(participantRepo *repo.DealParticipantRepository, dealRepo *repo.DealRepository, contactRepo *repo.ContactRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *DealParticipantService {
+	return &DealParticipantService{
+		participantRepo: participantRepo,
+		dealRepo:        dealRepo,
+		contactRepo:     contactRepo,
+		workspaceRepo:   workspaceRepo,
+		auditRepo:       auditRepo,
+		log:             log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *DealParticipantService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("deal_participant"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// ListParticipants lista os participantes de um negócio.
+// Permission: all workspace members.
+func (s *DealParticipantService) ListParticipants(ctx context.Context, workspaceID, dealID, actorID string) ([]domain.DealParticipant, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if _, err := s.dealRepo.Get(ctx, workspaceID, dealID); err != nil {
+		return nil, err
+	}
+
+	return s.participantRepo.List(ctx, workspaceID, dealID)
+}
+
+// AddParticipant inclui um contato do workspace no negócio.
+// Permission: admin, manager, agent.
+func (s *DealParticipantService) AddParticipant(ctx context.Context, workspaceID, dealID, actorID string, req *domain.AddDealParticipantRequest) (*domain.DealParticipant, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if _, err := s.dealRepo.Get(ctx, workspaceID, dealID); err != nil {
+		return nil, err
+	}
+	if _, err := s.contactRepo.Get(ctx, workspaceID, req.ContactID); err != nil {
+		if errors.Is(err, repo.ErrContactNotFound) {
+			return nil, ErrInvalidContact
+		}
+		return nil, fmt.Errorf("validate contact: %w", err)
+	}
+
+	dealParticipantID, err := id.New(id.DealParticipant)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.Add(ctx, &domain.DealParticipant{
+		ID:          dealParticipantID,
+		WorkspaceID: workspaceID,
+		DealID:      dealID,
+		ContactID:   req.ContactID,
+		Role:        req.Role,
+		CreatedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "participant_add", "deal", &dealID, nil, "", "")
+
+	return participant, nil
+}
+
+// UpdateParticipant troca o papel de um participante.
+// Permission: admin, manager, agent.
+func (s *DealParticipantService) UpdateParticipant(ctx context.Context, workspaceID, dealID, contactID, actorID string, req *domain.UpdateDealParticipantRequest) (*domain.DealParticipant, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	participant, err := s.participantRepo.UpdateRole(ctx, workspaceID, dealID, contactID, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "participant_update", "deal", &dealID, nil, "", "")
+
+	return participant, nil
+}
+
+// RemoveParticipant retira um contato do negócio.
+// Permission: admin, manager, agent.
+func (s *DealParticipantService) RemoveParticipant(ctx context.Context, workspaceID, dealID, contactID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanModifyContacts(role) {
+		return ErrUnauthorized
+	}
+
+	if err := s.participantRepo.Remove(ctx, workspaceID, dealID, contactID); err != nil {
+		return err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "participant_remove", "deal", &dealID, nil, "", "")
+
+	return nil
+}
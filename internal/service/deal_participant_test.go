@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestDealParticipantService_Integration
+func TestDealParticipantService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	participants := repo.NewDealParticipantRepository(pool)
+	svc := service.NewDealParticipantService(participants, repo.NewDealRepository(pool), repo.NewContactRepository(pool),
+		repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool), log)
+
+	add := func(actorID, dealID, contactID string, role domain.DealParticipantRole) (*domain.DealParticipant, error) {
+		return svc.AddParticipant(ctx, f.WorkspaceID, dealID, actorID, &domain.AddDealParticipantRequest{ContactID: contactID, Role: role})
+	}
+
+	t.Run("a contact holds a single role per deal", func(t *testing.T) {
+		deal := f.Deal()
+		contact := f.Contact()
+
+		created, err := add(f.UserID, deal.ID, contact.ID, domain.ParticipantChampion)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ParticipantChampion, created.Role)
+		assert.Equal(t, contact.FullName, *created.ContactName)
+
+		_, err = add(f.UserID, deal.ID, contact.ID, domain.ParticipantBilling)
+		assert.ErrorIs(t, err, service.ErrDealParticipantConflict, "the role is changed with update, not a second add")
+
+		updated, err := svc.UpdateParticipant(ctx, f.WorkspaceID, deal.ID, contact.ID, f.UserID,
+			&domain.UpdateDealParticipantRequest{Role: domain.ParticipantDecisionMaker})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, updated.ID)
+		assert.Equal(t, domain.ParticipantDecisionMaker, updated.Role)
+
+		_, err = add(f.UserID, f.Deal().ID, contact.ID, domain.ParticipantBilling)
+		assert.NoError(t, err, "the same contact can take part in another deal")
+
+		list, err := svc.ListParticipants(ctx, f.WorkspaceID, deal.ID, f.Member(domain.RoleViewer))
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, domain.ParticipantDecisionMaker, list[0].Role)
+	})
+
+	t.Run("rejects contacts and deals from another workspace", func(t *testing.T) {
+		deal := f.Deal()
+		foreignContact := other.Contact()
+
+		_, err := add(f.UserID, deal.ID, foreignContact.ID, domain.ParticipantOther)
+		assert.ErrorIs(t, err, service.ErrInvalidContact)
+
+		_, err = add(f.UserID, other.Deal().ID, f.Contact().ID, domain.ParticipantOther)
+		assert.ErrorIs(t, err, repo.ErrDealNotFound)
+
+		_, err = svc.ListParticipants(ctx, other.WorkspaceID, deal.ID, other.UserID)
+		assert.ErrorIs(t, err, repo.ErrDealNotFound)
+
+		list, err := participants.List(ctx, f.WorkspaceID, deal.ID)
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+
+	t.Run("only roles that modify contacts change participants", func(t *testing.T) {
+		deal := f.Deal()
+		contact := f.Contact()
+
+		_, err := add(f.Member(domain.RoleViewer), deal.ID, contact.ID, domain.ParticipantOther)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		_, err = add(f.Member(domain.RoleUser), deal.ID, contact.ID, domain.ParticipantOther)
+		require.NoError(t, err)
+		err = svc.RemoveParticipant(ctx, f.WorkspaceID, deal.ID, contact.ID, f.Member(domain.RoleViewer))
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	t.Run("participants go away with the deal", func(t *testing.T) {
+		deal := f.Deal()
+		contact := f.Contact()
+		_, err := add(f.UserID, deal.ID, contact.ID, domain.ParticipantInfluencer)
+		require.NoError(t, err)
+
+		_, err = pool.Exec(ctx, `UPDATE public."Deal" SET "deletedAt" = NOW() WHERE id = $1`, deal.ID)
+		require.NoError(t, err)
+		_, err = svc.ListParticipants(ctx, f.WorkspaceID, deal.ID, f.UserID)
+		assert.ErrorIs(t, err, repo.ErrDealNotFound, "a deleted deal has no participants to list")
+
+		// Purga da lixeira: o CASCADE da FK remove os participantes
+		_, err = pool.Exec(ctx, `DELETE FROM public."Deal" WHERE id = $1`, deal.ID)
+		require.NoError(t, err)
+		var remaining int
+		err = pool.QueryRow(ctx, `SELECT COUNT(*) FROM public."DealParticipant" WHERE "dealId" = $1`, deal.ID).Scan(&remaining)
+		require.NoError(t, err)
+		assert.Zero(t, remaining)
+	})
+}
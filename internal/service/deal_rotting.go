@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"go.uber.org/zap"
+)
+
+// DealRottingService mantém o rottingSince dos deals sem atividade nem mudança de estágio
+// por mais de rottingDays do estágio, e registra um evento DEAL_ROTTING na timeline de
+// cada deal marcado (gatilho para automações). rottingDays conta dias úteis do calendário
+// do workspace. Executado pelo deal-rotting-worker.
+type DealRottingService struct {
+	dealRepo      *repo.DealRepository
+	activityRepo  *repo.ActivityRepository
+	businessHours *repo.BusinessHoursRepository
+	log           *logger.Logger
+}
+
+func NewDealRottingService(dealRepo *repo.DealRepository, activityRepo *repo.ActivityRepository, businessHours *repo.BusinessHoursRepository, log *logger.Logger) *DealRottingService {
+	return &DealRottingService{
+		dealRepo:      dealRepo,
+		activityRepo:  activityRepo,
+		businessHours: businessHours,
+		log:           log,
+	}
+}
+
+// ProcessRotting desmarca os deals que voltaram a ter atividade e marca até limit deals
+// parados. Retorna quantos deals foram marcados.
+func (s *DealRottingService) ProcessRotting(ctx context.Context, now time.Time, limit int) (int, error) {
+	cleared, err := s.dealRepo.ClearRecovered(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	if cleared > 0 {
+		s.log.Info(ctx, "deals no longer rotting",
+			logger.Module("deal_rotting"),
+			logger.Action("clear"),
+			zap.Int64("cleared", cleared),
+		)
+	}
+
+	// Candidatos já venceram em dias corridos; o prazo em dias úteis é sempre igual ou
+	// posterior, então só os que também venceram no calendário do workspace são marcados
+	candidates, err := s.dealRepo.ListRottingCandidates(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	calendars := make(map[string]*domain.BusinessCalendar)
+	flagged := 0
+	for i := range candidates {
+		d := &candidates[i]
+
+		cal, ok := calendars[d.WorkspaceID]
+		if !ok {
+			cal, err = s.businessHours.GetCalendar(ctx, d.WorkspaceID)
+			if err != nil {
+				return flagged, err
+			}
+			calendars[d.WorkspaceID] = cal
+		}
+
+		d.RottingSince = cal.AddWorkingDays(d.LastActivityAt, d.RottingDays)
+		if d.RottingSince.After(now) {
+			continue
+		}
+
+		marked, err := s.dealRepo.MarkRotting(ctx, d.ID, d.RottingSince)
+		if err != nil {
+			return flagged, err
+		}
+		if !marked {
+			continue
+		}
+
+		s.emitRottingEvent(ctx, d)
+		flagged++
+	}
+
+	return flagged, nil
+}
+
+// emitRottingEvent registra o DEAL_ROTTING na timeline do deal (e do contato/empresa).
+// Best-effort: o deal já está marcado.
+func (s *DealRottingService) emitRottingEvent(ctx context.Context, d *domain.RottingDeal) {
+	// Atividade exige um usuário: o dono do deal ou, sem dono, quem o criou
+	userID := d.CreatedByID
+	if d.OwnerID != nil && *d.OwnerID != "" {
+		userID = *d.OwnerID
+	}
+
+	metadata := map[string]interface{}{
+		"pipelineId":     d.PipelineID,
+		"rottingDays":    d.RottingDays,
+		"lastActivityAt": d.LastActivityAt,
+		"rottingSince":   d.RottingSince,
+	}
+	if d.StageID != nil {
+		metadata["stageId"] = *d.StageID
+	}
+	metadataJSON, _ := json.Marshal(metadata)
+
+	activityID, err := id.New(id.Activity)
+	if err == nil {
+		_, err = s.activityRepo.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: d.WorkspaceID,
+			CompanyID:   d.CompanyID,
+			ContactID:   d.ContactID,
+			DealID:      &d.ID,
+			Type:        domain.ActivityTypeDealRotting,
+			UserID:      userID,
+			Metadata:    metadataJSON,
+			CreatedAt:   time.Now(),
+		})
+	}
+	if err != nil {
+		s.log.Warn(ctx, "failed to record deal rotting activity",
+			logger.Module("deal_rotting"),
+			logger.Action("flag"),
+			zap.String("deal_id", d.ID),
+			zap.String("workspace_id", d.WorkspaceID),
+			zap.Error(err),
+		)
+	}
+}
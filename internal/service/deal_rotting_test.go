@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestDealRottingService_ProcessRotting_Integration
+func TestDealRottingService_ProcessRotting_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	deals := repo.NewDealRepository(pool)
+	activities := repo.NewActivityRepository(pool)
+	svc := service.NewDealRottingService(deals, activities, repo.NewBusinessHoursRepository(pool), log)
+
+	// Primeira etapa do pipeline da factory apodrece em 1 dia; a segunda não tem limite.
+	// Sem horário comercial configurado, rottingDays conta dias corridos.
+	pipeline := f.Pipeline()
+	rottingStage, freshStage := pipeline.Stages[0].ID, pipeline.Stages[1].ID
+	_, err = pool.Exec(ctx, `UPDATE public."PipelineStage" SET "rottingDays" = 1 WHERE id = $1`, rottingStage)
+	require.NoError(t, err)
+	inStage := func(stageID string) func(*domain.Deal) {
+		return func(d *domain.Deal) { d.PipelineID = pipeline.ID; d.StageID = &stageID }
+	}
+
+	stale := f.Deal(inStage(rottingStage))
+	won := f.Deal(inStage(rottingStage), func(d *domain.Deal) { d.Stage = domain.DealStageWon })
+	fresh := f.Deal(inStage(freshStage))
+
+	// O worker é global: outros deals parados do banco também podem ser marcados
+	const limit = 10000
+	now := time.Now().Add(36 * time.Hour)
+	rottingSince := func(dealID string) *time.Time {
+		t.Helper()
+		deal, err := deals.Get(ctx, f.WorkspaceID, dealID)
+		require.NoError(t, err)
+		return deal.RottingSince
+	}
+	rottingEvents := func() int {
+		t.Helper()
+		activityType := domain.ActivityTypeDealRotting
+		listed, err := activities.List(ctx, f.WorkspaceID, nil, nil, &stale.ID, nil, &activityType, nil)
+		require.NoError(t, err)
+		return len(listed)
+	}
+
+	t.Run("flags open deals past the stage limit", func(t *testing.T) {
+		_, err := svc.ProcessRotting(ctx, now, limit)
+		require.NoError(t, err)
+
+		since := rottingSince(stale.ID)
+		require.NotNil(t, since)
+		assert.WithinDuration(t, stale.CreatedAt.Add(24*time.Hour), *since, time.Second)
+		assert.Nil(t, rottingSince(won.ID), "closed deals never rot")
+		assert.Nil(t, rottingSince(fresh.ID), "stage without rottingDays")
+		assert.Equal(t, 1, rottingEvents())
+	})
+
+	t.Run("flagged deals are not flagged again", func(t *testing.T) {
+		_, err := svc.ProcessRotting(ctx, now, limit)
+		require.NoError(t, err)
+		// O próprio DEAL_ROTTING não conta como atividade
+		assert.Equal(t, 1, rottingEvents())
+	})
+
+	t.Run("new activity moves the rotting date and flags again", func(t *testing.T) {
+		before := rottingSince(stale.ID)
+		require.NotNil(t, before)
+
+		activityID, err := id.New(id.Activity)
+		require.NoError(t, err)
+		_, err = activities.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: f.WorkspaceID,
+			DealID:      &stale.ID,
+			Type:        domain.ActivityTypeCall,
+			UserID:      f.UserID,
+			Metadata:    []byte(`{}`),
+		})
+		require.NoError(t, err)
+
+		_, err = svc.ProcessRotting(ctx, now, limit)
+		require.NoError(t, err)
+		after := rottingSince(stale.ID)
+		require.NotNil(t, after)
+		assert.True(t, after.After(*before))
+		assert.Equal(t, 2, rottingEvents())
+	})
+
+	t.Run("disabling the stage limit clears the flag", func(t *testing.T) {
+		_, err := pool.Exec(ctx, `UPDATE public."PipelineStage" SET "rottingDays" = NULL WHERE id = $1`, rottingStage)
+		require.NoError(t, err)
+
+		_, err = svc.ProcessRotting(ctx, now, limit)
+		require.NoError(t, err)
+		assert.Nil(t, rottingSince(stale.ID))
+	})
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidSplitUser = apperr.Unprocessable(apperr.CodeValidationError, "split user_id does not belong to workspace", "split user does not belong to workspace")
+
+// DealSplitService gerencia a divisão do crédito de um negócio entre usuários, para times
+// em que SDR e AE recebem crédito pelo mesmo negócio. Os relatórios por dono usam os splits.
+type DealSplitService struct {
+	splitRepo     *repo.DealSplitRepository
+	dealRepo      *repo.DealRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewDealSplitService(splitRepo *repo.DealSplitRepository, dealRepo *repo.DealRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *DealSplitService {
+	return &DealSplitService{
+		splitRepo:     splitRepo,
+		dealRepo:      dealRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *DealSplitService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("deal_split"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// ListSplits lista os splits de um negócio (vazio = crédito integral do dono).
+// Permission: all workspace members.
+func (s *DealSplitService) ListSplits(ctx context.Context, workspaceID, dealID, actorID string) ([]domain.DealSplit, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if _, err := s.dealRepo.Get(ctx, workspaceID, dealID); err != nil {
+		return nil, err
+	}
+
+	return s.splitRepo.List(ctx, workspaceID, dealID)
+}
+
+// ReplaceSplits substitui os splits do negócio. Cada usuário precisa ser membro do workspace.
+// Permission: admin, manager, agent.
+func (s *DealSplitService) ReplaceSplits(ctx context.Context, workspaceID, dealID, actorID string, req *domain.ReplaceDealSplitsRequest) ([]domain.DealSplit, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if _, err := s.dealRepo.Get(ctx, workspaceID, dealID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	splits := make([]domain.DealSplit, 0, len(req.Splits))
+	for _, input := range req.Splits {
+		isMember, err := s.workspaceRepo.IsMember(ctx, input.UserID, workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("validate split user: %w", err)
+		}
+		if !isMember {
+			return nil, ErrInvalidSplitUser
+		}
+
+		splitID, err := id.New(id.DealSplit)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, domain.DealSplit{
+			ID:          splitID,
+			WorkspaceID: workspaceID,
+			DealID:      dealID,
+			UserID:      input.UserID,
+			Percentage:  input.Percentage,
+			CreatedByID: actorID,
+			CreatedAt:   now,
+		})
+	}
+
+	replaced, err := s.splitRepo.Replace(ctx, workspaceID, dealID, splits)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "splits_update", "deal", &dealID, nil, "", "")
+
+	return replaced, nil
+}
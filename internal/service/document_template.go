@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrDocumentTemplateNotFound     = repo.ErrDocumentTemplateNotFound
+	ErrDocumentTemplateNameConflict = repo.ErrDocumentTemplateNameConflict
+)
+
+// DocumentTemplateService gerencia os templates de documentos usados para gerar orçamentos.
+type DocumentTemplateService struct {
+	templateRepo  *repo.DocumentTemplateRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewDocumentTemplateService(templateRepo *repo.DocumentTemplateRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *DocumentTemplateService {
+	return &DocumentTemplateService{
+		templateRepo:  templateRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *DocumentTemplateService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("document_template"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *DocumentTemplateService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateTemplate creates a workspace document template.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *DocumentTemplateService) CreateTemplate(ctx context.Context, workspaceID, actorID string, req *domain.CreateDocumentTemplateRequest) (*domain.DocumentTemplate, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	documentTemplateID, err := id.New(id.DocumentTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.templateRepo.Create(ctx, &domain.DocumentTemplate{
+		ID:          documentTemplateID,
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		Title:       req.Title,
+		Body:        req.Body,
+		Description: req.Description,
+		CreatedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", created.ID)
+	return created, nil
+}
+
+// GetTemplate retrieves a single template.
+// Permission: all workspace members.
+func (s *DocumentTemplateService) GetTemplate(ctx context.Context, workspaceID, templateID, actorID string) (*domain.DocumentTemplate, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.templateRepo.Get(ctx, workspaceID, templateID)
+}
+
+// ListTemplates lists the workspace templates ordered by name.
+// Permission: all workspace members.
+func (s *DocumentTemplateService) ListTemplates(ctx context.Context, workspaceID, actorID string) ([]domain.DocumentTemplate, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.templateRepo.List(ctx, workspaceID)
+}
+
+// UpdateTemplate partially updates a template. Quotes already generated keep their rendered text.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *DocumentTemplateService) UpdateTemplate(ctx context.Context, workspaceID, templateID, actorID string, req *domain.UpdateDocumentTemplateRequest) (*domain.DocumentTemplate, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	template, err := s.templateRepo.Get(ctx, workspaceID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(template)
+	template.UpdatedByID = &actorID
+
+	updated, err := s.templateRepo.Update(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "update", templateID)
+	return updated, nil
+}
+
+// DeleteTemplate removes a template.
+// Permission: admin, manager.
+func (s *DocumentTemplateService) DeleteTemplate(ctx context.Context, workspaceID, templateID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(ctx, workspaceID, templateID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", templateID)
+	return nil
+}
+
+func (s *DocumentTemplateService) logAction(ctx context.Context, workspaceID, actorID, action, templateID string) {
+	idStr := templateID
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "document_template", &idStr, nil, "", "")
+}
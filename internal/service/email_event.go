@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// defaultEmailEventProvider provedor gravado quando nem o request nem a credencial informam um
+const defaultEmailEventProvider = "unknown"
+
+// EmailEventService ingere eventos de engajamento de email (aberturas, cliques, bounces e
+// descadastros) enviados pelo provedor de envio via S2S, ligando-os aos contatos.
+type EmailEventService struct {
+	eventRepo        *repo.EmailEventRepository
+	contactRepo      *repo.ContactRepository
+	activityRepo     *repo.ActivityRepository
+	verificationRepo *repo.EmailVerificationRepository
+	workspaceRepo    *repo.WorkspaceRepository
+	auditRepo        *repo.AuditRepo
+	log              *logger.Logger
+}
+
+func NewEmailEventService(eventRepo *repo.EmailEventRepository, contactRepo *repo.ContactRepository, activityRepo *repo.ActivityRepository, verificationRepo *repo.EmailVerificationRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *EmailEventService {
+	return &EmailEventService{
+		eventRepo:        eventRepo,
+		contactRepo:      contactRepo,
+		activityRepo:     activityRepo,
+		verificationRepo: verificationRepo,
+		workspaceRepo:    workspaceRepo,
+		auditRepo:        auditRepo,
+		log:              log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *EmailEventService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("email_event"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// IngestEvents grava o lote na ordem recebida. Eventos ligados a um contato viram EMAIL_* na
+// timeline (gatilho para automações); bounce HARD marca o email do contato como INVALID.
+// Reenvios (mesmo provider + providerEventId) são ignorados, então o provedor pode repetir o
+// lote inteiro após uma falha.
+// defaultProvider é o cliente S2S, usado quando o request não informa provider.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *EmailEventService) IngestEvents(ctx context.Context, workspaceID, actorID, defaultProvider string, req *domain.IngestEmailEventsRequest) (*domain.IngestEmailEventsResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	provider := req.Provider
+	if provider == "" {
+		provider = strings.ToLower(defaultProvider)
+	}
+	if provider == "" {
+		provider = defaultEmailEventProvider
+	}
+
+	response := &domain.IngestEmailEventsResponse{Results: make([]domain.EmailEventResult, 0, len(req.Events))}
+	for i := range req.Events {
+		result, err := s.ingest(ctx, workspaceID, provider, &req.Events[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Index = i
+		switch result.Status {
+		case domain.EmailEventResultCreated:
+			response.Created++
+		case domain.EmailEventResultUnmatched:
+			response.Unmatched++
+		case domain.EmailEventResultDuplicate:
+			response.Duplicate++
+		}
+		response.Results = append(response.Results, *result)
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "ingest", "email_event", nil, map[string]interface{}{
+		"provider":  provider,
+		"created":   response.Created,
+		"unmatched": response.Unmatched,
+		"duplicate": response.Duplicate,
+	}, "", "")
+
+	return response, nil
+}
+
+// ingest grava um evento e aplica os efeitos no contato.
+func (s *EmailEventService) ingest(ctx context.Context, workspaceID, provider string, in *domain.EmailEventInput) (*domain.EmailEventResult, error) {
+	contact, err := s.resolveContact(ctx, workspaceID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	emailEventID, err := id.New(id.EmailEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &domain.EmailEvent{
+		ID:              emailEventID,
+		WorkspaceID:     workspaceID,
+		Type:            in.Type,
+		Email:           in.Email,
+		Provider:        provider,
+		ProviderEventID: in.ProviderEventID,
+		MessageID:       in.MessageID,
+		URL:             in.URL,
+		BounceType:      in.BounceType,
+		Reason:          in.Reason,
+		OccurredAt:      in.OccurredAt,
+	}
+	if contact != nil {
+		event.ContactID = &contact.ID
+	}
+
+	created, err := s.eventRepo.Create(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return &domain.EmailEventResult{Status: domain.EmailEventResultDuplicate}, nil
+	}
+	result := &domain.EmailEventResult{Status: domain.EmailEventResultUnmatched, EventID: &event.ID}
+	if contact == nil {
+		return result, nil
+	}
+	result.Status = domain.EmailEventResultCreated
+	result.ContactID = &contact.ID
+
+	s.recordActivity(ctx, contact, event)
+
+	if event.Type == domain.EmailEventBounced && *event.BounceType == domain.EmailBounceHard {
+		reason := "hard bounce"
+		if event.Reason != nil {
+			reason += ": " + *event.Reason
+		}
+		if err := s.verificationRepo.MarkInvalid(ctx, workspaceID, contact.ID, event.Email, reason); err != nil {
+			s.log.Warn(ctx, "failed to mark bounced contact email invalid",
+				logger.Module("email_event"),
+				logger.Action("ingest"),
+				zap.String("contact_id", contact.ID),
+				zap.Error(err),
+			)
+		}
+	}
+	return result, nil
+}
+
+// resolveContact contactId informado (se existir no workspace) ou o contato mais recente com o email.
+func (s *EmailEventService) resolveContact(ctx context.Context, workspaceID string, in *domain.EmailEventInput) (*domain.Contact, error) {
+	contactID := in.ContactID
+	if contactID != nil {
+		contact, err := s.contactRepo.Get(ctx, workspaceID, *contactID)
+		if err == nil {
+			return contact, nil
+		}
+		if !errors.Is(err, repo.ErrContactNotFound) {
+			return nil, fmt.Errorf("get email event contact: %w", err)
+		}
+	}
+
+	contactID, err := s.eventRepo.FindContactByEmail(ctx, workspaceID, in.Email)
+	if err != nil || contactID == nil {
+		return nil, err
+	}
+	contact, err := s.contactRepo.Get(ctx, workspaceID, *contactID)
+	if err != nil {
+		if errors.Is(err, repo.ErrContactNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get email event contact: %w", err)
+	}
+	return contact, nil
+}
+
+// recordActivity grava o EMAIL_* na timeline do contato (best-effort: o evento já foi persistido).
+func (s *EmailEventService) recordActivity(ctx context.Context, contact *domain.Contact, event *domain.EmailEvent) {
+	metadata := map[string]interface{}{
+		"emailEventId": event.ID,
+		"email":        event.Email,
+		"provider":     event.Provider,
+		"occurredAt":   event.OccurredAt,
+	}
+	if event.MessageID != nil {
+		metadata["messageId"] = *event.MessageID
+	}
+	if event.URL != nil {
+		metadata["url"] = *event.URL
+	}
+	if event.BounceType != nil {
+		metadata["bounceType"] = *event.BounceType
+	}
+	if event.Reason != nil {
+		metadata["reason"] = *event.Reason
+	}
+
+	metadataJSON, _ := json.Marshal(metadata)
+	activityID, err := id.New(id.Activity)
+	if err == nil {
+		_, err = s.activityRepo.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: contact.WorkspaceID,
+			CompanyID:   contact.CompanyID,
+			ContactID:   &contact.ID,
+			Type:        event.Type.ActivityType(),
+			UserID:      contact.ActorID,
+			Metadata:    metadataJSON,
+			CreatedAt:   event.OccurredAt,
+		})
+	}
+	if err != nil {
+		s.log.Warn(ctx, "failed to record email event activity",
+			logger.Module("email_event"),
+			logger.Action("ingest"),
+			zap.String("contact_id", contact.ID),
+			zap.String("email_event_id", event.ID),
+			zap.Error(err),
+		)
+	}
+}
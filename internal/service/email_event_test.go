@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestEmailEventService_Integration
+func TestEmailEventService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	contacts := repo.NewContactRepository(pool)
+	activities := repo.NewActivityRepository(pool)
+	svc := service.NewEmailEventService(repo.NewEmailEventRepository(pool), contacts, activities,
+		repo.NewEmailVerificationRepository(pool), repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool), log)
+
+	contact := f.Contact()
+	occurredAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	hard := domain.EmailBounceHard
+	ingest := func(t *testing.T, actor string, events ...domain.EmailEventInput) *domain.IngestEmailEventsResponse {
+		t.Helper()
+		req := &domain.IngestEmailEventsRequest{Events: events}
+		require.NoError(t, req.Validate())
+		resp, err := svc.IngestEvents(ctx, f.WorkspaceID, actor, "SendGrid", req)
+		require.NoError(t, err)
+		return resp
+	}
+	timeline := func(t *testing.T, typ domain.ActivityType) []domain.Activity {
+		t.Helper()
+		list, err := activities.List(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, &typ, nil)
+		require.NoError(t, err)
+		return list
+	}
+
+	t.Run("viewers cannot ingest", func(t *testing.T) {
+		req := &domain.IngestEmailEventsRequest{Events: []domain.EmailEventInput{
+			{Type: domain.EmailEventOpened, Email: contact.Email, OccurredAt: occurredAt},
+		}}
+		require.NoError(t, req.Validate())
+		_, err := svc.IngestEvents(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), "sendgrid", req)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	batch := []domain.EmailEventInput{
+		{Type: domain.EmailEventOpened, Email: strings.ToUpper(contact.Email), ProviderEventID: factory.Ptr("evt-1"), OccurredAt: occurredAt},
+		{Type: domain.EmailEventClicked, Email: contact.Email, ProviderEventID: factory.Ptr("evt-2"),
+			URL: factory.Ptr("https://example.com/proposta"), OccurredAt: occurredAt},
+		{Type: domain.EmailEventOpened, Email: "desconhecido@example.com", ProviderEventID: factory.Ptr("evt-3"), OccurredAt: occurredAt},
+	}
+
+	t.Run("events are matched by email", func(t *testing.T) {
+		resp := ingest(t, f.Member(domain.RoleUser), batch...)
+		assert.Equal(t, 2, resp.Created)
+		assert.Equal(t, 1, resp.Unmatched)
+		require.Len(t, resp.Results, 3)
+		assert.Equal(t, contact.ID, *resp.Results[0].ContactID)
+		assert.Equal(t, domain.EmailEventResultUnmatched, resp.Results[2].Status)
+		assert.Nil(t, resp.Results[2].ContactID)
+
+		opened := timeline(t, domain.ActivityTypeEmailOpened)
+		require.Len(t, opened, 1)
+		assert.True(t, opened[0].CreatedAt.Equal(occurredAt), "timeline uses the provider timestamp")
+		assert.Len(t, timeline(t, domain.ActivityTypeEmailClicked), 1)
+	})
+
+	t.Run("retried batches are deduplicated by provider event id", func(t *testing.T) {
+		resp := ingest(t, f.UserID, batch...)
+		assert.Equal(t, 0, resp.Created)
+		assert.Equal(t, 3, resp.Duplicate)
+		assert.Len(t, timeline(t, domain.ActivityTypeEmailOpened), 1)
+	})
+
+	t.Run("hard bounce invalidates the contact email", func(t *testing.T) {
+		resp := ingest(t, f.UserID, domain.EmailEventInput{
+			Type: domain.EmailEventBounced, Email: contact.Email, ContactID: &contact.ID, BounceType: &hard,
+			Reason: factory.Ptr("mailbox does not exist"), OccurredAt: occurredAt,
+		})
+		assert.Equal(t, 1, resp.Created)
+
+		bounced, err := contacts.Get(ctx, f.WorkspaceID, contact.ID)
+		require.NoError(t, err)
+		require.NotNil(t, bounced.EmailStatus)
+		assert.Equal(t, domain.EmailStatusInvalid, *bounced.EmailStatus)
+		assert.Equal(t, "hard bounce: mailbox does not exist", *bounced.EmailStatusReason)
+	})
+
+	t.Run("soft bounce keeps the email status", func(t *testing.T) {
+		other := f.Contact()
+		soft := domain.EmailBounceSoft
+		resp := ingest(t, f.UserID, domain.EmailEventInput{
+			Type: domain.EmailEventBounced, Email: other.Email, BounceType: &soft, OccurredAt: occurredAt,
+		})
+		assert.Equal(t, other.ID, *resp.Results[0].ContactID)
+
+		current, err := contacts.Get(ctx, f.WorkspaceID, other.ID)
+		require.NoError(t, err)
+		assert.Nil(t, current.EmailStatus)
+	})
+}
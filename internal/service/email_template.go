@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrEmailTemplateNotFound     = repo.ErrEmailTemplateNotFound
+	ErrEmailTemplateNameConflict = repo.ErrEmailTemplateNameConflict
+)
+
+type EmailTemplateService struct {
+	templateRepo  *repo.EmailTemplateRepository
+	contactRepo   *repo.ContactRepository
+	dealRepo      *repo.DealRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewEmailTemplateService(templateRepo *repo.EmailTemplateRepository, contactRepo *repo.ContactRepository, dealRepo *repo.DealRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *EmailTemplateService {
+	return &EmailTemplateService{
+		templateRepo:  templateRepo,
+		contactRepo:   contactRepo,
+		dealRepo:      dealRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *EmailTemplateService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("email_template"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// CreateEmailTemplate creates a workspace email template.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *EmailTemplateService) CreateEmailTemplate(ctx context.Context, workspaceID, actorID string, req *domain.CreateEmailTemplateRequest) (*domain.EmailTemplate, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	emailTemplateID, err := id.New(id.EmailTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.templateRepo.Create(ctx, &domain.EmailTemplate{
+		ID:          emailTemplateID,
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		Subject:     req.Subject,
+		Body:        req.Body,
+		Description: req.Description,
+		CreatedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logEmailTemplateAction(ctx, workspaceID, actorID, "create", created.ID)
+
+	return created, nil
+}
+
+// GetEmailTemplate retrieves a single template.
+// Permission: all workspace members.
+func (s *EmailTemplateService) GetEmailTemplate(ctx context.Context, workspaceID, templateID, actorID string) (*domain.EmailTemplate, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.templateRepo.Get(ctx, workspaceID, templateID)
+}
+
+// ListEmailTemplates lists the workspace templates ordered by name.
+// Permission: all workspace members.
+func (s *EmailTemplateService) ListEmailTemplates(ctx context.Context, workspaceID, actorID string, query *string) ([]domain.EmailTemplate, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.templateRepo.List(ctx, workspaceID, query)
+}
+
+// UpdateEmailTemplate partially updates a template.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *EmailTemplateService) UpdateEmailTemplate(ctx context.Context, workspaceID, templateID, actorID string, req *domain.UpdateEmailTemplateRequest) (*domain.EmailTemplate, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	updated, err := s.templateRepo.Update(ctx, workspaceID, templateID, req, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logEmailTemplateAction(ctx, workspaceID, actorID, "update", templateID)
+
+	return updated, nil
+}
+
+// DeleteEmailTemplate soft-deletes a template.
+// Permission: admin, manager.
+func (s *EmailTemplateService) DeleteEmailTemplate(ctx context.Context, workspaceID, templateID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanDeleteContacts(role) {
+		return ErrUnauthorized
+	}
+
+	if err := s.templateRepo.Delete(ctx, workspaceID, templateID, actorID); err != nil {
+		return err
+	}
+
+	s.logEmailTemplateAction(ctx, workspaceID, actorID, "delete", templateID)
+	return nil
+}
+
+// RenderEmailTemplate previews a template with the merge fields of a contact and/or deal.
+// Without contactId, the deal's contact (if any) is used. Nothing is sent or persisted.
+// Permission: all workspace members.
+func (s *EmailTemplateService) RenderEmailTemplate(ctx context.Context, workspaceID, templateID, actorID string, req *domain.RenderEmailTemplateRequest) (*domain.RenderedEmail, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	template, err := s.templateRepo.Get(ctx, workspaceID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deal *domain.Deal
+	if req.DealID != nil {
+		deal, err = s.dealRepo.Get(ctx, workspaceID, *req.DealID)
+		if err != nil {
+			if errors.Is(err, repo.ErrDealNotFound) {
+				return nil, ErrDealNotFound
+			}
+			return nil, fmt.Errorf("get deal: %w", err)
+		}
+	}
+
+	contactID := req.ContactID
+	if contactID == nil && deal != nil {
+		contactID = deal.ContactID
+	}
+
+	var contact *domain.Contact
+	if contactID != nil {
+		contact, err = s.contactRepo.Get(ctx, workspaceID, *contactID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return template.Render(domain.MergeValues(contact, deal)), nil
+}
+
+func (s *EmailTemplateService) logEmailTemplateAction(ctx context.Context, workspaceID, actorID, action, templateID string) {
+	idStr := templateID
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "email_template", &idStr, nil, "", "")
+}
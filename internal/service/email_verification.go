@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/integrations/emailverify"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"go.uber.org/zap"
+)
+
+// EmailVerificationService verifica os emails de contatos novos ou alterados (sintaxe, MX e
+// provedor opcional) e grava emailStatus. Executado pelo email-verification-worker.
+type EmailVerificationService struct {
+	repo     *repo.EmailVerificationRepository
+	verifier *emailverify.Verifier
+	log      *logger.Logger
+}
+
+func NewEmailVerificationService(repo *repo.EmailVerificationRepository, verifier *emailverify.Verifier, log *logger.Logger) *EmailVerificationService {
+	return &EmailVerificationService{
+		repo:     repo,
+		verifier: verifier,
+		log:      log,
+	}
+}
+
+// ProcessPending verifica até limit emails pendentes e retorna quantos resultados foram gravados.
+// Workers concorrentes podem verificar o mesmo contato; SetResult grava só o primeiro resultado.
+func (s *EmailVerificationService) ProcessPending(ctx context.Context, limit int) (int, error) {
+	pending, err := s.repo.ListPending(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, p := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result := s.verifier.Verify(ctx, p.Email)
+		if ctx.Err() != nil {
+			// Interrompido no meio da consulta: o resultado pode ser um falso dns_error
+			break
+		}
+
+		saved, err := s.repo.SetResult(ctx, p, domain.EmailStatus(result.Status), result.Reason)
+		if err != nil {
+			s.log.Error(ctx, "failed to save email verification",
+				logger.Module("email_verification"),
+				zap.String("workspace_id", p.WorkspaceID),
+				zap.String("contact_id", p.ContactID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if saved {
+			processed++
+		}
+	}
+
+	return processed, nil
+}
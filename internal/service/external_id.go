@@ -0,0 +1,17 @@
+package service
+
+import (
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/repo"
+)
+
+var (
+	ErrExternalIDConflict = repo.ErrExternalIDConflict
+	// ErrUpsertContention o registro do externalId foi criado, alterado ou removido por outras
+	// requests em todas as tentativas do upsert
+	ErrUpsertContention = apperr.Conflict("record with this externalId kept changing during upsert", "record was modified by another request, retry")
+)
+
+// upsertMaxAttempts tentativas de um upsert por externalId. Cada perda de corrida (outra request
+// criou o registro primeiro, ou alterou/removeu o encontrado) repete a busca pelo externalId.
+const upsertMaxAttempts = 3
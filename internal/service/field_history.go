@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// FieldHistoryService registra e consulta o histórico por campo de contatos, empresas e negócios.
+// Os services das entidades chamam Record após cada alteração; falhas de gravação são
+// registradas em log sem falhar a operação, como o audit log.
+type FieldHistoryService struct {
+	historyRepo   *repo.FieldHistoryRepository
+	contactRepo   *repo.ContactRepository
+	companyRepo   *repo.CompanyRepository
+	dealRepo      *repo.DealRepository
+	workspaceRepo *repo.WorkspaceRepository
+	log           *logger.Logger
+}
+
+func NewFieldHistoryService(historyRepo *repo.FieldHistoryRepository, contactRepo *repo.ContactRepository, companyRepo *repo.CompanyRepository, dealRepo *repo.DealRepository, workspaceRepo *repo.WorkspaceRepository, log *logger.Logger) *FieldHistoryService {
+	return &FieldHistoryService{
+		historyRepo:   historyRepo,
+		contactRepo:   contactRepo,
+		companyRepo:   companyRepo,
+		dealRepo:      dealRepo,
+		workspaceRepo: workspaceRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *FieldHistoryService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("field_history"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// Record grava a revisão com os campos que mudaram entre before e after.
+// Nada é gravado quando não há diferença. Seguro com receiver nil.
+func (s *FieldHistoryService) Record(ctx context.Context, workspaceID, actorID string, entityType domain.HistoryEntityType, entityID, action string, before, after any) {
+	if s == nil {
+		return
+	}
+
+	changes, err := domain.DiffFields(before, after)
+	if err == nil && len(changes) == 0 {
+		return
+	}
+	var revisionID string
+	if err == nil {
+		revisionID, err = id.New(id.FieldRevision)
+	}
+	if err == nil {
+		err = s.historyRepo.Create(ctx, &domain.FieldRevision{
+			ID:          revisionID,
+			WorkspaceID: workspaceID,
+			EntityType:  entityType,
+			EntityID:    entityID,
+			Action:      action,
+			Changes:     changes,
+			ActorID:     actorID,
+		})
+	}
+	if err != nil {
+		s.log.Warn(ctx, "failed to record field history",
+			logger.Module("field_history"),
+			logger.Action(action),
+			zap.String("entity_type", string(entityType)),
+			zap.String("entity_id", entityID),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListHistory returns the field-level revisions of a record, most recent first.
+// Permission: all workspace members.
+func (s *FieldHistoryService) ListHistory(ctx context.Context, workspaceID, actorID string, params domain.ListFieldHistoryParams) (*domain.FieldHistoryResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.ensureEntityExists(ctx, workspaceID, params.EntityType, params.EntityID); err != nil {
+		return nil, err
+	}
+
+	params.WorkspaceID = workspaceID
+	params.Normalize()
+
+	revisions, err := s.historyRepo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.FieldHistoryResponse{Data: revisions}
+	if len(revisions) > params.Limit {
+		response.Data = revisions[:params.Limit]
+		nextCursor := response.Data[params.Limit-1].CreatedAt.Format(time.RFC3339Nano)
+		response.Meta.HasNextPage = true
+		response.Meta.NextCursor = &nextCursor
+	}
+	return response, nil
+}
+
+// ensureEntityExists devolve o 404 da entidade quando o registro não existe no workspace.
+func (s *FieldHistoryService) ensureEntityExists(ctx context.Context, workspaceID string, entityType domain.HistoryEntityType, entityID string) error {
+	var err error
+	switch entityType {
+	case domain.HistoryEntityContact:
+		_, err = s.contactRepo.Get(ctx, workspaceID, entityID)
+	case domain.HistoryEntityCompany:
+		_, err = s.companyRepo.Get(ctx, workspaceID, entityID)
+	case domain.HistoryEntityDeal:
+		_, err = s.dealRepo.Get(ctx, workspaceID, entityID)
+		if errors.Is(err, repo.ErrDealNotFound) {
+			return ErrDealNotFound
+		}
+	default:
+		return fmt.Errorf("unsupported history entity %q", entityType)
+	}
+	return err
+}
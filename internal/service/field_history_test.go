@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestFieldHistoryService_Integration
+func TestFieldHistoryService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	historyRepo := repo.NewFieldHistoryRepository(pool)
+	svc := service.NewFieldHistoryService(historyRepo, repo.NewContactRepository(pool), repo.NewCompanyRepository(pool),
+		repo.NewDealRepository(pool), repo.NewWorkspaceRepository(pool), log)
+
+	v1 := f.Contact(func(c *domain.Contact) { c.FullName = "Ana Souza" })
+	v2 := *v1
+	v2.FullName = "Ana Lima"
+	v2.Phone = factory.Ptr("+55 11 99999-0000")
+	v3 := v2
+	v3.Phone = nil
+
+	svc.Record(ctx, f.WorkspaceID, f.UserID, domain.HistoryEntityContact, v1.ID, "update", v1, &v2)
+	svc.Record(ctx, f.WorkspaceID, f.UserID, domain.HistoryEntityContact, v1.ID, "update", &v2, &v3)
+	svc.Record(ctx, f.WorkspaceID, f.UserID, domain.HistoryEntityContact, v1.ID, "update", &v3, &v3)
+
+	list := func(workspaceID, actorID string, params domain.ListFieldHistoryParams) (*domain.FieldHistoryResponse, error) {
+		params.EntityType = domain.HistoryEntityContact
+		params.EntityID = v1.ID
+		return svc.ListHistory(ctx, workspaceID, actorID, params)
+	}
+
+	t.Run("records old and new values per field, most recent first", func(t *testing.T) {
+		history, err := list(f.WorkspaceID, f.Member(domain.RoleViewer), domain.ListFieldHistoryParams{})
+		require.NoError(t, err)
+		require.Len(t, history.Data, 2, "a revision without changes is not recorded")
+		assert.False(t, history.Meta.HasNextPage)
+
+		latest, first := history.Data[0], history.Data[1]
+		assert.False(t, latest.CreatedAt.Before(first.CreatedAt))
+
+		assert.Equal(t, map[string]domain.FieldChange{
+			"phone": {From: json.RawMessage(`"+55 11 99999-0000"`), To: json.RawMessage(`null`)},
+		}, latest.Changes)
+		assert.Equal(t, map[string]domain.FieldChange{
+			"fullName": {From: json.RawMessage(`"Ana Souza"`), To: json.RawMessage(`"Ana Lima"`)},
+			"phone":    {From: json.RawMessage(`null`), To: json.RawMessage(`"+55 11 99999-0000"`)},
+		}, first.Changes)
+		assert.Equal(t, f.UserID, first.ActorID)
+		assert.Equal(t, "update", first.Action)
+	})
+
+	t.Run("paginates with the cursor and filters by field", func(t *testing.T) {
+		page, err := list(f.WorkspaceID, f.UserID, domain.ListFieldHistoryParams{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page.Data, 1)
+		require.True(t, page.Meta.HasNextPage)
+		require.NotNil(t, page.Meta.NextCursor)
+
+		cursor, err := time.Parse(time.RFC3339Nano, *page.Meta.NextCursor)
+		require.NoError(t, err)
+		next, err := list(f.WorkspaceID, f.UserID, domain.ListFieldHistoryParams{Limit: 1, Cursor: &cursor})
+		require.NoError(t, err)
+		require.Len(t, next.Data, 1)
+		assert.False(t, next.Meta.HasNextPage)
+		assert.Contains(t, next.Data[0].Changes, "fullName")
+
+		byName, err := list(f.WorkspaceID, f.UserID, domain.ListFieldHistoryParams{Field: factory.Ptr("fullName")})
+		require.NoError(t, err)
+		require.Len(t, byName.Data, 1)
+		assert.Equal(t, next.Data[0].ID, byName.Data[0].ID)
+	})
+
+	t.Run("isolates workspaces", func(t *testing.T) {
+		_, err := list(other.WorkspaceID, other.UserID, domain.ListFieldHistoryParams{})
+		assert.ErrorIs(t, err, repo.ErrContactNotFound, "the contact does not exist in the other workspace")
+
+		_, err = list(f.WorkspaceID, other.UserID, domain.ListFieldHistoryParams{})
+		assert.ErrorIs(t, err, service.ErrMemberNotFound)
+
+		leaked, err := historyRepo.List(ctx, domain.ListFieldHistoryParams{
+			WorkspaceID: other.WorkspaceID,
+			EntityType:  domain.HistoryEntityContact,
+			EntityID:    v1.ID,
+			Limit:       10,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, leaked)
+	})
+}
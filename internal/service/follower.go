@@ -0,0 +1,482 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/integrations/mailer"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// FollowerService gerencia quem segue contatos, negócios e tarefas e entrega notificações
+// aos seguidores quando o registro muda. Usuários passam a seguir explicitamente (follow)
+// ou automaticamente ao virar responsável pelo registro ou ao serem mencionados em uma nota.
+// Os hooks (AutoFollow, Notify, NoteAdded...) são best-effort e nil-safe: falhas são
+// registradas em log e nunca derrubam a escrita que os disparou. A entrega respeita as
+// preferências de notificação de cada destinatário (canal por evento e horário silencioso).
+type FollowerService struct {
+	followerRepo  *repo.FollowerRepository
+	contactRepo   *repo.ContactRepository
+	dealRepo      *repo.DealRepository
+	taskRepo      *repo.TaskRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	mailer        mailer.Mailer // nil = notificações por email viram somente in_app
+	log           *logger.Logger
+	now           func() time.Time
+}
+
+func NewFollowerService(followerRepo *repo.FollowerRepository, contactRepo *repo.ContactRepository, dealRepo *repo.DealRepository, taskRepo *repo.TaskRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, m mailer.Mailer, log *logger.Logger) *FollowerService {
+	return &FollowerService{
+		followerRepo:  followerRepo,
+		contactRepo:   contactRepo,
+		dealRepo:      dealRepo,
+		taskRepo:      taskRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		mailer:        m,
+		log:           log,
+		now:           time.Now,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *FollowerService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("follower"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// ListFollowers lista os seguidores de uma entidade.
+// Permission: all workspace members.
+func (s *FollowerService) ListFollowers(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID, actorID string) ([]domain.Follower, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.ensureEntityExists(ctx, workspaceID, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	return s.followerRepo.List(ctx, workspaceID, entityType, entityID)
+}
+
+// Follow faz o usuário autenticado seguir a entidade. Idempotente.
+// Permission: all workspace members.
+func (s *FollowerService) Follow(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID, actorID string) (*domain.Follower, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.ensureEntityExists(ctx, workspaceID, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	followerID, err := id.New(id.Follower)
+	if err != nil {
+		return nil, err
+	}
+
+	follower, err := s.followerRepo.Follow(ctx, &domain.Follower{
+		ID:          followerID,
+		WorkspaceID: workspaceID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		UserID:      actorID,
+		Reason:      domain.FollowReasonManual,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "follow", strings.ToLower(string(entityType)), &entityID, nil, "", "")
+
+	return follower, nil
+}
+
+// Unfollow faz o usuário autenticado deixar de seguir a entidade. Idempotente.
+// Permission: all workspace members.
+func (s *FollowerService) Unfollow(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return ErrUnauthorized
+	}
+
+	if err := s.ensureEntityExists(ctx, workspaceID, entityType, entityID); err != nil {
+		return err
+	}
+
+	if err := s.followerRepo.Unfollow(ctx, workspaceID, entityType, entityID, actorID); err != nil {
+		return err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "unfollow", strings.ToLower(string(entityType)), &entityID, nil, "", "")
+
+	return nil
+}
+
+// ListNotifications retorna a caixa de entrada do usuário autenticado, mais recentes primeiro.
+// Permission: all workspace members.
+func (s *FollowerService) ListNotifications(ctx context.Context, workspaceID, actorID string, params domain.ListNotificationsParams) (*domain.NotificationListResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	params.UserID = actorID
+	params.Normalize()
+
+	notifications, err := s.followerRepo.ListNotifications(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.NotificationListResponse{Data: notifications}
+	if len(notifications) > params.Limit {
+		response.Data = notifications[:params.Limit]
+		nextCursor := response.Data[params.Limit-1].CreatedAt.Format(time.RFC3339Nano)
+		response.Meta.HasNextPage = true
+		response.Meta.NextCursor = &nextCursor
+	}
+	return response, nil
+}
+
+// MarkNotificationsRead marca notificações do usuário autenticado como lidas.
+// Permission: all workspace members.
+func (s *FollowerService) MarkNotificationsRead(ctx context.Context, workspaceID, actorID string, req *domain.MarkNotificationsReadRequest) (*domain.MarkNotificationsReadResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	updated, err := s.followerRepo.MarkNotificationsRead(ctx, workspaceID, actorID, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MarkNotificationsReadResponse{Updated: updated}, nil
+}
+
+// GetNotificationPreferences retorna as preferências de notificação do usuário autenticado.
+// Permission: all workspace members.
+func (s *FollowerService) GetNotificationPreferences(ctx context.Context, workspaceID, actorID string) (*domain.NotificationPreferences, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.followerRepo.GetNotificationPreferences(ctx, workspaceID, actorID)
+}
+
+// UpdateNotificationPreferences aplica o PATCH às preferências do usuário autenticado.
+// Permission: all workspace members.
+func (s *FollowerService) UpdateNotificationPreferences(ctx context.Context, workspaceID, actorID string, req *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	prefs, err := s.followerRepo.GetNotificationPreferences(ctx, workspaceID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(prefs)
+
+	saved, err := s.followerRepo.UpsertNotificationPreferences(ctx, prefs)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "update", "notification_preferences", &actorID, nil, "", "")
+
+	return saved, nil
+}
+
+// AutoFollow inclui o usuário como seguidor sem passar por RBAC (responsável ou mencionado).
+// userID nil ou vazio é ignorado.
+func (s *FollowerService) AutoFollow(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID string, userID *string, reason domain.FollowReason) {
+	if s == nil || userID == nil || *userID == "" {
+		return
+	}
+
+	followerID, err := id.New(id.Follower)
+	if err == nil {
+		_, err = s.followerRepo.Follow(ctx, &domain.Follower{
+			ID:          followerID,
+			WorkspaceID: workspaceID,
+			EntityType:  entityType,
+			EntityID:    entityID,
+			UserID:      *userID,
+			Reason:      reason,
+		})
+	}
+	if err != nil {
+		s.logHookError(ctx, "auto_follow", entityType, entityID, err)
+	}
+}
+
+// Notify entrega o evento a todos os seguidores da entidade, exceto ao autor da mudança.
+func (s *FollowerService) Notify(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID, actorID string, event domain.NotificationEvent) {
+	if s == nil {
+		return
+	}
+
+	followers, err := s.followerRepo.List(ctx, workspaceID, entityType, entityID)
+	if err != nil {
+		s.logHookError(ctx, string(event), entityType, entityID, err)
+		return
+	}
+
+	userIDs := make([]string, 0, len(followers))
+	for _, f := range followers {
+		userIDs = append(userIDs, f.UserID)
+	}
+	s.deliver(ctx, workspaceID, entityType, entityID, actorID, event, userIDs)
+}
+
+// Changed notifica os seguidores de uma atualização. Se o responsável mudou, o novo
+// responsável passa a seguir a entidade e o evento entregue é "assigned".
+func (s *FollowerService) Changed(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID, actorID string, assigneeBefore, assigneeAfter *string) {
+	if s == nil {
+		return
+	}
+
+	event := domain.NotificationUpdated
+	if assigneeAfter != nil && *assigneeAfter != "" && (assigneeBefore == nil || *assigneeBefore != *assigneeAfter) {
+		s.AutoFollow(ctx, workspaceID, entityType, entityID, assigneeAfter, domain.FollowReasonAssigned)
+		event = domain.NotificationAssigned
+	}
+	s.Notify(ctx, workspaceID, entityType, entityID, actorID, event)
+}
+
+// NoteAdded notifica os seguidores do contato e do negócio da nota e processa as menções
+// do body: membros mencionados passam a seguir essas entidades e recebem "mentioned".
+// Menções a usuários fora do workspace são ignoradas.
+func (s *FollowerService) NoteAdded(ctx context.Context, note *domain.Note, actorID string, mentioned []string) {
+	if s == nil {
+		return
+	}
+
+	targets := noteFollowTargets(note)
+	for _, t := range targets {
+		s.Notify(ctx, note.WorkspaceID, t.entityType, t.entityID, actorID, domain.NotificationNoteAdded)
+	}
+	s.Mentioned(ctx, note, actorID, mentioned)
+}
+
+// Mentioned processa menções novas de uma nota (criação ou edição).
+func (s *FollowerService) Mentioned(ctx context.Context, note *domain.Note, actorID string, mentioned []string) {
+	if s == nil || len(mentioned) == 0 {
+		return
+	}
+
+	members := make([]string, 0, len(mentioned))
+	for _, userID := range mentioned {
+		if userID == actorID {
+			continue
+		}
+		if _, err := s.workspaceRepo.GetMemberRole(ctx, userID, note.WorkspaceID); err != nil {
+			if !errors.Is(err, repo.ErrMemberNotFound) {
+				s.log.Warn(ctx, "failed to resolve mentioned user",
+					logger.Module("follower"),
+					logger.Action("mention"),
+					zap.String("note_id", note.ID),
+					zap.String("user_id", userID),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+		members = append(members, userID)
+	}
+
+	for _, t := range noteFollowTargets(note) {
+		for i := range members {
+			s.AutoFollow(ctx, note.WorkspaceID, t.entityType, t.entityID, &members[i], domain.FollowReasonMentioned)
+		}
+		s.deliver(ctx, note.WorkspaceID, t.entityType, t.entityID, actorID, domain.NotificationMentioned, members)
+	}
+}
+
+type followTarget struct {
+	entityType domain.FollowEntityType
+	entityID   string
+}
+
+// noteFollowTargets entidades seguíveis às quais a nota pertence (contato e/ou negócio).
+func noteFollowTargets(note *domain.Note) []followTarget {
+	var targets []followTarget
+	if note.ContactID != nil && *note.ContactID != "" {
+		targets = append(targets, followTarget{domain.FollowEntityContact, *note.ContactID})
+	}
+	if note.DealID != nil && *note.DealID != "" {
+		targets = append(targets, followTarget{domain.FollowEntityDeal, *note.DealID})
+	}
+	return targets
+}
+
+// deliver grava a notificação na caixa de entrada de cada destinatário (exceto o autor) conforme
+// o canal escolhido para o evento: none não entrega; email também envia um email, salvo
+// durante o horário silencioso do destinatário.
+func (s *FollowerService) deliver(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID, actorID string, event domain.NotificationEvent, userIDs []string) {
+	recipients := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != actorID {
+			recipients = append(recipients, userID)
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	prefs, err := s.followerRepo.ListNotificationPreferences(ctx, workspaceID, recipients)
+	if err != nil {
+		s.logHookError(ctx, string(event), entityType, entityID, err)
+		return
+	}
+
+	now := s.now()
+	notifications := make([]domain.Notification, 0, len(recipients))
+	var emailTo []string
+	for _, userID := range recipients {
+		p, ok := prefs[userID]
+		if !ok {
+			p = domain.DefaultNotificationPreferences(workspaceID, userID)
+		}
+		channel := p.Channel(event)
+		if channel == domain.NotificationChannelNone {
+			continue
+		}
+		if channel == domain.NotificationChannelEmail && !p.QuietHours.Contains(now) {
+			emailTo = append(emailTo, userID)
+		}
+
+		notificationID, err := id.New(id.Notification)
+		if err != nil {
+			s.logHookError(ctx, string(event), entityType, entityID, err)
+			return
+		}
+		notifications = append(notifications, domain.Notification{
+			ID:          notificationID,
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+			EntityType:  entityType,
+			EntityID:    entityID,
+			Event:       event,
+			ActorID:     actorID,
+		})
+	}
+	if err := s.followerRepo.CreateNotifications(ctx, notifications); err != nil {
+		s.logHookError(ctx, string(event), entityType, entityID, err)
+		return
+	}
+
+	if len(emailTo) > 0 && s.mailer != nil {
+		go s.sendEmails(context.WithoutCancel(ctx), entityType, entityID, event, emailTo)
+	}
+}
+
+// sendEmails envia um email por destinatário, fora do request que disparou o evento.
+func (s *FollowerService) sendEmails(ctx context.Context, entityType domain.FollowEntityType, entityID string, event domain.NotificationEvent, userIDs []string) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	emails, err := s.followerRepo.ListUserEmails(ctx, userIDs)
+	if err != nil {
+		s.logHookError(ctx, "email_"+string(event), entityType, entityID, err)
+		return
+	}
+
+	subject := fmt.Sprintf("Linkko: %s %s", strings.ToLower(string(entityType)), strings.ReplaceAll(string(event), "_", " "))
+	for _, userID := range userIDs {
+		email, ok := emails[userID]
+		if !ok || email == "" {
+			continue
+		}
+		err := s.mailer.Send(ctx, mailer.Message{
+			To:      []string{email},
+			Subject: subject,
+			Text: fmt.Sprintf("There is a new %q event on %s %s that you follow.\n",
+				string(event), strings.ToLower(string(entityType)), entityID),
+		})
+		if err != nil {
+			s.logHookError(ctx, "email_"+string(event), entityType, entityID, err)
+		}
+	}
+}
+
+// ensureEntityExists devolve o 404 da entidade quando o registro não existe no workspace.
+func (s *FollowerService) ensureEntityExists(ctx context.Context, workspaceID string, entityType domain.FollowEntityType, entityID string) error {
+	var err error
+	switch entityType {
+	case domain.FollowEntityContact:
+		_, err = s.contactRepo.Get(ctx, workspaceID, entityID)
+	case domain.FollowEntityDeal:
+		_, err = s.dealRepo.Get(ctx, workspaceID, entityID)
+		if errors.Is(err, repo.ErrDealNotFound) {
+			return ErrDealNotFound
+		}
+	case domain.FollowEntityTask:
+		_, err = s.taskRepo.Get(ctx, workspaceID, entityID)
+	default:
+		return fmt.Errorf("unsupported follow entity %q", entityType)
+	}
+	return err
+}
+
+func (s *FollowerService) logHookError(ctx context.Context, action string, entityType domain.FollowEntityType, entityID string, err error) {
+	s.log.Warn(ctx, "failed to update followers",
+		logger.Module("follower"),
+		logger.Action(action),
+		zap.String("entity_type", string(entityType)),
+		zap.String("entity_id", entityID),
+		zap.Error(err),
+	)
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrFormNotFound = repo.ErrFormNotFound
+
+	// ErrInvalidFormPipeline o pipeline do formulário precisa existir no workspace
+	ErrInvalidFormPipeline = apperr.Unprocessable(apperr.CodeValidationError, "form pipelineId does not belong to workspace", "pipeline does not belong to workspace")
+
+	// ErrInvalidFormStage o estágio precisa pertencer ao pipeline do formulário
+	ErrInvalidFormStage = apperr.Unprocessable(apperr.CodeInvalidStage, "form stageId does not belong to the form pipeline", "stage does not belong to pipeline")
+
+	// ErrFormOwnerCannotWrite o dono dos registros criados precisa poder criar contatos (viewer não pode)
+	ErrFormOwnerCannotWrite = apperr.Unprocessable(apperr.CodeValidationError, "form owner is not allowed to create contacts", "owner must be allowed to create contacts")
+)
+
+// FormService gerencia os formulários de captura de leads. As submissões são recebidas pelo
+// PublicFormService.
+type FormService struct {
+	formRepo      *repo.FormRepository
+	pipelineRepo  *repo.PipelineRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewFormService(formRepo *repo.FormRepository, pipelineRepo *repo.PipelineRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *FormService {
+	return &FormService{
+		formRepo:      formRepo,
+		pipelineRepo:  pipelineRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *FormService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("form"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *FormService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateForm cria o formulário. ownerId (padrão: o ator) é o dono dos contatos e negócios
+// criados pelas submissões.
+// Permission: admin or manager.
+func (s *FormService) CreateForm(ctx context.Context, workspaceID, actorID string, req *domain.CreateFormRequest) (*domain.Form, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return nil, err
+	}
+
+	ownerID := actorID
+	if req.OwnerID != nil {
+		ownerID = *req.OwnerID
+	}
+
+	formID, err := id.New(id.Form)
+	if err != nil {
+		return nil, err
+	}
+
+	form := &domain.Form{
+		ID:                 formID,
+		WorkspaceID:        workspaceID,
+		Name:               req.Name,
+		Description:        req.Description,
+		Fields:             req.Fields,
+		PipelineID:         req.PipelineID,
+		StageID:            req.StageID,
+		OwnerID:            ownerID,
+		SuccessRedirectURL: req.SuccessRedirectURL,
+		SuccessMessage:     req.SuccessMessage,
+		SpamProtection:     *req.SpamProtection,
+		Enabled:            req.Enabled == nil || *req.Enabled,
+		CreatedByID:        actorID,
+	}
+	if err := s.validateReferences(ctx, form); err != nil {
+		return nil, err
+	}
+
+	created, err := s.formRepo.Create(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", created.ID)
+	return withSubmissionURL(created), nil
+}
+
+// GetForm retorna o formulário.
+// Permission: all workspace members.
+func (s *FormService) GetForm(ctx context.Context, workspaceID, formID, actorID string) (*domain.Form, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	form, err := s.formRepo.Get(ctx, workspaceID, formID)
+	if err != nil {
+		return nil, err
+	}
+	return withSubmissionURL(form), nil
+}
+
+// ListForms lista os formulários do workspace.
+// Permission: all workspace members.
+func (s *FormService) ListForms(ctx context.Context, workspaceID, actorID string) ([]domain.Form, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	forms, err := s.formRepo.List(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range forms {
+		withSubmissionURL(&forms[i])
+	}
+	return forms, nil
+}
+
+// UpdateForm aplica o PATCH. O resultado da mesclagem já foi validado pelo handler (Form.Validate).
+// Permission: admin or manager.
+func (s *FormService) UpdateForm(ctx context.Context, workspaceID, formID, actorID string, req *domain.UpdateFormRequest) (*domain.Form, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return nil, err
+	}
+
+	form, err := s.formRepo.Get(ctx, workspaceID, formID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(form)
+	if err := s.validateReferences(ctx, form); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.formRepo.Update(ctx, form, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "update", updated.ID)
+	return withSubmissionURL(updated), nil
+}
+
+// DeleteForm remove o formulário; submissões seguintes recebem 404.
+// Permission: admin or manager.
+func (s *FormService) DeleteForm(ctx context.Context, workspaceID, formID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return err
+	}
+	if err := s.formRepo.Delete(ctx, workspaceID, formID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", formID)
+	return nil
+}
+
+// validateReferences confere que pipeline, estágio e dono pertencem ao workspace.
+func (s *FormService) validateReferences(ctx context.Context, form *domain.Form) error {
+	if form.PipelineID != nil {
+		if _, err := s.pipelineRepo.Get(ctx, form.WorkspaceID, *form.PipelineID); err != nil {
+			if errors.Is(err, repo.ErrPipelineNotFound) {
+				return ErrInvalidFormPipeline
+			}
+			return fmt.Errorf("validate form pipeline: %w", err)
+		}
+	}
+	if form.StageID != nil {
+		stage, err := s.pipelineRepo.GetStage(ctx, *form.StageID)
+		if err != nil {
+			if errors.Is(err, repo.ErrStageNotFound) {
+				return ErrInvalidFormStage
+			}
+			return fmt.Errorf("validate form stage: %w", err)
+		}
+		if stage.WorkspaceID != form.WorkspaceID || stage.PipelineID == nil || *stage.PipelineID != *form.PipelineID {
+			return ErrInvalidFormStage
+		}
+	}
+
+	role, err := s.workspaceRepo.GetMemberRole(ctx, form.OwnerID, form.WorkspaceID)
+	if err != nil {
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return ErrInvalidOwner
+		}
+		return fmt.Errorf("validate form owner: %w", err)
+	}
+	if !domain.CanModifyContacts(role) {
+		return ErrFormOwnerCannotWrite
+	}
+	return nil
+}
+
+// withSubmissionURL preenche a URL pública usada pelo site
+func withSubmissionURL(form *domain.Form) *domain.Form {
+	form.SubmissionURL = publicFormSubmissionURL(form.ID)
+	return form
+}
+
+func publicFormSubmissionURL(formID string) string {
+	return "/v1/public/forms/" + url.PathEscape(formID) + "/submissions"
+}
+
+func (s *FormService) logAction(ctx context.Context, workspaceID, actorID, action, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "form", &idStr, nil, "", "")
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestFormService_Integration
+func TestFormService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	forms := repo.NewFormRepository(pool)
+	svc := service.NewFormService(forms, repo.NewPipelineRepository(pool), repo.NewWorkspaceRepository(pool),
+		repo.NewAuditRepo(pool), log)
+
+	pipeline := f.Pipeline()
+	other := f.Pipeline()
+	newRequest := func(t *testing.T, opts ...func(*domain.CreateFormRequest)) *domain.CreateFormRequest {
+		t.Helper()
+		req := &domain.CreateFormRequest{
+			Name: "Fale conosco",
+			Fields: []domain.FormField{
+				{Name: "nome", Type: domain.FormFieldText, Target: domain.FormTargetContactFullName, Required: true},
+				{Name: "email", Type: domain.FormFieldEmail, Target: domain.FormTargetContactEmail, Required: true},
+				{Name: "empresa", Type: domain.FormFieldText, Target: domain.FormTargetDealName},
+			},
+			PipelineID: &pipeline.ID,
+			StageID:    &pipeline.Stages[1].ID,
+		}
+		for _, opt := range opts {
+			opt(req)
+		}
+		require.NoError(t, req.Validate())
+		return req
+	}
+
+	t.Run("users cannot create forms", func(t *testing.T) {
+		_, err := svc.CreateForm(ctx, f.WorkspaceID, f.Member(domain.RoleUser), newRequest(t))
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	t.Run("stage must belong to the form pipeline", func(t *testing.T) {
+		_, err := svc.CreateForm(ctx, f.WorkspaceID, f.UserID, newRequest(t, func(r *domain.CreateFormRequest) {
+			r.StageID = &other.Stages[0].ID
+		}))
+		assert.ErrorIs(t, err, service.ErrInvalidFormStage)
+	})
+
+	t.Run("pipeline must belong to the workspace", func(t *testing.T) {
+		_, err := svc.CreateForm(ctx, f.WorkspaceID, f.UserID, newRequest(t, func(r *domain.CreateFormRequest) {
+			r.PipelineID = factory.Ptr("pip_missing")
+			r.StageID = nil
+		}))
+		assert.ErrorIs(t, err, service.ErrInvalidFormPipeline)
+	})
+
+	t.Run("owner must be able to create contacts", func(t *testing.T) {
+		viewer := f.Member(domain.RoleViewer)
+		_, err := svc.CreateForm(ctx, f.WorkspaceID, f.UserID, newRequest(t, func(r *domain.CreateFormRequest) {
+			r.OwnerID = &viewer
+		}))
+		assert.ErrorIs(t, err, service.ErrFormOwnerCannotWrite)
+	})
+
+	manager := f.Member(domain.RoleManager)
+	form, err := svc.CreateForm(ctx, f.WorkspaceID, manager, newRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, manager, form.OwnerID, "owner defaults to the actor")
+	assert.True(t, form.Enabled)
+	assert.Equal(t, domain.DefaultFormRateLimitPerMinute, form.SpamProtection.RateLimitPerMinute)
+	assert.Equal(t, "/v1/public/forms/"+form.ID+"/submissions", form.SubmissionURL)
+
+	t.Run("switching pipeline drops the stage", func(t *testing.T) {
+		updated, err := svc.UpdateForm(ctx, f.WorkspaceID, form.ID, manager, &domain.UpdateFormRequest{PipelineID: &other.ID})
+		require.NoError(t, err)
+		assert.Equal(t, other.ID, *updated.PipelineID)
+		assert.Nil(t, updated.StageID)
+	})
+
+	t.Run("submissions are counted on enabled forms", func(t *testing.T) {
+		require.NoError(t, forms.RecordSubmission(ctx, form.ID))
+		enabled, err := forms.GetEnabled(ctx, form.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), enabled.SubmissionCount)
+		assert.NotNil(t, enabled.LastSubmissionAt)
+
+		_, err = svc.UpdateForm(ctx, f.WorkspaceID, form.ID, manager, &domain.UpdateFormRequest{Enabled: factory.Ptr(false)})
+		require.NoError(t, err)
+		_, err = forms.GetEnabled(ctx, form.ID)
+		assert.ErrorIs(t, err, service.ErrFormNotFound)
+	})
+
+	t.Run("deleted forms are gone", func(t *testing.T) {
+		require.NoError(t, svc.DeleteForm(ctx, f.WorkspaceID, form.ID, manager))
+		_, err := svc.GetForm(ctx, f.WorkspaceID, form.ID, f.Member(domain.RoleViewer))
+		assert.ErrorIs(t, err, service.ErrFormNotFound)
+	})
+}
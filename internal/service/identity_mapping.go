@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var ErrIdentityMappingEntityNotFound = apperr.Unprocessable(apperr.CodeValidationError, "identity mapping entityId does not exist in workspace", "mapped record not found in workspace")
+
+// IdentityMappingService traduz ids entre a Linkko e os sistemas externos de um workspace, para
+// integrações que sincronizam com vários sistemas ao mesmo tempo (ERP, e-commerce, suporte).
+type IdentityMappingService struct {
+	mappingRepo   *repo.IdentityMappingRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewIdentityMappingService(mappingRepo *repo.IdentityMappingRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *IdentityMappingService {
+	return &IdentityMappingService{
+		mappingRepo:   mappingRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *IdentityMappingService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("identity_mapping"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// RegisterMappings cria ou atualiza os mappings do lote (todos ou nenhum). Cada entityId precisa
+// existir no workspace; um externalId já registrado passa a apontar para o novo registro.
+// Permission: admin, manager, agent.
+func (s *IdentityMappingService) RegisterMappings(ctx context.Context, workspaceID, actorID string, req *domain.RegisterIdentityMappingsRequest) ([]domain.IdentityMapping, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	// Uma consulta por tipo para validar os registros do lote
+	entityIDs := make(map[domain.IdentityEntityType][]string)
+	for _, m := range req.Mappings {
+		entityIDs[m.EntityType] = append(entityIDs[m.EntityType], m.EntityID)
+	}
+	for entityType, ids := range entityIDs {
+		existing, err := s.mappingRepo.ExistingEntityIDs(ctx, workspaceID, entityType, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, entityID := range ids {
+			if !existing[entityID] {
+				s.log.Warn(ctx, "identity mapping references missing record",
+					logger.Module("identity_mapping"),
+					zap.String("workspace_id", workspaceID),
+					zap.String("entity_type", string(entityType)),
+					zap.String("entity_id", entityID),
+				)
+				return nil, ErrIdentityMappingEntityNotFound
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	mappings := make([]domain.IdentityMapping, 0, len(req.Mappings))
+	for _, input := range req.Mappings {
+		mappingID, err := id.New(id.IdentityMapping)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, domain.IdentityMapping{
+			ID:          mappingID,
+			WorkspaceID: workspaceID,
+			System:      input.System,
+			EntityType:  input.EntityType,
+			ExternalID:  input.ExternalID,
+			EntityID:    input.EntityID,
+			CreatedByID: actorID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+
+	saved, err := s.mappingRepo.Upsert(ctx, workspaceID, mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "register", "identity_mapping", nil, nil, "", "")
+
+	return saved, nil
+}
+
+// ResolveMappings resolve externalIds de um sistema (ou entityIds, em um ou todos os sistemas) e
+// devolve os ids pedidos que não têm mapping.
+// Permission: all workspace members.
+func (s *IdentityMappingService) ResolveMappings(ctx context.Context, workspaceID, actorID string, req *domain.ResolveIdentityMappingsRequest) (*domain.ResolveIdentityMappingsResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	var (
+		mappings  []domain.IdentityMapping
+		requested []string
+		key       func(domain.IdentityMapping) string
+	)
+	if len(req.ExternalIDs) > 0 {
+		requested = req.ExternalIDs
+		key = func(m domain.IdentityMapping) string { return m.ExternalID }
+		mappings, err = s.mappingRepo.ResolveExternalIDs(ctx, workspaceID, req.System, req.EntityType, req.ExternalIDs)
+	} else {
+		requested = req.EntityIDs
+		key = func(m domain.IdentityMapping) string { return m.EntityID }
+		mappings, err = s.mappingRepo.ResolveEntityIDs(ctx, workspaceID, req.System, req.EntityType, req.EntityIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]bool, len(mappings))
+	for _, m := range mappings {
+		resolved[key(m)] = true
+	}
+	unresolved := []string{}
+	for _, requestedID := range requested {
+		if !resolved[requestedID] {
+			unresolved = append(unresolved, requestedID)
+			resolved[requestedID] = true // ids repetidos no request aparecem uma vez
+		}
+	}
+
+	return &domain.ResolveIdentityMappingsResponse{Data: mappings, Unresolved: unresolved}, nil
+}
+
+// DeleteMapping remove um mapping; o registro Linkko continua intacto.
+// Permission: admin, manager, agent.
+func (s *IdentityMappingService) DeleteMapping(ctx context.Context, workspaceID, mappingID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanModifyContacts(role) {
+		return ErrUnauthorized
+	}
+
+	if err := s.mappingRepo.Delete(ctx, workspaceID, mappingID); err != nil {
+		return err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "delete", "identity_mapping", &mappingID, nil, "", "")
+
+	return nil
+}
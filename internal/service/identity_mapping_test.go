@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestIdentityMappingService_Integration
+func TestIdentityMappingService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	svc := service.NewIdentityMappingService(repo.NewIdentityMappingRepository(pool), repo.NewWorkspaceRepository(pool),
+		repo.NewAuditRepo(pool), log)
+
+	register := func(actorID string, inputs ...domain.IdentityMappingInput) ([]domain.IdentityMapping, error) {
+		req := &domain.RegisterIdentityMappingsRequest{Mappings: inputs}
+		require.NoError(t, req.Validate())
+		return svc.RegisterMappings(ctx, f.WorkspaceID, actorID, req)
+	}
+	resolve := func(actorID string, req *domain.ResolveIdentityMappingsRequest) (*domain.ResolveIdentityMappingsResponse, error) {
+		require.NoError(t, req.Validate())
+		return svc.ResolveMappings(ctx, f.WorkspaceID, actorID, req)
+	}
+	contact := func(system, externalID, entityID string) domain.IdentityMappingInput {
+		return domain.IdentityMappingInput{System: system, EntityType: domain.IdentityEntityContact, ExternalID: externalID, EntityID: entityID}
+	}
+
+	t.Run("registers and resolves in both directions", func(t *testing.T) {
+		ana := f.Contact()
+		deal := f.Deal()
+
+		saved, err := register(f.Member(domain.RoleUser),
+			contact("ERP", "C-10", ana.ID),
+			contact("zendesk", "Z-10", ana.ID),
+			domain.IdentityMappingInput{System: "erp", EntityType: domain.IdentityEntityDeal, ExternalID: "C-10", EntityID: deal.ID},
+		)
+		require.NoError(t, err)
+		require.Len(t, saved, 3)
+		assert.Equal(t, "erp", saved[0].System, "systems are case-insensitive")
+
+		viewer := f.Member(domain.RoleViewer)
+		byExternal, err := resolve(viewer, &domain.ResolveIdentityMappingsRequest{
+			System: "erp", EntityType: domain.IdentityEntityContact, ExternalIDs: []string{"C-10", "C-404", "C-404"},
+		})
+		require.NoError(t, err)
+		require.Len(t, byExternal.Data, 1)
+		assert.Equal(t, ana.ID, byExternal.Data[0].EntityID)
+		assert.Equal(t, []string{"C-404"}, byExternal.Unresolved)
+
+		byEntity, err := resolve(viewer, &domain.ResolveIdentityMappingsRequest{
+			EntityType: domain.IdentityEntityContact, EntityIDs: []string{ana.ID},
+		})
+		require.NoError(t, err)
+		assert.Len(t, byEntity.Data, 2, "one id per system")
+		assert.Empty(t, byEntity.Unresolved)
+	})
+
+	t.Run("rejects records from another workspace", func(t *testing.T) {
+		_, err := register(f.UserID, contact("erp", "C-20", other.Contact().ID))
+		assert.ErrorIs(t, err, service.ErrIdentityMappingEntityNotFound)
+
+		_, err = register(f.UserID, contact("erp", "C-21", f.Contact().ID), contact("erp", "C-22", other.Contact().ID))
+		assert.ErrorIs(t, err, service.ErrIdentityMappingEntityNotFound)
+
+		resolved, err := resolve(f.UserID, &domain.ResolveIdentityMappingsRequest{
+			System: "erp", EntityType: domain.IdentityEntityContact, ExternalIDs: []string{"C-21"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resolved.Data, "the batch is all or nothing")
+	})
+
+	t.Run("viewers cannot register or delete", func(t *testing.T) {
+		viewer := f.Member(domain.RoleViewer)
+		_, err := register(viewer, contact("erp", "C-30", f.Contact().ID))
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		saved, err := register(f.UserID, contact("erp", "C-31", f.Contact().ID))
+		require.NoError(t, err)
+		assert.ErrorIs(t, svc.DeleteMapping(ctx, f.WorkspaceID, saved[0].ID, viewer), service.ErrUnauthorized)
+		assert.ErrorIs(t, svc.DeleteMapping(ctx, other.WorkspaceID, saved[0].ID, other.UserID), repo.ErrIdentityMappingNotFound)
+		require.NoError(t, svc.DeleteMapping(ctx, f.WorkspaceID, saved[0].ID, f.UserID))
+	})
+}
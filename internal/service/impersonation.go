@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrImpersonationTargetNotFound o usuário impersonado precisa ser membro do workspace
+	ErrImpersonationTargetNotFound = apperr.NotFound("impersonation target is not a member of the workspace", "user not found in workspace")
+
+	// ErrImpersonateSelf o admin não pode impersonar a si mesmo
+	ErrImpersonateSelf = apperr.Unprocessable(apperr.CodeValidationError, "actor cannot impersonate itself", "cannot impersonate yourself")
+
+	// ErrImpersonateAdmin admins não podem ser impersonados (o token herdaria os poderes de admin)
+	ErrImpersonateAdmin = apperr.Forbidden("impersonation target is a workspace admin", "cannot impersonate an admin")
+
+	// ErrNestedImpersonation um token de impersonation não emite outro
+	ErrNestedImpersonation = apperr.Forbidden("impersonation token cannot issue another impersonation token", "not allowed under impersonation")
+)
+
+// ImpersonationService emite tokens de impersonation para o suporte reproduzir problemas de
+// clientes. O admin original fica no claim impersonatorId e em toda entrada do audit log.
+type ImpersonationService struct {
+	workspaceRepo  *repo.WorkspaceRepository
+	auditRepo      *repo.AuditRepo
+	signer         *auth.HS256Signer
+	maxTTL         time.Duration
+	blockMutations atomic.Bool // IMPERSONATION_BLOCK_MUTATIONS, recarregável
+	log            *logger.Logger
+}
+
+func NewImpersonationService(workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, signer *auth.HS256Signer, maxTTL time.Duration, blockMutations bool, log *logger.Logger) *ImpersonationService {
+	s := &ImpersonationService{
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		signer:        signer,
+		maxTTL:        maxTTL,
+		log:           log,
+	}
+	s.blockMutations.Store(blockMutations)
+	return s
+}
+
+// SetBlockMutations aplica um novo IMPERSONATION_BLOCK_MUTATIONS (reload de configuração)
+func (s *ImpersonationService) SetBlockMutations(block bool) {
+	s.blockMutations.Store(block)
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *ImpersonationService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("impersonation"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// StartImpersonation emite um token em que o admin age como req.ActorID no workspace.
+// A validade é ttlMinutes, limitada (e por padrão igual) a IMPERSONATION_MAX_TTL_MINUTES.
+// Permission: admin only; nunca a partir de outro token de impersonation.
+func (s *ImpersonationService) StartImpersonation(ctx context.Context, workspaceID, actorID string, req *domain.CreateImpersonationRequest) (*domain.ImpersonationToken, error) {
+	if _, impersonated := auth.ImpersonatorIDFromContext(ctx); impersonated {
+		return nil, ErrNestedImpersonation
+	}
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanManageWorkspace(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if req.ActorID == actorID {
+		return nil, ErrImpersonateSelf
+	}
+	targetRole, err := s.workspaceRepo.GetMemberRole(ctx, req.ActorID, workspaceID)
+	if err != nil {
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return nil, ErrImpersonationTargetNotFound
+		}
+		return nil, fmt.Errorf("get target member role: %w", err)
+	}
+	if targetRole == domain.RoleAdmin {
+		return nil, ErrImpersonateAdmin
+	}
+
+	ttl := s.maxTTL
+	if req.TTLMinutes != nil {
+		if requested := time.Duration(*req.TTLMinutes) * time.Minute; requested < ttl {
+			ttl = requested
+		}
+	}
+
+	token, expiresAt, err := s.signer.Sign(&auth.CustomClaims{
+		WorkspaceID:    workspaceID,
+		ActorID:        req.ActorID,
+		ImpersonatorID: actorID,
+	}, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("sign impersonation token: %w", err)
+	}
+
+	readOnly := s.blockMutations.Load()
+	s.logAction(ctx, workspaceID, actorID, "impersonation_start", req.ActorID, map[string]interface{}{
+		"reason":    req.Reason,
+		"expiresAt": expiresAt.UTC().Format(time.RFC3339),
+		"readOnly":  readOnly,
+	})
+
+	return &domain.ImpersonationToken{
+		Token:          token,
+		TokenType:      "Bearer",
+		WorkspaceID:    workspaceID,
+		ActorID:        req.ActorID,
+		ImpersonatorID: actorID,
+		ReadOnly:       readOnly,
+		ExpiresAt:      expiresAt.UTC(),
+	}, nil
+}
+
+func (s *ImpersonationService) logAction(ctx context.Context, workspaceID, actorID, action, targetID string, metadata map[string]interface{}) {
+	if err := s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "user", &targetID, metadata, "", ""); err != nil {
+		s.log.Warn(ctx, "failed to write audit log",
+			logger.Module("impersonation"),
+			logger.Action(action),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+	}
+	s.log.Info(ctx, "impersonation started",
+		logger.Module("impersonation"),
+		logger.Action(action),
+		zap.String("workspace_id", workspaceID),
+		zap.String("impersonator_id", actorID),
+		zap.String("actor_id", targetID),
+	)
+}
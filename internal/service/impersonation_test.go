@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestImpersonationService_StartImpersonation_Integration
+func TestImpersonationService_StartImpersonation_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	signer := auth.NewHS256Signer([]byte("impersonation-test-secret-impersonation"), "linkko-api", "linkko-api-gateway", "")
+	svc := service.NewImpersonationService(repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool), signer, time.Hour, true, log)
+
+	t.Run("admins cannot be impersonated", func(t *testing.T) {
+		admin := f.Member(domain.RoleAdmin)
+		_, err := svc.StartImpersonation(ctx, f.WorkspaceID, f.UserID, &domain.CreateImpersonationRequest{
+			ActorID: admin,
+			Reason:  "reproduce ticket",
+		})
+		assert.ErrorIs(t, err, service.ErrImpersonateAdmin)
+	})
+
+	t.Run("other members can be impersonated read-only", func(t *testing.T) {
+		member := f.Member(domain.RoleManager)
+		token, err := svc.StartImpersonation(ctx, f.WorkspaceID, f.UserID, &domain.CreateImpersonationRequest{
+			ActorID: member,
+			Reason:  "reproduce ticket",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, member, token.ActorID)
+		assert.Equal(t, f.UserID, token.ImpersonatorID)
+		assert.True(t, token.ReadOnly)
+	})
+
+	t.Run("only admins can impersonate", func(t *testing.T) {
+		manager := f.Member(domain.RoleManager)
+		_, err := svc.StartImpersonation(ctx, f.WorkspaceID, manager, &domain.CreateImpersonationRequest{
+			ActorID: f.Member(domain.RoleUser),
+			Reason:  "reproduce ticket",
+		})
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+}
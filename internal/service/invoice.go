@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// defaultInvoiceProvider provedor usado na reconciliação quando nem o request nem a credencial informam um
+const defaultInvoiceProvider = "unknown"
+
+var (
+	ErrInvoiceNotFound           = repo.ErrInvoiceNotFound
+	ErrInvoiceExternalIDConflict = repo.ErrInvoiceExternalIDConflict
+
+	ErrInvalidInvoiceDeal    = apperr.Unprocessable(apperr.CodeValidationError, "deal_id does not belong to workspace", "deal does not belong to workspace")
+	ErrInvoiceDealNotWon     = apperr.Unprocessable(apperr.CodeInvalidStage, "invoices can only be created for won deals", "")
+	ErrInvoiceAmountRequired = apperr.Unprocessable(apperr.CodeValidationError, "amount is required when the deal has no value", "")
+
+	// ErrInvoiceProviderRequired externalId sem provider (a reconciliação casa pelos dois)
+	ErrInvoiceProviderRequired = apperr.Unprocessable(apperr.CodeValidationError, "provider is required when externalId is set", "")
+)
+
+// InvoiceService registra as faturas dos negócios ganhos e as reconcilia com o provedor de
+// pagamento (eventos via S2S), separando a receita booked (negócio ganho) da collected (fatura paga).
+type InvoiceService struct {
+	invoiceRepo   *repo.InvoiceRepository
+	dealRepo      *repo.DealRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewInvoiceService(invoiceRepo *repo.InvoiceRepository, dealRepo *repo.DealRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *InvoiceService {
+	return &InvoiceService{
+		invoiceRepo:   invoiceRepo,
+		dealRepo:      dealRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *InvoiceService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("invoice"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *InvoiceService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateInvoice registra uma fatura OPEN para um negócio ganho. Sem amount/currency, usa o
+// valor e a moeda do negócio.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *InvoiceService) CreateInvoice(ctx context.Context, workspaceID, actorID string, req *domain.CreateInvoiceRequest) (*domain.Invoice, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	deal, err := s.dealRepo.Get(ctx, workspaceID, req.DealID)
+	if err != nil {
+		if errors.Is(err, repo.ErrDealNotFound) {
+			return nil, ErrInvalidInvoiceDeal
+		}
+		return nil, fmt.Errorf("get deal: %w", err)
+	}
+	if deal.Stage != domain.DealStageWon {
+		return nil, ErrInvoiceDealNotWon
+	}
+
+	amount := req.Amount
+	if amount == nil {
+		amount = deal.Value
+	}
+	if amount == nil {
+		return nil, ErrInvoiceAmountRequired
+	}
+	currency := deal.Currency
+	if req.Currency != nil {
+		currency = *req.Currency
+	}
+
+	invoiceID, err := id.New(id.Invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.invoiceRepo.Create(ctx, &domain.Invoice{
+		ID:          invoiceID,
+		WorkspaceID: workspaceID,
+		DealID:      deal.ID,
+		Number:      req.Number,
+		Status:      domain.InvoiceStatusOpen,
+		Amount:      *amount,
+		Currency:    currency,
+		DueDate:     req.DueDate,
+		Provider:    req.Provider,
+		ExternalID:  req.ExternalID,
+		CreatedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", created.ID, map[string]interface{}{
+		"dealId": created.DealID,
+		"amount": created.Amount,
+	})
+	return created, nil
+}
+
+// GetInvoice retorna a fatura.
+// Permission: all workspace members.
+func (s *InvoiceService) GetInvoice(ctx context.Context, workspaceID, invoiceID, actorID string) (*domain.Invoice, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.invoiceRepo.Get(ctx, workspaceID, invoiceID)
+}
+
+// ListInvoices lista as faturas, mais recentes primeiro, com filtros opcionais de negócio e status.
+// Permission: all workspace members.
+func (s *InvoiceService) ListInvoices(ctx context.Context, workspaceID, actorID string, params domain.InvoiceListParams) ([]domain.Invoice, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.invoiceRepo.List(ctx, workspaceID, params)
+}
+
+// UpdateInvoice altera a fatura manualmente (ex.: pagamento recebido fora do provedor).
+// Permission: admin, manager, user. Viewer cannot.
+func (s *InvoiceService) UpdateInvoice(ctx context.Context, workspaceID, invoiceID, actorID string, req *domain.UpdateInvoiceRequest) (*domain.Invoice, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.invoiceRepo.Get(ctx, workspaceID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	previousStatus := invoice.Status
+
+	if req.Number != nil {
+		invoice.Number = req.Number
+	}
+	if req.Amount != nil {
+		invoice.Amount = *req.Amount
+	}
+	if req.DueDate != nil {
+		invoice.DueDate = req.DueDate
+	}
+	if req.Provider != nil {
+		invoice.Provider = req.Provider
+	}
+	if req.ExternalID != nil {
+		invoice.ExternalID = req.ExternalID
+	}
+	if invoice.ExternalID != nil && invoice.Provider == nil {
+		return nil, ErrInvoiceProviderRequired
+	}
+	if req.Status != nil || req.AmountPaid != nil || req.PaidAt != nil {
+		status := invoice.Status
+		if req.Status != nil {
+			status = *req.Status
+		}
+		invoice.ApplyStatus(status, req.AmountPaid, req.PaidAt, time.Now().UTC())
+	}
+
+	updated, err := s.invoiceRepo.Update(ctx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{"dealId": updated.DealID}
+	if updated.Status != previousStatus {
+		metadata["fromStatus"] = previousStatus
+		metadata["toStatus"] = updated.Status
+	}
+	s.logAction(ctx, workspaceID, actorID, "update", invoiceID, metadata)
+	return updated, nil
+}
+
+// DeleteInvoice remove a fatura.
+// Permission: admin, manager.
+func (s *InvoiceService) DeleteInvoice(ctx context.Context, workspaceID, invoiceID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return err
+	}
+	if err := s.invoiceRepo.Delete(ctx, workspaceID, invoiceID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", invoiceID, nil)
+	return nil
+}
+
+// ReconcileInvoices aplica o estado reportado pelo provedor de pagamento às faturas com o
+// mesmo provider + externalId, na ordem do lote. Eventos mais antigos que o último aplicado
+// à fatura são ignorados, então o provedor pode reenviar o lote inteiro após uma falha.
+// defaultProvider é o cliente S2S, usado quando o request não informa provider.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *InvoiceService) ReconcileInvoices(ctx context.Context, workspaceID, actorID, defaultProvider string, req *domain.ReconcileInvoicesRequest) (*domain.ReconcileInvoicesResponse, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	provider := req.Provider
+	if provider == "" {
+		provider = strings.ToLower(defaultProvider)
+	}
+	if provider == "" {
+		provider = defaultInvoiceProvider
+	}
+
+	response := &domain.ReconcileInvoicesResponse{Results: make([]domain.InvoiceEventResult, 0, len(req.Events))}
+	for i := range req.Events {
+		result, err := s.reconcile(ctx, workspaceID, provider, &req.Events[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Index = i
+		switch result.Status {
+		case domain.InvoiceEventResultUpdated:
+			response.Updated++
+		case domain.InvoiceEventResultStale:
+			response.Stale++
+		case domain.InvoiceEventResultUnmatched:
+			response.Unmatched++
+		}
+		response.Results = append(response.Results, *result)
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "reconcile", "invoice", nil, map[string]interface{}{
+		"provider":  provider,
+		"updated":   response.Updated,
+		"stale":     response.Stale,
+		"unmatched": response.Unmatched,
+	}, "", "")
+
+	return response, nil
+}
+
+// reconcile aplica um evento à fatura correspondente.
+func (s *InvoiceService) reconcile(ctx context.Context, workspaceID, provider string, in *domain.InvoiceEventInput) (*domain.InvoiceEventResult, error) {
+	invoice, err := s.invoiceRepo.GetByExternalID(ctx, workspaceID, provider, in.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return &domain.InvoiceEventResult{Status: domain.InvoiceEventResultUnmatched}, nil
+	}
+	result := &domain.InvoiceEventResult{Status: domain.InvoiceEventResultStale, InvoiceID: &invoice.ID}
+	if invoice.ProviderUpdatedAt != nil && in.OccurredAt.Before(*invoice.ProviderUpdatedAt) {
+		return result, nil
+	}
+
+	if in.Amount != nil {
+		invoice.Amount = *in.Amount
+	}
+	if in.DueDate != nil {
+		invoice.DueDate = in.DueDate
+	}
+	paidAt := in.PaidAt
+	if in.Status == domain.InvoiceStatusPaid && paidAt == nil {
+		paidAt = &in.OccurredAt
+	}
+	invoice.ApplyStatus(in.Status, in.AmountPaid, paidAt, in.OccurredAt)
+
+	updated, err := s.invoiceRepo.ApplyProviderState(ctx, invoice, in.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return result, nil
+	}
+	result.Status = domain.InvoiceEventResultUpdated
+	result.InvoiceStatus = &updated.Status
+
+	s.log.Info(ctx, "invoice reconciled",
+		logger.Module("invoice"),
+		logger.Action("reconcile"),
+		zap.String("invoice_id", updated.ID),
+		zap.String("provider", provider),
+		zap.String("status", string(updated.Status)),
+	)
+	return result, nil
+}
+
+// logAction registra a ação no audit log (best-effort).
+func (s *InvoiceService) logAction(ctx context.Context, workspaceID, actorID, action, invoiceID string, metadata map[string]interface{}) {
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "invoice", &invoiceID, metadata, "", "")
+}
@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestInvoiceService_Reconcile_Integration
+func TestInvoiceService_Reconcile_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	other := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	invoices := repo.NewInvoiceRepository(pool)
+	svc := service.NewInvoiceService(invoices, repo.NewDealRepository(pool), repo.NewWorkspaceRepository(pool),
+		repo.NewAuditRepo(pool), log)
+
+	user := f.Member(domain.RoleUser)
+	won := f.Deal(func(d *domain.Deal) { d.Stage = domain.DealStageWon })
+	createInvoice := func(externalID string) *domain.Invoice {
+		t.Helper()
+		req := &domain.CreateInvoiceRequest{DealID: won.ID, Provider: factory.Ptr("Stripe"), ExternalID: &externalID}
+		require.NoError(t, req.Validate())
+		invoice, err := svc.CreateInvoice(ctx, f.WorkspaceID, user, req)
+		require.NoError(t, err)
+		return invoice
+	}
+	reconcile := func(events ...domain.InvoiceEventInput) *domain.ReconcileInvoicesResponse {
+		t.Helper()
+		req := &domain.ReconcileInvoicesRequest{Events: events}
+		require.NoError(t, req.Validate())
+		response, err := svc.ReconcileInvoices(ctx, f.WorkspaceID, user, "stripe", req)
+		require.NoError(t, err)
+		return response
+	}
+
+	t.Run("invoices require a won deal", func(t *testing.T) {
+		req := &domain.CreateInvoiceRequest{DealID: f.Deal().ID}
+		require.NoError(t, req.Validate())
+		_, err := svc.CreateInvoice(ctx, f.WorkspaceID, user, req)
+		assert.ErrorIs(t, err, service.ErrInvoiceDealNotWon)
+		appErr, ok := apperr.From(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+
+		req = &domain.CreateInvoiceRequest{DealID: other.Deal(func(d *domain.Deal) { d.Stage = domain.DealStageWon }).ID}
+		require.NoError(t, req.Validate())
+		_, err = svc.CreateInvoice(ctx, f.WorkspaceID, user, req)
+		assert.ErrorIs(t, err, service.ErrInvalidInvoiceDeal)
+	})
+
+	t.Run("out-of-order events keep the most recent state", func(t *testing.T) {
+		invoice := createInvoice("in_out_of_order")
+		t0 := time.Now().UTC().Truncate(time.Millisecond)
+
+		response := reconcile(
+			domain.InvoiceEventInput{ExternalID: "in_out_of_order", Status: domain.InvoiceStatusVoid, OccurredAt: t0.Add(2 * time.Minute)},
+			domain.InvoiceEventInput{ExternalID: "in_out_of_order", Status: domain.InvoiceStatusOpen, OccurredAt: t0.Add(time.Minute)},
+		)
+		assert.Equal(t, 1, response.Updated)
+		assert.Equal(t, 1, response.Stale)
+		require.Len(t, response.Results, 2)
+		assert.Equal(t, domain.InvoiceEventResultUpdated, response.Results[0].Status)
+		assert.Equal(t, domain.InvoiceEventResultStale, response.Results[1].Status)
+		assert.Equal(t, invoice.ID, *response.Results[1].InvoiceID)
+
+		got, err := invoices.Get(ctx, f.WorkspaceID, invoice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InvoiceStatusVoid, got.Status)
+		require.NotNil(t, got.ProviderUpdatedAt)
+		assert.True(t, got.ProviderUpdatedAt.Equal(t0.Add(2*time.Minute)))
+
+		// Reenvio do mesmo lote: nada muda
+		response = reconcile(
+			domain.InvoiceEventInput{ExternalID: "in_out_of_order", Status: domain.InvoiceStatusOpen, OccurredAt: t0.Add(time.Minute)},
+		)
+		assert.Equal(t, 1, response.Stale)
+	})
+
+	t.Run("paid events set paidAt from the event time", func(t *testing.T) {
+		invoice := createInvoice("in_paid")
+		occurredAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+
+		response := reconcile(domain.InvoiceEventInput{ExternalID: "in_paid", Status: domain.InvoiceStatusPaid, OccurredAt: occurredAt})
+		require.Equal(t, 1, response.Updated)
+		assert.Equal(t, domain.InvoiceStatusPaid, *response.Results[0].InvoiceStatus)
+
+		got, err := invoices.Get(ctx, f.WorkspaceID, invoice.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.PaidAt)
+		assert.True(t, got.PaidAt.Equal(occurredAt))
+		assert.Equal(t, invoice.Amount, got.AmountPaid, "settled in full")
+	})
+
+	t.Run("unmatched externalIds are reported without failing the batch", func(t *testing.T) {
+		createInvoice("in_matched")
+		now := time.Now().UTC()
+
+		response := reconcile(
+			domain.InvoiceEventInput{ExternalID: "in_unknown", Status: domain.InvoiceStatusPaid, OccurredAt: now},
+			domain.InvoiceEventInput{ExternalID: "in_matched", Status: domain.InvoiceStatusUncollectible, OccurredAt: now},
+		)
+		assert.Equal(t, 1, response.Unmatched)
+		assert.Equal(t, 1, response.Updated)
+		assert.Equal(t, domain.InvoiceEventResultUnmatched, response.Results[0].Status)
+		assert.Nil(t, response.Results[0].InvoiceID)
+		assert.Equal(t, 1, response.Results[1].Index)
+
+		// Fatura com o mesmo externalId em outro provedor não casa
+		req := &domain.ReconcileInvoicesRequest{Provider: "adyen", Events: []domain.InvoiceEventInput{
+			{ExternalID: "in_matched", Status: domain.InvoiceStatusPaid, OccurredAt: now.Add(time.Minute)},
+		}}
+		require.NoError(t, req.Validate())
+		response, err := svc.ReconcileInvoices(ctx, f.WorkspaceID, user, "stripe", req)
+		require.NoError(t, err)
+		assert.Equal(t, 1, response.Unmatched)
+	})
+
+	t.Run("viewers cannot reconcile", func(t *testing.T) {
+		req := &domain.ReconcileInvoicesRequest{Events: []domain.InvoiceEventInput{
+			{ExternalID: "in_paid", Status: domain.InvoiceStatusVoid, OccurredAt: time.Now().UTC()},
+		}}
+		_, err := svc.ReconcileInvoices(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), "stripe", req)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+}
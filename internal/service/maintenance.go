@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"go.uber.org/zap"
+)
+
+var ErrMaintenanceNotFound = repo.ErrMaintenanceNotFound
+
+// MaintenanceService liga e desliga o modo de manutenção (rotas /admin) e responde ao
+// MaintenanceMiddleware. As manutenções ligadas são poucas: cada instância guarda a lista inteira
+// por ttl, então uma mudança feita em outra instância vale em até ttl.
+// Implements middleware.MaintenanceLookup.
+type MaintenanceService struct {
+	maintenanceRepo *repo.MaintenanceRepository
+	workspaceRepo   *repo.WorkspaceRepository
+	ttl             time.Duration
+	now             func() time.Time
+	log             *logger.Logger
+
+	mu        sync.Mutex
+	active    map[string]domain.Maintenance
+	expiresAt time.Time
+}
+
+// NewMaintenanceService ttl <= 0 consulta o banco em toda escrita.
+func NewMaintenanceService(maintenanceRepo *repo.MaintenanceRepository, workspaceRepo *repo.WorkspaceRepository, ttl time.Duration, log *logger.Logger) *MaintenanceService {
+	return &MaintenanceService{
+		maintenanceRepo: maintenanceRepo,
+		workspaceRepo:   workspaceRepo,
+		ttl:             ttl,
+		now:             time.Now,
+		log:             log,
+	}
+}
+
+// Maintenance retorna a manutenção que vale para o workspace (a global tem precedência) ou nil.
+// workspaceID vazio considera só a global. Erros do banco não são cacheados.
+func (s *MaintenanceService) Maintenance(ctx context.Context, workspaceID string) (*domain.Maintenance, error) {
+	active, err := s.activeMaintenances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := active[domain.MaintenanceScopeGlobal]; ok {
+		return &m, nil
+	}
+	if m, ok := active[workspaceID]; ok && workspaceID != "" {
+		return &m, nil
+	}
+	return nil, nil
+}
+
+// List retorna as manutenções ligadas, sem cache.
+func (s *MaintenanceService) List(ctx context.Context) ([]domain.Maintenance, error) {
+	return s.maintenanceRepo.List(ctx)
+}
+
+// Enable liga (ou atualiza) a manutenção do escopo: domain.MaintenanceScopeGlobal ou o ID de um
+// workspace existente.
+func (s *MaintenanceService) Enable(ctx context.Context, scope string, req *domain.SetMaintenanceRequest) (*domain.Maintenance, error) {
+	if scope != domain.MaintenanceScopeGlobal {
+		if _, err := s.workspaceRepo.GetStatus(ctx, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := s.maintenanceRepo.Upsert(ctx, scope, req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	s.log.Warn(ctx, "maintenance enabled",
+		zap.String("scope", scope),
+		zap.Timep("ends_at", m.EndsAt),
+	)
+	return m, nil
+}
+
+// Disable desliga a manutenção do escopo.
+func (s *MaintenanceService) Disable(ctx context.Context, scope string) error {
+	if err := s.maintenanceRepo.Delete(ctx, scope); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.log.Warn(ctx, "maintenance disabled", zap.String("scope", scope))
+	return nil
+}
+
+// activeMaintenances lista em cache por escopo; a consulta roda fora do lock
+func (s *MaintenanceService) activeMaintenances(ctx context.Context) (map[string]domain.Maintenance, error) {
+	s.mu.Lock()
+	if s.active != nil && s.now().Before(s.expiresAt) {
+		active := s.active
+		s.mu.Unlock()
+		return active, nil
+	}
+	s.mu.Unlock()
+
+	list, err := s.maintenanceRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[string]domain.Maintenance, len(list))
+	for _, m := range list {
+		active[m.Scope] = m
+	}
+
+	s.mu.Lock()
+	s.active, s.expiresAt = active, s.now().Add(s.ttl)
+	s.mu.Unlock()
+	return active, nil
+}
+
+// invalidate faz a próxima escrita desta instância reler a lista
+func (s *MaintenanceService) invalidate() {
+	s.mu.Lock()
+	s.active = nil
+	s.mu.Unlock()
+}
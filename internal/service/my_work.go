@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// MyWorkService agrega o trabalho pendente do ator (tarefas, reuniões e negócios).
+type MyWorkService struct {
+	myWorkRepo    *repo.MyWorkRepository
+	workspaceRepo *repo.WorkspaceRepository
+	log           *logger.Logger
+}
+
+func NewMyWorkService(myWorkRepo *repo.MyWorkRepository, workspaceRepo *repo.WorkspaceRepository, log *logger.Logger) *MyWorkService {
+	return &MyWorkService{
+		myWorkRepo:    myWorkRepo,
+		workspaceRepo: workspaceRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *MyWorkService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("my_work"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// GetMyWork returns the actor's overdue and today's tasks, upcoming meetings
+// and open deals requiring attention.
+// Permission: all workspace members (data is scoped to the actor).
+func (s *MyWorkService) GetMyWork(ctx context.Context, workspaceID, actorID string, params domain.MyWorkParams) (*domain.MyWork, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.Normalize()
+	todayStart, todayEnd := params.Today()
+
+	overdue, err := s.myWorkRepo.TasksDueBetween(ctx, workspaceID, actorID, nil, todayStart, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	today, err := s.myWorkRepo.TasksDueBetween(ctx, workspaceID, actorID, &todayStart, todayEnd, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	meetings, err := s.myWorkRepo.UpcomingMeetings(ctx, workspaceID, actorID, params.Now, params.Now.Add(domain.MyWorkMeetingsWindow), params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	deals, err := s.myWorkRepo.DealsRequiringAttention(ctx, workspaceID, actorID, params.Now, todayStart, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MyWork{
+		AsOf:                    params.Now,
+		TimeZone:                params.Location.String(),
+		OverdueTasks:            *overdue,
+		TodayTasks:              *today,
+		UpcomingMeetings:        *meetings,
+		DealsRequiringAttention: *deals,
+	}, nil
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/objectstore"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrNoteNotFound           = repo.ErrNoteNotFound
+	ErrNoteAttachmentNotFound = repo.ErrNoteAttachmentNotFound
+	ErrUnknownNoteAttachment  = apperr.Unprocessable(apperr.CodeValidationError, "note body references an attachment that does not belong to the note", "note body references an unknown attachment")
+	ErrNoteAttachmentTooLarge = apperr.Unprocessable(apperr.CodeValidationError, "note attachment exceeds the maximum size", "attachment exceeds the maximum size")
+	ErrNoteAttachmentMissing  = apperr.NotFound("note attachment file missing from object storage", "attachment file not found")
+	ErrNoteAttachmentInfected = apperr.Unprocessable(apperr.CodeAttachmentInfected, "note attachment failed the malware scan", "attachment was rejected by the malware scan")
+	ErrAttachmentScanFailed   = apperr.Define(apperr.CodeServiceUnavailable, http.StatusServiceUnavailable, "attachment scanner is not available", "attachment scanning is temporarily unavailable, retry later")
+)
+
+// quarantinePrefix prefixo no object storage dos anexos retidos pelo antivírus
+const quarantinePrefix = "quarantine"
+
+// GetNote retorna a nota com seus anexos.
+// Permission: all workspace members.
+func (s *ActivityService) GetNote(ctx context.Context, workspaceID, noteID, actorID string) (*domain.Note, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	note, err := s.activityRepo.GetNote(ctx, workspaceID, noteID, domain.VisibilityViewer(actorID, role))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachNoteAttachments(ctx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// UpdateNote substitui o texto da nota. Anexos referenciados inline no body precisam ter
+// sido enviados para a própria nota; menções novas notificam os usuários mencionados.
+// Permission: admin, manager, agent; só o autor ou um admin altera a visibilidade.
+func (s *ActivityService) UpdateNote(ctx context.Context, workspaceID, noteID, actorID string, req *domain.UpdateNoteRequest) (*domain.Note, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	current, err := s.activityRepo.GetNote(ctx, workspaceID, noteID, domain.VisibilityViewer(actorID, role))
+	if err != nil {
+		return nil, err
+	}
+	if req.Visibility != nil && current.UserID != actorID && role != domain.RoleAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	if refs := req.Body.AttachmentIDs(); len(refs) > 0 {
+		attachments, err := s.activityRepo.ListNoteAttachments(ctx, workspaceID, noteID)
+		if err != nil {
+			return nil, err
+		}
+		known := make(map[string]bool, len(attachments))
+		for _, a := range attachments {
+			known[a.ID] = true
+		}
+		for _, id := range refs {
+			if !known[id] {
+				return nil, ErrUnknownNoteAttachment
+			}
+		}
+	}
+
+	note, err := s.activityRepo.UpdateNoteBody(ctx, workspaceID, noteID, req.Body, req.Content, req.Visibility)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachNoteAttachments(ctx, note); err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "update", "note", &noteID, nil, "", "")
+
+	if note.Visibility != domain.VisibilityPrivate {
+		s.followers.Mentioned(ctx, note, actorID, newMentions(current.Body, note.Body))
+	}
+
+	return note, nil
+}
+
+// SetNotePinned fixa ou desafixa a nota no topo da timeline das entidades dela.
+// Permission: admin, manager, agent.
+func (s *ActivityService) SetNotePinned(ctx context.Context, workspaceID, noteID, actorID string, pinned bool) (*domain.Note, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if _, err := s.activityRepo.GetNote(ctx, workspaceID, noteID, domain.VisibilityViewer(actorID, role)); err != nil {
+		return nil, err
+	}
+
+	var pinnedBy *string
+	action := "unpin"
+	if pinned {
+		pinnedBy = &actorID
+		action = "pin"
+	}
+
+	note, err := s.activityRepo.SetNotePinned(ctx, workspaceID, noteID, pinnedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "note", &noteID, nil, "", "")
+
+	return note, nil
+}
+
+// UploadNoteAttachment grava o arquivo no object storage e o registra na nota.
+// Conteúdo acima de maxAttachmentBytes é rejeitado com ErrNoteAttachmentTooLarge; com scanner
+// configurado, o arquivo só é registrado depois de passar pelo antivírus (scanNoteAttachment).
+// Permission: admin, manager, agent.
+func (s *ActivityService) UploadNoteAttachment(ctx context.Context, workspaceID, noteID, actorID, fileName, contentType string, content io.Reader) (*domain.NoteAttachment, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if _, err := s.activityRepo.GetNote(ctx, workspaceID, noteID, domain.VisibilityViewer(actorID, role)); err != nil {
+		return nil, err
+	}
+
+	attachmentID, err := id.New(id.NoteAttachment)
+	if err != nil {
+		return nil, err
+	}
+	key := path.Join("notes", workspaceID, noteID, attachmentID)
+	if !objectstore.ValidKey(key) {
+		return nil, ErrNoteNotFound
+	}
+
+	// Lê um byte além do limite para detectar arquivos grandes demais
+	size, err := s.attachments.Put(ctx, key, io.LimitReader(content, s.maxAttachmentBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("store note attachment: %w", err)
+	}
+	if size > s.maxAttachmentBytes {
+		s.discardNoteAttachment(ctx, workspaceID, attachmentID, key)
+		return nil, ErrNoteAttachmentTooLarge
+	}
+
+	if err := s.scanNoteAttachment(ctx, workspaceID, noteID, actorID, attachmentID, key, fileName); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.activityRepo.CreateNoteAttachment(ctx, &domain.NoteAttachment{
+		ID:           attachmentID,
+		WorkspaceID:  workspaceID,
+		NoteID:       noteID,
+		FileName:     sanitizeAttachmentName(fileName),
+		ContentType:  contentType,
+		Size:         size,
+		StorageKey:   key,
+		UploadedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "attachment_upload", "note", &noteID, nil, "", "")
+
+	return attachment, nil
+}
+
+// OpenNoteAttachment abre o arquivo de um anexo; o chamador fecha o reader.
+// Permission: all workspace members.
+func (s *ActivityService) OpenNoteAttachment(ctx context.Context, workspaceID, noteID, attachmentID, actorID string) (*domain.NoteAttachment, io.ReadCloser, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, nil, ErrUnauthorized
+	}
+
+	attachment, err := s.activityRepo.GetNoteAttachment(ctx, workspaceID, noteID, attachmentID, domain.VisibilityViewer(actorID, role))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.attachments.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, objectstore.ErrNotFound) {
+			return nil, nil, ErrNoteAttachmentMissing
+		}
+		return nil, nil, fmt.Errorf("open note attachment: %w", err)
+	}
+	return attachment, body, nil
+}
+
+// scanNoteAttachment passa o arquivo já gravado pelo antivírus. Infectado, o objeto vai para
+// quarentena (fora das chaves servidas no download), o evento de segurança é logado e gravado no
+// audit log e o upload falha com ErrNoteAttachmentInfected. Com o scanner indisponível o upload
+// é recusado (ErrAttachmentScanFailed) e o objeto apagado, a menos que scanFailOpen esteja
+// ligado. Se a quarentena falhar, o objeto infectado é apagado.
+func (s *ActivityService) scanNoteAttachment(ctx context.Context, workspaceID, noteID, actorID, attachmentID, key, fileName string) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	body, err := s.attachments.Get(ctx, key)
+	if err != nil {
+		s.discardNoteAttachment(ctx, workspaceID, attachmentID, key)
+		return fmt.Errorf("open note attachment for scan: %w", err)
+	}
+	result, err := s.scanner.Scan(ctx, body)
+	body.Close()
+	if err != nil {
+		s.log.Error(ctx, "attachment scan failed",
+			logger.Module("activity"),
+			logger.Action("attachment_scan"),
+			zap.String("scanner", s.scanner.Name()),
+			zap.String("workspace_id", workspaceID),
+			zap.String("attachment_id", attachmentID),
+			zap.Bool("fail_open", s.scanFailOpen),
+			zap.Error(err),
+		)
+		if s.scanFailOpen {
+			return nil
+		}
+		s.discardNoteAttachment(ctx, workspaceID, attachmentID, key)
+		return ErrAttachmentScanFailed
+	}
+	if !result.Infected {
+		return nil
+	}
+
+	quarantineKey := path.Join(quarantinePrefix, key)
+	if err := s.attachments.Move(ctx, key, quarantineKey); err != nil {
+		s.log.Error(ctx, "failed to quarantine infected attachment, deleting it",
+			logger.Module("security"),
+			logger.Action("malware_detected"),
+			zap.String("workspace_id", workspaceID),
+			zap.String("attachment_id", attachmentID),
+			zap.Error(err),
+		)
+		s.discardNoteAttachment(ctx, workspaceID, attachmentID, key)
+		quarantineKey = ""
+	}
+
+	s.log.Warn(ctx, "infected attachment rejected",
+		logger.Module("security"),
+		logger.Action("malware_detected"),
+		zap.String("scanner", s.scanner.Name()),
+		zap.String("signature", result.Signature),
+		zap.String("workspace_id", workspaceID),
+		zap.String("note_id", noteID),
+		zap.String("attachment_id", attachmentID),
+		zap.String("actor_id", actorID),
+		zap.String("quarantine_key", quarantineKey),
+	)
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "attachment_quarantined", "note", &noteID, map[string]interface{}{
+		"attachmentId":  attachmentID,
+		"fileName":      sanitizeAttachmentName(fileName),
+		"scanner":       s.scanner.Name(),
+		"signature":     result.Signature,
+		"quarantineKey": quarantineKey, // vazio: a quarentena falhou e o objeto foi apagado
+	}, "", "")
+
+	return ErrNoteAttachmentInfected
+}
+
+// discardNoteAttachment apaga o objeto de um upload recusado; uma falha só é logada (o objeto
+// não é referenciado por nenhuma nota).
+func (s *ActivityService) discardNoteAttachment(ctx context.Context, workspaceID, attachmentID, key string) {
+	if err := s.attachments.Delete(ctx, key); err != nil {
+		s.log.Error(ctx, "failed to delete rejected attachment",
+			logger.Module("activity"),
+			logger.Action("attachment_upload"),
+			zap.String("workspace_id", workspaceID),
+			zap.String("attachment_id", attachmentID),
+			zap.String("storage_key", key),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *ActivityService) attachNoteAttachments(ctx context.Context, note *domain.Note) error {
+	attachments, err := s.activityRepo.ListNoteAttachments(ctx, note.WorkspaceID, note.ID)
+	if err != nil {
+		return err
+	}
+	note.Attachments = attachments
+	return nil
+}
+
+// newMentions usuários mencionados em after que não estavam em before.
+func newMentions(before, after *domain.RichTextNode) []string {
+	if after == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	if before != nil {
+		for _, id := range before.MentionedUserIDs() {
+			seen[id] = true
+		}
+	}
+	var added []string
+	for _, id := range after.MentionedUserIDs() {
+		if !seen[id] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// sanitizeAttachmentName mantém só o nome base do arquivo, sem separadores nem aspas
+// (o nome volta no Content-Disposition do download).
+func sanitizeAttachmentName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"linkko-api/internal/integrations/antivirus"
+	"linkko-api/internal/objectstore"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScanner devolve o veredito configurado depois de consumir o conteúdo.
+type fakeScanner struct {
+	result antivirus.Result
+	err    error
+}
+
+func (fakeScanner) Name() string { return "fake" }
+
+func (s fakeScanner) Scan(ctx context.Context, r io.Reader) (antivirus.Result, error) {
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return antivirus.Result{}, err
+	}
+	return s.result, s.err
+}
+
+// failingMoveStore simula um storage em que a quarentena falha.
+type failingMoveStore struct {
+	objectstore.Store
+}
+
+func (failingMoveStore) Move(ctx context.Context, src, dst string) error {
+	return errors.New("bucket policy denies copy")
+}
+
+// auditDB registra as ações gravadas pelo AuditRepo (único acesso ao banco do scan).
+type auditDB struct {
+	mu      sync.Mutex
+	actions []string
+}
+
+func (db *auditDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.actions = append(db.actions, args[2].(string))
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func (db *auditDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (db *auditDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return errRow{}
+}
+
+func (db *auditDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return nil, errors.New("unexpected transaction")
+}
+
+type errRow struct{}
+
+func (errRow) Scan(dest ...any) error { return errors.New("unexpected query") }
+
+func TestScanNoteAttachment(t *testing.T) {
+	const key = "notes/ws_1/nte_1/att_1"
+	quarantineKey := path.Join(quarantinePrefix, key)
+
+	tests := []struct {
+		name            string
+		scanner         fakeScanner
+		failOpen        bool
+		failMove        bool
+		wantErr         error
+		wantKept        bool
+		wantQuarantined bool
+		wantAudit       []string
+	}{
+		{
+			name:     "clean",
+			scanner:  fakeScanner{},
+			wantKept: true,
+		},
+		{
+			name:            "infected goes to quarantine",
+			scanner:         fakeScanner{result: antivirus.Result{Infected: true, Signature: "Eicar-Test-Signature"}},
+			wantErr:         ErrNoteAttachmentInfected,
+			wantQuarantined: true,
+			wantAudit:       []string{"attachment_quarantined"},
+		},
+		{
+			name:      "infected is deleted when quarantine fails",
+			scanner:   fakeScanner{result: antivirus.Result{Infected: true, Signature: "Eicar-Test-Signature"}},
+			failMove:  true,
+			wantErr:   ErrNoteAttachmentInfected,
+			wantAudit: []string{"attachment_quarantined"},
+		},
+		{
+			name:    "scan error fails closed and deletes the upload",
+			scanner: fakeScanner{err: errors.New("connect to clamd: connection refused")},
+			wantErr: ErrAttachmentScanFailed,
+		},
+		{
+			name:     "scan error fails open",
+			scanner:  fakeScanner{err: errors.New("connect to clamd: connection refused")},
+			failOpen: true,
+			wantKept: true,
+		},
+	}
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			files, err := objectstore.Open("file://" + t.TempDir())
+			require.NoError(t, err)
+			_, err = files.Put(ctx, key, strings.NewReader("attachment content"))
+			require.NoError(t, err)
+
+			var store objectstore.Store = files
+			if tt.failMove {
+				store = failingMoveStore{Store: files}
+			}
+			db := &auditDB{}
+			svc := NewActivityService(nil, nil, repo.NewAuditRepo(db), store, nil, log, 1<<20).WithScanner(tt.scanner, tt.failOpen)
+
+			err = svc.scanNoteAttachment(ctx, "ws_1", "nte_1", "usr_1", "att_1", key, "report.pdf")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			kept, err := files.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKept, kept, "uploaded object kept")
+			quarantined, err := files.Exists(ctx, quarantineKey)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuarantined, quarantined, "object in quarantine")
+			assert.Equal(t, tt.wantAudit, db.actions)
+		})
+	}
+}
+
+func TestScanNoteAttachment_NoScanner(t *testing.T) {
+	svc := NewActivityService(nil, nil, nil, nil, nil, nil, 1<<20)
+	assert.NoError(t, svc.scanNoteAttachment(context.Background(), "ws_1", "nte_1", "usr_1", "att_1", "notes/ws_1/nte_1/att_1", "report.pdf"))
+}
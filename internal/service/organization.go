@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrOrganizationNotFound = repo.ErrOrganizationNotFound
+	ErrOrgMemberNotFound    = repo.ErrOrgMemberNotFound
+
+	// ErrOrgUserNotInDirectory só usuários de algum workspace da organização recebem papel nela
+	ErrOrgUserNotInDirectory = apperr.NotFound("user has no access to any workspace of the organization", "user not found in organization")
+
+	// ErrLastOrgAdmin a organização não pode ficar sem org_admin
+	ErrLastOrgAdmin = apperr.Conflict("organization must keep at least one org_admin", "organization must keep at least one admin")
+)
+
+// OrganizationService camada de organização acima dos workspaces: dados e cobrança
+// compartilhados, diretório de membros e relatórios entre workspaces (org_admin).
+type OrganizationService struct {
+	orgRepo *repo.OrganizationRepository
+	log     *logger.Logger
+}
+
+func NewOrganizationService(orgRepo *repo.OrganizationRepository, log *logger.Logger) *OrganizationService {
+	return &OrganizationService{orgRepo: orgRepo, log: log}
+}
+
+// authorize resolve o papel do ator na organização e checa a permissão com a regra informada.
+func (s *OrganizationService) authorize(ctx context.Context, orgID, actorID string, allowed func(domain.OrgRole) bool) (domain.OrgRole, error) {
+	role, err := s.orgRepo.GetMemberRole(ctx, actorID, orgID)
+	if err != nil {
+		s.log.Error(ctx, "failed to get organization role",
+			logger.Module("organization"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("organization_id", orgID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrOrgMemberNotFound) {
+			return "", ErrOrgMemberNotFound
+		}
+		return "", fmt.Errorf("get organization role: %w", err)
+	}
+	if !allowed(role) {
+		return "", ErrUnauthorized
+	}
+	return role, nil
+}
+
+// ListOrganizations retorna as organizações em que o ator tem papel.
+func (s *OrganizationService) ListOrganizations(ctx context.Context, actorID string) ([]domain.Organization, error) {
+	return s.orgRepo.ListByUser(ctx, actorID)
+}
+
+// GetOrganization retorna a organização com o papel do ator.
+// Permission: org members.
+func (s *OrganizationService) GetOrganization(ctx context.Context, orgID, actorID string) (*domain.Organization, error) {
+	role, err := s.authorize(ctx, orgID, actorID, domain.IsOrgMember)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	org.Role = role
+	return org, nil
+}
+
+// UpdateOrganization altera nome e email de cobrança.
+// Permission: org_admin.
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, orgID, actorID string, req *domain.UpdateOrganizationRequest) (*domain.Organization, error) {
+	role, err := s.authorize(ctx, orgID, actorID, domain.CanManageOrganization)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.Update(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+	org.Role = role
+
+	s.logAction(ctx, orgID, actorID, "update", orgID)
+	return org, nil
+}
+
+// GetBilling retorna a cobrança compartilhada da organização.
+// Permission: org_admin.
+func (s *OrganizationService) GetBilling(ctx context.Context, orgID, actorID string) (*domain.OrganizationBilling, error) {
+	if _, err := s.authorize(ctx, orgID, actorID, domain.CanManageOrganization); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.Billing(ctx, orgID)
+}
+
+// ListWorkspaces retorna os workspaces da organização.
+// Permission: org members.
+func (s *OrganizationService) ListWorkspaces(ctx context.Context, orgID, actorID string) ([]domain.OrganizationWorkspace, error) {
+	if _, err := s.authorize(ctx, orgID, actorID, domain.IsOrgMember); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListWorkspaces(ctx, orgID)
+}
+
+// ListMembers retorna o diretório compartilhado da organização.
+// Permission: org members.
+func (s *OrganizationService) ListMembers(ctx context.Context, orgID, actorID string) ([]domain.OrganizationMember, error) {
+	if _, err := s.authorize(ctx, orgID, actorID, domain.IsOrgMember); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListDirectory(ctx, orgID, nil)
+}
+
+// SetMemberRole concede ou altera o papel de um usuário do diretório na organização.
+// Rebaixar o último org_admin retorna ErrLastOrgAdmin.
+// Permission: org_admin.
+func (s *OrganizationService) SetMemberRole(ctx context.Context, orgID, userID, actorID string, req *domain.SetOrganizationMemberRequest) (*domain.OrganizationMember, error) {
+	if _, err := s.authorize(ctx, orgID, actorID, domain.CanManageOrganization); err != nil {
+		return nil, err
+	}
+
+	member, err := s.directoryEntry(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.OrgRole != nil && *member.OrgRole == domain.OrgRoleAdmin && req.Role != domain.OrgRoleAdmin {
+		if err := s.ensureAnotherAdmin(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+
+	memberID, err := id.New(id.OrgMember)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.orgRepo.SetMemberRole(ctx, memberID, orgID, userID, req.Role); err != nil {
+		return nil, err
+	}
+	role := req.Role
+	member.OrgRole = &role
+
+	s.logAction(ctx, orgID, actorID, "set_member_role", userID, zap.String("role", string(req.Role)))
+	return member, nil
+}
+
+// RemoveMember remove o papel do usuário na organização; os papéis nos workspaces ficam.
+// Permission: org_admin.
+func (s *OrganizationService) RemoveMember(ctx context.Context, orgID, userID, actorID string) error {
+	if _, err := s.authorize(ctx, orgID, actorID, domain.CanManageOrganization); err != nil {
+		return err
+	}
+
+	role, err := s.orgRepo.GetMemberRole(ctx, userID, orgID)
+	if err != nil {
+		if errors.Is(err, repo.ErrOrgMemberNotFound) {
+			return ErrOrgUserNotInDirectory
+		}
+		return err
+	}
+	if role == domain.OrgRoleAdmin {
+		if err := s.ensureAnotherAdmin(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.orgRepo.DeleteMember(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, orgID, actorID, "remove_member", userID)
+	return nil
+}
+
+// OverviewReport relatório entre workspaces (estoque atual e ganhos desde since).
+// Permission: org_admin.
+func (s *OrganizationService) OverviewReport(ctx context.Context, orgID, actorID string, since time.Time) (*domain.OrganizationOverviewReport, error) {
+	if _, err := s.authorize(ctx, orgID, actorID, domain.CanManageOrganization); err != nil {
+		return nil, err
+	}
+	rows, err := s.orgRepo.Overview(ctx, orgID, since)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewOrganizationOverviewReport(orgID, since, rows), nil
+}
+
+func (s *OrganizationService) directoryEntry(ctx context.Context, orgID, userID string) (*domain.OrganizationMember, error) {
+	members, err := s.orgRepo.ListDirectory(ctx, orgID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, ErrOrgUserNotInDirectory
+	}
+	return &members[0], nil
+}
+
+func (s *OrganizationService) ensureAnotherAdmin(ctx context.Context, orgID string) error {
+	admins, err := s.orgRepo.CountAdmins(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastOrgAdmin
+	}
+	return nil
+}
+
+// logAction registra a ação no log estruturado: audit_log é por workspace e ações de
+// organização não pertencem a nenhum.
+func (s *OrganizationService) logAction(ctx context.Context, orgID, actorID, action, entityID string, fields ...zap.Field) {
+	s.log.Info(ctx, "organization action",
+		append([]zap.Field{
+			logger.Module("organization"),
+			logger.Action(action),
+			zap.String("organization_id", orgID),
+			zap.String("actor_id", actorID),
+			zap.String("entity_id", entityID),
+		}, fields...)...,
+	)
+}
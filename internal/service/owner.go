@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"linkko-api/internal/repo"
+)
+
+// validateOwner confere, antes de gravar, que o usuário referenciado como dono/responsável
+// (ownerId, actorId, assignedTo) é membro do workspace. nil não é validado: o campo não foi
+// informado e o registro fica com o padrão (o ator) ou com o valor atual.
+func validateOwner(ctx context.Context, userRepo *repo.UserRepository, workspaceID string, userID *string) error {
+	if userID == nil {
+		return nil
+	}
+	exists, err := userRepo.ExistsInWorkspace(ctx, workspaceID, *userID)
+	if err != nil {
+		return fmt.Errorf("validate owner: %w", err)
+	}
+	if !exists {
+		return ErrInvalidOwner
+	}
+	return nil
+}
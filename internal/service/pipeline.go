@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 
+	"linkko-api/internal/apperr"
 	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -17,14 +21,17 @@ var (
 	ErrPipelineNameConflict  = repo.ErrPipelineNameConflict
 	ErrStageNotFound         = repo.ErrStageNotFound
 	ErrStageNameConflict     = repo.ErrStageNameConflict
+	ErrStageHasDeals         = repo.ErrStageHasDeals
 	ErrDefaultPipelineExists = repo.ErrDefaultPipelineExists
-	ErrCannotDeleteDefault   = errors.New("cannot delete default pipeline")
+	ErrCannotDeleteDefault   = apperr.Unprocessable(apperr.CodeCannotDeleteDefault, "cannot delete default pipeline", "cannot delete default pipeline; set another as default first")
+	ErrSLARequiresTicket     = apperr.Unprocessable(apperr.CodeValidationError, "SLA targets set on a stage whose type is not TICKET", "SLA targets can only be set on TICKET stages")
 )
 
 type PipelineService struct {
 	pipelineRepo  *repo.PipelineRepository
 	auditRepo     *repo.AuditRepo
 	workspaceRepo *repo.WorkspaceRepository
+	counters      *CounterService // Negócios excluídos junto com o pipeline
 	log           *logger.Logger
 }
 
@@ -37,9 +44,17 @@ func NewPipelineService(pipelineRepo *repo.PipelineRepository, auditRepo *repo.A
 	}
 }
 
+// WithCounters invalida os contadores do workspace quando o DELETE do pipeline exclui negócios.
+func (s *PipelineService) WithCounters(counters *CounterService) *PipelineService {
+	s.counters = counters
+	return s
+}
+
 // getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
 func (s *PipelineService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
 	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		s.log.Error(ctx, "failed to get member role",
 			logger.Module("pipeline"),
@@ -96,8 +111,9 @@ func (s *PipelineService) ListPipelines(ctx context.Context, workspaceID, actorI
 }
 
 // GetPipeline retrieves a single pipeline with all stages.
+// includeMetrics adds open deal count and value to each stage.
 // Permission: all workspace members can view pipelines.
-func (s *PipelineService) GetPipeline(ctx context.Context, workspaceID, pipelineID, actorID string) (*domain.Pipeline, error) {
+func (s *PipelineService) GetPipeline(ctx context.Context, workspaceID, pipelineID, actorID string, includeMetrics bool) (*domain.Pipeline, error) {
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -109,7 +125,7 @@ func (s *PipelineService) GetPipeline(ctx context.Context, workspaceID, pipeline
 		return nil, ErrUnauthorized
 	}
 
-	pipeline, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipelineID)
+	pipeline, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipelineID, includeMetrics)
 	if err != nil {
 		return nil, fmt.Errorf("get pipeline: %w", err)
 	}
@@ -138,8 +154,13 @@ func (s *PipelineService) CreatePipeline(ctx context.Context, workspaceID, actor
 		req.PipelineType = &defaultType
 	}
 
+	pipelineID, err := id.New(id.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
 	pipeline := &domain.Pipeline{
-		ID:           generateID(),
+		ID:           pipelineID,
 		WorkspaceID:  workspaceID,
 		Name:         req.Name,
 		PipelineType: *req.PipelineType,
@@ -238,8 +259,13 @@ func (s *PipelineService) CreatePipelineWithStages(ctx context.Context, workspac
 	defer tx.Rollback(ctx)
 
 	// Create pipeline
+	pipelineID, err := id.New(id.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
 	pipeline := &domain.Pipeline{
-		ID:           generateID(),
+		ID:           pipelineID,
 		WorkspaceID:  workspaceID,
 		Name:         req.Pipeline.Name,
 		PipelineType: *req.Pipeline.PipelineType,
@@ -271,8 +297,13 @@ func (s *PipelineService) CreatePipelineWithStages(ctx context.Context, workspac
 			stageReq.StageGroup = &defaultGroup
 		}
 
+		pipelineStageID, err := id.New(id.PipelineStage)
+		if err != nil {
+			return nil, err
+		}
+
 		stage := &domain.PipelineStage{
-			ID:          generateID(),
+			ID:          pipelineStageID,
 			PipelineID:  &pipeline.ID,
 			WorkspaceID: workspaceID,
 			Name:        stageReq.Name,
@@ -283,12 +314,11 @@ func (s *PipelineService) CreatePipelineWithStages(ctx context.Context, workspac
 		if stageReq.Description != nil {
 			stage.Description = stageReq.Description
 		}
-		if stageReq.Probability != nil {
-			stage.Probability = *stageReq.Probability
-		}
+		stage.Probability = stageReq.Probability
 		if stageReq.AutoArchiveDays != nil {
 			stage.AutoArchiveDays = stageReq.AutoArchiveDays
 		}
+		stage.RottingDays = stageReq.RottingDays
 
 		err = s.pipelineRepo.CreateStage(ctx, stage)
 		if err != nil {
@@ -310,7 +340,7 @@ func (s *PipelineService) CreatePipelineWithStages(ctx context.Context, workspac
 	}
 
 	// Load stages for response
-	result, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipeline.ID)
+	result, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipeline.ID, false)
 	if err != nil {
 		return nil, fmt.Errorf("get created pipeline: %w", err)
 	}
@@ -390,7 +420,7 @@ func (s *PipelineService) UpdatePipeline(ctx context.Context, workspaceID, pipel
 	}
 
 	// Fetch updated pipeline
-	pipeline, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipelineID)
+	pipeline, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipelineID, false)
 	if err != nil {
 		return nil, fmt.Errorf("get updated pipeline: %w", err)
 	}
@@ -416,6 +446,8 @@ func (s *PipelineService) UpdatePipeline(ctx context.Context, workspaceID, pipel
 }
 
 // DeletePipeline soft deletes a pipeline with RBAC validation.
+// Stages and active deals of the pipeline are deleted with it and come back when it is
+// restored from the trash.
 // Permission: only admin and manager can delete pipelines.
 // Cannot delete default pipeline (must set another as default first).
 func (s *PipelineService) DeletePipeline(ctx context.Context, workspaceID, pipelineID, actorID string) error {
@@ -440,10 +472,11 @@ func (s *PipelineService) DeletePipeline(ctx context.Context, workspaceID, pipel
 		return ErrCannotDeleteDefault
 	}
 
-	err = s.pipelineRepo.SoftDelete(ctx, workspaceID, pipelineID)
+	err = s.pipelineRepo.SoftDelete(ctx, workspaceID, pipelineID, actorID)
 	if err != nil {
 		return fmt.Errorf("delete pipeline: %w", err)
 	}
+	s.counters.Invalidate(ctx, workspaceID)
 
 	// Audit: log pipeline deletion
 	pipelineIDStr := pipelineID
@@ -468,8 +501,9 @@ func (s *PipelineService) DeletePipeline(ctx context.Context, workspaceID, pipel
 // ===== PIPELINE STAGE METHODS =====
 
 // ListStages retrieves all stages for a pipeline.
+// includeMetrics adds open deal count and value to each stage.
 // Permission: all workspace members can list stages.
-func (s *PipelineService) ListStages(ctx context.Context, workspaceID, pipelineID, actorID string) ([]domain.PipelineStage, error) {
+func (s *PipelineService) ListStages(ctx context.Context, workspaceID, pipelineID, actorID string, includeMetrics bool) ([]domain.PipelineStage, error) {
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -487,7 +521,7 @@ func (s *PipelineService) ListStages(ctx context.Context, workspaceID, pipelineI
 		return nil, fmt.Errorf("get pipeline: %w", err)
 	}
 
-	stages, err := s.pipelineRepo.ListStagesByPipeline(ctx, workspaceID, &pipelineID)
+	stages, err := s.pipelineRepo.ListStagesByPipeline(ctx, workspaceID, &pipelineID, includeMetrics)
 	if err != nil {
 		return nil, fmt.Errorf("list stages: %w", err)
 	}
@@ -528,8 +562,13 @@ func (s *PipelineService) CreateStage(ctx context.Context, workspaceID, pipeline
 		req.StageGroup = &defaultGroup
 	}
 
+	pipelineStageID, err := id.New(id.PipelineStage)
+	if err != nil {
+		return nil, err
+	}
+
 	stage := &domain.PipelineStage{
-		ID:          generateID(),
+		ID:          pipelineStageID,
 		PipelineID:  &pipelineID,
 		WorkspaceID: workspaceID,
 		Name:        req.Name,
@@ -540,12 +579,11 @@ func (s *PipelineService) CreateStage(ctx context.Context, workspaceID, pipeline
 	if req.Description != nil {
 		stage.Description = req.Description
 	}
-	if req.Probability != nil {
-		stage.Probability = *req.Probability
-	}
+	stage.Probability = req.Probability
 	if req.AutoArchiveDays != nil {
 		stage.AutoArchiveDays = req.AutoArchiveDays
 	}
+	stage.RottingDays = req.RottingDays
 
 	err = s.pipelineRepo.CreateStage(ctx, stage)
 	if err != nil {
@@ -597,11 +635,27 @@ func (s *PipelineService) UpdateStage(ctx context.Context, workspaceID, stageID,
 		return nil, fmt.Errorf("get pipeline: %w", err)
 	}
 
+	// Metas de SLA só fazem sentido em estágios de suporte
+	stageType := stage.Type
+	if req.Type != nil {
+		stageType = *req.Type
+	}
+	if stageType != domain.PipelineTypeTicket && (isPositive(req.FirstResponseSLAMinutes) || isPositive(req.ResolutionSLAMinutes)) {
+		return nil, ErrSLARequiresTicket
+	}
+
 	err = s.pipelineRepo.UpdateStage(ctx, stageID, req)
 	if err != nil {
 		return nil, fmt.Errorf("update stage: %w", err)
 	}
 
+	// Nova probabilidade: deals da etapa sem categoria manual acompanham
+	if req.Probability != nil {
+		if _, err := s.pipelineRepo.RecalculateStageForecast(ctx, stageID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Fetch updated stage
 	updatedStage, err := s.pipelineRepo.GetStage(ctx, stageID)
 	if err != nil {
@@ -629,6 +683,7 @@ func (s *PipelineService) UpdateStage(ctx context.Context, workspaceID, stageID,
 }
 
 // DeleteStage soft deletes a stage with RBAC validation.
+// Fails with ErrStageHasDeals while the stage has active deals (move them first).
 // Permission: only admin and manager can delete stages.
 func (s *PipelineService) DeleteStage(ctx context.Context, workspaceID, stageID, actorID string) error {
 	// Fetch user's role in this workspace from database
@@ -733,8 +788,13 @@ func (s *PipelineService) CreateDefaultPipeline(ctx context.Context, workspaceID
 	defer tx.Rollback(ctx)
 
 	// Create pipeline
+	pipelineID, err := id.New(id.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
 	pipeline := &domain.Pipeline{
-		ID:           generateID(),
+		ID:           pipelineID,
 		WorkspaceID:  workspaceID,
 		Name:         req.Pipeline.Name,
 		Description:  req.Pipeline.Description,
@@ -751,8 +811,13 @@ func (s *PipelineService) CreateDefaultPipeline(ctx context.Context, workspaceID
 
 	// Create stages
 	for i, stageReq := range req.Stages {
+		pipelineStageID, err := id.New(id.PipelineStage)
+		if err != nil {
+			return nil, err
+		}
+
 		stage := &domain.PipelineStage{
-			ID:              generateID(),
+			ID:              pipelineStageID,
 			PipelineID:      &pipeline.ID,
 			WorkspaceID:     workspaceID,
 			Name:            stageReq.Name,
@@ -761,8 +826,9 @@ func (s *PipelineService) CreateDefaultPipeline(ctx context.Context, workspaceID
 			OrderIndex:      i + 1,
 			Color:           stageReq.Color,
 			IsLocked:        false,
-			Probability:     *stageReq.Probability,
+			Probability:     stageReq.Probability,
 			AutoArchiveDays: stageReq.AutoArchiveDays,
+			RottingDays:     stageReq.RottingDays,
 		}
 
 		err = s.pipelineRepo.CreateStage(ctx, stage)
@@ -782,7 +848,7 @@ func (s *PipelineService) CreateDefaultPipeline(ctx context.Context, workspaceID
 	}
 
 	// Load full pipeline with stages
-	result, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipeline.ID)
+	result, err := s.pipelineRepo.GetWithStages(ctx, workspaceID, pipeline.ID, false)
 	if err != nil {
 		return nil, fmt.Errorf("get created pipeline: %w", err)
 	}
@@ -849,3 +915,8 @@ func pipelineTypePtr(t domain.PipelineType) *domain.PipelineType {
 func stageGroupPtr(g domain.StageGroup) *domain.StageGroup {
 	return &g
 }
+
+// isPositive indica se o valor opcional foi informado e é maior que zero.
+func isPositive(v *int) bool {
+	return v != nil && *v > 0
+}
@@ -2,15 +2,15 @@ package service
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/base32"
 	"fmt"
-	"strings"
 
 	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -32,7 +32,9 @@ func NewPortfolioService(portfolioRepo *repo.PortfolioRepository, workspaceRepo
 
 // getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
 func (s *PortfolioService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
 	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		s.log.Error(ctx, "failed to get member role",
 			logger.Module("portfolio"),
@@ -69,8 +71,13 @@ func (s *PortfolioService) CreatePortfolioItem(ctx context.Context, workspaceID,
 		return nil, err
 	}
 
+	portfolioItemID, err := id.New(id.PortfolioItem)
+	if err != nil {
+		return nil, err
+	}
+
 	item := &domain.PortfolioItem{
-		ID:          generatePortfolioID(),
+		ID:          portfolioItemID,
 		WorkspaceID: workspaceID,
 		Name:        req.Name,
 		Description: req.Description,
@@ -173,13 +180,6 @@ func (s *PortfolioService) DeletePortfolioItem(ctx context.Context, workspaceID,
 	return nil
 }
 
-// Helpers
-func generatePortfolioID() string {
-	b := make([]byte, 16)
-	rand.Read(b)
-	return "pit_" + strings.ToLower(base32.StdEncoding.EncodeToString(b)[:24])
-}
-
 func (s *PortfolioService) logPortfolioAction(ctx context.Context, workspaceID, actorID, action, itemID string) {
 	idStr := itemID
 	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "portfolio_item", &idStr, nil, "", "")
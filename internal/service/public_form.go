@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/integrations/captcha"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/ratelimit"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// Form tokens são assinados com um segredo próprio e nunca são aceitos como JWT de workspace:
+// iss/aud diferentes e o AuthMiddleware não é montado na rota pública.
+const (
+	publicFormTokenIssuer   = "linkko-public-forms"
+	publicFormTokenAudience = "linkko-public-forms"
+	publicFormRateWindow    = 60
+)
+
+var (
+	// ErrInvalidFormToken token ausente, adulterado, expirado, de outro formulário ou cujo emissor perdeu acesso
+	ErrInvalidFormToken = apperr.Define("INVALID_FORM_TOKEN", http.StatusUnauthorized, "form token is invalid or revoked", "invalid form token")
+
+	// ErrFormRateLimited o formulário excedeu rateLimitPerMinute
+	ErrFormRateLimited = apperr.Define("RATE_LIMIT_EXCEEDED", http.StatusTooManyRequests, "form submission rate limit exceeded", "rate limit exceeded")
+
+	// ErrCaptchaFailed o desafio não foi resolvido (token ausente ou rejeitado pelo provedor)
+	ErrCaptchaFailed = apperr.Define("CAPTCHA_FAILED", http.StatusBadRequest, "captcha verification failed", "captcha verification failed")
+
+	// ErrFormTokensDisabled PUBLIC_FORM_TOKEN_SECRET não configurado (Forms cadastrados seguem funcionando)
+	ErrFormTokensDisabled = apperr.NotFound("public form tokens are disabled", "public form tokens are not enabled")
+
+	// ErrCaptchaUnavailable captcha obrigatório sem provedor configurado ou provedor fora do ar (fail-closed)
+	ErrCaptchaUnavailable = apperr.Define(apperr.CodeServiceUnavailable, http.StatusServiceUnavailable, "captcha provider is not available", "captcha verification is temporarily unavailable, retry later")
+)
+
+// FormValidationError os campos enviados não formam um contato/negócio válido (422 com detalhes por campo)
+type FormValidationError struct {
+	Err error
+}
+
+func (e *FormValidationError) Error() string { return e.Err.Error() }
+
+func (e *FormValidationError) Unwrap() error { return e.Err }
+
+// FormRateLimiter is implemented by ratelimit.RedisRateLimiter
+type FormRateLimiter interface {
+	AllowRequest(ctx context.Context, key string, limit int, windowSeconds int) (ratelimit.Decision, error)
+}
+
+// publicFormClaims conteúdo do form token: o workspace, o membro em nome de quem os registros são
+// criados e a configuração do formulário. Não há estado no banco: trocar a configuração é emitir outro token.
+type publicFormClaims struct {
+	WorkspaceID string                  `json:"workspaceId"`
+	ActorID     string                  `json:"actorId"`
+	Form        domain.PublicFormConfig `json:"form"`
+	jwt.RegisteredClaims
+}
+
+// PublicFormService emite form tokens e recebe submissões anônimas de formulários embutidos em sites
+// (configurados pelo token ou cadastrados como Form).
+type PublicFormService struct {
+	contactService *ContactService
+	dealService    *DealService
+	formRepo       *repo.FormRepository
+	workspaceRepo  *repo.WorkspaceRepository
+	auditRepo      *repo.AuditRepo
+	limiter        FormRateLimiter
+	captcha        captcha.Provider
+	secret         []byte
+	shards         *ShardService
+	log            *logger.Logger
+}
+
+// NewPublicFormService limiter pode ser nil (sem rate limit por formulário); captchaProvider nil
+// recusa formulários com captchaRequired; secret vazio desliga os form tokens (só Forms cadastrados).
+func NewPublicFormService(contactService *ContactService, dealService *DealService, formRepo *repo.FormRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, limiter FormRateLimiter, captchaProvider captcha.Provider, secret []byte, log *logger.Logger) *PublicFormService {
+	return &PublicFormService{
+		contactService: contactService,
+		dealService:    dealService,
+		formRepo:       formRepo,
+		workspaceRepo:  workspaceRepo,
+		auditRepo:      auditRepo,
+		limiter:        limiter,
+		captcha:        captchaProvider,
+		secret:         secret,
+		log:            log,
+	}
+}
+
+// WithShards resolve o shard de dados dos workspaces dos form tokens (DATABASE_SHARDS).
+func (s *PublicFormService) WithShards(shards *ShardService) *PublicFormService {
+	s.shards = shards
+	return s
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *PublicFormService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("public_form"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// IssueToken assina a configuração do formulário. Os contatos/negócios criados pelas submissões
+// ficam em nome do emissor; removê-lo do workspace (ou rebaixá-lo a viewer) revoga o token.
+// Permission: admin, manager.
+func (s *PublicFormService) IssueToken(ctx context.Context, workspaceID, actorID string, req *domain.CreatePublicFormTokenRequest) (*domain.PublicFormToken, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrFormTokensDisabled
+	}
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanDeleteContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	config := req.Config()
+	now := time.Now()
+	claims := &publicFormClaims{
+		WorkspaceID: workspaceID,
+		ActorID:     actorID,
+		Form:        config,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   publicFormTokenIssuer,
+			Audience: jwt.ClaimStrings{publicFormTokenAudience},
+			Subject:  config.FormID,
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+	}
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		exp := now.Add(time.Duration(*req.ExpiresInDays) * 24 * time.Hour).UTC()
+		claims.ExpiresAt = jwt.NewNumericDate(exp)
+		expiresAt = &exp
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return nil, fmt.Errorf("sign form token: %w", err)
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "public_form_token_create", config.FormID, map[string]interface{}{
+		"fieldMapping":       config.FieldMapping,
+		"pipelineId":         config.PipelineID,
+		"rateLimitPerMinute": config.RateLimitPerMinute,
+		"captchaRequired":    config.CaptchaRequired,
+	})
+
+	return &domain.PublicFormToken{
+		Token:         token,
+		FormID:        config.FormID,
+		WorkspaceID:   workspaceID,
+		SubmissionURL: publicFormSubmissionURL(config.FormID),
+		Config:        &config,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// Submit recebe uma submissão anônima. Com form token, a configuração vem do token; sem token,
+// formId é um Form cadastrado e ativo. Aplica o rate limit do formulário, as proteções
+// anti-spam e o captcha, e cria o contato (e o negócio, se o formulário tiver pipeline).
+// Permission: form token válido ou Form ativo; a criação usa o papel atual do emissor/dono.
+func (s *PublicFormService) Submit(ctx context.Context, formID string, sub *domain.PublicFormSubmission) (*domain.PublicFormSubmissionResult, error) {
+	if sub.Token != "" {
+		return s.submitWithToken(ctx, formID, sub)
+	}
+	return s.submitForm(ctx, formID, sub)
+}
+
+// submitWithToken formulário sem cadastro, configurado pelo form token
+func (s *PublicFormService) submitWithToken(ctx context.Context, formID string, sub *domain.PublicFormSubmission) (*domain.PublicFormSubmissionResult, error) {
+	claims, err := s.parseToken(sub.Token)
+	if err != nil || claims.Form.FormID != formID {
+		s.log.Warn(ctx, "rejected form token",
+			logger.Module("public_form"),
+			logger.Action("submit"),
+			zap.String("form_id", formID),
+			zap.NamedError("reason", err),
+		)
+		return nil, ErrInvalidFormToken
+	}
+
+	// O workspace só é conhecido pelo token: sem consulta que encontre o shard (ShardProbeMiddleware)
+	ctx, err = s.shards.WithWorkspaceShard(ctx, claims.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := submissionTarget{workspaceID: claims.WorkspaceID, actorID: claims.ActorID, config: claims.Form, revoked: ErrInvalidFormToken}
+	if err := s.checkLimits(ctx, &target, sub); err != nil {
+		return nil, err
+	}
+	if err := s.create(ctx, &target, sub.Fields); err != nil {
+		return nil, err
+	}
+	return &domain.PublicFormSubmissionResult{Status: "received"}, nil
+}
+
+// submitForm formulário cadastrado (Form): campos obrigatórios, honeypot e tempo mínimo
+func (s *PublicFormService) submitForm(ctx context.Context, formID string, sub *domain.PublicFormSubmission) (*domain.PublicFormSubmissionResult, error) {
+	form, err := s.formRepo.GetEnabled(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.PublicFormSubmissionResult{
+		Status:      "received",
+		Message:     form.SuccessMessage,
+		RedirectURL: form.SuccessRedirectURL,
+	}
+
+	target := submissionTarget{workspaceID: form.WorkspaceID, actorID: form.OwnerID, config: form.PublicConfig(), revoked: ErrFormNotFound}
+	if form.IsSpam(sub, time.Now()) {
+		// O bot recebe a mesma resposta de sucesso para não aprender a contornar a proteção
+		s.log.Info(ctx, "discarded spam form submission",
+			logger.Module("public_form"),
+			logger.Action("submit"),
+			zap.String("workspace_id", form.WorkspaceID),
+			zap.String("form_id", form.ID),
+		)
+		return result, nil
+	}
+	if err := s.checkLimits(ctx, &target, sub); err != nil {
+		return nil, err
+	}
+	if err := form.CheckSubmission(sub.Fields); err != nil {
+		return nil, &FormValidationError{Err: err}
+	}
+	if err := s.create(ctx, &target, sub.Fields); err != nil {
+		return nil, err
+	}
+
+	if err := s.formRepo.RecordSubmission(ctx, form.ID); err != nil {
+		s.log.Warn(ctx, "failed to record form submission",
+			logger.Module("public_form"),
+			zap.String("form_id", form.ID),
+			zap.Error(err),
+		)
+	}
+	return result, nil
+}
+
+// submissionTarget workspace, ator e configuração resolvidos do token ou do Form.
+// revoked é devolvido quando o ator perdeu acesso ao workspace.
+type submissionTarget struct {
+	workspaceID string
+	actorID     string
+	config      domain.PublicFormConfig
+	revoked     error
+}
+
+// checkLimits aplica o rate limit do formulário e o captcha
+func (s *PublicFormService) checkLimits(ctx context.Context, target *submissionTarget, sub *domain.PublicFormSubmission) error {
+	form := target.config
+	if s.limiter != nil && form.RateLimitPerMinute > 0 {
+		key := "form:" + target.workspaceID + ":" + form.FormID
+		decision, err := s.limiter.AllowRequest(ctx, key, form.RateLimitPerMinute, publicFormRateWindow)
+		if err != nil {
+			// Fail-open como o RateLimitMiddleware: indisponibilidade do Redis não derruba os formulários
+			s.log.Warn(ctx, "form rate limit check failed, allowing submission",
+				logger.Module("public_form"),
+				zap.String("form_id", form.FormID),
+				zap.Error(err),
+			)
+		} else if !decision.Allowed {
+			return ErrFormRateLimited
+		}
+	}
+
+	if form.CaptchaRequired {
+		return s.verifyCaptcha(ctx, sub.CaptchaToken, sub.RemoteIP)
+	}
+	return nil
+}
+
+// create mapeia os campos e cria o contato e, com pipeline, o negócio ligado a ele
+func (s *PublicFormService) create(ctx context.Context, target *submissionTarget, fields map[string]string) error {
+	contactReq, dealReq, err := target.config.MapSubmission(fields)
+	if err != nil {
+		return &FormValidationError{Err: err}
+	}
+	if err := contactReq.Validate(); err != nil {
+		return &FormValidationError{Err: err}
+	}
+
+	contact, err := s.contactService.CreateContact(ctx, target.workspaceID, target.actorID, contactReq)
+	if err != nil {
+		return s.mapActorError(ctx, target, err)
+	}
+
+	if dealReq != nil {
+		dealReq.ContactID = &contact.ID
+		if _, err := s.dealService.CreateDeal(ctx, target.workspaceID, target.actorID, dealReq); err != nil {
+			return s.mapActorError(ctx, target, err)
+		}
+	}
+
+	s.log.Info(ctx, "public form submission received",
+		logger.Module("public_form"),
+		logger.Action("submit"),
+		zap.String("workspace_id", target.workspaceID),
+		zap.String("form_id", target.config.FormID),
+		zap.String("contact_id", contact.ID),
+		zap.Bool("deal_created", dealReq != nil),
+	)
+	return nil
+}
+
+func (s *PublicFormService) parseToken(token string) (*publicFormClaims, error) {
+	if len(s.secret) == 0 {
+		return nil, errors.New("form tokens are disabled")
+	}
+	claims := &publicFormClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(publicFormTokenIssuer),
+		jwt.WithAudience(publicFormTokenAudience),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.WorkspaceID == "" || claims.ActorID == "" || claims.Form.FormID == "" {
+		return nil, errors.New("form token is missing required claims")
+	}
+	return claims, nil
+}
+
+// verifyCaptcha é fail-closed: sem provedor ou com o provedor fora, a submissão é recusada
+func (s *PublicFormService) verifyCaptcha(ctx context.Context, token, remoteIP string) error {
+	if s.captcha == nil {
+		return ErrCaptchaUnavailable
+	}
+	if token == "" {
+		return ErrCaptchaFailed
+	}
+	ok, err := s.captcha.Verify(ctx, token, remoteIP)
+	if err != nil {
+		s.log.Warn(ctx, "captcha verification failed",
+			logger.Module("public_form"),
+			zap.String("provider", s.captcha.Name()),
+			zap.Error(err),
+		)
+		return ErrCaptchaUnavailable
+	}
+	if !ok {
+		return ErrCaptchaFailed
+	}
+	return nil
+}
+
+// mapActorError o emissor do token (ou dono do Form) saiu do workspace ou perdeu permissão de
+// escrita: o formulário deixa de aceitar submissões
+func (s *PublicFormService) mapActorError(ctx context.Context, target *submissionTarget, err error) error {
+	if errors.Is(err, ErrMemberNotFound) || errors.Is(err, ErrUnauthorized) {
+		s.log.Warn(ctx, "form actor lost access",
+			logger.Module("public_form"),
+			zap.String("workspace_id", target.workspaceID),
+			zap.String("form_id", target.config.FormID),
+			zap.String("actor_id", target.actorID),
+		)
+		return target.revoked
+	}
+	return err
+}
+
+func (s *PublicFormService) logAction(ctx context.Context, workspaceID, actorID, action, formID string, metadata map[string]interface{}) {
+	if err := s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "form", &formID, metadata, "", ""); err != nil {
+		s.log.Warn(ctx, "failed to write audit log",
+			logger.Module("public_form"),
+			logger.Action(action),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/objectstore"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/reportfile"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// QuotePDFStaleAfter tempo após o qual um PDF RUNNING é considerado abandonado
+// (worker caiu no meio) e volta a ser gerado.
+const QuotePDFStaleAfter = 10 * time.Minute
+
+// quoteNumberAttempts tentativas de reservar o próximo número antes de desistir
+const quoteNumberAttempts = 3
+
+var (
+	ErrQuoteNotFound      = repo.ErrQuoteNotFound
+	ErrQuoteNotAcceptable = repo.ErrQuoteNotAcceptable
+
+	ErrInvalidQuoteTemplate = apperr.Unprocessable(apperr.CodeValidationError, "template_id does not belong to workspace", "document template does not belong to workspace")
+	ErrInvalidQuoteDeal     = apperr.Unprocessable(apperr.CodeValidationError, "deal_id does not belong to workspace", "deal does not belong to workspace")
+	ErrQuotePDFNotReady     = apperr.Unprocessable(apperr.CodeInvalidStatus, "quote PDF is not ready yet", "")
+	ErrQuotePDFMissing      = apperr.NotFound("quote PDF missing from object storage", "quote PDF not found")
+	ErrQuoteAlreadyAccepted = apperr.Unprocessable(apperr.CodeInvalidStatus, "accepted quotes cannot be sent again", "")
+)
+
+// QuoteService gera orçamentos a partir de templates de documentos e negócios, publica o link
+// de compartilhamento (/q/{token}) e registra abertura e aceite. O PDF é gerado pelo quote-worker
+// via ProcessNext e gravado no object storage dos anexos.
+type QuoteService struct {
+	quoteRepo     *repo.QuoteRepository
+	templateRepo  *repo.DocumentTemplateRepository
+	contactRepo   *repo.ContactRepository
+	companyRepo   *repo.CompanyRepository
+	dealRepo      *repo.DealRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	store         objectstore.Store
+	baseURL       string
+	log           *logger.Logger
+}
+
+// NewQuoteService cria o service. baseURL (QUOTE_SHARE_BASE_URL) prefixa os links de
+// compartilhamento; vazio gera URLs relativas (/q/{token}).
+func NewQuoteService(quoteRepo *repo.QuoteRepository, templateRepo *repo.DocumentTemplateRepository, contactRepo *repo.ContactRepository, companyRepo *repo.CompanyRepository, dealRepo *repo.DealRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, store objectstore.Store, baseURL string, log *logger.Logger) *QuoteService {
+	return &QuoteService{
+		quoteRepo:     quoteRepo,
+		templateRepo:  templateRepo,
+		contactRepo:   contactRepo,
+		companyRepo:   companyRepo,
+		dealRepo:      dealRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		store:         store,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *QuoteService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("quote"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *QuoteService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateQuote renderiza o template com os dados do negócio (contato, empresa, itens e totais)
+// e grava o orçamento com o PDF pendente.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *QuoteService) CreateQuote(ctx context.Context, workspaceID, actorID string, req *domain.CreateQuoteRequest) (*domain.Quote, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	template, err := s.templateRepo.Get(ctx, workspaceID, req.TemplateID)
+	if err != nil {
+		if errors.Is(err, repo.ErrDocumentTemplateNotFound) {
+			return nil, ErrInvalidQuoteTemplate
+		}
+		return nil, err
+	}
+	deal, err := s.dealRepo.Get(ctx, workspaceID, req.DealID)
+	if err != nil {
+		if errors.Is(err, repo.ErrDealNotFound) {
+			return nil, ErrInvalidQuoteDeal
+		}
+		return nil, fmt.Errorf("get deal: %w", err)
+	}
+	contact, company, err := s.customer(ctx, workspaceID, deal)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteID, err := id.New(id.Quote)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &domain.Quote{
+		ID:           quoteID,
+		WorkspaceID:  workspaceID,
+		DealID:       deal.ID,
+		ContactID:    deal.ContactID,
+		CompanyID:    deal.CompanyID,
+		TemplateID:   &template.ID,
+		Currency:     deal.Currency,
+		LineItems:    req.LineItems,
+		ValidUntil:   req.ValidUntil,
+		PDFObjectKey: domain.QuotePDFObjectKey(workspaceID, quoteID),
+		CreatedByID:  actorID,
+		CreatedAt:    time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if len(quote.LineItems) == 0 {
+		quote.LineItems = []domain.QuoteLineItem{}
+		if deal.Value != nil {
+			quote.LineItems = append(quote.LineItems, domain.QuoteLineItem{Description: deal.Name, Quantity: 1, UnitPrice: *deal.Value})
+		}
+	}
+	quote.ComputeTotals()
+
+	var created *domain.Quote
+	for attempt := 0; attempt < quoteNumberAttempts; attempt++ {
+		quote.Number, err = s.quoteRepo.NextNumber(ctx, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var body string
+		quote.Title, body, _ = template.Render(quote.MergeValues(contact, deal, company))
+		if !strings.Contains(template.Body, domain.QuoteLineItemsField) {
+			body = strings.TrimRight(body, "\n") + "\n\n" + strings.Join(quote.LineItemLines(), "\n")
+		}
+		quote.Body = body
+
+		created, err = s.quoteRepo.Create(ctx, quote)
+		if !errors.Is(err, repo.ErrQuoteNumberTaken) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", created.ID, map[string]interface{}{
+		"dealId":     created.DealID,
+		"templateId": template.ID,
+		"number":     created.Number,
+	})
+	return created, nil
+}
+
+// customer contato e empresa do negócio (opcionais; registros removidos são ignorados).
+func (s *QuoteService) customer(ctx context.Context, workspaceID string, deal *domain.Deal) (*domain.Contact, *domain.Company, error) {
+	var contact *domain.Contact
+	if deal.ContactID != nil {
+		c, err := s.contactRepo.Get(ctx, workspaceID, *deal.ContactID)
+		if err != nil && !errors.Is(err, repo.ErrContactNotFound) {
+			return nil, nil, fmt.Errorf("get quote contact: %w", err)
+		}
+		contact = c
+	}
+
+	companyID := deal.CompanyID
+	if companyID == nil && contact != nil {
+		companyID = contact.CompanyID
+	}
+	var company *domain.Company
+	if companyID != nil {
+		c, err := s.companyRepo.Get(ctx, workspaceID, *companyID)
+		if err != nil && !errors.Is(err, repo.ErrCompanyNotFound) {
+			return nil, nil, fmt.Errorf("get quote company: %w", err)
+		}
+		company = c
+	}
+	return contact, company, nil
+}
+
+// GetQuote retorna o orçamento.
+// Permission: all workspace members.
+func (s *QuoteService) GetQuote(ctx context.Context, workspaceID, quoteID, actorID string) (*domain.Quote, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.quoteRepo.Get(ctx, workspaceID, quoteID)
+}
+
+// ListQuotes lista os orçamentos, mais recentes primeiro; dealID filtra (nil = todos).
+// Permission: all workspace members.
+func (s *QuoteService) ListQuotes(ctx context.Context, workspaceID, actorID string, dealID *string) ([]domain.Quote, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	return s.quoteRepo.List(ctx, workspaceID, dealID)
+}
+
+// DeleteQuote remove o orçamento; o link de compartilhamento deixa de funcionar.
+// Permission: admin, manager.
+func (s *QuoteService) DeleteQuote(ctx context.Context, workspaceID, quoteID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return err
+	}
+	if err := s.quoteRepo.Delete(ctx, workspaceID, quoteID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", quoteID, nil)
+	return nil
+}
+
+// OpenPDF abre o PDF do orçamento; o chamador fecha o reader.
+// Permission: all workspace members.
+func (s *QuoteService) OpenPDF(ctx context.Context, workspaceID, quoteID, actorID string) (*domain.Quote, io.ReadCloser, error) {
+	quote, err := s.GetQuote(ctx, workspaceID, quoteID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.openPDF(ctx, quote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quote, body, nil
+}
+
+// SendQuote gera um novo link de compartilhamento (o anterior deixa de valer) e marca o
+// orçamento como SENT. O link só é retornado aqui: o token não é guardado em claro.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *QuoteService) SendQuote(ctx context.Context, workspaceID, quoteID, actorID string) (*domain.Quote, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	quote, err := s.quoteRepo.Get(ctx, workspaceID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status == domain.QuoteStatusAccepted {
+		return nil, ErrQuoteAlreadyAccepted
+	}
+
+	token := generateQuoteShareToken()
+	sent, err := s.quoteRepo.MarkSent(ctx, workspaceID, quoteID, hashQuoteShareToken(token))
+	if err != nil {
+		return nil, err
+	}
+	shareURL := s.baseURL + "/q/" + token
+	sent.ShareURL = &shareURL
+
+	s.logAction(ctx, workspaceID, actorID, "send", quoteID, nil)
+	return sent, nil
+}
+
+// ViewSharedQuote retorna o orçamento do link público e registra a primeira abertura (SENT -> VIEWED).
+func (s *QuoteService) ViewSharedQuote(ctx context.Context, token string) (*domain.PublicQuote, error) {
+	quote, err := s.resolveShared(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.Status == domain.QuoteStatusSent {
+		viewed, err := s.quoteRepo.MarkViewed(ctx, quote.ID)
+		if err != nil {
+			// A visualização não deve falhar por causa do registro de abertura.
+			s.log.Warn(ctx, "failed to mark quote viewed",
+				logger.Module("quote"),
+				logger.Action("view"),
+				zap.String("quote_id", quote.ID),
+				zap.Error(err),
+			)
+		} else if viewed != nil {
+			quote = viewed
+		}
+	}
+	return quote.Public(), nil
+}
+
+// OpenSharedPDF abre o PDF do link público; o chamador fecha o reader.
+func (s *QuoteService) OpenSharedPDF(ctx context.Context, token string) (*domain.Quote, io.ReadCloser, error) {
+	quote, err := s.resolveShared(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.openPDF(ctx, quote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quote, body, nil
+}
+
+// AcceptSharedQuote registra o aceite pelo link público. Orçamentos vencidos ou já aceitos
+// retornam ErrQuoteNotAcceptable.
+func (s *QuoteService) AcceptSharedQuote(ctx context.Context, token string, req *domain.AcceptQuoteRequest) (*domain.PublicQuote, error) {
+	quote, err := s.resolveShared(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := s.quoteRepo.Accept(ctx, quote.ID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sem ator autenticado: o aceite fica no audit em nome de quem gerou o orçamento.
+	s.logAction(ctx, accepted.WorkspaceID, accepted.CreatedByID, "accept", accepted.ID, map[string]interface{}{
+		"acceptedByName": req.Name,
+	})
+	return accepted.Public(), nil
+}
+
+func (s *QuoteService) resolveShared(ctx context.Context, token string) (*domain.Quote, error) {
+	if token == "" {
+		return nil, ErrQuoteNotFound
+	}
+	return s.quoteRepo.GetByShareTokenHash(ctx, hashQuoteShareToken(token))
+}
+
+func (s *QuoteService) openPDF(ctx context.Context, quote *domain.Quote) (io.ReadCloser, error) {
+	if quote.PDFStatus != domain.QuotePDFCompleted {
+		return nil, ErrQuotePDFNotReady
+	}
+	body, err := s.store.Get(ctx, quote.PDFObjectKey)
+	if err != nil {
+		if errors.Is(err, objectstore.ErrNotFound) {
+			return nil, ErrQuotePDFMissing
+		}
+		return nil, fmt.Errorf("open quote pdf: %w", err)
+	}
+	return body, nil
+}
+
+// ProcessNext gera o próximo PDF pendente. Retorna false quando não havia orçamento na fila.
+// Falhas da geração ficam registradas no orçamento (pdfStatus FAILED); o erro retornado é só
+// de infraestrutura.
+func (s *QuoteService) ProcessNext(ctx context.Context) (bool, error) {
+	quote, err := s.quoteRepo.ClaimNextPDF(ctx, time.Now().UTC().Add(-QuotePDFStaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if quote == nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	pdf := reportfile.RenderDocument(&reportfile.Document{
+		Title: quote.Title,
+		Lines: strings.Split(strings.ReplaceAll(quote.Body, "\r\n", "\n"), "\n"),
+	})
+	size, err := s.store.Put(ctx, quote.PDFObjectKey, bytes.NewReader(pdf))
+
+	fields := []zap.Field{
+		logger.Module("quote"),
+		logger.Action("render_pdf"),
+		zap.String("quote_id", quote.ID),
+		zap.String("workspace_id", quote.WorkspaceID),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		s.log.Error(ctx, "quote pdf rendering failed", append(fields, zap.Error(err))...)
+		return true, s.quoteRepo.FailPDF(ctx, quote.ID, fmt.Sprintf("store quote pdf: %v", err))
+	}
+
+	s.log.Info(ctx, "quote pdf rendered", append(fields, zap.Int64("size_bytes", size))...)
+	return true, s.quoteRepo.CompletePDF(ctx, quote.ID, size)
+}
+
+// generateQuoteShareToken token do link público (256 bits, base64url)
+func generateQuoteShareToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hashQuoteShareToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func (s *QuoteService) logAction(ctx context.Context, workspaceID, actorID, action, quoteID string, metadata map[string]interface{}) {
+	idStr := quoteID
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "quote", &idStr, metadata, "", "")
+}
@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/objectstore"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestQuoteService_Integration
+func TestQuoteService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	store, err := objectstore.Open("file://" + t.TempDir())
+	require.NoError(t, err)
+	templates := repo.NewDocumentTemplateRepository(pool)
+	templateSvc := service.NewDocumentTemplateService(templates, repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool), log)
+	svc := service.NewQuoteService(repo.NewQuoteRepository(pool), templates, repo.NewContactRepository(pool),
+		repo.NewCompanyRepository(pool), repo.NewDealRepository(pool), repo.NewWorkspaceRepository(pool),
+		repo.NewAuditRepo(pool), store, "https://app.example.com", log)
+
+	user := f.Member(domain.RoleUser)
+	newTemplate := func(t *testing.T, name, body string) *domain.DocumentTemplate {
+		t.Helper()
+		req := &domain.CreateDocumentTemplateRequest{Name: name, Title: "Proposta {quote.number} - {deal.name}", Body: body}
+		require.NoError(t, req.Validate())
+		template, err := templateSvc.CreateTemplate(ctx, f.WorkspaceID, user, req)
+		require.NoError(t, err)
+		return template
+	}
+
+	withTable := newTemplate(t, "Com tabela", "Itens:\n{quote.lineItems}\nTotal: {quote.total}")
+	withoutTable := newTemplate(t, "Sem tabela", "Olá, segue a proposta.")
+
+	t.Run("template names are unique", func(t *testing.T) {
+		req := &domain.CreateDocumentTemplateRequest{Name: "Com tabela", Title: "Outra", Body: "Outra"}
+		require.NoError(t, req.Validate())
+		_, err := templateSvc.CreateTemplate(ctx, f.WorkspaceID, user, req)
+		assert.ErrorIs(t, err, service.ErrDocumentTemplateNameConflict)
+	})
+
+	deal := f.Deal(func(d *domain.Deal) { d.Name = "Projeto Acme" })
+	create := func(t *testing.T, req *domain.CreateQuoteRequest) *domain.Quote {
+		t.Helper()
+		require.NoError(t, req.Validate())
+		quote, err := svc.CreateQuote(ctx, f.WorkspaceID, user, req)
+		require.NoError(t, err)
+		return quote
+	}
+
+	t.Run("template and deal must belong to the workspace", func(t *testing.T) {
+		_, err := svc.CreateQuote(ctx, f.WorkspaceID, user, &domain.CreateQuoteRequest{TemplateID: "dtp_missing", DealID: deal.ID})
+		assert.ErrorIs(t, err, service.ErrInvalidQuoteTemplate)
+		_, err = svc.CreateQuote(ctx, f.WorkspaceID, user, &domain.CreateQuoteRequest{TemplateID: withTable.ID, DealID: "deal_missing"})
+		assert.ErrorIs(t, err, service.ErrInvalidQuoteDeal)
+	})
+
+	t.Run("viewers cannot create quotes", func(t *testing.T) {
+		_, err := svc.CreateQuote(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), &domain.CreateQuoteRequest{TemplateID: withTable.ID, DealID: deal.ID})
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	ten := 10.0
+	first := create(t, &domain.CreateQuoteRequest{TemplateID: withTable.ID, DealID: deal.ID, LineItems: []domain.QuoteLineItem{
+		{Description: "Implantação", Quantity: 1, UnitPrice: 2000, DiscountPercent: &ten},
+		{Description: "Treinamento", Quantity: 4, UnitPrice: 250},
+	}})
+	second := create(t, &domain.CreateQuoteRequest{TemplateID: withoutTable.ID, DealID: deal.ID})
+
+	t.Run("quotes are rendered on creation", func(t *testing.T) {
+		assert.Equal(t, first.Number+1, second.Number, "numbers are sequential per workspace")
+		assert.Equal(t, domain.QuoteStatusDraft, first.Status)
+		assert.Equal(t, domain.QuotePDFPending, first.PDFStatus)
+
+		assert.Equal(t, 2800.0, first.Total)
+		assert.Equal(t, 200.0, first.Discount)
+		assert.Contains(t, first.Title, "Projeto Acme")
+		assert.Contains(t, first.Body, "Total: 2800.00")
+		assert.Equal(t, 1, strings.Count(first.Body, "Treinamento"))
+
+		// Sem lineItems: um item com o valor do negócio, e a tabela anexada ao corpo
+		require.Len(t, second.LineItems, 1)
+		assert.Equal(t, "Projeto Acme", second.LineItems[0].Description)
+		assert.Equal(t, 1000.0, second.Total)
+		assert.True(t, strings.HasPrefix(second.Body, "Olá, segue a proposta.\n\n"))
+		assert.Contains(t, second.Body, "Total ("+deal.Currency+")")
+	})
+
+	t.Run("pdf is rendered by the worker", func(t *testing.T) {
+		_, _, err := svc.OpenPDF(ctx, f.WorkspaceID, first.ID, user)
+		assert.ErrorIs(t, err, service.ErrQuotePDFNotReady)
+
+		for i := 0; i < 100; i++ {
+			processed, err := svc.ProcessNext(ctx)
+			require.NoError(t, err)
+			if !processed {
+				break
+			}
+		}
+
+		quote, body, err := svc.OpenPDF(ctx, f.WorkspaceID, first.ID, user)
+		require.NoError(t, err)
+		defer body.Close()
+		pdf, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(pdf), "%PDF-"))
+		assert.Equal(t, domain.QuotePDFCompleted, quote.PDFStatus)
+		assert.Equal(t, int64(len(pdf)), *quote.PDFSizeBytes)
+	})
+
+	t.Run("share link lifecycle", func(t *testing.T) {
+		sent, err := svc.SendQuote(ctx, f.WorkspaceID, first.ID, user)
+		require.NoError(t, err)
+		require.NotNil(t, sent.ShareURL)
+		assert.Equal(t, domain.QuoteStatusSent, sent.Status)
+		oldToken := strings.TrimPrefix(*sent.ShareURL, "https://app.example.com/q/")
+
+		// Reenviar gera outro link e invalida o anterior
+		resent, err := svc.SendQuote(ctx, f.WorkspaceID, first.ID, user)
+		require.NoError(t, err)
+		token := strings.TrimPrefix(*resent.ShareURL, "https://app.example.com/q/")
+		_, err = svc.ViewSharedQuote(ctx, oldToken)
+		assert.ErrorIs(t, err, service.ErrQuoteNotFound)
+
+		viewed, err := svc.ViewSharedQuote(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, domain.QuoteStatusViewed, viewed.Status)
+		assert.True(t, viewed.PDFReady)
+
+		accepted, err := svc.AcceptSharedQuote(ctx, token, &domain.AcceptQuoteRequest{Name: "Ana Cliente"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.QuoteStatusAccepted, accepted.Status)
+		assert.Equal(t, "Ana Cliente", *accepted.AcceptedByName)
+
+		_, err = svc.AcceptSharedQuote(ctx, token, &domain.AcceptQuoteRequest{Name: "Outro"})
+		assert.ErrorIs(t, err, service.ErrQuoteNotAcceptable)
+		_, err = svc.SendQuote(ctx, f.WorkspaceID, first.ID, user)
+		assert.ErrorIs(t, err, service.ErrQuoteAlreadyAccepted)
+	})
+
+	t.Run("expired quotes cannot be accepted", func(t *testing.T) {
+		expired := create(t, &domain.CreateQuoteRequest{TemplateID: withTable.ID, DealID: deal.ID,
+			ValidUntil: factory.Ptr(time.Now().UTC().Add(-time.Hour))})
+		sent, err := svc.SendQuote(ctx, f.WorkspaceID, expired.ID, user)
+		require.NoError(t, err)
+		token := strings.TrimPrefix(*sent.ShareURL, "https://app.example.com/q/")
+
+		_, err = svc.AcceptSharedQuote(ctx, token, &domain.AcceptQuoteRequest{Name: "Ana Cliente"})
+		assert.ErrorIs(t, err, service.ErrQuoteNotAcceptable)
+	})
+
+	t.Run("deleted quotes drop the share link", func(t *testing.T) {
+		sent, err := svc.SendQuote(ctx, f.WorkspaceID, second.ID, user)
+		require.NoError(t, err)
+		token := strings.TrimPrefix(*sent.ShareURL, "https://app.example.com/q/")
+
+		assert.ErrorIs(t, svc.DeleteQuote(ctx, f.WorkspaceID, second.ID, user), service.ErrUnauthorized)
+		require.NoError(t, svc.DeleteQuote(ctx, f.WorkspaceID, second.ID, f.UserID))
+		_, err = svc.ViewSharedQuote(ctx, token)
+		assert.ErrorIs(t, err, service.ErrQuoteNotFound)
+	})
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// ReportService expõe relatórios agregados do workspace (somente leitura).
+type ReportService struct {
+	reportRepo    *repo.ReportRepository
+	dealRepo      *repo.DealRepository
+	businessHours *repo.BusinessHoursRepository
+	workspaceRepo *repo.WorkspaceRepository
+	snapshots     *database.TxManager
+	log           *logger.Logger
+}
+
+func NewReportService(reportRepo *repo.ReportRepository, dealRepo *repo.DealRepository, businessHours *repo.BusinessHoursRepository, workspaceRepo *repo.WorkspaceRepository, log *logger.Logger) *ReportService {
+	return &ReportService{
+		reportRepo:    reportRepo,
+		dealRepo:      dealRepo,
+		businessHours: businessHours,
+		workspaceRepo: workspaceRepo,
+		log:           log,
+	}
+}
+
+// WithSnapshots faz os relatórios que leem mais de uma consulta rodarem em um único snapshot
+// (TxManager dos repositórios de dados). Sem ele, cada consulta lê o estado do seu momento.
+func (s *ReportService) WithSnapshots(snapshots *database.TxManager) *ReportService {
+	s.snapshots = snapshots
+	return s
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *ReportService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("report"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// WorkspaceLocation fuso padrão dos filtros de data dos relatórios (sem ?tz=).
+func (s *ReportService) WorkspaceLocation(ctx context.Context, workspaceID string) (*time.Location, error) {
+	return workspaceLocation(ctx, s.businessHours, workspaceID)
+}
+
+// AttributionReport aggregates contacts and deals per acquisition channel.
+// Permission: all workspace members can view reports.
+func (s *ReportService) AttributionReport(ctx context.Context, workspaceID, actorID string, params domain.AttributionReportParams) (*domain.AttributionReport, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	if params.GroupBy == "" {
+		params.GroupBy = domain.AttributionBySource
+	}
+
+	rows, err := s.reportRepo.Attribution(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("attribution report: %w", err)
+	}
+
+	return &domain.AttributionReport{GroupBy: params.GroupBy, Rows: rows}, nil
+}
+
+// OverdueReport lists contacts and open deals whose next step is past due, grouped per owner.
+// Permission: all workspace members can view reports.
+func (s *ReportService) OverdueReport(ctx context.Context, workspaceID, actorID string, params domain.OverdueReportParams) (*domain.OverdueReport, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	if params.AsOf.IsZero() {
+		params.AsOf = time.Now().UTC()
+	}
+
+	items, err := s.reportRepo.Overdue(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("overdue report: %w", err)
+	}
+
+	// Agrupa por responsável preservando a ordem do item mais atrasado
+	report := &domain.OverdueReport{AsOf: params.AsOf, Owners: []domain.OverdueOwnerGroup{}, Total: len(items)}
+	groupIndex := make(map[string]int)
+	for _, item := range items {
+		item.DaysOverdue = int(params.AsOf.Sub(item.NextStepAt).Hours() / 24)
+
+		key := ""
+		if item.OwnerID != nil {
+			key = *item.OwnerID
+		}
+		idx, ok := groupIndex[key]
+		if !ok {
+			idx = len(report.Owners)
+			groupIndex[key] = idx
+			report.Owners = append(report.Owners, domain.OverdueOwnerGroup{OwnerID: item.OwnerID})
+		}
+		report.Owners[idx].Items = append(report.Owners[idx].Items, item)
+		report.Owners[idx].Count++
+	}
+
+	return report, nil
+}
+
+// TimeReport rolls up logged hours (stopped timers only) per user, deal or task.
+// Permission: all workspace members can view reports.
+func (s *ReportService) TimeReport(ctx context.Context, workspaceID, actorID string, params domain.TimeReportParams) (*domain.TimeReport, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	if params.GroupBy == "" {
+		params.GroupBy = domain.TimeByUser
+	}
+
+	rows, err := s.reportRepo.Time(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("time report: %w", err)
+	}
+
+	return domain.NewTimeReport(params.GroupBy, rows), nil
+}
+
+// SLABreachReport lists breached SLA timers of open tickets, oldest due date first.
+// Permission: all workspace members can view reports.
+func (s *ReportService) SLABreachReport(ctx context.Context, workspaceID, actorID string, params domain.SLABreachReportParams) (*domain.SLABreachReport, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	if params.AsOf.IsZero() {
+		params.AsOf = time.Now().UTC()
+	}
+
+	var inputs []domain.DealSLAInput
+	var cal *domain.BusinessCalendar
+	err = s.snapshots.ReadSnapshot(ctx, func(ctx context.Context) error {
+		var err error
+		inputs, err = s.dealRepo.ListSLAInputs(ctx, workspaceID, nil, params.PipelineID, params.OwnerID, true)
+		if err != nil {
+			return fmt.Errorf("sla breach report: %w", err)
+		}
+		cal, err = s.businessHours.GetCalendar(ctx, workspaceID)
+		if err != nil {
+			return fmt.Errorf("load business calendar: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := []domain.SLATimerKind{domain.SLATimerFirstResponse, domain.SLATimerResolution}
+	if params.Timer != nil {
+		kinds = []domain.SLATimerKind{*params.Timer}
+	}
+
+	items := []domain.SLABreachItem{}
+	for _, in := range inputs {
+		sla := in.Compute(cal, params.AsOf)
+		for _, kind := range kinds {
+			timer := sla.Timer(kind)
+			if timer == nil || timer.Status != domain.SLAStatusBreached {
+				continue
+			}
+
+			// Atraso conta até a conclusão (resposta tardia) ou até asOf se ainda em aberto
+			end := params.AsOf
+			if timer.CompletedAt != nil {
+				end = *timer.CompletedAt
+			}
+			items = append(items, domain.SLABreachItem{
+				DealID:         in.DealID,
+				Name:           in.Name,
+				PipelineID:     in.PipelineID,
+				StageID:        in.StageID,
+				OwnerID:        in.OwnerID,
+				Timer:          kind,
+				TargetMinutes:  timer.TargetMinutes,
+				DueAt:          timer.DueAt,
+				CompletedAt:    timer.CompletedAt,
+				MinutesOverdue: int(end.Sub(timer.DueAt).Minutes()),
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DueAt.Before(items[j].DueAt)
+	})
+
+	return &domain.SLABreachReport{AsOf: params.AsOf, Items: items, Total: len(items)}, nil
+}
+
+// PipelineSummaryReport totals open deals per stage plus deals won/lost since params.Since.
+// Permission: all workspace members can view reports.
+func (s *ReportService) PipelineSummaryReport(ctx context.Context, workspaceID, actorID string, params domain.PipelineSummaryParams) (*domain.PipelineSummaryReport, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	if params.Since.IsZero() {
+		params.Since = time.Now().UTC().AddDate(0, 0, -domain.DefaultPipelineSummaryDays)
+	}
+
+	rows, err := s.reportRepo.PipelineSummary(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline summary report: %w", err)
+	}
+
+	return domain.NewPipelineSummaryReport(params.Since, rows), nil
+}
+
+// RevenueReport compares booked revenue (won deals by closedAt) with collected revenue
+// (paid invoices by paidAt) per month or owner, plus the current outstanding balance.
+// Permission: all workspace members can view reports.
+func (s *ReportService) RevenueReport(ctx context.Context, workspaceID, actorID string, params domain.RevenueReportParams) (*domain.RevenueReport, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	if params.GroupBy == "" {
+		params.GroupBy = domain.RevenueByMonth
+	}
+
+	// Faturado, recebido e em aberto vêm do mesmo snapshot para fecharem entre si
+	var rows []domain.RevenueReportRow
+	var outstanding float64
+	err = s.snapshots.ReadSnapshot(ctx, func(ctx context.Context) error {
+		var err error
+		if rows, err = s.reportRepo.Revenue(ctx, params); err != nil {
+			return err
+		}
+		outstanding, err = s.reportRepo.OutstandingRevenue(ctx, workspaceID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revenue report: %w", err)
+	}
+
+	return domain.NewRevenueReport(params.GroupBy, rows, outstanding), nil
+}
+
+// ForecastReport totals deals per forecast category (commit, best case, pipeline, omitted)
+// closing in the period: closedAt for won/lost deals, expectedCloseDate for open ones.
+// Permission: all workspace members can view reports.
+func (s *ReportService) ForecastReport(ctx context.Context, workspaceID, actorID string, params domain.ForecastReportParams) (*domain.ForecastReport, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	rows, err := s.reportRepo.Forecast(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("forecast report: %w", err)
+	}
+
+	return domain.NewForecastReport(params, rows), nil
+}
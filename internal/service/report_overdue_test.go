@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestReportService_OverdueReport_Integration
+func TestReportService_OverdueReport_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	svc := service.NewReportService(repo.NewReportRepository(pool), repo.NewDealRepository(pool), repo.NewBusinessHoursRepository(pool), repo.NewWorkspaceRepository(pool), log)
+
+	asOf := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		v := asOf.AddDate(0, 0, -days)
+		return &v
+	}
+	seller := f.Member(domain.RoleUser)
+
+	oldest := f.Contact(func(c *domain.Contact) { c.NextStepAt = daysAgo(10) })
+	sellerDeal := f.Deal(func(d *domain.Deal) { d.NextStepAt = daysAgo(5); d.OwnerID = &seller })
+	adminDeal := f.Deal(func(d *domain.Deal) { d.NextStepAt = daysAgo(1) })
+	// Fora do relatório: sem próximo passo, agendado no futuro, negócio fechado e contato excluído
+	f.Contact()
+	f.Contact(func(c *domain.Contact) { c.NextStepAt = daysAgo(-2) })
+	f.Deal(func(d *domain.Deal) { d.NextStepAt = daysAgo(30); d.Stage = domain.DealStageWon })
+	deleted := f.Contact(func(c *domain.Contact) { c.NextStepAt = daysAgo(3) })
+	require.NoError(t, repo.NewContactRepository(pool).SoftDelete(ctx, f.WorkspaceID, deleted.ID, f.UserID))
+
+	t.Run("groups per owner, oldest first", func(t *testing.T) {
+		report, err := svc.OverdueReport(ctx, f.WorkspaceID, f.UserID, domain.OverdueReportParams{AsOf: asOf})
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Total)
+		require.Len(t, report.Owners, 2)
+
+		admin := report.Owners[0]
+		require.NotNil(t, admin.OwnerID)
+		assert.Equal(t, f.UserID, *admin.OwnerID)
+		assert.Equal(t, 2, admin.Count)
+		assert.Equal(t, oldest.ID, admin.Items[0].ID)
+		assert.Equal(t, domain.OverdueContact, admin.Items[0].Type)
+		assert.Equal(t, 10, admin.Items[0].DaysOverdue)
+		assert.Equal(t, adminDeal.ID, admin.Items[1].ID)
+		assert.Equal(t, 1, admin.Items[1].DaysOverdue)
+
+		require.NotNil(t, report.Owners[1].OwnerID)
+		assert.Equal(t, seller, *report.Owners[1].OwnerID)
+		assert.Equal(t, sellerDeal.ID, report.Owners[1].Items[0].ID)
+	})
+
+	t.Run("filters by owner and type", func(t *testing.T) {
+		deals := domain.OverdueDeal
+		report, err := svc.OverdueReport(ctx, f.WorkspaceID, seller, domain.OverdueReportParams{AsOf: asOf, Type: &deals})
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Total)
+
+		report, err = svc.OverdueReport(ctx, f.WorkspaceID, seller, domain.OverdueReportParams{AsOf: asOf, OwnerID: &seller})
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Total)
+		assert.Equal(t, sellerDeal.ID, report.Owners[0].Items[0].ID)
+	})
+
+	t.Run("non members are rejected", func(t *testing.T) {
+		other := factory.New(t, pool)
+		_, err := svc.OverdueReport(ctx, f.WorkspaceID, other.UserID, domain.OverdueReportParams{AsOf: asOf})
+		assert.ErrorIs(t, err, service.ErrMemberNotFound)
+	})
+}
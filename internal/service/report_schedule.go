@@ -0,0 +1,490 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/integrations/mailer"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/reportfile"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var ErrReportScheduleNotFound = repo.ErrReportScheduleNotFound
+
+const (
+	// reportScheduleLease tempo máximo de uma execução antes de o agendamento voltar à fila.
+	reportScheduleLease = 15 * time.Minute
+	// reportScheduleRunTimeout limita geração + entrega de um relatório.
+	reportScheduleRunTimeout = 5 * time.Minute
+	// defaultReportPeriodDays janela padrão dos relatórios agendados (última semana).
+	defaultReportPeriodDays = 7
+)
+
+// ReportScheduleService gerencia os agendamentos de relatórios e, no worker, executa os
+// vencidos: gera o relatório como o criador do agendamento, renderiza em CSV/PDF e entrega
+// por email (anexo) e/ou webhook (POST assinado com HMAC-SHA256).
+type ReportScheduleService struct {
+	scheduleRepo  *repo.ReportScheduleRepository
+	reports       *ReportService
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	mailer        mailer.Mailer // nil = email não configurado
+	httpClient    *http.Client
+	log           *logger.Logger
+}
+
+// NewReportScheduleService cria o service. mailer e httpClient só são usados na execução
+// (worker); a API pode passar nil.
+func NewReportScheduleService(scheduleRepo *repo.ReportScheduleRepository, reports *ReportService, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, m mailer.Mailer, httpClient *http.Client, log *logger.Logger) *ReportScheduleService {
+	return &ReportScheduleService{
+		scheduleRepo:  scheduleRepo,
+		reports:       reports,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		mailer:        m,
+		httpClient:    httpClient,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *ReportScheduleService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("report_schedule"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *ReportScheduleService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateReportSchedule cria o agendamento e calcula a primeira execução. O segredo do webhook
+// é gerado aqui e devolvido somente nesta resposta.
+// Permission: admin or manager.
+func (s *ReportScheduleService) CreateReportSchedule(ctx context.Context, workspaceID, actorID string, req *domain.CreateReportScheduleRequest) (*domain.ReportSchedule, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return nil, err
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+	reportScheduleID, err := id.New(id.ReportSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &domain.ReportSchedule{
+		ID:          reportScheduleID,
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		ReportType:  req.ReportType,
+		Filters:     req.Filters,
+		Format:      req.Format,
+		Recipients:  req.Recipients,
+		WebhookURL:  req.WebhookURL,
+		Cron:        req.Cron,
+		Timezone:    req.Timezone,
+		Enabled:     enabled,
+		CreatedByID: actorID,
+	}
+	if schedule.WebhookURL != nil {
+		secret := generateWebhookSecret()
+		schedule.WebhookSecret = &secret
+	}
+	if err := s.reschedule(schedule, time.Now()); err != nil {
+		return nil, err
+	}
+
+	created, err := s.scheduleRepo.Create(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", created.ID)
+	return created, nil
+}
+
+// GetReportSchedule retorna o agendamento (sem o segredo do webhook).
+// Permission: all workspace members.
+func (s *ReportScheduleService) GetReportSchedule(ctx context.Context, workspaceID, scheduleID, actorID string) (*domain.ReportSchedule, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.scheduleRepo.Get(ctx, workspaceID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	schedule.WebhookSecret = nil
+	return schedule, nil
+}
+
+// ListReportSchedules lista os agendamentos do workspace (sem os segredos dos webhooks).
+// Permission: all workspace members.
+func (s *ReportScheduleService) ListReportSchedules(ctx context.Context, workspaceID, actorID string) ([]domain.ReportSchedule, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.scheduleRepo.List(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range schedules {
+		schedules[i].WebhookSecret = nil
+	}
+	return schedules, nil
+}
+
+// UpdateReportSchedule aplica o PATCH. O resultado da mesclagem já foi validado pelo handler
+// (ReportSchedule.Validate). Um segredo novo é gerado (e devolvido) quando o webhook é
+// configurado em um agendamento que não tinha webhook.
+// Permission: admin or manager.
+func (s *ReportScheduleService) UpdateReportSchedule(ctx context.Context, workspaceID, scheduleID, actorID string, req *domain.UpdateReportScheduleRequest) (*domain.ReportSchedule, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.scheduleRepo.Get(ctx, workspaceID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Apply(schedule) {
+		if err := s.reschedule(schedule, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	newSecret := false
+	switch {
+	case schedule.WebhookURL == nil:
+		schedule.WebhookSecret = nil
+	case schedule.WebhookSecret == nil:
+		secret := generateWebhookSecret()
+		schedule.WebhookSecret = &secret
+		newSecret = true
+	}
+
+	updated, err := s.scheduleRepo.Update(ctx, schedule, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !newSecret {
+		updated.WebhookSecret = nil
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "update", updated.ID)
+	return updated, nil
+}
+
+// DeleteReportSchedule remove o agendamento.
+// Permission: admin or manager.
+func (s *ReportScheduleService) DeleteReportSchedule(ctx context.Context, workspaceID, scheduleID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return err
+	}
+	if err := s.scheduleRepo.Delete(ctx, workspaceID, scheduleID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", scheduleID)
+	return nil
+}
+
+// reschedule recalcula nextRunAt (nil quando o agendamento está desativado).
+func (s *ReportScheduleService) reschedule(schedule *domain.ReportSchedule, now time.Time) error {
+	if !schedule.Enabled {
+		schedule.NextRunAt = nil
+		return nil
+	}
+	next, err := domain.NextReportRun(schedule.Cron, schedule.Timezone, now)
+	if err != nil {
+		return fmt.Errorf("compute next report run: %w", err)
+	}
+	schedule.NextRunAt = &next
+	return nil
+}
+
+// ProcessDue executa até limit agendamentos vencidos e retorna quantos foram executados
+// (com sucesso ou falha registrada). Usado pelo report-schedule-worker.
+func (s *ReportScheduleService) ProcessDue(ctx context.Context, limit int) (int, error) {
+	processed := 0
+	for processed < limit && ctx.Err() == nil {
+		now := time.Now().UTC()
+		// TIMESTAMP(3): o lease é comparado de volta em Finish
+		lease := now.Add(reportScheduleLease).Truncate(time.Millisecond)
+
+		schedule, err := s.scheduleRepo.ClaimDue(ctx, now, lease)
+		if err != nil {
+			return processed, err
+		}
+		if schedule == nil {
+			break
+		}
+
+		status, runErr := domain.ReportRunSucceeded, (*string)(nil)
+		if err := s.run(ctx, schedule, now); err != nil {
+			msg := err.Error()
+			status, runErr = domain.ReportRunFailed, &msg
+			s.log.Warn(ctx, "scheduled report failed",
+				logger.Module("report_schedule"),
+				zap.String("workspace_id", schedule.WorkspaceID),
+				zap.String("schedule_id", schedule.ID),
+				zap.Error(err),
+			)
+		}
+
+		// A próxima execução parte de agora: execuções perdidas (worker parado) não são repetidas
+		var next *time.Time
+		if t, err := domain.NextReportRun(schedule.Cron, schedule.Timezone, now); err == nil {
+			next = &t
+		}
+		if err := s.scheduleRepo.Finish(ctx, schedule.ID, lease, next, status, runErr); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// run gera o relatório e entrega nos destinos configurados.
+func (s *ReportScheduleService) run(ctx context.Context, schedule *domain.ReportSchedule, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, reportScheduleRunTimeout)
+	defer cancel()
+
+	table, err := s.buildTable(ctx, schedule, now)
+	if err != nil {
+		return err
+	}
+
+	format := reportfile.Format(schedule.Format)
+	content, err := reportfile.Render(table, format)
+	if err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	filename := fmt.Sprintf("%s-%s.%s", strings.ReplaceAll(string(schedule.ReportType), "_", "-"), now.Format("2006-01-02"), format)
+
+	var errs []error
+	if len(schedule.Recipients) > 0 {
+		if err := s.sendEmail(ctx, schedule, table, filename, content); err != nil {
+			errs = append(errs, fmt.Errorf("email delivery: %w", err))
+		}
+	}
+	if schedule.WebhookURL != nil {
+		if err := s.postWebhook(ctx, schedule, filename, content, now); err != nil {
+			errs = append(errs, fmt.Errorf("webhook delivery: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *ReportScheduleService) sendEmail(ctx context.Context, schedule *domain.ReportSchedule, table *reportfile.Table, filename string, content []byte) error {
+	if s.mailer == nil {
+		return mailer.ErrNotConfigured
+	}
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      schedule.Recipients,
+		Subject: fmt.Sprintf("%s: %s", schedule.Name, table.Title),
+		Text: fmt.Sprintf("Your scheduled report %q is attached (%d rows).\nGenerated at %s.\n",
+			schedule.Name, len(table.Rows), table.GeneratedAt.Format("2006-01-02 15:04 UTC")),
+		Attachments: []mailer.Attachment{{
+			Filename:    filename,
+			ContentType: reportfile.Format(schedule.Format).ContentType(),
+			Data:        content,
+		}},
+	})
+}
+
+// postWebhook envia o arquivo no corpo do POST. X-Linkko-Signature = "sha256=" +
+// HMAC-SHA256(webhookSecret, timestamp + "." + corpo), com timestamp em X-Linkko-Timestamp.
+func (s *ReportScheduleService) postWebhook(ctx context.Context, schedule *domain.ReportSchedule, filename string, content []byte, now time.Time) error {
+	if s.httpClient == nil {
+		return fmt.Errorf("webhook delivery is not configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *schedule.WebhookURL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", reportfile.Format(schedule.Format).ContentType())
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	req.Header.Set("X-Linkko-Report-Schedule-Id", schedule.ID)
+	req.Header.Set("X-Linkko-Report-Type", string(schedule.ReportType))
+	req.Header.Set("X-Linkko-Timestamp", timestamp)
+	if schedule.WebhookSecret != nil {
+		mac := hmac.New(sha256.New, []byte(*schedule.WebhookSecret))
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(content)
+		req.Header.Set("X-Linkko-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// buildTable executa o relatório como o criador do agendamento (se ele saiu do workspace,
+// a execução falha com ErrMemberNotFound) e converte o resultado em tabela.
+func (s *ReportScheduleService) buildTable(ctx context.Context, schedule *domain.ReportSchedule, now time.Time) (*reportfile.Table, error) {
+	f := schedule.Filters
+	periodDays := defaultReportPeriodDays
+	if f.PeriodDays != nil {
+		periodDays = *f.PeriodDays
+	}
+	// O período começa à meia-noite do fuso do agendamento, não no horário da execução
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load schedule timezone: %w", err)
+	}
+	since := domain.StartOfDay(now.In(loc).AddDate(0, 0, -periodDays), loc)
+	table := &reportfile.Table{GeneratedAt: now}
+
+	switch schedule.ReportType {
+	case domain.ReportTypePipelineSummary:
+		report, err := s.reports.PipelineSummaryReport(ctx, schedule.WorkspaceID, schedule.CreatedByID, domain.PipelineSummaryParams{
+			PipelineID: f.PipelineID, OwnerID: f.OwnerID, Since: since,
+		})
+		if err != nil {
+			return nil, err
+		}
+		table.Title = fmt.Sprintf("Pipeline summary (won/lost since %s)", since.In(loc).Format("2006-01-02"))
+		table.Columns = []string{"Pipeline", "Stage", "Open deals", "Open value", "Won deals", "Won value", "Lost deals"}
+		for _, row := range report.Rows {
+			table.Rows = append(table.Rows, []string{
+				row.PipelineName, row.StageName, formatCount(row.OpenDeals), formatAmount(row.OpenValue),
+				formatCount(row.WonDeals), formatAmount(row.WonValue), formatCount(row.LostDeals),
+			})
+		}
+		table.Rows = append(table.Rows, []string{
+			"Total", "", formatCount(report.OpenDeals), formatAmount(report.OpenValue),
+			formatCount(report.WonDeals), formatAmount(report.WonValue), formatCount(report.LostDeals),
+		})
+
+	case domain.ReportTypeAttribution:
+		params := domain.AttributionReportParams{From: &since}
+		if f.GroupBy != nil {
+			params.GroupBy = domain.AttributionGroupBy(*f.GroupBy)
+		}
+		report, err := s.reports.AttributionReport(ctx, schedule.WorkspaceID, schedule.CreatedByID, params)
+		if err != nil {
+			return nil, err
+		}
+		table.Title = fmt.Sprintf("Attribution by %s (since %s)", report.GroupBy, since.In(loc).Format("2006-01-02"))
+		table.Columns = []string{string(report.GroupBy), "Contacts", "Deals", "Won deals", "Pipeline value", "Won value"}
+		for _, row := range report.Rows {
+			table.Rows = append(table.Rows, []string{
+				formatKey(row.Key), formatCount(row.Contacts), formatCount(row.Deals), formatCount(row.WonDeals),
+				formatAmount(row.PipelineValue), formatAmount(row.WonValue),
+			})
+		}
+
+	case domain.ReportTypeOverdue:
+		report, err := s.reports.OverdueReport(ctx, schedule.WorkspaceID, schedule.CreatedByID, domain.OverdueReportParams{
+			OwnerID: f.OwnerID, AsOf: now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		table.Title = "Overdue next steps"
+		table.Columns = []string{"Owner", "Type", "Name", "Next step at", "Days overdue", "Note"}
+		for _, group := range report.Owners {
+			for _, item := range group.Items {
+				table.Rows = append(table.Rows, []string{
+					formatKey(item.OwnerID), string(item.Type), item.Name, item.NextStepAt.UTC().Format("2006-01-02 15:04"),
+					strconv.Itoa(item.DaysOverdue), formatKey(item.NextStepNote),
+				})
+			}
+		}
+
+	case domain.ReportTypeTime:
+		params := domain.TimeReportParams{UserID: f.OwnerID, From: &since}
+		if f.GroupBy != nil {
+			params.GroupBy = domain.TimeReportGroupBy(*f.GroupBy)
+		}
+		report, err := s.reports.TimeReport(ctx, schedule.WorkspaceID, schedule.CreatedByID, params)
+		if err != nil {
+			return nil, err
+		}
+		table.Title = fmt.Sprintf("Time by %s (since %s)", report.GroupBy, since.In(loc).Format("2006-01-02"))
+		table.Columns = []string{string(report.GroupBy), "Entries", "Hours", "Billable hours"}
+		for _, row := range report.Rows {
+			table.Rows = append(table.Rows, []string{
+				formatKey(row.Key), strconv.Itoa(row.Entries), formatHours(row.TotalSeconds), formatHours(row.BillableSeconds),
+			})
+		}
+		table.Rows = append(table.Rows, []string{"Total", "", formatHours(report.TotalSeconds), formatHours(report.BillableSeconds)})
+
+	default:
+		return nil, fmt.Errorf("unsupported report type %q", schedule.ReportType)
+	}
+
+	return table, nil
+}
+
+func formatCount(n int64) string       { return strconv.FormatInt(n, 10) }
+func formatAmount(v float64) string    { return strconv.FormatFloat(v, 'f', 2, 64) }
+func formatHours(seconds int64) string { return strconv.FormatFloat(float64(seconds)/3600, 'f', 2, 64) }
+
+func formatKey(key *string) string {
+	if key == nil {
+		return "-"
+	}
+	return *key
+}
+
+func generateWebhookSecret() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
+
+func (s *ReportScheduleService) logAction(ctx context.Context, workspaceID, actorID, action, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "report_schedule", &idStr, nil, "", "")
+}
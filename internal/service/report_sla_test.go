@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestReportService_SLABreachReport_Integration
+func TestReportService_SLABreachReport_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	svc := service.NewReportService(repo.NewReportRepository(pool), repo.NewDealRepository(pool), repo.NewBusinessHoursRepository(pool), repo.NewWorkspaceRepository(pool), log)
+	activities := repo.NewActivityRepository(pool)
+
+	// Sem horário comercial configurado os timers correm 24x7
+	pipeline := f.Pipeline(func(p *domain.Pipeline) { p.PipelineType = domain.PipelineTypeTicket })
+	stageID := pipeline.Stages[0].ID
+	_, err = pool.Exec(ctx, `UPDATE public."PipelineStage" SET "firstResponseSlaMinutes" = 60, "resolutionSlaMinutes" = 600 WHERE id = $1`, stageID)
+	require.NoError(t, err)
+	ticket := func(opts ...func(*domain.Deal)) *domain.Deal {
+		return f.Deal(append([]func(*domain.Deal){func(d *domain.Deal) {
+			d.PipelineID = pipeline.ID
+			d.StageID = &stageID
+		}}, opts...)...)
+	}
+
+	silent := ticket()
+	answered := ticket()
+	ticket(func(d *domain.Deal) { d.Stage = domain.DealStageWon })
+	// Deal comum (sem SLA) nunca entra no relatório
+	f.Deal()
+
+	activityID, err := id.New(id.Activity)
+	require.NoError(t, err)
+	_, err = activities.CreateActivity(ctx, &domain.Activity{
+		ID:          activityID,
+		WorkspaceID: f.WorkspaceID,
+		DealID:      &answered.ID,
+		Type:        domain.ActivityTypeEmail,
+		UserID:      f.UserID,
+		Metadata:    []byte(`{}`),
+	})
+	require.NoError(t, err)
+
+	t.Run("first response breached only without a reply", func(t *testing.T) {
+		report, err := svc.SLABreachReport(ctx, f.WorkspaceID, f.UserID, domain.SLABreachReportParams{AsOf: time.Now().Add(3 * time.Hour)})
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Total)
+		item := report.Items[0]
+		assert.Equal(t, silent.ID, item.DealID)
+		assert.Equal(t, domain.SLATimerFirstResponse, item.Timer)
+		assert.Equal(t, 60, item.TargetMinutes)
+		assert.Nil(t, item.CompletedAt)
+		assert.InDelta(t, 120, item.MinutesOverdue, 1)
+	})
+
+	t.Run("timer filter", func(t *testing.T) {
+		resolution := domain.SLATimerResolution
+		report, err := svc.SLABreachReport(ctx, f.WorkspaceID, f.UserID, domain.SLABreachReportParams{AsOf: time.Now().Add(3 * time.Hour), Timer: &resolution})
+		require.NoError(t, err)
+		assert.Zero(t, report.Total)
+
+		report, err = svc.SLABreachReport(ctx, f.WorkspaceID, f.UserID, domain.SLABreachReportParams{AsOf: time.Now().Add(11 * time.Hour), Timer: &resolution})
+		require.NoError(t, err)
+		var ids []string
+		for _, item := range report.Items {
+			assert.Equal(t, domain.SLATimerResolution, item.Timer)
+			ids = append(ids, item.DealID)
+		}
+		assert.ElementsMatch(t, []string{silent.ID, answered.ID}, ids)
+	})
+
+	t.Run("first reply and resolution inputs", func(t *testing.T) {
+		deals := repo.NewDealRepository(pool)
+		inputs, err := deals.ListSLAInputs(ctx, f.WorkspaceID, []string{answered.ID}, nil, nil, false)
+		require.NoError(t, err)
+		require.Len(t, inputs, 1)
+		assert.NotNil(t, inputs[0].FirstResponseAt)
+		assert.Nil(t, inputs[0].ResolvedAt)
+	})
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSandboxSameWorkspace = apperr.Define(apperr.CodeInvalidParameter, http.StatusBadRequest, "target workspace must differ from source workspace", "targetWorkspaceId must differ from source workspace")
+)
+
+// sandboxSkippedResources recursos de estrutura que ainda não são modelados pela API (o resto da
+// configuração vem do copiador da promoção). Mantidos no resultado da clonagem para deixar
+// explícito o que não foi copiado.
+var sandboxSkippedResources = []string{"customFields", "automations"}
+
+type SandboxService struct {
+	pipelineRepo  *repo.PipelineRepository
+	contactRepo   *repo.ContactRepository
+	workspaceRepo *repo.WorkspaceRepository
+	promotionRepo *repo.PromotionRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewSandboxService(pipelineRepo *repo.PipelineRepository, contactRepo *repo.ContactRepository, workspaceRepo *repo.WorkspaceRepository, promotionRepo *repo.PromotionRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *SandboxService {
+	return &SandboxService{
+		pipelineRepo:  pipelineRepo,
+		contactRepo:   contactRepo,
+		workspaceRepo: workspaceRepo,
+		promotionRepo: promotionRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *SandboxService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("sandbox"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+
+	s.log.Info(ctx, "workspace access granted",
+		logger.Module("sandbox"),
+		logger.Action("authorization"),
+		zap.String("actor_id", actorID),
+		zap.String("workspace_id", workspaceID),
+		zap.String("role", string(role)),
+	)
+	return role, nil
+}
+
+// authorizeBothWorkspaces exige papel admin na origem e no destino.
+func (s *SandboxService) authorizeBothWorkspaces(ctx context.Context, actorID, sourceWorkspaceID, targetWorkspaceID string) error {
+	if targetWorkspaceID == sourceWorkspaceID {
+		return ErrSandboxSameWorkspace
+	}
+	for _, workspaceID := range []string{sourceWorkspaceID, targetWorkspaceID} {
+		role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+		if err != nil {
+			return err
+		}
+		if !domain.CanManageWorkspace(role) {
+			return ErrUnauthorized
+		}
+	}
+	return nil
+}
+
+// PreviewPromotion retorna o diff da configuração do sandbox (origem) contra produção
+// (destino) sem gravar nada.
+// Permission: actor precisa ser admin na origem e no destino.
+func (s *SandboxService) PreviewPromotion(ctx context.Context, sourceWorkspaceID, actorID string, req *domain.PromoteWorkspaceRequest) (*domain.PromotionPlan, error) {
+	if err := s.authorizeBothWorkspaces(ctx, actorID, sourceWorkspaceID, req.TargetWorkspaceID); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.promotionRepo.Preview(ctx, sourceWorkspaceID, req.TargetWorkspaceID)
+	if err != nil {
+		s.log.Error(ctx, "failed to preview promotion",
+			logger.Module("sandbox"),
+			logger.Action("promotion_preview"),
+			zap.String("source_workspace_id", sourceWorkspaceID),
+			zap.String("target_workspace_id", req.TargetWorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Promote copia a configuração do sandbox para produção: pipelines, stages, campos calculados,
+// objetos customizados, templates e sequências (nunca dados). Tudo é gravado em uma única
+// transação; recursos que só existem em produção são mantidos.
+// Permission: actor precisa ser admin na origem e no destino.
+func (s *SandboxService) Promote(ctx context.Context, sourceWorkspaceID, actorID string, req *domain.PromoteWorkspaceRequest) (*domain.PromotionPlan, error) {
+	if err := s.authorizeBothWorkspaces(ctx, actorID, sourceWorkspaceID, req.TargetWorkspaceID); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.promotionRepo.Apply(ctx, sourceWorkspaceID, req.TargetWorkspaceID, actorID)
+	if err != nil {
+		s.log.Error(ctx, "failed to promote workspace configuration",
+			logger.Module("sandbox"),
+			logger.Action("promote"),
+			zap.String("source_workspace_id", sourceWorkspaceID),
+			zap.String("target_workspace_id", req.TargetWorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	s.log.Info(ctx, "workspace configuration promoted",
+		logger.Module("sandbox"),
+		logger.Action("promote"),
+		zap.String("source_workspace_id", sourceWorkspaceID),
+		zap.String("target_workspace_id", req.TargetWorkspaceID),
+		zap.Int("created", plan.Summary.Create),
+		zap.Int("updated", plan.Summary.Update),
+		zap.Int("unchanged", plan.Summary.Unchanged),
+	)
+
+	// Audit: registra a promoção nos dois workspaces
+	metadata := map[string]interface{}{
+		"sourceWorkspaceId": sourceWorkspaceID,
+		"targetWorkspaceId": req.TargetWorkspaceID,
+		"created":           plan.Summary.Create,
+		"updated":           plan.Summary.Update,
+		"unchanged":         plan.Summary.Unchanged,
+	}
+	for _, ws := range []string{sourceWorkspaceID, req.TargetWorkspaceID} {
+		auditErr := s.auditRepo.LogAction(ctx, ws, actorID, "promote", "workspace", nil, metadata, "", "")
+		if auditErr != nil {
+			// Log audit failure but don't fail the operation
+		}
+	}
+
+	return plan, nil
+}
+
+// CloneWorkspace copia a configuração do workspace de origem para um workspace sandbox com o
+// mesmo copiador da promoção (pipelines, stages, campos calculados, objetos customizados,
+// templates e sequências) e, opcionalmente, contatos de exemplo anonimizados. Tudo é gravado em
+// uma única transação; repetir a clonagem atualiza a configuração já copiada em vez de duplicá-la.
+// Permission: actor precisa ser admin na origem e no destino.
+func (s *SandboxService) CloneWorkspace(ctx context.Context, sourceWorkspaceID, actorID string, req *domain.CloneWorkspaceRequest) (*domain.CloneWorkspaceResult, error) {
+	if err := s.authorizeBothWorkspaces(ctx, actorID, sourceWorkspaceID, req.TargetWorkspaceID); err != nil {
+		return nil, err
+	}
+
+	// Leituras da origem antes da transação: pipeline default e amostra de contatos
+	defaultPipelineID, err := s.defaultPipelineID(ctx, sourceWorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	var samples []domain.Contact
+	if sampleSize := req.EffectiveSampleSize(); sampleSize > 0 {
+		samples, _, err = s.contactRepo.List(ctx, domain.ListContactsParams{
+			WorkspaceID: sourceWorkspaceID,
+			Limit:       sampleSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list source contacts: %w", err)
+		}
+	}
+
+	tx, err := s.promotionRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	plan, err := s.promotionRepo.ApplyTx(ctx, tx, sourceWorkspaceID, req.TargetWorkspaceID, actorID)
+	if err != nil {
+		s.log.Error(ctx, "failed to clone workspace configuration",
+			logger.Module("sandbox"),
+			logger.Action("clone"),
+			zap.String("source_workspace_id", sourceWorkspaceID),
+			zap.String("target_workspace_id", req.TargetWorkspaceID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	result := &domain.CloneWorkspaceResult{
+		SourceWorkspaceID: sourceWorkspaceID,
+		TargetWorkspaceID: req.TargetWorkspaceID,
+		Resources:         map[domain.PromotionResource]int{},
+		Skipped:           sandboxSkippedResources,
+	}
+	for _, change := range plan.Changes {
+		result.Resources[change.Resource]++
+		// O pipeline default da origem continua default no sandbox
+		if change.Resource == domain.PromotionPipeline && change.SourceID == defaultPipelineID && change.TargetID != nil {
+			if err := s.pipelineRepo.SetAsDefault(ctx, tx, req.TargetWorkspaceID, *change.TargetID); err != nil {
+				return nil, fmt.Errorf("set default pipeline: %w", err)
+			}
+		}
+	}
+	result.PipelinesCloned = result.Resources[domain.PromotionPipeline]
+	result.StagesCloned = result.Resources[domain.PromotionStage]
+
+	if err := s.cloneSampleContacts(ctx, s.contactRepo.WithTx(tx), samples, req.TargetWorkspaceID, actorID); err != nil {
+		return nil, err
+	}
+	result.ContactsCloned = len(samples)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.log.Info(ctx, "workspace cloned to sandbox",
+		logger.Module("sandbox"),
+		logger.Action("clone"),
+		zap.String("source_workspace_id", sourceWorkspaceID),
+		zap.String("target_workspace_id", req.TargetWorkspaceID),
+		zap.Int("pipelines", result.PipelinesCloned),
+		zap.Int("stages", result.StagesCloned),
+		zap.Int("contacts", result.ContactsCloned),
+	)
+
+	// Audit: registra a clonagem nos dois workspaces
+	metadata := map[string]interface{}{
+		"sourceWorkspaceId": sourceWorkspaceID,
+		"targetWorkspaceId": req.TargetWorkspaceID,
+		"pipelinesCloned":   result.PipelinesCloned,
+		"stagesCloned":      result.StagesCloned,
+		"contactsCloned":    result.ContactsCloned,
+		"resources":         result.Resources,
+	}
+	for _, ws := range []string{sourceWorkspaceID, req.TargetWorkspaceID} {
+		auditErr := s.auditRepo.LogAction(ctx, ws, actorID, "clone", "workspace", nil, metadata, "", "")
+		if auditErr != nil {
+			// Log audit failure but don't fail the operation
+		}
+	}
+
+	return result, nil
+}
+
+// defaultPipelineID retorna o pipeline default do workspace ("" se não houver).
+func (s *SandboxService) defaultPipelineID(ctx context.Context, workspaceID string) (string, error) {
+	isDefault := true
+	pipelines, _, err := s.pipelineRepo.List(ctx, domain.ListPipelinesParams{
+		WorkspaceID: workspaceID,
+		IsDefault:   &isDefault,
+		Limit:       1,
+	})
+	if err != nil {
+		return "", fmt.Errorf("get default pipeline: %w", err)
+	}
+	if len(pipelines) == 0 {
+		return "", nil
+	}
+	return pipelines[0].ID, nil
+}
+
+// cloneSampleContacts grava no destino, com contacts vinculado à transação da clonagem, uma
+// cópia de cada contato de exemplo com nome, email e telefone substituídos por valores
+// sintéticos. Tags são preservadas porque automations costumam depender delas;
+// relacionamentos com empresas não são copiados.
+func (s *SandboxService) cloneSampleContacts(ctx context.Context, contacts *repo.ContactRepository, samples []domain.Contact, targetWorkspaceID, actorID string) error {
+	now := time.Now()
+	for i, src := range samples {
+		contactID, err := id.New(id.Contact)
+		if err != nil {
+			return err
+		}
+		contact := anonymizeContact(src, i+1)
+		contact.ID = contactID
+		contact.WorkspaceID = targetWorkspaceID
+		contact.ActorID = actorID
+		contact.CreatedAt = now
+		contact.UpdatedAt = now
+
+		if err := contacts.Create(ctx, contact); err != nil {
+			return fmt.Errorf("clone sample contact: %w", err)
+		}
+	}
+	return nil
+}
+
+// anonymizeContact gera uma cópia do contato sem PII.
+// O email usa o TLD reservado .invalid para nunca ser entregável.
+func anonymizeContact(src domain.Contact, seq int) *domain.Contact {
+	return &domain.Contact{
+		FullName: fmt.Sprintf("Contato Sandbox %03d", seq),
+		Email:    fmt.Sprintf("sandbox+%03d@example.invalid", seq),
+		Tags:     src.Tags,
+	}
+}
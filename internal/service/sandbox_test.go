@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestSandboxService_CloneWorkspace_Integration
+func TestSandboxService_CloneWorkspace_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	src := factory.New(t, pool)
+	dst := factory.New(t, pool)
+	ctx := src.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	pipelines := repo.NewPipelineRepository(pool)
+	contacts := repo.NewContactRepository(pool)
+	svc := service.NewSandboxService(pipelines, contacts, repo.NewWorkspaceRepository(pool),
+		repo.NewPromotionRepository(pool), repo.NewAuditRepo(pool), log)
+
+	defaultPipeline := src.Pipeline(func(p *domain.Pipeline) { p.IsDefault = true })
+	otherPipeline := src.Pipeline()
+	secret := src.Contact(func(c *domain.Contact) {
+		c.FullName = "Ana Confidencial"
+		c.Phone = factory.Ptr("+55 11 99999-0000")
+		c.Tags = []string{"vip"}
+	})
+
+	clone := func(actorID string, includeSampleData bool) (*domain.CloneWorkspaceResult, error) {
+		return svc.CloneWorkspace(ctx, src.WorkspaceID, actorID, &domain.CloneWorkspaceRequest{
+			TargetWorkspaceID: dst.WorkspaceID,
+			IncludeSampleData: includeSampleData,
+		})
+	}
+
+	t.Run("requires admin in both workspaces", func(t *testing.T) {
+		_, err := clone(src.UserID, false)
+		assert.ErrorIs(t, err, service.ErrMemberNotFound, "not a member of the sandbox")
+
+		sourceManager := src.Member(domain.RoleManager)
+		dst.Join(sourceManager, domain.RoleAdmin)
+		_, err = clone(sourceManager, false)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		targetUser := src.Member(domain.RoleAdmin)
+		dst.Join(targetUser, domain.RoleUser)
+		_, err = clone(targetUser, false)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		_, err = svc.CloneWorkspace(ctx, src.WorkspaceID, src.UserID, &domain.CloneWorkspaceRequest{TargetWorkspaceID: src.WorkspaceID})
+		assert.ErrorIs(t, err, service.ErrSandboxSameWorkspace)
+
+		cloned, _, err := pipelines.List(ctx, domain.ListPipelinesParams{WorkspaceID: dst.WorkspaceID, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, cloned, "denied clones write nothing")
+	})
+
+	dst.Join(src.UserID, domain.RoleAdmin)
+
+	t.Run("copies pipelines, stages and the default pipeline", func(t *testing.T) {
+		result, err := clone(src.UserID, true)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.PipelinesCloned)
+		assert.Equal(t, 6, result.StagesCloned)
+		assert.Equal(t, 1, result.ContactsCloned)
+		assert.Equal(t, 2, result.Resources[domain.PromotionPipeline])
+		assert.Equal(t, []string{"customFields", "automations"}, result.Skipped)
+
+		cloned, _, err := pipelines.List(ctx, domain.ListPipelinesParams{WorkspaceID: dst.WorkspaceID, IncludeStages: true, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, cloned, 2)
+		byName := map[string]domain.Pipeline{}
+		for _, p := range cloned {
+			byName[p.Name] = p
+		}
+
+		copied, ok := byName[defaultPipeline.Name]
+		require.True(t, ok)
+		assert.NotEqual(t, defaultPipeline.ID, copied.ID)
+		assert.True(t, copied.IsDefault, "source default stays default in the sandbox")
+		require.Len(t, copied.Stages, len(defaultPipeline.Stages))
+		for i, stage := range copied.Stages {
+			source := defaultPipeline.Stages[i]
+			assert.Equal(t, source.Name, stage.Name)
+			assert.Equal(t, source.Group, stage.Group)
+			assert.Equal(t, source.OrderIndex, stage.OrderIndex)
+			assert.Equal(t, source.Probability, stage.Probability)
+			assert.NotEqual(t, source.ID, stage.ID)
+			assert.Equal(t, dst.WorkspaceID, stage.WorkspaceID)
+			require.NotNil(t, stage.PipelineID)
+			assert.Equal(t, copied.ID, *stage.PipelineID)
+		}
+
+		assert.False(t, byName[otherPipeline.Name].IsDefault)
+	})
+
+	t.Run("anonymizes sample contacts", func(t *testing.T) {
+		cloned, _, err := contacts.List(ctx, domain.ListContactsParams{WorkspaceID: dst.WorkspaceID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, cloned, 1)
+
+		contact := cloned[0]
+		assert.NotEqual(t, secret.ID, contact.ID)
+		assert.NotContains(t, contact.FullName, "Ana")
+		assert.NotEqual(t, secret.Email, contact.Email)
+		assert.NotContains(t, contact.Email, secret.ID)
+		assert.True(t, strings.HasSuffix(contact.Email, ".invalid"), "email %s must be undeliverable", contact.Email)
+		assert.Nil(t, contact.Phone)
+		assert.Equal(t, []string{"vip"}, contact.Tags)
+	})
+
+	t.Run("cloning again does not duplicate configuration", func(t *testing.T) {
+		result, err := clone(src.UserID, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.PipelinesCloned)
+		assert.Zero(t, result.ContactsCloned)
+
+		cloned, _, err := pipelines.List(ctx, domain.ListPipelinesParams{WorkspaceID: dst.WorkspaceID, IncludeStages: true, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, cloned, 2)
+		for _, p := range cloned {
+			assert.Len(t, p.Stages, 3)
+		}
+	})
+}
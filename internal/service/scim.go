@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// scimActorID ator registrado no audit log para alterações feitas pelo diretório
+const scimActorID = "scim"
+
+var (
+	ErrScimTokenInvalid        = repo.ErrScimTokenInvalid
+	ErrScimUserNotFound        = repo.ErrScimUserNotFound
+	ErrScimGroupNotFound       = repo.ErrScimGroupNotFound
+	ErrScimUserConflict        = repo.ErrScimUserConflict
+	ErrScimGroupConflict       = repo.ErrScimGroupConflict
+	ErrScimGroupMemberNotFound = repo.ErrScimGroupMemberNotFound
+)
+
+// ScimService provisiona e desprovisiona membros do workspace a partir de um diretório
+// (Okta, Azure AD) via SCIM 2.0, mapeando grupos do diretório para papéis. Os endpoints
+// /scim/v2 autenticam com o token do workspace; a configuração fica com os admins em /v1.
+type ScimService struct {
+	scimRepo      *repo.ScimRepository
+	workspaceRepo *repo.WorkspaceRepository
+	billing       *BillingService
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+// NewScimService cria o service. billing nil desliga a checagem da quota de membros do plano.
+func NewScimService(scimRepo *repo.ScimRepository, workspaceRepo *repo.WorkspaceRepository, billing *BillingService, auditRepo *repo.AuditRepo, log *logger.Logger) *ScimService {
+	return &ScimService{
+		scimRepo:      scimRepo,
+		workspaceRepo: workspaceRepo,
+		billing:       billing,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *ScimService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("scim"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorizeAdmin a configuração SCIM altera membros e papéis: somente quem gerencia membros.
+func (s *ScimService) authorizeAdmin(ctx context.Context, workspaceID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanManageMembers(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// =====================================================
+// Configuração (admin do workspace)
+// =====================================================
+
+// GetConfig returns the SCIM provisioning state of the workspace.
+// Permission: admin only.
+func (s *ScimService) GetConfig(ctx context.Context, workspaceID, actorID string) (*domain.ScimConfig, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.scimRepo.GetConfig(ctx, workspaceID)
+}
+
+// RotateToken gera um novo token SCIM (o anterior deixa de valer). O token em claro só é
+// devolvido aqui; o banco guarda o sha256.
+// Permission: admin only.
+func (s *ScimService) RotateToken(ctx context.Context, workspaceID, actorID string, req *domain.RotateScimTokenRequest) (*domain.ScimTokenResponse, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	token := generateScimToken()
+	cfg, err := s.scimRepo.RotateToken(ctx, workspaceID, actorID, hashScimToken(token), req.DefaultRole)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "scim.token_rotated", "scim_config", workspaceID, map[string]interface{}{
+		"defaultRole": cfg.DefaultRole,
+	})
+	return &domain.ScimTokenResponse{Token: token, ScimConfig: *cfg}, nil
+}
+
+// RevokeToken desativa o provisionamento. Membros já provisionados continuam no workspace.
+// Permission: admin only.
+func (s *ScimService) RevokeToken(ctx context.Context, workspaceID, actorID string) error {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return err
+	}
+	if err := s.scimRepo.RevokeToken(ctx, workspaceID); err != nil {
+		return err
+	}
+	s.logAction(ctx, workspaceID, actorID, "scim.token_revoked", "scim_config", workspaceID, nil)
+	return nil
+}
+
+// ListGroupRoles lista os grupos do diretório e o papel mapeado de cada um.
+// Permission: admin only.
+func (s *ScimService) ListGroupRoles(ctx context.Context, workspaceID, actorID string) ([]domain.ScimGroupSummary, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.scimRepo.ListGroupSummaries(ctx, workspaceID)
+}
+
+// SetGroupRole mapeia o grupo para um papel (nil remove) e recalcula o papel dos membros.
+// Permission: admin only.
+func (s *ScimService) SetGroupRole(ctx context.Context, workspaceID, groupID, actorID string, req *domain.UpdateScimGroupRoleRequest) (*domain.ScimGroupSummary, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	group, err := s.scimRepo.SetGroupRole(ctx, workspaceID, groupID, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "scim.group_role_updated", "scim_group", groupID, map[string]interface{}{
+		"role": req.Role,
+	})
+	return group, nil
+}
+
+// =====================================================
+// Diretório (/scim/v2, autenticado pelo token)
+// =====================================================
+
+// ResolveToken retorna o workspace do token SCIM (usado pelo ScimAuthMiddleware).
+func (s *ScimService) ResolveToken(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", ErrScimTokenInvalid
+	}
+	return s.scimRepo.ResolveToken(ctx, hashScimToken(token))
+}
+
+// ListUsers retorna a página de usuários provisionados.
+func (s *ScimService) ListUsers(ctx context.Context, workspaceID string, params domain.ScimListParams) (*domain.ScimListResponse, error) {
+	users, total, err := s.scimRepo.ListUsers(ctx, workspaceID, params)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*domain.ScimUserResource, len(users))
+	for i := range users {
+		resources[i] = users[i].Resource()
+	}
+	return newScimListResponse(total, params.StartIndex, len(resources), resources), nil
+}
+
+// GetUser retorna o usuário provisionado.
+func (s *ScimService) GetUser(ctx context.Context, workspaceID, userID string) (*domain.ScimUserResource, error) {
+	user, err := s.scimRepo.GetUser(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.Resource(), nil
+}
+
+// CreateUser provisiona o usuário: reaproveita o "User" com o mesmo email (ou cria um) e,
+// se ativo, o adiciona ao workspace com o papel dos grupos mapeados (ou o defaultRole).
+func (s *ScimService) CreateUser(ctx context.Context, workspaceID string, in *domain.ScimUserInput) (*domain.ScimUserResource, error) {
+	existingID, err := s.scimRepo.FindUserByEmail(ctx, in.Email)
+	if err != nil {
+		return nil, err
+	}
+	if in.Active {
+		if err := s.checkMemberQuota(ctx, workspaceID, existingID); err != nil {
+			return nil, err
+		}
+	}
+
+	// ID usado só quando não há "User" com o mesmo email
+	newUserID, err := id.New(id.User)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.scimRepo.CreateUser(ctx, workspaceID, existingID, newUserID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, scimActorID, "scim.user_provisioned", "workspace_member", userID, map[string]interface{}{
+		"userName": in.UserName,
+		"active":   in.Active,
+		"linked":   existingID != "",
+	})
+	return s.GetUser(ctx, workspaceID, userID)
+}
+
+// ReplaceUser substitui os atributos do usuário (PUT).
+func (s *ScimService) ReplaceUser(ctx context.Context, workspaceID, userID string, in *domain.ScimUserInput) (*domain.ScimUserResource, error) {
+	current, err := s.scimRepo.GetUser(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateUser(ctx, workspaceID, current, in)
+}
+
+// PatchUser aplica as operações PATCH aos atributos atuais do usuário.
+func (s *ScimService) PatchUser(ctx context.Context, workspaceID, userID string, patch *domain.ScimPatchRequest) (*domain.ScimUserResource, error) {
+	current, err := s.scimRepo.GetUser(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	in := current.Input()
+	if err := patch.ApplyUserPatch(&in); err != nil {
+		return nil, &domain.ScimInvalidValueError{Err: err}
+	}
+	if err := in.Validate(); err != nil {
+		return nil, &domain.ScimInvalidValueError{Err: err}
+	}
+	return s.updateUser(ctx, workspaceID, current, &in)
+}
+
+func (s *ScimService) updateUser(ctx context.Context, workspaceID string, current *domain.ScimUser, in *domain.ScimUserInput) (*domain.ScimUserResource, error) {
+	if in.Active && !current.Active {
+		if err := s.checkMemberQuota(ctx, workspaceID, current.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.scimRepo.UpdateUser(ctx, workspaceID, current.UserID, in); err != nil {
+		return nil, err
+	}
+
+	action := "scim.user_updated"
+	switch {
+	case current.Active && !in.Active:
+		action = "scim.user_deactivated"
+	case !current.Active && in.Active:
+		action = "scim.user_reactivated"
+	}
+	s.logAction(ctx, workspaceID, scimActorID, action, "workspace_member", current.UserID, map[string]interface{}{
+		"userName": in.UserName,
+	})
+	return s.GetUser(ctx, workspaceID, current.UserID)
+}
+
+// DeleteUser desprovisiona o usuário: remove a associação ao workspace e o vínculo SCIM.
+func (s *ScimService) DeleteUser(ctx context.Context, workspaceID, userID string) error {
+	if err := s.scimRepo.DeleteUser(ctx, workspaceID, userID); err != nil {
+		return err
+	}
+	s.logAction(ctx, workspaceID, scimActorID, "scim.user_deprovisioned", "workspace_member", userID, nil)
+	return nil
+}
+
+// ListGroups retorna a página de grupos; withMembers = false omite os membros.
+func (s *ScimService) ListGroups(ctx context.Context, workspaceID string, params domain.ScimListParams, withMembers bool) (*domain.ScimListResponse, error) {
+	groups, total, err := s.scimRepo.ListGroups(ctx, workspaceID, params, withMembers)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*domain.ScimGroupResource, len(groups))
+	for i := range groups {
+		resources[i] = groups[i].Resource()
+	}
+	return newScimListResponse(total, params.StartIndex, len(resources), resources), nil
+}
+
+// GetGroup retorna o grupo com seus membros.
+func (s *ScimService) GetGroup(ctx context.Context, workspaceID, groupID string) (*domain.ScimGroupResource, error) {
+	group, err := s.scimRepo.GetGroup(ctx, workspaceID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return group.Resource(), nil
+}
+
+// CreateGroup cria o grupo. O papel é definido depois por um admin do workspace.
+func (s *ScimService) CreateGroup(ctx context.Context, workspaceID string, in *domain.ScimGroupInput) (*domain.ScimGroupResource, error) {
+	groupID, err := id.New(id.ScimGroup)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.scimRepo.CreateGroup(ctx, workspaceID, groupID, in); err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, scimActorID, "scim.group_created", "scim_group", groupID, map[string]interface{}{
+		"displayName": in.DisplayName,
+		"members":     len(in.MemberIDs),
+	})
+	return s.GetGroup(ctx, workspaceID, groupID)
+}
+
+// ReplaceGroup substitui nome, externalId e membros do grupo (PUT).
+func (s *ScimService) ReplaceGroup(ctx context.Context, workspaceID, groupID string, in *domain.ScimGroupInput) (*domain.ScimGroupResource, error) {
+	members := in.MemberIDs
+	if members == nil {
+		members = []string{}
+	}
+	return s.patchGroup(ctx, workspaceID, groupID, &domain.ScimGroupPatch{
+		DisplayName:     &in.DisplayName,
+		ExternalID:      in.ExternalID,
+		ReplaceMembers:  members,
+		MembersReplaced: true,
+	})
+}
+
+// PatchGroup aplica as operações PATCH (membros, displayName, externalId).
+func (s *ScimService) PatchGroup(ctx context.Context, workspaceID, groupID string, req *domain.ScimPatchRequest) (*domain.ScimGroupResource, error) {
+	patch, err := req.GroupPatch()
+	if err != nil {
+		return nil, &domain.ScimInvalidValueError{Err: err}
+	}
+	return s.patchGroup(ctx, workspaceID, groupID, patch)
+}
+
+func (s *ScimService) patchGroup(ctx context.Context, workspaceID, groupID string, patch *domain.ScimGroupPatch) (*domain.ScimGroupResource, error) {
+	if err := s.scimRepo.PatchGroup(ctx, workspaceID, groupID, patch); err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"added":   len(patch.AddMembers),
+		"removed": len(patch.RemoveMembers),
+	}
+	if patch.MembersReplaced {
+		metadata["replaced"] = len(patch.ReplaceMembers)
+	}
+	s.logAction(ctx, workspaceID, scimActorID, "scim.group_updated", "scim_group", groupID, metadata)
+	return s.GetGroup(ctx, workspaceID, groupID)
+}
+
+// DeleteGroup remove o grupo; os ex-membros voltam ao papel de outro grupo ou ao defaultRole.
+func (s *ScimService) DeleteGroup(ctx context.Context, workspaceID, groupID string) error {
+	if err := s.scimRepo.DeleteGroup(ctx, workspaceID, groupID); err != nil {
+		return err
+	}
+	s.logAction(ctx, workspaceID, scimActorID, "scim.group_deleted", "scim_group", groupID, nil)
+	return nil
+}
+
+// checkMemberQuota aplica o limite de membros do plano quando o usuário ainda não é membro.
+// Falhas ao consultar o billing liberam o provisionamento, como no QuotaMiddleware.
+func (s *ScimService) checkMemberQuota(ctx context.Context, workspaceID, userID string) error {
+	if s.billing == nil {
+		return nil
+	}
+	if userID != "" {
+		if _, err := s.workspaceRepo.GetMemberRole(ctx, userID, workspaceID); err == nil {
+			return nil
+		}
+	}
+
+	quota, err := s.billing.Quota(ctx, workspaceID)
+	if err == nil {
+		err = s.billing.CheckQuota(ctx, workspaceID, quota, domain.QuotaMembers)
+	}
+	if err == nil {
+		return nil
+	}
+	if _, known := apperr.From(err); known {
+		return err
+	}
+	s.log.Warn(ctx, "member quota check failed, allowing scim provisioning",
+		logger.Module("scim"),
+		logger.Action("quota"),
+		zap.String("workspace_id", workspaceID),
+		zap.Error(err),
+	)
+	return nil
+}
+
+func newScimListResponse(total, startIndex, itemsPerPage int, resources interface{}) *domain.ScimListResponse {
+	return &domain.ScimListResponse{
+		Schemas:      []string{domain.ScimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}
+}
+
+// generateScimToken token do diretório (256 bits, base64url)
+func generateScimToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hashScimToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func (s *ScimService) logAction(ctx context.Context, workspaceID, actorID, action, entity, id string, metadata map[string]interface{}) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, entity, &idStr, metadata, "", "")
+}
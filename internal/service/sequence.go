@@ -0,0 +1,563 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSequenceNotFound           = repo.ErrSequenceNotFound
+	ErrSequenceEnrollmentNotFound = repo.ErrSequenceEnrollmentNotFound
+	ErrContactAlreadyEnrolled     = repo.ErrContactAlreadyEnrolled
+	ErrSequenceTemplateNotFound   = apperr.Unprocessable(apperr.CodeValidationError, "email template referenced by sequence step not found", "")
+	ErrInvalidEnrollmentStatus    = apperr.Unprocessable(apperr.CodeInvalidStatus, "enrollment status does not allow this operation", "")
+	ErrContactEmailInvalid        = apperr.Unprocessable(apperr.CodeValidationError, "contact email failed verification and cannot receive sequence emails", "")
+)
+
+// SequenceService gerencia sequências (cadências), inscrições e a execução dos passos pelo worker.
+type SequenceService struct {
+	sequenceRepo  *repo.SequenceRepository
+	templateRepo  *repo.EmailTemplateRepository
+	contactRepo   *repo.ContactRepository
+	dealRepo      *repo.DealRepository
+	taskRepo      *repo.TaskRepository
+	businessHours *repo.BusinessHoursRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewSequenceService(sequenceRepo *repo.SequenceRepository, templateRepo *repo.EmailTemplateRepository, contactRepo *repo.ContactRepository, dealRepo *repo.DealRepository, taskRepo *repo.TaskRepository, businessHours *repo.BusinessHoursRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *SequenceService {
+	return &SequenceService{
+		sequenceRepo:  sequenceRepo,
+		templateRepo:  templateRepo,
+		contactRepo:   contactRepo,
+		dealRepo:      dealRepo,
+		taskRepo:      taskRepo,
+		businessHours: businessHours,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *SequenceService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("sequence"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// checkTemplates garante que os templates dos passos de email existem no workspace.
+func (s *SequenceService) checkTemplates(ctx context.Context, workspaceID string, templateIDs []string) error {
+	for _, id := range templateIDs {
+		if _, err := s.templateRepo.Get(ctx, workspaceID, id); err != nil {
+			if errors.Is(err, repo.ErrEmailTemplateNotFound) {
+				return ErrSequenceTemplateNotFound
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateSequence creates a sequence.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *SequenceService) CreateSequence(ctx context.Context, workspaceID, actorID string, req *domain.CreateSequenceRequest) (*domain.Sequence, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.checkTemplates(ctx, workspaceID, req.EmailTemplateIDs()); err != nil {
+		return nil, err
+	}
+
+	sequenceID, err := id.New(id.Sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence := &domain.Sequence{
+		ID:          sequenceID,
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		Description: req.Description,
+		Steps:       req.Steps,
+		OnDealWon:   domain.SequenceExitUnenroll,
+		OnReply:     domain.SequenceExitPause,
+		CreatedByID: actorID,
+	}
+	if req.OnDealWon != nil {
+		sequence.OnDealWon = *req.OnDealWon
+	}
+	if req.OnReply != nil {
+		sequence.OnReply = *req.OnReply
+	}
+
+	created, err := s.sequenceRepo.Create(ctx, sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logSequenceAction(ctx, workspaceID, actorID, "create", "sequence", created.ID)
+
+	return created, nil
+}
+
+// GetSequence retrieves a single sequence.
+// Permission: all workspace members.
+func (s *SequenceService) GetSequence(ctx context.Context, workspaceID, sequenceID, actorID string) (*domain.Sequence, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.sequenceRepo.Get(ctx, workspaceID, sequenceID)
+}
+
+// ListSequences lists the workspace sequences ordered by name.
+// Permission: all workspace members.
+func (s *SequenceService) ListSequences(ctx context.Context, workspaceID, actorID string) ([]domain.Sequence, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.sequenceRepo.List(ctx, workspaceID)
+}
+
+// UpdateSequence partially updates a sequence.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *SequenceService) UpdateSequence(ctx context.Context, workspaceID, sequenceID, actorID string, req *domain.UpdateSequenceRequest) (*domain.Sequence, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.checkTemplates(ctx, workspaceID, req.EmailTemplateIDs()); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.sequenceRepo.Update(ctx, workspaceID, sequenceID, req, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logSequenceAction(ctx, workspaceID, actorID, "update", "sequence", sequenceID)
+
+	return updated, nil
+}
+
+// DeleteSequence soft-deletes a sequence and exits its in-progress enrollments.
+// Permission: admin, manager.
+func (s *SequenceService) DeleteSequence(ctx context.Context, workspaceID, sequenceID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanDeleteContacts(role) {
+		return ErrUnauthorized
+	}
+
+	if err := s.sequenceRepo.Delete(ctx, workspaceID, sequenceID, actorID); err != nil {
+		return err
+	}
+
+	s.logSequenceAction(ctx, workspaceID, actorID, "delete", "sequence", sequenceID)
+	return nil
+}
+
+// EnrollContact enrolls a contact (optionally tied to a deal) in a sequence.
+// The first step runs on the next worker tick.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *SequenceService) EnrollContact(ctx context.Context, workspaceID, sequenceID, actorID string, req *domain.EnrollContactRequest) (*domain.SequenceEnrollment, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	sequence, err := s.sequenceRepo.Get(ctx, workspaceID, sequenceID)
+	if err != nil {
+		return nil, err
+	}
+	contact, err := s.contactRepo.Get(ctx, workspaceID, req.ContactID)
+	if err != nil {
+		return nil, err
+	}
+	if sequence.HasEmailSteps() && contact.EmailStatus != nil && *contact.EmailStatus == domain.EmailStatusInvalid {
+		return nil, ErrContactEmailInvalid
+	}
+	if req.DealID != nil {
+		if _, err := s.dealRepo.Get(ctx, workspaceID, *req.DealID); err != nil {
+			if errors.Is(err, repo.ErrDealNotFound) {
+				return nil, ErrDealNotFound
+			}
+			return nil, fmt.Errorf("get deal: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	sequenceEnrollmentID, err := id.New(id.SequenceEnrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.sequenceRepo.CreateEnrollment(ctx, &domain.SequenceEnrollment{
+		ID:           sequenceEnrollmentID,
+		WorkspaceID:  workspaceID,
+		SequenceID:   sequenceID,
+		ContactID:    req.ContactID,
+		DealID:       req.DealID,
+		Status:       domain.EnrollmentActive,
+		NextRunAt:    &now,
+		EnrolledByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logSequenceAction(ctx, workspaceID, actorID, "enroll", "sequence_enrollment", enrollment.ID)
+
+	return enrollment, nil
+}
+
+// ListEnrollments lists the enrollments of a sequence.
+// Permission: all workspace members.
+func (s *SequenceService) ListEnrollments(ctx context.Context, workspaceID, sequenceID, actorID string, status *domain.EnrollmentStatus) ([]domain.SequenceEnrollment, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if _, err := s.sequenceRepo.Get(ctx, workspaceID, sequenceID); err != nil {
+		return nil, err
+	}
+
+	return s.sequenceRepo.ListEnrollments(ctx, workspaceID, sequenceID, status)
+}
+
+// PauseEnrollment pauses an ACTIVE enrollment, keeping its schedule.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *SequenceService) PauseEnrollment(ctx context.Context, workspaceID, sequenceID, enrollmentID, actorID string) (*domain.SequenceEnrollment, error) {
+	return s.changeEnrollmentStatus(ctx, workspaceID, sequenceID, enrollmentID, actorID, "pause",
+		func(e *domain.SequenceEnrollment) ([]domain.EnrollmentStatus, domain.EnrollmentStatus, *time.Time) {
+			return []domain.EnrollmentStatus{domain.EnrollmentActive}, domain.EnrollmentPaused, e.NextRunAt
+		})
+}
+
+// ResumeEnrollment reactivates a PAUSED enrollment. Steps overdue while paused run on the next tick.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *SequenceService) ResumeEnrollment(ctx context.Context, workspaceID, sequenceID, enrollmentID, actorID string) (*domain.SequenceEnrollment, error) {
+	return s.changeEnrollmentStatus(ctx, workspaceID, sequenceID, enrollmentID, actorID, "resume",
+		func(e *domain.SequenceEnrollment) ([]domain.EnrollmentStatus, domain.EnrollmentStatus, *time.Time) {
+			nextRunAt := time.Now().UTC()
+			if e.NextRunAt != nil && e.NextRunAt.After(nextRunAt) {
+				nextRunAt = *e.NextRunAt
+			}
+			return []domain.EnrollmentStatus{domain.EnrollmentPaused}, domain.EnrollmentActive, &nextRunAt
+		})
+}
+
+// UnenrollContact exits an ACTIVE or PAUSED enrollment. No further steps run.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *SequenceService) UnenrollContact(ctx context.Context, workspaceID, sequenceID, enrollmentID, actorID string) (*domain.SequenceEnrollment, error) {
+	return s.changeEnrollmentStatus(ctx, workspaceID, sequenceID, enrollmentID, actorID, "unenroll",
+		func(e *domain.SequenceEnrollment) ([]domain.EnrollmentStatus, domain.EnrollmentStatus, *time.Time) {
+			return []domain.EnrollmentStatus{domain.EnrollmentActive, domain.EnrollmentPaused}, domain.EnrollmentExited, nil
+		})
+}
+
+// changeEnrollmentStatus aplica uma transição manual; transition devolve os status de origem
+// aceitos, o status de destino e o novo nextRunAt.
+func (s *SequenceService) changeEnrollmentStatus(ctx context.Context, workspaceID, sequenceID, enrollmentID, actorID, action string, transition func(*domain.SequenceEnrollment) ([]domain.EnrollmentStatus, domain.EnrollmentStatus, *time.Time)) (*domain.SequenceEnrollment, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	current, err := s.sequenceRepo.GetEnrollment(ctx, workspaceID, sequenceID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, nextRunAt := transition(current)
+	var reason *string
+	if to != domain.EnrollmentActive {
+		r := domain.EnrollmentReasonManual
+		reason = &r
+	}
+
+	updated, err := s.sequenceRepo.SetEnrollmentStatus(ctx, workspaceID, enrollmentID, from, to, reason, nextRunAt)
+	if err != nil {
+		if errors.Is(err, repo.ErrSequenceEnrollmentNotFound) {
+			// A inscrição existe (lida acima): o status atual não aceita a transição
+			return nil, ErrInvalidEnrollmentStatus
+		}
+		return nil, err
+	}
+
+	s.logSequenceAction(ctx, workspaceID, actorID, action, "sequence_enrollment", enrollmentID)
+
+	return updated, nil
+}
+
+// ProcessDueEnrollments executa o passo atual das inscrições vencidas (até limit).
+// Chamado periodicamente pelo worker; seguro com vários workers em paralelo
+// (AdvanceEnrollment garante que cada passo é executado no máximo uma vez).
+func (s *SequenceService) ProcessDueEnrollments(ctx context.Context, now time.Time, limit int) (int, error) {
+	due, err := s.sequenceRepo.ListDueEnrollments(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for i := range due {
+		if err := s.processEnrollment(ctx, &due[i], now); err != nil {
+			s.log.Error(ctx, "failed to process sequence enrollment",
+				logger.Module("sequence"),
+				logger.Action("process"),
+				zap.String("enrollment_id", due[i].ID),
+				zap.String("workspace_id", due[i].WorkspaceID),
+				zap.Error(err),
+			)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (s *SequenceService) processEnrollment(ctx context.Context, e *domain.SequenceEnrollment, now time.Time) error {
+	sequence, err := s.sequenceRepo.Get(ctx, e.WorkspaceID, e.SequenceID)
+	if err != nil {
+		if errors.Is(err, repo.ErrSequenceNotFound) {
+			return s.exitEnrollment(ctx, e, domain.SequenceExitUnenroll, domain.EnrollmentReasonSequenceDeleted)
+		}
+		return err
+	}
+
+	contact, err := s.contactRepo.Get(ctx, e.WorkspaceID, e.ContactID)
+	if err != nil {
+		if errors.Is(err, repo.ErrContactNotFound) {
+			return s.exitEnrollment(ctx, e, domain.SequenceExitUnenroll, domain.EnrollmentReasonContactDeleted)
+		}
+		return err
+	}
+
+	// Eventos que pausam/encerram a inscrição (desde a última mudança de status)
+	if sequence.OnDealWon != domain.SequenceExitNone {
+		won, err := s.sequenceRepo.HasDealWonSince(ctx, e, e.StatusChangedAt)
+		if err != nil {
+			return err
+		}
+		if won {
+			return s.exitEnrollment(ctx, e, sequence.OnDealWon, domain.EnrollmentReasonDealWon)
+		}
+	}
+	if sequence.OnReply != domain.SequenceExitNone {
+		replied, err := s.sequenceRepo.HasReplySince(ctx, e.WorkspaceID, e.ContactID, e.StatusChangedAt)
+		if err != nil {
+			return err
+		}
+		if replied {
+			return s.exitEnrollment(ctx, e, sequence.OnReply, domain.EnrollmentReasonContactReplied)
+		}
+	}
+
+	if e.CurrentStep >= len(sequence.Steps) {
+		_, err := s.sequenceRepo.AdvanceEnrollment(ctx, e, e.CurrentStep, nil, true)
+		return err
+	}
+
+	step := sequence.Steps[e.CurrentStep]
+	// Email verificado como inválido depois da inscrição: não gera mais tarefas de email
+	if step.Type == domain.SequenceStepEmail && contact.EmailStatus != nil && *contact.EmailStatus == domain.EmailStatusInvalid {
+		return s.exitEnrollment(ctx, e, domain.SequenceExitUnenroll, domain.EnrollmentReasonEmailInvalid)
+	}
+	nextStep := e.CurrentStep + 1
+	completed := false
+	var nextRunAt *time.Time
+	if step.Type == domain.SequenceStepWait {
+		// Espera conta dias úteis e termina dentro do expediente do workspace.
+		// A conclusão (se for o último passo) acontece no tick seguinte ao fim da espera
+		cal, err := s.businessHours.GetCalendar(ctx, e.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		at := cal.NextWorkingTime(cal.AddWorkingDays(now, *step.WaitDays))
+		nextRunAt = &at
+	} else if nextStep >= len(sequence.Steps) {
+		completed = true
+	} else {
+		nextRunAt = &now
+	}
+
+	// Reserva o passo antes de materializá-lo: se outro worker já avançou, não duplica a tarefa
+	claimed, err := s.sequenceRepo.AdvanceEnrollment(ctx, e, nextStep, nextRunAt, completed)
+	if err != nil || !claimed {
+		return err
+	}
+
+	switch step.Type {
+	case domain.SequenceStepTask:
+		taskType := domain.TaskTypeFollowup
+		if step.TaskType != nil {
+			taskType = *step.TaskType
+		}
+		return s.createStepTask(ctx, e, contact, *step.Title, step.Description, taskType, now)
+	case domain.SequenceStepEmail:
+		return s.createEmailStepTask(ctx, e, contact, *step.EmailTemplateID, now)
+	}
+	return nil
+}
+
+// createEmailStepTask renderiza o template e cria uma tarefa EMAIL para o responsável enviar.
+func (s *SequenceService) createEmailStepTask(ctx context.Context, e *domain.SequenceEnrollment, contact *domain.Contact, templateID string, now time.Time) error {
+	template, err := s.templateRepo.Get(ctx, e.WorkspaceID, templateID)
+	if err != nil {
+		if errors.Is(err, repo.ErrEmailTemplateNotFound) {
+			s.log.Warn(ctx, "email template removed, skipping sequence step",
+				logger.Module("sequence"),
+				zap.String("enrollment_id", e.ID),
+				zap.String("template_id", templateID),
+			)
+			return nil
+		}
+		return err
+	}
+
+	var deal *domain.Deal
+	if e.DealID != nil {
+		deal, err = s.dealRepo.Get(ctx, e.WorkspaceID, *e.DealID)
+		if err != nil && !errors.Is(err, repo.ErrDealNotFound) {
+			return fmt.Errorf("get deal: %w", err)
+		}
+	}
+
+	rendered := template.Render(domain.MergeValues(contact, deal))
+	return s.createStepTask(ctx, e, contact, rendered.Subject, &rendered.Body, domain.TaskTypeEmail, now)
+}
+
+// createStepTask cria a tarefa do passo atribuída ao dono do contato (ou a quem inscreveu).
+func (s *SequenceService) createStepTask(ctx context.Context, e *domain.SequenceEnrollment, contact *domain.Contact, title string, description *string, taskType domain.TaskType, now time.Time) error {
+	assignee := e.EnrolledByID
+	if contact.ActorID != "" {
+		assignee = contact.ActorID
+	}
+
+	taskID, err := id.New(id.Task)
+	if err != nil {
+		return err
+	}
+
+	task := &domain.Task{
+		ID:          taskID,
+		WorkspaceID: e.WorkspaceID,
+		Title:       title,
+		Description: description,
+		Status:      domain.TaskStatusTodo,
+		Priority:    domain.PriorityMedium,
+		Type:        taskType,
+		ActorID:     e.EnrolledByID,
+		AssignedTo:  &assignee,
+		ContactID:   &e.ContactID,
+		DueDate:     &now,
+	}
+
+	maxPos, err := s.taskRepo.GetMaxPosition(ctx, e.WorkspaceID, task.Status)
+	if err != nil {
+		return fmt.Errorf("get max position: %w", err)
+	}
+	task.Position = maxPos + PositionIncrement
+
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		return fmt.Errorf("create sequence task: %w", err)
+	}
+
+	s.log.Info(ctx, "sequence step materialized",
+		logger.Module("sequence"),
+		logger.Action("process"),
+		zap.String("enrollment_id", e.ID),
+		zap.String("task_id", task.ID),
+		zap.Int("step", e.CurrentStep),
+	)
+	return nil
+}
+
+// exitEnrollment pausa ou encerra a inscrição conforme a ação configurada na sequência.
+func (s *SequenceService) exitEnrollment(ctx context.Context, e *domain.SequenceEnrollment, action domain.SequenceExitAction, reason string) error {
+	to := domain.EnrollmentExited
+	var nextRunAt *time.Time
+	if action == domain.SequenceExitPause {
+		to = domain.EnrollmentPaused
+		nextRunAt = e.NextRunAt
+	}
+
+	_, err := s.sequenceRepo.SetEnrollmentStatus(ctx, e.WorkspaceID, e.ID, []domain.EnrollmentStatus{domain.EnrollmentActive}, to, &reason, nextRunAt)
+	if err != nil && !errors.Is(err, repo.ErrSequenceEnrollmentNotFound) {
+		return err
+	}
+
+	s.log.Info(ctx, "sequence enrollment stopped",
+		logger.Module("sequence"),
+		logger.Action("process"),
+		zap.String("enrollment_id", e.ID),
+		zap.String("status", string(to)),
+		zap.String("reason", reason),
+	)
+	return nil
+}
+
+func (s *SequenceService) logSequenceAction(ctx context.Context, workspaceID, actorID, action, resource, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, resource, &idStr, nil, "", "")
+}
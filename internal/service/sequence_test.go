@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestSequenceService_Integration
+func TestSequenceService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	sequences := repo.NewSequenceRepository(pool)
+	svc := service.NewSequenceService(sequences, repo.NewEmailTemplateRepository(pool), repo.NewContactRepository(pool),
+		repo.NewDealRepository(pool), repo.NewTaskRepository(pool), repo.NewBusinessHoursRepository(pool),
+		repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool), log)
+
+	waitDays := 2
+	req := &domain.CreateSequenceRequest{
+		Name: "Onboarding",
+		Steps: []domain.SequenceStep{
+			{Type: domain.SequenceStepTask, Title: factory.Ptr("Ligar para o contato")},
+			{Type: domain.SequenceStepWait, WaitDays: &waitDays},
+			{Type: domain.SequenceStepTask, Title: factory.Ptr("Enviar proposta")},
+		},
+	}
+	require.NoError(t, req.Validate())
+
+	t.Run("viewers cannot create sequences", func(t *testing.T) {
+		_, err := svc.CreateSequence(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), req)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	sequence, err := svc.CreateSequence(ctx, f.WorkspaceID, f.UserID, req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SequenceExitUnenroll, sequence.OnDealWon)
+	assert.Equal(t, domain.SequenceExitPause, sequence.OnReply)
+
+	enroll := func(t *testing.T, contactID string) *domain.SequenceEnrollment {
+		t.Helper()
+		e, err := svc.EnrollContact(ctx, f.WorkspaceID, sequence.ID, f.UserID, &domain.EnrollContactRequest{ContactID: contactID})
+		require.NoError(t, err)
+		return e
+	}
+	enrollment := func(t *testing.T, enrollmentID string) *domain.SequenceEnrollment {
+		t.Helper()
+		e, err := sequences.GetEnrollment(ctx, f.WorkspaceID, sequence.ID, enrollmentID)
+		require.NoError(t, err)
+		return e
+	}
+	taskTitles := func(t *testing.T, contactID string) []string {
+		t.Helper()
+		rows, err := pool.Query(ctx, `SELECT title FROM public."Task" WHERE "contactId" = $1 ORDER BY "createdAt", title`, contactID)
+		require.NoError(t, err)
+		defer rows.Close()
+		titles := []string{}
+		for rows.Next() {
+			var title string
+			require.NoError(t, rows.Scan(&title))
+			titles = append(titles, title)
+		}
+		require.NoError(t, rows.Err())
+		return titles
+	}
+	// O worker é global: outros testes podem ter inscrições vencidas no mesmo banco
+	tick := func(t *testing.T, now time.Time) {
+		t.Helper()
+		_, err := svc.ProcessDueEnrollments(ctx, now, 10000)
+		require.NoError(t, err)
+	}
+
+	t.Run("steps run in order and the enrollment completes", func(t *testing.T) {
+		contact := f.Contact()
+		e := enroll(t, contact.ID)
+		assert.Equal(t, domain.EnrollmentActive, e.Status)
+
+		now := time.Now().UTC().Add(time.Minute).Truncate(time.Second)
+		tick(t, now)
+		assert.Equal(t, []string{"Ligar para o contato"}, taskTitles(t, contact.ID))
+		assert.Equal(t, 1, enrollment(t, e.ID).CurrentStep)
+
+		// Espera: próximo passo só depois de dois dias úteis
+		tick(t, now)
+		waiting := enrollment(t, e.ID)
+		assert.Equal(t, 2, waiting.CurrentStep)
+		require.NotNil(t, waiting.NextRunAt)
+		assert.False(t, waiting.NextRunAt.Before(now.Add(48*time.Hour)), "next run %s", waiting.NextRunAt)
+
+		tick(t, now)
+		assert.Len(t, taskTitles(t, contact.ID), 1)
+
+		tick(t, waiting.NextRunAt.Add(time.Second))
+		assert.Equal(t, []string{"Ligar para o contato", "Enviar proposta"}, taskTitles(t, contact.ID))
+		done := enrollment(t, e.ID)
+		assert.Equal(t, domain.EnrollmentCompleted, done.Status)
+		assert.Nil(t, done.NextRunAt)
+	})
+
+	t.Run("won deal unenrolls the contact", func(t *testing.T) {
+		contact := f.Contact()
+		e := enroll(t, contact.ID)
+		f.Deal(func(d *domain.Deal) {
+			d.ContactID = &contact.ID
+			d.Stage = domain.DealStageWon
+		})
+
+		tick(t, time.Now().UTC().Add(time.Minute))
+		exited := enrollment(t, e.ID)
+		assert.Equal(t, domain.EnrollmentExited, exited.Status)
+		require.NotNil(t, exited.StatusReason)
+		assert.Equal(t, domain.EnrollmentReasonDealWon, *exited.StatusReason)
+		assert.Empty(t, taskTitles(t, contact.ID))
+	})
+
+	t.Run("manual transitions", func(t *testing.T) {
+		e := enroll(t, f.Contact().ID)
+
+		paused, err := svc.PauseEnrollment(ctx, f.WorkspaceID, sequence.ID, e.ID, f.UserID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.EnrollmentPaused, paused.Status)
+		assert.Equal(t, domain.EnrollmentReasonManual, *paused.StatusReason)
+
+		_, err = svc.PauseEnrollment(ctx, f.WorkspaceID, sequence.ID, e.ID, f.UserID)
+		assert.ErrorIs(t, err, service.ErrInvalidEnrollmentStatus)
+
+		resumed, err := svc.ResumeEnrollment(ctx, f.WorkspaceID, sequence.ID, e.ID, f.UserID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.EnrollmentActive, resumed.Status)
+		assert.NotNil(t, resumed.NextRunAt)
+
+		exited, err := svc.UnenrollContact(ctx, f.WorkspaceID, sequence.ID, e.ID, f.UserID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.EnrollmentExited, exited.Status)
+		assert.Nil(t, exited.NextRunAt)
+
+		_, err = svc.ResumeEnrollment(ctx, f.WorkspaceID, sequence.ID, e.ID, f.UserID)
+		assert.ErrorIs(t, err, service.ErrInvalidEnrollmentStatus)
+	})
+}
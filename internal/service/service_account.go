@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrServiceAccountNotFound = repo.ErrServiceAccountNotFound
+	ErrServiceAccountConflict = repo.ErrServiceAccountConflict
+
+	// ErrServiceAccountInvalidClient client_id desconhecido, secret errado ou conta desativada.
+	// A mensagem não distingue os casos.
+	ErrServiceAccountInvalidClient = apperr.Define("INVALID_CLIENT", http.StatusUnauthorized, "service account client authentication failed", "invalid client credentials")
+	// ErrServiceAccountInvalidScope escopo pedido malformado ou além dos escopos da conta
+	ErrServiceAccountInvalidScope = apperr.Define("INVALID_SCOPE", http.StatusBadRequest, "requested scope is not granted to the service account", "requested scope is invalid or not granted to this client")
+	// ErrUnsupportedGrantType o endpoint de token só aceita client_credentials
+	ErrUnsupportedGrantType = apperr.Define("UNSUPPORTED_GRANT_TYPE", http.StatusBadRequest, "unsupported grant_type", "grant_type must be client_credentials")
+)
+
+// ServiceAccountService gerencia as service accounts do workspace e emite os tokens curtos do grant
+// client_credentials. O token carrega o escopo pedido (ScopeMiddleware) e actorType
+// service_account, que o audit log grava em actor_type: integrações deixam de usar a credencial
+// de um usuário e aparecem como atores próprios.
+type ServiceAccountService struct {
+	accountRepo   *repo.ServiceAccountRepository
+	workspaceRepo *repo.WorkspaceRepository
+	signer        *auth.HS256Signer
+	auditRepo     *repo.AuditRepo
+	tokenTTL      time.Duration
+	log           *logger.Logger
+}
+
+func NewServiceAccountService(accountRepo *repo.ServiceAccountRepository, workspaceRepo *repo.WorkspaceRepository, signer *auth.HS256Signer, auditRepo *repo.AuditRepo, tokenTTL time.Duration, log *logger.Logger) *ServiceAccountService {
+	return &ServiceAccountService{
+		accountRepo:   accountRepo,
+		workspaceRepo: workspaceRepo,
+		signer:        signer,
+		auditRepo:     auditRepo,
+		tokenTTL:      tokenTTL,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *ServiceAccountService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("service_account"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorizeAdmin service accounts entram no workspace como membros: somente quem gerencia membros.
+func (s *ServiceAccountService) authorizeAdmin(ctx context.Context, workspaceID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanManageMembers(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// =====================================================
+// Service accounts (admin do workspace)
+// =====================================================
+
+// List lista as service accounts do workspace.
+// Permission: admin only.
+func (s *ServiceAccountService) List(ctx context.Context, workspaceID, actorID string) ([]domain.ServiceAccount, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.List(ctx, workspaceID)
+}
+
+// Get retorna uma service account do workspace.
+// Permission: admin only.
+func (s *ServiceAccountService) Get(ctx context.Context, workspaceID, accountID, actorID string) (*domain.ServiceAccount, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.Get(ctx, workspaceID, accountID)
+}
+
+// Create cadastra a conta (work_user por padrão) e devolve o client secret, mostrado só agora.
+// Permission: admin only.
+func (s *ServiceAccountService) Create(ctx context.Context, workspaceID, actorID string, req *domain.CreateServiceAccountRequest) (*domain.ServiceAccountCredentials, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	serviceAccountID, err := id.New(id.ServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	sa := &domain.ServiceAccount{
+		ID:          serviceAccountID,
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		Description: req.Description,
+		Scopes:      req.Scopes,
+		Role:        domain.RoleUser,
+		Enabled:     true,
+		CreatedByID: actorID,
+	}
+	if req.Role != nil {
+		sa.Role = *req.Role
+	}
+
+	secret := generateServiceAccountSecret()
+	created, err := s.accountRepo.Create(ctx, sa, hashServiceAccountSecret(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "service_account.created", "service_account", created.ID, map[string]interface{}{
+		"name":   created.Name,
+		"role":   created.Role,
+		"scopes": created.Scopes,
+	})
+	return &domain.ServiceAccountCredentials{ServiceAccount: *created, ClientID: created.ID, ClientSecret: secret}, nil
+}
+
+// Update altera nome, descrição, escopos, papel ou enabled. Desativar tira a conta do workspace.
+// Permission: admin only.
+func (s *ServiceAccountService) Update(ctx context.Context, workspaceID, accountID, actorID string, req *domain.UpdateServiceAccountRequest) (*domain.ServiceAccount, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	sa, err := s.accountRepo.Get(ctx, workspaceID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(sa)
+
+	updated, err := s.accountRepo.Update(ctx, sa)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "service_account.updated", "service_account", accountID, map[string]interface{}{
+		"enabled": updated.Enabled,
+		"role":    updated.Role,
+		"scopes":  updated.Scopes,
+	})
+	return updated, nil
+}
+
+// RotateSecret gera um novo client secret; o anterior deixa de valer (tokens emitidos valem até expirar).
+// Permission: admin only.
+func (s *ServiceAccountService) RotateSecret(ctx context.Context, workspaceID, accountID, actorID string) (*domain.ServiceAccountCredentials, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	secret := generateServiceAccountSecret()
+	sa, err := s.accountRepo.RotateSecret(ctx, workspaceID, accountID, hashServiceAccountSecret(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "service_account.secret_rotated", "service_account", accountID, nil)
+	return &domain.ServiceAccountCredentials{ServiceAccount: *sa, ClientID: sa.ID, ClientSecret: secret}, nil
+}
+
+// Delete remove a conta e o vínculo com o workspace.
+// Permission: admin only.
+func (s *ServiceAccountService) Delete(ctx context.Context, workspaceID, accountID, actorID string) error {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return err
+	}
+	if err := s.accountRepo.Delete(ctx, workspaceID, accountID); err != nil {
+		return err
+	}
+	s.logAction(ctx, workspaceID, actorID, "service_account.deleted", "service_account", accountID, nil)
+	return nil
+}
+
+// =====================================================
+// Token (pública, grant client_credentials)
+// =====================================================
+
+// IssueToken autentica client_id/client_secret e emite um JWT do workspace da conta com o escopo
+// pedido (vazio = todos os escopos da conta). O papel continua vindo de workspace_members a cada
+// request, então desativar ou remover a conta corta os tokens já emitidos.
+func (s *ServiceAccountService) IssueToken(ctx context.Context, req *domain.ServiceAccountTokenRequest) (*domain.ServiceAccountToken, error) {
+	if req.GrantType != domain.GrantTypeClientCredentials {
+		return nil, ErrUnsupportedGrantType
+	}
+	if req.ClientID == "" || req.ClientSecret == "" {
+		s.rejectClient(ctx, req.ClientID, "missing_credentials")
+		return nil, ErrServiceAccountInvalidClient
+	}
+
+	sa, secretHash, err := s.accountRepo.GetCredentials(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, ErrServiceAccountNotFound) {
+			s.rejectClient(ctx, req.ClientID, "unknown_client")
+			return nil, ErrServiceAccountInvalidClient
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(hashServiceAccountSecret(req.ClientSecret), secretHash) != 1 {
+		s.rejectClient(ctx, req.ClientID, "invalid_secret")
+		return nil, ErrServiceAccountInvalidClient
+	}
+	if !sa.Enabled {
+		s.rejectClient(ctx, req.ClientID, "disabled")
+		return nil, ErrServiceAccountInvalidClient
+	}
+
+	scopes := sa.Scopes
+	if requested := domain.ParseScope(req.Scope); len(requested) > 0 {
+		if domain.ValidateScopes(requested) != nil || !domain.ScopesCover(sa.Scopes, requested) {
+			return nil, ErrServiceAccountInvalidScope
+		}
+		scopes = requested
+	}
+	scope := strings.Join(scopes, " ")
+
+	token, _, err := s.signer.Sign(&auth.CustomClaims{
+		WorkspaceID: sa.WorkspaceID,
+		ActorID:     sa.ID,
+		Role:        sa.Role.String(),
+		Scope:       scope,
+		ActorType:   auth.ActorTypeServiceAccount,
+	}, s.tokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign service account token: %w", err)
+	}
+
+	if err := s.accountRepo.MarkTokenIssued(ctx, sa.ID); err != nil {
+		s.log.Warn(ctx, "failed to record service account token issuance",
+			logger.Module("service_account"),
+			logger.Action("token"),
+			zap.String("service_account_id", sa.ID),
+			zap.Error(err),
+		)
+	}
+	s.logAction(auth.WithServiceAccount(ctx, sa.WorkspaceID, sa.ID), sa.WorkspaceID, sa.ID, "service_account.token_issued", "service_account", sa.ID, map[string]interface{}{
+		"scope": scope,
+	})
+
+	return &domain.ServiceAccountToken{
+		AccessToken: token,
+		TokenType:   domain.ServiceAccountTokenType,
+		ExpiresIn:   int(s.tokenTTL.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+// rejectClient registra o motivo real da recusa; o cliente recebe sempre invalid_client
+func (s *ServiceAccountService) rejectClient(ctx context.Context, clientID, reason string) {
+	s.log.Warn(ctx, "service account authentication failed",
+		logger.Module("service_account"),
+		logger.Action("token"),
+		zap.String("client_id", clientID),
+		zap.String("reason", reason),
+	)
+}
+
+// generateServiceAccountSecret client secret (256 bits, base64url)
+func generateServiceAccountSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hashServiceAccountSecret(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+func (s *ServiceAccountService) logAction(ctx context.Context, workspaceID, actorID, action, entity, id string, metadata map[string]interface{}) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, entity, &idStr, metadata, "", "")
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"go.uber.org/zap"
+)
+
+// rateLimitWindowSeconds janela usada pelo RateLimitMiddleware
+const rateLimitWindowSeconds = 60
+
+// RateLimitUsage lê o consumo da janela de rate limit sem consumir a cota.
+// Implemented by ratelimit.RedisRateLimiter.
+type RateLimitUsage interface {
+	Usage(ctx context.Context, workspaceID string, windowSeconds int) (int, error)
+}
+
+// SessionService resolve o contexto de autenticação em um perfil completo (GET /v1/me).
+type SessionService struct {
+	sessionRepo *repo.SessionRepository
+	usage       RateLimitUsage
+	limitPerMin atomic.Int64 // RATE_LIMIT_PER_WORKSPACE_PER_MIN, recarregável
+	log         *logger.Logger
+}
+
+// NewSessionService usage pode ser nil (rateLimit fica fora da resposta).
+func NewSessionService(sessionRepo *repo.SessionRepository, usage RateLimitUsage, limitPerMin int, log *logger.Logger) *SessionService {
+	s := &SessionService{
+		sessionRepo: sessionRepo,
+		usage:       usage,
+		log:         log,
+	}
+	s.limitPerMin.Store(int64(limitPerMin))
+	return s
+}
+
+// SetLimitPerMin aplica um novo RATE_LIMIT_PER_WORKSPACE_PER_MIN (reload de configuração)
+func (s *SessionService) SetLimitPerMin(limitPerMin int) {
+	s.limitPerMin.Store(int64(limitPerMin))
+}
+
+// GetSession monta o perfil da credencial atual. claims é nil em S2S.
+// Permission: qualquer credencial válida.
+func (s *SessionService) GetSession(ctx context.Context, authCtx *auth.AuthContext, claims *auth.CustomClaims) (*domain.Session, error) {
+	session := &domain.Session{
+		Actor:      domain.SessionActor{ID: authCtx.ActorID, Type: authCtx.ActorType},
+		AuthMethod: authCtx.AuthMethod,
+		Token:      sessionToken(authCtx, claims),
+	}
+	if authCtx.ImpersonatorID != "" {
+		session.ImpersonatorID = &authCtx.ImpersonatorID
+	}
+	if authCtx.OrgID != "" {
+		session.OrgID = &authCtx.OrgID
+	}
+
+	if authCtx.ActorID != "" {
+		actor, err := s.sessionRepo.GetActor(ctx, authCtx.ActorID)
+		switch {
+		case err == nil:
+			actor.Type = authCtx.ActorType
+			session.Actor = *actor
+		case !errors.Is(err, repo.ErrSessionUserNotFound):
+			return nil, err
+		}
+	}
+
+	granted := authCtx.Workspaces
+	if len(granted) == 0 && authCtx.WorkspaceID != "" {
+		granted = []string{authCtx.WorkspaceID}
+	}
+
+	workspaces, err := s.sessionRepo.ListWorkspaces(ctx, authCtx.ActorID, granted)
+	if err != nil {
+		return nil, err
+	}
+	// Workspaces da credencial que não existem continuam na resposta: é o que o S2S precisa conferir
+	listed := make(map[string]bool, len(workspaces))
+	for _, ws := range workspaces {
+		listed[ws.WorkspaceID] = true
+	}
+	for _, id := range granted {
+		if !listed[id] {
+			workspaces = append(workspaces, domain.SessionWorkspace{WorkspaceID: id})
+		}
+	}
+
+	for i := range workspaces {
+		ws := &workspaces[i]
+		ws.Permissions = []string{}
+		if ws.Role != nil {
+			ws.Permissions = domain.PermissionsForRole(*ws.Role)
+		}
+		ws.GrantedByToken = authCtx.CanAccessWorkspace(ws.WorkspaceID)
+		if ws.GrantedByToken {
+			ws.RateLimit = s.rateLimitStatus(ctx, ws.WorkspaceID)
+		}
+	}
+	session.Workspaces = workspaces
+
+	return session, nil
+}
+
+func sessionToken(authCtx *auth.AuthContext, claims *auth.CustomClaims) domain.SessionToken {
+	var token domain.SessionToken
+	if authCtx.Client != "" {
+		token.Client = &authCtx.Client
+	}
+	if claims == nil {
+		return token
+	}
+	if claims.Issuer != "" {
+		token.Issuer = &claims.Issuer
+	}
+	if claims.IssuedAt != nil {
+		issuedAt := claims.IssuedAt.UTC()
+		token.IssuedAt = &issuedAt
+	}
+	if claims.ExpiresAt != nil {
+		expiresAt := claims.ExpiresAt.UTC()
+		expiresIn := int64(time.Until(expiresAt).Seconds())
+		if expiresIn < 0 {
+			expiresIn = 0
+		}
+		token.ExpiresAt = &expiresAt
+		token.ExpiresInSeconds = &expiresIn
+	}
+	return token
+}
+
+// rateLimitStatus falha aberto como o RateLimitMiddleware: sem Redis o campo fica fora.
+func (s *SessionService) rateLimitStatus(ctx context.Context, workspaceID string) *domain.SessionRateLimit {
+	if s.usage == nil {
+		return nil
+	}
+	used, err := s.usage.Usage(ctx, workspaceID, rateLimitWindowSeconds)
+	if err != nil {
+		s.log.Warn(ctx, "failed to read rate limit usage",
+			logger.Module("session"),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	limit := int(s.limitPerMin.Load())
+	remaining := limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &domain.SessionRateLimit{
+		Limit:         limit,
+		Remaining:     remaining,
+		WindowSeconds: rateLimitWindowSeconds,
+		ResetAt:       time.Now().UTC().Add(rateLimitWindowSeconds * time.Second).Truncate(time.Second),
+	}
+}
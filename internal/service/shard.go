@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+
+	"go.uber.org/zap"
+)
+
+// Erros do comando shard (não chegam à API)
+var (
+	ErrUnknownShard          = errors.New("shard is not configured in DATABASE_SHARDS")
+	ErrWorkspaceAlreadyThere = errors.New("workspace data is already in this shard")
+)
+
+// ShardService resolve o shard de dados de cada workspace (ShardMiddleware) e move workspaces
+// entre shards (comando shard). O mapa tem só os workspaces fora do banco padrão: cada instância
+// guarda o mapa inteiro por ttl, então uma troca feita em outra instância vale em até ttl.
+// Implements middleware.ShardResolver.
+type ShardService struct {
+	shardRepo *repo.ShardRepository
+	router    *database.ShardRouter
+	ttl       time.Duration
+	now       func() time.Time
+	log       *logger.Logger
+
+	mu        sync.Mutex
+	shards    map[string]string
+	expiresAt time.Time
+}
+
+// NewShardService ttl <= 0 consulta o banco em toda request.
+func NewShardService(shardRepo *repo.ShardRepository, router *database.ShardRouter, ttl time.Duration, log *logger.Logger) *ShardService {
+	return &ShardService{
+		shardRepo: shardRepo,
+		router:    router,
+		ttl:       ttl,
+		now:       time.Now,
+		log:       log,
+	}
+}
+
+// ShardFor retorna o shard do workspace (database.DefaultShard sem entrada no mapa). Erros do
+// banco não são cacheados.
+func (s *ShardService) ShardFor(ctx context.Context, workspaceID string) (string, error) {
+	shards, err := s.shardMap(ctx)
+	if err != nil {
+		return "", err
+	}
+	if shard, ok := shards[workspaceID]; ok {
+		return shard, nil
+	}
+	return database.DefaultShard, nil
+}
+
+// WithWorkspaceShard grava no ctx o shard do workspace, para fluxos sem ShardMiddleware que
+// descobrem o workspace no meio do caminho (ex.: form token). Receiver nil (sem shards) não muda ctx.
+func (s *ShardService) WithWorkspaceShard(ctx context.Context, workspaceID string) (context.Context, error) {
+	if s == nil {
+		return ctx, nil
+	}
+	shard, err := s.ShardFor(ctx, workspaceID)
+	if err != nil {
+		return ctx, err
+	}
+	return database.WithShard(ctx, shard), nil
+}
+
+// List retorna o mapa de shards, sem cache.
+func (s *ShardService) List(ctx context.Context) ([]domain.WorkspaceShard, error) {
+	return s.shardRepo.List(ctx)
+}
+
+// ShardMigrationOptions controla Migrate.
+type ShardMigrationOptions struct {
+	// Maintenance liga e desliga a manutenção do workspace em volta da cópia (nil = o chamador cuida)
+	Maintenance *MaintenanceService
+	// Settle espera depois de ligar a manutenção e depois de trocar o shard, para todas as
+	// instâncias verem a mudança (TTLs dos caches de manutenção e do mapa de shards)
+	MaintenanceSettle time.Duration
+	ShardMapSettle    time.Duration
+	// KeepSource mantém a cópia na origem; workers apontados para ela continuariam processando
+	// os dados antigos, então o padrão é removê-la
+	KeepSource bool
+}
+
+// Migrate move o workspace para o shard to: escritas bloqueadas pela manutenção, cópia, conferência
+// das contagens, troca no mapa e remoção dos dados da origem. Uma falha antes da troca deixa o
+// workspace na origem (a cópia parcial é descartada na próxima tentativa).
+func (s *ShardService) Migrate(ctx context.Context, workspaceID, to string, opts ShardMigrationOptions) (*domain.ShardMigration, error) {
+	toDB, ok := s.router.Shard(to)
+	if !ok {
+		return nil, ErrUnknownShard
+	}
+	from, err := s.shardRepo.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, ErrWorkspaceAlreadyThere
+	}
+	fromDB, ok := s.router.Shard(from)
+	if !ok {
+		return nil, fmt.Errorf("%w: workspace %s is mapped to %q", ErrUnknownShard, workspaceID, from)
+	}
+	control, _ := s.router.Shard(database.DefaultShard)
+	copier := repo.NewShardCopier(control, fromDB, toDB, to)
+
+	fields := []zap.Field{zap.String("workspace_id", workspaceID), zap.String("from", from), zap.String("to", to)}
+
+	if opts.Maintenance != nil {
+		message := "Workspace data is being moved to another region"
+		if _, err := opts.Maintenance.Enable(ctx, workspaceID, &domain.SetMaintenanceRequest{Message: &message}); err != nil {
+			return nil, fmt.Errorf("enable maintenance: %w", err)
+		}
+		defer func() {
+			if err := opts.Maintenance.Disable(context.WithoutCancel(ctx), workspaceID); err != nil && !errors.Is(err, ErrMaintenanceNotFound) {
+				s.log.Error(ctx, "failed to disable maintenance after shard migration", append(fields, zap.Error(err))...)
+			}
+		}()
+		s.sleep(ctx, opts.MaintenanceSettle)
+	}
+
+	s.log.Info(ctx, "copying workspace data", fields...)
+	copied, err := copier.Copy(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := copier.Verify(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+
+	if err := s.shardRepo.Set(ctx, workspaceID, to); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	s.log.Warn(ctx, "workspace shard switched", fields...)
+	s.sleep(ctx, opts.ShardMapSettle)
+
+	result := &domain.ShardMigration{WorkspaceID: workspaceID, From: from, To: to, Copied: copied, Purged: map[string]int64{}}
+	if !opts.KeepSource {
+		purged, err := copier.PurgeSource(ctx, workspaceID)
+		if err != nil {
+			return result, fmt.Errorf("workspace moved, but purging the source failed: %w", err)
+		}
+		result.Purged = purged
+	}
+	return result, nil
+}
+
+// SyncAnchors atualiza no shard do workspace as linhas do plano de controle (workspace, membros,
+// usuários...) usadas pelas FKs. Membros novos só podem ser referenciados nos dados depois dela.
+func (s *ShardService) SyncAnchors(ctx context.Context, workspaceID string) (map[string]int64, error) {
+	shard, err := s.shardRepo.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	db, ok := s.router.Shard(shard)
+	if !ok {
+		return nil, fmt.Errorf("%w: workspace %s is mapped to %q", ErrUnknownShard, workspaceID, shard)
+	}
+	control, _ := s.router.Shard(database.DefaultShard)
+	return repo.NewShardCopier(control, db, db, shard).SyncAnchors(ctx, workspaceID)
+}
+
+// sleep espera d ou o cancelamento do ctx
+func (s *ShardService) sleep(ctx context.Context, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// shardMap mapa em cache; a consulta roda fora do lock
+func (s *ShardService) shardMap(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	if s.shards != nil && s.now().Before(s.expiresAt) {
+		shards := s.shards
+		s.mu.Unlock()
+		return shards, nil
+	}
+	s.mu.Unlock()
+
+	list, err := s.shardRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[string]string, len(list))
+	for _, ws := range list {
+		shards[ws.WorkspaceID] = ws.Shard
+	}
+
+	s.mu.Lock()
+	s.shards, s.expiresAt = shards, s.now().Add(s.ttl)
+	s.mu.Unlock()
+	return shards, nil
+}
+
+// invalidate faz a próxima request desta instância reler o mapa
+func (s *ShardService) invalidate() {
+	s.mu.Lock()
+	s.shards = nil
+	s.mu.Unlock()
+}
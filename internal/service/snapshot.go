@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/objectstore"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/snapshot"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// SnapshotStaleAfter tempo após o qual um job RUNNING é considerado abandonado
+// (worker caiu no meio) e volta a ser processado.
+const SnapshotStaleAfter = time.Hour
+
+var (
+	ErrSnapshotNotFound         = repo.ErrSnapshotNotFound
+	ErrSnapshotJobActive        = repo.ErrSnapshotJobActive
+	ErrSnapshotNotDownloadable  = apperr.Unprocessable(apperr.CodeInvalidStatus, "only completed exports can be downloaded", "")
+	ErrSnapshotNotRestorable    = apperr.Unprocessable(apperr.CodeInvalidStatus, "only completed exports can be restored", "")
+	ErrSnapshotInvalidObjectKey = apperr.Unprocessable(apperr.CodeValidationError, "objectKey must be under imports/{workspaceId}/", "")
+	ErrSnapshotArchiveNotFound  = apperr.Unprocessable(apperr.CodeValidationError, "snapshot archive not found in object storage", "")
+)
+
+// SnapshotService gerencia snapshots do workspace: exportação completa para o object
+// storage e restauração a partir de um arquivo. Os jobs são assíncronos; o snapshot-worker
+// os executa via ProcessNext.
+type SnapshotService struct {
+	snapshotRepo  *repo.SnapshotRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	store         objectstore.Store
+	counters      *CounterService
+	log           *logger.Logger
+}
+
+func NewSnapshotService(snapshotRepo *repo.SnapshotRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, store objectstore.Store, counters *CounterService, log *logger.Logger) *SnapshotService {
+	return &SnapshotService{
+		snapshotRepo:  snapshotRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		store:         store,
+		counters:      counters,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *SnapshotService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("snapshot"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// requireAdmin snapshots expõem e sobrescrevem todos os dados do workspace.
+func (s *SnapshotService) requireAdmin(ctx context.Context, workspaceID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanManageWorkspace(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateSnapshot enfileira a exportação completa do workspace.
+// Permission: admin only.
+func (s *SnapshotService) CreateSnapshot(ctx context.Context, workspaceID, actorID string) (*domain.WorkspaceSnapshot, error) {
+	if err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	snapshotID, err := id.New(id.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.snapshotRepo.Create(ctx, &domain.WorkspaceSnapshot{
+		ID:            snapshotID,
+		WorkspaceID:   workspaceID,
+		Kind:          domain.SnapshotKindExport,
+		ObjectKey:     domain.SnapshotObjectKey(workspaceID, snapshotID),
+		RequestedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logSnapshotAction(ctx, workspaceID, actorID, "snapshot_export", created.ID)
+	return created, nil
+}
+
+// RestoreSnapshot enfileira a restauração de uma exportação concluída do workspace
+// ou de um arquivo importado em imports/{workspaceId}/.
+// Permission: admin only.
+func (s *SnapshotService) RestoreSnapshot(ctx context.Context, workspaceID, actorID string, req *domain.RestoreSnapshotRequest) (*domain.WorkspaceSnapshot, error) {
+	if err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	var objectKey string
+	if req.SnapshotID != nil {
+		source, err := s.snapshotRepo.Get(ctx, workspaceID, *req.SnapshotID)
+		if err != nil {
+			return nil, err
+		}
+		if !source.Downloadable() {
+			return nil, ErrSnapshotNotRestorable
+		}
+		objectKey = source.ObjectKey
+	} else {
+		objectKey = *req.ObjectKey
+		if !strings.HasPrefix(objectKey, domain.SnapshotImportPrefix+workspaceID+"/") || !objectstore.ValidKey(objectKey) {
+			return nil, ErrSnapshotInvalidObjectKey
+		}
+	}
+
+	exists, err := s.store.Exists(ctx, objectKey)
+	if err != nil {
+		return nil, fmt.Errorf("check snapshot archive: %w", err)
+	}
+	if !exists {
+		return nil, ErrSnapshotArchiveNotFound
+	}
+
+	snapshotID, err := id.New(id.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.snapshotRepo.Create(ctx, &domain.WorkspaceSnapshot{
+		ID:            snapshotID,
+		WorkspaceID:   workspaceID,
+		Kind:          domain.SnapshotKindRestore,
+		ObjectKey:     objectKey,
+		RequestedByID: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logSnapshotAction(ctx, workspaceID, actorID, "snapshot_restore", created.ID)
+	return created, nil
+}
+
+// GetSnapshot retrieves a snapshot job.
+// Permission: admin only.
+func (s *SnapshotService) GetSnapshot(ctx context.Context, workspaceID, snapshotID, actorID string) (*domain.WorkspaceSnapshot, error) {
+	if err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.snapshotRepo.Get(ctx, workspaceID, snapshotID)
+}
+
+// ListSnapshots lists snapshot jobs, most recent first.
+// Permission: admin only.
+func (s *SnapshotService) ListSnapshots(ctx context.Context, workspaceID, actorID string, params domain.ListSnapshotsParams) ([]domain.WorkspaceSnapshot, error) {
+	if err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	params.WorkspaceID = workspaceID
+	params.Normalize()
+
+	return s.snapshotRepo.List(ctx, params)
+}
+
+// OpenDownload abre o arquivo de uma exportação concluída; o chamador fecha o reader.
+// Permission: admin only.
+func (s *SnapshotService) OpenDownload(ctx context.Context, workspaceID, snapshotID, actorID string) (*domain.WorkspaceSnapshot, io.ReadCloser, error) {
+	snap, err := s.GetSnapshot(ctx, workspaceID, snapshotID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !snap.Downloadable() {
+		return nil, nil, ErrSnapshotNotDownloadable
+	}
+
+	body, err := s.store.Get(ctx, snap.ObjectKey)
+	if err != nil {
+		if errors.Is(err, objectstore.ErrNotFound) {
+			return nil, nil, ErrSnapshotArchiveNotFound
+		}
+		return nil, nil, fmt.Errorf("open snapshot archive: %w", err)
+	}
+
+	s.logSnapshotAction(ctx, workspaceID, actorID, "snapshot_download", snap.ID)
+	return snap, body, nil
+}
+
+// ProcessNext executa o próximo job da fila. Retorna false quando não havia job.
+// Falhas do job ficam registradas nele (status FAILED); o erro retornado é só de infraestrutura.
+func (s *SnapshotService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.snapshotRepo.ClaimNext(ctx, time.Now().UTC().Add(-SnapshotStaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	var sizeBytes *int64
+	var counts map[string]int64
+	switch job.Kind {
+	case domain.SnapshotKindExport:
+		sizeBytes, counts, err = s.runExport(ctx, job)
+	case domain.SnapshotKindRestore:
+		counts, err = s.runRestore(ctx, job)
+	default:
+		err = fmt.Errorf("unknown snapshot kind %q", job.Kind)
+	}
+
+	fields := []zap.Field{
+		logger.Module("snapshot"),
+		logger.Action(strings.ToLower(string(job.Kind))),
+		zap.String("snapshot_id", job.ID),
+		zap.String("workspace_id", job.WorkspaceID),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		s.log.Error(ctx, "snapshot job failed", append(fields, zap.Error(err))...)
+		return true, s.snapshotRepo.Fail(ctx, job.ID, err.Error())
+	}
+
+	s.log.Info(ctx, "snapshot job completed", fields...)
+	return true, s.snapshotRepo.Complete(ctx, job.ID, sizeBytes, counts)
+}
+
+// runExport lê o workspace para arquivos temporários e envia o tar.gz ao object storage.
+func (s *SnapshotService) runExport(ctx context.Context, job *domain.WorkspaceSnapshot) (*int64, map[string]int64, error) {
+	w, err := snapshot.NewWriter()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer w.Close()
+
+	for _, entity := range repo.SnapshotEntityNames() {
+		if err := w.Begin(entity); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := s.snapshotRepo.ExportWorkspace(ctx, job.WorkspaceID, w.Add); err != nil {
+		return nil, nil, err
+	}
+
+	pr, pw := io.Pipe()
+	manifestCh := make(chan *domain.SnapshotManifest, 1)
+	go func() {
+		manifest, err := w.WriteArchive(pw, job.WorkspaceID, time.Now().UTC())
+		manifestCh <- manifest
+		pw.CloseWithError(err)
+	}()
+
+	size, err := s.store.Put(ctx, job.ObjectKey, pr)
+	pr.CloseWithError(err) // desbloqueia o writer se o upload falhou
+	manifest := <-manifestCh
+	if err != nil {
+		return nil, nil, fmt.Errorf("upload snapshot archive: %w", err)
+	}
+	return &size, manifest.Entities, nil
+}
+
+// runRestore importa o arquivo em uma única transação: ou tudo entra, ou nada.
+func (s *SnapshotService) runRestore(ctx context.Context, job *domain.WorkspaceSnapshot) (map[string]int64, error) {
+	body, err := s.store.Get(ctx, job.ObjectKey)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot archive: %w", err)
+	}
+	defer body.Close()
+
+	restore, err := s.snapshotRepo.BeginRestore(ctx, job.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer restore.Rollback(ctx)
+
+	_, err = snapshot.ReadArchive(body, func(entity string, row []byte) error {
+		return restore.Insert(ctx, entity, row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := restore.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	s.counters.Invalidate(ctx, job.WorkspaceID)
+	return restore.Inserted(), nil
+}
+
+func (s *SnapshotService) logSnapshotAction(ctx context.Context, workspaceID, actorID, action, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "workspace_snapshot", &idStr, nil, "", "")
+}
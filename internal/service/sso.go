@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/auth"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSsoProviderNotFound = repo.ErrSsoProviderNotFound
+	ErrSsoProviderConflict = repo.ErrSsoProviderConflict
+	ErrSsoTokenInvalid     = repo.ErrSsoTokenInvalid
+
+	// ErrSsoEmailNotVerified o id_token não traz email, ou o IdP marcou o email como não verificado
+	ErrSsoEmailNotVerified = apperr.Forbidden("sso id_token has no verified email", "the identity provider did not assert a verified email")
+	// ErrSsoDomainNotAllowed domínio do email fora de allowedDomains
+	ErrSsoDomainNotAllowed = apperr.Forbidden("sso email domain is not allowed by the provider", "email domain not allowed for this workspace")
+	// ErrSsoNotMember o usuário não é membro e o IdP não faz provisionamento JIT
+	ErrSsoNotMember = apperr.Forbidden("sso user is not a workspace member and jit provisioning is off", "user is not a member of this workspace")
+)
+
+// SsoService troca id_tokens OIDC dos IdPs configurados no workspace por JWTs de sessão do
+// Linkko, sem passar pelo backend do CRM web. O primeiro login vincula o sub do IdP ao usuário
+// (pelo email) e, com JIT, o adiciona ao workspace com o defaultRole do IdP.
+type SsoService struct {
+	ssoRepo       *repo.SsoRepository
+	workspaceRepo *repo.WorkspaceRepository
+	verifier      *auth.OIDCVerifier
+	signer        *auth.HS256Signer
+	billing       *BillingService
+	auditRepo     *repo.AuditRepo
+	sessionTTL    time.Duration
+	log           *logger.Logger
+}
+
+// NewSsoService cria o service. billing nil desliga a checagem da quota de membros no JIT.
+func NewSsoService(ssoRepo *repo.SsoRepository, workspaceRepo *repo.WorkspaceRepository, verifier *auth.OIDCVerifier, signer *auth.HS256Signer, billing *BillingService, auditRepo *repo.AuditRepo, sessionTTL time.Duration, log *logger.Logger) *SsoService {
+	return &SsoService{
+		ssoRepo:       ssoRepo,
+		workspaceRepo: workspaceRepo,
+		verifier:      verifier,
+		signer:        signer,
+		billing:       billing,
+		auditRepo:     auditRepo,
+		sessionTTL:    sessionTTL,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *SsoService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("sso"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorizeAdmin os IdPs decidem quem entra no workspace: somente quem gerencia membros.
+func (s *SsoService) authorizeAdmin(ctx context.Context, workspaceID, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanManageMembers(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// =====================================================
+// IdPs (admin do workspace)
+// =====================================================
+
+// ListProviders lista os IdPs do workspace.
+// Permission: admin only.
+func (s *SsoService) ListProviders(ctx context.Context, workspaceID, actorID string) ([]domain.SsoProvider, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.ssoRepo.ListProviders(ctx, workspaceID)
+}
+
+// GetProvider retorna um IdP do workspace.
+// Permission: admin only.
+func (s *SsoService) GetProvider(ctx context.Context, workspaceID, providerID, actorID string) (*domain.SsoProvider, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.ssoRepo.GetProvider(ctx, workspaceID, providerID)
+}
+
+// CreateProvider cadastra um IdP (JIT ligado e work_user por padrão).
+// Permission: admin only.
+func (s *SsoService) CreateProvider(ctx context.Context, workspaceID, actorID string, req *domain.CreateSsoProviderRequest) (*domain.SsoProvider, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	ssoProviderID, err := id.New(id.SsoProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &domain.SsoProvider{
+		ID:              ssoProviderID,
+		WorkspaceID:     workspaceID,
+		Name:            req.Name,
+		Issuer:          req.Issuer,
+		ClientID:        req.ClientID,
+		AllowedDomains:  req.AllowedDomains,
+		DefaultRole:     domain.RoleUser,
+		JITProvisioning: true,
+		Enabled:         true,
+		CreatedByID:     actorID,
+	}
+	if p.AllowedDomains == nil {
+		p.AllowedDomains = []string{}
+	}
+	if req.DefaultRole != nil {
+		p.DefaultRole = *req.DefaultRole
+	}
+	if req.JITProvisioning != nil {
+		p.JITProvisioning = *req.JITProvisioning
+	}
+	if req.Enabled != nil {
+		p.Enabled = *req.Enabled
+	}
+
+	created, err := s.ssoRepo.CreateProvider(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "sso.provider_created", "sso_provider", created.ID, map[string]interface{}{
+		"issuer":          created.Issuer,
+		"defaultRole":     created.DefaultRole,
+		"jitProvisioning": created.JITProvisioning,
+	})
+	return created, nil
+}
+
+// UpdateProvider altera um IdP.
+// Permission: admin only.
+func (s *SsoService) UpdateProvider(ctx context.Context, workspaceID, providerID, actorID string, req *domain.UpdateSsoProviderRequest) (*domain.SsoProvider, error) {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	p, err := s.ssoRepo.GetProvider(ctx, workspaceID, providerID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(p)
+	if p.AllowedDomains == nil {
+		p.AllowedDomains = []string{}
+	}
+
+	updated, err := s.ssoRepo.UpdateProvider(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "sso.provider_updated", "sso_provider", providerID, map[string]interface{}{
+		"enabled":         updated.Enabled,
+		"defaultRole":     updated.DefaultRole,
+		"jitProvisioning": updated.JITProvisioning,
+	})
+	return updated, nil
+}
+
+// DeleteProvider remove o IdP. Sessões já emitidas valem até expirar; membros continuam no workspace.
+// Permission: admin only.
+func (s *SsoService) DeleteProvider(ctx context.Context, workspaceID, providerID, actorID string) error {
+	if err := s.authorizeAdmin(ctx, workspaceID, actorID); err != nil {
+		return err
+	}
+	if err := s.ssoRepo.DeleteProvider(ctx, workspaceID, providerID); err != nil {
+		return err
+	}
+	s.logAction(ctx, workspaceID, actorID, "sso.provider_deleted", "sso_provider", providerID, nil)
+	return nil
+}
+
+// =====================================================
+// Troca do id_token (pública)
+// =====================================================
+
+// Exchange valida o id_token contra o IdP do workspace com o mesmo issuer e emite o JWT de
+// sessão com o workspace e o papel do usuário. Quem ainda não é membro entra com o defaultRole
+// do IdP quando o JIT está ligado (respeitando a quota de membros do plano).
+func (s *SsoService) Exchange(ctx context.Context, req *domain.SsoExchangeRequest) (*domain.SsoSession, error) {
+	issuer, err := auth.UnverifiedIssuer(req.IDToken)
+	if err != nil {
+		s.rejectLogin(ctx, req.WorkspaceID, "", err)
+		return nil, ErrSsoTokenInvalid
+	}
+	provider, err := s.ssoRepo.GetEnabledProviderByIssuer(ctx, req.WorkspaceID, issuer)
+	if err != nil {
+		if errors.Is(err, ErrSsoTokenInvalid) {
+			s.rejectLogin(ctx, req.WorkspaceID, issuer, err)
+		}
+		return nil, err
+	}
+
+	nonce := ""
+	if req.Nonce != nil {
+		nonce = *req.Nonce
+	}
+	identity, err := s.verifier.Verify(ctx, provider.Issuer, provider.ClientID, req.IDToken, nonce)
+	if err != nil {
+		s.rejectLogin(ctx, req.WorkspaceID, issuer, err)
+		return nil, ErrSsoTokenInvalid
+	}
+
+	if identity.Email == "" || (identity.EmailVerified != nil && !*identity.EmailVerified) {
+		return nil, ErrSsoEmailNotVerified
+	}
+	if !provider.AllowsEmail(identity.Email) {
+		return nil, ErrSsoDomainNotAllowed
+	}
+
+	// Sem vínculo prévio, o email só identifica membros do workspace ou usuários de um domínio
+	// listado no IdP; fora disso o login cria um usuário novo
+	userID, err := s.ssoRepo.FindLinkedUser(ctx, provider, identity.Subject, identity.Email, provider.OwnsEmailDomain(identity.Email))
+	if err != nil {
+		return nil, err
+	}
+	addMember := false
+	if userID == "" {
+		addMember = true
+	} else if _, err := s.workspaceRepo.GetMemberRole(ctx, userID, req.WorkspaceID); err != nil {
+		if !errors.Is(err, repo.ErrMemberNotFound) {
+			return nil, fmt.Errorf("get member role: %w", err)
+		}
+		addMember = true
+	}
+	if addMember {
+		if !provider.JITProvisioning {
+			return nil, ErrSsoNotMember
+		}
+		if err := s.checkMemberQuota(ctx, req.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	login := &domain.SsoLogin{Subject: identity.Subject, Email: identity.Email}
+	if identity.Name != "" {
+		login.Name = &identity.Name
+	}
+	// ID usado só se o login provisionar um usuário novo
+	newUserID, err := id.New(id.User)
+	if err != nil {
+		return nil, err
+	}
+	userID, role, provisioned, err := s.ssoRepo.RecordLogin(ctx, provider, userID, newUserID, login, addMember)
+	if err != nil {
+		return nil, err
+	}
+
+	token, expiresAt, err := s.signer.Sign(&auth.CustomClaims{
+		WorkspaceID: req.WorkspaceID,
+		ActorID:     userID,
+		Role:        role.String(),
+	}, s.sessionTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign sso session token: %w", err)
+	}
+
+	action := "sso.login"
+	if provisioned {
+		action = "sso.member_provisioned"
+	}
+	s.logAction(ctx, req.WorkspaceID, userID, action, "user", userID, map[string]interface{}{
+		"providerId": provider.ID,
+		"issuer":     provider.Issuer,
+		"role":       role,
+	})
+
+	return &domain.SsoSession{
+		Token:       token,
+		TokenType:   domain.SsoSessionTokenType,
+		WorkspaceID: req.WorkspaceID,
+		ActorID:     userID,
+		Role:        role,
+		Provisioned: provisioned,
+		ExpiresAt:   expiresAt.UTC(),
+	}, nil
+}
+
+// checkMemberQuota aplica o limite de membros do plano antes do JIT.
+// Falhas ao consultar o billing liberam o login, como no QuotaMiddleware.
+func (s *SsoService) checkMemberQuota(ctx context.Context, workspaceID string) error {
+	if s.billing == nil {
+		return nil
+	}
+	quota, err := s.billing.Quota(ctx, workspaceID)
+	if err == nil {
+		err = s.billing.CheckQuota(ctx, workspaceID, quota, domain.QuotaMembers)
+	}
+	if err == nil {
+		return nil
+	}
+	if _, known := apperr.From(err); known {
+		return err
+	}
+	s.log.Warn(ctx, "member quota check failed, allowing sso provisioning",
+		logger.Module("sso"),
+		logger.Action("quota"),
+		zap.String("workspace_id", workspaceID),
+		zap.Error(err),
+	)
+	return nil
+}
+
+// rejectLogin registra o motivo real da recusa; o cliente recebe sempre INVALID_TOKEN
+func (s *SsoService) rejectLogin(ctx context.Context, workspaceID, issuer string, err error) {
+	reason := string(auth.AuthFailureUnknown)
+	var authErr *auth.AuthError
+	if errors.As(err, &authErr) {
+		reason = string(authErr.Reason)
+	}
+	s.log.Warn(ctx, "sso id_token rejected",
+		logger.Module("sso"),
+		logger.Action("exchange"),
+		zap.String("workspace_id", workspaceID),
+		zap.String("issuer", issuer),
+		zap.String("reason", reason),
+		zap.Error(err),
+	)
+}
+
+func (s *SsoService) logAction(ctx context.Context, workspaceID, actorID, action, entity, id string, metadata map[string]interface{}) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, entity, &idStr, metadata, "", "")
+}
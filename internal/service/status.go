@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/ratelimit"
+
+	"go.uber.org/zap"
+)
+
+// statusPingTimeout limita o health check de cada componente: o status precisa responder
+// justamente quando uma dependência está lenta.
+const statusPingTimeout = 2 * time.Second
+
+// StatusPinger dependência com health check. Implemented by pgxpool.Pool.
+type StatusPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// StatusRateLimiter consulta o rate limit sem consumir a cota e informa se está em fallback.
+// Implemented by ratelimit.RedisRateLimiter.
+type StatusRateLimiter interface {
+	Peek(ctx context.Context, workspaceID string, limit int, windowSeconds int) (ratelimit.Decision, error)
+	Degraded() bool
+}
+
+// StatusService monta o status público da API (GET /v1/status): saúde dos componentes,
+// manutenção ligada e, por workspace, o rate limit atual.
+type StatusService struct {
+	db          StatusPinger
+	maintenance *MaintenanceService
+	rateLimiter StatusRateLimiter
+	limitPerMin atomic.Int64 // RATE_LIMIT_PER_WORKSPACE_PER_MIN, recarregável
+	log         *logger.Logger
+}
+
+// NewStatusService db, maintenance e rateLimiter podem ser nil (o componente fica fora da resposta).
+func NewStatusService(db StatusPinger, maintenance *MaintenanceService, rateLimiter StatusRateLimiter, limitPerMin int, log *logger.Logger) *StatusService {
+	s := &StatusService{
+		db:          db,
+		maintenance: maintenance,
+		rateLimiter: rateLimiter,
+		log:         log,
+	}
+	s.limitPerMin.Store(int64(limitPerMin))
+	return s
+}
+
+// SetLimitPerMin aplica um novo RATE_LIMIT_PER_WORKSPACE_PER_MIN (reload de configuração)
+func (s *StatusService) SetLimitPerMin(limitPerMin int) {
+	s.limitPerMin.Store(int64(limitPerMin))
+}
+
+// Status retorna a saúde dos componentes e a manutenção global.
+// Permission: público (sem credencial).
+func (s *StatusService) Status(ctx context.Context) *domain.APIStatus {
+	status := &domain.APIStatus{
+		CheckedAt:  time.Now().UTC().Truncate(time.Second),
+		Components: s.components(ctx),
+	}
+	status.Maintenance = s.activeMaintenance(ctx, "")
+	status.Resolve()
+	return status
+}
+
+// WorkspaceStatus retorna o Status com a manutenção do workspace e o rate limit dele.
+// limitPerMin > 0 substitui o limite global (planos com limite próprio).
+// Permission: qualquer credencial com acesso ao workspace.
+func (s *StatusService) WorkspaceStatus(ctx context.Context, workspaceID string, limitPerMin int) *domain.APIStatus {
+	status := s.Status(ctx)
+
+	ws := &domain.WorkspaceAPIStatus{WorkspaceID: workspaceID}
+	// Com manutenção global ligada ela tem precedência e já aparece em status.Maintenance
+	if status.Maintenance == nil {
+		ws.Maintenance = s.activeMaintenance(ctx, workspaceID)
+	}
+	ws.RateLimit = s.throttle(ctx, workspaceID, limitPerMin)
+
+	status.Workspace = ws
+	status.Resolve()
+	return status
+}
+
+func (s *StatusService) components(ctx context.Context) []domain.StatusComponent {
+	components := []domain.StatusComponent{}
+
+	if s.db != nil {
+		c := domain.StatusComponent{Name: domain.StatusComponentDatabase, Status: domain.ServiceOperational}
+		pingCtx, cancel := context.WithTimeout(ctx, statusPingTimeout)
+		err := s.db.Ping(pingCtx)
+		cancel()
+		if err != nil {
+			s.log.Warn(ctx, "status check failed: database unavailable",
+				logger.Module("status"),
+				zap.Error(err),
+			)
+			msg := "database unavailable"
+			c.Status, c.Message = domain.ServiceDown, &msg
+		}
+		components = append(components, c)
+	}
+
+	if s.rateLimiter != nil {
+		c := domain.StatusComponent{Name: domain.StatusComponentRateLimit, Status: domain.ServiceOperational}
+		if s.rateLimiter.Degraded() {
+			msg := "rate limit store unavailable, limits are approximate"
+			c.Status, c.Message = domain.ServiceDegraded, &msg
+		}
+		components = append(components, c)
+	}
+
+	return components
+}
+
+// activeMaintenance falha aberto: sem banco a manutenção não pode ser lida e o componente
+// database já aparece como down.
+func (s *StatusService) activeMaintenance(ctx context.Context, workspaceID string) *domain.Maintenance {
+	if s.maintenance == nil {
+		return nil
+	}
+	m, err := s.maintenance.Maintenance(ctx, workspaceID)
+	if err != nil {
+		s.log.Warn(ctx, "failed to read maintenance for status",
+			logger.Module("status"),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if m != nil && workspaceID != "" && m.Scope == domain.MaintenanceScopeGlobal {
+		return nil
+	}
+	return m
+}
+
+// throttle falha aberto como o RateLimitMiddleware: sem rate limit o campo fica nil.
+func (s *StatusService) throttle(ctx context.Context, workspaceID string, limitPerMin int) *domain.StatusThrottle {
+	if s.rateLimiter == nil {
+		return nil
+	}
+	limit := limitPerMin
+	if limit <= 0 {
+		limit = int(s.limitPerMin.Load())
+	}
+	decision, err := s.rateLimiter.Peek(ctx, workspaceID, limit, rateLimitWindowSeconds)
+	if err != nil {
+		s.log.Warn(ctx, "failed to read rate limit for status",
+			logger.Module("status"),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return &domain.StatusThrottle{
+		Limit:         decision.Limit,
+		Remaining:     decision.Remaining,
+		WindowSeconds: rateLimitWindowSeconds,
+		ResetAt:       time.Now().UTC().Add(decision.ResetAfter).Truncate(time.Second),
+		Throttled:     !decision.Allowed,
+	}
+}
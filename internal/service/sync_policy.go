@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// SyncPolicyService gerencia as políticas de conflito das integrações e aplica essas políticas
+// aos upserts por externalId com ?system=, para que syncs bidirecionais não fiquem trocando
+// os valores de um campo entre si (ping-pong).
+type SyncPolicyService struct {
+	syncRepo      *repo.SyncPolicyRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	log           *logger.Logger
+}
+
+func NewSyncPolicyService(syncRepo *repo.SyncPolicyRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *SyncPolicyService {
+	return &SyncPolicyService{
+		syncRepo:      syncRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *SyncPolicyService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("sync_policy"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// ListPolicies lista as políticas de conflito configuradas (sistemas sem política usam o padrão).
+// Permission: all workspace members.
+func (s *SyncPolicyService) ListPolicies(ctx context.Context, workspaceID, actorID string) ([]domain.SyncPolicy, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.syncRepo.ListPolicies(ctx, workspaceID)
+}
+
+// PutPolicy cria ou substitui a política de conflito do system.
+// Permission: admin only.
+func (s *SyncPolicyService) PutPolicy(ctx context.Context, workspaceID, system, actorID string, req *domain.PutSyncPolicyRequest) (*domain.SyncPolicy, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanManageWorkspace(role) {
+		return nil, ErrUnauthorized
+	}
+
+	policyID, err := id.New(id.SyncPolicy)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.syncRepo.PutPolicy(ctx, &domain.SyncPolicy{
+		ID:              policyID,
+		WorkspaceID:     workspaceID,
+		System:          system,
+		Strategy:        req.Strategy,
+		Priority:        req.Priority,
+		FieldPriorities: req.FieldPriorities,
+		CreatedByID:     actorID,
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "update", "sync_policy", &policy.ID, nil, "", "")
+
+	return policy, nil
+}
+
+// DeletePolicy remove a política: o system volta a LAST_WRITER_WINS com prioridade 0.
+// Permission: admin only.
+func (s *SyncPolicyService) DeletePolicy(ctx context.Context, workspaceID, system, actorID string) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !domain.CanManageWorkspace(role) {
+		return ErrUnauthorized
+	}
+
+	if err := s.syncRepo.DeletePolicy(ctx, workspaceID, system); err != nil {
+		return err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "delete", "sync_policy", nil, nil, "", "")
+
+	return nil
+}
+
+// ListConflicts lista o log de conflitos dos upserts, mais recentes primeiro.
+// Permission: all workspace members.
+func (s *SyncPolicyService) ListConflicts(ctx context.Context, workspaceID, actorID string, params domain.ListSyncConflictsParams) (*domain.SyncConflictListResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	params.Normalize()
+
+	conflicts, err := s.syncRepo.ListConflicts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.SyncConflictListResponse{Data: conflicts}
+	if len(conflicts) > params.Limit {
+		response.Data = conflicts[:params.Limit]
+		nextCursor := response.Data[params.Limit-1].CreatedAt.Format(time.RFC3339Nano)
+		response.Meta.HasNextPage = true
+		response.Meta.NextCursor = &nextCursor
+	}
+	return response, nil
+}
+
+// syncPlan decisão da política para um upsert: Rejected são os campos a descartar do request,
+// Applied os que passam a ter o system como origem e conflicts o que vai para o log.
+type syncPlan struct {
+	workspaceID string
+	system      string
+	entityType  domain.IdentityEntityType
+	entityID    string
+	applied     []string
+	rejected    []string
+	conflicts   []domain.SyncConflict
+}
+
+// planUpsert compara o request (incoming) com o registro atual (current; nil em registro novo,
+// com entityID vazio) e decide campo a campo. Um campo alterado cuja origem é outro sistema é um
+// conflito: com LAST_WRITER_WINS (ou sem política) o system sobrescreve; com SOURCE_PRIORITY
+// sobrescreve só se a prioridade dele no campo for maior ou igual à do sistema de origem.
+// Retorna nil sem system ou com receiver nil (upsert sem política).
+func (s *SyncPolicyService) planUpsert(ctx context.Context, workspaceID, system string, entityType domain.IdentityEntityType, entityID string, current, incoming any) (*syncPlan, error) {
+	if s == nil || system == "" {
+		return nil, nil
+	}
+
+	fields, changes, err := domain.SyncChangedFields(current, incoming)
+	if err != nil {
+		return nil, err
+	}
+	plan := &syncPlan{workspaceID: workspaceID, system: system, entityType: entityType, entityID: entityID}
+	if len(fields) == 0 {
+		return plan, nil
+	}
+
+	sources := map[string]string{}
+	if entityID != "" {
+		if sources, err = s.syncRepo.FieldSources(ctx, workspaceID, entityType, entityID); err != nil {
+			return nil, err
+		}
+	}
+
+	var policies map[string]*domain.SyncPolicy
+	now := time.Now().UTC()
+	for _, field := range fields {
+		source, ok := sources[field]
+		if !ok || source == system {
+			plan.applied = append(plan.applied, field)
+			continue
+		}
+
+		if policies == nil {
+			list, err := s.syncRepo.ListPolicies(ctx, workspaceID)
+			if err != nil {
+				return nil, err
+			}
+			policies = make(map[string]*domain.SyncPolicy, len(list))
+			for i := range list {
+				policies[list[i].System] = &list[i]
+			}
+		}
+
+		incomingPolicy := policies[system]
+		resolution := domain.SyncOverwritten
+		if incomingPolicy.StrategyOrDefault() == domain.SyncSourcePriority &&
+			incomingPolicy.PriorityFor(entityType, field) < policies[source].PriorityFor(entityType, field) {
+			resolution = domain.SyncKeptCurrent
+		}
+		if resolution == domain.SyncOverwritten {
+			plan.applied = append(plan.applied, field)
+		} else {
+			plan.rejected = append(plan.rejected, field)
+		}
+
+		conflictID, err := id.New(id.SyncConflict)
+		if err != nil {
+			return nil, err
+		}
+		plan.conflicts = append(plan.conflicts, domain.SyncConflict{
+			ID:            conflictID,
+			WorkspaceID:   workspaceID,
+			EntityType:    entityType,
+			EntityID:      entityID,
+			Field:         field,
+			System:        system,
+			CurrentSystem: source,
+			IncomingValue: changes[field].To,
+			CurrentValue:  changes[field].From,
+			Resolution:    resolution,
+			CreatedAt:     now,
+		})
+	}
+	return plan, nil
+}
+
+// recordUpsert grava as origens e os conflitos depois que o upsert foi aplicado. entityID é o do
+// registro criado (o plano de um registro novo não tem id). Falhas são logadas: o upsert já foi
+// gravado e não deve falhar por causa do log de conflitos.
+func (s *SyncPolicyService) recordUpsert(ctx context.Context, plan *syncPlan, entityID string) {
+	if s == nil || plan == nil || (len(plan.applied) == 0 && len(plan.conflicts) == 0) {
+		return
+	}
+
+	for i := range plan.conflicts {
+		plan.conflicts[i].EntityID = entityID
+	}
+	err := s.syncRepo.RecordSync(ctx, plan.workspaceID, plan.entityType, entityID, plan.system, plan.applied, plan.conflicts, time.Now().UTC())
+	if err != nil {
+		s.log.Warn(ctx, "failed to record sync field sources",
+			logger.Module("sync_policy"),
+			zap.String("workspace_id", plan.workspaceID),
+			zap.String("system", plan.system),
+			zap.String("entity_type", string(plan.entityType)),
+			zap.String("entity_id", entityID),
+			zap.Error(err),
+		)
+	}
+}
+
+// rejectedFields campos que o upsert deve descartar (plano nil: nenhum).
+func (p *syncPlan) rejectedFields() []string {
+	if p == nil {
+		return nil
+	}
+	return p.rejected
+}
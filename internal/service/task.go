@@ -5,19 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 
+	"linkko-api/internal/apperr"
 	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
 	"linkko-api/internal/observability/logger"
 	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 var (
 	ErrTaskNotFound      = repo.ErrTaskNotFound
-	ErrInvalidPosition   = errors.New("invalid position: beforeTaskID and afterTaskID must be in same status")
-	ErrInvalidStatus     = errors.New("invalid status transition")
-	ErrPositionCollision = errors.New("position difference too small, consider renormalizing positions")
+	ErrInvalidPosition   = apperr.Unprocessable(apperr.CodeInvalidPosition, "invalid position: beforeTaskID and afterTaskID must be in same status", "")
+	ErrInvalidStatus     = apperr.Unprocessable(apperr.CodeInvalidStatus, "invalid status transition", "")
+	ErrPositionCollision = apperr.Define(apperr.CodePositionCollision, http.StatusConflict, "position difference too small, consider renormalizing positions", "")
 )
 
 const (
@@ -33,21 +38,31 @@ type TaskService struct {
 	taskRepo      *repo.TaskRepository
 	auditRepo     *repo.AuditRepo
 	workspaceRepo *repo.WorkspaceRepository
+	userRepo      *repo.UserRepository // Validação de dono/responsável
+	counters      *CounterService
+	undo          *UndoService
+	followers     *FollowerService
 	log           *logger.Logger
 }
 
-func NewTaskService(taskRepo *repo.TaskRepository, auditRepo *repo.AuditRepo, workspaceRepo *repo.WorkspaceRepository, log *logger.Logger) *TaskService {
+func NewTaskService(taskRepo *repo.TaskRepository, auditRepo *repo.AuditRepo, workspaceRepo *repo.WorkspaceRepository, userRepo *repo.UserRepository, counters *CounterService, undo *UndoService, followers *FollowerService, log *logger.Logger) *TaskService {
 	return &TaskService{
 		taskRepo:      taskRepo,
 		auditRepo:     auditRepo,
 		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		counters:      counters,
+		undo:          undo,
+		followers:     followers,
 		log:           log,
 	}
 }
 
 // getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
 func (s *TaskService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
 	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
 	if err != nil {
 		s.log.Error(ctx, "failed to get member role",
 			logger.Module("task"),
@@ -75,6 +90,9 @@ func (s *TaskService) getMemberRoleWithLogging(ctx context.Context, actorID, wor
 // ListTasks retrieves tasks with RBAC validation.
 // Permission: all workspace members can list tasks.
 func (s *TaskService) ListTasks(ctx context.Context, workspaceID, actorID string, params domain.ListTasksParams) (*domain.TaskListResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.ListTasks")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -87,6 +105,7 @@ func (s *TaskService) ListTasks(ctx context.Context, workspaceID, actorID string
 	}
 
 	params.WorkspaceID = workspaceID
+	params.Viewer = domain.VisibilityViewer(actorID, role)
 	params.Normalize()
 
 	tasks, nextCursor, err := s.taskRepo.List(ctx, params)
@@ -104,9 +123,44 @@ func (s *TaskService) ListTasks(ctx context.Context, workspaceID, actorID string
 	return response, nil
 }
 
+// GetTaskBoard returns the Kanban projection: columns per status, optionally split into
+// swimlanes (assignee or priority), with per-cell counts and at most params.Limit tasks per cell.
+// Permission: all workspace members.
+func (s *TaskService) GetTaskBoard(ctx context.Context, workspaceID, actorID string, params domain.TaskBoardParams) (*domain.TaskBoard, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.GetTaskBoard")
+	defer span.End()
+
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	params.Viewer = domain.VisibilityViewer(actorID, role)
+	params.Normalize()
+
+	counts, err := s.taskRepo.BoardCounts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("task board counts: %w", err)
+	}
+
+	tasks, err := s.taskRepo.BoardTasks(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("task board tasks: %w", err)
+	}
+
+	return domain.NewTaskBoard(params.Swimlane, counts, tasks), nil
+}
+
 // GetTask retrieves a single task with RBAC validation.
 // Permission: all workspace members can view tasks.
 func (s *TaskService) GetTask(ctx context.Context, workspaceID, taskID, actorID string) (*domain.Task, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.GetTask")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -118,7 +172,7 @@ func (s *TaskService) GetTask(ctx context.Context, workspaceID, taskID, actorID
 		return nil, ErrUnauthorized
 	}
 
-	task, err := s.taskRepo.Get(ctx, workspaceID, taskID)
+	task, err := s.taskRepo.GetVisible(ctx, workspaceID, taskID, domain.VisibilityViewer(actorID, role))
 	if err != nil {
 		return nil, fmt.Errorf("get task: %w", err)
 	}
@@ -129,6 +183,9 @@ func (s *TaskService) GetTask(ctx context.Context, workspaceID, taskID, actorID
 // CreateTask creates a new task with RBAC validation and position calculation.
 // Permission: work_admin, work_manager, work_user can create tasks.
 func (s *TaskService) CreateTask(ctx context.Context, workspaceID, actorID string, req *domain.CreateTaskRequest) (*domain.Task, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.CreateTask")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -140,16 +197,30 @@ func (s *TaskService) CreateTask(ctx context.Context, workspaceID, actorID strin
 		return nil, ErrUnauthorized
 	}
 
+	// Dono e responsável informados precisam ser membros do workspace
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.ActorID); err != nil {
+		return nil, err
+	}
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.AssignedTo); err != nil {
+		return nil, err
+	}
+
 	// Defaults
+	taskID, err := id.New(id.Task)
+	if err != nil {
+		return nil, err
+	}
+
 	task := &domain.Task{
-		ID:          generateID(),
+		ID:          taskID,
 		WorkspaceID: workspaceID,
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      domain.TaskStatusBacklog, // default
 		Priority:    domain.PriorityMedium,    // default
 		Type:        domain.TaskTypeTask,      // default
-		ActorID:     actorID,                  // default to JWT claims.ActorID
+		Visibility:  domain.VisibilityWorkspace,
+		ActorID:     actorID, // default to JWT claims.ActorID
 		AssignedTo:  req.AssignedTo,
 		ContactID:   req.ContactID,
 		DueDate:     req.DueDate,
@@ -165,6 +236,9 @@ func (s *TaskService) CreateTask(ctx context.Context, workspaceID, actorID strin
 	if req.Type != nil {
 		task.Type = *req.Type
 	}
+	if req.Visibility != nil {
+		task.Visibility = *req.Visibility
+	}
 	if req.ActorID != nil {
 		task.ActorID = *req.ActorID
 	}
@@ -199,6 +273,9 @@ func (s *TaskService) CreateTask(ctx context.Context, workspaceID, actorID strin
 		// Log audit failure but don't fail the operation
 	}
 
+	s.counters.TaskChanged(ctx, workspaceID, nil, task)
+	s.followers.AutoFollow(ctx, workspaceID, domain.FollowEntityTask, task.ID, task.AssignedTo, domain.FollowReasonAssigned)
+
 	return task, nil
 }
 
@@ -206,6 +283,9 @@ func (s *TaskService) CreateTask(ctx context.Context, workspaceID, actorID strin
 // Permission: work_admin, work_manager, work_user can update tasks.
 // Para mover task (drag-and-drop), usar MoveTask.
 func (s *TaskService) UpdateTask(ctx context.Context, workspaceID, taskID, actorID string, req *domain.UpdateTaskRequest) (*domain.Task, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.UpdateTask")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
@@ -217,12 +297,21 @@ func (s *TaskService) UpdateTask(ctx context.Context, workspaceID, taskID, actor
 		return nil, ErrUnauthorized
 	}
 
-	// Verificar se task existe
-	_, err = s.taskRepo.Get(ctx, workspaceID, taskID)
+	// Verificar se task existe (e é visível ao usuário)
+	current, err := s.taskRepo.GetVisible(ctx, workspaceID, taskID, domain.VisibilityViewer(actorID, role))
 	if err != nil {
 		return nil, fmt.Errorf("get task: %w", err)
 	}
 
+	// Visibilidade só é alterada pelo dono da tarefa ou por um admin
+	if req.Visibility != nil && current.ActorID != actorID && role != domain.RoleAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	if err := validateOwner(ctx, s.userRepo, workspaceID, req.AssignedTo); err != nil {
+		return nil, err
+	}
+
 	// Update task
 	err = s.taskRepo.Update(ctx, workspaceID, taskID, req)
 	if err != nil {
@@ -252,33 +341,40 @@ func (s *TaskService) UpdateTask(ctx context.Context, workspaceID, taskID, actor
 		return nil, fmt.Errorf("get updated task: %w", err)
 	}
 
+	s.counters.TaskChanged(ctx, workspaceID, current, updatedTask)
+	s.followers.Changed(ctx, workspaceID, domain.FollowEntityTask, taskID, actorID, current.AssignedTo, updatedTask.AssignedTo)
+
 	return updatedTask, nil
 }
 
 // DeleteTask soft deletes a task with RBAC validation.
 // Permission: work_admin, work_manager can delete tasks.
-func (s *TaskService) DeleteTask(ctx context.Context, workspaceID, taskID, actorID string) error {
+// Returns an undo receipt (nil if it could not be issued) to restore the task within the undo window.
+func (s *TaskService) DeleteTask(ctx context.Context, workspaceID, taskID, actorID string) (*domain.UndoReceipt, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.DeleteTask")
+	defer span.End()
+
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// RBAC: admin, manager can delete tasks (user and viewer cannot)
 	if !domain.CanDeleteContacts(role) { // Reuso da mesma lógica de permissão de contacts
-		return ErrUnauthorized
+		return nil, ErrUnauthorized
 	}
 
-	// Verificar se task existe
-	_, err = s.taskRepo.Get(ctx, workspaceID, taskID)
+	// Verificar se task existe (e é visível ao usuário)
+	current, err := s.taskRepo.GetVisible(ctx, workspaceID, taskID, domain.VisibilityViewer(actorID, role))
 	if err != nil {
-		return fmt.Errorf("get task: %w", err)
+		return nil, fmt.Errorf("get task: %w", err)
 	}
 
 	// Soft delete
-	err = s.taskRepo.SoftDelete(ctx, workspaceID, taskID)
+	err = s.taskRepo.SoftDelete(ctx, workspaceID, taskID, actorID)
 	if err != nil {
-		return fmt.Errorf("delete task: %w", err)
+		return nil, fmt.Errorf("delete task: %w", err)
 	}
 
 	// Audit log (simplified)
@@ -298,7 +394,9 @@ func (s *TaskService) DeleteTask(ctx context.Context, workspaceID, taskID, actor
 		// Log audit failure but don't fail the operation
 	}
 
-	return nil
+	s.counters.TaskChanged(ctx, workspaceID, current, nil)
+
+	return s.undo.Issue(ctx, workspaceID, actorID, domain.UndoEntityTask, taskID), nil
 }
 
 // MoveTask move uma tarefa no Kanban com fractional positioning e pessimistic locking.
@@ -318,34 +416,72 @@ func (s *TaskService) DeleteTask(ctx context.Context, workspaceID, taskID, actor
 // 6. Update task com nova position e status
 // 7. Commit transaction
 func (s *TaskService) MoveTask(ctx context.Context, workspaceID, taskID, actorID string, req *domain.MoveTaskRequest) (*domain.Task, error) {
+	_, moved, _, err := s.moveTask(ctx, workspaceID, taskID, actorID, req)
+	return moved, err
+}
+
+// MoveTaskExtended é o MoveTask com return=extended: inclui a contagem das colunas de
+// origem e destino (respeitando a visibilidade do usuário).
+func (s *TaskService) MoveTaskExtended(ctx context.Context, workspaceID, taskID, actorID string, req *domain.MoveTaskRequest) (*domain.TaskMutationResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.MoveTaskExtended")
+	defer span.End()
+
+	before, moved, viewer, err := s.moveTask(ctx, workspaceID, taskID, actorID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []domain.TaskStatus{before.Status}
+	if moved.Status != before.Status {
+		statuses = append(statuses, moved.Status)
+	}
+	columns, err := s.taskRepo.StatusAggregates(ctx, workspaceID, statuses, viewer)
+	if err != nil {
+		return nil, fmt.Errorf("task status aggregates: %w", err)
+	}
+
+	result := &domain.TaskMutationResult{Task: moved}
+	result.Aggregates.Statuses = columns
+	return result, nil
+}
+
+// moveTask executa o MoveTask e devolve a tarefa antes e depois, além do filtro de
+// visibilidade do usuário.
+func (s *TaskService) moveTask(ctx context.Context, workspaceID, taskID, actorID string, req *domain.MoveTaskRequest) (*domain.Task, *domain.Task, *string, error) {
 	// Fetch user's role in this workspace from database
 	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// RBAC: admin, manager, user can move tasks
 	if !domain.CanModifyContacts(role) {
-		return nil, ErrUnauthorized
+		return nil, nil, nil, ErrUnauthorized
+	}
+
+	// Tarefa invisível ao usuário é tratada como inexistente
+	viewer := domain.VisibilityViewer(actorID, role)
+	if _, err := s.taskRepo.GetVisible(ctx, workspaceID, taskID, viewer); err != nil {
+		return nil, nil, nil, fmt.Errorf("get task: %w", err)
 	}
 
 	// Begin transaction (primeira vez usando transação no projeto!)
 	tx, err := s.taskRepo.BeginTx(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("begin transaction: %w", err)
+		return nil, nil, nil, fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx) // Rollback automático se não commitado
 
 	// Lock task com FOR UPDATE
 	task, err := s.taskRepo.GetForUpdate(ctx, tx, workspaceID, taskID)
 	if err != nil {
-		return nil, fmt.Errorf("get task for update: %w", err)
+		return nil, nil, nil, fmt.Errorf("get task for update: %w", err)
 	}
 
 	// Lock beforeTask e afterTask (se fornecidos) e obter positions
 	posBefore, posAfter, err := s.taskRepo.GetPositionBounds(ctx, tx, workspaceID, req.ToStatus, req.BeforeTaskID, req.AfterTaskID)
 	if err != nil {
-		return nil, fmt.Errorf("get position bounds: %w", err)
+		return nil, nil, nil, fmt.Errorf("get position bounds: %w", err)
 	}
 
 	// Calcular nova position (fractional positioning)
@@ -376,13 +512,13 @@ func (s *TaskService) MoveTask(ctx context.Context, workspaceID, taskID, actorID
 	// Update task position e status
 	err = s.taskRepo.UpdatePosition(ctx, tx, workspaceID, taskID, newPosition, req.ToStatus)
 	if err != nil {
-		return nil, fmt.Errorf("update task position: %w", err)
+		return nil, nil, nil, fmt.Errorf("update task position: %w", err)
 	}
 
 	// Commit transaction
 	err = tx.Commit(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("commit transaction: %w", err)
+		return nil, nil, nil, fmt.Errorf("commit transaction: %w", err)
 	}
 
 	// Audit log (após commit bem-sucedido)
@@ -410,8 +546,13 @@ func (s *TaskService) MoveTask(ctx context.Context, workspaceID, taskID, actorID
 	// Fetch updated task
 	movedTask, err := s.taskRepo.Get(ctx, workspaceID, taskID)
 	if err != nil {
-		return nil, fmt.Errorf("get moved task: %w", err)
+		return nil, nil, nil, fmt.Errorf("get moved task: %w", err)
+	}
+
+	s.counters.TaskChanged(ctx, workspaceID, task, movedTask)
+	if movedTask.Status != task.Status {
+		s.followers.Notify(ctx, workspaceID, domain.FollowEntityTask, taskID, actorID, domain.NotificationStageChanged)
 	}
 
-	return movedTask, nil
+	return task, movedTask, viewer, nil
 }
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTimeEntryNotFound       = repo.ErrTimeEntryNotFound
+	ErrTimerAlreadyRunning     = repo.ErrTimerAlreadyRunning
+	ErrTimeEntryNotRunning     = apperr.Unprocessable(apperr.CodeInvalidStatus, "time entry is not running", "")
+	ErrTimeEntryEndBeforeStart = apperr.Unprocessable(apperr.CodeValidationError, "endedAt must be after startedAt", "")
+	ErrTimeEntryTooLong        = apperr.Unprocessable(apperr.CodeValidationError, "time entry cannot exceed 24 hours", "")
+)
+
+// TimeEntryService gerencia apontamentos de horas em tarefas e negócios.
+type TimeEntryService struct {
+	timeEntryRepo *repo.TimeEntryRepository
+	taskRepo      *repo.TaskRepository
+	dealRepo      *repo.DealRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	businessHours *repo.BusinessHoursRepository
+	log           *logger.Logger
+}
+
+func NewTimeEntryService(timeEntryRepo *repo.TimeEntryRepository, taskRepo *repo.TaskRepository, dealRepo *repo.DealRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, log *logger.Logger) *TimeEntryService {
+	return &TimeEntryService{
+		timeEntryRepo: timeEntryRepo,
+		taskRepo:      taskRepo,
+		dealRepo:      dealRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		log:           log,
+	}
+}
+
+// WithBusinessHours usa o fuso do expediente do workspace nos filtros por data (sem ?tz=).
+func (s *TimeEntryService) WithBusinessHours(businessHours *repo.BusinessHoursRepository) *TimeEntryService {
+	s.businessHours = businessHours
+	return s
+}
+
+// WorkspaceLocation fuso padrão dos filtros de data (sem ?tz=).
+func (s *TimeEntryService) WorkspaceLocation(ctx context.Context, workspaceID string) (*time.Location, error) {
+	return workspaceLocation(ctx, s.businessHours, workspaceID)
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *TimeEntryService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("time_entry"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// CreateTimeEntry starts a timer or logs a closed entry for the actor.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *TimeEntryService) CreateTimeEntry(ctx context.Context, workspaceID, actorID string, req *domain.CreateTimeEntryRequest) (*domain.TimeEntry, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if req.TaskID != nil {
+		if _, err := s.taskRepo.Get(ctx, workspaceID, *req.TaskID); err != nil {
+			return nil, err
+		}
+	}
+	if req.DealID != nil {
+		if _, err := s.dealRepo.Get(ctx, workspaceID, *req.DealID); err != nil {
+			if errors.Is(err, repo.ErrDealNotFound) {
+				return nil, ErrDealNotFound
+			}
+			return nil, fmt.Errorf("get deal: %w", err)
+		}
+	}
+
+	entry, err := req.NewTimeEntry(time.Now().UTC())
+	if err != nil {
+		return nil, timeEntryIntervalError(err)
+	}
+	entry.ID, err = id.New(id.TimeEntry)
+	if err != nil {
+		return nil, err
+	}
+	entry.WorkspaceID = workspaceID
+	entry.UserID = actorID
+
+	created, err := s.timeEntryRepo.Create(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logTimeEntryAction(ctx, workspaceID, actorID, "create", created.ID)
+
+	return created, nil
+}
+
+// GetTimeEntry retrieves a single time entry.
+// Permission: all workspace members.
+func (s *TimeEntryService) GetTimeEntry(ctx context.Context, workspaceID, entryID, actorID string) (*domain.TimeEntry, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.timeEntryRepo.Get(ctx, workspaceID, entryID)
+}
+
+// ListTimeEntries lists time entries, most recent first.
+// Permission: all workspace members.
+func (s *TimeEntryService) ListTimeEntries(ctx context.Context, workspaceID, actorID string, params domain.ListTimeEntriesParams) ([]domain.TimeEntry, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsWorkspaceMember(role) {
+		return nil, ErrUnauthorized
+	}
+
+	params.WorkspaceID = workspaceID
+	params.Normalize()
+
+	return s.timeEntryRepo.List(ctx, params)
+}
+
+// UpdateTimeEntry partially updates a time entry, recomputing its duration.
+// Permission: the entry owner, admin or manager.
+func (s *TimeEntryService) UpdateTimeEntry(ctx context.Context, workspaceID, entryID, actorID string, req *domain.UpdateTimeEntryRequest) (*domain.TimeEntry, error) {
+	entry, err := s.getOwnedEntry(ctx, workspaceID, entryID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.Apply(req); err != nil {
+		return nil, timeEntryIntervalError(err)
+	}
+
+	updated, err := s.timeEntryRepo.Update(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logTimeEntryAction(ctx, workspaceID, actorID, "update", entryID)
+
+	return updated, nil
+}
+
+// StopTimeEntry stops a running timer at the current time.
+// Permission: the entry owner, admin or manager.
+func (s *TimeEntryService) StopTimeEntry(ctx context.Context, workspaceID, entryID, actorID string) (*domain.TimeEntry, error) {
+	entry, err := s.getOwnedEntry(ctx, workspaceID, entryID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !entry.Running {
+		return nil, ErrTimeEntryNotRunning
+	}
+
+	if err := entry.Stop(time.Now().UTC()); err != nil {
+		return nil, timeEntryIntervalError(err)
+	}
+
+	updated, err := s.timeEntryRepo.Update(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logTimeEntryAction(ctx, workspaceID, actorID, "stop", entryID)
+
+	return updated, nil
+}
+
+// DeleteTimeEntry soft-deletes a time entry.
+// Permission: the entry owner, admin or manager.
+func (s *TimeEntryService) DeleteTimeEntry(ctx context.Context, workspaceID, entryID, actorID string) error {
+	if _, err := s.getOwnedEntry(ctx, workspaceID, entryID, actorID); err != nil {
+		return err
+	}
+
+	if err := s.timeEntryRepo.Delete(ctx, workspaceID, entryID); err != nil {
+		return err
+	}
+
+	s.logTimeEntryAction(ctx, workspaceID, actorID, "delete", entryID)
+	return nil
+}
+
+// getOwnedEntry carrega o apontamento e verifica se o ator pode alterá-lo:
+// o próprio usuário (exceto viewer) ou admin/manager.
+func (s *TimeEntryService) getOwnedEntry(ctx context.Context, workspaceID, entryID, actorID string) (*domain.TimeEntry, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanModifyContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	entry, err := s.timeEntryRepo.Get(ctx, workspaceID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != actorID && !domain.CanDeleteContacts(role) {
+		return nil, ErrUnauthorized
+	}
+	return entry, nil
+}
+
+// timeEntryIntervalError traduz os erros de intervalo do domínio para o catálogo (422).
+func timeEntryIntervalError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrTimeEntryEndBeforeStart):
+		return ErrTimeEntryEndBeforeStart
+	case errors.Is(err, domain.ErrTimeEntryTooLong):
+		return ErrTimeEntryTooLong
+	}
+	return err
+}
+
+func (s *TimeEntryService) logTimeEntryAction(ctx context.Context, workspaceID, actorID, action, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "time_entry", &idStr, nil, "", "")
+}
@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestTimeEntryService_Integration
+func TestTimeEntryService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	svc := service.NewTimeEntryService(repo.NewTimeEntryRepository(pool), repo.NewTaskRepository(pool),
+		repo.NewDealRepository(pool), repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool), log)
+	reports := service.NewReportService(repo.NewReportRepository(pool), repo.NewDealRepository(pool),
+		repo.NewBusinessHoursRepository(pool), repo.NewWorkspaceRepository(pool), log)
+
+	task := f.Task()
+	deal := f.Deal()
+	user := f.Member(domain.RoleUser)
+	manager := f.Member(domain.RoleManager)
+
+	t.Run("viewers cannot log time", func(t *testing.T) {
+		_, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), &domain.CreateTimeEntryRequest{TaskID: &task.ID})
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	t.Run("one running timer per user", func(t *testing.T) {
+		timer, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, user, &domain.CreateTimeEntryRequest{TaskID: &task.ID})
+		require.NoError(t, err)
+		assert.True(t, timer.Running)
+		assert.Equal(t, user, timer.UserID)
+
+		_, err = svc.CreateTimeEntry(ctx, f.WorkspaceID, user, &domain.CreateTimeEntryRequest{DealID: &deal.ID})
+		assert.ErrorIs(t, err, service.ErrTimerAlreadyRunning)
+
+		// Outro usuário pode ter o próprio timer em paralelo.
+		other, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, manager, &domain.CreateTimeEntryRequest{TaskID: &task.ID})
+		require.NoError(t, err)
+		require.NoError(t, svc.DeleteTimeEntry(ctx, f.WorkspaceID, other.ID, manager))
+
+		stopped, err := svc.StopTimeEntry(ctx, f.WorkspaceID, timer.ID, user)
+		require.NoError(t, err)
+		assert.False(t, stopped.Running)
+		require.NotNil(t, stopped.DurationSeconds)
+
+		_, err = svc.StopTimeEntry(ctx, f.WorkspaceID, timer.ID, user)
+		assert.ErrorIs(t, err, service.ErrTimeEntryNotRunning)
+
+		// Parado o timer, o usuário pode iniciar outro.
+		next, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, user, &domain.CreateTimeEntryRequest{DealID: &deal.ID})
+		require.NoError(t, err)
+		require.NoError(t, svc.DeleteTimeEntry(ctx, f.WorkspaceID, next.ID, user))
+		require.NoError(t, svc.DeleteTimeEntry(ctx, f.WorkspaceID, timer.ID, user))
+	})
+
+	t.Run("intervals longer than a day are rejected", func(t *testing.T) {
+		start := time.Now().UTC().Add(-48 * time.Hour)
+		end := start.Add(25 * time.Hour)
+		_, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, user, &domain.CreateTimeEntryRequest{TaskID: &task.ID, StartedAt: &start, EndedAt: &end})
+		assert.ErrorIs(t, err, service.ErrTimeEntryTooLong)
+	})
+
+	t.Run("only the owner, admins and managers edit an entry", func(t *testing.T) {
+		entry, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, user, &domain.CreateTimeEntryRequest{TaskID: &task.ID, DurationSeconds: factory.Ptr(600)})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = svc.DeleteTimeEntry(ctx, f.WorkspaceID, entry.ID, f.UserID) })
+
+		update := &domain.UpdateTimeEntryRequest{DurationSeconds: factory.Ptr(1200)}
+		_, err = svc.UpdateTimeEntry(ctx, f.WorkspaceID, entry.ID, f.Member(domain.RoleUser), update)
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		updated, err := svc.UpdateTimeEntry(ctx, f.WorkspaceID, entry.ID, manager, update)
+		require.NoError(t, err)
+		assert.Equal(t, 1200, *updated.DurationSeconds)
+		assert.Equal(t, updated.StartedAt.Add(20*time.Minute), *updated.EndedAt)
+	})
+
+	t.Run("time report sums closed entries", func(t *testing.T) {
+		reportDeal := f.Deal()
+		logTime := func(actor string, seconds int, billable bool) {
+			_, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, actor, &domain.CreateTimeEntryRequest{
+				DealID: &reportDeal.ID, DurationSeconds: factory.Ptr(seconds), Billable: factory.Ptr(billable),
+			})
+			require.NoError(t, err)
+		}
+		logTime(user, 3600, true)
+		logTime(user, 600, false)
+		logTime(manager, 1800, true)
+		// Timer em andamento não entra no relatório.
+		running, err := svc.CreateTimeEntry(ctx, f.WorkspaceID, user, &domain.CreateTimeEntryRequest{DealID: &reportDeal.ID})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = svc.DeleteTimeEntry(ctx, f.WorkspaceID, running.ID, user) })
+
+		report, err := reports.TimeReport(ctx, f.WorkspaceID, f.UserID, domain.TimeReportParams{DealID: &reportDeal.ID})
+		require.NoError(t, err)
+		assert.Equal(t, domain.TimeByUser, report.GroupBy)
+		assert.Equal(t, int64(6000), report.TotalSeconds)
+		assert.Equal(t, int64(5400), report.BillableSeconds)
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, user, *report.Rows[0].Key)
+		assert.Equal(t, 2, report.Rows[0].Entries)
+		assert.Equal(t, int64(4200), report.Rows[0].TotalSeconds)
+		assert.Equal(t, manager, *report.Rows[1].Key)
+
+		billable, err := reports.TimeReport(ctx, f.WorkspaceID, f.UserID, domain.TimeReportParams{
+			DealID: &reportDeal.ID, GroupBy: domain.TimeByDeal, Billable: factory.Ptr(true),
+		})
+		require.NoError(t, err)
+		require.Len(t, billable.Rows, 1)
+		assert.Equal(t, reportDeal.ID, *billable.Rows[0].Key)
+		assert.Equal(t, int64(5400), billable.TotalSeconds)
+	})
+}
@@ -0,0 +1,455 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"linkko-api/internal/apperr"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTrackedLinkNotFound = repo.ErrTrackedLinkNotFound
+
+	// ErrInvalidLinkDeal o negócio ligado ao link precisa existir no workspace
+	ErrInvalidLinkDeal = apperr.Unprocessable(apperr.CodeValidationError, "deal_id does not belong to workspace", "deal does not belong to workspace")
+)
+
+// trackedLinkCodeAttempts tentativas de gerar um code livre antes de desistir
+const trackedLinkCodeAttempts = 3
+
+// TrackedLinkService gerencia links curtos rastreados e registra os cliques recebidos em /l/{code}.
+type TrackedLinkService struct {
+	linkRepo      *repo.TrackedLinkRepository
+	contactRepo   *repo.ContactRepository
+	dealRepo      *repo.DealRepository
+	activityRepo  *repo.ActivityRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	baseURL       string
+	log           *logger.Logger
+}
+
+// NewTrackedLinkService cria o service. baseURL (TRACKED_LINK_BASE_URL) prefixa as URLs curtas;
+// vazio gera URLs relativas (/l/{code}).
+func NewTrackedLinkService(linkRepo *repo.TrackedLinkRepository, contactRepo *repo.ContactRepository, dealRepo *repo.DealRepository, activityRepo *repo.ActivityRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, baseURL string, log *logger.Logger) *TrackedLinkService {
+	return &TrackedLinkService{
+		linkRepo:      linkRepo,
+		contactRepo:   contactRepo,
+		dealRepo:      dealRepo,
+		activityRepo:  activityRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *TrackedLinkService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("tracked_link"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// authorize checa a permissão do ator com a regra informada.
+func (s *TrackedLinkService) authorize(ctx context.Context, workspaceID, actorID string, allowed func(domain.Role) bool) error {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateLink gera o code e o segredo dos contact tokens e grava o link.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *TrackedLinkService) CreateLink(ctx context.Context, workspaceID, actorID string, req *domain.CreateTrackedLinkRequest) (*domain.TrackedLink, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	trackedLinkID, err := id.New(id.TrackedLink)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &domain.TrackedLink{
+		ID:             trackedLinkID,
+		WorkspaceID:    workspaceID,
+		Name:           req.Name,
+		DestinationURL: req.DestinationURL,
+		UTMSource:      req.UTMSource,
+		UTMMedium:      req.UTMMedium,
+		UTMCampaign:    req.UTMCampaign,
+		UTMTerm:        req.UTMTerm,
+		UTMContent:     req.UTMContent,
+		ContactID:      req.ContactID,
+		DealID:         req.DealID,
+		TokenSecret:    secret,
+		CreatedByID:    actorID,
+	}
+	if err := s.validateReferences(ctx, link); err != nil {
+		return nil, err
+	}
+
+	var created *domain.TrackedLink
+	for attempt := 0; attempt < trackedLinkCodeAttempts; attempt++ {
+		link.Code = generateTrackedLinkCode()
+		created, err = s.linkRepo.Create(ctx, link)
+		if !errors.Is(err, repo.ErrTrackedLinkCodeTaken) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "create", created.ID)
+	return s.withShortURL(created), nil
+}
+
+// GetLink retorna o link.
+// Permission: all workspace members.
+func (s *TrackedLinkService) GetLink(ctx context.Context, workspaceID, linkID, actorID string) (*domain.TrackedLink, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	link, err := s.linkRepo.Get(ctx, workspaceID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withShortURL(link), nil
+}
+
+// ListLinks lista os links do workspace (contactID/dealID filtram).
+// Permission: all workspace members.
+func (s *TrackedLinkService) ListLinks(ctx context.Context, workspaceID, actorID string, contactID, dealID *string) ([]domain.TrackedLink, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.List(ctx, workspaceID, contactID, dealID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range links {
+		s.withShortURL(&links[i])
+	}
+	return links, nil
+}
+
+// UpdateLink aplica o PATCH; o code não muda, então as URLs já enviadas seguem válidas.
+// Permission: admin, manager, user. Viewer cannot.
+func (s *TrackedLinkService) UpdateLink(ctx context.Context, workspaceID, linkID, actorID string, req *domain.UpdateTrackedLinkRequest) (*domain.TrackedLink, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanModifyContacts); err != nil {
+		return nil, err
+	}
+
+	link, err := s.linkRepo.Get(ctx, workspaceID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(link)
+	if err := s.validateReferences(ctx, link); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.linkRepo.Update(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "update", updated.ID)
+	return s.withShortURL(updated), nil
+}
+
+// DeleteLink remove o link e seus cliques; as atividades LINK_CLICK permanecem na timeline.
+// Permission: admin, manager.
+func (s *TrackedLinkService) DeleteLink(ctx context.Context, workspaceID, linkID, actorID string) error {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.CanDeleteContacts); err != nil {
+		return err
+	}
+	if err := s.linkRepo.Delete(ctx, workspaceID, linkID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, workspaceID, actorID, "delete", linkID)
+	return nil
+}
+
+// ListClicks lista os cliques do link, mais recentes primeiro (paginação por cursor).
+// Permission: all workspace members.
+func (s *TrackedLinkService) ListClicks(ctx context.Context, workspaceID, actorID string, params domain.ListTrackedLinkClicksParams) (*domain.TrackedLinkClickListResponse, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+	if _, err := s.linkRepo.Get(ctx, workspaceID, params.LinkID); err != nil {
+		return nil, err
+	}
+
+	params.WorkspaceID = workspaceID
+	params.Normalize()
+
+	clicks, err := s.linkRepo.ListClicks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.TrackedLinkClickListResponse{Data: clicks}
+	if len(clicks) > params.Limit {
+		response.Data = clicks[:params.Limit]
+		nextCursor := response.Data[params.Limit-1].ClickedAt.Format(time.RFC3339Nano)
+		response.Meta.HasNextPage = true
+		response.Meta.NextCursor = &nextCursor
+	}
+	return response, nil
+}
+
+// ContactURL gera a URL curta com o contact token de um contato: um mesmo link (ex.: proposta
+// padrão) enviado a vários contatos registra cada clique na timeline de quem clicou.
+// Permission: all workspace members.
+func (s *TrackedLinkService) ContactURL(ctx context.Context, workspaceID, linkID, contactID, actorID string) (*domain.TrackedLinkContactURL, error) {
+	if err := s.authorize(ctx, workspaceID, actorID, domain.IsWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	link, err := s.linkRepo.Get(ctx, workspaceID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.contactRepo.Get(ctx, workspaceID, contactID); err != nil {
+		if errors.Is(err, repo.ErrContactNotFound) {
+			return nil, ErrInvalidContact
+		}
+		return nil, fmt.Errorf("validate contact: %w", err)
+	}
+
+	token := signContactToken(link, contactID)
+	return &domain.TrackedLinkContactURL{
+		LinkID:    link.ID,
+		ContactID: contactID,
+		Token:     token,
+		ShortURL:  s.shortURL(link.Code) + "?c=" + url.QueryEscape(token),
+	}, nil
+}
+
+// ResolveLink busca o link pelo code (redirecionamento público, sem autenticação).
+func (s *TrackedLinkService) ResolveLink(ctx context.Context, code string) (*domain.TrackedLink, error) {
+	return s.linkRepo.GetByCode(ctx, code)
+}
+
+// RecordClick registra o clique e, com contato conhecido, o LINK_CLICK na timeline.
+// Best-effort: falhas são logadas e o visitante é redirecionado de qualquer forma.
+// O contact token (se válido) prevalece sobre o contactId do link.
+func (s *TrackedLinkService) RecordClick(ctx context.Context, link *domain.TrackedLink, contactToken, referrer, userAgent string) {
+	contactID := link.ContactID
+	if contactToken != "" {
+		if id, ok := verifyContactToken(link, contactToken); ok {
+			contactID = &id
+		} else {
+			s.log.Warn(ctx, "ignored invalid link contact token",
+				logger.Module("tracked_link"),
+				logger.Action("click"),
+				zap.String("link_id", link.ID),
+			)
+		}
+	}
+
+	// O contato pode ter sido removido depois do envio: o clique fica anônimo
+	var contact *domain.Contact
+	if contactID != nil {
+		c, err := s.contactRepo.Get(ctx, link.WorkspaceID, *contactID)
+		if err == nil {
+			contact = c
+		} else if !errors.Is(err, repo.ErrContactNotFound) {
+			s.log.Warn(ctx, "failed to load clicked link contact",
+				logger.Module("tracked_link"),
+				logger.Action("click"),
+				zap.String("link_id", link.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	click := &domain.TrackedLinkClick{
+		WorkspaceID: link.WorkspaceID,
+		LinkID:      link.ID,
+		Referrer:    truncateOptional(referrer, 2048),
+		UserAgent:   truncateOptional(userAgent, 512),
+	}
+	if contact != nil {
+		click.ContactID = &contact.ID
+	}
+	clickID, err := id.New(id.TrackedLinkClick)
+	if err == nil {
+		click.ID = clickID
+		err = s.linkRepo.RecordClick(ctx, click)
+	}
+	if err != nil {
+		s.log.Warn(ctx, "failed to record link click",
+			logger.Module("tracked_link"),
+			logger.Action("click"),
+			zap.String("link_id", link.ID),
+			zap.Error(err),
+		)
+		return
+	}
+	if contact == nil {
+		return
+	}
+
+	metadata := map[string]interface{}{
+		"linkId":         link.ID,
+		"clickId":        click.ID,
+		"code":           link.Code,
+		"name":           link.Name,
+		"destinationUrl": link.DestinationURL,
+		"attribution":    link.Attribution(),
+	}
+	if click.Referrer != nil {
+		metadata["referrer"] = *click.Referrer
+	}
+
+	// Event: LINK_CLICK na timeline do contato (e do negócio/empresa, se houver)
+	metadataJSON, _ := json.Marshal(metadata)
+	activityID, err := id.New(id.Activity)
+	if err == nil {
+		_, err = s.activityRepo.CreateActivity(ctx, &domain.Activity{
+			ID:          activityID,
+			WorkspaceID: link.WorkspaceID,
+			CompanyID:   contact.CompanyID,
+			ContactID:   &contact.ID,
+			DealID:      link.DealID,
+			Type:        domain.ActivityTypeLinkClick,
+			UserID:      link.CreatedByID,
+			Metadata:    metadataJSON,
+			CreatedAt:   click.ClickedAt,
+		})
+	}
+	if err != nil {
+		s.log.Warn(ctx, "failed to record link click activity",
+			logger.Module("tracked_link"),
+			logger.Action("click"),
+			zap.String("link_id", link.ID),
+			zap.String("contact_id", contact.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// validateReferences confere que contato e negócio pertencem ao workspace.
+func (s *TrackedLinkService) validateReferences(ctx context.Context, link *domain.TrackedLink) error {
+	if link.ContactID != nil {
+		if _, err := s.contactRepo.Get(ctx, link.WorkspaceID, *link.ContactID); err != nil {
+			if errors.Is(err, repo.ErrContactNotFound) {
+				return ErrInvalidContact
+			}
+			return fmt.Errorf("validate contact: %w", err)
+		}
+	}
+	if link.DealID != nil {
+		if _, err := s.dealRepo.Get(ctx, link.WorkspaceID, *link.DealID); err != nil {
+			if errors.Is(err, repo.ErrDealNotFound) {
+				return ErrInvalidLinkDeal
+			}
+			return fmt.Errorf("validate deal: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *TrackedLinkService) withShortURL(link *domain.TrackedLink) *domain.TrackedLink {
+	link.ShortURL = s.shortURL(link.Code)
+	return link
+}
+
+func (s *TrackedLinkService) shortURL(code string) string {
+	return s.baseURL + "/l/" + code
+}
+
+// signContactToken "<contactId em base64url>.<HMAC truncado>", assinado com o segredo do link
+func signContactToken(link *domain.TrackedLink, contactID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(contactID)) + "." + contactTokenMAC(link, contactID)
+}
+
+func verifyContactToken(link *domain.TrackedLink, token string) (string, bool) {
+	encoded, mac, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(raw) == 0 {
+		return "", false
+	}
+	contactID := string(raw)
+	if !hmac.Equal([]byte(mac), []byte(contactTokenMAC(link, contactID))) {
+		return "", false
+	}
+	return contactID, true
+}
+
+func contactTokenMAC(link *domain.TrackedLink, contactID string) string {
+	h := hmac.New(sha256.New, link.TokenSecret)
+	h.Write([]byte(link.ID + ":" + contactID))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:16])
+}
+
+func truncateOptional(s string, max int) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	if len(s) > max {
+		s = s[:max]
+	}
+	return &s
+}
+
+// generateTrackedLinkCode 10 caracteres base32 (50 bits): curto e impossível de enumerar
+func generateTrackedLinkCode() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return strings.ToLower(base32.StdEncoding.EncodeToString(b)[:10])
+}
+
+func (s *TrackedLinkService) logAction(ctx context.Context, workspaceID, actorID, action, id string) {
+	idStr := id
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, action, "tracked_link", &idStr, nil, "", "")
+}
@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestTrackedLinkService_Integration
+func TestTrackedLinkService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	activities := repo.NewActivityRepository(pool)
+	svc := service.NewTrackedLinkService(repo.NewTrackedLinkRepository(pool), repo.NewContactRepository(pool),
+		repo.NewDealRepository(pool), activities, repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool),
+		"https://lnk.example.com", log)
+
+	contact := f.Contact()
+	user := f.Member(domain.RoleUser)
+
+	t.Run("viewers cannot create links", func(t *testing.T) {
+		_, err := svc.CreateLink(ctx, f.WorkspaceID, f.Member(domain.RoleViewer), &domain.CreateTrackedLinkRequest{
+			Name: "Proposta", DestinationURL: "https://example.com/proposta",
+		})
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+
+	t.Run("deal must belong to the workspace", func(t *testing.T) {
+		_, err := svc.CreateLink(ctx, f.WorkspaceID, user, &domain.CreateTrackedLinkRequest{
+			Name: "Proposta", DestinationURL: "https://example.com/proposta", DealID: factory.Ptr("deal_missing"),
+		})
+		assert.ErrorIs(t, err, service.ErrInvalidLinkDeal)
+	})
+
+	link, err := svc.CreateLink(ctx, f.WorkspaceID, user, &domain.CreateTrackedLinkRequest{
+		Name: "Proposta padrão", DestinationURL: "https://example.com/proposta", UTMCampaign: factory.Ptr("q1"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, link.Code, 10)
+	assert.Equal(t, "https://lnk.example.com/l/"+link.Code, link.ShortURL)
+
+	timeline := func(t *testing.T) []domain.Activity {
+		t.Helper()
+		linkClick := domain.ActivityTypeLinkClick
+		list, err := activities.List(ctx, f.WorkspaceID, &contact.ID, nil, nil, nil, &linkClick, nil)
+		require.NoError(t, err)
+		return list
+	}
+
+	t.Run("anonymous clicks stay off the timeline", func(t *testing.T) {
+		resolved, err := svc.ResolveLink(ctx, link.Code)
+		require.NoError(t, err)
+		svc.RecordClick(ctx, resolved, "", "https://mail.example.com", "Mozilla/5.0")
+		svc.RecordClick(ctx, resolved, "forjado.token", "", "")
+
+		clicks, err := svc.ListClicks(ctx, f.WorkspaceID, user, domain.ListTrackedLinkClicksParams{LinkID: link.ID})
+		require.NoError(t, err)
+		require.Len(t, clicks.Data, 2)
+		for _, click := range clicks.Data {
+			assert.Nil(t, click.ContactID)
+		}
+		assert.Empty(t, timeline(t))
+	})
+
+	t.Run("contact token attributes the click", func(t *testing.T) {
+		contactURL, err := svc.ContactURL(ctx, f.WorkspaceID, link.ID, contact.ID, user)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(contactURL.ShortURL, link.ShortURL+"?c="))
+		assert.Contains(t, contactURL.ShortURL, url.QueryEscape(contactURL.Token))
+
+		resolved, err := svc.ResolveLink(ctx, link.Code)
+		require.NoError(t, err)
+		svc.RecordClick(ctx, resolved, contactURL.Token, "", "")
+
+		clicks, err := svc.ListClicks(ctx, f.WorkspaceID, user, domain.ListTrackedLinkClicksParams{LinkID: link.ID, ContactID: &contact.ID})
+		require.NoError(t, err)
+		require.Len(t, clicks.Data, 1)
+
+		events := timeline(t)
+		require.Len(t, events, 1)
+		var metadata map[string]any
+		require.NoError(t, json.Unmarshal(events[0].Metadata, &metadata))
+		assert.Equal(t, link.ID, metadata["linkId"])
+		assert.Equal(t, link.Code, metadata["code"])
+
+		counted, err := svc.GetLink(ctx, f.WorkspaceID, link.ID, user)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), counted.ClickCount)
+		assert.NotNil(t, counted.LastClickedAt)
+	})
+
+	t.Run("patch clears optional values", func(t *testing.T) {
+		req := &domain.UpdateTrackedLinkRequest{UTMCampaign: factory.Ptr(""), ContactID: &contact.ID}
+		require.NoError(t, req.Validate())
+		updated, err := svc.UpdateLink(ctx, f.WorkspaceID, link.ID, user, req)
+		require.NoError(t, err)
+		assert.Nil(t, updated.UTMCampaign)
+		assert.Equal(t, contact.ID, *updated.ContactID)
+		assert.Equal(t, link.Code, updated.Code, "code never changes")
+	})
+
+	t.Run("deleted links no longer resolve", func(t *testing.T) {
+		require.NoError(t, svc.DeleteLink(ctx, f.WorkspaceID, link.ID, f.Member(domain.RoleManager)))
+		_, err := svc.ResolveLink(ctx, link.Code)
+		assert.ErrorIs(t, err, service.ErrTrackedLinkNotFound)
+		assert.Len(t, timeline(t), 1, "LINK_CLICK activities are kept")
+	})
+}
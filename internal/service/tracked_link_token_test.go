@@ -0,0 +1,55 @@
+package service
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"testing"
+
+	"linkko-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactToken_RoundTrip(t *testing.T) {
+	link := &domain.TrackedLink{ID: "lnk_1", TokenSecret: []byte("segredo-do-link")}
+	token := signContactToken(link, "ctc_1")
+
+	contactID, ok := verifyContactToken(link, token)
+	assert.True(t, ok)
+	assert.Equal(t, "ctc_1", contactID)
+
+	// Token de outro contato não pode ser reaproveitado trocando o ID
+	_, mac, _ := strings.Cut(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte("ctc_2")) + "." + mac
+	_, ok = verifyContactToken(link, forged)
+	assert.False(t, ok)
+
+	// O segredo e o ID são por link
+	_, ok = verifyContactToken(&domain.TrackedLink{ID: "lnk_1", TokenSecret: []byte("outro")}, token)
+	assert.False(t, ok)
+	_, ok = verifyContactToken(&domain.TrackedLink{ID: "lnk_2", TokenSecret: link.TokenSecret}, token)
+	assert.False(t, ok)
+
+	for _, invalid := range []string{"", "semponto", ".mac", "!!!.mac"} {
+		_, ok := verifyContactToken(link, invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestGenerateTrackedLinkCode(t *testing.T) {
+	pattern := regexp.MustCompile(`^[a-z2-7]{10}$`)
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code := generateTrackedLinkCode()
+		assert.Regexp(t, pattern, code)
+		assert.False(t, seen[code], "duplicate code %s", code)
+		seen[code] = true
+	}
+}
+
+func TestTruncateOptional(t *testing.T) {
+	assert.Nil(t, truncateOptional("   ", 10))
+	assert.Equal(t, "abc", *truncateOptional(" abc ", 10))
+	assert.Equal(t, "abcde", *truncateOptional("abcdefgh", 5))
+}
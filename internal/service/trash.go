@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// TrashService é a superfície única de recuperação do workspace: lista os registros
+// excluídos de todas as entidades dentro da retenção e os restaura.
+type TrashService struct {
+	trashRepo     *repo.TrashRepository
+	contactRepo   *repo.ContactRepository
+	companyRepo   *repo.CompanyRepository
+	dealRepo      *repo.DealRepository
+	taskRepo      *repo.TaskRepository
+	pipelineRepo  *repo.PipelineRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	counters      *CounterService
+	retention     time.Duration
+	log           *logger.Logger
+}
+
+func NewTrashService(trashRepo *repo.TrashRepository, contactRepo *repo.ContactRepository, companyRepo *repo.CompanyRepository, dealRepo *repo.DealRepository, taskRepo *repo.TaskRepository, pipelineRepo *repo.PipelineRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, counters *CounterService, retention time.Duration, log *logger.Logger) *TrashService {
+	return &TrashService{
+		trashRepo:     trashRepo,
+		contactRepo:   contactRepo,
+		companyRepo:   companyRepo,
+		dealRepo:      dealRepo,
+		taskRepo:      taskRepo,
+		pipelineRepo:  pipelineRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		counters:      counters,
+		retention:     retention,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *TrashService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("trash"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// ListTrash lista os registros excluídos ainda não purgados, com quem excluiu e quanto
+// falta para a purga.
+// Permission: admin, manager (quem pode excluir).
+func (s *TrashService) ListTrash(ctx context.Context, workspaceID, actorID string, params domain.ListTrashParams) (*domain.TrashListResponse, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanDeleteContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 50
+	}
+	now := time.Now().UTC()
+	params.WorkspaceID = workspaceID
+	params.DeletedAfter = now.Add(-s.retention)
+	params.Viewer = domain.VisibilityViewer(actorID, role)
+
+	items, err := s.trashRepo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &domain.TrashListResponse{}
+	if len(items) > params.Limit {
+		items = items[:params.Limit]
+		cursor := items[len(items)-1].DeletedAt.Format(time.RFC3339Nano)
+		resp.Meta.HasNextPage = true
+		resp.Meta.NextCursor = &cursor
+	}
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(s.retention)
+		items[i].PurgesInSeconds = max(int64(items[i].PurgeAt.Sub(now).Seconds()), 0)
+	}
+	resp.Data = items
+	return resp, nil
+}
+
+// Restore limpa o soft delete do registro e o retorna já restaurado.
+// Permission: admin, manager (quem pode excluir).
+func (s *TrashService) Restore(ctx context.Context, workspaceID, actorID string, entityType domain.TrashEntityType, entityID string) (*domain.TrashRestoreResult, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanDeleteContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	data, err := s.restore(ctx, workspaceID, entityType, entityID, domain.VisibilityViewer(actorID, role))
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "restore", string(entityType), &entityID, nil, "", "")
+
+	return &domain.TrashRestoreResult{EntityType: entityType, EntityID: entityID, Data: data}, nil
+}
+
+// restore limpa o soft delete; viewer filtra as tarefas como na listagem da lixeira.
+func (s *TrashService) restore(ctx context.Context, workspaceID string, entityType domain.TrashEntityType, entityID string, viewer *string) (any, error) {
+	switch entityType {
+	case domain.TrashEntityContact:
+		if err := s.contactRepo.Restore(ctx, workspaceID, entityID); err != nil {
+			return nil, err
+		}
+		s.counters.Invalidate(ctx, workspaceID)
+		return s.contactRepo.Get(ctx, workspaceID, entityID)
+	case domain.TrashEntityCompany:
+		if err := s.companyRepo.Restore(ctx, workspaceID, entityID); err != nil {
+			return nil, err
+		}
+		return s.companyRepo.Get(ctx, workspaceID, entityID)
+	case domain.TrashEntityDeal:
+		if err := s.dealRepo.Restore(ctx, workspaceID, entityID); err != nil {
+			return nil, err
+		}
+		deal, err := s.dealRepo.Get(ctx, workspaceID, entityID)
+		if err != nil {
+			return nil, err
+		}
+		s.counters.DealChanged(ctx, workspaceID, nil, deal)
+		return deal, nil
+	case domain.TrashEntityTask:
+		if err := s.taskRepo.RestoreVisible(ctx, workspaceID, entityID, viewer); err != nil {
+			return nil, err
+		}
+		task, err := s.taskRepo.Get(ctx, workspaceID, entityID)
+		if err != nil {
+			return nil, err
+		}
+		s.counters.TaskChanged(ctx, workspaceID, nil, task)
+		return task, nil
+	case domain.TrashEntityPipeline:
+		if err := s.pipelineRepo.Restore(ctx, workspaceID, entityID); err != nil {
+			return nil, err
+		}
+		s.counters.Invalidate(ctx, workspaceID) // negócios excluídos junto com o pipeline
+		return s.pipelineRepo.Get(ctx, workspaceID, entityID)
+	default:
+		return nil, fmt.Errorf("trash: unsupported entity type %q", entityType)
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// UndoService emite os tokens de desfazer devolvidos pelos DELETEs de contatos, empresas
+// e tarefas e restaura o registro (limpa o soft delete) quando o token é apresentado
+// dentro da janela.
+type UndoService struct {
+	undoRepo      *repo.UndoRepository
+	contactRepo   *repo.ContactRepository
+	companyRepo   *repo.CompanyRepository
+	taskRepo      *repo.TaskRepository
+	workspaceRepo *repo.WorkspaceRepository
+	auditRepo     *repo.AuditRepo
+	counters      *CounterService
+	window        time.Duration
+	log           *logger.Logger
+}
+
+func NewUndoService(undoRepo *repo.UndoRepository, contactRepo *repo.ContactRepository, companyRepo *repo.CompanyRepository, taskRepo *repo.TaskRepository, workspaceRepo *repo.WorkspaceRepository, auditRepo *repo.AuditRepo, counters *CounterService, window time.Duration, log *logger.Logger) *UndoService {
+	return &UndoService{
+		undoRepo:      undoRepo,
+		contactRepo:   contactRepo,
+		companyRepo:   companyRepo,
+		taskRepo:      taskRepo,
+		workspaceRepo: workspaceRepo,
+		auditRepo:     auditRepo,
+		counters:      counters,
+		window:        window,
+		log:           log,
+	}
+}
+
+// getMemberRoleWithLogging wraps GetMemberRole with authorization audit logging.
+func (s *UndoService) getMemberRoleWithLogging(ctx context.Context, actorID, workspaceID string) (domain.Role, error) {
+	ctx, span := telemetry.StartSpan(ctx, "rbac.member_role", attribute.String("workspace.id", workspaceID))
+	role, err := s.workspaceRepo.GetMemberRole(ctx, actorID, workspaceID)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		s.log.Error(ctx, "failed to get member role",
+			logger.Module("undo"),
+			logger.Action("authorization"),
+			zap.String("actor_id", actorID),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err),
+		)
+		if errors.Is(err, repo.ErrMemberNotFound) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("get member role: %w", err)
+	}
+	return role, nil
+}
+
+// Issue emite um token para desfazer o DELETE que o ator acabou de fazer.
+// Seguro com receiver nil. Falhas são registradas em log e retornam nil: o DELETE já
+// aconteceu e não deve falhar por causa do token.
+func (s *UndoService) Issue(ctx context.Context, workspaceID, actorID string, entityType domain.UndoEntityType, entityID string) *domain.UndoReceipt {
+	if s == nil {
+		return nil
+	}
+
+	token := generateUndoToken()
+	expiresAt := time.Now().UTC().Add(s.window).Truncate(time.Millisecond)
+	err := s.undoRepo.Create(ctx, &domain.UndoToken{
+		TokenHash:   hashUndoToken(token),
+		WorkspaceID: workspaceID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		ActorID:     actorID,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		s.log.Warn(ctx, "failed to issue undo token",
+			logger.Module("undo"),
+			logger.Action("issue"),
+			zap.String("workspace_id", workspaceID),
+			zap.String("entity_type", string(entityType)),
+			zap.String("entity_id", entityID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	return &domain.UndoReceipt{UndoToken: token, UndoExpiresAt: expiresAt}
+}
+
+// Undo consome o token e restaura o registro excluído.
+// Permission: o mesmo ator que excluiu, desde que ainda possa excluir (admin, manager).
+// A restauração é registrada no audit log como "restore" com metadata undo=true.
+func (s *UndoService) Undo(ctx context.Context, workspaceID, actorID string, req *domain.UndoRequest) (*domain.UndoResult, error) {
+	role, err := s.getMemberRoleWithLogging(ctx, actorID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.CanDeleteContacts(role) {
+		return nil, ErrUnauthorized
+	}
+
+	tokenHash := hashUndoToken(req.UndoToken)
+	token, err := s.undoRepo.Consume(ctx, workspaceID, actorID, tokenHash, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.restore(ctx, workspaceID, token)
+	if err != nil {
+		// Token volta a valer: a falha pode ser transitória
+		if releaseErr := s.undoRepo.Release(ctx, tokenHash); releaseErr != nil {
+			s.log.Warn(ctx, "failed to release undo token",
+				logger.Module("undo"),
+				logger.Action("undo"),
+				zap.String("workspace_id", workspaceID),
+				zap.Error(releaseErr),
+			)
+		}
+		return nil, err
+	}
+
+	entityID := token.EntityID
+	_ = s.auditRepo.LogAction(ctx, workspaceID, actorID, "restore", string(token.EntityType), &entityID, map[string]interface{}{"undo": true}, "", "")
+
+	return &domain.UndoResult{
+		EntityType: token.EntityType,
+		EntityID:   token.EntityID,
+		Data:       data,
+	}, nil
+}
+
+// restore limpa o soft delete do registro e o retorna já restaurado.
+func (s *UndoService) restore(ctx context.Context, workspaceID string, token *domain.UndoToken) (any, error) {
+	switch token.EntityType {
+	case domain.UndoEntityContact:
+		if err := s.contactRepo.Restore(ctx, workspaceID, token.EntityID); err != nil {
+			return nil, err
+		}
+		// Igual ao DELETE: sem o createdAt não dá para ajustar newLeadsThisWeek
+		s.counters.Invalidate(ctx, workspaceID)
+		return s.contactRepo.Get(ctx, workspaceID, token.EntityID)
+	case domain.UndoEntityCompany:
+		if err := s.companyRepo.Restore(ctx, workspaceID, token.EntityID); err != nil {
+			return nil, err
+		}
+		return s.companyRepo.Get(ctx, workspaceID, token.EntityID)
+	case domain.UndoEntityTask:
+		if err := s.taskRepo.Restore(ctx, workspaceID, token.EntityID); err != nil {
+			return nil, err
+		}
+		task, err := s.taskRepo.Get(ctx, workspaceID, token.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		s.counters.TaskChanged(ctx, workspaceID, nil, task)
+		return task, nil
+	default:
+		return nil, fmt.Errorf("undo: unsupported entity type %q", token.EntityType)
+	}
+}
+
+// generateUndoToken cria o token opaco entregue ao cliente (só o hash é persistido).
+func generateUndoToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return "undo_" + strings.ToLower(strings.TrimRight(base32.StdEncoding.EncodeToString(b), "="))
+}
+
+func hashUndoToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
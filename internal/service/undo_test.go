@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/observability/logger"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/service"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/service -run TestUndoService_Integration
+func TestUndoService_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	log, err := logger.New("test", "error")
+	require.NoError(t, err)
+	contacts := repo.NewContactRepository(pool)
+	newService := func(window time.Duration) *service.UndoService {
+		return service.NewUndoService(repo.NewUndoRepository(pool), contacts, repo.NewCompanyRepository(pool),
+			repo.NewTaskRepository(pool), repo.NewWorkspaceRepository(pool), repo.NewAuditRepo(pool), nil, window, log)
+	}
+	svc := newService(10 * time.Minute)
+
+	// deleteContact reproduz o DELETE: soft delete e emissão do token pelo ator
+	deleteContact := func(svc *service.UndoService, actorID string) (*domain.Contact, *domain.UndoReceipt) {
+		contact := f.Contact()
+		require.NoError(t, contacts.SoftDelete(ctx, f.WorkspaceID, contact.ID, actorID))
+		receipt := svc.Issue(ctx, f.WorkspaceID, actorID, domain.UndoEntityContact, contact.ID)
+		require.NotNil(t, receipt)
+		return contact, receipt
+	}
+
+	t.Run("restores within the window", func(t *testing.T) {
+		contact, receipt := deleteContact(svc, f.UserID)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), receipt.UndoExpiresAt, time.Minute)
+
+		result, err := svc.Undo(ctx, f.WorkspaceID, f.UserID, &domain.UndoRequest{UndoToken: receipt.UndoToken})
+		require.NoError(t, err)
+		assert.Equal(t, domain.UndoEntityContact, result.EntityType)
+		assert.Equal(t, contact.ID, result.EntityID)
+
+		restored, err := contacts.Get(ctx, f.WorkspaceID, contact.ID)
+		require.NoError(t, err)
+		assert.Equal(t, contact.ID, restored.ID)
+
+		_, err = svc.Undo(ctx, f.WorkspaceID, f.UserID, &domain.UndoRequest{UndoToken: receipt.UndoToken})
+		assert.ErrorIs(t, err, repo.ErrUndoTokenInvalid, "tokens are single use")
+	})
+
+	t.Run("rejects after the window", func(t *testing.T) {
+		expired := newService(-time.Second)
+		contact, receipt := deleteContact(expired, f.UserID)
+
+		_, err := expired.Undo(ctx, f.WorkspaceID, f.UserID, &domain.UndoRequest{UndoToken: receipt.UndoToken})
+		assert.ErrorIs(t, err, repo.ErrUndoTokenInvalid)
+
+		_, err = contacts.Get(ctx, f.WorkspaceID, contact.ID)
+		assert.ErrorIs(t, err, repo.ErrContactNotFound, "the contact stays deleted")
+	})
+
+	t.Run("rejects a different user", func(t *testing.T) {
+		contact, receipt := deleteContact(svc, f.UserID)
+
+		otherAdmin := f.Member(domain.RoleAdmin)
+		_, err := svc.Undo(ctx, f.WorkspaceID, otherAdmin, &domain.UndoRequest{UndoToken: receipt.UndoToken})
+		assert.ErrorIs(t, err, repo.ErrUndoTokenInvalid)
+
+		_, err = svc.Undo(ctx, f.WorkspaceID, f.Member(domain.RoleUser), &domain.UndoRequest{UndoToken: receipt.UndoToken})
+		assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+		_, err = contacts.Get(ctx, f.WorkspaceID, contact.ID)
+		assert.ErrorIs(t, err, repo.ErrContactNotFound)
+
+		_, err = svc.Undo(ctx, f.WorkspaceID, f.UserID, &domain.UndoRequest{UndoToken: receipt.UndoToken})
+		assert.NoError(t, err, "the token is still valid for the actor who deleted")
+	})
+}
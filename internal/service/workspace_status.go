@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+)
+
+// workspaceStatusCacheMaxEntries limita a memória do cache; cheio, novas consultas não são cacheadas
+const workspaceStatusCacheMaxEntries = 50000
+
+// WorkspaceStatusService resolve se o workspace existe e está ativo, com cache em memória por
+// instância: a checagem roda em toda request de workspace. Workspaces inexistentes ficam menos
+// tempo no cache (negativeTTL) para um workspace recém-criado não esperar o TTL inteiro.
+// Implements middleware.WorkspaceStatusLookup.
+type WorkspaceStatusService struct {
+	workspaceRepo *repo.WorkspaceRepository
+	ttl           time.Duration
+	negativeTTL   time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]workspaceStatusEntry
+}
+
+type workspaceStatusEntry struct {
+	status    domain.WorkspaceStatus
+	found     bool
+	expiresAt time.Time
+}
+
+// NewWorkspaceStatusService ttl/negativeTTL <= 0 desligam o cache do respectivo resultado.
+func NewWorkspaceStatusService(workspaceRepo *repo.WorkspaceRepository, ttl, negativeTTL time.Duration) *WorkspaceStatusService {
+	return &WorkspaceStatusService{
+		workspaceRepo: workspaceRepo,
+		ttl:           ttl,
+		negativeTTL:   negativeTTL,
+		now:           time.Now,
+		entries:       make(map[string]workspaceStatusEntry),
+	}
+}
+
+// WorkspaceStatus retorna o status do workspace; found é false quando ele não existe.
+// Erros do banco não são cacheados.
+func (s *WorkspaceStatusService) WorkspaceStatus(ctx context.Context, workspaceID string) (domain.WorkspaceStatus, bool, error) {
+	if entry, ok := s.cached(workspaceID); ok {
+		return entry.status, entry.found, nil
+	}
+
+	status, err := s.workspaceRepo.GetStatus(ctx, workspaceID)
+	if err != nil && !errors.Is(err, repo.ErrWorkspaceNotFound) {
+		return "", false, err
+	}
+	found := err == nil
+
+	ttl := s.ttl
+	if !found {
+		ttl = s.negativeTTL
+	}
+	s.store(workspaceID, workspaceStatusEntry{status: status, found: found, expiresAt: s.now().Add(ttl)})
+	return status, found, nil
+}
+
+func (s *WorkspaceStatusService) cached(workspaceID string) (workspaceStatusEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[workspaceID]
+	if !ok {
+		return workspaceStatusEntry{}, false
+	}
+	if !s.now().Before(entry.expiresAt) {
+		delete(s.entries, workspaceID)
+		return workspaceStatusEntry{}, false
+	}
+	return entry, true
+}
+
+func (s *WorkspaceStatusService) store(workspaceID string, entry workspaceStatusEntry) {
+	now := s.now()
+	if !now.Before(entry.expiresAt) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.entries) >= workspaceStatusCacheMaxEntries {
+		for k, e := range s.entries {
+			if !now.Before(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+		if len(s.entries) >= workspaceStatusCacheMaxEntries {
+			return
+		}
+	}
+	s.entries[workspaceID] = entry
+}
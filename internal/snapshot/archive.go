@@ -0,0 +1,265 @@
+// Package snapshot implementa o formato de arquivo dos snapshots de workspace:
+// um tar.gz com manifest.json seguido de um <entidade>.ndjson por entidade,
+// na ordem em que as entidades devem ser restauradas (dependências primeiro).
+package snapshot
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"linkko-api/internal/domain"
+)
+
+const (
+	manifestName = "manifest.json"
+	entityExt    = ".ndjson"
+
+	// MaxRowBytes limita uma linha NDJSON na leitura.
+	MaxRowBytes = 16 << 20
+)
+
+// ErrInvalidArchive indica um arquivo que não segue o formato de snapshot.
+var ErrInvalidArchive = errors.New("invalid snapshot archive")
+
+// Writer acumula as linhas de cada entidade em arquivos temporários (o tar precisa do
+// tamanho de cada membro antes do conteúdo) e monta o arquivo final em WriteArchive.
+// Close remove os temporários.
+type Writer struct {
+	dir     string
+	order   []string
+	files   map[string]*os.File
+	buffers map[string]*bufio.Writer
+	counts  map[string]int64
+}
+
+// NewWriter cria um Writer com diretório temporário próprio.
+func NewWriter() (*Writer, error) {
+	dir, err := os.MkdirTemp("", "linkko-snapshot-*")
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot temp dir: %w", err)
+	}
+	return &Writer{
+		dir:     dir,
+		files:   map[string]*os.File{},
+		buffers: map[string]*bufio.Writer{},
+		counts:  map[string]int64{},
+	}, nil
+}
+
+// Begin registra uma entidade mesmo que ela não tenha linhas, fixando sua posição no arquivo.
+func (w *Writer) Begin(entity string) error {
+	if _, ok := w.files[entity]; ok {
+		return nil
+	}
+	if !validEntityName(entity) {
+		return fmt.Errorf("invalid entity name %q", entity)
+	}
+	f, err := os.Create(path.Join(w.dir, entity+entityExt))
+	if err != nil {
+		return fmt.Errorf("create entity file: %w", err)
+	}
+	w.order = append(w.order, entity)
+	w.files[entity] = f
+	w.buffers[entity] = bufio.NewWriter(f)
+	w.counts[entity] = 0
+	return nil
+}
+
+// Add grava uma linha (um objeto JSON, sem quebra de linha) da entidade.
+func (w *Writer) Add(entity string, row []byte) error {
+	if err := w.Begin(entity); err != nil {
+		return err
+	}
+	buf := w.buffers[entity]
+	if _, err := buf.Write(row); err != nil {
+		return fmt.Errorf("write %s row: %w", entity, err)
+	}
+	if err := buf.WriteByte('\n'); err != nil {
+		return fmt.Errorf("write %s row: %w", entity, err)
+	}
+	w.counts[entity]++
+	return nil
+}
+
+// WriteArchive escreve o tar.gz em out e retorna o manifest gravado.
+func (w *Writer) WriteArchive(out io.Writer, workspaceID string, createdAt time.Time) (*domain.SnapshotManifest, error) {
+	manifest := &domain.SnapshotManifest{
+		Version:     domain.SnapshotFormatVersion,
+		WorkspaceID: workspaceID,
+		CreatedAt:   createdAt.UTC(),
+		Entities:    make(map[string]int64, len(w.counts)),
+	}
+	for entity, n := range w.counts {
+		manifest.Entities[entity] = n
+	}
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	if err := writeMember(tw, manifestName, int64(len(manifestJSON)), createdAt, bytes.NewReader(manifestJSON)); err != nil {
+		return nil, err
+	}
+	for _, entity := range w.order {
+		if err := w.buffers[entity].Flush(); err != nil {
+			return nil, fmt.Errorf("flush %s: %w", entity, err)
+		}
+		f := w.files[entity]
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", entity, err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("rewind %s: %w", entity, err)
+		}
+		if err := writeMember(tw, entity+entityExt, info.Size(), createdAt, f); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("close tar: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("close gzip: %w", err)
+	}
+	return manifest, nil
+}
+
+// Close fecha e remove os arquivos temporários.
+func (w *Writer) Close() error {
+	for _, f := range w.files {
+		f.Close()
+	}
+	return os.RemoveAll(w.dir)
+}
+
+func writeMember(tw *tar.Writer, name string, size int64, modTime time.Time, r io.Reader) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o640,
+		Size:    size,
+		ModTime: modTime.UTC(),
+		Format:  tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write %s header: %w", name, err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// RowFunc recebe cada linha de uma entidade durante a leitura.
+// row só é válido durante a chamada.
+type RowFunc func(entity string, row []byte) error
+
+// ReadArchive lê o arquivo em streaming: valida o manifest (primeiro membro) e chama fn
+// para cada linha, na ordem do arquivo. As contagens de linhas são conferidas contra o manifest.
+func ReadArchive(in io.Reader, fn RowFunc) (*domain.SnapshotManifest, error) {
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return nil, fmt.Errorf("%w: %s must be the first member", ErrInvalidArchive, manifestName)
+	}
+	var manifest domain.SnapshotManifest
+	if err := json.NewDecoder(io.LimitReader(tr, MaxRowBytes)).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: decode manifest: %v", ErrInvalidArchive, err)
+	}
+	if manifest.Version != domain.SnapshotFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidArchive, manifest.Version)
+	}
+
+	seen := map[string]bool{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+
+		entity, ok := strings.CutSuffix(hdr.Name, entityExt)
+		if !ok || !validEntityName(entity) || seen[entity] {
+			return nil, fmt.Errorf("%w: unexpected member %q", ErrInvalidArchive, hdr.Name)
+		}
+		expected, ok := manifest.Entities[entity]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not listed in the manifest", ErrInvalidArchive, entity)
+		}
+		seen[entity] = true
+
+		n, err := readRows(tr, entity, fn)
+		if err != nil {
+			return nil, err
+		}
+		if n != expected {
+			return nil, fmt.Errorf("%w: %s has %d rows, manifest lists %d", ErrInvalidArchive, entity, n, expected)
+		}
+	}
+
+	for entity := range manifest.Entities {
+		if !seen[entity] {
+			return nil, fmt.Errorf("%w: %s is missing", ErrInvalidArchive, entity)
+		}
+	}
+	return &manifest, nil
+}
+
+func readRows(r io.Reader, entity string, fn RowFunc) (int64, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxRowBytes)
+
+	var n int64
+	for scanner.Scan() {
+		row := scanner.Bytes()
+		if len(row) == 0 {
+			continue
+		}
+		if !json.Valid(row) {
+			return n, fmt.Errorf("%w: %s row %d is not valid JSON", ErrInvalidArchive, entity, n+1)
+		}
+		if err := fn(entity, row); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := scanner.Err(); err != nil {
+		return n, fmt.Errorf("%w: read %s: %v", ErrInvalidArchive, entity, err)
+	}
+	return n, nil
+}
+
+// validEntityName aceita nomes de tabela simples (letras, dígitos e "_").
+func validEntityName(name string) bool {
+	if name == "" || len(name) > 63 {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
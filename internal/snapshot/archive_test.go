@@ -0,0 +1,93 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive_RoundTripPreservesOrderAndRows(t *testing.T) {
+	w, err := NewWriter()
+	require.NoError(t, err)
+	defer w.Close()
+
+	require.NoError(t, w.Add("Company", []byte(`{"id":"cmp_1"}`)))
+	require.NoError(t, w.Begin("Tag"))
+	require.NoError(t, w.Add("Contact", []byte(`{"id":"ctc_1","companyId":"cmp_1"}`)))
+	require.NoError(t, w.Add("Contact", []byte(`{"id":"ctc_2"}`)))
+
+	var buf bytes.Buffer
+	createdAt := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	written, err := w.WriteArchive(&buf, "ws_1", createdAt)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"Company": 1, "Tag": 0, "Contact": 2}, written.Entities)
+
+	type row struct{ entity, data string }
+	var rows []row
+	manifest, err := ReadArchive(&buf, func(entity string, data []byte) error {
+		rows = append(rows, row{entity, string(data)})
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ws_1", manifest.WorkspaceID)
+	assert.True(t, manifest.CreatedAt.Equal(createdAt))
+	assert.Equal(t, written.Entities, manifest.Entities)
+	assert.Equal(t, []row{
+		{"Company", `{"id":"cmp_1"}`},
+		{"Contact", `{"id":"ctc_1","companyId":"cmp_1"}`},
+		{"Contact", `{"id":"ctc_2"}`},
+	}, rows)
+}
+
+func TestWriter_RejectsInvalidEntityName(t *testing.T) {
+	w, err := NewWriter()
+	require.NoError(t, err)
+	defer w.Close()
+
+	assert.Error(t, w.Add("../Contact", []byte(`{}`)))
+	assert.Error(t, w.Add(`Contact"; DROP`, []byte(`{}`)))
+}
+
+func TestReadArchive_RejectsMalformedArchives(t *testing.T) {
+	build := func(members ...[2]string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for _, m := range members {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: m[0], Mode: 0o640, Size: int64(len(m[1]))}))
+			_, err := tw.Write([]byte(m[1]))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+		return &buf
+	}
+	manifest := `{"version":1,"workspaceId":"ws_1","createdAt":"2026-10-17T00:00:00Z","entities":{"Contact":1}}`
+	noop := func(string, []byte) error { return nil }
+
+	tests := []struct {
+		name    string
+		archive *bytes.Buffer
+	}{
+		{"not gzip", bytes.NewBufferString("plain text")},
+		{"manifest not first", build([2]string{"Contact.ndjson", "{}\n"}, [2]string{"manifest.json", manifest})},
+		{"unsupported version", build([2]string{"manifest.json", `{"version":99,"entities":{}}`})},
+		{"entity not in manifest", build([2]string{"manifest.json", manifest}, [2]string{"Company.ndjson", "{}\n"})},
+		{"row count mismatch", build([2]string{"manifest.json", manifest}, [2]string{"Contact.ndjson", "{}\n{}\n"})},
+		{"invalid json row", build([2]string{"manifest.json", manifest}, [2]string{"Contact.ndjson", "{oops\n"})},
+		{"missing entity", build([2]string{"manifest.json", manifest})},
+		{"path traversal", build([2]string{"manifest.json", manifest}, [2]string{"../Contact.ndjson", "{}\n"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadArchive(tt.archive, noop)
+			assert.ErrorIs(t, err, ErrInvalidArchive)
+		})
+	}
+}
@@ -3,34 +3,55 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// exemplarFeatureEnv feature flag do SDK OTel que liga a coleta de exemplars
+const exemplarFeatureEnv = "OTEL_GO_X_EXEMPLAR"
+
 // Metrics holds all application metrics
 type Metrics struct {
 	RequestsTotal       metric.Int64Counter
 	RequestDuration     metric.Float64Histogram
 	RateLimitRejections metric.Int64Counter
+	// Decisões do limitador local enquanto o Redis está indisponível (atributo: circuit_open)
+	RateLimitFallbacks metric.Int64Counter
+
+	// Circuit breakers (Redis/Postgres)
+	CircuitBreakerTransitions metric.Int64Counter
+	CircuitBreakerOpen        metric.Int64UpDownCounter
+
+	// Cache de decisões de autenticação (hit rate = hit / total)
+	AuthCacheLookups metric.Int64Counter
+
+	// Requests recusadas pelo WorkspaceStatusMiddleware (atributo: reason = not_found|suspended)
+	WorkspaceRejections metric.Int64Counter
 }
 
-// InitMetrics initializes OpenTelemetry metrics with OTLP gRPC exporter
-func InitMetrics(ctx context.Context, serviceName, endpoint string) (*sdkmetric.MeterProvider, *Metrics, error) {
+// InitMetrics initializes OpenTelemetry metrics with OTLP gRPC exporter.
+// Com exemplars, medições feitas dentro de um span amostrado (ex.: http_request_duration_seconds,
+// gravada sob o span do OTelMiddleware) carregam trace_id/span_id, ligando métricas a traces.
+func InitMetrics(ctx context.Context, serviceName, region, endpoint string, exemplars bool) (*sdkmetric.MeterProvider, *Metrics, error) {
+	if exemplars {
+		// Exemplars ainda são experimentais no SDK: habilitados pela feature flag de ambiente,
+		// lida na criação dos instrumentos. Um valor já definido pelo operador prevalece.
+		if _, ok := os.LookupEnv(exemplarFeatureEnv); !ok {
+			if err := os.Setenv(exemplarFeatureEnv, "true"); err != nil {
+				return nil, nil, fmt.Errorf("failed to enable exemplars: %w", err)
+			}
+		}
+	}
+
 	// Create resource
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-			semconv.ServiceVersion("1.0.0"),
-		),
-	)
+	res, err := newResource(ctx, serviceName, region)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create resource: %w", err)
 	}
@@ -92,10 +113,60 @@ func InitMetrics(ctx context.Context, serviceName, endpoint string) (*sdkmetric.
 		return nil, nil, fmt.Errorf("failed to create rate limit counter: %w", err)
 	}
 
+	rateLimitFallbacks, err := meter.Int64Counter(
+		"rate_limit_fallback_total",
+		metric.WithDescription("Total number of rate limit decisions made by the local fallback limiter while Redis is unavailable"),
+		metric.WithUnit("{decision}"),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create rate limit fallback counter: %w", err)
+	}
+
+	breakerTransitions, err := meter.Int64Counter(
+		"circuit_breaker_transitions_total",
+		metric.WithDescription("Total number of circuit breaker state transitions"),
+		metric.WithUnit("{transition}"),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create circuit breaker transitions counter: %w", err)
+	}
+
+	breakerOpen, err := meter.Int64UpDownCounter(
+		"circuit_breaker_open",
+		metric.WithDescription("1 while the circuit breaker is open or half-open, 0 when closed"),
+		metric.WithUnit("{breaker}"),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create circuit breaker open gauge: %w", err)
+	}
+
+	authCacheLookups, err := meter.Int64Counter(
+		"auth_cache_lookups_total",
+		metric.WithDescription("Total number of auth decision cache lookups by result (hit, negative_hit, miss)"),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create auth cache lookups counter: %w", err)
+	}
+
+	workspaceRejections, err := meter.Int64Counter(
+		"workspace_rejections_total",
+		metric.WithDescription("Total number of requests rejected because the workspace does not exist or is suspended"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create workspace rejections counter: %w", err)
+	}
+
 	metrics := &Metrics{
-		RequestsTotal:       requestsTotal,
-		RequestDuration:     requestDuration,
-		RateLimitRejections: rateLimitRejections,
+		RequestsTotal:             requestsTotal,
+		RequestDuration:           requestDuration,
+		RateLimitRejections:       rateLimitRejections,
+		RateLimitFallbacks:        rateLimitFallbacks,
+		CircuitBreakerTransitions: breakerTransitions,
+		CircuitBreakerOpen:        breakerOpen,
+		AuthCacheLookups:          authCacheLookups,
+		WorkspaceRejections:       workspaceRejections,
 	}
 
 	return mp, metrics, nil
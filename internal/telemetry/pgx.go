@@ -0,0 +1,183 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer cria um span por query do pgx (pgx.QueryTracer), com db.operation e
+// db.sql.table extraídos do SQL. Só abre spans dentro de um trace existente, para que
+// workers e jobs em background não gerem traces de uma query só.
+type QueryTracer struct{}
+
+// NewQueryTracer retorna o tracer a ser configurado em pgx.ConnConfig.Tracer.
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	op, table := ParseSQL(data.SQL)
+	name := "db." + strings.ToLower(op)
+	if table != "" {
+		name += " " + table
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", op),
+	}
+	if table != "" {
+		attrs = append(attrs, attribute.String("db.sql.table", table))
+	}
+
+	ctx, _ = otel.Tracer(TracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// ParseSQL extrai a operação (SELECT, INSERT, UPDATE, DELETE...) e a tabela principal do
+// statement. CTEs (WITH) usam a operação e a tabela do statement final. Tabela vazia quando
+// não há uma tabela identificável (ex.: SELECT 1).
+func ParseSQL(sql string) (op, table string) {
+	tokens := sqlTokens(sql)
+	if len(tokens) == 0 {
+		return "UNKNOWN", ""
+	}
+
+	start := 0
+	if strings.EqualFold(tokens[0], "WITH") {
+		// O statement principal é o primeiro verbo fora dos parênteses da CTE
+		depth := 0
+		for i, tok := range tokens {
+			switch tok {
+			case "(":
+				depth++
+				continue
+			case ")":
+				depth--
+				continue
+			}
+			if depth == 0 && i > 0 && isVerb(tok) {
+				start = i
+				break
+			}
+		}
+	}
+
+	op = strings.ToUpper(tokens[start])
+	var marker string
+	switch op {
+	case "SELECT", "DELETE":
+		marker = "FROM"
+	case "INSERT":
+		marker = "INTO"
+	case "UPDATE":
+		return op, tableAt(tokens, start+1)
+	default:
+		return op, ""
+	}
+
+	depth := 0
+	for i := start + 1; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		default:
+			if depth == 0 && strings.EqualFold(tokens[i], marker) {
+				return op, tableAt(tokens, i+1)
+			}
+		}
+	}
+	return op, ""
+}
+
+func isVerb(tok string) bool {
+	switch strings.ToUpper(tok) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+		return true
+	}
+	return false
+}
+
+// tableAt normaliza o identificador em tokens[i]: sem schema public e sem aspas.
+func tableAt(tokens []string, i int) string {
+	if i >= len(tokens) || tokens[i] == "(" {
+		return ""
+	}
+	name := strings.ReplaceAll(tokens[i], `"`, "")
+	if strings.EqualFold(name, "ONLY") {
+		return tableAt(tokens, i+1)
+	}
+	name = strings.TrimPrefix(name, "public.")
+	return strings.TrimSuffix(name, ";")
+}
+
+// sqlTokens separa o SQL em palavras, tratando parênteses como tokens próprios e
+// ignorando literais de string e comentários de linha.
+func sqlTokens(sql string) []string {
+	var tokens []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	quoted := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' && !quoted:
+			flush()
+			for i++; i < len(sql) && sql[i] != '\''; i++ {
+			}
+		case c == '-' && !quoted && i+1 < len(sql) && sql[i+1] == '-':
+			flush()
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			quoted = !quoted
+			cur.WriteByte(c)
+		case quoted:
+			cur.WriteByte(c)
+		case c == '(' || c == ')':
+			flush()
+			tokens = append(tokens, string(c))
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,35 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSQL(t *testing.T) {
+	cases := []struct {
+		name  string
+		sql   string
+		op    string
+		table string
+	}{
+		{"select", `SELECT id, name FROM public."Deal" d WHERE d."workspaceId" = $1`, "SELECT", "Deal"},
+		{"select subquery in columns", `SELECT (SELECT count(*) FROM "Note" n) AS c FROM "Contact" WHERE id = $1`, "SELECT", "Contact"},
+		{"insert", `INSERT INTO public."Notification" (id, "userId") SELECT * FROM unnest($1::TEXT[])`, "INSERT", "Notification"},
+		{"update", `UPDATE public."Task" SET "deletedAt" = NOW() WHERE id = $1`, "UPDATE", "Task"},
+		{"delete", "DELETE FROM \"Follower\" WHERE id = $1", "DELETE", "Follower"},
+		{"cte", `WITH moved AS (SELECT id FROM "Deal" WHERE stage = 'OPEN') UPDATE "PipelineStage" SET x = 1`, "UPDATE", "PipelineStage"},
+		{"comment and literal", "-- FROM \"Ignored\"\nSELECT 'FROM x' FROM workspace_member", "SELECT", "workspace_member"},
+		{"no table", "SELECT 1", "SELECT", ""},
+		{"other", "BEGIN", "BEGIN", ""},
+		{"empty", "   ", "UNKNOWN", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, table := ParseSQL(tc.sql)
+			assert.Equal(t, tc.op, op)
+			assert.Equal(t, tc.table, table)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// newResource identifica o processo em traces e métricas. Com region (REGION), todas as séries e
+// spans levam cloud.region, o que separa os deploys regionais nos dashboards.
+func newResource(ctx context.Context, serviceName, region string) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion("1.0.0"),
+	}
+	if region != "" {
+		attrs = append(attrs, semconv.CloudRegion(region))
+	}
+	return resource.New(ctx, resource.WithAttributes(attrs...))
+}
@@ -7,22 +7,15 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 // InitTracer initializes OpenTelemetry tracer with OTLP gRPC exporter
-func InitTracer(ctx context.Context, serviceName, endpoint string, samplingRatio float64) (*sdktrace.TracerProvider, error) {
+func InitTracer(ctx context.Context, serviceName, region, endpoint string, samplingRatio float64) (*sdktrace.TracerProvider, error) {
 	// Create resource
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-			semconv.ServiceVersion("1.0.0"),
-		),
-	)
+	res, err := newResource(ctx, serviceName, region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
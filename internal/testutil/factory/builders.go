@@ -0,0 +1,182 @@
+package factory
+
+import (
+	"time"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+)
+
+// Os builders preenchem um registro válido, aplicam os mutators na ordem e gravam pelo
+// repositório; um erro de gravação encerra o teste.
+
+// Contact cria um contato LEAD do admin com e-mail único.
+func (f *Factory) Contact(opts ...func(*domain.Contact)) *domain.Contact {
+	f.t.Helper()
+
+	contactID := f.newID(id.Contact)
+	now := time.Now().UTC()
+	contact := &domain.Contact{
+		ID:             contactID,
+		WorkspaceID:    f.WorkspaceID,
+		FullName:       "Contato " + contactID,
+		Email:          contactID + "@example.com",
+		LifecycleStage: domain.ContactStageLead,
+		ActorID:        f.UserID,
+		Tags:           []string{},
+		CustomFields:   map[string]interface{}{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	contact.DefaultSource(domain.SourceManual)
+	for _, opt := range opts {
+		opt(contact)
+	}
+
+	if err := f.contacts.Create(f.ctx, contact); err != nil {
+		f.t.Fatalf("factory: create contact: %v", err)
+	}
+	return contact
+}
+
+// Company cria uma empresa LEAD/SMB do admin.
+func (f *Factory) Company(opts ...func(*domain.Company)) *domain.Company {
+	f.t.Helper()
+
+	companyID := f.newID(id.Company)
+	company := &domain.Company{
+		ID:             companyID,
+		WorkspaceID:    f.WorkspaceID,
+		Name:           "Empresa " + companyID,
+		LifecycleStage: domain.LifecycleLead,
+		Size:           domain.SizeSMB,
+		OwnerID:        f.UserID,
+	}
+	for _, opt := range opts {
+		opt(company)
+	}
+
+	if err := f.companies.Create(f.ctx, company); err != nil {
+		f.t.Fatalf("factory: create company: %v", err)
+	}
+	return company
+}
+
+// defaultStages etapas dos pipelines da factory: duas abertas e uma de ganho.
+var defaultStages = []struct {
+	name        string
+	group       domain.StageGroup
+	probability int
+}{
+	{"Lead", domain.StageGroupActive, 10},
+	{"Proposta", domain.StageGroupActive, 50},
+	{"Fechado", domain.StageGroupWon, 100},
+}
+
+// Pipeline cria um pipeline de vendas ativo com as etapas Lead, Proposta e Fechado e o
+// retorna com Stages carregado. Os mutators alteram só o pipeline.
+func (f *Factory) Pipeline(opts ...func(*domain.Pipeline)) *domain.Pipeline {
+	f.t.Helper()
+
+	pipelineID := f.newID(id.Pipeline)
+	pipeline := &domain.Pipeline{
+		ID:           pipelineID,
+		WorkspaceID:  f.WorkspaceID,
+		Name:         "Pipeline " + pipelineID,
+		PipelineType: domain.PipelineTypeSales,
+		IsActive:     true,
+		OwnerID:      f.UserID,
+	}
+	for _, opt := range opts {
+		opt(pipeline)
+	}
+
+	if err := f.pipelines.Create(f.ctx, pipeline); err != nil {
+		f.t.Fatalf("factory: create pipeline: %v", err)
+	}
+
+	for i, def := range defaultStages {
+		stage := &domain.PipelineStage{
+			ID:          f.newID(id.PipelineStage),
+			PipelineID:  &pipeline.ID,
+			WorkspaceID: f.WorkspaceID,
+			Name:        def.name,
+			Group:       def.group,
+			Type:        pipeline.PipelineType,
+			OrderIndex:  i + 1,
+			Probability: Ptr(def.probability),
+		}
+		if err := f.pipelines.CreateStage(f.ctx, stage); err != nil {
+			f.t.Fatalf("factory: create stage %s: %v", def.name, err)
+		}
+	}
+
+	created, err := f.pipelines.GetWithStages(f.ctx, f.WorkspaceID, pipeline.ID, false)
+	if err != nil {
+		f.t.Fatalf("factory: get pipeline: %v", err)
+	}
+	return created
+}
+
+// Deal cria um negócio aberto de R$ 1.000 do admin na primeira etapa de um pipeline da
+// factory (criado no primeiro uso e reaproveitado). Para outro pipeline, defina PipelineID e
+// StageID nos mutators.
+func (f *Factory) Deal(opts ...func(*domain.Deal)) *domain.Deal {
+	f.t.Helper()
+
+	if f.pipeline == nil {
+		f.pipeline = f.Pipeline()
+	}
+
+	dealID := f.newID(id.Deal)
+	deal := &domain.Deal{
+		ID:          dealID,
+		WorkspaceID: f.WorkspaceID,
+		PipelineID:  f.pipeline.ID,
+		StageID:     Ptr(f.pipeline.Stages[0].ID),
+		Name:        "Negócio " + dealID,
+		Value:       Ptr(1000.0),
+		Currency:    "BRL",
+		Stage:       domain.DealStageOpen,
+		Probability: Ptr(int32(defaultStages[0].probability)),
+		OwnerID:     Ptr(f.UserID),
+		CreatedByID: f.UserID,
+	}
+	deal.DefaultSource(domain.SourceManual)
+	for _, opt := range opts {
+		opt(deal)
+	}
+
+	created, err := f.deals.Create(f.ctx, deal)
+	if err != nil {
+		f.t.Fatalf("factory: create deal: %v", err)
+	}
+	return created
+}
+
+// Task cria uma tarefa BACKLOG/MEDIUM do admin, visível ao workspace, no fim da coluna.
+func (f *Factory) Task(opts ...func(*domain.Task)) *domain.Task {
+	f.t.Helper()
+
+	f.taskPosition += 1000
+	taskID := f.newID(id.Task)
+	task := &domain.Task{
+		ID:          taskID,
+		WorkspaceID: f.WorkspaceID,
+		Title:       "Tarefa " + taskID,
+		Status:      domain.TaskStatusBacklog,
+		Priority:    domain.PriorityMedium,
+		Type:        domain.TaskTypeTask,
+		Position:    f.taskPosition,
+		Visibility:  domain.VisibilityWorkspace,
+		ActorID:     f.UserID,
+	}
+	for _, opt := range opts {
+		opt(task)
+	}
+
+	if err := f.tasks.Create(f.ctx, task); err != nil {
+		f.t.Fatalf("factory: create task: %v", err)
+	}
+	return task
+}
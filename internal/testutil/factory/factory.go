@@ -0,0 +1,193 @@
+// Package factory monta os dados dos testes de integração (handler/service/repo) contra um
+// banco de teste: cada New cria uma organização, um usuário admin e um workspace isolados, e os
+// builders (Contact, Company, Pipeline, Deal, Task) gravam pelos repositórios com defaults
+// válidos, em vez de cada teste repetir INSERTs na mão. Tudo é apagado no t.Cleanup.
+//
+// Uso:
+//
+//	pool := factory.Pool(t) // t.Skip sem DATABASE_URL
+//	f := factory.New(t, pool)
+//	deal := f.Deal(func(d *domain.Deal) { d.Value = factory.Ptr(1500.0) })
+package factory
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"linkko-api/internal/database"
+	"linkko-api/internal/domain"
+	"linkko-api/internal/id"
+	"linkko-api/internal/repo"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Prefixos dos registros de base criados pela factory (a API não cria workspaces nem organizações)
+const (
+	organizationPrefix id.Prefix = "org"
+	workspacePrefix    id.Prefix = "wks"
+)
+
+// Pool conecta em DATABASE_URL e fecha o pool no fim do teste; sem a variável o teste é pulado.
+func Pool(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	pool, err := database.NewPool(context.Background(), databaseURL)
+	if err != nil {
+		t.Fatalf("factory: connect to database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// Factory workspace de teste e os repositórios usados pelos builders.
+type Factory struct {
+	t   testing.TB
+	ctx context.Context
+	db  database.DB
+
+	OrganizationID string
+	WorkspaceID    string
+	UserID         string // admin do workspace; dono padrão dos registros criados
+
+	users []string
+
+	contacts  *repo.ContactRepository
+	companies *repo.CompanyRepository
+	pipelines *repo.PipelineRepository
+	deals     *repo.DealRepository
+	tasks     *repo.TaskRepository
+
+	pipeline     *domain.Pipeline // pipeline padrão dos deals, criado no primeiro Deal
+	taskPosition float64
+}
+
+// New cria organização, usuário admin e workspace e registra a limpeza no t.Cleanup.
+func New(t testing.TB, db database.DB) *Factory {
+	t.Helper()
+
+	f := &Factory{
+		t:         t,
+		ctx:       context.Background(),
+		db:        db,
+		contacts:  repo.NewContactRepository(db),
+		companies: repo.NewCompanyRepository(db),
+		pipelines: repo.NewPipelineRepository(db),
+		deals:     repo.NewDealRepository(db),
+		tasks:     repo.NewTaskRepository(db),
+	}
+
+	f.OrganizationID = f.newID(organizationPrefix)
+	f.WorkspaceID = f.newID(workspacePrefix)
+
+	// Registrado antes dos INSERTs para limpar também um setup que falhe no meio
+	t.Cleanup(f.cleanup)
+
+	f.exec("insert organization", `
+		INSERT INTO public."Organization" (id, name, "updatedAt")
+		VALUES ($1, $2, NOW())`, f.OrganizationID, "Test Org "+f.OrganizationID)
+
+	f.UserID = f.createUser()
+
+	f.exec("insert workspace", `
+		INSERT INTO public."Workspace" (id, name, slug, "ownerId", "organizationId", "updatedAt")
+		VALUES ($1, $2, $3, $4, $5, NOW())`,
+		f.WorkspaceID, "Test Workspace", f.WorkspaceID, f.UserID, f.OrganizationID)
+
+	f.addMember(f.UserID, domain.RoleAdmin)
+	return f
+}
+
+// Context contexto usado pelos builders.
+func (f *Factory) Context() context.Context {
+	return f.ctx
+}
+
+// Member cria um usuário com o papel informado no workspace e retorna o ID.
+func (f *Factory) Member(role domain.Role) string {
+	f.t.Helper()
+
+	userID := f.createUser()
+	f.addMember(userID, role)
+	return userID
+}
+
+// Join adiciona ao workspace, com o papel informado, um usuário criado por outra factory
+// (ex.: o admin de um segundo workspace). A associação cai junto com o workspace.
+func (f *Factory) Join(userID string, role domain.Role) {
+	f.t.Helper()
+
+	f.addMember(userID, role)
+}
+
+func (f *Factory) createUser() string {
+	f.t.Helper()
+
+	userID := f.newID(id.User)
+	f.exec("insert user", `
+		INSERT INTO public."User" (id, name, email, "updatedAt")
+		VALUES ($1, $2, $3, NOW())`, userID, "Test User", userID+"@test.linkko.local")
+	f.users = append(f.users, userID)
+	return userID
+}
+
+func (f *Factory) addMember(userID string, role domain.Role) {
+	f.t.Helper()
+
+	tag, err := f.db.Exec(f.ctx, `
+		INSERT INTO public."WorkspaceMember" ("userId", "workspaceId", "workspaceRoleId", accepted_at)
+		SELECT $1, $2, wr.id, NOW()
+		FROM public."WorkspaceRole" wr
+		WHERE wr.name = $3`, userID, f.WorkspaceID, role)
+	if err != nil {
+		f.t.Fatalf("factory: insert workspace member: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		f.t.Fatalf("factory: workspace role %s not found (migration 000003 applied?)", role)
+	}
+}
+
+// cleanup apaga o workspace (os dados do workspace caem em cascata), os usuários e a organização.
+func (f *Factory) cleanup() {
+	ctx := context.Background()
+	if _, err := f.db.Exec(ctx, `DELETE FROM public."Workspace" WHERE id = $1`, f.WorkspaceID); err != nil {
+		f.t.Logf("factory: delete workspace %s: %v", f.WorkspaceID, err)
+	}
+	if len(f.users) > 0 {
+		if _, err := f.db.Exec(ctx, `DELETE FROM public."User" WHERE id = ANY($1)`, f.users); err != nil {
+			f.t.Logf("factory: delete users: %v", err)
+		}
+	}
+	if _, err := f.db.Exec(ctx, `DELETE FROM public."Organization" WHERE id = $1`, f.OrganizationID); err != nil {
+		f.t.Logf("factory: delete organization %s: %v", f.OrganizationID, err)
+	}
+}
+
+func (f *Factory) exec(what, sql string, args ...any) {
+	f.t.Helper()
+
+	if _, err := f.db.Exec(f.ctx, sql, args...); err != nil {
+		f.t.Fatalf("factory: %s: %v", what, err)
+	}
+}
+
+func (f *Factory) newID(prefix id.Prefix) string {
+	f.t.Helper()
+
+	newID, err := id.New(prefix)
+	if err != nil {
+		f.t.Fatalf("factory: generate %s id: %v", prefix, err)
+	}
+	return newID
+}
+
+// Ptr retorna um ponteiro para v (campos opcionais dos builders).
+func Ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,55 @@
+package factory_test
+
+import (
+	"testing"
+
+	"linkko-api/internal/domain"
+	"linkko-api/internal/repo"
+	"linkko-api/internal/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with: DATABASE_URL=... go test -v ./internal/testutil/factory
+func TestFactory_Integration(t *testing.T) {
+	pool := factory.Pool(t)
+	f := factory.New(t, pool)
+	ctx := f.Context()
+
+	role, err := repo.NewWorkspaceRepository(pool).GetMemberRole(ctx, f.UserID, f.WorkspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, role)
+
+	viewerID := f.Member(domain.RoleViewer)
+	role, err = repo.NewWorkspaceRepository(pool).GetMemberRole(ctx, viewerID, f.WorkspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleViewer, role)
+
+	company := f.Company()
+	contact := f.Contact(func(c *domain.Contact) { c.CompanyID = &company.ID })
+	deal := f.Deal(func(d *domain.Deal) {
+		d.ContactID = &contact.ID
+		d.Value = factory.Ptr(2500.0)
+	})
+	task := f.Task(func(tk *domain.Task) { tk.ContactID = &contact.ID })
+
+	gotContact, err := repo.NewContactRepository(pool).Get(ctx, f.WorkspaceID, contact.ID)
+	require.NoError(t, err)
+	require.NotNil(t, gotContact.CompanyID)
+	assert.Equal(t, company.ID, *gotContact.CompanyID)
+
+	gotDeal, err := repo.NewDealRepository(pool).Get(ctx, f.WorkspaceID, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, gotDeal.Value)
+	assert.Equal(t, 2500.0, *gotDeal.Value)
+	assert.Equal(t, domain.DealStageOpen, gotDeal.Stage)
+
+	gotTask, err := repo.NewTaskRepository(pool).Get(ctx, f.WorkspaceID, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusBacklog, gotTask.Status)
+
+	// O segundo deal reaproveita o pipeline padrão da factory
+	other := f.Deal()
+	assert.Equal(t, deal.PipelineID, other.PipelineID)
+}
@@ -0,0 +1,19 @@
+-- Workspace descartável para o teste de contrato ao vivo (TestContractLive).
+-- Rodar depois do schema base e das migrations; os ids batem com o job contract-live do CI.
+INSERT INTO public."Organization" (id, name, "updatedAt")
+VALUES ('org_contract', 'Contract Org', NOW())
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO public."User" (id, name, email, "updatedAt")
+VALUES ('usr_contract', 'Contract Admin', 'contract@test.linkko.local', NOW())
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO public."Workspace" (id, name, slug, "ownerId", "organizationId", "updatedAt")
+VALUES ('wks_contract', 'Contract Workspace', 'wks_contract', 'usr_contract', 'org_contract', NOW())
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO public."WorkspaceMember" ("userId", "workspaceId", "workspaceRoleId", accepted_at)
+SELECT 'usr_contract', 'wks_contract', wr.id, NOW()
+FROM public."WorkspaceRole" wr
+WHERE wr.name = 'work_admin'
+ON CONFLICT DO NOTHING;